@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/aws/fake"
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/internal/workpool"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsFilter string
+	logsSince  time.Duration
+	logsFormat string
+	logsFollow bool
+)
+
+// logsCmd groups the headless log commands
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Work with CloudWatch Logs outside the TUI",
+}
+
+// logsTailCmd streams CloudWatch log events to stdout
+var logsTailCmd = &cobra.Command{
+	Use:   "tail <log-group>",
+	Short: "Stream CloudWatch log events to stdout",
+	Long: `Stream CloudWatch log events of a log group to stdout, e.g. for piping
+into jq or grep. Uses the AWS profile and region from the flags or config,
+or the sample log groups with --demo.
+
+Examples:
+  swiss-army-tui logs tail /aws/lambda/my-function --since 1h
+  swiss-army-tui logs tail /ecs/api --filter ERROR --format json | jq .message`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLogsTail,
+}
+
+func init() {
+	logsTailCmd.Flags().StringVar(&logsFilter, "filter", "", "CloudWatch filter pattern")
+	logsTailCmd.Flags().DurationVar(&logsSince, "since", 10*time.Minute, "show events newer than this duration (e.g. 30s, 15m, 1h)")
+	logsTailCmd.Flags().StringVar(&logsFormat, "format", "text", "output format (text, json)")
+	logsTailCmd.Flags().BoolVarP(&logsFollow, "follow", "f", true, "keep streaming new events")
+
+	logsCmd.AddCommand(logsTailCmd)
+	rootCmd.AddCommand(logsCmd)
+}
+
+// logLine is the JSON representation of a streamed log event
+type logLine struct {
+	Timestamp string `json:"timestamp"`
+	LogGroup  string `json:"log_group"`
+	LogStream string `json:"log_stream"`
+	Message   string `json:"message"`
+}
+
+// runLogsTail streams the events of a log group until interrupted
+func runLogsTail(cmd *cobra.Command, args []string) error {
+	logGroup := args[0]
+
+	if logsFormat != "text" && logsFormat != "json" {
+		return fmt.Errorf("unsupported format %q (use text or json)", logsFormat)
+	}
+
+	svc, err := newCloudWatchLogsService()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	since := time.Now().Add(-logsSince)
+
+	if !logsFollow {
+		events, err := svc.FilterLogEvents(ctx, logGroup, logsFilter, since)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := printLogEvent(cmd.OutOrStdout(), logGroup, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	eventsChan := make(chan clients.LogEvent, 100)
+	errorChan := make(chan error, 10)
+	go svc.TailLogGroup(ctx, logGroup, logsFilter, since, eventsChan, errorChan)
+
+	for eventsChan != nil || errorChan != nil {
+		select {
+		case event, ok := <-eventsChan:
+			if !ok {
+				eventsChan = nil
+				continue
+			}
+			if err := printLogEvent(cmd.OutOrStdout(), logGroup, event); err != nil {
+				return err
+			}
+		case err, ok := <-errorChan:
+			if !ok {
+				errorChan = nil
+				continue
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// newCloudWatchLogsService creates a CloudWatch Logs service for the
+// configured profile and region, or the sample service with --demo
+func newCloudWatchLogsService() (aws.CloudWatchLogsService, error) {
+	if demo {
+		return fake.NewClient().GetCloudWatchLogsService(), nil
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return nil, fmt.Errorf("configuration not loaded")
+	}
+
+	aws.SetRateLimit(cfg.AWS.RateLimit)
+	aws.SetRequestOptions(aws.RequestOptionsFromConfig(cfg.AWS))
+	workpool.SetLimits(cfg.AWS.Concurrency, cfg.AWS.ServiceConcurrency)
+
+	client, err := aws.NewClient(cfg.AWS.DefaultProfile, cfg.AWS.DefaultRegion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	svc := client.GetCloudWatchLogsService()
+	if svc == nil {
+		return nil, fmt.Errorf("CloudWatch Logs service not available")
+	}
+	return svc, nil
+}
+
+// printLogEvent writes a single event to w in the selected format
+func printLogEvent(w io.Writer, logGroup string, event clients.LogEvent) error {
+	timestamp := time.UnixMilli(event.Timestamp).Format(time.RFC3339Nano)
+	message := strings.TrimRight(event.Message, "\r\n")
+
+	if logsFormat == "json" {
+		data, err := json.Marshal(logLine{
+			Timestamp: timestamp,
+			LogGroup:  logGroup,
+			LogStream: event.LogStreamName,
+			Message:   message,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encode log event: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "%s [%s] %s\n", timestamp, event.LogStreamName, message)
+	return err
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLogsTailDemo(t *testing.T) {
+	// The default config and AWS files are created under $HOME
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() {
+		demo, logsFollow, logsFilter, logsFormat = false, true, "", "text"
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+	})
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"logs", "tail", "/ecs/orders-service", "--demo", "--follow=false",
+		"--filter", "order", "--format", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		t.Fatal("Expected events of the sample log group")
+	}
+	for _, line := range lines {
+		var event logLine
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("Expected a JSON event per line, got %q: %v", line, err)
+		}
+		if event.LogGroup != "/ecs/orders-service" || !strings.Contains(strings.ToLower(event.Message), "order") {
+			t.Errorf("Expected filtered events of the group, got %+v", event)
+		}
+	}
+}
+
+func TestLogsTailDemoUnknownGroup(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() {
+		demo, logsFollow = false, true
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	})
+
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"logs", "tail", "/missing", "--demo", "--follow=false"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("Expected the missing group to be reported, got %v", err)
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"os"
 
 	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/fake"
 	"swiss-army-tui/internal/config"
+	"swiss-army-tui/internal/snapshot"
 	"swiss-army-tui/internal/ui"
 	"swiss-army-tui/pkg/logger"
 
@@ -22,6 +24,9 @@ var (
 	awsProfile  string
 	awsRegion   string
 	development bool
+	demo        bool
+	openTarget  string
+	offline     string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -62,6 +67,11 @@ func init() {
 	// AWS flags
 	rootCmd.PersistentFlags().StringVar(&awsProfile, "aws-profile", "", "AWS profile to use")
 	rootCmd.PersistentFlags().StringVar(&awsRegion, "aws-region", "", "AWS region to use")
+	rootCmd.PersistentFlags().BoolVar(&demo, "demo", false, "use built-in sample data instead of AWS")
+
+	// Navigation flags
+	rootCmd.Flags().StringVar(&offline, "offline", "", "browse a snapshot taken with Ctrl+T instead of AWS, without any AWS calls")
+	rootCmd.Flags().StringVar(&openTarget, "open", "", "open a resource, log group or bookmark on start (e.g. ec2:i-0abc, logs:/aws/lambda/foo, bookmark:name)")
 
 	// Bind flags to viper
 	viper.BindPFlag("logger.level", rootCmd.PersistentFlags().Lookup("log-level"))
@@ -133,18 +143,44 @@ func runTUI(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("configuration validation failed: %w", err)
 	}
 
+	if demo && offline != "" {
+		return fmt.Errorf("--demo and --offline cannot be combined")
+	}
+	var snap *snapshot.Snapshot
+	if offline != "" {
+		loaded, err := snapshot.Load(offline)
+		if err != nil {
+			return err
+		}
+		snap = loaded
+	}
+
 	// Create and run TUI application
-	app, err := ui.NewApp(cfg)
+	app, err := ui.NewApp(cfg, ui.WithVersion(version))
 	if err != nil {
 		return fmt.Errorf("failed to create TUI application: %w", err)
 	}
 
+	if demo {
+		app.EnableDemoMode(fake.NewClient())
+		logger.Info("Running in demo mode with sample data")
+	}
+	if snap != nil {
+		app.EnableOfflineMode(snap, offline)
+	}
+
 	// Set up graceful shutdown
 	defer func() {
 		app.Quit()
 		logger.Sync()
 	}()
 
+	if openTarget != "" {
+		if err := app.Open(openTarget); err != nil {
+			return fmt.Errorf("invalid --open target: %w", err)
+		}
+	}
+
 	// Run the application
 	if err := app.Run(); err != nil {
 		return fmt.Errorf("TUI application error: %w", err)
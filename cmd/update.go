@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/internal/update"
+
+	"github.com/spf13/cobra"
+)
+
+var updateCheckOnly bool
+
+// updateCmd replaces the binary with the latest release
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update to the latest release",
+	Long: `Download the latest release for this platform from GitHub, verify it
+against the SHA-256 checksums published with it and replace this binary.
+The repository and API URL are taken from the update section of the config,
+e.g. to update from GitHub Enterprise or a mirror.
+
+Examples:
+  swiss-army-tui update --check
+  swiss-army-tui update`,
+	Args: cobra.NoArgs,
+	RunE: runUpdate,
+}
+
+func init() {
+	updateCmd.Flags().BoolVar(&updateCheckOnly, "check", false, "only report whether a newer release is available")
+
+	rootCmd.AddCommand(updateCmd)
+}
+
+// runUpdate updates the binary to the latest release if it is newer
+func runUpdate(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+	if err := cfg.Update.Validate(); err != nil {
+		return err
+	}
+
+	if !update.IsVersion(version) {
+		return fmt.Errorf("version %q is a development build and cannot be updated; install a release instead", version)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	client := update.NewClient(cfg.Update.APIURL, cfg.Update.Repository)
+	release, err := client.Latest(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !update.Newer(version, release.Version) {
+		fmt.Printf("Swiss Army TUI %s is up to date (latest release: %s)\n", version, release.Version)
+		return nil
+	}
+	fmt.Printf("Swiss Army TUI %s is available (running %s): %s\n", release.Version, version, release.URL)
+	if updateCheckOnly {
+		return nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the running binary: %w", err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return fmt.Errorf("failed to find the running binary: %w", err)
+	}
+
+	binary, err := client.Download(ctx, release, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	if err := update.Replace(exe, binary, runtime.GOOS); err != nil {
+		return err
+	}
+	fmt.Printf("Updated %s to %s\n", exe, release.Version)
+	return nil
+}
@@ -0,0 +1,467 @@
+// Package alarmrule parses the rule expressions of CloudWatch composite
+// alarms, e.g. `ALARM(api-5xx) OR (ALARM("db cpu") AND NOT OK(queue-age))`,
+// evaluates them against the states of the alarms they name and draws them
+// as a tree showing which branches hold.
+package alarmrule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// Alarm states as CloudWatch reports them
+const (
+	StateOK               = "OK"
+	StateAlarm            = "ALARM"
+	StateInsufficientData = "INSUFFICIENT_DATA"
+)
+
+// Kind is the kind of a node of a rule
+type Kind int
+
+const (
+	// Const is TRUE or FALSE
+	Const Kind = iota
+	// State holds when Alarm is in State, e.g. ALARM(api-5xx)
+	State
+	// Not holds when its only child does not
+	Not
+	// And holds when all its children hold
+	And
+	// Or holds when any of its children holds
+	Or
+	// AtLeast holds when Count (or Count percent) of Alarms are in State
+	AtLeast
+)
+
+// Node is an expression of a rule
+type Node struct {
+	Kind Kind
+	// Value is the value of a Const
+	Value bool
+	// State is the state a State or AtLeast node tests. For AtLeast it may
+	// be negated, e.g. NOT_OK.
+	State string
+	// Alarm is the name of the alarm a State node tests; ARNs are reduced to
+	// their name
+	Alarm string
+	// Alarms are the names of the alarms an AtLeast node counts
+	Alarms []string
+	// Count is how many of Alarms must be in State, a percentage if Percent
+	Count    int
+	Percent  bool
+	Children []*Node
+}
+
+// Parse parses a rule expression. NOT binds tighter than AND, AND tighter
+// than OR.
+func Parse(rule string) (*Node, error) {
+	tokens, err := tokenize(rule)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	node, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at %d", p.peek().text, p.peek().pos)
+	}
+	return node, nil
+}
+
+// Names returns the names of the alarms the rule tests, each once, in the
+// order they appear
+func (n *Node) Names() []string {
+	var names []string
+	seen := make(map[string]bool)
+	var walk func(*Node)
+	walk = func(node *Node) {
+		for _, name := range append([]string{node.Alarm}, node.Alarms...) {
+			if name != "" && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(n)
+	return names
+}
+
+// Evaluate reports whether the rule holds when the alarms are in states, by
+// name. Alarms missing from states are in no state.
+func (n *Node) Evaluate(states map[string]string) bool {
+	switch n.Kind {
+	case Const:
+		return n.Value
+	case State:
+		return states[n.Alarm] == n.State
+	case Not:
+		return !n.Children[0].Evaluate(states)
+	case And:
+		for _, child := range n.Children {
+			if !child.Evaluate(states) {
+				return false
+			}
+		}
+		return true
+	case Or:
+		for _, child := range n.Children {
+			if child.Evaluate(states) {
+				return true
+			}
+		}
+		return false
+	case AtLeast:
+		matching := 0
+		for _, name := range n.Alarms {
+			if inState(states[name], n.State) {
+				matching++
+			}
+		}
+		if n.Percent {
+			return matching*100 >= n.Count*len(n.Alarms)
+		}
+		return matching >= n.Count
+	}
+	return false
+}
+
+// inState reports whether state is want, which may be negated with a NOT_
+// prefix
+func inState(state, want string) bool {
+	if negated, ok := strings.CutPrefix(want, "NOT_"); ok {
+		return state != negated
+	}
+	return state == want
+}
+
+// String returns the rule as an expression, with parentheses only where
+// precedence needs them
+func (n *Node) String() string {
+	switch n.Kind {
+	case Const:
+		if n.Value {
+			return "TRUE"
+		}
+		return "FALSE"
+	case State:
+		return n.State + "(" + quote(n.Alarm) + ")"
+	case Not:
+		return "NOT " + n.Children[0].operand(Not)
+	case And, Or:
+		operator := " AND "
+		if n.Kind == Or {
+			operator = " OR "
+		}
+		parts := make([]string, len(n.Children))
+		for i, child := range n.Children {
+			parts[i] = child.operand(n.Kind)
+		}
+		return strings.Join(parts, operator)
+	case AtLeast:
+		count := strconv.Itoa(n.Count)
+		if n.Percent {
+			count += "%"
+		}
+		names := make([]string, len(n.Alarms))
+		for i, name := range n.Alarms {
+			names[i] = quote(name)
+		}
+		return fmt.Sprintf("AT_LEAST(%s, %s, (%s))", count, n.State, strings.Join(names, ", "))
+	}
+	return ""
+}
+
+// operand returns the node as an operand of parent, in parentheses when it
+// binds looser
+func (n *Node) operand(parent Kind) string {
+	if (n.Kind == Or && parent != Or) || (n.Kind == And && parent == Not) {
+		return "(" + n.String() + ")"
+	}
+	return n.String()
+}
+
+// quote quotes names that would not read back as a single word
+func quote(name string) string {
+	if name == "" || strings.ContainsAny(name, " \t(),\"") {
+		return strconv.Quote(name)
+	}
+	return name
+}
+
+// stateColors color alarm states
+var stateColors = map[string]string{
+	StateOK:               "green",
+	StateAlarm:            "red",
+	StateInsufficientData: "yellow",
+}
+
+// Render draws the rule as a tree with tview colors, one expression per
+// line. Every line tells whether its expression holds, and state tests show
+// the current state of their alarm.
+func Render(n *Node, states map[string]string) string {
+	var text strings.Builder
+	render(&text, n, states, "", "")
+	return text.String()
+}
+
+func render(text *strings.Builder, n *Node, states map[string]string, lead, indent string) {
+	mark := "[gray]✗[-]"
+	if n.Evaluate(states) {
+		mark = "[white::b]✓[-::-]"
+	}
+	text.WriteString(lead + mark + " ")
+
+	switch n.Kind {
+	case Not, And, Or:
+		text.WriteString(map[Kind]string{Not: "NOT", And: "AND", Or: "OR"}[n.Kind] + "\n")
+		for i, child := range n.Children {
+			if i == len(n.Children)-1 {
+				render(text, child, states, indent+"└─ ", indent+"   ")
+			} else {
+				render(text, child, states, indent+"├─ ", indent+"│  ")
+			}
+		}
+	case AtLeast:
+		count := strconv.Itoa(n.Count)
+		if n.Percent {
+			count += "%"
+		}
+		fmt.Fprintf(text, "AT_LEAST %s %s of\n", count, n.State)
+		for i, name := range n.Alarms {
+			branch := "├─ "
+			if i == len(n.Alarms)-1 {
+				branch = "└─ "
+			}
+			fmt.Fprintf(text, "%s%s%s  %s\n", indent, branch, tview.Escape(name), stateText(states[name]))
+		}
+	case State:
+		fmt.Fprintf(text, "%s(%s)  %s\n", n.State, tview.Escape(n.Alarm), stateText(states[n.Alarm]))
+	default:
+		text.WriteString(n.String() + "\n")
+	}
+}
+
+// stateText returns state in its color, "unknown" for alarms that were not
+// found
+func stateText(state string) string {
+	color, ok := stateColors[state]
+	if !ok {
+		return "[gray]unknown[-]"
+	}
+	return "[" + color + "]" + state + "[-]"
+}
+
+// token is a word, a quoted name or one of ( ) , of a rule
+type token struct {
+	text   string
+	quoted bool
+	pos    int
+}
+
+func tokenize(rule string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(rule); {
+		switch c := rule[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == ',':
+			tokens = append(tokens, token{text: string(c), pos: i})
+			i++
+		case c == '"':
+			end := strings.IndexByte(rule[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote at %d", i)
+			}
+			tokens = append(tokens, token{text: rule[i+1 : i+1+end], quoted: true, pos: i})
+			i += end + 2
+		default:
+			start := i
+			for i < len(rule) && !strings.ContainsRune(" \t\n\r(),\"", rune(rule[i])) {
+				i++
+			}
+			tokens = append(tokens, token{text: rule[start:i], pos: start})
+		}
+	}
+	return tokens, nil
+}
+
+// parser is a recursive descent parser over the tokens of a rule
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) done() bool { return p.next >= len(p.tokens) }
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{text: "end of rule", pos: -1}
+	}
+	return p.tokens[p.next]
+}
+
+// keyword consumes the next token if it is the unquoted word
+func (p *parser) keyword(word string) bool {
+	if t := p.peek(); !p.done() && !t.quoted && strings.EqualFold(t.text, word) {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if t := p.peek(); p.done() || t.quoted || t.text != text {
+		return fmt.Errorf("expected %q, got %q", text, t.text)
+	}
+	p.next++
+	return nil
+}
+
+func (p *parser) or() (*Node, error) {
+	return p.chain(Or, "OR", p.and)
+}
+
+func (p *parser) and() (*Node, error) {
+	return p.chain(And, "AND", p.unary)
+}
+
+// chain parses operands joined by the operator into one node of kind
+func (p *parser) chain(kind Kind, operator string, operand func() (*Node, error)) (*Node, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	node := &Node{Kind: kind, Children: []*Node{first}}
+	for p.keyword(operator) {
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, next)
+	}
+	if len(node.Children) == 1 {
+		return first, nil
+	}
+	return node, nil
+}
+
+func (p *parser) unary() (*Node, error) {
+	if p.keyword("NOT") {
+		child, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &Node{Kind: Not, Children: []*Node{child}}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (*Node, error) {
+	t := p.peek()
+	switch {
+	case p.done():
+		return nil, fmt.Errorf("unexpected end of rule")
+	case !t.quoted && t.text == "(":
+		p.next++
+		node, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return node, p.expect(")")
+	case p.keyword("TRUE"):
+		return &Node{Kind: Const, Value: true}, nil
+	case p.keyword("FALSE"):
+		return &Node{Kind: Const}, nil
+	case p.keyword("AT_LEAST"):
+		return p.atLeast()
+	}
+
+	for _, state := range []string{StateAlarm, StateOK, StateInsufficientData} {
+		if p.keyword(state) {
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			name, err := p.alarm()
+			if err != nil {
+				return nil, err
+			}
+			return &Node{Kind: State, State: state, Alarm: name}, p.expect(")")
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+}
+
+// atLeast parses the arguments of AT_LEAST, e.g. (2, ALARM, (a, b, c))
+func (p *parser) atLeast() (*Node, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	node := &Node{Kind: AtLeast}
+
+	count := p.peek()
+	text, percent := strings.CutSuffix(count.text, "%")
+	n, err := strconv.Atoi(text)
+	if p.done() || count.quoted || err != nil || n < 0 {
+		return nil, fmt.Errorf("expected a count or percentage, got %q", count.text)
+	}
+	p.next++
+	node.Count, node.Percent = n, percent
+
+	if err := p.expect(","); err != nil {
+		return nil, err
+	}
+	state := p.peek()
+	switch strings.TrimPrefix(strings.ToUpper(state.text), "NOT_") {
+	case StateAlarm, StateOK, StateInsufficientData:
+	default:
+		return nil, fmt.Errorf("expected a state, got %q", state.text)
+	}
+	if p.done() || state.quoted {
+		return nil, fmt.Errorf("expected a state, got %q", state.text)
+	}
+	node.State = strings.ToUpper(state.text)
+	p.next++
+
+	if err := p.expect(","); err != nil {
+		return nil, err
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	for {
+		name, err := p.alarm()
+		if err != nil {
+			return nil, err
+		}
+		node.Alarms = append(node.Alarms, name)
+		if p.expect(",") != nil {
+			break
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return node, p.expect(")")
+}
+
+// alarm parses the name or ARN of an alarm and returns its name
+func (p *parser) alarm() (string, error) {
+	t := p.peek()
+	if p.done() || (!t.quoted && strings.ContainsAny(t.text, "(),")) || t.text == "" {
+		return "", fmt.Errorf("expected an alarm name, got %q", t.text)
+	}
+	p.next++
+	if _, name, ok := strings.Cut(t.text, ":alarm:"); ok && strings.HasPrefix(t.text, "arn:") {
+		return name, nil
+	}
+	return t.text, nil
+}
@@ -0,0 +1,102 @@
+package alarmrule
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{`ALARM(api-5xx)`, `ALARM(api-5xx)`},
+		{`ALARM("api 5xx") OR OK(db)`, `ALARM("api 5xx") OR OK(db)`},
+		{`ALARM(a) OR ALARM(b) AND NOT OK(c)`, `ALARM(a) OR ALARM(b) AND NOT OK(c)`},
+		{`(ALARM(a) OR ALARM(b)) AND NOT (OK(c) AND TRUE)`, `(ALARM(a) OR ALARM(b)) AND NOT (OK(c) AND TRUE)`},
+		{`alarm(arn:aws:cloudwatch:us-east-1:123456789012:alarm:cpu) and false`, `ALARM(cpu) AND FALSE`},
+		{`AT_LEAST(50%, not_ok, (a, "b c", arn:aws:cloudwatch:us-east-1:1:alarm:d))`, `AT_LEAST(50%, NOT_OK, (a, "b c", d))`},
+		{`NOT NOT INSUFFICIENT_DATA(a)`, `NOT NOT INSUFFICIENT_DATA(a)`},
+	}
+	for _, tt := range tests {
+		node, err := Parse(tt.rule)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", tt.rule, err)
+			continue
+		}
+		if got := node.String(); got != tt.want {
+			t.Errorf("Parse(%q) = %q, want %q", tt.rule, got, tt.want)
+		}
+	}
+
+	for _, rule := range []string{
+		``,
+		`ALARM(a`,
+		`ALARM(a) OR`,
+		`ALARM(a) ALARM(b)`,
+		`BROKEN(a)`,
+		`ALARM("a)`,
+		`AT_LEAST(two, ALARM, (a))`,
+		`AT_LEAST(1, ON, (a))`,
+		`AT_LEAST(1, ALARM, ())`,
+	} {
+		if _, err := Parse(rule); err == nil {
+			t.Errorf("Expected Parse(%q) to fail", rule)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	states := map[string]string{"a": StateAlarm, "b": StateOK, "c": StateInsufficientData}
+	tests := []struct {
+		rule string
+		want bool
+	}{
+		{`ALARM(a)`, true},
+		{`ALARM(b) OR ALARM(a) AND OK(b)`, true},
+		{`(ALARM(b) OR ALARM(a)) AND NOT OK(b)`, false},
+		{`NOT OK(c)`, true},
+		{`ALARM(missing)`, false},
+		{`AT_LEAST(2, NOT_OK, (a, b, c))`, true},
+		{`AT_LEAST(50%, ALARM, (a, b, c))`, false},
+		{`AT_LEAST(50%, ALARM, (a, b))`, true},
+	}
+	for _, tt := range tests {
+		node, err := Parse(tt.rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := node.Evaluate(states); got != tt.want {
+			t.Errorf("Evaluate(%q) = %v, want %v", tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	node, err := Parse(`ALARM(api) OR (ALARM(cpu) AND NOT OK(queue))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := node.Names(); strings.Join(got, ",") != "api,cpu,queue" {
+		t.Errorf("Unexpected alarms %v", got)
+	}
+
+	text := Render(node, map[string]string{"api": StateAlarm, "cpu": StateOK})
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	want := []string{
+		"✓[-::-] OR",
+		"├─ [white::b]✓[-::-] ALARM(api)  [red]ALARM[-]",
+		"└─ [gray]✗[-] AND",
+		"   ├─ [gray]✗[-] ALARM(cpu)  [green]OK[-]",
+		"   └─ [white::b]✓[-::-] NOT",
+		"      └─ [gray]✗[-] OK(queue)  [gray]unknown[-]",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d lines, got:\n%s", len(want), text)
+	}
+	for i := range want {
+		if !strings.HasSuffix(lines[i], want[i]) {
+			t.Errorf("Line %d = %q, want it to end in %q", i, lines[i], want[i])
+		}
+	}
+}
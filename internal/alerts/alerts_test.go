@@ -0,0 +1,104 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"swiss-army-tui/internal/config"
+)
+
+func TestRenderPayload(t *testing.T) {
+	alert := Alert{Rule: "errors", Resource: "/aws/lambda/fn", Message: `boom "quoted"`}
+
+	got, err := renderPayload("", alert)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := `{"text":"[errors] /aws/lambda/fn: boom \"quoted\""}`; string(got) != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	got, err = renderPayload(`{"summary": "{{.Rule}}"}`, alert)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(got) != `{"summary": "errors"}` {
+		t.Errorf("Expected JSON template output to be posted as is, got %s", got)
+	}
+
+	if _, err := renderPayload("{{.Missing", alert); err == nil {
+		t.Error("Expected error for invalid template")
+	}
+}
+
+func TestRenderPayloadEscaping(t *testing.T) {
+	alert := Alert{Rule: "errors", Resource: `C:\logs`, Message: "{\"level\":\"error\"}\nat main.go:12"}
+
+	got, err := renderPayload(`{"text": {{json .Message}}, "resource": {{json .Resource}}}`, alert)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var payload map[string]string
+	if err := json.Unmarshal(got, &payload); err != nil {
+		t.Fatalf("Expected valid JSON, got %s: %v", got, err)
+	}
+	if payload["text"] != alert.Message || payload["resource"] != alert.Resource {
+		t.Errorf("Expected the values unchanged, got %+v", payload)
+	}
+
+	// Unquoted values break the JSON, which is an error rather than a text payload
+	got, err = renderPayload(`{"text": "{{.Message}}"}`, alert)
+	if err == nil || !strings.Contains(err.Error(), "invalid JSON") {
+		t.Errorf("Expected an invalid JSON error, got %s, %v", got, err)
+	}
+}
+
+func TestStateChanges(t *testing.T) {
+	prev := map[string]string{"i-1": "running", "i-2": "running", "i-3": "stopped"}
+	curr := map[string]string{"i-1": "stopped", "i-2": "running", "i-3": "running", "i-4": "pending"}
+
+	changes := stateChanges(prev, curr, config.WatchRule{})
+	if len(changes) != 2 || changes[0].resource != "i-1" || changes[1].resource != "i-3" {
+		t.Errorf("Expected changes for i-1 and i-3, got %+v", changes)
+	}
+
+	changes = stateChanges(prev, curr, config.WatchRule{States: []string{"STOPPED"}})
+	if len(changes) != 1 || changes[0].from != "running" || changes[0].to != "stopped" {
+		t.Errorf("Expected only the transition to stopped, got %+v", changes)
+	}
+
+	changes = stateChanges(prev, curr, config.WatchRule{Resources: []string{"i-3"}})
+	if len(changes) != 1 || changes[0].resource != "i-3" {
+		t.Errorf("Expected only i-3, got %+v", changes)
+	}
+}
+
+func TestNotifierSend(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(server.URL + "/hook")
+	if err := notifier.Send(context.Background(), config.WatchRule{Name: "r"}, Alert{Rule: "r", Resource: "x", Message: "y"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if body != `{"text":"[r] x: y"}` {
+		t.Errorf("Unexpected webhook body: %s", body)
+	}
+
+	rule := config.WatchRule{Name: "r", Webhook: server.URL + "/fail"}
+	if err := notifier.Send(context.Background(), rule, Alert{}); err == nil {
+		t.Error("Expected error for failing webhook")
+	}
+}
@@ -0,0 +1,258 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// maxLogAlertsPerPoll caps the notifications a single log rule sends per poll
+const maxLogAlertsPerPoll = 10
+
+// Monitor periodically evaluates watch rules and sends their notifications
+type Monitor struct {
+	client   *aws.Client
+	cfg      config.AlertsConfig
+	notifier *Notifier
+	onAlert  func(Alert, error)
+
+	// State from the previous poll; nil until the first poll completed
+	instanceStates map[string]string
+	alarmStates    map[string]string
+	logsSince      map[int]time.Time
+}
+
+// NewMonitor creates a monitor for the rules in cfg. onAlert is called for
+// every triggered alert with the webhook error, if any.
+func NewMonitor(client *aws.Client, cfg config.AlertsConfig, onAlert func(Alert, error)) *Monitor {
+	return &Monitor{
+		client:    client,
+		cfg:       cfg,
+		notifier:  NewNotifier(cfg.WebhookURL),
+		onAlert:   onAlert,
+		logsSince: make(map[int]time.Time),
+	}
+}
+
+// Run polls the rules until ctx is cancelled
+func (m *Monitor) Run(ctx context.Context) {
+	start := time.Now()
+	for i, rule := range m.cfg.Rules {
+		if rule.Type == config.RuleLogPattern {
+			m.logsSince[i] = start
+		}
+	}
+
+	ticker := time.NewTicker(time.Duration(m.cfg.PollInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		m.poll(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// poll evaluates all rules once
+func (m *Monitor) poll(ctx context.Context) {
+	var needInstances, needAlarms bool
+	for _, rule := range m.cfg.Rules {
+		switch rule.Type {
+		case config.RuleInstanceState:
+			needInstances = true
+		case config.RuleAlarmState:
+			needAlarms = true
+		}
+	}
+
+	if needInstances {
+		if states, err := m.fetchInstanceStates(ctx); err != nil {
+			logger.Warn("Failed to poll instance states", zap.Error(err))
+		} else {
+			m.evaluateStates(ctx, config.RuleInstanceState, m.instanceStates, states)
+			m.instanceStates = states
+		}
+	}
+
+	if needAlarms {
+		if states, err := m.fetchAlarmStates(ctx); err != nil {
+			logger.Warn("Failed to poll alarm states", zap.Error(err))
+		} else {
+			m.evaluateStates(ctx, config.RuleAlarmState, m.alarmStates, states)
+			m.alarmStates = states
+		}
+	}
+
+	for i, rule := range m.cfg.Rules {
+		if rule.Type == config.RuleLogPattern {
+			m.evaluateLogRule(ctx, i, rule)
+		}
+	}
+}
+
+func (m *Monitor) fetchInstanceStates(ctx context.Context) (map[string]string, error) {
+	instances, err := m.client.GetEC2FunctionDetails(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]string, len(instances))
+	for _, instance := range instances {
+		if instance.InstanceId != nil && instance.State != nil {
+			states[*instance.InstanceId] = string(instance.State.Name)
+		}
+	}
+	return states, nil
+}
+
+func (m *Monitor) fetchAlarmStates(ctx context.Context) (map[string]string, error) {
+	svc := m.client.GetCloudWatchService()
+	if svc == nil {
+		return nil, fmt.Errorf("CloudWatch service not available")
+	}
+
+	alarms, err := svc.DescribeAlarms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]string, len(alarms))
+	for _, alarm := range alarms {
+		states[alarm.Name] = alarm.State
+	}
+	return states, nil
+}
+
+// evaluateStates notifies the rules of ruleType about matching state changes
+func (m *Monitor) evaluateStates(ctx context.Context, ruleType string, prev, curr map[string]string) {
+	// The first poll only records the current states
+	if prev == nil {
+		return
+	}
+
+	for _, rule := range m.cfg.Rules {
+		if rule.Type != ruleType {
+			continue
+		}
+
+		for _, change := range stateChanges(prev, curr, rule) {
+			m.notify(ctx, rule, Alert{
+				Type:          ruleType,
+				Resource:      change.resource,
+				State:         change.to,
+				PreviousState: change.from,
+				Message:       fmt.Sprintf("state changed from %s to %s", change.from, change.to),
+			})
+		}
+	}
+}
+
+// evaluateLogRule notifies about log events matching the rule since the last poll
+func (m *Monitor) evaluateLogRule(ctx context.Context, index int, rule config.WatchRule) {
+	svc := m.client.GetCloudWatchLogsService()
+	if svc == nil {
+		return
+	}
+
+	events, err := svc.FilterLogEvents(ctx, rule.LogGroup, rule.Pattern, m.logsSince[index])
+	if err != nil {
+		logger.Warn("Failed to poll log group", zap.String("rule", rule.Name), zap.String("log_group", rule.LogGroup), zap.Error(err))
+		return
+	}
+
+	for i, event := range events {
+		if event.Timestamp >= m.logsSince[index].UnixMilli() {
+			m.logsSince[index] = time.UnixMilli(event.Timestamp + 1)
+		}
+
+		if i == maxLogAlertsPerPoll {
+			m.notify(ctx, rule, Alert{
+				Type:     config.RuleLogPattern,
+				Resource: rule.LogGroup,
+				Message:  fmt.Sprintf("%d more matching events not shown", len(events)-i),
+			})
+		}
+		if i >= maxLogAlertsPerPoll {
+			continue
+		}
+
+		m.notify(ctx, rule, Alert{
+			Type:     config.RuleLogPattern,
+			Resource: rule.LogGroup,
+			Message:  strings.TrimRight(event.Message, "\r\n"),
+			Time:     time.UnixMilli(event.Timestamp),
+		})
+	}
+}
+
+// notify fills in the common alert fields and posts the alert
+func (m *Monitor) notify(ctx context.Context, rule config.WatchRule, alert Alert) {
+	alert.Rule = rule.Name
+	alert.Profile = m.client.GetProfile()
+	alert.Region = m.client.GetRegion()
+	alert.Account = m.client.GetAccountID()
+	if alert.Time.IsZero() {
+		alert.Time = time.Now()
+	}
+
+	err := m.notifier.Send(ctx, rule, alert)
+	if err != nil {
+		logger.Warn("Failed to send alert", zap.String("rule", rule.Name), zap.Error(err))
+	} else {
+		logger.Info("Sent alert", zap.String("rule", rule.Name), zap.String("resource", alert.Resource))
+	}
+
+	if m.onAlert != nil {
+		m.onAlert(alert, err)
+	}
+}
+
+// stateChange is a resource state transition between two polls
+type stateChange struct {
+	resource string
+	from     string
+	to       string
+}
+
+// stateChanges returns the transitions between prev and curr that match the
+// rule's resources and target states, sorted by resource
+func stateChanges(prev, curr map[string]string, rule config.WatchRule) []stateChange {
+	var changes []stateChange
+	for resource, to := range curr {
+		from, ok := prev[resource]
+		if !ok || from == to {
+			continue
+		}
+		if len(rule.Resources) > 0 && !containsFold(rule.Resources, resource) {
+			continue
+		}
+		if len(rule.States) > 0 && !containsFold(rule.States, to) {
+			continue
+		}
+		changes = append(changes, stateChange{resource: resource, from: from, to: to})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].resource < changes[j].resource })
+	return changes
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
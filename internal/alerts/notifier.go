@@ -0,0 +1,126 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"swiss-army-tui/internal/config"
+)
+
+// defaultTemplate renders a plain text message, posted as {"text": ...}
+// which Slack incoming webhooks and most chat webhooks accept
+const defaultTemplate = "[{{.Rule}}] {{.Resource}}: {{.Message}}"
+
+// Alert is a triggered watch rule, also the data passed to payload templates
+type Alert struct {
+	Rule          string
+	Type          string
+	Resource      string
+	State         string
+	PreviousState string
+	Message       string
+	Profile       string
+	Region        string
+	Account       string
+	Time          time.Time
+}
+
+// Notifier posts alerts to webhooks
+type Notifier struct {
+	httpClient *http.Client
+	defaultURL string
+}
+
+// NewNotifier creates a notifier that posts to defaultURL unless a rule sets its own webhook
+func NewNotifier(defaultURL string) *Notifier {
+	return &Notifier{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		defaultURL: defaultURL,
+	}
+}
+
+// Send renders the rule's payload template for alert and posts it to the webhook
+func (n *Notifier) Send(ctx context.Context, rule config.WatchRule, alert Alert) error {
+	url := rule.Webhook
+	if url == "" {
+		url = n.defaultURL
+	}
+	if url == "" {
+		return fmt.Errorf("no webhook configured for rule %s", rule.Name)
+	}
+
+	payload, err := renderPayload(rule.Template, alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %s", resp.Status)
+	}
+	return nil
+}
+
+// payloadFuncs are the functions available to payload templates. json
+// quotes a value as a JSON string, number or object, for use inside JSON
+// payloads, e.g. {"text": {{json .Message}}}.
+var payloadFuncs = template.FuncMap{
+	"json": func(value any) (string, error) {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	},
+}
+
+// renderPayload executes the template for alert. Output that starts with {
+// is posted as is and must be valid JSON; anything else is wrapped as
+// {"text": ...}.
+func renderPayload(tmpl string, alert Alert) ([]byte, error) {
+	if tmpl == "" {
+		tmpl = defaultTemplate
+	}
+
+	t, err := template.New("payload").Funcs(payloadFuncs).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload template: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, alert); err != nil {
+		return nil, fmt.Errorf("failed to render payload template: %w", err)
+	}
+
+	rendered := strings.TrimSpace(out.String())
+	if strings.HasPrefix(rendered, "{") {
+		var payload any
+		if err := json.Unmarshal([]byte(rendered), &payload); err != nil {
+			return nil, fmt.Errorf("payload template rendered invalid JSON, quote values with json (e.g. {{json .Message}}): %w", err)
+		}
+		return []byte(rendered), nil
+	}
+
+	payload, err := json.Marshal(map[string]string{"text": rendered})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	return payload, nil
+}
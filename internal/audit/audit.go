@@ -0,0 +1,147 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Results recorded for an action
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Entry is a single mutating operation recorded in the audit log
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Profile   string    `json:"profile"`
+	Account   string    `json:"account"`
+	Region    string    `json:"region"`
+	Action    string    `json:"action"`
+	Resource  string    `json:"resource"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+	// Detail is what the action did beyond naming its resource, e.g. the
+	// SQL of a query
+	Detail string `json:"detail,omitempty"`
+}
+
+// Log is an append-only JSON Lines audit log file
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// defaultLog is the log at DefaultPath, looked up when it is used so that a
+// changed $HOME is followed
+var (
+	defaultMu  sync.Mutex
+	defaultLog *Log
+)
+
+// New creates an audit log writing to path
+func New(path string) *Log {
+	return &Log{path: path}
+}
+
+// Default returns the log at DefaultPath
+func Default() *Log {
+	path := DefaultPath()
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultLog == nil || defaultLog.path != path {
+		defaultLog = New(path)
+	}
+	return defaultLog
+}
+
+// Path returns the file the log is written to
+func (l *Log) Path() string {
+	return l.path
+}
+
+// DefaultPath returns ~/.swiss-army-tui/audit.log
+func DefaultPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "audit.log"
+	}
+	return filepath.Join(homeDir, ".swiss-army-tui", "audit.log")
+}
+
+// Record appends an entry to the default audit log
+func Record(entry Entry) error {
+	return Default().Record(entry)
+}
+
+// Read returns all entries of the default audit log
+func Read() ([]Entry, error) {
+	return Default().Read()
+}
+
+// Record appends an entry to the log
+func (l *Log) Record(entry Entry) error {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Read returns all entries of the log in the order they were recorded. A
+// missing file yields no entries; malformed lines are skipped.
+func (l *Log) Read() ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "audit.log")
+	log := New(path)
+
+	if entries, err := log.Read(); err != nil || len(entries) != 0 {
+		t.Fatalf("Expected no entries for missing file, got %v, %v", entries, err)
+	}
+
+	if err := log.Record(Entry{Action: "ec2:StartInstances", Resource: "arn:aws:ec2:us-east-1:1:instance/i-1", Result: ResultSuccess}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := log.Record(Entry{Action: "ec2:StopInstances", Result: ResultFailure, Error: "denied"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	entries, err := log.Read()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Action != "ec2:StartInstances" || entries[0].Timestamp.IsZero() {
+		t.Errorf("Unexpected first entry: %+v", entries[0])
+	}
+	if entries[1].Error != "denied" {
+		t.Errorf("Expected error to be recorded, got %+v", entries[1])
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Expected audit log file, got %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected audit log mode 0600, got %v", info.Mode().Perm())
+	}
+}
+
+func TestDefaultFollowsHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if err := Record(Entry{Action: "ec2:StopInstances", Result: ResultSuccess}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := filepath.Join(home, ".swiss-army-tui", "audit.log")
+	if Default().Path() != want {
+		t.Errorf("Expected the default log at %s, got %s", want, Default().Path())
+	}
+	if _, err := os.Stat(want); err != nil {
+		t.Errorf("Expected the entry written under the current home, got %v", err)
+	}
+}
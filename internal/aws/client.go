@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
 	"sync"
 	"time"
 
@@ -13,23 +12,68 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/health"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/savingsplans"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/aws-sdk-go-v2/service/support"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
 	"go.uber.org/zap"
 )
 
 type ServiceClients struct {
-	EC2            *clients.EC2Service
-	S3             *clients.S3Service
-	RDS            *clients.RDSService
-	Lambda         *clients.LambdaService
-	CloudWatchLogs *clients.CloudWatchLogsService
-	STS            *sts.Client
+	EC2            EC2Service
+	S3             S3Service
+	RDS            RDSService
+	Lambda         LambdaService
+	CloudWatchLogs CloudWatchLogsService
+	CloudWatch     CloudWatchService
+	ELB            ELBService
+	SavingsPlans   SavingsPlansService
+	Athena         AthenaService
+	SES            SESService
+	ACM            ACMService
+	WAF            WAFService
+	Support        SupportService
+	Health         HealthService
+	DynamoDB       DynamoDBService
+	AppAutoScaling ApplicationAutoScalingService
+	AutoScaling    AutoScalingService
+	SNS            SNSService
+	SQS            SQSService
+	EKS            EKSService
+	ECS            ECSService
+	ECR            ECRService
+	IAM            IAMService
+	CloudFormation CloudFormationService
+	Transfer       TransferService
+	DataSync       DataSyncService
+	Batch          BatchService
+	SageMaker      SageMakerService
+	Bedrock        BedrockService
+	AppConfig      AppConfigService
+	AccessAnalyzer AccessAnalyzerService
+	PI             PerformanceInsightsService
+	STS            STSService
 }
 
 type Client struct {
@@ -40,6 +84,67 @@ type Client struct {
 	region       string
 	accountID    string
 	userIdentity *sts.GetCallerIdentityOutput
+	// sso is the SSO token of the profile and the profiles sharing it, nil
+	// for other profiles
+	sso *SSOShare
+
+	// Closed once the caller identity of the current profile is resolved or
+	// failed to resolve; identityErr holds the failure
+	identityDone chan struct{}
+	identityErr  error
+}
+
+const (
+	// identityTimeout bounds the background GetCallerIdentity call
+	identityTimeout = 30 * time.Second
+	// identityCacheTTL is how long a resolved identity is reused for a profile
+	identityCacheTTL = 15 * time.Minute
+)
+
+// identityCache keeps caller identities by profile, so switching back to a
+// profile does not wait for STS again
+var identityCache = struct {
+	sync.Mutex
+	entries map[string]cachedIdentity
+}{entries: make(map[string]cachedIdentity)}
+
+type cachedIdentity struct {
+	identity  *sts.GetCallerIdentityOutput
+	fetchedAt time.Time
+}
+
+// cachedCallerIdentity returns the cached identity of profile, or nil if there
+// is none or it expired
+func cachedCallerIdentity(profile string) *sts.GetCallerIdentityOutput {
+	identityCache.Lock()
+	defer identityCache.Unlock()
+
+	entry, ok := identityCache.entries[profile]
+	if !ok || time.Since(entry.fetchedAt) > identityCacheTTL {
+		return nil
+	}
+	return entry.identity
+}
+
+func storeCallerIdentity(profile string, identity *sts.GetCallerIdentityOutput) {
+	identityCache.Lock()
+	defer identityCache.Unlock()
+
+	identityCache.entries[profile] = cachedIdentity{identity: identity, fetchedAt: time.Now()}
+}
+
+// NewClientWithServices creates a client backed by the given services instead
+// of the AWS SDK, e.g. in-memory fakes. The caller identity is resolved in the
+// background through services.STS.
+func NewClientWithServices(profile, region string, services *ServiceClients) *Client {
+	client := &Client{
+		profile: profile,
+		region:  region,
+		clients: services,
+	}
+
+	client.startIdentityLoad()
+	return client
 }
 
 func NewClient(profile, region string) (*Client, error) {
@@ -50,33 +155,43 @@ func NewClient(profile, region string) (*Client, error) {
 		zap.String("profile", profile),
 		zap.String("region", region))
 
-	cfg, _, err := loadAWSConfig(ctx, profile, region)
+	cfg, profileManager, err := loadAWSConfig(ctx, profile, region)
 	if err != nil {
 		return nil, err
 	}
 
+	// Profiles sharing an SSO session sign in with one cached token; once it
+	// expired none of them is tried until the session is signed in again
+	share := profileManager.SSOShare(profile)
+	if share.Expired(time.Now()) {
+		invalidateSSOShare(share)
+		return nil, fmt.Errorf("the SSO token of profile %s expired at %s. %s",
+			profile, share.ExpiresAt.Local().Format("2006-01-02 15:04"), ssoLoginHint(profile, share))
+	}
+	if others := share.Others(profile); len(others) > 0 {
+		logger.Debug("Reusing the SSO token shared with other profiles",
+			zap.String("profile", profile),
+			zap.String("sso_session", share.Session),
+			zap.Strings("shared_with", others))
+	}
+
 	client := &Client{
 		config:  cfg,
 		profile: profile,
 		region:  region,
+		sso:     share,
 	}
 
 	if err := client.initializeClients(); err != nil {
 		return nil, fmt.Errorf("failed to initialize AWS service clients: %w", err)
 	}
 
-	if err := client.loadCallerIdentity(ctx); err != nil {
-		// Check if this is an SSO-related error
-		if isSSOError(err) {
-			return nil, fmt.Errorf("failed to get caller identity for SSO profile %s. Please run 'aws sso login --profile %s' to authenticate and try again: %w", profile, profile, err)
-		}
-		return nil, fmt.Errorf("failed to get caller identity for profile %s: %w", profile, err)
-	}
+	// The account ID fills in when STS answers; see WaitForIdentity
+	client.startIdentityLoad()
 
 	logger.Info("AWS client created successfully",
 		zap.String("profile", profile),
-		zap.String("region", region),
-		zap.String("account_id", client.accountID))
+		zap.String("region", region))
 
 	return client, nil
 }
@@ -109,15 +224,14 @@ func loadAWSConfig(ctx context.Context, profile, region string) (aws.Config, *Pr
 
 	cfg, err := config.LoadDefaultConfig(ctx, options...)
 	if err != nil {
-		if p, exists := profileManager.GetProfile(profile); exists && p.IsSSOProfileConfigured() {
-			return aws.Config{}, nil, fmt.Errorf("failed to load AWS config for SSO profile %s. Please run 'aws sso login --profile %s' to authenticate and try again: %w", p.Name, p.Name, err)
-		}
-		if isSSOError(err) {
-			return aws.Config{}, nil, fmt.Errorf("failed to load AWS config for SSO profile %s. Please run 'aws sso login --profile %s' to authenticate and try again: %w", profile, profile, err)
+		if share := profileManager.SSOShare(profile); share != nil {
+			return aws.Config{}, nil, fmt.Errorf("failed to load AWS config for SSO profile %s: %w. %s", profile, err, ssoLoginHint(profile, share))
 		}
-		return aws.Config{}, nil, fmt.Errorf("failed to load AWS config for profile %s: %w", profile, err)
+		return aws.Config{}, nil, describeError("failed to load AWS config", profile, err)
 	}
 
+	configureRequestHandling(&cfg)
+
 	return cfg, profileManager, nil
 }
 
@@ -131,6 +245,30 @@ func (c *Client) initializeClients() error {
 	lambdaClient := lambda.NewFromConfig(c.config)
 	stsClient := sts.NewFromConfig(c.config)
 	cloudWatchLogsClient := cloudwatchlogs.NewFromConfig(c.config)
+	cloudWatchClient := cloudwatch.NewFromConfig(c.config)
+	elbClient := elasticloadbalancingv2.NewFromConfig(c.config)
+	savingsPlansClient := savingsplans.NewFromConfig(c.config)
+	athenaClient := athena.NewFromConfig(c.config)
+	sesClient := sesv2.NewFromConfig(c.config)
+	acmClient := acm.NewFromConfig(c.config)
+	wafClient := wafv2.NewFromConfig(c.config)
+	dynamoDBClient := dynamodb.NewFromConfig(c.config)
+	appAutoScalingClient := applicationautoscaling.NewFromConfig(c.config)
+	autoScalingClient := autoscaling.NewFromConfig(c.config)
+	snsClient := sns.NewFromConfig(c.config)
+	sqsClient := sqs.NewFromConfig(c.config)
+	eksClient := eks.NewFromConfig(c.config)
+	ecsClient := ecs.NewFromConfig(c.config)
+	ecrClient := ecr.NewFromConfig(c.config)
+	iamClient := iam.NewFromConfig(c.config)
+	// The Support API, and with it Trusted Advisor, and the global Health
+	// endpoint are only served in us-east-1
+	supportClient := support.NewFromConfig(c.config, func(o *support.Options) {
+		o.Region = "us-east-1"
+	})
+	healthClient := health.NewFromConfig(c.config, func(o *health.Options) {
+		o.Region = "us-east-1"
+	})
 
 	ec2Svc, err := clients.NewEC2Service(ec2Client)
 	if err != nil {
@@ -152,6 +290,114 @@ func (c *Client) initializeClients() error {
 	if err != nil {
 		return fmt.Errorf("failed to initialize CloudWatch Logs service: %w", err)
 	}
+	cloudWatchSvc, err := clients.NewCloudWatchService(cloudWatchClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize CloudWatch service: %w", err)
+	}
+	elbSvc, err := clients.NewELBService(elbClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize ELB service: %w", err)
+	}
+	savingsPlansSvc, err := clients.NewSavingsPlansService(savingsPlansClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Savings Plans service: %w", err)
+	}
+	athenaSvc, err := clients.NewAthenaService(athenaClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Athena service: %w", err)
+	}
+	sesSvc, err := clients.NewSESService(sesClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize SES service: %w", err)
+	}
+	acmSvc, err := clients.NewACMService(acmClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize ACM service: %w", err)
+	}
+	wafSvc, err := clients.NewWAFService(wafClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize WAF service: %w", err)
+	}
+	supportSvc, err := clients.NewSupportService(supportClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Support service: %w", err)
+	}
+	healthSvc, err := clients.NewHealthService(healthClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Health service: %w", err)
+	}
+	dynamoDBSvc, err := clients.NewDynamoDBService(dynamoDBClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize DynamoDB service: %w", err)
+	}
+	appAutoScalingSvc, err := clients.NewApplicationAutoScalingService(appAutoScalingClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Application Auto Scaling service: %w", err)
+	}
+	autoScalingSvc, err := clients.NewAutoScalingService(autoScalingClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Auto Scaling service: %w", err)
+	}
+	snsSvc, err := clients.NewSNSService(snsClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize SNS service: %w", err)
+	}
+	sqsSvc, err := clients.NewSQSService(sqsClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize SQS service: %w", err)
+	}
+	eksSvc, err := clients.NewEKSService(eksClient, stsClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize EKS service: %w", err)
+	}
+	ecsSvc, err := clients.NewECSService(ecsClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize ECS service: %w", err)
+	}
+	ecrSvc, err := clients.NewECRService(ecrClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize ECR service: %w", err)
+	}
+	iamSvc, err := clients.NewIAMService(iamClient)
+	if err != nil {
+		return fmt.Errorf("failed to initialize IAM service: %w", err)
+	}
+	cloudFormationSvc, err := clients.NewCloudFormationService(c.config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize CloudFormation service: %w", err)
+	}
+	transferSvc, err := clients.NewTransferService(c.config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Transfer Family service: %w", err)
+	}
+	dataSyncSvc, err := clients.NewDataSyncService(c.config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize DataSync service: %w", err)
+	}
+	batchSvc, err := clients.NewBatchService(c.config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Batch service: %w", err)
+	}
+	sageMakerSvc, err := clients.NewSageMakerService(c.config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize SageMaker service: %w", err)
+	}
+	bedrockSvc, err := clients.NewBedrockService(c.config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Bedrock service: %w", err)
+	}
+	appConfigSvc, err := clients.NewAppConfigService(c.config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AppConfig service: %w", err)
+	}
+	analyzerSvc, err := clients.NewAccessAnalyzerService(c.config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Access Analyzer service: %w", err)
+	}
+	piSvc, err := clients.NewPerformanceInsightsService(c.config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Performance Insights service: %w", err)
+	}
 
 	c.clients = &ServiceClients{
 		EC2:            ec2Svc,
@@ -159,27 +405,137 @@ func (c *Client) initializeClients() error {
 		RDS:            rdsSvc,
 		Lambda:         lambdaSvc,
 		CloudWatchLogs: cloudWatchLogsSvc,
+		CloudWatch:     cloudWatchSvc,
+		ELB:            elbSvc,
+		SavingsPlans:   savingsPlansSvc,
+		Athena:         athenaSvc,
+		SES:            sesSvc,
+		ACM:            acmSvc,
+		WAF:            wafSvc,
+		Support:        supportSvc,
+		Health:         healthSvc,
+		DynamoDB:       dynamoDBSvc,
+		AppAutoScaling: appAutoScalingSvc,
+		AutoScaling:    autoScalingSvc,
+		SNS:            snsSvc,
+		SQS:            sqsSvc,
+		EKS:            eksSvc,
+		ECS:            ecsSvc,
+		ECR:            ecrSvc,
+		IAM:            iamSvc,
+		CloudFormation: cloudFormationSvc,
+		Transfer:       transferSvc,
+		DataSync:       dataSyncSvc,
+		Batch:          batchSvc,
+		SageMaker:      sageMakerSvc,
+		Bedrock:        bedrockSvc,
+		AppConfig:      appConfigSvc,
+		AccessAnalyzer: analyzerSvc,
+		PI:             piSvc,
 		STS:            stsClient,
 	}
 
 	return nil
 }
 
-func (c *Client) loadCallerIdentity(ctx context.Context) error {
+// startIdentityLoad resolves the caller identity of the current profile, from
+// the cache if possible and otherwise in the background
+func (c *Client) startIdentityLoad() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	result, err := c.clients.STS.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	done := make(chan struct{})
+	c.identityDone = done
+	c.identityErr = nil
+	c.setIdentity(nil)
+
+	if identity := cachedCallerIdentity(c.profile); identity != nil {
+		c.setIdentity(identity)
+		close(done)
+		return
+	}
+
+	var svc STSService
+	if c.clients != nil {
+		svc = c.clients.STS
+	}
+	go c.loadCallerIdentity(done, c.profile, svc)
+}
+
+// loadCallerIdentity asks STS for the identity of profile and closes done.
+// The result is dropped if the profile was switched in the meantime.
+func (c *Client) loadCallerIdentity(done chan struct{}, profile string, svc STSService) {
+	defer close(done)
+
+	var result *sts.GetCallerIdentityOutput
+	err := fmt.Errorf("STS client not initialized")
+	if svc != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), identityTimeout)
+		defer cancel()
+		result, err = svc.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	}
+
+	if err != nil {
+		if ClassifyError(err) == ErrorSSOLogin {
+			invalidateSSOShare(c.sso)
+		}
+		err = describeError("failed to get caller identity", profile, err)
+		logger.Warn("Failed to resolve caller identity", zap.String("profile", profile), zap.Error(err))
+	} else {
+		storeCallerIdentity(profile, result)
+		logger.Debug("Resolved caller identity",
+			zap.String("profile", profile),
+			zap.String("account_id", aws.ToString(result.Account)))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.identityDone != done {
+		return
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get caller identity: %w", err)
+		c.identityErr = err
+		return
 	}
+	c.setIdentity(result)
+}
 
-	c.userIdentity = result
-	if result.Account != nil {
-		c.accountID = *result.Account
+// setIdentity stores identity as the current one. The caller must hold c.mu.
+func (c *Client) setIdentity(identity *sts.GetCallerIdentityOutput) {
+	c.userIdentity = identity
+	c.accountID = ""
+	if identity != nil {
+		c.accountID = aws.ToString(identity.Account)
 	}
+}
 
-	return nil
+// WaitForIdentity waits until the caller identity of the current profile is
+// resolved and returns it
+func (c *Client) WaitForIdentity(ctx context.Context) (*sts.GetCallerIdentityOutput, error) {
+	for {
+		c.mu.RLock()
+		done := c.identityDone
+		c.mu.RUnlock()
+
+		if done == nil {
+			return nil, fmt.Errorf("caller identity not requested")
+		}
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		c.mu.RLock()
+		identity, err, current := c.userIdentity, c.identityErr, c.identityDone == done
+		c.mu.RUnlock()
+
+		// A profile switch started a new lookup while waiting
+		if current {
+			return identity, err
+		}
+	}
 }
 
 func (c *Client) GetProfile() string {
@@ -224,6 +580,32 @@ func (c *Client) GetLambdaFunctionDetails(ctx context.Context) ([]clients.Lambda
 	return svc.GetLambdaDetail(ctx)
 }
 
+// ListLambdaFunctions lists all Lambda functions without their extended configuration
+func (c *Client) ListLambdaFunctions(ctx context.Context) ([]clients.LambdaFunctionDetail, error) {
+	c.mu.RLock()
+	svc := c.clients.Lambda
+	c.mu.RUnlock()
+
+	if svc == nil {
+		return nil, fmt.Errorf("lambda service not initialized")
+	}
+
+	return svc.ListLambdaFunctions(ctx)
+}
+
+// GetLambdaFunction retrieves the full configuration of a single Lambda function
+func (c *Client) GetLambdaFunction(ctx context.Context, functionName string) (clients.LambdaFunctionDetail, error) {
+	c.mu.RLock()
+	svc := c.clients.Lambda
+	c.mu.RUnlock()
+
+	if svc == nil {
+		return clients.LambdaFunctionDetail{}, fmt.Errorf("lambda service not initialized")
+	}
+
+	return svc.GetLambdaFunction(ctx, functionName)
+}
+
 func (c *Client) GetS3FunctionDetails(ctx context.Context) ([]clients.S3Details, error) {
 	c.mu.RLock()
 	svc := c.clients.S3
@@ -236,7 +618,36 @@ func (c *Client) GetS3FunctionDetails(ctx context.Context) ([]clients.S3Details,
 	return svc.GetS3Detail(ctx)
 }
 
-func (c *Client) GetEC2FunctionDetails(ctx context.Context) ([]types.Instance, error) {
+// ListS3Buckets lists all S3 buckets; only regions looked up before are set
+func (c *Client) ListS3Buckets(ctx context.Context) ([]clients.S3Details, error) {
+	c.mu.RLock()
+	svc := c.clients.S3
+	c.mu.RUnlock()
+
+	if svc == nil {
+		return nil, fmt.Errorf("S3 service not initialized")
+	}
+
+	return svc.ListBuckets(ctx)
+}
+
+// LookupS3BucketRegions looks up bucket regions concurrently, calling onRegion
+// for each one found; failed lookups are returned as a *clients.PartialError
+func (c *Client) LookupS3BucketRegions(ctx context.Context, names []string, onRegion func(name, region string)) error {
+	c.mu.RLock()
+	svc := c.clients.S3
+	c.mu.RUnlock()
+
+	if svc == nil {
+		return fmt.Errorf("S3 service not initialized")
+	}
+
+	return svc.LookupBucketRegions(ctx, names, onRegion)
+}
+
+// GetEC2FunctionDetails lists all EC2 instances, calling onPage, if set,
+// with every page as it arrives
+func (c *Client) GetEC2FunctionDetails(ctx context.Context, onPage func([]types.Instance)) ([]types.Instance, error) {
 	c.mu.RLock()
 	svc := c.clients.EC2
 	c.mu.RUnlock()
@@ -245,11 +656,98 @@ func (c *Client) GetEC2FunctionDetails(ctx context.Context) ([]types.Instance, e
 		return nil, fmt.Errorf("EC2 service not initialized")
 	}
 
-	return svc.GetEC2Detail(ctx)
+	return svc.GetEC2Detail(ctx, onPage)
 }
 
-// GetRDSFunctionDetails retrieves details of all RDS instances
-func (c *Client) GetRDSFunctionDetails(ctx context.Context) ([]clients.RDSDetails, error) {
+// WaitForInstanceState polls an EC2 instance every interval until it reaches
+// target, calling onUpdate with every description it gets. It fails if the
+// instance is terminated or ctx is done first.
+func (c *Client) WaitForInstanceState(ctx context.Context, instanceID string, target types.InstanceStateName, interval time.Duration, onUpdate func(types.Instance)) error {
+	c.mu.RLock()
+	var svc EC2Service
+	if c.clients != nil {
+		svc = c.clients.EC2
+	}
+	c.mu.RUnlock()
+
+	if svc == nil {
+		return fmt.Errorf("EC2 service not initialized")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		instance, err := svc.DescribeInstance(ctx, instanceID)
+		if err != nil {
+			return err
+		}
+		if onUpdate != nil {
+			onUpdate(instance)
+		}
+
+		var state types.InstanceStateName
+		if instance.State != nil {
+			state = instance.State.Name
+		}
+		// Other states may still be reported shortly after a start or stop,
+		// only a terminated instance can no longer get there
+		switch state {
+		case target:
+			return nil
+		case types.InstanceStateNameTerminated:
+			return fmt.Errorf("instance %s was terminated", instanceID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for instance %s to be %s: %w", instanceID, target, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForQuery polls an Athena query every interval until it finished,
+// calling onUpdate with every status it gets. It returns the final status; a
+// failed or cancelled query is not an error.
+func (c *Client) WaitForQuery(ctx context.Context, id string, interval time.Duration, onUpdate func(clients.QueryStatus)) (clients.QueryStatus, error) {
+	c.mu.RLock()
+	var svc AthenaService
+	if c.clients != nil {
+		svc = c.clients.Athena
+	}
+	c.mu.RUnlock()
+
+	if svc == nil {
+		return clients.QueryStatus{}, fmt.Errorf("Athena service not initialized")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := svc.GetQueryStatus(ctx, id)
+		if err != nil {
+			return status, err
+		}
+		if onUpdate != nil {
+			onUpdate(status)
+		}
+		if status.Done() {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, fmt.Errorf("gave up waiting for query %s: %w", id, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetRDSFunctionDetails retrieves details of all RDS instances, calling
+// onPage, if set, with every page as it arrives
+func (c *Client) GetRDSFunctionDetails(ctx context.Context, onPage func([]clients.RDSDetails)) ([]clients.RDSDetails, error) {
 	c.mu.RLock()
 	svc := c.clients.RDS
 	c.mu.RUnlock()
@@ -258,15 +756,28 @@ func (c *Client) GetRDSFunctionDetails(ctx context.Context) ([]clients.RDSDetail
 		return nil, fmt.Errorf("RDS service not initialized")
 	}
 
-	return svc.GetRDSDetail(ctx)
+	return svc.GetRDSDetail(ctx, onPage)
 }
 
 // GetCloudWatchLogsService retrieves the CloudWatch Logs service
-func (c *Client) GetCloudWatchLogsService() *clients.CloudWatchLogsService {
+func (c *Client) GetCloudWatchLogsService() CloudWatchLogsService {
 	c.mu.RLock()
-	svc := c.clients.CloudWatchLogs
-	c.mu.RUnlock()
-	return svc
+	defer c.mu.RUnlock()
+	// A closed client has no services
+	if c.clients == nil {
+		return nil
+	}
+	return c.clients.CloudWatchLogs
+}
+
+// GetCloudWatchService retrieves the CloudWatch service
+func (c *Client) GetCloudWatchService() CloudWatchService {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.clients == nil {
+		return nil
+	}
+	return c.clients.CloudWatch
 }
 
 func (c *Client) SwitchProfile(profile, region string) error {
@@ -293,38 +804,43 @@ func (c *Client) SwitchProfile(profile, region string) error {
 		return fmt.Errorf("failed to reinitialize AWS service clients: %w", err)
 	}
 
-	if err := c.loadCallerIdentity(ctx); err != nil {
-		// Check if this is an SSO-related error
-		if isSSOError(err) {
-			return fmt.Errorf("failed to get caller identity for SSO profile %s. Please run 'aws sso login --profile %s' to authenticate and try again: %w", profile, profile, err)
-		}
-		return fmt.Errorf("failed to get caller identity for profile %s: %w", profile, err)
-	}
+	c.startIdentityLoad()
 
 	logger.Info("AWS profile switched successfully",
 		zap.String("profile", profile),
-		zap.String("region", region),
-		zap.String("account_id", c.accountID))
+		zap.String("region", region))
 
 	return nil
 }
 
+// TestConnection calls STS directly, bypassing the identity cache, and
+// refreshes the cached identity on success
 func (c *Client) TestConnection(ctx context.Context) error {
 	c.mu.RLock()
-	defer c.mu.RUnlock()
+	profile := c.profile
+	var svc STSService
+	if c.clients != nil {
+		svc = c.clients.STS
+	}
+	c.mu.RUnlock()
 
-	if c.clients == nil || c.clients.STS == nil {
+	if svc == nil {
 		return fmt.Errorf("STS client not initialized")
 	}
 
-	_, err := c.clients.STS.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	result, err := svc.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
-		// Check if this is an SSO-related error
-		if isSSOError(err) {
-			return fmt.Errorf("AWS connection test failed for SSO profile %s. Please run 'aws sso login --profile %s' to authenticate and try again: %w", c.GetProfile(), c.GetProfile(), err)
-		}
-		return fmt.Errorf("AWS connection test failed for profile %s: %w", c.GetProfile(), err)
+		return describeError("AWS connection test failed", profile, err)
+	}
+
+	storeCallerIdentity(profile, result)
+
+	c.mu.Lock()
+	if c.profile == profile {
+		c.setIdentity(result)
+		c.identityErr = nil
 	}
+	c.mu.Unlock()
 
 	return nil
 }
@@ -339,31 +855,3 @@ func (c *Client) Close() error {
 	logger.Debug("AWS client closed", zap.String("profile", c.profile))
 	return nil
 }
-
-// isSSOError checks if the error is related to AWS SSO authentication
-func isSSOError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	errStr := err.Error()
-	ssoIndicators := []string{
-		"SSO",
-		"sso",
-		"token",
-		"expired",
-		"login",
-		"authenticate",
-		"not authorized",
-		"access denied",
-		"credentials",
-	}
-
-	for _, indicator := range ssoIndicators {
-		if strings.Contains(errStr, indicator) {
-			return true
-		}
-	}
-
-	return false
-}
@@ -0,0 +1,119 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// stubSTS answers GetCallerIdentity once release is closed
+type stubSTS struct {
+	release chan struct{}
+	calls   int
+	err     error
+}
+
+func (s *stubSTS) GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	s.calls++
+	<-s.release
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &sts.GetCallerIdentityOutput{Account: aws.String("111122223333")}, nil
+}
+
+func TestClientIdentityLoadsInBackground(t *testing.T) {
+	stub := &stubSTS{release: make(chan struct{})}
+	client := NewClientWithServices("identity-test", "us-east-1", &ServiceClients{STS: stub})
+
+	if got := client.GetAccountID(); got != "" {
+		t.Errorf("Expected no account before STS answered, got %q", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.WaitForIdentity(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected WaitForIdentity to time out, got %v", err)
+	}
+
+	close(stub.release)
+	identity, err := client.WaitForIdentity(context.Background())
+	if err != nil {
+		t.Fatalf("Expected identity, got %v", err)
+	}
+	if aws.ToString(identity.Account) != "111122223333" || client.GetAccountID() != "111122223333" {
+		t.Errorf("Unexpected identity %v, account %q", identity, client.GetAccountID())
+	}
+
+	// A second client for the same profile is served from the cache
+	cached := &stubSTS{release: make(chan struct{})}
+	second := NewClientWithServices("identity-test", "eu-west-1", &ServiceClients{STS: cached})
+	if second.GetAccountID() != "111122223333" || cached.calls != 0 {
+		t.Errorf("Expected cached account without STS call, got %q after %d calls", second.GetAccountID(), cached.calls)
+	}
+}
+
+func TestClientIdentityError(t *testing.T) {
+	stub := &stubSTS{release: make(chan struct{}), err: errors.New("boom")}
+	close(stub.release)
+	client := NewClientWithServices("identity-error-test", "us-east-1", &ServiceClients{STS: stub})
+
+	if _, err := client.WaitForIdentity(context.Background()); err == nil {
+		t.Fatal("Expected an identity error")
+	}
+	if cachedCallerIdentity("identity-error-test") != nil {
+		t.Error("Expected failures not to be cached")
+	}
+}
+
+// stubEC2 describes an instance with the next of states on every call
+type stubEC2 struct {
+	EC2Service
+	states []ec2types.InstanceStateName
+}
+
+func (s *stubEC2) DescribeInstance(ctx context.Context, instanceID string) (ec2types.Instance, error) {
+	state := s.states[0]
+	if len(s.states) > 1 {
+		s.states = s.states[1:]
+	}
+	return ec2types.Instance{InstanceId: aws.String(instanceID), State: &ec2types.InstanceState{Name: state}}, nil
+}
+
+func TestWaitForInstanceState(t *testing.T) {
+	released := make(chan struct{})
+	close(released)
+
+	wait := func(ctx context.Context, target ec2types.InstanceStateName, states ...ec2types.InstanceStateName) ([]ec2types.InstanceStateName, error) {
+		client := NewClientWithServices("ec2-test", "us-east-1", &ServiceClients{
+			EC2: &stubEC2{states: states},
+			STS: &stubSTS{release: released},
+		})
+		var seen []ec2types.InstanceStateName
+		err := client.WaitForInstanceState(ctx, "i-1", target, time.Millisecond, func(instance ec2types.Instance) {
+			seen = append(seen, instance.State.Name)
+		})
+		return seen, err
+	}
+
+	seen, err := wait(context.Background(), ec2types.InstanceStateNameRunning,
+		ec2types.InstanceStateNameStopped, ec2types.InstanceStateNamePending, ec2types.InstanceStateNameRunning)
+	if err != nil || len(seen) != 3 {
+		t.Errorf("Expected three updates until running, got %v, %v", seen, err)
+	}
+
+	if _, err := wait(context.Background(), ec2types.InstanceStateNameRunning, ec2types.InstanceStateNameTerminated); err == nil {
+		t.Error("Expected an error for a terminated instance")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := wait(ctx, ec2types.InstanceStateNameStopped, ec2types.InstanceStateNameStopping); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a timeout, got %v", err)
+	}
+}
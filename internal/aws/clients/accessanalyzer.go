@@ -0,0 +1,173 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// ErrNoAnalyzer is returned when a region has no active IAM Access Analyzer
+// reporting external access
+var ErrNoAnalyzer = errors.New("no active IAM Access Analyzer in the region")
+
+// AccessFinding is an active finding of IAM Access Analyzer about a
+// resource shared outside the account or organization of the analyzer
+type AccessFinding struct {
+	ID string
+	// Resource is the name of the resource, e.g. the bucket
+	Resource     string
+	ResourceType string
+	IsPublic     bool
+	// Principals are who is granted access, e.g. "AWS: 999988887777"
+	Principals []string
+	Actions    []string
+	// Conditions are the condition keys limiting the access, e.g.
+	// "aws:SourceIp = 203.0.113.0/24"
+	Conditions []string
+	UpdatedAt  time.Time
+}
+
+// AccessAnalyzerService reads the findings of IAM Access Analyzer, in the
+// region of each analyzer
+type AccessAnalyzerService struct {
+	cfg aws.Config
+
+	mu   sync.Mutex
+	apis map[string]*restJSONAPI
+}
+
+// NewAccessAnalyzerService creates a new Access Analyzer service with the
+// credentials of cfg
+func NewAccessAnalyzerService(cfg aws.Config) (*AccessAnalyzerService, error) {
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("Access Analyzer credentials not provided")
+	}
+	return &AccessAnalyzerService{
+		cfg:  cfg,
+		apis: make(map[string]*restJSONAPI),
+	}, nil
+}
+
+// api returns the client of Access Analyzer in region
+func (s *AccessAnalyzerService) api(region string) *restJSONAPI {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	api, ok := s.apis[region]
+	if !ok {
+		api = newRestJSONAPI(s.cfg, "AccessAnalyzer", regionalEndpoint("access-analyzer", region), region, "access-analyzer")
+		s.apis[region] = api
+	}
+	return api
+}
+
+// ListBucketFindings returns the active findings about S3 buckets of the
+// external access analyzer of region, or ErrNoAnalyzer if it has none
+func (s *AccessAnalyzerService) ListBucketFindings(ctx context.Context, region string) ([]AccessFinding, error) {
+	if s == nil || s.apis == nil {
+		return nil, fmt.Errorf("Access Analyzer service not initialized")
+	}
+	api := s.api(region)
+
+	analyzerARN, err := s.externalAccessAnalyzer(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+
+	type findingsInput struct {
+		AnalyzerARN string                         `json:"analyzerArn"`
+		Filter      map[string]map[string][]string `json:"filter"`
+		MaxResults  int                            `json:"maxResults"`
+		NextToken   string                         `json:"nextToken,omitempty"`
+	}
+	input := findingsInput{
+		AnalyzerARN: analyzerARN,
+		Filter: map[string]map[string][]string{
+			"resourceType": {"eq": {"AWS::S3::Bucket"}},
+			"status":       {"eq": {"ACTIVE"}},
+		},
+		MaxResults: 100,
+	}
+
+	var findings []AccessFinding
+	for {
+		var output struct {
+			Findings []struct {
+				ID           string            `json:"id"`
+				Resource     string            `json:"resource"`
+				ResourceType string            `json:"resourceType"`
+				IsPublic     bool              `json:"isPublic"`
+				Principal    map[string]string `json:"principal"`
+				Action       []string          `json:"action"`
+				Condition    map[string]string `json:"condition"`
+				UpdatedAt    time.Time         `json:"updatedAt"`
+			} `json:"findings"`
+			NextToken string `json:"nextToken"`
+		}
+		if err := api.call(ctx, "ListFindings", http.MethodPost, "/finding", input, &output); err != nil {
+			return nil, fmt.Errorf("failed to list Access Analyzer findings in %s: %w", region, err)
+		}
+		for _, f := range output.Findings {
+			finding := AccessFinding{
+				ID:           f.ID,
+				Resource:     f.Resource[strings.LastIndex(f.Resource, ":")+1:],
+				ResourceType: f.ResourceType,
+				IsPublic:     f.IsPublic,
+				Actions:      f.Action,
+				UpdatedAt:    f.UpdatedAt,
+			}
+			for typ, principal := range f.Principal {
+				finding.Principals = append(finding.Principals, typ+": "+principal)
+			}
+			for key, value := range f.Condition {
+				finding.Conditions = append(finding.Conditions, key+" = "+value)
+			}
+			sort.Strings(finding.Principals)
+			sort.Strings(finding.Conditions)
+			findings = append(findings, finding)
+		}
+		if output.NextToken == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Resource < findings[j].Resource })
+	return findings, nil
+}
+
+// externalAccessAnalyzer returns the ARN of an active analyzer of the
+// account or organization, the ones reporting access from outside it
+func (s *AccessAnalyzerService) externalAccessAnalyzer(ctx context.Context, api *restJSONAPI) (string, error) {
+	path := "/analyzer"
+	for {
+		var output struct {
+			Analyzers []struct {
+				ARN    string `json:"arn"`
+				Type   string `json:"type"`
+				Status string `json:"status"`
+			} `json:"analyzers"`
+			NextToken string `json:"nextToken"`
+		}
+		if err := api.call(ctx, "ListAnalyzers", http.MethodGet, path, nil, &output); err != nil {
+			return "", fmt.Errorf("failed to list analyzers in %s: %w", api.region, err)
+		}
+		for _, analyzer := range output.Analyzers {
+			if analyzer.Status == "ACTIVE" && (analyzer.Type == "ACCOUNT" || analyzer.Type == "ORGANIZATION") {
+				return analyzer.ARN, nil
+			}
+		}
+		if output.NextToken == "" {
+			return "", ErrNoAnalyzer
+		}
+		path = "/analyzer?nextToken=" + url.QueryEscape(output.NextToken)
+	}
+}
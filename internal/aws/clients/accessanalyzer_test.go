@@ -0,0 +1,83 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAccessAnalyzerListBucketFindings(t *testing.T) {
+	svc, err := NewAccessAnalyzerService(newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/analyzer":
+			if r.URL.Query().Get("nextToken") == "" {
+				w.Write([]byte(`{"analyzers":[{"arn":"arn:aws:access-analyzer:eu-west-1:123456789012:analyzer/unused","type":"ACCOUNT_UNUSED_ACCESS","status":"ACTIVE"}],"nextToken":"a2"}`))
+				return
+			}
+			w.Write([]byte(`{"analyzers":[{"arn":"arn:aws:access-analyzer:eu-west-1:123456789012:analyzer/external","type":"ACCOUNT","status":"ACTIVE"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/finding":
+			var input struct {
+				AnalyzerARN string                         `json:"analyzerArn"`
+				Filter      map[string]map[string][]string `json:"filter"`
+				NextToken   string                         `json:"nextToken"`
+			}
+			json.NewDecoder(r.Body).Decode(&input)
+			if input.AnalyzerARN != "arn:aws:access-analyzer:eu-west-1:123456789012:analyzer/external" || input.Filter["resourceType"]["eq"][0] != "AWS::S3::Bucket" {
+				t.Errorf("Unexpected input %+v", input)
+			}
+			if input.NextToken == "" {
+				w.Write([]byte(`{"findings":[{"id":"f1","resource":"arn:aws:s3:::website","resourceType":"AWS::S3::Bucket","isPublic":true,
+					"principal":{"AWS":"*"},"action":["s3:GetObject"],"updatedAt":"2026-10-01T12:00:00Z"}],"nextToken":"f2"}`))
+				return
+			}
+			w.Write([]byte(`{"findings":[{"id":"f2","resource":"arn:aws:s3:::exports","resourceType":"AWS::S3::Bucket",
+				"principal":{"AWS":"999988887777"},"action":["s3:GetObject","s3:ListBucket"],"condition":{"aws:SourceIp":"203.0.113.0/24"}}]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := svc.ListBucketFindings(context.Background(), "eu-west-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 2 || findings[0].Resource != "exports" || findings[1].Resource != "website" {
+		t.Fatalf("Expected the findings of both pages sorted by bucket, got %+v", findings)
+	}
+	if f := findings[0]; f.IsPublic || !reflect.DeepEqual(f.Principals, []string{"AWS: 999988887777"}) || !reflect.DeepEqual(f.Conditions, []string{"aws:SourceIp = 203.0.113.0/24"}) {
+		t.Errorf("Unexpected finding %+v", f)
+	}
+	if f := findings[1]; !f.IsPublic || f.UpdatedAt.IsZero() {
+		t.Errorf("Expected the website public, got %+v", f)
+	}
+}
+
+func TestAccessAnalyzerErrors(t *testing.T) {
+	svc, err := NewAccessAnalyzerService(newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"analyzers":[{"arn":"arn:aws:access-analyzer:eu-west-1:123456789012:analyzer/old","type":"ACCOUNT","status":"DISABLED"}]}`))
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ListBucketFindings(context.Background(), "eu-west-1"); !errors.Is(err, ErrNoAnalyzer) {
+		t.Errorf("Expected no active analyzer, got %v", err)
+	}
+
+	svc, err = NewAccessAnalyzerService(newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Amzn-ErrorType", "AccessDeniedException:http://internal.amazon.com/coral/com.amazon.accessanalyzer/")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"not authorized"}`))
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ListBucketFindings(context.Background(), "eu-west-1"); ErrorReason(err) != "access denied" {
+		t.Errorf("Expected access denied, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	"github.com/aws/aws-sdk-go-v2/service/acm/types"
+)
+
+// CertificateDetail describes an ACM certificate and what uses it
+type CertificateDetail struct {
+	ARN     string
+	Domain  string
+	SANs    []string
+	Status  string
+	Type    string
+	KeyType string
+	// ValidationStatus is the worst validation status of the domains, e.g.
+	// PENDING_VALIDATION while any domain is not validated yet
+	ValidationStatus   string
+	RenewalEligibility string
+	InUseBy            []string
+	// NotAfter is zero for certificates that were never issued
+	NotAfter time.Time
+	IssuedAt time.Time
+}
+
+// validationRank orders domain validation statuses, worst last
+var validationRank = map[types.DomainStatus]int{
+	types.DomainStatusSuccess:           0,
+	types.DomainStatusPendingValidation: 1,
+	types.DomainStatusFailed:            2,
+}
+
+// ACMService wraps the ACM client
+type ACMService struct {
+	client *acm.Client
+}
+
+// NewACMService creates a new ACM service wrapper
+func NewACMService(client *acm.Client) (*ACMService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("ACM client not provided")
+	}
+
+	return &ACMService{
+		client: client,
+	}, nil
+}
+
+// ListCertificates returns the certificates of all key types with the
+// resources using them. Certificates that cannot be described are returned
+// as listed, together with a *PartialError naming them.
+func (s *ACMService) ListCertificates(ctx context.Context) ([]CertificateDetail, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("ACM service not initialized")
+	}
+
+	// Without a key type filter ACM lists RSA 2048 certificates only
+	input := &acm.ListCertificatesInput{
+		Includes: &types.Filters{KeyTypes: types.KeyAlgorithm("").Values()},
+	}
+
+	var certificates []CertificateDetail
+	paginator := acm.NewListCertificatesPaginator(s.client, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list certificates: %w", err)
+		}
+		for _, summary := range output.CertificateSummaryList {
+			certificates = append(certificates, CertificateDetail{
+				ARN:                aws.ToString(summary.CertificateArn),
+				Domain:             aws.ToString(summary.DomainName),
+				SANs:               summary.SubjectAlternativeNameSummaries,
+				Status:             string(summary.Status),
+				Type:               string(summary.Type),
+				KeyType:            string(summary.KeyAlgorithm),
+				RenewalEligibility: string(summary.RenewalEligibility),
+				NotAfter:           aws.ToTime(summary.NotAfter),
+				IssuedAt:           aws.ToTime(summary.IssuedAt),
+			})
+		}
+	}
+
+	var failures failureCollector
+	for i := range certificates {
+		output, err := s.client.DescribeCertificate(ctx, &acm.DescribeCertificateInput{
+			CertificateArn: aws.String(certificates[i].ARN),
+		})
+		if err != nil {
+			failures.add(certificates[i].Domain, "", err)
+			continue
+		}
+		if detail := output.Certificate; detail != nil {
+			certificates[i].InUseBy = detail.InUseBy
+			certificates[i].ValidationStatus = worstValidation(detail.DomainValidationOptions)
+		}
+	}
+
+	return certificates, failures.err("DescribeCertificate")
+}
+
+// worstValidation returns the least advanced validation status of options,
+// empty if there are none (e.g. for imported certificates)
+func worstValidation(options []types.DomainValidation) string {
+	var worst types.DomainStatus
+	for _, option := range options {
+		if worst == "" || validationRank[option.ValidationStatus] > validationRank[worst] {
+			worst = option.ValidationStatus
+		}
+	}
+	return string(worst)
+}
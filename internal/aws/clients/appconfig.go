@@ -0,0 +1,315 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// AppConfigApplication is an AWS AppConfig application
+type AppConfigApplication struct {
+	ID          string
+	Name        string
+	Description string
+}
+
+// AppConfigEnvironment is an environment of an application that
+// configurations are deployed to
+type AppConfigEnvironment struct {
+	ID            string
+	ApplicationID string
+	Name          string
+	// State is READY_FOR_DEPLOYMENT, DEPLOYING, ROLLING_BACK, ROLLED_BACK or
+	// REVERTED
+	State string
+}
+
+// AppConfigProfile is a configuration profile of an application
+type AppConfigProfile struct {
+	ID            string
+	ApplicationID string
+	Name          string
+	// Type is AWS.AppConfig.FeatureFlags or AWS.Freeform
+	Type string
+	// LocationURI is "hosted" for configurations stored by AppConfig, else
+	// the S3 object, SSM parameter or document holding it
+	LocationURI string
+}
+
+// Hosted reports whether AppConfig stores the versions of the profile
+func (p AppConfigProfile) Hosted() bool {
+	return p.LocationURI == "hosted"
+}
+
+// AppConfigDeployment is a deployment of a configuration version to an
+// environment
+type AppConfigDeployment struct {
+	Number               int
+	ConfigurationName    string
+	ConfigurationVersion string
+	// State is BAKING, VALIDATING, DEPLOYING, COMPLETE, ROLLING_BACK,
+	// ROLLED_BACK or REVERTED
+	State       string
+	Percentage  float64
+	StartedAt   time.Time
+	CompletedAt time.Time
+}
+
+// AppConfigVersion is a version of a hosted configuration
+type AppConfigVersion struct {
+	Number      int
+	Label       string
+	Description string
+	ContentType string
+}
+
+// AppConfigStrategy is a deployment strategy: how fast a configuration
+// reaches all targets and how long it bakes
+type AppConfigStrategy struct {
+	ID              string
+	Name            string
+	DurationMinutes int
+	GrowthFactor    float64
+	BakeMinutes     int
+}
+
+// AppConfigService lists AWS AppConfig applications with their
+// environments, configuration profiles, deployments and hosted versions,
+// and starts deployments
+type AppConfigService struct {
+	*restJSONAPI
+}
+
+// NewAppConfigService creates a new AppConfig service for the region and
+// credentials of cfg
+func NewAppConfigService(cfg aws.Config) (*AppConfigService, error) {
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("AppConfig credentials not provided")
+	}
+	return &AppConfigService{
+		restJSONAPI: newRestJSONAPI(cfg, "AppConfig", regionalEndpoint("appconfig", cfg.Region), cfg.Region, "appconfig"),
+	}, nil
+}
+
+// appConfigList pages through the items listed by operation at path,
+// passing each to add
+func appConfigList[T any](ctx context.Context, s *AppConfigService, operation, path string, add func(T)) error {
+	if s == nil || s.restJSONAPI == nil {
+		return fmt.Errorf("AppConfig service not initialized")
+	}
+	next := ""
+	for {
+		query := url.Values{"max_results": {"50"}}
+		if next != "" {
+			query.Set("next_token", next)
+		}
+		var output struct {
+			Items     []T    `json:"Items"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.call(ctx, operation, http.MethodGet, path+"?"+query.Encode(), nil, &output); err != nil {
+			return err
+		}
+		for _, item := range output.Items {
+			add(item)
+		}
+		if output.NextToken == "" {
+			return nil
+		}
+		next = output.NextToken
+	}
+}
+
+// ListApplications returns the applications of the region, sorted by name
+func (s *AppConfigService) ListApplications(ctx context.Context) ([]AppConfigApplication, error) {
+	type item struct {
+		ID          string `json:"Id"`
+		Name        string `json:"Name"`
+		Description string `json:"Description"`
+	}
+	var applications []AppConfigApplication
+	err := appConfigList(ctx, s, "ListApplications", "/applications", func(a item) {
+		applications = append(applications, AppConfigApplication{ID: a.ID, Name: a.Name, Description: a.Description})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list AppConfig applications: %w", err)
+	}
+	sort.SliceStable(applications, func(i, j int) bool { return applications[i].Name < applications[j].Name })
+	return applications, nil
+}
+
+// ListEnvironments returns the environments of the application, sorted by
+// name
+func (s *AppConfigService) ListEnvironments(ctx context.Context, applicationID string) ([]AppConfigEnvironment, error) {
+	type item struct {
+		ID            string `json:"Id"`
+		ApplicationID string `json:"ApplicationId"`
+		Name          string `json:"Name"`
+		State         string `json:"State"`
+	}
+	var environments []AppConfigEnvironment
+	path := "/applications/" + url.PathEscape(applicationID) + "/environments"
+	err := appConfigList(ctx, s, "ListEnvironments", path, func(e item) {
+		environments = append(environments, AppConfigEnvironment{ID: e.ID, ApplicationID: e.ApplicationID, Name: e.Name, State: e.State})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the environments of %s: %w", applicationID, err)
+	}
+	sort.SliceStable(environments, func(i, j int) bool { return environments[i].Name < environments[j].Name })
+	return environments, nil
+}
+
+// ListConfigurationProfiles returns the configuration profiles of the
+// application, sorted by name
+func (s *AppConfigService) ListConfigurationProfiles(ctx context.Context, applicationID string) ([]AppConfigProfile, error) {
+	type item struct {
+		ID            string `json:"Id"`
+		ApplicationID string `json:"ApplicationId"`
+		Name          string `json:"Name"`
+		Type          string `json:"Type"`
+		LocationURI   string `json:"LocationUri"`
+	}
+	var profiles []AppConfigProfile
+	path := "/applications/" + url.PathEscape(applicationID) + "/configurationprofiles"
+	err := appConfigList(ctx, s, "ListConfigurationProfiles", path, func(p item) {
+		profiles = append(profiles, AppConfigProfile{ID: p.ID, ApplicationID: p.ApplicationID, Name: p.Name, Type: p.Type, LocationURI: p.LocationURI})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the configuration profiles of %s: %w", applicationID, err)
+	}
+	sort.SliceStable(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// ListDeployments returns the deployments to the environment, newest first
+func (s *AppConfigService) ListDeployments(ctx context.Context, applicationID, environmentID string) ([]AppConfigDeployment, error) {
+	type item struct {
+		DeploymentNumber     int       `json:"DeploymentNumber"`
+		ConfigurationName    string    `json:"ConfigurationName"`
+		ConfigurationVersion string    `json:"ConfigurationVersion"`
+		State                string    `json:"State"`
+		PercentageComplete   float64   `json:"PercentageComplete"`
+		StartedAt            time.Time `json:"StartedAt"`
+		CompletedAt          time.Time `json:"CompletedAt"`
+	}
+	var deployments []AppConfigDeployment
+	path := "/applications/" + url.PathEscape(applicationID) + "/environments/" + url.PathEscape(environmentID) + "/deployments"
+	err := appConfigList(ctx, s, "ListDeployments", path, func(d item) {
+		deployments = append(deployments, AppConfigDeployment{
+			Number:               d.DeploymentNumber,
+			ConfigurationName:    d.ConfigurationName,
+			ConfigurationVersion: d.ConfigurationVersion,
+			State:                d.State,
+			Percentage:           d.PercentageComplete,
+			StartedAt:            d.StartedAt,
+			CompletedAt:          d.CompletedAt,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the deployments of %s: %w", environmentID, err)
+	}
+	sort.SliceStable(deployments, func(i, j int) bool { return deployments[i].Number > deployments[j].Number })
+	return deployments, nil
+}
+
+// ListHostedVersions returns the hosted versions of the configuration
+// profile, newest first
+func (s *AppConfigService) ListHostedVersions(ctx context.Context, applicationID, profileID string) ([]AppConfigVersion, error) {
+	type item struct {
+		VersionNumber int    `json:"VersionNumber"`
+		VersionLabel  string `json:"VersionLabel"`
+		Description   string `json:"Description"`
+		ContentType   string `json:"ContentType"`
+	}
+	var versions []AppConfigVersion
+	path := "/applications/" + url.PathEscape(applicationID) + "/configurationprofiles/" + url.PathEscape(profileID) + "/hostedconfigurationversions"
+	err := appConfigList(ctx, s, "ListHostedConfigurationVersions", path, func(v item) {
+		versions = append(versions, AppConfigVersion{Number: v.VersionNumber, Label: v.VersionLabel, Description: v.Description, ContentType: v.ContentType})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the versions of %s: %w", profileID, err)
+	}
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].Number > versions[j].Number })
+	return versions, nil
+}
+
+// GetHostedVersion returns the content of a hosted configuration version.
+// The content comes as the body of the response rather than as JSON.
+func (s *AppConfigService) GetHostedVersion(ctx context.Context, applicationID, profileID string, version int) ([]byte, error) {
+	if s == nil || s.restJSONAPI == nil {
+		return nil, fmt.Errorf("AppConfig service not initialized")
+	}
+	path := "/applications/" + url.PathEscape(applicationID) + "/configurationprofiles/" + url.PathEscape(profileID) +
+		"/hostedconfigurationversions/" + strconv.Itoa(version)
+	_, data, err := s.do(ctx, http.MethodGet, path, "GetHostedConfigurationVersion", http.Header{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version %d of %s: %w", version, profileID, err)
+	}
+	return data, nil
+}
+
+// ListDeploymentStrategies returns the deployment strategies of the
+// account, the predefined ones included, sorted by name
+func (s *AppConfigService) ListDeploymentStrategies(ctx context.Context) ([]AppConfigStrategy, error) {
+	type item struct {
+		ID                          string  `json:"Id"`
+		Name                        string  `json:"Name"`
+		DeploymentDurationInMinutes int     `json:"DeploymentDurationInMinutes"`
+		GrowthFactor                float64 `json:"GrowthFactor"`
+		FinalBakeTimeInMinutes      int     `json:"FinalBakeTimeInMinutes"`
+	}
+	var strategies []AppConfigStrategy
+	err := appConfigList(ctx, s, "ListDeploymentStrategies", "/deploymentstrategies", func(d item) {
+		strategies = append(strategies, AppConfigStrategy{
+			ID:              d.ID,
+			Name:            d.Name,
+			DurationMinutes: d.DeploymentDurationInMinutes,
+			GrowthFactor:    d.GrowthFactor,
+			BakeMinutes:     d.FinalBakeTimeInMinutes,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployment strategies: %w", err)
+	}
+	sort.SliceStable(strategies, func(i, j int) bool { return strategies[i].Name < strategies[j].Name })
+	return strategies, nil
+}
+
+// StartDeployment deploys version of the configuration profile to the
+// environment with the deployment strategy and returns the deployment
+func (s *AppConfigService) StartDeployment(ctx context.Context, applicationID, environmentID, profileID, version, strategyID string) (AppConfigDeployment, error) {
+	if s == nil || s.restJSONAPI == nil {
+		return AppConfigDeployment{}, fmt.Errorf("AppConfig service not initialized")
+	}
+	input := map[string]string{
+		"ConfigurationProfileId": profileID,
+		"ConfigurationVersion":   version,
+		"DeploymentStrategyId":   strategyID,
+	}
+	var output struct {
+		DeploymentNumber     int       `json:"DeploymentNumber"`
+		ConfigurationName    string    `json:"ConfigurationName"`
+		ConfigurationVersion string    `json:"ConfigurationVersion"`
+		State                string    `json:"State"`
+		PercentageComplete   float64   `json:"PercentageComplete"`
+		StartedAt            time.Time `json:"StartedAt"`
+	}
+	path := "/applications/" + url.PathEscape(applicationID) + "/environments/" + url.PathEscape(environmentID) + "/deployments"
+	if err := s.call(ctx, "StartDeployment", http.MethodPost, path, input, &output); err != nil {
+		return AppConfigDeployment{}, fmt.Errorf("failed to deploy version %s of %s: %w", version, profileID, err)
+	}
+	return AppConfigDeployment{
+		Number:               output.DeploymentNumber,
+		ConfigurationName:    output.ConfigurationName,
+		ConfigurationVersion: output.ConfigurationVersion,
+		State:                output.State,
+		Percentage:           output.PercentageComplete,
+		StartedAt:            output.StartedAt,
+	}, nil
+}
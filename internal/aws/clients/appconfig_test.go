@@ -0,0 +1,106 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestAppConfigListDeployments(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/applications/app1/environments/env1/deployments" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if !strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/appconfig/aws4_request") {
+			t.Errorf("Expected a request signed for appconfig, got %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Query().Get("next_token") == "" {
+			w.Write([]byte(`{"Items":[{"DeploymentNumber":2,"ConfigurationName":"flags","ConfigurationVersion":"3","State":"DEPLOYING","PercentageComplete":40,"StartedAt":"2026-10-16T09:00:00Z"}],"NextToken":"p2"}`))
+			return
+		}
+		w.Write([]byte(`{"Items":[{"DeploymentNumber":3,"ConfigurationName":"flags","ConfigurationVersion":"4","State":"COMPLETE","PercentageComplete":100,
+			"StartedAt":"2026-10-16T10:00:00Z","CompletedAt":"2026-10-16T10:10:00Z"}]}`))
+	})
+
+	svc, err := NewAppConfigService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployments, err := svc.ListDeployments(context.Background(), "app1", "env1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deployments) != 2 || deployments[0].Number != 3 || deployments[1].Number != 2 {
+		t.Fatalf("Expected both pages newest first, got %+v", deployments)
+	}
+	if d := deployments[0]; d.ConfigurationVersion != "4" || d.State != "COMPLETE" || d.CompletedAt.Sub(d.StartedAt).Minutes() != 10 {
+		t.Errorf("Unexpected deployment %+v", d)
+	}
+	if deployments[1].Percentage != 40 {
+		t.Errorf("Expected the running deployment at 40%%, got %+v", deployments[1])
+	}
+}
+
+func TestAppConfigGetHostedVersion(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/applications/app1/configurationprofiles/prof1/hostedconfigurationversions/2":
+			w.Header().Set("Content-Type", "application/x-yaml")
+			w.Write([]byte("retries: 3\ntimeout: 5s\n"))
+		default:
+			w.Header().Set("X-Amzn-ErrorType", "ResourceNotFoundException:http://internal.amazon.com/coral/com.amazonaws.appconfig/")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Message":"Hosted configuration version 9 not found"}`))
+		}
+	})
+
+	svc, err := NewAppConfigService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := svc.GetHostedVersion(context.Background(), "app1", "prof1", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "retries: 3\ntimeout: 5s\n" {
+		t.Errorf("Expected the YAML content as is, got %q", content)
+	}
+
+	_, err = svc.GetHostedVersion(context.Background(), "app1", "prof1", 9)
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "ResourceNotFoundException" {
+		t.Errorf("Expected ResourceNotFoundException, got %v", err)
+	}
+}
+
+func TestAppConfigStartDeployment(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/applications/app1/environments/env1/deployments" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var input map[string]string
+		json.NewDecoder(r.Body).Decode(&input)
+		if input["ConfigurationProfileId"] != "prof1" || input["ConfigurationVersion"] != "4" || input["DeploymentStrategyId"] != "AppConfig.AllAtOnce" {
+			t.Errorf("Unexpected input %v", input)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"DeploymentNumber":7,"ConfigurationName":"flags","ConfigurationVersion":"4","State":"DEPLOYING","StartedAt":"2026-10-16T10:00:00Z"}`))
+	})
+
+	svc, err := NewAppConfigService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployment, err := svc.StartDeployment(context.Background(), "app1", "env1", "prof1", "4", "AppConfig.AllAtOnce")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deployment.Number != 7 || deployment.State != "DEPLOYING" {
+		t.Errorf("Unexpected deployment %+v", deployment)
+	}
+}
@@ -0,0 +1,234 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	"github.com/aws/aws-sdk-go-v2/service/athena/types"
+)
+
+// AthenaCatalog is the data catalog queries run against
+const AthenaCatalog = "AwsDataCatalog"
+
+// Athena query states
+const (
+	QueryQueued    = string(types.QueryExecutionStateQueued)
+	QueryRunning   = string(types.QueryExecutionStateRunning)
+	QuerySucceeded = string(types.QueryExecutionStateSucceeded)
+	QueryFailed    = string(types.QueryExecutionStateFailed)
+	QueryCancelled = string(types.QueryExecutionStateCancelled)
+)
+
+// QueryStatus is the progress of an Athena query execution
+type QueryStatus struct {
+	ID    string
+	State string
+	// Reason explains a failed or cancelled query
+	Reason         string
+	DataScanned    int64
+	Runtime        time.Duration
+	OutputLocation string
+}
+
+// Done reports whether the query finished, successfully or not
+func (s QueryStatus) Done() bool {
+	return s.State == QuerySucceeded || s.State == QueryFailed || s.State == QueryCancelled
+}
+
+// QueryResultPage is one page of the rows of a finished query
+type QueryResultPage struct {
+	Columns []string
+	Rows    [][]string
+	// NextToken fetches the next page, empty on the last one
+	NextToken string
+}
+
+// AthenaService wraps the Athena client
+type AthenaService struct {
+	client *athena.Client
+}
+
+// NewAthenaService creates a new Athena service wrapper
+func NewAthenaService(client *athena.Client) (*AthenaService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("Athena client not provided")
+	}
+
+	return &AthenaService{
+		client: client,
+	}, nil
+}
+
+// ListWorkGroups returns the names of the enabled workgroups
+func (s *AthenaService) ListWorkGroups(ctx context.Context) ([]string, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("Athena service not initialized")
+	}
+
+	var names []string
+	paginator := athena.NewListWorkGroupsPaginator(s.client, &athena.ListWorkGroupsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workgroups: %w", err)
+		}
+		for _, group := range output.WorkGroups {
+			if group.State == types.WorkGroupStateEnabled {
+				names = append(names, aws.ToString(group.Name))
+			}
+		}
+	}
+	return names, nil
+}
+
+// ListDatabases returns the names of the databases in AthenaCatalog
+func (s *AthenaService) ListDatabases(ctx context.Context) ([]string, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("Athena service not initialized")
+	}
+
+	var names []string
+	paginator := athena.NewListDatabasesPaginator(s.client, &athena.ListDatabasesInput{
+		CatalogName: aws.String(AthenaCatalog),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list databases: %w", err)
+		}
+		for _, database := range output.DatabaseList {
+			names = append(names, aws.ToString(database.Name))
+		}
+	}
+	return names, nil
+}
+
+// StartQuery starts sql in workgroup against database and returns the ID of
+// the execution
+func (s *AthenaService) StartQuery(ctx context.Context, sql, workgroup, database string) (string, error) {
+	if s == nil || s.client == nil {
+		return "", fmt.Errorf("Athena service not initialized")
+	}
+
+	input := &athena.StartQueryExecutionInput{
+		QueryString: aws.String(sql),
+		WorkGroup:   aws.String(workgroup),
+	}
+	if database != "" {
+		input.QueryExecutionContext = &types.QueryExecutionContext{
+			Catalog:  aws.String(AthenaCatalog),
+			Database: aws.String(database),
+		}
+	}
+
+	output, err := s.client.StartQueryExecution(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to start query: %w", err)
+	}
+	return aws.ToString(output.QueryExecutionId), nil
+}
+
+// GetQueryStatus returns the progress of the query execution id
+func (s *AthenaService) GetQueryStatus(ctx context.Context, id string) (QueryStatus, error) {
+	if s == nil || s.client == nil {
+		return QueryStatus{}, fmt.Errorf("Athena service not initialized")
+	}
+
+	output, err := s.client.GetQueryExecution(ctx, &athena.GetQueryExecutionInput{
+		QueryExecutionId: aws.String(id),
+	})
+	if err != nil {
+		return QueryStatus{}, fmt.Errorf("failed to get query %s: %w", id, err)
+	}
+
+	status := QueryStatus{ID: id}
+	execution := output.QueryExecution
+	if execution == nil {
+		return status, nil
+	}
+	if execution.Status != nil {
+		status.State = string(execution.Status.State)
+		status.Reason = aws.ToString(execution.Status.StateChangeReason)
+	}
+	if stats := execution.Statistics; stats != nil {
+		status.DataScanned = aws.ToInt64(stats.DataScannedInBytes)
+		status.Runtime = time.Duration(aws.ToInt64(stats.TotalExecutionTimeInMillis)) * time.Millisecond
+	}
+	if execution.ResultConfiguration != nil {
+		status.OutputLocation = aws.ToString(execution.ResultConfiguration.OutputLocation)
+	}
+	return status, nil
+}
+
+// GetQueryResults returns up to maxRows rows of a finished query, starting at
+// token (empty for the first page). The header row Athena puts first on the
+// first page of SELECT results is left out.
+func (s *AthenaService) GetQueryResults(ctx context.Context, id, token string, maxRows int32) (QueryResultPage, error) {
+	if s == nil || s.client == nil {
+		return QueryResultPage{}, fmt.Errorf("Athena service not initialized")
+	}
+
+	input := &athena.GetQueryResultsInput{
+		QueryExecutionId: aws.String(id),
+		MaxResults:       aws.Int32(maxRows),
+	}
+	if token != "" {
+		input.NextToken = aws.String(token)
+	}
+
+	output, err := s.client.GetQueryResults(ctx, input)
+	if err != nil {
+		return QueryResultPage{}, fmt.Errorf("failed to get results of query %s: %w", id, err)
+	}
+
+	page := QueryResultPage{NextToken: aws.ToString(output.NextToken)}
+	if output.ResultSet == nil {
+		return page, nil
+	}
+	if metadata := output.ResultSet.ResultSetMetadata; metadata != nil {
+		for _, column := range metadata.ColumnInfo {
+			page.Columns = append(page.Columns, aws.ToString(column.Name))
+		}
+	}
+	for i, row := range output.ResultSet.Rows {
+		values := make([]string, len(row.Data))
+		for j, datum := range row.Data {
+			values[j] = aws.ToString(datum.VarCharValue)
+		}
+		if i == 0 && token == "" && isHeader(values, page.Columns) {
+			continue
+		}
+		page.Rows = append(page.Rows, values)
+	}
+	return page, nil
+}
+
+// isHeader reports whether values are the column names
+func isHeader(values, columns []string) bool {
+	if len(values) != len(columns) || len(values) == 0 {
+		return false
+	}
+	for i := range values {
+		if values[i] != columns[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// StopQuery cancels the query execution id
+func (s *AthenaService) StopQuery(ctx context.Context, id string) error {
+	if s == nil || s.client == nil {
+		return fmt.Errorf("Athena service not initialized")
+	}
+
+	if _, err := s.client.StopQueryExecution(ctx, &athena.StopQueryExecutionInput{
+		QueryExecutionId: aws.String(id),
+	}); err != nil {
+		return fmt.Errorf("failed to stop query %s: %w", id, err)
+	}
+	return nil
+}
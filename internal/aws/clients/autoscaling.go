@@ -0,0 +1,127 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling/types"
+)
+
+// ScalableTarget is a dimension of a resource that Application Auto Scaling
+// scales, e.g. the read capacity of a DynamoDB table, with its policies
+type ScalableTarget struct {
+	// ResourceID names the resource, e.g. table/orders or
+	// table/orders/index/by-customer
+	ResourceID string
+	// Dimension is e.g. dynamodb:table:ReadCapacityUnits
+	Dimension string
+	Min       int32
+	Max       int32
+	Policies  []ScalingPolicy
+}
+
+// ScalingPolicy is a scaling policy of a ScalableTarget
+type ScalingPolicy struct {
+	Name string
+	// Type is TargetTrackingScaling, StepScaling or PredictiveScaling
+	Type string
+	// TargetValue is the utilization a target tracking policy aims for, in
+	// percent for the DynamoDB utilization metrics
+	TargetValue float64
+	// Metric is the predefined metric a target tracking policy tracks
+	Metric           string
+	ScaleInCooldown  int32
+	ScaleOutCooldown int32
+	ScaleInDisabled  bool
+}
+
+// ApplicationAutoScalingService wraps the Application Auto Scaling client
+type ApplicationAutoScalingService struct {
+	client *applicationautoscaling.Client
+}
+
+// NewApplicationAutoScalingService creates a new Application Auto Scaling
+// service wrapper
+func NewApplicationAutoScalingService(client *applicationautoscaling.Client) (*ApplicationAutoScalingService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("Application Auto Scaling client not provided")
+	}
+
+	return &ApplicationAutoScalingService{
+		client: client,
+	}, nil
+}
+
+// GetTableScaling returns the scalable targets of the DynamoDB table and the
+// given global secondary indexes of it, with their policies
+func (s *ApplicationAutoScalingService) GetTableScaling(ctx context.Context, tableName string, indexes []string) ([]ScalableTarget, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("Application Auto Scaling service not initialized")
+	}
+
+	resources := []string{"table/" + tableName}
+	for _, index := range indexes {
+		resources = append(resources, "table/"+tableName+"/index/"+index)
+	}
+
+	var targets []ScalableTarget
+	paginator := applicationautoscaling.NewDescribeScalableTargetsPaginator(s.client, &applicationautoscaling.DescribeScalableTargetsInput{
+		ServiceNamespace: types.ServiceNamespaceDynamodb,
+		ResourceIds:      resources,
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe scalable targets of table %s: %w", tableName, err)
+		}
+		for _, target := range output.ScalableTargets {
+			targets = append(targets, ScalableTarget{
+				ResourceID: aws.ToString(target.ResourceId),
+				Dimension:  string(target.ScalableDimension),
+				Min:        aws.ToInt32(target.MinCapacity),
+				Max:        aws.ToInt32(target.MaxCapacity),
+			})
+		}
+	}
+
+	// Scaling policies can only be filtered by a single resource
+	for _, resource := range resources {
+		policies := applicationautoscaling.NewDescribeScalingPoliciesPaginator(s.client, &applicationautoscaling.DescribeScalingPoliciesInput{
+			ServiceNamespace: types.ServiceNamespaceDynamodb,
+			ResourceId:       aws.String(resource),
+		})
+		for policies.HasMorePages() {
+			output, err := policies.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe scaling policies of %s: %w", resource, err)
+			}
+			for _, policy := range output.ScalingPolicies {
+				for i := range targets {
+					if targets[i].ResourceID == resource && targets[i].Dimension == string(policy.ScalableDimension) {
+						targets[i].Policies = append(targets[i].Policies, toScalingPolicy(policy))
+					}
+				}
+			}
+		}
+	}
+	return targets, nil
+}
+
+func toScalingPolicy(policy types.ScalingPolicy) ScalingPolicy {
+	result := ScalingPolicy{
+		Name: aws.ToString(policy.PolicyName),
+		Type: string(policy.PolicyType),
+	}
+	if config := policy.TargetTrackingScalingPolicyConfiguration; config != nil {
+		result.TargetValue = aws.ToFloat64(config.TargetValue)
+		result.ScaleInCooldown = aws.ToInt32(config.ScaleInCooldown)
+		result.ScaleOutCooldown = aws.ToInt32(config.ScaleOutCooldown)
+		result.ScaleInDisabled = aws.ToBool(config.DisableScaleIn)
+		if metric := config.PredefinedMetricSpecification; metric != nil {
+			result.Metric = string(metric.PredefinedMetricType)
+		}
+	}
+	return result
+}
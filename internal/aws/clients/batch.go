@@ -0,0 +1,334 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+const (
+	// batchDescribeBatch is how many jobs DescribeJobs accepts at once
+	batchDescribeBatch = 100
+	// BatchDefaultLogGroup is the log group of jobs that log to CloudWatch
+	// without naming a group
+	BatchDefaultLogGroup = "/aws/batch/job"
+)
+
+// BatchJobQueue is an AWS Batch job queue with the compute environments it
+// places jobs in, in order
+type BatchJobQueue struct {
+	Name string
+	ARN  string
+	// State is ENABLED or DISABLED, Status VALID, INVALID or one of the
+	// states of a change such as UPDATING
+	State        string
+	Status       string
+	StatusReason string
+	Priority     int
+	// ComputeEnvironments are the ARNs of the compute environments in the
+	// order jobs are placed in them
+	ComputeEnvironments []string
+}
+
+// BatchComputeEnvironment is an AWS Batch compute environment
+type BatchComputeEnvironment struct {
+	Name string
+	ARN  string
+	// Type is MANAGED or UNMANAGED
+	Type         string
+	State        string
+	Status       string
+	StatusReason string
+	// ResourceType is EC2, SPOT, FARGATE or FARGATE_SPOT
+	ResourceType string
+	MinVCPUs     int
+	MaxVCPUs     int
+	DesiredVCPUs int
+}
+
+// BatchJob is an AWS Batch job with where its container logs to
+type BatchJob struct {
+	ID   string
+	ARN  string
+	Name string
+	// Queue is the ARN of the job queue
+	Queue string
+	// Status is SUBMITTED, PENDING, RUNNABLE, STARTING, RUNNING, SUCCEEDED
+	// or FAILED
+	Status       string
+	StatusReason string
+	Definition   string
+	Image        string
+	// ExitCode is the exit code of the container, nil until it exits
+	ExitCode *int
+	// Reason explains why the container exited
+	Reason    string
+	Attempts  int
+	CreatedAt time.Time
+	StartedAt time.Time
+	StoppedAt time.Time
+	// LogGroup and LogStream are where the container logs to in CloudWatch,
+	// LogStream empty until the container starts or if it logs elsewhere
+	LogGroup  string
+	LogStream string
+}
+
+// BatchService reads AWS Batch job queues, compute environments and jobs,
+// and terminates jobs
+type BatchService struct {
+	*restJSONAPI
+}
+
+// NewBatchService creates a new AWS Batch service for the region and
+// credentials of cfg
+func NewBatchService(cfg aws.Config) (*BatchService, error) {
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("Batch credentials not provided")
+	}
+	return &BatchService{
+		restJSONAPI: newRestJSONAPI(cfg, "Batch", regionalEndpoint("batch", cfg.Region), cfg.Region, "batch"),
+	}, nil
+}
+
+// ListJobQueues returns the job queues of the region, highest priority
+// first
+func (s *BatchService) ListJobQueues(ctx context.Context) ([]BatchJobQueue, error) {
+	if s == nil || s.restJSONAPI == nil {
+		return nil, fmt.Errorf("Batch service not initialized")
+	}
+
+	var queues []BatchJobQueue
+	input := map[string]any{"maxResults": 100}
+	for {
+		var output struct {
+			JobQueues []struct {
+				JobQueueName            string `json:"jobQueueName"`
+				JobQueueArn             string `json:"jobQueueArn"`
+				State                   string `json:"state"`
+				Status                  string `json:"status"`
+				StatusReason            string `json:"statusReason"`
+				Priority                int    `json:"priority"`
+				ComputeEnvironmentOrder []struct {
+					Order              int    `json:"order"`
+					ComputeEnvironment string `json:"computeEnvironment"`
+				} `json:"computeEnvironmentOrder"`
+			} `json:"jobQueues"`
+			NextToken string `json:"nextToken"`
+		}
+		if err := s.call(ctx, "DescribeJobQueues", http.MethodPost, "/v1/describejobqueues", input, &output); err != nil {
+			return nil, fmt.Errorf("failed to describe job queues: %w", err)
+		}
+		for _, queue := range output.JobQueues {
+			order := queue.ComputeEnvironmentOrder
+			sort.SliceStable(order, func(i, j int) bool { return order[i].Order < order[j].Order })
+			environments := make([]string, len(order))
+			for i, environment := range order {
+				environments[i] = environment.ComputeEnvironment
+			}
+			queues = append(queues, BatchJobQueue{
+				Name:                queue.JobQueueName,
+				ARN:                 queue.JobQueueArn,
+				State:               queue.State,
+				Status:              queue.Status,
+				StatusReason:        queue.StatusReason,
+				Priority:            queue.Priority,
+				ComputeEnvironments: environments,
+			})
+		}
+		if output.NextToken == "" {
+			break
+		}
+		input["nextToken"] = output.NextToken
+	}
+
+	sort.SliceStable(queues, func(i, j int) bool {
+		if queues[i].Priority != queues[j].Priority {
+			return queues[i].Priority > queues[j].Priority
+		}
+		return queues[i].Name < queues[j].Name
+	})
+	return queues, nil
+}
+
+// ListComputeEnvironments returns the compute environments of the region
+func (s *BatchService) ListComputeEnvironments(ctx context.Context) ([]BatchComputeEnvironment, error) {
+	if s == nil || s.restJSONAPI == nil {
+		return nil, fmt.Errorf("Batch service not initialized")
+	}
+
+	var environments []BatchComputeEnvironment
+	input := map[string]any{"maxResults": 100}
+	for {
+		var output struct {
+			ComputeEnvironments []struct {
+				ComputeEnvironmentName string `json:"computeEnvironmentName"`
+				ComputeEnvironmentArn  string `json:"computeEnvironmentArn"`
+				Type                   string `json:"type"`
+				State                  string `json:"state"`
+				Status                 string `json:"status"`
+				StatusReason           string `json:"statusReason"`
+				ComputeResources       struct {
+					Type         string `json:"type"`
+					MinvCpus     int    `json:"minvCpus"`
+					MaxvCpus     int    `json:"maxvCpus"`
+					DesiredvCpus int    `json:"desiredvCpus"`
+				} `json:"computeResources"`
+			} `json:"computeEnvironments"`
+			NextToken string `json:"nextToken"`
+		}
+		if err := s.call(ctx, "DescribeComputeEnvironments", http.MethodPost, "/v1/describecomputeenvironments", input, &output); err != nil {
+			return nil, fmt.Errorf("failed to describe compute environments: %w", err)
+		}
+		for _, environment := range output.ComputeEnvironments {
+			environments = append(environments, BatchComputeEnvironment{
+				Name:         environment.ComputeEnvironmentName,
+				ARN:          environment.ComputeEnvironmentArn,
+				Type:         environment.Type,
+				State:        environment.State,
+				Status:       environment.Status,
+				StatusReason: environment.StatusReason,
+				ResourceType: environment.ComputeResources.Type,
+				MinVCPUs:     environment.ComputeResources.MinvCpus,
+				MaxVCPUs:     environment.ComputeResources.MaxvCpus,
+				DesiredVCPUs: environment.ComputeResources.DesiredvCpus,
+			})
+		}
+		if output.NextToken == "" {
+			break
+		}
+		input["nextToken"] = output.NextToken
+	}
+	return environments, nil
+}
+
+// batchJobDetail is the part of a DescribeJobs result that is shown
+type batchJobDetail struct {
+	JobID         string `json:"jobId"`
+	JobArn        string `json:"jobArn"`
+	JobName       string `json:"jobName"`
+	JobQueue      string `json:"jobQueue"`
+	Status        string `json:"status"`
+	StatusReason  string `json:"statusReason"`
+	JobDefinition string `json:"jobDefinition"`
+	CreatedAt     int64  `json:"createdAt"`
+	StartedAt     int64  `json:"startedAt"`
+	StoppedAt     int64  `json:"stoppedAt"`
+	Attempts      []struct {
+		StatusReason string `json:"statusReason"`
+	} `json:"attempts"`
+	Container struct {
+		Image            string `json:"image"`
+		ExitCode         *int   `json:"exitCode"`
+		Reason           string `json:"reason"`
+		LogStreamName    string `json:"logStreamName"`
+		LogConfiguration struct {
+			LogDriver string            `json:"logDriver"`
+			Options   map[string]string `json:"options"`
+		} `json:"logConfiguration"`
+	} `json:"container"`
+}
+
+// ListJobs returns the jobs of the job queue queue in status, e.g.
+// RUNNABLE, newest first, with where their containers log to
+func (s *BatchService) ListJobs(ctx context.Context, queue, status string) ([]BatchJob, error) {
+	if s == nil || s.restJSONAPI == nil {
+		return nil, fmt.Errorf("Batch service not initialized")
+	}
+
+	var ids []string
+	input := map[string]any{"jobQueue": queue, "jobStatus": status, "maxResults": 100}
+	for {
+		var output struct {
+			JobSummaryList []struct {
+				JobID string `json:"jobId"`
+			} `json:"jobSummaryList"`
+			NextToken string `json:"nextToken"`
+		}
+		if err := s.call(ctx, "ListJobs", http.MethodPost, "/v1/listjobs", input, &output); err != nil {
+			return nil, fmt.Errorf("failed to list the %s jobs of %s: %w", strings.ToLower(status), queue, err)
+		}
+		for _, job := range output.JobSummaryList {
+			ids = append(ids, job.JobID)
+		}
+		if output.NextToken == "" {
+			break
+		}
+		input["nextToken"] = output.NextToken
+	}
+
+	jobs := make([]BatchJob, 0, len(ids))
+	for start := 0; start < len(ids); start += batchDescribeBatch {
+		var output struct {
+			Jobs []batchJobDetail `json:"jobs"`
+		}
+		err := s.call(ctx, "DescribeJobs", http.MethodPost, "/v1/describejobs",
+			map[string]any{"jobs": ids[start:min(start+batchDescribeBatch, len(ids))]}, &output)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe the jobs of %s: %w", queue, err)
+		}
+		for _, job := range output.Jobs {
+			jobs = append(jobs, batchJob(job))
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	return jobs, nil
+}
+
+// batchJob converts a job of DescribeJobs
+func batchJob(job batchJobDetail) BatchJob {
+	converted := BatchJob{
+		ID:           job.JobID,
+		ARN:          job.JobArn,
+		Name:         job.JobName,
+		Queue:        job.JobQueue,
+		Status:       job.Status,
+		StatusReason: job.StatusReason,
+		Definition:   job.JobDefinition,
+		Image:        job.Container.Image,
+		ExitCode:     job.Container.ExitCode,
+		Reason:       job.Container.Reason,
+		Attempts:     len(job.Attempts),
+		CreatedAt:    epochMillis(job.CreatedAt),
+		StartedAt:    epochMillis(job.StartedAt),
+		StoppedAt:    epochMillis(job.StoppedAt),
+		LogStream:    job.Container.LogStreamName,
+	}
+	// Containers log to CloudWatch unless they name another log driver
+	if driver := job.Container.LogConfiguration.LogDriver; driver == "" || driver == "awslogs" {
+		converted.LogGroup = BatchDefaultLogGroup
+		if group := job.Container.LogConfiguration.Options["awslogs-group"]; group != "" {
+			converted.LogGroup = group
+		}
+	} else {
+		converted.LogStream = ""
+	}
+	return converted
+}
+
+// TerminateJob terminates the job with the ID id, giving reason. Jobs that
+// have not started yet are cancelled.
+func (s *BatchService) TerminateJob(ctx context.Context, id, reason string) error {
+	if s == nil || s.restJSONAPI == nil {
+		return fmt.Errorf("Batch service not initialized")
+	}
+	var output struct{}
+	if err := s.call(ctx, "TerminateJob", http.MethodPost, "/v1/terminatejob", map[string]any{"jobId": id, "reason": reason}, &output); err != nil {
+		return fmt.Errorf("failed to terminate job %s: %w", id, err)
+	}
+	return nil
+}
+
+// epochMillis converts the milliseconds since the epoch of a Batch
+// timestamp
+func epochMillis(millis int64) time.Time {
+	if millis == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(millis)
+}
@@ -0,0 +1,108 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestBatchListJobQueues(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/describejobqueues":
+			w.Write([]byte(`{"jobQueues":[
+				{"jobQueueName":"reports","jobQueueArn":"arn:queue/reports","state":"ENABLED","status":"VALID","priority":1,
+				 "computeEnvironmentOrder":[{"order":2,"computeEnvironment":"arn:ce/ondemand"},{"order":1,"computeEnvironment":"arn:ce/spot"}]},
+				{"jobQueueName":"orders","jobQueueArn":"arn:queue/orders","state":"ENABLED","status":"VALID","priority":10}]}`))
+		case "/v1/describecomputeenvironments":
+			w.Write([]byte(`{"computeEnvironments":[{"computeEnvironmentName":"spot","computeEnvironmentArn":"arn:ce/spot","type":"MANAGED","state":"ENABLED","status":"INVALID",
+				"statusReason":"CLIENT_ERROR - not authorized","computeResources":{"type":"FARGATE_SPOT","maxvCpus":64,"desiredvCpus":4}}]}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	})
+	svc, err := NewBatchService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queues, err := svc.ListJobQueues(context.Background())
+	if err != nil {
+		t.Fatalf("ListJobQueues returned error: %v", err)
+	}
+	if len(queues) != 2 || queues[0].Name != "orders" {
+		t.Fatalf("Expected the queues by priority, got %+v", queues)
+	}
+	if ces := queues[1].ComputeEnvironments; len(ces) != 2 || ces[0] != "arn:ce/spot" {
+		t.Errorf("Expected the compute environments in order, got %v", ces)
+	}
+
+	environments, err := svc.ListComputeEnvironments(context.Background())
+	if err != nil {
+		t.Fatalf("ListComputeEnvironments returned error: %v", err)
+	}
+	if len(environments) != 1 || environments[0].ResourceType != "FARGATE_SPOT" || environments[0].MaxVCPUs != 64 || environments[0].Status != "INVALID" {
+		t.Errorf("Unexpected compute environments %+v", environments)
+	}
+}
+
+func TestBatchListJobs(t *testing.T) {
+	var terminated map[string]any
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		var input map[string]any
+		json.NewDecoder(r.Body).Decode(&input)
+
+		switch r.URL.Path {
+		case "/v1/listjobs":
+			if input["jobQueue"] != "orders" || input["jobStatus"] != "FAILED" {
+				t.Errorf("Unexpected input %v", input)
+			}
+			if input["nextToken"] == nil {
+				w.Write([]byte(`{"jobSummaryList":[{"jobId":"job-1"}],"nextToken":"p2"}`))
+				return
+			}
+			w.Write([]byte(`{"jobSummaryList":[{"jobId":"job-2"}]}`))
+		case "/v1/describejobs":
+			if ids, _ := input["jobs"].([]any); len(ids) != 2 {
+				t.Errorf("Expected both jobs in one call, got %v", input["jobs"])
+			}
+			w.Write([]byte(`{"jobs":[
+				{"jobId":"job-1","jobName":"invoice","status":"FAILED","createdAt":1700000000000,"attempts":[{},{}],
+				 "container":{"exitCode":137,"reason":"OutOfMemoryError","logStreamName":"invoice/default/abc"}},
+				{"jobId":"job-2","jobName":"export","status":"FAILED","createdAt":1700000100000,
+				 "container":{"logStreamName":"export/default/def","logConfiguration":{"logDriver":"awslogs","options":{"awslogs-group":"/shop/batch"}}}}]}`))
+		case "/v1/terminatejob":
+			terminated = input
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	})
+	svc, err := NewBatchService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := svc.ListJobs(context.Background(), "orders", "FAILED")
+	if err != nil {
+		t.Fatalf("ListJobs returned error: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].ID != "job-2" {
+		t.Fatalf("Expected the jobs newest first, got %+v", jobs)
+	}
+	if jobs[0].LogGroup != "/shop/batch" || jobs[0].ExitCode != nil {
+		t.Errorf("Unexpected job %+v", jobs[0])
+	}
+	job := jobs[1]
+	if job.LogGroup != BatchDefaultLogGroup || job.LogStream != "invoice/default/abc" || job.ExitCode == nil || *job.ExitCode != 137 || job.Attempts != 2 {
+		t.Errorf("Unexpected job %+v", job)
+	}
+
+	if err := svc.TerminateJob(context.Background(), "job-1", "stuck"); err != nil {
+		t.Fatalf("TerminateJob returned error: %v", err)
+	}
+	if terminated["jobId"] != "job-1" || terminated["reason"] != "stuck" {
+		t.Errorf("Unexpected TerminateJob input %v", terminated)
+	}
+}
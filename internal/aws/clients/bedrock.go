@@ -0,0 +1,185 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// BedrockModel is a foundation model offered by Amazon Bedrock in a region
+type BedrockModel struct {
+	ID       string
+	Name     string
+	Provider string
+	// InputModalities and OutputModalities are TEXT, IMAGE or EMBEDDING
+	InputModalities  []string
+	OutputModalities []string
+	Streaming        bool
+	// InferenceTypes are ON_DEMAND and PROVISIONED; models without
+	// ON_DEMAND can only be invoked through provisioned throughput or an
+	// inference profile
+	InferenceTypes []string
+	// Lifecycle is ACTIVE or LEGACY
+	Lifecycle string
+}
+
+// OnDemand reports whether the model can be invoked by its ID, paying per
+// token
+func (m BedrockModel) OnDemand() bool {
+	for _, inference := range m.InferenceTypes {
+		if inference == "ON_DEMAND" {
+			return true
+		}
+	}
+	return false
+}
+
+// Chat reports whether the model answers text with text
+func (m BedrockModel) Chat() bool {
+	return hasModality(m.InputModalities, "TEXT") && hasModality(m.OutputModalities, "TEXT")
+}
+
+// hasModality reports whether modalities include modality
+func hasModality(modalities []string, modality string) bool {
+	for _, m := range modalities {
+		if m == modality {
+			return true
+		}
+	}
+	return false
+}
+
+// BedrockReply is the answer of a model to a prompt
+type BedrockReply struct {
+	Text string
+	// StopReason is end_turn, max_tokens, stop_sequence,
+	// guardrail_intervened or content_filtered
+	StopReason   string
+	InputTokens  int
+	OutputTokens int
+	Latency      time.Duration
+}
+
+// BedrockService lists the foundation models of Amazon Bedrock and sends
+// them prompts through the Converse API of the Bedrock runtime
+type BedrockService struct {
+	api     *restJSONAPI
+	runtime *restJSONAPI
+}
+
+// NewBedrockService creates a new Bedrock service for the region and
+// credentials of cfg
+func NewBedrockService(cfg aws.Config) (*BedrockService, error) {
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("Bedrock credentials not provided")
+	}
+	return &BedrockService{
+		api:     newRestJSONAPI(cfg, "Bedrock", regionalEndpoint("bedrock", cfg.Region), cfg.Region, "bedrock"),
+		runtime: newRestJSONAPI(cfg, "Bedrock Runtime", regionalEndpoint("bedrock-runtime", cfg.Region), cfg.Region, "bedrock"),
+	}, nil
+}
+
+// ListFoundationModels returns the foundation models of the region, sorted
+// by provider and name
+func (s *BedrockService) ListFoundationModels(ctx context.Context) ([]BedrockModel, error) {
+	if s == nil || s.api == nil {
+		return nil, fmt.Errorf("Bedrock service not initialized")
+	}
+
+	var output struct {
+		ModelSummaries []struct {
+			ModelID                    string   `json:"modelId"`
+			ModelName                  string   `json:"modelName"`
+			ProviderName               string   `json:"providerName"`
+			InputModalities            []string `json:"inputModalities"`
+			OutputModalities           []string `json:"outputModalities"`
+			ResponseStreamingSupported bool     `json:"responseStreamingSupported"`
+			InferenceTypesSupported    []string `json:"inferenceTypesSupported"`
+			ModelLifecycle             struct {
+				Status string `json:"status"`
+			} `json:"modelLifecycle"`
+		} `json:"modelSummaries"`
+	}
+	if err := s.api.call(ctx, "ListFoundationModels", http.MethodGet, "/foundation-models", nil, &output); err != nil {
+		return nil, fmt.Errorf("failed to list foundation models: %w", err)
+	}
+
+	models := make([]BedrockModel, 0, len(output.ModelSummaries))
+	for _, model := range output.ModelSummaries {
+		models = append(models, BedrockModel{
+			ID:               model.ModelID,
+			Name:             model.ModelName,
+			Provider:         model.ProviderName,
+			InputModalities:  model.InputModalities,
+			OutputModalities: model.OutputModalities,
+			Streaming:        model.ResponseStreamingSupported,
+			InferenceTypes:   model.InferenceTypesSupported,
+			Lifecycle:        model.ModelLifecycle.Status,
+		})
+	}
+
+	sort.SliceStable(models, func(i, j int) bool {
+		if models[i].Provider != models[j].Provider {
+			return models[i].Provider < models[j].Provider
+		}
+		return models[i].Name < models[j].Name
+	})
+	return models, nil
+}
+
+// Converse sends prompt to the model modelID as a single user message and
+// returns its answer, of at most maxTokens tokens
+func (s *BedrockService) Converse(ctx context.Context, modelID, prompt string, maxTokens int) (BedrockReply, error) {
+	if s == nil || s.runtime == nil {
+		return BedrockReply{}, fmt.Errorf("Bedrock service not initialized")
+	}
+
+	type content struct {
+		Text string `json:"text"`
+	}
+	input := map[string]any{
+		"messages": []map[string]any{
+			{"role": "user", "content": []content{{Text: prompt}}},
+		},
+		"inferenceConfig": map[string]any{"maxTokens": maxTokens},
+	}
+	var output struct {
+		Output struct {
+			Message struct {
+				Content []content `json:"content"`
+			} `json:"message"`
+		} `json:"output"`
+		StopReason string `json:"stopReason"`
+		Usage      struct {
+			InputTokens  int `json:"inputTokens"`
+			OutputTokens int `json:"outputTokens"`
+		} `json:"usage"`
+		Metrics struct {
+			LatencyMs int64 `json:"latencyMs"`
+		} `json:"metrics"`
+	}
+	path := "/model/" + url.PathEscape(modelID) + "/converse"
+	if err := s.runtime.call(ctx, "Converse", http.MethodPost, path, input, &output); err != nil {
+		return BedrockReply{}, fmt.Errorf("failed to invoke %s: %w", modelID, err)
+	}
+
+	var text []string
+	for _, part := range output.Output.Message.Content {
+		if part.Text != "" {
+			text = append(text, part.Text)
+		}
+	}
+	return BedrockReply{
+		Text:         strings.Join(text, "\n"),
+		StopReason:   output.StopReason,
+		InputTokens:  output.Usage.InputTokens,
+		OutputTokens: output.Usage.OutputTokens,
+		Latency:      time.Duration(output.Metrics.LatencyMs) * time.Millisecond,
+	}, nil
+}
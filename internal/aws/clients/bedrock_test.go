@@ -0,0 +1,88 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBedrockListFoundationModels(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/foundation-models" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if !strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/bedrock/aws4_request") {
+			t.Errorf("Expected a request signed for bedrock in eu-west-1, got %q", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`{"modelSummaries":[
+			{"modelId":"amazon.titan-embed-text-v2:0","modelName":"Titan Text Embeddings V2","providerName":"Amazon",
+			 "inputModalities":["TEXT"],"outputModalities":["EMBEDDING"],"inferenceTypesSupported":["ON_DEMAND"],"modelLifecycle":{"status":"ACTIVE"}},
+			{"modelId":"anthropic.claude-3-haiku-20240307-v1:0","modelName":"Claude 3 Haiku","providerName":"Anthropic",
+			 "inputModalities":["TEXT","IMAGE"],"outputModalities":["TEXT"],"responseStreamingSupported":true,
+			 "inferenceTypesSupported":["ON_DEMAND","PROVISIONED"],"modelLifecycle":{"status":"ACTIVE"}},
+			{"modelId":"amazon.nova-pro-v1:0","modelName":"Nova Pro","providerName":"Amazon",
+			 "inputModalities":["TEXT"],"outputModalities":["TEXT"],"inferenceTypesSupported":["INFERENCE_PROFILE"],"modelLifecycle":{"status":"LEGACY"}}]}`))
+	})
+
+	svc, err := NewBedrockService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	models, err := svc.ListFoundationModels(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, model := range models {
+		names = append(names, model.Name)
+	}
+	if strings.Join(names, ",") != "Nova Pro,Titan Text Embeddings V2,Claude 3 Haiku" {
+		t.Fatalf("Expected the models sorted by provider and name, got %v", names)
+	}
+	if haiku := models[2]; !haiku.Chat() || !haiku.OnDemand() || !haiku.Streaming || haiku.Lifecycle != "ACTIVE" {
+		t.Errorf("Expected Claude 3 Haiku to chat on demand, got %+v", haiku)
+	}
+	if models[0].OnDemand() || models[1].Chat() {
+		t.Errorf("Expected Nova Pro to need a profile and Titan to embed only, got %+v", models[:2])
+	}
+}
+
+func TestBedrockConverse(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/model/anthropic.claude-3-haiku-20240307-v1:0/converse" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var input struct {
+			Messages []struct {
+				Role    string `json:"role"`
+				Content []struct {
+					Text string `json:"text"`
+				} `json:"content"`
+			} `json:"messages"`
+			InferenceConfig struct {
+				MaxTokens int `json:"maxTokens"`
+			} `json:"inferenceConfig"`
+		}
+		json.NewDecoder(r.Body).Decode(&input)
+		if len(input.Messages) != 1 || input.Messages[0].Role != "user" || input.Messages[0].Content[0].Text != "Name a color" || input.InferenceConfig.MaxTokens != 64 {
+			t.Errorf("Unexpected input %+v", input)
+		}
+		w.Write([]byte(`{"output":{"message":{"role":"assistant","content":[{"text":"Teal."}]}},"stopReason":"end_turn",
+			"usage":{"inputTokens":11,"outputTokens":4,"totalTokens":15},"metrics":{"latencyMs":412}}`))
+	})
+
+	svc, err := NewBedrockService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply, err := svc.Converse(context.Background(), "anthropic.claude-3-haiku-20240307-v1:0", "Name a color", 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Text != "Teal." || reply.StopReason != "end_turn" || reply.InputTokens != 11 || reply.OutputTokens != 4 || reply.Latency != 412*time.Millisecond {
+		t.Errorf("Unexpected reply %+v", reply)
+	}
+}
@@ -0,0 +1,429 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// cloudFormationVersion is the version of the CloudFormation Query API
+const cloudFormationVersion = "2010-05-15"
+
+// driftDetectionPoll is how often a drift detection is checked while
+// CloudFormation runs it
+var driftDetectionPoll = 3 * time.Second
+
+// Stack is a CloudFormation stack and what the last drift detection found
+type Stack struct {
+	Name        string
+	ID          string
+	Status      string
+	Reason      string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// DriftStatus is DRIFTED, IN_SYNC, UNKNOWN or NOT_CHECKED as of
+	// DriftCheckedAt, zero if drift was never detected
+	DriftStatus    string
+	DriftCheckedAt time.Time
+	// ParentID and RootID are the IDs of the stack a nested stack belongs to
+	// and of the top stack of its tree, empty for stacks that are not nested
+	ParentID string
+	RootID   string
+}
+
+// StackResource is a resource of a stack
+type StackResource struct {
+	LogicalID  string
+	PhysicalID string
+	Type       string
+	Status     string
+	Reason     string
+	// DriftStatus is what the last drift detection of the stack found of
+	// the resource, NOT_CHECKED if it was never detected
+	DriftStatus string
+	UpdatedAt   time.Time
+}
+
+// Nested reports whether the resource is a nested stack, whose physical ID
+// is the ID of that stack
+func (r StackResource) Nested() bool {
+	return r.Type == "AWS::CloudFormation::Stack"
+}
+
+// StackSet is a CloudFormation StackSet, which deploys a template as stack
+// instances to accounts and regions
+type StackSet struct {
+	Name        string
+	ID          string
+	Description string
+	Status      string
+	// PermissionModel is SELF_MANAGED or SERVICE_MANAGED, where
+	// Organizations deploys to the accounts of organizational units
+	PermissionModel string
+	DriftStatus     string
+	DriftCheckedAt  time.Time
+}
+
+// StackInstance is the stack of a StackSet in an account and region
+type StackInstance struct {
+	Account string
+	Region  string
+	// StackID is empty until the stack was created
+	StackID string
+	// Status is CURRENT, OUTDATED or INOPERABLE
+	Status string
+	// DetailedStatus is how the last operation on the instance went, e.g.
+	// SUCCEEDED, FAILED or PENDING
+	DetailedStatus     string
+	Reason             string
+	OrganizationalUnit string
+	DriftStatus        string
+}
+
+// DriftDetection is the outcome of a drift detection of a stack
+type DriftDetection struct {
+	ID string
+	// Status is DETECTION_COMPLETE or DETECTION_FAILED; a failed detection
+	// may still have checked some resources
+	Status string
+	Reason string
+	// StackDriftStatus is DRIFTED, IN_SYNC or UNKNOWN
+	StackDriftStatus string
+	DriftedResources int
+	Timestamp        time.Time
+}
+
+// ResourceDrift is how a resource of a stack differs from its template
+type ResourceDrift struct {
+	LogicalID  string
+	PhysicalID string
+	Type       string
+	// Status is IN_SYNC, MODIFIED, DELETED or NOT_CHECKED
+	Status      string
+	Differences []PropertyDifference
+	CheckedAt   time.Time
+}
+
+// PropertyDifference is a property of a resource that differs from the
+// template
+type PropertyDifference struct {
+	// Path is the JSON pointer of the property, e.g. /SecurityGroupIngress/0/CidrIp
+	Path     string
+	Expected string
+	Actual   string
+	// Type is ADD, REMOVE or NOT_EQUAL
+	Type string
+}
+
+// CloudFormationService lists stacks and detects their drift with the
+// CloudFormation Query API
+type CloudFormationService struct {
+	*queryAPI
+}
+
+// NewCloudFormationService creates a CloudFormation service in the region of cfg
+func NewCloudFormationService(cfg aws.Config) (*CloudFormationService, error) {
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("CloudFormation credentials not provided")
+	}
+	return &CloudFormationService{
+		queryAPI: newQueryAPI(cfg, "CloudFormation", regionalEndpoint("cloudformation", cfg.Region), cfg.Region, "cloudformation", cloudFormationVersion),
+	}, nil
+}
+
+// cfnStack is a stack in a DescribeStacks response
+type cfnStack struct {
+	StackName         string    `xml:"StackName"`
+	StackID           string    `xml:"StackId"`
+	StackStatus       string    `xml:"StackStatus"`
+	StackStatusReason string    `xml:"StackStatusReason"`
+	Description       string    `xml:"Description"`
+	CreationTime      time.Time `xml:"CreationTime"`
+	LastUpdatedTime   time.Time `xml:"LastUpdatedTime"`
+	DriftStatus       string    `xml:"DriftInformation>StackDriftStatus"`
+	DriftCheckedAt    time.Time `xml:"DriftInformation>LastCheckTimestamp"`
+	ParentID          string    `xml:"ParentId"`
+	RootID            string    `xml:"RootId"`
+}
+
+// ListStacks returns the stacks of the region that are not deleted
+func (s *CloudFormationService) ListStacks(ctx context.Context) ([]Stack, error) {
+	if s == nil || s.queryAPI == nil {
+		return nil, fmt.Errorf("CloudFormation service not initialized")
+	}
+
+	var stacks []Stack
+	params := url.Values{}
+	for {
+		var output struct {
+			Stacks    []cfnStack `xml:"DescribeStacksResult>Stacks>member"`
+			NextToken string     `xml:"DescribeStacksResult>NextToken"`
+		}
+		if err := s.call(ctx, "DescribeStacks", params, &output); err != nil {
+			return nil, fmt.Errorf("failed to describe stacks: %w", err)
+		}
+		for _, stack := range output.Stacks {
+			stacks = append(stacks, Stack{
+				Name:           stack.StackName,
+				ID:             stack.StackID,
+				Status:         stack.StackStatus,
+				Reason:         stack.StackStatusReason,
+				Description:    stack.Description,
+				CreatedAt:      stack.CreationTime,
+				UpdatedAt:      stack.LastUpdatedTime,
+				DriftStatus:    stack.DriftStatus,
+				DriftCheckedAt: stack.DriftCheckedAt,
+				ParentID:       stack.ParentID,
+				RootID:         stack.RootID,
+			})
+		}
+		if output.NextToken == "" {
+			return stacks, nil
+		}
+		params.Set("NextToken", output.NextToken)
+	}
+}
+
+// ListStackResources returns the resources of stack, a name or ID
+func (s *CloudFormationService) ListStackResources(ctx context.Context, stack string) ([]StackResource, error) {
+	if s == nil || s.queryAPI == nil {
+		return nil, fmt.Errorf("CloudFormation service not initialized")
+	}
+
+	var resources []StackResource
+	params := url.Values{"StackName": {stack}}
+	for {
+		var output struct {
+			Resources []struct {
+				LogicalID   string    `xml:"LogicalResourceId"`
+				PhysicalID  string    `xml:"PhysicalResourceId"`
+				Type        string    `xml:"ResourceType"`
+				Status      string    `xml:"ResourceStatus"`
+				Reason      string    `xml:"ResourceStatusReason"`
+				DriftStatus string    `xml:"DriftInformation>StackResourceDriftStatus"`
+				UpdatedAt   time.Time `xml:"LastUpdatedTimestamp"`
+			} `xml:"ListStackResourcesResult>StackResourceSummaries>member"`
+			NextToken string `xml:"ListStackResourcesResult>NextToken"`
+		}
+		if err := s.call(ctx, "ListStackResources", params, &output); err != nil {
+			return nil, fmt.Errorf("failed to list resources of stack %s: %w", stack, err)
+		}
+		for _, r := range output.Resources {
+			resources = append(resources, StackResource(r))
+		}
+		if output.NextToken == "" {
+			return resources, nil
+		}
+		params.Set("NextToken", output.NextToken)
+	}
+}
+
+// ListStackSets returns the active StackSets administered from the account
+func (s *CloudFormationService) ListStackSets(ctx context.Context) ([]StackSet, error) {
+	if s == nil || s.queryAPI == nil {
+		return nil, fmt.Errorf("CloudFormation service not initialized")
+	}
+
+	var sets []StackSet
+	params := url.Values{"Status": {"ACTIVE"}}
+	for {
+		var output struct {
+			Sets []struct {
+				Name            string    `xml:"StackSetName"`
+				ID              string    `xml:"StackSetId"`
+				Description     string    `xml:"Description"`
+				Status          string    `xml:"Status"`
+				PermissionModel string    `xml:"PermissionModel"`
+				DriftStatus     string    `xml:"DriftStatus"`
+				DriftCheckedAt  time.Time `xml:"LastDriftCheckTimestamp"`
+			} `xml:"ListStackSetsResult>Summaries>member"`
+			NextToken string `xml:"ListStackSetsResult>NextToken"`
+		}
+		if err := s.call(ctx, "ListStackSets", params, &output); err != nil {
+			return nil, fmt.Errorf("failed to list StackSets: %w", err)
+		}
+		for _, set := range output.Sets {
+			sets = append(sets, StackSet(set))
+		}
+		if output.NextToken == "" {
+			return sets, nil
+		}
+		params.Set("NextToken", output.NextToken)
+	}
+}
+
+// ListStackInstances returns the stack instances of stackSet in every
+// account and region it deploys to
+func (s *CloudFormationService) ListStackInstances(ctx context.Context, stackSet string) ([]StackInstance, error) {
+	if s == nil || s.queryAPI == nil {
+		return nil, fmt.Errorf("CloudFormation service not initialized")
+	}
+
+	var instances []StackInstance
+	params := url.Values{"StackSetName": {stackSet}}
+	for {
+		var output struct {
+			Instances []struct {
+				Account            string `xml:"Account"`
+				Region             string `xml:"Region"`
+				StackID            string `xml:"StackId"`
+				Status             string `xml:"Status"`
+				DetailedStatus     string `xml:"StackInstanceStatus>DetailedStatus"`
+				Reason             string `xml:"StatusReason"`
+				OrganizationalUnit string `xml:"OrganizationalUnitId"`
+				DriftStatus        string `xml:"DriftStatus"`
+			} `xml:"ListStackInstancesResult>Summaries>member"`
+			NextToken string `xml:"ListStackInstancesResult>NextToken"`
+		}
+		if err := s.call(ctx, "ListStackInstances", params, &output); err != nil {
+			return nil, fmt.Errorf("failed to list instances of StackSet %s: %w", stackSet, err)
+		}
+		for _, instance := range output.Instances {
+			instances = append(instances, StackInstance(instance))
+		}
+		if output.NextToken == "" {
+			return instances, nil
+		}
+		params.Set("NextToken", output.NextToken)
+	}
+}
+
+// StackNameOf returns the name of the stack with the ID id, e.g. web of
+// arn:aws:cloudformation:eu-west-1:123456789012:stack/web/0a1b2c3d-...; a
+// name is returned as is
+func StackNameOf(id string) string {
+	if !strings.HasPrefix(id, "arn:") {
+		return id
+	}
+	parts := strings.Split(id, "/")
+	if len(parts) < 3 {
+		return id
+	}
+	return parts[1]
+}
+
+// DetectDrift starts a drift detection of stack and returns its ID
+func (s *CloudFormationService) DetectDrift(ctx context.Context, stack string) (string, error) {
+	if s == nil || s.queryAPI == nil {
+		return "", fmt.Errorf("CloudFormation service not initialized")
+	}
+
+	var output struct {
+		ID string `xml:"DetectStackDriftResult>StackDriftDetectionId"`
+	}
+	if err := s.call(ctx, "DetectStackDrift", url.Values{"StackName": {stack}}, &output); err != nil {
+		return "", fmt.Errorf("failed to detect drift of stack %s: %w", stack, err)
+	}
+	return output.ID, nil
+}
+
+// WaitForDriftDetection polls the drift detection id until it completed or
+// failed
+func (s *CloudFormationService) WaitForDriftDetection(ctx context.Context, id string) (DriftDetection, error) {
+	if s == nil || s.queryAPI == nil {
+		return DriftDetection{}, fmt.Errorf("CloudFormation service not initialized")
+	}
+
+	for {
+		var output struct {
+			Status           string    `xml:"DescribeStackDriftDetectionStatusResult>DetectionStatus"`
+			Reason           string    `xml:"DescribeStackDriftDetectionStatusResult>DetectionStatusReason"`
+			StackDriftStatus string    `xml:"DescribeStackDriftDetectionStatusResult>StackDriftStatus"`
+			DriftedResources int       `xml:"DescribeStackDriftDetectionStatusResult>DriftedStackResourceCount"`
+			Timestamp        time.Time `xml:"DescribeStackDriftDetectionStatusResult>Timestamp"`
+		}
+		params := url.Values{"StackDriftDetectionId": {id}}
+		if err := s.call(ctx, "DescribeStackDriftDetectionStatus", params, &output); err != nil {
+			return DriftDetection{}, fmt.Errorf("failed to read drift detection %s: %w", id, err)
+		}
+
+		if output.Status != "DETECTION_IN_PROGRESS" {
+			return DriftDetection{
+				ID:               id,
+				Status:           output.Status,
+				Reason:           output.Reason,
+				StackDriftStatus: output.StackDriftStatus,
+				DriftedResources: output.DriftedResources,
+				Timestamp:        output.Timestamp,
+			}, nil
+		}
+		select {
+		case <-ctx.Done():
+			return DriftDetection{}, ctx.Err()
+		case <-time.After(driftDetectionPoll):
+		}
+	}
+}
+
+// ListResourceDrifts returns what the last drift detection of stack found
+// of its resources, drifted ones first
+func (s *CloudFormationService) ListResourceDrifts(ctx context.Context, stack string) ([]ResourceDrift, error) {
+	if s == nil || s.queryAPI == nil {
+		return nil, fmt.Errorf("CloudFormation service not initialized")
+	}
+
+	var drifts []ResourceDrift
+	params := url.Values{"StackName": {stack}}
+	for {
+		var output struct {
+			Drifts []struct {
+				LogicalID   string    `xml:"LogicalResourceId"`
+				PhysicalID  string    `xml:"PhysicalResourceId"`
+				Type        string    `xml:"ResourceType"`
+				Status      string    `xml:"StackResourceDriftStatus"`
+				Timestamp   time.Time `xml:"Timestamp"`
+				Differences []struct {
+					Path     string `xml:"PropertyPath"`
+					Expected string `xml:"ExpectedValue"`
+					Actual   string `xml:"ActualValue"`
+					Type     string `xml:"DifferenceType"`
+				} `xml:"PropertyDifferences>member"`
+			} `xml:"DescribeStackResourceDriftsResult>StackResourceDrifts>member"`
+			NextToken string `xml:"DescribeStackResourceDriftsResult>NextToken"`
+		}
+		if err := s.call(ctx, "DescribeStackResourceDrifts", params, &output); err != nil {
+			return nil, fmt.Errorf("failed to read resource drifts of stack %s: %w", stack, err)
+		}
+		for _, d := range output.Drifts {
+			drift := ResourceDrift{
+				LogicalID:  d.LogicalID,
+				PhysicalID: d.PhysicalID,
+				Type:       d.Type,
+				Status:     d.Status,
+				CheckedAt:  d.Timestamp,
+			}
+			for _, diff := range d.Differences {
+				drift.Differences = append(drift.Differences, PropertyDifference(diff))
+			}
+			drifts = append(drifts, drift)
+		}
+		if output.NextToken == "" {
+			break
+		}
+		params.Set("NextToken", output.NextToken)
+	}
+
+	SortResourceDrifts(drifts)
+	return drifts, nil
+}
+
+// driftOrder ranks the drift statuses of resources, the most severe first
+var driftOrder = map[string]int{"DELETED": 0, "MODIFIED": 1, "NOT_CHECKED": 2, "IN_SYNC": 3}
+
+// SortResourceDrifts sorts drifts by status, deleted and modified resources
+// first, then by logical ID
+func SortResourceDrifts(drifts []ResourceDrift) {
+	sort.SliceStable(drifts, func(i, j int) bool {
+		a, b := driftOrder[drifts[i].Status], driftOrder[drifts[j].Status]
+		if a != b {
+			return a < b
+		}
+		return drifts[i].LogicalID < drifts[j].LogicalID
+	})
+}
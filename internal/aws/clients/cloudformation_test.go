@@ -0,0 +1,243 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCloudFormationListStacks(t *testing.T) {
+	svc, err := NewCloudFormationService(newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("Action") != "DescribeStacks" {
+			t.Errorf("Unexpected action %q", r.Form.Get("Action"))
+		}
+		if r.Form.Get("NextToken") == "" {
+			w.Write([]byte(`<DescribeStacksResponse><DescribeStacksResult><Stacks><member>
+				<StackName>web</StackName><StackId>arn:aws:cloudformation:eu-west-1:123456789012:stack/web/1</StackId>
+				<StackStatus>UPDATE_COMPLETE</StackStatus><CreationTime>2026-01-02T03:04:05Z</CreationTime>
+				<DriftInformation><StackDriftStatus>DRIFTED</StackDriftStatus><LastCheckTimestamp>2026-10-01T00:00:00Z</LastCheckTimestamp></DriftInformation>
+				</member></Stacks><NextToken>t2</NextToken></DescribeStacksResult></DescribeStacksResponse>`))
+			return
+		}
+		w.Write([]byte(`<DescribeStacksResponse><DescribeStacksResult><Stacks><member>
+			<StackName>network</StackName><StackStatus>ROLLBACK_COMPLETE</StackStatus><StackStatusReason>quota exceeded</StackStatusReason>
+			<ParentId>arn:aws:cloudformation:eu-west-1:123456789012:stack/web/1</ParentId><RootId>arn:aws:cloudformation:eu-west-1:123456789012:stack/web/1</RootId>
+			<DriftInformation><StackDriftStatus>NOT_CHECKED</StackDriftStatus></DriftInformation>
+			</member></Stacks></DescribeStacksResult></DescribeStacksResponse>`))
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stacks, err := svc.ListStacks(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stacks) != 2 {
+		t.Fatalf("Expected the stacks of both pages, got %+v", stacks)
+	}
+	web := stacks[0]
+	if web.Name != "web" || web.Status != "UPDATE_COMPLETE" || web.DriftStatus != "DRIFTED" ||
+		!web.DriftCheckedAt.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected stack %+v", web)
+	}
+	if network := stacks[1]; network.Reason != "quota exceeded" || !network.DriftCheckedAt.IsZero() || network.ParentID != web.ID || network.RootID != web.ID {
+		t.Errorf("Unexpected stack %+v", network)
+	}
+}
+
+func TestCloudFormationDetectDrift(t *testing.T) {
+	driftDetectionPoll = 0
+	defer func() { driftDetectionPoll = 3 * time.Second }()
+
+	var polls int
+	svc, err := NewCloudFormationService(newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "DetectStackDrift":
+			if r.Form.Get("StackName") != "web" {
+				t.Errorf("Unexpected stack %q", r.Form.Get("StackName"))
+			}
+			w.Write([]byte(`<DetectStackDriftResponse><DetectStackDriftResult>
+				<StackDriftDetectionId>d-1</StackDriftDetectionId></DetectStackDriftResult></DetectStackDriftResponse>`))
+		case "DescribeStackDriftDetectionStatus":
+			polls++
+			status := "DETECTION_IN_PROGRESS"
+			if polls > 1 {
+				status = "DETECTION_COMPLETE"
+			}
+			fmt.Fprintf(w, `<DescribeStackDriftDetectionStatusResponse><DescribeStackDriftDetectionStatusResult>
+				<DetectionStatus>%s</DetectionStatus><StackDriftStatus>DRIFTED</StackDriftStatus>
+				<DriftedStackResourceCount>2</DriftedStackResourceCount><Timestamp>2026-10-16T08:00:00Z</Timestamp>
+				</DescribeStackDriftDetectionStatusResult></DescribeStackDriftDetectionStatusResponse>`, status)
+		default:
+			t.Errorf("Unexpected action %q", r.Form.Get("Action"))
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := svc.DetectDrift(context.Background(), "web")
+	if err != nil || id != "d-1" {
+		t.Fatalf("DetectDrift = %q, %v", id, err)
+	}
+	detection, err := svc.WaitForDriftDetection(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if polls != 2 {
+		t.Errorf("Expected to poll until the detection completed, polled %d times", polls)
+	}
+	if detection.Status != "DETECTION_COMPLETE" || detection.StackDriftStatus != "DRIFTED" || detection.DriftedResources != 2 {
+		t.Errorf("Unexpected detection %+v", detection)
+	}
+}
+
+func TestCloudFormationListResourceDrifts(t *testing.T) {
+	svc, err := NewCloudFormationService(newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("NextToken") == "" {
+			w.Write([]byte(`<DescribeStackResourceDriftsResponse><DescribeStackResourceDriftsResult><StackResourceDrifts>
+				<member><LogicalResourceId>Queue</LogicalResourceId><ResourceType>AWS::SQS::Queue</ResourceType>
+					<StackResourceDriftStatus>IN_SYNC</StackResourceDriftStatus></member>
+				<member><LogicalResourceId>Group</LogicalResourceId><PhysicalResourceId>sg-1</PhysicalResourceId>
+					<ResourceType>AWS::EC2::SecurityGroup</ResourceType><StackResourceDriftStatus>MODIFIED</StackResourceDriftStatus>
+					<PropertyDifferences><member><PropertyPath>/SecurityGroupIngress/0/CidrIp</PropertyPath>
+						<ExpectedValue>10.0.0.0/16</ExpectedValue><ActualValue>0.0.0.0/0</ActualValue><DifferenceType>NOT_EQUAL</DifferenceType>
+					</member></PropertyDifferences></member>
+				</StackResourceDrifts><NextToken>t2</NextToken></DescribeStackResourceDriftsResult></DescribeStackResourceDriftsResponse>`))
+			return
+		}
+		w.Write([]byte(`<DescribeStackResourceDriftsResponse><DescribeStackResourceDriftsResult><StackResourceDrifts>
+			<member><LogicalResourceId>Parameter</LogicalResourceId><ResourceType>AWS::SSM::Parameter</ResourceType>
+				<StackResourceDriftStatus>DELETED</StackResourceDriftStatus></member>
+			</StackResourceDrifts></DescribeStackResourceDriftsResult></DescribeStackResourceDriftsResponse>`))
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	drifts, err := svc.ListResourceDrifts(context.Background(), "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, drift := range drifts {
+		order = append(order, drift.LogicalID)
+	}
+	if fmt.Sprint(order) != "[Parameter Group Queue]" {
+		t.Errorf("Expected deleted, modified, then resources in sync, got %v", order)
+	}
+	group := drifts[1]
+	if len(group.Differences) != 1 {
+		t.Fatalf("Expected the difference of the group, got %+v", group)
+	}
+	want := PropertyDifference{Path: "/SecurityGroupIngress/0/CidrIp", Expected: "10.0.0.0/16", Actual: "0.0.0.0/0", Type: "NOT_EQUAL"}
+	if group.Differences[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, group.Differences[0])
+	}
+}
+
+func TestCloudFormationListStackResources(t *testing.T) {
+	svc, err := NewCloudFormationService(newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("Action") != "ListStackResources" || r.Form.Get("StackName") != "platform" {
+			t.Errorf("Unexpected request %v", r.Form)
+		}
+		if r.Form.Get("NextToken") == "" {
+			w.Write([]byte(`<ListStackResourcesResponse><ListStackResourcesResult><StackResourceSummaries><member>
+				<LogicalResourceId>WebStack</LogicalResourceId><PhysicalResourceId>arn:aws:cloudformation:eu-west-1:123456789012:stack/platform-WebStack-1ABC/2</PhysicalResourceId>
+				<ResourceType>AWS::CloudFormation::Stack</ResourceType><ResourceStatus>UPDATE_COMPLETE</ResourceStatus>
+				<LastUpdatedTimestamp>2026-10-01T00:00:00Z</LastUpdatedTimestamp>
+				<DriftInformation><StackResourceDriftStatus>IN_SYNC</StackResourceDriftStatus></DriftInformation>
+				</member></StackResourceSummaries><NextToken>t2</NextToken></ListStackResourcesResult></ListStackResourcesResponse>`))
+			return
+		}
+		w.Write([]byte(`<ListStackResourcesResponse><ListStackResourcesResult><StackResourceSummaries><member>
+			<LogicalResourceId>Bucket</LogicalResourceId><PhysicalResourceId>platform-bucket</PhysicalResourceId>
+			<ResourceType>AWS::S3::Bucket</ResourceType><ResourceStatus>UPDATE_FAILED</ResourceStatus><ResourceStatusReason>access denied</ResourceStatusReason>
+			<DriftInformation><StackResourceDriftStatus>NOT_CHECKED</StackResourceDriftStatus></DriftInformation>
+			</member></StackResourceSummaries></ListStackResourcesResult></ListStackResourcesResponse>`))
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resources, err := svc.ListStackResources(context.Background(), "platform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("Expected the resources of both pages, got %+v", resources)
+	}
+	if web := resources[0]; !web.Nested() || StackNameOf(web.PhysicalID) != "platform-WebStack-1ABC" || web.DriftStatus != "IN_SYNC" {
+		t.Errorf("Expected the nested stack, got %+v", web)
+	}
+	if bucket := resources[1]; bucket.Nested() || bucket.Status != "UPDATE_FAILED" || bucket.Reason != "access denied" {
+		t.Errorf("Unexpected resource %+v", bucket)
+	}
+}
+
+func TestCloudFormationListStackSets(t *testing.T) {
+	svc, err := NewCloudFormationService(newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "ListStackSets":
+			if r.Form.Get("Status") != "ACTIVE" {
+				t.Errorf("Expected only active StackSets listed, got %v", r.Form)
+			}
+			w.Write([]byte(`<ListStackSetsResponse><ListStackSetsResult><Summaries><member>
+				<StackSetName>baseline</StackSetName><StackSetId>baseline:1</StackSetId><Status>ACTIVE</Status>
+				<PermissionModel>SERVICE_MANAGED</PermissionModel><DriftStatus>DRIFTED</DriftStatus>
+				</member></Summaries></ListStackSetsResult></ListStackSetsResponse>`))
+		case "ListStackInstances":
+			if r.Form.Get("StackSetName") != "baseline" {
+				t.Errorf("Unexpected StackSet %q", r.Form.Get("StackSetName"))
+			}
+			w.Write([]byte(`<ListStackInstancesResponse><ListStackInstancesResult><Summaries>
+				<member><Account>111111111111</Account><Region>eu-west-1</Region><Status>CURRENT</Status>
+					<StackId>arn:aws:cloudformation:eu-west-1:111111111111:stack/StackSet-baseline-1/2</StackId>
+					<StackInstanceStatus><DetailedStatus>SUCCEEDED</DetailedStatus></StackInstanceStatus><DriftStatus>IN_SYNC</DriftStatus></member>
+				<member><Account>222222222222</Account><Region>eu-west-1</Region><Status>OUTDATED</Status><StatusReason>bucket exists</StatusReason>
+					<StackInstanceStatus><DetailedStatus>FAILED</DetailedStatus></StackInstanceStatus><OrganizationalUnitId>ou-1</OrganizationalUnitId></member>
+				</Summaries></ListStackInstancesResult></ListStackInstancesResponse>`))
+		default:
+			t.Errorf("Unexpected action %q", r.Form.Get("Action"))
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sets, err := svc.ListStackSets(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 1 || sets[0].Name != "baseline" || sets[0].PermissionModel != "SERVICE_MANAGED" || sets[0].DriftStatus != "DRIFTED" {
+		t.Errorf("Unexpected StackSets %+v", sets)
+	}
+
+	instances, err := svc.ListStackInstances(context.Background(), "baseline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := StackInstance{Account: "222222222222", Region: "eu-west-1", Status: "OUTDATED", DetailedStatus: "FAILED", Reason: "bucket exists", OrganizationalUnit: "ou-1"}
+	if len(instances) != 2 || instances[0].DetailedStatus != "SUCCEEDED" || instances[1] != want {
+		t.Errorf("Unexpected instances %+v", instances)
+	}
+}
+
+func TestStackNameOf(t *testing.T) {
+	for id, want := range map[string]string{
+		"arn:aws:cloudformation:eu-west-1:123456789012:stack/web-Cache-1ABC/0a1b2c3d": "web-Cache-1ABC",
+		"web": "web",
+	} {
+		if got := StackNameOf(id); got != want {
+			t.Errorf("StackNameOf(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// CloudWatchService wraps the CloudWatch client
+type CloudWatchService struct {
+	client *cloudwatch.Client
+}
+
+// AlarmDetail represents the current state of a CloudWatch alarm
+type AlarmDetail struct {
+	Name        string
+	ARN         string
+	Type        string
+	State       string
+	StateReason string
+	UpdatedAt   time.Time
+	Description string
+	// Condition describes when a metric alarm fires, e.g. "AWS/EC2
+	// CPUUtilization Average > 80 for 3 of 3 datapoints of 5 min"
+	Condition string
+	// Rule is the rule expression of a composite alarm
+	Rule           string
+	ActionsEnabled bool
+}
+
+// AlarmTransition is a change of the state of an alarm
+type AlarmTransition struct {
+	At     time.Time
+	From   string
+	To     string
+	Reason string
+}
+
+// alarmComparisons are the operators of the comparisons of metric alarms
+var alarmComparisons = map[types.ComparisonOperator]string{
+	types.ComparisonOperatorGreaterThanOrEqualToThreshold:            ">=",
+	types.ComparisonOperatorGreaterThanThreshold:                     ">",
+	types.ComparisonOperatorLessThanThreshold:                        "<",
+	types.ComparisonOperatorLessThanOrEqualToThreshold:               "<=",
+	types.ComparisonOperatorLessThanLowerOrGreaterThanUpperThreshold: "outside the band",
+	types.ComparisonOperatorLessThanLowerThreshold:                   "below the band",
+	types.ComparisonOperatorGreaterThanUpperThreshold:                "above the band",
+}
+
+// DashboardDetail describes a CloudWatch dashboard
+type DashboardDetail struct {
+	Name         string
+	ARN          string
+	Size         int64
+	LastModified time.Time
+}
+
+// MetricDimension is a name/value pair that identifies a metric
+type MetricDimension struct {
+	Name  string
+	Value string
+}
+
+// MetricQuery selects a statistic of a metric, e.g. the average
+// CPUUtilization of an instance per 5 minutes
+type MetricQuery struct {
+	Namespace  string
+	Metric     string
+	Dimensions []MetricDimension
+	Stat       string
+	// Period in seconds
+	Period int32
+}
+
+// MetricSeries holds the datapoints of a MetricQuery, oldest first
+type MetricSeries struct {
+	Timestamps []time.Time
+	Values     []float64
+}
+
+// NewCloudWatchService creates a new CloudWatch service wrapper
+func NewCloudWatchService(client *cloudwatch.Client) (*CloudWatchService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("CloudWatch client not provided")
+	}
+
+	return &CloudWatchService{
+		client: client,
+	}, nil
+}
+
+// MetricSum returns the sum of a metric with one dimension between start and
+// end, e.g. the invocations of a Lambda function. Datapoints are summed per
+// day, so the range may span up to 1440 days.
+func (s *CloudWatchService) MetricSum(ctx context.Context, namespace, metric, dimension, value string, start, end time.Time) (float64, error) {
+	if s == nil || s.client == nil {
+		return 0, fmt.Errorf("CloudWatch service not initialized")
+	}
+
+	output, err := s.client.GetMetricStatistics(ctx, &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String(namespace),
+		MetricName: aws.String(metric),
+		Dimensions: []types.Dimension{{Name: aws.String(dimension), Value: aws.String(value)}},
+		StartTime:  aws.Time(start),
+		EndTime:    aws.Time(end),
+		Period:     aws.Int32(86400),
+		Statistics: []types.Statistic{types.StatisticSum},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s statistics: %w", metric, err)
+	}
+
+	var sum float64
+	for _, datapoint := range output.Datapoints {
+		sum += aws.ToFloat64(datapoint.Sum)
+	}
+	return sum, nil
+}
+
+// DescribeAlarms retrieves the metric and composite alarms of the region
+func (s *CloudWatchService) DescribeAlarms(ctx context.Context) ([]AlarmDetail, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("CloudWatch service not initialized")
+	}
+
+	input := &cloudwatch.DescribeAlarmsInput{
+		AlarmTypes: []types.AlarmType{types.AlarmTypeMetricAlarm, types.AlarmTypeCompositeAlarm},
+	}
+
+	var alarms []AlarmDetail
+	paginator := cloudwatch.NewDescribeAlarmsPaginator(s.client, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe alarms: %w", err)
+		}
+
+		for _, alarm := range output.MetricAlarms {
+			alarms = append(alarms, AlarmDetail{
+				Name:           getStringValue(alarm.AlarmName),
+				ARN:            getStringValue(alarm.AlarmArn),
+				Type:           string(types.AlarmTypeMetricAlarm),
+				State:          string(alarm.StateValue),
+				StateReason:    getStringValue(alarm.StateReason),
+				UpdatedAt:      aws.ToTime(alarm.StateUpdatedTimestamp),
+				Description:    getStringValue(alarm.AlarmDescription),
+				Condition:      alarmCondition(alarm),
+				ActionsEnabled: aws.ToBool(alarm.ActionsEnabled),
+			})
+		}
+		for _, alarm := range output.CompositeAlarms {
+			alarms = append(alarms, AlarmDetail{
+				Name:           getStringValue(alarm.AlarmName),
+				ARN:            getStringValue(alarm.AlarmArn),
+				Type:           string(types.AlarmTypeCompositeAlarm),
+				State:          string(alarm.StateValue),
+				StateReason:    getStringValue(alarm.StateReason),
+				UpdatedAt:      aws.ToTime(alarm.StateUpdatedTimestamp),
+				Description:    getStringValue(alarm.AlarmDescription),
+				Rule:           getStringValue(alarm.AlarmRule),
+				ActionsEnabled: aws.ToBool(alarm.ActionsEnabled),
+			})
+		}
+	}
+
+	return alarms, nil
+}
+
+// alarmCondition describes when a metric alarm fires. Alarms on metric math
+// name the expression, alarms on anomaly bands the band.
+func alarmCondition(alarm types.MetricAlarm) string {
+	metric := strings.TrimSpace(getStringValue(alarm.Namespace) + " " + getStringValue(alarm.MetricName))
+	stat := string(alarm.Statistic)
+	if stat == "" {
+		stat = getStringValue(alarm.ExtendedStatistic)
+	}
+	period := aws.ToInt32(alarm.Period)
+	for _, query := range alarm.Metrics {
+		if !aws.ToBool(query.ReturnData) {
+			continue
+		}
+		switch {
+		case query.Expression != nil:
+			metric, stat = getStringValue(query.Expression), ""
+			period = max(period, aws.ToInt32(query.Period))
+		case query.MetricStat != nil && query.MetricStat.Metric != nil:
+			metric = getStringValue(query.MetricStat.Metric.Namespace) + " " + getStringValue(query.MetricStat.Metric.MetricName)
+			stat = getStringValue(query.MetricStat.Stat)
+			period = aws.ToInt32(query.MetricStat.Period)
+		}
+	}
+
+	comparison, ok := alarmComparisons[alarm.ComparisonOperator]
+	if !ok {
+		comparison = string(alarm.ComparisonOperator)
+	}
+	threshold := strconv.FormatFloat(aws.ToFloat64(alarm.Threshold), 'f', -1, 64)
+	if alarm.ThresholdMetricId != nil {
+		threshold = getStringValue(alarm.ThresholdMetricId)
+	}
+	evaluations := aws.ToInt32(alarm.EvaluationPeriods)
+	datapoints := aws.ToInt32(alarm.DatapointsToAlarm)
+	if datapoints == 0 {
+		datapoints = evaluations
+	}
+
+	condition := strings.Join(strings.Fields(strings.Join([]string{metric, stat, comparison, threshold}, " ")), " ")
+	if period%60 == 0 {
+		return fmt.Sprintf("%s for %d of %d datapoints of %d min", condition, datapoints, evaluations, period/60)
+	}
+	return fmt.Sprintf("%s for %d of %d datapoints of %ds", condition, datapoints, evaluations, period)
+}
+
+// AlarmHistory returns the state changes of the alarm name between start and
+// end, oldest first. CloudWatch keeps them for 30 days.
+func (s *CloudWatchService) AlarmHistory(ctx context.Context, name string, start, end time.Time) ([]AlarmTransition, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("CloudWatch service not initialized")
+	}
+
+	input := &cloudwatch.DescribeAlarmHistoryInput{
+		AlarmName:       aws.String(name),
+		AlarmTypes:      []types.AlarmType{types.AlarmTypeMetricAlarm, types.AlarmTypeCompositeAlarm},
+		HistoryItemType: types.HistoryItemTypeStateUpdate,
+		StartDate:       aws.Time(start),
+		EndDate:         aws.Time(end),
+		ScanBy:          types.ScanByTimestampAscending,
+	}
+
+	var transitions []AlarmTransition
+	paginator := cloudwatch.NewDescribeAlarmHistoryPaginator(s.client, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe the history of %s: %w", name, err)
+		}
+		for _, item := range output.AlarmHistoryItems {
+			transitions = append(transitions, alarmTransition(item))
+		}
+	}
+	sort.SliceStable(transitions, func(i, j int) bool { return transitions[i].At.Before(transitions[j].At) })
+	return transitions, nil
+}
+
+// alarmTransition reads the states of a state update from its history data,
+// falling back to its summary, e.g. "Alarm updated from OK to ALARM"
+func alarmTransition(item types.AlarmHistoryItem) AlarmTransition {
+	transition := AlarmTransition{At: aws.ToTime(item.Timestamp)}
+
+	var data struct {
+		OldState struct {
+			StateValue string `json:"stateValue"`
+		} `json:"oldState"`
+		NewState struct {
+			StateValue  string `json:"stateValue"`
+			StateReason string `json:"stateReason"`
+		} `json:"newState"`
+	}
+	if err := json.Unmarshal([]byte(getStringValue(item.HistoryData)), &data); err == nil && data.NewState.StateValue != "" {
+		transition.From = data.OldState.StateValue
+		transition.To = data.NewState.StateValue
+		transition.Reason = data.NewState.StateReason
+		return transition
+	}
+
+	summary := getStringValue(item.HistorySummary)
+	if _, after, ok := strings.Cut(summary, " from "); ok {
+		transition.From, transition.To, _ = strings.Cut(after, " to ")
+	}
+	transition.Reason = summary
+	return transition
+}
+
+// ListDashboards retrieves the dashboards of the account
+func (s *CloudWatchService) ListDashboards(ctx context.Context) ([]DashboardDetail, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("CloudWatch service not initialized")
+	}
+
+	var dashboards []DashboardDetail
+	paginator := cloudwatch.NewListDashboardsPaginator(s.client, &cloudwatch.ListDashboardsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list dashboards: %w", err)
+		}
+
+		for _, entry := range output.DashboardEntries {
+			dashboards = append(dashboards, DashboardDetail{
+				Name:         getStringValue(entry.DashboardName),
+				ARN:          getStringValue(entry.DashboardArn),
+				Size:         aws.ToInt64(entry.Size),
+				LastModified: aws.ToTime(entry.LastModified),
+			})
+		}
+	}
+
+	return dashboards, nil
+}
+
+// GetDashboardBody retrieves the JSON definition of a dashboard
+func (s *CloudWatchService) GetDashboardBody(ctx context.Context, name string) (string, error) {
+	if s == nil || s.client == nil {
+		return "", fmt.Errorf("CloudWatch service not initialized")
+	}
+
+	output, err := s.client.GetDashboard(ctx, &cloudwatch.GetDashboardInput{
+		DashboardName: aws.String(name),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get dashboard %s: %w", name, err)
+	}
+	return getStringValue(output.DashboardBody), nil
+}
+
+// GetMetricSeries retrieves the datapoints of queries between start and end.
+// The series are returned in the order of the queries.
+func (s *CloudWatchService) GetMetricSeries(ctx context.Context, queries []MetricQuery, start, end time.Time) ([]MetricSeries, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("CloudWatch service not initialized")
+	}
+
+	series := make([]MetricSeries, len(queries))
+	// GetMetricData takes at most 500 queries per request
+	for offset := 0; offset < len(queries); offset += 500 {
+		batch := queries[offset:min(offset+500, len(queries))]
+
+		input := &cloudwatch.GetMetricDataInput{
+			StartTime: aws.Time(start),
+			EndTime:   aws.Time(end),
+			ScanBy:    types.ScanByTimestampAscending,
+		}
+		for i, query := range batch {
+			dimensions := make([]types.Dimension, 0, len(query.Dimensions))
+			for _, dimension := range query.Dimensions {
+				dimensions = append(dimensions, types.Dimension{
+					Name:  aws.String(dimension.Name),
+					Value: aws.String(dimension.Value),
+				})
+			}
+			input.MetricDataQueries = append(input.MetricDataQueries, types.MetricDataQuery{
+				Id: aws.String(fmt.Sprintf("m%d", offset+i)),
+				MetricStat: &types.MetricStat{
+					Metric: &types.Metric{
+						Namespace:  aws.String(query.Namespace),
+						MetricName: aws.String(query.Metric),
+						Dimensions: dimensions,
+					},
+					Period: aws.Int32(query.Period),
+					Stat:   aws.String(query.Stat),
+				},
+			})
+		}
+
+		paginator := cloudwatch.NewGetMetricDataPaginator(s.client, input)
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get metric data: %w", err)
+			}
+
+			for _, result := range output.MetricDataResults {
+				var index int
+				if _, err := fmt.Sscanf(getStringValue(result.Id), "m%d", &index); err != nil || index >= len(series) {
+					continue
+				}
+				series[index].Timestamps = append(series[index].Timestamps, result.Timestamps...)
+				series[index].Values = append(series[index].Values, result.Values...)
+			}
+		}
+	}
+
+	return series, nil
+}
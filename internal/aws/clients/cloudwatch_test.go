@@ -0,0 +1,105 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+)
+
+func TestCloudWatchDescribeAlarms(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if action := r.Form.Get("Action"); action != "DescribeAlarms" {
+			t.Errorf("Unexpected action %q", action)
+		}
+		w.Write([]byte(`<DescribeAlarmsResponse><DescribeAlarmsResult>
+			<MetricAlarms>
+				<member><AlarmName>cpu-high</AlarmName><AlarmArn>arn:aws:cloudwatch:us-east-1:1:alarm:cpu-high</AlarmArn>
+					<StateValue>ALARM</StateValue><ActionsEnabled>true</ActionsEnabled>
+					<Namespace>AWS/EC2</Namespace><MetricName>CPUUtilization</MetricName><Statistic>Average</Statistic>
+					<ComparisonOperator>GreaterThanThreshold</ComparisonOperator><Threshold>80</Threshold>
+					<EvaluationPeriods>3</EvaluationPeriods><DatapointsToAlarm>2</DatapointsToAlarm><Period>300</Period></member>
+				<member><AlarmName>errors-rate</AlarmName><StateValue>OK</StateValue>
+					<ComparisonOperator>GreaterThanOrEqualToThreshold</ComparisonOperator><Threshold>0.5</Threshold><EvaluationPeriods>1</EvaluationPeriods>
+					<Metrics>
+						<member><Id>errors</Id><ReturnData>false</ReturnData><MetricStat><Metric><Namespace>AWS/Lambda</Namespace><MetricName>Errors</MetricName></Metric><Period>60</Period><Stat>Sum</Stat></MetricStat></member>
+						<member><Id>rate</Id><ReturnData>true</ReturnData><Expression>errors / invocations</Expression><Period>60</Period></member>
+					</Metrics></member>
+			</MetricAlarms>
+			<CompositeAlarms>
+				<member><AlarmName>service-health</AlarmName><StateValue>ALARM</StateValue>
+					<AlarmRule>ALARM(cpu-high) OR ALARM(errors-rate)</AlarmRule><AlarmDescription>Pages on-call</AlarmDescription></member>
+			</CompositeAlarms>
+		</DescribeAlarmsResult></DescribeAlarmsResponse>`))
+	})
+
+	svc, err := NewCloudWatchService(cloudwatch.NewFromConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	alarms, err := svc.DescribeAlarms(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alarms) != 3 {
+		t.Fatalf("Expected 3 alarms, got %+v", alarms)
+	}
+	if got := alarms[0].Condition; got != "AWS/EC2 CPUUtilization Average > 80 for 2 of 3 datapoints of 5 min" {
+		t.Errorf("Unexpected condition %q", got)
+	}
+	if !alarms[0].ActionsEnabled || alarms[0].ARN == "" {
+		t.Errorf("Expected the ARN and enabled actions, got %+v", alarms[0])
+	}
+	if got := alarms[1].Condition; got != "errors / invocations >= 0.5 for 1 of 1 datapoints of 1 min" {
+		t.Errorf("Unexpected condition of a metric math alarm %q", got)
+	}
+	if alarms[2].Type != "CompositeAlarm" || alarms[2].Rule != "ALARM(cpu-high) OR ALARM(errors-rate)" || alarms[2].Description != "Pages on-call" {
+		t.Errorf("Unexpected composite alarm %+v", alarms[2])
+	}
+}
+
+func TestCloudWatchAlarmHistory(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if action := r.Form.Get("Action"); action != "DescribeAlarmHistory" {
+			t.Errorf("Unexpected action %q", action)
+		}
+		if r.Form.Get("AlarmName") != "cpu-high" || r.Form.Get("HistoryItemType") != "StateUpdate" {
+			t.Errorf("Expected the state updates of cpu-high, got %v", r.Form)
+		}
+		if r.Form.Get("NextToken") == "" {
+			w.Write([]byte(`<DescribeAlarmHistoryResponse><DescribeAlarmHistoryResult><AlarmHistoryItems>
+				<member><Timestamp>2026-10-15T23:40:00Z</Timestamp><HistoryItemType>StateUpdate</HistoryItemType>
+					<HistorySummary>Alarm updated from ALARM to OK</HistorySummary>
+					<HistoryData>{"oldState":{"stateValue":"ALARM"},"newState":{"stateValue":"OK","stateReason":"Threshold Crossed: back to normal"}}</HistoryData></member>
+			</AlarmHistoryItems><NextToken>page-2</NextToken></DescribeAlarmHistoryResult></DescribeAlarmHistoryResponse>`))
+			return
+		}
+		w.Write([]byte(`<DescribeAlarmHistoryResponse><DescribeAlarmHistoryResult><AlarmHistoryItems>
+			<member><Timestamp>2026-10-15T23:10:00Z</Timestamp><HistoryItemType>StateUpdate</HistoryItemType>
+				<HistorySummary>Alarm updated from OK to ALARM</HistorySummary></member>
+		</AlarmHistoryItems></DescribeAlarmHistoryResult></DescribeAlarmHistoryResponse>`))
+	})
+
+	end := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	svc, err := NewCloudWatchService(cloudwatch.NewFromConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transitions, err := svc.AlarmHistory(context.Background(), "cpu-high", end.Add(-24*time.Hour), end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transitions) != 2 {
+		t.Fatalf("Expected 2 transitions, got %+v", transitions)
+	}
+	if first := transitions[0]; first.From != "OK" || first.To != "ALARM" || first.Reason != "Alarm updated from OK to ALARM" {
+		t.Errorf("Expected the transition read from its summary first, got %+v", first)
+	}
+	if second := transitions[1]; second.From != "ALARM" || second.To != "OK" || second.Reason != "Threshold Crossed: back to normal" {
+		t.Errorf("Expected the transition read from its data, got %+v", second)
+	}
+}
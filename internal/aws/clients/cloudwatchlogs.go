@@ -3,17 +3,44 @@ package clients
 import (
 	"context"
 	"fmt"
+	"maps"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 )
 
+// logGroupPageSize is the most log groups DescribeLogGroups returns at once
+const logGroupPageSize = 50
+
 // CloudWatchLogsService wraps the CloudWatch Logs client
 type CloudWatchLogsService struct {
 	client *cloudwatchlogs.Client
 }
 
+// LogGroupInfo describes a log group
+type LogGroupInfo struct {
+	Name string
+	ARN  string
+	// Account owns the group; in a monitoring account it differs from the
+	// caller's for the groups of linked source accounts
+	Account string
+	// RetentionDays is 0 for groups whose events never expire
+	RetentionDays int32
+	StoredBytes   int64
+	Class         string
+	CreatedAt     time.Time
+}
+
+// LogGroupPage is a page of log groups and the token of the next one, ""
+// after the last page
+type LogGroupPage struct {
+	Groups    []LogGroupInfo
+	NextToken string
+}
+
 // LogStreamInfo represents information about a log stream
 type LogStreamInfo struct {
 	LogStreamName       string
@@ -29,6 +56,12 @@ type LogEvent struct {
 	Timestamp     int64
 	Message       string
 	IngestionTime int64
+	LogStreamName string
+	// Only set for events returned by FilterLogEvents
+	EventID string
+	// Backfilled is set for events a tail missed while it was interrupted
+	// and read once it could poll again
+	Backfilled bool
 }
 
 // NewCloudWatchLogsService creates a new CloudWatch Logs service wrapper
@@ -47,10 +80,10 @@ func (s *CloudWatchLogsService) DescribeLogStreams(ctx context.Context, logGroup
 	descending := true
 
 	input := &cloudwatchlogs.DescribeLogStreamsInput{
-		LogGroupName: &logGroupName,
-		OrderBy:      types.OrderByLastEventTime,
-		Descending:   &descending,
+		OrderBy:    types.OrderByLastEventTime,
+		Descending: &descending,
 	}
+	input.LogGroupName, input.LogGroupIdentifier = logGroupRef(logGroupName)
 
 	if limit > 0 {
 		input.Limit = &limit
@@ -90,10 +123,10 @@ func (s *CloudWatchLogsService) DescribeLogStreams(ctx context.Context, logGroup
 // GetLogEvents retrieves log events from a specific log stream
 func (s *CloudWatchLogsService) GetLogEvents(ctx context.Context, logGroupName, logStreamName string, limit int32, startFromHead bool) ([]LogEvent, *string, error) {
 	input := &cloudwatchlogs.GetLogEventsInput{
-		LogGroupName:  &logGroupName,
 		LogStreamName: &logStreamName,
 		StartFromHead: &startFromHead,
 	}
+	input.LogGroupName, input.LogGroupIdentifier = logGroupRef(logGroupName)
 
 	if limit > 0 {
 		input.Limit = &limit
@@ -107,7 +140,8 @@ func (s *CloudWatchLogsService) GetLogEvents(ctx context.Context, logGroupName,
 	var events []LogEvent
 	for _, event := range result.Events {
 		logEvent := LogEvent{
-			Message: *event.Message,
+			Message:       *event.Message,
+			LogStreamName: logStreamName,
 		}
 
 		if event.Timestamp != nil {
@@ -131,10 +165,10 @@ func (s *CloudWatchLogsService) GetLogEvents(ctx context.Context, logGroupName,
 // GetLogEventsWithToken retrieves log events using a pagination token
 func (s *CloudWatchLogsService) GetLogEventsWithToken(ctx context.Context, logGroupName, logStreamName, nextToken string, limit int32) ([]LogEvent, *string, error) {
 	input := &cloudwatchlogs.GetLogEventsInput{
-		LogGroupName:  &logGroupName,
 		LogStreamName: &logStreamName,
 		NextToken:     &nextToken,
 	}
+	input.LogGroupName, input.LogGroupIdentifier = logGroupRef(logGroupName)
 
 	if limit > 0 {
 		input.Limit = &limit
@@ -148,7 +182,8 @@ func (s *CloudWatchLogsService) GetLogEventsWithToken(ctx context.Context, logGr
 	var events []LogEvent
 	for _, event := range result.Events {
 		logEvent := LogEvent{
-			Message: *event.Message,
+			Message:       *event.Message,
+			LogStreamName: logStreamName,
 		}
 
 		if event.Timestamp != nil {
@@ -175,11 +210,11 @@ func (s *CloudWatchLogsService) GetLogEventsSinceTime(ctx context.Context, logGr
 	startFromHead := false
 
 	input := &cloudwatchlogs.GetLogEventsInput{
-		LogGroupName:  &logGroupName,
 		LogStreamName: &logStreamName,
 		StartTime:     &sinceTime,
 		StartFromHead: &startFromHead,
 	}
+	input.LogGroupName, input.LogGroupIdentifier = logGroupRef(logGroupName)
 
 	if limit > 0 {
 		input.Limit = &limit
@@ -193,7 +228,8 @@ func (s *CloudWatchLogsService) GetLogEventsSinceTime(ctx context.Context, logGr
 	var events []LogEvent
 	for _, event := range result.Events {
 		logEvent := LogEvent{
-			Message: *event.Message,
+			Message:       *event.Message,
+			LogStreamName: logStreamName,
 		}
 
 		if event.Timestamp != nil {
@@ -209,78 +245,476 @@ func (s *CloudWatchLogsService) GetLogEventsSinceTime(ctx context.Context, logGr
 	return events, nil
 }
 
-// TailLogStreams tails multiple log streams in real-time
+// tailPollInterval is how often tails poll. Tests shorten it.
+var tailPollInterval = 2 * time.Second
+
+const (
+	// A poll more than tailGapAfter after the previous one, e.g. after the
+	// laptop slept, backfills like a failed one
+	tailGapAfter = 30 * time.Second
+	// tailBackfillLimit bounds the events backfilled at once; later polls
+	// read on from where the backfill stopped
+	tailBackfillLimit = 10000
+)
+
+// tailStream is the tail position of a log stream
+type tailStream struct {
+	token *string
+	// last is the timestamp in ms of the newest event received
+	last int64
+	// atLast counts the events received at last, which a backfill starting
+	// there reads again. GetLogEvents returns no event IDs, so identical
+	// events are told apart by count only.
+	atLast map[tailEventKey]int
+	// gap is set once polling failed or stalled; the next poll backfills
+	// the events since last
+	gap bool
+}
+
+// tailEventKey identifies an event among the ones with the same timestamp
+type tailEventKey struct {
+	ingested int64
+	message  string
+}
+
+// received moves the position of st past events. Events older than last,
+// which arrive out of order, do not move it.
+func (st *tailStream) received(events []LogEvent) {
+	for _, event := range events {
+		switch {
+		case event.Timestamp > st.last:
+			st.last = event.Timestamp
+			st.atLast = make(map[tailEventKey]int)
+		case event.Timestamp < st.last:
+			continue
+		}
+		st.atLast[tailEventKey{event.IngestionTime, event.Message}]++
+	}
+}
+
+// backfilled drops the events of a backfill that were received before it,
+// the ones at last, marks the others backfilled after a gap and moves the
+// position of st past them
+func (st *tailStream) backfilled(events []LogEvent, gap bool) []LogEvent {
+	last := st.last
+	overlap := maps.Clone(st.atLast)
+	var fresh []LogEvent
+	for _, event := range events {
+		key := tailEventKey{event.IngestionTime, event.Message}
+		if event.Timestamp == last && overlap[key] > 0 {
+			overlap[key]--
+			continue
+		}
+		event.Backfilled = gap
+		fresh = append(fresh, event)
+	}
+	st.received(fresh)
+	return fresh
+}
+
+// TailLogStreams tails multiple log streams in real-time. It remembers the
+// newest event of every stream, and once polling failed or stalled it
+// backfills the events missed since then, marked as Backfilled.
 func (s *CloudWatchLogsService) TailLogStreams(ctx context.Context, logGroupName string, logStreamNames []string, eventsChan chan<- LogEvent, errorChan chan<- error) {
 	defer close(eventsChan)
 	defer close(errorChan)
 
-	// Track the next token for each stream
-	nextTokens := make(map[string]*string)
+	send := func(events []LogEvent) bool {
+		for _, event := range events {
+			select {
+			case eventsChan <- event:
+			case <-ctx.Done():
+				return false
+			}
+		}
+		return true
+	}
+	fail := func(err error) bool {
+		select {
+		case errorChan <- err:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
 
-	// Initialize tokens for all streams
+	streams := make(map[string]*tailStream)
 	for _, streamName := range logStreamNames {
+		// Streams that cannot be read yet are backfilled from now on
+		st := &tailStream{last: time.Now().UnixMilli(), atLast: make(map[tailEventKey]int)}
+		streams[streamName] = st
+
 		events, nextToken, err := s.GetLogEvents(ctx, logGroupName, streamName, 10, false)
 		if err != nil {
-			errorChan <- fmt.Errorf("failed to get initial events for stream %s: %w", streamName, err)
+			st.gap = true
+			if !fail(fmt.Errorf("failed to get initial events for stream %s: %w", streamName, err)) {
+				return
+			}
 			continue
 		}
+		st.last = 0
+		st.received(events)
+		if !send(events) {
+			return
+		}
+		st.token = nextToken
+	}
 
-		for _, event := range events {
-			select {
-			case eventsChan <- event:
-			case <-ctx.Done():
+	ticker := time.NewTicker(tailPollInterval)
+	defer ticker.Stop()
+
+	// Wall clock times, so the time asleep counts
+	lastPoll := time.Now().Round(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if time.Now().Round(0).Sub(lastPoll) > tailGapAfter {
+			for _, st := range streams {
+				st.gap = true
+			}
+		}
+		lastPoll = time.Now().Round(0)
+
+		for _, streamName := range logStreamNames {
+			st := streams[streamName]
+			var events []LogEvent
+			var err error
+			if st.gap || st.token == nil {
+				events, err = s.backfillStream(ctx, logGroupName, streamName, st)
+			} else {
+				var nextToken *string
+				events, nextToken, err = s.GetLogEventsWithToken(ctx, logGroupName, streamName, *st.token, 50)
+				if err == nil {
+					// Tokens never return an event twice
+					st.received(events)
+					if nextToken != nil {
+						st.token = nextToken
+					}
+				}
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				st.gap = true
+				if !fail(fmt.Errorf("failed to tail events for stream %s: %w", streamName, err)) {
+					return
+				}
+				continue
+			}
+			if !send(events) {
 				return
 			}
 		}
+	}
+}
+
+// backfillStream reads the events of a stream since the position of st,
+// oldest first, up to tailBackfillLimit, and continues the tail after them
+func (s *CloudWatchLogsService) backfillStream(ctx context.Context, logGroupName, logStreamName string, st *tailStream) ([]LogEvent, error) {
+	startFromHead := true
+	input := &cloudwatchlogs.GetLogEventsInput{
+		LogStreamName: &logStreamName,
+		StartTime:     aws.Int64(st.last),
+		StartFromHead: &startFromHead,
+	}
+	input.LogGroupName, input.LogGroupIdentifier = logGroupRef(logGroupName)
+
+	var events []LogEvent
+	for len(events) < tailBackfillLimit {
+		result, err := s.client.GetLogEvents(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to backfill log events: %w", err)
+		}
+		for _, event := range result.Events {
+			events = append(events, LogEvent{
+				Message:       safeString(event.Message),
+				Timestamp:     aws.ToInt64(event.Timestamp),
+				IngestionTime: aws.ToInt64(event.IngestionTime),
+				LogStreamName: logStreamName,
+			})
+		}
+
+		// The last page returns the token it was asked with
+		done := result.NextForwardToken == nil || (input.NextToken != nil && *result.NextForwardToken == *input.NextToken)
+		if result.NextForwardToken != nil {
+			st.token = result.NextForwardToken
+		}
+		if done {
+			break
+		}
+		input.NextToken = result.NextForwardToken
+		input.StartTime = nil
+	}
+
+	fresh := st.backfilled(events, st.gap)
+	st.gap = false
+	return fresh, nil
+}
+
+// FilterLogEvents retrieves events across all streams of a log group since the
+// given time, optionally restricted by a CloudWatch filter pattern
+func (s *CloudWatchLogsService) FilterLogEvents(ctx context.Context, logGroupName, filterPattern string, since time.Time) ([]LogEvent, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("CloudWatch Logs service not initialized")
+	}
+
+	startTime := since.UnixMilli()
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		StartTime: &startTime,
+	}
+	input.LogGroupName, input.LogGroupIdentifier = logGroupRef(logGroupName)
+	if filterPattern != "" {
+		input.FilterPattern = &filterPattern
+	}
+
+	var events []LogEvent
+	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(s.client, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter log events: %w", err)
+		}
 
-		if nextToken != nil {
-			nextTokens[streamName] = nextToken
+		for _, event := range output.Events {
+			logEvent := LogEvent{
+				Message:       safeString(event.Message),
+				LogStreamName: safeString(event.LogStreamName),
+				EventID:       safeString(event.EventId),
+			}
+			if event.Timestamp != nil {
+				logEvent.Timestamp = *event.Timestamp
+			}
+			if event.IngestionTime != nil {
+				logEvent.IngestionTime = *event.IngestionTime
+			}
+			events = append(events, logEvent)
 		}
 	}
 
+	return events, nil
+}
+
+// TailLogGroup streams matching events of a whole log group, starting at since,
+// by polling FilterLogEvents until the context is cancelled. Events read
+// after a failed poll are marked as Backfilled.
+func (s *CloudWatchLogsService) TailLogGroup(ctx context.Context, logGroupName, filterPattern string, since time.Time, eventsChan chan<- LogEvent, errorChan chan<- error) {
+	defer close(eventsChan)
+	defer close(errorChan)
+
+	// Polls restart at the newest seen timestamp, so remember which events
+	// were already sent for that millisecond
+	lastTimestamp := since.UnixMilli()
+	seen := make(map[string]bool)
+	backfill := false
+
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
 
 	for {
+		events, err := s.FilterLogEvents(ctx, logGroupName, filterPattern, time.UnixMilli(lastTimestamp))
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case errorChan <- err:
+			case <-ctx.Done():
+				return
+			}
+			backfill = true
+		} else {
+			// Polls start at the newest event seen, so these are the missed ones
+			for i := range events {
+				events[i].Backfilled = backfill
+			}
+			backfill = false
+		}
+
+		for _, event := range events {
+			if seen[event.EventID] {
+				continue
+			}
+			if event.Timestamp > lastTimestamp {
+				lastTimestamp = event.Timestamp
+				seen = make(map[string]bool)
+			}
+			seen[event.EventID] = true
+
+			select {
+			case eventsChan <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			// Poll for new events in each stream
-			for _, streamName := range logStreamNames {
-				if nextToken, exists := nextTokens[streamName]; exists && nextToken != nil {
-					events, newNextToken, err := s.GetLogEventsWithToken(ctx, logGroupName, streamName, *nextToken, 50)
-					if err != nil {
-						errorChan <- fmt.Errorf("failed to tail events for stream %s: %w", streamName, err)
-						continue
-					}
+		}
+	}
+}
 
-					for _, event := range events {
-						select {
-						case eventsChan <- event:
-						case <-ctx.Done():
-							return
-						}
-					}
+// ListLogGroups returns the page of log groups whose names start with prefix
+// after nextToken, "" for the first page. In a monitoring account the groups
+// of linked source accounts are included.
+func (s *CloudWatchLogsService) ListLogGroups(ctx context.Context, prefix, nextToken string) (LogGroupPage, error) {
+	if s == nil || s.client == nil {
+		return LogGroupPage{}, fmt.Errorf("CloudWatch Logs service not initialized")
+	}
 
-					if newNextToken != nil {
-						nextTokens[streamName] = newNextToken
-					}
-				}
-			}
+	includeLinked := true
+	limit := int32(logGroupPageSize)
+	input := &cloudwatchlogs.DescribeLogGroupsInput{
+		IncludeLinkedAccounts: &includeLinked,
+		Limit:                 &limit,
+	}
+	if prefix != "" {
+		input.LogGroupNamePrefix = &prefix
+	}
+	if nextToken != "" {
+		input.NextToken = &nextToken
+	}
+
+	result, err := s.client.DescribeLogGroups(ctx, input)
+	if err != nil {
+		return LogGroupPage{}, fmt.Errorf("failed to list log groups: %w", err)
+	}
+
+	page := LogGroupPage{NextToken: safeString(result.NextToken)}
+	for _, group := range result.LogGroups {
+		info := LogGroupInfo{
+			Name:        safeString(group.LogGroupName),
+			ARN:         safeString(group.LogGroupArn),
+			Class:       string(group.LogGroupClass),
+			StoredBytes: aws.ToInt64(group.StoredBytes),
+		}
+		if info.ARN == "" {
+			// The older ARN field ends in ":*"
+			info.ARN = strings.TrimSuffix(safeString(group.Arn), ":*")
+		}
+		if parts := strings.SplitN(info.ARN, ":", 6); len(parts) == 6 {
+			info.Account = parts[4]
+		}
+		if group.RetentionInDays != nil {
+			info.RetentionDays = *group.RetentionInDays
+		}
+		if group.CreationTime != nil {
+			info.CreatedAt = time.UnixMilli(*group.CreationTime)
+		}
+		page.Groups = append(page.Groups, info)
+	}
+	return page, nil
+}
+
+// ListAllLogGroups returns all log groups whose names start with prefix,
+// following every page
+func (s *CloudWatchLogsService) ListAllLogGroups(ctx context.Context, prefix string) ([]LogGroupInfo, error) {
+	var groups []LogGroupInfo
+	token := ""
+	for {
+		page, err := s.ListLogGroups(ctx, prefix, token)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, page.Groups...)
+		if page.NextToken == "" || page.NextToken == token {
+			return groups, nil
 		}
+		token = page.NextToken
 	}
 }
 
-func (s *CloudWatchLogsService) ListAllLogGroups(ctx context.Context) ([]types.LogGroupSummary, error) {
+// ExportTask is a CloudWatch Logs export task copying the events of a log
+// group to S3
+type ExportTask struct {
+	ID string
+	// Status is PENDING, RUNNING, COMPLETED, FAILED, CANCELLED or
+	// PENDING_CANCEL
+	Status string
+	// Message explains the status, e.g. why the task failed
+	Message string
+}
+
+// Finished reports whether the task stopped running
+func (t ExportTask) Finished() bool {
+	switch types.ExportTaskStatusCode(t.Status) {
+	case types.ExportTaskStatusCodeCompleted, types.ExportTaskStatusCodeFailed, types.ExportTaskStatusCodeCancelled:
+		return true
+	}
+	return false
+}
+
+// CreateExportTask starts exporting the events of logGroup from from until to
+// into bucket below prefix and returns the ID of the task. An account runs
+// one export task at a time.
+func (s *CloudWatchLogsService) CreateExportTask(ctx context.Context, logGroup, bucket, prefix string, from, to time.Time) (string, error) {
 	if s == nil || s.client == nil {
-		return nil, fmt.Errorf("CloudWatch Logs service not initialized")
+		return "", fmt.Errorf("CloudWatch Logs service not initialized")
 	}
 
-	input := &cloudwatchlogs.ListLogGroupsInput{}
-	result, err := s.client.ListLogGroups(ctx, input)
+	input := &cloudwatchlogs.CreateExportTaskInput{
+		LogGroupName: &logGroup,
+		Destination:  &bucket,
+		From:         aws.Int64(from.UnixMilli()),
+		To:           aws.Int64(to.UnixMilli()),
+		TaskName:     aws.String(fmt.Sprintf("swiss-army-tui-%d", time.Now().Unix())),
+	}
+	if prefix != "" {
+		input.DestinationPrefix = &prefix
+	}
+
+	result, err := s.client.CreateExportTask(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list log groups: %w", err)
+		return "", fmt.Errorf("failed to create export task for %s: %w", logGroup, err)
+	}
+	return safeString(result.TaskId), nil
+}
+
+// DescribeExportTask returns the status of the export task id
+func (s *CloudWatchLogsService) DescribeExportTask(ctx context.Context, id string) (ExportTask, error) {
+	if s == nil || s.client == nil {
+		return ExportTask{}, fmt.Errorf("CloudWatch Logs service not initialized")
+	}
+
+	result, err := s.client.DescribeExportTasks(ctx, &cloudwatchlogs.DescribeExportTasksInput{TaskId: &id})
+	if err != nil {
+		return ExportTask{}, fmt.Errorf("failed to describe export task %s: %w", id, err)
+	}
+	if len(result.ExportTasks) == 0 {
+		return ExportTask{}, fmt.Errorf("export task %s not found", id)
+	}
+
+	task := ExportTask{ID: id}
+	if status := result.ExportTasks[0].Status; status != nil {
+		task.Status = string(status.Code)
+		task.Message = safeString(status.Message)
+	}
+	return task, nil
+}
+
+// CancelExportTask cancels the pending or running export task id
+func (s *CloudWatchLogsService) CancelExportTask(ctx context.Context, id string) error {
+	if s == nil || s.client == nil {
+		return fmt.Errorf("CloudWatch Logs service not initialized")
+	}
+
+	if _, err := s.client.CancelExportTask(ctx, &cloudwatchlogs.CancelExportTaskInput{TaskId: &id}); err != nil {
+		return fmt.Errorf("failed to cancel export task %s: %w", id, err)
+	}
+	return nil
+}
+
+// logGroupRef sets either the name of a log group or, for a group of a
+// linked source account given by its ARN, its identifier
+func logGroupRef(logGroup string) (name, identifier *string) {
+	if strings.HasPrefix(logGroup, "arn:") {
+		return nil, &logGroup
 	}
-	return result.LogGroups, nil
+	return &logGroup, nil
 }
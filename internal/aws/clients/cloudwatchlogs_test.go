@@ -0,0 +1,238 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+func TestCloudWatchLogsListAllLogGroups(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if target := r.Header.Get("X-Amz-Target"); target != "Logs_20140328.DescribeLogGroups" {
+			t.Errorf("Unexpected operation %s", target)
+		}
+		var input struct {
+			LogGroupNamePrefix    string `json:"logGroupNamePrefix"`
+			IncludeLinkedAccounts bool   `json:"includeLinkedAccounts"`
+			NextToken             string `json:"nextToken"`
+		}
+		json.NewDecoder(r.Body).Decode(&input)
+		if input.LogGroupNamePrefix != "/aws/lambda/" || !input.IncludeLinkedAccounts {
+			t.Errorf("Expected the prefix with linked accounts, got %+v", input)
+		}
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		if input.NextToken == "" {
+			w.Write([]byte(`{"logGroups":[{"logGroupName":"/aws/lambda/orders-api","arn":"arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/orders-api:*",
+				"retentionInDays":14,"storedBytes":2048,"creationTime":1700000000000,"logGroupClass":"STANDARD"}],"nextToken":"page-2"}`))
+			return
+		}
+		w.Write([]byte(`{"logGroups":[{"logGroupName":"/aws/lambda/partner-sync","logGroupArn":"arn:aws:logs:us-east-1:999988887777:log-group:/aws/lambda/partner-sync",
+			"arn":"arn:aws:logs:us-east-1:999988887777:log-group:/aws/lambda/partner-sync:*","storedBytes":0}]}`))
+	})
+
+	client := cloudwatchlogs.NewFromConfig(cfg)
+	svc, err := NewCloudWatchLogsService(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groups, err := svc.ListAllLogGroups(context.Background(), "/aws/lambda/")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("Expected the groups of both pages, got %+v", groups)
+	}
+	orders, partner := groups[0], groups[1]
+	if orders.ARN != "arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/orders-api" || orders.Account != "123456789012" {
+		t.Errorf("Expected the ARN without the wildcard, got %+v", orders)
+	}
+	if orders.RetentionDays != 14 || orders.StoredBytes != 2048 || orders.CreatedAt.UnixMilli() != 1700000000000 || orders.Class != "STANDARD" {
+		t.Errorf("Expected the retention, size and creation time, got %+v", orders)
+	}
+	if partner.Account != "999988887777" || partner.RetentionDays != 0 {
+		t.Errorf("Expected the group of the linked account without retention, got %+v", partner)
+	}
+}
+
+func TestLogGroupRef(t *testing.T) {
+	if name, id := logGroupRef("/ecs/orders"); name == nil || *name != "/ecs/orders" || id != nil {
+		t.Errorf("Expected a name, got %v %v", name, id)
+	}
+	arn := "arn:aws:logs:us-east-1:999988887777:log-group:/aws/lambda/partner-sync"
+	if name, id := logGroupRef(arn); name != nil || id == nil || *id != arn {
+		t.Errorf("Expected an identifier, got %v %v", name, id)
+	}
+}
+
+func TestCloudWatchLogsExportTask(t *testing.T) {
+	from := time.UnixMilli(1700000000000)
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		switch target := r.Header.Get("X-Amz-Target"); target {
+		case "Logs_20140328.CreateExportTask":
+			var input struct {
+				LogGroupName      string `json:"logGroupName"`
+				Destination       string `json:"destination"`
+				DestinationPrefix string `json:"destinationPrefix"`
+				From              int64  `json:"from"`
+				To                int64  `json:"to"`
+			}
+			json.NewDecoder(r.Body).Decode(&input)
+			if input.LogGroupName != "/ecs/orders" || input.Destination != "acme-log-archive" || input.DestinationPrefix != "orders" ||
+				input.From != 1700000000000 || input.To != 1700003600000 {
+				t.Errorf("Unexpected export %+v", input)
+			}
+			w.Write([]byte(`{"taskId":"task-1"}`))
+		case "Logs_20140328.DescribeExportTasks":
+			w.Write([]byte(`{"exportTasks":[{"taskId":"task-1","status":{"code":"FAILED","message":"Access denied to the bucket"}}]}`))
+		default:
+			t.Errorf("Unexpected operation %s", target)
+		}
+	})
+
+	client := cloudwatchlogs.NewFromConfig(cfg)
+	svc, err := NewCloudWatchLogsService(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := svc.CreateExportTask(context.Background(), "/ecs/orders", "acme-log-archive", "orders", from, from.Add(time.Hour))
+	if err != nil || id != "task-1" {
+		t.Fatalf("Expected the task ID, got %q, %v", id, err)
+	}
+	task, err := svc.DescribeExportTask(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !task.Finished() || task.Status != "FAILED" || task.Message != "Access denied to the bucket" {
+		t.Errorf("Expected the failed task, got %+v", task)
+	}
+}
+
+func TestCloudWatchLogsTailBackfill(t *testing.T) {
+	restore := tailPollInterval
+	tailPollInterval = 10 * time.Millisecond
+	defer func() { tailPollInterval = restore }()
+
+	var mu sync.Mutex
+	failed, caughtUp, live := false, false, false
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		var input struct {
+			NextToken     string `json:"nextToken"`
+			StartTime     int64  `json:"startTime"`
+			StartFromHead bool   `json:"startFromHead"`
+		}
+		json.NewDecoder(r.Body).Decode(&input)
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		switch {
+		case input.NextToken == "" && !input.StartFromHead:
+			w.Write([]byte(`{"events":[{"timestamp":1000,"message":"first"}],"nextForwardToken":"f/1"}`))
+		case input.NextToken == "f/1" && !failed:
+			// The network drops while polling
+			failed = true
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"InvalidParameterException","message":"connection reset"}`))
+		case input.NextToken == "" && input.StartFromHead:
+			if input.StartTime != 1000 {
+				t.Errorf("Expected the backfill to start at the last event, got %d", input.StartTime)
+			}
+			w.Write([]byte(`{"events":[{"timestamp":1000,"message":"first"},{"timestamp":2000,"message":"missed"}],"nextForwardToken":"f/2"}`))
+		case input.NextToken == "f/2" && !caughtUp:
+			// The backfill reads until the token stays the same
+			caughtUp = true
+			w.Write([]byte(`{"events":[],"nextForwardToken":"f/2"}`))
+		case input.NextToken == "f/2" && !live:
+			live = true
+			w.Write([]byte(`{"events":[{"timestamp":3000,"message":"live"}],"nextForwardToken":"f/3"}`))
+		default:
+			w.Write([]byte(`{"events":[],"nextForwardToken":"` + input.NextToken + `"}`))
+		}
+	})
+
+	client := cloudwatchlogs.NewFromConfig(cfg, func(o *cloudwatchlogs.Options) {
+		o.RetryMaxAttempts = 1
+	})
+	svc, err := NewCloudWatchLogsService(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := make(chan LogEvent, 10)
+	errs := make(chan error, 10)
+	go svc.TailLogStreams(ctx, "/ecs/orders", []string{"web"}, events, errs)
+
+	var got []LogEvent
+	for len(got) < 3 {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		case <-ctx.Done():
+			t.Fatalf("Expected 3 events, got %+v", got)
+		}
+	}
+	cancel()
+
+	if got[0].Message != "first" || got[0].Backfilled {
+		t.Errorf("Expected the first event live, got %+v", got[0])
+	}
+	if got[1].Message != "missed" || !got[1].Backfilled {
+		t.Errorf("Expected the missed event backfilled once, got %+v", got[1])
+	}
+	if got[2].Message != "live" || got[2].Backfilled {
+		t.Errorf("Expected the tail to go on live, got %+v", got[2])
+	}
+	for _, event := range got {
+		if event.LogStreamName != "web" {
+			t.Errorf("Expected the stream of every event, got %+v", event)
+		}
+	}
+	if len(errs) == 0 {
+		t.Error("Expected the failed poll reported")
+	}
+}
+
+func TestTailStreamOverlap(t *testing.T) {
+	st := &tailStream{atLast: make(map[tailEventKey]int)}
+
+	// Polls by token pass every event on, also ones older than the newest
+	st.received([]LogEvent{
+		{Timestamp: 2000, IngestionTime: 2100, Message: "retry"},
+		{Timestamp: 2000, IngestionTime: 2100, Message: "retry"},
+		{Timestamp: 1500, IngestionTime: 2200, Message: "late"},
+	})
+	if st.last != 2000 || st.atLast[tailEventKey{2100, "retry"}] != 2 {
+		t.Fatalf("Expected both events at 2000 counted, got %d %v", st.last, st.atLast)
+	}
+
+	// A backfill from 2000 reads them again, next to ones not received yet
+	fresh := st.backfilled([]LogEvent{
+		{Timestamp: 2000, IngestionTime: 2100, Message: "retry"},
+		{Timestamp: 2000, IngestionTime: 2100, Message: "retry"},
+		{Timestamp: 2000, IngestionTime: 2100, Message: "retry"},
+		{Timestamp: 2000, IngestionTime: 2300, Message: "retry"},
+		{Timestamp: 2500, IngestionTime: 2600, Message: "missed"},
+	}, true)
+	if len(fresh) != 3 || fresh[0].IngestionTime != 2100 || fresh[1].IngestionTime != 2300 || fresh[2].Message != "missed" {
+		t.Fatalf("Expected the third identical event, the later ingested one and the missed one, got %+v", fresh)
+	}
+	for _, event := range fresh {
+		if !event.Backfilled {
+			t.Errorf("Expected %+v marked backfilled", event)
+		}
+	}
+	if st.last != 2500 || st.atLast[tailEventKey{2600, "missed"}] != 1 || len(st.atLast) != 1 {
+		t.Errorf("Expected the position past the backfill, got %d %v", st.last, st.atLast)
+	}
+}
@@ -0,0 +1,280 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// dataSyncTargetPrefix prefixes the operation in the X-Amz-Target header of
+// a DataSync call
+const dataSyncTargetPrefix = "FmrsService."
+
+// DataSyncTask is a DataSync task with where it copies from and to
+type DataSyncTask struct {
+	ARN  string
+	Name string
+	// Status is AVAILABLE, CREATING, QUEUED, RUNNING or UNAVAILABLE
+	Status string
+	// Source and Destination are the URIs of the locations, e.g.
+	// s3://shop-archive/ or nfs://10.0.1.15/exports/, their ARNs if the
+	// locations could not be listed
+	Source      string
+	Destination string
+	// Schedule is the schedule expression of scheduled tasks
+	Schedule    string
+	ErrorCode   string
+	ErrorDetail string
+	CreatedAt   time.Time
+	// Current is the running execution, if any
+	Current *DataSyncExecution
+}
+
+// DataSyncExecution is a run of a DataSync task with how much it copied
+type DataSyncExecution struct {
+	ARN string
+	// Status is QUEUED, LAUNCHING, PREPARING, TRANSFERRING, VERIFYING,
+	// SUCCESS or ERROR
+	Status    string
+	StartedAt time.Time
+	// Duration is how long the execution took in all, TransferDuration how
+	// long it copied
+	Duration         time.Duration
+	TransferDuration time.Duration
+	BytesTransferred int64
+	BytesWritten     int64
+	FilesTransferred int64
+	EstimatedBytes   int64
+	EstimatedFiles   int64
+	ErrorCode        string
+	ErrorDetail      string
+}
+
+// ID returns the ID of the execution, e.g. exec-0a1b2c3d4e5f6a7b8
+func (e DataSyncExecution) ID() string {
+	return e.ARN[strings.LastIndex(e.ARN, "/")+1:]
+}
+
+// Throughput returns the bytes copied per second while transferring, 0 if
+// the execution has not transferred yet
+func (e DataSyncExecution) Throughput() float64 {
+	if e.TransferDuration <= 0 {
+		return 0
+	}
+	return float64(e.BytesTransferred) / e.TransferDuration.Seconds()
+}
+
+// DataSyncService lists DataSync tasks and their executions
+type DataSyncService struct {
+	*jsonAPI
+}
+
+// NewDataSyncService creates a new DataSync service for the region and
+// credentials of cfg
+func NewDataSyncService(cfg aws.Config) (*DataSyncService, error) {
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("DataSync credentials not provided")
+	}
+	return &DataSyncService{
+		jsonAPI: newJSONAPI(cfg, "DataSync", regionalEndpoint("datasync", cfg.Region), cfg.Region, "datasync", dataSyncTargetPrefix),
+	}, nil
+}
+
+// ListTasks returns the tasks of the region with their locations and the
+// progress of running executions, sorted by name. Tasks that fail to
+// describe are returned as listed, with a PartialError.
+func (s *DataSyncService) ListTasks(ctx context.Context) ([]DataSyncTask, error) {
+	if s == nil || s.jsonAPI == nil {
+		return nil, fmt.Errorf("DataSync service not initialized")
+	}
+
+	var tasks []DataSyncTask
+	input := map[string]any{"MaxResults": 100}
+	for {
+		var output struct {
+			Tasks []struct {
+				TaskArn string `json:"TaskArn"`
+				Name    string `json:"Name"`
+				Status  string `json:"Status"`
+			} `json:"Tasks"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.call(ctx, "ListTasks", input, &output); err != nil {
+			return nil, fmt.Errorf("failed to list DataSync tasks: %w", err)
+		}
+		for _, task := range output.Tasks {
+			tasks = append(tasks, DataSyncTask{ARN: task.TaskArn, Name: task.Name, Status: task.Status})
+		}
+		if output.NextToken == "" {
+			break
+		}
+		input["NextToken"] = output.NextToken
+	}
+
+	var failures failureCollector
+	locations, err := s.listLocations(ctx)
+	if err != nil {
+		failures.add("locations", s.region, err)
+	}
+	for i := range tasks {
+		if err := s.describeTask(ctx, &tasks[i], locations); err != nil {
+			failures.add(tasks[i].Name, s.region, err)
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].Name < tasks[j].Name })
+	return tasks, failures.err("datasync:DescribeTask")
+}
+
+// listLocations returns the URIs of the locations by ARN
+func (s *DataSyncService) listLocations(ctx context.Context) (map[string]string, error) {
+	locations := make(map[string]string)
+	input := map[string]any{"MaxResults": 100}
+	for {
+		var output struct {
+			Locations []struct {
+				LocationArn string `json:"LocationArn"`
+				LocationURI string `json:"LocationUri"`
+			} `json:"Locations"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.call(ctx, "ListLocations", input, &output); err != nil {
+			return locations, err
+		}
+		for _, location := range output.Locations {
+			locations[location.LocationArn] = location.LocationURI
+		}
+		if output.NextToken == "" {
+			return locations, nil
+		}
+		input["NextToken"] = output.NextToken
+	}
+}
+
+// describeTask fills in the locations, schedule and errors of task, and
+// its running execution
+func (s *DataSyncService) describeTask(ctx context.Context, task *DataSyncTask, locations map[string]string) error {
+	var output struct {
+		Name                    string  `json:"Name"`
+		Status                  string  `json:"Status"`
+		SourceLocationArn       string  `json:"SourceLocationArn"`
+		DestinationLocationArn  string  `json:"DestinationLocationArn"`
+		CurrentTaskExecutionArn string  `json:"CurrentTaskExecutionArn"`
+		ErrorCode               string  `json:"ErrorCode"`
+		ErrorDetail             string  `json:"ErrorDetail"`
+		CreationTime            float64 `json:"CreationTime"`
+		Schedule                struct {
+			ScheduleExpression string `json:"ScheduleExpression"`
+		} `json:"Schedule"`
+	}
+	if err := s.call(ctx, "DescribeTask", map[string]any{"TaskArn": task.ARN}, &output); err != nil {
+		return err
+	}
+	task.Source = locationURI(locations, output.SourceLocationArn)
+	task.Destination = locationURI(locations, output.DestinationLocationArn)
+	task.Schedule = output.Schedule.ScheduleExpression
+	task.ErrorCode = output.ErrorCode
+	task.ErrorDetail = output.ErrorDetail
+	task.CreatedAt = epochTime(output.CreationTime)
+	if output.CurrentTaskExecutionArn != "" {
+		execution, err := s.describeExecution(ctx, output.CurrentTaskExecutionArn)
+		if err != nil {
+			return err
+		}
+		task.Current = &execution
+	}
+	return nil
+}
+
+// locationURI returns the URI of the location arn, the ARN if it is unknown
+func locationURI(locations map[string]string, arn string) string {
+	if uri, ok := locations[arn]; ok && uri != "" {
+		return uri
+	}
+	return arn
+}
+
+// ListTaskExecutions returns the last limit executions of the task ARN
+// task, newest first
+func (s *DataSyncService) ListTaskExecutions(ctx context.Context, task string, limit int) ([]DataSyncExecution, error) {
+	if s == nil || s.jsonAPI == nil {
+		return nil, fmt.Errorf("DataSync service not initialized")
+	}
+
+	var arns []string
+	input := map[string]any{"TaskArn": task, "MaxResults": 100}
+	for {
+		var output struct {
+			TaskExecutions []struct {
+				TaskExecutionArn string `json:"TaskExecutionArn"`
+			} `json:"TaskExecutions"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.call(ctx, "ListTaskExecutions", input, &output); err != nil {
+			return nil, fmt.Errorf("failed to list the executions of %s: %w", task, err)
+		}
+		for _, execution := range output.TaskExecutions {
+			arns = append(arns, execution.TaskExecutionArn)
+		}
+		if output.NextToken == "" {
+			break
+		}
+		input["NextToken"] = output.NextToken
+	}
+
+	// ListTaskExecutions does not order executions, so all of them are
+	// described to find the latest
+	executions := make([]DataSyncExecution, 0, len(arns))
+	for _, arn := range arns {
+		execution, err := s.describeExecution(ctx, arn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe %s: %w", arn, err)
+		}
+		executions = append(executions, execution)
+	}
+	sort.SliceStable(executions, func(i, j int) bool { return executions[i].StartedAt.After(executions[j].StartedAt) })
+	if limit > 0 && len(executions) > limit {
+		executions = executions[:limit]
+	}
+	return executions, nil
+}
+
+// describeExecution returns the execution ARN arn
+func (s *DataSyncService) describeExecution(ctx context.Context, arn string) (DataSyncExecution, error) {
+	var output struct {
+		Status                   string  `json:"Status"`
+		StartTime                float64 `json:"StartTime"`
+		EstimatedFilesToTransfer int64   `json:"EstimatedFilesToTransfer"`
+		EstimatedBytesToTransfer int64   `json:"EstimatedBytesToTransfer"`
+		FilesTransferred         int64   `json:"FilesTransferred"`
+		BytesWritten             int64   `json:"BytesWritten"`
+		BytesTransferred         int64   `json:"BytesTransferred"`
+		Result                   struct {
+			TotalDuration    int64  `json:"TotalDuration"`
+			TransferDuration int64  `json:"TransferDuration"`
+			ErrorCode        string `json:"ErrorCode"`
+			ErrorDetail      string `json:"ErrorDetail"`
+		} `json:"Result"`
+	}
+	if err := s.call(ctx, "DescribeTaskExecution", map[string]any{"TaskExecutionArn": arn}, &output); err != nil {
+		return DataSyncExecution{}, err
+	}
+	return DataSyncExecution{
+		ARN:              arn,
+		Status:           output.Status,
+		StartedAt:        epochTime(output.StartTime),
+		Duration:         time.Duration(output.Result.TotalDuration) * time.Millisecond,
+		TransferDuration: time.Duration(output.Result.TransferDuration) * time.Millisecond,
+		BytesTransferred: output.BytesTransferred,
+		BytesWritten:     output.BytesWritten,
+		FilesTransferred: output.FilesTransferred,
+		EstimatedBytes:   output.EstimatedBytesToTransfer,
+		EstimatedFiles:   output.EstimatedFilesToTransfer,
+		ErrorCode:        output.Result.ErrorCode,
+		ErrorDetail:      output.Result.ErrorDetail,
+	}, nil
+}
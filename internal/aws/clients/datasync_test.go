@@ -0,0 +1,101 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDataSyncListTasks(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		var input map[string]any
+		json.NewDecoder(r.Body).Decode(&input)
+
+		switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), dataSyncTargetPrefix) {
+		case "ListTasks":
+			w.Write([]byte(`{"Tasks":[{"TaskArn":"arn:task/task-2","Name":"nightly-archive","Status":"RUNNING"},{"TaskArn":"arn:task/task-1","Name":"media-import","Status":"AVAILABLE"}]}`))
+		case "ListLocations":
+			w.Write([]byte(`{"Locations":[{"LocationArn":"arn:location/loc-nfs","LocationUri":"nfs://10.0.1.15/exports/"},{"LocationArn":"arn:location/loc-s3","LocationUri":"s3://shop-archive/"}]}`))
+		case "DescribeTask":
+			switch input["TaskArn"] {
+			case "arn:task/task-2":
+				w.Write([]byte(`{"Status":"RUNNING","SourceLocationArn":"arn:location/loc-nfs","DestinationLocationArn":"arn:location/loc-s3",
+					"CurrentTaskExecutionArn":"arn:task/task-2/execution/exec-9","Schedule":{"ScheduleExpression":"cron(0 2 * * ? *)"}}`))
+			default:
+				w.Write([]byte(`{"Status":"AVAILABLE","SourceLocationArn":"arn:location/loc-gone","DestinationLocationArn":"arn:location/loc-s3"}`))
+			}
+		case "DescribeTaskExecution":
+			w.Write([]byte(`{"Status":"TRANSFERRING","StartTime":1.7e9,"BytesTransferred":1048576,"EstimatedBytesToTransfer":4194304,"Result":{"TransferDuration":2000}}`))
+		default:
+			t.Errorf("Unexpected target %q", r.Header.Get("X-Amz-Target"))
+		}
+	})
+
+	svc, err := NewDataSyncService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, err := svc.ListTasks(context.Background())
+	if err != nil {
+		t.Fatalf("ListTasks returned error: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].Name != "media-import" {
+		t.Fatalf("Expected the tasks sorted by name, got %+v", tasks)
+	}
+	if task := tasks[0]; task.Source != "arn:location/loc-gone" || task.Destination != "s3://shop-archive/" || task.Current != nil {
+		t.Errorf("Unexpected task %+v", task)
+	}
+	task := tasks[1]
+	if task.Source != "nfs://10.0.1.15/exports/" || task.Schedule != "cron(0 2 * * ? *)" || task.Current == nil {
+		t.Fatalf("Unexpected task %+v", task)
+	}
+	if task.Current.ID() != "exec-9" || task.Current.Throughput() != 524288 || task.Current.EstimatedBytes != 4194304 {
+		t.Errorf("Unexpected execution %+v", task.Current)
+	}
+}
+
+func TestDataSyncListTaskExecutions(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		var input map[string]any
+		json.NewDecoder(r.Body).Decode(&input)
+
+		switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), dataSyncTargetPrefix) {
+		case "ListTaskExecutions":
+			w.Write([]byte(`{"TaskExecutions":[{"TaskExecutionArn":"arn:exec-1"},{"TaskExecutionArn":"arn:exec-3"},{"TaskExecutionArn":"arn:exec-2"}]}`))
+		case "DescribeTaskExecution":
+			switch input["TaskExecutionArn"] {
+			case "arn:exec-1":
+				w.Write([]byte(`{"Status":"SUCCESS","StartTime":1.7e9,"Result":{"TotalDuration":60000}}`))
+			case "arn:exec-2":
+				w.Write([]byte(`{"Status":"ERROR","StartTime":1.7001e9,"Result":{"ErrorCode":"OpNotSupp","ErrorDetail":"operation not supported"}}`))
+			default:
+				w.Write([]byte(`{"Status":"SUCCESS","StartTime":1.7002e9}`))
+			}
+		}
+	})
+
+	svc, err := NewDataSyncService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executions, err := svc.ListTaskExecutions(context.Background(), "arn:task/task-1", 2)
+	if err != nil {
+		t.Fatalf("ListTaskExecutions returned error: %v", err)
+	}
+	if len(executions) != 2 || executions[0].ARN != "arn:exec-3" || executions[1].ARN != "arn:exec-2" {
+		t.Fatalf("Expected the last two executions newest first, got %+v", executions)
+	}
+	if e := executions[1]; e.ErrorCode != "OpNotSupp" || e.Throughput() != 0 {
+		t.Errorf("Unexpected execution %+v", e)
+	}
+
+	all, _ := svc.ListTaskExecutions(context.Background(), "arn:task/task-1", 0)
+	if len(all) != 3 || all[2].Duration != time.Minute {
+		t.Errorf("Expected all executions, got %+v", all)
+	}
+}
@@ -0,0 +1,202 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// TableDetail describes a DynamoDB table and its capacity
+type TableDetail struct {
+	Name   string
+	ARN    string
+	Status string
+	// BillingMode is PROVISIONED or PAY_PER_REQUEST
+	BillingMode string
+	// ReadCapacity and WriteCapacity are the provisioned capacity units, 0
+	// for on-demand tables
+	ReadCapacity  int64
+	WriteCapacity int64
+	// MaxReadRequests and MaxWriteRequests cap the request units per second
+	// of on-demand tables, 0 when unlimited
+	MaxReadRequests  int64
+	MaxWriteRequests int64
+	PartitionKey     TableKey
+	// SortKey has no name when the table has a partition key only
+	SortKey   TableKey
+	ItemCount int64
+	SizeBytes int64
+	// TableClass is STANDARD or STANDARD_INFREQUENT_ACCESS
+	TableClass string
+	// StreamView is what the stream of the table records, e.g.
+	// NEW_AND_OLD_IMAGES, empty without a stream
+	StreamView     string
+	GlobalIndexes  []TableIndex
+	LocalIndexes   []TableIndex
+	DeletionLocked bool
+	CreatedAt      time.Time
+}
+
+// TableKey is a key attribute of a table or index
+type TableKey struct {
+	Name string
+	// Type is S, N or B for string, number or binary
+	Type string
+}
+
+// TableIndex is a secondary index of a table with its keys and, for global
+// indexes, its provisioned capacity
+type TableIndex struct {
+	Name         string
+	Status       string
+	PartitionKey TableKey
+	SortKey      TableKey
+	// Projection is ALL, KEYS_ONLY or INCLUDE
+	Projection    string
+	ReadCapacity  int64
+	WriteCapacity int64
+	ItemCount     int64
+	SizeBytes     int64
+}
+
+// DynamoDBService wraps the DynamoDB client
+type DynamoDBService struct {
+	client *dynamodb.Client
+}
+
+// NewDynamoDBService creates a new DynamoDB service wrapper
+func NewDynamoDBService(client *dynamodb.Client) (*DynamoDBService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("DynamoDB client not provided")
+	}
+
+	return &DynamoDBService{
+		client: client,
+	}, nil
+}
+
+// ListTables returns the tables of the region with their capacity. Tables
+// that cannot be described are returned by name only, together with a
+// *PartialError naming them.
+func (s *DynamoDBService) ListTables(ctx context.Context) ([]TableDetail, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("DynamoDB service not initialized")
+	}
+
+	var names []string
+	paginator := dynamodb.NewListTablesPaginator(s.client, &dynamodb.ListTablesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tables: %w", err)
+		}
+		names = append(names, output.TableNames...)
+	}
+
+	var failures failureCollector
+	tables := make([]TableDetail, 0, len(names))
+	for _, name := range names {
+		output, err := s.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+			TableName: aws.String(name),
+		})
+		if err != nil || output.Table == nil {
+			failures.add(name, "", err)
+			tables = append(tables, TableDetail{Name: name})
+			continue
+		}
+		tables = append(tables, toTableDetail(*output.Table))
+	}
+
+	return tables, failures.err("DescribeTable")
+}
+
+// toTableDetail converts a described table
+func toTableDetail(table types.TableDescription) TableDetail {
+	detail := TableDetail{
+		Name:           aws.ToString(table.TableName),
+		ARN:            aws.ToString(table.TableArn),
+		Status:         string(table.TableStatus),
+		BillingMode:    string(types.BillingModeProvisioned),
+		ItemCount:      aws.ToInt64(table.ItemCount),
+		SizeBytes:      aws.ToInt64(table.TableSizeBytes),
+		TableClass:     string(types.TableClassStandard),
+		DeletionLocked: aws.ToBool(table.DeletionProtectionEnabled),
+		CreatedAt:      aws.ToTime(table.CreationDateTime),
+	}
+	// Tables that were never switched to on-demand have no billing mode summary
+	if table.BillingModeSummary != nil {
+		detail.BillingMode = string(table.BillingModeSummary.BillingMode)
+	}
+	if throughput := table.ProvisionedThroughput; throughput != nil && detail.BillingMode == string(types.BillingModeProvisioned) {
+		detail.ReadCapacity = aws.ToInt64(throughput.ReadCapacityUnits)
+		detail.WriteCapacity = aws.ToInt64(throughput.WriteCapacityUnits)
+	}
+	// Unlimited on-demand throughput is reported as -1
+	if throughput := table.OnDemandThroughput; throughput != nil {
+		detail.MaxReadRequests = max(aws.ToInt64(throughput.MaxReadRequestUnits), 0)
+		detail.MaxWriteRequests = max(aws.ToInt64(throughput.MaxWriteRequestUnits), 0)
+	}
+	if table.TableClassSummary != nil && table.TableClassSummary.TableClass != "" {
+		detail.TableClass = string(table.TableClassSummary.TableClass)
+	}
+	if stream := table.StreamSpecification; stream != nil && aws.ToBool(stream.StreamEnabled) {
+		detail.StreamView = string(stream.StreamViewType)
+	}
+
+	attributes := make(map[string]string, len(table.AttributeDefinitions))
+	for _, attribute := range table.AttributeDefinitions {
+		attributes[aws.ToString(attribute.AttributeName)] = string(attribute.AttributeType)
+	}
+	detail.PartitionKey, detail.SortKey = tableKeys(table.KeySchema, attributes)
+
+	for _, index := range table.GlobalSecondaryIndexes {
+		gsi := TableIndex{
+			Name:      aws.ToString(index.IndexName),
+			Status:    string(index.IndexStatus),
+			ItemCount: aws.ToInt64(index.ItemCount),
+			SizeBytes: aws.ToInt64(index.IndexSizeBytes),
+		}
+		gsi.PartitionKey, gsi.SortKey = tableKeys(index.KeySchema, attributes)
+		if index.Projection != nil {
+			gsi.Projection = string(index.Projection.ProjectionType)
+		}
+		if throughput := index.ProvisionedThroughput; throughput != nil {
+			gsi.ReadCapacity = aws.ToInt64(throughput.ReadCapacityUnits)
+			gsi.WriteCapacity = aws.ToInt64(throughput.WriteCapacityUnits)
+		}
+		detail.GlobalIndexes = append(detail.GlobalIndexes, gsi)
+	}
+	for _, index := range table.LocalSecondaryIndexes {
+		lsi := TableIndex{
+			Name:      aws.ToString(index.IndexName),
+			ItemCount: aws.ToInt64(index.ItemCount),
+			SizeBytes: aws.ToInt64(index.IndexSizeBytes),
+		}
+		lsi.PartitionKey, lsi.SortKey = tableKeys(index.KeySchema, attributes)
+		if index.Projection != nil {
+			lsi.Projection = string(index.Projection.ProjectionType)
+		}
+		detail.LocalIndexes = append(detail.LocalIndexes, lsi)
+	}
+	return detail
+}
+
+// tableKeys returns the partition and sort key of schema with their types
+// from attributes
+func tableKeys(schema []types.KeySchemaElement, attributes map[string]string) (partition, sort TableKey) {
+	for _, element := range schema {
+		name := aws.ToString(element.AttributeName)
+		key := TableKey{Name: name, Type: attributes[name]}
+		switch element.KeyType {
+		case types.KeyTypeHash:
+			partition = key
+		case types.KeyTypeRange:
+			sort = key
+		}
+	}
+	return partition, sort
+}
@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestToTableDetail(t *testing.T) {
+	table := types.TableDescription{
+		TableName:   aws.String("orders"),
+		TableStatus: types.TableStatusActive,
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("customerId"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("orderId"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("status"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("placedAt"), AttributeType: types.ScalarAttributeTypeN},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("orderId"), KeyType: types.KeyTypeRange},
+			{AttributeName: aws.String("customerId"), KeyType: types.KeyTypeHash},
+		},
+		BillingModeSummary: &types.BillingModeSummary{BillingMode: types.BillingModePayPerRequest},
+		ProvisionedThroughput: &types.ProvisionedThroughputDescription{
+			ReadCapacityUnits: aws.Int64(0), WriteCapacityUnits: aws.Int64(0),
+		},
+		OnDemandThroughput:  &types.OnDemandThroughput{MaxReadRequestUnits: aws.Int64(-1), MaxWriteRequestUnits: aws.Int64(500)},
+		TableClassSummary:   &types.TableClassSummary{TableClass: types.TableClassStandardInfrequentAccess},
+		StreamSpecification: &types.StreamSpecification{StreamEnabled: aws.Bool(true), StreamViewType: types.StreamViewTypeNewAndOldImages},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndexDescription{{
+			IndexName:   aws.String("by-status"),
+			IndexStatus: types.IndexStatusActive,
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: aws.String("status"), KeyType: types.KeyTypeHash},
+				{AttributeName: aws.String("placedAt"), KeyType: types.KeyTypeRange},
+			},
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeKeysOnly},
+			ItemCount:  aws.Int64(42),
+		}},
+		LocalSecondaryIndexes: []types.LocalSecondaryIndexDescription{{
+			IndexName: aws.String("by-date"),
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: aws.String("customerId"), KeyType: types.KeyTypeHash},
+				{AttributeName: aws.String("placedAt"), KeyType: types.KeyTypeRange},
+			},
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+		}},
+	}
+
+	detail := toTableDetail(table)
+	if detail.BillingMode != "PAY_PER_REQUEST" || detail.ReadCapacity != 0 || detail.MaxReadRequests != 0 || detail.MaxWriteRequests != 500 {
+		t.Errorf("Expected an on-demand table capped at 500 writes, got %+v", detail)
+	}
+	if detail.PartitionKey != (TableKey{"customerId", "S"}) || detail.SortKey != (TableKey{"orderId", "S"}) {
+		t.Errorf("Unexpected keys %+v and %+v", detail.PartitionKey, detail.SortKey)
+	}
+	if detail.TableClass != "STANDARD_INFREQUENT_ACCESS" || detail.StreamView != "NEW_AND_OLD_IMAGES" {
+		t.Errorf("Unexpected table class %q or stream %q", detail.TableClass, detail.StreamView)
+	}
+	if len(detail.GlobalIndexes) != 1 || len(detail.LocalIndexes) != 1 {
+		t.Fatalf("Expected one global and one local index, got %+v", detail)
+	}
+	if gsi := detail.GlobalIndexes[0]; gsi.PartitionKey != (TableKey{"status", "S"}) || gsi.SortKey != (TableKey{"placedAt", "N"}) || gsi.Projection != "KEYS_ONLY" || gsi.ItemCount != 42 {
+		t.Errorf("Unexpected global index %+v", gsi)
+	}
+	if lsi := detail.LocalIndexes[0]; lsi.SortKey.Name != "placedAt" || lsi.Projection != "ALL" {
+		t.Errorf("Unexpected local index %+v", lsi)
+	}
+
+	// Tables never switched to on-demand have no billing mode summary, nor
+	// a table class summary
+	plain := toTableDetail(types.TableDescription{
+		TableName:             aws.String("inventory"),
+		ProvisionedThroughput: &types.ProvisionedThroughputDescription{ReadCapacityUnits: aws.Int64(5), WriteCapacityUnits: aws.Int64(5)},
+	})
+	if plain.BillingMode != "PROVISIONED" || plain.ReadCapacity != 5 || plain.TableClass != "STANDARD" || plain.StreamView != "" || plain.SortKey.Name != "" {
+		t.Errorf("Unexpected provisioned table %+v", plain)
+	}
+}
@@ -2,11 +2,13 @@ package clients
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
 )
 
 type EC2Service struct {
@@ -23,7 +25,10 @@ func NewEC2Service(EC2Client *ec2.Client) (*EC2Service, error) {
 	}, nil
 }
 
-func (c *EC2Service) GetEC2Detail(ctx context.Context) ([]types.Instance, error) {
+// GetEC2Detail lists all instances of the region. onPage, if set, is called
+// with the instances of every page as it arrives, so a listing can be shown
+// before its last page.
+func (c *EC2Service) GetEC2Detail(ctx context.Context, onPage func([]types.Instance)) ([]types.Instance, error) {
 	var allInstances []types.Instance
 	input := &ec2.DescribeInstancesInput{}
 	if c == nil || c.client == nil {
@@ -37,14 +42,126 @@ func (c *EC2Service) GetEC2Detail(ctx context.Context) ([]types.Instance, error)
 			return nil, fmt.Errorf("failed to get page: %w", err)
 		}
 
+		var page []types.Instance
 		for _, reservation := range output.Reservations {
-			allInstances = append(allInstances, reservation.Instances...)
+			page = append(page, reservation.Instances...)
+		}
+		allInstances = append(allInstances, page...)
+		if onPage != nil {
+			onPage(page)
 		}
 	}
 
 	return allInstances, nil
 }
 
+// DescribeVolumes returns all EBS volumes of the region
+func (c *EC2Service) DescribeVolumes(ctx context.Context) ([]types.Volume, error) {
+	if c == nil || c.client == nil {
+		return nil, fmt.Errorf("EC2 service not initialized")
+	}
+
+	var volumes []types.Volume
+	paginator := ec2.NewDescribeVolumesPaginator(c.client, &ec2.DescribeVolumesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe volumes: %w", err)
+		}
+		volumes = append(volumes, output.Volumes...)
+	}
+	return volumes, nil
+}
+
+// DescribeAddresses returns all Elastic IP addresses of the region
+func (c *EC2Service) DescribeAddresses(ctx context.Context) ([]types.Address, error) {
+	if c == nil || c.client == nil {
+		return nil, fmt.Errorf("EC2 service not initialized")
+	}
+
+	output, err := c.client.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe addresses: %w", err)
+	}
+	return output.Addresses, nil
+}
+
+// DescribeNatGateways returns the NAT gateways of the region, including
+// ones being deleted
+func (c *EC2Service) DescribeNatGateways(ctx context.Context) ([]types.NatGateway, error) {
+	if c == nil || c.client == nil {
+		return nil, fmt.Errorf("EC2 service not initialized")
+	}
+
+	var gateways []types.NatGateway
+	paginator := ec2.NewDescribeNatGatewaysPaginator(c.client, &ec2.DescribeNatGatewaysInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe NAT gateways: %w", err)
+		}
+		gateways = append(gateways, output.NatGateways...)
+	}
+	return gateways, nil
+}
+
+// DescribeSpotInstanceRequests returns the Spot Instance requests of the
+// region, including closed ones whose status tells why they ended
+func (c *EC2Service) DescribeSpotInstanceRequests(ctx context.Context) ([]types.SpotInstanceRequest, error) {
+	if c == nil || c.client == nil {
+		return nil, fmt.Errorf("EC2 service not initialized")
+	}
+
+	var requests []types.SpotInstanceRequest
+	paginator := ec2.NewDescribeSpotInstanceRequestsPaginator(c.client, &ec2.DescribeSpotInstanceRequestsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe spot instance requests: %w", err)
+		}
+		requests = append(requests, output.SpotInstanceRequests...)
+	}
+	return requests, nil
+}
+
+// DescribeReservedInstances returns the active Reserved Instances of the region
+func (c *EC2Service) DescribeReservedInstances(ctx context.Context) ([]types.ReservedInstances, error) {
+	if c == nil || c.client == nil {
+		return nil, fmt.Errorf("EC2 service not initialized")
+	}
+
+	output, err := c.client.DescribeReservedInstances(ctx, &ec2.DescribeReservedInstancesInput{
+		Filters: []types.Filter{{Name: aws.String("state"), Values: []string{"active"}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe reserved instances: %w", err)
+	}
+	return output.ReservedInstances, nil
+}
+
+// DescribeInstance returns the current description of a single instance
+func (c *EC2Service) DescribeInstance(ctx context.Context, instanceID string) (types.Instance, error) {
+	if c == nil || c.client == nil {
+		return types.Instance{}, fmt.Errorf("EC2 service not initialized")
+	}
+
+	output, err := c.client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+		InstanceIds: []string{instanceID},
+	})
+	if err != nil {
+		return types.Instance{}, fmt.Errorf("failed to describe instance: %w", err)
+	}
+
+	for _, reservation := range output.Reservations {
+		for _, instance := range reservation.Instances {
+			if aws.ToString(instance.InstanceId) == instanceID {
+				return instance, nil
+			}
+		}
+	}
+	return types.Instance{}, fmt.Errorf("instance %s not found", instanceID)
+}
+
 func (c *EC2Service) StartInstance(ctx context.Context, instanceID string) error {
 	if c == nil || c.client == nil {
 		return fmt.Errorf("EC2 service not initialized")
@@ -52,8 +169,6 @@ func (c *EC2Service) StartInstance(ctx context.Context, instanceID string) error
 
 	_, err := c.client.StartInstances(ctx, &ec2.StartInstancesInput{
 		InstanceIds: []string{instanceID},
-		// TODO: Remove this once we have a way to check if the user has enough permissions
-		DryRun: aws.Bool(false),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to start instance: %w", err)
@@ -68,8 +183,6 @@ func (c *EC2Service) StopInstance(ctx context.Context, instanceID string) error
 
 	_, err := c.client.StopInstances(ctx, &ec2.StopInstancesInput{
 		InstanceIds: []string{instanceID},
-		// TODO: Remove this once we have a way to check if the user has enough permissions
-		DryRun: aws.Bool(false),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to stop instance: %w", err)
@@ -77,6 +190,54 @@ func (c *EC2Service) StopInstance(ctx context.Context, instanceID string) error
 	return nil
 }
 
+// CanStartInstance reports whether the caller may start the instance, using
+// a dry run of StartInstances
+func (c *EC2Service) CanStartInstance(ctx context.Context, instanceID string) (bool, error) {
+	if c == nil || c.client == nil {
+		return false, fmt.Errorf("EC2 service not initialized")
+	}
+
+	_, err := c.client.StartInstances(ctx, &ec2.StartInstancesInput{
+		InstanceIds: []string{instanceID},
+		DryRun:      aws.Bool(true),
+	})
+	return dryRunAllowed(err)
+}
+
+// CanStopInstance reports whether the caller may stop the instance, using a
+// dry run of StopInstances
+func (c *EC2Service) CanStopInstance(ctx context.Context, instanceID string) (bool, error) {
+	if c == nil || c.client == nil {
+		return false, fmt.Errorf("EC2 service not initialized")
+	}
+
+	_, err := c.client.StopInstances(ctx, &ec2.StopInstancesInput{
+		InstanceIds: []string{instanceID},
+		DryRun:      aws.Bool(true),
+	})
+	return dryRunAllowed(err)
+}
+
+// dryRunAllowed interprets the result of a dry run request. EC2 answers
+// DryRunOperation when the request would have succeeded and
+// UnauthorizedOperation when the caller lacks the permission.
+func dryRunAllowed(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "DryRunOperation":
+			return true, nil
+		case "UnauthorizedOperation":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("failed to check permission: %w", err)
+}
+
 func (c *EC2Service) RebootInstance(ctx context.Context, instanceID string) error {
 	if c == nil || c.client == nil {
 		return fmt.Errorf("EC2 service not initialized")
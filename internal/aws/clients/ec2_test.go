@@ -0,0 +1,29 @@
+package clients
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestDryRunAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		allowed bool
+		wantErr bool
+	}{
+		{"would succeed", &smithy.GenericAPIError{Code: "DryRunOperation"}, true, false},
+		{"unauthorized", &smithy.GenericAPIError{Code: "UnauthorizedOperation"}, false, false},
+		{"other API error", &smithy.GenericAPIError{Code: "InvalidInstanceID.NotFound"}, false, true},
+		{"network error", errors.New("connection reset"), false, true},
+	}
+
+	for _, tt := range tests {
+		allowed, err := dryRunAllowed(tt.err)
+		if allowed != tt.allowed || (err != nil) != tt.wantErr {
+			t.Errorf("%s: got %v, %v", tt.name, allowed, err)
+		}
+	}
+}
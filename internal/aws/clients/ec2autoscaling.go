@@ -0,0 +1,152 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+)
+
+// AutoScalingGroup is an EC2 Auto Scaling group with its capacity
+type AutoScalingGroup struct {
+	Name    string
+	Min     int32
+	Max     int32
+	Desired int32
+	// Instances is how many instances the group has, in any state
+	Instances int
+}
+
+// AutoScalingService wraps the EC2 Auto Scaling client
+type AutoScalingService struct {
+	client *autoscaling.Client
+}
+
+// NewAutoScalingService creates a new EC2 Auto Scaling service wrapper
+func NewAutoScalingService(client *autoscaling.Client) (*AutoScalingService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("Auto Scaling client not provided")
+	}
+
+	return &AutoScalingService{
+		client: client,
+	}, nil
+}
+
+// ListGroups returns the Auto Scaling groups of the region by name
+func (s *AutoScalingService) ListGroups(ctx context.Context) ([]AutoScalingGroup, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("Auto Scaling service not initialized")
+	}
+
+	var groups []AutoScalingGroup
+	paginator := autoscaling.NewDescribeAutoScalingGroupsPaginator(s.client, &autoscaling.DescribeAutoScalingGroupsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe Auto Scaling groups: %w", err)
+		}
+		for _, group := range output.AutoScalingGroups {
+			groups = append(groups, AutoScalingGroup{
+				Name:      getStringValue(group.AutoScalingGroupName),
+				Min:       aws.ToInt32(group.MinSize),
+				Max:       aws.ToInt32(group.MaxSize),
+				Desired:   aws.ToInt32(group.DesiredCapacity),
+				Instances: len(group.Instances),
+			})
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
+// SetCapacity sets the minimum, maximum and desired capacity of the group
+// name
+func (s *AutoScalingService) SetCapacity(ctx context.Context, name string, min, max, desired int32) error {
+	if s == nil || s.client == nil {
+		return fmt.Errorf("Auto Scaling service not initialized")
+	}
+
+	_, err := s.client.UpdateAutoScalingGroup(ctx, &autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(name),
+		MinSize:              aws.Int32(min),
+		MaxSize:              aws.Int32(max),
+		DesiredCapacity:      aws.Int32(desired),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update Auto Scaling group %s: %w", name, err)
+	}
+	return nil
+}
+
+// LaunchReferences are what Auto Scaling launches instances from: the AMIs
+// of launch configurations and the launch template versions groups name
+type LaunchReferences struct {
+	Images    []string
+	Templates []LaunchTemplateRef
+}
+
+// LaunchTemplateRef is a launch template version a group launches from,
+// by ID or name. Version is a number, $Latest or $Default.
+type LaunchTemplateRef struct {
+	ID      string
+	Name    string
+	Version string
+}
+
+// ListLaunchReferences lists the AMIs of all launch configurations and the
+// launch template versions of all groups, including those of their mixed
+// instances overrides
+func (s *AutoScalingService) ListLaunchReferences(ctx context.Context) (LaunchReferences, error) {
+	var refs LaunchReferences
+	if s == nil || s.client == nil {
+		return refs, fmt.Errorf("Auto Scaling service not initialized")
+	}
+
+	configurations := autoscaling.NewDescribeLaunchConfigurationsPaginator(s.client, &autoscaling.DescribeLaunchConfigurationsInput{})
+	for configurations.HasMorePages() {
+		output, err := configurations.NextPage(ctx)
+		if err != nil {
+			return refs, fmt.Errorf("failed to describe launch configurations: %w", err)
+		}
+		for _, configuration := range output.LaunchConfigurations {
+			refs.Images = append(refs.Images, aws.ToString(configuration.ImageId))
+		}
+	}
+
+	addTemplate := func(spec *types.LaunchTemplateSpecification) {
+		if spec == nil {
+			return
+		}
+		// Groups without a version launch the default one
+		version := aws.ToString(spec.Version)
+		if version == "" {
+			version = "$Default"
+		}
+		refs.Templates = append(refs.Templates, LaunchTemplateRef{
+			ID:      aws.ToString(spec.LaunchTemplateId),
+			Name:    aws.ToString(spec.LaunchTemplateName),
+			Version: version,
+		})
+	}
+	groups := autoscaling.NewDescribeAutoScalingGroupsPaginator(s.client, &autoscaling.DescribeAutoScalingGroupsInput{})
+	for groups.HasMorePages() {
+		output, err := groups.NextPage(ctx)
+		if err != nil {
+			return refs, fmt.Errorf("failed to describe Auto Scaling groups: %w", err)
+		}
+		for _, group := range output.AutoScalingGroups {
+			addTemplate(group.LaunchTemplate)
+			if policy := group.MixedInstancesPolicy; policy != nil && policy.LaunchTemplate != nil {
+				addTemplate(policy.LaunchTemplate.LaunchTemplateSpecification)
+				for _, override := range policy.LaunchTemplate.Overrides {
+					addTemplate(override.LaunchTemplateSpecification)
+				}
+			}
+		}
+	}
+	return refs, nil
+}
@@ -0,0 +1,63 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+)
+
+func TestAutoScalingGroups(t *testing.T) {
+	var updated map[string]string
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "DescribeAutoScalingGroups":
+			if r.Form.Get("NextToken") == "" {
+				w.Write([]byte(`<DescribeAutoScalingGroupsResponse><DescribeAutoScalingGroupsResult><AutoScalingGroups>
+					<member><AutoScalingGroupName>worker</AutoScalingGroupName><MinSize>1</MinSize><MaxSize>4</MaxSize><DesiredCapacity>2</DesiredCapacity>
+						<Instances><member><InstanceId>i-1</InstanceId></member><member><InstanceId>i-2</InstanceId></member></Instances></member>
+				</AutoScalingGroups><NextToken>p2</NextToken></DescribeAutoScalingGroupsResult></DescribeAutoScalingGroupsResponse>`))
+				return
+			}
+			w.Write([]byte(`<DescribeAutoScalingGroupsResponse><DescribeAutoScalingGroupsResult><AutoScalingGroups>
+				<member><AutoScalingGroupName>web</AutoScalingGroupName><MinSize>2</MinSize><MaxSize>6</MaxSize><DesiredCapacity>3</DesiredCapacity></member>
+			</AutoScalingGroups></DescribeAutoScalingGroupsResult></DescribeAutoScalingGroupsResponse>`))
+		case "UpdateAutoScalingGroup":
+			updated = map[string]string{
+				"name":    r.Form.Get("AutoScalingGroupName"),
+				"min":     r.Form.Get("MinSize"),
+				"max":     r.Form.Get("MaxSize"),
+				"desired": r.Form.Get("DesiredCapacity"),
+			}
+			w.Write([]byte(`<UpdateAutoScalingGroupResponse><ResponseMetadata><RequestId>r</RequestId></ResponseMetadata></UpdateAutoScalingGroupResponse>`))
+		default:
+			t.Errorf("Unexpected action %q", r.Form.Get("Action"))
+		}
+	})
+
+	svc, err := NewAutoScalingService(autoscaling.NewFromConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groups, err := svc.ListGroups(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 || groups[0].Name != "web" || groups[1].Name != "worker" {
+		t.Fatalf("Expected both groups by name, got %+v", groups)
+	}
+	if worker := groups[1]; worker.Min != 1 || worker.Max != 4 || worker.Desired != 2 || worker.Instances != 2 {
+		t.Errorf("Unexpected capacity %+v", worker)
+	}
+
+	// A scale to 0 sets all three sizes, as the minimum would block it
+	if err := svc.SetCapacity(context.Background(), "web", 0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if updated["name"] != "web" || updated["min"] != "0" || updated["max"] != "0" || updated["desired"] != "0" {
+		t.Errorf("Unexpected update %v", updated)
+	}
+}
@@ -0,0 +1,324 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/workpool"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// UnusedImage is an AMI of the account that no instance runs and that no
+// launch configuration or launch template version in use names
+type UnusedImage struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+	// Snapshots back the volumes of the image and are deleted with it
+	Snapshots []string
+	// SizeGB is the total size of the volumes of the image
+	SizeGB int
+	// SharedWith are the accounts, organizations and units the image is
+	// shared with, "public" if anyone may launch it. Nothing in the region
+	// references it, but they may.
+	SharedWith []string
+}
+
+// UnusedSnapshot is an EBS snapshot of the account whose volume is gone, no
+// volume was created from and that backs no AMI
+type UnusedSnapshot struct {
+	ID          string
+	VolumeID    string
+	Description string
+	StorageTier string
+	StartedAt   time.Time
+	// SizeGB is the size of the volume; the snapshot stores at most that
+	SizeGB int
+}
+
+// CleanupCandidates are the AMIs and snapshots of the account in the region
+// that nothing references, oldest first
+type CleanupCandidates struct {
+	Images    []UnusedImage
+	Snapshots []UnusedSnapshot
+}
+
+// FindCleanupCandidates lists the AMIs and EBS snapshots owned by the
+// account that are not referenced by any instance, volume or launch
+// template of the region, nor by the launch configurations and template
+// versions of the Auto Scaling groups in launches. Snapshots backing an
+// unused AMI are part of the AMI, and unused AMIs shared with other accounts
+// say so. Anything unreadable fails the search, as it could hide a
+// reference.
+func (c *EC2Service) FindCleanupCandidates(ctx context.Context, launches LaunchReferences) (CleanupCandidates, error) {
+	var found CleanupCandidates
+	if c == nil || c.client == nil {
+		return found, fmt.Errorf("EC2 service not initialized")
+	}
+
+	var images []types.Image
+	imagePages := ec2.NewDescribeImagesPaginator(c.client, &ec2.DescribeImagesInput{Owners: []string{"self"}})
+	for imagePages.HasMorePages() {
+		output, err := imagePages.NextPage(ctx)
+		if err != nil {
+			return found, fmt.Errorf("failed to describe images: %w", err)
+		}
+		images = append(images, output.Images...)
+	}
+
+	var snapshots []types.Snapshot
+	snapshotPages := ec2.NewDescribeSnapshotsPaginator(c.client, &ec2.DescribeSnapshotsInput{OwnerIds: []string{"self"}})
+	for snapshotPages.HasMorePages() {
+		output, err := snapshotPages.NextPage(ctx)
+		if err != nil {
+			return found, fmt.Errorf("failed to describe snapshots: %w", err)
+		}
+		snapshots = append(snapshots, output.Snapshots...)
+	}
+
+	used := make(map[string]bool)
+	instances, err := c.GetEC2Detail(ctx, nil)
+	if err != nil {
+		return found, err
+	}
+	for _, instance := range instances {
+		if instance.State == nil || instance.State.Name != types.InstanceStateNameTerminated {
+			used[aws.ToString(instance.ImageId)] = true
+		}
+	}
+
+	volumes, err := c.DescribeVolumes(ctx)
+	if err != nil {
+		return found, err
+	}
+	for _, volume := range volumes {
+		used[aws.ToString(volume.VolumeId)] = true
+		used[aws.ToString(volume.SnapshotId)] = true
+	}
+
+	// The latest and default versions of every template, which is what
+	// launches use unless they name a version
+	templatePages := ec2.NewDescribeLaunchTemplateVersionsPaginator(c.client, &ec2.DescribeLaunchTemplateVersionsInput{
+		Versions:     []string{"$Latest", "$Default"},
+		ResolveAlias: aws.Bool(true),
+	})
+	for templatePages.HasMorePages() {
+		output, err := templatePages.NextPage(ctx)
+		if err != nil {
+			return found, fmt.Errorf("failed to describe launch templates: %w", err)
+		}
+		for _, version := range output.LaunchTemplateVersions {
+			if data := version.LaunchTemplateData; data != nil {
+				used[aws.ToString(data.ImageId)] = true
+			}
+		}
+	}
+
+	for _, image := range launches.Images {
+		used[image] = true
+	}
+	if err := c.markTemplateImages(ctx, launches.Templates, used); err != nil {
+		return found, err
+	}
+
+	// Fields that are not set reference nothing
+	delete(used, "")
+
+	for _, image := range images {
+		unused := !used[aws.ToString(image.ImageId)]
+		candidate := UnusedImage{ID: aws.ToString(image.ImageId), Name: aws.ToString(image.Name)}
+		candidate.CreatedAt, _ = time.Parse(time.RFC3339, aws.ToString(image.CreationDate))
+		for _, mapping := range image.BlockDeviceMappings {
+			if mapping.Ebs == nil || mapping.Ebs.SnapshotId == nil {
+				continue
+			}
+			if unused {
+				candidate.Snapshots = append(candidate.Snapshots, aws.ToString(mapping.Ebs.SnapshotId))
+				candidate.SizeGB += int(aws.ToInt32(mapping.Ebs.VolumeSize))
+			}
+			// Listed with the image when it is unused, kept otherwise
+			used[aws.ToString(mapping.Ebs.SnapshotId)] = true
+		}
+		if unused {
+			if image.Public != nil && *image.Public {
+				candidate.SharedWith = []string{"public"}
+			}
+			found.Images = append(found.Images, candidate)
+		}
+	}
+	if err := c.addSharing(ctx, found.Images); err != nil {
+		return found, err
+	}
+
+	for _, snapshot := range snapshots {
+		if used[aws.ToString(snapshot.SnapshotId)] || used[aws.ToString(snapshot.VolumeId)] {
+			continue
+		}
+		found.Snapshots = append(found.Snapshots, UnusedSnapshot{
+			ID:          aws.ToString(snapshot.SnapshotId),
+			VolumeID:    aws.ToString(snapshot.VolumeId),
+			Description: aws.ToString(snapshot.Description),
+			StorageTier: string(snapshot.StorageTier),
+			StartedAt:   aws.ToTime(snapshot.StartTime),
+			SizeGB:      int(aws.ToInt32(snapshot.VolumeSize)),
+		})
+	}
+
+	sort.SliceStable(found.Images, func(i, j int) bool { return found.Images[i].CreatedAt.Before(found.Images[j].CreatedAt) })
+	sort.SliceStable(found.Snapshots, func(i, j int) bool { return found.Snapshots[i].StartedAt.Before(found.Snapshots[j].StartedAt) })
+	return found, nil
+}
+
+// markTemplateImages marks the AMIs of the launch template versions
+// templates refer to as used. Templates that are gone reference nothing.
+func (c *EC2Service) markTemplateImages(ctx context.Context, templates []LaunchTemplateRef, used map[string]bool) error {
+	// One call per template for all its versions
+	versions := make(map[LaunchTemplateRef][]string)
+	var order []LaunchTemplateRef
+	for _, ref := range templates {
+		template := LaunchTemplateRef{ID: ref.ID, Name: ref.Name}
+		if template.ID != "" {
+			template.Name = ""
+		}
+		if _, ok := versions[template]; !ok {
+			order = append(order, template)
+		}
+		if !slices.Contains(versions[template], ref.Version) {
+			versions[template] = append(versions[template], ref.Version)
+		}
+	}
+
+	for _, template := range order {
+		input := &ec2.DescribeLaunchTemplateVersionsInput{
+			Versions:     versions[template],
+			ResolveAlias: aws.Bool(true),
+		}
+		if template.ID != "" {
+			input.LaunchTemplateId = aws.String(template.ID)
+		} else {
+			input.LaunchTemplateName = aws.String(template.Name)
+		}
+
+		pages := ec2.NewDescribeLaunchTemplateVersionsPaginator(c.client, input)
+		for pages.HasMorePages() {
+			output, err := pages.NextPage(ctx)
+			if isAPIError(err, "InvalidLaunchTemplateId.NotFound", "InvalidLaunchTemplateName.NotFoundException",
+				"InvalidLaunchTemplateId.VersionNotFound") {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to describe the versions of launch template %s: %w", template.ID+template.Name, err)
+			}
+			for _, version := range output.LaunchTemplateVersions {
+				if data := version.LaunchTemplateData; data != nil {
+					used[aws.ToString(data.ImageId)] = true
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addSharing adds who else may launch each of images, in the "ec2" slots of
+// the worker pool
+func (c *EC2Service) addSharing(ctx context.Context, images []UnusedImage) error {
+	var mu sync.Mutex
+	var failed error
+	err := workpool.Each(ctx, "ec2", len(images), func(i int) {
+		output, err := c.client.DescribeImageAttribute(ctx, &ec2.DescribeImageAttributeInput{
+			ImageId:   aws.String(images[i].ID),
+			Attribute: types.ImageAttributeNameLaunchPermission,
+		})
+		if err != nil {
+			mu.Lock()
+			failed = fmt.Errorf("failed to describe who may launch %s: %w", images[i].ID, err)
+			mu.Unlock()
+			return
+		}
+		for _, permission := range output.LaunchPermissions {
+			var with string
+			switch {
+			case permission.Group == types.PermissionGroupAll:
+				with = "public"
+			case permission.UserId != nil:
+				with = *permission.UserId
+			case permission.OrganizationArn != nil:
+				with = *permission.OrganizationArn
+			case permission.OrganizationalUnitArn != nil:
+				with = *permission.OrganizationalUnitArn
+			}
+			if with != "" && !slices.Contains(images[i].SharedWith, with) {
+				images[i].SharedWith = append(images[i].SharedWith, with)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return failed
+}
+
+// CleanUp deregisters images, deleting their snapshots once they are, and
+// deletes snapshots, in the "ec2" slots of the worker pool. With dryRun EC2
+// only checks that each request would be allowed and nothing changes. The
+// images and snapshots that fail are returned as a *PartialError naming
+// them.
+func (c *EC2Service) CleanUp(ctx context.Context, images []UnusedImage, snapshots []UnusedSnapshot, dryRun bool) error {
+	if c == nil || c.client == nil {
+		return fmt.Errorf("EC2 service not initialized")
+	}
+
+	var failures failureCollector
+	err := workpool.Each(ctx, "ec2", len(images), func(i int) {
+		image := images[i]
+		_, err := c.client.DeregisterImage(ctx, &ec2.DeregisterImageInput{ImageId: aws.String(image.ID), DryRun: aws.Bool(dryRun)})
+		if err = dryRunResult(err, dryRun); err != nil {
+			failures.add(image.ID, "", err)
+			return
+		}
+		for _, snapshot := range image.Snapshots {
+			_, err := c.client.DeleteSnapshot(ctx, &ec2.DeleteSnapshotInput{SnapshotId: aws.String(snapshot), DryRun: aws.Bool(dryRun)})
+			// In a dry run the image still holds its snapshots
+			if dryRun && isAPIError(err, "InvalidSnapshot.InUse") {
+				continue
+			}
+			if err = dryRunResult(err, dryRun); err != nil {
+				failures.add(snapshot, "", err)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = workpool.Each(ctx, "ec2", len(snapshots), func(i int) {
+		_, err := c.client.DeleteSnapshot(ctx, &ec2.DeleteSnapshotInput{SnapshotId: aws.String(snapshots[i].ID), DryRun: aws.Bool(dryRun)})
+		if err = dryRunResult(err, dryRun); err != nil {
+			failures.add(snapshots[i].ID, "", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		return failures.err("clean up images and snapshots (dry run)")
+	}
+	return failures.err("clean up images and snapshots")
+}
+
+// dryRunResult returns nil when the request succeeded or, in a dry run,
+// would have, and err otherwise
+func dryRunResult(err error, dryRun bool) error {
+	if dryRun && isAPIError(err, "DryRunOperation") {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,237 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// ec2Error answers an EC2 request with the error code
+func ec2Error(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	w.Write([]byte(`<Response><Errors><Error><Code>` + code + `</Code><Message>` + code + `</Message></Error></Errors><RequestID>r</RequestID></Response>`))
+}
+
+func TestEC2FindCleanupCandidates(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "DescribeImages":
+			if r.Form.Get("Owner.1") != "self" {
+				t.Errorf("Expected the images of the account, got %v", r.Form)
+			}
+			w.Write([]byte(`<DescribeImagesResponse><imagesSet>
+				<item><imageId>ami-run</imageId><name>web</name><creationDate>2025-01-01T00:00:00.000Z</creationDate>
+					<blockDeviceMapping><item><ebs><snapshotId>snap-run-root</snapshotId><volumeSize>8</volumeSize></ebs></item></blockDeviceMapping></item>
+				<item><imageId>ami-template</imageId><creationDate>2025-02-01T00:00:00.000Z</creationDate></item>
+				<item><imageId>ami-gone</imageId><name>batch</name><creationDate>2024-06-01T00:00:00.000Z</creationDate></item>
+				<item><imageId>ami-old</imageId><name>web-2023</name><creationDate>2023-03-01T00:00:00.000Z</creationDate>
+					<blockDeviceMapping><item><ebs><snapshotId>snap-old-root</snapshotId><volumeSize>8</volumeSize></ebs></item>
+						<item><ebs><snapshotId>snap-old-data</snapshotId><volumeSize>100</volumeSize></ebs></item>
+						<item><virtualName>ephemeral0</virtualName></item></blockDeviceMapping></item>
+			</imagesSet></DescribeImagesResponse>`))
+		case "DescribeSnapshots":
+			w.Write([]byte(`<DescribeSnapshotsResponse><snapshotSet>
+				<item><snapshotId>snap-run-root</snapshotId><volumeId>vol-gone</volumeId><startTime>2025-01-01T00:00:00.000Z</startTime><volumeSize>8</volumeSize></item>
+				<item><snapshotId>snap-old-root</snapshotId><volumeId>vol-gone</volumeId><startTime>2023-03-01T00:00:00.000Z</startTime><volumeSize>8</volumeSize></item>
+				<item><snapshotId>snap-old-data</snapshotId><volumeId>vol-gone</volumeId><startTime>2023-03-01T00:00:00.000Z</startTime><volumeSize>100</volumeSize></item>
+				<item><snapshotId>snap-restored</snapshotId><volumeId>vol-gone</volumeId><startTime>2024-01-01T00:00:00.000Z</startTime><volumeSize>50</volumeSize></item>
+				<item><snapshotId>snap-backup</snapshotId><volumeId>vol-live</volumeId><startTime>2024-01-01T00:00:00.000Z</startTime><volumeSize>20</volumeSize></item>
+				<item><snapshotId>snap-orphan</snapshotId><volumeId>vol-gone</volumeId><startTime>2022-05-01T00:00:00.000Z</startTime><volumeSize>500</volumeSize>
+					<storageTier>archive</storageTier><description>before the migration</description></item>
+			</snapshotSet></DescribeSnapshotsResponse>`))
+		case "DescribeInstances":
+			w.Write([]byte(`<DescribeInstancesResponse><reservationSet><item><instancesSet>
+				<item><instanceId>i-1</instanceId><imageId>ami-run</imageId><instanceState><name>stopped</name></instanceState></item>
+				<item><instanceId>i-2</instanceId><imageId>ami-gone</imageId><instanceState><name>terminated</name></instanceState></item>
+			</instancesSet></item></reservationSet></DescribeInstancesResponse>`))
+		case "DescribeVolumes":
+			w.Write([]byte(`<DescribeVolumesResponse><volumeSet>
+				<item><volumeId>vol-live</volumeId></item>
+				<item><volumeId>vol-restored</volumeId><snapshotId>snap-restored</snapshotId></item>
+			</volumeSet></DescribeVolumesResponse>`))
+		case "DescribeLaunchTemplateVersions":
+			w.Write([]byte(`<DescribeLaunchTemplateVersionsResponse><launchTemplateVersionSet>
+				<item><launchTemplateData><imageId>ami-template</imageId></launchTemplateData></item>
+			</launchTemplateVersionSet></DescribeLaunchTemplateVersionsResponse>`))
+		case "DescribeImageAttribute":
+			w.Write([]byte(`<DescribeImageAttributeResponse><imageId>` + r.Form.Get("ImageId") + `</imageId></DescribeImageAttributeResponse>`))
+		default:
+			t.Errorf("Unexpected action %q", r.Form.Get("Action"))
+		}
+	})
+
+	svc, err := NewEC2Service(ec2.NewFromConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := svc.FindCleanupCandidates(context.Background(), LaunchReferences{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found.Images) != 2 || found.Images[0].ID != "ami-old" || found.Images[1].ID != "ami-gone" {
+		t.Fatalf("Expected the unused images oldest first, got %+v", found.Images)
+	}
+	if old := found.Images[0]; len(old.Snapshots) != 2 || old.SizeGB != 108 || old.Name != "web-2023" || old.CreatedAt.Year() != 2023 {
+		t.Errorf("Expected the image with its snapshots, got %+v", old)
+	}
+	if len(found.Snapshots) != 1 {
+		t.Fatalf("Expected only the orphaned snapshot, got %+v", found.Snapshots)
+	}
+	if orphan := found.Snapshots[0]; orphan.ID != "snap-orphan" || orphan.SizeGB != 500 || orphan.StorageTier != "archive" || orphan.Description != "before the migration" {
+		t.Errorf("Unexpected snapshot %+v", orphan)
+	}
+}
+
+func TestEC2FindCleanupCandidatesAutoScaling(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "DescribeLaunchConfigurations":
+			w.Write([]byte(`<DescribeLaunchConfigurationsResponse><DescribeLaunchConfigurationsResult><LaunchConfigurations>
+				<member><LaunchConfigurationName>legacy</LaunchConfigurationName><ImageId>ami-config</ImageId></member>
+			</LaunchConfigurations></DescribeLaunchConfigurationsResult></DescribeLaunchConfigurationsResponse>`))
+		case "DescribeAutoScalingGroups":
+			w.Write([]byte(`<DescribeAutoScalingGroupsResponse><DescribeAutoScalingGroupsResult><AutoScalingGroups>
+				<member><AutoScalingGroupName>web</AutoScalingGroupName>
+					<LaunchTemplate><LaunchTemplateId>lt-web</LaunchTemplateId><Version>3</Version></LaunchTemplate></member>
+				<member><AutoScalingGroupName>batch</AutoScalingGroupName><MixedInstancesPolicy><LaunchTemplate>
+					<LaunchTemplateSpecification><LaunchTemplateName>batch-gone</LaunchTemplateName></LaunchTemplateSpecification>
+				</LaunchTemplate></MixedInstancesPolicy></member>
+			</AutoScalingGroups></DescribeAutoScalingGroupsResult></DescribeAutoScalingGroupsResponse>`))
+		case "DescribeImages":
+			w.Write([]byte(`<DescribeImagesResponse><imagesSet>
+				<item><imageId>ami-pinned</imageId><creationDate>2024-01-01T00:00:00.000Z</creationDate></item>
+				<item><imageId>ami-config</imageId><creationDate>2024-02-01T00:00:00.000Z</creationDate></item>
+				<item><imageId>ami-shared</imageId><creationDate>2024-03-01T00:00:00.000Z</creationDate></item>
+				<item><imageId>ami-public</imageId><creationDate>2024-04-01T00:00:00.000Z</creationDate><isPublic>true</isPublic></item>
+			</imagesSet></DescribeImagesResponse>`))
+		case "DescribeSnapshots":
+			w.Write([]byte(`<DescribeSnapshotsResponse><snapshotSet></snapshotSet></DescribeSnapshotsResponse>`))
+		case "DescribeInstances":
+			w.Write([]byte(`<DescribeInstancesResponse><reservationSet></reservationSet></DescribeInstancesResponse>`))
+		case "DescribeVolumes":
+			w.Write([]byte(`<DescribeVolumesResponse><volumeSet></volumeSet></DescribeVolumesResponse>`))
+		case "DescribeLaunchTemplateVersions":
+			switch {
+			case r.Form.Get("LaunchTemplateId") == "lt-web":
+				if r.Form.Get("LaunchTemplateVersion.1") != "3" {
+					t.Errorf("Expected the version the group pins, got %v", r.Form)
+				}
+				// Neither the latest nor the default version
+				w.Write([]byte(`<DescribeLaunchTemplateVersionsResponse><launchTemplateVersionSet>
+					<item><versionNumber>3</versionNumber><launchTemplateData><imageId>ami-pinned</imageId></launchTemplateData></item>
+				</launchTemplateVersionSet></DescribeLaunchTemplateVersionsResponse>`))
+			case r.Form.Get("LaunchTemplateName") == "batch-gone":
+				ec2Error(w, http.StatusBadRequest, "InvalidLaunchTemplateName.NotFoundException")
+			default:
+				w.Write([]byte(`<DescribeLaunchTemplateVersionsResponse><launchTemplateVersionSet></launchTemplateVersionSet></DescribeLaunchTemplateVersionsResponse>`))
+			}
+		case "DescribeImageAttribute":
+			permissions := ""
+			switch r.Form.Get("ImageId") {
+			case "ami-shared":
+				permissions = `<item><userId>222233334444</userId></item><item><organizationArn>arn:aws:organizations::111122223333:organization/o-abc</organizationArn></item>`
+			case "ami-public":
+				permissions = `<item><group>all</group></item>`
+			}
+			w.Write([]byte(`<DescribeImageAttributeResponse><imageId>` + r.Form.Get("ImageId") + `</imageId><launchPermission>` +
+				permissions + `</launchPermission></DescribeImageAttributeResponse>`))
+		default:
+			t.Errorf("Unexpected action %q", r.Form.Get("Action"))
+		}
+	})
+
+	groups, err := NewAutoScalingService(autoscaling.NewFromConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	launches, err := groups.ListLaunchReferences(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(launches.Templates) != 2 || launches.Templates[1].Version != "$Default" {
+		t.Errorf("Expected the default version of the unversioned template, got %+v", launches.Templates)
+	}
+
+	svc, err := NewEC2Service(ec2.NewFromConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := svc.FindCleanupCandidates(context.Background(), launches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the group and the launch configuration reference these
+	if len(found.Images) != 2 || found.Images[0].ID != "ami-shared" || found.Images[1].ID != "ami-public" {
+		t.Fatalf("Expected only the unreferenced images, got %+v", found.Images)
+	}
+	if shared := found.Images[0].SharedWith; len(shared) != 2 || shared[0] != "222233334444" {
+		t.Errorf("Expected the account and organization it is shared with, got %v", shared)
+	}
+	if public := found.Images[1].SharedWith; len(public) != 1 || public[0] != "public" {
+		t.Errorf("Expected the public image flagged once, got %v", public)
+	}
+}
+
+func TestEC2CleanUp(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		action, id := r.Form.Get("Action"), r.Form.Get("ImageId")+r.Form.Get("SnapshotId")
+		if r.Form.Get("DryRun") == "true" {
+			switch {
+			case id == "snap-denied":
+				ec2Error(w, http.StatusForbidden, "UnauthorizedOperation")
+			case id == "snap-root":
+				ec2Error(w, http.StatusBadRequest, "InvalidSnapshot.InUse")
+			default:
+				ec2Error(w, http.StatusPreconditionFailed, "DryRunOperation")
+			}
+			return
+		}
+
+		mu.Lock()
+		calls = append(calls, action+" "+id)
+		mu.Unlock()
+		switch action {
+		case "DeregisterImage":
+			w.Write([]byte(`<DeregisterImageResponse><return>true</return></DeregisterImageResponse>`))
+		case "DeleteSnapshot":
+			w.Write([]byte(`<DeleteSnapshotResponse><return>true</return></DeleteSnapshotResponse>`))
+		default:
+			t.Errorf("Unexpected action %q", action)
+		}
+	})
+
+	svc, err := NewEC2Service(ec2.NewFromConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	images := []UnusedImage{{ID: "ami-old", Snapshots: []string{"snap-root"}}}
+	snapshots := []UnusedSnapshot{{ID: "snap-orphan"}, {ID: "snap-denied"}}
+
+	err = svc.CleanUp(context.Background(), images, snapshots, true)
+	var partial *PartialError
+	if !errors.As(err, &partial) || len(partial.Failures) != 1 || partial.Failures[0].Item != "snap-denied" {
+		t.Errorf("Expected only the denied snapshot in the dry run report, got %v", err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("Expected the dry run to change nothing, got %v", calls)
+	}
+
+	if err := svc.CleanUp(context.Background(), images, snapshots[:1], false); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 3 || calls[0] != "DeregisterImage ami-old" || calls[1] != "DeleteSnapshot snap-root" || calls[2] != "DeleteSnapshot snap-orphan" {
+		t.Errorf("Expected the image deregistered before its snapshot is deleted, got %v", calls)
+	}
+}
@@ -0,0 +1,144 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+// Scan statuses besides the ones of ECR such as COMPLETE, IN_PROGRESS and
+// FAILED
+const (
+	// ScanStatusNotInECR is the status of images not stored in ECR, e.g. on
+	// Docker Hub
+	ScanStatusNotInECR = "NOT_IN_ECR"
+	// ScanStatusNotScanned is the status of ECR images that were never scanned
+	ScanStatusNotScanned = "NOT_SCANNED"
+)
+
+// ecrImagePattern matches the URI of an image in a private ECR registry,
+// capturing the registry ID, the region and the repository with its tag or
+// digest
+var ecrImagePattern = regexp.MustCompile(`^(\d{12})\.dkr\.ecr(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?/(.+)$`)
+
+// ECRImage is a container image in an ECR repository
+type ECRImage struct {
+	RegistryID string
+	Region     string
+	Repository string
+	// Tag is the tag the image is referenced by, empty if it is referenced
+	// by Digest
+	Tag    string
+	Digest string
+}
+
+// ParseECRImage resolves the image URI of a container to its ECR
+// repository. Images without a tag or digest are tagged latest; images of
+// other registries are not resolved.
+func ParseECRImage(image string) (ECRImage, bool) {
+	match := ecrImagePattern.FindStringSubmatch(image)
+	if match == nil {
+		return ECRImage{}, false
+	}
+	ref := ECRImage{RegistryID: match[1], Region: match[2]}
+
+	repository := match[3]
+	if name, digest, ok := strings.Cut(repository, "@"); ok {
+		ref.Repository, ref.Digest = name, digest
+		return ref, name != ""
+	}
+	slash := strings.LastIndex(repository, "/")
+	if colon := strings.LastIndex(repository, ":"); colon > slash {
+		ref.Repository, ref.Tag = repository[:colon], repository[colon+1:]
+	} else {
+		ref.Repository, ref.Tag = repository, "latest"
+	}
+	return ref, ref.Repository != "" && ref.Tag != ""
+}
+
+// ImageScanFindings are the findings of the latest scan of an image, counted
+// by severity
+type ImageScanFindings struct {
+	Image string
+	// Status is the status of the scan: COMPLETE, IN_PROGRESS and FAILED
+	// for basic scanning, ACTIVE and others for enhanced scanning, or
+	// ScanStatusNotInECR or ScanStatusNotScanned
+	Status      string
+	Description string
+	Critical    int
+	High        int
+	Medium      int
+	Low         int
+	ScannedAt   time.Time
+}
+
+// ECRService reads the image scan findings of ECR repositories, in the
+// region of each image
+type ECRService struct {
+	client *ecr.Client
+}
+
+// NewECRService creates a new ECR service
+func NewECRService(client *ecr.Client) (*ECRService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("ECR client not provided")
+	}
+	return &ECRService{client: client}, nil
+}
+
+// GetImageScanFindings returns the findings of the latest scan of the
+// container image URI image, with ScanStatusNotInECR for images of other
+// registries
+func (s *ECRService) GetImageScanFindings(ctx context.Context, image string) (ImageScanFindings, error) {
+	if s == nil || s.client == nil {
+		return ImageScanFindings{}, fmt.Errorf("ECR service not initialized")
+	}
+
+	findings := ImageScanFindings{Image: image}
+	ref, ok := ParseECRImage(image)
+	if !ok {
+		findings.Status = ScanStatusNotInECR
+		return findings, nil
+	}
+
+	imageID := &types.ImageIdentifier{ImageTag: aws.String(ref.Tag)}
+	if ref.Digest != "" {
+		imageID = &types.ImageIdentifier{ImageDigest: aws.String(ref.Digest)}
+	}
+	output, err := s.client.DescribeImageScanFindings(ctx, &ecr.DescribeImageScanFindingsInput{
+		RegistryId:     aws.String(ref.RegistryID),
+		RepositoryName: aws.String(ref.Repository),
+		ImageId:        imageID,
+		MaxResults:     aws.Int32(1),
+	}, func(o *ecr.Options) {
+		o.Region = ref.Region
+	})
+	if err != nil {
+		var notFound *types.ScanNotFoundException
+		if errors.As(err, &notFound) {
+			findings.Status = ScanStatusNotScanned
+			return findings, nil
+		}
+		return findings, fmt.Errorf("failed to read the scan findings of %s: %w", image, err)
+	}
+
+	if status := output.ImageScanStatus; status != nil {
+		findings.Status = string(status.Status)
+		findings.Description = aws.ToString(status.Description)
+	}
+	if scan := output.ImageScanFindings; scan != nil {
+		findings.ScannedAt = aws.ToTime(scan.ImageScanCompletedAt)
+		findings.Critical = int(scan.FindingSeverityCounts["CRITICAL"])
+		findings.High = int(scan.FindingSeverityCounts["HIGH"])
+		findings.Medium = int(scan.FindingSeverityCounts["MEDIUM"])
+		findings.Low = int(scan.FindingSeverityCounts["LOW"])
+	}
+	return findings, nil
+}
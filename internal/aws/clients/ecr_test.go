@@ -0,0 +1,90 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+)
+
+func TestParseECRImage(t *testing.T) {
+	tests := []struct {
+		image string
+		want  ECRImage
+		ok    bool
+	}{
+		{"123456789012.dkr.ecr.eu-west-1.amazonaws.com/shop/web:1.4", ECRImage{RegistryID: "123456789012", Region: "eu-west-1", Repository: "shop/web", Tag: "1.4"}, true},
+		{"123456789012.dkr.ecr.eu-west-1.amazonaws.com/web", ECRImage{RegistryID: "123456789012", Region: "eu-west-1", Repository: "web", Tag: "latest"}, true},
+		{"123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn/web@sha256:abc", ECRImage{RegistryID: "123456789012", Region: "cn-north-1", Repository: "web", Digest: "sha256:abc"}, true},
+		{"nginx:1.25", ECRImage{}, false},
+		{"public.ecr.aws/nginx/nginx:1.25", ECRImage{}, false},
+		{"localhost:5000/web:1", ECRImage{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseECRImage(tt.image)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("%s: expected %+v, %v, got %+v, %v", tt.image, tt.want, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestECRGetImageScanFindings(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/ecr/aws4_request") {
+			t.Errorf("Expected a request signed for ecr in eu-west-1, got %q", r.Header.Get("Authorization"))
+		}
+		if target := r.Header.Get("X-Amz-Target"); target != "AmazonEC2ContainerRegistry_V20150921.DescribeImageScanFindings" {
+			t.Errorf("Unexpected target %q", target)
+		}
+		var input struct {
+			RegistryID     string            `json:"registryId"`
+			RepositoryName string            `json:"repositoryName"`
+			ImageID        map[string]string `json:"imageId"`
+		}
+		json.NewDecoder(r.Body).Decode(&input)
+
+		switch input.RepositoryName {
+		case "web":
+			if input.RegistryID != "123456789012" || input.ImageID["imageTag"] != "1.4" {
+				t.Errorf("Unexpected input %+v", input)
+			}
+			w.Write([]byte(`{"imageScanStatus":{"status":"COMPLETE"},"imageScanFindings":{"imageScanCompletedAt":1.7605e9,"findingSeverityCounts":{"CRITICAL":2,"HIGH":5,"LOW":1}}}`))
+		case "new":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"ScanNotFoundException","message":"Image scan does not exist"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"AccessDeniedException","message":"not authorized"}`))
+		}
+	})
+
+	svc, err := NewECRService(ecr.NewFromConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	findings, err := svc.GetImageScanFindings(ctx, "123456789012.dkr.ecr.eu-west-1.amazonaws.com/web:1.4")
+	if err != nil {
+		t.Fatalf("GetImageScanFindings returned error: %v", err)
+	}
+	if findings.Status != "COMPLETE" || findings.Critical != 2 || findings.High != 5 || findings.Low != 1 || findings.ScannedAt.Unix() != 1760500000 {
+		t.Errorf("Unexpected findings %+v", findings)
+	}
+
+	findings, err = svc.GetImageScanFindings(ctx, "123456789012.dkr.ecr.eu-west-1.amazonaws.com/new:1")
+	if err != nil || findings.Status != ScanStatusNotScanned {
+		t.Errorf("Expected a missing scan to be not scanned, got %+v, %v", findings, err)
+	}
+	findings, err = svc.GetImageScanFindings(ctx, "nginx:1.25")
+	if err != nil || findings.Status != ScanStatusNotInECR {
+		t.Errorf("Expected a Docker Hub image to be not in ECR, got %+v, %v", findings, err)
+	}
+	_, err = svc.GetImageScanFindings(ctx, "602401143452.dkr.ecr.eu-west-1.amazonaws.com/eks/coredns:v1.11")
+	if got := ErrorReason(err); got != "access denied" {
+		t.Errorf("Expected access denied, got %q (%v)", got, err)
+	}
+}
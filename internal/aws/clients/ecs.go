@@ -0,0 +1,354 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+const (
+	// ecsDescribeBatch is how many services DescribeServices accepts at once
+	ecsDescribeBatch = 10
+	// ecsTaskBatch is how many clusters or tasks DescribeClusters and
+	// DescribeTasks accept at once
+	ecsTaskBatch = 100
+)
+
+// ECSServiceDetail is an ECS service with the container images of its task
+// definition
+type ECSServiceDetail struct {
+	Name           string
+	ARN            string
+	Cluster        string
+	Status         string
+	LaunchType     string
+	TaskDefinition string
+	Desired        int
+	Running        int
+	Pending        int
+	// Images are the images of the containers of the task definition, by
+	// container name
+	Images    map[string]string
+	CreatedAt time.Time
+}
+
+// ECSCluster is an ECS cluster with the counts of its services and tasks
+type ECSCluster struct {
+	Name           string
+	ARN            string
+	Status         string
+	ActiveServices int
+	RunningTasks   int
+	PendingTasks   int
+}
+
+// ECSContainer is a container of a task
+type ECSContainer struct {
+	Name         string
+	Image        string
+	LastStatus   string
+	HealthStatus string
+	// ExitCode is set once the container stopped
+	ExitCode *int
+	// Reason is why the container stopped or failed to start
+	Reason string
+}
+
+// ECSTask is a task of a service with its containers
+type ECSTask struct {
+	ID             string
+	ARN            string
+	TaskDefinition string
+	LastStatus     string
+	DesiredStatus  string
+	HealthStatus   string
+	LaunchType     string
+	Zone           string
+	CPU            string
+	Memory         string
+	CreatedAt      time.Time
+	StartedAt      time.Time
+	StoppedAt      time.Time
+	// StoppedReason is why a stopped task stopped
+	StoppedReason string
+	Containers    []ECSContainer
+}
+
+// Stopped reports whether the task stopped or is stopping
+func (t ECSTask) Stopped() bool {
+	return t.DesiredStatus == "STOPPED"
+}
+
+// ECSService lists ECS services
+type ECSService struct {
+	client *ecs.Client
+}
+
+// NewECSService creates a new ECS service
+func NewECSService(client *ecs.Client) (*ECSService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("ECS client not provided")
+	}
+	return &ECSService{client: client}, nil
+}
+
+// ListServices returns the services of every cluster with the images of
+// their task definitions. Clusters and task definitions that fail to load
+// are returned as a PartialError together with the rest.
+func (s *ECSService) ListServices(ctx context.Context) ([]ECSServiceDetail, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("ECS service not initialized")
+	}
+
+	clusters, err := s.listClusters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ECS clusters: %w", err)
+	}
+
+	region := s.client.Options().Region
+	var failures failureCollector
+	var services []ECSServiceDetail
+	for _, cluster := range clusters {
+		found, err := s.clusterServices(ctx, cluster)
+		if err != nil {
+			failures.add(ecsName(cluster), region, err)
+			continue
+		}
+		services = append(services, found...)
+	}
+
+	images := make(map[string]map[string]string)
+	for i, service := range services {
+		if service.TaskDefinition == "" {
+			continue
+		}
+		containers, ok := images[service.TaskDefinition]
+		if !ok {
+			containers, err = s.taskDefinitionImages(ctx, service.TaskDefinition)
+			if err != nil {
+				failures.add(ecsName(service.TaskDefinition), region, err)
+			}
+			images[service.TaskDefinition] = containers
+		}
+		services[i].Images = containers
+	}
+
+	sort.SliceStable(services, func(i, j int) bool {
+		if services[i].Cluster != services[j].Cluster {
+			return services[i].Cluster < services[j].Cluster
+		}
+		return services[i].Name < services[j].Name
+	})
+	return services, failures.err("ecs:ListServices")
+}
+
+// listClusters returns the ARNs of the clusters
+func (s *ECSService) listClusters(ctx context.Context) ([]string, error) {
+	var arns []string
+	paginator := ecs.NewListClustersPaginator(s.client, &ecs.ListClustersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		arns = append(arns, output.ClusterArns...)
+	}
+	return arns, nil
+}
+
+// ListClusters returns the clusters with the counts of their services and
+// tasks, sorted by name
+func (s *ECSService) ListClusters(ctx context.Context) ([]ECSCluster, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("ECS service not initialized")
+	}
+
+	arns, err := s.listClusters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ECS clusters: %w", err)
+	}
+
+	clusters := make([]ECSCluster, 0, len(arns))
+	for start := 0; start < len(arns); start += ecsTaskBatch {
+		output, err := s.client.DescribeClusters(ctx, &ecs.DescribeClustersInput{
+			Clusters: arns[start:min(start+ecsTaskBatch, len(arns))],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe ECS clusters: %w", err)
+		}
+		for _, cluster := range output.Clusters {
+			clusters = append(clusters, ECSCluster{
+				Name:           aws.ToString(cluster.ClusterName),
+				ARN:            aws.ToString(cluster.ClusterArn),
+				Status:         aws.ToString(cluster.Status),
+				ActiveServices: int(cluster.ActiveServicesCount),
+				RunningTasks:   int(cluster.RunningTasksCount),
+				PendingTasks:   int(cluster.PendingTasksCount),
+			})
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+	return clusters, nil
+}
+
+// ListTasks returns the tasks of service in cluster with their containers:
+// the running ones, then those that stopped, which ECS keeps for about an
+// hour, newest first within each
+func (s *ECSService) ListTasks(ctx context.Context, cluster, service string) ([]ECSTask, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("ECS service not initialized")
+	}
+
+	var arns []string
+	for _, desired := range []types.DesiredStatus{types.DesiredStatusRunning, types.DesiredStatusStopped} {
+		paginator := ecs.NewListTasksPaginator(s.client, &ecs.ListTasksInput{
+			Cluster:       aws.String(cluster),
+			ServiceName:   aws.String(service),
+			DesiredStatus: desired,
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list the tasks of %s: %w", service, err)
+			}
+			arns = append(arns, output.TaskArns...)
+		}
+	}
+
+	tasks := make([]ECSTask, 0, len(arns))
+	for start := 0; start < len(arns); start += ecsTaskBatch {
+		output, err := s.client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+			Cluster: aws.String(cluster),
+			Tasks:   arns[start:min(start+ecsTaskBatch, len(arns))],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe the tasks of %s: %w", service, err)
+		}
+		for _, task := range output.Tasks {
+			tasks = append(tasks, ecsTask(task))
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Stopped() != tasks[j].Stopped() {
+			return !tasks[i].Stopped()
+		}
+		return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+	})
+	return tasks, nil
+}
+
+// ecsTask converts a described task, with its containers by name
+func ecsTask(t types.Task) ECSTask {
+	task := ECSTask{
+		ID:             ecsName(aws.ToString(t.TaskArn)),
+		ARN:            aws.ToString(t.TaskArn),
+		TaskDefinition: ecsName(aws.ToString(t.TaskDefinitionArn)),
+		LastStatus:     aws.ToString(t.LastStatus),
+		DesiredStatus:  aws.ToString(t.DesiredStatus),
+		HealthStatus:   string(t.HealthStatus),
+		LaunchType:     string(t.LaunchType),
+		Zone:           aws.ToString(t.AvailabilityZone),
+		CPU:            aws.ToString(t.Cpu),
+		Memory:         aws.ToString(t.Memory),
+		CreatedAt:      aws.ToTime(t.CreatedAt),
+		StartedAt:      aws.ToTime(t.StartedAt),
+		StoppedAt:      aws.ToTime(t.StoppedAt),
+		StoppedReason:  aws.ToString(t.StoppedReason),
+	}
+	for _, container := range t.Containers {
+		var exitCode *int
+		if container.ExitCode != nil {
+			code := int(*container.ExitCode)
+			exitCode = &code
+		}
+		task.Containers = append(task.Containers, ECSContainer{
+			Name:         aws.ToString(container.Name),
+			Image:        aws.ToString(container.Image),
+			LastStatus:   aws.ToString(container.LastStatus),
+			HealthStatus: string(container.HealthStatus),
+			ExitCode:     exitCode,
+			Reason:       aws.ToString(container.Reason),
+		})
+	}
+	sort.Slice(task.Containers, func(i, j int) bool { return task.Containers[i].Name < task.Containers[j].Name })
+	return task
+}
+
+// clusterServices returns the services of the cluster ARN cluster
+func (s *ECSService) clusterServices(ctx context.Context, cluster string) ([]ECSServiceDetail, error) {
+	var arns []string
+	paginator := ecs.NewListServicesPaginator(s.client, &ecs.ListServicesInput{
+		Cluster:    aws.String(cluster),
+		MaxResults: aws.Int32(100),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		arns = append(arns, output.ServiceArns...)
+	}
+
+	services := make([]ECSServiceDetail, 0, len(arns))
+	for start := 0; start < len(arns); start += ecsDescribeBatch {
+		output, err := s.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+			Cluster:  aws.String(cluster),
+			Services: arns[start:min(start+ecsDescribeBatch, len(arns))],
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range output.Services {
+			services = append(services, ECSServiceDetail{
+				Name:           aws.ToString(service.ServiceName),
+				ARN:            aws.ToString(service.ServiceArn),
+				Cluster:        ecsName(cluster),
+				Status:         aws.ToString(service.Status),
+				LaunchType:     string(service.LaunchType),
+				TaskDefinition: aws.ToString(service.TaskDefinition),
+				Desired:        int(service.DesiredCount),
+				Running:        int(service.RunningCount),
+				Pending:        int(service.PendingCount),
+				CreatedAt:      aws.ToTime(service.CreatedAt),
+			})
+		}
+	}
+	return services, nil
+}
+
+// taskDefinitionImages returns the images of the containers of the task
+// definition ARN taskDefinition by container name
+func (s *ECSService) taskDefinitionImages(ctx context.Context, taskDefinition string) (map[string]string, error) {
+	output, err := s.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(taskDefinition),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if output.TaskDefinition == nil {
+		return map[string]string{}, nil
+	}
+
+	images := make(map[string]string, len(output.TaskDefinition.ContainerDefinitions))
+	for _, container := range output.TaskDefinition.ContainerDefinitions {
+		images[aws.ToString(container.Name)] = aws.ToString(container.Image)
+	}
+	return images, nil
+}
+
+// ecsName returns the name at the end of an ECS ARN, e.g. shop for
+// arn:aws:ecs:eu-west-1:123456789012:cluster/shop or web:42 for a task
+// definition
+func ecsName(arn string) string {
+	if i := strings.LastIndex(arn, "/"); i >= 0 {
+		return arn[i+1:]
+	}
+	return arn
+}
@@ -0,0 +1,140 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+func TestECSListServices(t *testing.T) {
+	var describes int
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		var input map[string]any
+		json.NewDecoder(r.Body).Decode(&input)
+
+		switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonEC2ContainerServiceV20141113.") {
+		case "ListClusters":
+			w.Write([]byte(`{"clusterArns":["arn:aws:ecs:eu-west-1:123456789012:cluster/shop","arn:aws:ecs:eu-west-1:123456789012:cluster/locked"]}`))
+		case "ListServices":
+			if input["cluster"] == "arn:aws:ecs:eu-west-1:123456789012:cluster/locked" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"__type":"AccessDeniedException","message":"not authorized"}`))
+				return
+			}
+			if input["nextToken"] == nil {
+				w.Write([]byte(`{"serviceArns":["arn:aws:ecs:eu-west-1:123456789012:service/shop/web"],"nextToken":"p2"}`))
+				return
+			}
+			w.Write([]byte(`{"serviceArns":["arn:aws:ecs:eu-west-1:123456789012:service/shop/api"]}`))
+		case "DescribeServices":
+			describes++
+			w.Write([]byte(`{"services":[
+				{"serviceName":"web","serviceArn":"arn:aws:ecs:eu-west-1:123456789012:service/shop/web","status":"ACTIVE","launchType":"FARGATE",
+				 "taskDefinition":"arn:aws:ecs:eu-west-1:123456789012:task-definition/web:7","desiredCount":2,"runningCount":1,"pendingCount":1,"createdAt":1.7e9},
+				{"serviceName":"api","serviceArn":"arn:aws:ecs:eu-west-1:123456789012:service/shop/api","status":"ACTIVE",
+				 "taskDefinition":"arn:aws:ecs:eu-west-1:123456789012:task-definition/web:7","desiredCount":1,"runningCount":1}]}`))
+		case "DescribeTaskDefinition":
+			w.Write([]byte(`{"taskDefinition":{"containerDefinitions":[{"name":"app","image":"123456789012.dkr.ecr.eu-west-1.amazonaws.com/web:1.4"},{"name":"envoy","image":"envoyproxy/envoy:v1.31"}]}}`))
+		default:
+			t.Errorf("Unexpected target %q", r.Header.Get("X-Amz-Target"))
+		}
+	})
+
+	svc, err := NewECSService(ecs.NewFromConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	services, err := svc.ListServices(context.Background())
+	var partial *PartialError
+	if !errors.As(err, &partial) || len(partial.Failures) != 1 || partial.Failures[0].Item != "locked" {
+		t.Errorf("Expected the locked cluster to fail, got %v", err)
+	}
+	if len(services) != 2 || describes != 1 {
+		t.Fatalf("Expected both services of shop in one call, got %+v in %d calls", services, describes)
+	}
+	if s := services[0]; s.Name != "api" || s.Cluster != "shop" {
+		t.Errorf("Expected the services sorted by name, got %+v", s)
+	}
+	s := services[1]
+	if s.Desired != 2 || s.Running != 1 || s.Pending != 1 || s.LaunchType != "FARGATE" || s.CreatedAt.Unix() != 1700000000 {
+		t.Errorf("Unexpected service %+v", s)
+	}
+	if s.Images["app"] != "123456789012.dkr.ecr.eu-west-1.amazonaws.com/web:1.4" || s.Images["envoy"] != "envoyproxy/envoy:v1.31" {
+		t.Errorf("Expected the images of the task definition, got %v", s.Images)
+	}
+}
+
+func TestECSListClustersAndTasks(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		var input map[string]any
+		json.NewDecoder(r.Body).Decode(&input)
+
+		switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonEC2ContainerServiceV20141113.") {
+		case "ListClusters":
+			w.Write([]byte(`{"clusterArns":["arn:aws:ecs:eu-west-1:123456789012:cluster/shop","arn:aws:ecs:eu-west-1:123456789012:cluster/batch"]}`))
+		case "DescribeClusters":
+			w.Write([]byte(`{"clusters":[
+				{"clusterName":"shop","clusterArn":"arn:aws:ecs:eu-west-1:123456789012:cluster/shop","status":"ACTIVE","activeServicesCount":2,"runningTasksCount":3,"pendingTasksCount":1},
+				{"clusterName":"batch","clusterArn":"arn:aws:ecs:eu-west-1:123456789012:cluster/batch","status":"ACTIVE"}]}`))
+		case "ListTasks":
+			if input["serviceName"] != "web" {
+				t.Errorf("Expected the tasks of web, got %v", input)
+			}
+			if input["desiredStatus"] == "RUNNING" {
+				w.Write([]byte(`{"taskArns":["arn:aws:ecs:eu-west-1:123456789012:task/shop/old","arn:aws:ecs:eu-west-1:123456789012:task/shop/new"]}`))
+				return
+			}
+			w.Write([]byte(`{"taskArns":["arn:aws:ecs:eu-west-1:123456789012:task/shop/crashed"]}`))
+		case "DescribeTasks":
+			if tasks, _ := input["tasks"].([]any); len(tasks) != 3 {
+				t.Errorf("Expected the three tasks described at once, got %v", input["tasks"])
+			}
+			w.Write([]byte(`{"tasks":[
+				{"taskArn":"arn:aws:ecs:eu-west-1:123456789012:task/shop/crashed","taskDefinitionArn":"arn:aws:ecs:eu-west-1:123456789012:task-definition/web:7",
+				 "lastStatus":"STOPPED","desiredStatus":"STOPPED","createdAt":1.7e9,"stoppedReason":"Essential container in task exited",
+				 "containers":[{"name":"app","lastStatus":"STOPPED","exitCode":137,"reason":"OutOfMemoryError"}]},
+				{"taskArn":"arn:aws:ecs:eu-west-1:123456789012:task/shop/old","lastStatus":"RUNNING","desiredStatus":"RUNNING","createdAt":1.6e9},
+				{"taskArn":"arn:aws:ecs:eu-west-1:123456789012:task/shop/new","lastStatus":"PENDING","desiredStatus":"RUNNING","createdAt":1.65e9,
+				 "containers":[{"name":"envoy","lastStatus":"PENDING"},{"name":"app","lastStatus":"RUNNING","healthStatus":"HEALTHY"}]}]}`))
+		default:
+			t.Errorf("Unexpected target %q", r.Header.Get("X-Amz-Target"))
+		}
+	})
+
+	svc, err := NewECSService(ecs.NewFromConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clusters, err := svc.ListClusters(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clusters) != 2 || clusters[0].Name != "batch" {
+		t.Fatalf("Expected the clusters sorted by name, got %+v", clusters)
+	}
+	if shop := clusters[1]; shop.ActiveServices != 2 || shop.RunningTasks != 3 || shop.PendingTasks != 1 {
+		t.Errorf("Unexpected cluster %+v", shop)
+	}
+
+	tasks, err := svc.ListTasks(context.Background(), "shop", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 3 || tasks[0].ID != "new" || tasks[1].ID != "old" || tasks[2].ID != "crashed" {
+		t.Fatalf("Expected the running tasks newest first, then the stopped one, got %+v", tasks)
+	}
+	if containers := tasks[0].Containers; len(containers) != 2 || containers[0].Name != "app" || containers[0].HealthStatus != "HEALTHY" {
+		t.Errorf("Expected the containers by name, got %+v", containers)
+	}
+	crashed := tasks[2]
+	if !crashed.Stopped() || crashed.TaskDefinition != "web:7" || crashed.Containers[0].ExitCode == nil || *crashed.Containers[0].ExitCode != 137 {
+		t.Errorf("Unexpected stopped task %+v", crashed)
+	}
+}
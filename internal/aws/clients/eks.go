@@ -0,0 +1,242 @@
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+const (
+	// clusterIDHeader binds a presigned GetCallerIdentity call to the
+	// cluster it authenticates to
+	clusterIDHeader = "x-k8s-aws-id"
+	// tokenPrefix marks a Kubernetes bearer token as a presigned STS URL
+	tokenPrefix = "k8s-aws-v1."
+	// tokenLifetime is how long a token is reused. EKS accepts a token for
+	// 15 minutes after it was signed.
+	tokenLifetime = 10 * time.Minute
+)
+
+// EKSCluster describes an EKS cluster
+type EKSCluster struct {
+	Name            string
+	ARN             string
+	Status          string
+	Version         string
+	PlatformVersion string
+	Endpoint        string
+	// CertificateAuthority is the base64 encoded PEM of the cluster CA
+	CertificateAuthority string
+	// PublicEndpoint is set when the API server is reachable from the internet
+	PublicEndpoint bool
+	CreatedAt      time.Time
+}
+
+// EKSService wraps the EKS client. The workloads of a cluster are read from
+// its Kubernetes API, authenticated with a token presigned by STS.
+type EKSService struct {
+	client  *eks.Client
+	presign *sts.PresignClient
+
+	mu       sync.Mutex
+	clusters map[string]*kubeClient
+}
+
+// NewEKSService creates a new EKS service wrapper. stsClient signs the
+// tokens for the Kubernetes API of the clusters.
+func NewEKSService(client *eks.Client, stsClient *sts.Client) (*EKSService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("EKS client not provided")
+	}
+	if stsClient == nil {
+		return nil, fmt.Errorf("STS client not provided")
+	}
+
+	return &EKSService{
+		client:   client,
+		presign:  sts.NewPresignClient(stsClient),
+		clusters: make(map[string]*kubeClient),
+	}, nil
+}
+
+// ListClusters returns the clusters of the region by name. Clusters that
+// cannot be described are returned by name only, together with a
+// *PartialError naming them.
+func (s *EKSService) ListClusters(ctx context.Context) ([]EKSCluster, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("EKS service not initialized")
+	}
+
+	var names []string
+	paginator := eks.NewListClustersPaginator(s.client, &eks.ListClustersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %w", err)
+		}
+		names = append(names, output.Clusters...)
+	}
+	sort.Strings(names)
+
+	var failures failureCollector
+	clusters := make([]EKSCluster, 0, len(names))
+	for _, name := range names {
+		cluster, err := s.describeCluster(ctx, name)
+		if err != nil {
+			failures.add(name, "", err)
+			cluster = EKSCluster{Name: name}
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters, failures.err("ListClusters")
+}
+
+// describeCluster reads the endpoint and CA of the cluster name
+func (s *EKSService) describeCluster(ctx context.Context, name string) (EKSCluster, error) {
+	output, err := s.client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(name)})
+	if err != nil {
+		return EKSCluster{}, fmt.Errorf("failed to describe cluster %s: %w", name, err)
+	}
+
+	c := output.Cluster
+	cluster := EKSCluster{
+		Name:            aws.ToString(c.Name),
+		ARN:             aws.ToString(c.Arn),
+		Status:          string(c.Status),
+		Version:         aws.ToString(c.Version),
+		PlatformVersion: aws.ToString(c.PlatformVersion),
+		Endpoint:        aws.ToString(c.Endpoint),
+		CreatedAt:       aws.ToTime(c.CreatedAt),
+	}
+	if c.CertificateAuthority != nil {
+		cluster.CertificateAuthority = aws.ToString(c.CertificateAuthority.Data)
+	}
+	if c.ResourcesVpcConfig != nil {
+		cluster.PublicEndpoint = c.ResourcesVpcConfig.EndpointPublicAccess
+	}
+	return cluster, nil
+}
+
+// ListNamespaces returns the namespaces of the cluster by name
+func (s *EKSService) ListNamespaces(ctx context.Context, cluster string) ([]string, error) {
+	kube, err := s.kube(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return kube.ListNamespaces(ctx)
+}
+
+// ListDeployments returns the deployments of namespace, or of every
+// namespace if it is empty
+func (s *EKSService) ListDeployments(ctx context.Context, cluster, namespace string) ([]Deployment, error) {
+	kube, err := s.kube(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return kube.ListDeployments(ctx, namespace)
+}
+
+// ListPods returns the pods of namespace, or of every namespace if it is
+// empty
+func (s *EKSService) ListPods(ctx context.Context, cluster, namespace string) ([]Pod, error) {
+	kube, err := s.kube(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return kube.ListPods(ctx, namespace)
+}
+
+// StreamPodLogs sends the last tailLines lines of every container of pod to
+// lines and then follows them until ctx is done or the pod stops
+func (s *EKSService) StreamPodLogs(ctx context.Context, cluster string, pod Pod, tailLines int, lines chan<- PodLogLine) error {
+	kube, err := s.kube(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	return kube.StreamPodLogs(ctx, pod, tailLines, lines)
+}
+
+// kube returns the Kubernetes API client of the cluster name, describing
+// the cluster the first time it is used
+func (s *EKSService) kube(ctx context.Context, name string) (*kubeClient, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("EKS service not initialized")
+	}
+
+	s.mu.Lock()
+	kube, ok := s.clusters[name]
+	s.mu.Unlock()
+	if ok {
+		return kube, nil
+	}
+
+	cluster, err := s.describeCluster(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.Endpoint == "" {
+		return nil, fmt.Errorf("cluster %s has no API endpoint yet (status %s)", name, cluster.Status)
+	}
+
+	var token clusterToken
+	kube, err = newKubeClient(cluster.Endpoint, cluster.CertificateAuthority, func(ctx context.Context) (string, error) {
+		return token.get(ctx, func(ctx context.Context) (string, error) {
+			return s.token(ctx, name)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cluster %s: %w", name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clusters[name] = kube
+	return kube, nil
+}
+
+// token returns a bearer token for the cluster name: the presigned URL of
+// an STS GetCallerIdentity call bound to the cluster, as aws eks get-token
+// creates it
+func (s *EKSService) token(ctx context.Context, name string) (string, error) {
+	presigned, err := s.presign.PresignGetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}, func(o *sts.PresignOptions) {
+		o.ClientOptions = append(o.ClientOptions, sts.WithAPIOptions(
+			smithyhttp.AddHeaderValue(clusterIDHeader, name),
+			smithyhttp.AddHeaderValue("X-Amz-Expires", "60"),
+		))
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign a token for cluster %s: %w", name, err)
+	}
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(presigned.URL)), nil
+}
+
+// clusterToken reuses a token for tokenLifetime
+type clusterToken struct {
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// get returns the cached token, or a new one from sign once it expired
+func (t *clusterToken) get(ctx context.Context, sign func(context.Context) (string, error)) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Before(t.expires) {
+		return t.token, nil
+	}
+	token, err := sign(ctx)
+	if err != nil {
+		return "", err
+	}
+	t.token, t.expires = token, time.Now().Add(tokenLifetime)
+	return token, nil
+}
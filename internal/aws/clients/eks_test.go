@@ -0,0 +1,168 @@
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+func TestEKSToken(t *testing.T) {
+	stsClient := sts.New(sts.Options{
+		Region:      "eu-west-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "secret", ""),
+	})
+	svc, err := NewEKSService(eks.New(eks.Options{Region: "eu-west-1"}), stsClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := svc.token(context.Background(), "shop-prod")
+	if err != nil {
+		t.Fatalf("Failed to sign a token: %v", err)
+	}
+	if !strings.HasPrefix(token, tokenPrefix) {
+		t.Fatalf("Expected the token to start with %s, got %s", tokenPrefix, token)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, tokenPrefix))
+	if err != nil {
+		t.Fatalf("Expected unpadded base64url after the prefix: %v", err)
+	}
+	presigned, err := url.Parse(string(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := presigned.Query()
+	if presigned.Host != "sts.eu-west-1.amazonaws.com" || query.Get("Action") != "GetCallerIdentity" {
+		t.Errorf("Expected a regional GetCallerIdentity URL, got %s", raw)
+	}
+	if !strings.Contains(query.Get("X-Amz-SignedHeaders"), clusterIDHeader) {
+		t.Errorf("Expected the cluster header to be signed, got %q", query.Get("X-Amz-SignedHeaders"))
+	}
+}
+
+// testAPIServer serves namespaces, deployments, pods and logs like the
+// Kubernetes API and denies the kube-system namespace
+func testAPIServer(t *testing.T) *kubeClient {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer k8s-aws-v1.test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/namespaces":
+			if r.URL.Query().Get("continue") == "" {
+				w.Write([]byte(`{"metadata":{"continue":"page2"},"items":[{"metadata":{"name":"shop"}}]}`))
+				return
+			}
+			w.Write([]byte(`{"metadata":{},"items":[{"metadata":{"name":"default"}}]}`))
+		case "/apis/apps/v1/namespaces/shop/deployments":
+			w.Write([]byte(`{"items":[{"metadata":{"name":"cart","namespace":"shop"},"spec":{"replicas":3,"template":{"spec":{"containers":[{"name":"app","image":"nginx:1.25"}]}}},"status":{"readyReplicas":2,"updatedReplicas":3,"availableReplicas":2}}]}`))
+		case "/api/v1/pods":
+			w.Write([]byte(`{"items":[
+				{"metadata":{"name":"cart-1","namespace":"shop"},"spec":{"nodeName":"ip-10-0-1-5","containers":[{"name":"app"},{"name":"envoy"}]},
+				 "status":{"phase":"Running","containerStatuses":[
+					{"name":"app","ready":false,"restartCount":7,"state":{"waiting":{"reason":"CrashLoopBackOff"}}},
+					{"name":"envoy","ready":true,"restartCount":0,"state":{"running":{}}}]}},
+				{"metadata":{"name":"cart-2","namespace":"shop","deletionTimestamp":"2026-10-15T08:00:00Z"},"spec":{"containers":[{"name":"app"}]},"status":{"phase":"Running"}}]}`))
+		case "/api/v1/namespaces/shop/pods/cart-1/log":
+			if r.URL.Query().Get("container") != "app" || r.URL.Query().Get("follow") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte("2026-10-15T08:00:01.5Z starting\n2026-10-15T08:00:02Z listening on :8080\n"))
+		case "/api/v1/namespaces/kube-system/pods":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"kind":"Status","reason":"Forbidden","message":"pods is forbidden"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	kube, err := newKubeClient(server.URL, base64.StdEncoding.EncodeToString(ca), func(ctx context.Context) (string, error) {
+		return "k8s-aws-v1.test", nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to create the client: %v", err)
+	}
+	return kube
+}
+
+func TestKubeClient(t *testing.T) {
+	ctx := context.Background()
+	kube := testAPIServer(t)
+
+	namespaces, err := kube.ListNamespaces(ctx)
+	if err != nil || strings.Join(namespaces, ",") != "default,shop" {
+		t.Errorf("Expected both pages of namespaces sorted, got %v, %v", namespaces, err)
+	}
+
+	deployments, err := kube.ListDeployments(ctx, "shop")
+	if err != nil || len(deployments) != 1 {
+		t.Fatalf("Expected 1 deployment, got %v, %v", deployments, err)
+	}
+	if d := deployments[0]; d.Replicas != 3 || d.Ready != 2 || d.UpToDate != 3 || d.Available != 2 || d.Images["app"] != "nginx:1.25" {
+		t.Errorf("Unexpected deployment %+v", d)
+	}
+
+	pods, err := kube.ListPods(ctx, "")
+	if err != nil || len(pods) != 2 {
+		t.Fatalf("Expected 2 pods, got %v, %v", pods, err)
+	}
+	if p := pods[0]; p.Status != "CrashLoopBackOff" || p.Ready != 1 || p.Restarts != 7 || len(p.Containers) != 2 || p.Node != "ip-10-0-1-5" {
+		t.Errorf("Unexpected pod %+v", p)
+	}
+	if p := pods[1]; p.Status != "Terminating" {
+		t.Errorf("Expected a deleted pod to be terminating, got %+v", p)
+	}
+
+	_, err = kube.ListPods(ctx, "kube-system")
+	if got := ErrorReason(err); got != "access denied" {
+		t.Errorf("Expected a forbidden namespace to be access denied, got %q (%v)", got, err)
+	}
+}
+
+func TestKubeClientStreamPodLogs(t *testing.T) {
+	kube := testAPIServer(t)
+	lines := make(chan PodLogLine, 10)
+
+	pod := Pod{Name: "cart-1", Namespace: "shop", Containers: []string{"app"}}
+	if err := kube.StreamPodLogs(context.Background(), pod, 100, lines); err != nil {
+		t.Fatalf("Failed to stream logs: %v", err)
+	}
+	close(lines)
+
+	var got []PodLogLine
+	for line := range lines {
+		got = append(got, line)
+	}
+	if len(got) != 2 || got[1].Message != "listening on :8080" || got[0].Container != "app" || got[0].Timestamp.Nanosecond() != 500_000_000 {
+		t.Errorf("Unexpected log lines %+v", got)
+	}
+
+	// A pod none of whose containers can be read fails
+	pod.Containers = []string{"missing"}
+	if err := kube.StreamPodLogs(context.Background(), pod, 100, make(chan PodLogLine, 1)); err == nil {
+		t.Error("Expected an error when no container log can be opened")
+	}
+}
+
+func TestNewKubeClientRejectsBadCA(t *testing.T) {
+	token := func(ctx context.Context) (string, error) { return "", nil }
+	for _, ca := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte("no pem here"))} {
+		if _, err := newKubeClient("https://example.eks.amazonaws.com", ca, token); err == nil {
+			t.Errorf("Expected CA %q to be rejected", ca)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+)
+
+// LoadBalancerDetail describes an Application, Network or Gateway Load
+// Balancer and how many targets are registered behind it
+type LoadBalancerDetail struct {
+	Name        string
+	ARN         string
+	Type        string
+	State       string
+	CreatedTime *time.Time
+	TargetCount int
+}
+
+// ELBService wraps the Elastic Load Balancing v2 client
+type ELBService struct {
+	client *elb.Client
+}
+
+// NewELBService creates a new Elastic Load Balancing service wrapper
+func NewELBService(client *elb.Client) (*ELBService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("ELB client not provided")
+	}
+
+	return &ELBService{
+		client: client,
+	}, nil
+}
+
+// DescribeLoadBalancers returns the load balancers of the region with the
+// number of targets registered in their target groups. Target groups whose
+// health could not be read are reported in a PartialError.
+func (s *ELBService) DescribeLoadBalancers(ctx context.Context) ([]LoadBalancerDetail, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("ELB service not initialized")
+	}
+
+	var balancers []LoadBalancerDetail
+	index := make(map[string]int)
+	lbPaginator := elb.NewDescribeLoadBalancersPaginator(s.client, &elb.DescribeLoadBalancersInput{})
+	for lbPaginator.HasMorePages() {
+		output, err := lbPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe load balancers: %w", err)
+		}
+		for _, lb := range output.LoadBalancers {
+			detail := LoadBalancerDetail{
+				Name:        aws.ToString(lb.LoadBalancerName),
+				ARN:         aws.ToString(lb.LoadBalancerArn),
+				Type:        string(lb.Type),
+				CreatedTime: lb.CreatedTime,
+			}
+			if lb.State != nil {
+				detail.State = string(lb.State.Code)
+			}
+			index[detail.ARN] = len(balancers)
+			balancers = append(balancers, detail)
+		}
+	}
+
+	var failures failureCollector
+	tgPaginator := elb.NewDescribeTargetGroupsPaginator(s.client, &elb.DescribeTargetGroupsInput{})
+	for tgPaginator.HasMorePages() {
+		output, err := tgPaginator.NextPage(ctx)
+		if err != nil {
+			return balancers, fmt.Errorf("failed to describe target groups: %w", err)
+		}
+		for _, group := range output.TargetGroups {
+			if len(group.LoadBalancerArns) == 0 {
+				continue
+			}
+
+			health, err := s.client.DescribeTargetHealth(ctx, &elb.DescribeTargetHealthInput{
+				TargetGroupArn: group.TargetGroupArn,
+			})
+			if err != nil {
+				failures.add(aws.ToString(group.TargetGroupName), "", err)
+				continue
+			}
+			for _, arn := range group.LoadBalancerArns {
+				if i, ok := index[arn]; ok {
+					balancers[i].TargetCount += len(health.TargetHealthDescriptions)
+				}
+			}
+		}
+	}
+
+	return balancers, failures.err("DescribeTargetHealth")
+}
@@ -0,0 +1,150 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/health"
+	"github.com/aws/aws-sdk-go-v2/service/health/types"
+)
+
+// HealthEvent is an AWS Health event: a service issue, a scheduled change
+// or an account notification
+type HealthEvent struct {
+	ARN     string
+	Service string
+	// Type is the event type code, e.g. AWS_EC2_OPERATIONAL_ISSUE
+	Type string
+	// Category is issue, scheduledChange, accountNotification or investigation
+	Category         string
+	Status           string
+	Region           string
+	AvailabilityZone string
+	// AccountSpecific is set for events that affect resources of the account,
+	// as opposed to public events of a service
+	AccountSpecific bool
+	Start           time.Time
+	End             time.Time
+	LastUpdated     time.Time
+}
+
+// AffectedEntity is a resource affected by a Health event
+type AffectedEntity struct {
+	Value string
+	ARN   string
+	// Status is IMPAIRED, UNIMPAIRED, UNKNOWN, PENDING or RESOLVED
+	Status      string
+	URL         string
+	LastUpdated time.Time
+}
+
+// HealthService wraps the Health client
+type HealthService struct {
+	client *health.Client
+}
+
+// NewHealthService creates a new Health service wrapper
+func NewHealthService(client *health.Client) (*HealthService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("Health client not provided")
+	}
+
+	return &HealthService{
+		client: client,
+	}, nil
+}
+
+// ListOpenEvents returns the open and upcoming events of the account's
+// services and resources
+func (s *HealthService) ListOpenEvents(ctx context.Context) ([]HealthEvent, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("Health service not initialized")
+	}
+
+	input := &health.DescribeEventsInput{
+		Filter: &types.EventFilter{
+			EventStatusCodes: []types.EventStatusCode{types.EventStatusCodeOpen, types.EventStatusCodeUpcoming},
+		},
+	}
+
+	var events []HealthEvent
+	paginator := health.NewDescribeEventsPaginator(s.client, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe health events: %w", supportError(err))
+		}
+		for _, event := range output.Events {
+			events = append(events, HealthEvent{
+				ARN:              aws.ToString(event.Arn),
+				Service:          aws.ToString(event.Service),
+				Type:             aws.ToString(event.EventTypeCode),
+				Category:         string(event.EventTypeCategory),
+				Status:           string(event.StatusCode),
+				Region:           aws.ToString(event.Region),
+				AvailabilityZone: aws.ToString(event.AvailabilityZone),
+				AccountSpecific:  event.EventScopeCode == types.EventScopeCodeAccountSpecific,
+				Start:            aws.ToTime(event.StartTime),
+				End:              aws.ToTime(event.EndTime),
+				LastUpdated:      aws.ToTime(event.LastUpdatedTime),
+			})
+		}
+	}
+	return events, nil
+}
+
+// GetEventDescription returns the latest description of the event with arn
+func (s *HealthService) GetEventDescription(ctx context.Context, arn string) (string, error) {
+	if s == nil || s.client == nil {
+		return "", fmt.Errorf("Health service not initialized")
+	}
+
+	output, err := s.client.DescribeEventDetails(ctx, &health.DescribeEventDetailsInput{
+		EventArns: []string{arn},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe health event: %w", supportError(err))
+	}
+	for _, failed := range output.FailedSet {
+		return "", fmt.Errorf("failed to describe health event: %s: %s", aws.ToString(failed.ErrorName), aws.ToString(failed.ErrorMessage))
+	}
+	for _, details := range output.SuccessfulSet {
+		if details.EventDescription != nil {
+			return aws.ToString(details.EventDescription.LatestDescription), nil
+		}
+	}
+	return "", nil
+}
+
+// ListAffectedEntities returns the resources of the account affected by the
+// event with arn
+func (s *HealthService) ListAffectedEntities(ctx context.Context, arn string) ([]AffectedEntity, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("Health service not initialized")
+	}
+
+	input := &health.DescribeAffectedEntitiesInput{
+		Filter: &types.EntityFilter{EventArns: []string{arn}},
+	}
+
+	var entities []AffectedEntity
+	paginator := health.NewDescribeAffectedEntitiesPaginator(s.client, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe affected entities: %w", supportError(err))
+		}
+		for _, entity := range output.Entities {
+			entities = append(entities, AffectedEntity{
+				Value:       aws.ToString(entity.EntityValue),
+				ARN:         aws.ToString(entity.EntityArn),
+				Status:      string(entity.StatusCode),
+				URL:         aws.ToString(entity.EntityUrl),
+				LastUpdated: aws.ToTime(entity.LastUpdatedTime),
+			})
+		}
+	}
+	return entities, nil
+}
@@ -0,0 +1,312 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/workpool"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+// serviceLastAccessedPoll is how often a services last accessed report is
+// checked while IAM generates it
+var serviceLastAccessedPoll = 2 * time.Second
+
+// IAMRole is an IAM role with its trust policy and when it was last used
+type IAMRole struct {
+	Name        string
+	ID          string
+	ARN         string
+	Path        string
+	Description string
+	CreatedAt   time.Time
+	// LastUsed is when the role was last assumed, zero if it was not within
+	// the 400 days IAM tracks
+	LastUsed           time.Time
+	LastUsedRegion     string
+	MaxSessionDuration int
+	// TrustPolicy is the JSON policy document saying who may assume the role
+	TrustPolicy string
+}
+
+// ServiceLinked reports whether the role is linked to an AWS service, which
+// creates and deletes it
+func (r IAMRole) ServiceLinked() bool {
+	return strings.HasPrefix(r.Path, "/aws-service-role/")
+}
+
+// TrustStatement is a statement of a trust policy
+type TrustStatement struct {
+	Effect string
+	// Principals are who the statement is about, e.g. "Service:
+	// ec2.amazonaws.com" or "AWS: arn:aws:iam::123456789012:root"
+	Principals []string
+	Actions    []string
+	// Conditions are e.g. "StringEquals sts:ExternalId = partner-7"
+	Conditions []string
+}
+
+// policyValues is a policy element given as a string or a list of strings
+type policyValues []string
+
+func (v *policyValues) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*v = policyValues{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*v = list
+	return nil
+}
+
+// ParseTrustPolicy decodes the statements of the trust policy document, in
+// the order they appear
+func ParseTrustPolicy(document string) ([]TrustStatement, error) {
+	var policy struct {
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(document), &policy); err != nil {
+		return nil, fmt.Errorf("invalid trust policy: %w", err)
+	}
+
+	type statement struct {
+		Effect       string                             `json:"Effect"`
+		Principal    json.RawMessage                    `json:"Principal"`
+		NotPrincipal json.RawMessage                    `json:"NotPrincipal"`
+		Action       policyValues                       `json:"Action"`
+		Condition    map[string]map[string]policyValues `json:"Condition"`
+	}
+	var raw []statement
+	if err := json.Unmarshal(policy.Statement, &raw); err != nil {
+		// A policy with one statement may give it as an object
+		var single statement
+		if err := json.Unmarshal(policy.Statement, &single); err != nil {
+			return nil, fmt.Errorf("invalid trust policy statement: %w", err)
+		}
+		raw = []statement{single}
+	}
+
+	statements := make([]TrustStatement, 0, len(raw))
+	for _, s := range raw {
+		stmt := TrustStatement{Effect: s.Effect, Actions: s.Action}
+		principals, err := parsePrincipals(s.Principal, "")
+		if err != nil {
+			return nil, err
+		}
+		notPrincipals, err := parsePrincipals(s.NotPrincipal, "not ")
+		if err != nil {
+			return nil, err
+		}
+		stmt.Principals = append(principals, notPrincipals...)
+
+		for operator, keys := range s.Condition {
+			for key, values := range keys {
+				stmt.Conditions = append(stmt.Conditions, fmt.Sprintf("%s %s = %s", operator, key, strings.Join(values, ", ")))
+			}
+		}
+		sort.Strings(stmt.Conditions)
+		statements = append(statements, stmt)
+	}
+	return statements, nil
+}
+
+// parsePrincipals lists the principals of a Principal element, "*" or a
+// map of principal types to one or more principals, prefixed with prefix
+func parsePrincipals(data json.RawMessage, prefix string) ([]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var anyone string
+	if err := json.Unmarshal(data, &anyone); err == nil {
+		return []string{prefix + anyone}, nil
+	}
+	var byType map[string]policyValues
+	if err := json.Unmarshal(data, &byType); err != nil {
+		return nil, fmt.Errorf("invalid principal: %w", err)
+	}
+
+	types := make([]string, 0, len(byType))
+	for typ := range byType {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+
+	var principals []string
+	for _, typ := range types {
+		for _, principal := range byType[typ] {
+			principals = append(principals, fmt.Sprintf("%s%s: %s", prefix, typ, principal))
+		}
+	}
+	return principals, nil
+}
+
+// ServiceLastAccessed is when a role last used a service its policies allow
+type ServiceLastAccessed struct {
+	Service   string
+	Namespace string
+	// LastAuthenticated is zero if the service was not used within the
+	// tracking period
+	LastAuthenticated       time.Time
+	LastAuthenticatedRegion string
+}
+
+// IAMService reads IAM roles
+type IAMService struct {
+	client *iam.Client
+}
+
+// NewIAMService creates a new IAM service. IAM is global; the client
+// resolves its endpoint from the partition of its region.
+func NewIAMService(client *iam.Client) (*IAMService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("IAM client not provided")
+	}
+	return &IAMService{client: client}, nil
+}
+
+// iamRole converts r, decoding its URL encoded trust policy
+func iamRole(r types.Role) IAMRole {
+	document := aws.ToString(r.AssumeRolePolicyDocument)
+	policy, err := url.PathUnescape(document)
+	if err != nil {
+		policy = document
+	}
+	role := IAMRole{
+		Name:               aws.ToString(r.RoleName),
+		ID:                 aws.ToString(r.RoleId),
+		ARN:                aws.ToString(r.Arn),
+		Path:               aws.ToString(r.Path),
+		Description:        aws.ToString(r.Description),
+		CreatedAt:          aws.ToTime(r.CreateDate),
+		MaxSessionDuration: int(aws.ToInt32(r.MaxSessionDuration)),
+		TrustPolicy:        policy,
+	}
+	if r.RoleLastUsed != nil {
+		role.LastUsed = aws.ToTime(r.RoleLastUsed.LastUsedDate)
+		role.LastUsedRegion = aws.ToString(r.RoleLastUsed.Region)
+	}
+	return role
+}
+
+// ListRoles returns the roles of the account sorted by name, with when they
+// were last used. ListRoles leaves that out, so each role is also read with
+// GetRole in the "iam" slots of the worker pool; roles that fail to read are
+// returned as listed, with a PartialError naming them.
+func (s *IAMService) ListRoles(ctx context.Context) ([]IAMRole, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("IAM service not initialized")
+	}
+
+	var roles []IAMRole
+	paginator := iam.NewListRolesPaginator(s.client, &iam.ListRolesInput{MaxItems: aws.Int32(1000)})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roles: %w", err)
+		}
+		for _, role := range output.Roles {
+			roles = append(roles, iamRole(role))
+		}
+	}
+
+	var failures failureCollector
+	err := workpool.Each(ctx, "iam", len(roles), func(i int) {
+		output, err := s.client.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(roles[i].Name)})
+		if err != nil {
+			failures.add(roles[i].Name, "", err)
+			return
+		}
+		if output.Role != nil && output.Role.RoleLastUsed != nil {
+			roles[i].LastUsed = aws.ToTime(output.Role.RoleLastUsed.LastUsedDate)
+			roles[i].LastUsedRegion = aws.ToString(output.Role.RoleLastUsed.Region)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading roles cancelled: %w", err)
+	}
+
+	sort.SliceStable(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	return roles, failures.err("iam:GetRole")
+}
+
+// GetServiceLastAccessed returns when the role arn last used each service
+// its policies allow, the most recent first and unused services last. IAM
+// generates the report on request, which takes a few seconds.
+func (s *IAMService) GetServiceLastAccessed(ctx context.Context, arn string) ([]ServiceLastAccessed, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("IAM service not initialized")
+	}
+
+	job, err := s.client.GenerateServiceLastAccessedDetails(ctx, &iam.GenerateServiceLastAccessedDetailsInput{Arn: aws.String(arn)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to request the services last accessed by %s: %w", arn, err)
+	}
+
+	var services []ServiceLastAccessed
+	input := &iam.GetServiceLastAccessedDetailsInput{JobId: job.JobId}
+	for {
+		output, err := s.client.GetServiceLastAccessedDetails(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the services last accessed by %s: %w", arn, err)
+		}
+
+		switch output.JobStatus {
+		case types.JobStatusTypeInProgress:
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(serviceLastAccessedPoll):
+			}
+			continue
+		case types.JobStatusTypeFailed:
+			var reason string
+			if output.Error != nil {
+				reason = aws.ToString(output.Error.Message)
+			}
+			return nil, fmt.Errorf("IAM failed to report the services last accessed by %s: %s", arn, reason)
+		}
+
+		for _, service := range output.ServicesLastAccessed {
+			services = append(services, ServiceLastAccessed{
+				Service:                 aws.ToString(service.ServiceName),
+				Namespace:               aws.ToString(service.ServiceNamespace),
+				LastAuthenticated:       aws.ToTime(service.LastAuthenticated),
+				LastAuthenticatedRegion: aws.ToString(service.LastAuthenticatedRegion),
+			})
+		}
+		if !output.IsTruncated || aws.ToString(output.Marker) == "" {
+			break
+		}
+		input.Marker = output.Marker
+	}
+
+	SortServicesLastAccessed(services)
+	return services, nil
+}
+
+// SortServicesLastAccessed sorts services by when they were last used, the
+// most recent first, and services never used by name after them
+func SortServicesLastAccessed(services []ServiceLastAccessed) {
+	sort.SliceStable(services, func(i, j int) bool {
+		a, b := services[i].LastAuthenticated, services[j].LastAuthenticated
+		if a.IsZero() != b.IsZero() {
+			return !a.IsZero()
+		}
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return services[i].Service < services[j].Service
+	})
+}
@@ -0,0 +1,174 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+)
+
+// iamRoleXML is a role of a ListRoles or GetRole response
+func iamRoleXML(name, lastUsed string) string {
+	policy := url.PathEscape(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}]}`)
+	role := fmt.Sprintf(`<RoleName>%s</RoleName><Arn>arn:aws:iam::123456789012:role/%s</Arn><Path>/</Path>
+		<CreateDate>2024-01-02T03:04:05Z</CreateDate><MaxSessionDuration>3600</MaxSessionDuration>
+		<AssumeRolePolicyDocument>%s</AssumeRolePolicyDocument>`, name, name, policy)
+	if lastUsed != "" {
+		role += fmt.Sprintf(`<RoleLastUsed><LastUsedDate>%s</LastUsedDate><Region>eu-west-1</Region></RoleLastUsed>`, lastUsed)
+	}
+	return role
+}
+
+func TestIAMListRoles(t *testing.T) {
+	svc, err := NewIAMService(iam.NewFromConfig(newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "ListRoles":
+			if r.Form.Get("Marker") == "" {
+				fmt.Fprintf(w, `<ListRolesResponse><ListRolesResult><Roles><member>%s</member></Roles>
+					<IsTruncated>true</IsTruncated><Marker>m2</Marker></ListRolesResult></ListRolesResponse>`, iamRoleXML("web", ""))
+				return
+			}
+			fmt.Fprintf(w, `<ListRolesResponse><ListRolesResult><Roles><member>%s</member><member>%s</member></Roles>
+				<IsTruncated>false</IsTruncated></ListRolesResult></ListRolesResponse>`, iamRoleXML("batch", ""), iamRoleXML("locked", ""))
+		case "GetRole":
+			name := r.Form.Get("RoleName")
+			switch name {
+			case "locked":
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`<ErrorResponse><Error><Code>AccessDenied</Code><Message>not authorized</Message></Error></ErrorResponse>`))
+			case "web":
+				fmt.Fprintf(w, `<GetRoleResponse><GetRoleResult><Role>%s</Role></GetRoleResult></GetRoleResponse>`, iamRoleXML(name, "2026-09-30T12:00:00Z"))
+			default:
+				fmt.Fprintf(w, `<GetRoleResponse><GetRoleResult><Role>%s</Role></GetRoleResult></GetRoleResponse>`, iamRoleXML(name, ""))
+			}
+		default:
+			t.Errorf("Unexpected action %q", r.Form.Get("Action"))
+		}
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roles, err := svc.ListRoles(context.Background())
+	var partial *PartialError
+	if !errors.As(err, &partial) || len(partial.Failures) != 1 || partial.Failures[0].Item != "locked" {
+		t.Errorf("Expected the locked role to fail, got %v", err)
+	}
+	if len(roles) != 3 || roles[0].Name != "batch" || roles[1].Name != "locked" || roles[2].Name != "web" {
+		t.Fatalf("Expected the roles of both pages sorted by name, got %+v", roles)
+	}
+	if !roles[0].LastUsed.IsZero() {
+		t.Errorf("Expected batch never used, got %v", roles[0].LastUsed)
+	}
+	web := roles[2]
+	if web.LastUsed.Format("2006-01-02") != "2026-09-30" || web.LastUsedRegion != "eu-west-1" || web.MaxSessionDuration != 3600 {
+		t.Errorf("Expected when web was last used, got %+v", web)
+	}
+	if !strings.HasPrefix(web.TrustPolicy, `{"Version":"2012-10-17"`) {
+		t.Errorf("Expected the trust policy decoded, got %q", web.TrustPolicy)
+	}
+}
+
+func TestIAMGetServiceLastAccessed(t *testing.T) {
+	serviceLastAccessedPoll = 0
+	defer func() { serviceLastAccessedPoll = 2 * time.Second }()
+
+	var polls int
+	svc, err := NewIAMService(iam.NewFromConfig(newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "GenerateServiceLastAccessedDetails":
+			if r.Form.Get("Arn") != "arn:aws:iam::123456789012:role/web" {
+				t.Errorf("Unexpected role %q", r.Form.Get("Arn"))
+			}
+			w.Write([]byte(`<GenerateServiceLastAccessedDetailsResponse><GenerateServiceLastAccessedDetailsResult>
+				<JobId>job-1</JobId></GenerateServiceLastAccessedDetailsResult></GenerateServiceLastAccessedDetailsResponse>`))
+		case "GetServiceLastAccessedDetails":
+			polls++
+			if polls == 1 {
+				w.Write([]byte(`<GetServiceLastAccessedDetailsResponse><GetServiceLastAccessedDetailsResult>
+					<JobStatus>IN_PROGRESS</JobStatus></GetServiceLastAccessedDetailsResult></GetServiceLastAccessedDetailsResponse>`))
+				return
+			}
+			w.Write([]byte(`<GetServiceLastAccessedDetailsResponse><GetServiceLastAccessedDetailsResult>
+				<JobStatus>COMPLETED</JobStatus><IsTruncated>false</IsTruncated><ServicesLastAccessed>
+				<member><ServiceName>Amazon DynamoDB</ServiceName><ServiceNamespace>dynamodb</ServiceNamespace></member>
+				<member><ServiceName>Amazon S3</ServiceName><ServiceNamespace>s3</ServiceNamespace>
+					<LastAuthenticated>2026-08-01T00:00:00Z</LastAuthenticated><LastAuthenticatedRegion>eu-west-1</LastAuthenticatedRegion></member>
+				<member><ServiceName>AWS Lambda</ServiceName><ServiceNamespace>lambda</ServiceNamespace>
+					<LastAuthenticated>2026-10-01T00:00:00Z</LastAuthenticated><LastAuthenticatedRegion>eu-west-1</LastAuthenticatedRegion></member>
+				</ServicesLastAccessed></GetServiceLastAccessedDetailsResult></GetServiceLastAccessedDetailsResponse>`))
+		default:
+			t.Errorf("Unexpected action %q", r.Form.Get("Action"))
+		}
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	services, err := svc.GetServiceLastAccessed(context.Background(), "arn:aws:iam::123456789012:role/web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if polls != 2 {
+		t.Errorf("Expected the report polled until completed, got %d polls", polls)
+	}
+	var names []string
+	for _, service := range services {
+		names = append(names, service.Namespace)
+	}
+	if !reflect.DeepEqual(names, []string{"lambda", "s3", "dynamodb"}) {
+		t.Errorf("Expected the most recently used first and unused last, got %v", names)
+	}
+}
+
+func TestIAMGetServiceLastAccessedDenied(t *testing.T) {
+	svc, err := NewIAMService(iam.NewFromConfig(newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`<ErrorResponse><Error><Code>AccessDenied</Code><Message>not authorized</Message></Error></ErrorResponse>`))
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = svc.GetServiceLastAccessed(context.Background(), "arn:aws:iam::123456789012:role/web")
+	if err == nil || ErrorReason(err) != "access denied" {
+		t.Errorf("Expected access denied, got %v", err)
+	}
+}
+
+func TestParseTrustPolicy(t *testing.T) {
+	statements, err := ParseTrustPolicy(`{"Version":"2012-10-17","Statement":{"Effect":"Allow",
+		"Principal":{"AWS":["arn:aws:iam::999988887777:root","arn:aws:iam::111122223333:role/ci"],"Service":"ec2.amazonaws.com"},
+		"Action":["sts:AssumeRole","sts:TagSession"],
+		"Condition":{"StringEquals":{"sts:ExternalId":"partner-7"}}}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TrustStatement{{
+		Effect:     "Allow",
+		Principals: []string{"AWS: arn:aws:iam::999988887777:root", "AWS: arn:aws:iam::111122223333:role/ci", "Service: ec2.amazonaws.com"},
+		Actions:    []string{"sts:AssumeRole", "sts:TagSession"},
+		Conditions: []string{"StringEquals sts:ExternalId = partner-7"},
+	}}
+	if !reflect.DeepEqual(statements, want) {
+		t.Errorf("Expected %+v, got %+v", want, statements)
+	}
+
+	statements, err = ParseTrustPolicy(`{"Statement":[{"Effect":"Deny","Principal":"*","Action":"sts:AssumeRole"}]}`)
+	if err != nil || len(statements) != 1 || !reflect.DeepEqual(statements[0].Principals, []string{"*"}) {
+		t.Errorf("Expected anyone denied, got %+v, %v", statements, err)
+	}
+
+	if _, err := ParseTrustPolicy("not json"); err == nil {
+		t.Error("Expected an invalid policy to fail")
+	}
+}
@@ -0,0 +1,328 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// signedClient sends requests to an AWS API, signing them with the
+// credentials of a configuration. It serves the services this module has no
+// SDK client for, running each request through a middleware stack like the
+// SDK clients do, so the retryer and API options of the configuration apply.
+type signedClient struct {
+	serviceID   string
+	endpoint    string
+	region      string
+	signingName string
+	credentials aws.CredentialsProvider
+	http        aws.HTTPClient
+	signer      *v4.Signer
+	retryer     func() aws.Retryer
+	apiOptions  []func(*middleware.Stack) error
+	// decodeError turns a failed response into a smithy.APIError
+	decodeError func(status int, header http.Header, body []byte) error
+}
+
+// newSignedClient creates a client of the API serviceID at endpoint in
+// region, signing requests for signingName. The base endpoint of cfg, if
+// set, replaces endpoint.
+func newSignedClient(cfg aws.Config, serviceID, endpoint, region, signingName string) signedClient {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if cfg.BaseEndpoint != nil {
+		endpoint = *cfg.BaseEndpoint
+	}
+	retryer := cfg.Retryer
+	if retryer == nil {
+		retryer = func() aws.Retryer { return retry.NewStandard() }
+	}
+	return signedClient{
+		serviceID:   serviceID,
+		endpoint:    endpoint,
+		region:      region,
+		signingName: signingName,
+		credentials: cfg.Credentials,
+		http:        httpClient,
+		signer:      v4.NewSigner(),
+		retryer:     retryer,
+		apiOptions:  cfg.APIOptions,
+	}
+}
+
+// regionalEndpoint returns the endpoint of host in region, e.g.
+// https://pi.eu-west-1.amazonaws.com
+func regionalEndpoint(host, region string) string {
+	endpoint := fmt.Sprintf("https://%s.%s.amazonaws.com", host, region)
+	if strings.HasPrefix(region, "cn-") {
+		endpoint += ".cn"
+	}
+	return endpoint
+}
+
+// signedResponse is the result of a request sent by a signedClient
+type signedResponse struct {
+	header http.Header
+	body   []byte
+}
+
+// send posts body with header for operation and returns the body of the
+// response
+func (c *signedClient) send(ctx context.Context, operation string, header http.Header, body []byte) ([]byte, error) {
+	_, data, err := c.do(ctx, http.MethodPost, "/", operation, header, body)
+	return data, err
+}
+
+// do sends a signed request with method to path, which may carry a query,
+// and returns the headers and body of the response. Failed responses are
+// returned as errors wrapping the smithy.APIError of decodeError, after the
+// retryer gave up on them.
+func (c *signedClient) do(ctx context.Context, method, path, operation string, header http.Header, body []byte) (http.Header, []byte, error) {
+	stack := middleware.NewStack(operation, smithyhttp.NewStackRequest)
+	if err := c.addMiddlewares(stack, method, path, operation, header, body); err != nil {
+		return nil, nil, err
+	}
+	for _, fn := range c.apiOptions {
+		if err := fn(stack); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	handler := middleware.DecorateHandler(smithyhttp.NewClientHandler(c.http), stack)
+	result, _, err := handler.Handle(ctx, nil)
+	if err != nil {
+		return nil, nil, &smithy.OperationError{ServiceID: c.serviceID, OperationName: operation, Err: err}
+	}
+	resp := result.(*signedResponse)
+	return resp.header, resp.body, nil
+}
+
+// addMiddlewares adds the steps of a request to stack: the metadata the
+// API options read, building, retrying, signing and decoding the response
+func (c *signedClient) addMiddlewares(stack *middleware.Stack, method, path, operation string, header http.Header, body []byte) error {
+	metadata := &awsmiddleware.RegisterServiceMetadata{
+		ServiceID:     c.serviceID,
+		SigningName:   c.signingName,
+		Region:        c.region,
+		OperationName: operation,
+	}
+	if err := stack.Initialize.Add(metadata, middleware.Before); err != nil {
+		return err
+	}
+
+	serialize := middleware.SerializeMiddlewareFunc("SignedClientSerialize",
+		func(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (middleware.SerializeOutput, middleware.Metadata, error) {
+			req, ok := in.Request.(*smithyhttp.Request)
+			if !ok {
+				return middleware.SerializeOutput{}, middleware.Metadata{}, fmt.Errorf("unexpected request type %T", in.Request)
+			}
+			target, err := url.Parse(c.endpoint + path)
+			if err != nil {
+				return middleware.SerializeOutput{}, middleware.Metadata{}, err
+			}
+			req.Method = method
+			req.URL = target
+			for key, values := range header {
+				req.Header[key] = values
+			}
+			if in.Request, err = req.SetStream(bytes.NewReader(body)); err != nil {
+				return middleware.SerializeOutput{}, middleware.Metadata{}, err
+			}
+			return next.HandleSerialize(ctx, in)
+		})
+	if err := stack.Serialize.Add(serialize, middleware.After); err != nil {
+		return err
+	}
+	if err := smithyhttp.AddComputeContentLengthMiddleware(stack); err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(hash[:])
+	sign := middleware.FinalizeMiddlewareFunc("Signing",
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			req, ok := in.Request.(*smithyhttp.Request)
+			if !ok {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("unexpected request type %T", in.Request)
+			}
+			creds, err := c.credentials.Retrieve(ctx)
+			if err != nil {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("failed to retrieve credentials: %w", err)
+			}
+			if err := c.signer.SignHTTP(ctx, creds, req.Request, payloadHash, c.signingName, c.region, time.Now()); err != nil {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("failed to sign %s: %w", operation, err)
+			}
+			return next.HandleFinalize(ctx, in)
+		})
+	if err := stack.Finalize.Add(sign, middleware.After); err != nil {
+		return err
+	}
+	if err := retry.AddRetryMiddlewares(stack, retry.AddRetryMiddlewaresOptions{Retryer: c.retryer()}); err != nil {
+		return err
+	}
+
+	deserialize := middleware.DeserializeMiddlewareFunc("SignedClientDeserialize",
+		func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (middleware.DeserializeOutput, middleware.Metadata, error) {
+			out, metadata, err := next.HandleDeserialize(ctx, in)
+			if err != nil {
+				return out, metadata, err
+			}
+			resp, ok := out.RawResponse.(*smithyhttp.Response)
+			if !ok {
+				return out, metadata, fmt.Errorf("unexpected response type %T", out.RawResponse)
+			}
+			defer resp.Body.Close()
+
+			data, err := io.ReadAll(io.LimitReader(resp.Body, 16*1024*1024))
+			if err != nil {
+				return out, metadata, err
+			}
+			if resp.StatusCode < 200 || resp.StatusCode > 299 {
+				return out, metadata, &awshttp.ResponseError{
+					ResponseError: &smithyhttp.ResponseError{Response: resp, Err: c.decodeError(resp.StatusCode, resp.Header, data)},
+					RequestID:     resp.Header.Get("X-Amzn-Requestid"),
+				}
+			}
+			out.Result = &signedResponse{header: resp.Header, body: data}
+			return out, metadata, nil
+		})
+	return stack.Deserialize.Add(deserialize, middleware.After)
+}
+
+// jsonAPI calls an AWS JSON 1.1 API directly
+type jsonAPI struct {
+	signedClient
+	targetPrefix string
+}
+
+// newJSONAPI creates a client of the API serviceID at endpoint in region,
+// signing requests for signingName and prefixing operations with
+// targetPrefix in the X-Amz-Target header
+func newJSONAPI(cfg aws.Config, serviceID, endpoint, region, signingName, targetPrefix string) *jsonAPI {
+	api := &jsonAPI{
+		signedClient: newSignedClient(cfg, serviceID, endpoint, region, signingName),
+		targetPrefix: targetPrefix,
+	}
+	api.decodeError = jsonError
+	return api
+}
+
+// call signs and sends the operation with input and decodes its response
+// into output. Failed calls wrap a smithy.APIError with the exception name
+// as code, e.g. NotAuthorizedException.
+func (a *jsonAPI) call(ctx context.Context, operation string, input, output any) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/x-amz-json-1.1")
+	header.Set("X-Amz-Target", a.targetPrefix+operation)
+
+	data, err := a.send(ctx, operation, header, body)
+	if err != nil {
+		return err
+	}
+	// Operations without output may answer with an empty body
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, output); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", operation, err)
+	}
+	return nil
+}
+
+// jsonError decodes a failed call of a JSON API into a smithy.APIError.
+// Failures come as {"__type":"...#NotAuthorizedException","message":"..."};
+// The X-Amzn-ErrorType header, if present, gives the type instead.
+func jsonError(status int, header http.Header, data []byte) error {
+	var failure struct {
+		Type    string `json:"__type"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(data, &failure) != nil || failure.Message == "" {
+		failure.Message = strings.TrimSpace(string(data))
+	}
+	if errorType := header.Get("X-Amzn-ErrorType"); errorType != "" {
+		failure.Type, _, _ = strings.Cut(errorType, ":")
+	}
+	if failure.Type == "" {
+		failure.Type = strings.ReplaceAll(http.StatusText(status), " ", "")
+	}
+	_, code, _ := strings.Cut(failure.Type, "#")
+	if code == "" {
+		code = failure.Type
+	}
+	return &smithy.GenericAPIError{Code: code, Message: failure.Message}
+}
+
+// restJSONAPI calls an AWS REST JSON API directly, where the method and
+// path of a request select the operation
+type restJSONAPI struct {
+	signedClient
+}
+
+// newRestJSONAPI creates a client of the API serviceID at endpoint in
+// region, signing requests for signingName
+func newRestJSONAPI(cfg aws.Config, serviceID, endpoint, region, signingName string) *restJSONAPI {
+	api := &restJSONAPI{signedClient: newSignedClient(cfg, serviceID, endpoint, region, signingName)}
+	api.decodeError = jsonError
+	return api
+}
+
+// call signs and sends operation as method to path with input, if not nil,
+// as body and decodes its response into output. Failed calls wrap a
+// smithy.APIError like those of jsonAPI.
+func (a *restJSONAPI) call(ctx context.Context, operation, method, path string, input, output any) error {
+	var body []byte
+	header := http.Header{}
+	if input != nil {
+		var err error
+		if body, err = json.Marshal(input); err != nil {
+			return err
+		}
+		header.Set("Content-Type", "application/json")
+	}
+
+	_, data, err := a.do(ctx, method, path, operation, header, body)
+	if err != nil {
+		return err
+	}
+	// Operations without output may answer with an empty body
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, output); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", operation, err)
+	}
+	return nil
+}
+
+// epochTime converts the seconds since the epoch of a JSON 1.1 timestamp
+func epochTime(seconds float64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	sec := int64(seconds)
+	return time.Unix(sec, int64((seconds-float64(sec))*1e9))
+}
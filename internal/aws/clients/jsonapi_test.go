@@ -0,0 +1,67 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestSignedClientRetriesWithConfig(t *testing.T) {
+	var requests int
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.Header.Get("X-Amz-Target") {
+		case "PerformanceInsightsv20180227.DescribeDimensionKeys":
+			if requests == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"__type":"ThrottlingException","message":"Rate exceeded"}`))
+				return
+			}
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"NotAuthorizedException","message":"not authorized"}`))
+		}
+	})
+
+	var attempts []string
+	recordAttempt := middleware.FinalizeMiddlewareFunc("RecordAttempt",
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			attempts = append(attempts, awsmiddleware.GetServiceID(ctx)+":"+awsmiddleware.GetOperationName(ctx))
+			return next.HandleFinalize(ctx, in)
+		})
+	cfg.Retryer = func() aws.Retryer {
+		return retry.NewStandard(func(o *retry.StandardOptions) {
+			o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) { return 0, nil })
+		})
+	}
+	cfg.APIOptions = append(cfg.APIOptions, func(stack *middleware.Stack) error {
+		return stack.Finalize.Insert(recordAttempt, "Retry", middleware.After)
+	})
+	api := newJSONAPI(cfg, "PI", "https://pi.eu-west-1.amazonaws.com", "eu-west-1", "pi", piTargetPrefix)
+
+	var output struct{}
+	if err := api.call(context.Background(), "DescribeDimensionKeys", map[string]any{}, &output); err != nil {
+		t.Fatalf("Expected the throttled call to be retried, got %v", err)
+	}
+	if len(attempts) != 2 || attempts[0] != "PI:DescribeDimensionKeys" {
+		t.Errorf("Expected two attempts through the API options, got %v", attempts)
+	}
+
+	err := api.call(context.Background(), "GetResourceMetrics", map[string]any{}, &output)
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "NotAuthorizedException" {
+		t.Errorf("Expected NotAuthorizedException, got %v", err)
+	}
+	if len(attempts) != 3 {
+		t.Errorf("Expected a denied call not to be retried, got %d attempts", len(attempts))
+	}
+}
@@ -0,0 +1,406 @@
+package clients
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go"
+)
+
+// kubePageSize is how many items a Kubernetes list call returns per page
+const kubePageSize = 500
+
+// Deployment is a Kubernetes deployment with its replica counts
+type Deployment struct {
+	Name      string
+	Namespace string
+	Replicas  int
+	Ready     int
+	UpToDate  int
+	Available int
+	// Images are the images of the containers of its pods, by container
+	// name
+	Images    map[string]string
+	CreatedAt time.Time
+}
+
+// Pod is a Kubernetes pod with the state of its containers
+type Pod struct {
+	Name      string
+	Namespace string
+	// Status is the phase of the pod, or why a container of it is not
+	// running such as CrashLoopBackOff, as kubectl get pods shows it
+	Status     string
+	Ready      int
+	Containers []string
+	Restarts   int
+	Node       string
+	CreatedAt  time.Time
+}
+
+// PodLogLine is a line logged by a container of a pod
+type PodLogLine struct {
+	Pod       string
+	Namespace string
+	Container string
+	Timestamp time.Time
+	Message   string
+}
+
+// kubeClient reads a Kubernetes API server with a bearer token
+type kubeClient struct {
+	endpoint string
+	http     *http.Client
+	token    func(ctx context.Context) (string, error)
+}
+
+// newKubeClient creates a client of the API server at endpoint whose
+// certificate is signed by the base64 encoded PEM caData
+func newKubeClient(endpoint, caData string, token func(ctx context.Context) (string, error)) (*kubeClient, error) {
+	pem, err := base64.StdEncoding.DecodeString(caData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate authority: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("invalid certificate authority: no certificates found")
+	}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		TLSClientConfig:       &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12},
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+	}
+	return &kubeClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		http:     &http.Client{Transport: transport},
+		token:    token,
+	}, nil
+}
+
+// open sends a GET request for path and returns the response body. Failed
+// requests are returned as smithy.APIError with the Kubernetes reason as
+// code, e.g. Forbidden or NotFound.
+func (k *kubeClient) open(ctx context.Context, path string, query url.Values) (io.ReadCloser, error) {
+	token, err := k.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	target := k.endpoint + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := k.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp.Body, nil
+	}
+	defer resp.Body.Close()
+
+	// Failures come as a Status object, e.g. {"reason":"Forbidden","message":"..."}
+	var status struct {
+		Reason  string `json:"reason"`
+		Message string `json:"message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if json.Unmarshal(body, &status) != nil || status.Reason == "" {
+		status.Reason = strings.ReplaceAll(http.StatusText(resp.StatusCode), " ", "")
+		status.Message = strings.TrimSpace(string(body))
+	}
+	return nil, &smithy.GenericAPIError{Code: status.Reason, Message: status.Message}
+}
+
+// kubeList is a page of a Kubernetes list call
+type kubeList[T any] struct {
+	Metadata struct {
+		Continue string `json:"continue"`
+	} `json:"metadata"`
+	Items []T `json:"items"`
+}
+
+// listAll reads every page of the list at path
+func listAll[T any](ctx context.Context, k *kubeClient, path string) ([]T, error) {
+	var items []T
+	query := url.Values{"limit": {strconv.Itoa(kubePageSize)}}
+	for {
+		body, err := k.open(ctx, path, query)
+		if err != nil {
+			return nil, err
+		}
+		var page kubeList[T]
+		err = json.NewDecoder(body).Decode(&page)
+		body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+
+		items = append(items, page.Items...)
+		if page.Metadata.Continue == "" {
+			return items, nil
+		}
+		query.Set("continue", page.Metadata.Continue)
+	}
+}
+
+// namespaced returns the path of resource in namespace, or across all
+// namespaces if it is empty
+func namespaced(group, namespace, resource string) string {
+	if namespace == "" {
+		return group + "/" + resource
+	}
+	return group + "/namespaces/" + url.PathEscape(namespace) + "/" + resource
+}
+
+// kubeMeta is the metadata of a Kubernetes object
+type kubeMeta struct {
+	Name              string     `json:"name"`
+	Namespace         string     `json:"namespace"`
+	CreationTimestamp time.Time  `json:"creationTimestamp"`
+	DeletionTimestamp *time.Time `json:"deletionTimestamp"`
+}
+
+// ListNamespaces returns the names of the namespaces
+func (k *kubeClient) ListNamespaces(ctx context.Context) ([]string, error) {
+	items, err := listAll[struct {
+		Metadata kubeMeta `json:"metadata"`
+	}](ctx, k, "/api/v1/namespaces")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Metadata.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// kubeDeployment is the part of a Deployment object that is shown
+type kubeDeployment struct {
+	Metadata kubeMeta `json:"metadata"`
+	Spec     struct {
+		Replicas *int `json:"replicas"`
+		Template struct {
+			Spec struct {
+				Containers []struct {
+					Name  string `json:"name"`
+					Image string `json:"image"`
+				} `json:"containers"`
+			} `json:"spec"`
+		} `json:"template"`
+	} `json:"spec"`
+	Status struct {
+		ReadyReplicas     int `json:"readyReplicas"`
+		UpdatedReplicas   int `json:"updatedReplicas"`
+		AvailableReplicas int `json:"availableReplicas"`
+	} `json:"status"`
+}
+
+// ListDeployments returns the deployments of namespace, or of every
+// namespace if it is empty
+func (k *kubeClient) ListDeployments(ctx context.Context, namespace string) ([]Deployment, error) {
+	items, err := listAll[kubeDeployment](ctx, k, namespaced("/apis/apps/v1", namespace, "deployments"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	deployments := make([]Deployment, 0, len(items))
+	for _, item := range items {
+		// An unset replica count defaults to 1
+		replicas := 1
+		if item.Spec.Replicas != nil {
+			replicas = *item.Spec.Replicas
+		}
+		images := make(map[string]string, len(item.Spec.Template.Spec.Containers))
+		for _, container := range item.Spec.Template.Spec.Containers {
+			images[container.Name] = container.Image
+		}
+		deployments = append(deployments, Deployment{
+			Name:      item.Metadata.Name,
+			Namespace: item.Metadata.Namespace,
+			Replicas:  replicas,
+			Ready:     item.Status.ReadyReplicas,
+			UpToDate:  item.Status.UpdatedReplicas,
+			Available: item.Status.AvailableReplicas,
+			Images:    images,
+			CreatedAt: item.Metadata.CreationTimestamp,
+		})
+	}
+	return deployments, nil
+}
+
+// kubePod is the part of a Pod object that is shown
+type kubePod struct {
+	Metadata kubeMeta `json:"metadata"`
+	Spec     struct {
+		NodeName   string `json:"nodeName"`
+		Containers []struct {
+			Name string `json:"name"`
+		} `json:"containers"`
+	} `json:"spec"`
+	Status struct {
+		Phase             string `json:"phase"`
+		Reason            string `json:"reason"`
+		ContainerStatuses []struct {
+			Name         string `json:"name"`
+			Ready        bool   `json:"ready"`
+			RestartCount int    `json:"restartCount"`
+			State        struct {
+				Waiting *struct {
+					Reason string `json:"reason"`
+				} `json:"waiting"`
+				Terminated *struct {
+					Reason string `json:"reason"`
+				} `json:"terminated"`
+			} `json:"state"`
+		} `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+// ListPods returns the pods of namespace, or of every namespace if it is
+// empty
+func (k *kubeClient) ListPods(ctx context.Context, namespace string) ([]Pod, error) {
+	items, err := listAll[kubePod](ctx, k, namespaced("/api/v1", namespace, "pods"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	pods := make([]Pod, 0, len(items))
+	for _, item := range items {
+		pods = append(pods, podFromKube(item))
+	}
+	return pods, nil
+}
+
+// podFromKube summarizes item the way kubectl get pods does
+func podFromKube(item kubePod) Pod {
+	pod := Pod{
+		Name:      item.Metadata.Name,
+		Namespace: item.Metadata.Namespace,
+		Status:    item.Status.Phase,
+		Node:      item.Spec.NodeName,
+		CreatedAt: item.Metadata.CreationTimestamp,
+	}
+	if item.Status.Reason != "" {
+		pod.Status = item.Status.Reason
+	}
+	for _, container := range item.Spec.Containers {
+		pod.Containers = append(pod.Containers, container.Name)
+	}
+	for _, status := range item.Status.ContainerStatuses {
+		pod.Restarts += status.RestartCount
+		if status.Ready {
+			pod.Ready++
+		}
+		switch {
+		case status.State.Waiting != nil && status.State.Waiting.Reason != "":
+			pod.Status = status.State.Waiting.Reason
+		case status.State.Terminated != nil && status.State.Terminated.Reason != "" && pod.Status == "Running":
+			pod.Status = status.State.Terminated.Reason
+		}
+	}
+	if item.Metadata.DeletionTimestamp != nil {
+		pod.Status = "Terminating"
+	}
+	return pod
+}
+
+// StreamPodLogs sends the last tailLines lines of every container of pod to
+// lines and then follows them until ctx is done or the containers stop. It
+// fails if no container's log could be opened.
+func (k *kubeClient) StreamPodLogs(ctx context.Context, pod Pod, tailLines int, lines chan<- PodLogLine) error {
+	path := namespaced("/api/v1", pod.Namespace, "pods") + "/" + url.PathEscape(pod.Name) + "/log"
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		opened int
+		errs   []error
+	)
+	for _, container := range pod.Containers {
+		query := url.Values{
+			"container":  {container},
+			"follow":     {"true"},
+			"timestamps": {"true"},
+			"tailLines":  {strconv.Itoa(tailLines)},
+		}
+		body, err := k.open(ctx, path, query)
+		if err != nil {
+			mu.Lock()
+			errs = append(errs, fmt.Errorf("container %s: %w", container, err))
+			mu.Unlock()
+			continue
+		}
+		opened++
+
+		wg.Add(1)
+		go func(container string, body io.ReadCloser) {
+			defer wg.Done()
+			defer body.Close()
+			// Closing the body ends a scan blocked on a quiet container
+			stop := context.AfterFunc(ctx, func() { body.Close() })
+			defer stop()
+
+			scanner := bufio.NewScanner(body)
+			scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				line := parseLogLine(scanner.Text())
+				line.Pod, line.Namespace, line.Container = pod.Name, pod.Namespace, container
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err := scanner.Err(); err != nil && ctx.Err() == nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("container %s: %w", container, err))
+				mu.Unlock()
+			}
+		}(container, body)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 && (opened == 0 || ctx.Err() == nil) {
+		return fmt.Errorf("failed to stream the logs of pod %s: %w", pod.Name, errs[0])
+	}
+	return nil
+}
+
+// parseLogLine splits the timestamp that timestamps=true puts in front of
+// a log line from its message
+func parseLogLine(text string) PodLogLine {
+	stamp, message, found := strings.Cut(text, " ")
+	if found {
+		if ts, err := time.Parse(time.RFC3339Nano, stamp); err == nil {
+			return PodLogLine{Timestamp: ts, Message: message}
+		}
+	}
+	return PodLogLine{Timestamp: time.Now(), Message: text}
+}
@@ -3,8 +3,12 @@ package clients
 import (
 	"context"
 	"fmt"
+	"strings"
+
+	"swiss-army-tui/internal/workpool"
 	"swiss-army-tui/pkg/logger"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
 	"go.uber.org/zap"
@@ -19,12 +23,59 @@ type LambdaFunctionDetail struct {
 	SnapStartEnabled bool
 	SnapStartStatus  string
 	State            string
+	StateReason      string
+	LastUpdateStatus string
 	LastModified     string
 	Description      string
 	CodeSize         int64
 	LogGroupName     string
 }
 
+// LambdaConcurrency describes the concurrency settings of a function and the
+// concurrency left in the account
+type LambdaConcurrency struct {
+	// Reserved is nil when the function draws from the unreserved pool
+	Reserved    *int32
+	Provisioned []ProvisionedConcurrency
+	// Aliases can be given provisioned concurrency
+	Aliases           []string
+	AccountLimit      int32
+	AccountUnreserved int32
+}
+
+// ProvisionedConcurrency is the provisioned concurrency of an alias or
+// version of a function
+type ProvisionedConcurrency struct {
+	Qualifier string
+	Requested int32
+	Allocated int32
+	Available int32
+	// Status is IN_PROGRESS, READY or FAILED
+	Status       string
+	StatusReason string
+}
+
+// EventSourceMapping is a function reading from a queue or stream
+type EventSourceMapping struct {
+	UUID        string
+	FunctionARN string
+	SourceARN   string
+	// State is e.g. Enabled, Disabled or Creating
+	State     string
+	BatchSize int32
+}
+
+// LambdaAlias is an alias of a function and the versions it routes to
+type LambdaAlias struct {
+	Name string
+	// Version receives the invocations not routed to AdditionalVersion
+	Version string
+	// AdditionalVersion receives Weight (0 to 1) of the invocations, e.g.
+	// a canary; empty when the alias routes to Version only
+	AdditionalVersion string
+	Weight            float64
+}
+
 type LambdaService struct {
 	client *lambda.Client
 }
@@ -39,60 +90,361 @@ func NewLambdaService(lambdaClient *lambda.Client) (*LambdaService, error) {
 	}, nil
 }
 
-func (c *LambdaService) GetLambdaDetail(ctx context.Context) ([]LambdaFunctionDetail, error) {
+// ListLambdaFunctions lists all functions without fetching their extended
+// configuration. ListFunctions does not return State or the SnapStart
+// optimization status, use GetLambdaFunction for those.
+func (c *LambdaService) ListLambdaFunctions(ctx context.Context) ([]LambdaFunctionDetail, error) {
+	if c == nil || c.client == nil {
+		return nil, fmt.Errorf("lambda service not initialized")
+	}
+
 	var functions []LambdaFunctionDetail
+	paginator := lambda.NewListFunctionsPaginator(c.client, &lambda.ListFunctionsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			logger.Error("failed to list Lambda functions", zap.Error(err))
+			return nil, fmt.Errorf("failed to list Lambda functions: %w", err)
+		}
 
+		for _, fn := range output.Functions {
+			functions = append(functions, toLambdaFunctionDetail(fn))
+		}
+	}
+
+	return functions, nil
+}
+
+// GetLambdaFunction fetches the full configuration of a single function
+func (c *LambdaService) GetLambdaFunction(ctx context.Context, functionName string) (LambdaFunctionDetail, error) {
 	if c == nil || c.client == nil {
-		return nil, fmt.Errorf("lambda service not initialized")
+		return LambdaFunctionDetail{}, fmt.Errorf("lambda service not initialized")
+	}
+
+	output, err := c.client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: &functionName,
+	})
+	if err != nil {
+		return LambdaFunctionDetail{}, fmt.Errorf("failed to get configuration of function %s: %w", functionName, err)
+	}
+
+	return toLambdaFunctionDetail(types.FunctionConfiguration{
+		FunctionName:     output.FunctionName,
+		Runtime:          output.Runtime,
+		Handler:          output.Handler,
+		MemorySize:       output.MemorySize,
+		Timeout:          output.Timeout,
+		SnapStart:        output.SnapStart,
+		State:            output.State,
+		StateReason:      output.StateReason,
+		LastUpdateStatus: output.LastUpdateStatus,
+		LastModified:     output.LastModified,
+		Description:      output.Description,
+		CodeSize:         output.CodeSize,
+	}), nil
+}
+
+// GetLambdaDetail lists all functions and fetches their full configuration.
+// The configurations are fetched concurrently in the "lambda" slots of the
+// worker pool. If some cannot be fetched, all functions are returned with a
+// *PartialError naming the failed ones.
+func (c *LambdaService) GetLambdaDetail(ctx context.Context) ([]LambdaFunctionDetail, error) {
+	listed, err := c.ListLambdaFunctions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*LambdaFunctionDetail, len(listed))
+	var failures failureCollector
+	err = workpool.Each(ctx, "lambda", len(listed), func(i int) {
+		detail, err := c.GetLambdaFunction(ctx, listed[i].FunctionName)
+		if err != nil {
+			logger.Warn("Error getting function details", zap.String("function", listed[i].FunctionName), zap.Error(err))
+			failures.add(listed[i].FunctionName, "", err)
+			return
+		}
+		results[i] = &detail
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching Lambda function details cancelled: %w", err)
+	}
+
+	// Functions whose configuration failed are returned as listed
+	functions := make([]LambdaFunctionDetail, 0, len(listed))
+	for i, detail := range results {
+		if detail != nil {
+			functions = append(functions, *detail)
+		} else {
+			functions = append(functions, listed[i])
+		}
+	}
+	return functions, failures.err("get Lambda function details")
+}
+
+// GetConcurrency returns the reserved and provisioned concurrency of
+// functionName, its aliases and the concurrency limits of the account
+func (c *LambdaService) GetConcurrency(ctx context.Context, functionName string) (LambdaConcurrency, error) {
+	if c == nil || c.client == nil {
+		return LambdaConcurrency{}, fmt.Errorf("lambda service not initialized")
 	}
 
-	listOutput, err := c.client.ListFunctions(ctx, &lambda.ListFunctionsInput{})
+	var concurrency LambdaConcurrency
+	reserved, err := c.client.GetFunctionConcurrency(ctx, &lambda.GetFunctionConcurrencyInput{
+		FunctionName: aws.String(functionName),
+	})
 	if err != nil {
-		logger.Error("failed to list Lambda functions", zap.Error(err))
-		return nil, fmt.Errorf("failed to list Lambda functions: %w", err)
+		return LambdaConcurrency{}, fmt.Errorf("failed to get concurrency of function %s: %w", functionName, err)
 	}
+	concurrency.Reserved = reserved.ReservedConcurrentExecutions
 
-	for _, fn := range listOutput.Functions {
-		detail, err := c.client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
-			FunctionName: fn.FunctionName,
-		})
+	configs := lambda.NewListProvisionedConcurrencyConfigsPaginator(c.client, &lambda.ListProvisionedConcurrencyConfigsInput{
+		FunctionName: aws.String(functionName),
+	})
+	for configs.HasMorePages() {
+		output, err := configs.NextPage(ctx)
 		if err != nil {
-			if fn.FunctionName != nil {
-				logger.Warn("Error getting function details", zap.String("function", *fn.FunctionName), zap.Error(err))
-			} else {
-				logger.Warn("Error getting function details", zap.Error(err))
+			return LambdaConcurrency{}, fmt.Errorf("failed to list provisioned concurrency of function %s: %w", functionName, err)
+		}
+		for _, config := range output.ProvisionedConcurrencyConfigs {
+			arn := aws.ToString(config.FunctionArn)
+			concurrency.Provisioned = append(concurrency.Provisioned, ProvisionedConcurrency{
+				Qualifier:    arn[strings.LastIndex(arn, ":")+1:],
+				Requested:    safeInt32(config.RequestedProvisionedConcurrentExecutions),
+				Allocated:    safeInt32(config.AllocatedProvisionedConcurrentExecutions),
+				Available:    safeInt32(config.AvailableProvisionedConcurrentExecutions),
+				Status:       string(config.Status),
+				StatusReason: aws.ToString(config.StatusReason),
+			})
+		}
+	}
+
+	aliases := lambda.NewListAliasesPaginator(c.client, &lambda.ListAliasesInput{
+		FunctionName: aws.String(functionName),
+	})
+	for aliases.HasMorePages() {
+		output, err := aliases.NextPage(ctx)
+		if err != nil {
+			return LambdaConcurrency{}, fmt.Errorf("failed to list aliases of function %s: %w", functionName, err)
+		}
+		for _, alias := range output.Aliases {
+			concurrency.Aliases = append(concurrency.Aliases, aws.ToString(alias.Name))
+		}
+	}
+
+	account, err := c.client.GetAccountSettings(ctx, &lambda.GetAccountSettingsInput{})
+	if err != nil {
+		return LambdaConcurrency{}, fmt.Errorf("failed to get Lambda account settings: %w", err)
+	}
+	if account.AccountLimit != nil {
+		concurrency.AccountLimit = account.AccountLimit.ConcurrentExecutions
+		concurrency.AccountUnreserved = safeInt32(account.AccountLimit.UnreservedConcurrentExecutions)
+	}
+	return concurrency, nil
+}
+
+// PutReservedConcurrency reserves executions for functionName. Zero
+// reserved executions throttle every invocation.
+func (c *LambdaService) PutReservedConcurrency(ctx context.Context, functionName string, executions int32) error {
+	if c == nil || c.client == nil {
+		return fmt.Errorf("lambda service not initialized")
+	}
+
+	_, err := c.client.PutFunctionConcurrency(ctx, &lambda.PutFunctionConcurrencyInput{
+		FunctionName:                 aws.String(functionName),
+		ReservedConcurrentExecutions: aws.Int32(executions),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set reserved concurrency of function %s: %w", functionName, err)
+	}
+	return nil
+}
+
+// DeleteReservedConcurrency returns functionName to the unreserved pool
+func (c *LambdaService) DeleteReservedConcurrency(ctx context.Context, functionName string) error {
+	if c == nil || c.client == nil {
+		return fmt.Errorf("lambda service not initialized")
+	}
+
+	_, err := c.client.DeleteFunctionConcurrency(ctx, &lambda.DeleteFunctionConcurrencyInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove reserved concurrency of function %s: %w", functionName, err)
+	}
+	return nil
+}
+
+// PutProvisionedConcurrency provisions executions for the alias or version
+// qualifier of functionName
+func (c *LambdaService) PutProvisionedConcurrency(ctx context.Context, functionName, qualifier string, executions int32) error {
+	if c == nil || c.client == nil {
+		return fmt.Errorf("lambda service not initialized")
+	}
+
+	_, err := c.client.PutProvisionedConcurrencyConfig(ctx, &lambda.PutProvisionedConcurrencyConfigInput{
+		FunctionName:                    aws.String(functionName),
+		Qualifier:                       aws.String(qualifier),
+		ProvisionedConcurrentExecutions: aws.Int32(executions),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set provisioned concurrency of %s:%s: %w", functionName, qualifier, err)
+	}
+	return nil
+}
+
+// DeleteProvisionedConcurrency removes the provisioned concurrency of the
+// alias or version qualifier of functionName
+func (c *LambdaService) DeleteProvisionedConcurrency(ctx context.Context, functionName, qualifier string) error {
+	if c == nil || c.client == nil {
+		return fmt.Errorf("lambda service not initialized")
+	}
+
+	_, err := c.client.DeleteProvisionedConcurrencyConfig(ctx, &lambda.DeleteProvisionedConcurrencyConfigInput{
+		FunctionName: aws.String(functionName),
+		Qualifier:    aws.String(qualifier),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove provisioned concurrency of %s:%s: %w", functionName, qualifier, err)
+	}
+	return nil
+}
+
+// ListAliases returns the aliases of functionName with their routing
+func (c *LambdaService) ListAliases(ctx context.Context, functionName string) ([]LambdaAlias, error) {
+	if c == nil || c.client == nil {
+		return nil, fmt.Errorf("lambda service not initialized")
+	}
+
+	var aliases []LambdaAlias
+	paginator := lambda.NewListAliasesPaginator(c.client, &lambda.ListAliasesInput{
+		FunctionName: aws.String(functionName),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list aliases of function %s: %w", functionName, err)
+		}
+		for _, alias := range output.Aliases {
+			la := LambdaAlias{Name: aws.ToString(alias.Name), Version: aws.ToString(alias.FunctionVersion)}
+			if alias.RoutingConfig != nil {
+				// Lambda routes to one additional version at most
+				for version, weight := range alias.RoutingConfig.AdditionalVersionWeights {
+					la.AdditionalVersion, la.Weight = version, weight
+				}
 			}
-			continue
+			aliases = append(aliases, la)
 		}
+	}
+	return aliases, nil
+}
 
-		// Extract SnapStart information
-		snapStartEnabled := false
-		snapStartStatus := "Not Available"
+// ListVersions returns the published versions of functionName, oldest first
+func (c *LambdaService) ListVersions(ctx context.Context, functionName string) ([]string, error) {
+	if c == nil || c.client == nil {
+		return nil, fmt.Errorf("lambda service not initialized")
+	}
 
-		if detail.SnapStart != nil {
-			snapStartEnabled = detail.SnapStart.ApplyOn == types.SnapStartApplyOnPublishedVersions
-			if detail.SnapStart.OptimizationStatus != "" {
-				snapStartStatus = string(detail.SnapStart.OptimizationStatus)
+	var versions []string
+	paginator := lambda.NewListVersionsByFunctionPaginator(c.client, &lambda.ListVersionsByFunctionInput{
+		FunctionName: aws.String(functionName),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list versions of function %s: %w", functionName, err)
+		}
+		for _, fn := range output.Versions {
+			// $LATEST is not published and cannot take weighted traffic
+			if version := aws.ToString(fn.Version); version != "$LATEST" {
+				versions = append(versions, version)
 			}
 		}
+	}
+	return versions, nil
+}
 
-		functions = append(functions, LambdaFunctionDetail{
-			FunctionName:     safeString(detail.FunctionName),
-			Runtime:          string(detail.Runtime),
-			Handler:          safeString(detail.Handler),
-			MemorySize:       safeInt32(detail.MemorySize),
-			Timeout:          safeInt32(detail.Timeout),
-			SnapStartEnabled: snapStartEnabled,
-			SnapStartStatus:  snapStartStatus,
-			State:            string(detail.State),
-			LastModified:     safeString(detail.LastModified),
-			Description:      safeString(detail.Description),
-			CodeSize:         detail.CodeSize,
-			LogGroupName:     fmt.Sprintf("/aws/lambda/%s", safeString(detail.FunctionName)),
-		})
+// UpdateAliasRouting points alias of functionName at version and routes
+// weight (0 to 1) of the invocations to additionalVersion. An empty
+// additionalVersion routes all invocations to version.
+func (c *LambdaService) UpdateAliasRouting(ctx context.Context, functionName, alias, version, additionalVersion string, weight float64) error {
+	if c == nil || c.client == nil {
+		return fmt.Errorf("lambda service not initialized")
 	}
 
-	return functions, nil
+	// An empty map removes the routing configuration of the alias
+	weights := map[string]float64{}
+	if additionalVersion != "" {
+		weights[additionalVersion] = weight
+	}
+	_, err := c.client.UpdateAlias(ctx, &lambda.UpdateAliasInput{
+		FunctionName:    aws.String(functionName),
+		Name:            aws.String(alias),
+		FunctionVersion: aws.String(version),
+		RoutingConfig:   &types.AliasRoutingConfiguration{AdditionalVersionWeights: weights},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update alias %s:%s: %w", functionName, alias, err)
+	}
+	return nil
+}
+
+// ListEventSourceMappings returns the functions that read from the queue or
+// stream sourceARN
+func (c *LambdaService) ListEventSourceMappings(ctx context.Context, sourceARN string) ([]EventSourceMapping, error) {
+	if c == nil || c.client == nil {
+		return nil, fmt.Errorf("lambda service not initialized")
+	}
+
+	var mappings []EventSourceMapping
+	paginator := lambda.NewListEventSourceMappingsPaginator(c.client, &lambda.ListEventSourceMappingsInput{
+		EventSourceArn: aws.String(sourceARN),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list event source mappings of %s: %w", sourceARN, err)
+		}
+		for _, mapping := range output.EventSourceMappings {
+			mappings = append(mappings, EventSourceMapping{
+				UUID:        aws.ToString(mapping.UUID),
+				FunctionARN: aws.ToString(mapping.FunctionArn),
+				SourceARN:   aws.ToString(mapping.EventSourceArn),
+				State:       aws.ToString(mapping.State),
+				BatchSize:   safeInt32(mapping.BatchSize),
+			})
+		}
+	}
+	return mappings, nil
+}
+
+func toLambdaFunctionDetail(fn types.FunctionConfiguration) LambdaFunctionDetail {
+	// Extract SnapStart information
+	snapStartEnabled := false
+	snapStartStatus := "Not Available"
+
+	if fn.SnapStart != nil {
+		snapStartEnabled = fn.SnapStart.ApplyOn == types.SnapStartApplyOnPublishedVersions
+		if fn.SnapStart.OptimizationStatus != "" {
+			snapStartStatus = string(fn.SnapStart.OptimizationStatus)
+		}
+	}
+
+	return LambdaFunctionDetail{
+		FunctionName:     safeString(fn.FunctionName),
+		Runtime:          string(fn.Runtime),
+		Handler:          safeString(fn.Handler),
+		MemorySize:       safeInt32(fn.MemorySize),
+		Timeout:          safeInt32(fn.Timeout),
+		SnapStartEnabled: snapStartEnabled,
+		SnapStartStatus:  snapStartStatus,
+		State:            string(fn.State),
+		StateReason:      safeString(fn.StateReason),
+		LastUpdateStatus: string(fn.LastUpdateStatus),
+		LastModified:     safeString(fn.LastModified),
+		Description:      safeString(fn.Description),
+		CodeSize:         fn.CodeSize,
+		LogGroupName:     fmt.Sprintf("/aws/lambda/%s", safeString(fn.FunctionName)),
+	}
 }
 
 func safeString(ptr *string) string {
@@ -0,0 +1,125 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"swiss-army-tui/internal/workpool"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+// lambdaServer lists count functions named fn-0, fn-1, ... and answers
+// their configurations with configuration
+func lambdaServer(t *testing.T, count int, configuration http.HandlerFunc) *LambdaService {
+	t.Helper()
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/configuration") {
+			configuration(w, r)
+			return
+		}
+		var functions []string
+		for i := 0; i < count; i++ {
+			functions = append(functions, fmt.Sprintf(`{"FunctionName":"fn-%d","Runtime":"python3.12"}`, i))
+		}
+		fmt.Fprintf(w, `{"Functions":[%s]}`, strings.Join(functions, ","))
+	})
+
+	svc, err := NewLambdaService(lambda.NewFromConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+// functionName returns the name of the function of a configuration request
+func functionName(r *http.Request) string {
+	return strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/2015-03-31/functions/"), "/configuration")
+}
+
+func TestGetLambdaDetailBounded(t *testing.T) {
+	workpool.SetLimits(0, map[string]int{"lambda": 3})
+	t.Cleanup(func() { workpool.SetLimits(0, nil) })
+
+	var running, most atomic.Int32
+	svc := lambdaServer(t, 12, func(w http.ResponseWriter, r *http.Request) {
+		now := running.Add(1)
+		defer running.Add(-1)
+		for {
+			seen := most.Load()
+			if now <= seen || most.CompareAndSwap(seen, now) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		name := functionName(r)
+		if name == "fn-7" {
+			w.Header().Set("X-Amzn-Errortype", "AccessDeniedException")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"not allowed"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"FunctionName":%q,"Runtime":"python3.12","State":"Active"}`, name)
+	})
+
+	functions, err := svc.GetLambdaDetail(context.Background())
+	if got := most.Load(); got > 3 || got < 2 {
+		t.Errorf("Expected up to 3 configurations fetched at once, got %d", got)
+	}
+
+	// The failed function is returned as listed, next to the others
+	var partial *PartialError
+	if !errors.As(err, &partial) || len(partial.Failures) != 1 || partial.Failures[0].Item != "fn-7" {
+		t.Fatalf("Expected a partial error for fn-7, got %v", err)
+	}
+	if len(functions) != 12 {
+		t.Fatalf("Expected all 12 functions, got %d", len(functions))
+	}
+	for i, fn := range functions {
+		want := "Active"
+		if fn.FunctionName == "fn-7" {
+			want = ""
+		}
+		if fn.FunctionName != fmt.Sprintf("fn-%d", i) || fn.State != want {
+			t.Errorf("Expected fn-%d in state %q, got %+v", i, want, fn)
+		}
+	}
+}
+
+func TestGetLambdaDetailCancelled(t *testing.T) {
+	workpool.SetLimits(0, map[string]int{"lambda": 2})
+	t.Cleanup(func() { workpool.SetLimits(0, nil) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var fetched []string
+	svc := lambdaServer(t, 10, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fetched = append(fetched, functionName(r))
+		if len(fetched) == 2 {
+			// Navigating away while the first configurations are fetched
+			cancel()
+		}
+		mu.Unlock()
+		<-r.Context().Done()
+	})
+
+	if _, err := svc.GetLambdaDetail(ctx); err == nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the fetch to be cancelled, got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(fetched) != 2 {
+		t.Errorf("Expected no configurations fetched after the cancel, got %v", fetched)
+	}
+}
@@ -0,0 +1,117 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/smithy-go"
+)
+
+// ItemError is the failure of one item of a call that fans out over many
+// items, e.g. one function of a Lambda listing or one region of a search
+type ItemError struct {
+	Item   string
+	Region string
+	Err    error
+}
+
+// Reason returns a short cause of the failure such as "access denied"
+func (e ItemError) Reason() string {
+	return ErrorReason(e.Err)
+}
+
+// ErrorReason returns a short cause of an AWS error such as "access denied",
+// the error code for other API errors and "failed" for anything else
+func ErrorReason(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		code := apiErr.ErrorCode()
+		switch {
+		case strings.Contains(code, "AccessDenied"), strings.Contains(code, "Unauthorized"), strings.Contains(code, "NotAuthorized"), code == "Forbidden":
+			return "access denied"
+		case strings.Contains(code, "Throttl"), code == "TooManyRequestsException", code == "RequestLimitExceeded", code == "SlowDown":
+			return "throttled"
+		case strings.Contains(code, "NotFound"), code == "NoSuchBucket":
+			return "not found"
+		default:
+			return code
+		}
+	}
+	return "failed"
+}
+
+// PartialError is returned together with the results of a fan-out call when
+// some of its items failed
+type PartialError struct {
+	Op       string
+	Failures []ItemError
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%s: %d item(s) failed: %s", e.Op, len(e.Failures), SummarizeFailures(e.Failures, "item"))
+}
+
+// Unwrap returns the errors of the failed items
+func (e *PartialError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		errs = append(errs, failure.Err)
+	}
+	return errs
+}
+
+// SummarizeFailures groups failures by reason and region, e.g.
+// "access denied on 3 functions; throttled in eu-west-1"
+func SummarizeFailures(failures []ItemError, noun string) string {
+	type group struct {
+		reason string
+		region string
+	}
+	counts := make(map[group]int)
+	for _, failure := range failures {
+		counts[group{failure.Reason(), failure.Region}]++
+	}
+
+	var parts []string
+	for g, count := range counts {
+		part := g.reason
+		if count == 1 {
+			part += fmt.Sprintf(" on 1 %s", noun)
+		} else {
+			part += fmt.Sprintf(" on %d %ss", count, noun)
+		}
+		if g.region != "" {
+			part += " in " + g.region
+		}
+		parts = append(parts, part)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "; ")
+}
+
+// failureCollector gathers item errors from concurrent workers
+type failureCollector struct {
+	mu       sync.Mutex
+	failures []ItemError
+}
+
+func (c *failureCollector) add(item, region string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = append(c.failures, ItemError{Item: item, Region: region, Err: err})
+}
+
+// err returns a PartialError for op if any item failed, nil otherwise
+func (c *failureCollector) err(op string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.failures) == 0 {
+		return nil
+	}
+	sort.Slice(c.failures, func(i, j int) bool { return c.failures[i].Item < c.failures[j].Item })
+	return &PartialError{Op: op, Failures: append([]ItemError(nil), c.failures...)}
+}
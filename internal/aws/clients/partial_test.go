@@ -0,0 +1,53 @@
+package clients
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestSummarizeFailures(t *testing.T) {
+	denied := &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "denied"}
+	throttled := &smithy.GenericAPIError{Code: "ThrottlingException", Message: "slow down"}
+
+	failures := []ItemError{
+		{Item: "fn-a", Err: denied},
+		{Item: "fn-b", Err: denied},
+		{Item: "fn-c", Err: denied},
+		{Item: "fn-d", Region: "eu-west-1", Err: throttled},
+	}
+
+	got := SummarizeFailures(failures, "function")
+	want := "access denied on 3 functions; throttled on 1 function in eu-west-1"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	if reason := (ItemError{Err: errors.New("boom")}).Reason(); reason != "failed" {
+		t.Errorf("Expected generic reason %q, got %q", "failed", reason)
+	}
+}
+
+func TestFailureCollector(t *testing.T) {
+	var collector failureCollector
+	if err := collector.err("op"); err != nil {
+		t.Fatalf("Expected no error without failures, got %v", err)
+	}
+
+	cause := errors.New("boom")
+	collector.add("b", "", cause)
+	collector.add("a", "", cause)
+
+	err := collector.err("op")
+	var partial *PartialError
+	if !errors.As(err, &partial) {
+		t.Fatalf("Expected a PartialError, got %v", err)
+	}
+	if len(partial.Failures) != 2 || partial.Failures[0].Item != "a" {
+		t.Errorf("Expected failures sorted by item, got %+v", partial.Failures)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("Expected the PartialError to unwrap to the item errors")
+	}
+}
@@ -0,0 +1,201 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+const (
+	// piTargetPrefix prefixes the operation in the X-Amz-Target header of a
+	// Performance Insights call
+	piTargetPrefix = "PerformanceInsightsv20180227."
+	// dbLoadMetric is the average number of active sessions of a database
+	dbLoadMetric = "db.load.avg"
+)
+
+// DBLoadItem is a SQL statement or a wait event and the load it caused
+type DBLoadItem struct {
+	// ID is the digest of a tokenized SQL statement, empty for wait events
+	ID string
+	// Name is the tokenized SQL statement or the name of the wait event
+	Name string
+	// Type is the type of a wait event such as CPU, IO or Lock
+	Type string
+	// Load is the average number of active sessions over the window
+	Load float64
+}
+
+// DBLoad is the load of a database over a window, as average active
+// sessions, with the SQL statements and wait events that caused most of it
+type DBLoad struct {
+	Average  float64
+	Peak     float64
+	TopSQL   []DBLoadItem
+	TopWaits []DBLoadItem
+}
+
+// PerformanceInsightsService reads the database load of RDS instances with
+// Performance Insights enabled
+type PerformanceInsightsService struct {
+	*jsonAPI
+}
+
+// NewPerformanceInsightsService creates a new Performance Insights service
+// for the region and credentials of cfg
+func NewPerformanceInsightsService(cfg aws.Config) (*PerformanceInsightsService, error) {
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("Performance Insights credentials not provided")
+	}
+	return &PerformanceInsightsService{
+		jsonAPI: newJSONAPI(cfg, "PI", regionalEndpoint("pi", cfg.Region), cfg.Region, "pi", piTargetPrefix),
+	}, nil
+}
+
+// GetDBLoad returns the load of the instance with the DbiResourceId
+// resourceID between start and end with its top limit SQL statements and
+// wait events, each sorted by load
+func (s *PerformanceInsightsService) GetDBLoad(ctx context.Context, resourceID string, start, end time.Time, limit int) (DBLoad, error) {
+	if s == nil || s.jsonAPI == nil {
+		return DBLoad{}, fmt.Errorf("Performance Insights service not initialized")
+	}
+
+	var load DBLoad
+	var err error
+	load.Average, load.Peak, err = s.loadSummary(ctx, resourceID, start, end)
+	if err != nil {
+		return DBLoad{}, fmt.Errorf("failed to read the load of %s: %w", resourceID, err)
+	}
+
+	keys, err := s.topKeys(ctx, resourceID, start, end, "db.sql_tokenized", limit)
+	if err != nil {
+		return DBLoad{}, fmt.Errorf("failed to read the top SQL of %s: %w", resourceID, err)
+	}
+	for _, key := range keys {
+		load.TopSQL = append(load.TopSQL, DBLoadItem{
+			ID:   key.Dimensions["db.sql_tokenized.id"],
+			Name: key.Dimensions["db.sql_tokenized.statement"],
+			Load: key.Total,
+		})
+	}
+
+	keys, err = s.topKeys(ctx, resourceID, start, end, "db.wait_event", limit)
+	if err != nil {
+		return DBLoad{}, fmt.Errorf("failed to read the top waits of %s: %w", resourceID, err)
+	}
+	for _, key := range keys {
+		load.TopWaits = append(load.TopWaits, DBLoadItem{
+			Name: key.Dimensions["db.wait_event.name"],
+			Type: key.Dimensions["db.wait_event.type"],
+			Load: key.Total,
+		})
+	}
+
+	SortDBLoadItems(load.TopSQL)
+	SortDBLoadItems(load.TopWaits)
+	return load, nil
+}
+
+// SortDBLoadItems sorts items by load, highest first
+func SortDBLoadItems(items []DBLoadItem) {
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Load > items[j].Load })
+}
+
+// DBLoadPeriod returns the period of the load datapoints of a window. The
+// API only accepts 1 second, 1 minute, 5 minutes, 1 hour and 1 day.
+func DBLoadPeriod(window time.Duration) int32 {
+	switch {
+	case window <= 3*time.Hour:
+		return 60
+	case window <= 24*time.Hour:
+		return 300
+	default:
+		return 3600
+	}
+}
+
+// dimensionKey is a group of a DescribeDimensionKeys response
+type dimensionKey struct {
+	Dimensions map[string]string `json:"Dimensions"`
+	Total      float64           `json:"Total"`
+}
+
+// topKeys returns the limit dimension keys of group with the most load
+func (s *PerformanceInsightsService) topKeys(ctx context.Context, resourceID string, start, end time.Time, group string, limit int) ([]dimensionKey, error) {
+	var dimensions []string
+	switch group {
+	case "db.sql_tokenized":
+		dimensions = []string{"db.sql_tokenized.id", "db.sql_tokenized.statement"}
+	case "db.wait_event":
+		dimensions = []string{"db.wait_event.name", "db.wait_event.type"}
+	}
+
+	var output struct {
+		Keys []dimensionKey `json:"Keys"`
+	}
+	err := s.call(ctx, "DescribeDimensionKeys", map[string]any{
+		"ServiceType": "RDS",
+		"Identifier":  resourceID,
+		"StartTime":   start.Unix(),
+		"EndTime":     end.Unix(),
+		"Metric":      dbLoadMetric,
+		"GroupBy": map[string]any{
+			"Group":      group,
+			"Dimensions": dimensions,
+			"Limit":      min(max(limit, 1), 25),
+		},
+	}, &output)
+	return output.Keys, err
+}
+
+// loadSummary returns the average and the highest load between start and end
+func (s *PerformanceInsightsService) loadSummary(ctx context.Context, resourceID string, start, end time.Time) (float64, float64, error) {
+	input := map[string]any{
+		"ServiceType":     "RDS",
+		"Identifier":      resourceID,
+		"StartTime":       start.Unix(),
+		"EndTime":         end.Unix(),
+		"PeriodInSeconds": DBLoadPeriod(end.Sub(start)),
+		"MetricQueries":   []map[string]any{{"Metric": dbLoadMetric}},
+	}
+
+	var sum, peak float64
+	var count int
+	for {
+		var output struct {
+			MetricList []struct {
+				DataPoints []struct {
+					Value *float64 `json:"Value"`
+				} `json:"DataPoints"`
+			} `json:"MetricList"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.call(ctx, "GetResourceMetrics", input, &output); err != nil {
+			return 0, 0, err
+		}
+		for _, metric := range output.MetricList {
+			for _, point := range metric.DataPoints {
+				// Periods without samples have no value
+				if point.Value == nil {
+					continue
+				}
+				sum += *point.Value
+				peak = math.Max(peak, *point.Value)
+				count++
+			}
+		}
+		if output.NextToken == "" {
+			break
+		}
+		input["NextToken"] = output.NextToken
+	}
+
+	if count == 0 {
+		return 0, 0, nil
+	}
+	return sum / float64(count), peak, nil
+}
@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPerformanceInsightsGetDBLoad(t *testing.T) {
+	var pages int
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/pi/aws4_request") {
+			t.Errorf("Expected a request signed for pi, got %q", r.Header.Get("Authorization"))
+		}
+		var input map[string]any
+		json.NewDecoder(r.Body).Decode(&input)
+		if input["Identifier"] == "db-DENIED" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"com.amazon.pi#NotAuthorizedException","message":"not authorized"}`))
+			return
+		}
+
+		switch r.Header.Get("X-Amz-Target") {
+		case piTargetPrefix + "GetResourceMetrics":
+			pages++
+			if input["NextToken"] == nil {
+				w.Write([]byte(`{"MetricList":[{"Key":{"Metric":"db.load.avg"},"DataPoints":[{"Timestamp":1,"Value":1.0},{"Timestamp":2}]}],"NextToken":"p2"}`))
+				return
+			}
+			w.Write([]byte(`{"MetricList":[{"Key":{"Metric":"db.load.avg"},"DataPoints":[{"Timestamp":3,"Value":3.0}]}]}`))
+		case piTargetPrefix + "DescribeDimensionKeys":
+			group := input["GroupBy"].(map[string]any)["Group"]
+			if group == "db.sql_tokenized" {
+				w.Write([]byte(`{"Keys":[{"Dimensions":{"db.sql_tokenized.id":"B","db.sql_tokenized.statement":"COMMIT"},"Total":0.1},
+					{"Dimensions":{"db.sql_tokenized.id":"A","db.sql_tokenized.statement":"SELECT 1"},"Total":1.2}]}`))
+				return
+			}
+			w.Write([]byte(`{"Keys":[{"Dimensions":{"db.wait_event.name":"CPU","db.wait_event.type":"CPU"},"Total":1.3}]}`))
+		default:
+			t.Errorf("Unexpected target %q", r.Header.Get("X-Amz-Target"))
+		}
+	})
+
+	svc, err := NewPerformanceInsightsService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	end := time.Now()
+	load, err := svc.GetDBLoad(context.Background(), "db-ABC", end.Add(-time.Hour), end, 10)
+	if err != nil {
+		t.Fatalf("GetDBLoad returned error: %v", err)
+	}
+	if load.Average != 2 || load.Peak != 3 || pages != 2 {
+		t.Errorf("Expected average 2 and peak 3 over 2 pages skipping empty periods, got %+v over %d pages", load, pages)
+	}
+	if len(load.TopSQL) != 2 || load.TopSQL[0].ID != "A" || load.TopSQL[0].Name != "SELECT 1" {
+		t.Errorf("Expected the top SQL sorted by load, got %+v", load.TopSQL)
+	}
+	if len(load.TopWaits) != 1 || load.TopWaits[0].Type != "CPU" || load.TopWaits[0].Load != 1.3 {
+		t.Errorf("Unexpected top waits %+v", load.TopWaits)
+	}
+
+	_, err = svc.GetDBLoad(context.Background(), "db-DENIED", end.Add(-time.Hour), end, 10)
+	if got := ErrorReason(err); got != "access denied" {
+		t.Errorf("Expected NotAuthorizedException to be access denied, got %q (%v)", got, err)
+	}
+}
+
+func TestDBLoadPeriod(t *testing.T) {
+	for window, want := range map[time.Duration]int32{time.Hour: 60, 12 * time.Hour: 300, 7 * 24 * time.Hour: 3600} {
+		if got := DBLoadPeriod(window); got != want {
+			t.Errorf("DBLoadPeriod(%s) = %d, want %d", window, got, want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package clients
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go"
+)
+
+// queryAPI calls an AWS Query API such as CloudFormation directly, with form
+// encoded requests and XML responses
+type queryAPI struct {
+	signedClient
+	version string
+}
+
+// newQueryAPI creates a client of version of the API serviceID at endpoint
+// in region, signing requests for signingName
+func newQueryAPI(cfg aws.Config, serviceID, endpoint, region, signingName, version string) *queryAPI {
+	api := &queryAPI{
+		signedClient: newSignedClient(cfg, serviceID, endpoint, region, signingName),
+		version:      version,
+	}
+	api.decodeError = queryError
+	return api
+}
+
+// call signs and sends action with params and decodes its XML response into
+// output. Failed calls wrap a smithy.APIError with the error code, e.g.
+// AccessDenied.
+func (a *queryAPI) call(ctx context.Context, action string, params url.Values, output any) error {
+	form := url.Values{}
+	for key, values := range params {
+		form[key] = values
+	}
+	form.Set("Action", action)
+	form.Set("Version", a.version)
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	data, err := a.send(ctx, action, header, []byte(form.Encode()))
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(data, output); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", action, err)
+	}
+	return nil
+}
+
+// queryError decodes a failed call of a Query API into a smithy.APIError.
+// Failures come as <ErrorResponse><Error><Code>AccessDenied</Code>...
+func queryError(status int, _ http.Header, data []byte) error {
+	var failure struct {
+		Error struct {
+			Code    string `xml:"Code"`
+			Message string `xml:"Message"`
+		} `xml:"Error"`
+	}
+	if xml.Unmarshal(data, &failure) != nil || failure.Error.Code == "" {
+		failure.Error.Code = strings.ReplaceAll(http.StatusText(status), " ", "")
+		failure.Error.Message = strings.TrimSpace(string(data))
+	}
+	return &smithy.GenericAPIError{Code: failure.Error.Code, Message: failure.Error.Message}
+}
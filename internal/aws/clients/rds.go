@@ -3,10 +3,12 @@ package clients
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"swiss-army-tui/pkg/logger"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"go.uber.org/zap"
 )
@@ -14,6 +16,8 @@ import (
 // RDSDetails represents the details of an RDS instance
 type RDSDetails struct {
 	DBInstanceIdentifier string
+	DBInstanceClass      string
+	MultiAZ              bool
 	Engine               string
 	EngineVersion        string
 	DBInstanceStatus     string
@@ -21,6 +25,9 @@ type RDSDetails struct {
 	AllocatedStorage     int32
 	InstanceCreateTime   *time.Time
 	Region               string
+	// DbiResourceID identifies the instance to Performance Insights
+	DbiResourceID              string
+	PerformanceInsightsEnabled bool
 }
 
 // RDSService wraps the RDS client and provides high-level operations
@@ -28,8 +35,9 @@ type RDSService struct {
 	client *rds.Client
 }
 
-// GetRDSDetail retrieves details of all RDS instances
-func (s *RDSService) GetRDSDetail(ctx context.Context) ([]RDSDetails, error) {
+// GetRDSDetail retrieves details of all RDS instances. onPage, if set, is
+// called with the instances of every page as it arrives.
+func (s *RDSService) GetRDSDetail(ctx context.Context, onPage func([]RDSDetails)) ([]RDSDetails, error) {
 	if s == nil || s.client == nil {
 		return nil, fmt.Errorf("RDS service not initialized")
 	}
@@ -49,14 +57,19 @@ func (s *RDSService) GetRDSDetail(ctx context.Context) ([]RDSDetails, error) {
 			return nil, fmt.Errorf("failed to describe RDS instances: %w", err)
 		}
 
+		page := make([]RDSDetails, 0, len(output.DBInstances))
 		for _, dbInstance := range output.DBInstances {
 			detail := RDSDetails{
-				DBInstanceIdentifier: getStringValue(dbInstance.DBInstanceIdentifier),
-				Engine:               getStringValue(dbInstance.Engine),
-				EngineVersion:        getStringValue(dbInstance.EngineVersion),
-				DBInstanceStatus:     getStringValue(dbInstance.DBInstanceStatus),
-				AllocatedStorage:     getInt32Value(dbInstance.AllocatedStorage),
-				InstanceCreateTime:   dbInstance.InstanceCreateTime,
+				DBInstanceIdentifier:       getStringValue(dbInstance.DBInstanceIdentifier),
+				DBInstanceClass:            getStringValue(dbInstance.DBInstanceClass),
+				MultiAZ:                    dbInstance.MultiAZ != nil && *dbInstance.MultiAZ,
+				Engine:                     getStringValue(dbInstance.Engine),
+				EngineVersion:              getStringValue(dbInstance.EngineVersion),
+				DBInstanceStatus:           getStringValue(dbInstance.DBInstanceStatus),
+				AllocatedStorage:           getInt32Value(dbInstance.AllocatedStorage),
+				InstanceCreateTime:         dbInstance.InstanceCreateTime,
+				DbiResourceID:              getStringValue(dbInstance.DbiResourceId),
+				PerformanceInsightsEnabled: dbInstance.PerformanceInsightsEnabled != nil && *dbInstance.PerformanceInsightsEnabled,
 			}
 
 			// Get the endpoint
@@ -67,13 +80,163 @@ func (s *RDSService) GetRDSDetail(ctx context.Context) ([]RDSDetails, error) {
 				)
 			}
 
-			allInstances = append(allInstances, detail)
+			page = append(page, detail)
+		}
+		allInstances = append(allInstances, page...)
+		if onPage != nil {
+			onPage(page)
 		}
 	}
 
 	return allInstances, nil
 }
 
+// ParameterGroup is a DB parameter group with the instances using it
+type ParameterGroup struct {
+	Name        string
+	Family      string
+	Description string
+	ARN         string
+	Instances   []ParameterGroupMember
+}
+
+// ParameterGroupMember is an instance using a parameter group and whether
+// it applied the latest changes of the group
+type ParameterGroupMember struct {
+	Instance string
+	// ApplyStatus is in-sync, applying, pending-reboot or failed-to-apply
+	ApplyStatus string
+}
+
+// PendingReboot returns the instances that apply changes of the group only
+// after a reboot
+func (g ParameterGroup) PendingReboot() []string {
+	var instances []string
+	for _, member := range g.Instances {
+		if member.ApplyStatus == "pending-reboot" {
+			instances = append(instances, member.Instance)
+		}
+	}
+	return instances
+}
+
+// ParameterDiff is a parameter set in a parameter group with the value it
+// has in the default group of the engine
+type ParameterDiff struct {
+	Name  string
+	Value string
+	// Default is the value in the engine default group, empty when the
+	// engine leaves it to the database
+	Default string
+	// ApplyType is static when a change only takes effect after a reboot
+	ApplyType   string
+	Description string
+}
+
+// ListParameterGroups returns the DB parameter groups of the region by name
+// with the instances using each of them
+func (s *RDSService) ListParameterGroups(ctx context.Context) ([]ParameterGroup, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("RDS service not initialized")
+	}
+
+	var groups []ParameterGroup
+	byName := make(map[string]int)
+	paginator := rds.NewDescribeDBParameterGroupsPaginator(s.client, &rds.DescribeDBParameterGroupsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe DB parameter groups: %w", err)
+		}
+		for _, group := range output.DBParameterGroups {
+			byName[aws.ToString(group.DBParameterGroupName)] = len(groups)
+			groups = append(groups, ParameterGroup{
+				Name:        aws.ToString(group.DBParameterGroupName),
+				Family:      aws.ToString(group.DBParameterGroupFamily),
+				Description: aws.ToString(group.Description),
+				ARN:         aws.ToString(group.DBParameterGroupArn),
+			})
+		}
+	}
+
+	instances := rds.NewDescribeDBInstancesPaginator(s.client, &rds.DescribeDBInstancesInput{})
+	for instances.HasMorePages() {
+		output, err := instances.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe RDS instances: %w", err)
+		}
+		for _, instance := range output.DBInstances {
+			for _, status := range instance.DBParameterGroups {
+				i, ok := byName[aws.ToString(status.DBParameterGroupName)]
+				if !ok {
+					continue
+				}
+				groups[i].Instances = append(groups[i].Instances, ParameterGroupMember{
+					Instance:    aws.ToString(instance.DBInstanceIdentifier),
+					ApplyStatus: aws.ToString(status.ParameterApplyStatus),
+				})
+			}
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
+// DiffParameterGroup returns the parameters set in the group name, sorted by
+// name, with their values in the default group of the engine family
+func (s *RDSService) DiffParameterGroup(ctx context.Context, name, family string) ([]ParameterDiff, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("RDS service not initialized")
+	}
+
+	var diffs []ParameterDiff
+	paginator := rds.NewDescribeDBParametersPaginator(s.client, &rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(name),
+		Source:               aws.String("user"),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe the parameters of %s: %w", name, err)
+		}
+		for _, parameter := range output.Parameters {
+			diffs = append(diffs, ParameterDiff{
+				Name:        aws.ToString(parameter.ParameterName),
+				Value:       aws.ToString(parameter.ParameterValue),
+				ApplyType:   aws.ToString(parameter.ApplyType),
+				Description: aws.ToString(parameter.Description),
+			})
+		}
+	}
+	if len(diffs) == 0 {
+		return nil, nil
+	}
+
+	defaults := make(map[string]string)
+	engine := rds.NewDescribeEngineDefaultParametersPaginator(s.client, &rds.DescribeEngineDefaultParametersInput{
+		DBParameterGroupFamily: aws.String(family),
+	})
+	for engine.HasMorePages() {
+		output, err := engine.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe the engine defaults of %s: %w", family, err)
+		}
+		if output.EngineDefaults == nil {
+			continue
+		}
+		for _, parameter := range output.EngineDefaults.Parameters {
+			defaults[aws.ToString(parameter.ParameterName)] = aws.ToString(parameter.ParameterValue)
+		}
+	}
+
+	for i := range diffs {
+		diffs[i].Default = defaults[diffs[i].Name]
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+	return diffs, nil
+}
+
 // NewRDSService creates a new RDSService instance
 func NewRDSService(client *rds.Client) (*RDSService, error) {
 	if client == nil {
@@ -3,10 +3,17 @@ package clients
 import (
 	"context"
 	"fmt"
-	"swiss-army-tui/pkg/logger"
+	"io"
+	"net/url"
+	"sync"
 	"time"
 
+	"swiss-army-tui/internal/workpool"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"go.uber.org/zap"
 )
 
@@ -16,13 +23,46 @@ type S3Details struct {
 	Region       string
 }
 
+// s3RegionTTL is how long a looked up bucket region is reused. Regions of a
+// bucket never change, but a deleted bucket's name can be taken in another one.
+const s3RegionTTL = time.Hour
+
 type S3Service struct {
 	client *s3.Client
+
+	// Bucket regions are cached across refreshes
+	mu      sync.RWMutex
+	regions map[string]bucketRegionEntry
+	now     func() time.Time
 }
 
+type bucketRegionEntry struct {
+	region   string
+	lookedUp time.Time
+}
+
+// GetS3Detail lists all buckets including their regions. Buckets whose region
+// lookup failed are returned without one, with a *PartialError naming them.
 func (s *S3Service) GetS3Detail(ctx context.Context) ([]S3Details, error) {
-	var details []S3Details
+	details, err := s.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, detail := range details {
+		names = append(names, detail.Name)
+	}
+	lookupErr := s.LookupBucketRegions(ctx, names, nil)
+
+	for i := range details {
+		details[i].Region = s.cachedRegion(details[i].Name)
+	}
+	return details, lookupErr
+}
 
+// ListBuckets lists all buckets. Only regions already looked up are set.
+func (s *S3Service) ListBuckets(ctx context.Context) ([]S3Details, error) {
 	if s == nil || s.client == nil {
 		return nil, fmt.Errorf("s3 service not initialized")
 	}
@@ -33,30 +73,103 @@ func (s *S3Service) GetS3Detail(ctx context.Context) ([]S3Details, error) {
 		return nil, fmt.Errorf("failed to list s3 buckets: %w", err)
 	}
 
+	var details []S3Details
 	for _, bucket := range listOutput.Buckets {
-		region := ""
-		locationOutput, err := s.client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
-			Bucket: bucket.Name,
-		})
 		name := ""
 		if bucket.Name != nil {
 			name = *bucket.Name
 		}
+
+		details = append(details, S3Details{
+			Name:         name,
+			CreationDate: bucket.CreationDate,
+			Region:       s.cachedRegion(name),
+		})
+	}
+
+	return details, nil
+}
+
+// LookupBucketRegions looks up the regions of buckets concurrently, in the
+// "s3" slots of the worker pool, and calls onRegion, if set, for every region
+// found. Failed lookups are returned as a *PartialError. Cached regions are
+// not looked up again. It returns once all lookups finished or ctx is
+// cancelled.
+func (s *S3Service) LookupBucketRegions(ctx context.Context, names []string, onRegion func(name, region string)) error {
+	if s == nil || s.client == nil {
+		return fmt.Errorf("s3 service not initialized")
+	}
+
+	var lookups []string
+	for _, name := range names {
+		if region := s.cachedRegion(name); region != "" {
+			if onRegion != nil {
+				onRegion(name, region)
+			}
+			continue
+		}
+		lookups = append(lookups, name)
+	}
+
+	var failures failureCollector
+	err := workpool.Each(ctx, "s3", len(lookups), func(i int) {
+		name := lookups[i]
+		region, err := s.lookupBucketRegion(ctx, name)
 		if err != nil {
 			logger.Debug("failed to get bucket location", zap.String("bucket", name), zap.Error(err))
-		} else if locationOutput.LocationConstraint != "" {
-			region = string(locationOutput.LocationConstraint)
+			if ctx.Err() == nil {
+				failures.add(name, "", err)
+			}
+			return
 		}
 
-		detail := S3Details{
-			Name:         name,
-			CreationDate: bucket.CreationDate,
-			Region:       region,
+		s.mu.Lock()
+		s.regions[name] = bucketRegionEntry{region: region, lookedUp: s.now()}
+		s.mu.Unlock()
+
+		if onRegion != nil {
+			onRegion(name, region)
 		}
-		details = append(details, detail)
+	})
+	if err != nil {
+		return err
 	}
+	return failures.err("look up bucket regions")
+}
 
-	return details, nil
+func (s *S3Service) lookupBucketRegion(ctx context.Context, name string) (string, error) {
+	output, err := s.client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+		Bucket: &name,
+	})
+	if err != nil {
+		return "", err
+	}
+	return bucketRegion(string(output.LocationConstraint)), nil
+}
+
+// bucketRegion maps a bucket location constraint to its region
+func bucketRegion(constraint string) string {
+	switch constraint {
+	case "":
+		// Buckets in us-east-1 have no location constraint
+		return "us-east-1"
+	case "EU":
+		return "eu-west-1"
+	default:
+		return constraint
+	}
+}
+
+// cachedRegion returns the region of bucket, empty if it was not looked up
+// or the lookup expired
+func (s *S3Service) cachedRegion(name string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.regions[name]
+	if !ok || s.now().Sub(entry.lookedUp) >= s3RegionTTL {
+		return ""
+	}
+	return entry.region
 }
 
 func NewS3Service(S3Client *s3.Client) (*S3Service, error) {
@@ -65,6 +178,306 @@ func NewS3Service(S3Client *s3.Client) (*S3Service, error) {
 	}
 
 	return &S3Service{
-		client: S3Client,
+		client:  S3Client,
+		regions: make(map[string]bucketRegionEntry),
+		now:     time.Now,
 	}, nil
 }
+
+// S3Object is an object of a bucket, or a common prefix of the keys below a
+// prefix when listed by folder
+type S3Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	StorageClass string
+	IsPrefix     bool
+}
+
+// s3DeleteBatch is the most keys DeleteObjects takes at once
+const s3DeleteBatch = 1000
+
+// ListObjects lists the objects of bucket below prefix. Unless recursive, keys
+// containing a slash after prefix are folded into their common prefix.
+func (s *S3Service) ListObjects(ctx context.Context, bucket, prefix string, recursive bool) ([]S3Object, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("s3 service not initialized")
+	}
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}
+	if !recursive {
+		input.Delimiter = aws.String("/")
+	}
+
+	var objects []S3Object
+	paginator := s3.NewListObjectsV2Paginator(s.client, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx, s.inBucketRegion(bucket))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects of %s: %w", bucket, err)
+		}
+		for _, common := range output.CommonPrefixes {
+			objects = append(objects, S3Object{Key: aws.ToString(common.Prefix), IsPrefix: true})
+		}
+		for _, object := range output.Contents {
+			objects = append(objects, S3Object{
+				Key:          aws.ToString(object.Key),
+				Size:         aws.ToInt64(object.Size),
+				LastModified: aws.ToTime(object.LastModified),
+				StorageClass: string(object.StorageClass),
+			})
+		}
+	}
+	return objects, nil
+}
+
+// DeleteObjects deletes keys from bucket in batches. Keys that could not be
+// deleted are returned as a *PartialError.
+func (s *S3Service) DeleteObjects(ctx context.Context, bucket string, keys []string) error {
+	if s == nil || s.client == nil {
+		return fmt.Errorf("s3 service not initialized")
+	}
+
+	var failures failureCollector
+	for start := 0; start < len(keys); start += s3DeleteBatch {
+		batch := keys[start:min(start+s3DeleteBatch, len(keys))]
+		identifiers := make([]types.ObjectIdentifier, len(batch))
+		for i, key := range batch {
+			identifiers[i] = types.ObjectIdentifier{Key: aws.String(key)}
+		}
+
+		output, err := s.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &types.Delete{Objects: identifiers, Quiet: aws.Bool(true)},
+		}, s.inBucketRegion(bucket))
+		if err != nil {
+			return fmt.Errorf("failed to delete objects of %s: %w", bucket, err)
+		}
+		for _, failed := range output.Errors {
+			failures.add(aws.ToString(failed.Key), "", fmt.Errorf("%s: %s", aws.ToString(failed.Code), aws.ToString(failed.Message)))
+		}
+	}
+	return failures.err("delete objects")
+}
+
+// s3CopyLimit is the largest object CopyObject copies in one request; larger
+// ones are copied in parts
+const s3CopyLimit int64 = 5 << 30
+
+// s3CopyPartSize is the size of the parts of a multipart copy, grown for
+// objects that would need more than s3MaxParts of them
+const (
+	s3CopyPartSize int64 = 512 << 20
+	s3MaxParts     int64 = 10000
+)
+
+// CopyObject copies key of bucket, of size bytes, to destKey of destBucket,
+// keeping its metadata. An empty storageClass keeps the class of the source;
+// copying an object onto itself with another class changes its class.
+// Objects above 5 GiB are copied in parts.
+func (s *S3Service) CopyObject(ctx context.Context, bucket, key string, size int64, destBucket, destKey, storageClass string) error {
+	if s == nil || s.client == nil {
+		return fmt.Errorf("s3 service not initialized")
+	}
+
+	var err error
+	if size > s3CopyLimit {
+		err = s.copyObjectParts(ctx, bucket, key, size, destBucket, destKey, storageClass)
+	} else {
+		input := &s3.CopyObjectInput{
+			Bucket:            aws.String(destBucket),
+			Key:               aws.String(destKey),
+			CopySource:        copySource(bucket, key),
+			MetadataDirective: types.MetadataDirectiveCopy,
+		}
+		if storageClass != "" {
+			input.StorageClass = types.StorageClass(storageClass)
+		}
+		_, err = s.client.CopyObject(ctx, input, s.inBucketRegion(destBucket))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy %s/%s to %s/%s: %w", bucket, key, destBucket, destKey, err)
+	}
+	return nil
+}
+
+// copyObjectParts copies an object with a multipart upload whose parts are
+// copied from ranges of the source in the "s3" slots of the worker pool. The
+// headers and metadata of the source are carried over, as a multipart upload
+// does not copy them. A failed copy aborts the upload, so no part is left
+// behind to be billed.
+func (s *S3Service) copyObjectParts(ctx context.Context, bucket, key string, size int64, destBucket, destKey, storageClass string) error {
+	head, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}, s.inBucketRegion(bucket))
+	if err != nil {
+		return err
+	}
+
+	create := &s3.CreateMultipartUploadInput{
+		Bucket:             aws.String(destBucket),
+		Key:                aws.String(destKey),
+		CacheControl:       head.CacheControl,
+		ContentDisposition: head.ContentDisposition,
+		ContentEncoding:    head.ContentEncoding,
+		ContentLanguage:    head.ContentLanguage,
+		ContentType:        head.ContentType,
+		Metadata:           head.Metadata,
+		StorageClass:       head.StorageClass,
+	}
+	if storageClass != "" {
+		create.StorageClass = types.StorageClass(storageClass)
+	}
+	upload, err := s.client.CreateMultipartUpload(ctx, create, s.inBucketRegion(destBucket))
+	if err != nil {
+		return err
+	}
+
+	partSize := max(s3CopyPartSize, (size+s3MaxParts-1)/s3MaxParts)
+	count := int((size + partSize - 1) / partSize)
+	parts := make([]types.CompletedPart, count)
+	var mu sync.Mutex
+	var partErr error
+	err = workpool.Each(ctx, "s3", count, func(i int) {
+		start := int64(i) * partSize
+		end := min(start+partSize, size) - 1
+		output, err := s.client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
+			Bucket:          aws.String(destBucket),
+			Key:             aws.String(destKey),
+			UploadId:        upload.UploadId,
+			PartNumber:      aws.Int32(int32(i + 1)),
+			CopySource:      copySource(bucket, key),
+			CopySourceRange: aws.String(fmt.Sprintf("bytes=%d-%d", start, end)),
+		}, s.inBucketRegion(destBucket))
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			if partErr == nil {
+				partErr = fmt.Errorf("part %d: %w", i+1, err)
+			}
+			return
+		}
+		parts[i] = types.CompletedPart{PartNumber: aws.Int32(int32(i + 1))}
+		if output.CopyPartResult != nil {
+			parts[i].ETag = output.CopyPartResult.ETag
+		}
+	})
+	if err == nil {
+		err = partErr
+	}
+	if err == nil {
+		_, err = s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(destBucket),
+			Key:             aws.String(destKey),
+			UploadId:        upload.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+		}, s.inBucketRegion(destBucket))
+	}
+	if err != nil {
+		// Aborted even when the copy was cancelled
+		_, abortErr := s.client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(destBucket),
+			Key:      aws.String(destKey),
+			UploadId: upload.UploadId,
+		}, s.inBucketRegion(destBucket))
+		if abortErr != nil {
+			logger.Warn("Failed to abort multipart copy",
+				zap.String("bucket", destBucket),
+				zap.String("key", destKey),
+				zap.Error(abortErr))
+		}
+		return err
+	}
+	return nil
+}
+
+// copySource is the escaped source of a copy of key of bucket
+func copySource(bucket, key string) *string {
+	return aws.String(url.PathEscape(bucket + "/" + key))
+}
+
+// inBucketRegion sends a request to the region of bucket once it was looked
+// up, since S3 redirects requests sent to another region
+func (s *S3Service) inBucketRegion(bucket string) func(*s3.Options) {
+	region := s.cachedRegion(bucket)
+	return func(o *s3.Options) {
+		if region != "" {
+			o.Region = region
+		}
+	}
+}
+
+// ObjectPreview is the start of an object with its headers
+type ObjectPreview struct {
+	Key             string
+	Size            int64
+	ContentType     string
+	ContentEncoding string
+	StorageClass    string
+	ETag            string
+	LastModified    time.Time
+	// Metadata is the user metadata, without the x-amz-meta- prefix
+	Metadata map[string]string
+	// Body is the first bytes of the object, all of it if Truncated is false
+	Body []byte
+}
+
+// Truncated reports whether Body holds only the start of the object
+func (p ObjectPreview) Truncated() bool {
+	return int64(len(p.Body)) < p.Size
+}
+
+// GetObjectPreview returns the headers of key in bucket and at most its first
+// maxBytes, fetched with a range request so large objects are not downloaded
+func (s *S3Service) GetObjectPreview(ctx context.Context, bucket, key string, maxBytes int64) (ObjectPreview, error) {
+	if s == nil || s.client == nil {
+		return ObjectPreview{}, fmt.Errorf("s3 service not initialized")
+	}
+
+	head, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}, s.inBucketRegion(bucket))
+	if err != nil {
+		return ObjectPreview{}, fmt.Errorf("failed to get %s/%s: %w", bucket, key, err)
+	}
+
+	preview := ObjectPreview{
+		Key:             key,
+		Size:            aws.ToInt64(head.ContentLength),
+		ContentType:     aws.ToString(head.ContentType),
+		ContentEncoding: aws.ToString(head.ContentEncoding),
+		StorageClass:    string(head.StorageClass),
+		ETag:            aws.ToString(head.ETag),
+		LastModified:    aws.ToTime(head.LastModified),
+		Metadata:        head.Metadata,
+	}
+	if preview.StorageClass == "" {
+		// HeadObject leaves out the class of STANDARD objects
+		preview.StorageClass = string(types.StorageClassStandard)
+	}
+	if preview.Size == 0 || maxBytes <= 0 {
+		return preview, nil
+	}
+
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=0-%d", min(maxBytes, preview.Size)-1)),
+	}, s.inBucketRegion(bucket))
+	if err != nil {
+		return ObjectPreview{}, fmt.Errorf("failed to read %s/%s: %w", bucket, key, err)
+	}
+	defer output.Body.Close()
+
+	preview.Body, err = io.ReadAll(io.LimitReader(output.Body, maxBytes))
+	if err != nil {
+		return ObjectPreview{}, fmt.Errorf("failed to read %s/%s: %w", bucket, key, err)
+	}
+	return preview, nil
+}
@@ -0,0 +1,185 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func TestS3BucketRegionCache(t *testing.T) {
+	var mu sync.Mutex
+	lookups := map[string]int{}
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		bucket := strings.Trim(r.URL.Path, "/")
+		if bucket == "" {
+			w.Write([]byte(`<ListAllMyBucketsResult><Buckets>
+				<Bucket><Name>logs</Name></Bucket><Bucket><Name>site</Name></Bucket></Buckets></ListAllMyBucketsResult>`))
+			return
+		}
+
+		mu.Lock()
+		lookups[bucket]++
+		mu.Unlock()
+		if bucket == "site" {
+			w.Write([]byte(`<LocationConstraint></LocationConstraint>`))
+			return
+		}
+		w.Write([]byte(`<LocationConstraint>eu-central-1</LocationConstraint>`))
+	})
+
+	svc, err := NewS3Service(s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	lookup := func() map[string]string {
+		t.Helper()
+		details, err := svc.GetS3Detail(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		regions := map[string]string{}
+		for _, detail := range details {
+			regions[detail.Name] = detail.Region
+		}
+		return regions
+	}
+
+	regions := lookup()
+	if regions["logs"] != "eu-central-1" || regions["site"] != "us-east-1" {
+		t.Errorf("Expected the looked up regions, got %v", regions)
+	}
+
+	// A refresh within the TTL is served from the cache
+	now = now.Add(s3RegionTTL - time.Minute)
+	if regions := lookup(); regions["logs"] != "eu-central-1" || regions["site"] != "us-east-1" {
+		t.Errorf("Expected the cached regions, got %v", regions)
+	}
+	if lookups["logs"] != 1 || lookups["site"] != 1 {
+		t.Errorf("Expected one lookup per bucket, got %v", lookups)
+	}
+
+	// Listing alone only sets regions still cached
+	now = now.Add(time.Minute)
+	buckets, err := svc.ListBuckets(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, bucket := range buckets {
+		if bucket.Region != "" {
+			t.Errorf("Expected no region for %s once expired, got %s", bucket.Name, bucket.Region)
+		}
+	}
+
+	if regions := lookup(); regions["logs"] != "eu-central-1" {
+		t.Errorf("Expected the region looked up again, got %v", regions)
+	}
+	if lookups["logs"] != 2 || lookups["site"] != 2 {
+		t.Errorf("Expected expired regions to be looked up again, got %v", lookups)
+	}
+}
+
+func TestS3CopyObjectParts(t *testing.T) {
+	var mu sync.Mutex
+	var ranges []string
+	var completed, aborted string
+	var copied bool
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		query := r.URL.Query()
+		switch {
+		case r.Method == http.MethodHead:
+			w.Header().Set("Content-Type", "application/x-tar")
+			w.Header().Set("X-Amz-Meta-Owner", "backup")
+			w.Header().Set("X-Amz-Storage-Class", "STANDARD_IA")
+		case query.Has("uploads"):
+			if !strings.HasSuffix(r.URL.Path, "/big.tar") || r.Header.Get("Content-Type") != "application/x-tar" ||
+				r.Header.Get("X-Amz-Meta-Owner") != "backup" || r.Header.Get("X-Amz-Storage-Class") != "STANDARD_IA" {
+				t.Errorf("Expected the upload with the source headers, got %s %v", r.URL.Path, r.Header)
+			}
+			w.Write([]byte(`<InitiateMultipartUploadResult><UploadId>u1</UploadId></InitiateMultipartUploadResult>`))
+		case query.Has("partNumber"):
+			if r.Header.Get("X-Amz-Copy-Source") != "backups%2Fbig.tar" {
+				t.Errorf("Unexpected copy source %q", r.Header.Get("X-Amz-Copy-Source"))
+			}
+			ranges = append(ranges, query.Get("partNumber")+"="+r.Header.Get("X-Amz-Copy-Source-Range"))
+			if strings.HasPrefix(r.URL.Path, "/broken/") && query.Get("partNumber") == "3" {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`<Error><Code>InternalError</Code><Message>try again</Message></Error>`))
+				return
+			}
+			w.Write([]byte(`<CopyPartResult><ETag>"etag-` + query.Get("partNumber") + `"</ETag></CopyPartResult>`))
+		case r.Method == http.MethodPost && query.Has("uploadId"):
+			body, _ := io.ReadAll(r.Body)
+			completed = string(body)
+			w.Write([]byte(`<CompleteMultipartUploadResult><Key>big.tar</Key></CompleteMultipartUploadResult>`))
+		case r.Method == http.MethodDelete && query.Has("uploadId"):
+			aborted = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		case r.Header.Get("X-Amz-Copy-Source") != "":
+			copied = true
+			w.Write([]byte(`<CopyObjectResult><ETag>"etag"</ETag></CopyObjectResult>`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	svc, err := NewS3Service(s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// Up to 5 GiB is copied in one request
+	if err := svc.CopyObject(ctx, "backups", "big.tar", s3CopyLimit, "archive", "big.tar", ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !copied || len(ranges) != 0 {
+		t.Errorf("Expected a single copy request, got parts %v", ranges)
+	}
+
+	size := 2*s3CopyPartSize + 100
+	if err := svc.CopyObject(ctx, "backups", "big.tar", s3CopyLimit+size, "archive", "big.tar", ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	count := int((s3CopyLimit + size + s3CopyPartSize - 1) / s3CopyPartSize)
+	if len(ranges) != count {
+		t.Fatalf("Expected %d parts, got %d", count, len(ranges))
+	}
+	last := fmt.Sprintf("%d=bytes=%d-%d", count, int64(count-1)*s3CopyPartSize, s3CopyLimit+size-1)
+	if !slices.Contains(ranges, "1=bytes=0-536870911") || !slices.Contains(ranges, last) {
+		t.Errorf("Expected the ranges to cover the object, got %v", ranges)
+	}
+	if !strings.Contains(completed, `etag-1&#34;</ETag><PartNumber>1</PartNumber>`) ||
+		strings.Index(completed, "<PartNumber>1<") > strings.Index(completed, "<PartNumber>2<") {
+		t.Errorf("Expected the parts to be completed in order, got %s", completed)
+	}
+	if aborted != "" {
+		t.Errorf("Expected no abort, got %s", aborted)
+	}
+
+	// A failed part aborts the upload instead of completing it
+	completed = ""
+	err = svc.CopyObject(ctx, "backups", "big.tar", s3CopyLimit+size, "broken", "big.tar", "")
+	if err == nil || !strings.Contains(err.Error(), "part 3") {
+		t.Errorf("Expected part 3 to fail the copy, got %v", err)
+	}
+	if aborted != "/broken/big.tar" || completed != "" {
+		t.Errorf("Expected the upload aborted, got aborted %q completed %q", aborted, completed)
+	}
+}
@@ -0,0 +1,208 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"swiss-army-tui/internal/workpool"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+)
+
+// publicGroups names the ACL groups granting access beyond the account
+var publicGroups = map[string]string{
+	"http://acs.amazonaws.com/groups/global/AllUsers":           "everyone",
+	"http://acs.amazonaws.com/groups/global/AuthenticatedUsers": "any AWS account",
+}
+
+// accountInPrincipal matches the account ID of an AWS principal, given as
+// an ARN or the bare ID
+var accountInPrincipal = regexp.MustCompile(`^(?:arn:aws[a-z-]*:(?:iam|sts)::)?(\d{12})(?::|$)`)
+
+// PublicAccessBlock is the public access block of a bucket
+type PublicAccessBlock struct {
+	// Configured is false for buckets without a block, which leaves all
+	// settings off
+	Configured            bool
+	BlockPublicAcls       bool
+	IgnorePublicAcls      bool
+	BlockPublicPolicy     bool
+	RestrictPublicBuckets bool
+}
+
+// Disabled names the settings that are off, none if the block stops all
+// public access
+func (b PublicAccessBlock) Disabled() []string {
+	var off []string
+	for _, setting := range []struct {
+		name string
+		on   bool
+	}{
+		{"BlockPublicAcls", b.BlockPublicAcls},
+		{"IgnorePublicAcls", b.IgnorePublicAcls},
+		{"BlockPublicPolicy", b.BlockPublicPolicy},
+		{"RestrictPublicBuckets", b.RestrictPublicBuckets},
+	} {
+		if !setting.on {
+			off = append(off, setting.name)
+		}
+	}
+	return off
+}
+
+// BucketExposure is how a bucket is shared beyond its account
+type BucketExposure struct {
+	Bucket            string
+	Region            string
+	PublicAccessBlock PublicAccessBlock
+	// HasPolicy is false for buckets without a bucket policy
+	HasPolicy bool
+	// PolicyPublic is S3's verdict whether the policy grants public access
+	PolicyPublic bool
+	// PublicGrants are the ACL grants to groups outside the account, e.g.
+	// "everyone: READ"
+	PublicGrants []string
+	// CrossAccount are the principals of other accounts the policy allows,
+	// e.g. "arn:aws:iam::999988887777:root"
+	CrossAccount []string
+}
+
+// Public reports whether the bucket policy or ACL grants public access
+func (e BucketExposure) Public() bool {
+	return e.PolicyPublic || len(e.PublicGrants) > 0
+}
+
+// AuditBuckets reads how buckets are exposed, in their regions, for account,
+// in the "s3" slots of the worker pool.
+// Buckets that fail to read are left out and returned as a *PartialError.
+func (s *S3Service) AuditBuckets(ctx context.Context, buckets []S3Details, account string) ([]BucketExposure, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("s3 service not initialized")
+	}
+
+	var (
+		mu        sync.Mutex
+		exposures []BucketExposure
+		failures  failureCollector
+	)
+	err := workpool.Each(ctx, "s3", len(buckets), func(i int) {
+		exposure, err := s.bucketExposure(ctx, buckets[i], account)
+		if err != nil {
+			failures.add(buckets[i].Name, buckets[i].Region, err)
+			return
+		}
+		mu.Lock()
+		exposures = append(exposures, exposure)
+		mu.Unlock()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(exposures, func(i, j int) bool { return exposures[i].Bucket < exposures[j].Bucket })
+	return exposures, failures.err("audit buckets")
+}
+
+// bucketExposure reads the public access block, policy and ACL of bucket
+func (s *S3Service) bucketExposure(ctx context.Context, bucket S3Details, account string) (BucketExposure, error) {
+	exposure := BucketExposure{Bucket: bucket.Name, Region: bucket.Region}
+	inRegion := func(o *s3.Options) {
+		if bucket.Region != "" {
+			o.Region = bucket.Region
+		}
+	}
+
+	block, err := s.client.GetPublicAccessBlock(ctx, &s3.GetPublicAccessBlockInput{Bucket: &bucket.Name}, inRegion)
+	switch {
+	case isAPIError(err, "NoSuchPublicAccessBlockConfiguration"):
+	case err != nil:
+		return exposure, fmt.Errorf("failed to read the public access block of %s: %w", bucket.Name, err)
+	case block.PublicAccessBlockConfiguration != nil:
+		c := block.PublicAccessBlockConfiguration
+		exposure.PublicAccessBlock = PublicAccessBlock{
+			Configured:            true,
+			BlockPublicAcls:       aws.ToBool(c.BlockPublicAcls),
+			IgnorePublicAcls:      aws.ToBool(c.IgnorePublicAcls),
+			BlockPublicPolicy:     aws.ToBool(c.BlockPublicPolicy),
+			RestrictPublicBuckets: aws.ToBool(c.RestrictPublicBuckets),
+		}
+	}
+
+	policy, err := s.client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{Bucket: &bucket.Name}, inRegion)
+	switch {
+	case isAPIError(err, "NoSuchBucketPolicy"):
+	case err != nil:
+		return exposure, fmt.Errorf("failed to read the policy of %s: %w", bucket.Name, err)
+	default:
+		exposure.HasPolicy = true
+		exposure.CrossAccount = crossAccountPrincipals(aws.ToString(policy.Policy), account)
+
+		status, err := s.client.GetBucketPolicyStatus(ctx, &s3.GetBucketPolicyStatusInput{Bucket: &bucket.Name}, inRegion)
+		if err != nil {
+			return exposure, fmt.Errorf("failed to read the policy status of %s: %w", bucket.Name, err)
+		}
+		if status.PolicyStatus != nil {
+			exposure.PolicyPublic = aws.ToBool(status.PolicyStatus.IsPublic)
+		}
+	}
+
+	acl, err := s.client.GetBucketAcl(ctx, &s3.GetBucketAclInput{Bucket: &bucket.Name}, inRegion)
+	if err != nil {
+		return exposure, fmt.Errorf("failed to read the ACL of %s: %w", bucket.Name, err)
+	}
+	for _, grant := range acl.Grants {
+		if grant.Grantee == nil {
+			continue
+		}
+		if group, ok := publicGroups[aws.ToString(grant.Grantee.URI)]; ok {
+			exposure.PublicGrants = append(exposure.PublicGrants, fmt.Sprintf("%s: %s", group, grant.Permission))
+		}
+	}
+	return exposure, nil
+}
+
+// crossAccountPrincipals returns the AWS principals of accounts other than
+// account that the statements of policy allow, sorted. A bucket policy has
+// the shape of a trust policy; "*" is public access, which S3 reports itself.
+func crossAccountPrincipals(policy, account string) []string {
+	statements, err := ParseTrustPolicy(policy)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var principals []string
+	for _, stmt := range statements {
+		if stmt.Effect != "Allow" {
+			continue
+		}
+		for _, principal := range stmt.Principals {
+			arn, ok := strings.CutPrefix(principal, "AWS: ")
+			if !ok {
+				continue
+			}
+			match := accountInPrincipal.FindStringSubmatch(arn)
+			if match == nil || match[1] == account || seen[arn] {
+				continue
+			}
+			seen[arn] = true
+			principals = append(principals, arn)
+		}
+	}
+	sort.Strings(principals)
+	return principals
+}
+
+// isAPIError reports whether err is an AWS API error with one of codes
+func isAPIError(err error, codes ...string) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && slices.Contains(codes, apiErr.ErrorCode())
+}
@@ -0,0 +1,94 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func TestS3AuditBuckets(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		bucket := strings.Trim(r.URL.Path, "/")
+		notFound := func(code string) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>` + code + `</Code><Message>missing</Message></Error>`))
+		}
+		if bucket == "locked" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`))
+			return
+		}
+
+		query := r.URL.Query()
+		switch {
+		case query.Has("publicAccessBlock"):
+			if bucket == "website" {
+				notFound("NoSuchPublicAccessBlockConfiguration")
+				return
+			}
+			w.Write([]byte(`<PublicAccessBlockConfiguration><BlockPublicAcls>true</BlockPublicAcls><IgnorePublicAcls>true</IgnorePublicAcls>
+				<BlockPublicPolicy>true</BlockPublicPolicy><RestrictPublicBuckets>false</RestrictPublicBuckets></PublicAccessBlockConfiguration>`))
+		case query.Has("policyStatus"):
+			w.Write([]byte(`<PolicyStatus><IsPublic>` + map[bool]string{true: "true", false: "false"}[bucket == "website"] + `</IsPublic></PolicyStatus>`))
+		case query.Has("policy"):
+			switch bucket {
+			case "website":
+				w.Write([]byte(`{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::website/*"}]}`))
+			case "exports":
+				w.Write([]byte(`{"Statement":[
+					{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::999988887777:role/reporting","arn:aws:iam::123456789012:role/app"]},"Action":"s3:GetObject"},
+					{"Effect":"Allow","Principal":{"AWS":"111122223333","Service":"logging.s3.amazonaws.com"},"Action":"s3:PutObject"},
+					{"Effect":"Deny","Principal":{"AWS":"arn:aws:iam::444455556666:root"},"Action":"s3:*"}]}`))
+			default:
+				notFound("NoSuchBucketPolicy")
+			}
+		case query.Has("acl"):
+			grants := ""
+			if bucket == "website" {
+				grants = `<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>http://acs.amazonaws.com/groups/global/AllUsers</URI></Grantee><Permission>READ</Permission></Grant>`
+			}
+			w.Write([]byte(`<AccessControlPolicy><Owner><ID>abc</ID></Owner><AccessControlList>
+				<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>abc</ID></Grantee><Permission>FULL_CONTROL</Permission></Grant>` +
+				grants + `</AccessControlList></AccessControlPolicy>`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+	})
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+	})
+	svc, err := NewS3Service(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buckets := []S3Details{{Name: "website", Region: "us-east-1"}, {Name: "locked", Region: "us-east-1"}, {Name: "exports", Region: "us-east-1"}, {Name: "private", Region: "us-east-1"}}
+	exposures, err := svc.AuditBuckets(context.Background(), buckets, "123456789012")
+	var partial *PartialError
+	if !errors.As(err, &partial) || len(partial.Failures) != 1 || partial.Failures[0].Item != "locked" {
+		t.Errorf("Expected the locked bucket to fail, got %v", err)
+	}
+	if len(exposures) != 3 {
+		t.Fatalf("Expected 3 buckets audited, got %+v", exposures)
+	}
+
+	exports, private, website := exposures[0], exposures[1], exposures[2]
+	if website.PublicAccessBlock.Configured || !website.PolicyPublic || !reflect.DeepEqual(website.PublicGrants, []string{"everyone: READ"}) || !website.Public() {
+		t.Errorf("Expected the website public by policy and ACL without a block, got %+v", website)
+	}
+	if want := []string{"111122223333", "arn:aws:iam::999988887777:role/reporting"}; !reflect.DeepEqual(exports.CrossAccount, want) {
+		t.Errorf("Expected the other accounts allowed by the policy %v, got %v", want, exports.CrossAccount)
+	}
+	if exports.Public() || !exports.HasPolicy {
+		t.Errorf("Expected the exports shared but not public, got %+v", exports)
+	}
+	if private.HasPolicy || private.Public() || !reflect.DeepEqual(private.PublicAccessBlock.Disabled(), []string{"RestrictPublicBuckets"}) {
+		t.Errorf("Expected the private bucket without a policy and one setting off, got %+v", private)
+	}
+}
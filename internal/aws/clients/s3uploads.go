@@ -0,0 +1,272 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/workpool"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// AbortRuleID is the ID of the lifecycle rule SetAbortIncompleteUploads
+// adds to a bucket
+const AbortRuleID = "abort-incomplete-multipart-uploads"
+
+// MultipartUpload is an upload that was started but neither completed nor
+// aborted. Its parts are stored, and billed, until it is.
+type MultipartUpload struct {
+	Key          string
+	UploadID     string
+	Initiated    time.Time
+	StorageClass string
+	// Initiator is the display name or ARN of who started the upload
+	Initiator string
+	Parts     int
+	// Size is the total size of the uploaded parts
+	Size int64
+}
+
+// BucketUploads are the incomplete multipart uploads of a bucket, oldest
+// first
+type BucketUploads struct {
+	Bucket  string
+	Region  string
+	Uploads []MultipartUpload
+	// AbortAfterDays is the fewest days after which an enabled lifecycle
+	// rule for the whole bucket aborts incomplete uploads, 0 if none does
+	AbortAfterDays int
+}
+
+// Size is the total size of the parts of all uploads
+func (b BucketUploads) Size() int64 {
+	var size int64
+	for _, upload := range b.Uploads {
+		size += upload.Size
+	}
+	return size
+}
+
+// ListIncompleteUploads lists the incomplete multipart uploads of buckets,
+// in their regions, with the sizes of their parts and whether a lifecycle
+// rule aborts them, in the "s3" slots of the worker pool. Buckets that
+// fail to read are left out and returned as a *PartialError.
+func (s *S3Service) ListIncompleteUploads(ctx context.Context, buckets []S3Details) ([]BucketUploads, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("s3 service not initialized")
+	}
+
+	var (
+		mu       sync.Mutex
+		found    []BucketUploads
+		failures failureCollector
+	)
+	err := workpool.Each(ctx, "s3", len(buckets), func(i int) {
+		uploads, err := s.bucketUploads(ctx, buckets[i])
+		if err != nil {
+			failures.add(buckets[i].Name, buckets[i].Region, err)
+			return
+		}
+		mu.Lock()
+		found = append(found, uploads)
+		mu.Unlock()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].Bucket < found[j].Bucket })
+	return found, failures.err("list multipart uploads")
+}
+
+// bucketUploads reads the incomplete uploads of bucket with their parts,
+// and its lifecycle rules
+func (s *S3Service) bucketUploads(ctx context.Context, bucket S3Details) (BucketUploads, error) {
+	found := BucketUploads{Bucket: bucket.Name, Region: bucket.Region}
+	inRegion := func(o *s3.Options) {
+		if bucket.Region != "" {
+			o.Region = bucket.Region
+		}
+	}
+
+	paginator := s3.NewListMultipartUploadsPaginator(s.client, &s3.ListMultipartUploadsInput{Bucket: aws.String(bucket.Name)})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx, inRegion)
+		if err != nil {
+			return found, fmt.Errorf("failed to list the multipart uploads of %s: %w", bucket.Name, err)
+		}
+		for _, upload := range output.Uploads {
+			found.Uploads = append(found.Uploads, MultipartUpload{
+				Key:          aws.ToString(upload.Key),
+				UploadID:     aws.ToString(upload.UploadId),
+				Initiated:    aws.ToTime(upload.Initiated),
+				StorageClass: string(upload.StorageClass),
+				Initiator:    initiatorName(upload.Initiator),
+			})
+		}
+	}
+
+	for i := range found.Uploads {
+		upload := &found.Uploads[i]
+		parts := s3.NewListPartsPaginator(s.client, &s3.ListPartsInput{
+			Bucket:   aws.String(bucket.Name),
+			Key:      aws.String(upload.Key),
+			UploadId: aws.String(upload.UploadID),
+		})
+		for parts.HasMorePages() {
+			output, err := parts.NextPage(ctx, inRegion)
+			if isAPIError(err, "NoSuchUpload") {
+				// Completed or aborted since it was listed
+				break
+			}
+			if err != nil {
+				return found, fmt.Errorf("failed to list the parts of %s in %s: %w", upload.Key, bucket.Name, err)
+			}
+			for _, part := range output.Parts {
+				upload.Parts++
+				upload.Size += aws.ToInt64(part.Size)
+			}
+		}
+	}
+	sort.SliceStable(found.Uploads, func(i, j int) bool { return found.Uploads[i].Initiated.Before(found.Uploads[j].Initiated) })
+
+	lifecycle, err := s.client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(bucket.Name)}, inRegion)
+	switch {
+	case isAPIError(err, "NoSuchLifecycleConfiguration"):
+	case err != nil:
+		return found, fmt.Errorf("failed to read the lifecycle rules of %s: %w", bucket.Name, err)
+	default:
+		found.AbortAfterDays = abortAfterDays(lifecycle.Rules)
+	}
+	return found, nil
+}
+
+// initiatorName names who started an upload
+func initiatorName(initiator *types.Initiator) string {
+	if initiator == nil {
+		return ""
+	}
+	if name := aws.ToString(initiator.DisplayName); name != "" {
+		return name
+	}
+	return aws.ToString(initiator.ID)
+}
+
+// abortAfterDays returns the fewest days after which an enabled rule for
+// all keys of the bucket aborts incomplete uploads, 0 if none does
+func abortAfterDays(rules []types.LifecycleRule) int {
+	days := 0
+	for _, rule := range rules {
+		if rule.Status != types.ExpirationStatusEnabled || rule.AbortIncompleteMultipartUpload == nil || !wholeBucket(rule) {
+			continue
+		}
+		after := int(aws.ToInt32(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation))
+		if after > 0 && (days == 0 || after < days) {
+			days = after
+		}
+	}
+	return days
+}
+
+// wholeBucket reports whether rule applies to every key of the bucket.
+// Uploads have no tags or size yet, so only a prefix narrows an abort.
+func wholeBucket(rule types.LifecycleRule) bool {
+	if aws.ToString(rule.Prefix) != "" {
+		return false
+	}
+	filter := rule.Filter
+	if filter == nil {
+		return true
+	}
+	if filter.And != nil {
+		return aws.ToString(filter.And.Prefix) == ""
+	}
+	return aws.ToString(filter.Prefix) == ""
+}
+
+// withAbortRule returns rules with the rule AbortRuleID aborting incomplete
+// uploads after days, replacing the one already there
+func withAbortRule(rules []types.LifecycleRule, days int) []types.LifecycleRule {
+	rule := types.LifecycleRule{
+		ID:     aws.String(AbortRuleID),
+		Status: types.ExpirationStatusEnabled,
+		Filter: &types.LifecycleRuleFilter{Prefix: aws.String("")},
+		AbortIncompleteMultipartUpload: &types.AbortIncompleteMultipartUpload{
+			DaysAfterInitiation: aws.Int32(int32(days)),
+		},
+	}
+
+	merged := make([]types.LifecycleRule, 0, len(rules)+1)
+	for _, existing := range rules {
+		if aws.ToString(existing.ID) != AbortRuleID {
+			merged = append(merged, existing)
+		}
+	}
+	return append(merged, rule)
+}
+
+// SetAbortIncompleteUploads adds a lifecycle rule to bucket aborting
+// multipart uploads days after they were started, keeping its other rules.
+// S3 replaces the whole lifecycle configuration, so the rules are read
+// first.
+func (s *S3Service) SetAbortIncompleteUploads(ctx context.Context, bucket string, days int) error {
+	if s == nil || s.client == nil {
+		return fmt.Errorf("s3 service not initialized")
+	}
+	if days < 1 {
+		return fmt.Errorf("uploads can be aborted 1 day after they started at the earliest")
+	}
+
+	var rules []types.LifecycleRule
+	var minimumSize types.TransitionDefaultMinimumObjectSize
+	current, err := s.client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(bucket)}, s.inBucketRegion(bucket))
+	switch {
+	case isAPIError(err, "NoSuchLifecycleConfiguration"):
+	case err != nil:
+		return fmt.Errorf("failed to read the lifecycle rules of %s: %w", bucket, err)
+	default:
+		rules = current.Rules
+		minimumSize = current.TransitionDefaultMinimumObjectSize
+	}
+
+	_, err = s.client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                             aws.String(bucket),
+		LifecycleConfiguration:             &types.BucketLifecycleConfiguration{Rules: withAbortRule(rules, days)},
+		TransitionDefaultMinimumObjectSize: minimumSize,
+	}, s.inBucketRegion(bucket))
+	if err != nil {
+		return fmt.Errorf("failed to set the lifecycle rules of %s: %w", bucket, err)
+	}
+	return nil
+}
+
+// AbortUploads aborts uploads of bucket, freeing their parts. Uploads that
+// are gone already count as aborted; the others that fail are returned as
+// a *PartialError naming their keys.
+func (s *S3Service) AbortUploads(ctx context.Context, bucket string, uploads []MultipartUpload) error {
+	if s == nil || s.client == nil {
+		return fmt.Errorf("s3 service not initialized")
+	}
+
+	var failures failureCollector
+	err := workpool.Each(ctx, "s3", len(uploads), func(i int) {
+		upload := uploads[i]
+		_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(upload.Key),
+			UploadId: aws.String(upload.UploadID),
+		}, s.inBucketRegion(bucket))
+		if err != nil && !isAPIError(err, "NoSuchUpload") {
+			failures.add(upload.Key, "", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return failures.err("abort multipart uploads")
+}
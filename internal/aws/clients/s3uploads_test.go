@@ -0,0 +1,129 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func TestS3ListIncompleteUploads(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		bucket, _, _ := strings.Cut(strings.Trim(r.URL.Path, "/"), "/")
+		if bucket == "locked" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`))
+			return
+		}
+
+		query := r.URL.Query()
+		switch {
+		case query.Has("uploads") && bucket == "backups":
+			w.Write([]byte(`<ListMultipartUploadsResult><Bucket>backups</Bucket><IsTruncated>false</IsTruncated>
+				<Upload><Key>db/new.dump</Key><UploadId>u2</UploadId><Initiated>2026-10-10T00:00:00Z</Initiated><StorageClass>STANDARD</StorageClass>
+					<Initiator><ID>arn:aws:iam::123456789012:role/backup</ID></Initiator></Upload>
+				<Upload><Key>db/old.dump</Key><UploadId>u1</UploadId><Initiated>2026-08-01T00:00:00Z</Initiated><StorageClass>DEEP_ARCHIVE</StorageClass>
+					<Initiator><ID>abc</ID><DisplayName>backup-runner</DisplayName></Initiator></Upload>
+			</ListMultipartUploadsResult>`))
+		case query.Has("uploads"):
+			w.Write([]byte(`<ListMultipartUploadsResult><Bucket>` + bucket + `</Bucket><IsTruncated>false</IsTruncated></ListMultipartUploadsResult>`))
+		case query.Get("uploadId") == "u1":
+			w.Write([]byte(`<ListPartsResult><IsTruncated>false</IsTruncated>
+				<Part><PartNumber>1</PartNumber><Size>1000</Size></Part><Part><PartNumber>2</PartNumber><Size>500</Size></Part></ListPartsResult>`))
+		case query.Get("uploadId") == "u2":
+			// Completed while the uploads were listed
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>NoSuchUpload</Code><Message>gone</Message></Error>`))
+		case query.Has("lifecycle") && bucket == "backups":
+			w.Write([]byte(`<LifecycleConfiguration>
+				<Rule><ID>logs</ID><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule>
+				<Rule><ID>all</ID><Filter></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>14</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule>
+				<Rule><ID>off</ID><Filter><Prefix></Prefix></Filter><Status>Disabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>3</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule>
+			</LifecycleConfiguration>`))
+		case query.Has("lifecycle"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>NoSuchLifecycleConfiguration</Code><Message>none</Message></Error>`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+	})
+
+	buckets := []S3Details{{Name: "backups", Region: "us-east-1"}, {Name: "locked", Region: "us-east-1"}, {Name: "assets", Region: "us-east-1"}}
+	svc, err := NewS3Service(s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := svc.ListIncompleteUploads(context.Background(), buckets)
+	var partial *PartialError
+	if !errors.As(err, &partial) || len(partial.Failures) != 1 || partial.Failures[0].Item != "locked" {
+		t.Errorf("Expected the locked bucket to fail, got %v", err)
+	}
+	if len(found) != 2 || found[0].Bucket != "assets" || found[1].Bucket != "backups" {
+		t.Fatalf("Expected two buckets by name, got %+v", found)
+	}
+
+	if assets := found[0]; len(assets.Uploads) != 0 || assets.AbortAfterDays != 0 {
+		t.Errorf("Expected no uploads nor abort rule, got %+v", assets)
+	}
+	backups := found[1]
+	if backups.AbortAfterDays != 14 {
+		t.Errorf("Expected the enabled rule for the whole bucket to abort after 14 days, got %d", backups.AbortAfterDays)
+	}
+	if len(backups.Uploads) != 2 {
+		t.Fatalf("Expected two uploads, got %+v", backups.Uploads)
+	}
+	old, recent := backups.Uploads[0], backups.Uploads[1]
+	if old.Key != "db/old.dump" || old.Parts != 2 || old.Size != 1500 || old.Initiator != "backup-runner" || old.StorageClass != "DEEP_ARCHIVE" {
+		t.Errorf("Expected the oldest upload first with its parts, got %+v", old)
+	}
+	if recent.Parts != 0 || recent.Initiator != "arn:aws:iam::123456789012:role/backup" {
+		t.Errorf("Expected the completed upload without parts, got %+v", recent)
+	}
+	if backups.Size() != 1500 {
+		t.Errorf("Expected 1500 bytes in parts, got %d", backups.Size())
+	}
+}
+
+func TestS3SetAbortIncompleteUploads(t *testing.T) {
+	var put string
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !r.URL.Query().Has("lifecycle") {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		if r.Method == http.MethodPut {
+			body, _ := io.ReadAll(r.Body)
+			put = string(body)
+			return
+		}
+		w.Write([]byte(`<LifecycleConfiguration>
+			<Rule><ID>archive</ID><Filter><Prefix>exports/</Prefix></Filter><Status>Enabled</Status><Transition><Days>30</Days><StorageClass>GLACIER</StorageClass></Transition></Rule>
+			<Rule><ID>` + AbortRuleID + `</ID><Filter><Prefix></Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>30</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule>
+		</LifecycleConfiguration>`))
+	})
+
+	svc, err := NewS3Service(s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.SetAbortIncompleteUploads(context.Background(), "exports", 7); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(put, "<ID>archive</ID>") || !strings.Contains(put, "<StorageClass>GLACIER</StorageClass>") {
+		t.Errorf("Expected the other rules kept, got %s", put)
+	}
+	if strings.Count(put, AbortRuleID) != 1 || !strings.Contains(put, "<DaysAfterInitiation>7</DaysAfterInitiation>") || strings.Contains(put, ">30</DaysAfterInitiation>") {
+		t.Errorf("Expected the abort rule replaced, got %s", put)
+	}
+
+	if err := svc.SetAbortIncompleteUploads(context.Background(), "exports", 0); err == nil {
+		t.Error("Expected aborting after 0 days to be refused")
+	}
+}
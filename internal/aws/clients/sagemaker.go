@@ -0,0 +1,297 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// sageMakerTargetPrefix prefixes the operation in the X-Amz-Target header of
+// a SageMaker call
+const sageMakerTargetPrefix = "SageMaker."
+
+// SageMakerNotebook is a SageMaker notebook instance
+type SageMakerNotebook struct {
+	Name string
+	ARN  string
+	// Status is Pending, InService, Stopping, Stopped, Failed, Deleting or
+	// Updating
+	Status       string
+	InstanceType string
+	CreatedAt    time.Time
+	ModifiedAt   time.Time
+}
+
+// SageMakerEndpoint is a SageMaker real-time inference endpoint with the
+// instances of its variants
+type SageMakerEndpoint struct {
+	Name string
+	ARN  string
+	// Status is InService, Creating, Updating, SystemUpdating, RollingBack,
+	// OutOfService, Deleting, Failed or UpdateRollbackFailed
+	Status        string
+	FailureReason string
+	Config        string
+	Variants      []SageMakerVariant
+	CreatedAt     time.Time
+	ModifiedAt    time.Time
+}
+
+// SageMakerVariant is a production variant of an endpoint
+type SageMakerVariant struct {
+	Name string
+	// InstanceType and Instances are empty for serverless variants, which
+	// are only billed for the requests they serve
+	InstanceType string
+	Instances    int
+	Serverless   bool
+}
+
+// SageMakerTrainingJob is a SageMaker training job
+type SageMakerTrainingJob struct {
+	Name string
+	ARN  string
+	// Status is InProgress, Completed, Failed, Stopping or Stopped
+	Status    string
+	CreatedAt time.Time
+	// EndedAt is zero while the job runs
+	EndedAt time.Time
+}
+
+// Duration returns how long the job ran, or has run so far
+func (j SageMakerTrainingJob) Duration(now time.Time) time.Duration {
+	if j.EndedAt.IsZero() {
+		return now.Sub(j.CreatedAt)
+	}
+	return j.EndedAt.Sub(j.CreatedAt)
+}
+
+// SageMakerService lists SageMaker notebook instances, endpoints and
+// training jobs, stops notebooks and deletes endpoints
+type SageMakerService struct {
+	*jsonAPI
+}
+
+// NewSageMakerService creates a new SageMaker service for the region and
+// credentials of cfg
+func NewSageMakerService(cfg aws.Config) (*SageMakerService, error) {
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("SageMaker credentials not provided")
+	}
+	return &SageMakerService{
+		jsonAPI: newJSONAPI(cfg, "SageMaker", regionalEndpoint("api.sagemaker", cfg.Region), cfg.Region, "sagemaker", sageMakerTargetPrefix),
+	}, nil
+}
+
+// ListNotebookInstances returns the notebook instances of the region,
+// sorted by name
+func (s *SageMakerService) ListNotebookInstances(ctx context.Context) ([]SageMakerNotebook, error) {
+	if s == nil || s.jsonAPI == nil {
+		return nil, fmt.Errorf("SageMaker service not initialized")
+	}
+
+	var notebooks []SageMakerNotebook
+	input := map[string]any{"MaxResults": 100}
+	for {
+		var output struct {
+			NotebookInstances []struct {
+				NotebookInstanceName   string  `json:"NotebookInstanceName"`
+				NotebookInstanceArn    string  `json:"NotebookInstanceArn"`
+				NotebookInstanceStatus string  `json:"NotebookInstanceStatus"`
+				InstanceType           string  `json:"InstanceType"`
+				CreationTime           float64 `json:"CreationTime"`
+				LastModifiedTime       float64 `json:"LastModifiedTime"`
+			} `json:"NotebookInstances"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.call(ctx, "ListNotebookInstances", input, &output); err != nil {
+			return nil, fmt.Errorf("failed to list notebook instances: %w", err)
+		}
+		for _, notebook := range output.NotebookInstances {
+			notebooks = append(notebooks, SageMakerNotebook{
+				Name:         notebook.NotebookInstanceName,
+				ARN:          notebook.NotebookInstanceArn,
+				Status:       notebook.NotebookInstanceStatus,
+				InstanceType: notebook.InstanceType,
+				CreatedAt:    epochTime(notebook.CreationTime),
+				ModifiedAt:   epochTime(notebook.LastModifiedTime),
+			})
+		}
+		if output.NextToken == "" {
+			break
+		}
+		input["NextToken"] = output.NextToken
+	}
+
+	sort.SliceStable(notebooks, func(i, j int) bool { return notebooks[i].Name < notebooks[j].Name })
+	return notebooks, nil
+}
+
+// ListEndpoints returns the endpoints of the region with the instances of
+// their variants, sorted by name. Endpoints that fail to describe are
+// returned as listed, with a PartialError.
+func (s *SageMakerService) ListEndpoints(ctx context.Context) ([]SageMakerEndpoint, error) {
+	if s == nil || s.jsonAPI == nil {
+		return nil, fmt.Errorf("SageMaker service not initialized")
+	}
+
+	var endpoints []SageMakerEndpoint
+	input := map[string]any{"MaxResults": 100}
+	for {
+		var output struct {
+			Endpoints []struct {
+				EndpointName     string  `json:"EndpointName"`
+				EndpointArn      string  `json:"EndpointArn"`
+				EndpointStatus   string  `json:"EndpointStatus"`
+				CreationTime     float64 `json:"CreationTime"`
+				LastModifiedTime float64 `json:"LastModifiedTime"`
+			} `json:"Endpoints"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.call(ctx, "ListEndpoints", input, &output); err != nil {
+			return nil, fmt.Errorf("failed to list endpoints: %w", err)
+		}
+		for _, endpoint := range output.Endpoints {
+			endpoints = append(endpoints, SageMakerEndpoint{
+				Name:       endpoint.EndpointName,
+				ARN:        endpoint.EndpointArn,
+				Status:     endpoint.EndpointStatus,
+				CreatedAt:  epochTime(endpoint.CreationTime),
+				ModifiedAt: epochTime(endpoint.LastModifiedTime),
+			})
+		}
+		if output.NextToken == "" {
+			break
+		}
+		input["NextToken"] = output.NextToken
+	}
+
+	var failures failureCollector
+	for i := range endpoints {
+		if err := s.describeEndpoint(ctx, &endpoints[i]); err != nil {
+			failures.add(endpoints[i].Name, s.region, err)
+		}
+	}
+
+	sort.SliceStable(endpoints, func(i, j int) bool { return endpoints[i].Name < endpoints[j].Name })
+	return endpoints, failures.err("sagemaker:DescribeEndpoint")
+}
+
+// describeEndpoint fills in the configuration and variants of endpoint.
+// The instance types of the variants are those of the configuration.
+func (s *SageMakerService) describeEndpoint(ctx context.Context, endpoint *SageMakerEndpoint) error {
+	var output struct {
+		EndpointConfigName string `json:"EndpointConfigName"`
+		FailureReason      string `json:"FailureReason"`
+		ProductionVariants []struct {
+			VariantName             string    `json:"VariantName"`
+			CurrentInstanceCount    int       `json:"CurrentInstanceCount"`
+			CurrentServerlessConfig *struct{} `json:"CurrentServerlessConfig"`
+		} `json:"ProductionVariants"`
+	}
+	if err := s.call(ctx, "DescribeEndpoint", map[string]any{"EndpointName": endpoint.Name}, &output); err != nil {
+		return err
+	}
+	endpoint.Config = output.EndpointConfigName
+	endpoint.FailureReason = output.FailureReason
+
+	var config struct {
+		ProductionVariants []struct {
+			VariantName      string    `json:"VariantName"`
+			InstanceType     string    `json:"InstanceType"`
+			ServerlessConfig *struct{} `json:"ServerlessConfig"`
+		} `json:"ProductionVariants"`
+	}
+	if err := s.call(ctx, "DescribeEndpointConfig", map[string]any{"EndpointConfigName": output.EndpointConfigName}, &config); err != nil {
+		return err
+	}
+	types := make(map[string]string, len(config.ProductionVariants))
+	for _, variant := range config.ProductionVariants {
+		types[variant.VariantName] = variant.InstanceType
+	}
+
+	endpoint.Variants = make([]SageMakerVariant, 0, len(output.ProductionVariants))
+	for _, variant := range output.ProductionVariants {
+		v := SageMakerVariant{Name: variant.VariantName, Serverless: variant.CurrentServerlessConfig != nil}
+		if !v.Serverless {
+			v.InstanceType = types[variant.VariantName]
+			v.Instances = variant.CurrentInstanceCount
+		}
+		endpoint.Variants = append(endpoint.Variants, v)
+	}
+	return nil
+}
+
+// ListTrainingJobs returns the last limit training jobs of the region,
+// newest first
+func (s *SageMakerService) ListTrainingJobs(ctx context.Context, limit int) ([]SageMakerTrainingJob, error) {
+	if s == nil || s.jsonAPI == nil {
+		return nil, fmt.Errorf("SageMaker service not initialized")
+	}
+
+	var jobs []SageMakerTrainingJob
+	input := map[string]any{"MaxResults": min(max(limit, 1), 100), "SortBy": "CreationTime", "SortOrder": "Descending"}
+	for {
+		var output struct {
+			TrainingJobSummaries []struct {
+				TrainingJobName   string  `json:"TrainingJobName"`
+				TrainingJobArn    string  `json:"TrainingJobArn"`
+				TrainingJobStatus string  `json:"TrainingJobStatus"`
+				CreationTime      float64 `json:"CreationTime"`
+				TrainingEndTime   float64 `json:"TrainingEndTime"`
+			} `json:"TrainingJobSummaries"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.call(ctx, "ListTrainingJobs", input, &output); err != nil {
+			return nil, fmt.Errorf("failed to list training jobs: %w", err)
+		}
+		for _, job := range output.TrainingJobSummaries {
+			jobs = append(jobs, SageMakerTrainingJob{
+				Name:      job.TrainingJobName,
+				ARN:       job.TrainingJobArn,
+				Status:    job.TrainingJobStatus,
+				CreatedAt: epochTime(job.CreationTime),
+				EndedAt:   epochTime(job.TrainingEndTime),
+			})
+		}
+		if output.NextToken == "" || len(jobs) >= limit {
+			break
+		}
+		input["NextToken"] = output.NextToken
+	}
+
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
+}
+
+// StopNotebookInstance stops the notebook instance name, which keeps its
+// storage and bills only for it until started again
+func (s *SageMakerService) StopNotebookInstance(ctx context.Context, name string) error {
+	if s == nil || s.jsonAPI == nil {
+		return fmt.Errorf("SageMaker service not initialized")
+	}
+	var output struct{}
+	if err := s.call(ctx, "StopNotebookInstance", map[string]any{"NotebookInstanceName": name}, &output); err != nil {
+		return fmt.Errorf("failed to stop notebook instance %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteEndpoint deletes the endpoint name, which stops its instances.
+// Endpoints cannot be stopped otherwise; the endpoint configuration is kept,
+// so the endpoint can be created again from it.
+func (s *SageMakerService) DeleteEndpoint(ctx context.Context, name string) error {
+	if s == nil || s.jsonAPI == nil {
+		return fmt.Errorf("SageMaker service not initialized")
+	}
+	var output struct{}
+	if err := s.call(ctx, "DeleteEndpoint", map[string]any{"EndpointName": name}, &output); err != nil {
+		return fmt.Errorf("failed to delete endpoint %s: %w", name, err)
+	}
+	return nil
+}
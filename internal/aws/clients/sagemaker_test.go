@@ -0,0 +1,124 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSageMakerListEndpoints(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/sagemaker/aws4_request") {
+			t.Errorf("Expected a request signed for sagemaker in eu-west-1, got %q", r.Header.Get("Authorization"))
+		}
+		var input map[string]any
+		json.NewDecoder(r.Body).Decode(&input)
+
+		switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), sageMakerTargetPrefix) {
+		case "ListEndpoints":
+			if input["NextToken"] == nil {
+				w.Write([]byte(`{"Endpoints":[{"EndpointName":"reco","EndpointArn":"arn:aws:sagemaker:eu-west-1:123456789012:endpoint/reco","EndpointStatus":"InService","CreationTime":1760000000}],"NextToken":"p2"}`))
+				return
+			}
+			w.Write([]byte(`{"Endpoints":[{"EndpointName":"fraud","EndpointStatus":"Failed"}]}`))
+		case "DescribeEndpoint":
+			if input["EndpointName"] == "fraud" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"__type":"AccessDeniedException","Message":"not authorized"}`))
+				return
+			}
+			w.Write([]byte(`{"EndpointConfigName":"reco-v2","ProductionVariants":[
+				{"VariantName":"primary","CurrentInstanceCount":2},
+				{"VariantName":"shadow","CurrentServerlessConfig":{"MaxConcurrency":5}}]}`))
+		case "DescribeEndpointConfig":
+			if input["EndpointConfigName"] != "reco-v2" {
+				t.Errorf("Unexpected input %v", input)
+			}
+			w.Write([]byte(`{"ProductionVariants":[{"VariantName":"primary","InstanceType":"ml.g5.xlarge"},{"VariantName":"shadow","ServerlessConfig":{}}]}`))
+		default:
+			t.Errorf("Unexpected target %q", r.Header.Get("X-Amz-Target"))
+		}
+	})
+
+	svc, err := NewSageMakerService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoints, err := svc.ListEndpoints(context.Background())
+	var partial *PartialError
+	if !errors.As(err, &partial) || len(partial.Failures) != 1 || partial.Failures[0].Item != "fraud" {
+		t.Errorf("Expected fraud to fail to describe, got %v", err)
+	}
+	if len(endpoints) != 2 || endpoints[0].Name != "fraud" {
+		t.Fatalf("Expected both endpoints sorted by name, got %+v", endpoints)
+	}
+	reco := endpoints[1]
+	if reco.Config != "reco-v2" || !reco.CreatedAt.Equal(time.Unix(1760000000, 0)) || len(reco.Variants) != 2 {
+		t.Fatalf("Unexpected endpoint %+v", reco)
+	}
+	if v := reco.Variants[0]; v.InstanceType != "ml.g5.xlarge" || v.Instances != 2 || v.Serverless {
+		t.Errorf("Expected two ml.g5.xlarge instances, got %+v", v)
+	}
+	if v := reco.Variants[1]; !v.Serverless || v.InstanceType != "" || v.Instances != 0 {
+		t.Errorf("Expected a serverless variant without instances, got %+v", v)
+	}
+}
+
+func TestSageMakerListTrainingJobs(t *testing.T) {
+	calls := 0
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var input map[string]any
+		json.NewDecoder(r.Body).Decode(&input)
+		if input["SortBy"] != "CreationTime" || input["SortOrder"] != "Descending" || input["MaxResults"] != float64(2) {
+			t.Errorf("Unexpected input %v", input)
+		}
+		w.Write([]byte(`{"TrainingJobSummaries":[
+			{"TrainingJobName":"churn-3","TrainingJobStatus":"InProgress","CreationTime":1760003600},
+			{"TrainingJobName":"churn-2","TrainingJobStatus":"Completed","CreationTime":1760000000,"TrainingEndTime":1760001800}],"NextToken":"p2"}`))
+	})
+
+	svc, err := NewSageMakerService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs, err := svc.ListTrainingJobs(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 || len(jobs) != 2 {
+		t.Fatalf("Expected one page of two jobs, got %d calls and %+v", calls, jobs)
+	}
+	if d := jobs[1].Duration(time.Now()); d != 30*time.Minute {
+		t.Errorf("Expected the finished job to have run 30m, got %v", d)
+	}
+	if d := jobs[0].Duration(time.Unix(1760007200, 0)); d != time.Hour {
+		t.Errorf("Expected the running job to have run 1h so far, got %v", d)
+	}
+}
+
+func TestSageMakerStopActions(t *testing.T) {
+	var targets []string
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		targets = append(targets, strings.TrimPrefix(r.Header.Get("X-Amz-Target"), sageMakerTargetPrefix))
+		// Operations without output answer with an empty body
+	})
+
+	svc, err := NewSageMakerService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.StopNotebookInstance(context.Background(), "research"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.DeleteEndpoint(context.Background(), "reco"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(targets, ",") != "StopNotebookInstance,DeleteEndpoint" {
+		t.Errorf("Unexpected calls %v", targets)
+	}
+}
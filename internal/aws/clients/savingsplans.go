@@ -0,0 +1,83 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/savingsplans"
+	"github.com/aws/aws-sdk-go-v2/service/savingsplans/types"
+)
+
+// SavingsPlanDetail describes a Savings Plan and its hourly commitment
+type SavingsPlanDetail struct {
+	ID             string
+	Type           string
+	State          string
+	PaymentOption  string
+	InstanceFamily string
+	Region         string
+	// Commitment is the amount in USD per hour the plan pays for
+	Commitment float64
+	Start      time.Time
+	End        time.Time
+}
+
+// SavingsPlansService wraps the Savings Plans client
+type SavingsPlansService struct {
+	client *savingsplans.Client
+}
+
+// NewSavingsPlansService creates a new Savings Plans service wrapper
+func NewSavingsPlansService(client *savingsplans.Client) (*SavingsPlansService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("Savings Plans client not provided")
+	}
+
+	return &SavingsPlansService{
+		client: client,
+	}, nil
+}
+
+// DescribeSavingsPlans returns the active and queued Savings Plans of the account
+func (s *SavingsPlansService) DescribeSavingsPlans(ctx context.Context) ([]SavingsPlanDetail, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("Savings Plans service not initialized")
+	}
+
+	input := &savingsplans.DescribeSavingsPlansInput{
+		States: []types.SavingsPlanState{types.SavingsPlanStateActive, types.SavingsPlanStateQueued},
+	}
+
+	var plans []SavingsPlanDetail
+	for {
+		output, err := s.client.DescribeSavingsPlans(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe savings plans: %w", err)
+		}
+
+		for _, plan := range output.SavingsPlans {
+			commitment, _ := strconv.ParseFloat(aws.ToString(plan.Commitment), 64)
+			start, _ := time.Parse(time.RFC3339, aws.ToString(plan.Start))
+			end, _ := time.Parse(time.RFC3339, aws.ToString(plan.End))
+			plans = append(plans, SavingsPlanDetail{
+				ID:             aws.ToString(plan.SavingsPlanId),
+				Type:           string(plan.SavingsPlanType),
+				State:          string(plan.State),
+				PaymentOption:  string(plan.PaymentOption),
+				InstanceFamily: aws.ToString(plan.Ec2InstanceFamily),
+				Region:         aws.ToString(plan.Region),
+				Commitment:     commitment,
+				Start:          start,
+				End:            end,
+			})
+		}
+
+		if output.NextToken == nil {
+			return plans, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
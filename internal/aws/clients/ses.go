@@ -0,0 +1,142 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+)
+
+// EmailIdentityDetail describes a verified or pending SES sending identity
+type EmailIdentityDetail struct {
+	Name string
+	// Type is EMAIL_ADDRESS, DOMAIN or MANAGED_DOMAIN
+	Type               string
+	VerificationStatus string
+	SendingEnabled     bool
+}
+
+// SendingAccount describes the SES sending quota and usage of the account
+type SendingAccount struct {
+	Max24HourSend   float64
+	MaxSendRate     float64
+	SentLast24Hours float64
+	SendingEnabled  bool
+	// ProductionAccess is false while the account is in the sandbox
+	ProductionAccess bool
+	// EnforcementStatus is HEALTHY, PROBATION or SHUTDOWN
+	EnforcementStatus string
+}
+
+// SuppressedDestination is an address on the account suppression list
+type SuppressedDestination struct {
+	Email string
+	// Reason is BOUNCE or COMPLAINT
+	Reason     string
+	LastUpdate time.Time
+}
+
+// SESService wraps the SES v2 client
+type SESService struct {
+	client *sesv2.Client
+}
+
+// NewSESService creates a new SES service wrapper
+func NewSESService(client *sesv2.Client) (*SESService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("SES client not provided")
+	}
+
+	return &SESService{
+		client: client,
+	}, nil
+}
+
+// ListIdentities returns the email address and domain identities of the account
+func (s *SESService) ListIdentities(ctx context.Context) ([]EmailIdentityDetail, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("SES service not initialized")
+	}
+
+	var identities []EmailIdentityDetail
+	paginator := sesv2.NewListEmailIdentitiesPaginator(s.client, &sesv2.ListEmailIdentitiesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list email identities: %w", err)
+		}
+		for _, identity := range output.EmailIdentities {
+			identities = append(identities, EmailIdentityDetail{
+				Name:               aws.ToString(identity.IdentityName),
+				Type:               string(identity.IdentityType),
+				VerificationStatus: string(identity.VerificationStatus),
+				SendingEnabled:     identity.SendingEnabled,
+			})
+		}
+	}
+	return identities, nil
+}
+
+// GetSendingAccount returns the sending quota, usage and status of the account
+func (s *SESService) GetSendingAccount(ctx context.Context) (SendingAccount, error) {
+	if s == nil || s.client == nil {
+		return SendingAccount{}, fmt.Errorf("SES service not initialized")
+	}
+
+	output, err := s.client.GetAccount(ctx, &sesv2.GetAccountInput{})
+	if err != nil {
+		return SendingAccount{}, fmt.Errorf("failed to get SES account: %w", err)
+	}
+
+	account := SendingAccount{
+		SendingEnabled:    output.SendingEnabled,
+		ProductionAccess:  output.ProductionAccessEnabled,
+		EnforcementStatus: aws.ToString(output.EnforcementStatus),
+	}
+	if quota := output.SendQuota; quota != nil {
+		account.Max24HourSend = quota.Max24HourSend
+		account.MaxSendRate = quota.MaxSendRate
+		account.SentLast24Hours = quota.SentLast24Hours
+	}
+	return account, nil
+}
+
+// ListSuppressedDestinations returns the addresses on the account suppression list
+func (s *SESService) ListSuppressedDestinations(ctx context.Context) ([]SuppressedDestination, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("SES service not initialized")
+	}
+
+	var destinations []SuppressedDestination
+	paginator := sesv2.NewListSuppressedDestinationsPaginator(s.client, &sesv2.ListSuppressedDestinationsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list suppressed destinations: %w", err)
+		}
+		for _, destination := range output.SuppressedDestinationSummaries {
+			destinations = append(destinations, SuppressedDestination{
+				Email:      aws.ToString(destination.EmailAddress),
+				Reason:     string(destination.Reason),
+				LastUpdate: aws.ToTime(destination.LastUpdateTime),
+			})
+		}
+	}
+	return destinations, nil
+}
+
+// RemoveSuppressedDestination removes email from the account suppression list
+func (s *SESService) RemoveSuppressedDestination(ctx context.Context, email string) error {
+	if s == nil || s.client == nil {
+		return fmt.Errorf("SES service not initialized")
+	}
+
+	if _, err := s.client.DeleteSuppressedDestination(ctx, &sesv2.DeleteSuppressedDestinationInput{
+		EmailAddress: aws.String(email),
+	}); err != nil {
+		return fmt.Errorf("failed to remove %s from the suppression list: %w", email, err)
+	}
+	return nil
+}
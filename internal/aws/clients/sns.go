@@ -0,0 +1,130 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+// TopicDetail describes an SNS topic
+type TopicDetail struct {
+	ARN                  string
+	Name                 string
+	DisplayName          string
+	FIFO                 bool
+	SubscriptionsActive  int
+	SubscriptionsPending int
+	Encrypted            bool
+}
+
+// Subscription is a subscription of an SNS topic
+type Subscription struct {
+	ARN string
+	// Protocol is sqs, lambda, http, https, email, sms, firehose or application
+	Protocol string
+	// Endpoint is the ARN of a queue or function, or the address notified
+	Endpoint string
+	// Pending is set until the endpoint confirmed the subscription
+	Pending bool
+}
+
+// SNSService wraps the SNS client
+type SNSService struct {
+	client *sns.Client
+}
+
+// NewSNSService creates a new SNS service wrapper
+func NewSNSService(client *sns.Client) (*SNSService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("SNS client not provided")
+	}
+
+	return &SNSService{
+		client: client,
+	}, nil
+}
+
+// ListTopics returns the topics of the region by ARN and name; GetTopic
+// reads the rest of a topic
+func (s *SNSService) ListTopics(ctx context.Context) ([]TopicDetail, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("SNS service not initialized")
+	}
+
+	var topics []TopicDetail
+	paginator := sns.NewListTopicsPaginator(s.client, &sns.ListTopicsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list topics: %w", err)
+		}
+		for _, topic := range output.Topics {
+			arn := aws.ToString(topic.TopicArn)
+			name := arnResource(arn)
+			topics = append(topics, TopicDetail{ARN: arn, Name: name, FIFO: strings.HasSuffix(name, ".fifo")})
+		}
+	}
+	return topics, nil
+}
+
+// GetTopic returns the topic arn with its display name, encryption and
+// subscription counts
+func (s *SNSService) GetTopic(ctx context.Context, arn string) (TopicDetail, error) {
+	if s == nil || s.client == nil {
+		return TopicDetail{}, fmt.Errorf("SNS service not initialized")
+	}
+
+	output, err := s.client.GetTopicAttributes(ctx, &sns.GetTopicAttributesInput{
+		TopicArn: aws.String(arn),
+	})
+	if err != nil {
+		return TopicDetail{}, fmt.Errorf("failed to read topic %s: %w", arnResource(arn), err)
+	}
+
+	attributes := output.Attributes
+	topic := TopicDetail{ARN: arn, Name: arnResource(arn)}
+	topic.DisplayName = attributes["DisplayName"]
+	topic.FIFO = attributes["FifoTopic"] == "true"
+	topic.Encrypted = attributes["KmsMasterKeyId"] != ""
+	topic.SubscriptionsActive, _ = strconv.Atoi(attributes["SubscriptionsConfirmed"])
+	topic.SubscriptionsPending, _ = strconv.Atoi(attributes["SubscriptionsPending"])
+	return topic, nil
+}
+
+// ListSubscriptions returns the subscriptions of the topic topicARN
+func (s *SNSService) ListSubscriptions(ctx context.Context, topicARN string) ([]Subscription, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("SNS service not initialized")
+	}
+
+	var subscriptions []Subscription
+	paginator := sns.NewListSubscriptionsByTopicPaginator(s.client, &sns.ListSubscriptionsByTopicInput{
+		TopicArn: aws.String(topicARN),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subscriptions of topic %s: %w", arnResource(topicARN), err)
+		}
+		for _, subscription := range output.Subscriptions {
+			arn := aws.ToString(subscription.SubscriptionArn)
+			subscriptions = append(subscriptions, Subscription{
+				ARN:      arn,
+				Protocol: aws.ToString(subscription.Protocol),
+				Endpoint: aws.ToString(subscription.Endpoint),
+				Pending:  arn == "PendingConfirmation",
+			})
+		}
+	}
+	return subscriptions, nil
+}
+
+// arnResource returns the part of arn after its last colon, e.g. the name
+// of a topic, queue or function
+func arnResource(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
@@ -0,0 +1,100 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// QueueDetail describes an SQS queue and how many messages it holds
+type QueueDetail struct {
+	Name string
+	ARN  string
+	URL  string
+	// Visible messages wait to be received, InFlight ones were received but
+	// not deleted yet and Delayed ones are not visible yet
+	Visible  int64
+	InFlight int64
+	Delayed  int64
+	// DeadLetterARN is the queue messages move to after MaxReceives failed
+	// receives, empty without a redrive policy
+	DeadLetterARN string
+	MaxReceives   int
+}
+
+// SQSService wraps the SQS client
+type SQSService struct {
+	client *sqs.Client
+}
+
+// NewSQSService creates a new SQS service wrapper
+func NewSQSService(client *sqs.Client) (*SQSService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("SQS client not provided")
+	}
+
+	return &SQSService{
+		client: client,
+	}, nil
+}
+
+// GetQueue returns the queue with the ARN arn and its current depth
+func (s *SQSService) GetQueue(ctx context.Context, arn string) (QueueDetail, error) {
+	if s == nil || s.client == nil {
+		return QueueDetail{}, fmt.Errorf("SQS service not initialized")
+	}
+
+	// arn:aws:sqs:region:account:name
+	parts := strings.Split(arn, ":")
+	if len(parts) != 6 {
+		return QueueDetail{}, fmt.Errorf("invalid queue ARN %q", arn)
+	}
+	queue := QueueDetail{Name: parts[5], ARN: arn}
+
+	url, err := s.client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{
+		QueueName:              aws.String(queue.Name),
+		QueueOwnerAWSAccountId: aws.String(parts[4]),
+	})
+	if err != nil {
+		return QueueDetail{}, fmt.Errorf("failed to get URL of queue %s: %w", queue.Name, err)
+	}
+	queue.URL = aws.ToString(url.QueueUrl)
+
+	output, err := s.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl: url.QueueUrl,
+		AttributeNames: []types.QueueAttributeName{
+			types.QueueAttributeNameApproximateNumberOfMessages,
+			types.QueueAttributeNameApproximateNumberOfMessagesNotVisible,
+			types.QueueAttributeNameApproximateNumberOfMessagesDelayed,
+			types.QueueAttributeNameRedrivePolicy,
+		},
+	})
+	if err != nil {
+		return QueueDetail{}, fmt.Errorf("failed to get attributes of queue %s: %w", queue.Name, err)
+	}
+
+	attributes := output.Attributes
+	queue.Visible, _ = strconv.ParseInt(attributes[string(types.QueueAttributeNameApproximateNumberOfMessages)], 10, 64)
+	queue.InFlight, _ = strconv.ParseInt(attributes[string(types.QueueAttributeNameApproximateNumberOfMessagesNotVisible)], 10, 64)
+	queue.Delayed, _ = strconv.ParseInt(attributes[string(types.QueueAttributeNameApproximateNumberOfMessagesDelayed)], 10, 64)
+	if policy := attributes[string(types.QueueAttributeNameRedrivePolicy)]; policy != "" {
+		var redrive struct {
+			DeadLetterTargetArn string `json:"deadLetterTargetArn"`
+			// maxReceiveCount is a string or a number depending on how the
+			// policy was set
+			MaxReceiveCount json.Number `json:"maxReceiveCount"`
+		}
+		if err := json.Unmarshal([]byte(policy), &redrive); err == nil {
+			queue.DeadLetterARN = redrive.DeadLetterTargetArn
+			maxReceives, _ := redrive.MaxReceiveCount.Int64()
+			queue.MaxReceives = int(maxReceives)
+		}
+	}
+	return queue, nil
+}
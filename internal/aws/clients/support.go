@@ -0,0 +1,170 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/support"
+	"github.com/aws/smithy-go"
+)
+
+// ErrSupportPlanRequired is returned when the account's support plan does
+// not include the Trusted Advisor or Health API
+var ErrSupportPlanRequired = errors.New("this API needs a Business, Enterprise On-Ramp or Enterprise Support plan")
+
+// supportSummaryBatch is how many checks are summarized per call
+const supportSummaryBatch = 100
+
+// TrustedAdvisorCheck is a Trusted Advisor check with the result of its
+// latest run
+type TrustedAdvisorCheck struct {
+	ID          string
+	Name        string
+	Description string
+	// Category is cost_optimizing, fault_tolerance, performance, security or
+	// service_limits
+	Category string
+	// Status is ok, warning, error or not_available
+	Status      string
+	RefreshedAt time.Time
+	Processed   int64
+	Flagged     int64
+	Suppressed  int64
+	// EstimatedMonthlySavings is set for cost optimization checks, in USD
+	EstimatedMonthlySavings float64
+	// Columns names the metadata of the flagged resources
+	Columns []string
+}
+
+// FlaggedResource is a resource a Trusted Advisor check flagged
+type FlaggedResource struct {
+	ID         string
+	Status     string
+	Region     string
+	Suppressed bool
+	// Metadata are the values of the columns of the check
+	Metadata []string
+}
+
+// SupportService wraps the Support client, which serves Trusted Advisor
+type SupportService struct {
+	client *support.Client
+}
+
+// NewSupportService creates a new Support service wrapper
+func NewSupportService(client *support.Client) (*SupportService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("Support client not provided")
+	}
+
+	return &SupportService{
+		client: client,
+	}, nil
+}
+
+// supportError wraps err in ErrSupportPlanRequired for accounts without a
+// support plan that includes Trusted Advisor and Health
+func supportError(err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "SubscriptionRequiredException" {
+		return fmt.Errorf("%w: %w", ErrSupportPlanRequired, err)
+	}
+	return err
+}
+
+// ListTrustedAdvisorChecks returns the Trusted Advisor checks with the
+// summary of their latest run
+func (s *SupportService) ListTrustedAdvisorChecks(ctx context.Context) ([]TrustedAdvisorCheck, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("Support service not initialized")
+	}
+
+	output, err := s.client.DescribeTrustedAdvisorChecks(ctx, &support.DescribeTrustedAdvisorChecksInput{
+		Language: aws.String("en"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe Trusted Advisor checks: %w", supportError(err))
+	}
+
+	checks := make([]TrustedAdvisorCheck, 0, len(output.Checks))
+	index := make(map[string]int, len(output.Checks))
+	ids := make([]string, 0, len(output.Checks))
+	for _, check := range output.Checks {
+		id := aws.ToString(check.Id)
+		index[id] = len(checks)
+		ids = append(ids, id)
+		checks = append(checks, TrustedAdvisorCheck{
+			ID:          id,
+			Name:        aws.ToString(check.Name),
+			Description: aws.ToString(check.Description),
+			Category:    aws.ToString(check.Category),
+			Status:      "not_available",
+			Columns:     aws.ToStringSlice(check.Metadata),
+		})
+	}
+
+	for start := 0; start < len(ids); start += supportSummaryBatch {
+		end := min(start+supportSummaryBatch, len(ids))
+		summaries, err := s.client.DescribeTrustedAdvisorCheckSummaries(ctx, &support.DescribeTrustedAdvisorCheckSummariesInput{
+			CheckIds: aws.StringSlice(ids[start:end]),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize Trusted Advisor checks: %w", supportError(err))
+		}
+
+		for _, summary := range summaries.Summaries {
+			i, ok := index[aws.ToString(summary.CheckId)]
+			if !ok {
+				continue
+			}
+			check := &checks[i]
+			check.Status = aws.ToString(summary.Status)
+			if t, err := time.Parse(time.RFC3339, aws.ToString(summary.Timestamp)); err == nil {
+				check.RefreshedAt = t
+			}
+			if r := summary.ResourcesSummary; r != nil {
+				check.Processed = r.ResourcesProcessed
+				check.Flagged = r.ResourcesFlagged
+				check.Suppressed = r.ResourcesSuppressed
+			}
+			if c := summary.CategorySpecificSummary; c != nil && c.CostOptimizing != nil {
+				check.EstimatedMonthlySavings = c.CostOptimizing.EstimatedMonthlySavings
+			}
+		}
+	}
+	return checks, nil
+}
+
+// GetFlaggedResources returns the resources the latest run of the check
+// with checkID flagged
+func (s *SupportService) GetFlaggedResources(ctx context.Context, checkID string) ([]FlaggedResource, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("Support service not initialized")
+	}
+
+	output, err := s.client.DescribeTrustedAdvisorCheckResult(ctx, &support.DescribeTrustedAdvisorCheckResultInput{
+		CheckId:  aws.String(checkID),
+		Language: aws.String("en"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Trusted Advisor check result: %w", supportError(err))
+	}
+	if output.Result == nil {
+		return nil, nil
+	}
+
+	resources := make([]FlaggedResource, 0, len(output.Result.FlaggedResources))
+	for _, flagged := range output.Result.FlaggedResources {
+		resources = append(resources, FlaggedResource{
+			ID:         aws.ToString(flagged.ResourceId),
+			Status:     aws.ToString(flagged.Status),
+			Region:     aws.ToString(flagged.Region),
+			Suppressed: flagged.IsSuppressed,
+			Metadata:   aws.ToStringSlice(flagged.Metadata),
+		})
+	}
+	return resources, nil
+}
@@ -0,0 +1,201 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// transferTargetPrefix prefixes the operation in the X-Amz-Target header of
+// a Transfer Family call
+const transferTargetPrefix = "TransferService."
+
+// TransferServer is a Transfer Family server with its protocols and endpoint
+type TransferServer struct {
+	ID    string
+	ARN   string
+	State string
+	// Protocols are the protocols clients connect with: SFTP, FTPS, FTP
+	// and AS2
+	Protocols []string
+	// Domain is where files are stored: S3 or EFS
+	Domain string
+	// EndpointType is PUBLIC, VPC or VPC_ENDPOINT
+	EndpointType string
+	// Endpoint is the host name clients connect to
+	Endpoint string
+	// VPCID and the elastic IPs are set for servers in a VPC
+	VPCID                string
+	AddressAllocationIDs []string
+	// IdentityProvider is SERVICE_MANAGED, API_GATEWAY, AWS_DIRECTORY_SERVICE
+	// or AWS_LAMBDA
+	IdentityProvider string
+	LoggingRole      string
+	Users            int
+	Tags             map[string]string
+}
+
+// TransferUser is a user of a Transfer Family server
+type TransferUser struct {
+	Name          string
+	ARN           string
+	Role          string
+	HomeDirectory string
+	// HomeDirectoryType is PATH or LOGICAL
+	HomeDirectoryType string
+	SSHKeys           int
+}
+
+// TransferService lists Transfer Family servers and their users
+type TransferService struct {
+	*jsonAPI
+}
+
+// NewTransferService creates a new Transfer Family service for the region
+// and credentials of cfg
+func NewTransferService(cfg aws.Config) (*TransferService, error) {
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("Transfer Family credentials not provided")
+	}
+	return &TransferService{
+		jsonAPI: newJSONAPI(cfg, "Transfer", regionalEndpoint("transfer", cfg.Region), cfg.Region, "transfer", transferTargetPrefix),
+	}, nil
+}
+
+// ListServers returns the servers of the region with their protocols and
+// endpoints, sorted by ID. Servers that fail to describe are returned as
+// listed, with a PartialError.
+func (s *TransferService) ListServers(ctx context.Context) ([]TransferServer, error) {
+	if s == nil || s.jsonAPI == nil {
+		return nil, fmt.Errorf("Transfer Family service not initialized")
+	}
+
+	var servers []TransferServer
+	input := map[string]any{"MaxResults": 1000}
+	for {
+		var output struct {
+			Servers []struct {
+				ServerID             string `json:"ServerId"`
+				Arn                  string `json:"Arn"`
+				State                string `json:"State"`
+				Domain               string `json:"Domain"`
+				EndpointType         string `json:"EndpointType"`
+				IdentityProviderType string `json:"IdentityProviderType"`
+				LoggingRole          string `json:"LoggingRole"`
+				UserCount            int    `json:"UserCount"`
+			} `json:"Servers"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.call(ctx, "ListServers", input, &output); err != nil {
+			return nil, fmt.Errorf("failed to list Transfer Family servers: %w", err)
+		}
+		for _, server := range output.Servers {
+			servers = append(servers, TransferServer{
+				ID:               server.ServerID,
+				ARN:              server.Arn,
+				State:            server.State,
+				Domain:           server.Domain,
+				EndpointType:     server.EndpointType,
+				Endpoint:         transferEndpoint(server.ServerID, s.region),
+				IdentityProvider: server.IdentityProviderType,
+				LoggingRole:      server.LoggingRole,
+				Users:            server.UserCount,
+			})
+		}
+		if output.NextToken == "" {
+			break
+		}
+		input["NextToken"] = output.NextToken
+	}
+
+	var failures failureCollector
+	for i := range servers {
+		if err := s.describeServer(ctx, &servers[i]); err != nil {
+			failures.add(servers[i].ID, s.region, err)
+		}
+	}
+
+	sort.SliceStable(servers, func(i, j int) bool { return servers[i].ID < servers[j].ID })
+	return servers, failures.err("transfer:DescribeServer")
+}
+
+// describeServer fills in the protocols, VPC and tags of server
+func (s *TransferService) describeServer(ctx context.Context, server *TransferServer) error {
+	var output struct {
+		Server struct {
+			Protocols       []string `json:"Protocols"`
+			EndpointDetails struct {
+				VpcID                string   `json:"VpcId"`
+				AddressAllocationIDs []string `json:"AddressAllocationIds"`
+			} `json:"EndpointDetails"`
+			Tags []struct {
+				Key   string `json:"Key"`
+				Value string `json:"Value"`
+			} `json:"Tags"`
+		} `json:"Server"`
+	}
+	if err := s.call(ctx, "DescribeServer", map[string]any{"ServerId": server.ID}, &output); err != nil {
+		return err
+	}
+	server.Protocols = output.Server.Protocols
+	server.VPCID = output.Server.EndpointDetails.VpcID
+	server.AddressAllocationIDs = output.Server.EndpointDetails.AddressAllocationIDs
+	server.Tags = make(map[string]string, len(output.Server.Tags))
+	for _, tag := range output.Server.Tags {
+		server.Tags[tag.Key] = tag.Value
+	}
+	return nil
+}
+
+// ListUsers returns the users of the server with the ID server, sorted by
+// name
+func (s *TransferService) ListUsers(ctx context.Context, server string) ([]TransferUser, error) {
+	if s == nil || s.jsonAPI == nil {
+		return nil, fmt.Errorf("Transfer Family service not initialized")
+	}
+
+	var users []TransferUser
+	input := map[string]any{"ServerId": server, "MaxResults": 1000}
+	for {
+		var output struct {
+			Users []struct {
+				UserName          string `json:"UserName"`
+				Arn               string `json:"Arn"`
+				Role              string `json:"Role"`
+				HomeDirectory     string `json:"HomeDirectory"`
+				HomeDirectoryType string `json:"HomeDirectoryType"`
+				SSHPublicKeyCount int    `json:"SshPublicKeyCount"`
+			} `json:"Users"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.call(ctx, "ListUsers", input, &output); err != nil {
+			return nil, fmt.Errorf("failed to list the users of %s: %w", server, err)
+		}
+		for _, user := range output.Users {
+			users = append(users, TransferUser{
+				Name:              user.UserName,
+				ARN:               user.Arn,
+				Role:              user.Role,
+				HomeDirectory:     user.HomeDirectory,
+				HomeDirectoryType: user.HomeDirectoryType,
+				SSHKeys:           user.SSHPublicKeyCount,
+			})
+		}
+		if output.NextToken == "" {
+			break
+		}
+		input["NextToken"] = output.NextToken
+	}
+
+	sort.SliceStable(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	return users, nil
+}
+
+// transferEndpoint returns the host name of the server with the ID server
+// in region, e.g. s-1234567890abcdef0.server.transfer.eu-west-1.amazonaws.com
+func transferEndpoint(server, region string) string {
+	return strings.TrimPrefix(regionalEndpoint(server+".server.transfer", region), "https://")
+}
@@ -0,0 +1,88 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTransferListServers(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/transfer/aws4_request") {
+			t.Errorf("Expected a request signed for transfer in eu-west-1, got %q", r.Header.Get("Authorization"))
+		}
+		var input map[string]any
+		json.NewDecoder(r.Body).Decode(&input)
+
+		switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), transferTargetPrefix) {
+		case "ListServers":
+			if input["NextToken"] == nil {
+				w.Write([]byte(`{"Servers":[{"ServerId":"s-2222","Arn":"arn:aws:transfer:eu-west-1:123456789012:server/s-2222","State":"ONLINE","Domain":"S3","EndpointType":"VPC","IdentityProviderType":"SERVICE_MANAGED","UserCount":3}],"NextToken":"p2"}`))
+				return
+			}
+			w.Write([]byte(`{"Servers":[{"ServerId":"s-1111","State":"OFFLINE","Domain":"EFS","EndpointType":"PUBLIC","IdentityProviderType":"AWS_LAMBDA"}]}`))
+		case "DescribeServer":
+			if input["ServerId"] == "s-1111" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"__type":"AccessDeniedException","Message":"not authorized"}`))
+				return
+			}
+			w.Write([]byte(`{"Server":{"Protocols":["SFTP","FTPS"],"EndpointDetails":{"VpcId":"vpc-1","AddressAllocationIds":["eipalloc-1"]},"Tags":[{"Key":"team","Value":"partners"}]}}`))
+		default:
+			t.Errorf("Unexpected target %q", r.Header.Get("X-Amz-Target"))
+		}
+	})
+
+	svc, err := NewTransferService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	servers, err := svc.ListServers(context.Background())
+	var partial *PartialError
+	if !errors.As(err, &partial) || len(partial.Failures) != 1 || partial.Failures[0].Item != "s-1111" {
+		t.Errorf("Expected s-1111 to fail to describe, got %v", err)
+	}
+	if len(servers) != 2 || servers[0].ID != "s-1111" {
+		t.Fatalf("Expected both servers sorted by ID, got %+v", servers)
+	}
+	s := servers[1]
+	if s.Endpoint != "s-2222.server.transfer.eu-west-1.amazonaws.com" || s.Users != 3 || s.VPCID != "vpc-1" || s.Tags["team"] != "partners" {
+		t.Errorf("Unexpected server %+v", s)
+	}
+	if strings.Join(s.Protocols, ",") != "SFTP,FTPS" {
+		t.Errorf("Expected the protocols of the server, got %v", s.Protocols)
+	}
+}
+
+func TestTransferListUsers(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		var input map[string]any
+		json.NewDecoder(r.Body).Decode(&input)
+		if input["ServerId"] != "s-2222" {
+			t.Errorf("Unexpected input %v", input)
+		}
+		w.Write([]byte(`{"ServerId":"s-2222","Users":[
+			{"UserName":"wholesaler","Role":"arn:aws:iam::123456789012:role/sftp","HomeDirectory":"/shop-inbox/wholesaler","HomeDirectoryType":"PATH","SshPublicKeyCount":2},
+			{"UserName":"carrier","HomeDirectoryType":"LOGICAL"}]}`))
+	})
+
+	svc, err := NewTransferService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := svc.ListUsers(context.Background(), "s-2222")
+	if err != nil {
+		t.Fatalf("ListUsers returned error: %v", err)
+	}
+	if len(users) != 2 || users[0].Name != "carrier" {
+		t.Fatalf("Expected the users sorted by name, got %+v", users)
+	}
+	if u := users[1]; u.SSHKeys != 2 || u.HomeDirectory != "/shop-inbox/wholesaler" || u.HomeDirectoryType != "PATH" {
+		t.Errorf("Unexpected user %+v", u)
+	}
+}
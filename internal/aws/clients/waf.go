@@ -0,0 +1,313 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+)
+
+// Scopes of WAF Web ACLs. CloudFront Web ACLs are only visible in us-east-1.
+const (
+	WAFScopeRegional   = string(types.ScopeRegional)
+	WAFScopeCloudFront = string(types.ScopeCloudfront)
+)
+
+// wafResourceTypes are the regional resource types a Web ACL can protect
+var wafResourceTypes = []types.ResourceType{
+	types.ResourceTypeApplicationLoadBalancer,
+	types.ResourceTypeApiGateway,
+	types.ResourceTypeAppsync,
+	types.ResourceTypeCognitioUserPool,
+	types.ResourceTypeAppRunnerService,
+	types.ResourceTypeVerifiedAccessInstance,
+}
+
+// WebACLSummary identifies a Web ACL
+type WebACLSummary struct {
+	Name        string
+	ID          string
+	ARN         string
+	Scope       string
+	Description string
+}
+
+// WAFRule is a rule of a Web ACL
+type WAFRule struct {
+	Name     string
+	Priority int32
+	// Action is what a match does: ALLOW, BLOCK, COUNT, CAPTCHA or
+	// CHALLENGE, or for rule groups the override (NONE or COUNT)
+	Action string
+	// Statement describes what the rule inspects, e.g. "managed AWS/AWSManagedRulesCommonRuleSet"
+	Statement string
+	// MetricName names the rule in CloudWatch metrics and sampled requests
+	MetricName string
+}
+
+// WebACLDetail describes a Web ACL with its rules
+type WebACLDetail struct {
+	WebACLSummary
+	DefaultAction string
+	Capacity      int64
+	MetricName    string
+	Rules         []WAFRule
+}
+
+// SampledRequest is a web request WAF inspected and sampled
+type SampledRequest struct {
+	Time     time.Time
+	Action   string
+	ClientIP string
+	Country  string
+	Method   string
+	URI      string
+	// Rule is the rule within a rule group that matched, if any
+	Rule   string
+	Weight int64
+}
+
+// WAFService wraps the WAFv2 client
+type WAFService struct {
+	client *wafv2.Client
+}
+
+// NewWAFService creates a new WAF service wrapper
+func NewWAFService(client *wafv2.Client) (*WAFService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("WAF client not provided")
+	}
+
+	return &WAFService{
+		client: client,
+	}, nil
+}
+
+// ListWebACLs returns the Web ACLs of scope
+func (s *WAFService) ListWebACLs(ctx context.Context, scope string) ([]WebACLSummary, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("WAF service not initialized")
+	}
+
+	input := &wafv2.ListWebACLsInput{Scope: types.Scope(scope)}
+
+	var acls []WebACLSummary
+	for {
+		output, err := s.client.ListWebACLs(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list web ACLs: %w", err)
+		}
+		for _, acl := range output.WebACLs {
+			acls = append(acls, WebACLSummary{
+				Name:        aws.ToString(acl.Name),
+				ID:          aws.ToString(acl.Id),
+				ARN:         aws.ToString(acl.ARN),
+				Scope:       scope,
+				Description: aws.ToString(acl.Description),
+			})
+		}
+
+		// The marker is returned even on the last page, which is then short or empty
+		if output.NextMarker == nil || len(output.WebACLs) == 0 {
+			return acls, nil
+		}
+		input.NextMarker = output.NextMarker
+	}
+}
+
+// GetWebACL returns acl with its default action and rules
+func (s *WAFService) GetWebACL(ctx context.Context, acl WebACLSummary) (WebACLDetail, error) {
+	if s == nil || s.client == nil {
+		return WebACLDetail{}, fmt.Errorf("WAF service not initialized")
+	}
+
+	output, err := s.client.GetWebACL(ctx, &wafv2.GetWebACLInput{
+		Name:  aws.String(acl.Name),
+		Id:    aws.String(acl.ID),
+		Scope: types.Scope(acl.Scope),
+	})
+	if err != nil {
+		return WebACLDetail{}, fmt.Errorf("failed to get web ACL %s: %w", acl.Name, err)
+	}
+
+	detail := WebACLDetail{WebACLSummary: acl}
+	webACL := output.WebACL
+	if webACL == nil {
+		return detail, nil
+	}
+
+	detail.Capacity = webACL.Capacity
+	if webACL.VisibilityConfig != nil {
+		detail.MetricName = aws.ToString(webACL.VisibilityConfig.MetricName)
+	}
+	if webACL.DefaultAction != nil {
+		detail.DefaultAction = "ALLOW"
+		if webACL.DefaultAction.Block != nil {
+			detail.DefaultAction = "BLOCK"
+		}
+	}
+
+	for _, rule := range webACL.Rules {
+		r := WAFRule{
+			Name:      aws.ToString(rule.Name),
+			Priority:  rule.Priority,
+			Action:    ruleAction(rule),
+			Statement: describeStatement(rule.Statement),
+		}
+		if rule.VisibilityConfig != nil {
+			r.MetricName = aws.ToString(rule.VisibilityConfig.MetricName)
+		}
+		detail.Rules = append(detail.Rules, r)
+	}
+	return detail, nil
+}
+
+// ruleAction returns the action of rule, or the override of a rule group
+func ruleAction(rule types.Rule) string {
+	if action := rule.Action; action != nil {
+		switch {
+		case action.Allow != nil:
+			return "ALLOW"
+		case action.Block != nil:
+			return "BLOCK"
+		case action.Count != nil:
+			return "COUNT"
+		case action.Captcha != nil:
+			return "CAPTCHA"
+		case action.Challenge != nil:
+			return "CHALLENGE"
+		}
+	}
+	if override := rule.OverrideAction; override != nil {
+		if override.Count != nil {
+			return "COUNT"
+		}
+		return "NONE"
+	}
+	return ""
+}
+
+// describeStatement names what statement inspects in a few words
+func describeStatement(statement *types.Statement) string {
+	if statement == nil {
+		return ""
+	}
+
+	switch {
+	case statement.ManagedRuleGroupStatement != nil:
+		group := statement.ManagedRuleGroupStatement
+		return fmt.Sprintf("managed %s/%s", aws.ToString(group.VendorName), aws.ToString(group.Name))
+	case statement.RuleGroupReferenceStatement != nil:
+		arn := aws.ToString(statement.RuleGroupReferenceStatement.ARN)
+		parts := strings.Split(arn, "/")
+		if len(parts) >= 3 {
+			return "rule group " + parts[len(parts)-2]
+		}
+		return "rule group " + arn
+	case statement.RateBasedStatement != nil:
+		rate := statement.RateBasedStatement
+		window := rate.EvaluationWindowSec
+		if window == 0 {
+			window = 300
+		}
+		return fmt.Sprintf("rate limit %d per %ds by %s", aws.ToInt64(rate.Limit), window, strings.ToLower(string(rate.AggregateKeyType)))
+	case statement.IPSetReferenceStatement != nil:
+		arn := aws.ToString(statement.IPSetReferenceStatement.ARN)
+		parts := strings.Split(arn, "/")
+		if len(parts) >= 3 {
+			return "IP set " + parts[len(parts)-2]
+		}
+		return "IP set " + arn
+	case statement.GeoMatchStatement != nil:
+		codes := make([]string, 0, len(statement.GeoMatchStatement.CountryCodes))
+		for _, code := range statement.GeoMatchStatement.CountryCodes {
+			codes = append(codes, string(code))
+		}
+		return "country in " + strings.Join(codes, ", ")
+	case statement.SqliMatchStatement != nil:
+		return "SQL injection"
+	case statement.XssMatchStatement != nil:
+		return "cross-site scripting"
+	case statement.ByteMatchStatement != nil:
+		return fmt.Sprintf("%s match %q", strings.ToLower(string(statement.ByteMatchStatement.PositionalConstraint)), statement.ByteMatchStatement.SearchString)
+	case statement.LabelMatchStatement != nil:
+		return "label " + aws.ToString(statement.LabelMatchStatement.Key)
+	case statement.AndStatement != nil:
+		return fmt.Sprintf("all of %d conditions", len(statement.AndStatement.Statements))
+	case statement.OrStatement != nil:
+		return fmt.Sprintf("any of %d conditions", len(statement.OrStatement.Statements))
+	case statement.NotStatement != nil:
+		return "not " + describeStatement(statement.NotStatement.Statement)
+	default:
+		return "custom"
+	}
+}
+
+// ListResourcesForWebACL returns the ARNs of the regional resources acl
+// protects. CloudFront distributions are not listed.
+func (s *WAFService) ListResourcesForWebACL(ctx context.Context, arn string) ([]string, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("WAF service not initialized")
+	}
+
+	var resources []string
+	for _, resourceType := range wafResourceTypes {
+		output, err := s.client.ListResourcesForWebACL(ctx, &wafv2.ListResourcesForWebACLInput{
+			WebACLArn:    aws.String(arn),
+			ResourceType: resourceType,
+		})
+		if err != nil {
+			return resources, fmt.Errorf("failed to list %s resources of web ACL: %w", resourceType, err)
+		}
+		resources = append(resources, output.ResourceArns...)
+	}
+	return resources, nil
+}
+
+// GetSampledRequests returns up to 500 requests that the rule with metricName
+// of acl (or acl's metric name for its default action) matched between start
+// and end, newest first. It also returns how many requests the sample was
+// taken from.
+func (s *WAFService) GetSampledRequests(ctx context.Context, acl WebACLSummary, metricName string, start, end time.Time) ([]SampledRequest, int64, error) {
+	if s == nil || s.client == nil {
+		return nil, 0, fmt.Errorf("WAF service not initialized")
+	}
+
+	output, err := s.client.GetSampledRequests(ctx, &wafv2.GetSampledRequestsInput{
+		WebAclArn:      aws.String(acl.ARN),
+		RuleMetricName: aws.String(metricName),
+		Scope:          types.Scope(acl.Scope),
+		TimeWindow: &types.TimeWindow{
+			StartTime: aws.Time(start),
+			EndTime:   aws.Time(end),
+		},
+		MaxItems: aws.Int64(500),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get sampled requests of %s: %w", metricName, err)
+	}
+
+	requests := make([]SampledRequest, 0, len(output.SampledRequests))
+	for _, sample := range output.SampledRequests {
+		request := SampledRequest{
+			Time:   aws.ToTime(sample.Timestamp),
+			Action: aws.ToString(sample.Action),
+			Rule:   aws.ToString(sample.RuleNameWithinRuleGroup),
+			Weight: sample.Weight,
+		}
+		if r := sample.Request; r != nil {
+			request.ClientIP = aws.ToString(r.ClientIP)
+			request.Country = aws.ToString(r.Country)
+			request.Method = aws.ToString(r.Method)
+			request.URI = aws.ToString(r.URI)
+		}
+		requests = append(requests, request)
+	}
+	sort.SliceStable(requests, func(i, j int) bool { return requests[i].Time.After(requests[j].Time) })
+	return requests, output.PopulationSize, nil
+}
@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
+	"github.com/aws/smithy-go"
+)
+
+// ErrorClass is the kind of an authentication or authorization failure,
+// deciding what the user has to do about it
+type ErrorClass int
+
+const (
+	// ErrorOther is any failure not caused by the credentials, e.g. a
+	// network error
+	ErrorOther ErrorClass = iota
+	// ErrorSSOLogin is an expired or missing SSO session
+	ErrorSSOLogin
+	// ErrorExpiredCredentials is an expired session token
+	ErrorExpiredCredentials
+	// ErrorInvalidCredentials is an access key AWS does not know or a
+	// signature it does not accept
+	ErrorInvalidCredentials
+	// ErrorAccessDenied is a call the credentials are not allowed to make
+	ErrorAccessDenied
+)
+
+// Error codes by class, as returned by STS, SSO and the service APIs
+var errorCodeClasses = map[string]ErrorClass{
+	"UnauthorizedSSOTokenError":   ErrorSSOLogin,
+	"UnauthorizedException":       ErrorSSOLogin,
+	"InvalidGrantException":       ErrorSSOLogin,
+	"ExpiredToken":                ErrorExpiredCredentials,
+	"ExpiredTokenException":       ErrorExpiredCredentials,
+	"RequestExpired":              ErrorExpiredCredentials,
+	"InvalidClientTokenId":        ErrorInvalidCredentials,
+	"UnrecognizedClientException": ErrorInvalidCredentials,
+	"SignatureDoesNotMatch":       ErrorInvalidCredentials,
+	"AuthFailure":                 ErrorInvalidCredentials,
+	"AccessDenied":                ErrorAccessDenied,
+	"AccessDeniedException":       ErrorAccessDenied,
+	"UnauthorizedOperation":       ErrorAccessDenied,
+}
+
+// ClassifyError returns the class of err from the SDK error types and API
+// error codes in its chain, never from its message
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorOther
+	}
+
+	var tokenErr *ssocreds.InvalidTokenError
+	if errors.As(err, &tokenErr) {
+		return ErrorSSOLogin
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		if class, ok := errorCodeClasses[apiErr.ErrorCode()]; ok {
+			return class
+		}
+	}
+	return ErrorOther
+}
+
+// RemediationHint tells what to do about a failure of class with profile, or
+// returns "" for ErrorOther. An expired SSO session names the profiles
+// sharing it, which one login signs in again.
+func RemediationHint(class ErrorClass, profile string) string {
+	switch class {
+	case ErrorSSOLogin:
+		return ssoLoginHint(profile, lookupSSOShare(profile))
+	case ErrorExpiredCredentials:
+		return fmt.Sprintf("The session token of profile %s has expired. Refresh its temporary credentials and try again.", profile)
+	case ErrorInvalidCredentials:
+		return fmt.Sprintf("AWS does not accept the credentials of profile %s. Check its access key and secret, or run 'aws configure --profile %s'.", profile, profile)
+	case ErrorAccessDenied:
+		return fmt.Sprintf("The credentials of profile %s are valid but not allowed to do this. Check the IAM policies of its user or role.", profile)
+	default:
+		return ""
+	}
+}
+
+// describeError wraps err of what for profile, adding the remediation hint
+// of its class
+func describeError(what, profile string, err error) error {
+	if hint := RemediationHint(ClassifyError(err), profile); hint != "" {
+		return fmt.Errorf("%s for profile %s: %w. %s", what, profile, err, hint)
+	}
+	return fmt.Errorf("%s for profile %s: %w", what, profile, err)
+}
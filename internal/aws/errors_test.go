@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
+	"github.com/aws/smithy-go"
+)
+
+func TestClassifyError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected ErrorClass
+	}{
+		{name: "nil error", err: nil, expected: ErrorOther},
+		{name: "expired SSO session", err: fmt.Errorf("get identity: %w", &ssocreds.InvalidTokenError{}), expected: ErrorSSOLogin},
+		{name: "unauthorized SSO token", err: &smithy.GenericAPIError{Code: "UnauthorizedSSOTokenError"}, expected: ErrorSSOLogin},
+		{name: "expired session token", err: fmt.Errorf("operation error STS: %w", &smithy.GenericAPIError{Code: "ExpiredTokenException"}), expected: ErrorExpiredCredentials},
+		{name: "unknown access key", err: &smithy.GenericAPIError{Code: "InvalidClientTokenId"}, expected: ErrorInvalidCredentials},
+		{name: "access denied", err: &smithy.GenericAPIError{Code: "AccessDenied"}, expected: ErrorAccessDenied},
+		{name: "other API error", err: &smithy.GenericAPIError{Code: "ThrottlingException"}, expected: ErrorOther},
+		// The messages of network errors mention tokens and credentials too
+		{name: "network error", err: errors.New("failed to refresh cached credentials, dial tcp: i/o timeout fetching token"), expected: ErrorOther},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ClassifyError(tc.err); got != tc.expected {
+				t.Errorf("Expected class %d, got %d for %v", tc.expected, got, tc.err)
+			}
+		})
+	}
+}
+
+func TestDescribeErrorAddsHint(t *testing.T) {
+	err := describeError("AWS connection test failed", "dev", &smithy.GenericAPIError{Code: "ExpiredToken"})
+	if !strings.Contains(err.Error(), "session token of profile dev has expired") {
+		t.Errorf("Expected the expired credentials hint, got %q", err)
+	}
+	if ClassifyError(err) != ErrorExpiredCredentials {
+		t.Error("Expected the class to survive wrapping")
+	}
+
+	err = describeError("AWS connection test failed", "dev", errors.New("no route to host"))
+	if err.Error() != "AWS connection test failed for profile dev: no route to host" {
+		t.Errorf("Expected no hint for other errors, got %q", err)
+	}
+}
@@ -0,0 +1,53 @@
+package fake
+
+import (
+	"context"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// AccessAnalyzerService has analyzers in us-east-1, reporting the public web
+// assets and the exports shared with a partner, and in eu-west-1, reporting
+// nothing. Other regions have none.
+type AccessAnalyzerService struct {
+	findings map[string][]clients.AccessFinding
+}
+
+// NewAccessAnalyzerService returns the sample findings
+func NewAccessAnalyzerService() *AccessAnalyzerService {
+	updated := time.Now().Add(-36 * time.Hour).Truncate(time.Minute)
+	return &AccessAnalyzerService{
+		findings: map[string][]clients.AccessFinding{
+			"us-east-1": {
+				{
+					ID:           "5d6c1f0e-7a3b-4c1d-9e2f-0a1b2c3d4e5f",
+					Resource:     "acme-order-exports",
+					ResourceType: "AWS::S3::Bucket",
+					Principals:   []string{"AWS: 999988887777"},
+					Actions:      []string{"s3:GetObject", "s3:ListBucket"},
+					UpdatedAt:    updated,
+				},
+				{
+					ID:           "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d",
+					Resource:     "acme-web-assets",
+					ResourceType: "AWS::S3::Bucket",
+					IsPublic:     true,
+					Principals:   []string{"AWS: *"},
+					Actions:      []string{"s3:GetObject"},
+					UpdatedAt:    updated,
+				},
+			},
+			"eu-west-1": {},
+		},
+	}
+}
+
+// ListBucketFindings returns the sample findings of region
+func (s *AccessAnalyzerService) ListBucketFindings(ctx context.Context, region string) ([]clients.AccessFinding, error) {
+	findings, ok := s.findings[region]
+	if !ok {
+		return nil, clients.ErrNoAnalyzer
+	}
+	return append([]clients.AccessFinding(nil), findings...), nil
+}
@@ -0,0 +1,106 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// ACMService lists certificates in every stage of their life: valid, about
+// to expire, pending validation and expired
+type ACMService struct {
+	certificates []clients.CertificateDetail
+}
+
+// NewACMService returns the sample certificates, with expiry dates relative
+// to now
+func NewACMService() *ACMService {
+	today := time.Now().Truncate(24 * time.Hour)
+	days := func(n int) time.Time { return today.AddDate(0, 0, n) }
+	arn := func(id string) string {
+		return fmt.Sprintf("arn:aws:acm:%s:%s:certificate/%s", Region, Account, id)
+	}
+	balancer := func(name, id string) string {
+		return fmt.Sprintf("arn:aws:elasticloadbalancing:%s:%s:loadbalancer/app/%s/%s", Region, Account, name, id)
+	}
+
+	return &ACMService{
+		certificates: []clients.CertificateDetail{
+			{
+				ARN:                arn("0c2f1f64-5e2a-4a8e-9a3b-2f6d0e1b7c11"),
+				Domain:             "example.com",
+				SANs:               []string{"example.com", "*.example.com"},
+				Status:             "ISSUED",
+				Type:               "AMAZON_ISSUED",
+				KeyType:            "RSA_2048",
+				ValidationStatus:   "SUCCESS",
+				RenewalEligibility: "ELIGIBLE",
+				InUseBy:            []string{balancer("web-prod", "50dc6c495c0c9188")},
+				NotAfter:           days(243),
+				IssuedAt:           days(-152),
+			},
+			{
+				ARN:                arn("6a1d9f0e-3b7c-4c2d-8e5f-9a0b1c2d3e44"),
+				Domain:             "shop.example.com",
+				SANs:               []string{"shop.example.com"},
+				Status:             "ISSUED",
+				Type:               "IMPORTED",
+				KeyType:            "EC_prime256v1",
+				RenewalEligibility: "INELIGIBLE",
+				InUseBy: []string{
+					balancer("web-prod", "50dc6c495c0c9188"),
+					"arn:aws:cloudfront::" + Account + ":distribution/E2QWRUHAPOMQZL",
+				},
+				NotAfter: days(12),
+				IssuedAt: days(-353),
+			},
+			{
+				ARN:                arn("9e8d7c6b-5a4f-4e3d-2c1b-0a9f8e7d6c55"),
+				Domain:             "api.example.com",
+				SANs:               []string{"api.example.com"},
+				Status:             "ISSUED",
+				Type:               "AMAZON_ISSUED",
+				KeyType:            "RSA_2048",
+				ValidationStatus:   "SUCCESS",
+				RenewalEligibility: "INELIGIBLE",
+				NotAfter:           days(27),
+				IssuedAt:           days(-368),
+			},
+			{
+				ARN:                arn("3f2e1d0c-9b8a-4f7e-6d5c-4b3a2f1e0d66"),
+				Domain:             "staging.example.org",
+				SANs:               []string{"staging.example.org", "www.staging.example.org"},
+				Status:             "PENDING_VALIDATION",
+				Type:               "AMAZON_ISSUED",
+				KeyType:            "RSA_2048",
+				ValidationStatus:   "PENDING_VALIDATION",
+				RenewalEligibility: "INELIGIBLE",
+			},
+			{
+				ARN:                arn("c4b3a291-8f7e-4d6c-5b4a-3f2e1d0c9b77"),
+				Domain:             "promo2023.example.com",
+				SANs:               []string{"promo2023.example.com"},
+				Status:             "EXPIRED",
+				Type:               "AMAZON_ISSUED",
+				KeyType:            "RSA_2048",
+				ValidationStatus:   "SUCCESS",
+				RenewalEligibility: "INELIGIBLE",
+				NotAfter:           days(-41),
+				IssuedAt:           days(-436),
+			},
+		},
+	}
+}
+
+// ListCertificates returns all certificates
+func (s *ACMService) ListCertificates(ctx context.Context) ([]clients.CertificateDetail, error) {
+	certificates := make([]clients.CertificateDetail, len(s.certificates))
+	for i, certificate := range s.certificates {
+		certificate.SANs = append([]string(nil), certificate.SANs...)
+		certificate.InUseBy = append([]string(nil), certificate.InUseBy...)
+		certificates[i] = certificate
+	}
+	return certificates, nil
+}
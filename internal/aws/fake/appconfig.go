@@ -0,0 +1,224 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// sampleConfig is a configuration profile of the demo account with the
+// content of its hosted versions, oldest first
+type sampleConfig struct {
+	profile  clients.AppConfigProfile
+	versions []string
+}
+
+// AppConfigService holds the AppConfig applications of the demo account:
+// a checkout whose staging runs newer feature flags than production and a
+// mobile app whose last flag deployment rolled back, next to a profile kept
+// in S3. Deployments complete at once.
+type AppConfigService struct {
+	applications []clients.AppConfigApplication
+	configs      []sampleConfig
+	strategies   []clients.AppConfigStrategy
+
+	mu           sync.Mutex
+	environments []clients.AppConfigEnvironment
+	// deployments are keyed by environment ID, oldest first
+	deployments map[string][]clients.AppConfigDeployment
+}
+
+// NewAppConfigService returns the sample applications
+func NewAppConfigService() *AppConfigService {
+	now := time.Now().UTC().Truncate(time.Minute)
+	profile := func(app, id, name, typ, location string, versions ...string) sampleConfig {
+		return sampleConfig{
+			profile:  clients.AppConfigProfile{ID: id, ApplicationID: app, Name: name, Type: typ, LocationURI: location},
+			versions: versions,
+		}
+	}
+	deployment := func(number int, config, version, state string, ago time.Duration) clients.AppConfigDeployment {
+		d := clients.AppConfigDeployment{
+			Number: number, ConfigurationName: config, ConfigurationVersion: version,
+			State: state, Percentage: 100, StartedAt: now.Add(-ago),
+		}
+		if state == "COMPLETE" {
+			d.CompletedAt = d.StartedAt.Add(10 * time.Minute)
+		}
+		return d
+	}
+
+	return &AppConfigService{
+		applications: []clients.AppConfigApplication{
+			{ID: "a1chk01", Name: "checkout", Description: "Web shop checkout"},
+			{ID: "a2mob02", Name: "mobile-app", Description: "iOS and Android apps"},
+		},
+		environments: []clients.AppConfigEnvironment{
+			{ID: "e1prd01", ApplicationID: "a1chk01", Name: "production", State: "READY_FOR_DEPLOYMENT"},
+			{ID: "e2stg02", ApplicationID: "a1chk01", Name: "staging", State: "READY_FOR_DEPLOYMENT"},
+			{ID: "e3prd03", ApplicationID: "a2mob02", Name: "production", State: "ROLLED_BACK"},
+		},
+		configs: []sampleConfig{
+			profile("a1chk01", "p1flg01", "feature-flags", "AWS.AppConfig.FeatureFlags", "hosted",
+				`{"flags":{"express-checkout":{"name":"Express checkout"}},"values":{"express-checkout":{"enabled":false}},"version":"1"}`,
+				`{"flags":{"express-checkout":{"name":"Express checkout"}},"values":{"express-checkout":{"enabled":true}},"version":"1"}`,
+				`{"flags":{"express-checkout":{"name":"Express checkout"},"gift-cards":{"name":"Gift cards"}},"values":{"express-checkout":{"enabled":true},"gift-cards":{"enabled":false}},"version":"1"}`,
+				`{"flags":{"express-checkout":{"name":"Express checkout"},"gift-cards":{"name":"Gift cards"},"saved-carts":{"name":"Saved carts"}},"values":{"express-checkout":{"enabled":true},"gift-cards":{"enabled":true},"saved-carts":{"enabled":false}},"version":"1"}`),
+			profile("a1chk01", "p2svc02", "service-config", "AWS.Freeform", "hosted",
+				"payment:\n  provider: stripe\n  timeout: 5s\n  retries: 2\n",
+				"payment:\n  provider: stripe\n  timeout: 8s\n  retries: 3\n"),
+			profile("a2mob02", "p3flg03", "flags", "AWS.AppConfig.FeatureFlags", "hosted",
+				`{"flags":{"dark-mode":{"name":"Dark mode"}},"values":{"dark-mode":{"enabled":false}},"version":"1"}`,
+				`{"flags":{"dark-mode":{"name":"Dark mode"}},"values":{"dark-mode":{"enabled":true}},"version":"1"}`,
+				`{"flags":{"dark-mode":{"name":"Dark mode"},"new-onboarding":{"name":"New onboarding"}},"values":{"dark-mode":{"enabled":true},"new-onboarding":{"enabled":true}},"version":"1"}`),
+			profile("a2mob02", "p4rmt04", "remote-config", "AWS.Freeform", "s3://mobile-app-config/remote-config.json"),
+		},
+		strategies: []clients.AppConfigStrategy{
+			{ID: "AppConfig.AllAtOnce", Name: "AppConfig.AllAtOnce", GrowthFactor: 100, BakeMinutes: 10},
+			{ID: "AppConfig.Canary10Percent20Minutes", Name: "AppConfig.Canary10Percent20Minutes", DurationMinutes: 20, GrowthFactor: 10, BakeMinutes: 10},
+			{ID: "AppConfig.Linear50PercentEvery30Seconds", Name: "AppConfig.Linear50PercentEvery30Seconds", DurationMinutes: 1, GrowthFactor: 50, BakeMinutes: 1},
+		},
+		deployments: map[string][]clients.AppConfigDeployment{
+			"e1prd01": {
+				deployment(1, "feature-flags", "2", "COMPLETE", 30*24*time.Hour),
+				deployment(2, "service-config", "2", "COMPLETE", 12*24*time.Hour),
+				deployment(3, "feature-flags", "3", "COMPLETE", 6*24*time.Hour),
+			},
+			"e2stg02": {
+				deployment(1, "feature-flags", "3", "COMPLETE", 7*24*time.Hour),
+				deployment(2, "service-config", "2", "COMPLETE", 12*24*time.Hour),
+				deployment(3, "feature-flags", "4", "COMPLETE", 26*time.Hour),
+			},
+			"e3prd03": {
+				deployment(1, "flags", "2", "COMPLETE", 20*24*time.Hour),
+				deployment(2, "flags", "3", "ROLLED_BACK", 3*time.Hour),
+			},
+		},
+	}
+}
+
+// ListApplications returns the sample applications
+func (s *AppConfigService) ListApplications(ctx context.Context) ([]clients.AppConfigApplication, error) {
+	return append([]clients.AppConfigApplication(nil), s.applications...), nil
+}
+
+// ListEnvironments returns the sample environments of the application
+func (s *AppConfigService) ListEnvironments(ctx context.Context, applicationID string) ([]clients.AppConfigEnvironment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var environments []clients.AppConfigEnvironment
+	for _, environment := range s.environments {
+		if environment.ApplicationID == applicationID {
+			environments = append(environments, environment)
+		}
+	}
+	return environments, nil
+}
+
+// ListConfigurationProfiles returns the sample profiles of the application
+func (s *AppConfigService) ListConfigurationProfiles(ctx context.Context, applicationID string) ([]clients.AppConfigProfile, error) {
+	var profiles []clients.AppConfigProfile
+	for _, config := range s.configs {
+		if config.profile.ApplicationID == applicationID {
+			profiles = append(profiles, config.profile)
+		}
+	}
+	return profiles, nil
+}
+
+// ListDeployments returns the deployments to the sample environment, newest
+// first
+func (s *AppConfigService) ListDeployments(ctx context.Context, applicationID, environmentID string) ([]clients.AppConfigDeployment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deployments := s.deployments[environmentID]
+	newest := make([]clients.AppConfigDeployment, len(deployments))
+	for i, deployment := range deployments {
+		newest[len(deployments)-1-i] = deployment
+	}
+	return newest, nil
+}
+
+// config returns the sample profile profileID of the application
+func (s *AppConfigService) config(applicationID, profileID string) (sampleConfig, error) {
+	for _, config := range s.configs {
+		if config.profile.ApplicationID == applicationID && config.profile.ID == profileID {
+			return config, nil
+		}
+	}
+	return sampleConfig{}, apiError("ResourceNotFoundException", fmt.Sprintf("Configuration profile %s not found", profileID))
+}
+
+// ListHostedVersions returns the versions of the sample profile, newest
+// first
+func (s *AppConfigService) ListHostedVersions(ctx context.Context, applicationID, profileID string) ([]clients.AppConfigVersion, error) {
+	config, err := s.config(applicationID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	contentType := "application/json"
+	if config.profile.Type == "AWS.Freeform" {
+		contentType = "application/x-yaml"
+	}
+	versions := make([]clients.AppConfigVersion, 0, len(config.versions))
+	for number := len(config.versions); number > 0; number-- {
+		versions = append(versions, clients.AppConfigVersion{
+			Number:      number,
+			Label:       fmt.Sprintf("v1.%d", number-1),
+			ContentType: contentType,
+		})
+	}
+	return versions, nil
+}
+
+// GetHostedVersion returns the content of a version of the sample profile
+func (s *AppConfigService) GetHostedVersion(ctx context.Context, applicationID, profileID string, version int) ([]byte, error) {
+	config, err := s.config(applicationID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if version < 1 || version > len(config.versions) {
+		return nil, apiError("ResourceNotFoundException", fmt.Sprintf("Hosted configuration version %d not found", version))
+	}
+	return []byte(config.versions[version-1]), nil
+}
+
+// ListDeploymentStrategies returns the predefined strategies
+func (s *AppConfigService) ListDeploymentStrategies(ctx context.Context) ([]clients.AppConfigStrategy, error) {
+	return append([]clients.AppConfigStrategy(nil), s.strategies...), nil
+}
+
+// StartDeployment deploys a version of a sample profile, completing at once
+func (s *AppConfigService) StartDeployment(ctx context.Context, applicationID, environmentID, profileID, version, strategyID string) (clients.AppConfigDeployment, error) {
+	config, err := s.config(applicationID, profileID)
+	if err != nil {
+		return clients.AppConfigDeployment{}, err
+	}
+	if number, err := strconv.Atoi(version); config.profile.Hosted() && (err != nil || number < 1 || number > len(config.versions)) {
+		return clients.AppConfigDeployment{}, apiError("BadRequestException", fmt.Sprintf("Hosted configuration version %s not found", version))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now().UTC()
+	deployment := clients.AppConfigDeployment{
+		Number:               len(s.deployments[environmentID]) + 1,
+		ConfigurationName:    config.profile.Name,
+		ConfigurationVersion: version,
+		State:                "COMPLETE",
+		Percentage:           100,
+		StartedAt:            now,
+		CompletedAt:          now,
+	}
+	s.deployments[environmentID] = append(s.deployments[environmentID], deployment)
+	for i := range s.environments {
+		if s.environments[i].ID == environmentID {
+			s.environments[i].State = "READY_FOR_DEPLOYMENT"
+		}
+	}
+	return deployment, nil
+}
@@ -0,0 +1,280 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// DefaultQueryDuration is how long queries run unless changed with
+// SetQueryDuration
+const DefaultQueryDuration = 2 * time.Second
+
+// table is a table of the sample databases
+type table struct {
+	columns []string
+	rows    func() [][]string
+}
+
+// athenaTables are the tables of the sample databases, by database
+var athenaTables = map[string]map[string]table{
+	"default": {},
+	"sales": {
+		"orders": {
+			columns: []string{"order_id", "customer_id", "total", "status", "created_at"},
+			rows: func() [][]string {
+				statuses := []string{"shipped", "shipped", "delivered", "pending", "refunded"}
+				start := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+				rows := make([][]string, 250)
+				for i := range rows {
+					rows[i] = []string{
+						fmt.Sprintf("o-%05d", 10001+i),
+						fmt.Sprintf("c-%03d", 1+i*7%40),
+						fmt.Sprintf("%.2f", float64(i*37%500)+0.99),
+						statuses[i%len(statuses)],
+						start.Add(time.Duration(i) * 97 * time.Minute).Format("2006-01-02 15:04:05"),
+					}
+				}
+				return rows
+			},
+		},
+		"customers": {
+			columns: []string{"customer_id", "name", "country"},
+			rows: func() [][]string {
+				countries := []string{"DE", "US", "FR", "GB", "NL"}
+				rows := make([][]string, 40)
+				for i := range rows {
+					rows[i] = []string{fmt.Sprintf("c-%03d", 1+i), fmt.Sprintf("Customer %d", 1+i), countries[i%len(countries)]}
+				}
+				return rows
+			},
+		},
+	},
+	"weblogs": {
+		"access_logs": {
+			columns: []string{"request_time", "status", "path", "bytes"},
+			rows: func() [][]string {
+				paths := []string{"/", "/cart", "/checkout", "/api/orders", "/static/app.js"}
+				start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+				rows := make([][]string, 500)
+				for i := range rows {
+					status := "200"
+					if i%23 == 0 {
+						status = "500"
+					}
+					rows[i] = []string{
+						start.Add(time.Duration(i) * 13 * time.Second).Format("2006-01-02 15:04:05"),
+						status,
+						paths[i%len(paths)],
+						strconv.Itoa(512 + i*131%20000),
+					}
+				}
+				return rows
+			},
+		},
+	},
+}
+
+var (
+	fromPattern  = regexp.MustCompile(`(?i)\bfrom\s+("?[\w.]+"?)`)
+	limitPattern = regexp.MustCompile(`(?i)\blimit\s+(\d+)`)
+)
+
+// query is a query execution of the fake
+type query struct {
+	started   time.Time
+	duration  time.Duration
+	cancelled bool
+
+	columns []string
+	rows    [][]string
+	// failure is why the query fails, empty if it succeeds
+	failure string
+}
+
+// AthenaService runs a few kinds of queries against sample tables: SHOW
+// TABLES and SELECT ... FROM table [LIMIT n], returning all columns. Queries
+// run for a fixed duration.
+type AthenaService struct {
+	mu       sync.Mutex
+	duration time.Duration
+	queries  map[string]*query
+	next     int
+}
+
+// NewAthenaService returns the sample workgroups and databases
+func NewAthenaService() *AthenaService {
+	return &AthenaService{
+		duration: DefaultQueryDuration,
+		queries:  make(map[string]*query),
+	}
+}
+
+// SetQueryDuration sets how long later queries run
+func (s *AthenaService) SetQueryDuration(duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.duration = duration
+}
+
+// ListWorkGroups returns the sample workgroups
+func (s *AthenaService) ListWorkGroups(ctx context.Context) ([]string, error) {
+	return []string{"primary", "analytics"}, nil
+}
+
+// ListDatabases returns the sample databases
+func (s *AthenaService) ListDatabases(ctx context.Context) ([]string, error) {
+	return []string{"default", "sales", "weblogs"}, nil
+}
+
+// StartQuery starts sql; its outcome is decided right away
+func (s *AthenaService) StartQuery(ctx context.Context, sql, workgroup, database string) (string, error) {
+	if strings.TrimSpace(sql) == "" {
+		return "", apiError("InvalidRequestException", "Query string must not be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.next++
+	id := fmt.Sprintf("7c1a2f3e-0b4d-4e5f-9a6b-%012d", s.next)
+	q := &query{started: time.Now(), duration: s.duration}
+	q.columns, q.rows, q.failure = runQuery(sql, database)
+	s.queries[id] = q
+	return id, nil
+}
+
+// runQuery returns the result of sql against the sample database
+func runQuery(sql, database string) ([]string, [][]string, string) {
+	words := strings.Fields(strings.ToLower(sql))
+	tables := athenaTables[database]
+
+	switch {
+	case len(words) >= 2 && words[0] == "show" && words[1] == "tables":
+		var rows [][]string
+		for name := range tables {
+			rows = append(rows, []string{name})
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i][0] < rows[j][0] })
+		return []string{"tab_name"}, rows, ""
+	case words[0] == "select":
+		match := fromPattern.FindStringSubmatch(sql)
+		if match == nil {
+			return []string{"_col0"}, [][]string{{"1"}}, ""
+		}
+		name := strings.Trim(match[1], `"`)
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			database, name = name[:i], name[i+1:]
+			tables = athenaTables[database]
+		}
+		t, ok := tables[name]
+		if !ok {
+			return nil, nil, fmt.Sprintf("TABLE_NOT_FOUND: line 1:%d: Table 'awsdatacatalog.%s.%s' does not exist",
+				strings.Index(strings.ToLower(sql), "from")+6, database, name)
+		}
+		rows := t.rows()
+		if limit := limitPattern.FindStringSubmatch(sql); limit != nil {
+			if n, err := strconv.Atoi(limit[1]); err == nil && n < len(rows) {
+				rows = rows[:n]
+			}
+		}
+		return t.columns, rows, ""
+	default:
+		return nil, nil, fmt.Sprintf("line 1:1: mismatched input '%s'. Expecting: 'SELECT', 'SHOW', 'WITH'", strings.Fields(sql)[0])
+	}
+}
+
+// GetQueryStatus reports a query as running until its duration passed
+func (s *AthenaService) GetQueryStatus(ctx context.Context, id string) (clients.QueryStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q, ok := s.queries[id]
+	if !ok {
+		return clients.QueryStatus{}, apiError("InvalidRequestException", "QueryExecution "+id+" was not found")
+	}
+	return q.status(id), nil
+}
+
+// status returns the status of q at this moment
+func (q *query) status(id string) clients.QueryStatus {
+	status := clients.QueryStatus{
+		ID:             id,
+		OutputLocation: "s3://aws-athena-query-results-" + Account + "-" + Region + "/" + id + ".csv",
+	}
+
+	elapsed := time.Since(q.started)
+	switch {
+	case q.cancelled:
+		status.State = clients.QueryCancelled
+		status.Reason = "Query cancelled by user"
+	case elapsed < q.duration/4:
+		status.State = clients.QueryQueued
+	case elapsed < q.duration:
+		status.State = clients.QueryRunning
+	case q.failure != "":
+		status.State = clients.QueryFailed
+		status.Reason = q.failure
+	default:
+		status.State = clients.QuerySucceeded
+		status.DataScanned = int64(len(q.rows)) * 96
+	}
+	status.Runtime = min(elapsed, q.duration).Truncate(time.Millisecond)
+	return status
+}
+
+// GetQueryResults pages through the rows of a succeeded query. Tokens are
+// row offsets.
+func (s *AthenaService) GetQueryResults(ctx context.Context, id, token string, maxRows int32) (clients.QueryResultPage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q, ok := s.queries[id]
+	if !ok {
+		return clients.QueryResultPage{}, apiError("InvalidRequestException", "QueryExecution "+id+" was not found")
+	}
+	if status := q.status(id); status.State != clients.QuerySucceeded {
+		return clients.QueryResultPage{}, apiError("InvalidRequestException", "Query has not yet finished. Current state: "+status.State)
+	}
+
+	offset := 0
+	if token != "" {
+		n, err := strconv.Atoi(token)
+		if err != nil || n < 0 || n > len(q.rows) {
+			return clients.QueryResultPage{}, apiError("InvalidRequestException", "Invalid NextToken")
+		}
+		offset = n
+	}
+	end := min(offset+int(maxRows), len(q.rows))
+
+	page := clients.QueryResultPage{
+		Columns: q.columns,
+		Rows:    q.rows[offset:end],
+	}
+	if end < len(q.rows) {
+		page.NextToken = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+// StopQuery cancels a query that has not finished yet
+func (s *AthenaService) StopQuery(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q, ok := s.queries[id]
+	if !ok {
+		return apiError("InvalidRequestException", "QueryExecution "+id+" was not found")
+	}
+	if !q.status(id).Done() {
+		q.cancelled = true
+	}
+	return nil
+}
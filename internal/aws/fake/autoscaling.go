@@ -0,0 +1,58 @@
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// AutoScalingService keeps the Auto Scaling groups of the sample shop, whose
+// capacity can be changed
+type AutoScalingService struct {
+	mu     sync.Mutex
+	groups []clients.AutoScalingGroup
+}
+
+// NewAutoScalingService returns the web and worker groups of the shop
+func NewAutoScalingService() *AutoScalingService {
+	return &AutoScalingService{
+		groups: []clients.AutoScalingGroup{
+			{Name: "shop-web-asg", Min: 2, Max: 6, Desired: 3, Instances: 3},
+			{Name: "shop-worker-asg", Min: 1, Max: 4, Desired: 2, Instances: 2},
+		},
+	}
+}
+
+// ListGroups returns the groups
+func (s *AutoScalingService) ListGroups(ctx context.Context) ([]clients.AutoScalingGroup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]clients.AutoScalingGroup(nil), s.groups...), nil
+}
+
+// SetCapacity sets the capacity of a group, whose instances follow at once
+func (s *AutoScalingService) SetCapacity(ctx context.Context, name string, min, max, desired int32) error {
+	if min > desired || desired > max {
+		return apiError("ValidationError", "Desired capacity must be between the minimum and maximum size")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.groups {
+		if s.groups[i].Name == name {
+			s.groups[i].Min, s.groups[i].Max, s.groups[i].Desired = min, max, desired
+			s.groups[i].Instances = int(desired)
+			return nil
+		}
+	}
+	return apiError("ValidationError", "AutoScalingGroup name not found - AutoScalingGroup "+name+" not found")
+}
+
+// ListLaunchReferences returns the launch templates of the groups
+func (s *AutoScalingService) ListLaunchReferences(ctx context.Context) (clients.LaunchReferences, error) {
+	return clients.LaunchReferences{Templates: []clients.LaunchTemplateRef{
+		{Name: "shop-web", Version: "$Default"},
+		{Name: "orders-worker", Version: "7"},
+	}}, nil
+}
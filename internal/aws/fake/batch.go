@@ -0,0 +1,180 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// BatchService holds the AWS Batch queues of the demo account: orders-high
+// running invoice renders on Fargate, orders-bulk spreading exports over
+// Fargate Spot and Fargate, and reports whose EC2 compute environment is
+// invalid, so its jobs stay runnable. Terminated jobs fail at once.
+type BatchService struct {
+	queues       []clients.BatchJobQueue
+	environments []clients.BatchComputeEnvironment
+
+	mu   sync.Mutex
+	jobs []clients.BatchJob
+}
+
+// NewBatchService returns the sample queues and their jobs
+func NewBatchService() *BatchService {
+	now := time.Now()
+	arn := func(kind, name string) string {
+		return fmt.Sprintf("arn:aws:batch:%s:%s:%s/%s", Region, Account, kind, name)
+	}
+
+	environment := func(name, resourceType string, max, desired int) clients.BatchComputeEnvironment {
+		return clients.BatchComputeEnvironment{
+			Name:         name,
+			ARN:          arn("compute-environment", name),
+			Type:         "MANAGED",
+			State:        "ENABLED",
+			Status:       "VALID",
+			StatusReason: "ComputeEnvironment Healthy",
+			ResourceType: resourceType,
+			MaxVCPUs:     max,
+			DesiredVCPUs: desired,
+		}
+	}
+	environments := []clients.BatchComputeEnvironment{
+		environment("shop-fargate", "FARGATE", 64, 8),
+		environment("shop-fargate-spot", "FARGATE_SPOT", 256, 48),
+		environment("reports-ec2", "EC2", 32, 0),
+	}
+	environments[2].Status = "INVALID"
+	environments[2].StatusReason = "CLIENT_ERROR - instance profile ecsInstanceRole does not exist"
+
+	queue := func(name string, priority int, environments ...string) clients.BatchJobQueue {
+		queue := clients.BatchJobQueue{
+			Name:         name,
+			ARN:          arn("job-queue", name),
+			State:        "ENABLED",
+			Status:       "VALID",
+			StatusReason: "JobQueue Healthy",
+			Priority:     priority,
+		}
+		for _, environment := range environments {
+			queue.ComputeEnvironments = append(queue.ComputeEnvironments, arn("compute-environment", environment))
+		}
+		return queue
+	}
+	queues := []clients.BatchJobQueue{
+		queue("orders-high", 100, "shop-fargate"),
+		queue("orders-bulk", 10, "shop-fargate-spot", "shop-fargate"),
+		queue("reports", 1, "reports-ec2"),
+	}
+
+	n := 0
+	job := func(queue, name, definition, status string, created time.Duration) clients.BatchJob {
+		n++
+		id := fmt.Sprintf("%08x-4b1e-4c2a-9d3f-%012x", 0x5e7a0000+n, 0x1a2b3c4d0000+n)
+		job := clients.BatchJob{
+			ID:         id,
+			ARN:        arn("job", id),
+			Name:       name,
+			Queue:      arn("job-queue", queue),
+			Status:     status,
+			Definition: arn("job-definition", definition+":3"),
+			Image:      fmt.Sprintf("%s.dkr.ecr.%s.amazonaws.com/%s:1.8.2", Account, Region, definition),
+			CreatedAt:  now.Add(-created),
+			LogGroup:   clients.BatchDefaultLogGroup,
+		}
+		if status == "RUNNING" || status == "SUCCEEDED" || status == "FAILED" {
+			job.Attempts = 1
+			job.StartedAt = job.CreatedAt.Add(40 * time.Second)
+			job.LogStream = fmt.Sprintf("%s/default/%032x", definition, 0xb47c0000+n)
+		}
+		return job
+	}
+	exited := func(job clients.BatchJob, code int, took time.Duration, reason string) clients.BatchJob {
+		job.ExitCode = &code
+		job.StoppedAt = job.StartedAt.Add(took)
+		job.Reason = reason
+		if code != 0 {
+			job.StatusReason = "Essential container in task exited"
+		}
+		return job
+	}
+
+	jobs := []clients.BatchJob{
+		job("orders-high", "render-invoices-1042", "invoice-render", "RUNNING", 6*time.Minute),
+		job("orders-high", "render-invoices-1043", "invoice-render", "RUNNING", 3*time.Minute),
+		job("orders-high", "render-invoices-1044", "invoice-render", "RUNNABLE", time.Minute),
+		exited(job("orders-high", "render-invoices-1041", "invoice-render", "SUCCEEDED", 40*time.Minute), 0, 9*time.Minute, ""),
+		exited(job("orders-high", "render-invoices-1040", "invoice-render", "FAILED", 2*time.Hour), 137, 4*time.Minute,
+			"OutOfMemoryError: Container killed due to memory usage"),
+		job("orders-bulk", "export-orders-2026-10", "order-export", "RUNNING", 50*time.Minute),
+		exited(job("orders-bulk", "export-orders-2026-09", "order-export", "FAILED", 26*time.Hour), 1, 18*time.Minute,
+			"Error: S3 upload to shop-exports failed: AccessDenied"),
+		job("reports", "monthly-revenue", "revenue-report", "RUNNABLE", 5*time.Hour),
+		job("reports", "stock-forecast", "stock-forecast", "RUNNABLE", 3*time.Hour),
+	}
+	jobs[6].Attempts = 3
+	return &BatchService{queues: queues, environments: environments, jobs: jobs}
+}
+
+// ListJobQueues returns the sample queues, highest priority first
+func (s *BatchService) ListJobQueues(ctx context.Context) ([]clients.BatchJobQueue, error) {
+	return append([]clients.BatchJobQueue(nil), s.queues...), nil
+}
+
+// ListComputeEnvironments returns the sample compute environments
+func (s *BatchService) ListComputeEnvironments(ctx context.Context) ([]clients.BatchComputeEnvironment, error) {
+	return append([]clients.BatchComputeEnvironment(nil), s.environments...), nil
+}
+
+// ListJobs returns the jobs of the queue, given by name or ARN, in status,
+// newest first
+func (s *BatchService) ListJobs(ctx context.Context, queue, status string) ([]clients.BatchJob, error) {
+	arn := ""
+	for _, q := range s.queues {
+		if q.Name == queue || q.ARN == queue {
+			arn = q.ARN
+		}
+	}
+	if arn == "" {
+		return nil, apiError("ClientException", fmt.Sprintf("Job queue %s does not exist", queue))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var jobs []clients.BatchJob
+	for _, job := range s.jobs {
+		if job.Queue == arn && job.Status == status {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	return jobs, nil
+}
+
+// TerminateJob fails the job with reason; finished jobs are left alone
+func (s *BatchService) TerminateJob(ctx context.Context, id, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.jobs {
+		job := &s.jobs[i]
+		if job.ID != id {
+			continue
+		}
+		if job.Status == "SUCCEEDED" || job.Status == "FAILED" {
+			return nil
+		}
+		job.Status = "FAILED"
+		job.StatusReason = reason
+		if job.StartedAt.IsZero() {
+			return nil
+		}
+		code := 143
+		job.ExitCode = &code
+		job.StoppedAt = time.Now()
+		return nil
+	}
+	return apiError("ClientException", fmt.Sprintf("Job %s does not exist", id))
+}
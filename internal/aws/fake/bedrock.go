@@ -0,0 +1,87 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// noModelAccess are the sample models the demo account was not granted
+// access to
+var noModelAccess = map[string]bool{
+	"meta.llama3-70b-instruct-v1:0": true,
+}
+
+// BedrockService offers the foundation models of the demo region: chat
+// models of several providers, one the account has no access to, one only
+// served through inference profiles and models that embed or draw rather
+// than answer. Prompts are answered with a canned reply echoing them.
+type BedrockService struct {
+	models []clients.BedrockModel
+}
+
+// NewBedrockService returns the sample foundation models
+func NewBedrockService() *BedrockService {
+	text := []string{"TEXT"}
+	model := func(id, name, provider string, input, output []string, lifecycle string, inference ...string) clients.BedrockModel {
+		return clients.BedrockModel{
+			ID:               id,
+			Name:             name,
+			Provider:         provider,
+			InputModalities:  input,
+			OutputModalities: output,
+			Streaming:        output[0] == "TEXT",
+			InferenceTypes:   inference,
+			Lifecycle:        lifecycle,
+		}
+	}
+	return &BedrockService{models: []clients.BedrockModel{
+		model("amazon.nova-pro-v1:0", "Nova Pro", "Amazon", []string{"TEXT", "IMAGE", "VIDEO"}, text, "ACTIVE", "INFERENCE_PROFILE"),
+		model("amazon.titan-embed-text-v2:0", "Titan Text Embeddings V2", "Amazon", text, []string{"EMBEDDING"}, "ACTIVE", "ON_DEMAND"),
+		model("amazon.titan-text-express-v1", "Titan Text G1 - Express", "Amazon", text, text, "LEGACY", "ON_DEMAND"),
+		model("anthropic.claude-3-haiku-20240307-v1:0", "Claude 3 Haiku", "Anthropic", []string{"TEXT", "IMAGE"}, text, "ACTIVE", "ON_DEMAND", "PROVISIONED"),
+		model("anthropic.claude-3-5-sonnet-20240620-v1:0", "Claude 3.5 Sonnet", "Anthropic", []string{"TEXT", "IMAGE"}, text, "ACTIVE", "ON_DEMAND"),
+		model("meta.llama3-70b-instruct-v1:0", "Llama 3 70B Instruct", "Meta", text, text, "ACTIVE", "ON_DEMAND"),
+		model("meta.llama3-8b-instruct-v1:0", "Llama 3 8B Instruct", "Meta", text, text, "ACTIVE", "ON_DEMAND"),
+		model("stability.stable-image-core-v1:0", "Stable Image Core", "Stability AI", text, []string{"IMAGE"}, "ACTIVE", "ON_DEMAND"),
+	}}
+}
+
+// ListFoundationModels returns the sample models, sorted by provider and name
+func (s *BedrockService) ListFoundationModels(ctx context.Context) ([]clients.BedrockModel, error) {
+	return append([]clients.BedrockModel(nil), s.models...), nil
+}
+
+// Converse answers prompt with a canned reply of the model modelID, cut at
+// maxTokens. Tokens are counted as four for every three words.
+func (s *BedrockService) Converse(ctx context.Context, modelID, prompt string, maxTokens int) (clients.BedrockReply, error) {
+	var model *clients.BedrockModel
+	for i := range s.models {
+		if s.models[i].ID == modelID {
+			model = &s.models[i]
+		}
+	}
+	switch {
+	case model == nil || !model.Chat():
+		return clients.BedrockReply{}, apiError("ValidationException", "The provided model identifier is invalid.")
+	case noModelAccess[modelID]:
+		return clients.BedrockReply{}, apiError("AccessDeniedException", "You don't have access to the model with the specified model ID.")
+	case !model.OnDemand():
+		return clients.BedrockReply{}, apiError("ValidationException", fmt.Sprintf("Invocation of model ID %s with on-demand throughput isn't supported. Retry your request with the ID or ARN of an inference profile that contains this model.", modelID))
+	}
+
+	tokens := func(words int) int { return (words*4 + 2) / 3 }
+	words := strings.Fields(fmt.Sprintf("This is %s answering from the demo account, which has no model behind it, so here is your prompt back: %s", model.Name, prompt))
+	reply := clients.BedrockReply{StopReason: "end_turn", InputTokens: tokens(len(strings.Fields(prompt)))}
+	if maxTokens > 0 && tokens(len(words)) > maxTokens {
+		words = words[:maxTokens*3/4]
+		reply.StopReason = "max_tokens"
+	}
+	reply.Text = strings.Join(words, " ")
+	reply.OutputTokens = tokens(len(words))
+	reply.Latency = 200*time.Millisecond + time.Duration(reply.OutputTokens)*15*time.Millisecond
+	return reply, nil
+}
@@ -0,0 +1,326 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// CloudFormationService holds the stacks of the demo account: a network
+// stack in sync, a web stack whose security group was opened up by hand and
+// whose drift was never checked, an order pipeline found drifted days ago, a
+// reports stack whose drift cannot be detected, a platform stack with two
+// levels of nested stacks and the stack of a StackSet. Drift detections
+// complete at once. The StackSets deploy a baseline to the accounts of the
+// organization, one of which failed, and guardrails to two regions of the
+// demo account.
+type CloudFormationService struct {
+	mu     sync.Mutex
+	stacks []clients.Stack
+	// drifts holds the resources of each stack as drift detection finds them
+	drifts map[string][]clients.ResourceDrift
+	// detections holds the stack of each drift detection by ID
+	detections map[string]string
+
+	stackSets []clients.StackSet
+	instances map[string][]clients.StackInstance
+}
+
+// failingDriftStack is a stack whose drift detection fails
+const failingDriftStack = "legacy-reports"
+
+// NewCloudFormationService returns the sample stacks
+func NewCloudFormationService() *CloudFormationService {
+	now := time.Now()
+	day := 24 * time.Hour
+	stack := func(name, status, description string, created, updated time.Duration, drift string, checked time.Duration) clients.Stack {
+		s := clients.Stack{
+			Name:        name,
+			ID:          stackID(Region, name),
+			Status:      status,
+			Description: description,
+			CreatedAt:   now.Add(-created),
+			DriftStatus: drift,
+		}
+		if updated > 0 {
+			s.UpdatedAt = now.Add(-updated)
+		}
+		if checked > 0 {
+			s.DriftCheckedAt = now.Add(-checked)
+		}
+		return s
+	}
+	resource := func(logicalID, physicalID, resourceType, status string, differences ...clients.PropertyDifference) clients.ResourceDrift {
+		return clients.ResourceDrift{LogicalID: logicalID, PhysicalID: physicalID, Type: resourceType, Status: status, Differences: differences}
+	}
+
+	nested := func(parent, root clients.Stack, logicalID, suffix string) clients.Stack {
+		s := stack(parent.Name+"-"+logicalID+"-"+suffix, "UPDATE_COMPLETE", "", 250*day, 9*day, "IN_SYNC", day)
+		s.ParentID, s.RootID = parent.ID, root.ID
+		return s
+	}
+	set := func(name, description, model, drift string, checked time.Duration) clients.StackSet {
+		s := clients.StackSet{
+			Name:            name,
+			ID:              fmt.Sprintf("%s:%08x-6c3d-11ef-9e5a-0a1b2c3d4e5f", name, len(name)*7919),
+			Description:     description,
+			Status:          "ACTIVE",
+			PermissionModel: model,
+			DriftStatus:     drift,
+		}
+		if checked > 0 {
+			s.DriftCheckedAt = now.Add(-checked)
+		}
+		return s
+	}
+	instance := func(account, region, ou, status, detailed, drift string) clients.StackInstance {
+		return clients.StackInstance{
+			Account:            account,
+			Region:             region,
+			StackID:            fmt.Sprintf("arn:aws:cloudformation:%s:%s:stack/StackSet-org-baseline-%s/%08x-7d4e-11ef-af6b-0a1b2c3d4e5f", region, account, account[:4], len(region)*31),
+			Status:             status,
+			DetailedStatus:     detailed,
+			OrganizationalUnit: ou,
+			DriftStatus:        drift,
+		}
+	}
+
+	reports := stack(failingDriftStack, "UPDATE_ROLLBACK_COMPLETE", "Monthly reports, replaced by Athena", 900*day, 300*day, "NOT_CHECKED", 0)
+	reports.Reason = "Resource ReportsBucket failed to update: bucket policy denied s3:PutBucketPolicy"
+	platform := stack("shop-platform", "UPDATE_COMPLETE", "Shared platform of the shop, split into nested stacks", 250*day, 9*day, "IN_SYNC", day)
+	web := nested(platform, platform, "Web", "1QX2Z3ABCDEF")
+	cache := nested(web, platform, "Cache", "9KJ8H7GFEDCB")
+	guardrails := stack(guardrailsStack, "CREATE_COMPLETE", "Config rules of the shop guardrails", 120*day, 0, "IN_SYNC", 2*day)
+
+	failed := instance("333333333333", "eu-west-1", "ou-ab12-dev56789", "OUTDATED", "FAILED", "NOT_CHECKED")
+	failed.Reason = "ResourceLogicalId:CloudTrailBucket, ResourceType:AWS::S3::Bucket, ResourceStatusReason:org-trail-333333333333 already exists."
+	drifted := instance("222222222222", "us-east-1", "ou-ab12-prod1234", "CURRENT", "SUCCEEDED", "DRIFTED")
+
+	return &CloudFormationService{
+		stacks: []clients.Stack{
+			stack("shop-network", "CREATE_COMPLETE", "VPC, subnets and NAT gateways of the shop", 700*day, 0, "IN_SYNC", 5*day),
+			stack("shop-web", "UPDATE_COMPLETE", "Web servers behind the load balancer", 400*day, 20*day, "NOT_CHECKED", 0),
+			stack("orders-pipeline", "UPDATE_COMPLETE", "Order queue, functions and table", 300*day, 6*day, "DRIFTED", 3*day),
+			reports,
+			platform,
+			web,
+			cache,
+			guardrails,
+		},
+		drifts: map[string][]clients.ResourceDrift{
+			"shop-network": {
+				resource("Vpc", "vpc-0a1b2c3d4e5f60718", "AWS::EC2::VPC", "IN_SYNC"),
+				resource("PublicSubnetA", "subnet-0a1b2c3d4e5f60001", "AWS::EC2::Subnet", "IN_SYNC"),
+				resource("NatGatewayA", "nat-0a1b2c3d4e5f60001", "AWS::EC2::NatGateway", "IN_SYNC"),
+			},
+			"shop-web": {
+				resource("WebSecurityGroup", "sg-0a1b2c3d4e5f60718", "AWS::EC2::SecurityGroup", "MODIFIED",
+					clients.PropertyDifference{Path: "/SecurityGroupIngress/0/CidrIp", Expected: "10.0.0.0/16", Actual: "0.0.0.0/0", Type: "NOT_EQUAL"},
+					clients.PropertyDifference{Path: "/SecurityGroupIngress/1", Actual: `{"CidrIp":"0.0.0.0/0","FromPort":22,"IpProtocol":"tcp","ToPort":22}`, Type: "ADD"}),
+				resource("WebAutoScalingGroup", "shop-web-asg", "AWS::AutoScaling::AutoScalingGroup", "MODIFIED",
+					clients.PropertyDifference{Path: "/MaxSize", Expected: "6", Actual: "12", Type: "NOT_EQUAL"},
+					clients.PropertyDifference{Path: "/Tags/2", Expected: `{"Key":"CostCenter","PropagateAtLaunch":true,"Value":"shop"}`, Type: "REMOVE"}),
+				resource("WebConfigParameter", "/shop/web/config", "AWS::SSM::Parameter", "DELETED"),
+				resource("WebLoadBalancer", "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/shop-web/50dc6c495c0c9188", "AWS::ElasticLoadBalancingV2::LoadBalancer", "IN_SYNC"),
+				resource("WebListener", "arn:aws:elasticloadbalancing:us-east-1:123456789012:listener/app/shop-web/50dc6c495c0c9188/f2f7dc8efc522ab2", "AWS::ElasticLoadBalancingV2::Listener", "NOT_CHECKED"),
+			},
+			"orders-pipeline": {
+				resource("OrdersFunction", "orders-api", "AWS::Lambda::Function", "MODIFIED",
+					clients.PropertyDifference{Path: "/MemorySize", Expected: "512", Actual: "1024", Type: "NOT_EQUAL"},
+					clients.PropertyDifference{Path: "/Timeout", Expected: "10", Actual: "30", Type: "NOT_EQUAL"}),
+				resource("OrdersQueue", "https://sqs.us-east-1.amazonaws.com/123456789012/orders", "AWS::SQS::Queue", "IN_SYNC"),
+				resource("OrdersTable", "orders", "AWS::DynamoDB::Table", "IN_SYNC"),
+			},
+			platform.Name: {
+				resource("WebStack", web.ID, "AWS::CloudFormation::Stack", "IN_SYNC"),
+				resource("AlertsTopic", "arn:aws:sns:us-east-1:123456789012:shop-platform-alerts", "AWS::SNS::Topic", "IN_SYNC"),
+				resource("LogsBucket", "shop-platform-logs-123456789012", "AWS::S3::Bucket", "IN_SYNC"),
+			},
+			web.Name: {
+				resource("CacheStack", cache.ID, "AWS::CloudFormation::Stack", "IN_SYNC"),
+				resource("AssetsBucket", "shop-platform-assets-123456789012", "AWS::S3::Bucket", "IN_SYNC"),
+				resource("WebRole", "shop-platform-web-role", "AWS::IAM::Role", "IN_SYNC"),
+			},
+			cache.Name: {
+				resource("CacheCluster", "shop-sessions", "AWS::ElastiCache::ReplicationGroup", "IN_SYNC"),
+				resource("CacheSubnetGroup", "shop-sessions-subnets", "AWS::ElastiCache::SubnetGroup", "IN_SYNC"),
+			},
+			guardrails.Name: {
+				resource("RequiredTagsRule", "shop-required-tags", "AWS::Config::ConfigRule", "IN_SYNC"),
+				resource("EncryptedVolumesRule", "shop-encrypted-volumes", "AWS::Config::ConfigRule", "IN_SYNC"),
+			},
+		},
+		detections: make(map[string]string),
+		stackSets: []clients.StackSet{
+			set("org-baseline", "CloudTrail, Config and the security roles of every account", "SERVICE_MANAGED", "DRIFTED", 4*day),
+			set("shop-guardrails", "Config rules of the shop guardrails", "SELF_MANAGED", "IN_SYNC", 2*day),
+		},
+		instances: map[string][]clients.StackInstance{
+			"org-baseline": {
+				instance("111111111111", "us-east-1", "ou-ab12-prod1234", "CURRENT", "SUCCEEDED", "IN_SYNC"),
+				instance("111111111111", "eu-west-1", "ou-ab12-prod1234", "CURRENT", "SUCCEEDED", "IN_SYNC"),
+				drifted,
+				instance("222222222222", "eu-west-1", "ou-ab12-prod1234", "CURRENT", "SUCCEEDED", "IN_SYNC"),
+				instance("333333333333", "us-east-1", "ou-ab12-dev56789", "CURRENT", "SUCCEEDED", "IN_SYNC"),
+				failed,
+			},
+			"shop-guardrails": {
+				{Account: Account, Region: Region, StackID: guardrails.ID, Status: "CURRENT", DetailedStatus: "SUCCEEDED", DriftStatus: "IN_SYNC"},
+				{Account: Account, Region: "eu-west-1", StackID: stackID("eu-west-1", guardrailsStack), Status: "CURRENT", DetailedStatus: "SUCCEEDED", DriftStatus: "IN_SYNC"},
+			},
+		},
+	}
+}
+
+// guardrailsStack is the stack the shop-guardrails StackSet deployed to the
+// demo account
+const guardrailsStack = "StackSet-shop-guardrails-5e6f7a8b"
+
+// stackID returns the ID of the stack named name in region of the demo
+// account
+func stackID(region, name string) string {
+	return fmt.Sprintf("arn:aws:cloudformation:%s:%s:stack/%s/%08x-4a1b-11ef-9c3e-0a1b2c3d4e5f", region, Account, name, len(name)*104729)
+}
+
+// ListStacks returns the sample stacks
+func (s *CloudFormationService) ListStacks(ctx context.Context) ([]clients.Stack, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]clients.Stack(nil), s.stacks...), nil
+}
+
+// ListStackResources returns the resources of stack with the drift its last
+// detection found
+func (s *CloudFormationService) ListStackResources(ctx context.Context, stack string) ([]clients.StackResource, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.stack(stack)
+	if i < 0 {
+		return nil, stackNotFound(stack)
+	}
+	checked := !s.stacks[i].DriftCheckedAt.IsZero()
+	updated := s.stacks[i].UpdatedAt
+	if updated.IsZero() {
+		updated = s.stacks[i].CreatedAt
+	}
+
+	var resources []clients.StackResource
+	for _, drift := range s.drifts[s.stacks[i].Name] {
+		resource := clients.StackResource{
+			LogicalID:   drift.LogicalID,
+			PhysicalID:  drift.PhysicalID,
+			Type:        drift.Type,
+			Status:      "UPDATE_COMPLETE",
+			DriftStatus: "NOT_CHECKED",
+			UpdatedAt:   updated,
+		}
+		if checked {
+			resource.DriftStatus = drift.Status
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// ListStackSets returns the sample StackSets
+func (s *CloudFormationService) ListStackSets(ctx context.Context) ([]clients.StackSet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]clients.StackSet(nil), s.stackSets...), nil
+}
+
+// ListStackInstances returns the instances of stackSet
+func (s *CloudFormationService) ListStackInstances(ctx context.Context, stackSet string) ([]clients.StackInstance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instances, ok := s.instances[stackSet]
+	if !ok {
+		return nil, apiError("StackSetNotFoundException", fmt.Sprintf("StackSet %s not found", stackSet))
+	}
+	return append([]clients.StackInstance(nil), instances...), nil
+}
+
+// DetectDrift starts a drift detection of stack
+func (s *CloudFormationService) DetectDrift(ctx context.Context, stack string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.stack(stack)
+	if i < 0 {
+		return "", stackNotFound(stack)
+	}
+	id := fmt.Sprintf("%08x-5b2c-11ef-8d4f-%012x", len(s.detections)+1, len(stack))
+	s.detections[id] = s.stacks[i].Name
+	return id, nil
+}
+
+// WaitForDriftDetection completes the drift detection id and records its
+// outcome on the stack
+func (s *CloudFormationService) WaitForDriftDetection(ctx context.Context, id string) (clients.DriftDetection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name, ok := s.detections[id]
+	if !ok {
+		return clients.DriftDetection{}, apiError("ValidationError", fmt.Sprintf("Drift detection %s does not exist", id))
+	}
+	i := s.stack(name)
+	detection := clients.DriftDetection{ID: id, Status: "DETECTION_COMPLETE", StackDriftStatus: "IN_SYNC", Timestamp: time.Now()}
+	if name == failingDriftStack {
+		detection.Status = "DETECTION_FAILED"
+		detection.StackDriftStatus = "UNKNOWN"
+		detection.Reason = "Failed to detect drift on resource [ReportsBucket]: access denied to s3:GetBucketPolicy"
+	}
+	for _, drift := range s.drifts[name] {
+		if drift.Status == "MODIFIED" || drift.Status == "DELETED" {
+			detection.DriftedResources++
+			detection.StackDriftStatus = "DRIFTED"
+		}
+	}
+	s.stacks[i].DriftStatus = detection.StackDriftStatus
+	s.stacks[i].DriftCheckedAt = detection.Timestamp
+	return detection, nil
+}
+
+// ListResourceDrifts returns the resources of stack as its last drift
+// detection found them, none if its drift was never detected
+func (s *CloudFormationService) ListResourceDrifts(ctx context.Context, stack string) ([]clients.ResourceDrift, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.stack(stack)
+	if i < 0 {
+		return nil, stackNotFound(stack)
+	}
+	if s.stacks[i].DriftCheckedAt.IsZero() {
+		return nil, nil
+	}
+	drifts := make([]clients.ResourceDrift, len(s.drifts[s.stacks[i].Name]))
+	for j, drift := range s.drifts[s.stacks[i].Name] {
+		drift.CheckedAt = s.stacks[i].DriftCheckedAt
+		drifts[j] = drift
+	}
+	clients.SortResourceDrifts(drifts)
+	return drifts, nil
+}
+
+// stack returns the index of the stack with the name or ID name, -1 if
+// there is none
+func (s *CloudFormationService) stack(name string) int {
+	for i, stack := range s.stacks {
+		if stack.Name == name || stack.ID == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// stackNotFound is the error of CloudFormation for an unknown stack
+func stackNotFound(name string) error {
+	return apiError("ValidationError", fmt.Sprintf("Stack with id %s does not exist", name))
+}
@@ -0,0 +1,261 @@
+package fake
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// dashboardBodies are the definitions of the sample dashboards by name
+var dashboardBodies = map[string]string{
+	"orders-service": `{
+  "widgets": [
+    {"type": "text", "x": 0, "y": 0, "width": 24, "height": 2,
+     "properties": {"markdown": "# Orders service\nProduction health at a glance. Runbook: wiki/orders"}},
+    {"type": "metric", "x": 0, "y": 2, "width": 12, "height": 6,
+     "properties": {"title": "Instance CPU", "view": "timeSeries", "stat": "Average", "period": 300, "region": "us-east-1",
+       "metrics": [
+         ["AWS/EC2", "CPUUtilization", "InstanceId", "i-0a12b34c56d78e901", {"label": "web-1"}],
+         ["...", "i-0b23c45d67e89f012", {"label": "web-2"}],
+         ["...", "i-0c34d56e78f90a123", {"label": "worker-1"}]
+       ]}},
+    {"type": "metric", "x": 12, "y": 2, "width": 6, "height": 6,
+     "properties": {"title": "DB connections", "view": "singleValue", "stat": "Maximum", "period": 60, "region": "us-east-1",
+       "metrics": [["AWS/RDS", "DatabaseConnections", "DBInstanceIdentifier", "orders-prod"]]}},
+    {"type": "alarm", "x": 18, "y": 2, "width": 6, "height": 6,
+     "properties": {"title": "Alarms", "alarms": [
+       "arn:aws:cloudwatch:us-east-1:123456789012:alarm:orders-api-5xx",
+       "arn:aws:cloudwatch:us-east-1:123456789012:alarm:orders-prod-cpu-high"
+     ]}},
+    {"type": "log", "x": 0, "y": 8, "width": 24, "height": 6,
+     "properties": {"title": "Recent errors", "region": "us-east-1",
+       "query": "SOURCE '/aws/lambda/orders-api' | fields @timestamp, @message | filter @message like /ERROR/"}}
+  ]
+}`,
+	"lambda-overview": `{
+  "widgets": [
+    {"type": "metric", "x": 0, "y": 0, "width": 12, "height": 6,
+     "properties": {"title": "Invocations", "view": "timeSeries", "stat": "Sum", "period": 300, "region": "us-east-1",
+       "metrics": [
+         ["AWS/Lambda", "Invocations", "FunctionName", "orders-api"],
+         [".", ".", ".", "image-resizer"]
+       ]}},
+    {"type": "metric", "x": 12, "y": 0, "width": 12, "height": 6,
+     "properties": {"title": "Errors", "view": "timeSeries", "stat": "Sum", "period": 300, "region": "us-east-1",
+       "metrics": [["AWS/Lambda", "Errors", "FunctionName", "orders-api"]]}}
+  ]
+}`,
+}
+
+// CloudWatchService reports a fixed set of alarms and dashboards and makes up
+// metric data
+type CloudWatchService struct {
+	alarms     []clients.AlarmDetail
+	history    map[string][]clients.AlarmTransition
+	dashboards []clients.DashboardDetail
+}
+
+// alarmARN returns the ARN of the sample alarm name
+func alarmARN(name string) string {
+	return "arn:aws:cloudwatch:" + Region + ":" + Account + ":alarm:" + name
+}
+
+// NewCloudWatchService returns alarms with one of them firing after flapping
+// overnight, a composite alarm over the others and two dashboards
+func NewCloudWatchService() *CloudWatchService {
+	now := time.Now().Truncate(time.Minute)
+	metric := string(cwtypes.AlarmTypeMetricAlarm)
+	ok, alarm, unknown := string(cwtypes.StateValueOk), string(cwtypes.StateValueAlarm), string(cwtypes.StateValueInsufficientData)
+	breach := "Threshold Crossed: 1 datapoint [12.0] was greater than the threshold (5.0)."
+	recover := "Threshold Crossed: 1 datapoint [1.0] was not greater than the threshold (5.0)."
+
+	return &CloudWatchService{
+		alarms: []clients.AlarmDetail{
+			{
+				Name:           "orders-api-5xx",
+				ARN:            alarmARN("orders-api-5xx"),
+				Type:           metric,
+				State:          alarm,
+				StateReason:    breach,
+				UpdatedAt:      now.Add(-7 * time.Minute),
+				Description:    "5xx responses of the orders API",
+				Condition:      "AWS/ApiGateway 5XXError Sum > 5 for 1 of 1 datapoints of 5 min",
+				ActionsEnabled: true,
+			},
+			{
+				Name:           "orders-prod-cpu-high",
+				ARN:            alarmARN("orders-prod-cpu-high"),
+				Type:           metric,
+				State:          ok,
+				StateReason:    "Threshold Crossed: 3 datapoints were not greater than the threshold (80.0).",
+				UpdatedAt:      now.Add(-3 * time.Hour),
+				Condition:      "AWS/RDS CPUUtilization Average > 80 for 3 of 3 datapoints of 5 min",
+				ActionsEnabled: true,
+			},
+			{
+				Name:        "orders-worker-throttles",
+				ARN:         alarmARN("orders-worker-throttles"),
+				Type:        metric,
+				State:       unknown,
+				StateReason: "Insufficient Data: 1 datapoint was unknown.",
+				UpdatedAt:   now.Add(-26 * time.Hour),
+				Condition:   "AWS/Lambda Throttles Sum > 0 for 2 of 3 datapoints of 1 min",
+			},
+			{
+				Name:           "orders-service-health",
+				ARN:            alarmARN("orders-service-health"),
+				Type:           string(cwtypes.AlarmTypeCompositeAlarm),
+				State:          alarm,
+				StateReason:    alarmARN("orders-api-5xx") + " transitioned to ALARM",
+				UpdatedAt:      now.Add(-6 * time.Minute),
+				Description:    "Pages the orders on-call",
+				Rule:           `ALARM("orders-api-5xx") OR (ALARM(orders-prod-cpu-high) AND NOT OK(orders-worker-throttles))`,
+				ActionsEnabled: true,
+			},
+		},
+		history: map[string][]clients.AlarmTransition{
+			"orders-api-5xx": {
+				{At: now.Add(-9 * time.Hour), From: ok, To: alarm, Reason: breach},
+				{At: now.Add(-8*time.Hour - 40*time.Minute), From: alarm, To: ok, Reason: recover},
+				{At: now.Add(-7*time.Hour - 15*time.Minute), From: ok, To: alarm, Reason: breach},
+				{At: now.Add(-7 * time.Hour), From: alarm, To: ok, Reason: recover},
+				{At: now.Add(-5*time.Hour - 30*time.Minute), From: ok, To: alarm, Reason: breach},
+				{At: now.Add(-5 * time.Hour), From: alarm, To: ok, Reason: recover},
+				{At: now.Add(-7 * time.Minute), From: ok, To: alarm, Reason: breach},
+			},
+			"orders-prod-cpu-high": {
+				{At: now.Add(-4 * time.Hour), From: ok, To: alarm, Reason: "Threshold Crossed: 3 datapoints [91.2, 88.0, 86.4] were greater than the threshold (80.0)."},
+				{At: now.Add(-3 * time.Hour), From: alarm, To: ok, Reason: "Threshold Crossed: 3 datapoints were not greater than the threshold (80.0)."},
+			},
+			"orders-worker-throttles": {
+				{At: now.Add(-26 * time.Hour), From: ok, To: unknown, Reason: "Insufficient Data: 1 datapoint was unknown."},
+			},
+			"orders-service-health": {
+				{At: now.Add(-9*time.Hour + time.Minute), From: ok, To: alarm, Reason: alarmARN("orders-api-5xx") + " transitioned to ALARM"},
+				{At: now.Add(-8*time.Hour - 39*time.Minute), From: alarm, To: ok, Reason: alarmARN("orders-api-5xx") + " transitioned to OK"},
+				{At: now.Add(-7*time.Hour - 14*time.Minute), From: ok, To: alarm, Reason: alarmARN("orders-api-5xx") + " transitioned to ALARM"},
+				{At: now.Add(-7*time.Hour + time.Minute), From: alarm, To: ok, Reason: alarmARN("orders-api-5xx") + " transitioned to OK"},
+				{At: now.Add(-5*time.Hour - 29*time.Minute), From: ok, To: alarm, Reason: alarmARN("orders-api-5xx") + " transitioned to ALARM"},
+				{At: now.Add(-5*time.Hour + time.Minute), From: alarm, To: ok, Reason: alarmARN("orders-api-5xx") + " transitioned to OK"},
+				{At: now.Add(-6 * time.Minute), From: ok, To: alarm, Reason: alarmARN("orders-api-5xx") + " transitioned to ALARM"},
+			},
+		},
+		dashboards: []clients.DashboardDetail{
+			{
+				Name:         "lambda-overview",
+				ARN:          "arn:aws:cloudwatch::" + Account + ":dashboard/lambda-overview",
+				Size:         int64(len(dashboardBodies["lambda-overview"])),
+				LastModified: now.AddDate(0, -2, 0),
+			},
+			{
+				Name:         "orders-service",
+				ARN:          "arn:aws:cloudwatch::" + Account + ":dashboard/orders-service",
+				Size:         int64(len(dashboardBodies["orders-service"])),
+				LastModified: now.AddDate(0, 0, -9),
+			},
+		},
+	}
+}
+
+// DescribeAlarms returns all alarms
+func (s *CloudWatchService) DescribeAlarms(ctx context.Context) ([]clients.AlarmDetail, error) {
+	return append([]clients.AlarmDetail(nil), s.alarms...), nil
+}
+
+// AlarmHistory returns the state changes of a sample alarm between start and end
+func (s *CloudWatchService) AlarmHistory(ctx context.Context, name string, start, end time.Time) ([]clients.AlarmTransition, error) {
+	var transitions []clients.AlarmTransition
+	for _, transition := range s.history[name] {
+		if !transition.At.Before(start) && transition.At.Before(end) {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions, nil
+}
+
+// natGatewayTraffic is how many bytes the sample NAT gateways move per hour
+// by metric. The data pipeline pulls its input from S3 through its gateway.
+var natGatewayTraffic = map[string]map[string]float64{
+	"nat-0a1b2c3d4e5f60711": {"BytesOutToDestination": 1.2e9, "BytesInFromSource": 1.2e9, "BytesInFromDestination": 0.6e9},
+	"nat-0a1b2c3d4e5f60712": {"BytesOutToDestination": 0.9e9, "BytesInFromSource": 0.9e9, "BytesInFromDestination": 0.5e9},
+	"nat-0a1b2c3d4e5f60713": {"BytesOutToDestination": 0.4e9, "BytesInFromSource": 0.4e9, "BytesInFromDestination": 38e9},
+}
+
+// MetricSum reports a steady stream of invocations for every Lambda function
+// but idleFunction and steady traffic through the NAT gateways. Other
+// metrics are empty.
+func (s *CloudWatchService) MetricSum(ctx context.Context, namespace, metric, dimension, value string, start, end time.Time) (float64, error) {
+	switch {
+	case namespace == "AWS/NATGateway":
+		return end.Sub(start).Hours() * natGatewayTraffic[value][metric], nil
+	case namespace != "AWS/Lambda" || metric != "Invocations" || value == idleFunction:
+		return 0, nil
+	}
+	return end.Sub(start).Hours() * 120, nil
+}
+
+// ListDashboards returns all dashboards
+func (s *CloudWatchService) ListDashboards(ctx context.Context) ([]clients.DashboardDetail, error) {
+	return append([]clients.DashboardDetail(nil), s.dashboards...), nil
+}
+
+// GetDashboardBody returns the definition of a sample dashboard
+func (s *CloudWatchService) GetDashboardBody(ctx context.Context, name string) (string, error) {
+	body, ok := dashboardBodies[name]
+	if !ok {
+		return "", apiError("ResourceNotFound", "Dashboard "+name+" does not exist")
+	}
+	return body, nil
+}
+
+// GetMetricSeries makes up a wave per query, with a level and phase derived
+// from the metric, so the same metric always looks the same. Metrics of
+// idleFunction and idleEndpoints stay at zero.
+func (s *CloudWatchService) GetMetricSeries(ctx context.Context, queries []clients.MetricQuery, start, end time.Time) ([]clients.MetricSeries, error) {
+	series := make([]clients.MetricSeries, len(queries))
+	for i, query := range queries {
+		if table, ok := tableMetricSeries(query, start, end); ok {
+			series[i] = table
+			continue
+		}
+		if queue, ok := queueMetricSeries(query, start, end); ok {
+			series[i] = queue
+			continue
+		}
+
+		h := fnv.New32a()
+		h.Write([]byte(query.Namespace + query.Metric))
+		for _, dimension := range query.Dimensions {
+			h.Write([]byte(dimension.Name + dimension.Value))
+		}
+		seed := h.Sum32()
+		level := float64(10 + seed%60)
+		for _, dimension := range query.Dimensions {
+			if dimension.Value == idleFunction || idleEndpoints[dimension.Value] {
+				level = 0
+			}
+		}
+		phase := float64(seed%628) / 100
+
+		period := time.Duration(max(query.Period, 60)) * time.Second
+		if rate, ok := sesReputation[query.Metric]; ok && query.Namespace == "AWS/SES" {
+			// Rates are fractions far below the rounding of the other metrics
+			for t := start.Truncate(period); t.Before(end); t = t.Add(period) {
+				series[i].Timestamps = append(series[i].Timestamps, t)
+				series[i].Values = append(series[i].Values, rate)
+			}
+			continue
+		}
+		for t := start.Truncate(period); t.Before(end); t = t.Add(period) {
+			wave := math.Sin(phase + float64(t.Unix())/3600)
+			series[i].Timestamps = append(series[i].Timestamps, t)
+			series[i].Values = append(series[i].Values, math.Round(level*(1+0.4*wave)*10)/10)
+		}
+	}
+	return series, nil
+}
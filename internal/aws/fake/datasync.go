@@ -0,0 +1,116 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// DataSyncService holds the DataSync tasks of the demo account: a nightly
+// archive of the order exports that has run for a week, a media import
+// copying right now, an EFS backup whose last run failed and a warehouse
+// sync whose agent is offline
+type DataSyncService struct {
+	tasks []clients.DataSyncTask
+	// executions holds the executions of each task by ARN, newest first
+	executions map[string][]clients.DataSyncExecution
+}
+
+// NewDataSyncService returns the sample tasks
+func NewDataSyncService() *DataSyncService {
+	now := time.Now()
+	day := 24 * time.Hour
+	taskARN := func(id string) string {
+		return fmt.Sprintf("arn:aws:datasync:%s:%s:task/%s", Region, Account, id)
+	}
+	execution := func(task string, n int, status string, started time.Duration, took time.Duration, bytes, files int64) clients.DataSyncExecution {
+		return clients.DataSyncExecution{
+			ARN:              fmt.Sprintf("%s/execution/exec-%017x", taskARN(task), n),
+			Status:           status,
+			StartedAt:        now.Add(-started),
+			Duration:         took + 2*time.Minute,
+			TransferDuration: took,
+			BytesTransferred: bytes,
+			BytesWritten:     bytes,
+			FilesTransferred: files,
+			EstimatedBytes:   bytes,
+			EstimatedFiles:   files,
+		}
+	}
+	gb := int64(1 << 30)
+
+	archive, media, backup, warehouse := "task-0a1b2c3d4e5f60011", "task-0a1b2c3d4e5f60022", "task-0a1b2c3d4e5f60033", "task-0a1b2c3d4e5f60044"
+	executions := map[string][]clients.DataSyncExecution{}
+	// A week of nightly archives at 2:00, newest first
+	for n := 0; n < 7; n++ {
+		took := time.Duration(9+n%3) * time.Minute
+		executions[archive] = append(executions[archive],
+			execution(archive, 7-n, "SUCCESS", time.Duration(n)*day+6*time.Hour, took, int64(48+n*3)*gb, int64(120000+n*4100)))
+	}
+
+	current := execution(media, 3, "TRANSFERRING", 25*time.Minute, 20*time.Minute, 84*gb, 61500)
+	current.Duration = 0
+	current.EstimatedBytes, current.EstimatedFiles = 200*gb, 146000
+	executions[media] = []clients.DataSyncExecution{
+		current,
+		execution(media, 2, "SUCCESS", 7*day, 52*time.Minute, 180*gb, 131000),
+		execution(media, 1, "SUCCESS", 14*day, 47*time.Minute, 165*gb, 120400),
+	}
+
+	failed := execution(backup, 2, "ERROR", 20*time.Hour, 3*time.Minute, 2*gb, 800)
+	failed.EstimatedBytes, failed.EstimatedFiles = 31*gb, 9400
+	failed.ErrorCode = "PermissionDenied"
+	failed.ErrorDetail = "Transfer and verification failed with an error: the destination bucket shop-backups denied s3:PutObject to the DataSync role"
+	executions[backup] = []clients.DataSyncExecution{
+		failed,
+		execution(backup, 1, "SUCCESS", 44*time.Hour, 14*time.Minute, 30*gb, 9100),
+	}
+	executions[warehouse] = nil
+
+	task := func(id, name, status, source, destination, schedule string, created time.Duration) clients.DataSyncTask {
+		return clients.DataSyncTask{
+			ARN:         taskARN(id),
+			Name:        name,
+			Status:      status,
+			Source:      source,
+			Destination: destination,
+			Schedule:    schedule,
+			CreatedAt:   now.Add(-created),
+		}
+	}
+	tasks := []clients.DataSyncTask{
+		task(backup, "efs-backup", "AVAILABLE", "efs://us-east-1.fs-0c1d2e3f/", "s3://shop-backups/efs/", "cron(0 6 * * ? *)", 90*day),
+		task(media, "media-import", "RUNNING", "smb://fileserver.corp.example.com/media/", "s3://shop-media/import/", "", 30*day),
+		task(archive, "nightly-archive", "AVAILABLE", "nfs://10.0.1.15/exports/orders/", "s3://shop-archive/orders/", "cron(0 2 * * ? *)", 200*day),
+		task(warehouse, "warehouse-sync", "UNAVAILABLE", "nfs://10.20.0.8/warehouse/", "efs://us-east-1.fs-0c1d2e3f/warehouse/", "", 400*day),
+	}
+	tasks[1].Current = &executions[media][0]
+	tasks[3].ErrorCode = "AgentOffline"
+	tasks[3].ErrorDetail = "The agent warehouse-agent is offline and cannot reach the source location"
+
+	byTask := make(map[string][]clients.DataSyncExecution, len(executions))
+	for id, list := range executions {
+		byTask[taskARN(id)] = list
+	}
+	return &DataSyncService{tasks: tasks, executions: byTask}
+}
+
+// ListTasks returns the sample tasks
+func (s *DataSyncService) ListTasks(ctx context.Context) ([]clients.DataSyncTask, error) {
+	return append([]clients.DataSyncTask(nil), s.tasks...), nil
+}
+
+// ListTaskExecutions returns the last limit executions of the sample task,
+// newest first
+func (s *DataSyncService) ListTaskExecutions(ctx context.Context, task string, limit int) ([]clients.DataSyncExecution, error) {
+	executions, ok := s.executions[task]
+	if !ok {
+		return nil, apiError("InvalidRequestException", fmt.Sprintf("Task %s not found", task))
+	}
+	if limit > 0 && len(executions) > limit {
+		executions = executions[:limit]
+	}
+	return append([]clients.DataSyncExecution(nil), executions...), nil
+}
@@ -0,0 +1,207 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// sampleTable is a DynamoDB table of the demo account with how busy it is
+type sampleTable struct {
+	detail clients.TableDetail
+	// usage is the consumed share of the provisioned capacity, or the
+	// consumed units per second of on-demand tables
+	usage float64
+}
+
+// sampleTables are a well sized table with auto scaling, an on-demand table
+// and an undersized table that throttles
+var sampleTables = func() []sampleTable {
+	created := time.Now().AddDate(-1, -2, 0).UTC().Truncate(time.Hour)
+	arn := func(name string) string {
+		return fmt.Sprintf("arn:aws:dynamodb:%s:%s:table/%s", Region, Account, name)
+	}
+	return []sampleTable{
+		{
+			detail: clients.TableDetail{
+				Name: "orders", ARN: arn("orders"), Status: "ACTIVE", BillingMode: "PROVISIONED",
+				ReadCapacity: 50, WriteCapacity: 25, ItemCount: 1_284_551, SizeBytes: 412_880_113,
+				PartitionKey: clients.TableKey{Name: "orderId", Type: "S"},
+				TableClass:   "STANDARD", StreamView: "NEW_AND_OLD_IMAGES",
+				GlobalIndexes: []clients.TableIndex{
+					{
+						Name: "by-customer", Status: "ACTIVE", ReadCapacity: 20, WriteCapacity: 25,
+						PartitionKey: clients.TableKey{Name: "customerId", Type: "S"}, SortKey: clients.TableKey{Name: "placedAt", Type: "N"},
+						Projection: "ALL", ItemCount: 1_284_551, SizeBytes: 398_102_554,
+					},
+				},
+				DeletionLocked: true,
+				CreatedAt:      created,
+			},
+			usage: 0.55,
+		},
+		{
+			detail: clients.TableDetail{
+				Name: "sessions", ARN: arn("sessions"), Status: "ACTIVE", BillingMode: "PAY_PER_REQUEST",
+				ItemCount: 88_412, SizeBytes: 21_554_020, MaxWriteRequests: 1000,
+				PartitionKey: clients.TableKey{Name: "userId", Type: "S"}, SortKey: clients.TableKey{Name: "sessionId", Type: "S"},
+				TableClass: "STANDARD",
+				LocalIndexes: []clients.TableIndex{
+					{
+						Name: "by-expiry", PartitionKey: clients.TableKey{Name: "userId", Type: "S"}, SortKey: clients.TableKey{Name: "expiresAt", Type: "N"},
+						Projection: "KEYS_ONLY", ItemCount: 88_412, SizeBytes: 4_210_330,
+					},
+				},
+				CreatedAt: created.AddDate(0, 3, 0),
+			},
+			usage: 35,
+		},
+		{
+			detail: clients.TableDetail{
+				Name: "inventory", ARN: arn("inventory"), Status: "ACTIVE", BillingMode: "PROVISIONED",
+				ReadCapacity: 5, WriteCapacity: 5, ItemCount: 15_032, SizeBytes: 3_110_245,
+				PartitionKey: clients.TableKey{Name: "sku", Type: "S"}, SortKey: clients.TableKey{Name: "warehouse", Type: "S"},
+				TableClass: "STANDARD_INFREQUENT_ACCESS", CreatedAt: created.AddDate(0, 5, 0),
+			},
+			usage: 1.1,
+		},
+	}
+}()
+
+// DynamoDBService lists the sample tables
+type DynamoDBService struct{}
+
+// NewDynamoDBService returns the sample tables
+func NewDynamoDBService() *DynamoDBService {
+	return &DynamoDBService{}
+}
+
+// ListTables returns the sample tables
+func (s *DynamoDBService) ListTables(ctx context.Context) ([]clients.TableDetail, error) {
+	tables := make([]clients.TableDetail, len(sampleTables))
+	for i, table := range sampleTables {
+		tables[i] = table.detail
+	}
+	return tables, nil
+}
+
+// tableMetricSeries returns the AWS/DynamoDB metric of query for a sample
+// table, false if query is not about one. Consumed capacity follows the usage
+// of the table and the capacity it exceeds is throttled.
+func tableMetricSeries(query clients.MetricQuery, start, end time.Time) (clients.MetricSeries, bool) {
+	if query.Namespace != "AWS/DynamoDB" {
+		return clients.MetricSeries{}, false
+	}
+
+	var table *sampleTable
+	for _, dimension := range query.Dimensions {
+		for i := range sampleTables {
+			if dimension.Name == "TableName" && dimension.Value == sampleTables[i].detail.Name {
+				table = &sampleTables[i]
+			}
+		}
+	}
+	if table == nil {
+		return clients.MetricSeries{}, false
+	}
+
+	capacity := float64(table.detail.ReadCapacity)
+	if strings.Contains(query.Metric, "Write") {
+		capacity = float64(table.detail.WriteCapacity)
+	}
+	provisioned := table.detail.BillingMode == "PROVISIONED"
+
+	var series clients.MetricSeries
+	period := time.Duration(max(query.Period, 60)) * time.Second
+	for t := start.Truncate(period); t.Before(end); t = t.Add(period) {
+		wave := 1 + 0.3*math.Sin(float64(t.Unix())/3600)
+		perSecond := table.usage * wave
+		if provisioned {
+			perSecond *= capacity
+		}
+
+		var value float64
+		switch {
+		case strings.HasPrefix(query.Metric, "Provisioned"):
+			if !provisioned {
+				// On-demand tables report no provisioned capacity
+				return clients.MetricSeries{}, true
+			}
+			value = capacity
+		case strings.HasPrefix(query.Metric, "Consumed"):
+			if provisioned {
+				perSecond = min(perSecond, capacity)
+			}
+			value = perSecond * period.Seconds()
+		case strings.HasSuffix(query.Metric, "ThrottleEvents"):
+			if provisioned {
+				value = math.Round(max(perSecond-capacity, 0) * period.Seconds() / 10)
+			}
+		default:
+			return clients.MetricSeries{}, false
+		}
+		series.Timestamps = append(series.Timestamps, t)
+		series.Values = append(series.Values, math.Round(value*10)/10)
+	}
+	return series, true
+}
+
+// ApplicationAutoScalingService scales the read and write capacity of the
+// orders table and the reads of its index
+type ApplicationAutoScalingService struct {
+	targets []clients.ScalableTarget
+}
+
+// NewApplicationAutoScalingService returns the scalable targets of the
+// sample tables
+func NewApplicationAutoScalingService() *ApplicationAutoScalingService {
+	target := func(resource, capacity string, min, max int32) clients.ScalableTarget {
+		metric := "DynamoDB" + capacity + "CapacityUtilization"
+		kind := "table"
+		if strings.Contains(resource, "/index/") {
+			kind = "index"
+		}
+		return clients.ScalableTarget{
+			ResourceID: resource,
+			Dimension:  fmt.Sprintf("dynamodb:%s:%sCapacityUnits", kind, capacity),
+			Min:        min,
+			Max:        max,
+			Policies: []clients.ScalingPolicy{{
+				Name:             metric + ":" + resource,
+				Type:             "TargetTrackingScaling",
+				TargetValue:      70,
+				Metric:           metric,
+				ScaleInCooldown:  60,
+				ScaleOutCooldown: 60,
+			}},
+		}
+	}
+
+	return &ApplicationAutoScalingService{
+		targets: []clients.ScalableTarget{
+			target("table/orders", "Read", 25, 400),
+			target("table/orders", "Write", 10, 200),
+			target("table/orders/index/by-customer", "Read", 5, 100),
+		},
+	}
+}
+
+// GetTableScaling returns the scalable targets of a table and its indexes
+func (s *ApplicationAutoScalingService) GetTableScaling(ctx context.Context, tableName string, indexes []string) ([]clients.ScalableTarget, error) {
+	resources := map[string]bool{"table/" + tableName: true}
+	for _, index := range indexes {
+		resources["table/"+tableName+"/index/"+index] = true
+	}
+
+	var targets []clients.ScalableTarget
+	for _, target := range s.targets {
+		if resources[target.ResourceID] {
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
@@ -0,0 +1,354 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// EC2Service keeps a fixed set of instances whose state can be changed.
+// Like in AWS, instances pass through pending, stopping or shutting-down
+// for TransitionDelay before they settle.
+type EC2Service struct {
+	mu          sync.Mutex
+	instances   []types.Instance
+	volumes     []types.Volume
+	addresses   []types.Address
+	gateways    []types.NatGateway
+	spot        []types.SpotInstanceRequest
+	reserved    []types.ReservedInstances
+	cleanup     clients.CleanupCandidates
+	delay       time.Duration
+	transitions map[string]transition
+}
+
+// transition is a state an instance settles in at a given time
+type transition struct {
+	state types.InstanceStateName
+	at    time.Time
+}
+
+// protectedInstance may not be stopped by the demo user, to show how missing
+// permissions look
+const protectedInstance = "i-0c34d56e78f90a123"
+
+// instancePage is how many instances a page of GetEC2Detail holds, few to
+// show the paging
+const instancePage = 2
+
+// DefaultTransitionDelay is how long state changes take unless changed with
+// SetTransitionDelay
+const DefaultTransitionDelay = 3 * time.Second
+
+// NewEC2Service returns instances of a small web shop
+func NewEC2Service() *EC2Service {
+	launched := time.Now().Add(-72 * time.Hour).Truncate(time.Hour)
+
+	instance := func(id, name, instanceType, zone string, state types.InstanceStateName, privateIP, publicIP string, extraTags map[string]string) types.Instance {
+		tags := []types.Tag{{Key: awssdk.String("Name"), Value: awssdk.String(name)}}
+		for key, value := range extraTags {
+			tags = append(tags, types.Tag{Key: awssdk.String(key), Value: awssdk.String(value)})
+		}
+
+		inst := types.Instance{
+			InstanceId:       awssdk.String(id),
+			InstanceType:     types.InstanceType(instanceType),
+			ImageId:          awssdk.String("ami-0c02fb55956c7d316"),
+			VpcId:            awssdk.String("vpc-0a1b2c3d4e5f60718"),
+			SubnetId:         awssdk.String("subnet-0123456789abcdef0"),
+			PrivateIpAddress: awssdk.String(privateIP),
+			KeyName:          awssdk.String("demo-key"),
+			LaunchTime:       awssdk.Time(launched),
+			Placement:        &types.Placement{AvailabilityZone: awssdk.String(Region + zone)},
+			State:            &types.InstanceState{Name: state},
+			Tags:             tags,
+			SecurityGroups: []types.GroupIdentifier{
+				{GroupId: awssdk.String("sg-0fedcba9876543210"), GroupName: awssdk.String("web")},
+			},
+		}
+		if publicIP != "" {
+			inst.PublicIpAddress = awssdk.String(publicIP)
+		}
+		return inst
+	}
+
+	// Every instance has a root volume; one old data volume is left over
+	volume := func(id, name string, size int32, volumeType types.VolumeType, instanceID string) types.Volume {
+		vol := types.Volume{
+			VolumeId:   awssdk.String(id),
+			Size:       awssdk.Int32(size),
+			VolumeType: volumeType,
+			State:      types.VolumeStateAvailable,
+			CreateTime: awssdk.Time(launched),
+			Tags:       []types.Tag{{Key: awssdk.String("Name"), Value: awssdk.String(name)}},
+		}
+		if instanceID != "" {
+			vol.State = types.VolumeStateInUse
+			vol.Attachments = []types.VolumeAttachment{{
+				InstanceId: awssdk.String(instanceID),
+				Device:     awssdk.String("/dev/xvda"),
+				State:      types.VolumeAttachmentStateAttached,
+			}}
+		}
+		return vol
+	}
+
+	address := func(allocationID, ip, name, instanceID string) types.Address {
+		addr := types.Address{
+			AllocationId: awssdk.String(allocationID),
+			PublicIp:     awssdk.String(ip),
+			Domain:       types.DomainTypeVpc,
+			Tags:         []types.Tag{{Key: awssdk.String("Name"), Value: awssdk.String(name)}},
+		}
+		if instanceID != "" {
+			addr.InstanceId = awssdk.String(instanceID)
+			addr.AssociationId = awssdk.String("eipassoc-" + allocationID[len("eipalloc-"):])
+		}
+		return addr
+	}
+
+	gateway := func(id, name, subnet string, state types.NatGatewayState) types.NatGateway {
+		return types.NatGateway{
+			NatGatewayId:     awssdk.String(id),
+			State:            state,
+			VpcId:            awssdk.String("vpc-0a1b2c3d4e5f60718"),
+			SubnetId:         awssdk.String(subnet),
+			ConnectivityType: types.ConnectivityTypePublic,
+			CreateTime:       awssdk.Time(launched.AddDate(0, -6, 0)),
+			Tags:             []types.Tag{{Key: awssdk.String("Name"), Value: awssdk.String(name)}},
+		}
+	}
+
+	spotRequest := func(id, instanceID, instanceType, maxPrice string, state types.SpotInstanceState, code, message string) types.SpotInstanceRequest {
+		return types.SpotInstanceRequest{
+			SpotInstanceRequestId:    awssdk.String(id),
+			InstanceId:               awssdk.String(instanceID),
+			LaunchSpecification:      &types.LaunchSpecification{InstanceType: types.InstanceType(instanceType)},
+			SpotPrice:                awssdk.String(maxPrice),
+			State:                    state,
+			Type:                     types.SpotInstanceTypePersistent,
+			LaunchedAvailabilityZone: awssdk.String(Region + "a"),
+			CreateTime:               awssdk.Time(launched),
+			Status: &types.SpotInstanceStatus{
+				Code:       awssdk.String(code),
+				Message:    awssdk.String(message),
+				UpdateTime: awssdk.Time(time.Now().Add(-2 * time.Minute).Truncate(time.Minute)),
+			},
+		}
+	}
+
+	reservation := func(id, instanceType string, count int32, hourly float64, monthsLeft int) types.ReservedInstances {
+		end := time.Now().AddDate(0, monthsLeft, 0).Truncate(24 * time.Hour)
+		return types.ReservedInstances{
+			ReservedInstancesId: awssdk.String(id),
+			InstanceType:        types.InstanceType(instanceType),
+			InstanceCount:       awssdk.Int32(count),
+			State:               types.ReservedInstanceStateActive,
+			Scope:               types.ScopeRegional,
+			OfferingType:        types.OfferingTypeValuesNoUpfront,
+			ProductDescription:  types.RIProductDescriptionLinuxUnix,
+			Start:               awssdk.Time(end.AddDate(-1, 0, 0)),
+			End:                 awssdk.Time(end),
+			Duration:            awssdk.Int64(365 * 24 * 3600),
+			CurrencyCode:        types.CurrencyCodeValuesUsd,
+			RecurringCharges: []types.RecurringCharge{
+				{Amount: awssdk.Float64(hourly), Frequency: types.RecurringChargeFrequencyHourly},
+			},
+		}
+	}
+
+	return &EC2Service{
+		cleanup:     sampleCleanup(),
+		delay:       DefaultTransitionDelay,
+		transitions: make(map[string]transition),
+		instances: []types.Instance{
+			instance("i-0a12b34c56d78e901", "web-1", "t3.medium", "a", types.InstanceStateNameRunning, "10.0.1.10", "54.210.10.1", map[string]string{"env": "prod", "team": "web"}),
+			instance("i-0b23c45d67e89f012", "web-2", "t3.medium", "b", types.InstanceStateNameRunning, "10.0.2.10", "54.210.10.2", map[string]string{"env": "prod", "team": "web"}),
+			instance("i-0c34d56e78f90a123", "worker-1", "c6i.large", "a", types.InstanceStateNameRunning, "10.0.3.20", "", map[string]string{"env": "prod", "team": "orders"}),
+			instance("i-0d45e67f89a01b234", "bastion", "t3.micro", "a", types.InstanceStateNameStopped, "10.0.0.5", "", map[string]string{"env": "shared"}),
+			instance("i-0e56f78a90b12c345", "staging-app", "t3.small", "b", types.InstanceStateNameStopped, "10.1.1.10", "", map[string]string{"env": "staging", "team": "web"}),
+		},
+		volumes: []types.Volume{
+			volume("vol-0a12b34c56d78e901", "web-1-root", 30, types.VolumeTypeGp3, "i-0a12b34c56d78e901"),
+			volume("vol-0b23c45d67e89f012", "web-2-root", 30, types.VolumeTypeGp3, "i-0b23c45d67e89f012"),
+			volume("vol-0c34d56e78f90a123", "worker-1-root", 50, types.VolumeTypeGp3, "i-0c34d56e78f90a123"),
+			volume("vol-0d45e67f89a01b234", "bastion-root", 8, types.VolumeTypeGp2, "i-0d45e67f89a01b234"),
+			volume("vol-0e56f78a90b12c345", "staging-app-root", 100, types.VolumeTypeGp3, "i-0e56f78a90b12c345"),
+			volume("vol-0f67a89b01c23d456", "orders-db-old-data", 500, types.VolumeTypeGp2, ""),
+		},
+		addresses: []types.Address{
+			address("eipalloc-0a1b2c3d4e5f60701", "54.210.10.1", "web-1", "i-0a12b34c56d78e901"),
+			address("eipalloc-0a1b2c3d4e5f60702", "54.210.10.2", "web-2", "i-0b23c45d67e89f012"),
+			address("eipalloc-0a1b2c3d4e5f60703", "3.91.44.17", "legacy-ftp", ""),
+		},
+		gateways: []types.NatGateway{
+			gateway("nat-0a1b2c3d4e5f60711", "shop-nat-a", "subnet-0123456789abcdef1", types.NatGatewayStateAvailable),
+			gateway("nat-0a1b2c3d4e5f60712", "shop-nat-b", "subnet-0123456789abcdef2", types.NatGatewayStateAvailable),
+			gateway("nat-0a1b2c3d4e5f60713", "data-pipeline-nat", "subnet-0123456789abcdef3", types.NatGatewayStateAvailable),
+			gateway("nat-0a1b2c3d4e5f60714", "legacy-nat", "subnet-0123456789abcdef4", types.NatGatewayStateDeleted),
+		},
+		spot: []types.SpotInstanceRequest{
+			spotRequest("sir-4k7m2p9q", "i-0f11a22b33c44d556", "c6i.xlarge", "0.0800", types.SpotInstanceStateActive,
+				"fulfilled", "Your spot request is fulfilled."),
+			spotRequest("sir-8r3t6w1y", "i-0f22b33c44d55e667", "c6i.xlarge", "0.0800", types.SpotInstanceStateActive,
+				"marked-for-termination", "Your Spot Instance is marked for termination because of capacity constraints."),
+			spotRequest("sir-2b5n8c4x", "", "m5.2xlarge", "0.1200", types.SpotInstanceStateOpen,
+				"capacity-not-available", "There is no Spot capacity available that matches your request."),
+		},
+		reserved: []types.ReservedInstances{
+			reservation("9d5c8a71-2f3e-4b6a-8c1d-0e9f7a6b5c41", "t3.medium", 2, 0.026, 7),
+			reservation("1a2b3c4d-5e6f-4a8b-9c0d-e1f2a3b4c5d6", "m5.large", 2, 0.060, 1),
+		},
+	}
+}
+
+// SetTransitionDelay sets how long later state changes take
+func (s *EC2Service) SetTransitionDelay(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delay = delay
+}
+
+// GetEC2Detail returns copies of all instances, passing them to onPage, if
+// set, instancePage at a time
+func (s *EC2Service) GetEC2Detail(ctx context.Context, onPage func([]types.Instance)) ([]types.Instance, error) {
+	s.mu.Lock()
+	instances := make([]types.Instance, len(s.instances))
+	for i := range s.instances {
+		instances[i] = s.instance(i)
+	}
+	s.mu.Unlock()
+
+	if onPage != nil {
+		for start := 0; start < len(instances); start += instancePage {
+			onPage(instances[start:min(start+instancePage, len(instances))])
+		}
+	}
+	return instances, nil
+}
+
+// DescribeVolumes returns all volumes
+func (s *EC2Service) DescribeVolumes(ctx context.Context) ([]types.Volume, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]types.Volume(nil), s.volumes...), nil
+}
+
+// DescribeAddresses returns all Elastic IP addresses
+func (s *EC2Service) DescribeAddresses(ctx context.Context) ([]types.Address, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]types.Address(nil), s.addresses...), nil
+}
+
+// DescribeNatGateways returns all NAT gateways
+func (s *EC2Service) DescribeNatGateways(ctx context.Context) ([]types.NatGateway, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]types.NatGateway(nil), s.gateways...), nil
+}
+
+// DescribeSpotInstanceRequests returns all Spot Instance requests
+func (s *EC2Service) DescribeSpotInstanceRequests(ctx context.Context) ([]types.SpotInstanceRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]types.SpotInstanceRequest(nil), s.spot...), nil
+}
+
+// DescribeReservedInstances returns all Reserved Instances
+func (s *EC2Service) DescribeReservedInstances(ctx context.Context) ([]types.ReservedInstances, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]types.ReservedInstances(nil), s.reserved...), nil
+}
+
+// DescribeInstance returns a copy of one instance
+func (s *EC2Service) DescribeInstance(ctx context.Context, instanceID string) (types.Instance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, err := s.find(instanceID)
+	if err != nil {
+		return types.Instance{}, err
+	}
+	return s.instance(i), nil
+}
+
+func (s *EC2Service) StartInstance(ctx context.Context, instanceID string) error {
+	return s.changeState(instanceID, types.InstanceStateNamePending, types.InstanceStateNameRunning)
+}
+
+func (s *EC2Service) StopInstance(ctx context.Context, instanceID string) error {
+	if allowed, _ := s.CanStopInstance(ctx, instanceID); !allowed {
+		return apiError("UnauthorizedOperation", "You are not authorized to perform this operation.")
+	}
+	return s.changeState(instanceID, types.InstanceStateNameStopping, types.InstanceStateNameStopped)
+}
+
+// CanStartInstance allows starting every instance
+func (s *EC2Service) CanStartInstance(ctx context.Context, instanceID string) (bool, error) {
+	return true, nil
+}
+
+// CanStopInstance allows stopping every instance but protectedInstance
+func (s *EC2Service) CanStopInstance(ctx context.Context, instanceID string) (bool, error) {
+	return instanceID != protectedInstance, nil
+}
+
+func (s *EC2Service) RebootInstance(ctx context.Context, instanceID string) error {
+	return s.changeState(instanceID, types.InstanceStateNameRunning, types.InstanceStateNameRunning)
+}
+
+func (s *EC2Service) TerminateInstance(ctx context.Context, instanceID string) error {
+	return s.changeState(instanceID, types.InstanceStateNameShuttingDown, types.InstanceStateNameTerminated)
+}
+
+// changeState puts an instance into the interim state and schedules the
+// final one. Terminated instances stay terminated.
+func (s *EC2Service) changeState(instanceID string, interim, final types.InstanceStateName) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, err := s.find(instanceID)
+	if err != nil {
+		return err
+	}
+	if s.instance(i).State.Name == types.InstanceStateNameTerminated {
+		return apiError("IncorrectInstanceState", fmt.Sprintf("The instance '%s' is terminated", instanceID))
+	}
+
+	s.instances[i].State = &types.InstanceState{Name: interim}
+	s.transitions[instanceID] = transition{state: final, at: time.Now().Add(s.delay)}
+	return nil
+}
+
+// find returns the index of an instance. The caller must hold s.mu.
+func (s *EC2Service) find(instanceID string) (int, error) {
+	for i := range s.instances {
+		if awssdk.ToString(s.instances[i].InstanceId) == instanceID {
+			return i, nil
+		}
+	}
+	return 0, apiError("InvalidInstanceID.NotFound", fmt.Sprintf("The instance ID '%s' does not exist", instanceID))
+}
+
+// instance settles a due transition and returns a copy of the instance at
+// index i. The caller must hold s.mu.
+func (s *EC2Service) instance(i int) types.Instance {
+	id := awssdk.ToString(s.instances[i].InstanceId)
+	if t, ok := s.transitions[id]; ok && !time.Now().Before(t.at) {
+		s.instances[i].State = &types.InstanceState{Name: t.state}
+		delete(s.transitions, id)
+	}
+
+	instance := s.instances[i]
+	state := *instance.State
+	instance.State = &state
+	return instance
+}
@@ -0,0 +1,96 @@
+package fake
+
+import (
+	"context"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// protectedSnapshot is under a legal hold the demo user may not lift, to
+// show how a refused deletion looks in the dry run
+const protectedSnapshot = "snap-0c3d4e5f6a7b8c9d1"
+
+// sampleCleanup are the AMIs and snapshots of the sample account nothing
+// references: images of releases long replaced, the worker one still shared
+// with the staging account, the data of the old orders database, a copy of
+// the staging data and the root volume of an image deregistered without its
+// snapshot. The references themselves are not modelled.
+func sampleCleanup() clients.CleanupCandidates {
+	now := time.Now().Truncate(time.Hour)
+	day := 24 * time.Hour
+	return clients.CleanupCandidates{
+		Images: []clients.UnusedImage{
+			{ID: "ami-0a1b2c3d4e5f60721", Name: "shop-web-2025-08", CreatedAt: now.Add(-430 * day),
+				Snapshots: []string{"snap-0a1b2c3d4e5f60731"}, SizeGB: 30},
+			{ID: "ami-0a1b2c3d4e5f60722", Name: "orders-worker-2026-03", CreatedAt: now.Add(-210 * day),
+				Snapshots: []string{"snap-0a1b2c3d4e5f60732", "snap-0a1b2c3d4e5f60733"}, SizeGB: 80, SharedWith: []string{"210987654321"}},
+		},
+		Snapshots: []clients.UnusedSnapshot{
+			{ID: "snap-0b2c3d4e5f6a7b8c1", VolumeID: "vol-0a9b8c7d6e5f40321", Description: "orders-db data before the Aurora migration",
+				StorageTier: "standard", StartedAt: now.Add(-700 * day), SizeGB: 500},
+			{ID: protectedSnapshot, VolumeID: "vol-0a9b8c7d6e5f40322", Description: "legal-hold: orders audit 2025",
+				StorageTier: "archive", StartedAt: now.Add(-400 * day), SizeGB: 200},
+			{ID: "snap-0d4e5f6a7b8c9d0e1", VolumeID: "vol-0a9b8c7d6e5f40323", Description: "staging-app data copy",
+				StorageTier: "standard", StartedAt: now.Add(-120 * day), SizeGB: 100},
+			{ID: "snap-0e5f6a7b8c9d0e1f2", VolumeID: "vol-0a9b8c7d6e5f40324", Description: "Created by CreateImage(i-0e56f78a90b12c345) for ami-0a1b2c3d4e5f60729",
+				StorageTier: "standard", StartedAt: now.Add(-12 * day), SizeGB: 30},
+		},
+	}
+}
+
+// FindCleanupCandidates returns copies of the sample candidates, which the
+// sample launch references do not touch
+func (s *EC2Service) FindCleanupCandidates(ctx context.Context, launches clients.LaunchReferences) (clients.CleanupCandidates, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return clients.CleanupCandidates{
+		Images:    append([]clients.UnusedImage(nil), s.cleanup.Images...),
+		Snapshots: append([]clients.UnusedSnapshot(nil), s.cleanup.Snapshots...),
+	}, nil
+}
+
+// CleanUp drops images and snapshots from the candidates unless dryRun is
+// set; protectedSnapshot is refused either way
+func (s *EC2Service) CleanUp(ctx context.Context, images []clients.UnusedImage, snapshots []clients.UnusedSnapshot, dryRun bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := make(map[string]bool)
+	var failures []clients.ItemError
+	for _, image := range images {
+		removed[image.ID] = true
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.ID == protectedSnapshot {
+			failures = append(failures, clients.ItemError{Item: snapshot.ID,
+				Err: apiError("UnauthorizedOperation", "You are not authorized to perform this operation.")})
+			continue
+		}
+		removed[snapshot.ID] = true
+	}
+
+	op := "clean up images and snapshots"
+	if dryRun {
+		op += " (dry run)"
+	} else {
+		var keptImages []clients.UnusedImage
+		for _, image := range s.cleanup.Images {
+			if !removed[image.ID] {
+				keptImages = append(keptImages, image)
+			}
+		}
+		var keptSnapshots []clients.UnusedSnapshot
+		for _, snapshot := range s.cleanup.Snapshots {
+			if !removed[snapshot.ID] {
+				keptSnapshots = append(keptSnapshots, snapshot)
+			}
+		}
+		s.cleanup = clients.CleanupCandidates{Images: keptImages, Snapshots: keptSnapshots}
+	}
+
+	if len(failures) > 0 {
+		return &clients.PartialError{Op: op, Failures: failures}
+	}
+	return nil
+}
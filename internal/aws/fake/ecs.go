@@ -0,0 +1,228 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// ecrImage returns the URI of image in the ECR registry of the demo account
+func ecrImage(image string) string {
+	return fmt.Sprintf("%s.dkr.ecr.%s.amazonaws.com/%s", Account, Region, image)
+}
+
+// eksAddonImage returns the URI of an EKS add-on image in the registry AWS
+// serves them from, which the demo account cannot read
+func eksAddonImage(image string) string {
+	return fmt.Sprintf("602401143452.dkr.ecr.%s.amazonaws.com/eks/%s", Region, image)
+}
+
+// ECSService is the shop's ECS cluster running the storefront and the order
+// API on Fargate, a report generator that fails to start and a proxy from
+// Docker Hub, next to an empty cluster for internal tools
+type ECSService struct {
+	services []clients.ECSServiceDetail
+	tasks    map[string][]clients.ECSTask
+}
+
+// NewECSService returns the sample services
+func NewECSService() *ECSService {
+	now := time.Now()
+	service := func(name string, desired, running int, created time.Duration, images map[string]string) clients.ECSServiceDetail {
+		return clients.ECSServiceDetail{
+			Name:           name,
+			ARN:            fmt.Sprintf("arn:aws:ecs:%s:%s:service/shop/%s", Region, Account, name),
+			Cluster:        "shop",
+			Status:         "ACTIVE",
+			LaunchType:     "FARGATE",
+			TaskDefinition: fmt.Sprintf("arn:aws:ecs:%s:%s:task-definition/%s:%d", Region, Account, name, len(name)+3),
+			Desired:        desired,
+			Running:        running,
+			Pending:        desired - running,
+			Images:         images,
+			CreatedAt:      now.Add(-created),
+		}
+	}
+
+	s := &ECSService{
+		services: []clients.ECSServiceDetail{
+			service("edge-proxy", 2, 2, 300*24*time.Hour, map[string]string{"nginx": "nginx:1.25-alpine"}),
+			service("orders-api", 2, 2, 180*24*time.Hour, map[string]string{"app": ecrImage("orders-api:2.3.0")}),
+			service("reports", 1, 0, 30*24*time.Hour, map[string]string{"app": ecrImage("reports:latest")}),
+			service("storefront", 3, 3, 240*24*time.Hour, map[string]string{
+				"app":        ecrImage("storefront:4.0.7"),
+				"log-router": "public.ecr.aws/aws-observability/aws-for-fluent-bit:2.32.2",
+			}),
+		},
+		tasks: make(map[string][]clients.ECSTask),
+	}
+	for _, service := range s.services {
+		s.tasks[service.Name] = sampleTasks(service, now)
+	}
+	return s
+}
+
+// sampleTasks makes up the tasks of service: its running tasks spread over
+// the zones with healthy containers and a pending one for each missing.
+// The report generator exits right after starting and the order API
+// replaced a task in its last deployment.
+func sampleTasks(service clients.ECSServiceDetail, now time.Time) []clients.ECSTask {
+	zones := []string{Region + "a", Region + "b", Region + "c"}
+	seed := crc32.ChecksumIEEE([]byte(service.Name))
+	task := func(i int, status string, age time.Duration) clients.ECSTask {
+		t := clients.ECSTask{
+			ID:             fmt.Sprintf("%08x%024x", seed, i+1),
+			TaskDefinition: service.TaskDefinition[strings.LastIndex(service.TaskDefinition, "/")+1:],
+			LastStatus:     status,
+			DesiredStatus:  "RUNNING",
+			HealthStatus:   "UNKNOWN",
+			LaunchType:     service.LaunchType,
+			Zone:           zones[i%len(zones)],
+			CPU:            "512",
+			Memory:         "1024",
+			CreatedAt:      now.Add(-age),
+		}
+		t.ARN = fmt.Sprintf("arn:aws:ecs:%s:%s:task/%s/%s", Region, Account, service.Cluster, t.ID)
+		if status != "PENDING" {
+			t.StartedAt = t.CreatedAt.Add(40 * time.Second)
+		}
+		for _, name := range sortedKeys(service.Images) {
+			container := clients.ECSContainer{Name: name, Image: service.Images[name], LastStatus: status, HealthStatus: "UNKNOWN"}
+			if name == "app" && status == "RUNNING" {
+				container.HealthStatus = "HEALTHY"
+				t.HealthStatus = "HEALTHY"
+			}
+			t.Containers = append(t.Containers, container)
+		}
+		return t
+	}
+	stop := func(t clients.ECSTask, reason string, exitCode int, after time.Duration) clients.ECSTask {
+		t.LastStatus, t.DesiredStatus, t.HealthStatus = "STOPPED", "STOPPED", "UNKNOWN"
+		t.StoppedAt = t.CreatedAt.Add(after)
+		t.StoppedReason = reason
+		for i := range t.Containers {
+			code := exitCode
+			t.Containers[i].LastStatus = "STOPPED"
+			t.Containers[i].HealthStatus = "UNKNOWN"
+			t.Containers[i].ExitCode = &code
+		}
+		return t
+	}
+
+	var tasks []clients.ECSTask
+	for i := 0; i < service.Running; i++ {
+		tasks = append(tasks, task(i, "RUNNING", time.Duration(6+i)*24*time.Hour))
+	}
+	for i := service.Running; i < service.Desired; i++ {
+		tasks = append(tasks, task(i, "PENDING", 20*time.Second))
+	}
+	switch service.Name {
+	case "reports":
+		for i, age := range []time.Duration{3 * time.Minute, 9 * time.Minute} {
+			stopped := stop(task(service.Desired+i, "RUNNING", age), "Essential container in task exited", 1, 50*time.Second)
+			stopped.Containers[0].Reason = "OutOfMemoryError: Container killed due to memory usage"
+			tasks = append(tasks, stopped)
+		}
+	case "orders-api":
+		tasks = append(tasks, stop(task(service.Desired, "RUNNING", 26*time.Hour),
+			"Scaling activity initiated by (deployment ecs-svc/4215934427106359301)", 0, 20*time.Hour))
+	}
+	return tasks
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ListServices returns the sample services
+func (s *ECSService) ListServices(ctx context.Context) ([]clients.ECSServiceDetail, error) {
+	return append([]clients.ECSServiceDetail(nil), s.services...), nil
+}
+
+// ListClusters returns the shop cluster with the counts of its sample
+// services and the empty tools cluster
+func (s *ECSService) ListClusters(ctx context.Context) ([]clients.ECSCluster, error) {
+	shop := clients.ECSCluster{
+		Name:   "shop",
+		ARN:    fmt.Sprintf("arn:aws:ecs:%s:%s:cluster/shop", Region, Account),
+		Status: "ACTIVE",
+	}
+	for _, service := range s.services {
+		shop.ActiveServices++
+		shop.RunningTasks += service.Running
+		shop.PendingTasks += service.Pending
+	}
+	return []clients.ECSCluster{
+		{Name: "internal-tools", ARN: fmt.Sprintf("arn:aws:ecs:%s:%s:cluster/internal-tools", Region, Account), Status: "ACTIVE"},
+		shop,
+	}, nil
+}
+
+// ListTasks returns the sample tasks of service
+func (s *ECSService) ListTasks(ctx context.Context, cluster, service string) ([]clients.ECSTask, error) {
+	tasks, ok := s.tasks[service]
+	if !ok || (cluster != "shop" && !strings.HasSuffix(cluster, ":cluster/shop")) {
+		return nil, apiError("ServiceNotFoundException", "Service not found.")
+	}
+	return append([]clients.ECSTask(nil), tasks...), nil
+}
+
+// ECRService has scanned most images of the demo account on push: the
+// storefront has critical vulnerabilities, the frontend is still being
+// scanned and the report generator was never scanned
+type ECRService struct {
+	findings map[string]clients.ImageScanFindings
+}
+
+// NewECRService returns the sample scan findings
+func NewECRService() *ECRService {
+	scanned := time.Now().Add(-26 * time.Hour)
+	findings := func(image string, critical, high, medium, low int) clients.ImageScanFindings {
+		return clients.ImageScanFindings{
+			Image: ecrImage(image), Status: "COMPLETE", Description: "The scan was completed successfully.",
+			Critical: critical, High: high, Medium: medium, Low: low, ScannedAt: scanned,
+		}
+	}
+
+	s := &ECRService{findings: make(map[string]clients.ImageScanFindings)}
+	for _, f := range []clients.ImageScanFindings{
+		findings("storefront:4.0.7", 2, 7, 12, 20),
+		findings("orders-api:2.3.0", 0, 3, 4, 9),
+		findings("payments-api:3.1.0", 1, 2, 5, 3),
+		findings("cart:2.8.1", 0, 0, 1, 4),
+		findings("checkout:1.12.0", 0, 1, 2, 2),
+		{Image: ecrImage("frontend:5.2.0"), Status: "IN_PROGRESS", Description: "The scan is in progress."},
+	} {
+		s.findings[f.Image] = f
+	}
+	return s
+}
+
+// GetImageScanFindings returns the sample findings of image. Images of
+// other accounts cannot be read and images without findings were never
+// scanned.
+func (s *ECRService) GetImageScanFindings(ctx context.Context, image string) (clients.ImageScanFindings, error) {
+	ref, ok := clients.ParseECRImage(image)
+	if !ok {
+		return clients.ImageScanFindings{Image: image, Status: clients.ScanStatusNotInECR}, nil
+	}
+	if ref.RegistryID != Account {
+		return clients.ImageScanFindings{Image: image}, apiError("AccessDeniedException",
+			fmt.Sprintf("User: arn:aws:iam::%s:user/demo is not authorized to perform: ecr:DescribeImageScanFindings on resource: arn:aws:ecr:%s:%s:repository/%s", Account, ref.Region, ref.RegistryID, ref.Repository))
+	}
+	if findings, ok := s.findings[image]; ok {
+		return findings, nil
+	}
+	return clients.ImageScanFindings{Image: image, Status: clients.ScanStatusNotScanned}, nil
+}
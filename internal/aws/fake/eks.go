@@ -0,0 +1,217 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// podLogInterval is how often a followed sample pod logs a new line
+const podLogInterval = 2 * time.Second
+
+// EKSService is the shop's production cluster running the storefront and
+// payments, and a staging cluster that is still being created
+type EKSService struct {
+	clusters    []clients.EKSCluster
+	deployments []clients.Deployment
+	pods        []clients.Pod
+}
+
+// NewEKSService returns the sample clusters and workloads
+func NewEKSService() *EKSService {
+	now := time.Now()
+	created := now.Add(-21 * 24 * time.Hour)
+
+	deployment := func(namespace, name string, replicas, ready int, images map[string]string) clients.Deployment {
+		return clients.Deployment{
+			Name: name, Namespace: namespace,
+			Replicas: replicas, Ready: ready, UpToDate: replicas, Available: ready,
+			Images: images, CreatedAt: created,
+		}
+	}
+	pod := func(namespace, name, status string, ready, restarts int, age time.Duration, containers ...string) clients.Pod {
+		return clients.Pod{
+			Name: name, Namespace: namespace, Status: status,
+			Ready: ready, Containers: containers, Restarts: restarts,
+			Node: fmt.Sprintf("ip-10-20-%d-17.ec2.internal", len(name)%3+1), CreatedAt: now.Add(-age),
+		}
+	}
+
+	return &EKSService{
+		clusters: []clients.EKSCluster{
+			{
+				Name:            "shop-prod",
+				ARN:             fmt.Sprintf("arn:aws:eks:%s:%s:cluster/shop-prod", Region, Account),
+				Status:          "ACTIVE",
+				Version:         "1.31",
+				PlatformVersion: "eks.12",
+				Endpoint:        "https://6A1F3C2B9D4E5F60718293A4B5C6D7E8.gr7.us-east-1.eks.amazonaws.com",
+				PublicEndpoint:  true,
+				CreatedAt:       now.Add(-400 * 24 * time.Hour),
+			},
+			{
+				Name:            "shop-staging",
+				ARN:             fmt.Sprintf("arn:aws:eks:%s:%s:cluster/shop-staging", Region, Account),
+				Status:          "CREATING",
+				Version:         "1.32",
+				PlatformVersion: "eks.3",
+				CreatedAt:       now.Add(-8 * time.Minute),
+			},
+		},
+		deployments: []clients.Deployment{
+			deployment("kube-system", "coredns", 2, 2, map[string]string{"coredns": eksAddonImage("coredns:v1.11.3-eksbuild.2")}),
+			deployment("payments", "payments-api", 2, 2, map[string]string{"app": ecrImage("payments-api:3.1.0")}),
+			deployment("payments", "payments-worker", 1, 0, map[string]string{"worker": ecrImage("payments-api:3.1.0")}),
+			deployment("shop", "cart", 3, 2, map[string]string{"app": ecrImage("cart:2.8.1")}),
+			deployment("shop", "checkout", 1, 1, map[string]string{"app": ecrImage("checkout:1.12.0")}),
+			deployment("shop", "frontend", 2, 2, map[string]string{"app": ecrImage("frontend:5.2.0"), "envoy": "envoyproxy/envoy:v1.31.2"}),
+		},
+		pods: []clients.Pod{
+			pod("kube-system", "aws-node-5kx2q", "Running", 2, 0, 21*24*time.Hour, "aws-node", "aws-eks-nodeagent"),
+			pod("kube-system", "coredns-787cb67946-4r8tn", "Running", 1, 0, 21*24*time.Hour, "coredns"),
+			pod("kube-system", "coredns-787cb67946-xw2lp", "Running", 1, 0, 21*24*time.Hour, "coredns"),
+			pod("payments", "payments-api-6c9d5b7f48-h2kqz", "Running", 1, 0, 3*24*time.Hour, "app"),
+			pod("payments", "payments-api-6c9d5b7f48-q7vwd", "Running", 1, 1, 3*24*time.Hour, "app"),
+			pod("payments", "payments-worker-58f6d9c7b-m4n8s", "Pending", 0, 0, 12*time.Minute, "worker"),
+			pod("shop", "cart-7d9f8b6c5-2xjlq", "Running", 1, 0, 6*time.Hour, "app"),
+			pod("shop", "cart-7d9f8b6c5-9kfzt", "Running", 1, 0, 6*time.Hour, "app"),
+			pod("shop", "cart-7d9f8b6c5-vb4rm", "CrashLoopBackOff", 0, 14, 6*time.Hour, "app"),
+			pod("shop", "checkout-5b8c6d9f7-t3wpx", "Running", 1, 0, 2*24*time.Hour, "app"),
+			pod("shop", "frontend-84f7c9d6b-6gkzm", "Running", 2, 0, 2*24*time.Hour, "app", "envoy"),
+			pod("shop", "frontend-84f7c9d6b-rj5ns", "Running", 2, 0, 2*24*time.Hour, "app", "envoy"),
+		},
+	}
+}
+
+// ListClusters returns the sample clusters
+func (s *EKSService) ListClusters(ctx context.Context) ([]clients.EKSCluster, error) {
+	return append([]clients.EKSCluster(nil), s.clusters...), nil
+}
+
+// cluster checks that the sample cluster name serves its Kubernetes API
+func (s *EKSService) cluster(name string) error {
+	for _, cluster := range s.clusters {
+		if cluster.Name != name {
+			continue
+		}
+		if cluster.Endpoint == "" {
+			return fmt.Errorf("cluster %s has no API endpoint yet (status %s)", name, cluster.Status)
+		}
+		return nil
+	}
+	return apiError("ResourceNotFoundException", fmt.Sprintf("No cluster found for name: %s.", name))
+}
+
+// ListNamespaces returns the namespaces of the sample workloads
+func (s *EKSService) ListNamespaces(ctx context.Context, cluster string) ([]string, error) {
+	if err := s.cluster(cluster); err != nil {
+		return nil, err
+	}
+	namespaces := []string{"default"}
+	for _, pod := range s.pods {
+		if namespaces[len(namespaces)-1] != pod.Namespace {
+			namespaces = append(namespaces, pod.Namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// ListDeployments returns the sample deployments of namespace, or all of
+// them if it is empty
+func (s *EKSService) ListDeployments(ctx context.Context, cluster, namespace string) ([]clients.Deployment, error) {
+	if err := s.cluster(cluster); err != nil {
+		return nil, err
+	}
+	var deployments []clients.Deployment
+	for _, deployment := range s.deployments {
+		if namespace == "" || deployment.Namespace == namespace {
+			deployments = append(deployments, deployment)
+		}
+	}
+	return deployments, nil
+}
+
+// ListPods returns the sample pods of namespace, or all of them if it is
+// empty
+func (s *EKSService) ListPods(ctx context.Context, cluster, namespace string) ([]clients.Pod, error) {
+	if err := s.cluster(cluster); err != nil {
+		return nil, err
+	}
+	var pods []clients.Pod
+	for _, pod := range s.pods {
+		if namespace == "" || pod.Namespace == namespace {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+// StreamPodLogs sends up to tailLines made up lines of every container of
+// pod and then a new line every podLogInterval until ctx is done. A pod
+// that is still pending has no logs yet.
+func (s *EKSService) StreamPodLogs(ctx context.Context, cluster string, pod clients.Pod, tailLines int, lines chan<- clients.PodLogLine) error {
+	if err := s.cluster(cluster); err != nil {
+		return err
+	}
+	if pod.Status == "Pending" {
+		return apiError("BadRequest", fmt.Sprintf("container %q in pod %q is waiting to start: ContainerCreating", pod.Containers[0], pod.Name))
+	}
+
+	send := func(line clients.PodLogLine) bool {
+		select {
+		case lines <- line:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	const history = 20
+	start := time.Now().Add(-history * 3 * time.Second)
+	for i := max(history-tailLines, 0); i < history; i++ {
+		for _, container := range pod.Containers {
+			if !send(podLogLine(pod, container, i, start.Add(time.Duration(i)*3*time.Second))) {
+				return nil
+			}
+		}
+	}
+
+	ticker := time.NewTicker(podLogInterval)
+	defer ticker.Stop()
+	for i := history; ; i++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if !send(podLogLine(pod, pod.Containers[i%len(pod.Containers)], i, now)) {
+				return nil
+			}
+		}
+	}
+}
+
+// podLogLine makes up line i of container of pod: access logs for the
+// sidecar, a crash for a crash looping pod and requests otherwise
+func podLogLine(pod clients.Pod, container string, i int, at time.Time) clients.PodLogLine {
+	paths := []string{"/healthz", "/api/cart", "/api/cart/items", "/api/products/1182", "/api/orders"}
+	path := paths[i%len(paths)]
+
+	var message string
+	switch {
+	case container == "envoy":
+		message = fmt.Sprintf(`[%s] "GET %s HTTP/1.1" 200 - 0 %d %d "10.20.1.4"`, at.UTC().Format(time.RFC3339Nano), path, 180+i*7%900, 2+i%13)
+	case pod.Status == "CrashLoopBackOff" && i%5 == 4:
+		message = `panic: runtime error: invalid memory address or nil pointer dereference [signal SIGSEGV: segmentation violation code=0x1 addr=0x18]`
+	case pod.Status == "CrashLoopBackOff" && i%5 == 3:
+		message = `level=error msg="redis: connection refused" addr=cart-cache.shop.svc:6379`
+	case pod.Namespace == "kube-system":
+		message = fmt.Sprintf(`[INFO] 10.20.1.%d:53211 - %d "A IN orders.shop.svc.cluster.local. udp 48 false 512" NOERROR qr,aa,rd 106 0.0001s`, 10+i%40, 4000+i)
+	default:
+		message = fmt.Sprintf(`level=info msg="request handled" method=GET path=%s status=200 duration=%dms`, path, 3+i*11%70)
+	}
+	return clients.PodLogLine{Pod: pod.Name, Namespace: pod.Namespace, Container: container, Timestamp: at, Message: message}
+}
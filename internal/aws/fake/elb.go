@@ -0,0 +1,41 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// ELBService lists a fixed set of load balancers, one of them without targets
+type ELBService struct {
+	balancers []clients.LoadBalancerDetail
+}
+
+// NewELBService returns the load balancer of the web shop and a forgotten one
+func NewELBService() *ELBService {
+	created := time.Now().AddDate(-1, 0, 0).Truncate(24 * time.Hour)
+	balancer := func(name, lbType, id string, targets int) clients.LoadBalancerDetail {
+		return clients.LoadBalancerDetail{
+			Name:        name,
+			ARN:         fmt.Sprintf("arn:aws:elasticloadbalancing:%s:%s:loadbalancer/%s/%s/%s", Region, Account, lbType[:3], name, id),
+			Type:        lbType,
+			State:       "active",
+			CreatedTime: &created,
+			TargetCount: targets,
+		}
+	}
+
+	return &ELBService{
+		balancers: []clients.LoadBalancerDetail{
+			balancer("web-prod", "application", "50dc6c495c0c9188", 2),
+			balancer("promo-2023", "application", "73e2d6bc24d8a067", 0),
+		},
+	}
+}
+
+// DescribeLoadBalancers returns all load balancers
+func (s *ELBService) DescribeLoadBalancers(ctx context.Context) ([]clients.LoadBalancerDetail, error) {
+	return append([]clients.LoadBalancerDetail(nil), s.balancers...), nil
+}
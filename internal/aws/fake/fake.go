@@ -0,0 +1,83 @@
+// Package fake provides in-memory implementations of the AWS service
+// interfaces with realistic sample data. It backs the --demo mode and tests
+// that need a client without AWS credentials.
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"swiss-army-tui/internal/aws"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+)
+
+// Identity of the demo account
+const (
+	Profile = "demo"
+	Region  = "us-east-1"
+	Account = "123456789012"
+)
+
+// NewServices returns fresh in-memory services filled with sample data
+func NewServices() *aws.ServiceClients {
+	return &aws.ServiceClients{
+		EC2:            NewEC2Service(),
+		S3:             NewS3Service(),
+		RDS:            NewRDSService(),
+		Lambda:         NewLambdaService(),
+		CloudWatchLogs: NewCloudWatchLogsService(),
+		CloudWatch:     NewCloudWatchService(),
+		ELB:            NewELBService(),
+		SavingsPlans:   NewSavingsPlansService(),
+		Athena:         NewAthenaService(),
+		SES:            NewSESService(),
+		ACM:            NewACMService(),
+		WAF:            NewWAFService(),
+		Support:        NewSupportService(),
+		Health:         NewHealthService(),
+		DynamoDB:       NewDynamoDBService(),
+		AppAutoScaling: NewApplicationAutoScalingService(),
+		AutoScaling:    NewAutoScalingService(),
+		SNS:            NewSNSService(),
+		SQS:            NewSQSService(),
+		EKS:            NewEKSService(),
+		ECS:            NewECSService(),
+		ECR:            NewECRService(),
+		IAM:            NewIAMService(),
+		CloudFormation: NewCloudFormationService(),
+		Transfer:       NewTransferService(),
+		DataSync:       NewDataSyncService(),
+		Batch:          NewBatchService(),
+		SageMaker:      NewSageMakerService(),
+		Bedrock:        NewBedrockService(),
+		AppConfig:      NewAppConfigService(),
+		AccessAnalyzer: NewAccessAnalyzerService(),
+		PI:             NewPerformanceInsightsService(),
+		STS:            &STSService{},
+	}
+}
+
+// NewClient returns a client for the demo account backed by NewServices
+func NewClient() *aws.Client {
+	return aws.NewClientWithServices(Profile, Region, NewServices())
+}
+
+// STSService returns the identity of the demo account
+type STSService struct{}
+
+// GetCallerIdentity returns a demo user of the demo account
+func (s *STSService) GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{
+		Account: awssdk.String(Account),
+		Arn:     awssdk.String(fmt.Sprintf("arn:aws:iam::%s:user/demo", Account)),
+		UserId:  awssdk.String("AIDADEMOUSER000000000"),
+	}, nil
+}
+
+// apiError returns an error shaped like an AWS API error with the given code
+func apiError(code, message string) error {
+	return &smithy.GenericAPIError{Code: code, Message: message, Fault: smithy.FaultClient}
+}
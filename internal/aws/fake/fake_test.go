@@ -0,0 +1,232 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestNewClient(t *testing.T) {
+	client := NewClient()
+	if _, err := client.WaitForIdentity(context.Background()); err != nil {
+		t.Fatalf("WaitForIdentity returned error: %v", err)
+	}
+	if got := client.GetAccountID(); got != Account {
+		t.Errorf("account = %q, want %q", got, Account)
+	}
+	if got := client.GetProfile(); got != Profile {
+		t.Errorf("profile = %q, want %q", got, Profile)
+	}
+
+	instances, err := client.GetEC2FunctionDetails(context.Background(), nil)
+	if err != nil || len(instances) == 0 {
+		t.Errorf("GetEC2FunctionDetails = %d instances, %v", len(instances), err)
+	}
+}
+
+func TestEC2StateChanges(t *testing.T) {
+	ctx := context.Background()
+	svc := NewEC2Service()
+
+	state := func(id string) types.InstanceStateName {
+		instances, _ := svc.GetEC2Detail(ctx, nil)
+		for _, instance := range instances {
+			if awssdk.ToString(instance.InstanceId) == id {
+				return instance.State.Name
+			}
+		}
+		return ""
+	}
+
+	id := "i-0d45e67f89a01b234"
+	if got := state(id); got != types.InstanceStateNameStopped {
+		t.Fatalf("initial state = %q, want stopped", got)
+	}
+	if err := svc.StartInstance(ctx, id); err != nil {
+		t.Fatalf("StartInstance returned error: %v", err)
+	}
+	if got := state(id); got != types.InstanceStateNamePending {
+		t.Errorf("state right after start = %q, want pending", got)
+	}
+
+	svc.SetTransitionDelay(0)
+	if err := svc.StartInstance(ctx, id); err != nil {
+		t.Fatalf("StartInstance returned error: %v", err)
+	}
+	instance, err := svc.DescribeInstance(ctx, id)
+	if err != nil || instance.State.Name != types.InstanceStateNameRunning {
+		t.Errorf("DescribeInstance = %v, %v, want running", instance.State, err)
+	}
+	if err := svc.TerminateInstance(ctx, id); err != nil {
+		t.Fatalf("TerminateInstance returned error: %v", err)
+	}
+	if err := svc.StartInstance(ctx, id); err == nil {
+		t.Error("StartInstance on a terminated instance succeeded")
+	}
+	if err := svc.StopInstance(ctx, "i-unknown"); err == nil {
+		t.Error("StopInstance on an unknown instance succeeded")
+	}
+	if allowed, _ := svc.CanStopInstance(ctx, protectedInstance); allowed {
+		t.Error("CanStopInstance allowed the protected instance")
+	}
+	if err := svc.StopInstance(ctx, protectedInstance); err == nil {
+		t.Error("StopInstance on the protected instance succeeded")
+	}
+}
+
+func TestPartialFailures(t *testing.T) {
+	ctx := context.Background()
+
+	functions, err := NewLambdaService().GetLambdaDetail(ctx)
+	var partial *clients.PartialError
+	if !errors.As(err, &partial) {
+		t.Fatalf("GetLambdaDetail error = %v, want a partial error", err)
+	}
+	if len(partial.Failures) != 1 || partial.Failures[0].Reason() != "access denied" {
+		t.Errorf("failures = %+v, want one access denied", partial.Failures)
+	}
+	if len(functions) != 5 {
+		t.Errorf("got %d functions, want 5", len(functions))
+	}
+
+	buckets, err := NewS3Service().GetS3Detail(ctx)
+	if !errors.As(err, &partial) {
+		t.Fatalf("GetS3Detail error = %v, want a partial error", err)
+	}
+	for _, bucket := range buckets {
+		if bucket.Name != restrictedBucket && bucket.Region == "" {
+			t.Errorf("bucket %s has no region", bucket.Name)
+		}
+	}
+}
+
+func TestLogEventsAreStable(t *testing.T) {
+	svc := NewCloudWatchLogsService()
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	group := "/ecs/orders-service"
+	streams, err := svc.DescribeLogStreams(context.Background(), group, 0)
+	if err != nil || len(streams) == 0 {
+		t.Fatalf("DescribeLogStreams = %d streams, %v", len(streams), err)
+	}
+
+	stream := streams[0].LogStreamName
+	first, token, _ := svc.GetLogEvents(context.Background(), group, stream, 20, false)
+	second, _, _ := svc.GetLogEvents(context.Background(), group, stream, 20, false)
+	if len(first) != 20 || len(second) != 20 {
+		t.Fatalf("got %d and %d events, want 20", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("event %d differs between calls: %+v != %+v", i, first[i], second[i])
+		}
+	}
+
+	now = now.Add(time.Minute)
+	newer, _, err := svc.GetLogEventsWithToken(context.Background(), group, stream, *token, 100)
+	if err != nil {
+		t.Fatalf("GetLogEventsWithToken returned error: %v", err)
+	}
+	if len(newer) == 0 || newer[0].Timestamp <= first[len(first)-1].Timestamp {
+		t.Errorf("GetLogEventsWithToken returned %d events not after the token", len(newer))
+	}
+
+	if _, err := svc.FilterLogEvents(context.Background(), "/missing", "", now); err == nil {
+		t.Error("FilterLogEvents on a missing group succeeded")
+	}
+}
+
+func TestTailLogGroupClosesChannels(t *testing.T) {
+	svc := NewCloudWatchLogsService()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events := make(chan clients.LogEvent, 1000)
+	errs := make(chan error, 1)
+	go svc.TailLogGroup(ctx, "/ecs/orders-service", "", time.Now().Add(-time.Minute), events, errs)
+
+	select {
+	case <-events:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no events received")
+	}
+	cancel()
+
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				if _, ok := <-errs; ok {
+					t.Error("error channel not closed")
+				}
+				return
+			}
+		case <-deadline:
+			t.Fatal("events channel not closed after cancel")
+		}
+	}
+}
+
+func TestAthenaQueries(t *testing.T) {
+	ctx := context.Background()
+	svc := NewAthenaService()
+	svc.SetQueryDuration(0)
+
+	id, err := svc.StartQuery(ctx, "SELECT * FROM orders LIMIT 150", "primary", "sales")
+	if err != nil {
+		t.Fatalf("StartQuery returned error: %v", err)
+	}
+	status, err := svc.GetQueryStatus(ctx, id)
+	if err != nil || status.State != clients.QuerySucceeded {
+		t.Fatalf("GetQueryStatus = %+v, %v, want succeeded", status, err)
+	}
+
+	var rows int
+	token := ""
+	for pages := 1; ; pages++ {
+		page, err := svc.GetQueryResults(ctx, id, token, 100)
+		if err != nil {
+			t.Fatalf("GetQueryResults returned error: %v", err)
+		}
+		if len(page.Columns) != 5 || page.Columns[0] != "order_id" {
+			t.Errorf("columns = %v, want the orders columns", page.Columns)
+		}
+		rows += len(page.Rows)
+		if token = page.NextToken; token == "" {
+			if pages != 2 {
+				t.Errorf("got %d pages, want 2", pages)
+			}
+			break
+		}
+	}
+	if rows != 150 {
+		t.Errorf("got %d rows, want 150", rows)
+	}
+
+	id, _ = svc.StartQuery(ctx, "SELECT * FROM missing", "primary", "sales")
+	if status, _ := svc.GetQueryStatus(ctx, id); status.State != clients.QueryFailed || status.Reason == "" {
+		t.Errorf("status of a query of a missing table = %+v, want failed with a reason", status)
+	}
+	if _, err := svc.GetQueryResults(ctx, id, "", 100); err == nil {
+		t.Error("GetQueryResults of a failed query succeeded")
+	}
+
+	svc.SetQueryDuration(time.Hour)
+	id, _ = svc.StartQuery(ctx, "SHOW TABLES", "primary", "sales")
+	if status, _ := svc.GetQueryStatus(ctx, id); status.State != clients.QueryQueued {
+		t.Errorf("state right after start = %q, want queued", status.State)
+	}
+	if err := svc.StopQuery(ctx, id); err != nil {
+		t.Fatalf("StopQuery returned error: %v", err)
+	}
+	if status, _ := svc.GetQueryStatus(ctx, id); status.State != clients.QueryCancelled {
+		t.Errorf("state after stop = %q, want cancelled", status.State)
+	}
+}
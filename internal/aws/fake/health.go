@@ -0,0 +1,91 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// HealthService has an ongoing EC2 issue impairing an instance of the
+// sample account, scheduled maintenance and a public S3 issue elsewhere
+type HealthService struct {
+	events       []clients.HealthEvent
+	descriptions map[string]string
+	entities     map[string][]clients.AffectedEntity
+}
+
+// NewHealthService returns the sample events, relative to now
+func NewHealthService() *HealthService {
+	now := time.Now().UTC().Truncate(time.Minute)
+	arn := func(region, service, typ, id string) string {
+		return fmt.Sprintf("arn:aws:health:%s::event/%s/%s/%s", region, service, typ, id)
+	}
+
+	ec2Issue := arn(Region, "EC2", "AWS_EC2_OPERATIONAL_ISSUE", "AWS_EC2_OPERATIONAL_ISSUE_7XK2Q")
+	retirement := arn(Region, "EC2", "AWS_EC2_INSTANCE_RETIREMENT_SCHEDULED", "AWS_EC2_INSTANCE_RETIREMENT_SCHEDULED_93F1A")
+	rdsUpgrade := arn(Region, "RDS", "AWS_RDS_SYSTEM_UPGRADE_SCHEDULED", "AWS_RDS_SYSTEM_UPGRADE_SCHEDULED_5D0C2")
+	lambdaUpdate := arn(Region, "LAMBDA", "AWS_LAMBDA_PLANNED_LIFECYCLE_EVENT", "AWS_LAMBDA_PLANNED_LIFECYCLE_EVENT_1B8E4")
+	s3Issue := arn("eu-west-1", "S3", "AWS_S3_OPERATIONAL_ISSUE", "AWS_S3_OPERATIONAL_ISSUE_EU4W1")
+
+	instance := func(id string) string {
+		return fmt.Sprintf("arn:aws:ec2:%s:%s:instance/%s", Region, Account, id)
+	}
+
+	return &HealthService{
+		events: []clients.HealthEvent{
+			{ARN: ec2Issue, Service: "EC2", Type: "AWS_EC2_OPERATIONAL_ISSUE", Category: "issue", Status: "open",
+				Region: Region, AvailabilityZone: "us-east-1c", AccountSpecific: true, Start: now.Add(-47 * time.Minute), LastUpdated: now.Add(-12 * time.Minute)},
+			{ARN: retirement, Service: "EC2", Type: "AWS_EC2_INSTANCE_RETIREMENT_SCHEDULED", Category: "scheduledChange", Status: "upcoming",
+				Region: Region, AccountSpecific: true, Start: now.Add(14 * 24 * time.Hour), End: now.Add(14*24*time.Hour + time.Hour), LastUpdated: now.Add(-2 * 24 * time.Hour)},
+			{ARN: rdsUpgrade, Service: "RDS", Type: "AWS_RDS_SYSTEM_UPGRADE_SCHEDULED", Category: "scheduledChange", Status: "upcoming",
+				Region: Region, AccountSpecific: true, Start: now.Add(9 * 24 * time.Hour), End: now.Add(9*24*time.Hour + 30*time.Minute), LastUpdated: now.Add(-5 * 24 * time.Hour)},
+			{ARN: lambdaUpdate, Service: "LAMBDA", Type: "AWS_LAMBDA_PLANNED_LIFECYCLE_EVENT", Category: "accountNotification", Status: "open",
+				Region: Region, AccountSpecific: true, Start: now.Add(-20 * 24 * time.Hour), LastUpdated: now.Add(-20 * 24 * time.Hour)},
+			{ARN: s3Issue, Service: "S3", Type: "AWS_S3_OPERATIONAL_ISSUE", Category: "issue", Status: "open",
+				Region: "eu-west-1", Start: now.Add(-25 * time.Minute), LastUpdated: now.Add(-5 * time.Minute)},
+		},
+		descriptions: map[string]string{
+			ec2Issue:     "We are investigating increased API error rates and connectivity issues for some instances in a single Availability Zone (use1-az6) in the US-EAST-1 Region.",
+			retirement:   "EC2 has detected degradation of the underlying hardware hosting your Amazon EC2 instance. Due to this degradation your instance could already be unreachable. Your instance will be stopped after the scheduled time.",
+			rdsUpgrade:   "A system update is available for your Amazon RDS DB instance. It will be applied during your maintenance window.",
+			lambdaUpdate: "We are moving functions on the Python 3.12 runtime to an updated base image. Functions are updated automatically and need no action unless they bundle native dependencies.",
+			s3Issue:      "We are investigating elevated error rates for S3 PUT requests in the EU-WEST-1 Region.",
+		},
+		entities: map[string][]clients.AffectedEntity{
+			ec2Issue: {
+				{Value: "i-0c34d56e78f90a123", ARN: instance("i-0c34d56e78f90a123"), Status: "IMPAIRED", LastUpdated: now.Add(-12 * time.Minute)},
+				{Value: "i-0b23c45d67e89f012", ARN: instance("i-0b23c45d67e89f012"), Status: "UNIMPAIRED", LastUpdated: now.Add(-30 * time.Minute)},
+			},
+			retirement: {
+				{Value: "i-0d45e67f89a01b234", ARN: instance("i-0d45e67f89a01b234"), Status: "PENDING", LastUpdated: now.Add(-2 * 24 * time.Hour)},
+			},
+			rdsUpgrade: {
+				{Value: "orders-prod", ARN: fmt.Sprintf("arn:aws:rds:%s:%s:db:orders-prod", Region, Account), Status: "PENDING", LastUpdated: now.Add(-5 * 24 * time.Hour)},
+			},
+			lambdaUpdate: {
+				{Value: "orders-worker", ARN: fmt.Sprintf("arn:aws:lambda:%s:%s:function:orders-worker", Region, Account), Status: "UNKNOWN", LastUpdated: now.Add(-20 * 24 * time.Hour)},
+			},
+		},
+	}
+}
+
+// ListOpenEvents returns the sample events
+func (s *HealthService) ListOpenEvents(ctx context.Context) ([]clients.HealthEvent, error) {
+	return append([]clients.HealthEvent(nil), s.events...), nil
+}
+
+// GetEventDescription returns the description of the sample event with arn
+func (s *HealthService) GetEventDescription(ctx context.Context, arn string) (string, error) {
+	description, ok := s.descriptions[arn]
+	if !ok {
+		return "", apiError("InvalidParameterValueException", "Event "+arn+" not found")
+	}
+	return description, nil
+}
+
+// ListAffectedEntities returns the sample resources affected by the event with arn
+func (s *HealthService) ListAffectedEntities(ctx context.Context, arn string) ([]clients.AffectedEntity, error) {
+	return append([]clients.AffectedEntity(nil), s.entities[arn]...), nil
+}
@@ -0,0 +1,124 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// IAMService holds the roles of the demo account: roles of the shop's
+// instances, functions and deployments in daily use, an audit role of a
+// partner unused for months, an ETL role never used and a role created days
+// ago
+type IAMService struct {
+	roles    []clients.IAMRole
+	accessed map[string][]clients.ServiceLastAccessed
+}
+
+// trustService is a trust policy letting an AWS service assume a role
+func trustService(service string) string {
+	return fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"%s"},"Action":"sts:AssumeRole"}]}`, service)
+}
+
+// NewIAMService returns the sample roles
+func NewIAMService() *IAMService {
+	now := time.Now()
+	day := 24 * time.Hour
+	role := func(name, path, description string, created, lastUsed time.Duration, trust string) clients.IAMRole {
+		r := clients.IAMRole{
+			Name:               name,
+			ID:                 fmt.Sprintf("AROA%016X", len(name)*7919+int(created/day)),
+			ARN:                fmt.Sprintf("arn:aws:iam::%s:role%s%s", Account, path, name),
+			Path:               path,
+			Description:        description,
+			CreatedAt:          now.Add(-created),
+			MaxSessionDuration: 3600,
+			TrustPolicy:        trust,
+		}
+		if lastUsed > 0 {
+			r.LastUsed = now.Add(-lastUsed)
+			r.LastUsedRegion = Region
+		}
+		return r
+	}
+	accessed := func(service, namespace string, ago time.Duration) clients.ServiceLastAccessed {
+		a := clients.ServiceLastAccessed{Service: service, Namespace: namespace}
+		if ago > 0 {
+			a.LastAuthenticated = now.Add(-ago)
+			a.LastAuthenticatedRegion = Region
+		}
+		return a
+	}
+
+	return &IAMService{
+		roles: []clients.IAMRole{
+			role("AWSServiceRoleForECS", "/aws-service-role/ecs.amazonaws.com/", "Role to enable Amazon ECS to manage your cluster.",
+				700*day, time.Hour, trustService("ecs.amazonaws.com")),
+			role("github-actions-deploy", "/", "Deploys the shop from GitHub Actions", 200*day, 20*time.Hour,
+				`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Federated":"arn:aws:iam::`+Account+`:oidc-provider/token.actions.githubusercontent.com"},"Action":"sts:AssumeRoleWithWebIdentity","Condition":{"StringEquals":{"token.actions.githubusercontent.com:aud":"sts.amazonaws.com"},"StringLike":{"token.actions.githubusercontent.com:sub":"repo:shop/*:ref:refs/heads/main"}}}]}`),
+			role("legacy-etl-role", "/", "Nightly export to the old warehouse", 730*day, 0, trustService("glue.amazonaws.com")),
+			role("new-analytics-role", "/", "", 3*day, 0, trustService("athena.amazonaws.com")),
+			role("orders-lambda-role", "/service-role/", "", 400*day, 2*time.Hour, trustService("lambda.amazonaws.com")),
+			role("partner-audit", "/", "Read-only access for the external auditor", 500*day, 143*day,
+				`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::999988887777:root"]},"Action":"sts:AssumeRole","Condition":{"StringEquals":{"sts:ExternalId":"audit-2025"}}}}`),
+			role("web-instance-role", "/", "Instance profile of the web servers", 600*day, 5*time.Minute, trustService("ec2.amazonaws.com")),
+		},
+		accessed: map[string][]clients.ServiceLastAccessed{
+			"AWSServiceRoleForECS": {
+				accessed("Amazon EC2", "ec2", time.Hour),
+				accessed("Elastic Load Balancing", "elasticloadbalancing", time.Hour),
+				accessed("AWS Cloud Map", "servicediscovery", 0),
+			},
+			"github-actions-deploy": {
+				accessed("Amazon Elastic Container Registry", "ecr", 20*time.Hour),
+				accessed("Amazon Elastic Container Service", "ecs", 20*time.Hour),
+				accessed("AWS CloudFormation", "cloudformation", 12*day),
+				accessed("AWS Lambda", "lambda", 0),
+			},
+			"legacy-etl-role": {
+				accessed("AWS Glue", "glue", 0),
+				accessed("Amazon S3", "s3", 0),
+			},
+			"new-analytics-role": {
+				accessed("Amazon Athena", "athena", 0),
+				accessed("Amazon S3", "s3", 0),
+			},
+			"orders-lambda-role": {
+				accessed("Amazon DynamoDB", "dynamodb", 2*time.Hour),
+				accessed("Amazon CloudWatch Logs", "logs", 2*time.Hour),
+				accessed("Amazon SQS", "sqs", 3*day),
+			},
+			"partner-audit": {
+				accessed("AWS Identity and Access Management", "iam", 143*day),
+				accessed("Amazon S3", "s3", 150*day),
+				accessed("AWS CloudTrail", "cloudtrail", 0),
+			},
+			"web-instance-role": {
+				accessed("Amazon S3", "s3", 5*time.Minute),
+				accessed("Amazon CloudWatch Logs", "logs", 5*time.Minute),
+				accessed("AWS Systems Manager", "ssm", 2*day),
+				accessed("Amazon DynamoDB", "dynamodb", 0),
+			},
+		},
+	}
+}
+
+// ListRoles returns the sample roles
+func (s *IAMService) ListRoles(ctx context.Context) ([]clients.IAMRole, error) {
+	return append([]clients.IAMRole(nil), s.roles...), nil
+}
+
+// GetServiceLastAccessed returns the sample services last accessed by the
+// role arn
+func (s *IAMService) GetServiceLastAccessed(ctx context.Context, arn string) ([]clients.ServiceLastAccessed, error) {
+	for _, role := range s.roles {
+		if role.ARN == arn {
+			services := append([]clients.ServiceLastAccessed(nil), s.accessed[role.Name]...)
+			clients.SortServicesLastAccessed(services)
+			return services, nil
+		}
+	}
+	return nil, apiError("NoSuchEntity", fmt.Sprintf("The role with ARN %s cannot be found.", arn))
+}
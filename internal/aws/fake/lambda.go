@@ -0,0 +1,335 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// LambdaService lists a fixed set of functions. The configuration of
+// restrictedFunction cannot be read, to show partial failures. Concurrency
+// settings can be changed and provisioned concurrency is ready at once.
+// orders-api is mid canary: its live alias sends 10% to the newest version.
+type LambdaService struct {
+	functions []clients.LambdaFunctionDetail
+
+	mu       sync.Mutex
+	reserved map[string]int32
+	// provisioned holds the provisioned executions by function and alias
+	provisioned map[string]map[string]int32
+	aliases     map[string][]clients.LambdaAlias
+	// versions holds the published versions by function
+	versions map[string][]string
+}
+
+// lambdaAccountLimit is the concurrency limit of the sample account
+const lambdaAccountLimit = 1000
+
+// lambdaMinUnreserved is the concurrency Lambda keeps unreserved
+const lambdaMinUnreserved = 100
+
+const restrictedFunction = "billing-reconcile"
+
+// idleFunction was last deployed long ago and is no longer invoked
+const idleFunction = "image-resizer"
+
+// NewLambdaService returns the functions of a small order pipeline
+func NewLambdaService() *LambdaService {
+	modified := time.Now().Add(-36 * time.Hour).UTC().Format("2006-01-02T15:04:05.000+0000")
+	function := func(name, runtime, handler, description string, memory, timeout int32, codeSize int64) clients.LambdaFunctionDetail {
+		return clients.LambdaFunctionDetail{
+			FunctionName:     name,
+			Runtime:          runtime,
+			Handler:          handler,
+			MemorySize:       memory,
+			Timeout:          timeout,
+			SnapStartStatus:  "Not Available",
+			State:            "Active",
+			LastUpdateStatus: "Successful",
+			LastModified:     modified,
+			Description:      description,
+			CodeSize:         codeSize,
+			LogGroupName:     fmt.Sprintf("/aws/lambda/%s", name),
+		}
+	}
+
+	functions := []clients.LambdaFunctionDetail{
+		function("orders-api", "nodejs20.x", "index.handler", "Public orders API", 512, 15, 2_481_233),
+		function("orders-worker", "python3.12", "worker.handle", "Processes queued orders", 1024, 300, 8_113_572),
+		function(idleFunction, "nodejs20.x", "resize.handler", "Resizes uploaded product images", 2048, 60, 31_904_118),
+		function("nightly-report", "python3.12", "report.main", "Builds the nightly sales report", 256, 900, 412_004),
+		function(restrictedFunction, "java21", "com.acme.Reconcile::handle", "Reconciles payments", 1536, 120, 22_310_987),
+	}
+	functions[2].LastModified = time.Now().AddDate(0, -7, 0).UTC().Format("2006-01-02T15:04:05.000+0000")
+
+	return &LambdaService{
+		functions: functions,
+		reserved:  map[string]int32{"orders-api": 100, "orders-worker": 10},
+		provisioned: map[string]map[string]int32{
+			"orders-api": {"live": 20},
+		},
+		aliases: map[string][]clients.LambdaAlias{
+			"orders-api": {
+				{Name: "live", Version: "3", AdditionalVersion: "4", Weight: 0.1},
+				{Name: "staging", Version: "4"},
+			},
+			"orders-worker": {{Name: "live", Version: "2"}},
+		},
+		versions: map[string][]string{
+			"orders-api":    {"1", "2", "3", "4"},
+			"orders-worker": {"1", "2"},
+		},
+	}
+}
+
+// ListLambdaFunctions returns all functions
+func (s *LambdaService) ListLambdaFunctions(ctx context.Context) ([]clients.LambdaFunctionDetail, error) {
+	return append([]clients.LambdaFunctionDetail(nil), s.functions...), nil
+}
+
+// GetLambdaFunction returns the configuration of a function
+func (s *LambdaService) GetLambdaFunction(ctx context.Context, functionName string) (clients.LambdaFunctionDetail, error) {
+	if functionName == restrictedFunction {
+		return clients.LambdaFunctionDetail{}, apiError("AccessDeniedException",
+			fmt.Sprintf("User is not authorized to perform: lambda:GetFunctionConfiguration on resource: %s", functionName))
+	}
+
+	for _, function := range s.functions {
+		if function.FunctionName == functionName {
+			return function, nil
+		}
+	}
+	return clients.LambdaFunctionDetail{}, apiError("ResourceNotFoundException", fmt.Sprintf("Function not found: %s", functionName))
+}
+
+// GetLambdaDetail returns all functions with a *clients.PartialError for
+// those whose configuration cannot be read
+func (s *LambdaService) GetLambdaDetail(ctx context.Context) ([]clients.LambdaFunctionDetail, error) {
+	var failures []clients.ItemError
+	for _, function := range s.functions {
+		if _, err := s.GetLambdaFunction(ctx, function.FunctionName); err != nil {
+			failures = append(failures, clients.ItemError{Item: function.FunctionName, Err: err})
+		}
+	}
+
+	functions, _ := s.ListLambdaFunctions(ctx)
+	if len(failures) > 0 {
+		return functions, &clients.PartialError{Op: "get Lambda function details", Failures: failures}
+	}
+	return functions, nil
+}
+
+// GetConcurrency returns the concurrency settings of a function
+func (s *LambdaService) GetConcurrency(ctx context.Context, functionName string) (clients.LambdaConcurrency, error) {
+	if _, err := s.GetLambdaFunction(ctx, functionName); err != nil {
+		return clients.LambdaConcurrency{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	concurrency := clients.LambdaConcurrency{
+		AccountLimit:      lambdaAccountLimit,
+		AccountUnreserved: lambdaAccountLimit - s.totalReserved(""),
+	}
+	for _, alias := range s.aliases[functionName] {
+		concurrency.Aliases = append(concurrency.Aliases, alias.Name)
+	}
+	if reserved, ok := s.reserved[functionName]; ok {
+		concurrency.Reserved = &reserved
+	}
+
+	qualifiers := make([]string, 0, len(s.provisioned[functionName]))
+	for qualifier := range s.provisioned[functionName] {
+		qualifiers = append(qualifiers, qualifier)
+	}
+	sort.Strings(qualifiers)
+	for _, qualifier := range qualifiers {
+		executions := s.provisioned[functionName][qualifier]
+		concurrency.Provisioned = append(concurrency.Provisioned, clients.ProvisionedConcurrency{
+			Qualifier: qualifier,
+			Requested: executions,
+			Allocated: executions,
+			Available: executions,
+			Status:    "READY",
+		})
+	}
+	return concurrency, nil
+}
+
+// totalReserved returns the executions reserved by all functions other than except
+func (s *LambdaService) totalReserved(except string) int32 {
+	var total int32
+	for name, reserved := range s.reserved {
+		if name != except {
+			total += reserved
+		}
+	}
+	return total
+}
+
+// PutReservedConcurrency reserves executions for a function, keeping the
+// minimum unreserved concurrency of the account
+func (s *LambdaService) PutReservedConcurrency(ctx context.Context, functionName string, executions int32) error {
+	if _, err := s.GetLambdaFunction(ctx, functionName); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if lambdaAccountLimit-s.totalReserved(functionName)-executions < lambdaMinUnreserved {
+		return apiError("InvalidParameterValueException",
+			fmt.Sprintf("Specified ReservedConcurrentExecutions for function decreases account's UnreservedConcurrentExecution below its minimum value of [%d].", lambdaMinUnreserved))
+	}
+	for _, provisioned := range s.provisioned[functionName] {
+		if provisioned > executions {
+			return apiError("InvalidParameterValueException",
+				"Requested Provisioned Concurrency should not be greater than the reservedConcurrentExecution for function")
+		}
+	}
+	s.reserved[functionName] = executions
+	return nil
+}
+
+// DeleteReservedConcurrency returns a function to the unreserved pool
+func (s *LambdaService) DeleteReservedConcurrency(ctx context.Context, functionName string) error {
+	if _, err := s.GetLambdaFunction(ctx, functionName); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.reserved, functionName)
+	return nil
+}
+
+// PutProvisionedConcurrency provisions executions for an alias of a function
+func (s *LambdaService) PutProvisionedConcurrency(ctx context.Context, functionName, qualifier string, executions int32) error {
+	if _, err := s.GetLambdaFunction(ctx, functionName); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	known := false
+	for _, alias := range s.aliases[functionName] {
+		known = known || alias.Name == qualifier
+	}
+	if !known {
+		return apiError("ResourceNotFoundException", fmt.Sprintf("Cannot find alias arn: arn:aws:lambda:%s:%s:function:%s:%s", Region, Account, functionName, qualifier))
+	}
+	if reserved, ok := s.reserved[functionName]; ok && executions > reserved {
+		return apiError("InvalidParameterValueException",
+			"Requested Provisioned Concurrency should not be greater than the reservedConcurrentExecution for function")
+	}
+
+	if s.provisioned[functionName] == nil {
+		s.provisioned[functionName] = make(map[string]int32)
+	}
+	s.provisioned[functionName][qualifier] = executions
+	return nil
+}
+
+// DeleteProvisionedConcurrency removes the provisioned concurrency of an alias
+func (s *LambdaService) DeleteProvisionedConcurrency(ctx context.Context, functionName, qualifier string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.provisioned[functionName][qualifier]; !ok {
+		return apiError("ProvisionedConcurrencyConfigNotFoundException", fmt.Sprintf("No Provisioned Concurrency Config found for this function: %s:%s", functionName, qualifier))
+	}
+	delete(s.provisioned[functionName], qualifier)
+	return nil
+}
+
+// ListAliases returns the aliases of a function
+func (s *LambdaService) ListAliases(ctx context.Context, functionName string) ([]clients.LambdaAlias, error) {
+	if _, err := s.GetLambdaFunction(ctx, functionName); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]clients.LambdaAlias(nil), s.aliases[functionName]...), nil
+}
+
+// ListVersions returns the published versions of a function
+func (s *LambdaService) ListVersions(ctx context.Context, functionName string) ([]string, error) {
+	if _, err := s.GetLambdaFunction(ctx, functionName); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.versions[functionName]...), nil
+}
+
+// UpdateAliasRouting changes the versions an alias routes to, checking them
+// like Lambda does
+func (s *LambdaService) UpdateAliasRouting(ctx context.Context, functionName, alias, version, additionalVersion string, weight float64) error {
+	if _, err := s.GetLambdaFunction(ctx, functionName); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	published := func(v string) bool {
+		for _, published := range s.versions[functionName] {
+			if published == v {
+				return true
+			}
+		}
+		return false
+	}
+	if !published(version) {
+		return apiError("ResourceNotFoundException", fmt.Sprintf("Function not found: %s:%s", functionARN(functionName), version))
+	}
+	if additionalVersion != "" && !published(additionalVersion) {
+		return apiError("ResourceNotFoundException", fmt.Sprintf("Function not found: %s:%s", functionARN(functionName), additionalVersion))
+	}
+	if additionalVersion == version {
+		return apiError("InvalidParameterValueException", "Primary and additional version cannot be the same.")
+	}
+	if additionalVersion != "" && (weight < 0 || weight > 1) {
+		return apiError("InvalidParameterValueException", "Invalid weight. Weights must be between 0.0 and 1.0.")
+	}
+
+	for i, a := range s.aliases[functionName] {
+		if a.Name != alias {
+			continue
+		}
+		updated := clients.LambdaAlias{Name: alias, Version: version}
+		if additionalVersion != "" {
+			updated.AdditionalVersion, updated.Weight = additionalVersion, weight
+		}
+		s.aliases[functionName][i] = updated
+		return nil
+	}
+	return apiError("ResourceNotFoundException", fmt.Sprintf("Alias not found: %s:%s", functionARN(functionName), alias))
+}
+
+// ListEventSourceMappings returns orders-worker reading the orders-shipping queue
+func (s *LambdaService) ListEventSourceMappings(ctx context.Context, sourceARN string) ([]clients.EventSourceMapping, error) {
+	if sourceARN != queueARN("orders-shipping") {
+		return nil, nil
+	}
+	return []clients.EventSourceMapping{{
+		UUID:        "6d1f8b2e-41c7-4a0b-9d55-0e2f7c3a9b14",
+		FunctionARN: functionARN("orders-worker"),
+		SourceARN:   sourceARN,
+		State:       "Enabled",
+		BatchSize:   10,
+	}}, nil
+}
+
+// functionARN returns the ARN of the sample function name
+func functionARN(name string) string {
+	return fmt.Sprintf("arn:aws:lambda:%s:%s:function:%s", Region, Account, name)
+}
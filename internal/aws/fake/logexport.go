@@ -0,0 +1,84 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// exportDuration is how long an export task runs
+const exportDuration = 2 * time.Second
+
+// exportTask is an export task that completes exportDuration after it was
+// created, unless cancelled
+type exportTask struct {
+	id        string
+	completes time.Time
+	cancelled bool
+}
+
+// CreateExportTask starts exporting a log group. Like CloudWatch Logs, it
+// runs one task at a time.
+func (s *CloudWatchLogsService) CreateExportTask(ctx context.Context, logGroup, bucket, prefix string, from, to time.Time) (string, error) {
+	if !s.hasGroup(logGroup) {
+		return "", fmt.Errorf("failed to create export task for %s: %w", logGroup,
+			apiError("ResourceNotFoundException", "The specified log group does not exist."))
+	}
+	if bucket == "" || !to.After(from) {
+		return "", fmt.Errorf("failed to create export task for %s: %w", logGroup,
+			apiError("InvalidParameterException", "The destination bucket and a time range are required."))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for _, task := range s.exports {
+		if !task.cancelled && now.Before(task.completes) {
+			return "", fmt.Errorf("failed to create export task for %s: %w", logGroup,
+				apiError("LimitExceededException", "Resource limit exceeded."))
+		}
+	}
+	task := &exportTask{
+		id:        fmt.Sprintf("%08x-export", hash(logGroup+bucket+prefix, now.UnixNano())&0xffffffff),
+		completes: now.Add(exportDuration),
+	}
+	s.exports[task.id] = task
+	return task.id, nil
+}
+
+// DescribeExportTask returns whether the task is running, completed or
+// cancelled
+func (s *CloudWatchLogsService) DescribeExportTask(ctx context.Context, id string) (clients.ExportTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.exports[id]
+	if !ok {
+		return clients.ExportTask{}, fmt.Errorf("export task %s not found", id)
+	}
+	switch {
+	case task.cancelled:
+		return clients.ExportTask{ID: id, Status: "CANCELLED", Message: "Cancelled by user"}, nil
+	case s.now().Before(task.completes):
+		return clients.ExportTask{ID: id, Status: "RUNNING", Message: "Started successfully"}, nil
+	default:
+		return clients.ExportTask{ID: id, Status: "COMPLETED", Message: "Completed successfully"}, nil
+	}
+}
+
+// CancelExportTask cancels a running task
+func (s *CloudWatchLogsService) CancelExportTask(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.exports[id]
+	if !ok || !s.now().Before(task.completes) {
+		return fmt.Errorf("failed to cancel export task %s: %w", id,
+			apiError("InvalidOperationException", "The export task is not running."))
+	}
+	task.cancelled = true
+	return nil
+}
@@ -0,0 +1,398 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+)
+
+const (
+	// Every stream logs one event per eventInterval, offset by its index
+	eventInterval = 5 * time.Second
+	// How far back log history is generated
+	historyWindow = time.Hour
+	// How often the tail functions look for new events
+	pollInterval = time.Second
+	// How many log groups a page lists, few to show the paging
+	logGroupPage = 5
+	// A group of a source account linked to the demo account as a monitoring
+	// account, opened by its ARN
+	linkedAccount  = "999988887777"
+	linkedLogGroup = "/aws/lambda/partner-sync"
+)
+
+// Message templates; %d is replaced by a number derived from the event
+var logMessages = []string{
+	"INFO Received request GET /orders/%d",
+	"INFO Order %d created",
+	"DEBUG Cache hit for customer %d",
+	"INFO Completed request in %dms",
+	"WARN Slow query on orders table took %dms",
+	"INFO Processed batch of %d messages",
+	`{"level":"info","msg":"payment authorized","order_id":%d}`,
+	`{"level":"error","msg":"upstream timeout","attempt":%d}`,
+	"ERROR Failed to publish event: ThrottlingException (retry %d)",
+	"INFO Health check passed in %dms",
+}
+
+// CloudWatchLogsService generates a steady stream of log events for a
+// fixed set of log groups
+type CloudWatchLogsService struct {
+	groups  []string
+	streams int
+	now     func() time.Time
+
+	mu sync.Mutex
+	// exports are the export tasks by ID
+	exports map[string]*exportTask
+}
+
+// NewCloudWatchLogsService returns log groups for the demo Lambda functions,
+// an ECS service, the Batch jobs and the production database
+func NewCloudWatchLogsService() *CloudWatchLogsService {
+	groups := []string{
+		clients.BatchDefaultLogGroup,
+		"/aws/rds/instance/orders-prod/postgresql",
+		"/ecs/orders-service",
+	}
+	for _, function := range NewLambdaService().functions {
+		groups = append(groups, function.LogGroupName)
+	}
+	sort.Strings(groups)
+
+	return &CloudWatchLogsService{groups: groups, streams: 3, now: time.Now, exports: make(map[string]*exportTask)}
+}
+
+// ListLogGroups returns a page of logGroupPage groups whose names start with
+// prefix, the groups of the account followed by one of a linked account. The
+// token is the index of the first group of the page.
+func (s *CloudWatchLogsService) ListLogGroups(ctx context.Context, prefix, token string) (clients.LogGroupPage, error) {
+	var groups []clients.LogGroupInfo
+	for _, group := range s.logGroups() {
+		if strings.HasPrefix(group.Name, prefix) {
+			groups = append(groups, group)
+		}
+	}
+
+	start := 0
+	if token != "" {
+		var err error
+		if start, err = strconv.Atoi(token); err != nil || start > len(groups) {
+			return clients.LogGroupPage{}, fmt.Errorf("failed to list log groups: %w",
+				apiError("InvalidParameterException", "The specified nextToken is invalid."))
+		}
+	}
+	end := min(start+logGroupPage, len(groups))
+	page := clients.LogGroupPage{Groups: groups[start:end]}
+	if end < len(groups) {
+		page.NextToken = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+// ListAllLogGroups returns all log groups whose names start with prefix
+func (s *CloudWatchLogsService) ListAllLogGroups(ctx context.Context, prefix string) ([]clients.LogGroupInfo, error) {
+	var groups []clients.LogGroupInfo
+	for _, group := range s.logGroups() {
+		if strings.HasPrefix(group.Name, prefix) {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+// logGroups describes the groups of the account and the linked group
+func (s *CloudWatchLogsService) logGroups() []clients.LogGroupInfo {
+	groups := make([]clients.LogGroupInfo, 0, len(s.groups)+1)
+	for _, name := range s.groups {
+		group := clients.LogGroupInfo{
+			Name:          name,
+			ARN:           fmt.Sprintf("arn:aws:logs:%s:%s:log-group:%s", Region, Account, name),
+			Account:       Account,
+			RetentionDays: 14,
+			StoredBytes:   int64(hash(name, 0)%(5<<30)) + 1<<20,
+			Class:         "STANDARD",
+			CreatedAt:     s.now().AddDate(0, 0, -30-int(hash(name, 1)%300)),
+		}
+		switch {
+		case strings.HasPrefix(name, "/ecs/"):
+			group.RetentionDays = 30
+		case strings.HasPrefix(name, "/aws/rds/"):
+			// Never expires, the group to look at for storage cost
+			group.RetentionDays = 0
+		}
+		groups = append(groups, group)
+	}
+	groups = append(groups, clients.LogGroupInfo{
+		Name:          linkedLogGroup,
+		ARN:           fmt.Sprintf("arn:aws:logs:%s:%s:log-group:%s", Region, linkedAccount, linkedLogGroup),
+		Account:       linkedAccount,
+		RetentionDays: 7,
+		StoredBytes:   250 << 20,
+		Class:         "STANDARD",
+		CreatedAt:     s.now().AddDate(0, 0, -90),
+	})
+	return groups
+}
+
+// DescribeLogStreams returns the streams of a group, newest first
+func (s *CloudWatchLogsService) DescribeLogStreams(ctx context.Context, logGroupName string, limit int32) ([]clients.LogStreamInfo, error) {
+	if !s.hasGroup(logGroupName) {
+		return nil, fmt.Errorf("failed to describe log streams: %w",
+			apiError("ResourceNotFoundException", "The specified log group does not exist."))
+	}
+
+	now := s.now().UnixMilli()
+	first := s.now().Add(-historyWindow).UnixMilli()
+	names := s.streamNames(logGroupName)
+	if limit > 0 && int(limit) < len(names) {
+		names = names[:limit]
+	}
+
+	streams := make([]clients.LogStreamInfo, len(names))
+	for i, name := range names {
+		streams[i] = clients.LogStreamInfo{
+			LogStreamName:     name,
+			FirstEventTime:    first,
+			LastEventTime:     now,
+			LastIngestionTime: now,
+			Arn:               fmt.Sprintf("arn:aws:logs:%s:%s:log-group:%s:log-stream:%s", Region, Account, logGroupName, name),
+		}
+	}
+	return streams, nil
+}
+
+// GetLogEvents returns up to limit events of the last hour
+func (s *CloudWatchLogsService) GetLogEvents(ctx context.Context, logGroupName, logStreamName string, limit int32, startFromHead bool) ([]clients.LogEvent, *string, error) {
+	now := s.now()
+	events := s.streamEvents(logGroupName, logStreamName, now.Add(-historyWindow), now)
+	if limit > 0 && int(limit) < len(events) {
+		if startFromHead {
+			events = events[:limit]
+		} else {
+			events = events[len(events)-int(limit):]
+		}
+	}
+	return events, nextToken(events, now), nil
+}
+
+// GetLogEventsWithToken returns up to limit events newer than the token
+func (s *CloudWatchLogsService) GetLogEventsWithToken(ctx context.Context, logGroupName, logStreamName, token string, limit int32) ([]clients.LogEvent, *string, error) {
+	after, err := strconv.ParseInt(token, 10, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get log events: %w",
+			apiError("InvalidParameterException", "The specified nextToken is invalid."))
+	}
+
+	now := s.now()
+	events := s.streamEvents(logGroupName, logStreamName, time.UnixMilli(after+1), now)
+	if limit > 0 && int(limit) < len(events) {
+		events = events[:limit]
+	}
+	if len(events) == 0 {
+		return nil, &token, nil
+	}
+	return events, nextToken(events, now), nil
+}
+
+// GetLogEventsSinceTime returns up to limit events since the given time
+func (s *CloudWatchLogsService) GetLogEventsSinceTime(ctx context.Context, logGroupName, logStreamName string, since time.Time, limit int32) ([]clients.LogEvent, error) {
+	events := s.streamEvents(logGroupName, logStreamName, since, s.now())
+	if limit > 0 && int(limit) < len(events) {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+// FilterLogEvents returns the events of all streams of a group since the given
+// time whose message contains filterPattern
+func (s *CloudWatchLogsService) FilterLogEvents(ctx context.Context, logGroupName, filterPattern string, since time.Time) ([]clients.LogEvent, error) {
+	if !s.hasGroup(logGroupName) {
+		return nil, fmt.Errorf("failed to filter log events: %w",
+			apiError("ResourceNotFoundException", "The specified log group does not exist."))
+	}
+
+	now := s.now()
+	if since.Before(now.Add(-historyWindow)) {
+		since = now.Add(-historyWindow)
+	}
+
+	var events []clients.LogEvent
+	for _, stream := range s.streamNames(logGroupName) {
+		for _, event := range s.streamEvents(logGroupName, stream, since, now) {
+			if filterPattern == "" || strings.Contains(strings.ToLower(event.Message), strings.ToLower(filterPattern)) {
+				events = append(events, event)
+			}
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Timestamp < events[j].Timestamp })
+	return events, nil
+}
+
+// TailLogStreams sends the latest events of the given streams and then new
+// events as they are generated until ctx is done
+func (s *CloudWatchLogsService) TailLogStreams(ctx context.Context, logGroupName string, logStreamNames []string, eventsChan chan<- clients.LogEvent, errorChan chan<- error) {
+	defer close(eventsChan)
+	defer close(errorChan)
+
+	last := make(map[string]time.Time)
+	for _, stream := range logStreamNames {
+		events, _, _ := s.GetLogEvents(ctx, logGroupName, stream, 10, false)
+		if !send(ctx, eventsChan, events) {
+			return
+		}
+		last[stream] = s.now()
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := s.now()
+			for _, stream := range logStreamNames {
+				events := s.streamEvents(logGroupName, stream, last[stream].Add(time.Millisecond), now)
+				if !send(ctx, eventsChan, events) {
+					return
+				}
+				last[stream] = now
+			}
+		}
+	}
+}
+
+// TailLogGroup sends matching events of a whole group from since on until
+// ctx is done
+func (s *CloudWatchLogsService) TailLogGroup(ctx context.Context, logGroupName, filterPattern string, since time.Time, eventsChan chan<- clients.LogEvent, errorChan chan<- error) {
+	defer close(eventsChan)
+	defer close(errorChan)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		now := s.now()
+		events, err := s.FilterLogEvents(ctx, logGroupName, filterPattern, since)
+		if err != nil {
+			select {
+			case errorChan <- err:
+			case <-ctx.Done():
+			}
+			return
+		}
+		if !send(ctx, eventsChan, events) {
+			return
+		}
+		since = now.Add(time.Millisecond)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *CloudWatchLogsService) hasGroup(name string) bool {
+	if name == fmt.Sprintf("arn:aws:logs:%s:%s:log-group:%s", Region, linkedAccount, linkedLogGroup) {
+		return true
+	}
+	for _, group := range s.groups {
+		if group == name {
+			return true
+		}
+	}
+	return false
+}
+
+// streamNames returns the streams of a group in the style of the service
+// that writes to it
+func (s *CloudWatchLogsService) streamNames(logGroupName string) []string {
+	// Groups of linked accounts are given by ARN
+	if _, name, ok := strings.Cut(logGroupName, ":log-group:"); ok {
+		logGroupName = name
+	}
+	day := s.now().UTC().Format("2006/01/02")
+	names := make([]string, s.streams)
+	for i := range names {
+		switch {
+		case strings.HasPrefix(logGroupName, "/aws/lambda/"):
+			names[i] = fmt.Sprintf("%s/[$LATEST]%032x", day, hash(logGroupName, int64(i)))
+		case strings.HasPrefix(logGroupName, "/ecs/"):
+			names[i] = fmt.Sprintf("orders/app/%032x", hash(logGroupName, int64(i)))
+		case strings.HasPrefix(logGroupName, "/aws/batch/"):
+			names[i] = fmt.Sprintf("invoice-render/default/%032x", hash(logGroupName, int64(i)))
+		default:
+			names[i] = fmt.Sprintf("orders-prod.%d", i)
+		}
+	}
+	return names
+}
+
+// streamEvents generates the events of a stream in [from, to]. The same
+// stream always produces the same events for the same time range.
+func (s *CloudWatchLogsService) streamEvents(logGroupName, logStreamName string, from, to time.Time) []clients.LogEvent {
+	interval := eventInterval.Milliseconds()
+	offset := int64(hash(logGroupName, int64(len(logStreamName)))%uint64(interval)) + int64(hash(logStreamName, 0)%1000)
+
+	start := from.UnixMilli()
+	end := to.UnixMilli()
+	first := start - (start-offset)%interval
+	if first < start {
+		first += interval
+	}
+
+	var events []clients.LogEvent
+	for ts := first; ts <= end; ts += interval {
+		h := hash(logStreamName, ts)
+		template := logMessages[h%uint64(len(logMessages))]
+		events = append(events, clients.LogEvent{
+			Timestamp:     ts,
+			Message:       fmt.Sprintf(template, 10+h%990),
+			IngestionTime: ts + int64(h%400),
+			LogStreamName: logStreamName,
+			EventID:       fmt.Sprintf("%d%016x", ts, h),
+		})
+	}
+	return events
+}
+
+// nextToken encodes the timestamp up to which events were returned
+func nextToken(events []clients.LogEvent, now time.Time) *string {
+	last := now.UnixMilli()
+	if len(events) > 0 {
+		last = events[len(events)-1].Timestamp
+	}
+	return awssdk.String(strconv.FormatInt(last, 10))
+}
+
+// send delivers events and reports false once ctx is done
+func send(ctx context.Context, eventsChan chan<- clients.LogEvent, events []clients.LogEvent) bool {
+	for _, event := range events {
+		select {
+		case eventsChan <- event:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return ctx.Err() == nil
+}
+
+func hash(s string, n int64) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	h.Write([]byte(strconv.FormatInt(n, 10)))
+	return h.Sum64()
+}
@@ -0,0 +1,68 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// ordersProdResourceID is the DbiResourceId of the production database, the
+// only sample instance with Performance Insights enabled
+const ordersProdResourceID = "db-ORDERSPROD7Q2XKZ4H3VYWJ5A"
+
+// PerformanceInsightsService reports the load of the production orders
+// database: an order lookup scanning without an index and lock contention
+// on the inventory counter
+type PerformanceInsightsService struct {
+	topSQL   []clients.DBLoadItem
+	topWaits []clients.DBLoadItem
+}
+
+// NewPerformanceInsightsService returns the sample load
+func NewPerformanceInsightsService() *PerformanceInsightsService {
+	return &PerformanceInsightsService{
+		topSQL: []clients.DBLoadItem{
+			{ID: "A3F1C9E27B0D4C85", Name: "SELECT o.id, o.status, o.total FROM orders o WHERE o.customer_email = $1 ORDER BY o.created_at DESC LIMIT $2", Load: 1.42},
+			{ID: "5D7E20B94C1AF368", Name: "UPDATE inventory SET reserved = reserved + $1 WHERE sku = $2", Load: 0.61},
+			{ID: "C08B6F3A15E2D947", Name: "INSERT INTO order_items (order_id, sku, quantity, price) VALUES ($1, $2, $3, $4)", Load: 0.24},
+			{ID: "9E4A7D1B2C60F853", Name: "SELECT count(*) FROM orders WHERE status = $1 AND created_at > now() - interval $2", Load: 0.17},
+			{ID: "17C3B8E5F9A02D64", Name: "COMMIT", Load: 0.08},
+		},
+		topWaits: []clients.DBLoadItem{
+			{Name: "CPU", Type: "CPU", Load: 1.18},
+			{Name: "IO:DataFileRead", Type: "IO", Load: 0.57},
+			{Name: "Lock:transactionid", Type: "Lock", Load: 0.49},
+			{Name: "LWLock:WALWrite", Type: "LWLock", Load: 0.21},
+			{Name: "Client:ClientRead", Type: "Client", Load: 0.07},
+		},
+	}
+}
+
+// GetDBLoad returns the sample load of the production database. Longer
+// windows average out the spikes, so their load is a little lower.
+func (s *PerformanceInsightsService) GetDBLoad(ctx context.Context, resourceID string, start, end time.Time, limit int) (clients.DBLoad, error) {
+	if resourceID != ordersProdResourceID {
+		return clients.DBLoad{}, apiError("InvalidArgumentException", fmt.Sprintf("Performance Insights is not enabled for %s", resourceID))
+	}
+
+	scale := 1.0
+	if end.Sub(start) > 3*time.Hour {
+		scale = 0.8
+	}
+	scaled := func(items []clients.DBLoadItem) []clients.DBLoadItem {
+		out := make([]clients.DBLoadItem, 0, min(len(items), limit))
+		for _, item := range items[:min(len(items), limit)] {
+			item.Load *= scale
+			out = append(out, item)
+		}
+		return out
+	}
+
+	load := clients.DBLoad{TopSQL: scaled(s.topSQL), TopWaits: scaled(s.topWaits), Peak: 6.3 * scale}
+	for _, item := range load.TopWaits {
+		load.Average += item.Load
+	}
+	return load, nil
+}
@@ -0,0 +1,51 @@
+package fake
+
+import (
+	"sync"
+
+	"swiss-army-tui/internal/aws"
+)
+
+// ProfileStore holds a fixed set of AWS profiles instead of reading the AWS
+// config files. None of them signs in with SSO.
+type ProfileStore struct {
+	mu       sync.RWMutex
+	profiles map[string]*aws.Profile
+}
+
+// NewProfileStore returns a store of profiles
+func NewProfileStore(profiles ...*aws.Profile) *ProfileStore {
+	s := &ProfileStore{profiles: make(map[string]*aws.Profile)}
+	for _, p := range profiles {
+		s.profiles[p.Name] = p
+	}
+	return s
+}
+
+// LoadProfiles keeps the profiles of the store
+func (s *ProfileStore) LoadProfiles() error {
+	return nil
+}
+
+// GetProfiles returns the profiles by name
+func (s *ProfileStore) GetProfiles() map[string]*aws.Profile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	profiles := make(map[string]*aws.Profile, len(s.profiles))
+	for name, p := range s.profiles {
+		profiles[name] = p
+	}
+	return profiles
+}
+
+// SSOShare returns nil, the profiles sign in without SSO
+func (s *ProfileStore) SSOShare(profile string) *aws.SSOShare {
+	return nil
+}
+
+// NewClientFor returns a client for profile in region backed by fresh
+// NewServices, like the client AWS would give the demo account
+func NewClientFor(profile, region string) (*aws.Client, error) {
+	return aws.NewClientWithServices(profile, region, NewServices()), nil
+}
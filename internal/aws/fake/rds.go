@@ -0,0 +1,117 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// RDSService lists a fixed set of database instances. The production
+// database uses a tuned parameter group with a change waiting for a reboot,
+// staging the engine default group.
+type RDSService struct {
+	instances  []clients.RDSDetails
+	groups     []clients.ParameterGroup
+	parameters map[string][]clients.ParameterDiff
+}
+
+// NewRDSService returns a production and a staging database
+func NewRDSService() *RDSService {
+	created := time.Now().AddDate(0, -8, 0).Truncate(24 * time.Hour)
+	stagingCreated := created.AddDate(0, 3, 0)
+
+	return &RDSService{
+		instances: []clients.RDSDetails{
+			{
+				DBInstanceIdentifier:       "orders-prod",
+				DBInstanceClass:            "db.m6g.large",
+				MultiAZ:                    true,
+				Engine:                     "postgres",
+				EngineVersion:              "16.3",
+				DBInstanceStatus:           "available",
+				Endpoint:                   "orders-prod.c9akciq32.us-east-1.rds.amazonaws.com",
+				AllocatedStorage:           200,
+				InstanceCreateTime:         &created,
+				Region:                     Region,
+				DbiResourceID:              ordersProdResourceID,
+				PerformanceInsightsEnabled: true,
+			},
+			{
+				DBInstanceIdentifier: "orders-staging",
+				DBInstanceClass:      "db.t4g.medium",
+				Engine:               "postgres",
+				EngineVersion:        "16.3",
+				DBInstanceStatus:     "stopped",
+				Endpoint:             "orders-staging.c9akciq32.us-east-1.rds.amazonaws.com",
+				AllocatedStorage:     50,
+				InstanceCreateTime:   &stagingCreated,
+				Region:               Region,
+				DbiResourceID:        "db-3MZQ8T5LKRX2WB7NHVJ4P6CYDA",
+			},
+		},
+		groups: []clients.ParameterGroup{
+			{
+				Name:        "default.postgres16",
+				Family:      "postgres16",
+				Description: "Default parameter group for postgres16",
+				ARN:         fmt.Sprintf("arn:aws:rds:%s:%s:pg:default.postgres16", Region, Account),
+				Instances:   []clients.ParameterGroupMember{{Instance: "orders-staging", ApplyStatus: "in-sync"}},
+			},
+			{
+				Name:        "orders-postgres16",
+				Family:      "postgres16",
+				Description: "Orders database tuning",
+				ARN:         fmt.Sprintf("arn:aws:rds:%s:%s:pg:orders-postgres16", Region, Account),
+				Instances:   []clients.ParameterGroupMember{{Instance: "orders-prod", ApplyStatus: "pending-reboot"}},
+			},
+			{
+				Name:        "reporting-postgres15",
+				Family:      "postgres15",
+				Description: "Reporting replica, decommissioned",
+				ARN:         fmt.Sprintf("arn:aws:rds:%s:%s:pg:reporting-postgres15", Region, Account),
+			},
+		},
+		parameters: map[string][]clients.ParameterDiff{
+			"orders-postgres16": {
+				{Name: "log_min_duration_statement", Value: "500", ApplyType: "dynamic", Description: "Sets the minimum execution time above which statements will be logged."},
+				{Name: "max_connections", Value: "400", Default: "LEAST({DBInstanceClassMemory/9531392},5000)", ApplyType: "static", Description: "Sets the maximum number of concurrent connections."},
+				{Name: "random_page_cost", Value: "1.1", ApplyType: "dynamic", Description: "Sets the planner's estimate of the cost of a nonsequentially fetched disk page."},
+				{Name: "rds.force_ssl", Value: "1", Default: "1", ApplyType: "dynamic", Description: "Force SSL connections."},
+				{Name: "shared_preload_libraries", Value: "pg_stat_statements,auto_explain", Default: "pg_stat_statements", ApplyType: "static", Description: "Lists shared libraries to preload into server."},
+				{Name: "work_mem", Value: "16384", ApplyType: "dynamic", Description: "Sets the maximum memory to be used for query workspaces."},
+			},
+			"reporting-postgres15": {
+				{Name: "work_mem", Value: "65536", ApplyType: "dynamic", Description: "Sets the maximum memory to be used for query workspaces."},
+			},
+		},
+	}
+}
+
+// GetRDSDetail returns all database instances, passing them to onPage, if
+// set, one at a time
+func (s *RDSService) GetRDSDetail(ctx context.Context, onPage func([]clients.RDSDetails)) ([]clients.RDSDetails, error) {
+	instances := append([]clients.RDSDetails(nil), s.instances...)
+	if onPage != nil {
+		for i := range instances {
+			onPage(instances[i : i+1])
+		}
+	}
+	return instances, nil
+}
+
+// ListParameterGroups returns the sample parameter groups
+func (s *RDSService) ListParameterGroups(ctx context.Context) ([]clients.ParameterGroup, error) {
+	return append([]clients.ParameterGroup(nil), s.groups...), nil
+}
+
+// DiffParameterGroup returns the sample parameters set in the group name
+func (s *RDSService) DiffParameterGroup(ctx context.Context, name, family string) ([]clients.ParameterDiff, error) {
+	for _, group := range s.groups {
+		if group.Name == name {
+			return append([]clients.ParameterDiff(nil), s.parameters[name]...), nil
+		}
+	}
+	return nil, apiError("DBParameterGroupNotFound", fmt.Sprintf("DBParameterGroup not found: %s", name))
+}
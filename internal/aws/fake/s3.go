@@ -0,0 +1,370 @@
+package fake
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// S3Service lists a fixed set of buckets and keeps their objects in memory.
+// The region and objects of restrictedBucket cannot be read and the objects
+// below lockedPrefix cannot be deleted, to show partial failures.
+type S3Service struct {
+	buckets []clients.S3Details
+
+	mu      sync.Mutex
+	objects map[string]map[string]clients.S3Object
+	// contents holds the bodies of objects by bucket and key; the others are
+	// made up from their key when read
+	contents map[string][]byte
+	// uploads are the incomplete multipart uploads by bucket and abortDays
+	// the days after which a lifecycle rule of the bucket aborts them
+	uploads   map[string][]clients.MultipartUpload
+	abortDays map[string]int
+}
+
+const (
+	restrictedBucket = "acme-security-audit"
+	lockedPrefix     = "legal-hold/"
+)
+
+// s3CopyDelay slows copies down so jobs can be watched progressing
+const s3CopyDelay = 20 * time.Millisecond
+
+// NewS3Service returns buckets spread over a few regions
+func NewS3Service() *S3Service {
+	created := time.Now().AddDate(-1, 0, 0).Truncate(24 * time.Hour)
+	bucket := func(name, region string, age int) clients.S3Details {
+		date := created.AddDate(0, age, 0)
+		return clients.S3Details{Name: name, Region: region, CreationDate: &date}
+	}
+
+	modified := time.Now().AddDate(0, 0, -3).Truncate(time.Hour)
+	objects := map[string]map[string]clients.S3Object{}
+	add := func(bucket, key string, size int64, class string, age int) {
+		if objects[bucket] == nil {
+			objects[bucket] = map[string]clients.S3Object{}
+		}
+		objects[bucket][key] = clients.S3Object{Key: key, Size: size, StorageClass: class, LastModified: modified.AddDate(0, 0, -age)}
+	}
+	add("acme-web-assets", "index.html", 4_812, "STANDARD", 1)
+	add("acme-web-assets", "robots.txt", 68, "STANDARD", 90)
+	add("acme-web-assets", "css/site.css", 22_410, "STANDARD", 1)
+	add("acme-web-assets", "js/app.js", 181_977, "STANDARD", 1)
+	add("acme-web-assets", "images/logo.png", 12_003, "STANDARD", 200)
+	add("acme-web-assets", "images/hero.jpg", 845_112, "STANDARD", 30)
+	for month := 1; month <= 9; month++ {
+		add("acme-order-exports", fmt.Sprintf("exports/2026/%02d/orders.csv", month), int64(1_200_000+month*48_311), "STANDARD_IA", 30*(9-month))
+		add("acme-order-exports", fmt.Sprintf("exports/2026/%02d/refunds.csv", month), int64(84_000+month*1_207), "STANDARD_IA", 30*(9-month))
+	}
+	add("acme-order-exports", "archive/2025/orders.csv.gz", 58_119_442, "GLACIER", 300)
+	add("acme-order-exports", lockedPrefix+"case-1182/orders.csv", 310_554, "STANDARD", 120)
+	add("acme-backups-replica", "db/orders-2026-10-01.dump", 4_118_221_004, "DEEP_ARCHIVE", 14)
+	add("acme-backups-replica", "db/orders-2026-10-08.dump", 4_120_983_211, "DEEP_ARCHIVE", 7)
+	add("acme-terraform-state", "prod/terraform.tfstate", 188_245, "STANDARD", 2)
+	add("acme-terraform-state", "staging/terraform.tfstate", 97_420, "STANDARD", 5)
+
+	contents := map[string][]byte{}
+	write := func(bucket, key string, body []byte) {
+		add(bucket, key, int64(len(body)), "STANDARD", 1)
+		contents[bucket+"/"+key] = body
+	}
+	write("acme-web-assets", "index.html", []byte("<!doctype html>\n<html>\n<head><title>Acme</title><link rel=\"stylesheet\" href=\"/css/site.css\"></head>\n<body><img src=\"/images/logo.png\"><script src=\"/js/app.js\"></script></body>\n</html>\n"))
+	write("acme-web-assets", "robots.txt", []byte("User-agent: *\nDisallow: /admin/\n"))
+	write("acme-web-assets", "config/site.json", []byte(`{"name":"acme","regions":["us-east-1","eu-west-1"],"cdn":{"enabled":true,"ttl":3600},"maintenance":false}`))
+	write("acme-web-assets", "config/feature-flags.yaml", []byte("flags:\n    new-checkout: {enabled: true, rollout: 25}\n    dark-mode: {enabled: false}\nowner: web-team\n"))
+	var log bytes.Buffer
+	zw := gzip.NewWriter(&log)
+	for i := 0; i < 200; i++ {
+		fmt.Fprintf(zw, "10.0.%d.%d - - [14/Oct/2026:10:%02d:00 +0000] \"GET /index.html HTTP/1.1\" 200 4812\n", i%4, i, i%60)
+	}
+	zw.Close()
+	write("acme-web-assets", "logs/access-2026-10-14.log.gz", log.Bytes())
+
+	return &S3Service{
+		objects:   objects,
+		contents:  contents,
+		uploads:   sampleUploads(),
+		abortDays: map[string]int{"acme-order-exports": 7},
+		buckets: []clients.S3Details{
+			bucket("acme-web-assets", "us-east-1", 0),
+			bucket("acme-order-exports", "us-east-1", 2),
+			bucket("acme-eu-customer-data", "eu-west-1", 3),
+			bucket("acme-backups-replica", "us-west-2", 5),
+			bucket("acme-terraform-state", "us-east-1", 1),
+			bucket(restrictedBucket, "eu-central-1", 7),
+		},
+	}
+}
+
+// GetS3Detail lists all buckets including the regions that can be looked up
+func (s *S3Service) GetS3Detail(ctx context.Context) ([]clients.S3Details, error) {
+	details, _ := s.ListBuckets(ctx)
+	err := s.LookupBucketRegions(ctx, nil, func(name, region string) {
+		for i := range details {
+			if details[i].Name == name {
+				details[i].Region = region
+			}
+		}
+	})
+	return details, err
+}
+
+// ListBuckets lists all buckets without their regions
+func (s *S3Service) ListBuckets(ctx context.Context) ([]clients.S3Details, error) {
+	details := make([]clients.S3Details, len(s.buckets))
+	for i, bucket := range s.buckets {
+		details[i] = clients.S3Details{Name: bucket.Name, CreationDate: bucket.CreationDate}
+	}
+	return details, nil
+}
+
+// LookupBucketRegions reports the region of every named bucket, or of all
+// buckets if names is empty
+func (s *S3Service) LookupBucketRegions(ctx context.Context, names []string, onRegion func(name, region string)) error {
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var failures []clients.ItemError
+	for _, bucket := range s.buckets {
+		if len(names) > 0 && !wanted[bucket.Name] {
+			continue
+		}
+		if bucket.Name == restrictedBucket {
+			failures = append(failures, clients.ItemError{
+				Item: bucket.Name,
+				Err:  apiError("AccessDenied", "Access Denied"),
+			})
+			continue
+		}
+		if onRegion != nil {
+			onRegion(bucket.Name, bucket.Region)
+		}
+	}
+
+	if len(failures) > 0 {
+		return &clients.PartialError{Op: "look up bucket regions", Failures: failures}
+	}
+	return nil
+}
+
+// ListObjects lists the objects of bucket below prefix, folding keys into
+// their common prefix unless recursive
+func (s *S3Service) ListObjects(ctx context.Context, bucket, prefix string, recursive bool) ([]clients.S3Object, error) {
+	if err := s.checkBucket(bucket); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefixes := map[string]bool{}
+	var objects []clients.S3Object
+	for key, object := range s.objects[bucket] {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if i := strings.Index(key[len(prefix):], "/"); i >= 0 && !recursive {
+			prefixes[key[:len(prefix)+i+1]] = true
+			continue
+		}
+		objects = append(objects, object)
+	}
+	for common := range prefixes {
+		objects = append(objects, clients.S3Object{Key: common, IsPrefix: true})
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].IsPrefix != objects[j].IsPrefix {
+			return objects[i].IsPrefix
+		}
+		return objects[i].Key < objects[j].Key
+	})
+	return objects, nil
+}
+
+// DeleteObjects deletes keys from bucket except those below lockedPrefix
+func (s *S3Service) DeleteObjects(ctx context.Context, bucket string, keys []string) error {
+	if err := s.checkBucket(bucket); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var failures []clients.ItemError
+	for _, key := range keys {
+		if strings.HasPrefix(key, lockedPrefix) {
+			failures = append(failures, clients.ItemError{
+				Item: key,
+				Err:  apiError("AccessDenied", "Object is under legal hold"),
+			})
+			continue
+		}
+		delete(s.objects[bucket], key)
+		delete(s.contents, bucket+"/"+key)
+	}
+
+	if len(failures) > 0 {
+		return &clients.PartialError{Op: "delete objects", Failures: failures}
+	}
+	return nil
+}
+
+// CopyObject copies key of bucket to destKey of destBucket, changing its
+// storage class when storageClass is set
+func (s *S3Service) CopyObject(ctx context.Context, bucket, key string, size int64, destBucket, destKey, storageClass string) error {
+	if err := s.checkBucket(bucket); err != nil {
+		return err
+	}
+	if err := s.checkBucket(destBucket); err != nil {
+		return err
+	}
+
+	select {
+	case <-time.After(s3CopyDelay):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[bucket][key]
+	if !ok {
+		return apiError("NoSuchKey", "The specified key does not exist.")
+	}
+	if object.StorageClass == "GLACIER" || object.StorageClass == "DEEP_ARCHIVE" {
+		return apiError("InvalidObjectState", "Operation is not valid for the source object's storage class")
+	}
+	object.Key = destKey
+	object.LastModified = time.Now()
+	if storageClass != "" {
+		object.StorageClass = storageClass
+	}
+	if s.objects[destBucket] == nil {
+		s.objects[destBucket] = map[string]clients.S3Object{}
+	}
+	s.objects[destBucket][destKey] = object
+	if body, ok := s.contents[bucket+"/"+key]; ok {
+		s.contents[destBucket+"/"+destKey] = body
+	}
+	return nil
+}
+
+// GetObjectPreview returns the headers and at most the first maxBytes of
+// key. Archived objects cannot be read, like in S3.
+func (s *S3Service) GetObjectPreview(ctx context.Context, bucket, key string, maxBytes int64) (clients.ObjectPreview, error) {
+	if err := s.checkBucket(bucket); err != nil {
+		return clients.ObjectPreview{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[bucket][key]
+	if !ok {
+		return clients.ObjectPreview{}, apiError("NoSuchKey", "The specified key does not exist.")
+	}
+	preview := clients.ObjectPreview{
+		Key:          key,
+		Size:         object.Size,
+		ContentType:  objectContentType(key),
+		StorageClass: object.StorageClass,
+		ETag:         fmt.Sprintf("\"%x\"", len(key)*7919+int(object.Size)),
+		LastModified: object.LastModified,
+		Metadata:     map[string]string{"uploaded-by": "ci-pipeline"},
+	}
+	if strings.HasSuffix(key, ".gz") {
+		preview.ContentEncoding = "gzip"
+	}
+	if object.StorageClass == "GLACIER" || object.StorageClass == "DEEP_ARCHIVE" {
+		return clients.ObjectPreview{}, apiError("InvalidObjectState", "The operation is not valid for the object's storage class")
+	}
+
+	body, ok := s.contents[bucket+"/"+key]
+	if !ok {
+		body = madeUpContent(key, min(object.Size, maxBytes))
+	}
+	preview.Body = body[:min(int64(len(body)), maxBytes)]
+	return preview, nil
+}
+
+// objectContentType guesses the content type of key from its extension
+func objectContentType(key string) string {
+	switch path.Ext(strings.TrimSuffix(key, ".gz")) {
+	case ".html":
+		return "text/html"
+	case ".css":
+		return "text/css"
+	case ".js":
+		return "application/javascript"
+	case ".json", ".tfstate":
+		return "application/json"
+	case ".yaml":
+		return "application/yaml"
+	case ".csv":
+		return "text/csv"
+	case ".txt", ".log":
+		return "text/plain"
+	case ".png":
+		return "image/png"
+	case ".jpg":
+		return "image/jpeg"
+	default:
+		return "binary/octet-stream"
+	}
+}
+
+// madeUpContent returns size bytes that look like the content of key
+func madeUpContent(key string, size int64) []byte {
+	var body bytes.Buffer
+	switch path.Ext(key) {
+	case ".csv":
+		body.WriteString("order_id,customer_id,amount,currency,created_at\n")
+		for i := 0; int64(body.Len()) < size; i++ {
+			fmt.Fprintf(&body, "%d,c-%05d,%d.%02d,USD,2026-09-%02dT%02d:00:00Z\n", 100000+i, i*37%99991, i*13%500, i%100, i%28+1, i%24)
+		}
+	case ".tfstate":
+		fmt.Fprintf(&body, `{"version":4,"terraform_version":"1.9.5","serial":%d,"lineage":"3f1c2a","outputs":{},"resources":[`, len(key))
+		for i := 0; int64(body.Len()) < size-64; i++ {
+			if i > 0 {
+				body.WriteString(",")
+			}
+			fmt.Fprintf(&body, `{"mode":"managed","type":"aws_s3_bucket","name":"bucket_%d","instances":[{"attributes":{"bucket":"acme-%d"}}]}`, i, i)
+		}
+		body.WriteString("]}")
+	case ".png", ".jpg":
+		body.WriteString("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+		for i := 0; int64(body.Len()) < size; i++ {
+			body.WriteByte(byte(i * 31))
+		}
+	default:
+		for i := 0; int64(body.Len()) < size; i++ {
+			fmt.Fprintf(&body, "/* %s line %d */\n", key, i+1)
+		}
+	}
+	return body.Bytes()[:min(int64(body.Len()), size)]
+}
+
+// checkBucket fails for unknown buckets and restrictedBucket
+func (s *S3Service) checkBucket(bucket string) error {
+	if bucket == restrictedBucket {
+		return apiError("AccessDenied", "Access Denied")
+	}
+	for _, known := range s.buckets {
+		if known.Name == bucket {
+			return nil
+		}
+	}
+	return apiError("NoSuchBucket", "The specified bucket does not exist")
+}
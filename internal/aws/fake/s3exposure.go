@@ -0,0 +1,61 @@
+package fake
+
+import (
+	"context"
+	"sort"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// blockAll is a public access block stopping all public access
+var blockAll = clients.PublicAccessBlock{
+	Configured:            true,
+	BlockPublicAcls:       true,
+	IgnorePublicAcls:      true,
+	BlockPublicPolicy:     true,
+	RestrictPublicBuckets: true,
+}
+
+// bucketExposures are the sample exposures: the web assets are a public
+// website, the order exports are shared with a partner account and the
+// public access block of the replica was partly turned off
+var bucketExposures = map[string]clients.BucketExposure{
+	"acme-web-assets": {
+		HasPolicy:    true,
+		PolicyPublic: true,
+		PublicGrants: []string{"everyone: READ"},
+	},
+	"acme-order-exports": {
+		PublicAccessBlock: blockAll,
+		HasPolicy:         true,
+		CrossAccount:      []string{"arn:aws:iam::999988887777:role/partner-reporting"},
+	},
+	"acme-eu-customer-data": {PublicAccessBlock: blockAll},
+	"acme-backups-replica": {
+		PublicAccessBlock: clients.PublicAccessBlock{Configured: true, BlockPublicPolicy: true, RestrictPublicBuckets: true},
+	},
+	"acme-terraform-state": {PublicAccessBlock: blockAll, HasPolicy: true},
+}
+
+// AuditBuckets returns the sample exposures of buckets; restrictedBucket
+// cannot be read
+func (s *S3Service) AuditBuckets(ctx context.Context, buckets []clients.S3Details, account string) ([]clients.BucketExposure, error) {
+	var exposures []clients.BucketExposure
+	var failures []clients.ItemError
+	for _, bucket := range buckets {
+		exposure, ok := bucketExposures[bucket.Name]
+		if !ok {
+			failures = append(failures, clients.ItemError{Item: bucket.Name, Region: bucket.Region, Err: apiError("AccessDenied", "Access Denied")})
+			continue
+		}
+		exposure.Bucket = bucket.Name
+		exposure.Region = bucket.Region
+		exposures = append(exposures, exposure)
+	}
+	sort.Slice(exposures, func(i, j int) bool { return exposures[i].Bucket < exposures[j].Bucket })
+
+	if len(failures) > 0 {
+		return exposures, &clients.PartialError{Op: "audit buckets", Failures: failures}
+	}
+	return exposures, nil
+}
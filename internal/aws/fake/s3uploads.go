@@ -0,0 +1,109 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// sampleUploads are the incomplete multipart uploads of the sample buckets:
+// database dumps to the replica that were interrupted weeks ago, an import
+// of customer data nobody finished and a fresh export, in a bucket whose
+// lifecycle rule aborts uploads after a week
+func sampleUploads() map[string][]clients.MultipartUpload {
+	now := time.Now().Truncate(time.Hour)
+	upload := func(key, id, class, initiator string, age time.Duration, parts int, partSize int64) clients.MultipartUpload {
+		return clients.MultipartUpload{
+			Key: key, UploadID: id, Initiated: now.Add(-age), StorageClass: class,
+			Initiator: initiator, Parts: parts, Size: int64(parts) * partSize,
+		}
+	}
+	const gb = 1 << 30
+	day := 24 * time.Hour
+	return map[string][]clients.MultipartUpload{
+		"acme-backups-replica": {
+			upload("db/orders-2026-08-30.dump", "VXBsb2FkIElEIGZvciA2aWWpbmcncyBteS1tb3ZpZS5tMnRzIHVwbG9hZA", "DEEP_ARCHIVE", "backup-runner", 47*day, 312, 8<<20),
+			upload("db/orders-2026-09-13.dump", "2Ez4bF1c.0kPbEYgu3Yxg1Xz9ZxWHhDcxtRTqsQ6cE0Kq5U", "DEEP_ARCHIVE", "backup-runner", 33*day, 3, gb),
+			upload("db/orders-2026-10-03.dump", "Jx8kVQpzTx2dmL0v1wzr3TtXqkc8H5nG6b9WZrXW7uE", "DEEP_ARCHIVE", "backup-runner", 13*day, 41, 64<<20),
+		},
+		"acme-eu-customer-data": {
+			upload("imports/crm-export-full.parquet", "tQ3f8e.yN2bJ4kU1lP0sR7wX5zC9vA6dG", "STANDARD", "data-import", 94*day, 120, 16<<20),
+			upload("imports/crm-export-delta.parquet", "mK2pL9sQ4wE7rT1yU8iO3aS6dF0gH5jZ", "STANDARD", "data-import", 94*day, 2, 16<<20),
+		},
+		"acme-order-exports": {
+			upload("exports/2026/10/orders.csv", "bN7vC1xZ4mQ8wE2rT5yU9iO3pA6sD0fG", "STANDARD_IA", "export-job", 20*time.Hour, 1, 5<<20),
+		},
+	}
+}
+
+// ListIncompleteUploads returns the sample uploads of buckets;
+// restrictedBucket cannot be read
+func (s *S3Service) ListIncompleteUploads(ctx context.Context, buckets []clients.S3Details) ([]clients.BucketUploads, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var found []clients.BucketUploads
+	var failures []clients.ItemError
+	for _, bucket := range buckets {
+		if err := s.checkBucket(bucket.Name); err != nil {
+			failures = append(failures, clients.ItemError{Item: bucket.Name, Region: bucket.Region, Err: err})
+			continue
+		}
+		uploads := append([]clients.MultipartUpload(nil), s.uploads[bucket.Name]...)
+		sort.Slice(uploads, func(i, j int) bool { return uploads[i].Initiated.Before(uploads[j].Initiated) })
+		found = append(found, clients.BucketUploads{
+			Bucket:         bucket.Name,
+			Region:         bucket.Region,
+			Uploads:        uploads,
+			AbortAfterDays: s.abortDays[bucket.Name],
+		})
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Bucket < found[j].Bucket })
+
+	if len(failures) > 0 {
+		return found, &clients.PartialError{Op: "list multipart uploads", Failures: failures}
+	}
+	return found, nil
+}
+
+// AbortUploads drops the sample uploads; those gone already count as
+// aborted
+func (s *S3Service) AbortUploads(ctx context.Context, bucket string, uploads []clients.MultipartUpload) error {
+	if err := s.checkBucket(bucket); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	aborted := make(map[string]bool, len(uploads))
+	for _, upload := range uploads {
+		aborted[upload.UploadID] = true
+	}
+	var kept []clients.MultipartUpload
+	for _, upload := range s.uploads[bucket] {
+		if !aborted[upload.UploadID] {
+			kept = append(kept, upload)
+		}
+	}
+	s.uploads[bucket] = kept
+	return nil
+}
+
+// SetAbortIncompleteUploads records the rule aborting the uploads of bucket
+// after days
+func (s *S3Service) SetAbortIncompleteUploads(ctx context.Context, bucket string, days int) error {
+	if err := s.checkBucket(bucket); err != nil {
+		return err
+	}
+	if days < 1 {
+		return apiError("InvalidArgument", fmt.Sprintf("DaysAfterInitiation must be positive, got %d", days))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.abortDays[bucket] = days
+	return nil
+}
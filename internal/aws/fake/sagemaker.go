@@ -0,0 +1,152 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// idleEndpoints are the sample endpoints that have not been invoked lately;
+// their invocation metrics stay at zero
+var idleEndpoints = map[string]bool{
+	"churn-predictor":      true,
+	"image-tagger-staging": true,
+}
+
+// SageMakerService holds the SageMaker resources of the demo account: a
+// busy recommender on GPU instances, two endpoints nobody calls, a
+// serverless fraud scorer and one that failed its health check, next to
+// notebooks and a few weeks of training jobs. Notebooks stop and endpoints
+// are deleted at once.
+type SageMakerService struct {
+	jobs []clients.SageMakerTrainingJob
+
+	mu        sync.Mutex
+	notebooks []clients.SageMakerNotebook
+	endpoints []clients.SageMakerEndpoint
+}
+
+// NewSageMakerService returns the sample notebooks, endpoints and jobs
+func NewSageMakerService() *SageMakerService {
+	now := time.Now()
+	day := 24 * time.Hour
+	arn := func(kind, name string) string {
+		return fmt.Sprintf("arn:aws:sagemaker:%s:%s:%s/%s", Region, Account, kind, name)
+	}
+
+	notebook := func(name, instanceType, status string, created time.Duration) clients.SageMakerNotebook {
+		return clients.SageMakerNotebook{
+			Name:         name,
+			ARN:          arn("notebook-instance", name),
+			Status:       status,
+			InstanceType: instanceType,
+			CreatedAt:    now.Add(-created),
+			ModifiedAt:   now.Add(-created / 4),
+		}
+	}
+	endpoint := func(name, config, status string, created time.Duration, variants ...clients.SageMakerVariant) clients.SageMakerEndpoint {
+		return clients.SageMakerEndpoint{
+			Name:       name,
+			ARN:        arn("endpoint", name),
+			Status:     status,
+			Config:     config,
+			Variants:   variants,
+			CreatedAt:  now.Add(-created),
+			ModifiedAt: now.Add(-created / 3),
+		}
+	}
+	instances := func(instanceType string, count int) clients.SageMakerVariant {
+		return clients.SageMakerVariant{Name: "AllTraffic", InstanceType: instanceType, Instances: count}
+	}
+
+	failed := endpoint("sentiment-v2", "sentiment-v2-config", "Failed", 2*day)
+	failed.FailureReason = "The primary container for production variant AllTraffic did not pass the ping health check. Please check CloudWatch logs for this endpoint."
+
+	job := func(name, status string, created, took time.Duration) clients.SageMakerTrainingJob {
+		j := clients.SageMakerTrainingJob{Name: name, ARN: arn("training-job", name), Status: status, CreatedAt: now.Add(-created)}
+		if took > 0 {
+			j.EndedAt = j.CreatedAt.Add(took)
+		}
+		return j
+	}
+
+	return &SageMakerService{
+		notebooks: []clients.SageMakerNotebook{
+			notebook("data-prep", "ml.t3.medium", "Stopped", 120*day),
+			notebook("gpu-experiments", "ml.g4dn.xlarge", "InService", 9*day),
+			notebook("research", "ml.t3.medium", "InService", 60*day),
+		},
+		endpoints: []clients.SageMakerEndpoint{
+			endpoint("churn-predictor", "churn-predictor-v1", "InService", 75*day, instances("ml.m5.xlarge", 1)),
+			endpoint("fraud-scorer", "fraud-scorer-serverless", "InService", 40*day, clients.SageMakerVariant{Name: "AllTraffic", Serverless: true}),
+			endpoint("image-tagger-staging", "image-tagger-v3", "InService", 21*day, instances("ml.g5.2xlarge", 1)),
+			endpoint("product-recommender", "product-recommender-v4", "InService", 30*day, instances("ml.g5.xlarge", 2)),
+			failed,
+		},
+		jobs: []clients.SageMakerTrainingJob{
+			job("churn-train-2026-10-16", "InProgress", 40*time.Minute, 0),
+			job("image-tagger-finetune-7", "Failed", 2*day, 12*time.Minute),
+			job("churn-train-2026-10-09", "Completed", 7*day, 52*time.Minute),
+			job("recommender-train-42", "Completed", 9*day, 3*time.Hour+10*time.Minute),
+			job("image-tagger-finetune-6", "Stopped", 10*day, 25*time.Minute),
+			job("churn-train-2026-10-02", "Completed", 14*day, 49*time.Minute),
+		},
+	}
+}
+
+// ListNotebookInstances returns the sample notebooks
+func (s *SageMakerService) ListNotebookInstances(ctx context.Context) ([]clients.SageMakerNotebook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]clients.SageMakerNotebook(nil), s.notebooks...), nil
+}
+
+// ListEndpoints returns the sample endpoints that were not deleted
+func (s *SageMakerService) ListEndpoints(ctx context.Context) ([]clients.SageMakerEndpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]clients.SageMakerEndpoint(nil), s.endpoints...), nil
+}
+
+// ListTrainingJobs returns the last limit sample jobs, newest first
+func (s *SageMakerService) ListTrainingJobs(ctx context.Context, limit int) ([]clients.SageMakerTrainingJob, error) {
+	jobs := s.jobs
+	if limit > 0 && len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return append([]clients.SageMakerTrainingJob(nil), jobs...), nil
+}
+
+// StopNotebookInstance stops a running sample notebook
+func (s *SageMakerService) StopNotebookInstance(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.notebooks {
+		if s.notebooks[i].Name != name {
+			continue
+		}
+		if status := s.notebooks[i].Status; status != "InService" && status != "Pending" {
+			return apiError("ValidationException", fmt.Sprintf("Status (%s) not in ([InService, Pending]). Unable to transition to (Stopping) for Notebook Instance (%s).", status, s.notebooks[i].ARN))
+		}
+		s.notebooks[i].Status = "Stopped"
+		s.notebooks[i].ModifiedAt = time.Now()
+		return nil
+	}
+	return apiError("ValidationException", "RecordNotFound")
+}
+
+// DeleteEndpoint removes a sample endpoint
+func (s *SageMakerService) DeleteEndpoint(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.endpoints, func(e clients.SageMakerEndpoint) bool { return e.Name == name })
+	if i < 0 {
+		return apiError("ValidationException", fmt.Sprintf("Could not find endpoint \"%s\".", name))
+	}
+	s.endpoints = slices.Delete(s.endpoints, i, i+1)
+	return nil
+}
@@ -0,0 +1,37 @@
+package fake
+
+import (
+	"context"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// SavingsPlansService lists a single Compute Savings Plan
+type SavingsPlansService struct {
+	plans []clients.SavingsPlanDetail
+}
+
+// NewSavingsPlansService returns a Compute Savings Plan bought half a year ago
+func NewSavingsPlansService() *SavingsPlansService {
+	start := time.Now().AddDate(0, -6, 0).Truncate(24 * time.Hour)
+
+	return &SavingsPlansService{
+		plans: []clients.SavingsPlanDetail{
+			{
+				ID:            "sp-0a1b2c3d4e5f67890",
+				Type:          "Compute",
+				State:         "active",
+				PaymentOption: "No Upfront",
+				Commitment:    0.25,
+				Start:         start,
+				End:           start.AddDate(1, 0, 0),
+			},
+		},
+	}
+}
+
+// DescribeSavingsPlans returns all Savings Plans
+func (s *SavingsPlansService) DescribeSavingsPlans(ctx context.Context) ([]clients.SavingsPlanDetail, error) {
+	return append([]clients.SavingsPlanDetail(nil), s.plans...), nil
+}
@@ -0,0 +1,81 @@
+package fake
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// sesReputation are the bounce and complaint rates of the demo account as
+// reported by the AWS/SES reputation metrics
+var sesReputation = map[string]float64{
+	"Reputation.BounceRate":    0.021,
+	"Reputation.ComplaintRate": 0.0004,
+}
+
+// SESService is a production SES account with a domain, a few addresses and
+// a short suppression list
+type SESService struct {
+	mu         sync.Mutex
+	identities []clients.EmailIdentityDetail
+	suppressed []clients.SuppressedDestination
+}
+
+// NewSESService returns the sample identities and suppressed addresses
+func NewSESService() *SESService {
+	now := time.Now().Truncate(time.Minute)
+
+	return &SESService{
+		identities: []clients.EmailIdentityDetail{
+			{Name: "example.com", Type: "DOMAIN", VerificationStatus: "SUCCESS", SendingEnabled: true},
+			{Name: "mail.example.org", Type: "DOMAIN", VerificationStatus: "PENDING"},
+			{Name: "noreply@example.com", Type: "EMAIL_ADDRESS", VerificationStatus: "SUCCESS", SendingEnabled: true},
+			{Name: "billing@example.net", Type: "EMAIL_ADDRESS", VerificationStatus: "FAILED"},
+		},
+		suppressed: []clients.SuppressedDestination{
+			{Email: "old-customer@example.de", Reason: "BOUNCE", LastUpdate: now.Add(-26 * time.Hour)},
+			{Email: "typo@gmial.com", Reason: "BOUNCE", LastUpdate: now.Add(-3 * time.Hour)},
+			{Email: "angry@example.fr", Reason: "COMPLAINT", LastUpdate: now.Add(-50 * time.Minute)},
+		},
+	}
+}
+
+// ListIdentities returns the sample identities
+func (s *SESService) ListIdentities(ctx context.Context) ([]clients.EmailIdentityDetail, error) {
+	return append([]clients.EmailIdentityDetail(nil), s.identities...), nil
+}
+
+// GetSendingAccount returns a healthy production account at 23% of its quota
+func (s *SESService) GetSendingAccount(ctx context.Context) (clients.SendingAccount, error) {
+	return clients.SendingAccount{
+		Max24HourSend:     50000,
+		MaxSendRate:       14,
+		SentLast24Hours:   11532,
+		SendingEnabled:    true,
+		ProductionAccess:  true,
+		EnforcementStatus: "HEALTHY",
+	}, nil
+}
+
+// ListSuppressedDestinations returns the addresses still on the suppression list
+func (s *SESService) ListSuppressedDestinations(ctx context.Context) ([]clients.SuppressedDestination, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]clients.SuppressedDestination(nil), s.suppressed...), nil
+}
+
+// RemoveSuppressedDestination removes email from the suppression list
+func (s *SESService) RemoveSuppressedDestination(ctx context.Context, email string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, destination := range s.suppressed {
+		if destination.Email == email {
+			s.suppressed = append(s.suppressed[:i:i], s.suppressed[i+1:]...)
+			return nil
+		}
+	}
+	return apiError("NotFoundException", "Email address "+email+" does not exist on your suppression list.")
+}
@@ -0,0 +1,74 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// SNSService is the order pipeline's event topic fanning out to queues, a
+// function and an email address, and an alerts topic
+type SNSService struct {
+	topics        []clients.TopicDetail
+	subscriptions map[string][]clients.Subscription
+}
+
+// topicARN returns the ARN of the sample topic name
+func topicARN(name string) string {
+	return fmt.Sprintf("arn:aws:sns:%s:%s:%s", Region, Account, name)
+}
+
+// NewSNSService returns the sample topics
+func NewSNSService() *SNSService {
+	subscription := func(topic, id, protocol, endpoint string) clients.Subscription {
+		return clients.Subscription{ARN: topicARN(topic) + ":" + id, Protocol: protocol, Endpoint: endpoint}
+	}
+
+	return &SNSService{
+		topics: []clients.TopicDetail{
+			{ARN: topicARN("orders-events"), Name: "orders-events", DisplayName: "Order events", SubscriptionsActive: 4, Encrypted: true},
+			{ARN: topicARN("ops-alerts"), Name: "ops-alerts", DisplayName: "Ops alerts", SubscriptionsActive: 1, SubscriptionsPending: 1},
+		},
+		subscriptions: map[string][]clients.Subscription{
+			topicARN("orders-events"): {
+				subscription("orders-events", "1b7f2c4e-0d55-4a8b-b6e3-7e4c1f9a2d01", "sqs", queueARN("orders-billing")),
+				subscription("orders-events", "2c8e3d5f-1e66-4b9c-c7f4-8f5d2e0b3e12", "sqs", queueARN("orders-shipping")),
+				subscription("orders-events", "3d9f4e6a-2f77-4cad-d8a5-9a6e3f1c4f23", "lambda", functionARN("orders-api")),
+				subscription("orders-events", "4e0a5f7b-3a88-4dbe-e9b6-0b7f4a2d5a34", "email", "orders-team@example.com"),
+			},
+			topicARN("ops-alerts"): {
+				subscription("ops-alerts", "5f1b6a8c-4b99-4ecf-fac7-1c8a5b3e6b45", "https", "https://hooks.example.com/aws-alerts"),
+				{ARN: "PendingConfirmation", Protocol: "email", Endpoint: "oncall@example.com", Pending: true},
+			},
+		},
+	}
+}
+
+// ListTopics returns the sample topics by ARN and name
+func (s *SNSService) ListTopics(ctx context.Context) ([]clients.TopicDetail, error) {
+	topics := make([]clients.TopicDetail, 0, len(s.topics))
+	for _, topic := range s.topics {
+		topics = append(topics, clients.TopicDetail{ARN: topic.ARN, Name: topic.Name, FIFO: topic.FIFO})
+	}
+	return topics, nil
+}
+
+// GetTopic returns a sample topic
+func (s *SNSService) GetTopic(ctx context.Context, arn string) (clients.TopicDetail, error) {
+	for _, topic := range s.topics {
+		if topic.ARN == arn {
+			return topic, nil
+		}
+	}
+	return clients.TopicDetail{}, apiError("NotFound", "Topic does not exist")
+}
+
+// ListSubscriptions returns the subscriptions of a sample topic
+func (s *SNSService) ListSubscriptions(ctx context.Context, topicARN string) ([]clients.Subscription, error) {
+	subscriptions, ok := s.subscriptions[topicARN]
+	if !ok {
+		return nil, apiError("NotFound", "Topic does not exist")
+	}
+	return append([]clients.Subscription(nil), subscriptions...), nil
+}
@@ -0,0 +1,83 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// sampleQueues are the queues behind the orders-events topic: billing keeps
+// up but dead-letters a few messages, shipping piles up
+var sampleQueues = map[string]clients.QueueDetail{
+	"orders-billing": {
+		Visible: 3, InFlight: 2,
+		DeadLetterARN: queueARN("orders-billing-dlq"), MaxReceives: 5,
+	},
+	"orders-billing-dlq": {Visible: 12},
+	"orders-shipping": {
+		Visible: 8412, InFlight: 10, Delayed: 0,
+		DeadLetterARN: queueARN("orders-shipping-dlq"), MaxReceives: 3,
+	},
+	"orders-shipping-dlq": {},
+}
+
+// queueAges are the ages in seconds of the oldest message of the sample
+// queues, as reported by CloudWatch
+var queueAges = map[string]float64{
+	"orders-billing":     4,
+	"orders-billing-dlq": 2 * 24 * 3600,
+	"orders-shipping":    52 * 60,
+}
+
+// queueARN returns the ARN of the sample queue name
+func queueARN(name string) string {
+	return fmt.Sprintf("arn:aws:sqs:%s:%s:%s", Region, Account, name)
+}
+
+// SQSService returns the depth of the sample queues
+type SQSService struct{}
+
+// NewSQSService returns the sample queues
+func NewSQSService() *SQSService {
+	return &SQSService{}
+}
+
+// GetQueue returns a sample queue by ARN
+func (s *SQSService) GetQueue(ctx context.Context, arn string) (clients.QueueDetail, error) {
+	for name, queue := range sampleQueues {
+		if queueARN(name) == arn {
+			queue.Name = name
+			queue.ARN = arn
+			queue.URL = fmt.Sprintf("https://sqs.%s.amazonaws.com/%s/%s", Region, Account, name)
+			return queue, nil
+		}
+	}
+	return clients.QueueDetail{}, apiError("AWS.SimpleQueueService.NonExistentQueue", "The specified queue does not exist.")
+}
+
+// queueMetricSeries returns the age of the oldest message of a sample queue,
+// false if query is not about one
+func queueMetricSeries(query clients.MetricQuery, start, end time.Time) (clients.MetricSeries, bool) {
+	if query.Namespace != "AWS/SQS" || query.Metric != "ApproximateAgeOfOldestMessage" {
+		return clients.MetricSeries{}, false
+	}
+
+	var series clients.MetricSeries
+	for _, dimension := range query.Dimensions {
+		age, ok := queueAges[dimension.Value]
+		if dimension.Name != "QueueName" || !ok {
+			continue
+		}
+		period := time.Duration(max(query.Period, 60)) * time.Second
+		for t := start.Truncate(period); t.Before(end); t = t.Add(period) {
+			// The backlog grew over the range up to the current age
+			share := float64(t.Sub(start)) / float64(end.Sub(start))
+			series.Timestamps = append(series.Timestamps, t)
+			series.Values = append(series.Values, math.Round(age*max(share, 0.1)))
+		}
+	}
+	return series, true
+}
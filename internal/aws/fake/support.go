@@ -0,0 +1,101 @@
+package fake
+
+import (
+	"context"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// SupportService has Trusted Advisor checks of every category, some of which
+// flag resources of the sample account
+type SupportService struct {
+	checks  []clients.TrustedAdvisorCheck
+	flagged map[string][]clients.FlaggedResource
+}
+
+// NewSupportService returns the sample Trusted Advisor checks, last
+// refreshed this morning
+func NewSupportService() *SupportService {
+	refreshed := time.Now().UTC().Truncate(24 * time.Hour).Add(6 * time.Hour)
+	if refreshed.After(time.Now()) {
+		refreshed = refreshed.Add(-24 * time.Hour)
+	}
+
+	check := func(id, name, category, status string, processed, flagged int64, columns ...string) clients.TrustedAdvisorCheck {
+		return clients.TrustedAdvisorCheck{
+			ID:          id,
+			Name:        name,
+			Description: "Checks " + name + ".",
+			Category:    category,
+			Status:      status,
+			RefreshedAt: refreshed,
+			Processed:   processed,
+			Flagged:     flagged,
+			Columns:     columns,
+		}
+	}
+
+	lowUtilization := check("Qch7DwouX1", "Low Utilization Amazon EC2 Instances", "cost_optimizing", "warning", 6, 2,
+		"Region/AZ", "Instance ID", "Instance Name", "Instance Type", "Estimated Monthly Savings", "Average CPU Utilization")
+	lowUtilization.EstimatedMonthlySavings = 97.09
+	idleVolumes := check("DAvU99Dc4C", "Underutilized Amazon EBS Volumes", "cost_optimizing", "warning", 9, 1,
+		"Region", "Volume ID", "Volume Name", "Volume Type", "Volume Size", "Monthly Storage Cost")
+	idleVolumes.EstimatedMonthlySavings = 40
+	idleBalancers := check("hjLMh88uM8", "Idle Load Balancers", "cost_optimizing", "ok", 2, 0,
+		"Region", "Load Balancer Name", "Reason", "Estimated Monthly Savings")
+
+	return &SupportService{
+		checks: []clients.TrustedAdvisorCheck{
+			lowUtilization,
+			idleVolumes,
+			idleBalancers,
+			check("HCP4007jGY", "Security Groups - Specific Ports Unrestricted", "security", "error", 14, 1,
+				"Region", "Security Group Name", "Security Group ID", "Protocol", "Port", "Status"),
+			check("Pfx0RwqBli", "Amazon S3 Bucket Permissions", "security", "ok", 3, 0,
+				"Region", "Bucket Name", "ACL Allows List", "ACL Allows Upload/Delete", "Status"),
+			check("7DAFEmoDos", "MFA on Root Account", "security", "ok", 1, 0,
+				"Status", "Reason"),
+			check("opQPADkZvH", "Amazon RDS Backups", "fault_tolerance", "ok", 2, 0,
+				"Region/AZ", "DB Instance", "VPC ID", "Backup Retention Period", "Status"),
+			check("f2iK5R6Dep", "Amazon RDS Multi-AZ", "fault_tolerance", "warning", 2, 1,
+				"Region/AZ", "DB Instance", "VPC ID", "Multi-AZ", "Status"),
+			check("H7IgTzjTYb", "Amazon EBS Snapshots", "fault_tolerance", "not_available", 0, 0,
+				"Region", "Volume ID", "Volume Name", "Snapshot ID", "Snapshot Age", "Status"),
+			check("ZRxQlPsb6c", "High Utilization Amazon EC2 Instances", "performance", "ok", 6, 0,
+				"Region/AZ", "Instance ID", "Instance Name", "Instance Type", "Number of Days over 90% CPU Utilization"),
+			check("eW7HH0l7J9", "EC2 On-Demand Instances", "service_limits", "ok", 1, 0,
+				"Region", "Service", "Limit Name", "Limit Amount", "Current Usage", "Status"),
+		},
+		flagged: map[string][]clients.FlaggedResource{
+			"Qch7DwouX1": {
+				{ID: "i-0a1b2c3d4e5f60003", Status: "warning", Region: Region, Metadata: []string{"us-east-1b", "i-0a1b2c3d4e5f60003", "batch-worker", "m5.xlarge", "$70.08", "3.1%"}},
+				{ID: "i-0a1b2c3d4e5f60005", Status: "warning", Region: Region, Metadata: []string{"us-east-1a", "i-0a1b2c3d4e5f60005", "jump-host", "t3.medium", "$27.01", "0.4%"}},
+			},
+			"DAvU99Dc4C": {
+				{ID: "vol-0abc1234def567890", Status: "warning", Region: Region, Metadata: []string{Region, "vol-0abc1234def567890", "old-build-cache", "gp2", "400", "$40.00"}},
+			},
+			"HCP4007jGY": {
+				{ID: "sg-0bad1234ssh", Status: "error", Region: Region, Metadata: []string{Region, "legacy-admin", "sg-0bad1234ssh", "tcp", "22", "Red"}},
+			},
+			"f2iK5R6Dep": {
+				{ID: "orders-db-staging", Status: "warning", Region: Region, Metadata: []string{"us-east-1c", "orders-db-staging", "vpc-0a1b2c3d", "No", "Yellow"}},
+			},
+		},
+	}
+}
+
+// ListTrustedAdvisorChecks returns the sample checks
+func (s *SupportService) ListTrustedAdvisorChecks(ctx context.Context) ([]clients.TrustedAdvisorCheck, error) {
+	return append([]clients.TrustedAdvisorCheck(nil), s.checks...), nil
+}
+
+// GetFlaggedResources returns the resources the sample check checkID flagged
+func (s *SupportService) GetFlaggedResources(ctx context.Context, checkID string) ([]clients.FlaggedResource, error) {
+	for _, check := range s.checks {
+		if check.ID == checkID {
+			return append([]clients.FlaggedResource(nil), s.flagged[checkID]...), nil
+		}
+	}
+	return nil, apiError("InvalidParameterValueException", "Could not find check with id "+checkID)
+}
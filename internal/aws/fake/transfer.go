@@ -0,0 +1,91 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// TransferService runs the SFTP server partners deliver orders to, an EDI
+// server for carriers in the VPC that authenticates through API Gateway
+// and a stopped FTP server of the old warehouse system
+type TransferService struct {
+	servers []clients.TransferServer
+	users   map[string][]clients.TransferUser
+}
+
+// Sample servers
+const (
+	partnerServer = "s-4f1a9c2e7b3d40011"
+	carrierServer = "s-8c2e5a1f9d7b40022"
+	legacyServer  = "s-1d9b7f3a5c2e40033"
+)
+
+// NewTransferService returns the sample servers
+func NewTransferService() *TransferService {
+	server := func(id, name, state, domain, endpointType, identity string, protocols ...string) clients.TransferServer {
+		return clients.TransferServer{
+			ID:               id,
+			ARN:              fmt.Sprintf("arn:aws:transfer:%s:%s:server/%s", Region, Account, id),
+			State:            state,
+			Protocols:        protocols,
+			Domain:           domain,
+			EndpointType:     endpointType,
+			Endpoint:         fmt.Sprintf("%s.server.transfer.%s.amazonaws.com", id, Region),
+			IdentityProvider: identity,
+			LoggingRole:      fmt.Sprintf("arn:aws:iam::%s:role/transfer-logging", Account),
+			Tags:             map[string]string{"Name": name},
+		}
+	}
+	user := func(name, home string, keys int) clients.TransferUser {
+		return clients.TransferUser{
+			Name:              name,
+			ARN:               fmt.Sprintf("arn:aws:transfer:%s:%s:user/%s/%s", Region, Account, partnerServer, name),
+			Role:              fmt.Sprintf("arn:aws:iam::%s:role/transfer-%s", Account, name),
+			HomeDirectory:     home,
+			HomeDirectoryType: "PATH",
+			SSHKeys:           keys,
+		}
+	}
+
+	partner := server(partnerServer, "partner-sftp", "ONLINE", "S3", "PUBLIC", "SERVICE_MANAGED", "SFTP")
+	carrier := server(carrierServer, "carrier-edi", "ONLINE", "S3", "VPC", "API_GATEWAY", "SFTP", "FTPS")
+	carrier.VPCID = "vpc-0a1b2c3d4e5f60718"
+	carrier.AddressAllocationIDs = []string{"eipalloc-0f1e2d3c4b5a69788"}
+	legacy := server(legacyServer, "warehouse-ftp", "OFFLINE", "EFS", "VPC", "SERVICE_MANAGED", "FTP")
+	legacy.VPCID = "vpc-0a1b2c3d4e5f60718"
+
+	users := map[string][]clients.TransferUser{
+		partnerServer: {
+			user("auditor", "/shop-partner-inbox", 0),
+			user("marketplace", "/shop-partner-inbox/marketplace", 1),
+			user("wholesaler", "/shop-partner-inbox/wholesaler", 2),
+		},
+		carrierServer: nil,
+		legacyServer: {
+			user("warehouse", "/fs-0c1d2e3f/inbound", 0),
+		},
+	}
+	users[legacyServer][0].ARN = fmt.Sprintf("arn:aws:transfer:%s:%s:user/%s/warehouse", Region, Account, legacyServer)
+	partner.Users, carrier.Users, legacy.Users = len(users[partnerServer]), 0, len(users[legacyServer])
+
+	return &TransferService{
+		servers: []clients.TransferServer{legacy, partner, carrier},
+		users:   users,
+	}
+}
+
+// ListServers returns the sample servers
+func (s *TransferService) ListServers(ctx context.Context) ([]clients.TransferServer, error) {
+	return append([]clients.TransferServer(nil), s.servers...), nil
+}
+
+// ListUsers returns the users of the sample server
+func (s *TransferService) ListUsers(ctx context.Context, server string) ([]clients.TransferUser, error) {
+	users, ok := s.users[server]
+	if !ok {
+		return nil, apiError("ResourceNotFoundException", fmt.Sprintf("Unknown server: %s", server))
+	}
+	return append([]clients.TransferUser(nil), users...), nil
+}
@@ -0,0 +1,146 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// WAFService has the Web ACL protecting the web shop load balancer, with
+// managed and custom rules, and an unused one
+type WAFService struct {
+	acls      []clients.WebACLDetail
+	resources map[string][]string
+}
+
+// NewWAFService returns the sample Web ACLs
+func NewWAFService() *WAFService {
+	arn := func(name, id string) string {
+		return fmt.Sprintf("arn:aws:wafv2:%s:%s:regional/webacl/%s/%s", Region, Account, name, id)
+	}
+	shop := clients.WebACLSummary{
+		Name:        "web-prod-acl",
+		ID:          "a1b2c3d4-5678-90ab-cdef-111111111111",
+		ARN:         arn("web-prod-acl", "a1b2c3d4-5678-90ab-cdef-111111111111"),
+		Scope:       clients.WAFScopeRegional,
+		Description: "Protects the web shop",
+	}
+	legacy := clients.WebACLSummary{
+		Name:  "legacy-api-acl",
+		ID:    "a1b2c3d4-5678-90ab-cdef-222222222222",
+		ARN:   arn("legacy-api-acl", "a1b2c3d4-5678-90ab-cdef-222222222222"),
+		Scope: clients.WAFScopeRegional,
+	}
+
+	return &WAFService{
+		acls: []clients.WebACLDetail{
+			{
+				WebACLSummary: shop,
+				DefaultAction: "ALLOW",
+				Capacity:      1225,
+				MetricName:    "web-prod-acl",
+				Rules: []clients.WAFRule{
+					{Name: "AWS-AWSManagedRulesCommonRuleSet", Priority: 0, Action: "NONE", Statement: "managed AWS/AWSManagedRulesCommonRuleSet", MetricName: "AWS-AWSManagedRulesCommonRuleSet"},
+					{Name: "AWS-AWSManagedRulesSQLiRuleSet", Priority: 1, Action: "NONE", Statement: "managed AWS/AWSManagedRulesSQLiRuleSet", MetricName: "AWS-AWSManagedRulesSQLiRuleSet"},
+					{Name: "rate-limit-per-ip", Priority: 2, Action: "BLOCK", Statement: "rate limit 2000 per 300s by ip", MetricName: "rate-limit-per-ip"},
+					{Name: "block-countries", Priority: 3, Action: "BLOCK", Statement: "country in KP, IR", MetricName: "block-countries"},
+					{Name: "bot-control-trial", Priority: 4, Action: "COUNT", Statement: "managed AWS/AWSManagedRulesBotControlRuleSet", MetricName: "bot-control-trial"},
+				},
+			},
+			{
+				WebACLSummary: legacy,
+				DefaultAction: "BLOCK",
+				Capacity:      1,
+				MetricName:    "legacy-api-acl",
+				Rules: []clients.WAFRule{
+					{Name: "allow-office", Priority: 0, Action: "ALLOW", Statement: "IP set office-ips", MetricName: "allow-office"},
+				},
+			},
+		},
+		resources: map[string][]string{
+			shop.ARN: {fmt.Sprintf("arn:aws:elasticloadbalancing:%s:%s:loadbalancer/app/web-prod/50dc6c495c0c9188", Region, Account)},
+		},
+	}
+}
+
+// ListWebACLs returns the sample Web ACLs of scope
+func (s *WAFService) ListWebACLs(ctx context.Context, scope string) ([]clients.WebACLSummary, error) {
+	var acls []clients.WebACLSummary
+	for _, acl := range s.acls {
+		if acl.Scope == scope {
+			acls = append(acls, acl.WebACLSummary)
+		}
+	}
+	return acls, nil
+}
+
+// GetWebACL returns the sample Web ACL with the ID of acl
+func (s *WAFService) GetWebACL(ctx context.Context, acl clients.WebACLSummary) (clients.WebACLDetail, error) {
+	for _, detail := range s.acls {
+		if detail.ID == acl.ID {
+			detail.Rules = append([]clients.WAFRule(nil), detail.Rules...)
+			return detail, nil
+		}
+	}
+	return clients.WebACLDetail{}, apiError("WAFNonexistentItemException", "AWS WAF couldn't perform the operation because your resource doesn't exist.")
+}
+
+// ListResourcesForWebACL returns the load balancer of the web shop for its ACL
+func (s *WAFService) ListResourcesForWebACL(ctx context.Context, arn string) ([]string, error) {
+	return append([]string(nil), s.resources[arn]...), nil
+}
+
+// sampleTraffic are the requests sampled for the rules that match, by metric name
+var sampleTraffic = map[string]struct {
+	action string
+	rules  []string
+	uris   []string
+}{
+	"AWS-AWSManagedRulesCommonRuleSet": {"BLOCK", []string{"CrossSiteScripting_QUERYARGUMENTS", "SizeRestrictions_BODY", "NoUserAgent_HEADER"}, []string{"/search?q=<script>", "/api/orders", "/"}},
+	"AWS-AWSManagedRulesSQLiRuleSet":   {"BLOCK", []string{"SQLi_QUERYARGUMENTS"}, []string{"/products?id=1%27%20OR%201=1", "/login"}},
+	"rate-limit-per-ip":                {"BLOCK", nil, []string{"/api/cart", "/api/products"}},
+	"bot-control-trial":                {"COUNT", []string{"CategoryHttpLibrary", "SignalNonBrowserUserAgent"}, []string{"/", "/sitemap.xml", "/products"}},
+	"web-prod-acl":                     {"ALLOW", nil, []string{"/", "/products", "/cart", "/checkout"}},
+}
+
+var (
+	sampleIPs       = []string{"203.0.113.24", "198.51.100.7", "192.0.2.150", "203.0.113.99", "198.51.100.201"}
+	sampleCountries = []string{"US", "DE", "CN", "BR", "NL"}
+	sampleMethods   = []string{"GET", "GET", "POST", "GET"}
+)
+
+// GetSampledRequests returns up to 25 requests per hour of the window for
+// rules with sample traffic, and none for the others
+func (s *WAFService) GetSampledRequests(ctx context.Context, acl clients.WebACLSummary, metricName string, start, end time.Time) ([]clients.SampledRequest, int64, error) {
+	traffic, ok := sampleTraffic[metricName]
+	if !ok {
+		return nil, 0, nil
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(metricName))
+	seed := int(h.Sum32() % 1000)
+
+	count := min(int(end.Sub(start).Hours()*25), 100)
+	var requests []clients.SampledRequest
+	for i := 0; i < count; i++ {
+		n := seed + i*7
+		request := clients.SampledRequest{
+			Time:     end.Add(-time.Duration(i*137+n%60) * time.Second).Truncate(time.Second),
+			Action:   traffic.action,
+			ClientIP: sampleIPs[n%len(sampleIPs)],
+			Country:  sampleCountries[n%len(sampleCountries)],
+			Method:   sampleMethods[n%len(sampleMethods)],
+			URI:      traffic.uris[i%len(traffic.uris)],
+			Weight:   1,
+		}
+		if len(traffic.rules) > 0 {
+			request.Rule = traffic.rules[i%len(traffic.rules)]
+		}
+		requests = append(requests, request)
+	}
+	return requests, int64(count * 40), nil
+}
@@ -0,0 +1,96 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"swiss-army-tui/internal/workpool"
+)
+
+const (
+	// latencyProbes is how many requests are timed per region; the first
+	// also pays for DNS and the TLS handshake
+	latencyProbes = 3
+	// latencyProbeTimeout bounds the probes of one region
+	latencyProbeTimeout = 5 * time.Second
+)
+
+// latencyEndpoint returns the URL timed for region. DynamoDB answers /ping
+// without credentials in every region. Tests point it elsewhere.
+var latencyEndpoint = func(region string) string {
+	return fmt.Sprintf("https://dynamodb.%s.amazonaws.com/ping", region)
+}
+
+// RegionLatency is the round trip time to the endpoints of a region
+type RegionLatency struct {
+	Region  string
+	Latency time.Duration
+	// Err is why the region could not be reached; Latency is zero then
+	Err error
+}
+
+// ProbeRegionLatency times a request to each of regions concurrently, in the
+// "regions" slots of the worker pool, and returns their latencies, fastest
+// first and unreachable regions last. The latency of a region is its fastest
+// probe, which leaves out connection setup.
+func ProbeRegionLatency(ctx context.Context, regions []string) []RegionLatency {
+	client := &http.Client{
+		Timeout:   latencyProbeTimeout,
+		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, MaxIdleConnsPerHost: 1},
+	}
+	defer client.CloseIdleConnections()
+
+	// Regions left unprobed when ctx is done count as unreachable
+	results := make([]RegionLatency, len(regions))
+	for i, region := range regions {
+		results[i] = RegionLatency{Region: region, Err: context.Canceled}
+	}
+	workpool.Each(ctx, "regions", len(regions), func(i int) {
+		latency, err := probeRegion(ctx, client, latencyEndpoint(regions[i]))
+		results[i] = RegionLatency{Region: regions[i], Latency: latency, Err: err}
+	})
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if (a.Err == nil) != (b.Err == nil) {
+			return a.Err == nil
+		}
+		return a.Latency < b.Latency
+	})
+	return results
+}
+
+// probeRegion returns the fastest of latencyProbes requests to url. Any
+// answer counts, the status does not matter.
+func probeRegion(ctx context.Context, client *http.Client, url string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, latencyProbeTimeout)
+	defer cancel()
+
+	var fastest time.Duration
+	for i := 0; i < latencyProbes; i++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return 0, err
+		}
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			if fastest > 0 {
+				// Later probes only refine the first
+				return fastest, nil
+			}
+			return 0, err
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if elapsed := time.Since(start); fastest == 0 || elapsed < fastest {
+			fastest = elapsed
+		}
+	}
+	return fastest, nil
+}
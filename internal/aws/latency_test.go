@@ -0,0 +1,41 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestProbeRegionLatency(t *testing.T) {
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer fast.Close()
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer slow.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+
+	restore := latencyEndpoint
+	latencyEndpoint = func(region string) string {
+		return map[string]string{"far-1": slow.URL, "near-1": fast.URL, "down-1": down.URL}[region]
+	}
+	defer func() { latencyEndpoint = restore }()
+
+	results := ProbeRegionLatency(context.Background(), []string{"down-1", "far-1", "near-1"})
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %+v", results)
+	}
+	if results[0].Region != "near-1" || results[1].Region != "far-1" || results[2].Region != "down-1" {
+		t.Errorf("Expected the regions fastest first and the unreachable last, got %+v", results)
+	}
+	if results[1].Err != nil || results[1].Latency < 30*time.Millisecond {
+		t.Errorf("Expected any answer to count, got %+v", results[1])
+	}
+	if results[2].Err == nil || results[2].Latency != 0 {
+		t.Errorf("Expected the closed server to be unreachable, got %+v", results[2])
+	}
+}
@@ -35,6 +35,8 @@ type ProfileManager struct {
 	configPath      string
 	credentialsPath string
 	profiles        map[string]*Profile
+	// sessions are the [sso-session] sections of the config file by name
+	sessions map[string]*SSOSession
 }
 
 // NewProfileManager creates a new profile manager
@@ -43,6 +45,7 @@ func NewProfileManager(configPath, credentialsPath string) *ProfileManager {
 		configPath:      configPath,
 		credentialsPath: credentialsPath,
 		profiles:        make(map[string]*Profile),
+		sessions:        make(map[string]*SSOSession),
 	}
 }
 
@@ -54,11 +57,13 @@ func (pm *ProfileManager) LoadProfiles() error {
 
 	// Clear existing profiles
 	pm.profiles = make(map[string]*Profile)
+	pm.sessions = make(map[string]*SSOSession)
 
 	// Load from config file
 	if err := pm.loadFromConfigFile(); err != nil {
 		logger.Warn("Failed to load from config file", zap.Error(err))
 	}
+	pm.resolveSSOSessions()
 
 	// Load from credentials file
 	if err := pm.loadFromCredentialsFile(); err != nil {
@@ -119,11 +124,14 @@ func (pm *ProfileManager) loadFromConfigFile() error {
 
 	scanner := bufio.NewScanner(file)
 	var currentProfile *Profile
+	var currentSession *SSOSession
 	var currentSection string
 
 	// Regex patterns
 	profilePattern := regexp.MustCompile(`^\[profile\s+(.+)\]$`)
 	defaultPattern := regexp.MustCompile(`^\[default\]$`)
+	sessionPattern := regexp.MustCompile(`^\[sso-session\s+(.+)\]$`)
+	otherPattern := regexp.MustCompile(`^\[.*\]$`)
 	keyValuePattern := regexp.MustCompile(`^(\w+)\s*=\s*(.+)$`)
 
 	for scanner.Scan() {
@@ -157,6 +165,36 @@ func (pm *ProfileManager) loadFromConfigFile() error {
 			continue
 		}
 
+		// Check for SSO session section
+		if matches := sessionPattern.FindStringSubmatch(line); matches != nil {
+			sessionName := strings.TrimSpace(matches[1])
+			currentSession = &SSOSession{Name: sessionName}
+			pm.sessions[sessionName] = currentSession
+			currentSection = "sso-session"
+			continue
+		}
+
+		// Sections such as [services] are not read
+		if otherPattern.MatchString(line) {
+			currentSection = ""
+			continue
+		}
+
+		if currentSession != nil && currentSection == "sso-session" {
+			if matches := keyValuePattern.FindStringSubmatch(line); matches != nil {
+				value := strings.TrimSpace(matches[2])
+				switch strings.ToLower(strings.TrimSpace(matches[1])) {
+				case "sso_start_url":
+					currentSession.StartURL = value
+				case "sso_region":
+					currentSession.Region = value
+				case "sso_registration_scopes":
+					currentSession.RegistrationScopes = value
+				}
+			}
+			continue
+		}
+
 		// Parse key-value pairs
 		if currentProfile != nil && currentSection == "profile" {
 			if matches := keyValuePattern.FindStringSubmatch(line); matches != nil {
@@ -305,6 +343,9 @@ func (p *Profile) GetSSOErrorMessage() string {
 		return ""
 	}
 
+	if p.SSOSessionName != "" {
+		return fmt.Sprintf("Profile '%s' is configured for AWS SSO session '%s'. Please run 'aws sso login --sso-session %s' to authenticate and try again.", p.Name, p.SSOSessionName, p.SSOSessionName)
+	}
 	return fmt.Sprintf("Profile '%s' is configured for AWS SSO. Please run 'aws sso login --profile %s' to authenticate and try again.", p.Name, p.Name)
 }
 
@@ -1,9 +1,17 @@
 package aws
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
 func TestProfileSSO(t *testing.T) {
@@ -100,89 +108,114 @@ aws_secret_access_key = je7MtGbClwBF/2Zp9Utk/h3yCo8nvbEXAMPLEKEY
 	}
 }
 
-func TestIsSSOError(t *testing.T) {
-	testCases := []struct {
-		name     string
-		err      error
-		expected bool
-	}{
-		{
-			name:     "nil error",
-			err:      nil,
-			expected: false,
-		},
-		{
-			name:     "SSO error",
-			err:      &testError{msg: "SSO token expired"},
-			expected: true,
-		},
-		{
-			name:     "sso lowercase error",
-			err:      &testError{msg: "sso login required"},
-			expected: true,
-		},
-		{
-			name:     "token error",
-			err:      &testError{msg: "invalid token"},
-			expected: true,
-		},
-		{
-			name:     "expired error",
-			err:      &testError{msg: "credentials expired"},
-			expected: true,
-		},
-		{
-			name:     "login error",
-			err:      &testError{msg: "please login"},
-			expected: true,
-		},
-		{
-			name:     "authenticate error",
-			err:      &testError{msg: "failed to authenticate"},
-			expected: true,
-		},
-		{
-			name:     "not authorized error",
-			err:      &testError{msg: "not authorized"},
-			expected: true,
-		},
-		{
-			name:     "access denied error",
-			err:      &testError{msg: "access denied"},
-			expected: true,
-		},
-		{
-			name:     "credentials error",
-			err:      &testError{msg: "invalid credentials"},
-			expected: true,
-		},
-		{
-			name:     "non-SSO error",
-			err:      &testError{msg: "network timeout"},
-			expected: false,
-		},
-		{
-			name:     "empty error",
-			err:      &testError{msg: ""},
-			expected: false,
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			result := isSSOError(tc.err)
-			if result != tc.expected {
-				t.Errorf("Expected %v, got %v for error '%s'", tc.expected, result, tc.err.Error())
-			}
-		})
+func TestProfileSSOSessionShared(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config")
+	configContent := `[profile dev]
+sso_session = acme
+sso_account_id = 111111111111
+sso_role_name = Developer
+
+[sso-session acme]
+sso_start_url = https://acme.awsapps.com/start
+sso_region = eu-west-1
+sso_registration_scopes = sso:account:access
+
+[profile prod]
+sso_session = acme
+sso_account_id = 222222222222
+sso_role_name = ReadOnly
+
+[profile legacy]
+sso_start_url = https://acme.awsapps.com/start
+sso_region = eu-west-1
+sso_account_id = 333333333333
+sso_role_name = Admin
+
+[profile static]
+region = us-east-1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
 	}
-}
 
-// testError is a simple error implementation for testing
-type testError struct {
-	msg string
-}
+	// The token of the session is cached under the SHA-1 of its name
+	cacheDir := filepath.Join(tempDir, "sso", "cache")
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	restore := ssoTokenCacheDir
+	ssoTokenCacheDir = func() string { return cacheDir }
+	defer func() { ssoTokenCacheDir = restore }()
+	writeToken := func(expiresAt time.Time) {
+		sum := sha1.Sum([]byte("acme"))
+		token := `{"accessToken":"secret","expiresAt":"` + expiresAt.UTC().Format(time.RFC3339) + `"}`
+		if err := os.WriteFile(filepath.Join(cacheDir, hex.EncodeToString(sum[:])+".json"), []byte(token), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pm := NewProfileManager(configPath, filepath.Join(tempDir, "credentials"))
+	if err := pm.LoadProfiles(); err != nil {
+		t.Fatalf("Failed to load profiles: %v", err)
+	}
+	if session, ok := pm.GetSSOSession("acme"); !ok || session.RegistrationScopes != "sso:account:access" {
+		t.Errorf("Expected the acme session, got %+v", session)
+	}
+	dev, _ := pm.GetProfile("dev")
+	if !dev.IsSSOProfileConfigured() || dev.SSOStartURL != "https://acme.awsapps.com/start" || dev.SSORegion != "eu-west-1" {
+		t.Errorf("Expected the start URL and region of the session, got %+v", dev)
+	}
+	if _, ok := pm.GetProfile("acme"); ok {
+		t.Error("Expected the session not to be read as a profile")
+	}
 
-func (e *testError) Error() string {
-	return e.msg
+	if share := pm.SSOShare("static"); share != nil {
+		t.Errorf("Expected no SSO token for a static profile, got %+v", share)
+	}
+	// The legacy profile has its own token despite the same start URL
+	if share := pm.SSOShare("legacy"); !reflect.DeepEqual(share.Profiles, []string{"legacy"}) || share.LoginCommand("legacy") != "aws sso login --profile legacy" {
+		t.Errorf("Expected the legacy profile alone, got %+v", share)
+	}
+
+	share := pm.SSOShare("dev")
+	if !reflect.DeepEqual(share.Profiles, []string{"dev", "prod"}) || !reflect.DeepEqual(share.Others("dev"), []string{"prod"}) {
+		t.Errorf("Expected dev and prod to share the session, got %+v", share)
+	}
+	if !share.ExpiresAt.IsZero() || share.Expired(time.Now()) {
+		t.Errorf("Expected no cached token, got %v", share.ExpiresAt)
+	}
+	if got := share.LoginCommand("dev"); got != "aws sso login --sso-session acme" {
+		t.Errorf("Expected a login of the session, got %q", got)
+	}
+	if hint := ssoLoginHint("dev", share); !strings.Contains(hint, "profiles dev and prod alike") || !strings.Contains(hint, "--sso-session acme") {
+		t.Errorf("Expected the hint to name both profiles, got %q", hint)
+	}
+
+	writeToken(time.Now().Add(time.Hour))
+	if share := pm.SSOShare("prod"); share.Expired(time.Now()) || share.ExpiresAt.IsZero() {
+		t.Errorf("Expected the cached token to be valid, got %v", share.ExpiresAt)
+	}
+
+	// An expired token signs out all profiles of the session together
+	writeToken(time.Now().Add(-time.Minute))
+	share = pm.SSOShare("prod")
+	if !share.Expired(time.Now()) {
+		t.Errorf("Expected the cached token to be expired, got %v", share.ExpiresAt)
+	}
+	for _, profile := range []string{"dev", "prod", "legacy"} {
+		storeCallerIdentity(profile, &sts.GetCallerIdentityOutput{Account: aws.String("111111111111")})
+	}
+	invalidateSSOShare(share)
+	if cachedCallerIdentity("dev") != nil || cachedCallerIdentity("prod") != nil {
+		t.Error("Expected the identities of the session profiles to be forgotten")
+	}
+	if cachedCallerIdentity("legacy") == nil {
+		t.Error("Expected the identity of the legacy profile to be kept")
+	}
+
+	expected := "Profile 'dev' is configured for AWS SSO session 'acme'. Please run 'aws sso login --sso-session acme' to authenticate and try again."
+	if errMsg := dev.GetSSOErrorMessage(); errMsg != expected {
+		t.Errorf("Expected error message '%s', got '%s'", expected, errMsg)
+	}
 }
@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultRateLimit is the default number of AWS API requests per second
+	DefaultRateLimit = 20
+
+	defaultMaxAttempts = 5
+	defaultMaxBackoff  = 20 * time.Second
+)
+
+// apiLimiter is shared by all clients so multi-region fan-out and aggressive
+// refreshes stay below the account's API limits
+var apiLimiter = rate.NewLimiter(rate.Limit(DefaultRateLimit), DefaultRateLimit)
+
+var (
+	throttleMu      sync.RWMutex
+	throttleHandler func(service, operation string)
+)
+
+// SetRateLimit sets the shared number of AWS API requests per second for all
+// clients. Zero or less disables rate limiting.
+func SetRateLimit(perSecond int) {
+	if perSecond <= 0 {
+		apiLimiter.SetLimit(rate.Inf)
+		return
+	}
+	apiLimiter.SetLimit(rate.Limit(perSecond))
+	apiLimiter.SetBurst(int(math.Max(1, float64(perSecond))))
+}
+
+// OnThrottle registers fn to be called whenever AWS throttles a request.
+// fn is called from the goroutine making the request.
+func OnThrottle(fn func(service, operation string)) {
+	throttleMu.Lock()
+	defer throttleMu.Unlock()
+	throttleHandler = fn
+}
+
+// configureRequestHandling adds the configured retries and timeouts and the
+// shared rate limiter to cfg
+func configureRequestHandling(cfg *aws.Config) {
+	cfg.Retryer = newRetryer
+	cfg.APIOptions = append(cfg.APIOptions, addRateLimitMiddleware, addTimeoutMiddleware)
+}
+
+// addRateLimitMiddleware runs the rate limiter inside the retry loop so every attempt is limited
+func addRateLimitMiddleware(stack *middleware.Stack) error {
+	if err := stack.Finalize.Insert(rateLimitMiddleware, "Retry", middleware.After); err != nil {
+		return stack.Finalize.Add(rateLimitMiddleware, middleware.After)
+	}
+	return nil
+}
+
+var rateLimitMiddleware = middleware.FinalizeMiddlewareFunc("RateLimit",
+	func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		if err := apiLimiter.Wait(ctx); err != nil {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("waiting for rate limiter: %w", err)
+		}
+
+		out, metadata, err := next.HandleFinalize(ctx, in)
+		if err != nil && retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == aws.TrueTernary {
+			throttleMu.RLock()
+			handler := throttleHandler
+			throttleMu.RUnlock()
+
+			if handler != nil {
+				handler(awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx))
+			}
+		}
+		return out, metadata, err
+	})
@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestRateLimitMiddlewareReportsThrottling(t *testing.T) {
+	var throttled []string
+	OnThrottle(func(service, operation string) {
+		throttled = append(throttled, service)
+	})
+	defer OnThrottle(nil)
+
+	ctx := awsmiddleware.SetServiceID(context.Background(), "EC2")
+	run := func(err error) {
+		next := middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+		})
+		rateLimitMiddleware.HandleFinalize(ctx, middleware.FinalizeInput{}, next)
+	}
+
+	run(&smithy.GenericAPIError{Code: "ThrottlingException"})
+	run(errors.New("access denied"))
+	run(nil)
+
+	if len(throttled) != 1 || throttled[0] != "EC2" {
+		t.Errorf("Expected one throttle event for EC2, got %v", throttled)
+	}
+}
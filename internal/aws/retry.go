@@ -0,0 +1,148 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	appconfig "swiss-army-tui/internal/config"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// RequestOptions tune how AWS API calls are retried and how long they may
+// take, e.g. for users on flaky VPNs
+type RequestOptions struct {
+	// RetryMode is aws.RetryModeStandard or aws.RetryModeAdaptive, which
+	// also slows down clients that get throttled
+	RetryMode   aws.RetryMode
+	MaxAttempts int
+	MaxBackoff  time.Duration
+	// Timeout bounds a call including its retries; 0 leaves it to the caller
+	Timeout time.Duration
+	// OperationTimeouts overrides Timeout per operation, keyed by the
+	// operation name (GetObject) or service and operation name
+	// (s3:GetObject), ignoring case
+	OperationTimeouts map[string]time.Duration
+}
+
+// DefaultRequestOptions returns adaptive retries of up to 5 attempts,
+// backing off for up to 20 seconds, without timeouts
+func DefaultRequestOptions() RequestOptions {
+	return RequestOptions{
+		RetryMode:   aws.RetryModeAdaptive,
+		MaxAttempts: defaultMaxAttempts,
+		MaxBackoff:  defaultMaxBackoff,
+	}
+}
+
+// RequestOptionsFromConfig returns the request options of the aws section of
+// the configuration
+func RequestOptionsFromConfig(cfg appconfig.AWSConfig) RequestOptions {
+	opts := RequestOptions{
+		RetryMode:   aws.RetryModeAdaptive,
+		MaxAttempts: cfg.MaxAttempts,
+		MaxBackoff:  time.Duration(cfg.MaxBackoff) * time.Second,
+		Timeout:     time.Duration(cfg.RequestTimeout) * time.Second,
+	}
+	if cfg.RetryMode == appconfig.RetryModeStandard {
+		opts.RetryMode = aws.RetryModeStandard
+	}
+	if len(cfg.OperationTimeouts) > 0 {
+		opts.OperationTimeouts = make(map[string]time.Duration, len(cfg.OperationTimeouts))
+		for operation, seconds := range cfg.OperationTimeouts {
+			opts.OperationTimeouts[operation] = time.Duration(seconds) * time.Second
+		}
+	}
+	return opts
+}
+
+var (
+	requestMu      sync.RWMutex
+	requestOptions = DefaultRequestOptions()
+)
+
+// SetRequestOptions sets the retry and timeout options of all clients. The
+// retry options apply to clients created afterwards, the timeouts to all
+// calls made afterwards.
+func SetRequestOptions(opts RequestOptions) {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultMaxAttempts
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultMaxBackoff
+	}
+	timeouts := make(map[string]time.Duration, len(opts.OperationTimeouts))
+	for name, timeout := range opts.OperationTimeouts {
+		timeouts[strings.ToLower(name)] = timeout
+	}
+	opts.OperationTimeouts = timeouts
+
+	requestMu.Lock()
+	defer requestMu.Unlock()
+	requestOptions = opts
+}
+
+// currentRequestOptions returns the options set last
+func currentRequestOptions() RequestOptions {
+	requestMu.RLock()
+	defer requestMu.RUnlock()
+	return requestOptions
+}
+
+// newRetryer creates a retryer with the current retry options
+func newRetryer() aws.Retryer {
+	opts := currentRequestOptions()
+	standard := func(so *retry.StandardOptions) {
+		so.MaxAttempts = opts.MaxAttempts
+		so.MaxBackoff = opts.MaxBackoff
+	}
+	if opts.RetryMode == aws.RetryModeStandard {
+		return retry.NewStandard(standard)
+	}
+	return retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+		o.StandardOptions = append(o.StandardOptions, standard)
+	})
+}
+
+// operationTimeout returns the timeout of operation of service, 0 for none
+func (opts RequestOptions) operationTimeout(service, operation string) time.Duration {
+	operation = strings.ToLower(operation)
+	service = strings.ToLower(strings.ReplaceAll(service, " ", ""))
+	if timeout, ok := opts.OperationTimeouts[service+":"+operation]; ok {
+		return timeout
+	}
+	if timeout, ok := opts.OperationTimeouts[operation]; ok {
+		return timeout
+	}
+	return opts.Timeout
+}
+
+// addTimeoutMiddleware bounds every call, including its retries, by the
+// timeout of its operation
+func addTimeoutMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(timeoutMiddleware, middleware.After)
+}
+
+var timeoutMiddleware = middleware.InitializeMiddlewareFunc("OperationTimeout",
+	func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+		timeout := currentRequestOptions().operationTimeout(awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx))
+		if timeout <= 0 {
+			return next.HandleInitialize(ctx, in)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		out, metadata, err := next.HandleInitialize(ctx, in)
+		if err != nil {
+			cancel()
+			return out, metadata, err
+		}
+		// Streamed bodies such as of S3 GetObject are read after the call
+		// returns, so on success the context is left to expire on its own
+		time.AfterFunc(timeout, cancel)
+		return out, metadata, err
+	})
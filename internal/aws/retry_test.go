@@ -0,0 +1,71 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appconfig "swiss-army-tui/internal/config"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestRequestOptionsFromConfig(t *testing.T) {
+	SetRequestOptions(RequestOptionsFromConfig(appconfig.AWSConfig{
+		RetryMode:         appconfig.RetryModeStandard,
+		RequestTimeout:    30,
+		OperationTimeouts: map[string]int{"getobject": 120, "athena:GetQueryResults": 60},
+	}))
+	defer SetRequestOptions(DefaultRequestOptions())
+
+	if got := newRetryer().MaxAttempts(); got != defaultMaxAttempts {
+		t.Errorf("Expected the default of %d attempts, got %d", defaultMaxAttempts, got)
+	}
+
+	opts := currentRequestOptions()
+	tests := []struct {
+		service, operation string
+		expected           time.Duration
+	}{
+		{"S3", "GetObject", 120 * time.Second},
+		{"Athena", "GetQueryResults", 60 * time.Second},
+		{"CloudWatch Logs", "GetQueryResults", 30 * time.Second},
+		{"EC2", "DescribeInstances", 30 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := opts.operationTimeout(tt.service, tt.operation); got != tt.expected {
+			t.Errorf("%s %s: expected %s, got %s", tt.service, tt.operation, tt.expected, got)
+		}
+	}
+}
+
+func TestTimeoutMiddlewareBoundsCall(t *testing.T) {
+	SetRequestOptions(RequestOptions{MaxAttempts: 3, OperationTimeouts: map[string]time.Duration{"DescribeInstances": 10 * time.Millisecond}})
+	defer SetRequestOptions(DefaultRequestOptions())
+
+	run := func(operation string) error {
+		next := middleware.InitializeHandlerFunc(func(ctx context.Context, in middleware.InitializeInput) (middleware.InitializeOutput, middleware.Metadata, error) {
+			select {
+			case <-ctx.Done():
+				return middleware.InitializeOutput{}, middleware.Metadata{}, ctx.Err()
+			case <-time.After(100 * time.Millisecond):
+				return middleware.InitializeOutput{}, middleware.Metadata{}, nil
+			}
+		})
+		// The SDK registers the operation before the API options run
+		metadata := awsmiddleware.RegisterServiceMetadata{ServiceID: "EC2", OperationName: operation}
+		_, _, err := metadata.HandleInitialize(context.Background(), middleware.InitializeInput{},
+			middleware.InitializeHandlerFunc(func(ctx context.Context, in middleware.InitializeInput) (middleware.InitializeOutput, middleware.Metadata, error) {
+				return timeoutMiddleware.HandleInitialize(ctx, in, next)
+			}))
+		return err
+	}
+
+	if err := run("DescribeInstances"); err != context.DeadlineExceeded {
+		t.Errorf("Expected the call to time out, got %v", err)
+	}
+	if err := run("DescribeVolumes"); err != nil {
+		t.Errorf("Expected no timeout for other operations, got %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// The service interfaces below are what Client needs from each AWS service.
+// The clients package implements them on top of the AWS SDK; the fake
+// package implements them in memory for demo mode and tests.
+
+// EC2Service lists and controls EC2 instances and cleans up the AMIs and
+// snapshots nothing uses
+type EC2Service interface {
+	GetEC2Detail(ctx context.Context, onPage func([]types.Instance)) ([]types.Instance, error)
+	DescribeInstance(ctx context.Context, instanceID string) (types.Instance, error)
+	DescribeVolumes(ctx context.Context) ([]types.Volume, error)
+	DescribeAddresses(ctx context.Context) ([]types.Address, error)
+	DescribeNatGateways(ctx context.Context) ([]types.NatGateway, error)
+	DescribeSpotInstanceRequests(ctx context.Context) ([]types.SpotInstanceRequest, error)
+	DescribeReservedInstances(ctx context.Context) ([]types.ReservedInstances, error)
+	StartInstance(ctx context.Context, instanceID string) error
+	StopInstance(ctx context.Context, instanceID string) error
+	CanStartInstance(ctx context.Context, instanceID string) (bool, error)
+	CanStopInstance(ctx context.Context, instanceID string) (bool, error)
+	RebootInstance(ctx context.Context, instanceID string) error
+	TerminateInstance(ctx context.Context, instanceID string) error
+	FindCleanupCandidates(ctx context.Context, launches clients.LaunchReferences) (clients.CleanupCandidates, error)
+	CleanUp(ctx context.Context, images []clients.UnusedImage, snapshots []clients.UnusedSnapshot, dryRun bool) error
+}
+
+// S3Service lists buckets, looks up their regions and lists, previews,
+// deletes and copies objects. It also audits how buckets are exposed and
+// cleans up incomplete multipart uploads.
+type S3Service interface {
+	GetS3Detail(ctx context.Context) ([]clients.S3Details, error)
+	ListBuckets(ctx context.Context) ([]clients.S3Details, error)
+	LookupBucketRegions(ctx context.Context, names []string, onRegion func(name, region string)) error
+	ListObjects(ctx context.Context, bucket, prefix string, recursive bool) ([]clients.S3Object, error)
+	GetObjectPreview(ctx context.Context, bucket, key string, maxBytes int64) (clients.ObjectPreview, error)
+	DeleteObjects(ctx context.Context, bucket string, keys []string) error
+	CopyObject(ctx context.Context, bucket, key string, size int64, destBucket, destKey, storageClass string) error
+	AuditBuckets(ctx context.Context, buckets []clients.S3Details, account string) ([]clients.BucketExposure, error)
+	ListIncompleteUploads(ctx context.Context, buckets []clients.S3Details) ([]clients.BucketUploads, error)
+	AbortUploads(ctx context.Context, bucket string, uploads []clients.MultipartUpload) error
+	SetAbortIncompleteUploads(ctx context.Context, bucket string, days int) error
+}
+
+// RDSService lists RDS instances and their parameter groups
+type RDSService interface {
+	GetRDSDetail(ctx context.Context, onPage func([]clients.RDSDetails)) ([]clients.RDSDetails, error)
+	ListParameterGroups(ctx context.Context) ([]clients.ParameterGroup, error)
+	DiffParameterGroup(ctx context.Context, name, family string) ([]clients.ParameterDiff, error)
+}
+
+// LambdaService lists Lambda functions with their configuration and event
+// sources and manages their concurrency and the traffic of their aliases
+type LambdaService interface {
+	ListLambdaFunctions(ctx context.Context) ([]clients.LambdaFunctionDetail, error)
+	GetLambdaFunction(ctx context.Context, functionName string) (clients.LambdaFunctionDetail, error)
+	GetLambdaDetail(ctx context.Context) ([]clients.LambdaFunctionDetail, error)
+	GetConcurrency(ctx context.Context, functionName string) (clients.LambdaConcurrency, error)
+	PutReservedConcurrency(ctx context.Context, functionName string, executions int32) error
+	DeleteReservedConcurrency(ctx context.Context, functionName string) error
+	PutProvisionedConcurrency(ctx context.Context, functionName, qualifier string, executions int32) error
+	DeleteProvisionedConcurrency(ctx context.Context, functionName, qualifier string) error
+	ListEventSourceMappings(ctx context.Context, sourceARN string) ([]clients.EventSourceMapping, error)
+	ListAliases(ctx context.Context, functionName string) ([]clients.LambdaAlias, error)
+	ListVersions(ctx context.Context, functionName string) ([]string, error)
+	UpdateAliasRouting(ctx context.Context, functionName, alias, version, additionalVersion string, weight float64) error
+}
+
+// CloudWatchLogsService reads and tails CloudWatch Logs
+type CloudWatchLogsService interface {
+	DescribeLogStreams(ctx context.Context, logGroupName string, limit int32) ([]clients.LogStreamInfo, error)
+	GetLogEvents(ctx context.Context, logGroupName, logStreamName string, limit int32, startFromHead bool) ([]clients.LogEvent, *string, error)
+	GetLogEventsWithToken(ctx context.Context, logGroupName, logStreamName, nextToken string, limit int32) ([]clients.LogEvent, *string, error)
+	GetLogEventsSinceTime(ctx context.Context, logGroupName, logStreamName string, since time.Time, limit int32) ([]clients.LogEvent, error)
+	TailLogStreams(ctx context.Context, logGroupName string, logStreamNames []string, eventsChan chan<- clients.LogEvent, errorChan chan<- error)
+	FilterLogEvents(ctx context.Context, logGroupName, filterPattern string, since time.Time) ([]clients.LogEvent, error)
+	TailLogGroup(ctx context.Context, logGroupName, filterPattern string, since time.Time, eventsChan chan<- clients.LogEvent, errorChan chan<- error)
+	ListLogGroups(ctx context.Context, prefix, nextToken string) (clients.LogGroupPage, error)
+	ListAllLogGroups(ctx context.Context, prefix string) ([]clients.LogGroupInfo, error)
+	CreateExportTask(ctx context.Context, logGroup, bucket, prefix string, from, to time.Time) (string, error)
+	DescribeExportTask(ctx context.Context, id string) (clients.ExportTask, error)
+	CancelExportTask(ctx context.Context, id string) error
+}
+
+// CloudWatchService reads CloudWatch alarms and metrics
+type CloudWatchService interface {
+	DescribeAlarms(ctx context.Context) ([]clients.AlarmDetail, error)
+	AlarmHistory(ctx context.Context, name string, start, end time.Time) ([]clients.AlarmTransition, error)
+	MetricSum(ctx context.Context, namespace, metric, dimension, value string, start, end time.Time) (float64, error)
+	ListDashboards(ctx context.Context) ([]clients.DashboardDetail, error)
+	GetDashboardBody(ctx context.Context, name string) (string, error)
+	GetMetricSeries(ctx context.Context, queries []clients.MetricQuery, start, end time.Time) ([]clients.MetricSeries, error)
+}
+
+// ELBService lists load balancers
+type ELBService interface {
+	DescribeLoadBalancers(ctx context.Context) ([]clients.LoadBalancerDetail, error)
+}
+
+// SavingsPlansService lists Savings Plans
+type SavingsPlansService interface {
+	DescribeSavingsPlans(ctx context.Context) ([]clients.SavingsPlanDetail, error)
+}
+
+// AthenaService runs Athena queries and reads their results
+type AthenaService interface {
+	ListWorkGroups(ctx context.Context) ([]string, error)
+	ListDatabases(ctx context.Context) ([]string, error)
+	StartQuery(ctx context.Context, sql, workgroup, database string) (string, error)
+	GetQueryStatus(ctx context.Context, id string) (clients.QueryStatus, error)
+	GetQueryResults(ctx context.Context, id, token string, maxRows int32) (clients.QueryResultPage, error)
+	StopQuery(ctx context.Context, id string) error
+}
+
+// SESService reports on SES sending and manages the suppression list
+type SESService interface {
+	ListIdentities(ctx context.Context) ([]clients.EmailIdentityDetail, error)
+	GetSendingAccount(ctx context.Context) (clients.SendingAccount, error)
+	ListSuppressedDestinations(ctx context.Context) ([]clients.SuppressedDestination, error)
+	RemoveSuppressedDestination(ctx context.Context, email string) error
+}
+
+// ACMService lists certificates
+type ACMService interface {
+	ListCertificates(ctx context.Context) ([]clients.CertificateDetail, error)
+}
+
+// WAFService inspects WAF Web ACLs and the requests they sampled
+type WAFService interface {
+	ListWebACLs(ctx context.Context, scope string) ([]clients.WebACLSummary, error)
+	GetWebACL(ctx context.Context, acl clients.WebACLSummary) (clients.WebACLDetail, error)
+	ListResourcesForWebACL(ctx context.Context, arn string) ([]string, error)
+	GetSampledRequests(ctx context.Context, acl clients.WebACLSummary, metricName string, start, end time.Time) ([]clients.SampledRequest, int64, error)
+}
+
+// SupportService reads Trusted Advisor checks and what they flagged
+type SupportService interface {
+	ListTrustedAdvisorChecks(ctx context.Context) ([]clients.TrustedAdvisorCheck, error)
+	GetFlaggedResources(ctx context.Context, checkID string) ([]clients.FlaggedResource, error)
+}
+
+// HealthService reads AWS Health events and the resources they affect
+type HealthService interface {
+	ListOpenEvents(ctx context.Context) ([]clients.HealthEvent, error)
+	GetEventDescription(ctx context.Context, arn string) (string, error)
+	ListAffectedEntities(ctx context.Context, arn string) ([]clients.AffectedEntity, error)
+}
+
+// DynamoDBService lists DynamoDB tables
+type DynamoDBService interface {
+	ListTables(ctx context.Context) ([]clients.TableDetail, error)
+}
+
+// ApplicationAutoScalingService reads how Application Auto Scaling scales
+// resources such as DynamoDB tables
+type ApplicationAutoScalingService interface {
+	GetTableScaling(ctx context.Context, tableName string, indexes []string) ([]clients.ScalableTarget, error)
+}
+
+// AutoScalingService lists EC2 Auto Scaling groups and what they launch
+// from, and sets their capacity
+type AutoScalingService interface {
+	ListGroups(ctx context.Context) ([]clients.AutoScalingGroup, error)
+	SetCapacity(ctx context.Context, name string, min, max, desired int32) error
+	ListLaunchReferences(ctx context.Context) (clients.LaunchReferences, error)
+}
+
+// SNSService lists SNS topics and their subscriptions
+type SNSService interface {
+	ListTopics(ctx context.Context) ([]clients.TopicDetail, error)
+	GetTopic(ctx context.Context, arn string) (clients.TopicDetail, error)
+	ListSubscriptions(ctx context.Context, topicARN string) ([]clients.Subscription, error)
+}
+
+// SQSService reads the depth of SQS queues
+type SQSService interface {
+	GetQueue(ctx context.Context, arn string) (clients.QueueDetail, error)
+}
+
+// EKSService lists EKS clusters and reads the namespaces, deployments, pods
+// and pod logs of a cluster from its Kubernetes API
+type EKSService interface {
+	ListClusters(ctx context.Context) ([]clients.EKSCluster, error)
+	ListNamespaces(ctx context.Context, cluster string) ([]string, error)
+	ListDeployments(ctx context.Context, cluster, namespace string) ([]clients.Deployment, error)
+	ListPods(ctx context.Context, cluster, namespace string) ([]clients.Pod, error)
+	StreamPodLogs(ctx context.Context, cluster string, pod clients.Pod, tailLines int, lines chan<- clients.PodLogLine) error
+}
+
+// ECSService lists ECS clusters, their services with the images they run
+// and the tasks of a service with their containers
+type ECSService interface {
+	ListServices(ctx context.Context) ([]clients.ECSServiceDetail, error)
+	ListClusters(ctx context.Context) ([]clients.ECSCluster, error)
+	ListTasks(ctx context.Context, cluster, service string) ([]clients.ECSTask, error)
+}
+
+// ECRService reads the scan findings of container images in ECR
+type ECRService interface {
+	GetImageScanFindings(ctx context.Context, image string) (clients.ImageScanFindings, error)
+}
+
+// IAMService reads IAM roles, their trust policies and the services they use
+type IAMService interface {
+	ListRoles(ctx context.Context) ([]clients.IAMRole, error)
+	GetServiceLastAccessed(ctx context.Context, arn string) ([]clients.ServiceLastAccessed, error)
+}
+
+// CloudFormationService lists CloudFormation stacks with their resources
+// and StackSets with their instances, and detects how the resources of
+// stacks drifted from their templates
+type CloudFormationService interface {
+	ListStacks(ctx context.Context) ([]clients.Stack, error)
+	ListStackResources(ctx context.Context, stack string) ([]clients.StackResource, error)
+	ListStackSets(ctx context.Context) ([]clients.StackSet, error)
+	ListStackInstances(ctx context.Context, stackSet string) ([]clients.StackInstance, error)
+	DetectDrift(ctx context.Context, stack string) (string, error)
+	WaitForDriftDetection(ctx context.Context, id string) (clients.DriftDetection, error)
+	ListResourceDrifts(ctx context.Context, stack string) ([]clients.ResourceDrift, error)
+}
+
+// TransferService lists Transfer Family servers and their users
+type TransferService interface {
+	ListServers(ctx context.Context) ([]clients.TransferServer, error)
+	ListUsers(ctx context.Context, server string) ([]clients.TransferUser, error)
+}
+
+// DataSyncService lists DataSync tasks and the history of their executions
+type DataSyncService interface {
+	ListTasks(ctx context.Context) ([]clients.DataSyncTask, error)
+	ListTaskExecutions(ctx context.Context, task string, limit int) ([]clients.DataSyncExecution, error)
+}
+
+// BatchService reads AWS Batch job queues, compute environments and jobs,
+// and terminates jobs
+type BatchService interface {
+	ListJobQueues(ctx context.Context) ([]clients.BatchJobQueue, error)
+	ListComputeEnvironments(ctx context.Context) ([]clients.BatchComputeEnvironment, error)
+	ListJobs(ctx context.Context, queue, status string) ([]clients.BatchJob, error)
+	TerminateJob(ctx context.Context, id, reason string) error
+}
+
+// SageMakerService lists SageMaker notebook instances, endpoints and
+// training jobs, stops notebooks and deletes endpoints
+type SageMakerService interface {
+	ListNotebookInstances(ctx context.Context) ([]clients.SageMakerNotebook, error)
+	ListEndpoints(ctx context.Context) ([]clients.SageMakerEndpoint, error)
+	ListTrainingJobs(ctx context.Context, limit int) ([]clients.SageMakerTrainingJob, error)
+	StopNotebookInstance(ctx context.Context, name string) error
+	DeleteEndpoint(ctx context.Context, name string) error
+}
+
+// BedrockService lists the foundation models of Amazon Bedrock and sends
+// them prompts
+type BedrockService interface {
+	ListFoundationModels(ctx context.Context) ([]clients.BedrockModel, error)
+	Converse(ctx context.Context, modelID, prompt string, maxTokens int) (clients.BedrockReply, error)
+}
+
+// AppConfigService lists AWS AppConfig applications with their
+// environments, configuration profiles, deployments and hosted versions,
+// and starts deployments
+type AppConfigService interface {
+	ListApplications(ctx context.Context) ([]clients.AppConfigApplication, error)
+	ListEnvironments(ctx context.Context, applicationID string) ([]clients.AppConfigEnvironment, error)
+	ListConfigurationProfiles(ctx context.Context, applicationID string) ([]clients.AppConfigProfile, error)
+	ListDeployments(ctx context.Context, applicationID, environmentID string) ([]clients.AppConfigDeployment, error)
+	ListHostedVersions(ctx context.Context, applicationID, profileID string) ([]clients.AppConfigVersion, error)
+	GetHostedVersion(ctx context.Context, applicationID, profileID string, version int) ([]byte, error)
+	ListDeploymentStrategies(ctx context.Context) ([]clients.AppConfigStrategy, error)
+	StartDeployment(ctx context.Context, applicationID, environmentID, profileID, version, strategyID string) (clients.AppConfigDeployment, error)
+}
+
+// AccessAnalyzerService reads the findings of IAM Access Analyzer
+type AccessAnalyzerService interface {
+	ListBucketFindings(ctx context.Context, region string) ([]clients.AccessFinding, error)
+}
+
+// PerformanceInsightsService reads the database load of RDS instances with
+// Performance Insights enabled
+type PerformanceInsightsService interface {
+	GetDBLoad(ctx context.Context, resourceID string, start, end time.Time, limit int) (clients.DBLoad, error)
+}
+
+// STSService resolves the identity of the credentials in use
+type STSService interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+var (
+	_ EC2Service                    = (*clients.EC2Service)(nil)
+	_ S3Service                     = (*clients.S3Service)(nil)
+	_ RDSService                    = (*clients.RDSService)(nil)
+	_ LambdaService                 = (*clients.LambdaService)(nil)
+	_ CloudWatchLogsService         = (*clients.CloudWatchLogsService)(nil)
+	_ CloudWatchService             = (*clients.CloudWatchService)(nil)
+	_ ELBService                    = (*clients.ELBService)(nil)
+	_ SavingsPlansService           = (*clients.SavingsPlansService)(nil)
+	_ AthenaService                 = (*clients.AthenaService)(nil)
+	_ SESService                    = (*clients.SESService)(nil)
+	_ ACMService                    = (*clients.ACMService)(nil)
+	_ WAFService                    = (*clients.WAFService)(nil)
+	_ SupportService                = (*clients.SupportService)(nil)
+	_ HealthService                 = (*clients.HealthService)(nil)
+	_ DynamoDBService               = (*clients.DynamoDBService)(nil)
+	_ ApplicationAutoScalingService = (*clients.ApplicationAutoScalingService)(nil)
+	_ AutoScalingService            = (*clients.AutoScalingService)(nil)
+	_ SNSService                    = (*clients.SNSService)(nil)
+	_ SQSService                    = (*clients.SQSService)(nil)
+	_ EKSService                    = (*clients.EKSService)(nil)
+	_ ECSService                    = (*clients.ECSService)(nil)
+	_ ECRService                    = (*clients.ECRService)(nil)
+	_ IAMService                    = (*clients.IAMService)(nil)
+	_ CloudFormationService         = (*clients.CloudFormationService)(nil)
+	_ TransferService               = (*clients.TransferService)(nil)
+	_ DataSyncService               = (*clients.DataSyncService)(nil)
+	_ BatchService                  = (*clients.BatchService)(nil)
+	_ SageMakerService              = (*clients.SageMakerService)(nil)
+	_ BedrockService                = (*clients.BedrockService)(nil)
+	_ AppConfigService              = (*clients.AppConfigService)(nil)
+	_ AccessAnalyzerService         = (*clients.AccessAnalyzerService)(nil)
+	_ PerformanceInsightsService    = (*clients.PerformanceInsightsService)(nil)
+	_ STSService                    = (*sts.Client)(nil)
+)
@@ -0,0 +1,205 @@
+package aws
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"swiss-army-tui/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// ssoTokenCacheDir returns the directory the AWS CLI and SDK cache SSO tokens
+// in. Tests point it elsewhere.
+var ssoTokenCacheDir = func() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".aws", "sso", "cache")
+}
+
+// SSOSession is an [sso-session] section of the config file. Profiles naming
+// it in sso_session sign in with one token, cached under its name.
+type SSOSession struct {
+	Name               string `json:"name"`
+	StartURL           string `json:"sso_start_url,omitempty"`
+	Region             string `json:"sso_region,omitempty"`
+	RegistrationScopes string `json:"sso_registration_scopes,omitempty"`
+}
+
+// SSOShare is the SSO token a profile signs in with and the profiles sharing
+// it. Profiles of one sso-session share its token; legacy profiles without a
+// session share the token of their start URL.
+type SSOShare struct {
+	// Session is the sso-session of the token, "" for legacy profiles
+	Session  string
+	StartURL string
+	// Profiles are all profiles signing in with the token, sorted
+	Profiles []string
+	// ExpiresAt is when the cached token expires, zero if none is cached
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the cached token expired before now. Without a
+// cached token there is nothing to expire; the SDK asks for a login itself.
+func (s *SSOShare) Expired(now time.Time) bool {
+	return s != nil && !s.ExpiresAt.IsZero() && !now.Before(s.ExpiresAt)
+}
+
+// Others returns the profiles sharing the token besides profile
+func (s *SSOShare) Others(profile string) []string {
+	if s == nil {
+		return nil
+	}
+	var others []string
+	for _, name := range s.Profiles {
+		if name != profile {
+			others = append(others, name)
+		}
+	}
+	return others
+}
+
+// LoginCommand is the command signing profile in again. With a session it
+// signs in all profiles sharing it at once.
+func (s *SSOShare) LoginCommand(profile string) string {
+	if s != nil && s.Session != "" {
+		return "aws sso login --sso-session " + s.Session
+	}
+	return "aws sso login --profile " + profile
+}
+
+// GetSSOSession returns an [sso-session] section by name
+func (pm *ProfileManager) GetSSOSession(name string) (*SSOSession, bool) {
+	session, exists := pm.sessions[name]
+	return session, exists
+}
+
+// resolveSSOSessions fills in the start URL and SSO region of profiles from
+// the sso-session they name, where the profile does not set them itself
+func (pm *ProfileManager) resolveSSOSessions() {
+	for _, profile := range pm.profiles {
+		if profile.SSOSessionName == "" {
+			continue
+		}
+		session, exists := pm.sessions[profile.SSOSessionName]
+		if !exists {
+			logger.Warn("Profile names an unknown SSO session",
+				zap.String("profile", profile.Name),
+				zap.String("sso_session", profile.SSOSessionName))
+			continue
+		}
+		if profile.SSOStartURL == "" {
+			profile.SSOStartURL = session.StartURL
+		}
+		if profile.SSORegion == "" {
+			profile.SSORegion = session.Region
+		}
+	}
+}
+
+// SSOShare returns the SSO token profile signs in with, or nil if it is not
+// an SSO profile. The expiry is read from the token cache, so it reflects
+// logins made outside this program too.
+func (pm *ProfileManager) SSOShare(profile string) *SSOShare {
+	p, exists := pm.profiles[profile]
+	if !exists || !p.IsSSOProfileConfigured() {
+		return nil
+	}
+
+	share := &SSOShare{Session: p.SSOSessionName, StartURL: p.SSOStartURL}
+	for _, other := range pm.profiles {
+		if other.IsSSOProfileConfigured() && ssoTokenKey(other) == ssoTokenKey(p) {
+			share.Profiles = append(share.Profiles, other.Name)
+		}
+	}
+	sort.Strings(share.Profiles)
+
+	expiresAt, err := cachedSSOTokenExpiry(ssoTokenKey(p))
+	if err != nil {
+		logger.Debug("No cached SSO token", zap.String("profile", profile), zap.Error(err))
+	}
+	share.ExpiresAt = expiresAt
+	return share
+}
+
+// ssoTokenKey is what the SDK caches the token of p under: the session
+// name, or the start URL for legacy profiles
+func ssoTokenKey(p *Profile) string {
+	if p.SSOSessionName != "" {
+		return p.SSOSessionName
+	}
+	return p.SSOStartURL
+}
+
+// cachedSSOTokenExpiry returns when the cached token of key expires. The
+// cache file is named by the SHA-1 of the key, as the AWS CLI and SDK do.
+func cachedSSOTokenExpiry(key string) (time.Time, error) {
+	dir := ssoTokenCacheDir()
+	if dir == "" {
+		return time.Time{}, fmt.Errorf("no SSO token cache directory")
+	}
+	sum := sha1.Sum([]byte(key))
+	data, err := os.ReadFile(filepath.Join(dir, hex.EncodeToString(sum[:])+".json"))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var token struct {
+		ExpiresAt string `json:"expiresAt"`
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse the cached SSO token: %w", err)
+	}
+	expiresAt, err := time.Parse(time.RFC3339, token.ExpiresAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse the expiry of the cached SSO token: %w", err)
+	}
+	return expiresAt, nil
+}
+
+// lookupSSOShare reads the profiles from the default config files and
+// returns the SSO token of profile, or nil if it is not an SSO profile
+func lookupSSOShare(profile string) *SSOShare {
+	pm := NewProfileManager(GetDefaultConfigPath(), GetDefaultCredentialsPath())
+	if err := pm.LoadProfiles(); err != nil {
+		return nil
+	}
+	return pm.SSOShare(profile)
+}
+
+// ssoLoginHint tells how to sign in again after the SSO token of profile
+// expired, naming the profiles signed out with it
+func ssoLoginHint(profile string, share *SSOShare) string {
+	others := share.Others(profile)
+	if len(others) == 0 {
+		return fmt.Sprintf("The SSO session has expired. Run '%s' and try again.", share.LoginCommand(profile))
+	}
+	return fmt.Sprintf("The SSO session has expired for profiles %s and %s alike. Run '%s' once and try again.",
+		profile, strings.Join(others, ", "), share.LoginCommand(profile))
+}
+
+// invalidateSSOShare forgets the caller identities of all profiles sharing
+// the token of share, so none of them is used as signed in after it expired
+func invalidateSSOShare(share *SSOShare) {
+	if share == nil {
+		return
+	}
+	identityCache.Lock()
+	defer identityCache.Unlock()
+
+	for _, name := range share.Profiles {
+		delete(identityCache.entries, name)
+	}
+	logger.Info("Invalidated profiles of the expired SSO session",
+		zap.String("sso_session", share.Session),
+		zap.Strings("profiles", share.Profiles))
+}
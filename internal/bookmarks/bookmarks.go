@@ -0,0 +1,172 @@
+package bookmarks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Target names a view to open: a log group (logs:/aws/lambda/foo), the
+// listing of a service (ec2), a resource in it (ec2:i-0abc) or a saved
+// bookmark (bookmark:name)
+type Target struct {
+	Kind string
+	Ref  string
+}
+
+// ParseTarget parses a target of the form kind or kind:ref. Only the kind is
+// case insensitive.
+func ParseTarget(spec string) (Target, error) {
+	kind, ref, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	target := Target{Kind: strings.ToLower(strings.TrimSpace(kind)), Ref: strings.TrimSpace(ref)}
+	if target.Kind == "" {
+		return Target{}, fmt.Errorf("invalid target %q, expected kind:ref such as ec2:i-0abc or logs:/aws/lambda/foo", spec)
+	}
+	switch {
+	case target.Kind == "logs" && target.Ref == "":
+		return Target{}, fmt.Errorf("target %q needs a log group, such as logs:/aws/lambda/foo", spec)
+	case target.Kind == "bookmark" && target.Ref == "":
+		return Target{}, fmt.Errorf("target %q needs the name of a bookmark", spec)
+	}
+	return target, nil
+}
+
+// String returns the target in the form ParseTarget reads
+func (t Target) String() string {
+	if t.Ref == "" {
+		return t.Kind
+	}
+	return t.Kind + ":" + t.Ref
+}
+
+// Bookmark is a saved target with the profile and region it belongs to
+type Bookmark struct {
+	Name    string `json:"name"`
+	Target  string `json:"target"`
+	Profile string `json:"profile,omitempty"`
+	Region  string `json:"region,omitempty"`
+}
+
+// Store keeps bookmarks in a JSON file
+type Store struct {
+	path      string
+	mu        sync.Mutex
+	bookmarks []Bookmark
+}
+
+// New creates a store keeping bookmarks in path
+func New(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultPath returns ~/.swiss-army-tui/bookmarks.json
+func DefaultPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "bookmarks.json"
+	}
+	return filepath.Join(homeDir, ".swiss-army-tui", "bookmarks.json")
+}
+
+// Load reads the saved bookmarks. A missing file yields none.
+func (s *Store) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read bookmarks: %w", err)
+	}
+
+	var bookmarks []Bookmark
+	if err := json.Unmarshal(data, &bookmarks); err != nil {
+		return fmt.Errorf("failed to decode bookmarks: %w", err)
+	}
+	s.bookmarks = bookmarks
+	return nil
+}
+
+// List returns the bookmarks in the order they were added
+func (s *Store) List() []Bookmark {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Bookmark(nil), s.bookmarks...)
+}
+
+// Find returns the bookmark called name, ignoring case
+func (s *Store) Find(name string) (Bookmark, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range s.bookmarks {
+		if strings.EqualFold(b.Name, name) {
+			return b, true
+		}
+	}
+	return Bookmark{}, false
+}
+
+// Add saves bookmark, replacing a bookmark of the same name
+func (s *Store) Add(bookmark Bookmark) error {
+	if strings.TrimSpace(bookmark.Name) == "" {
+		return fmt.Errorf("bookmark name is empty")
+	}
+	if _, err := ParseTarget(bookmark.Target); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	replaced := false
+	for i, b := range s.bookmarks {
+		if strings.EqualFold(b.Name, bookmark.Name) {
+			s.bookmarks[i] = bookmark
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		s.bookmarks = append(s.bookmarks, bookmark)
+	}
+	return s.save()
+}
+
+// Remove deletes the bookmark called name
+func (s *Store) Remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, b := range s.bookmarks {
+		if strings.EqualFold(b.Name, name) {
+			s.bookmarks = append(s.bookmarks[:i], s.bookmarks[i+1:]...)
+			return s.save()
+		}
+	}
+	return fmt.Errorf("no bookmark named %q", name)
+}
+
+// save writes all bookmarks to the file. The caller must hold s.mu.
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(s.bookmarks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bookmarks: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create bookmarks directory: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write bookmarks: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write bookmarks: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package bookmarks
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		spec string
+		want Target
+	}{
+		{"ec2:i-0abc", Target{Kind: "ec2", Ref: "i-0abc"}},
+		{"EC2", Target{Kind: "ec2"}},
+		{"logs:/aws/lambda/foo", Target{Kind: "logs", Ref: "/aws/lambda/foo"}},
+		{"sns:arn:aws:sns:us-east-1:123456789012:orders", Target{Kind: "sns", Ref: "arn:aws:sns:us-east-1:123456789012:orders"}},
+		{" bookmark:Prod DB ", Target{Kind: "bookmark", Ref: "Prod DB"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseTarget(tt.spec)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %+v, got %+v", tt.spec, tt.want, got)
+		}
+		if again, _ := ParseTarget(got.String()); again != got {
+			t.Errorf("%s: expected %q to parse to the same target, got %+v", tt.spec, got.String(), again)
+		}
+	}
+
+	for _, spec := range []string{"", ":i-0abc", "logs", "logs:", "bookmark:"} {
+		if _, err := ParseTarget(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "bookmarks.json")
+	store := New(path)
+	if err := store.Load(); err != nil {
+		t.Fatalf("Expected no error for missing file, got %v", err)
+	}
+
+	for _, b := range []Bookmark{
+		{Name: "web", Target: "ec2:i-0abc", Profile: "prod", Region: "eu-west-1"},
+		{Name: "orders logs", Target: "logs:/aws/lambda/orders"},
+		{Name: "WEB", Target: "ec2:i-0def", Profile: "prod", Region: "eu-west-1"},
+	} {
+		if err := store.Add(b); err != nil {
+			t.Fatalf("Failed to add %s: %v", b.Name, err)
+		}
+	}
+	if err := store.Add(Bookmark{Name: "broken", Target: "logs"}); err == nil {
+		t.Error("Expected an invalid target to be rejected")
+	}
+	if err := store.Add(Bookmark{Name: " ", Target: "ec2"}); err == nil {
+		t.Error("Expected an empty name to be rejected")
+	}
+
+	reloaded := New(path)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	list := reloaded.List()
+	if len(list) != 2 {
+		t.Fatalf("Expected 2 bookmarks, got %+v", list)
+	}
+	// A bookmark of the same name is replaced in place
+	if list[0].Name != "WEB" || list[0].Target != "ec2:i-0def" || list[1].Name != "orders logs" {
+		t.Errorf("Unexpected bookmarks %+v", list)
+	}
+	if b, ok := reloaded.Find("web"); !ok || b.Region != "eu-west-1" {
+		t.Errorf("Expected to find web, got %+v, %v", b, ok)
+	}
+
+	if err := reloaded.Remove("Web"); err != nil {
+		t.Fatalf("Failed to remove: %v", err)
+	}
+	if err := reloaded.Remove("web"); err == nil {
+		t.Error("Expected removing a missing bookmark to fail")
+	}
+	if _, ok := reloaded.Find("web"); ok {
+		t.Error("Expected web to be removed")
+	}
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache stores values with the time they were fetched. Entries are kept after
+// their TTL expires so callers can show stale data while revalidating.
+type Cache[V any] struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]entry[V]
+}
+
+type entry[V any] struct {
+	value     V
+	fetchedAt time.Time
+}
+
+// New creates a cache whose entries are fresh for ttl. A ttl of zero
+// disables caching.
+func New[V any](ttl time.Duration) *Cache[V] {
+	return &Cache[V]{
+		ttl:     ttl,
+		entries: make(map[string]entry[V]),
+	}
+}
+
+// Get returns the value stored for key, when it was fetched and whether it is still fresh
+func (c *Cache[V]) Get(key string) (value V, fetchedAt time.Time, fresh bool, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.ttl <= 0 {
+		return value, fetchedAt, false, false
+	}
+
+	e, ok := c.entries[key]
+	if !ok {
+		return value, fetchedAt, false, false
+	}
+	return e.value, e.fetchedAt, time.Since(e.fetchedAt) < c.ttl, true
+}
+
+// Set stores value for key as fetched now
+func (c *Cache[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry[V]{value: value, fetchedAt: time.Now()}
+}
+
+// Invalidate removes the value stored for key
+func (c *Cache[V]) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// SetTTL changes how long entries stay fresh
+func (c *Cache[V]) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ttl = ttl
+}
+
+// Each calls fn with every stored value, fresh or not, in no particular order
+func (c *Cache[V]) Each(fn func(key string, value V, fetchedAt time.Time)) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for key, e := range c.entries {
+		fn(key, e.value, e.fetchedAt)
+	}
+}
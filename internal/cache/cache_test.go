@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheFreshAndStale(t *testing.T) {
+	c := New[[]string](time.Hour)
+
+	if _, _, _, ok := c.Get("ec2"); ok {
+		t.Fatal("Expected miss for empty cache")
+	}
+
+	c.Set("ec2", []string{"i-1"})
+	value, fetchedAt, fresh, ok := c.Get("ec2")
+	if !ok || !fresh || len(value) != 1 || fetchedAt.IsZero() {
+		t.Errorf("Expected fresh hit, got %v %v %v %v", value, fetchedAt, fresh, ok)
+	}
+
+	c.SetTTL(time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, _, fresh, ok := c.Get("ec2"); !ok || fresh {
+		t.Errorf("Expected stale hit after TTL, got fresh=%v ok=%v", fresh, ok)
+	}
+
+	c.Invalidate("ec2")
+	if _, _, _, ok := c.Get("ec2"); ok {
+		t.Error("Expected miss after invalidation")
+	}
+}
+
+func TestCacheDisabled(t *testing.T) {
+	c := New[int](0)
+	c.Set("key", 1)
+	if _, _, _, ok := c.Get("key"); ok {
+		t.Error("Expected zero TTL to disable caching")
+	}
+}
+
+func TestCacheEach(t *testing.T) {
+	c := New[int](time.Hour)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	seen := map[string]int{}
+	c.Each(func(key string, value int, fetchedAt time.Time) {
+		if fetchedAt.IsZero() {
+			t.Errorf("Expected fetch time for %s", key)
+		}
+		seen[key] = value
+	})
+	if len(seen) != 2 || seen["a"] != 1 || seen["b"] != 2 {
+		t.Errorf("Expected both entries, got %v", seen)
+	}
+}
@@ -2,12 +2,21 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/update"
 	"swiss-army-tui/pkg/logger"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
+	"go.uber.org/zap"
 )
 
 // Config represents the application configuration
@@ -15,6 +24,9 @@ type Config struct {
 	App    AppConfig     `mapstructure:"app" yaml:"app"`
 	AWS    AWSConfig     `mapstructure:"aws" yaml:"aws"`
 	UI     UIConfig      `mapstructure:"ui" yaml:"ui"`
+	Logs   LogsConfig    `mapstructure:"logs" yaml:"logs"`
+	Alerts AlertsConfig  `mapstructure:"alerts" yaml:"alerts"`
+	Update UpdateConfig  `mapstructure:"update" yaml:"update"`
 	Logger logger.Config `mapstructure:"logger" yaml:"logger"`
 }
 
@@ -33,17 +45,178 @@ type AWSConfig struct {
 	Profiles        map[string]string `mapstructure:"profiles" yaml:"profiles"`
 	ConfigPath      string            `mapstructure:"config_path" yaml:"config_path"`
 	CredentialsPath string            `mapstructure:"credentials_path" yaml:"credentials_path"`
+	// RateLimit caps the AWS API requests per second across all clients; 0 disables it
+	RateLimit int `mapstructure:"rate_limit" yaml:"rate_limit"`
+	// RetryMode is the SDK retry mode, "standard" or "adaptive" (the default)
+	RetryMode string `mapstructure:"retry_mode" yaml:"retry_mode"`
+	// MaxAttempts is how often a failing call is tried; 0 uses the default
+	MaxAttempts int `mapstructure:"max_attempts" yaml:"max_attempts"`
+	// MaxBackoff is the most seconds to wait between two attempts; 0 uses
+	// the default
+	MaxBackoff int `mapstructure:"max_backoff" yaml:"max_backoff"`
+	// RequestTimeout is how many seconds an AWS API call may take including
+	// its retries; 0 disables the timeout
+	RequestTimeout int `mapstructure:"request_timeout" yaml:"request_timeout"`
+	// OperationTimeouts overrides RequestTimeout in seconds per operation,
+	// such as GetObject or athena:GetQueryResults
+	OperationTimeouts map[string]int `mapstructure:"operation_timeouts" yaml:"operation_timeouts"`
+	// Concurrency is how many calls of a fan-out over many items, such as the
+	// configuration of every Lambda function, run at once per service; 0
+	// uses the default
+	Concurrency int `mapstructure:"concurrency" yaml:"concurrency"`
+	// ServiceConcurrency overrides Concurrency per service, such as lambda,
+	// s3, iam, insights or regions
+	ServiceConcurrency map[string]int `mapstructure:"service_concurrency" yaml:"service_concurrency"`
 }
 
+// Retry modes of AWS API calls
+const (
+	RetryModeStandard = "standard"
+	RetryModeAdaptive = "adaptive"
+)
+
 // UIConfig holds UI-related configuration
 type UIConfig struct {
 	Theme           string `mapstructure:"theme" yaml:"theme"`
 	RefreshInterval int    `mapstructure:"refresh_interval" yaml:"refresh_interval"`
 	MouseEnabled    bool   `mapstructure:"mouse_enabled" yaml:"mouse_enabled"`
 	BorderStyle     string `mapstructure:"border_style" yaml:"border_style"`
+	LogBufferSize   int    `mapstructure:"log_buffer_size" yaml:"log_buffer_size"`
+	// CacheTTL is how many seconds resource listings are served from the cache; 0 disables it
+	CacheTTL int `mapstructure:"cache_ttl" yaml:"cache_ttl"`
+	// PrefetchServices is how many recently used services are loaded in the
+	// background when the Resources tab opens; 0 disables prefetching
+	PrefetchServices int `mapstructure:"prefetch_services" yaml:"prefetch_services"`
+	// PreviewKB is how many KB of an S3 object are fetched to preview it
+	PreviewKB   int               `mapstructure:"preview_kb" yaml:"preview_kb"`
+	KeyBindings map[string]string `mapstructure:"keybindings" yaml:"keybindings"`
+	// Services lists the Resources tab services in display order. Services not
+	// listed are hidden; an empty list shows all services.
+	Services []string `mapstructure:"services" yaml:"services"`
+	// Timezone timestamps are shown in: local, UTC or an IANA name such as
+	// Europe/Berlin
+	Timezone string `mapstructure:"timezone" yaml:"timezone"`
+	// Timestamps is how timestamps are shown: time, datetime or relative
+	Timestamps string `mapstructure:"timestamps" yaml:"timestamps"`
+	// Locale is the language of the interface, such as en or de
+	Locale string `mapstructure:"locale" yaml:"locale"`
+	// Accessible shows services with ASCII labels instead of icons and puts
+	// a word to every state shown in color. Setting NO_COLOR turns it on
+	// and drops the colors too.
+	Accessible bool `mapstructure:"accessible" yaml:"accessible"`
 }
 
-var globalConfig *Config
+// Timestamp formats. Log rows show the time of day, or the date and time,
+// or the age of the entry; resource columns show the date and time, or the
+// age.
+const (
+	TimestampsTime     = "time"
+	TimestampsDateTime = "datetime"
+	TimestampsRelative = "relative"
+)
+
+// TimezoneLocal shows timestamps in the zone of the machine
+const TimezoneLocal = "local"
+
+// LoadTimezone returns the location of a timezone setting; empty is local
+func LoadTimezone(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "", TimezoneLocal:
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+	return loc, nil
+}
+
+// LogsConfig holds the settings of the Logs tab
+type LogsConfig struct {
+	// LevelRules infer the level of CloudWatch events; the first matching
+	// rule wins and events no rule matches are guessed from common formats
+	LevelRules []LevelRule `mapstructure:"level_rules" yaml:"level_rules"`
+}
+
+// LevelRule reads the level of the CloudWatch events of the log groups
+// starting with LogGroup from a JSON field or a regular expression
+type LevelRule struct {
+	// LogGroup is a log group name prefix; empty matches all groups
+	LogGroup string `mapstructure:"log_group" yaml:"log_group,omitempty"`
+	// Field is the dotted path of the JSON field holding the level, e.g.
+	// level or log.level
+	Field string `mapstructure:"field" yaml:"field,omitempty"`
+	// Pattern is a regular expression whose group named level, or else its
+	// first group, holds the level. A pattern without groups sets Level.
+	Pattern string `mapstructure:"pattern" yaml:"pattern,omitempty"`
+	Level   string `mapstructure:"level" yaml:"level,omitempty"`
+}
+
+// Bounds of the refresh interval in seconds
+const (
+	MinRefreshInterval = 1
+	MaxRefreshInterval = 3600
+)
+
+// regionPattern matches AWS region names such as us-east-1 or us-gov-west-1
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-gov|-iso[a-z]?)?-[a-z]+-[0-9]+$`)
+
+// repositoryPattern matches GitHub repositories such as owner/name
+var repositoryPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+// IsValidRegion reports whether region looks like an AWS region name
+func IsValidRegion(region string) bool {
+	return regionPattern.MatchString(region)
+}
+
+// Watch rule types
+const (
+	RuleLogPattern    = "log_pattern"
+	RuleAlarmState    = "alarm_state"
+	RuleInstanceState = "instance_state"
+)
+
+// UpdateConfig holds where new versions are looked up
+type UpdateConfig struct {
+	// Check looks for a new release in the background when the TUI starts;
+	// off for air-gapped machines
+	Check bool `mapstructure:"check" yaml:"check"`
+	// Repository is the GitHub repository ("owner/name") releases come from
+	Repository string `mapstructure:"repository" yaml:"repository"`
+	// APIURL is the GitHub API, e.g. of GitHub Enterprise or a mirror
+	APIURL string `mapstructure:"api_url" yaml:"api_url"`
+}
+
+// AlertsConfig holds the watch rules that post notifications to webhooks
+type AlertsConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// WebhookURL is used by rules that don't set their own webhook
+	WebhookURL   string      `mapstructure:"webhook_url" yaml:"webhook_url"`
+	PollInterval int         `mapstructure:"poll_interval" yaml:"poll_interval"`
+	Rules        []WatchRule `mapstructure:"rules" yaml:"rules"`
+}
+
+// WatchRule describes a condition that triggers a notification
+type WatchRule struct {
+	Name string `mapstructure:"name" yaml:"name"`
+	Type string `mapstructure:"type" yaml:"type"`
+	// LogGroup and Pattern (a CloudWatch filter pattern) apply to log_pattern rules
+	LogGroup string `mapstructure:"log_group" yaml:"log_group,omitempty"`
+	Pattern  string `mapstructure:"pattern" yaml:"pattern,omitempty"`
+	// States limits state rules to transitions into these states; empty matches any change
+	States []string `mapstructure:"states" yaml:"states,omitempty"`
+	// Resources limits state rules to these instance IDs or alarm names
+	Resources []string `mapstructure:"resources" yaml:"resources,omitempty"`
+	Webhook   string   `mapstructure:"webhook" yaml:"webhook,omitempty"`
+	// Template is a Go text/template rendering the notification text or JSON payload
+	Template string `mapstructure:"template" yaml:"template,omitempty"`
+}
+
+// globalConfig is swapped rather than overwritten when the config file is
+// reloaded, so readers on other goroutines see either the old or the new one
+var globalConfig atomic.Pointer[Config]
 
 // Load loads the configuration from file or environment variables
 func Load() (*Config, error) {
@@ -60,7 +233,7 @@ func Load() (*Config, error) {
 	viper.AutomaticEnv()
 
 	// Set default values
-	setDefaults()
+	setDefaults(viper.GetViper())
 
 	// Try to read config file
 	if err := viper.ReadInConfig(); err != nil {
@@ -75,7 +248,14 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	// Set AWS config paths if not specified
+	applyPathDefaults(&cfg)
+
+	globalConfig.Store(&cfg)
+	return &cfg, nil
+}
+
+// applyPathDefaults sets AWS config paths if not specified
+func applyPathDefaults(cfg *Config) {
 	if cfg.AWS.ConfigPath == "" {
 		homeDir, _ := os.UserHomeDir()
 		cfg.AWS.ConfigPath = filepath.Join(homeDir, ".aws", "config")
@@ -84,41 +264,106 @@ func Load() (*Config, error) {
 		homeDir, _ := os.UserHomeDir()
 		cfg.AWS.CredentialsPath = filepath.Join(homeDir, ".aws", "credentials")
 	}
-
-	globalConfig = &cfg
-	return &cfg, nil
 }
 
 // Get returns the global configuration
 func Get() *Config {
-	return globalConfig
+	return globalConfig.Load()
+}
+
+// Set replaces the global configuration, e.g. with one reloaded from disk
+func Set(cfg *Config) {
+	globalConfig.Store(cfg)
+}
+
+// Watch watches the loaded config file and calls onChange with the reloaded
+// configuration whenever the file changes on disk. Invalid files are skipped.
+func Watch(onChange func(*Config)) error {
+	if viper.ConfigFileUsed() == "" {
+		return fmt.Errorf("no config file loaded")
+	}
+
+	viper.OnConfigChange(func(event fsnotify.Event) {
+		var cfg Config
+		if err := viper.Unmarshal(&cfg); err != nil {
+			logger.Warn("Failed to reload configuration", zap.String("file", event.Name), zap.Error(err))
+			return
+		}
+		applyPathDefaults(&cfg)
+
+		if err := cfg.Validate(); err != nil {
+			logger.Warn("Ignoring invalid configuration change", zap.String("file", event.Name), zap.Error(err))
+			return
+		}
+
+		logger.Info("Configuration reloaded", zap.String("file", event.Name))
+		onChange(&cfg)
+	})
+	viper.WatchConfig()
+
+	return nil
 }
 
 // setDefaults sets default configuration values
-func setDefaults() {
+func setDefaults(v *viper.Viper) {
 	// App defaults
-	viper.SetDefault("app.name", "Swiss Army TUI")
-	viper.SetDefault("app.version", "1.0.0")
-	viper.SetDefault("app.description", "DevOps Swiss Army Knife TUI")
-	viper.SetDefault("app.debug", false)
+	v.SetDefault("app.name", "Swiss Army TUI")
+	v.SetDefault("app.version", "1.0.0")
+	v.SetDefault("app.description", "DevOps Swiss Army Knife TUI")
+	v.SetDefault("app.debug", false)
 
 	// AWS defaults
-	viper.SetDefault("aws.default_profile", "default")
-	viper.SetDefault("aws.default_region", "us-east-1")
-	viper.SetDefault("aws.profiles", map[string]string{})
+	v.SetDefault("aws.default_profile", "default")
+	v.SetDefault("aws.default_region", "us-east-1")
+	v.SetDefault("aws.profiles", map[string]string{})
+	v.SetDefault("aws.rate_limit", 20)
+	v.SetDefault("aws.retry_mode", RetryModeAdaptive)
+	v.SetDefault("aws.max_attempts", 5)
+	v.SetDefault("aws.max_backoff", 20)
+	v.SetDefault("aws.request_timeout", 0)
+	v.SetDefault("aws.operation_timeouts", map[string]int{})
+	v.SetDefault("aws.concurrency", 8)
+	v.SetDefault("aws.service_concurrency", map[string]int{})
 
 	// UI defaults
-	viper.SetDefault("ui.theme", "dark")
-	viper.SetDefault("ui.refresh_interval", 30)
-	viper.SetDefault("ui.mouse_enabled", true)
-	viper.SetDefault("ui.border_style", "rounded")
+	v.SetDefault("ui.theme", "dark")
+	v.SetDefault("ui.refresh_interval", 30)
+	v.SetDefault("ui.mouse_enabled", true)
+	v.SetDefault("ui.border_style", "rounded")
+	v.SetDefault("ui.log_buffer_size", 1000)
+	v.SetDefault("ui.cache_ttl", 60)
+	v.SetDefault("ui.prefetch_services", 3)
+	v.SetDefault("ui.preview_kb", 64)
+	v.SetDefault("ui.keybindings", map[string]string{})
+	v.SetDefault("ui.services", []string{})
+	v.SetDefault("ui.timezone", TimezoneLocal)
+	v.SetDefault("ui.timestamps", TimestampsTime)
+	v.SetDefault("ui.locale", i18n.DefaultLocale)
+	v.SetDefault("ui.accessible", false)
+
+	// Logs defaults
+	v.SetDefault("logs.level_rules", []LevelRule{})
+
+	// Alert defaults
+	v.SetDefault("alerts.enabled", false)
+	v.SetDefault("alerts.webhook_url", "")
+	v.SetDefault("alerts.poll_interval", 60)
+	v.SetDefault("alerts.rules", []WatchRule{})
+
+	// Update defaults
+	v.SetDefault("update.check", true)
+	v.SetDefault("update.repository", update.DefaultRepository)
+	v.SetDefault("update.api_url", update.DefaultAPIURL)
 
 	// Logger defaults
-	viper.SetDefault("logger.level", "info")
-	viper.SetDefault("logger.development", true)
-	viper.SetDefault("logger.encoding", "console")
+	v.SetDefault("logger.level", "info")
+	v.SetDefault("logger.development", true)
+	v.SetDefault("logger.encoding", "console")
 	// Log to a file by default so log output does not interfere with the TUI screen
-	viper.SetDefault("logger.output_paths", []string{"swiss-army-tui.log"})
+	v.SetDefault("logger.output_paths", []string{logger.DefaultPath()})
+	v.SetDefault("logger.max_size_mb", logger.DefaultMaxSizeMB)
+	v.SetDefault("logger.max_backups", logger.DefaultMaxBackups)
+	v.SetDefault("logger.compress", true)
 }
 
 // CreateDefaultConfigFile creates a default configuration file
@@ -150,19 +395,62 @@ aws:
   default_profile: "default"
   default_region: "us-east-1"
   profiles: {}
+  rate_limit: 20
+  retry_mode: "adaptive"
+  max_attempts: 5
+  max_backoff: 20
+  request_timeout: 0
+  operation_timeouts: {}
+  concurrency: 8
+  service_concurrency: {}
 
 ui:
   theme: "dark"
   refresh_interval: 30
   mouse_enabled: true
   border_style: "rounded"
+  log_buffer_size: 1000
+  cache_ttl: 60
+  prefetch_services: 3
+  preview_kb: 64
+  timezone: "local"
+  timestamps: "time"
+  locale: "en"
+  accessible: false
+  keybindings:
+    next_tab: "Tab"
+    prev_tab: "Backtab"
+    refresh: "Ctrl+R"
+    quit: "Ctrl+C, Esc"
+    help: "F1"
+    search: "Ctrl+F"
+    bookmarks: "Ctrl+B"
+    snapshot: "Ctrl+T"
+    record: "Ctrl+O"
+
+logs:
+  level_rules: []
+
+alerts:
+  enabled: false
+  webhook_url: ""
+  poll_interval: 60
+  rules: []
+
+update:
+  check: true
+  repository: "lukasVirtual/swiss-army-aws-tui"
+  api_url: "https://api.github.com"
 
 logger:
   level: "info"
   development: true
   encoding: "console"
   output_paths:
-    - "swiss-army-tui.log"
+    - "~/.swiss-army-tui/swiss-army-tui.log"
+  max_size_mb: 10
+  max_backups: 3
+  compress: true
 `
 
 	if err := os.WriteFile(configFile, []byte(defaultConfig), 0644); err != nil {
@@ -174,10 +462,20 @@ logger:
 
 // SaveConfig saves the current configuration to file
 func SaveConfig() error {
-	if globalConfig == nil {
+	cfg := globalConfig.Load()
+	if cfg == nil {
 		return fmt.Errorf("no configuration loaded")
 	}
 
+	// The settings form edits the struct directly, so push it back into viper
+	settings, err := cfg.toMap()
+	if err != nil {
+		return err
+	}
+	if err := viper.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to update config settings: %w", err)
+	}
+
 	return viper.WriteConfig()
 }
 
@@ -187,8 +485,161 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("app name cannot be empty")
 	}
 
-	if c.UI.RefreshInterval <= 0 {
-		return fmt.Errorf("refresh interval must be positive")
+	if c.UI.RefreshInterval < MinRefreshInterval || c.UI.RefreshInterval > MaxRefreshInterval {
+		return fmt.Errorf("refresh interval must be between %d and %d seconds", MinRefreshInterval, MaxRefreshInterval)
+	}
+
+	if c.AWS.DefaultRegion != "" && !IsValidRegion(c.AWS.DefaultRegion) {
+		return fmt.Errorf("unknown default region %q", c.AWS.DefaultRegion)
+	}
+
+	if c.UI.LogBufferSize <= 0 {
+		return fmt.Errorf("log buffer size must be positive")
+	}
+
+	if c.AWS.RateLimit < 0 {
+		return fmt.Errorf("rate limit cannot be negative")
+	}
+
+	if c.AWS.RetryMode != "" && c.AWS.RetryMode != RetryModeStandard && c.AWS.RetryMode != RetryModeAdaptive {
+		return fmt.Errorf("retry mode must be %q or %q", RetryModeStandard, RetryModeAdaptive)
+	}
+
+	if c.AWS.MaxAttempts < 0 {
+		return fmt.Errorf("max attempts cannot be negative")
+	}
+
+	if c.AWS.MaxBackoff < 0 {
+		return fmt.Errorf("max backoff cannot be negative")
+	}
+
+	if c.AWS.RequestTimeout < 0 {
+		return fmt.Errorf("request timeout cannot be negative")
+	}
+
+	for operation, timeout := range c.AWS.OperationTimeouts {
+		if timeout < 0 {
+			return fmt.Errorf("timeout of %s cannot be negative", operation)
+		}
+	}
+
+	if c.AWS.Concurrency < 0 {
+		return fmt.Errorf("concurrency cannot be negative")
+	}
+
+	for service, limit := range c.AWS.ServiceConcurrency {
+		if limit < 0 {
+			return fmt.Errorf("concurrency of %s cannot be negative", service)
+		}
+	}
+
+	if c.UI.CacheTTL < 0 {
+		return fmt.Errorf("cache TTL cannot be negative")
+	}
+
+	if c.UI.PrefetchServices < 0 {
+		return fmt.Errorf("prefetch services cannot be negative")
+	}
+
+	if c.UI.PreviewKB < 0 {
+		return fmt.Errorf("preview size cannot be negative")
+	}
+
+	if _, err := LoadTimezone(c.UI.Timezone); err != nil {
+		return err
+	}
+
+	switch c.UI.Timestamps {
+	case "", TimestampsTime, TimestampsDateTime, TimestampsRelative:
+	default:
+		return fmt.Errorf("timestamps must be %q, %q or %q", TimestampsTime, TimestampsDateTime, TimestampsRelative)
+	}
+
+	if c.UI.Locale != "" && !i18n.Supported(c.UI.Locale) {
+		return fmt.Errorf("unsupported locale %q (supported: %s)", c.UI.Locale, strings.Join(i18n.Locales(), ", "))
+	}
+
+	if c.Logger.MaxSizeMB < 0 {
+		return fmt.Errorf("log max size cannot be negative")
+	}
+
+	if c.Logger.MaxBackups < 0 {
+		return fmt.Errorf("log backups cannot be negative")
+	}
+
+	if err := c.Logs.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Update.Validate(); err != nil {
+		return err
+	}
+
+	return c.Alerts.Validate()
+}
+
+// Validate validates the level rules
+func (l *LogsConfig) Validate() error {
+	for i, rule := range l.LevelRules {
+		switch {
+		case (rule.Field == "") == (rule.Pattern == ""):
+			return fmt.Errorf("level rule #%d: set either field or pattern", i+1)
+		case rule.Pattern != "":
+			re, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return fmt.Errorf("level rule #%d: invalid pattern: %w", i+1, err)
+			}
+			if re.NumSubexp() == 0 && rule.Level == "" {
+				return fmt.Errorf("level rule #%d: a pattern without groups needs a level", i+1)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate validates where new versions are looked up
+func (u *UpdateConfig) Validate() error {
+	if u.Repository != "" && !repositoryPattern.MatchString(u.Repository) {
+		return fmt.Errorf("update repository must be owner/name, got %q", u.Repository)
+	}
+	if u.APIURL != "" {
+		parsed, err := url.Parse(u.APIURL)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return fmt.Errorf("update API URL must be an http(s) URL, got %q", u.APIURL)
+		}
+	}
+	return nil
+}
+
+// Validate validates the alert settings and watch rules
+func (a *AlertsConfig) Validate() error {
+	if !a.Enabled {
+		return nil
+	}
+
+	if a.PollInterval <= 0 {
+		return fmt.Errorf("alert poll interval must be positive")
+	}
+
+	for i, rule := range a.Rules {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+
+		switch rule.Type {
+		case RuleLogPattern:
+			if rule.LogGroup == "" {
+				return fmt.Errorf("watch rule %s: log_group is required", name)
+			}
+		case RuleAlarmState, RuleInstanceState:
+		default:
+			return fmt.Errorf("watch rule %s: unknown type %q", name, rule.Type)
+		}
+
+		if rule.Webhook == "" && a.WebhookURL == "" {
+			return fmt.Errorf("watch rule %s: no webhook configured", name)
+		}
 	}
 
 	return nil
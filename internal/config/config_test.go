@@ -0,0 +1,70 @@
+package config
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		return &Config{
+			App: AppConfig{Name: "Swiss Army TUI"},
+			AWS: AWSConfig{DefaultRegion: "eu-west-1"},
+			UI:  UIConfig{RefreshInterval: 30, LogBufferSize: 1000},
+		}
+	}
+	if err := valid().Validate(); err != nil {
+		t.Fatalf("Expected a valid config, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(*Config)
+	}{
+		{"refresh interval too short", func(c *Config) { c.UI.RefreshInterval = 0 }},
+		{"refresh interval too long", func(c *Config) { c.UI.RefreshInterval = MaxRefreshInterval + 1 }},
+		{"unknown region", func(c *Config) { c.AWS.DefaultRegion = "europe" }},
+		{"empty log buffer", func(c *Config) { c.UI.LogBufferSize = 0 }},
+		{"unknown retry mode", func(c *Config) { c.AWS.RetryMode = "legacy" }},
+		{"negative operation timeout", func(c *Config) { c.AWS.OperationTimeouts = map[string]int{"GetObject": -1} }},
+		{"negative concurrency", func(c *Config) { c.AWS.Concurrency = -1 }},
+		{"negative service concurrency", func(c *Config) { c.AWS.ServiceConcurrency = map[string]int{"lambda": -1} }},
+		{"unknown timezone", func(c *Config) { c.UI.Timezone = "Mars/Olympus" }},
+		{"unknown timestamp format", func(c *Config) { c.UI.Timestamps = "epoch" }},
+		{"unsupported locale", func(c *Config) { c.UI.Locale = "tlh" }},
+		{"level rule without field or pattern", func(c *Config) { c.Logs.LevelRules = []LevelRule{{LogGroup: "/ecs/"}} }},
+		{"invalid level pattern", func(c *Config) { c.Logs.LevelRules = []LevelRule{{Pattern: `(\[`}} }},
+		{"level pattern without level", func(c *Config) { c.Logs.LevelRules = []LevelRule{{Pattern: `Task timed out`}} }},
+		{"update repository without owner", func(c *Config) { c.Update.Repository = "swiss-army-tui" }},
+		{"update API URL without scheme", func(c *Config) { c.Update.APIURL = "github.example.com/api/v3" }},
+	}
+	for _, tt := range tests {
+		cfg := valid()
+		tt.change(cfg)
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: expected a validation error", tt.name)
+		}
+	}
+}
+
+func TestIsValidRegion(t *testing.T) {
+	for _, region := range []string{"us-east-1", "ap-southeast-3", "us-gov-west-1", "il-central-1"} {
+		if !IsValidRegion(region) {
+			t.Errorf("Expected %s to be valid", region)
+		}
+	}
+	for _, region := range []string{"", "us-east", "US-EAST-1", "us-east-1a"} {
+		if IsValidRegion(region) {
+			t.Errorf("Expected %q to be invalid", region)
+		}
+	}
+}
+
+func TestLoadTimezone(t *testing.T) {
+	for name, want := range map[string]string{"": "Local", "local": "Local", "UTC": "UTC", "Europe/Berlin": "Europe/Berlin"} {
+		loc, err := LoadTimezone(name)
+		if err != nil || loc.String() != want {
+			t.Errorf("LoadTimezone(%q) = %v, %v, expected %s", name, loc, err, want)
+		}
+	}
+	if _, err := LoadTimezone("Mars/Olympus"); err == nil {
+		t.Error("Expected an unknown timezone to fail")
+	}
+}
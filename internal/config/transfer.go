@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Change describes a single setting that differs between two configurations
+type Change struct {
+	Key string
+	Old interface{}
+	New interface{}
+}
+
+// ExportToFile writes the configuration to path. The format (YAML or JSON) is
+// derived from the file extension.
+func ExportToFile(cfg *Config, path string) error {
+	if err := checkTransferFormat(path); err != nil {
+		return err
+	}
+
+	v, err := cfg.toViper()
+	if err != nil {
+		return err
+	}
+
+	if err := v.WriteConfigAs(path); err != nil {
+		return fmt.Errorf("failed to export config to %s: %w", path, err)
+	}
+	return nil
+}
+
+// ImportFromFile reads and validates a configuration file without applying it
+func ImportFromFile(path string) (*Config, error) {
+	if err := checkTransferFormat(path); err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	setDefaults(v)
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	applyPathDefaults(&cfg)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("imported config is invalid: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// Diff returns the settings whose values differ between current and other,
+// sorted by key
+func Diff(current, other *Config) ([]Change, error) {
+	cv, err := current.toViper()
+	if err != nil {
+		return nil, err
+	}
+	ov, err := other.toViper()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]struct{})
+	for _, k := range cv.AllKeys() {
+		keys[k] = struct{}{}
+	}
+	for _, k := range ov.AllKeys() {
+		keys[k] = struct{}{}
+	}
+
+	var changes []Change
+	for k := range keys {
+		oldVal, newVal := cv.Get(k), ov.Get(k)
+		if !reflect.DeepEqual(oldVal, newVal) {
+			changes = append(changes, Change{Key: k, Old: oldVal, New: newVal})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes, nil
+}
+
+// toViper loads the configuration into a standalone viper instance so that it
+// can be written or compared key by key
+func (c *Config) toViper() (*viper.Viper, error) {
+	settings, err := c.toMap()
+	if err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	if err := v.MergeConfigMap(settings); err != nil {
+		return nil, fmt.Errorf("failed to load config settings: %w", err)
+	}
+	return v, nil
+}
+
+// toMap converts the configuration into a nested map keyed like the config file
+func (c *Config) toMap() (map[string]interface{}, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var settings map[string]interface{}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	return settings, nil
+}
+
+func checkTransferFormat(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("config file path cannot be empty")
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return nil
+	default:
+		return fmt.Errorf("unsupported config format %q (use .yaml, .yml or .json)", filepath.Ext(path))
+	}
+}
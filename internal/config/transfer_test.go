@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"swiss-army-tui/pkg/logger"
+)
+
+func testConfig() *Config {
+	return &Config{
+		App: AppConfig{Name: "Swiss Army TUI", Version: "1.0.0"},
+		AWS: AWSConfig{
+			DefaultProfile:  "default",
+			DefaultRegion:   "eu-central-1",
+			ConfigPath:      "/tmp/aws/config",
+			CredentialsPath: "/tmp/aws/credentials",
+		},
+		UI:     UIConfig{Theme: "dark", RefreshInterval: 30, MouseEnabled: true, BorderStyle: "rounded", LogBufferSize: 1000},
+		Logger: logger.Config{Level: "info", Encoding: "console", OutputPaths: []string{"swiss-army-tui.log"}},
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-transfer")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	for _, name := range []string{"export.yaml", "export.json"} {
+		path := filepath.Join(tempDir, name)
+		cfg := testConfig()
+
+		if err := ExportToFile(cfg, path); err != nil {
+			t.Fatalf("Failed to export %s: %v", name, err)
+		}
+
+		imported, err := ImportFromFile(path)
+		if err != nil {
+			t.Fatalf("Failed to import %s: %v", name, err)
+		}
+
+		changes, err := Diff(cfg, imported)
+		if err != nil {
+			t.Fatalf("Failed to diff %s: %v", name, err)
+		}
+		if len(changes) != 0 {
+			t.Errorf("Expected no changes after round trip of %s, got %v", name, changes)
+		}
+	}
+}
+
+func TestImportRejectsInvalidConfig(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-transfer")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, "invalid.yaml")
+	if err := os.WriteFile(path, []byte("app:\n  name: \"\"\nui:\n  refresh_interval: 0\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	if _, err := ImportFromFile(path); err == nil {
+		t.Error("Expected validation error for invalid config")
+	}
+
+	if _, err := ImportFromFile(filepath.Join(tempDir, "config.toml")); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	current := testConfig()
+	other := testConfig()
+	other.UI.Theme = "light"
+	other.UI.RefreshInterval = 60
+
+	changes, err := Diff(current, other)
+	if err != nil {
+		t.Fatalf("Failed to diff configs: %v", err)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d: %v", len(changes), changes)
+	}
+	if changes[0].Key != "ui.refresh_interval" || changes[1].Key != "ui.theme" {
+		t.Errorf("Unexpected change keys: %v", changes)
+	}
+	if changes[1].Old != "dark" || changes[1].New != "light" {
+		t.Errorf("Unexpected theme change: %v", changes[1])
+	}
+}
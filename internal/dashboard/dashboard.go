@@ -0,0 +1,298 @@
+// Package dashboard reads CloudWatch dashboard definitions and renders a text
+// approximation of their widgets: metrics as sparklines or single values,
+// alarm states and text. Widgets without a text form are named with a note.
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// Widget types
+const (
+	TypeMetric = "metric"
+	TypeAlarm  = "alarm"
+	TypeText   = "text"
+)
+
+// Metric widget views
+const (
+	ViewTimeSeries  = "timeSeries"
+	ViewSingleValue = "singleValue"
+)
+
+// Dashboard is a parsed dashboard definition
+type Dashboard struct {
+	Name    string
+	Widgets []Widget
+}
+
+// Widget is one widget of a dashboard. Only the properties needed to render
+// it as text are kept.
+type Widget struct {
+	Type   string
+	X, Y   int
+	Width  int
+	Height int
+
+	Title  string
+	View   string
+	Region string
+
+	// Metric widgets
+	Metrics []Metric
+	// Alarm widgets, as alarm ARNs
+	Alarms []string
+	// Text widgets
+	Markdown string
+}
+
+// Metric is a metric of a metric widget. Metrics defined by an expression
+// have no Query.
+type Metric struct {
+	Label      string
+	Expression string
+	Query      clients.MetricQuery
+}
+
+// rawDashboard is the JSON shape of a dashboard body
+type rawDashboard struct {
+	Widgets []struct {
+		Type       string `json:"type"`
+		X          int    `json:"x"`
+		Y          int    `json:"y"`
+		Width      int    `json:"width"`
+		Height     int    `json:"height"`
+		Properties struct {
+			Title    string            `json:"title"`
+			View     string            `json:"view"`
+			Region   string            `json:"region"`
+			Stat     string            `json:"stat"`
+			Period   int32             `json:"period"`
+			Metrics  []json.RawMessage `json:"metrics"`
+			Alarms   []string          `json:"alarms"`
+			Markdown string            `json:"markdown"`
+		} `json:"properties"`
+	} `json:"widgets"`
+}
+
+// metricOptions is the trailing object of a metric array
+type metricOptions struct {
+	Label      string `json:"label"`
+	Stat       string `json:"stat"`
+	Period     int32  `json:"period"`
+	Expression string `json:"expression"`
+	Visible    *bool  `json:"visible"`
+}
+
+// Parse reads the JSON body of the dashboard name. Widgets are ordered top to
+// bottom, left to right.
+func Parse(name, body string) (*Dashboard, error) {
+	var raw rawDashboard
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse dashboard %s: %w", name, err)
+	}
+
+	dashboard := &Dashboard{Name: name}
+	for i, w := range raw.Widgets {
+		widget := Widget{
+			Type:     w.Type,
+			X:        w.X,
+			Y:        w.Y,
+			Width:    w.Width,
+			Height:   w.Height,
+			Title:    w.Properties.Title,
+			View:     w.Properties.View,
+			Region:   w.Properties.Region,
+			Alarms:   w.Properties.Alarms,
+			Markdown: w.Properties.Markdown,
+		}
+		if widget.Type == TypeMetric && widget.View == "" {
+			widget.View = ViewTimeSeries
+		}
+
+		stat := w.Properties.Stat
+		if stat == "" {
+			stat = "Average"
+		}
+		period := w.Properties.Period
+		if period == 0 {
+			period = 300
+		}
+
+		var previous []string
+		for j, entry := range w.Properties.Metrics {
+			metric, fields, err := parseMetric(entry, previous, stat, period)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse metric %d of widget %d: %w", j+1, i+1, err)
+			}
+			if fields != nil {
+				previous = fields
+			}
+			if metric != nil {
+				widget.Metrics = append(widget.Metrics, *metric)
+			}
+		}
+		dashboard.Widgets = append(dashboard.Widgets, widget)
+	}
+
+	sort.SliceStable(dashboard.Widgets, func(i, j int) bool {
+		a, b := dashboard.Widgets[i], dashboard.Widgets[j]
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.X < b.X
+	})
+	return dashboard, nil
+}
+
+// parseMetric reads a metric array such as
+// ["AWS/EC2", "CPUUtilization", "InstanceId", "i-1", {"stat": "Maximum"}].
+// It expands the shorthands of the console against the fields of the
+// previous metric: "..." repeats its leading fields and "." the field at the
+// same position. It returns the expanded fields, and no metric for hidden
+// ones.
+func parseMetric(entry json.RawMessage, previous []string, stat string, period int32) (*Metric, []string, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(entry, &items); err != nil {
+		return nil, nil, err
+	}
+
+	var fields []string
+	var options metricOptions
+	for _, item := range items {
+		var field string
+		if err := json.Unmarshal(item, &field); err == nil {
+			fields = append(fields, field)
+			continue
+		}
+		if err := json.Unmarshal(item, &options); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if options.Expression != "" {
+		return &Metric{Label: options.Label, Expression: options.Expression}, nil, nil
+	}
+
+	if len(fields) > 0 && fields[0] == "..." {
+		rest := fields[1:]
+		if len(previous) < len(rest) {
+			return nil, nil, fmt.Errorf("\"...\" without a previous metric")
+		}
+		fields = append(append([]string(nil), previous[:len(previous)-len(rest)]...), rest...)
+	}
+	for i, field := range fields {
+		if field != "." {
+			continue
+		}
+		if i >= len(previous) {
+			return nil, nil, fmt.Errorf("\".\" without a previous metric")
+		}
+		fields[i] = previous[i]
+	}
+
+	if len(fields) < 2 || len(fields)%2 != 0 {
+		return nil, nil, fmt.Errorf("expected namespace, metric name and dimension pairs, got %v", fields)
+	}
+	if options.Visible != nil && !*options.Visible {
+		return nil, fields, nil
+	}
+
+	query := clients.MetricQuery{
+		Namespace: fields[0],
+		Metric:    fields[1],
+		Stat:      stat,
+		Period:    period,
+	}
+	if options.Stat != "" {
+		query.Stat = options.Stat
+	}
+	if options.Period != 0 {
+		query.Period = options.Period
+	}
+	for i := 2; i < len(fields); i += 2 {
+		query.Dimensions = append(query.Dimensions, clients.MetricDimension{Name: fields[i], Value: fields[i+1]})
+	}
+
+	label := options.Label
+	if label == "" {
+		var values []string
+		for _, dimension := range query.Dimensions {
+			values = append(values, dimension.Value)
+		}
+		label = strings.TrimSpace(strings.Join(values, " ") + " " + query.Metric)
+	}
+	return &Metric{Label: label, Query: query}, fields, nil
+}
+
+// Data is what the widgets of a dashboard show
+type Data struct {
+	Start, End time.Time
+	// Series of the metrics of each widget, by widget index
+	Series map[int][]clients.MetricSeries
+	// Alarms by name
+	Alarms map[string]clients.AlarmDetail
+	// Errors of widgets that could not be loaded, by widget index
+	Errors map[int]error
+}
+
+// Fetch loads the data of the widgets of dashboard between start and end.
+// Widgets of another region than region are skipped and so are expressions;
+// widgets that fail are recorded in Data.Errors.
+func Fetch(ctx context.Context, cw aws.CloudWatchService, dashboard *Dashboard, region string, start, end time.Time) *Data {
+	data := &Data{
+		Start:  start,
+		End:    end,
+		Series: make(map[int][]clients.MetricSeries),
+		Alarms: make(map[string]clients.AlarmDetail),
+		Errors: make(map[int]error),
+	}
+
+	needAlarms := false
+	for i, widget := range dashboard.Widgets {
+		if widget.Region != "" && widget.Region != region {
+			continue
+		}
+		switch widget.Type {
+		case TypeMetric:
+			var queries []clients.MetricQuery
+			for _, metric := range widget.Metrics {
+				if metric.Expression == "" {
+					queries = append(queries, metric.Query)
+				}
+			}
+			if len(queries) == 0 {
+				continue
+			}
+			series, err := cw.GetMetricSeries(ctx, queries, start, end)
+			if err != nil {
+				data.Errors[i] = err
+				continue
+			}
+			data.Series[i] = series
+		case TypeAlarm:
+			needAlarms = true
+		}
+	}
+
+	if needAlarms {
+		alarms, err := cw.DescribeAlarms(ctx)
+		for i, widget := range dashboard.Widgets {
+			if widget.Type == TypeAlarm && err != nil {
+				data.Errors[i] = err
+			}
+		}
+		for _, alarm := range alarms {
+			data.Alarms[alarm.Name] = alarm
+		}
+	}
+	return data
+}
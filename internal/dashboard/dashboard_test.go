@@ -0,0 +1,103 @@
+package dashboard
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"swiss-army-tui/internal/aws/fake"
+)
+
+func TestParseShorthands(t *testing.T) {
+	body := `{"widgets": [
+	  {"type": "alarm", "x": 12, "y": 0, "properties": {"alarms": ["arn:aws:cloudwatch:us-east-1:1:alarm:a"]}},
+	  {"type": "metric", "x": 0, "y": 0, "properties": {"stat": "Sum", "metrics": [
+	    ["AWS/Lambda", "Invocations", "FunctionName", "a"],
+	    ["...", "b", {"stat": "Maximum", "label": "B"}],
+	    [".", "Errors", ".", "c"],
+	    ["...", "d", {"visible": false}],
+	    [{"expression": "m1 + m2", "label": "total"}]
+	  ]}}
+	]}`
+
+	dashboard, err := Parse("test", body)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(dashboard.Widgets) != 2 || dashboard.Widgets[0].Type != TypeMetric {
+		t.Fatalf("Expected the metric widget first, got %+v", dashboard.Widgets)
+	}
+
+	metrics := dashboard.Widgets[0].Metrics
+	if len(metrics) != 4 {
+		t.Fatalf("Expected 4 visible metrics, got %d: %+v", len(metrics), metrics)
+	}
+	want := []struct{ metric, value, stat, label string }{
+		{"Invocations", "a", "Sum", "a Invocations"},
+		{"Invocations", "b", "Maximum", "B"},
+		{"Errors", "c", "Sum", "c Errors"},
+	}
+	for i, w := range want {
+		query := metrics[i].Query
+		if query.Namespace != "AWS/Lambda" || query.Metric != w.metric || len(query.Dimensions) != 1 ||
+			query.Dimensions[0].Value != w.value || query.Stat != w.stat || metrics[i].Label != w.label {
+			t.Errorf("Metric %d: expected %+v, got %+v (%s)", i, w, query, metrics[i].Label)
+		}
+	}
+	if metrics[3].Expression != "m1 + m2" {
+		t.Errorf("Expected the expression last, got %+v", metrics[3])
+	}
+
+	if _, err := Parse("broken", `{"widgets": [{"type": "metric", "properties": {"metrics": [["...", "x"]]}}]}`); err == nil {
+		t.Error("Expected an error for \"...\" without a previous metric")
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := Sparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}, 8); got != "▁▂▃▄▅▆▇█" {
+		t.Errorf("Expected a rising line, got %q", got)
+	}
+	if got := Sparkline([]float64{0, 0, 10, 10}, 2); got != "▁█" {
+		t.Errorf("Expected values averaged into 2 bars, got %q", got)
+	}
+	if got := Sparkline([]float64{3, 3}, 10); got != "▅▅" {
+		t.Errorf("Expected a flat line, got %q", got)
+	}
+}
+
+func TestRenderDemoDashboard(t *testing.T) {
+	cw := fake.NewCloudWatchService()
+	body, err := cw.GetDashboardBody(context.Background(), "orders-service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dashboard, err := Parse("orders-service", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	end := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	data := Fetch(context.Background(), cw, dashboard, fake.Region, end.Add(-3*time.Hour), end)
+	text := Render(dashboard, data, fake.Region, 30)
+
+	for _, want := range []string{
+		"Orders service",
+		"Instance CPU",
+		"web-2",
+		"worker-1",
+		"DB connections",
+		"[red]● orders-api-5xx  ALARM",
+		"[green]● orders-prod-cpu-high  OK",
+		"log widgets cannot be shown as text",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in the rendered dashboard:\n%s", want, text)
+		}
+	}
+
+	other := Render(dashboard, Fetch(context.Background(), cw, dashboard, "eu-west-1", end.Add(-time.Hour), end), "eu-west-1", 30)
+	if !strings.Contains(other, "Shows us-east-1, switch to that region to see it") {
+		t.Errorf("Expected widgets of another region to be skipped:\n%s", other)
+	}
+}
@@ -0,0 +1,203 @@
+package dashboard
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"swiss-army-tui/internal/aws/clients"
+
+	"github.com/rivo/tview"
+)
+
+// sparkBlocks are the bar heights of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a line of width bars. Values are averaged into
+// width buckets; NaN values are left out.
+func Sparkline(values []float64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+
+	buckets := make([]float64, 0, width)
+	if len(values) <= width {
+		buckets = append(buckets, values...)
+	} else {
+		for i := 0; i < width; i++ {
+			from, to := i*len(values)/width, (i+1)*len(values)/width
+			buckets = append(buckets, mean(values[from:to]))
+		}
+	}
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range buckets {
+		if !math.IsNaN(v) {
+			low, high = math.Min(low, v), math.Max(high, v)
+		}
+	}
+
+	var line strings.Builder
+	for _, v := range buckets {
+		switch {
+		case math.IsNaN(v):
+			line.WriteRune(' ')
+		case high == low:
+			line.WriteRune(sparkBlocks[len(sparkBlocks)/2])
+		default:
+			index := int((v - low) / (high - low) * float64(len(sparkBlocks)-1))
+			line.WriteRune(sparkBlocks[index])
+		}
+	}
+	return line.String()
+}
+
+// mean averages values, ignoring NaN
+func mean(values []float64) float64 {
+	var sum float64
+	var n int
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			sum += v
+			n++
+		}
+	}
+	if n == 0 {
+		return math.NaN()
+	}
+	return sum / float64(n)
+}
+
+// alarmColors colors alarm states
+var alarmColors = map[string]string{
+	"OK":                "green",
+	"ALARM":             "red",
+	"INSUFFICIENT_DATA": "gray",
+}
+
+// Render draws the widgets of dashboard with data as tview-colored text, one
+// widget below the other. Sparklines are width bars wide.
+func Render(dashboard *Dashboard, data *Data, region string, width int) string {
+	var text strings.Builder
+	for i, widget := range dashboard.Widgets {
+		if i > 0 {
+			text.WriteString("\n")
+		}
+
+		title := widget.Title
+		if title == "" {
+			title = "Untitled " + widget.Type + " widget"
+		}
+		if widget.Type != TypeText {
+			text.WriteString(fmt.Sprintf("[yellow::b]%s[-::-]\n", tview.Escape(title)))
+		}
+
+		if err, ok := data.Errors[i]; ok {
+			text.WriteString(fmt.Sprintf("  [red]%s: %s[-]\n", clients.ErrorReason(err), tview.Escape(err.Error())))
+			continue
+		}
+		if widget.Region != "" && widget.Region != region && widget.Type != TypeText {
+			text.WriteString(fmt.Sprintf("  [gray]Shows %s, switch to that region to see it[-]\n", widget.Region))
+			continue
+		}
+
+		switch widget.Type {
+		case TypeMetric:
+			renderMetrics(&text, widget, data.Series[i], width)
+		case TypeAlarm:
+			renderAlarms(&text, widget, data.Alarms)
+		case TypeText:
+			renderMarkdown(&text, widget.Markdown)
+		default:
+			text.WriteString(fmt.Sprintf("  [gray]%s widgets cannot be shown as text[-]\n", widget.Type))
+		}
+	}
+	return text.String()
+}
+
+// renderMetrics writes a line per metric: a sparkline with the latest and
+// highest value, or only the latest value for single value widgets
+func renderMetrics(text *strings.Builder, widget Widget, series []clients.MetricSeries, width int) {
+	labelWidth := 0
+	for _, metric := range widget.Metrics {
+		labelWidth = max(labelWidth, len(metric.Label))
+	}
+
+	next := 0
+	for _, metric := range widget.Metrics {
+		label := tview.Escape(metric.Label)
+		if metric.Expression != "" {
+			text.WriteString(fmt.Sprintf("  %-*s  [gray]expression %s not evaluated[-]\n", labelWidth, label, tview.Escape(metric.Expression)))
+			continue
+		}
+
+		var values []float64
+		if next < len(series) {
+			values = series[next].Values
+		}
+		next++
+
+		if len(values) == 0 {
+			text.WriteString(fmt.Sprintf("  %-*s  [gray]no data[-]\n", labelWidth, label))
+			continue
+		}
+
+		latest := values[len(values)-1]
+		if widget.View == ViewSingleValue {
+			text.WriteString(fmt.Sprintf("  %-*s  [::b]%s[::-]\n", labelWidth, label, formatValue(latest)))
+			continue
+		}
+
+		highest := math.Inf(-1)
+		for _, v := range values {
+			highest = math.Max(highest, v)
+		}
+		text.WriteString(fmt.Sprintf("  %-*s  [aqua]%s[-]  last %s  max %s\n",
+			labelWidth, label, Sparkline(values, width), formatValue(latest), formatValue(highest)))
+	}
+}
+
+// renderAlarms writes the state of each alarm of the widget
+func renderAlarms(text *strings.Builder, widget Widget, alarms map[string]clients.AlarmDetail) {
+	for _, arn := range widget.Alarms {
+		name := arn[strings.LastIndex(arn, ":")+1:]
+		alarm, ok := alarms[name]
+		if !ok {
+			text.WriteString(fmt.Sprintf("  [gray]● %s  unknown[-]\n", name))
+			continue
+		}
+		color := alarmColors[alarm.State]
+		if color == "" {
+			color = "white"
+		}
+		text.WriteString(fmt.Sprintf("  [%s]● %s  %s[-]\n", color, name, alarm.State))
+	}
+}
+
+// renderMarkdown writes markdown as plain text: headings in bold, other lines
+// as they are
+func renderMarkdown(text *strings.Builder, markdown string) {
+	for _, line := range strings.Split(markdown, "\n") {
+		if heading := strings.TrimLeft(line, "#"); heading != line {
+			text.WriteString(fmt.Sprintf("[::b]%s[::-]\n", tview.Escape(strings.TrimSpace(heading))))
+			continue
+		}
+		text.WriteString(tview.Escape(line) + "\n")
+	}
+}
+
+// formatValue shortens large values, e.g. 12345 to 12.3k
+func formatValue(v float64) string {
+	switch abs := math.Abs(v); {
+	case abs >= 1e9:
+		return fmt.Sprintf("%.1fG", v/1e9)
+	case abs >= 1e6:
+		return fmt.Sprintf("%.1fM", v/1e6)
+	case abs >= 1e4:
+		return fmt.Sprintf("%.1fk", v/1e3)
+	case abs == math.Trunc(abs):
+		return fmt.Sprintf("%.0f", v)
+	default:
+		return fmt.Sprintf("%.2f", v)
+	}
+}
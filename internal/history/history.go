@@ -0,0 +1,118 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultLimit is how many entries are kept of each kind
+const DefaultLimit = 100
+
+// Store keeps the most recent inputs of each kind, such as the filters typed
+// in a tab, in a JSON file so they can be recalled in later sessions
+type Store struct {
+	path    string
+	limit   int
+	mu      sync.Mutex
+	entries map[string][]string
+}
+
+// New creates a store keeping up to limit entries of each kind in path
+func New(path string, limit int) *Store {
+	return &Store{path: path, limit: limit, entries: make(map[string][]string)}
+}
+
+// DefaultPath returns ~/.swiss-army-tui/history.json
+func DefaultPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "history.json"
+	}
+	return filepath.Join(homeDir, ".swiss-army-tui", "history.json")
+}
+
+// Load reads the entries saved by earlier sessions. A missing file yields
+// no entries.
+func (s *Store) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+
+	entries := make(map[string][]string)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("failed to decode history: %w", err)
+	}
+	for kind, list := range entries {
+		if len(list) > s.limit {
+			entries[kind] = list[len(list)-s.limit:]
+		}
+	}
+	s.entries = entries
+	return nil
+}
+
+// Entries returns the entries of kind, the oldest first
+func (s *Store) Entries(kind string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.entries[kind]...)
+}
+
+// Add records text as the newest entry of kind and saves the store. Empty
+// text is ignored and an entry typed again moves to the end.
+func (s *Store) Add(kind, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := s.entries[kind]
+	for i, entry := range list {
+		if entry == text {
+			list = append(list[:i:i], list[i+1:]...)
+			break
+		}
+	}
+	list = append(list, text)
+	if len(list) > s.limit {
+		list = list[len(list)-s.limit:]
+	}
+	s.entries[kind] = list
+
+	return s.save()
+}
+
+// save writes all entries to the file, replacing it at once so a crash
+// cannot leave half of it. The caller must hold s.mu.
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAddAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "history.json")
+	store := New(path, 3)
+
+	if err := store.Load(); err != nil {
+		t.Fatalf("Expected no error for missing file, got %v", err)
+	}
+	if entries := store.Entries("logs"); len(entries) != 0 {
+		t.Fatalf("Expected no entries, got %v", entries)
+	}
+
+	for _, text := range []string{"error", "  ", "timeout", "state:running", "error", "warn"} {
+		if err := store.Add("logs", text); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if err := store.Add("resources", "web"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Blank input is skipped, repeats move to the end and the oldest entries go
+	want := []string{"state:running", "error", "warn"}
+	if got := store.Entries("logs"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// A new session sees the same entries
+	reloaded := New(path, 3)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := reloaded.Entries("logs"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v after reload, got %v", want, got)
+	}
+	if got := reloaded.Entries("resources"); !reflect.DeepEqual(got, []string{"web"}) {
+		t.Errorf("Expected the resources history after reload, got %v", got)
+	}
+
+	// A smaller limit keeps the newest entries
+	smaller := New(path, 1)
+	if err := smaller.Load(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := smaller.Entries("logs"); !reflect.DeepEqual(got, []string{"warn"}) {
+		t.Errorf("Expected only the newest entry, got %v", got)
+	}
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := New(path, DefaultLimit).Load(); err == nil {
+		t.Error("Expected an error for an invalid file")
+	}
+}
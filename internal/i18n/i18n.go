@@ -0,0 +1,122 @@
+// Package i18n holds the message catalogs of the user-facing strings of the
+// TUI, one JSON file per locale in locales/, and the locale they are shown
+// in. A translation is a new locales/<locale>.json with the keys of en.json;
+// keys it lacks show in English.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale of the catalog every other falls back to
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var files embed.FS
+
+// catalogs are the messages of every locale by key
+var catalogs = mustLoad()
+
+var (
+	mu      sync.RWMutex
+	current = DefaultLocale
+)
+
+// mustLoad reads the catalogs embedded
+func mustLoad() map[string]map[string]string {
+	entries, err := files.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	loaded := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := files.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("invalid message catalog %s: %v", entry.Name(), err))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+	return loaded
+}
+
+// Locales returns the locales there are catalogs of, DefaultLocale first
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		if locale != DefaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+	return append([]string{DefaultLocale}, locales...)
+}
+
+// Supported reports whether there is a catalog of locale
+func Supported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// Name returns the name of the language of locale in that language
+func Name(locale string) string {
+	if name, ok := catalogs[locale]["language"]; ok {
+		return name
+	}
+	return locale
+}
+
+// SetLocale shows the messages in locale; empty is DefaultLocale
+func SetLocale(locale string) error {
+	if locale == "" {
+		locale = DefaultLocale
+	}
+	if !Supported(locale) {
+		return fmt.Errorf("unsupported locale %q (supported: %s)", locale, strings.Join(Locales(), ", "))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	current = locale
+	return nil
+}
+
+// Locale returns the locale messages are shown in
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T returns the message of key in the current locale, formatted with args
+// like fmt.Sprintf if any are given. Messages missing from the locale are
+// taken from DefaultLocale, and unknown keys are returned as they are.
+func T(key string, args ...interface{}) string {
+	message, ok := catalogs[Locale()][key]
+	if !ok {
+		if message, ok = catalogs[DefaultLocale][key]; !ok {
+			message = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// N returns the message of key counting n in the current locale: key.one if
+// n is 1, key.other otherwise, formatted with n
+func N(key string, n int) string {
+	if n == 1 {
+		return T(key+".one", n)
+	}
+	return T(key+".other", n)
+}
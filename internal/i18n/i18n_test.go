@@ -0,0 +1,93 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+)
+
+// verbs matches the fmt verbs of a message
+var verbs = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalogsComplete(t *testing.T) {
+	if got := Locales(); len(got) < 2 || got[0] != DefaultLocale {
+		t.Fatalf("Expected English first and at least one translation, got %v", got)
+	}
+
+	for _, locale := range Locales()[1:] {
+		for key, message := range catalogs[DefaultLocale] {
+			translated, ok := catalogs[locale][key]
+			if !ok {
+				t.Errorf("%s: missing %q", locale, key)
+				continue
+			}
+			// Translations take the same arguments in the same order
+			want, got := verbs.FindAllString(message, -1), verbs.FindAllString(translated, -1)
+			if len(want) != len(got) {
+				t.Errorf("%s: %q has verbs %v, expected %v", locale, key, got, want)
+				continue
+			}
+			for i := range want {
+				if want[i] != got[i] {
+					t.Errorf("%s: %q has verbs %v, expected %v", locale, key, got, want)
+					break
+				}
+			}
+		}
+		for key := range catalogs[locale] {
+			if _, ok := catalogs[DefaultLocale][key]; !ok {
+				t.Errorf("%s: %q is not an English message", locale, key)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	t.Cleanup(func() { SetLocale(DefaultLocale) })
+
+	if got := T("resources.count", 5); got != "Resources (5)" {
+		t.Errorf("Expected English, got %q", got)
+	}
+	if err := SetLocale("de"); err != nil {
+		t.Fatal(err)
+	}
+	if got := T("resources.count", 5); got != "Ressourcen (5)" {
+		t.Errorf("Expected German, got %q", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("Expected the key of an unknown message, got %q", got)
+	}
+
+	if err := SetLocale("tlh"); err == nil {
+		t.Error("Expected an error for a locale without a catalog")
+	}
+	if Locale() != "de" {
+		t.Errorf("Expected an unsupported locale to be ignored, got %s", Locale())
+	}
+	if err := SetLocale(""); err != nil || Locale() != DefaultLocale {
+		t.Errorf("Expected empty to select English, got %s (%v)", Locale(), err)
+	}
+
+	names := []string{Name("en"), Name("de")}
+	sort.Strings(names)
+	if names[0] != "Deutsch" || names[1] != "English" {
+		t.Errorf("Unexpected language names %v", names)
+	}
+}
+
+func TestN(t *testing.T) {
+	t.Cleanup(func() { SetLocale(DefaultLocale) })
+
+	if got := N("count.day", 1); got != "1 day" {
+		t.Errorf("Expected the singular, got %q", got)
+	}
+	if got := N("count.day", 3); got != "3 days" {
+		t.Errorf("Expected the plural, got %q", got)
+	}
+	if err := SetLocale("de"); err != nil {
+		t.Fatal(err)
+	}
+	if got := N("count.day", 0); got != "0 Tage" {
+		t.Errorf("Expected the German plural, got %q", got)
+	}
+}
@@ -0,0 +1,305 @@
+// Package insights flags resources that likely waste money: stopped instances
+// that still pay for storage, unattached volumes and Elastic IPs, Lambda
+// functions nobody invokes and load balancers without targets. Every finding
+// comes with an estimate of what removing the resource would save.
+package insights
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/pricing"
+	"swiss-army-tui/internal/workpool"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// IdleLambdaWindow is how long a function must go without invocations to be
+// reported. Functions deployed within the window are not judged yet.
+const IdleLambdaWindow = 90 * 24 * time.Hour
+
+// Kinds of findings
+const (
+	KindStoppedInstance   = "Stopped instance"
+	KindUnattachedVolume  = "Unattached volume"
+	KindUnattachedEIP     = "Unattached Elastic IP"
+	KindIdleFunction      = "Idle Lambda function"
+	KindEmptyLoadBalancer = "Empty load balancer"
+)
+
+// Finding is a resource that is likely not needed
+type Finding struct {
+	Kind       string
+	ResourceID string
+	Name       string
+	Region     string
+	// Reason explains the finding, e.g. "not invoked in 90 days"
+	Reason string
+	// MonthlySavings is the estimated cost in USD per month of keeping the
+	// resource, 0 if unknown or free
+	MonthlySavings float64
+}
+
+// check is one kind of scan; it returns its findings or fails as a whole
+type check struct {
+	name string
+	run  func(ctx context.Context, svc *aws.ServiceClients, region string, now time.Time) ([]Finding, error)
+}
+
+var checks = []check{
+	{name: "EBS volumes", run: checkVolumes},
+	{name: "Elastic IPs", run: checkAddresses},
+	{name: "Lambda functions", run: checkFunctions},
+	{name: "load balancers", run: checkLoadBalancers},
+}
+
+// Scan runs all checks against the region of client concurrently, in the
+// "insights" slots of the worker pool, and returns the findings ordered by
+// savings. Checks that fail are reported in a clients.PartialError next to
+// the findings of the others.
+func Scan(ctx context.Context, client *aws.Client, now time.Time) ([]Finding, error) {
+	svc := client.GetClients()
+	if svc == nil {
+		return nil, fmt.Errorf("AWS services not initialized")
+	}
+	region := client.GetRegion()
+
+	var (
+		mu       sync.Mutex
+		findings = []Finding{}
+		failures []clients.ItemError
+	)
+	err := workpool.Each(ctx, "insights", len(checks), func(i int) {
+		c := checks[i]
+		found, err := c.run(ctx, svc, region, now)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failures = append(failures, clients.ItemError{Item: c.name, Region: region, Err: err})
+			return
+		}
+		findings = append(findings, found...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(failures) == len(checks) {
+		return nil, fmt.Errorf("all checks failed: %w", failures[0].Err)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].MonthlySavings != findings[j].MonthlySavings {
+			return findings[i].MonthlySavings > findings[j].MonthlySavings
+		}
+		return findings[i].ResourceID < findings[j].ResourceID
+	})
+
+	if len(failures) > 0 {
+		sort.Slice(failures, func(i, j int) bool { return failures[i].Item < failures[j].Item })
+		return findings, &clients.PartialError{Op: "insights", Failures: failures}
+	}
+	return findings, nil
+}
+
+// TotalSavings adds up the savings of findings
+func TotalSavings(findings []Finding) float64 {
+	var total float64
+	for _, finding := range findings {
+		total += finding.MonthlySavings
+	}
+	return total
+}
+
+// checkVolumes reports unattached volumes and stopped instances whose
+// volumes keep costing money
+func checkVolumes(ctx context.Context, svc *aws.ServiceClients, region string, now time.Time) ([]Finding, error) {
+	if svc.EC2 == nil {
+		return nil, fmt.Errorf("EC2 service not initialized")
+	}
+
+	volumes, err := svc.EC2.DescribeVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := svc.EC2.GetEC2Detail(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stopped := make(map[string]types.Instance)
+	for _, instance := range instances {
+		if instance.State != nil && instance.State.Name == types.InstanceStateNameStopped {
+			stopped[awssdk.ToString(instance.InstanceId)] = instance
+		}
+	}
+
+	type attached struct {
+		count int
+		size  int32
+		cost  float64
+	}
+	perInstance := make(map[string]*attached)
+
+	var findings []Finding
+	for _, volume := range volumes {
+		size := awssdk.ToInt32(volume.Size)
+		cost, _ := pricing.EBSMonthly(string(volume.VolumeType), size, region)
+
+		if volume.State == types.VolumeStateAvailable {
+			findings = append(findings, Finding{
+				Kind:           KindUnattachedVolume,
+				ResourceID:     awssdk.ToString(volume.VolumeId),
+				Name:           nameTag(volume.Tags),
+				Region:         region,
+				Reason:         fmt.Sprintf("%d GB %s, not attached to any instance", size, volume.VolumeType),
+				MonthlySavings: cost,
+			})
+			continue
+		}
+
+		for _, attachment := range volume.Attachments {
+			id := awssdk.ToString(attachment.InstanceId)
+			if _, ok := stopped[id]; !ok {
+				continue
+			}
+			if perInstance[id] == nil {
+				perInstance[id] = &attached{}
+			}
+			perInstance[id].count++
+			perInstance[id].size += size
+			perInstance[id].cost += cost
+		}
+	}
+
+	for id, volumes := range perInstance {
+		instance := stopped[id]
+		findings = append(findings, Finding{
+			Kind:           KindStoppedInstance,
+			ResourceID:     id,
+			Name:           nameTag(instance.Tags),
+			Region:         region,
+			Reason:         fmt.Sprintf("stopped, %d volume(s) with %d GB attached", volumes.count, volumes.size),
+			MonthlySavings: volumes.cost,
+		})
+	}
+	return findings, nil
+}
+
+// checkAddresses reports Elastic IPs that are not associated with anything
+func checkAddresses(ctx context.Context, svc *aws.ServiceClients, region string, now time.Time) ([]Finding, error) {
+	if svc.EC2 == nil {
+		return nil, fmt.Errorf("EC2 service not initialized")
+	}
+
+	addresses, err := svc.EC2.DescribeAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cost, _ := pricing.ElasticIPMonthly(region)
+	var findings []Finding
+	for _, address := range addresses {
+		if address.AssociationId != nil {
+			continue
+		}
+		id := awssdk.ToString(address.AllocationId)
+		if id == "" {
+			id = awssdk.ToString(address.PublicIp)
+		}
+		findings = append(findings, Finding{
+			Kind:           KindUnattachedEIP,
+			ResourceID:     id,
+			Name:           nameTag(address.Tags),
+			Region:         region,
+			Reason:         fmt.Sprintf("%s is not associated", awssdk.ToString(address.PublicIp)),
+			MonthlySavings: cost,
+		})
+	}
+	return findings, nil
+}
+
+// checkFunctions reports Lambda functions without invocations in
+// IdleLambdaWindow. They cost nothing while idle, so there are no savings,
+// but they are attack surface and clutter.
+func checkFunctions(ctx context.Context, svc *aws.ServiceClients, region string, now time.Time) ([]Finding, error) {
+	if svc.Lambda == nil || svc.CloudWatch == nil {
+		return nil, fmt.Errorf("Lambda or CloudWatch service not initialized")
+	}
+
+	functions, err := svc.Lambda.ListLambdaFunctions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	since := now.Add(-IdleLambdaWindow)
+	var findings []Finding
+	for _, function := range functions {
+		modified, err := time.Parse("2006-01-02T15:04:05.000-0700", function.LastModified)
+		if err == nil && modified.After(since) {
+			continue
+		}
+
+		invocations, err := svc.CloudWatch.MetricSum(ctx, "AWS/Lambda", "Invocations", "FunctionName", function.FunctionName, since, now)
+		if err != nil {
+			return nil, err
+		}
+		if invocations > 0 {
+			continue
+		}
+		findings = append(findings, Finding{
+			Kind:       KindIdleFunction,
+			ResourceID: function.FunctionName,
+			Name:       function.FunctionName,
+			Region:     region,
+			Reason:     fmt.Sprintf("not invoked in %d days", int(IdleLambdaWindow.Hours()/24)),
+		})
+	}
+	return findings, nil
+}
+
+// checkLoadBalancers reports load balancers without registered targets
+func checkLoadBalancers(ctx context.Context, svc *aws.ServiceClients, region string, now time.Time) ([]Finding, error) {
+	if svc.ELB == nil {
+		return nil, fmt.Errorf("ELB service not initialized")
+	}
+
+	balancers, err := svc.ELB.DescribeLoadBalancers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, lb := range balancers {
+		if lb.TargetCount > 0 {
+			continue
+		}
+		cost, _ := pricing.LoadBalancerMonthly(lb.Type, region)
+		findings = append(findings, Finding{
+			Kind:           KindEmptyLoadBalancer,
+			ResourceID:     lb.ARN,
+			Name:           lb.Name,
+			Region:         region,
+			Reason:         fmt.Sprintf("%s load balancer without registered targets", lb.Type),
+			MonthlySavings: cost,
+		})
+	}
+	return findings, nil
+}
+
+// nameTag returns the value of the Name tag, if any
+func nameTag(tags []types.Tag) string {
+	for _, tag := range tags {
+		if awssdk.ToString(tag.Key) == "Name" {
+			return awssdk.ToString(tag.Value)
+		}
+	}
+	return ""
+}
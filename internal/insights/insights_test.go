@@ -0,0 +1,69 @@
+package insights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/aws/fake"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestScanDemoAccount(t *testing.T) {
+	findings, err := Scan(context.Background(), fake.NewClient(), time.Now())
+	if err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+
+	kinds := make(map[string][]string)
+	for _, finding := range findings {
+		kinds[finding.Kind] = append(kinds[finding.Kind], finding.ResourceID)
+	}
+
+	want := map[string]int{
+		KindStoppedInstance:   2, // bastion and staging-app
+		KindUnattachedVolume:  1,
+		KindUnattachedEIP:     1,
+		KindIdleFunction:      1, // image-resizer; recently deployed functions are not judged
+		KindEmptyLoadBalancer: 1,
+	}
+	for kind, count := range want {
+		if len(kinds[kind]) != count {
+			t.Errorf("Expected %d %s findings, got %v", count, kind, kinds[kind])
+		}
+	}
+
+	// The 500 GB gp2 volume costs the most and comes first
+	if findings[0].ResourceID != "vol-0f67a89b01c23d456" || findings[0].MonthlySavings != 50 {
+		t.Errorf("Expected the unattached volume first, got %+v", findings[0])
+	}
+	if total := TotalSavings(findings); total <= findings[0].MonthlySavings {
+		t.Errorf("Expected total savings above the largest finding, got %v", total)
+	}
+}
+
+// deniedELB fails like a principal without elasticloadbalancing permissions
+type deniedELB struct{}
+
+func (deniedELB) DescribeLoadBalancers(ctx context.Context) ([]clients.LoadBalancerDetail, error) {
+	return nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized"}
+}
+
+func TestScanPartialFailure(t *testing.T) {
+	services := fake.NewServices()
+	services.ELB = deniedELB{}
+	client := aws.NewClientWithServices(fake.Profile, fake.Region, services)
+
+	findings, err := Scan(context.Background(), client, time.Now())
+	var partial *clients.PartialError
+	if !errors.As(err, &partial) || len(partial.Failures) != 1 || partial.Failures[0].Item != "load balancers" {
+		t.Fatalf("Expected the load balancer check to fail alone, got %v", err)
+	}
+	if len(findings) == 0 {
+		t.Error("Expected the findings of the other checks")
+	}
+}
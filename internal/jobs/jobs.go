@@ -0,0 +1,185 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// State is where a job is in its life
+type State string
+
+const (
+	StateRunning   State = "running"
+	StateSucceeded State = "succeeded"
+	StateFailed    State = "failed"
+	StateCancelled State = "cancelled"
+)
+
+// Job is a snapshot of a background job
+type Job struct {
+	ID    int
+	Title string
+	// Noun names the items of the job, e.g. "object"
+	Noun  string
+	State State
+	// Done of Total items are processed, Total is 0 until known
+	Done  int
+	Total int
+	Err   error
+
+	Started  time.Time
+	Finished time.Time
+}
+
+// IsFinished reports whether the job stopped running
+func (j Job) IsFinished() bool {
+	return j.State != StateRunning
+}
+
+// Progress reports how many of the items of a job are processed
+type Progress func(done, total int)
+
+// Tracker runs jobs in the background and keeps them until cleared, so
+// their outcome can be looked at after they finished
+type Tracker struct {
+	mu       sync.Mutex
+	jobs     []*tracked
+	nextID   int
+	onChange func(Job)
+}
+
+type tracked struct {
+	job    Job
+	cancel context.CancelFunc
+}
+
+// NewTracker creates a tracker that calls onChange, if set, whenever a job
+// starts, progresses or finishes. onChange is called from the goroutine of
+// the job, never from the caller of Start.
+func NewTracker(onChange func(Job)) *Tracker {
+	return &Tracker{onChange: onChange}
+}
+
+// Start runs run in a new goroutine as the job title over items called noun.
+// The job fails with the error run returns and is cancelled when run returns
+// after Cancel.
+func (t *Tracker) Start(title, noun string, run func(ctx context.Context, progress Progress) error) Job {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	t.mu.Lock()
+	t.nextID++
+	entry := &tracked{
+		job:    Job{ID: t.nextID, Title: title, Noun: noun, State: StateRunning, Started: time.Now()},
+		cancel: cancel,
+	}
+	t.jobs = append(t.jobs, entry)
+	job := entry.job
+	t.mu.Unlock()
+
+	go func() {
+		defer cancel()
+		t.changed(job)
+
+		err := run(ctx, func(done, total int) {
+			t.mu.Lock()
+			entry.job.Done, entry.job.Total = done, total
+			job := entry.job
+			t.mu.Unlock()
+			t.changed(job)
+		})
+
+		t.mu.Lock()
+		switch {
+		case ctx.Err() != nil && (err == nil || errors.Is(err, context.Canceled)):
+			entry.job.State = StateCancelled
+		case err != nil:
+			entry.job.State = StateFailed
+			entry.job.Err = err
+		default:
+			entry.job.State = StateSucceeded
+		}
+		entry.job.Finished = time.Now()
+		job := entry.job
+		t.mu.Unlock()
+		t.changed(job)
+	}()
+
+	return job
+}
+
+// Cancel asks the running job id to stop and reports whether it was running
+func (t *Tracker) Cancel(id int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, entry := range t.jobs {
+		if entry.job.ID == id && entry.job.State == StateRunning {
+			entry.cancel()
+			return true
+		}
+	}
+	return false
+}
+
+// CancelAll asks every running job to stop and returns how many were running
+func (t *Tracker) CancelAll() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cancelled := 0
+	for _, entry := range t.jobs {
+		if entry.job.State == StateRunning {
+			entry.cancel()
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+// Jobs returns the jobs, newest first
+func (t *Tracker) Jobs() []Job {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	jobs := make([]Job, len(t.jobs))
+	for i, entry := range t.jobs {
+		jobs[len(t.jobs)-1-i] = entry.job
+	}
+	return jobs
+}
+
+// Running returns how many jobs are running
+func (t *Tracker) Running() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	running := 0
+	for _, entry := range t.jobs {
+		if entry.job.State == StateRunning {
+			running++
+		}
+	}
+	return running
+}
+
+// ClearFinished forgets the jobs that stopped running
+func (t *Tracker) ClearFinished() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	kept := t.jobs[:0]
+	for _, entry := range t.jobs {
+		if entry.job.State == StateRunning {
+			kept = append(kept, entry)
+		}
+	}
+	t.jobs = kept
+}
+
+func (t *Tracker) changed(job Job) {
+	if t.onChange != nil {
+		t.onChange(job)
+	}
+}
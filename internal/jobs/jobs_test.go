@@ -0,0 +1,112 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitFinished waits until the job id stopped running
+func waitFinished(t *testing.T, tracker *Tracker, id int) Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, job := range tracker.Jobs() {
+			if job.ID == id && job.IsFinished() {
+				return job
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Job %d did not finish", id)
+	return Job{}
+}
+
+func TestTrackerOutcomes(t *testing.T) {
+	changes := make(chan Job, 100)
+	tracker := NewTracker(func(job Job) { changes <- job })
+
+	ok := tracker.Start("copy", "object", func(ctx context.Context, progress Progress) error {
+		progress(1, 2)
+		progress(2, 2)
+		return nil
+	})
+	failed := tracker.Start("delete", "object", func(ctx context.Context, progress Progress) error {
+		return errors.New("access denied")
+	})
+
+	if job := waitFinished(t, tracker, ok.ID); job.State != StateSucceeded || job.Done != 2 || job.Total != 2 || job.Finished.IsZero() {
+		t.Errorf("Expected a succeeded job with 2 of 2 done, got %+v", job)
+	}
+	if job := waitFinished(t, tracker, failed.ID); job.State != StateFailed || job.Err == nil {
+		t.Errorf("Expected a failed job, got %+v", job)
+	}
+
+	jobs := tracker.Jobs()
+	if len(jobs) != 2 || jobs[0].Title != "delete" {
+		t.Errorf("Expected the newest job first, got %+v", jobs)
+	}
+	if len(changes) < 5 {
+		t.Errorf("Expected a change for every start, progress and finish, got %d", len(changes))
+	}
+
+	tracker.ClearFinished()
+	if jobs := tracker.Jobs(); len(jobs) != 0 {
+		t.Errorf("Expected no jobs after clearing, got %+v", jobs)
+	}
+}
+
+func TestTrackerCancel(t *testing.T) {
+	tracker := NewTracker(nil)
+	started := make(chan struct{})
+
+	job := tracker.Start("move", "object", func(ctx context.Context, progress Progress) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	<-started
+	if tracker.Running() != 1 {
+		t.Errorf("Expected 1 running job, got %d", tracker.Running())
+	}
+
+	if !tracker.Cancel(job.ID) {
+		t.Fatal("Expected the running job to be cancelled")
+	}
+	if got := waitFinished(t, tracker, job.ID); got.State != StateCancelled || got.Err != nil {
+		t.Errorf("Expected a cancelled job, got %+v", got)
+	}
+	if tracker.Cancel(job.ID) {
+		t.Error("Expected a finished job not to be cancelled again")
+	}
+}
+
+func TestTrackerCancelAll(t *testing.T) {
+	tracker := NewTracker(nil)
+	started := make(chan struct{}, 2)
+
+	var ids []int
+	for _, title := range []string{"copy", "delete"} {
+		job := tracker.Start(title, "object", func(ctx context.Context, progress Progress) error {
+			started <- struct{}{}
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		ids = append(ids, job.ID)
+	}
+	<-started
+	<-started
+
+	if cancelled := tracker.CancelAll(); cancelled != 2 {
+		t.Errorf("Expected 2 cancelled jobs, got %d", cancelled)
+	}
+	for _, id := range ids {
+		if got := waitFinished(t, tracker, id); got.State != StateCancelled {
+			t.Errorf("Expected a cancelled job, got %+v", got)
+		}
+	}
+	if cancelled := tracker.CancelAll(); cancelled != 0 {
+		t.Errorf("Expected nothing left to cancel, got %d", cancelled)
+	}
+}
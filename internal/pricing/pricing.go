@@ -0,0 +1,239 @@
+// Package pricing estimates the on-demand cost of EC2, RDS and SageMaker
+// instances, EBS volumes, Elastic IPs, load balancers, NAT gateways and
+// Transfer Family servers from an embedded price table, so no access to the AWS Pricing API is needed. The estimates
+// leave out traffic, except what NAT gateways process, capacity units and
+// discounts.
+package pricing
+
+import "strings"
+
+// HoursPerMonth is the number of hours AWS bills for an average month
+const HoursPerMonth = 730
+
+// ec2Hourly holds on-demand Linux prices in USD per hour in us-east-1
+var ec2Hourly = map[string]float64{
+	"t2.micro": 0.0116, "t2.small": 0.023, "t2.medium": 0.0464, "t2.large": 0.0928,
+	"t3.nano": 0.0052, "t3.micro": 0.0104, "t3.small": 0.0208, "t3.medium": 0.0416,
+	"t3.large": 0.0832, "t3.xlarge": 0.1664, "t3.2xlarge": 0.3328,
+	"t3a.micro": 0.0094, "t3a.small": 0.0188, "t3a.medium": 0.0376, "t3a.large": 0.0752,
+	"t4g.micro": 0.0084, "t4g.small": 0.0168, "t4g.medium": 0.0336, "t4g.large": 0.0672,
+	"m5.large": 0.096, "m5.xlarge": 0.192, "m5.2xlarge": 0.384, "m5.4xlarge": 0.768,
+	"m6i.large": 0.096, "m6i.xlarge": 0.192, "m6i.2xlarge": 0.384, "m6i.4xlarge": 0.768,
+	"m6g.large": 0.077, "m6g.xlarge": 0.154, "m6g.2xlarge": 0.308,
+	"m7g.large": 0.0816, "m7g.xlarge": 0.1632,
+	"c5.large": 0.085, "c5.xlarge": 0.17, "c5.2xlarge": 0.34,
+	"c6i.large": 0.085, "c6i.xlarge": 0.17, "c6i.2xlarge": 0.34,
+	"c6g.large": 0.068, "c6g.xlarge": 0.136,
+	"c7g.large": 0.0725, "c7g.xlarge": 0.145,
+	"r5.large": 0.126, "r5.xlarge": 0.252, "r5.2xlarge": 0.504,
+	"r6i.large": 0.126, "r6i.xlarge": 0.252, "r6i.2xlarge": 0.504,
+	"r6g.large": 0.1008, "r6g.xlarge": 0.2016,
+}
+
+// rdsHourly holds on-demand Single-AZ MySQL/PostgreSQL prices in USD per hour
+// in us-east-1
+var rdsHourly = map[string]float64{
+	"db.t3.micro": 0.017, "db.t3.small": 0.034, "db.t3.medium": 0.068, "db.t3.large": 0.136,
+	"db.t4g.micro": 0.016, "db.t4g.small": 0.032, "db.t4g.medium": 0.065, "db.t4g.large": 0.129,
+	"db.m5.large": 0.171, "db.m5.xlarge": 0.342, "db.m5.2xlarge": 0.684,
+	"db.m6i.large": 0.171, "db.m6i.xlarge": 0.342,
+	"db.m6g.large": 0.152, "db.m6g.xlarge": 0.304,
+	"db.r5.large": 0.24, "db.r5.xlarge": 0.48,
+	"db.r6g.large": 0.215, "db.r6g.xlarge": 0.43,
+}
+
+// sageMakerHourly holds on-demand prices of SageMaker notebook and real-time
+// inference instances in USD per hour in us-east-1
+var sageMakerHourly = map[string]float64{
+	"ml.t2.medium": 0.0464, "ml.t3.medium": 0.05, "ml.t3.large": 0.1, "ml.t3.xlarge": 0.2,
+	"ml.m5.large": 0.115, "ml.m5.xlarge": 0.23, "ml.m5.2xlarge": 0.461, "ml.m5.4xlarge": 0.922,
+	"ml.c5.large": 0.102, "ml.c5.xlarge": 0.204, "ml.c5.2xlarge": 0.408,
+	"ml.r5.large": 0.151, "ml.r5.xlarge": 0.302,
+	"ml.g4dn.xlarge": 0.7364, "ml.g4dn.2xlarge": 1.0528,
+	"ml.g5.xlarge": 1.408, "ml.g5.2xlarge": 1.515, "ml.g5.12xlarge": 7.09,
+	"ml.p3.2xlarge": 3.825, "ml.inf2.xlarge": 0.99,
+}
+
+// regionFactor scales us-east-1 prices to other regions
+var regionFactor = map[string]float64{
+	"us-east-1":      1.0,
+	"us-east-2":      1.0,
+	"us-west-2":      1.0,
+	"us-west-1":      1.17,
+	"ca-central-1":   1.1,
+	"eu-west-1":      1.11,
+	"eu-west-2":      1.16,
+	"eu-west-3":      1.17,
+	"eu-central-1":   1.16,
+	"eu-north-1":     1.05,
+	"ap-south-1":     1.05,
+	"ap-southeast-1": 1.25,
+	"ap-southeast-2": 1.25,
+	"ap-northeast-1": 1.28,
+	"ap-northeast-2": 1.23,
+	"sa-east-1":      1.59,
+}
+
+// EC2Monthly returns the estimated monthly on-demand cost in USD of a running
+// EC2 instance, or false if the instance type or region is not in the table
+func EC2Monthly(instanceType, region string) (float64, bool) {
+	return monthly(ec2Hourly, instanceType, region)
+}
+
+// RDSMonthly returns the estimated monthly on-demand cost in USD of an RDS
+// instance class. Multi-AZ deployments run a standby and cost twice as much.
+func RDSMonthly(instanceClass, region string, multiAZ bool) (float64, bool) {
+	cost, ok := monthly(rdsHourly, instanceClass, region)
+	if multiAZ {
+		cost *= 2
+	}
+	return cost, ok
+}
+
+// SageMakerMonthly returns the estimated monthly on-demand cost in USD of
+// count SageMaker instances of instanceType, e.g. ml.m5.large
+func SageMakerMonthly(instanceType string, count int, region string) (float64, bool) {
+	cost, ok := monthly(sageMakerHourly, instanceType, region)
+	return cost * float64(count), ok
+}
+
+func monthly(prices map[string]float64, class, region string) (float64, bool) {
+	hourly, ok := prices[strings.ToLower(class)]
+	if !ok {
+		return 0, false
+	}
+	factor, ok := regionFactor[region]
+	if !ok {
+		return 0, false
+	}
+	return hourly * factor * HoursPerMonth, true
+}
+
+// ebsMonthlyPerGB holds EBS storage prices in USD per GB-month in us-east-1
+var ebsMonthlyPerGB = map[string]float64{
+	"gp3": 0.08, "gp2": 0.10, "io1": 0.125, "io2": 0.125,
+	"st1": 0.045, "sc1": 0.015, "standard": 0.05,
+}
+
+// ebsSnapshotMonthlyPerGB holds EBS snapshot prices in USD per GB-month in
+// us-east-1, by storage tier
+var ebsSnapshotMonthlyPerGB = map[string]float64{
+	"standard": 0.05, "archive": 0.0125,
+}
+
+// s3MonthlyPerGB holds S3 storage prices in USD per GB-month in us-east-1,
+// by storage class
+var s3MonthlyPerGB = map[string]float64{
+	"standard": 0.023, "intelligent_tiering": 0.023, "standard_ia": 0.0125, "onezone_ia": 0.01,
+	"glacier_ir": 0.004, "glacier": 0.0036, "deep_archive": 0.00099, "reduced_redundancy": 0.024,
+}
+
+// publicIPv4Hourly is the price in USD per hour of a public IPv4 address
+const publicIPv4Hourly = 0.005
+
+// loadBalancerHourly holds base prices in USD per hour of load balancers in
+// us-east-1
+var loadBalancerHourly = map[string]float64{
+	"application": 0.0225,
+	"network":     0.0225,
+	"gateway":     0.0125,
+	"classic":     0.025,
+}
+
+// NAT gateways cost an hourly charge and a charge per GB they process, in USD
+// in us-east-1
+const (
+	natGatewayHourly = 0.045
+	natGatewayPerGB  = 0.045
+)
+
+// transferProtocolHourly is the price in USD per hour of each protocol
+// enabled on a Transfer Family server in us-east-1
+const transferProtocolHourly = 0.30
+
+// EBSMonthly returns the estimated monthly storage cost in USD of an EBS
+// volume, without provisioned IOPS or throughput
+func EBSMonthly(volumeType string, sizeGB int32, region string) (float64, bool) {
+	perGB, ok := ebsMonthlyPerGB[strings.ToLower(volumeType)]
+	if !ok {
+		return 0, false
+	}
+	factor, ok := regionFactor[region]
+	if !ok {
+		return 0, false
+	}
+	return perGB * float64(sizeGB) * factor, true
+}
+
+// EBSSnapshotMonthly returns the estimated monthly cost in USD of an EBS
+// snapshot of a sizeGB volume in a storage tier; an empty tier is standard.
+// Snapshots are incremental, so this is the most it costs.
+func EBSSnapshotMonthly(tier string, sizeGB int, region string) (float64, bool) {
+	if tier == "" {
+		tier = "standard"
+	}
+	perGB, ok := ebsSnapshotMonthlyPerGB[strings.ToLower(tier)]
+	if !ok {
+		return 0, false
+	}
+	factor, ok := regionFactor[region]
+	if !ok {
+		return 0, false
+	}
+	return perGB * float64(sizeGB) * factor, true
+}
+
+// S3Monthly returns the estimated monthly storage cost in USD of bytes
+// stored in an S3 storage class; an empty class is STANDARD
+func S3Monthly(storageClass string, bytes int64, region string) (float64, bool) {
+	if storageClass == "" {
+		storageClass = "standard"
+	}
+	perGB, ok := s3MonthlyPerGB[strings.ToLower(storageClass)]
+	if !ok {
+		return 0, false
+	}
+	factor, ok := regionFactor[region]
+	if !ok {
+		return 0, false
+	}
+	return perGB * float64(bytes) / (1 << 30) * factor, true
+}
+
+// ElasticIPMonthly returns the estimated monthly cost in USD of an Elastic IP
+// address. AWS charges for every public IPv4 address, attached or not.
+func ElasticIPMonthly(region string) (float64, bool) {
+	factor, ok := regionFactor[region]
+	if !ok {
+		return 0, false
+	}
+	return publicIPv4Hourly * factor * HoursPerMonth, true
+}
+
+// LoadBalancerMonthly returns the estimated monthly base cost in USD of a load
+// balancer of the given type, without capacity units
+func LoadBalancerMonthly(lbType, region string) (float64, bool) {
+	return monthly(loadBalancerHourly, lbType, region)
+}
+
+// NATGatewayMonthly returns the estimated monthly cost in USD of a NAT
+// gateway that processes processedGB a month, split into the hourly charge
+// and the data processing charge
+func NATGatewayMonthly(processedGB float64, region string) (hourly, processing float64, ok bool) {
+	factor, ok := regionFactor[region]
+	if !ok {
+		return 0, 0, false
+	}
+	return natGatewayHourly * factor * HoursPerMonth, processedGB * natGatewayPerGB * factor, true
+}
+
+// TransferServerMonthly returns the estimated monthly cost in USD of a
+// Transfer Family server with protocols protocols enabled, without the
+// data uploaded and downloaded. Stopped servers are billed too.
+func TransferServerMonthly(protocols int, region string) (float64, bool) {
+	factor, ok := regionFactor[region]
+	if !ok {
+		return 0, false
+	}
+	return transferProtocolHourly * float64(protocols) * factor * HoursPerMonth, true
+}
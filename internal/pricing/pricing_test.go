@@ -0,0 +1,77 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEC2Monthly(t *testing.T) {
+	cost, ok := EC2Monthly("t3.medium", "us-east-1")
+	if !ok || math.Abs(cost-30.368) > 0.001 {
+		t.Errorf("Expected $30.37 for t3.medium in us-east-1, got %v %v", cost, ok)
+	}
+
+	if eu, _ := EC2Monthly("t3.medium", "eu-west-1"); eu <= cost {
+		t.Errorf("Expected eu-west-1 to cost more than us-east-1, got %v", eu)
+	}
+
+	if _, ok := EC2Monthly("x99.huge", "us-east-1"); ok {
+		t.Error("Expected no estimate for an unknown instance type")
+	}
+	if _, ok := EC2Monthly("t3.medium", "mars-north-1"); ok {
+		t.Error("Expected no estimate for an unknown region")
+	}
+}
+
+func TestRDSMonthly(t *testing.T) {
+	single, ok := RDSMonthly("db.t3.micro", "us-east-1", false)
+	if !ok {
+		t.Fatal("Expected an estimate for db.t3.micro")
+	}
+	if multi, _ := RDSMonthly("db.t3.micro", "us-east-1", true); multi != 2*single {
+		t.Errorf("Expected Multi-AZ to double the cost, got %v and %v", single, multi)
+	}
+}
+
+func TestSageMakerMonthly(t *testing.T) {
+	one, ok := SageMakerMonthly("ml.g5.xlarge", 1, "us-east-1")
+	if !ok || math.Abs(one-1027.84) > 0.001 {
+		t.Fatalf("Expected $1027.84 for an ml.g5.xlarge, got %v %v", one, ok)
+	}
+	if two, _ := SageMakerMonthly("ml.g5.xlarge", 2, "us-east-1"); two != 2*one {
+		t.Errorf("Expected two instances to double the cost, got %v and %v", one, two)
+	}
+	if _, ok := SageMakerMonthly("ml.unknown.large", 1, "us-east-1"); ok {
+		t.Error("Expected no estimate for an unknown instance type")
+	}
+}
+
+func TestStorageAndNetworkMonthly(t *testing.T) {
+	if cost, ok := EBSMonthly("gp3", 100, "us-east-1"); !ok || math.Abs(cost-8) > 0.001 {
+		t.Errorf("Expected $8 for 100 GB gp3, got %v %v", cost, ok)
+	}
+	if cost, ok := EBSSnapshotMonthly("", 100, "us-east-1"); !ok || math.Abs(cost-5) > 0.001 {
+		t.Errorf("Expected $5 for a snapshot of 100 GB, got %v %v", cost, ok)
+	}
+	if archived, _ := EBSSnapshotMonthly("archive", 100, "us-east-1"); math.Abs(archived-1.25) > 0.001 {
+		t.Errorf("Expected $1.25 for an archived snapshot of 100 GB, got %v", archived)
+	}
+	if cost, ok := S3Monthly("", 100<<30, "us-east-1"); !ok || math.Abs(cost-2.3) > 0.001 {
+		t.Errorf("Expected $2.30 for 100 GB in S3 Standard, got %v %v", cost, ok)
+	}
+	if deep, _ := S3Monthly("DEEP_ARCHIVE", 100<<30, "us-east-1"); deep >= 0.1 {
+		t.Errorf("Expected Deep Archive to cost a fraction of Standard, got %v", deep)
+	}
+	if cost, ok := ElasticIPMonthly("us-east-1"); !ok || math.Abs(cost-3.65) > 0.001 {
+		t.Errorf("Expected $3.65 for an Elastic IP, got %v %v", cost, ok)
+	}
+	if _, ok := LoadBalancerMonthly("application", "us-east-1"); !ok {
+		t.Error("Expected an estimate for an Application Load Balancer")
+	}
+	if hourly, processing, ok := NATGatewayMonthly(1000, "us-east-1"); !ok || math.Abs(hourly-32.85) > 0.001 || math.Abs(processing-45) > 0.001 {
+		t.Errorf("Expected $32.85 and $45 for a NAT gateway processing 1000 GB, got %v %v %v", hourly, processing, ok)
+	}
+	if cost, ok := TransferServerMonthly(2, "us-east-1"); !ok || math.Abs(cost-438) > 0.001 {
+		t.Errorf("Expected $438 for a Transfer Family server with two protocols, got %v %v", cost, ok)
+	}
+}
@@ -0,0 +1,160 @@
+// Package recording writes sessions of the TUI to asciinema v2 cast files:
+// the screen frames as output events and the keys pressed as input events,
+// so a session can be replayed with asciinema or attached to a postmortem.
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Version is the asciinema cast format written
+const Version = 2
+
+// Header is the first line of a cast file
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Event types of a cast file
+const (
+	Output = "o"
+	Input  = "i"
+	Resize = "r"
+)
+
+// Recorder appends the events of a session to a cast file. It is safe for
+// concurrent use; after a write failed all writes return that error.
+type Recorder struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	w       *bufio.Writer
+	started time.Time
+	last    string
+	err     error
+}
+
+// DefaultPath returns the file of a recording started at t,
+// ~/.swiss-army-tui/recordings/session-2006-01-02T15-04-05.cast
+func DefaultPath(t time.Time) string {
+	name := fmt.Sprintf("session-%s.cast", t.Format("2006-01-02T15-04-05"))
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(homeDir, ".swiss-army-tui", "recordings", name)
+}
+
+// Start creates the cast file at path, creating its directory if needed,
+// for a screen of width by height started at started
+func Start(path string, width, height int, started time.Time, title string) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	// Recordings show resource details and logs, so only the user may read them
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording: %w", err)
+	}
+
+	r := &Recorder{path: path, file: file, w: bufio.NewWriter(file), started: started}
+	header := Header{
+		Version:   Version,
+		Width:     width,
+		Height:    height,
+		Timestamp: started.Unix(),
+		Title:     title,
+		Env:       map[string]string{"TERM": os.Getenv("TERM"), "SHELL": os.Getenv("SHELL")},
+	}
+	if err := r.writeLine(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Path returns the cast file
+func (r *Recorder) Path() string {
+	return r.path
+}
+
+// Frame records the screen as drawn at t unless it is the frame recorded last
+func (r *Recorder) Frame(t time.Time, frame string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if frame == r.last {
+		return r.err
+	}
+	r.last = frame
+	return r.event(t, Output, frame)
+}
+
+// Key records a key pressed at t
+func (r *Recorder) Key(t time.Time, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.event(t, Input, key)
+}
+
+// Resize records that the screen became width by height at t. The next
+// frame is recorded even if it looks like the last one.
+func (r *Recorder) Resize(t time.Time, width, height int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last = ""
+	return r.event(t, Resize, fmt.Sprintf("%dx%d", width, height))
+}
+
+// Close writes the events buffered and closes the cast file
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return r.err
+	}
+	if err := r.w.Flush(); err != nil && r.err == nil {
+		r.err = fmt.Errorf("failed to write recording: %w", err)
+	}
+	if err := r.file.Close(); err != nil && r.err == nil {
+		r.err = fmt.Errorf("failed to close recording: %w", err)
+	}
+	r.file = nil
+	return r.err
+}
+
+// event writes an event of kind at t, seconds after the start (locked)
+func (r *Recorder) event(t time.Time, kind, data string) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.file == nil {
+		return fmt.Errorf("recording %s is closed", r.path)
+	}
+	elapsed := max(t.Sub(r.started).Seconds(), 0)
+	return r.writeLine([]interface{}{elapsed, kind, data})
+}
+
+// writeLine writes v as a line of JSON (locked)
+func (r *Recorder) writeLine(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		r.err = fmt.Errorf("failed to encode recording event: %w", err)
+		return r.err
+	}
+	data = append(data, '\n')
+	if _, err := r.w.Write(data); err != nil {
+		r.err = fmt.Errorf("failed to write recording: %w", err)
+	}
+	return r.err
+}
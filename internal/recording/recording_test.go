@@ -0,0 +1,91 @@
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recordings", "incident.cast")
+	started := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	r, err := Start(path, 120, 40, started, "prod eu-west-1")
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	at := func(seconds float64) time.Time {
+		return started.Add(time.Duration(seconds * float64(time.Second)))
+	}
+	for _, err := range []error{
+		r.Frame(at(0), "\x1b[Hfirst"),
+		// Frames that did not change are left out
+		r.Frame(at(0.5), "\x1b[Hfirst"),
+		r.Key(at(1), "r"),
+		r.Frame(at(1.5), "\x1b[Hsecond"),
+		r.Resize(at(2), 80, 24),
+		r.Frame(at(2.5), "\x1b[Hsecond"),
+	} {
+		if err != nil {
+			t.Fatalf("Recording failed: %v", err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := r.Key(at(3), "q"); err == nil {
+		t.Error("Expected an error recording after Close")
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected a file only the user can read, got %v, %v", info, err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	lines := bufio.NewScanner(file)
+
+	var header Header
+	if !lines.Scan() || json.Unmarshal(lines.Bytes(), &header) != nil {
+		t.Fatalf("Expected a header, got %q", lines.Text())
+	}
+	if header.Version != 2 || header.Width != 120 || header.Height != 40 || header.Timestamp != started.Unix() {
+		t.Errorf("Unexpected header %+v", header)
+	}
+
+	want := [][]interface{}{
+		{0.0, Output, "\x1b[Hfirst"},
+		{1.0, Input, "r"},
+		{1.5, Output, "\x1b[Hsecond"},
+		{2.0, Resize, "80x24"},
+		{2.5, Output, "\x1b[Hsecond"},
+	}
+	for i := 0; lines.Scan(); i++ {
+		var event []interface{}
+		if err := json.Unmarshal(lines.Bytes(), &event); err != nil {
+			t.Fatalf("Event %d: %v", i, err)
+		}
+		if i >= len(want) {
+			t.Fatalf("Unexpected event %v", event)
+		}
+		if len(event) != 3 || event[0] != want[i][0] || event[1] != want[i][1] || event[2] != want[i][2] {
+			t.Errorf("Event %d: expected %v, got %v", i, want[i], event)
+		}
+	}
+}
+
+func TestStartFails(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "file")
+	if err := os.WriteFile(blocker, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Start(filepath.Join(blocker, "session.cast"), 80, 24, time.Now(), ""); err == nil {
+		t.Error("Expected an error for a directory that is a file")
+	}
+}
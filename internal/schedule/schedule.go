@@ -0,0 +1,416 @@
+// Package schedule keeps resource actions queued for later, such as stopping
+// instances in the evening, in a JSON file so they survive restarts. They are
+// run by the TUI while it is running.
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Action is what a schedule does to its targets, named after the IAM action
+// it needs. Actions needing the same IAM action add a suffix to it.
+type Action string
+
+const (
+	ActionStopInstances  Action = "ec2:StopInstances"
+	ActionStartInstances Action = "ec2:StartInstances"
+	// ActionScaleToZero sets the capacity of Auto Scaling groups to 0 and
+	// saves their capacity in the schedule restoring it
+	ActionScaleToZero Action = "autoscaling:UpdateAutoScalingGroup"
+	// ActionRestoreCapacity gives Auto Scaling groups back the capacity
+	// saved when they were scaled to 0
+	ActionRestoreCapacity Action = "autoscaling:UpdateAutoScalingGroup:restore"
+)
+
+// Actions are the actions that can be scheduled
+var Actions = []Action{ActionStopInstances, ActionStartInstances, ActionScaleToZero, ActionRestoreCapacity}
+
+// InstanceActions are the actions on EC2 instances
+var InstanceActions = []Action{ActionStopInstances, ActionStartInstances}
+
+// Verb returns what the action does, e.g. "Stop"
+func (a Action) Verb() string {
+	switch a {
+	case ActionStopInstances:
+		return "Stop"
+	case ActionStartInstances:
+		return "Start"
+	case ActionScaleToZero:
+		return "Scale to 0"
+	case ActionRestoreCapacity:
+		return "Restore capacity of"
+	default:
+		return string(a)
+	}
+}
+
+// Permission returns the IAM action the action needs, e.g.
+// "autoscaling:UpdateAutoScalingGroup"
+func (a Action) Permission() string {
+	service, rest, _ := strings.Cut(string(a), ":")
+	name, _, _ := strings.Cut(rest, ":")
+	return service + ":" + name
+}
+
+// Noun returns what the targets of the action are, e.g. "instances"
+func (a Action) Noun() string {
+	if strings.HasPrefix(string(a), "autoscaling:") {
+		return "Auto Scaling groups"
+	}
+	return "instances"
+}
+
+// Capacity is the capacity of an Auto Scaling group
+type Capacity struct {
+	Min     int32 `json:"min"`
+	Max     int32 `json:"max"`
+	Desired int32 `json:"desired"`
+}
+
+// Schedule is an action queued for the targets in a profile and region
+type Schedule struct {
+	ID      int       `json:"id"`
+	Action  Action    `json:"action"`
+	Profile string    `json:"profile"`
+	Region  string    `json:"region"`
+	Targets []string  `json:"targets"`
+	At      time.Time `json:"at"`
+	Created time.Time `json:"created"`
+	// Restore is the ID of the schedule restoring the capacity of the
+	// groups a scale to 0 empties
+	Restore int `json:"restore,omitempty"`
+	// Capacities are the capacities the groups of a restore had before they
+	// were scaled to 0, by name, saved when the scale ran
+	Capacities map[string]Capacity `json:"capacities,omitempty"`
+}
+
+// Title describes the schedule, e.g. "Stop 5 instances in prod/eu-west-1"
+func (s Schedule) Title() string {
+	what := s.Targets[0]
+	if len(s.Targets) > 1 {
+		what = fmt.Sprintf("%d %s", len(s.Targets), s.Action.Noun())
+	}
+	return fmt.Sprintf("%s %s in %s/%s", s.Action.Verb(), what, s.Profile, s.Region)
+}
+
+// Store keeps schedules in a JSON file
+type Store struct {
+	path      string
+	mu        sync.Mutex
+	schedules []Schedule
+	// held are the restores whose scale to 0 was taken but has not run yet
+	held map[int]bool
+}
+
+// New creates a store keeping schedules in path
+func New(path string) *Store {
+	return &Store{path: path, held: make(map[int]bool)}
+}
+
+// DefaultPath returns ~/.swiss-army-tui/schedules.json
+func DefaultPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "schedules.json"
+	}
+	return filepath.Join(homeDir, ".swiss-army-tui", "schedules.json")
+}
+
+// Load reads the saved schedules. A missing file yields none.
+func (s *Store) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read schedules: %w", err)
+	}
+
+	var schedules []Schedule
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		return fmt.Errorf("failed to decode schedules: %w", err)
+	}
+	s.schedules = schedules
+	return nil
+}
+
+// List returns the pending schedules, the soonest first
+func (s *Store) List() []Schedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := append([]Schedule(nil), s.schedules...)
+	sort.SliceStable(list, func(i, j int) bool { return list[i].At.Before(list[j].At) })
+	return list
+}
+
+// Add saves schedule with a new ID and returns it
+func (s *Store) Add(schedule Schedule) (Schedule, error) {
+	if err := validate(schedule); err != nil {
+		return Schedule{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule = s.assignID(schedule)
+	s.schedules = append(s.schedules, schedule)
+	if err := s.save(); err != nil {
+		s.schedules = s.schedules[:len(s.schedules)-1]
+		return Schedule{}, err
+	}
+	return schedule, nil
+}
+
+// AddScaleToZero saves a scale to 0 of groups at the time of scale and the
+// restore of their capacity at the time of restore, and returns both. The
+// scale saves the capacity of the groups in the restore when it runs.
+func (s *Store) AddScaleToZero(scale, restore Schedule) (Schedule, Schedule, error) {
+	scale.Action, restore.Action = ActionScaleToZero, ActionRestoreCapacity
+	restore.Targets = scale.Targets
+	for _, schedule := range []Schedule{scale, restore} {
+		if err := validate(schedule); err != nil {
+			return Schedule{}, Schedule{}, err
+		}
+	}
+	if !restore.At.After(scale.At) {
+		return Schedule{}, Schedule{}, fmt.Errorf("the capacity must be restored after the groups are scaled to 0")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	restore = s.assignID(restore)
+	s.schedules = append(s.schedules, restore)
+	scale.Restore = restore.ID
+	scale = s.assignID(scale)
+	s.schedules = append(s.schedules, scale)
+	if err := s.save(); err != nil {
+		s.schedules = s.schedules[:len(s.schedules)-2]
+		return Schedule{}, Schedule{}, err
+	}
+	return scale, restore, nil
+}
+
+// SaveCapacities adds capacities, by group, to the pending schedule id
+func (s *Store) SaveCapacities(id int, capacities map[string]Capacity) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, schedule := range s.schedules {
+		if schedule.ID != id {
+			continue
+		}
+		previous := schedule.Capacities
+		saved := make(map[string]Capacity, len(previous)+len(capacities))
+		for name, capacity := range previous {
+			saved[name] = capacity
+		}
+		for name, capacity := range capacities {
+			saved[name] = capacity
+		}
+		s.schedules[i].Capacities = saved
+		if err := s.save(); err != nil {
+			s.schedules[i].Capacities = previous
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("no schedule %d", id)
+}
+
+// validate checks that schedule can be saved
+func validate(schedule Schedule) error {
+	if !knownAction(schedule.Action) {
+		return fmt.Errorf("unknown action %q", schedule.Action)
+	}
+	if len(schedule.Targets) == 0 {
+		return fmt.Errorf("schedule has no targets")
+	}
+	if schedule.At.IsZero() {
+		return fmt.Errorf("schedule has no time")
+	}
+	return nil
+}
+
+// assignID gives schedule the next free ID. The caller must hold mu.
+func (s *Store) assignID(schedule Schedule) Schedule {
+	for _, existing := range s.schedules {
+		schedule.ID = max(schedule.ID, existing.ID)
+	}
+	schedule.ID++
+	if schedule.Created.IsZero() {
+		schedule.Created = time.Now()
+	}
+	return schedule
+}
+
+// Remove deletes the schedule id
+func (s *Store) Remove(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, schedule := range s.schedules {
+		if schedule.ID == id {
+			s.schedules = append(s.schedules[:i], s.schedules[i+1:]...)
+			return s.save()
+		}
+	}
+	return fmt.Errorf("no schedule %d", id)
+}
+
+// TakeDue removes and returns the schedules due at now, the oldest first.
+// They are removed before they run, so a crash never runs them twice. A
+// restore is not due before its scale to 0 ran, since the scale saves the
+// capacities it restores: it stays while the scale is pending, and once
+// taken with it until Release.
+func (s *Store) TakeDue(now time.Time) ([]Schedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	waiting := make(map[int]bool)
+	for _, schedule := range s.schedules {
+		if schedule.Restore != 0 {
+			waiting[schedule.Restore] = true
+		}
+	}
+
+	var due []Schedule
+	kept := make([]Schedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		if schedule.At.After(now) || waiting[schedule.ID] || s.held[schedule.ID] {
+			kept = append(kept, schedule)
+		} else {
+			due = append(due, schedule)
+		}
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+
+	previous := s.schedules
+	s.schedules = kept
+	if err := s.save(); err != nil {
+		s.schedules = previous
+		return nil, err
+	}
+	for _, schedule := range due {
+		if schedule.Restore != 0 {
+			s.held[schedule.Restore] = true
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].At.Before(due[j].At) })
+	return due, nil
+}
+
+// Release lets the restore paired with a taken scale to 0 come due, once
+// the scale ran or failed
+func (s *Store) Release(scale Schedule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.held, scale.Restore)
+}
+
+// save writes the schedules to the file, creating its directory if needed.
+// The caller must hold mu.
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(s.schedules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode schedules: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create schedules directory: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write schedules: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write schedules: %w", err)
+	}
+	return nil
+}
+
+func knownAction(action Action) bool {
+	for _, known := range Actions {
+		if action == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTime parses when a schedule is due, relative to now in its location:
+// a time of day (19:00, the next one), a weekday and time (fri 19:00, the
+// next one), a date and time (2026-10-16 19:00) or a delay (+90m, +2h).
+func ParseTime(spec string, now time.Time) (time.Time, error) {
+	spec = strings.ToLower(strings.Join(strings.Fields(spec), " "))
+	if spec == "" {
+		return time.Time{}, fmt.Errorf("no time given")
+	}
+
+	if delay, ok := strings.CutPrefix(spec, "+"); ok {
+		d, err := time.ParseDuration(strings.ReplaceAll(delay, " ", ""))
+		if err != nil || d <= 0 {
+			return time.Time{}, fmt.Errorf("invalid delay %q, expected e.g. +90m or +2h", spec)
+		}
+		return now.Add(d).Truncate(time.Second), nil
+	}
+
+	if at, err := time.ParseInLocation("2006-01-02 15:04", spec, now.Location()); err == nil {
+		if !at.After(now) {
+			return time.Time{}, fmt.Errorf("%s is in the past", spec)
+		}
+		return at, nil
+	}
+
+	day, clock, hasDay := strings.Cut(spec, " ")
+	if !hasDay {
+		clock, day = spec, ""
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected 19:00, fri 19:00, 2026-10-16 19:00 or +2h", spec)
+	}
+
+	at := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if day == "" {
+		if !at.After(now) {
+			at = at.AddDate(0, 0, 1)
+		}
+		return at, nil
+	}
+
+	weekday, ok := parseWeekday(day)
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid weekday %q in %q", day, spec)
+	}
+	days := (int(weekday) - int(now.Weekday()) + 7) % 7
+	at = at.AddDate(0, 0, days)
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 7)
+	}
+	return at, nil
+}
+
+// parseWeekday parses a weekday name or its first three letters
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,182 @@
+package schedule
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	// A Thursday afternoon
+	now := time.Date(2026, 10, 15, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"19:00", time.Date(2026, 10, 15, 19, 0, 0, 0, time.UTC)},
+		{"09:00", time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)},
+		{"fri 19:00", time.Date(2026, 10, 16, 19, 0, 0, 0, time.UTC)},
+		{"Friday  19:00", time.Date(2026, 10, 16, 19, 0, 0, 0, time.UTC)},
+		{"thu 14:00", time.Date(2026, 10, 22, 14, 0, 0, 0, time.UTC)},
+		{"2026-10-20 07:30", time.Date(2026, 10, 20, 7, 30, 0, 0, time.UTC)},
+		{"+90m", time.Date(2026, 10, 15, 16, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.spec, now)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", tt.spec, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%q: expected %s, got %s", tt.spec, tt.want, got)
+		}
+	}
+
+	for _, spec := range []string{"", "25:00", "someday 19:00", "+0s", "+soon", "2026-10-01 12:00"} {
+		if _, err := ParseTime(spec, now); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.json")
+	store := New(path)
+	if err := store.Load(); err != nil {
+		t.Fatalf("Expected a missing file to load, got %v", err)
+	}
+
+	now := time.Now()
+	evening, err := store.Add(Schedule{Action: ActionStopInstances, Profile: "prod", Region: "eu-west-1", Targets: []string{"i-1", "i-2"}, At: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	soon, err := store.Add(Schedule{Action: ActionStartInstances, Profile: "prod", Region: "eu-west-1", Targets: []string{"i-3"}, At: now.Add(time.Minute)})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if evening.ID == soon.ID {
+		t.Errorf("Expected distinct IDs, got %d twice", soon.ID)
+	}
+	if evening.Title() != "Stop 2 instances in prod/eu-west-1" || soon.Title() != "Start i-3 in prod/eu-west-1" {
+		t.Errorf("Unexpected titles %q and %q", evening.Title(), soon.Title())
+	}
+	if _, err := store.Add(Schedule{Action: "ec2:TerminateInstances", Targets: []string{"i-1"}, At: now}); err == nil {
+		t.Error("Expected an unknown action to be rejected")
+	}
+
+	// A new session sees both, the soonest first
+	reloaded := New(path)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	list := reloaded.List()
+	if len(list) != 2 || list[0].ID != soon.ID || list[1].ID != evening.ID {
+		t.Fatalf("Expected both schedules, the soonest first, got %+v", list)
+	}
+
+	due, err := reloaded.TakeDue(now.Add(30 * time.Minute))
+	if err != nil || len(due) != 1 || due[0].ID != soon.ID {
+		t.Fatalf("Expected the soon schedule to be due, got %+v (%v)", due, err)
+	}
+	if err := reloaded.Remove(evening.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	final := New(path)
+	if err := final.Load(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if list := final.List(); len(list) != 0 {
+		t.Errorf("Expected no schedules left, got %+v", list)
+	}
+}
+
+func TestScaleToZero(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.json")
+	store := New(path)
+	now := time.Now()
+
+	groups := []string{"web-asg", "worker-asg"}
+	scale, restore, err := store.AddScaleToZero(
+		Schedule{Profile: "prod", Region: "eu-west-1", Targets: groups, At: now.Add(time.Hour)},
+		Schedule{Profile: "prod", Region: "eu-west-1", At: now.Add(60 * time.Hour)},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if scale.Restore != restore.ID || restore.Action != ActionRestoreCapacity || len(restore.Targets) != 2 {
+		t.Errorf("Expected the scale paired with a restore of both groups, got %+v and %+v", scale, restore)
+	}
+	if scale.Title() != "Scale to 0 2 Auto Scaling groups in prod/eu-west-1" {
+		t.Errorf("Unexpected title %q", scale.Title())
+	}
+	if scale.Action.Permission() != "autoscaling:UpdateAutoScalingGroup" || restore.Action.Permission() != "autoscaling:UpdateAutoScalingGroup" {
+		t.Errorf("Expected both to need autoscaling:UpdateAutoScalingGroup, got %s and %s", scale.Action.Permission(), restore.Action.Permission())
+	}
+	if _, _, err := store.AddScaleToZero(Schedule{Targets: groups, At: now.Add(time.Hour)}, Schedule{At: now}); err == nil {
+		t.Error("Expected a restore before the scale to be rejected")
+	}
+
+	// Capacities saved by the scale add up and survive a restart
+	if err := store.SaveCapacities(restore.ID, map[string]Capacity{"web-asg": {Min: 2, Max: 6, Desired: 3}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := store.SaveCapacities(restore.ID, map[string]Capacity{"worker-asg": {Min: 1, Max: 4, Desired: 2}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := store.SaveCapacities(999, nil); err == nil {
+		t.Error("Expected saving into a missing schedule to fail")
+	}
+	reloaded := New(path)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	list := reloaded.List()
+	if len(list) != 2 || list[1].ID != restore.ID {
+		t.Fatalf("Expected the scale and the restore, got %+v", list)
+	}
+	want := map[string]Capacity{"web-asg": {Min: 2, Max: 6, Desired: 3}, "worker-asg": {Min: 1, Max: 4, Desired: 2}}
+	if got := list[1].Capacities; len(got) != 2 || got["web-asg"] != want["web-asg"] || got["worker-asg"] != want["worker-asg"] {
+		t.Errorf("Expected the capacities of both groups, got %+v", got)
+	}
+}
+
+func TestTakeDueHoldsRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.json")
+	store := New(path)
+	now := time.Now()
+
+	scale, restore, err := store.AddScaleToZero(
+		Schedule{Profile: "prod", Region: "eu-west-1", Targets: []string{"web-asg"}, At: now.Add(time.Hour)},
+		Schedule{Profile: "prod", Region: "eu-west-1", At: now.Add(2 * time.Hour)},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Both due in the same tick: the restore waits for the scale to run
+	due, err := store.TakeDue(now.Add(3 * time.Hour))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(due) != 1 || due[0].ID != scale.ID {
+		t.Fatalf("Expected only the scale, got %+v", due)
+	}
+	if err := store.SaveCapacities(restore.ID, map[string]Capacity{"web-asg": {Min: 2, Max: 6, Desired: 3}}); err != nil {
+		t.Fatalf("Expected the capacity saved while the scale runs, got %v", err)
+	}
+	if due, _ := store.TakeDue(now.Add(3 * time.Hour)); len(due) != 0 {
+		t.Fatalf("Expected the restore held until the scale finished, got %+v", due)
+	}
+
+	store.Release(scale)
+	due, err = store.TakeDue(now.Add(3 * time.Hour))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(due) != 1 || due[0].ID != restore.ID || due[0].Capacities["web-asg"].Max != 6 {
+		t.Errorf("Expected the restore with its capacity, got %+v", due)
+	}
+}
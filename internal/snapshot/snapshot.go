@@ -0,0 +1,115 @@
+// Package snapshot saves the resource listings and log entries loaded in the
+// TUI to a file, so they can be browsed later without AWS access.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Version is the format version written to new snapshots
+const Version = 1
+
+// Snapshot is the state of the TUI at one point in time
+type Snapshot struct {
+	Version int       `json:"version"`
+	TakenAt time.Time `json:"taken_at"`
+	// Profile, region and account the TUI was connected to
+	Profile  string    `json:"profile"`
+	Region   string    `json:"region"`
+	Account  string    `json:"account,omitempty"`
+	Listings []Listing `json:"listings"`
+	Logs     []Logs    `json:"logs"`
+}
+
+// Listing is the resource listing of a service in a profile and region
+type Listing struct {
+	Profile   string     `json:"profile"`
+	Region    string     `json:"region"`
+	Service   string     `json:"service"`
+	FetchedAt time.Time  `json:"fetched_at"`
+	Resources []Resource `json:"resources"`
+}
+
+// Resource is a row of a listing
+type Resource struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Type        string                 `json:"type,omitempty"`
+	State       string                 `json:"state,omitempty"`
+	Region      string                 `json:"region,omitempty"`
+	CreatedDate string                 `json:"created_date,omitempty"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Details     map[string]interface{} `json:"details,omitempty"`
+	MonthlyCost float64                `json:"monthly_cost,omitempty"`
+	Alert       bool                   `json:"alert,omitempty"`
+}
+
+// Logs are the entries of a log source, e.g. the CloudWatch log group shown
+type Logs struct {
+	Source string `json:"source"`
+	// LogGroup is the CloudWatch log group of the entries, if any
+	LogGroup string     `json:"log_group,omitempty"`
+	Entries  []LogEntry `json:"entries"`
+}
+
+// LogEntry is a log entry of a source
+type LogEntry struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Level     string                 `json:"level"`
+	Message   string                 `json:"message"`
+	Stream    string                 `json:"stream,omitempty"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+}
+
+// DefaultPath returns the file of a snapshot taken at t,
+// ~/.swiss-army-tui/snapshots/snapshot-2006-01-02T15-04-05.json
+func DefaultPath(t time.Time) string {
+	name := fmt.Sprintf("snapshot-%s.json", t.Format("2006-01-02T15-04-05"))
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(homeDir, ".swiss-army-tui", "snapshots", name)
+}
+
+// Save writes s to path, creating its directory if needed
+func Save(path string, s *Snapshot) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	// Snapshots hold resource details and logs, so only the user may read them
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load reads the snapshot at path
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %s: %w", path, err)
+	}
+	if s.Version < 1 || s.Version > Version {
+		return nil, fmt.Errorf("snapshot %s has unsupported version %d", path, s.Version)
+	}
+	return &s, nil
+}
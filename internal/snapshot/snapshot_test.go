@@ -0,0 +1,62 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshots", "incident.json")
+	takenAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	s := &Snapshot{
+		Version: Version,
+		TakenAt: takenAt,
+		Profile: "prod",
+		Region:  "eu-west-1",
+		Listings: []Listing{{
+			Profile: "prod", Region: "eu-west-1", Service: "ec2", FetchedAt: takenAt,
+			Resources: []Resource{{ID: "i-0abc", Name: "web-1", State: "running", Details: map[string]interface{}{"VpcId": "vpc-1"}}},
+		}},
+		Logs: []Logs{{
+			Source: "cloudwatch", LogGroup: "/aws/lambda/orders",
+			Entries: []LogEntry{{Timestamp: takenAt, Level: "ERROR", Message: "timeout"}},
+		}},
+	}
+	if err := Save(path, s); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected a file only the user can read, got %v, %v", info, err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !loaded.TakenAt.Equal(takenAt) || loaded.Profile != "prod" || len(loaded.Listings) != 1 || len(loaded.Logs) != 1 {
+		t.Fatalf("Unexpected snapshot %+v", loaded)
+	}
+	if res := loaded.Listings[0].Resources[0]; res.Name != "web-1" || res.Details["VpcId"] != "vpc-1" {
+		t.Errorf("Unexpected resource %+v", res)
+	}
+	if logs := loaded.Logs[0]; logs.LogGroup != "/aws/lambda/orders" || logs.Entries[0].Message != "timeout" {
+		t.Errorf("Unexpected logs %+v", logs)
+	}
+}
+
+func TestLoadRejectsOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+
+	path := filepath.Join(dir, "future.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Expected an error for an unsupported version")
+	}
+}
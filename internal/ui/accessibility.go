@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// alertPrefix marks the names of alerting resources in the accessibility mode
+const alertPrefix = "! "
+
+// accessibility is whether the accessibility mode is on and whether colors
+// are dropped, shared by all tabs
+var accessibility = struct {
+	sync.RWMutex
+	on      bool
+	noColor bool
+}{}
+
+// noColorTheme is the theme with NO_COLOR set: the terminal's colors, with
+// contrasting backgrounds left to mark selections and fields, which
+// dropColors shows in reverse video
+var noColorTheme = tview.Theme{
+	PrimitiveBackgroundColor:    tcell.ColorDefault,
+	ContrastBackgroundColor:     tcell.ColorWhite,
+	MoreContrastBackgroundColor: tcell.ColorWhite,
+	BorderColor:                 tcell.ColorDefault,
+	TitleColor:                  tcell.ColorDefault,
+	GraphicsColor:               tcell.ColorDefault,
+	PrimaryTextColor:            tcell.ColorWhite,
+	SecondaryTextColor:          tcell.ColorDefault,
+	TertiaryTextColor:           tcell.ColorDefault,
+	InverseTextColor:            tcell.ColorDefault,
+	ContrastSecondaryTextColor:  tcell.ColorDefault,
+}
+
+// setAccessibility applies the accessibility mode of cfg. NO_COLOR
+// (https://no-color.org) turns it on whatever cfg says.
+func setAccessibility(cfg config.UIConfig) {
+	noColor := os.Getenv("NO_COLOR") != ""
+
+	accessibility.Lock()
+	defer accessibility.Unlock()
+	accessibility.on, accessibility.noColor = cfg.Accessible || noColor, noColor
+}
+
+// accessible reports whether the accessibility mode is on
+func accessible() bool {
+	accessibility.RLock()
+	defer accessibility.RUnlock()
+	return accessibility.on
+}
+
+// colorless reports whether colors are dropped
+func colorless() bool {
+	accessibility.RLock()
+	defer accessibility.RUnlock()
+	return accessibility.noColor
+}
+
+// serviceIcon returns the icon of service, or its ASCII label in the
+// accessibility mode
+func serviceIcon(service ServiceInfo) string {
+	if !accessible() {
+		return service.Icon
+	}
+	return tview.Escape(fmt.Sprintf("%-6s", "["+service.Label+"]"))
+}
+
+// stateWord returns the word put before a message shown in color in the
+// accessibility mode, so that errors, warnings and successes do not differ
+// by color alone
+func stateWord(color string) string {
+	if !accessible() {
+		return ""
+	}
+	switch color {
+	case "red":
+		return i18n.T("common.error_word") + ": "
+	case "yellow", "orange":
+		return i18n.T("common.warning_word") + ": "
+	case "green":
+		return i18n.T("common.ok_word") + ": "
+	}
+	return ""
+}
+
+// dropColors redraws every cell of screen without its colors. Cells with a
+// background, like selections, highlights and fields, turn reverse video.
+func dropColors(screen tcell.Screen) {
+	width, height := screen.Size()
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			mainc, combc, style, _ := screen.GetContent(x, y)
+			screen.SetContent(x, y, mainc, combc, monoStyle(style))
+		}
+	}
+}
+
+// monoStyle returns style without colors, reversed if it has a background
+func monoStyle(style tcell.Style) tcell.Style {
+	_, bg, attrs := style.Decompose()
+	mono := tcell.StyleDefault.Attributes(attrs)
+	if bg != tcell.ColorDefault && bg != tcell.ColorReset {
+		mono = mono.Reverse(attrs&tcell.AttrReverse == 0)
+	}
+	return mono
+}
+
+// afterDraw is the after draw func of the application
+func (app *App) afterDraw(screen tcell.Screen) {
+	if colorless() {
+		dropColors(screen)
+	}
+	app.recordFrame(screen)
+}
@@ -0,0 +1,357 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// resourceAction is an action of the Resources tab, either of all services
+// or of a ServiceView. The resource table runs them by key, the command
+// palette and the context menu list the ones that apply and the help
+// describes them.
+type resourceAction struct {
+	name string
+	key  rune
+	// description is the catalog key of the description shown in the help,
+	// the command palette and the context menu
+	description string
+	// services the action applies to, all if empty; the service of the
+	// view for the actions of views
+	services []string
+	// permissions are the IAM permissions the action needs; the ones the
+	// preflight found missing on the selected resource disable it. Only
+	// actions with a preflight declare them, so far the EC2 dry runs.
+	permissions []string
+	// onResource actions act on the selected resource and are offered in
+	// its context menu
+	onResource bool
+	// online actions call AWS and are unavailable offline
+	online bool
+	// inServiceList actions also run by key in the service list
+	inServiceList bool
+	run           func(rt *ResourcesTab)
+}
+
+// resourceActions are the registered actions of all services, in the order
+// registered
+var resourceActions []resourceAction
+
+// registerResourceActions registers actions of all services. Modules
+// register from init, as the actions refer to handlers that refer back to
+// the registry.
+func registerResourceActions(actions ...resourceAction) {
+	resourceActions = append(resourceActions, actions...)
+}
+
+// allResourceActions returns the registered actions followed by the actions
+// of the service views
+func allResourceActions() []resourceAction {
+	actions := append([]resourceAction(nil), resourceActions...)
+	for _, view := range serviceViews {
+		for _, action := range view.Actions() {
+			action.services = []string{view.Info().Name}
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// appliesTo reports whether the action applies to the resources of service
+func (a resourceAction) appliesTo(service string) bool {
+	if len(a.services) == 0 {
+		return true
+	}
+	for _, s := range a.services {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedResourceActions returns all actions, the ones of all services
+// first, then by service and key
+func sortedResourceActions() []resourceAction {
+	actions := allResourceActions()
+	sort.SliceStable(actions, func(i, j int) bool {
+		a, b := actions[i], actions[j]
+		if (len(a.services) == 0) != (len(b.services) == 0) {
+			return len(a.services) == 0
+		}
+		if as, bs := strings.Join(a.services, ","), strings.Join(b.services, ","); as != bs {
+			return as < bs
+		}
+		return a.key < b.key
+	})
+	return actions
+}
+
+// actionForKey returns the action run by key, preferring one that applies
+// to service. Actions of other services run too and tell where they apply.
+func actionForKey(key rune, service string) (resourceAction, bool) {
+	actions := allResourceActions()
+	var other *resourceAction
+	for i, action := range actions {
+		if action.key != key {
+			continue
+		}
+		if action.appliesTo(service) {
+			return action, true
+		}
+		if other == nil {
+			other = &actions[i]
+		}
+	}
+	if other == nil {
+		return resourceAction{}, false
+	}
+	return *other, true
+}
+
+// actionsFor returns the actions applying to service whose name or
+// description contains text, in any case. onResource limits them to the
+// actions on the selected resource.
+func actionsFor(service, text string, onResource bool) []resourceAction {
+	text = strings.ToLower(strings.TrimSpace(text))
+	var actions []resourceAction
+	for _, action := range sortedResourceActions() {
+		if !action.appliesTo(service) || (onResource && !action.onResource) {
+			continue
+		}
+		if text != "" && !strings.Contains(strings.ToLower(action.name), text) &&
+			!strings.Contains(strings.ToLower(i18n.T(action.description)), text) {
+			continue
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+// resourceActionsHelp lists the keys of the registered actions for the help
+func resourceActionsHelp() string {
+	var text strings.Builder
+	for _, action := range sortedResourceActions() {
+		fmt.Fprintf(&text, "  %-15c - %s\n", action.key, i18n.T(action.description))
+	}
+	return text.String()
+}
+
+// actionUnavailable tells why action cannot run now, "" if it can
+func (rt *ResourcesTab) actionUnavailable(action resourceAction) string {
+	if action.online && rt.isOffline() {
+		return offlineStatus()
+	}
+	if action.onResource && rt.selectedRes == nil {
+		return i18n.T("action.select_first")
+	}
+	return ""
+}
+
+// missingPermission returns the permission the preflight found missing for
+// action on the selected resource, "" if none
+func (rt *ResourcesTab) missingPermission(action resourceAction) string {
+	if !action.onResource || rt.selectedRes == nil {
+		return ""
+	}
+	for _, perm := range action.permissions {
+		if rt.actionDenied(rt.selectedRes.ID, perm) {
+			return perm
+		}
+	}
+	return ""
+}
+
+// runAction runs action unless it is unavailable, which the status tells.
+// Actions missing a permission run and tell so themselves.
+func (rt *ResourcesTab) runAction(action resourceAction) {
+	if reason := rt.actionUnavailable(action); reason != "" {
+		rt.updateStatus(reason, "yellow")
+		return
+	}
+	action.run(rt)
+}
+
+// handleActionKey runs the action of the key of event and reports whether
+// there was one. inServiceList is set for keys pressed in the service list.
+func (rt *ResourcesTab) handleActionKey(event *tcell.EventKey, inServiceList bool) bool {
+	if event.Key() != tcell.KeyRune {
+		return false
+	}
+	action, ok := actionForKey(event.Rune(), rt.selectedService)
+	if !ok || (inServiceList && !action.inServiceList) {
+		return false
+	}
+	rt.runAction(action)
+	return true
+}
+
+// actionItem renders action for the palette and the context menu, grayed
+// out with the reason if it is unavailable or missing a permission
+func (rt *ResourcesTab) actionItem(action resourceAction) string {
+	reason := rt.actionUnavailable(action)
+	if perm := rt.missingPermission(action); reason == "" && perm != "" {
+		reason = i18n.T("action.missing", perm)
+	}
+	description := i18n.T(action.description)
+	if reason != "" {
+		return fmt.Sprintf("[gray]%c  %s (%s)[-]", action.key, tview.Escape(description), tview.Escape(reason))
+	}
+	return fmt.Sprintf("[aqua]%c[-]  %s", action.key, tview.Escape(description))
+}
+
+// actionList lists the actions chosen from and runs the one selected
+type actionList struct {
+	list    *tview.List
+	actions []resourceAction
+}
+
+// fill lists actions
+func (l *actionList) fill(rt *ResourcesTab, actions []resourceAction) {
+	l.actions = actions
+	l.list.Clear()
+	for _, action := range actions {
+		l.list.AddItem(rt.actionItem(action), "", 0, nil)
+	}
+	if len(actions) == 0 {
+		l.list.AddItem("[gray]"+i18n.T("action.none_matching")+"[-]", "", 0, nil)
+	}
+}
+
+// newActionList creates the list of an action overlay closed by closeList
+func (rt *ResourcesTab) newActionList(closeList func()) *actionList {
+	l := &actionList{
+		list: tview.NewList().
+			SetMainTextColor(tcell.ColorWhite).
+			SetSelectedTextColor(tcell.ColorBlack).
+			SetSelectedBackgroundColor(tcell.ColorWhite).
+			ShowSecondaryText(false),
+	}
+	l.list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		if index < len(l.actions) {
+			closeList()
+			rt.runAction(l.actions[index])
+		}
+	})
+	return l
+}
+
+// showActionPalette opens the command palette: the actions applying to the
+// selected service, filtered by the text typed. Enter runs the first match
+// or the one selected below.
+func (rt *ResourcesTab) showActionPalette() {
+	input := tview.NewInputField().SetLabel(": ").SetFieldWidth(0)
+	var focus tview.Primitive = rt.resourceTable
+	if rt.app != nil {
+		focus = rt.app.GetFocus()
+	}
+
+	closePalette := func() {
+		rt.setOverlay(nil)
+		rt.view.RemovePage("actions")
+		if rt.app != nil {
+			rt.app.SetFocus(focus)
+		}
+	}
+	l := rt.newActionList(closePalette)
+	l.fill(rt, actionsFor(rt.selectedService, "", false))
+
+	input.SetChangedFunc(func(text string) {
+		l.fill(rt, actionsFor(rt.selectedService, text, false))
+	})
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyDown:
+			if len(l.actions) > 0 && rt.app != nil {
+				rt.app.SetFocus(l.list)
+			}
+			return nil
+		case tcell.KeyEnter:
+			if len(l.actions) > 0 {
+				closePalette()
+				rt.runAction(l.actions[0])
+			}
+			return nil
+		}
+		return event
+	})
+	l.list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyUp && l.list.GetCurrentItem() == 0 && rt.app != nil {
+			rt.app.SetFocus(input)
+			return nil
+		}
+		return event
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(l.list, 0, 1, false)
+	layout.SetBorder(true).
+		SetTitle(panelTitle("action.palette", rt.selectedService)).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.setOverlay(closePalette)
+	rt.view.AddPage("actions", centered(layout, 80, 16), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(input)
+	}
+}
+
+// showActionMenu opens the context menu of the selected resource: the
+// actions on it, with the ones it cannot take grayed out
+func (rt *ResourcesTab) showActionMenu() {
+	if rt.selectedRes == nil {
+		rt.updateStatus(i18n.T("action.select_first"), "yellow")
+		return
+	}
+	actions := actionsFor(rt.selectedService, "", true)
+	if len(actions) == 0 {
+		rt.updateStatus(i18n.T("action.none_on", rt.selectedService), "yellow")
+		return
+	}
+
+	closeMenu := func() {
+		rt.setOverlay(nil)
+		rt.view.RemovePage("actions")
+		if rt.app != nil {
+			rt.app.SetFocus(rt.resourceTable)
+		}
+	}
+	l := rt.newActionList(closeMenu)
+	l.fill(rt, actions)
+	l.list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' {
+			closeMenu()
+			return nil
+		}
+		return event
+	})
+	l.list.SetBorder(true).
+		SetTitle(panelTitle("action.menu", tview.Escape(rt.selectedRes.Name))).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.setOverlay(closeMenu)
+	rt.view.AddPage("actions", centered(l.list, 80, len(actions)+2), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(l.list)
+	}
+}
+
+// SetOverlayFunc sets how the tab tells the app that an overlay is open,
+// with the func closing it on Esc, or that it closed, with nil
+func (rt *ResourcesTab) SetOverlayFunc(overlay func(closeOverlay func())) {
+	rt.overlay = overlay
+}
+
+// setOverlay tells the app that an overlay closed by closeOverlay is open, or
+// that none is with nil
+func (rt *ResourcesTab) setOverlay(closeOverlay func()) {
+	if rt.overlay != nil {
+		rt.overlay(closeOverlay)
+	}
+}
@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// ansiColors are the tview names of the 16 standard terminal colors, the
+// normal ones first and then the bright ones
+var ansiColors = [16]string{
+	"black", "maroon", "green", "olive", "navy", "purple", "teal", "silver",
+	"gray", "red", "lime", "yellow", "blue", "fuchsia", "aqua", "white",
+}
+
+// ansiFlags are the tview flags of the attributes of an ansiStyle, by bit
+const ansiFlags = "bdiulrs"
+
+const (
+	ansiBold = 1 << iota
+	ansiDim
+	ansiItalic
+	ansiUnderline
+	ansiBlink
+	ansiReverse
+	ansiStrike
+)
+
+// ansiStyle is the text style set by SGR escape sequences, e.g. \x1b[1;31m;
+// empty colors are the defaults
+type ansiStyle struct {
+	fg, bg string
+	attrs  int
+}
+
+// tag returns the tview style tag switching to s
+func (s ansiStyle) tag() string {
+	fg, bg := s.fg, s.bg
+	if fg == "" {
+		fg = "-"
+	}
+	if bg == "" {
+		bg = "-"
+	}
+	flags := make([]byte, len(ansiFlags))
+	for i := range ansiFlags {
+		flags[i] = ansiFlags[i]
+		if s.attrs&(1<<i) == 0 {
+			flags[i] -= 'a' - 'A'
+		}
+	}
+	return fmt.Sprintf("[%s:%s:%s]", fg, bg, flags)
+}
+
+// apply changes s by the parameters of an SGR sequence
+func (s *ansiStyle) apply(params []int) {
+	if len(params) == 0 {
+		params = []int{0}
+	}
+	for i := 0; i < len(params); i++ {
+		switch p := params[i]; {
+		case p == 0:
+			*s = ansiStyle{}
+		case p == 1:
+			s.attrs |= ansiBold
+		case p == 2:
+			s.attrs |= ansiDim
+		case p == 3:
+			s.attrs |= ansiItalic
+		case p == 4:
+			s.attrs |= ansiUnderline
+		case p == 5 || p == 6:
+			s.attrs |= ansiBlink
+		case p == 7:
+			s.attrs |= ansiReverse
+		case p == 9:
+			s.attrs |= ansiStrike
+		case p == 22:
+			s.attrs &^= ansiBold | ansiDim
+		case p == 23:
+			s.attrs &^= ansiItalic
+		case p == 24:
+			s.attrs &^= ansiUnderline
+		case p == 25:
+			s.attrs &^= ansiBlink
+		case p == 27:
+			s.attrs &^= ansiReverse
+		case p == 29:
+			s.attrs &^= ansiStrike
+		case p >= 30 && p <= 37:
+			s.fg = ansiColors[p-30]
+		case p >= 90 && p <= 97:
+			s.fg = ansiColors[p-90+8]
+		case p >= 40 && p <= 47:
+			s.bg = ansiColors[p-40]
+		case p >= 100 && p <= 107:
+			s.bg = ansiColors[p-100+8]
+		case p == 39:
+			s.fg = ""
+		case p == 49:
+			s.bg = ""
+		case p == 38 || p == 48:
+			color, used := extendedColor(params[i+1:])
+			i += used
+			if color == "" {
+				continue
+			}
+			if p == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+}
+
+// extendedColor reads the color of a 38 or 48 parameter from the parameters
+// following it, 5;n for the 256 color palette or 2;r;g;b, and returns how
+// many it used
+func extendedColor(params []int) (string, int) {
+	switch {
+	case len(params) >= 2 && params[0] == 5:
+		return paletteColor(params[1]), 2
+	case len(params) >= 4 && params[0] == 2:
+		return fmt.Sprintf("#%02x%02x%02x", params[1]&0xff, params[2]&0xff, params[3]&0xff), 4
+	default:
+		// Unknown color spaces leave nothing to read after them
+		return "", len(params)
+	}
+}
+
+// paletteColor returns color n of the 256 color palette: the 16 standard
+// colors, a 6x6x6 color cube and 24 shades of gray
+func paletteColor(n int) string {
+	switch {
+	case n < 0 || n > 255:
+		return ""
+	case n < 16:
+		return ansiColors[n]
+	case n < 232:
+		n -= 16
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + 40*v
+		}
+		return fmt.Sprintf("#%02x%02x%02x", level(n/36), level(n/6%6), level(n%6))
+	default:
+		gray := 8 + 10*(n-232)
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
+
+// nextEscape returns the length of the escape sequence s starts with and,
+// for an SGR sequence, its parameters and true
+func nextEscape(s string) (int, []int, bool) {
+	if len(s) < 2 {
+		return len(s), nil, false
+	}
+	switch s[1] {
+	case '[':
+		// CSI: parameter and intermediate bytes up to a final byte
+		end := 2
+		for end < len(s) && (s[end] < 0x40 || s[end] > 0x7e) {
+			end++
+		}
+		if end == len(s) {
+			return len(s), nil, false
+		}
+		if s[end] != 'm' {
+			return end + 1, nil, false
+		}
+		var params []int
+		if end > 2 {
+			for _, field := range strings.FieldsFunc(s[2:end], func(r rune) bool { return r == ';' || r == ':' }) {
+				value, _ := strconv.Atoi(field)
+				params = append(params, value)
+			}
+		}
+		return end + 1, params, true
+	case ']':
+		// OSC, e.g. a hyperlink or window title, up to BEL or ST
+		end := len(s)
+		if bel := strings.IndexByte(s, '\a'); bel >= 0 {
+			end = bel + 1
+		}
+		if st := strings.Index(s, "\x1b\\"); st >= 0 && st+2 < end {
+			end = st + 2
+		}
+		return end, nil, false
+	case '(', ')':
+		// Character set selection
+		return min(3, len(s)), nil, false
+	default:
+		return 2, nil, false
+	}
+}
+
+// ansiToTview translates the colors and attributes of the SGR escape
+// sequences in text to tview style tags, escaping the rest and highlighting
+// terms in it. Other escape sequences, such as cursor movement, are dropped.
+func ansiToTview(text string, terms []string) string {
+	var result strings.Builder
+	var style ansiStyle
+	for text != "" {
+		esc := strings.IndexByte(text, '\x1b')
+		if esc < 0 {
+			result.WriteString(markTerms(tview.Escape(text), terms, style))
+			break
+		}
+		if esc > 0 {
+			result.WriteString(markTerms(tview.Escape(text[:esc]), terms, style))
+		}
+		n, params, sgr := nextEscape(text[esc:])
+		text = text[esc+n:]
+		if sgr {
+			style.apply(params)
+			result.WriteString(style.tag())
+		}
+	}
+	if style != (ansiStyle{}) {
+		result.WriteString("[-:-:-]")
+	}
+	return result.String()
+}
+
+// ansiState returns the SGR sequences restoring the style text leaves off
+// with, or "" if that is the default
+func ansiState(text string) string {
+	var style ansiStyle
+	var sequences strings.Builder
+	for {
+		esc := strings.IndexByte(text, '\x1b')
+		if esc < 0 {
+			break
+		}
+		n, params, sgr := nextEscape(text[esc:])
+		if sgr {
+			style.apply(params)
+			if style == (ansiStyle{}) {
+				sequences.Reset()
+			} else {
+				sequences.WriteString(text[esc : esc+n])
+			}
+		}
+		text = text[esc+n:]
+	}
+	return sequences.String()
+}
+
+// stripANSI removes the escape sequences from text
+func stripANSI(text string) string {
+	if !strings.Contains(text, "\x1b") {
+		return text
+	}
+	var result strings.Builder
+	for text != "" {
+		esc := strings.IndexByte(text, '\x1b')
+		if esc < 0 {
+			result.WriteString(text)
+			break
+		}
+		result.WriteString(text[:esc])
+		n, _, _ := nextEscape(text[esc:])
+		text = text[esc+n:]
+	}
+	return result.String()
+}
@@ -3,11 +3,24 @@ package ui
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode"
 
+	"swiss-army-tui/internal/alerts"
+	"swiss-army-tui/internal/audit"
 	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/bookmarks"
 	"swiss-army-tui/internal/config"
+	"swiss-army-tui/internal/history"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/recording"
+	"swiss-army-tui/internal/schedule"
+	"swiss-army-tui/internal/snapshot"
+	"swiss-army-tui/internal/workpool"
 	"swiss-army-tui/pkg/logger"
 
 	"github.com/gdamore/tcell/v2"
@@ -18,64 +31,120 @@ import (
 // App represents the main TUI application
 type App struct {
 	// Core components
-	app    *tview.Application
-	config *config.Config
+	app *tview.Application
+	// Swapped by applyConfig; background goroutines read it with currentConfig
+	config atomic.Pointer[config.Config]
 
 	// AWS components
-	profileManager *aws.ProfileManager
+	profileManager ProfileStore
+	clients        ClientFactory
 	awsClient      *aws.Client
+	// Set in demo mode, where the client is fixed and profile changes are ignored
+	demo bool
+	// Snapshot browsed in offline mode and its file, nil otherwise. The
+	// client is fixed as in demo mode and makes no AWS calls.
+	offline     *snapshot.Snapshot
+	offlinePath string
+	// Where the tabs record mutating actions, no log in demo and offline mode
+	audit *auditTrail
+	// Version of the build running, "" to skip the update check, and the
+	// newer version released, shown in the footer
+	version    string
+	newVersion string
+
+	// Recording of the session running, nil while none is
+	recorder *recording.Recorder
+	// Size of the screen last drawn, and of the frames last recorded
+	screenWidth, screenHeight     int
+	recordedWidth, recordedHeight int
 
 	// UI components
+	root         tview.Primitive
 	pages        *tview.Pages
 	tabs         *tview.TextView
+	footer       *tview.TextView
 	profileTab   *ProfileTab
 	resourcesTab *ResourcesTab
 	logsTab      *LogsTab
 	settingsTab  *SettingsTab
+	athenaTab    *AthenaTab
+	// Open search overlay, nil while closed
+	search *resourceSearch
+	// Closes the open overlay, such as the search or the bookmarks, nil while
+	// none is open
+	closeOverlay func()
+	bookmarks    *bookmarks.Store
 
 	// State management
+	clock      Clock
 	currentTab int
 	tabNames   []string
+	keys       *KeyBindings
 	mu         sync.RWMutex
 	ctx        context.Context
 	cancel     context.CancelFunc
+	quitOnce   sync.Once
+
+	// Most recent notice, e.g. an AWS throttling event, shown in the footer for a while
+	notice      string
+	noticeColor string
+	noticeAt    time.Time
+
+	// AWS calls of the worker pool running and queued, shown in the footer
+	// while any are; poolPending is set while an update is queued
+	poolStats   workpool.Stats
+	poolPending atomic.Bool
+
+	// Watch rule monitor, restarted when the client or the alert settings change
+	alertsConfig config.AlertsConfig
+	alertsCancel context.CancelFunc
+
+	// AWS Health poller of the current client
+	healthCancel context.CancelFunc
+	// Cancels the goroutines waiting on the current client
+	clientCancel context.CancelFunc
 
 	// Event handling
-	eventChan chan Event
-	stopChan  chan struct{}
+	events          *EventBus
+	stopChan        chan struct{}
+	refreshInterval chan time.Duration
 }
 
-// Event represents application events
-type Event struct {
-	Type string
-	Data interface{}
-}
+// noticeDuration is how long a notice stays in the footer
+const noticeDuration = 15 * time.Second
 
-const (
-	EventProfileChanged = "profile_changed"
-	EventRegionChanged  = "region_changed"
-	EventRefresh        = "refresh"
-	EventError          = "error"
-	EventShowLambdaLogs = "show_lambda_logs"
-)
+// healthPollInterval is how often AWS Health is checked for new service issues
+var healthPollInterval = 5 * time.Minute
 
-// NewApp creates a new TUI application
-func NewApp(cfg *config.Config) (*App, error) {
+// NewApp creates a new TUI application. By default it reads the profiles
+// from the AWS config files and connects with the AWS SDK; opts replace
+// these, e.g. with fakes in tests.
+func NewApp(cfg *config.Config, opts ...Option) (*App, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	app := &App{
 		app:        tview.NewApplication(),
-		config:     cfg,
-		tabNames:   []string{"Profiles", "Resources", "Logs", "Settings"},
+		tabNames:   []string{"Profiles", "Resources", "Logs", "Settings", "Athena"},
 		currentTab: 0,
 		ctx:        ctx,
 		cancel:     cancel,
-		eventChan:  make(chan Event, 100),
+		events:     NewEventBus(),
 		stopChan:   make(chan struct{}),
+
+		refreshInterval: make(chan time.Duration, 1),
+		audit:           &auditTrail{},
+
+		profileManager: aws.NewProfileManager(cfg.AWS.ConfigPath, cfg.AWS.CredentialsPath),
+		clients:        ClientFactoryFunc(aws.NewClient),
+		clock:          systemClock{},
+	}
+	app.config.Store(cfg)
+	app.audit.log.Store(audit.Default())
+	for _, opt := range opts {
+		opt(app)
 	}
 
 	// Initialize profile manager
-	app.profileManager = aws.NewProfileManager(cfg.AWS.ConfigPath, cfg.AWS.CredentialsPath)
 	if err := app.profileManager.LoadProfiles(); err != nil {
 		logger.Warn("Failed to load AWS profiles", zap.Error(err))
 	}
@@ -86,11 +155,42 @@ func NewApp(cfg *config.Config) (*App, error) {
 		return nil, fmt.Errorf("failed to initialize UI: %w", err)
 	}
 
+	// Apply theme, key bindings and tab settings from the configuration
+	app.applyConfig(cfg)
+
 	// Setup key bindings
 	app.setupKeyBindings()
 
-	// Start event handler
-	go app.eventHandler()
+	aws.OnThrottle(func(service, operation string) {
+		// Requests may be made from the UI goroutine, so never block here
+		go app.app.QueueUpdateDraw(func() {
+			app.showThrottle(service, operation)
+		})
+	})
+
+	workpool.OnChange(func(workpool.Stats) {
+		// Calls change the stats often; one update at a time is drawn
+		if app.poolPending.Swap(true) {
+			return
+		}
+		go app.app.QueueUpdateDraw(func() {
+			app.poolPending.Store(false)
+			app.poolStats = workpool.Current()
+			app.updateFooter()
+		})
+	})
+
+	// Handle application events one at a time, in the order they were published
+	router := app.eventRouter()
+	app.events.Subscribe(router.Handle, router.Types()...)
+	go app.autoRefresh()
+	go app.resourcesTab.RunSchedules(app.ctx, app.clock)
+
+	if err := config.Watch(func(newCfg *config.Config) {
+		app.events.Publish(ConfigChangedEvent{Config: newCfg})
+	}); err != nil {
+		logger.Warn("Config file watching disabled", zap.Error(err))
+	}
 
 	logger.Info("TUI application initialized successfully")
 	return app, nil
@@ -104,12 +204,12 @@ func (app *App) initializeUI() error {
 	app.pages = tview.NewPages()
 
 	// Initialize tabs
-	app.profileTab, err = NewProfileTab(app.app, app.profileManager, app.eventChan)
+	app.profileTab, err = NewProfileTab(app.app, app.profileManager, app.clients, app.clock, app.events)
 	if err != nil {
 		return fmt.Errorf("failed to create profile tab: %w", err)
 	}
 
-	app.resourcesTab, err = NewResourcesTab(app.app, app.eventChan)
+	app.resourcesTab, err = NewResourcesTab(app.app, app.events)
 	if err != nil {
 		return fmt.Errorf("failed to create resources tab: %w", err)
 	}
@@ -118,12 +218,41 @@ func (app *App) initializeUI() error {
 	if err != nil {
 		return fmt.Errorf("failed to create logs tab: %w", err)
 	}
+	app.logsTab.SetJobs(app.resourcesTab.jobs)
+	app.resourcesTab.audit = app.audit
+	app.logsTab.audit = app.audit
 
-	app.settingsTab, err = NewSettingsTab(app.config)
+	app.settingsTab, err = NewSettingsTab(app.app, app.currentConfig())
 	if err != nil {
 		return fmt.Errorf("failed to create settings tab: %w", err)
 	}
 
+	app.athenaTab, err = NewAthenaTab(app.app)
+	if err != nil {
+		return fmt.Errorf("failed to create Athena tab: %w", err)
+	}
+	app.athenaTab.audit = app.audit
+
+	// Filters typed in earlier sessions can be recalled in the filter inputs
+	inputs := history.New(history.DefaultPath(), history.DefaultLimit)
+	if err := inputs.Load(); err != nil {
+		logger.Warn("Failed to load input history", zap.Error(err))
+	}
+	app.resourcesTab.SetHistory(inputs)
+	app.logsTab.SetHistory(inputs)
+
+	app.bookmarks = bookmarks.New(bookmarks.DefaultPath())
+	if err := app.bookmarks.Load(); err != nil {
+		logger.Warn("Failed to load bookmarks", zap.Error(err))
+	}
+
+	schedules := schedule.New(schedule.DefaultPath())
+	if err := schedules.Load(); err != nil {
+		logger.Warn("Failed to load schedules", zap.Error(err))
+	}
+	app.resourcesTab.SetSchedules(schedules, app.scheduleClient)
+	app.resourcesTab.SetOverlayFunc(func(closeOverlay func()) { app.closeOverlay = closeOverlay })
+
 	// Create tab navigation
 	app.createTabNavigation()
 
@@ -135,6 +264,7 @@ func (app *App) initializeUI() error {
 	app.pages.AddPage("resources", app.resourcesTab.GetView(), true, false)
 	app.pages.AddPage("logs", app.logsTab.GetView(), true, false)
 	app.pages.AddPage("settings", app.settingsTab.GetView(), true, false)
+	app.pages.AddPage("athena", app.athenaTab.GetView(), true, false)
 
 	// Set initial tab
 	app.switchTab(0)
@@ -159,6 +289,7 @@ func (app *App) createTabNavigation() {
 func (app *App) updateTabDisplay() {
 	tabText := ""
 	for i, name := range app.tabNames {
+		name = i18n.T("tab." + strings.ToLower(name))
 		if i == app.currentTab {
 			tabText += fmt.Sprintf(`[black:white:b]  %s  [""]`, name)
 		} else {
@@ -182,7 +313,7 @@ func (app *App) createMainLayout() {
 		AddItem(app.pages, 0, 1, true)
 
 	// Create footer with shortcuts
-	footer := app.createFooter()
+	app.footer = app.createFooter()
 
 	// Main layout with margin
 	main := tview.NewFlex().SetDirection(tview.FlexRow).
@@ -192,12 +323,14 @@ func (app *App) createMainLayout() {
 			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
 							// AddItem(header, 3, 0, false).
 							AddItem(content, 0, 1, true).
-							AddItem(footer, 3, 0, false), 0, 1, true).
+							AddItem(app.footer, 3, 0, false), 0, 1, true).
 			AddItem(nil, 2, 0, false), // Right margin
 						0, 1, true).
 		AddItem(nil, 1, 0, false) // Bottom margin
 
+	app.root = main
 	app.app.SetRoot(main, true)
+	app.app.SetAfterDrawFunc(app.afterDraw)
 }
 
 // createHeader creates the application header
@@ -223,38 +356,124 @@ func (app *App) createFooter() *tview.TextView {
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter)
 
-	footerText := fmt.Sprintf(`[yellow:black]Tab[-:-:-]: Switch tabs | [yellow:black]Ctrl+R[-:-:-]: Refresh | [yellow:black]Ctrl+C[-:-:-]: Quit | [yellow:black]?[-:-:-]: Help | [yellow:black]v%s[-:-:-]`, app.config.App.Version)
-
-	footer.SetText(footerText).SetBorder(true)
+	footer.SetBorder(true)
 	return footer
 }
 
+// updateFooter renders the footer shortcuts from the active key bindings
+func (app *App) updateFooter() {
+	if app.footer == nil || app.keys == nil {
+		return
+	}
+
+	footerText := ""
+	if app.notice != "" && app.clock.Now().Sub(app.noticeAt) < noticeDuration {
+		footerText = fmt.Sprintf("[%s]%s%s[-] | ", app.noticeColor, stateWord(app.noticeColor), app.notice)
+	} else if app.offline != nil {
+		footerText = app.offlineFooter() + " | "
+	}
+	if app.recorder != nil {
+		footerText += "[red]" + i18n.T("footer.recording") + "[-] | "
+	}
+	if app.newVersion != "" {
+		footerText += "[green]" + i18n.T("footer.update", app.newVersion) + "[-] | "
+	}
+	if app.poolStats.Busy() {
+		footerText += "[aqua]" + i18n.T("footer.aws_calls", app.poolStats.Running, app.poolStats.Queued) + "[-] | "
+	}
+
+	for _, shortcut := range []struct{ action, message string }{
+		{ActionNextTab, "footer.switch_tabs"},
+		{ActionRefresh, "footer.refresh"},
+		{ActionSearch, "footer.search"},
+		{ActionBookmarks, "footer.bookmarks"},
+		{ActionSnapshot, "footer.snapshot"},
+		{ActionQuit, "footer.quit"},
+		{ActionHelp, "footer.help"},
+	} {
+		footerText += fmt.Sprintf("[yellow:black]%s[-:-:-]: %s | ", app.keys.Label(shortcut.action), i18n.T(shortcut.message))
+	}
+	footerText += fmt.Sprintf("[yellow:black]v%s[-:-:-]", app.currentConfig().App.Version)
+
+	app.footer.SetText(footerText)
+}
+
+// showThrottle shows an AWS throttling event in the footer
+func (app *App) showThrottle(service, operation string) {
+	logger.Warn("AWS request throttled, retrying with backoff",
+		zap.String("service", service),
+		zap.String("operation", operation))
+
+	app.showNotice(i18n.T("app.throttled", service, operation), "red")
+}
+
+// showNotice shows message in the footer for noticeDuration
+func (app *App) showNotice(message, color string) {
+	app.notice = message
+	app.noticeColor = color
+	app.noticeAt = app.clock.Now()
+	app.updateFooter()
+
+	time.AfterFunc(noticeDuration, func() {
+		app.app.QueueUpdateDraw(app.updateFooter)
+	})
+}
+
 // setupKeyBindings sets up global key bindings
 func (app *App) setupKeyBindings() {
 	app.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case tcell.KeyTab:
+		app.recordKey(event)
+		if app.keys.Matches(ActionRecord, event) {
+			app.toggleRecording()
+			return nil
+		}
+
+		// An overlay takes all keys but quitting; Esc closes it
+		if app.closeOverlay != nil {
+			switch {
+			case event.Key() == tcell.KeyEscape:
+				app.closeOverlay()
+				return nil
+			case app.keys.Matches(ActionQuit, event):
+				app.Quit()
+				return nil
+			}
+			return event
+		}
+
+		switch {
+		case app.keys.Matches(ActionNextTab, event):
 			app.nextTab()
 			return nil
-		case tcell.KeyBacktab:
+		case app.keys.Matches(ActionPrevTab, event):
 			app.prevTab()
 			return nil
-		case tcell.KeyCtrlR:
+		case app.keys.Matches(ActionRefresh, event):
 			app.refresh()
 			return nil
-		case tcell.KeyCtrlC:
-			app.Quit()
-			return nil
-		case tcell.KeyEscape:
+		case app.keys.Matches(ActionQuit, event):
 			app.Quit()
 			return nil
-		case tcell.KeyF1:
+		case app.keys.Matches(ActionHelp, event):
 			app.showHelp()
 			return nil
+		case app.keys.Matches(ActionSearch, event):
+			app.showSearch()
+			return nil
+		case app.keys.Matches(ActionBookmarks, event):
+			app.showBookmarks()
+			return nil
+		case app.keys.Matches(ActionSnapshot, event):
+			app.takeSnapshot()
+			return nil
 		}
 
-		// Handle number keys for direct tab switching
-		if event.Rune() >= '1' && event.Rune() <= '4' {
+		// Handle number keys for direct tab switching, unless typing into an input field
+		switch app.app.GetFocus().(type) {
+		case *tview.InputField, *tview.TextArea:
+			return event
+		}
+		if event.Rune() >= '1' && event.Rune() <= '9' {
 			tabIndex := int(event.Rune() - '1')
 			if tabIndex < len(app.tabNames) {
 				app.switchTab(tabIndex)
@@ -284,12 +503,16 @@ func (app *App) switchTab(index int) {
 	case 1: // Resources
 		app.pages.SwitchToPage("resources")
 		app.app.SetFocus(app.resourcesTab.GetView())
+		app.resourcesTab.Prefetch()
 	case 2: // Logs
 		app.pages.SwitchToPage("logs")
 		app.app.SetFocus(app.logsTab.GetView())
 	case 3: // Settings
 		app.pages.SwitchToPage("settings")
 		app.app.SetFocus(app.settingsTab.GetView())
+	case 4: // Athena
+		app.pages.SwitchToPage("athena")
+		app.app.SetFocus(app.athenaTab.GetView())
 	}
 
 	app.updateTabDisplay()
@@ -319,35 +542,37 @@ func (app *App) prevTab() {
 
 // refresh refreshes the current tab
 func (app *App) refresh() {
-	app.eventChan <- Event{Type: EventRefresh, Data: nil}
+	app.events.Publish(RefreshEvent{})
 }
 
 // showHelp shows the help dialog
 func (app *App) showHelp() {
-	helpText := `Swiss Army TUI - Help
-
-Global Shortcuts:
-  Tab / Shift+Tab  - Switch between tabs
-  1, 2, 3, 4       - Jump to specific tab
-  Ctrl+R          - Refresh current tab
-  Ctrl+C          - Quit application
-  F1 / ?          - Show this help
-
-Profile Tab:
-  Enter           - Select AWS profile
-  r               - Refresh profiles
-  Space           - Test connection
-
-Resources Tab:
-  Enter           - View resource details
-  r               - Refresh resources
-  f               - Filter resources
+	var global strings.Builder
+	fmt.Fprintf(&global, "%s\n\n%s\n", i18n.T("help.title"), i18n.T("help.global"))
+	for _, shortcut := range []struct{ keys, message string }{
+		{app.keys.Label(ActionNextTab) + " / " + app.keys.Label(ActionPrevTab), "help.switch_tabs"},
+		{"1, 2, 3, 4, 5", "help.jump_tab"},
+		{app.keys.Label(ActionRefresh), "help.refresh"},
+		{app.keys.Label(ActionSearch), "help.search"},
+		{app.keys.Label(ActionBookmarks), "help.bookmarks"},
+		{app.keys.Label(ActionSnapshot), "help.snapshot"},
+		{app.keys.Label(ActionRecord), "help.record"},
+		{app.keys.Label(ActionQuit), "help.quit"},
+		{app.keys.Label(ActionHelp), "help.help"},
+	} {
+		fmt.Fprintf(&global, "  %-16s - %s\n", shortcut.keys, i18n.T(shortcut.message))
+	}
+	helpText := global.String()
 
-Press any key to close this help.`
+	helpText += "\n" + i18n.T("help.profiles") + "\n\n" +
+		i18n.T("help.resources") + "\n" + resourceActionsHelp() + "\n" +
+		i18n.T("help.logs") + "\n\n" +
+		i18n.T("help.athena") + "\n\n" +
+		i18n.T("help.close")
 
 	modal := tview.NewModal().
 		SetText(helpText).
-		AddButtons([]string{"Close"}).
+		AddButtons([]string{i18n.T("help.close_button")}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 			app.pages.RemovePage("help")
 		})
@@ -355,12 +580,193 @@ Press any key to close this help.`
 	app.pages.AddPage("help", modal, false, true)
 }
 
-// eventHandler handles application events
-func (app *App) eventHandler() {
+// panelTitle returns the message of key, formatted with args, as the title
+// of a bordered panel
+func panelTitle(key string, args ...interface{}) string {
+	return " " + i18n.T(key, args...) + " "
+}
+
+// infoLabel returns the message of key as the label of a field of an info
+// panel
+func infoLabel(key string) string {
+	return "[yellow]" + i18n.T(key) + ":[-]"
+}
+
+// columnKey returns the catalog key of the table column name, e.g.
+// column.cost_mo for "Cost/mo"
+func columnKey(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return "column." + strings.Join(words, "_")
+}
+
+// columnTitle returns the header of the table column name in the current
+// locale
+func columnTitle(name string) string {
+	return i18n.T(columnKey(name))
+}
+
+// centered places p in the middle of the screen with the given size
+func centered(p tview.Primitive, width, height int) tview.Primitive {
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 1, true).
+			AddItem(nil, 0, 1, false), width, 1, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// eventRouter routes the application events to their handlers
+func (app *App) eventRouter() *EventRouter {
+	var r EventRouter
+	Route(&r, func(event ProfileChangedEvent) {
+		target := i18n.T("app.switch_profile", event.Profile, event.Region)
+		app.app.QueueUpdateDraw(func() {
+			app.confirmSwitch(target, func() {
+				go app.handleProfileChange(event)
+			}, app.keepClient)
+		})
+	})
+	Route(&r, func(event RegionChangedEvent) {
+		app.app.QueueUpdateDraw(func() {
+			app.confirmSwitch(i18n.T("app.switch_region", event.Region), func() {
+				go app.handleRegionChange(event.Region)
+			}, app.keepClient)
+		})
+	})
+	Route(&r, func(RefreshEvent) {
+		app.handleRefresh()
+	})
+	Route(&r, func(event ErrorEvent) {
+		app.showError(event.Err)
+	})
+	Route(&r, func(event ConfigChangedEvent) {
+		app.app.QueueUpdateDraw(func() {
+			app.handleConfigChange(event.Config)
+		})
+	})
+	Route(&r, func(event ShowLambdaLogsEvent) {
+		app.switchTab(2)
+		if app.logsTab != nil {
+			app.logsTab.ShowLambdaLogGroup(event.Function, event.LogGroup)
+		}
+	})
+	Route(&r, func(event ShowPodLogsEvent) {
+		app.app.QueueUpdateDraw(func() {
+			app.switchTab(2)
+			if app.logsTab != nil {
+				app.logsTab.ShowPodLogs(event.Cluster, event.Pod)
+			}
+		})
+	})
+	Route(&r, func(event ShowLogStreamEvent) {
+		app.app.QueueUpdateDraw(func() {
+			app.switchTab(2)
+			if app.logsTab != nil {
+				app.logsTab.ShowLogStream(event.LogGroup, event.Stream)
+			}
+		})
+	})
+	Route(&r, func(toast Toast) {
+		app.app.QueueUpdateDraw(func() {
+			app.showNotice(toast.Message, toast.Color)
+		})
+	})
+	return &r
+}
+
+// applyConfig applies UI settings from cfg to the running application and all tabs
+func (app *App) applyConfig(cfg *config.Config) {
+	// Readers on other goroutines keep the config they loaded, so it is
+	// swapped rather than overwritten
+	app.config.Store(cfg)
+	config.Set(cfg)
+
+	keys, errs := NewKeyBindings(cfg.UI.KeyBindings)
+	for _, err := range errs {
+		logger.Warn("Ignoring key binding", zap.Error(err))
+	}
+	app.keys = keys
+
+	aws.SetRateLimit(cfg.AWS.RateLimit)
+	aws.SetRequestOptions(aws.RequestOptionsFromConfig(cfg.AWS))
+	workpool.SetLimits(cfg.AWS.Concurrency, cfg.AWS.ServiceConcurrency)
+	app.app.EnableMouse(cfg.UI.MouseEnabled)
+	setAccessibility(cfg.UI)
+	applyTheme(cfg.UI.Theme, app.root)
+	setTimeDisplay(cfg.UI)
+	if err := i18n.SetLocale(cfg.UI.Locale); err != nil {
+		logger.Warn("Ignoring locale", zap.Error(err))
+	}
+	app.updateFooter()
+	app.updateTabDisplay()
+	app.profileTab.applyLocale()
+	app.resourcesTab.applyLocale()
+	app.logsTab.applyLocale()
+	app.settingsTab.applyLocale()
+	app.athenaTab.applyLocale()
+
+	app.profileTab.ApplyConfig(cfg)
+	app.resourcesTab.ApplyConfig(cfg)
+	app.logsTab.ApplyConfig(cfg)
+	app.settingsTab.ApplyConfig(cfg)
+
+	// Replace a pending interval that autoRefresh has not picked up yet
+	select {
+	case <-app.refreshInterval:
+	default:
+	}
+	app.refreshInterval <- time.Duration(cfg.UI.RefreshInterval) * time.Second
+
+	if !reflect.DeepEqual(app.alertsConfig, cfg.Alerts) {
+		app.restartAlertMonitor()
+	}
+
+	logger.Debug("Applied configuration",
+		zap.String("theme", cfg.UI.Theme),
+		zap.Int("refresh_interval", cfg.UI.RefreshInterval))
+}
+
+// currentConfig returns the configuration applied last
+func (app *App) currentConfig() *config.Config {
+	return app.config.Load()
+}
+
+// handleConfigChange applies a configuration reloaded from disk. Reloads that
+// match the current settings (e.g. triggered by our own save) are ignored.
+func (app *App) handleConfigChange(cfg *config.Config) {
+	changes, err := config.Diff(app.currentConfig(), cfg)
+	if err != nil {
+		logger.Warn("Failed to compare reloaded configuration", zap.Error(err))
+		return
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	app.applyConfig(cfg)
+	app.settingsTab.updateStatus(i18n.T("settings.reloaded", len(changes)), "green")
+}
+
+// autoRefresh periodically refreshes the resources tab while it is visible
+func (app *App) autoRefresh() {
+	ticker := time.NewTicker(time.Duration(app.currentConfig().UI.RefreshInterval) * time.Second)
+	defer ticker.Stop()
+
 	for {
 		select {
-		case event := <-app.eventChan:
-			app.handleEvent(event)
+		case interval := <-app.refreshInterval:
+			ticker.Reset(interval)
+		case <-ticker.C:
+			app.mu.RLock()
+			currentTab := app.currentTab
+			app.mu.RUnlock()
+
+			if currentTab == 1 {
+				app.app.QueueUpdateDraw(app.resourcesTab.AutoRefresh)
+			}
 		case <-app.stopChan:
 			return
 		case <-app.ctx.Done():
@@ -369,66 +775,191 @@ func (app *App) eventHandler() {
 	}
 }
 
-// handleEvent handles individual events
-func (app *App) handleEvent(event Event) {
-	switch event.Type {
-	case EventProfileChanged:
-		if profileData, ok := event.Data.(map[string]string); ok {
-			app.handleProfileChange(profileData)
-		}
-	case EventRegionChanged:
-		if region, ok := event.Data.(string); ok {
-			app.handleRegionChange(region)
-		}
-	case EventRefresh:
-		app.handleRefresh()
-	case EventError:
-		if err, ok := event.Data.(error); ok {
-			app.showError(err)
-		}
-	case EventShowLambdaLogs:
-		if data, ok := event.Data.(map[string]string); ok {
-			function := data["function"]
-			logGroup := data["logGroup"]
-			app.switchTab(2)
-			if app.logsTab != nil {
-				app.logsTab.ShowLambdaLogGroup(function, logGroup)
-			}
-		}
+// restartAlertMonitor stops the running watch rule monitor and starts a new
+// one for the current client and alert settings
+func (app *App) restartAlertMonitor() {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	if app.alertsCancel != nil {
+		app.alertsCancel()
+		app.alertsCancel = nil
 	}
+	app.alertsConfig = app.currentConfig().Alerts
+
+	if app.awsClient == nil || app.offline != nil || !app.alertsConfig.Enabled || len(app.alertsConfig.Rules) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(app.ctx)
+	app.alertsCancel = cancel
+
+	monitor := alerts.NewMonitor(app.awsClient, app.alertsConfig, app.handleAlert)
+	go monitor.Run(ctx)
+
+	logger.Info("Started watch rule monitor", zap.Int("rules", len(app.alertsConfig.Rules)))
+}
+
+// handleAlert records a triggered watch rule in the alerts log source
+func (app *App) handleAlert(alert alerts.Alert, err error) {
+	entry := LogEntry{
+		Timestamp: alert.Time,
+		Level:     "WARN",
+		Message:   fmt.Sprintf("[%s] %s: %s", alert.Rule, alert.Resource, alert.Message),
+		Source:    "alerts",
+		Fields:    map[string]interface{}{"type": alert.Type, "region": alert.Region},
+	}
+	if err != nil {
+		entry.Level = "ERROR"
+		entry.Fields["webhook_error"] = err.Error()
+	}
+
+	app.app.QueueUpdateDraw(func() {
+		app.logsTab.addLogEntry("alerts", entry)
+	})
 }
 
 // handleProfileChange handles AWS profile changes
-func (app *App) handleProfileChange(data map[string]string) {
-	profile := data["profile"]
-	region := data["region"]
+func (app *App) handleProfileChange(event ProfileChangedEvent) {
+	profile, region := event.Profile, event.Region
 
 	logger.Info("Handling profile change",
 		zap.String("profile", profile),
 		zap.String("region", region))
 
-	// Close existing client
-	if app.awsClient != nil {
-		app.awsClient.Close()
+	if mode := app.fixedClientMode(); mode != "" {
+		app.showMessage(i18n.T("app.profile_switch_disabled", mode))
+		return
 	}
 
 	// Create new client with selected profile
-	client, err := aws.NewClient(profile, region)
+	client, err := app.clients.NewClient(profile, region)
 	if err != nil {
 		app.showError(fmt.Errorf("failed to create AWS client: %w", err))
 		return
 	}
 
+	app.useClient(client)
+
+	// Show success message
+	app.showMessage(i18n.T("app.switched_profile", profile, region))
+}
+
+// EnableDemoMode uses client for all tabs and ignores later profile and
+// region changes
+func (app *App) EnableDemoMode(client *aws.Client) {
+	app.demo = true
+	app.audit.log.Store(nil)
+	app.useClient(client)
+}
+
+// useClient closes the current client and hands client to all tabs
+func (app *App) useClient(client *aws.Client) {
+	if app.awsClient != nil && app.awsClient != client {
+		app.awsClient.Close()
+	}
+	// Scheduled actions read the client from their jobs
+	app.mu.Lock()
 	app.awsClient = client
+	app.mu.Unlock()
 
-	// Update tabs with new client
 	app.resourcesTab.SetAWSClient(client)
+	app.athenaTab.SetAWSClient(client)
 	if app.logsTab != nil {
 		app.logsTab.SetAWSClient(client)
 	}
+	app.restartAlertMonitor()
+	app.restartHealthWatch(client)
 
-	// Show success message
-	app.showMessage(fmt.Sprintf("Switched to profile: %s (%s)", profile, region))
+	// Waiting for the identity of the previous client is pointless now
+	app.mu.Lock()
+	if app.clientCancel != nil {
+		app.clientCancel()
+	}
+	ctx, cancel := context.WithCancel(app.ctx)
+	app.clientCancel = cancel
+	app.mu.Unlock()
+
+	go app.watchIdentity(ctx, client)
+}
+
+// restartHealthWatch stops polling AWS Health for the previous client and
+// starts polling it for client
+func (app *App) restartHealthWatch(client *aws.Client) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	if app.healthCancel != nil {
+		app.healthCancel()
+	}
+	ctx, cancel := context.WithCancel(app.ctx)
+	app.healthCancel = cancel
+
+	go app.watchHealth(ctx, client)
+}
+
+// watchHealth polls the open AWS Health events of client until ctx is
+// cancelled and shows a banner in the footer for every new service issue.
+// Accounts whose support plan lacks the Health API are polled once.
+func (app *App) watchHealth(ctx context.Context, client *aws.Client) {
+	seen := make(map[string]bool)
+	ticker := time.NewTicker(healthPollInterval)
+	defer ticker.Stop()
+
+	for {
+		svc := client.GetClients()
+		if svc == nil || svc.Health == nil {
+			return
+		}
+
+		pollCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		events, err := svc.Health.ListOpenEvents(pollCtx)
+		cancel()
+		switch {
+		case ctx.Err() != nil:
+			return
+		case isSupportPlanError(err):
+			logger.Info("AWS Health is not available for this account", zap.Error(err))
+			return
+		case err != nil:
+			logger.Warn("Failed to poll AWS Health", zap.Error(err))
+		default:
+			if fresh := newCriticalHealthEvents(events, seen); len(fresh) > 0 {
+				logger.Warn("New AWS Health issue", zap.String("event", fresh[0].ARN), zap.Int("count", len(fresh)))
+				app.app.QueueUpdateDraw(func() {
+					if ctx.Err() == nil {
+						app.showNotice(healthBanner(fresh), "red")
+					}
+				})
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// watchIdentity shows the account of client once STS has answered, or why
+// it could not be resolved, unless ctx is cancelled first
+func (app *App) watchIdentity(ctx context.Context, client *aws.Client) {
+	_, err := client.WaitForIdentity(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+
+	app.app.QueueUpdateDraw(func() {
+		if app.awsClient != client {
+			return
+		}
+		if err != nil {
+			app.showError(err)
+			return
+		}
+		app.profileTab.updateStatus(i18n.T("profiles.connected", client.GetAccountID()), "green")
+	})
 }
 
 // handleRegionChange handles AWS region changes
@@ -436,6 +967,14 @@ func (app *App) handleRegionChange(region string) {
 	if app.awsClient == nil {
 		return
 	}
+	if mode := app.fixedClientMode(); mode != "" {
+		app.showMessage(i18n.T("app.region_switch_disabled", mode))
+		return
+	}
+
+	if region == app.awsClient.GetRegion() {
+		return
+	}
 
 	profile := app.awsClient.GetProfile()
 	err := app.awsClient.SwitchProfile(profile, region)
@@ -444,7 +983,21 @@ func (app *App) handleRegionChange(region string) {
 		return
 	}
 
-	app.showMessage(fmt.Sprintf("Changed region to: %s", region))
+	// The tabs drop the work and listings of the previous region
+	app.useClient(app.awsClient)
+
+	app.showMessage(i18n.T("profiles.region_changed", region))
+}
+
+// keepClient tells that a switch was called off and the current profile and
+// region stay in use
+func (app *App) keepClient() {
+	if app.awsClient == nil {
+		app.profileTab.updateStatus(i18n.T("app.switch_cancelled"), "yellow")
+		return
+	}
+	app.profileTab.updateStatus(i18n.T("app.switch_cancelled_keep",
+		app.awsClient.GetProfile(), app.awsClient.GetRegion()), "yellow")
 }
 
 // handleRefresh handles refresh events
@@ -462,33 +1015,40 @@ func (app *App) handleRefresh() {
 		app.logsTab.Refresh()
 	case 3: // Settings
 		app.settingsTab.Refresh()
+	case 4: // Athena
+		app.athenaTab.Refresh()
 	}
 }
 
-// showError shows an error modal
+// showError shows an error modal. It may be called from any goroutine.
 func (app *App) showError(err error) {
 	logger.Error("Application error", zap.Error(err))
 
 	modal := tview.NewModal().
-		SetText(fmt.Sprintf("Error: %s", err.Error())).
-		AddButtons([]string{"OK"}).
+		SetText(i18n.T("app.error", err.Error())).
+		AddButtons([]string{i18n.T("common.ok")}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 			app.pages.RemovePage("error")
 		})
 
-	app.pages.AddPage("error", modal, false, true)
+	// Never block here, the caller may be the UI goroutine
+	go app.app.QueueUpdateDraw(func() {
+		app.pages.AddPage("error", modal, false, true)
+	})
 }
 
-// showMessage shows an info modal
+// showMessage shows an info modal. It may be called from any goroutine.
 func (app *App) showMessage(message string) {
 	modal := tview.NewModal().
 		SetText(message).
-		AddButtons([]string{"OK"}).
+		AddButtons([]string{i18n.T("common.ok")}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 			app.pages.RemovePage("message")
 		})
 
-	app.pages.AddPage("message", modal, false, true)
+	go app.app.QueueUpdateDraw(func() {
+		app.pages.AddPage("message", modal, false, true)
+	})
 
 	// Auto-close after 2 seconds
 	go func() {
@@ -504,7 +1064,12 @@ func (app *App) Run() error {
 	logger.Info("Starting TUI application")
 
 	// Enable mouse and configure screen settings to prevent duplication
-	app.app.EnableMouse(app.config.UI.MouseEnabled)
+	cfg := app.currentConfig()
+	aws.SetRateLimit(cfg.AWS.RateLimit)
+	aws.SetRequestOptions(aws.RequestOptionsFromConfig(cfg.AWS))
+	workpool.SetLimits(cfg.AWS.Concurrency, cfg.AWS.ServiceConcurrency)
+	app.app.EnableMouse(cfg.UI.MouseEnabled)
+	go app.checkForUpdate(cfg.Update)
 
 	if err := app.app.Run(); err != nil {
 		return fmt.Errorf("failed to run TUI application: %w", err)
@@ -515,6 +1080,11 @@ func (app *App) Run() error {
 
 // Quit gracefully shuts down the application
 func (app *App) Quit() {
+	// Quitting from the UI is followed by the deferred Quit of the caller of Run
+	app.quitOnce.Do(app.quit)
+}
+
+func (app *App) quit() {
 	logger.Info("Shutting down TUI application")
 
 	// Close AWS client
@@ -522,7 +1092,8 @@ func (app *App) Quit() {
 		app.awsClient.Close()
 	}
 
-	// Stop event handler
+	// Stop event handling
+	app.events.Close()
 	close(app.stopChan)
 
 	// Cancel context
@@ -530,6 +1101,17 @@ func (app *App) Quit() {
 
 	// Stop the application
 	app.app.Stop()
+
+	// Save the recording running
+	if app.recorder != nil {
+		if err := app.recorder.Close(); err != nil {
+			logger.Error("Failed to save recording", zap.Error(err))
+		}
+		app.recorder = nil
+	}
+
+	// Stop following the application log and close the log search index
+	app.logsTab.Cleanup()
 }
 
 // GetAWSClient returns the current AWS client
@@ -537,7 +1119,7 @@ func (app *App) GetAWSClient() *aws.Client {
 	return app.awsClient
 }
 
-// GetProfileManager returns the profile manager
-func (app *App) GetProfileManager() *aws.ProfileManager {
+// GetProfileManager returns the store of the profiles
+func (app *App) GetProfileManager() ProfileStore {
 	return app.profileManager
 }
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"time"
+
+	"swiss-army-tui/internal/audit"
+	"swiss-army-tui/internal/aws"
+)
+
+// ProfileStore holds the AWS profiles to choose from. *aws.ProfileManager
+// reads them from the AWS config and credentials files.
+type ProfileStore interface {
+	LoadProfiles() error
+	GetProfiles() map[string]*aws.Profile
+	// SSOShare returns the SSO token profile signs in with, nil if none
+	SSOShare(profile string) *aws.SSOShare
+}
+
+// ClientFactory creates the AWS client of a profile in a region
+type ClientFactory interface {
+	NewClient(profile, region string) (*aws.Client, error)
+}
+
+// ClientFactoryFunc is a ClientFactory calling itself, e.g.
+// ClientFactoryFunc(aws.NewClient)
+type ClientFactoryFunc func(profile, region string) (*aws.Client, error)
+
+// NewClient calls f
+func (f ClientFactoryFunc) NewClient(profile, region string) (*aws.Client, error) {
+	return f(profile, region)
+}
+
+// Clock tells the time shown and compared against, e.g. of notices and
+// schedules
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock of the system
+type systemClock struct{}
+
+// Now returns the current time
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Option changes what NewApp builds the App from
+type Option func(*App)
+
+// WithProfileStore lists the profiles of store instead of the AWS config
+// and credentials files
+func WithProfileStore(store ProfileStore) Option {
+	return func(app *App) { app.profileManager = store }
+}
+
+// WithClientFactory creates the clients of profiles with factory instead of
+// the AWS SDK
+func WithClientFactory(factory ClientFactory) Option {
+	return func(app *App) { app.clients = factory }
+}
+
+// WithVersion sets the version of the build running, which is checked
+// against the latest release when update checks are on
+func WithVersion(version string) Option {
+	return func(app *App) { app.version = version }
+}
+
+// WithAuditLog records mutating actions to log instead of the audit log in
+// the home directory
+func WithAuditLog(log *audit.Log) Option {
+	return func(app *App) { app.audit.log.Store(log) }
+}
+
+// WithClock tells the time by clock instead of the system clock
+func WithClock(clock Clock) Option {
+	return func(app *App) { app.clock = clock }
+}
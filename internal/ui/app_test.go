@@ -0,0 +1,2774 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"swiss-army-tui/internal/audit"
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/aws/fake"
+	"swiss-army-tui/internal/bookmarks"
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/internal/history"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/jobs"
+	"swiss-army-tui/internal/recording"
+	"swiss-army-tui/internal/schedule"
+	"swiss-army-tui/internal/snapshot"
+	"swiss-army-tui/internal/workpool"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// testUI runs the full App on a simulated screen backed by the fake AWS services
+type testUI struct {
+	t      *testing.T
+	app    *App
+	screen tcell.SimulationScreen
+	done   chan error
+}
+
+func startTestUI(t *testing.T) *testUI {
+	t.Helper()
+	return startTestUIWith(t, func(app *App) { app.EnableDemoMode(fake.NewClient()) })
+}
+
+// startTestUIWith runs the App built with opts after setup has given it its
+// client
+func startTestUIWith(t *testing.T, setup func(app *App), opts ...Option) *testUI {
+	t.Helper()
+
+	dir := t.TempDir()
+	// Keep the filter history out of the real home directory
+	t.Setenv("HOME", dir)
+	cfg := &config.Config{
+		App: config.AppConfig{Name: "Swiss Army TUI", Version: "1.0.0"},
+		AWS: config.AWSConfig{
+			DefaultProfile:  "default",
+			DefaultRegion:   "us-east-1",
+			ConfigPath:      filepath.Join(dir, "config"),
+			CredentialsPath: filepath.Join(dir, "credentials"),
+			RateLimit:       20,
+		},
+		UI: config.UIConfig{Theme: "dark", RefreshInterval: 30, BorderStyle: "rounded", LogBufferSize: 1000, CacheTTL: 60},
+	}
+
+	app, err := NewApp(cfg, opts...)
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+
+	setup(app)
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	app.app.SetScreen(screen)
+	screen.SetSize(160, 45)
+
+	ui := &testUI{t: t, app: app, screen: screen, done: make(chan error, 1)}
+	go func() { ui.done <- app.Run() }()
+	t.Cleanup(func() {
+		app.Quit()
+		<-ui.done
+	})
+
+	ui.waitFor(" AWS Profiles ")
+	return ui
+}
+
+// snapshot returns the rendered screen, one line per row
+func (ui *testUI) snapshot() string {
+	// Read the screen on the UI goroutine so it is not drawn to at the same time
+	result := make(chan string, 1)
+	ui.app.app.QueueUpdate(func() {
+		cells, width, height := ui.screen.GetContents()
+		var text strings.Builder
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				runes := cells[y*width+x].Runes
+				if len(runes) == 0 {
+					text.WriteByte(' ')
+					continue
+				}
+				text.WriteString(string(runes))
+			}
+			text.WriteByte('\n')
+		}
+		result <- text.String()
+	})
+
+	select {
+	case text := <-result:
+		return text
+	case <-time.After(5 * time.Second):
+		ui.t.Fatal("UI did not respond")
+		return ""
+	}
+}
+
+// waitUntil waits until the screen satisfies cond and returns it. Keys are
+// handled asynchronously, so every check after a key press has to wait.
+func (ui *testUI) waitUntil(what string, cond func(screen string) bool) string {
+	ui.t.Helper()
+
+	var screen string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		if screen = ui.snapshot(); cond(screen) {
+			return screen
+		}
+	}
+	ui.t.Fatalf("Timed out waiting for %s, screen:\n%s", what, screen)
+	return ""
+}
+
+// waitFor waits until text is shown and returns the screen
+func (ui *testUI) waitFor(text string) string {
+	ui.t.Helper()
+	return ui.waitUntil(fmt.Sprintf("%q", text), func(screen string) bool {
+		return strings.Contains(screen, text)
+	})
+}
+
+// waitForGone waits until text is no longer shown and returns the screen
+func (ui *testUI) waitForGone(text string) string {
+	ui.t.Helper()
+	return ui.waitUntil(fmt.Sprintf("%q to disappear", text), func(screen string) bool {
+		return !strings.Contains(screen, text)
+	})
+}
+
+// testClock is a Clock that only moves when told to
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// startFakeUI runs the App wired with fakes only: profiles of a fake store,
+// clients backed by the fake services and a clock that stands still. It
+// returns the profiles and regions clients were created for.
+func startFakeUI(t *testing.T, clock Clock) (*testUI, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var created []string
+	store := fake.NewProfileStore(
+		&aws.Profile{Name: "staging", Region: "eu-west-1", Source: "config"},
+		&aws.Profile{Name: "production", Region: "us-east-1", Source: "config"},
+	)
+	factory := ClientFactoryFunc(func(profile, region string) (*aws.Client, error) {
+		mu.Lock()
+		created = append(created, profile+" "+region)
+		mu.Unlock()
+		return fake.NewClientFor(profile, region)
+	})
+
+	ui := startTestUIWith(t, func(app *App) {}, WithProfileStore(store), WithClientFactory(factory), WithClock(clock))
+	return ui, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), created...)
+	}
+}
+
+func (ui *testUI) key(key tcell.Key) {
+	ui.screen.InjectKey(key, 0, tcell.ModNone)
+}
+
+func (ui *testUI) typeText(text string) {
+	for _, r := range text {
+		ui.screen.InjectKey(tcell.KeyRune, r, tcell.ModNone)
+	}
+}
+
+func TestAppTabSwitching(t *testing.T) {
+	ui := startTestUI(t)
+	ui.waitFor("Connected to account: " + fake.Account)
+
+	ui.typeText("2")
+	ui.waitFor(" Filter Resources ")
+	screen := ui.waitForGone(" AWS Profiles ")
+	if !strings.Contains(screen, "EC2 Instances") {
+		t.Errorf("Expected the service list, screen:\n%s", screen)
+	}
+
+	ui.key(tcell.KeyTab)
+	ui.waitFor(" Log Sources ")
+
+	ui.key(tcell.KeyTab)
+	ui.waitFor(" Application Settings ")
+
+	ui.key(tcell.KeyBacktab)
+	ui.waitFor(" Log Sources ")
+
+	ui.typeText("1")
+	screen = ui.waitFor(" AWS Profiles ")
+	if strings.Contains(screen, " Log Sources ") {
+		t.Errorf("Expected only the profile tab, screen:\n%s", screen)
+	}
+}
+
+func TestAppResources(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyEnter)
+	// Pages show as they load; refreshing waits for the last one
+	screen := ui.waitUntil("the last page", func(screen string) bool {
+		return strings.Contains(screen, "Loaded 5 ec2 resources") && !strings.Contains(screen, "loading more")
+	})
+
+	for _, name := range []string{"web-1", "web-2", "worker-1", "bastion", "staging-app"} {
+		if !strings.Contains(screen, name) {
+			t.Errorf("Expected instance %s, screen:\n%s", name, screen)
+		}
+	}
+
+	// Running t3.medium instances cost $0.0416 an hour, stopped ones nothing
+	if count := strings.Count(screen, "$30.37"); count != 2 {
+		t.Errorf("Expected the cost of web-1 and web-2, got %d, screen:\n%s", count, screen)
+	}
+
+	// Reloading must pick up changes and replace the rows, not add to them
+	ui.app.awsClient.GetClients().EC2.(*fake.EC2Service).SetTransitionDelay(0)
+	if err := ui.app.awsClient.GetClients().EC2.StopInstance(context.Background(), "i-0b23c45d67e89f012"); err != nil {
+		t.Fatalf("Failed to stop instance: %v", err)
+	}
+	ui.typeText("r")
+	screen = ui.waitUntil("three stopped instances", func(screen string) bool {
+		return strings.Count(screen, "stopped") == 3
+	})
+	if count := strings.Count(screen, "i-0c34d56e78f90a123"); count != 1 {
+		t.Errorf("Expected worker-1 once after refresh, got %d, screen:\n%s", count, screen)
+	}
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("web")
+	screen = ui.waitFor(" Resources (2 of 5)")
+	if strings.Contains(screen, "worker-1") {
+		t.Errorf("Expected worker-1 to be filtered out, screen:\n%s", screen)
+	}
+
+	// Enter moves to the table, where the first row can be selected
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	screen = ui.waitFor("ID: i-0a12b34c56d78e901")
+	if !strings.Contains(screen, "team: web") {
+		t.Errorf("Expected the tags of web-1, screen:\n%s", screen)
+	}
+}
+
+func TestAppInstanceStop(t *testing.T) {
+	interval := instanceStatePollInterval
+	instanceStatePollInterval = 50 * time.Millisecond
+	t.Cleanup(func() { instanceStatePollInterval = interval })
+
+	ui := startTestUI(t)
+	ui.app.awsClient.GetClients().EC2.(*fake.EC2Service).SetTransitionDelay(500 * time.Millisecond)
+
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: i-0a12b34c56d78e901")
+
+	// The row follows the instance through stopping without a reload
+	ui.typeText("p")
+	ui.waitFor("stopping")
+	screen := ui.waitFor("Instance i-0a12b34c56d78e901 is now stopped")
+	if !strings.Contains(screen, "i-0a12b34c56d78e901 EC2 Instance stopped") {
+		t.Errorf("Expected web-1 to be stopped, screen:\n%s", screen)
+	}
+}
+
+func TestAppAuditLog(t *testing.T) {
+	interval := instanceStatePollInterval
+	instanceStatePollInterval = 50 * time.Millisecond
+	t.Cleanup(func() { instanceStatePollInterval = interval })
+
+	stopInstance := func(ui *testUI) {
+		ui.typeText("2")
+		ui.waitFor("EC2 Instances")
+		ui.key(tcell.KeyEnter)
+		ui.waitFor(" Resources (5)")
+		ui.typeText("f")
+		ui.waitFor(" Filter Resources (Active) ")
+		ui.key(tcell.KeyEnter)
+		ui.waitForGone(" Filter Resources (Active) ")
+		ui.key(tcell.KeyDown)
+		ui.waitFor("ID: i-0a12b34c56d78e901")
+		ui.typeText("p")
+		ui.waitFor("Instance i-0a12b34c56d78e901 is now stopped")
+	}
+
+	// Actions against the sample data of demo mode are not recorded
+	demo := startTestUI(t)
+	stopInstance(demo)
+	home := filepath.Join(os.Getenv("HOME"), ".swiss-army-tui", "audit.log")
+	if _, err := os.Stat(home); !os.IsNotExist(err) {
+		t.Errorf("Expected no audit log in demo mode, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	ui := startTestUIWith(t, func(app *App) { app.useClient(fake.NewClient()) }, WithAuditLog(audit.New(path)))
+	stopInstance(ui)
+	ui.waitUntil("the action recorded", func(string) bool {
+		entries, _ := audit.New(path).Read()
+		return len(entries) == 1 && entries[0].Action == "ec2:StopInstances" && entries[0].Result == audit.ResultSuccess
+	})
+	if _, err := os.Stat(filepath.Join(os.Getenv("HOME"), ".swiss-army-tui", "audit.log")); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing recorded in the home directory, got %v", err)
+	}
+}
+
+func TestAppMissingPermission(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+
+	// The demo user may stop web-1 but not worker-1
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: i-0a12b34c56d78e901")
+	screen := ui.waitUntil("the preflight of web-1", func(screen string) bool {
+		return strings.Contains(screen, "p  stop") && !strings.Contains(screen, "checking")
+	})
+	if strings.Contains(screen, "missing ec2:StopInstances") {
+		t.Errorf("Expected stop to be allowed for web-1, screen:\n%s", screen)
+	}
+
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: i-0c34d56e78f90a123")
+	ui.waitFor("stop (missing ec2:StopInstances)")
+
+	ui.typeText("p")
+	ui.waitFor("Cannot stop")
+	screen = ui.snapshot()
+	if strings.Contains(screen, "stopping") {
+		t.Errorf("Expected worker-1 not to be stopped, screen:\n%s", screen)
+	}
+
+	// The context menu grays out the denied action; Esc closes it
+	ui.typeText("a")
+	ui.waitFor("Stop the selected EC2 instance (missing ec2:StopInstances)")
+	ui.key(tcell.KeyEscape)
+	ui.waitForGone("Stop the selected EC2 instance")
+
+	// The command palette runs the first action matching the text
+	ui.typeText(":")
+	ui.waitFor(" Actions: ec2 ")
+	ui.typeText("export")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Export 5 Resources ")
+}
+
+func TestAppInsights(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("Insights")
+	for i := 0; i < 6; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor(" Resources (6)")
+	for _, want := range []string{"orders-db-old-data", "legacy-ftp", "promo-2023", "image-resizer", "bastion", "$8.00"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q among the findings, screen:\n%s", want, screen)
+		}
+	}
+	if strings.Contains(screen, "web-prod") {
+		t.Errorf("Expected the load balancer with targets to be left out, screen:\n%s", screen)
+	}
+}
+
+func TestAppReservations(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("Reservations")
+	for i := 0; i < 8; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor("2 of 4 reserved")
+	for _, want := range []string{"2 x t3.medium", "2 x m5.large", "unused", "Compute $0.25/h"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q among the reservations, screen:\n%s", want, screen)
+		}
+	}
+}
+
+func TestAppSpotRequests(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("Spot Requests")
+	for i := 0; i < 7; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor("3 Spot requests, 1 with")
+	if !strings.Contains(screen, "marked-for-termination") {
+		t.Errorf("Expected the interrupted request, screen:\n%s", screen)
+	}
+}
+
+func TestAppDashboard(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("CW Dashboards")
+	for i := 0; i < 9; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (2)")
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: orders-service")
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor("● orders-api-5xx  ALARM")
+	for _, want := range []string{" Dashboard orders-service - last 3h ", "Instance CPU", "worker-1", "DB connections"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q on the dashboard, screen:\n%s", want, screen)
+		}
+	}
+
+	ui.typeText("t")
+	ui.waitFor(" Dashboard orders-service - last 12h ")
+	ui.typeText("q")
+	ui.waitForGone(" Dashboard orders-service")
+}
+
+func TestAppPartialResults(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("S3 Buckets")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor(" Warnings (1) ")
+	if !strings.Contains(screen, "acme-security-audit: access denied") {
+		t.Errorf("Expected the failed bucket, screen:\n%s", screen)
+	}
+	if !strings.Contains(screen, "acme-eu-customer-data") || !strings.Contains(screen, "eu-west-1") {
+		t.Errorf("Expected the other buckets with their regions, screen:\n%s", screen)
+	}
+
+	// The failures of a listing stay with it in the cache
+	exposure := func(screen string) bool {
+		return strings.Contains(screen, "cross-account") && strings.Contains(screen, " Warnings (1) ")
+	}
+	ui.app.app.QueueUpdateDraw(func() { ui.app.resourcesTab.selectService("s3exposure") })
+	ui.waitUntil("the failed bucket", exposure)
+	ui.app.app.QueueUpdateDraw(func() { ui.app.resourcesTab.selectService("ec2") })
+	ui.waitUntil("the instances", func(screen string) bool {
+		return strings.Contains(screen, "Loaded 5 ec2 resources") && !strings.Contains(screen, " Warnings (1) ")
+	})
+	ui.app.app.QueueUpdateDraw(func() { ui.app.resourcesTab.selectService("s3exposure") })
+	ui.waitUntil("the failed bucket of the cached listing", exposure)
+}
+
+func TestAppQuit(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.key(tcell.KeyEscape)
+	select {
+	case err := <-ui.done:
+		if err != nil {
+			t.Errorf("Expected a clean exit, got %v", err)
+		}
+		// The cleanup quits again and waits for Run once more
+		ui.done <- err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Esc to quit")
+	}
+}
+
+func TestAppUpdateBadge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/tool/releases/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"tag_name": "v1.3.0", "html_url": "https://example.com/v1.3.0"}`)
+	}))
+	defer server.Close()
+
+	checkUpdates := func(app *App) {
+		app.currentConfig().Update = config.UpdateConfig{Check: true, Repository: "owner/tool", APIURL: server.URL}
+	}
+	ui := startTestUIWith(t, func(app *App) { app.EnableDemoMode(fake.NewClient()) }, checkUpdates, WithVersion("v1.2.0"))
+	ui.waitFor("v1.3.0 available: swiss-army-tui update")
+
+	// Releases up to date show nothing
+	current := startTestUIWith(t, func(app *App) { app.EnableDemoMode(fake.NewClient()) }, checkUpdates, WithVersion("v1.3.0"))
+	current.waitFor("Connected to account: " + fake.Account)
+	time.Sleep(100 * time.Millisecond)
+	if screen := current.snapshot(); strings.Contains(screen, "available") {
+		t.Errorf("Expected no update badge, screen:\n%s", screen)
+	}
+}
+
+func TestAppLocale(t *testing.T) {
+	// The locale is global; cleanups run last to first, so this runs after the app quit
+	t.Cleanup(func() { i18n.SetLocale(i18n.DefaultLocale) })
+	ui := startTestUI(t)
+	ui.waitFor("Settings")
+
+	setLocale := func(locale string) {
+		cfg := *ui.app.currentConfig()
+		cfg.UI.Locale = locale
+		ui.app.app.QueueUpdateDraw(func() { ui.app.handleConfigChange(&cfg) })
+	}
+
+	// The tab bar shows the change; footer shortcuts may be wrapped away by notices
+	setLocale("de")
+	ui.waitFor("Ressourcen")
+	ui.waitFor("Einstellungen")
+	ui.waitForGone("Settings")
+
+	setLocale("en")
+	ui.waitFor("Settings")
+	ui.waitForGone("Einstellungen")
+}
+
+func TestViewStringsTranslated(t *testing.T) {
+	// Color tags and verbs are no words; what is left must come from the catalogs
+	markup := regexp.MustCompile(`\[[a-zA-Z:#\-]*\]|%[-+# 0-9.*]*[a-zA-Z]`)
+	word := regexp.MustCompile(`[A-Za-z]{2,}`)
+	var raw func(expr ast.Expr) (string, bool)
+	raw = func(expr ast.Expr) (string, bool) {
+		switch e := expr.(type) {
+		case *ast.BasicLit:
+			text, _ := strconv.Unquote(e.Value)
+			return text, e.Kind == token.STRING && word.MatchString(markup.ReplaceAllString(text, ""))
+		case *ast.BinaryExpr:
+			if text, ok := raw(e.X); ok {
+				return text, true
+			}
+			return raw(e.Y)
+		case *ast.CallExpr:
+			// fmt.Sprintf("...", ...) only wraps its format
+			if sel, ok := e.Fun.(*ast.SelectorExpr); ok && len(e.Args) > 0 {
+				if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "fmt" && strings.HasPrefix(sel.Sel.Name, "Sprint") {
+					return raw(e.Args[0])
+				}
+			}
+		}
+		return "", false
+	}
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			// The message is the first argument of the methods and the second of setTableMessage
+			name, arg := "", 0
+			switch fun := call.Fun.(type) {
+			case *ast.SelectorExpr:
+				name = fun.Sel.Name
+			case *ast.Ident:
+				name, arg = fun.Name, 1
+			}
+			switch name {
+			case "SetTitle", "SetText", "updateStatus", "setTableMessage":
+				if len(call.Args) > arg {
+					if text, ok := raw(call.Args[arg]); ok {
+						t.Errorf("%s: %s is passed the literal %q; move it into the catalogs",
+							fset.Position(call.Args[arg].Pos()), name, text)
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+func TestAppConfigReload(t *testing.T) {
+	ui := startTestUI(t)
+	ui.waitFor("Settings")
+
+	// Goroutines holding the old config must not see it change under them
+	old := ui.app.currentConfig()
+	interval := old.UI.RefreshInterval
+	reloaded := *old
+	reloaded.UI.RefreshInterval = interval + 30
+	done := make(chan struct{})
+	ui.app.app.QueueUpdateDraw(func() {
+		ui.app.handleConfigChange(&reloaded)
+		close(done)
+	})
+	<-done
+
+	if current := ui.app.currentConfig(); current != &reloaded {
+		t.Errorf("Expected the reloaded config to replace the old one, got %+v", current.UI)
+	}
+	if old.UI.RefreshInterval != interval {
+		t.Errorf("Expected the old config unchanged, got interval %d", old.UI.RefreshInterval)
+	}
+}
+
+func TestAppNoColor(t *testing.T) {
+	// The accessibility mode is global; this cleanup runs after the app quit
+	t.Cleanup(func() { setAccessibility(config.UIConfig{}) })
+	t.Setenv("NO_COLOR", "1")
+	ui := startTestUI(t)
+
+	ui.waitFor("OK: Connected to account: " + fake.Account)
+	ui.typeText("2")
+	ui.waitFor("[EC2]  EC2 Instances")
+	if screen := ui.snapshot(); strings.Contains(screen, "🤖") {
+		t.Errorf("Expected ASCII labels instead of icons, screen:\n%s", screen)
+	}
+
+	ui.app.app.QueueUpdateDraw(func() {})
+	ui.waitUntil("no colors", func(string) bool {
+		width, height := ui.screen.Size()
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				_, _, style, _ := ui.screen.GetContent(x, y)
+				if fg, bg, _ := style.Decompose(); fg != tcell.ColorDefault || bg != tcell.ColorDefault {
+					return false
+				}
+			}
+		}
+		return true
+	})
+}
+
+func TestMonoStyle(t *testing.T) {
+	if got := monoStyle(tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true)); got != tcell.StyleDefault.Bold(true) {
+		t.Errorf("Expected colors dropped and bold kept, got %v", got)
+	}
+	// Selections stay visible in reverse video
+	selected := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorWhite)
+	if got := monoStyle(selected); got != tcell.StyleDefault.Reverse(true) {
+		t.Errorf("Expected a background to turn reverse video, got %v", got)
+	}
+	if got := monoStyle(selected.Reverse(true)); got != tcell.StyleDefault {
+		t.Errorf("Expected reversed backgrounds to turn normal, got %v", got)
+	}
+}
+
+func TestAppWorkPoolFooter(t *testing.T) {
+	ui := startTestUI(t)
+
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- workpool.Each(context.Background(), "lambda", workpool.DefaultLimit+2, func(int) { <-release })
+	}()
+	ui.waitFor(fmt.Sprintf("AWS calls: %d running, 2 queued", workpool.DefaultLimit))
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	ui.waitForGone("AWS calls:")
+}
+
+func TestAppAthena(t *testing.T) {
+	interval := athenaPollInterval
+	athenaPollInterval = 20 * time.Millisecond
+	t.Cleanup(func() { athenaPollInterval = interval })
+
+	ui := startTestUI(t)
+	ui.app.awsClient.GetClients().Athena.(*fake.AthenaService).SetQueryDuration(200 * time.Millisecond)
+
+	ui.typeText("5")
+	ui.waitFor("analytics")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" SQL (primary / default) ")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" SQL (primary / sales) ")
+
+	ui.typeText("SELECT * FROM order")
+	ui.key(tcell.KeyF5)
+	ui.waitFor("TABLE_NOT_FOUND")
+
+	// Digits go into the query instead of switching tabs
+	ui.typeText("s LIMIT 150")
+	ui.key(tcell.KeyF5)
+	screen := ui.waitFor(" Results 1-100 (page 1, n: next, e: export CSV) ")
+	for _, want := range []string{"order_id", "o-10001", "Succeeded in"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the results, screen:\n%s", want, screen)
+		}
+	}
+
+	ui.typeText("n")
+	ui.waitFor(" Results 101-150 (page 2, p: previous, e: export CSV) ")
+	ui.typeText("p")
+	ui.waitFor(" Results 1-100 (page 1, n: next, e: export CSV) ")
+}
+
+func TestAppAthenaAudit(t *testing.T) {
+	interval := athenaPollInterval
+	athenaPollInterval = 20 * time.Millisecond
+	t.Cleanup(func() { athenaPollInterval = interval })
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	ui := startTestUIWith(t, func(app *App) { app.useClient(fake.NewClient()) }, WithAuditLog(audit.New(path)))
+	ui.app.awsClient.GetClients().Athena.(*fake.AthenaService).SetQueryDuration(time.Minute)
+
+	ui.typeText("5")
+	ui.waitFor(" SQL (primary / default) ")
+	ui.key(tcell.KeyF2)
+	ui.key(tcell.KeyF2)
+	ui.typeText("DROP TABLE orders")
+	ui.key(tcell.KeyF5)
+	ui.waitFor("QUEUED")
+	ui.key(tcell.KeyF8)
+
+	// Both the query and its stop are recorded with the workgroup, database
+	// and SQL
+	ui.waitUntil("the query and its stop recorded", func(string) bool {
+		entries, _ := audit.New(path).Read()
+		if len(entries) != 2 {
+			return false
+		}
+		for i, action := range []string{"athena:StartQueryExecution", "athena:StopQueryExecution"} {
+			entry := entries[i]
+			if entry.Action != action || !strings.HasSuffix(entry.Resource, ":workgroup/primary") ||
+				entry.Detail != "database default: DROP TABLE orders" {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestAppSES(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("SES Sending")
+	for i := 0; i < 10; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor("Sent 11532 of")
+	for _, want := range []string{" Resources (8)", "example.com", "verified", "angry@example.fr", "complaint"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the SES view, screen:\n%s", want, screen)
+		}
+	}
+
+	// The summary shows as well when the listing comes from the cache
+	ui.app.app.QueueUpdateDraw(func() { ui.app.resourcesTab.selectService("ec2") })
+	ui.waitForGone("Sent 11532 of")
+	ui.app.app.QueueUpdateDraw(func() { ui.app.resourcesTab.selectService("ses") })
+	ui.waitFor("Sent 11532 of")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("angry")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: angry@example.fr")
+
+	ui.typeText("d")
+	ui.waitFor("Remove angry@example.fr from the suppression list?")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (0 of 7)")
+}
+
+func TestAppCertificates(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("ACM Certificates")
+	for i := 0; i < 11; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor("5 certificates, 2 expire")
+	for _, want := range []string{"shop.example.com", "Imported Certificate", "staging.example.org"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q among the certificates, screen:\n%s", want, screen)
+		}
+	}
+
+	// Soonest expiry first
+	if strings.Index(screen, "promo2023.example.com") > strings.Index(screen, "shop.example.com") ||
+		strings.Index(screen, "shop.example.com") > strings.Index(screen, "api.example.com") {
+		t.Errorf("Expected certificates ordered by expiry, screen:\n%s", screen)
+	}
+}
+
+func TestAppWAF(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("WAF Web ACLs")
+	for i := 0; i < 12; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+
+	ui.waitFor("2 web ACLs, 1 protect")
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("web-prod")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: web-prod-acl")
+	ui.key(tcell.KeyEnter)
+
+	// The rules of web-prod-acl, with its default action last
+	ui.waitFor("rate limit 2000 per 300s by ip")
+	screen := ui.waitFor("requests no rule terminated")
+	for _, want := range []string{"AWS-AWSManagedRulesSQLiRuleSet", "block-countries", "country in KP, IR"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q among the rules, screen:\n%s", want, screen)
+		}
+	}
+
+	ui.key(tcell.KeyEnter)
+	screen = ui.waitFor("75 sampled of 3000")
+	for _, want := range []string{"CrossSiteScripting_QUERYARGUMENTS", "BLOCK", "/search?q=<script>"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q among the sampled requests, screen:\n%s", want, screen)
+		}
+	}
+
+	// Rules without traffic have no samples
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("No requests matched in this window")
+
+	ui.typeText("q")
+	ui.waitForGone("requests no rule terminated")
+}
+
+func TestAppTrustedAdvisor(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("Trusted Advisor")
+	for i := 0; i < 13; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor("9 checks, 1 action")
+	for _, want := range []string{"Low Utilization Amazon EC2", "Fault Tolerance", "Security Groups - Specific"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q among the checks, screen:\n%s", want, screen)
+		}
+	}
+	// Performance and service limit checks are left out
+	if strings.Contains(screen, "High Utilization Amazon EC2") {
+		t.Errorf("Expected only cost, fault tolerance and security checks, screen:\n%s", screen)
+	}
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("Low Utilization")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: Qch7DwouX1")
+	ui.key(tcell.KeyEnter)
+
+	screen = ui.waitFor("batch-worker")
+	for _, want := range []string{"Average CPU Utilization", "jump-host", "$27.01"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q among the flagged resources, screen:\n%s", want, screen)
+		}
+	}
+
+	ui.typeText("q")
+	ui.waitForGone("batch-worker")
+}
+
+func TestAppHealth(t *testing.T) {
+	ui := startTestUI(t)
+
+	// The open issues of the account are announced when the client is set
+	ui.waitFor("AWS Health: EC2 issue in us-east-1 (and 1 more)")
+
+	ui.typeText("2")
+	ui.waitFor("Health Events")
+	for i := 0; i < 14; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor("5 events, 2 open issues")
+	for _, want := range []string{"AWS_S3_OPERATIONAL_ISSUE", "Scheduled Change", "AWS_LAMBDA_PLANNED_LIFECYCLE"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q among the events, screen:\n%s", want, screen)
+		}
+	}
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("EC2_OPERATIONAL")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: 7XK2Q")
+	ui.key(tcell.KeyEnter)
+
+	screen = ui.waitFor(" Affected Resources (2) ")
+	for _, want := range []string{"increased API error rates", "i-0c34d56e78f90a123", "IMPAIRED", "UNIMPAIRED"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the event view, screen:\n%s", want, screen)
+		}
+	}
+
+	ui.typeText("q")
+	ui.waitForGone(" Affected Resources (2) ")
+}
+
+func TestAppLambdaTraffic(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("Lambda Functions")
+	for i := 0; i < 3; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("orders-api")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: orders-api")
+
+	// live is mid canary, staging sends everything to the newest version
+	ui.typeText("t")
+	ui.waitUntil("the aliases", func(screen string) bool {
+		return strings.Contains(screen, "90%") && strings.Contains(screen, "10%") && strings.Contains(screen, "staging")
+	})
+
+	ui.typeText("b")
+	ui.waitFor("Rolled back version 4: live sends all invocations to version 3")
+	ui.waitForGone("90%")
+
+	// Route 10% to version 4 again: Version 3, Canary 4
+	ui.typeText("w")
+	ui.waitFor(" Traffic of live (Canary none: all to Version) ")
+	ui.key(tcell.KeyTab)
+	ui.key(tcell.KeyEnter)
+	for i := 0; i < 4; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.key(tcell.KeyTab)
+	ui.key(tcell.KeyTab)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("live sends 90% to version 3 and 10% to version 4")
+	ui.waitFor("90%")
+
+	ui.typeText("p")
+	ui.waitFor("Promoted version 4: live sends all invocations to it")
+	ui.waitForGone("90%")
+	aliases, err := ui.app.awsClient.GetClients().Lambda.ListAliases(context.Background(), "orders-api")
+	if err != nil || aliases[0].Version != "4" || aliases[0].AdditionalVersion != "" {
+		t.Errorf("Expected live to send everything to version 4, got %+v (%v)", aliases, err)
+	}
+
+	ui.typeText("q")
+	ui.waitForGone("Promoted version 4")
+}
+
+func TestAppStackDrift(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 22; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (8)")
+	ui.waitFor("8 stacks, 1 drifted")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("shop-web")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: shop-web")
+
+	// The deleted parameter comes first, then the modified resources
+	ui.typeText("d")
+	ui.waitFor("shop-web drifted: 3 resources differ from the template")
+	ui.waitFor("The resource was deleted outside CloudFormation")
+
+	ui.key(tcell.KeyDown)
+	screen := ui.waitFor("WebAutoScalingGroup (AWS::AutoScaling::AutoScalingGroup)")
+	for _, want := range []string{"NOT_EQUAL /MaxSize", "- expected: 6", "+ actual:   12", "REMOVE    /Tags/2"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the differences, screen:\n%s", want, screen)
+		}
+	}
+	ui.key(tcell.KeyDown)
+	ui.waitFor("+ actual:   0.0.0.0/0")
+
+	ui.typeText("q")
+	ui.waitForGone(" Property Differences ")
+	ui.waitFor("8 stacks, 2 drifted")
+}
+
+func TestAppNestedStacks(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 22; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (8)")
+
+	// Opened directly, a nested stack shows the stacks it is nested in
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("Cache")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("Type: Nested Stack")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" shop-platform > shop-platform-Web-1QX2Z3ABCDEF > shop-platform-Web-1QX2Z3ABCDEF-Cache-9KJ8H7GFEDCB (2) ")
+	ui.waitFor("CacheCluster")
+
+	// Up to the parent with the nested stack selected, and down again
+	ui.key(tcell.KeyBackspace2)
+	ui.waitFor(" shop-platform > shop-platform-Web-1QX2Z3ABCDEF (3) ")
+	ui.waitFor("AssetsBucket")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" shop-platform > shop-platform-Web-1QX2Z3ABCDEF > shop-platform-Web-1QX2Z3ABCDEF-Cache-9KJ8H7GFEDCB (2) ")
+
+	ui.key(tcell.KeyBackspace2)
+	ui.waitFor(" shop-platform > shop-platform-Web-1QX2Z3ABCDEF (3) ")
+	ui.key(tcell.KeyBackspace2)
+	screen := ui.waitFor(" shop-platform (3) ")
+	if !strings.Contains(screen, "WebStack >") || !strings.Contains(screen, "shop-platform-Web-1QX2Z3ABCDEF") {
+		t.Errorf("Expected the nested stack named in the root stack, screen:\n%s", screen)
+	}
+	ui.key(tcell.KeyBackspace2)
+	ui.waitForGone(" shop-platform (3) ")
+	ui.waitFor("Type: Nested Stack")
+}
+
+func TestAppStackSets(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 23; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (2)")
+	ui.waitFor("2 StackSets, 1 drifted")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: org-baseline")
+	ui.key(tcell.KeyEnter)
+	screen := ui.waitFor("6 instances in 3 accounts and 2 regions")
+	for _, want := range []string{"ou-ab12-dev56789", "outdated", "failed", "CloudTrailBucket", "drifted"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the instances, screen:\n%s", want, screen)
+		}
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("is in another account or region")
+
+	// The reason of a failed operation is shown in full
+	for i := 0; i < 4; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.waitFor("org-trail-333333333333 already exists.")
+	ui.typeText("q")
+	ui.waitForGone("6 instances in 3 accounts")
+
+	// The stack of an instance in the account and region of the tab opens
+	// below its StackSet
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: shop-guardrails")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("2 instances in 1 account and 2 regions")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" StackSet shop-guardrails > StackSet-shop-guardrails-5e6f7a8b (2) ")
+	ui.waitFor("RequiredTagsRule")
+
+	ui.key(tcell.KeyBackspace2)
+	ui.waitFor("2 instances in 1 account and 2 regions")
+	ui.waitForGone("RequiredTagsRule")
+}
+
+func TestAppLambdaConcurrency(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("Lambda Functions")
+	for i := 0; i < 3; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("orders-worker")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: orders-worker")
+
+	ui.typeText("c")
+	screen := ui.waitFor("Reserved concurrency: 10 (")
+	for _, want := range []string{"Provisioned concurrency:", "Aliases: live", "Concurrent executions (max)", "Throttles (sum)"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the concurrency panel, screen:\n%s", want, screen)
+		}
+	}
+
+	// Provisioned concurrency may not exceed the reserved concurrency
+	ui.typeText("a")
+	ui.waitFor(" Provisioned Concurrency: choose an alias (q: cancel) ")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Provisioned Concurrency of live (0 removes it) ")
+	ui.key(tcell.KeyBackspace2)
+	ui.typeText("20")
+	ui.key(tcell.KeyEnter)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("should not be greater than the reservedConcurrentExecution")
+
+	ui.typeText("c")
+	ui.waitFor(" Reserved Concurrency (empty: unreserved, 0: throttle all) ")
+	for i := 0; i < 2; i++ {
+		ui.key(tcell.KeyBackspace2)
+	}
+	ui.typeText("50")
+	ui.key(tcell.KeyEnter)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("Reserved concurrency set to 50")
+	ui.waitFor("Reserved concurrency: 50 (")
+
+	ui.typeText("a")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Provisioned Concurrency of live (0 removes it) ")
+	ui.key(tcell.KeyBackspace2)
+	ui.typeText("20")
+	ui.key(tcell.KeyEnter)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("Provisioned concurrency of live set to 20")
+	ui.waitFor("20 requested, 20 allocated")
+
+	ui.typeText("q")
+	ui.waitForGone("Reserved concurrency: 50 (")
+}
+
+func TestAppDynamoDB(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 15; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor(" Resources (3)")
+	for _, want := range []string{"orders", "sessions", "inventory", "On-demand Table (1 LSI)", "Items (Size)", "15032 (3.0 MB)"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q among the tables, screen:\n%s", want, screen)
+		}
+	}
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("inventory")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	screen = ui.waitFor("ID: inventory")
+	for _, want := range []string{"Partition Key: sku (string)", "Sort Key: warehouse (string)", "Table Class: standard infreq"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the details, screen:\n%s", want, screen)
+		}
+	}
+	ui.key(tcell.KeyEnter)
+
+	// The undersized table throttles and has no auto scaling
+	screen = ui.waitFor("none, the provisioned capacity is fixed")
+	for _, want := range []string{" Capacity inventory - last 3h ", "of 5 provisioned", "Read throttle events"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the capacity view, screen:\n%s", want, screen)
+		}
+	}
+	ui.typeText("q")
+	ui.waitForGone(" Capacity inventory")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	for i := 0; i < len("inventory"); i++ {
+		ui.key(tcell.KeyBackspace2)
+	}
+	ui.typeText("orders")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: orders")
+	ui.key(tcell.KeyEnter)
+
+	screen = ui.waitFor("index by-customer")
+	if !strings.Contains(screen, "target 70% DynamoDBWriteCapacityUtilization") {
+		t.Errorf("Expected the write scaling policy of orders, screen:\n%s", screen)
+	}
+	ui.typeText("t")
+	ui.waitFor(" Capacity orders - last 12h ")
+}
+
+func TestAppSNSFlow(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 16; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor(" Resources (2)")
+	for _, want := range []string{"orders-events", "ops-alerts", "SNS Topic"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q among the topics, screen:\n%s", want, screen)
+		}
+	}
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("orders-events")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: orders-events")
+	ui.key(tcell.KeyEnter)
+
+	// The shipping queue backs up behind its consumer
+	screen = ui.waitFor("◀ piling up")
+	for _, want := range []string{" Message flow orders-events - last 1h ", "SQS orders-shipping", "Lambda orders-worker", "DLQ orders-billing-dlq", "Lambda orders-api"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the message flow, screen:\n%s", want, screen)
+		}
+	}
+	ui.typeText("q")
+	ui.waitForGone(" Message flow orders-events")
+}
+
+func TestAppS3ObjectJobs(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("S3 Buckets")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("acme-order-exports")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("acme-order-exports")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("BucketName: acme-order-exports")
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor(" s3://acme-order-exports/ (3)")
+	for _, want := range []string{"archive/", "exports/", "legal-hold/"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q among the prefixes, screen:\n%s", want, screen)
+		}
+	}
+
+	// Copy exports/ to a new prefix
+	ui.key(tcell.KeyDown)
+	ui.typeText("c")
+	ui.waitFor(" Copy s3://acme-order-exports/exports/ ")
+	ui.key(tcell.KeyEnter)
+	for i := 0; i < len("exports/"); i++ {
+		ui.key(tcell.KeyBackspace2)
+	}
+	ui.typeText("exports-copy/")
+	ui.key(tcell.KeyEnter)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("Done: Copy s3://acme-order-exports/exports/")
+	ui.waitFor("exports-copy/")
+
+	// Deleting a prefix needs the prefix typed
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyDown)
+	ui.typeText("d")
+	ui.waitFor(" Delete every object below s3://acme-order-exports/legal-hold/ ")
+	ui.typeText("legal-hold/")
+	ui.key(tcell.KeyEnter)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("Failed: Delete s3://acme-order-exports/legal-hold/")
+
+	ui.typeText("J")
+	screen = ui.waitFor(" Jobs ")
+	for _, want := range []string{"succeeded", "18/18 (100%)", "failed", "access denied"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the jobs, screen:\n%s", want, screen)
+		}
+	}
+	ui.typeText("q")
+	ui.waitForGone(" Jobs ")
+	ui.typeText("q")
+	ui.waitForGone("d: delete, c: copy")
+}
+
+func TestAppS3ObjectPreview(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("S3 Buckets")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("acme-web-assets")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("acme-web-assets")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("BucketName: acme-web-assets")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" s3://acme-web-assets/ (")
+
+	// config/ is the first prefix; site.json follows feature-flags.yaml
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" s3://acme-web-assets/config/ (2)")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor("── JSON ──")
+	for _, want := range []string{"Preview s3://acme-web-assets/config/site.json", "application/json", `"regions": [`} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the preview, screen:\n%s", want, screen)
+		}
+	}
+	ui.typeText("q")
+	ui.waitForGone("── JSON ──")
+	ui.typeText("q")
+	ui.waitForGone(" s3://acme-web-assets/config/")
+}
+
+func TestAppEKSWorkloads(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 17; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (2)")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("shop-prod")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: shop-prod")
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor("cart-7d9f8b6c5-vb4rm")
+	for _, want := range []string{" Workloads of shop-prod ", "CrashLoopBackOff", "payments-worker", "coredns", "Critical/High", "1/2", "scanning"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the workloads, screen:\n%s", want, screen)
+		}
+	}
+
+	// Open the shop namespace: All namespaces, default, kube-system, payments, shop
+	for i := 0; i < 4; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone("coredns")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyDown)
+	ui.typeText("l")
+
+	screen = ui.waitFor("panic: runtime error")
+	if !strings.Contains(screen, "Kubernetes Logs") || !strings.Contains(screen, "vb4rm in shop-prod") {
+		t.Errorf("Expected the pod named in the status, screen:\n%s", screen)
+	}
+}
+
+func TestAppECSImageFindings(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 4; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	screen := ui.waitFor(" Resources (4)")
+	for _, want := range []string{"Critical/High", "storefront", "2/7", "0/3", "not scanned"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the services, screen:\n%s", want, screen)
+		}
+	}
+	ui.waitFor("4 services")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("storefront")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	screen = ui.waitFor("ID: shop/storefront")
+	for _, want := range []string{"Flag: runs images with 2 known", "log-router: not in"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the details, screen:\n%s", want, screen)
+		}
+	}
+}
+
+func TestAppECSTasks(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 4; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (4)")
+	ui.waitFor("degraded, 1 run")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("reports")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	screen := ui.waitFor("ID: shop/reports")
+	if !strings.Contains(screen, "runs 0 of 1 desired") {
+		t.Errorf("Expected the missing task flagged, screen:\n%s", screen)
+	}
+
+	// The pending task is listed first, then the two that crashed
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Tasks of shop/reports ")
+	ui.waitFor("PENDING")
+	ui.key(tcell.KeyDown)
+	screen = ui.waitFor("OutOfMemoryError")
+	for _, want := range []string{"Essential container in task exited", "exit code 1"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the containers, screen:\n%s", want, screen)
+		}
+	}
+
+	ui.typeText("q")
+	ui.waitForGone(" Tasks of shop/reports ")
+}
+
+func TestAppIAMRoles(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 20; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	screen := ui.waitFor(" Resources (7)")
+	for _, want := range []string{"legacy-etl-role", "Service-linked Role", "unused", "new"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the roles, screen:\n%s", want, screen)
+		}
+	}
+	ui.waitFor("7 roles, 2 unused")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("partner-audit")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	screen = ui.waitFor("ID: partner-audit")
+	if !strings.Contains(screen, "Flag: unused for 143 days") {
+		t.Errorf("Expected the role flagged in the details, screen:\n%s", screen)
+	}
+	ui.key(tcell.KeyEnter)
+
+	screen = ui.waitFor(" Services Last Accessed (2 of 3 used) ")
+	for _, want := range []string{" partner-audit - Trust Policy ", "AWS: arn:aws:iam::999988887777:root", "sts:ExternalId", "AWS CloudTrail", "never"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the view, screen:\n%s", want, screen)
+		}
+	}
+	ui.typeText("q")
+	ui.waitForGone(" Services Last Accessed ")
+}
+
+func TestAppS3Exposure(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 21; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	screen := ui.waitFor(" Resources (5)")
+	for _, want := range []string{"acme-web-assets", "public", "cross-account", "unblocked", "private"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the buckets, screen:\n%s", want, screen)
+		}
+	}
+	screen = ui.waitFor("1 public")
+	if !strings.Contains(screen, "acme-security-audit") {
+		t.Errorf("Expected the bucket that could not be read in the warnings, screen:\n%s", screen)
+	}
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("acme-order-exports")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	screen = ui.waitFor("ID: acme-order-exports")
+	for _, want := range []string{"Flag: shared with other", "Analyzer: AWS: 999988887777"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the details, screen:\n%s", want, screen)
+		}
+	}
+}
+
+func TestAppRDSPerformanceInsights(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (2)")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("orders-prod")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: orders-prod")
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor("Lock:transactionid")
+	for _, want := range []string{" Performance Insights orders-prod - last 3h ", "customer_email", "IO:DataFileRead"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the view, screen:\n%s", want, screen)
+		}
+	}
+
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" SQL Statement (q: close) ")
+	ui.typeText("q")
+	ui.waitForGone(" SQL Statement (q: close) ")
+
+	ui.typeText("t")
+	ui.waitFor(" Performance Insights orders-prod - last 12h ")
+}
+
+func TestAppRDSParameterGroups(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 18; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (3)")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("orders-postgres16")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: orders-postgres16")
+	ui.key(tcell.KeyEnter)
+
+	screen := ui.waitFor("shared_preload_libraries")
+	for _, want := range []string{" Parameter group orders-postgres16 vs default.postgres16 ", "orders-prod (pending-reboot)", "reboot pending", "same as default"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the view, screen:\n%s", want, screen)
+		}
+	}
+}
+
+func TestAppNATGateways(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 19; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	screen := ui.waitFor(" Resources (3)")
+	if strings.Contains(screen, "legacy-nat") {
+		t.Errorf("Expected deleted gateways to be left out, screen:\n%s", screen)
+	}
+	// The busiest gateway comes first
+	if busy, quiet := strings.Index(screen, "data-pipeline-nat"), strings.Index(screen, "shop-nat-a"); busy < 0 || quiet < busy {
+		t.Errorf("Expected data-pipeline-nat above shop-nat-a, screen:\n%s", screen)
+	}
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("data-pipeline")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	screen = ui.waitFor("ID: nat-0a1b2c3d4e5f60713")
+	for _, want := range []string{"$1294.29", "VPC endpoint"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q on screen:\n%s", want, screen)
+		}
+	}
+}
+
+func TestAppResourceSearch(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Resources (5)")
+
+	ui.key(tcell.KeyCtrlF)
+	ui.waitFor(" Search loaded resources ")
+	ui.typeText("worker")
+	screen := ui.waitFor("1 matches in")
+	if !strings.Contains(screen, "i-0c34d56e78f90a123") {
+		t.Errorf("Expected worker-1 in the results, screen:\n%s", screen)
+	}
+
+	// Enter shows the instance in the EC2 listing
+	ui.key(tcell.KeyEnter)
+	screen = ui.waitFor("ID: i-0c34d56e78f90a123")
+	if strings.Contains(screen, " Search loaded resources ") || !strings.Contains(screen, " Resources (5)") {
+		t.Errorf("Expected the EC2 listing without the search, screen:\n%s", screen)
+	}
+
+	// Esc closes the search without quitting
+	ui.key(tcell.KeyCtrlF)
+	ui.waitFor(" Search loaded resources ")
+	ui.key(tcell.KeyEscape)
+	ui.waitForGone(" Search loaded resources ")
+}
+
+func TestAppResourceQueryFilter(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("state:running AND tag.team:web")
+	screen := ui.waitFor(" Resources (2 of 5)")
+	if !strings.Contains(screen, "web-1") || !strings.Contains(screen, "web-2") {
+		t.Errorf("Expected web-1 and web-2, screen:\n%s", screen)
+	}
+
+	// Typing on into an incomplete query tells what is wrong
+	ui.typeText(" AND")
+	ui.waitFor("Invalid filter: expected a")
+	ui.typeText(" NOT name:web-1")
+	screen = ui.waitFor(" Resources (1 of 5)")
+	if !strings.Contains(screen, "web-2") || strings.Contains(screen, "i-0a12b34c56d78e901") {
+		t.Errorf("Expected only web-2, screen:\n%s", screen)
+	}
+}
+
+func TestAppFilterHistory(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+
+	for _, filter := range []string{"web", "state:stopped"} {
+		ui.typeText("f")
+		ui.waitFor(" Filter Resources (Active) ")
+		ui.typeText(filter)
+		ui.key(tcell.KeyEnter)
+		ui.waitForGone(" Filter Resources (Active) ")
+		ui.app.app.QueueUpdateDraw(func() { ui.app.resourcesTab.filterInput.SetText("") })
+		ui.waitFor(" Resources (5)")
+	}
+
+	// Up recalls the newest filter first, down goes back to what was typed
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("bas")
+	ui.key(tcell.KeyUp)
+	ui.waitFor(" Resources (2 of 5)")
+	ui.waitFor("Filter: state:stopped")
+	ui.key(tcell.KeyUp)
+	ui.waitFor("Filter: web")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyDown)
+	ui.waitFor("Filter: bas")
+	ui.waitFor(" Resources (1 of 5)")
+
+	// The history is kept for the next session
+	store := history.New(filepath.Join(os.Getenv("HOME"), ".swiss-army-tui", "history.json"), history.DefaultLimit)
+	if err := store.Load(); err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	if got := store.Entries("resources"); len(got) != 2 || got[0] != "web" || got[1] != "state:stopped" {
+		t.Errorf("Expected the filters to be saved, got %v", got)
+	}
+}
+
+func TestAppOpenTarget(t *testing.T) {
+	ui := startTestUI(t)
+
+	for _, spec := range []string{"", "logs", "sqs:queue", "bookmark:missing"} {
+		if err := ui.app.Open(spec); err == nil {
+			t.Errorf("Expected an error for %q", spec)
+		}
+	}
+
+	if err := ui.app.Open("ec2:i-0c34d56e78f90a123"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// The instance is selected as soon as its page is shown
+	ui.waitFor("ID: i-0c34d56e78f90a123")
+	ui.waitFor(" Resources (5)")
+
+	if err := ui.app.Open("logs:/aws/lambda/orders-api"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ui.waitUntil("the CloudWatch logs of the group", func(screen string) bool {
+		return strings.Contains(screen, "Loaded ") && strings.Contains(screen, " Log Sources ") &&
+			ui.app.logsTab.ActiveLogGroup() == "/aws/lambda/orders-api"
+	})
+}
+
+func TestAppBookmarks(t *testing.T) {
+	ui := startTestUI(t)
+
+	if err := ui.app.Open("ec2:i-0c34d56e78f90a123"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ui.waitFor("ID: i-0c34d56e78f90a123")
+
+	// The name of the selected resource is suggested
+	ui.key(tcell.KeyCtrlB)
+	ui.waitFor("No bookmarks yet")
+	ui.typeText("a")
+	ui.waitFor("Name: worker-1")
+	ui.key(tcell.KeyEnter)
+	screen := ui.waitFor("ec2:i-0c34d56e78f90a123")
+	if !strings.Contains(screen, "Enter: open") {
+		t.Errorf("Expected the bookmark in the list, screen:\n%s", screen)
+	}
+	ui.key(tcell.KeyEscape)
+	ui.waitForGone("Enter: open")
+
+	// Opening the bookmark from another service shows the instance again
+	if err := ui.app.Open("s3"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ui.waitForGone("ID: i-0c34d56e78f90a123")
+	if err := ui.app.Open("bookmark:Worker-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ui.waitFor("ID: i-0c34d56e78f90a123")
+
+	// A new session sees the bookmark and can delete it
+	reloaded := bookmarks.New(bookmarks.DefaultPath())
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := reloaded.Find("worker-1"); !ok {
+		t.Errorf("Expected the bookmark to be saved, got %v", reloaded.List())
+	}
+	ui.key(tcell.KeyCtrlB)
+	ui.waitFor("Enter: open")
+	ui.typeText("d")
+	ui.waitFor("No bookmarks yet")
+}
+
+func TestAppConfirmSwitchWithActiveWork(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.app.app.QueueUpdateDraw(func() {
+		ui.app.settingsTab.markModified()
+	})
+	ui.app.events.Publish(RegionChangedEvent{Region: "eu-west-1"})
+	screen := ui.waitFor("Switching to region eu-west-1 stops:")
+	if !strings.Contains(screen, "unsaved changes in the Settings tab") {
+		t.Errorf("Expected the unsaved settings in the confirmation, screen:\n%s", screen)
+	}
+
+	// Esc keeps the current region instead of quitting
+	ui.key(tcell.KeyEscape)
+	ui.waitForGone("Switching to region eu-west-1 stops:")
+	ui.waitFor("Switch cancelled")
+
+	ui.app.events.Publish(RegionChangedEvent{Region: "eu-west-1"})
+	ui.waitFor("Switching to region eu-west-1 stops:")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("Region switching is disabled in demo mode")
+}
+
+func TestAppRecording(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.key(tcell.KeyCtrlO)
+	ui.waitFor("● REC")
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyCtrlO)
+	screen := ui.waitFor("Recording saved to")
+	if strings.Contains(screen, "● REC") {
+		t.Errorf("Expected the recording to stop, screen:\n%s", screen)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(os.Getenv("HOME"), ".swiss-army-tui", "recordings", "*.cast"))
+	if err != nil || len(paths) != 1 {
+		t.Fatalf("Expected one recording, got %v (%v)", paths, err)
+	}
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	var header recording.Header
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil || header.Version != 2 || header.Width != 160 || header.Height != 45 {
+		t.Fatalf("Unexpected header %s (%v)", lines[0], err)
+	}
+	var typed, drawn bool
+	for _, line := range lines[1:] {
+		var event []interface{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil || len(event) != 3 {
+			t.Fatalf("Unexpected event %s (%v)", line, err)
+		}
+		typed = typed || (event[1] == recording.Input && event[2] == "2")
+		drawn = drawn || (event[1] == recording.Output && strings.Contains(event[2].(string), "EC2 Instances"))
+	}
+	if !typed || !drawn {
+		t.Errorf("Expected the key 2 and the Resources tab drawn, got key %v, frame %v", typed, drawn)
+	}
+}
+
+func TestAppOfflineSnapshot(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+	ui.key(tcell.KeyCtrlT)
+	ui.waitFor("Snapshot of 1 listings saved")
+
+	paths, err := filepath.Glob(filepath.Join(os.Getenv("HOME"), ".swiss-army-tui", "snapshots", "*.json"))
+	if err != nil || len(paths) != 1 {
+		t.Fatalf("Expected one snapshot, got %v (%v)", paths, err)
+	}
+	snap, err := snapshot.Load(paths[0])
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The snapshot is browsed without any EC2 client
+	offline := startTestUIWith(t, func(app *App) { app.EnableOfflineMode(snap, paths[0]) })
+	offline.waitFor("Offline: " + filepath.Base(paths[0]))
+	offline.typeText("2")
+	offline.waitFor("EC2 Instances")
+	offline.key(tcell.KeyEnter)
+	screen := offline.waitFor("Loaded 5 ec2 resources from")
+	if !strings.Contains(screen, "web-1") {
+		t.Errorf("Expected web-1 from the snapshot, screen:\n%s", screen)
+	}
+
+	offline.typeText("f")
+	offline.waitFor(" Filter Resources (Active) ")
+	offline.key(tcell.KeyEnter)
+	offline.waitForGone(" Filter Resources (Active) ")
+	offline.key(tcell.KeyDown)
+	offline.waitFor("ID: i-0a12b34c56d78e901")
+	offline.typeText("p")
+	offline.waitFor("Not available offline")
+
+	if err := offline.app.Open("s3"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	offline.waitFor("No s3 listing in the")
+}
+
+func TestAppScheduledActions(t *testing.T) {
+	ui := startTestUI(t)
+	ui.app.awsClient.GetClients().EC2.(*fake.EC2Service).SetTransitionDelay(0)
+
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("web")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: i-0a12b34c56d78e901")
+
+	ui.typeText("S")
+	ui.waitFor(" Schedule: choose an action (q: cancel) ")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" When? ")
+	ui.typeText("+30m")
+	ui.key(tcell.KeyEnter)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("Scheduled: Stop 2")
+
+	// The schedule is saved for later sessions and listed with the jobs
+	saved := schedule.New(schedule.DefaultPath())
+	if err := saved.Load(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	pending := saved.List()
+	if len(pending) != 1 || pending[0].Action != schedule.ActionStopInstances || len(pending[0].Targets) != 2 {
+		t.Fatalf("Expected a saved stop of both web instances, got %+v", pending)
+	}
+	ui.typeText("J")
+	ui.waitFor(" Jobs (")
+	screen := ui.waitFor("scheduled")
+	if !strings.Contains(screen, "Stop 2 instances in") {
+		t.Errorf("Expected the pending schedule, screen:\n%s", screen)
+	}
+
+	// Once due it runs as a job, and schedules missed by far fail instead
+	if _, err := ui.app.resourcesTab.schedules.Add(schedule.Schedule{
+		Action:  schedule.ActionStartInstances,
+		Profile: fake.Profile,
+		Region:  fake.Region,
+		Targets: []string{"i-0c34d56e78f90a123"},
+		At:      time.Now().Add(-3 * time.Hour),
+	}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ui.app.resourcesTab.runDueSchedules(time.Now().Add(time.Hour))
+	screen = ui.waitFor("2/2 (100%)")
+	if !strings.Contains(screen, "missed, it was due") {
+		t.Errorf("Expected the old schedule to be missed, screen:\n%s", screen)
+	}
+	ui.waitForGone("scheduled ")
+	if list := ui.app.resourcesTab.schedules.List(); len(list) != 0 {
+		t.Errorf("Expected no pending schedules, got %+v", list)
+	}
+}
+
+func TestAppScheduledScaleToZero(t *testing.T) {
+	ui := startTestUI(t)
+	groups := ui.app.awsClient.GetClients().AutoScaling.(*fake.AutoScalingService)
+	capacity := func(name string) clients.AutoScalingGroup {
+		list, _ := groups.ListGroups(context.Background())
+		for _, group := range list {
+			if group.Name == name {
+				return group
+			}
+		}
+		return clients.AutoScalingGroup{}
+	}
+
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: i-")
+
+	// The groups of the region are picked after the instance actions
+	ui.typeText("S")
+	ui.waitFor(" Schedule: choose an action (q: cancel) ")
+	for i := 0; i < 4; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("[ ] shop-web-asg  (min 2, max 6, desired 3)")
+	ui.typeText(" ")
+	ui.waitFor("[x] shop-web-asg")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Scale 1 group to 0 ")
+	ui.typeText("+30m")
+	ui.key(tcell.KeyEnter)
+	ui.typeText("+3h")
+	ui.key(tcell.KeyEnter)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("Scheduled: Scale to 0")
+
+	pending := ui.app.resourcesTab.schedules.List()
+	if len(pending) != 2 || pending[0].Action != schedule.ActionScaleToZero || pending[1].Action != schedule.ActionRestoreCapacity ||
+		pending[0].Restore != pending[1].ID || pending[1].At.Sub(pending[0].At) != 3*time.Hour {
+		t.Fatalf("Expected a scale to 0 paired with a restore 3 hours later, got %+v", pending)
+	}
+
+	// The scale saves the capacity of the group in its restore
+	now := time.Now()
+	ui.app.resourcesTab.runDueSchedules(now.Add(time.Hour))
+	ui.waitUntil("the group scaled to 0", func(string) bool {
+		return capacity("shop-web-asg").Max == 0 && ui.app.resourcesTab.jobs.Running() == 0
+	})
+	if worker := capacity("shop-worker-asg"); worker.Desired != 2 {
+		t.Errorf("Expected the worker group untouched, got %+v", worker)
+	}
+	restore := ui.app.resourcesTab.schedules.List()
+	if len(restore) != 1 || restore[0].Capacities["shop-web-asg"] != (schedule.Capacity{Min: 2, Max: 6, Desired: 3}) {
+		t.Fatalf("Expected the capacity saved in the restore, got %+v", restore)
+	}
+
+	ui.app.resourcesTab.runDueSchedules(now.Add(4 * time.Hour))
+	ui.waitUntil("the capacity restored", func(string) bool { return capacity("shop-web-asg").Desired == 3 })
+	if web := capacity("shop-web-asg"); web.Min != 2 || web.Max != 6 {
+		t.Errorf("Expected the capacity restored, got %+v", web)
+	}
+}
+
+func TestAppScheduledScaleAndRestoreDueTogether(t *testing.T) {
+	ui := startTestUI(t)
+	rt := ui.app.resourcesTab
+	groups := ui.app.awsClient.GetClients().AutoScaling.(*fake.AutoScalingService)
+	capacity := func(name string) clients.AutoScalingGroup {
+		list, _ := groups.ListGroups(context.Background())
+		for _, group := range list {
+			if group.Name == name {
+				return group
+			}
+		}
+		return clients.AutoScalingGroup{}
+	}
+	if err := groups.SetCapacity(context.Background(), "shop-worker-asg", 0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	client := ui.app.awsClient
+	now := time.Now()
+	if _, _, err := rt.schedules.AddScaleToZero(
+		schedule.Schedule{Profile: client.GetProfile(), Region: client.GetRegion(), Targets: []string{"shop-web-asg", "shop-worker-asg"}, At: now},
+		schedule.Schedule{Profile: client.GetProfile(), Region: client.GetRegion(), At: now.Add(time.Minute)},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both are due after a pause: the restore waits for the scale to finish
+	rt.runDueSchedules(now.Add(5 * time.Minute))
+	ui.waitUntil("the scale finished", func(string) bool { return rt.jobs.Running() == 0 })
+	if web := capacity("shop-web-asg"); web.Max != 0 {
+		t.Fatalf("Expected the group scaled to 0, got %+v", web)
+	}
+	if pending := rt.schedules.List(); len(pending) != 1 || pending[0].Action != schedule.ActionRestoreCapacity {
+		t.Fatalf("Expected the restore still pending, got %+v", pending)
+	}
+
+	// The group already at 0 was saved with the others and stays at 0
+	rt.runDueSchedules(now.Add(5 * time.Minute))
+	ui.waitUntil("the restore finished", func(string) bool { return rt.jobs.Running() == 0 })
+	for _, job := range rt.jobs.Jobs() {
+		if job.State != jobs.StateSucceeded {
+			t.Errorf("Expected %q to succeed, got %s: %v", job.Title, job.State, job.Err)
+		}
+	}
+	if web := capacity("shop-web-asg"); web.Min != 2 || web.Max != 6 || web.Desired != 3 {
+		t.Errorf("Expected the capacity restored, got %+v", web)
+	}
+	if worker := capacity("shop-worker-asg"); worker.Max != 0 {
+		t.Errorf("Expected the worker group left at 0, got %+v", worker)
+	}
+}
+
+func TestAppInstanceGrouping(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+
+	ui.typeText("g")
+	screen := ui.waitFor(" Resources (5) by instance type in 4 groups")
+	if !strings.Contains(screen, "▸ t3.medium") || !strings.Contains(screen, "2 instances") || strings.Contains(screen, "web-1") {
+		t.Errorf("Expected collapsed instance type groups, screen:\n%s", screen)
+	}
+
+	// The largest group comes first; Enter drills into its instances
+	ui.key(tcell.KeyDown)
+	screen = ui.waitFor("On: 2 (100%)")
+	if !strings.Contains(screen, "$60.74") {
+		t.Errorf("Expected the cost of the group, screen:\n%s", screen)
+	}
+	ui.key(tcell.KeyEnter)
+	screen = ui.waitFor("▾ t3.medium")
+	if !strings.Contains(screen, "web-1") || !strings.Contains(screen, "web-2") || strings.Contains(screen, "worker-1") {
+		t.Errorf("Expected the t3.medium instances only, screen:\n%s", screen)
+	}
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: i-0a12b34c56d78e901")
+
+	ui.typeText("g")
+	screen = ui.waitFor(" Resources (5) by availability zone in 2 groups")
+	if !strings.Contains(screen, "us-east-1a") || !strings.Contains(screen, "2 on / 1 off") {
+		t.Errorf("Expected the zones with their on/off ratio, screen:\n%s", screen)
+	}
+	ui.typeText("g")
+	ui.waitFor(" Resources (5) by AMI in 1 group")
+
+	ui.typeText("g")
+	ui.waitFor(" Group by tag ")
+	ui.typeText("team")
+	ui.key(tcell.KeyEnter)
+	screen = ui.waitFor(" Resources (5) by tag team in 3 groups")
+	if !strings.Contains(screen, "(no tag team)") {
+		t.Errorf("Expected a group of untagged instances, screen:\n%s", screen)
+	}
+
+	// Jumping to an instance expands its group
+	if err := ui.app.Open("ec2:i-0c34d56e78f90a123"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ui.waitFor("▾ orders")
+	ui.waitFor("ID: i-0c34d56e78f90a123")
+
+	ui.typeText("g")
+	screen = ui.waitFor("Grouping off")
+	if strings.Contains(screen, " by tag team") || !strings.Contains(screen, "staging-app") {
+		t.Errorf("Expected the flat listing, screen:\n%s", screen)
+	}
+}
+
+func TestAppCompareResources(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: i-0a12b34c56d78e901")
+	// Compare the details loaded on highlight
+	ui.waitFor("10.0.1.10")
+
+	ui.typeText("m")
+	ui.waitFor(markedPrefix + "web-1")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: i-0b23c45d67e89f012")
+	ui.waitFor("10.0.2.10")
+	ui.typeText("m")
+	screen := ui.waitFor(" Compare web-1 and web-2: ")
+	for _, want := range []string{"PrivateIpAddress", "10.0.1.10", "10.0.2.10", "AvailabilityZone", "us-east-1b", "differences only"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the comparison, screen:\n%s", want, screen)
+		}
+	}
+	if strings.Contains(screen, "t3.medium") {
+		t.Errorf("Expected equal attributes to be hidden, screen:\n%s", screen)
+	}
+
+	ui.typeText("a")
+	ui.waitFor("all attributes")
+	ui.waitFor("t3.medium")
+	ui.typeText("q")
+	screen = ui.waitForGone(" Compare web-1 and web-2: ")
+	if strings.Contains(screen, markedPrefix) {
+		t.Errorf("Expected the mark to be cleared, screen:\n%s", screen)
+	}
+}
+
+func TestAppResponsiveLayout(t *testing.T) {
+	ui := startTestUI(t)
+	resize := func(width, height int) {
+		ui.screen.SetSize(width, height)
+		ui.screen.PostEvent(tcell.NewEventResize(width, height))
+	}
+
+	ui.typeText("2")
+	ui.waitFor("EC2 Instances")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+	ui.waitFor(" Resource Details ")
+
+	// Narrow terminals hide the details panel until i opens it
+	resize(110, 45)
+	ui.waitForGone(" Resource Details ")
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.typeText("i")
+	ui.waitFor(" Resource Details (q: close) ")
+	ui.waitFor("ID: i-0a12b34c56d78e901")
+	ui.typeText("q")
+	ui.waitForGone(" Resource Details (q: close) ")
+
+	// Very narrow ones stack the service list above the resources
+	resize(70, 45)
+	ui.waitUntil("stacked panels", func(screen string) bool {
+		for _, line := range strings.Split(screen, "\n") {
+			if strings.Contains(line, " AWS Services ") {
+				return !strings.Contains(line, " Resources (5)") && strings.Contains(screen, " Resources (5)")
+			}
+		}
+		return false
+	})
+
+	resize(160, 45)
+	ui.waitFor(" Resource Details ")
+}
+
+func TestAppLogGroupBrowser(t *testing.T) {
+	ui := startTestUI(t)
+	ui.waitFor("Connected to account: " + fake.Account)
+
+	ui.typeText("3")
+	ui.waitFor(" Log Sources ")
+	ui.typeText("o")
+	screen := ui.waitFor(" Log Groups (5+) ")
+	if !strings.Contains(screen, "14 days") || !strings.Contains(screen, "more log groups load") {
+		t.Errorf("Expected the first page with the retention, screen:\n%s", screen)
+	}
+
+	// Reaching the end loads the next page, with the group of the linked account
+	ui.typeText("G")
+	screen = ui.waitFor("999988887777 (linked)")
+	if strings.Contains(screen, "more log groups load") || !strings.Contains(screen, "never expires") {
+		t.Errorf("Expected all groups loaded, screen:\n%s", screen)
+	}
+
+	ui.typeText("/")
+	ui.typeText("/ecs")
+	ui.key(tcell.KeyEnter)
+	screen = ui.waitFor(" Log Groups (1) ")
+	if !strings.Contains(screen, "/ecs/orders-service") || strings.Contains(screen, "/aws/lambda/") {
+		t.Errorf("Expected only the groups with the prefix, screen:\n%s", screen)
+	}
+
+	ui.key(tcell.KeyEnter)
+	ui.waitUntil("the CloudWatch logs of the group", func(screen string) bool {
+		return strings.Contains(screen, "Loaded ") && ui.app.logsTab.ActiveLogGroup() == "/ecs/orders-service"
+	})
+}
+
+func TestAppLogGroupExport(t *testing.T) {
+	restore := exportPollInterval
+	exportPollInterval = 50 * time.Millisecond
+	defer func() { exportPollInterval = restore }()
+
+	ui := startTestUI(t)
+	ui.waitFor("Connected to account: " + fake.Account)
+
+	ui.typeText("3")
+	ui.waitFor(" Log Sources ")
+	ui.typeText("o")
+	ui.waitFor(" Log Groups (5+) ")
+
+	ui.typeText("x")
+	ui.waitFor(" Export /aws/batch/job")
+	ui.typeText("acme-log-archive")
+	for i := 0; i < 5; i++ {
+		ui.key(tcell.KeyEnter)
+	}
+	ui.waitFor("Started: Export /aws/batch/job")
+	ui.waitFor("Done: Export /aws/batch/job")
+}
+
+func TestAppWithFakes(t *testing.T) {
+	clock := &testClock{now: time.Date(2026, 10, 16, 9, 30, 0, 0, time.Local)}
+	ui, created := startFakeUI(t, clock)
+	screen := ui.waitFor("staging")
+	if !strings.Contains(screen, "production") || !strings.Contains(screen, "09:30:00") {
+		t.Errorf("Expected the fake profiles at the time of the clock, screen:\n%s", screen)
+	}
+
+	// As confirmed in the switch dialog
+	go ui.app.handleProfileChange(ProfileChangedEvent{Profile: "staging", Region: "eu-west-1"})
+	ui.waitFor("Connected to account: " + fake.Account)
+	if got := created(); len(got) != 1 || got[0] != "staging eu-west-1" {
+		t.Errorf("Expected a client created for staging, got %v", got)
+	}
+
+	// Notices last as long as the clock says
+	ui.app.app.QueueUpdateDraw(func() { ui.app.showNotice("Backup finished", "green") })
+	ui.waitFor("Backup finished")
+	clock.Add(noticeDuration)
+	ui.app.app.QueueUpdateDraw(ui.app.updateFooter)
+	ui.waitForGone("Backup finished")
+}
+
+func TestAppTransferServers(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 24; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (3)")
+	ui.waitFor("3 servers, 2 online")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("partner")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: s-4f1a9c2e7b3d40011")
+
+	ui.key(tcell.KeyEnter)
+	screen := ui.waitFor(" Users of partner-sftp: 3 users ")
+	for _, want := range []string{"wholesaler", "/shop-partner-inbox/marketplace", "none", "transfer-auditor"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the users, screen:\n%s", want, screen)
+		}
+	}
+	ui.typeText("q")
+	ui.waitForGone(" Users of partner-sftp")
+
+	// Users of servers with an identity provider of their own are not in
+	// Transfer Family
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	for range "partner" {
+		ui.key(tcell.KeyBackspace2)
+	}
+	ui.typeText("carrier")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: s-8c2e5a1f9d7b40022")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("managed by its identity provider (api gateway)")
+}
+
+func TestAppDataSyncExecutions(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 25; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (4)")
+	ui.waitFor("4 tasks, 1 running")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("efs-backup")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: task-0a1b2c3d4e5f60033")
+
+	// The failed execution comes first with its error below the table
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Executions of efs-backup: 2 executions, 1 failed, throughput ")
+	ui.waitFor("PermissionDenied: Transfer and verification failed")
+	ui.key(tcell.KeyDown)
+	ui.waitForGone("PermissionDenied: Transfer")
+	ui.typeText("q")
+	ui.waitForGone(" Executions of efs-backup")
+}
+
+func TestAppBatchJobs(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 26; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (3)")
+	ui.waitFor("3 queues, 3 jobs running")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("orders-high")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: orders-high")
+
+	// The runnable job comes first, then the running ones with their logs
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Jobs of orders-high: 1 runnable, 2 running, 1 failed ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("Logs: /aws/batch/job invoice-render/default/")
+
+	// The log stream of the job is shown even if it is not among the latest
+	ui.typeText("l")
+	ui.waitFor(" Log Sources ")
+	ui.waitFor("log entries from 4")
+	ui.waitForGone("Stream: All streams")
+
+	ui.typeText("2")
+	ui.waitFor(" Jobs of orders-high")
+	ui.typeText("x")
+	ui.waitFor("Terminate job render-invoices-1043?")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Jobs of orders-high: 1 runnable, 1 running, 2 failed ")
+	ui.typeText("q")
+	ui.waitForGone(" Jobs of orders-high")
+}
+
+func TestAppSageMakerIdleEndpoint(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 27; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (14)")
+	ui.waitFor("5 endpoints, 2 idle")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("image-tagger-staging")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("Invocations (24h): 0")
+
+	ui.typeText("s")
+	ui.waitFor("Delete endpoint image-tagger-staging?")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (0 of 13)")
+	ui.waitFor("4 endpoints, 1 idle")
+}
+
+func TestAppBedrockPrompt(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 28; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (8)")
+	ui.waitFor("8 models from 4 providers")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("haiku")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Prompt Claude 3 Haiku ")
+
+	ui.typeText("Name a color")
+	for i := 0; i < 3; i++ {
+		ui.key(tcell.KeyEnter)
+	}
+	ui.waitFor("Tokens: 4 in, 34 out, 38 total")
+	ui.waitFor("your prompt back: Name a color")
+
+	// Ask again with fewer tokens; the answer is cut
+	ui.typeText("p")
+	ui.waitFor(" Prompt Claude 3 Haiku ")
+	ui.key(tcell.KeyEnter)
+	for i := 0; i < 3; i++ {
+		ui.key(tcell.KeyBackspace2)
+	}
+	ui.typeText("8")
+	ui.key(tcell.KeyEnter)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("The answer was cut at 8 tokens")
+
+	ui.typeText("q")
+	ui.waitForGone("Stop reason")
+}
+
+func TestAppAppConfigDeploy(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 29; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (4)")
+	ui.waitFor("1 rolled back")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("checkout/feature")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("Env production: version 3")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("Changes from version 3 to 4:")
+	ui.waitFor(`+     "saved-carts": {`)
+
+	// Deploy version 4 to production, listed first, all at once
+	ui.typeText("d")
+	ui.waitFor("choose an environment")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("choose a strategy")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("Deploy version 4 of checkout/feature-flags")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone("Deploy version 4 of checkout/feature-flags")
+
+	ui.typeText("q")
+	ui.waitForGone("Changes from version 3 to 4:")
+	ui.waitFor("Env production: version 4")
+}
+
+func TestAppS3AbortUploads(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 30; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (5)")
+	ui.waitFor("5 buckets, 3 holding")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("replica")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("Incomplete Uploads: 3")
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("db/orders-2026-08-30.dump")
+	ui.waitFor("No lifecycle rule aborts")
+
+	// Abort the uploads older than 30 days
+	ui.typeText("a")
+	ui.waitFor(" Abort uploads of acme-backups-replica ")
+	for i := 0; i < 2; i++ {
+		ui.key(tcell.KeyBackspace2)
+	}
+	ui.typeText("30")
+	ui.key(tcell.KeyEnter)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("Abort 2 uploads of acme-backups-replica")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone("db/orders-2026-08-30.dump")
+	ui.waitFor("db/orders-2026-10-03.dump")
+
+	// Abort the others after a week from now on
+	ui.typeText("l")
+	ui.waitFor(" Abort rule of acme-backups-replica ")
+	ui.key(tcell.KeyEnter)
+	ui.key(tcell.KeyEnter)
+	ui.waitFor("A lifecycle rule aborts uploads 7 days after")
+
+	ui.typeText("q")
+	ui.waitForGone("A lifecycle rule aborts")
+	ui.waitFor("Incomplete Uploads: 1")
+}
+
+func TestAppCleanupDryRun(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 31; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (6)")
+	ui.waitFor("2 AMIs and 4 snapshots")
+
+	// A dry run of the cleanup of everything older than 90 days
+	ui.typeText("c")
+	ui.waitFor(" Clean up unused AMIs and snapshots ")
+	ui.key(tcell.KeyEnter)
+	ui.key(tcell.KeyTab)
+	ui.key(tcell.KeyEnter)
+	screen := ui.waitFor("nothing was changed")
+	for _, want := range []string{"over 1 year", "6 to 12 months", "refused, access denied", "2 AMIs and 2 snapshots (710 GB) would be cleaned up"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the report, screen:\n%s", want, screen)
+		}
+	}
+	if strings.Contains(screen, "snap-0e5f6a7b8c9d0e1f2") {
+		t.Errorf("Expected the recent snapshot left out, screen:\n%s", screen)
+	}
+
+	ui.typeText("d")
+	ui.waitFor("Deregister 2 AMIs and delete 5 snapshots?")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone("nothing was changed")
+	ui.waitFor(" Resources (2)")
+	ui.waitFor("0 AMIs and 2 snapshots")
+}
+
+func TestAppAlarmHistory(t *testing.T) {
+	ui := startTestUI(t)
+
+	ui.typeText("2")
+	ui.waitFor(" AWS Services ")
+	for i := 0; i < 32; i++ {
+		ui.key(tcell.KeyDown)
+	}
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" Resources (4)")
+	ui.waitFor("2 in alarm")
+
+	ui.typeText("f")
+	ui.waitFor(" Filter Resources (Active) ")
+	ui.typeText("health")
+	ui.key(tcell.KeyEnter)
+	ui.waitForGone(" Filter Resources (Active) ")
+	ui.key(tcell.KeyDown)
+	ui.waitFor("ID: orders-service-health")
+
+	// The rule is evaluated against the states of its alarms, and the
+	// overnight flapping shows in the history
+	ui.key(tcell.KeyEnter)
+	ui.waitFor(" History of orders-service-health ")
+	screen := ui.waitFor("7 transitions, went into alarm 4 times")
+	for _, want := range []string{
+		"ALARM(orders-api-5xx)  ALARM",
+		"NOT",
+		"OK(orders-worker-throttles)  INSUFFICIENT_DATA",
+		"|now",
+		"transitioned to OK",
+	} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Expected %q in the history, screen:\n%s", want, screen)
+		}
+	}
+
+	ui.typeText("w")
+	ui.waitFor(" Last 7d ")
+	ui.typeText("q")
+	ui.waitForGone(" History of orders-service-health ")
+}
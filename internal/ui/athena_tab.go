@@ -0,0 +1,688 @@
+package ui
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// athenaPageSize is how many result rows are fetched and shown at once
+const athenaPageSize = 100
+
+// athenaPollInterval is how often a running query is checked
+var athenaPollInterval = time.Second
+
+// athenaQueryTimeout is how long a query is followed before giving up on it
+const athenaQueryTimeout = 30 * time.Minute
+
+// AthenaTab runs SQL in Athena and pages through the results. The workgroup
+// and database are picked from lists on the left. All fields except
+// awsClient are only used on the UI goroutine.
+type AthenaTab struct {
+	view *tview.Pages
+	app  *tview.Application
+
+	workgroupList *tview.List
+	databaseList  *tview.List
+	editor        *tview.TextArea
+	resultTable   *tview.Table
+	statusText    *tview.TextView
+	panes         []tview.Primitive
+
+	mu        sync.RWMutex
+	awsClient *aws.Client
+	audit     *auditTrail
+
+	workgroup string
+	database  string
+
+	// The running or last query; queryGen tells results of older queries apart
+	queryID string
+	// The workgroup ARN and detail the running query is audited with
+	queryARN    string
+	queryDetail string
+	queryCancel context.CancelFunc
+	queryGen    uint64
+	running     bool
+
+	// Result pages fetched so far, the shown one and the token of the next
+	columns   []string
+	pages     [][][]string
+	page      int
+	nextToken string
+	fetching  bool
+}
+
+// NewAthenaTab creates the Athena query tab
+func NewAthenaTab(app *tview.Application) (*AthenaTab, error) {
+	tab := &AthenaTab{app: app}
+
+	if err := tab.initializeUI(); err != nil {
+		return nil, fmt.Errorf("failed to initialize Athena tab UI: %w", err)
+	}
+	return tab, nil
+}
+
+func (at *AthenaTab) initializeUI() error {
+	newList := func(title string) *tview.List {
+		list := tview.NewList().
+			SetMainTextColor(tcell.ColorWhite).
+			SetSelectedTextColor(tcell.ColorBlack).
+			SetSelectedBackgroundColor(tcell.ColorWhite).
+			ShowSecondaryText(false)
+		list.SetBorder(true).SetTitle(title).SetTitleAlign(tview.AlignLeft)
+		return list
+	}
+
+	at.workgroupList = newList(panelTitle("athena.workgroups"))
+	at.workgroupList.SetSelectedFunc(func(index int, name, secondary string, shortcut rune) {
+		at.workgroup = name
+		at.updateTitles()
+		at.focus(at.databaseList)
+	})
+
+	at.databaseList = newList(panelTitle("athena.databases"))
+	at.databaseList.SetSelectedFunc(func(index int, name, secondary string, shortcut rune) {
+		at.database = name
+		at.updateTitles()
+		at.focus(at.editor)
+	})
+
+	at.editor = tview.NewTextArea().
+		SetPlaceholder("SELECT * FROM orders LIMIT 10")
+	at.editor.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	at.resultTable = tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	at.resultTable.SetBorder(true).SetTitle(panelTitle("athena.results")).SetTitleAlign(tview.AlignLeft)
+	at.resultTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'n':
+			at.showPage(at.page + 1)
+			return nil
+		case 'p':
+			at.showPage(at.page - 1)
+			return nil
+		case 'e':
+			at.exportResults()
+			return nil
+		case 'i':
+			at.focus(at.editor)
+			return nil
+		}
+		return event
+	})
+
+	at.statusText = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetTextAlign(tview.AlignCenter)
+	at.statusText.SetBorder(true).SetTitle(panelTitle("common.status")).SetTitleAlign(tview.AlignLeft)
+	at.updateStatus(i18n.T("athena.no_client"), "yellow")
+
+	leftPanel := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(at.workgroupList, 0, 1, true).
+		AddItem(at.databaseList, 0, 2, false).
+		AddItem(at.statusText, 7, 0, false)
+
+	rightPanel := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(at.editor, 10, 0, false).
+		AddItem(at.resultTable, 0, 1, false)
+
+	mainLayout := tview.NewFlex().SetDirection(tview.FlexColumn).
+		AddItem(leftPanel, 30, 0, true).
+		AddItem(rightPanel, 0, 1, false)
+
+	// The keys work in every pane, including the editor
+	mainLayout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyF2:
+			at.nextPane()
+			return nil
+		case tcell.KeyF5:
+			at.runQuery()
+			return nil
+		case tcell.KeyF8:
+			at.stopQuery()
+			return nil
+		}
+		return event
+	})
+
+	at.panes = []tview.Primitive{at.workgroupList, at.databaseList, at.editor, at.resultTable}
+	at.updateTitles()
+
+	at.view = tview.NewPages().AddPage("main", mainLayout, true, true)
+	return nil
+}
+
+// updateTitles shows the selected workgroup and database and the keys
+func (at *AthenaTab) updateTitles() {
+	workgroup, database := at.workgroup, at.database
+	if workgroup == "" {
+		workgroup = "-"
+	}
+	if database == "" {
+		database = "-"
+	}
+	at.editor.SetTitle(panelTitle("athena.sql", workgroup, database))
+}
+
+// applyLocale titles the panels in the current locale
+func (at *AthenaTab) applyLocale() {
+	at.workgroupList.SetTitle(panelTitle("athena.workgroups"))
+	at.databaseList.SetTitle(panelTitle("athena.databases"))
+	at.statusText.SetTitle(panelTitle("common.status"))
+	at.updateTitles()
+	if len(at.pages) > 0 {
+		at.renderPage()
+	} else {
+		at.resultTable.SetTitle(panelTitle("athena.results"))
+	}
+}
+
+// focus moves the focus to p
+func (at *AthenaTab) focus(p tview.Primitive) {
+	if at.app != nil {
+		at.app.SetFocus(p)
+	}
+}
+
+// nextPane moves the focus to the pane after the focused one
+func (at *AthenaTab) nextPane() {
+	if at.app == nil {
+		return
+	}
+	current := at.app.GetFocus()
+	for i, pane := range at.panes {
+		if pane == current {
+			at.focus(at.panes[(i+1)%len(at.panes)])
+			return
+		}
+	}
+	at.focus(at.panes[0])
+}
+
+// updateStatus shows message in the status panel
+func (at *AthenaTab) updateStatus(message, color string) {
+	at.statusText.SetText(fmt.Sprintf("[%s]%s%s[-]\n[gray]%s[-]", color, stateWord(color), tview.Escape(message), time.Now().Format("15:04:05")))
+}
+
+// client returns the AWS client in use
+func (at *AthenaTab) client() *aws.Client {
+	at.mu.RLock()
+	defer at.mu.RUnlock()
+	return at.awsClient
+}
+
+// IsRunning reports whether a query is running
+func (at *AthenaTab) IsRunning() bool {
+	return at.running
+}
+
+// SetAWSClient stops following the running query and lists the workgroups
+// and databases of client
+func (at *AthenaTab) SetAWSClient(client *aws.Client) {
+	at.mu.Lock()
+	at.awsClient = client
+	at.mu.Unlock()
+
+	if at.queryCancel != nil {
+		at.queryCancel()
+	}
+	at.queryGen++
+	at.running = false
+	at.workgroup = ""
+	at.database = ""
+	at.updateTitles()
+	at.clearResults()
+	at.loadCatalog()
+}
+
+// Refresh lists the workgroups and databases again
+func (at *AthenaTab) Refresh() {
+	at.loadCatalog()
+}
+
+// GetView returns the view of the tab
+func (at *AthenaTab) GetView() tview.Primitive {
+	return at.view
+}
+
+// loadCatalog lists the workgroups and databases in the background. The
+// selection is kept if it still exists; otherwise the primary workgroup and
+// the first database are picked.
+func (at *AthenaTab) loadCatalog() {
+	client := at.client()
+	if client == nil || client.GetClients() == nil || client.GetClients().Athena == nil {
+		return
+	}
+	svc := client.GetClients().Athena
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		workgroups, err := svc.ListWorkGroups(ctx)
+		var databases []string
+		if err == nil {
+			databases, err = svc.ListDatabases(ctx)
+		}
+
+		if at.app == nil {
+			return
+		}
+		at.app.QueueUpdateDraw(func() {
+			if at.client() != client {
+				return
+			}
+			if err != nil {
+				logger.Error("Failed to list Athena catalog", zap.Error(err))
+				at.updateStatus(i18n.T("athena.list_failed", clients.ErrorReason(err)), "red")
+				return
+			}
+
+			at.workgroup = fillList(at.workgroupList, workgroups, at.workgroup, "primary")
+			at.database = fillList(at.databaseList, databases, at.database, "")
+			at.updateTitles()
+			if !at.running {
+				at.updateStatus(i18n.T("athena.listed", len(workgroups), len(databases)), "green")
+			}
+		})
+	}()
+}
+
+// fillList replaces the items of list with names and selects selected, or
+// fallback, or the first item. It returns the selected name.
+func fillList(list *tview.List, names []string, selected, fallback string) string {
+	list.Clear()
+	index := -1
+	for i, name := range names {
+		list.AddItem(name, "", 0, nil)
+		if name == selected {
+			index = i
+		}
+	}
+	if index < 0 {
+		for i, name := range names {
+			if name == fallback {
+				index = i
+			}
+		}
+	}
+	if index < 0 && len(names) > 0 {
+		index = 0
+	}
+	if index < 0 {
+		return ""
+	}
+	list.SetCurrentItem(index)
+	return names[index]
+}
+
+// runQuery starts the SQL of the editor and follows it until it finished
+func (at *AthenaTab) runQuery() {
+	client := at.client()
+	if client == nil || client.GetClients() == nil || client.GetClients().Athena == nil {
+		at.updateStatus(i18n.T("athena.no_client"), "yellow")
+		return
+	}
+	if at.running {
+		at.updateStatus(i18n.T("athena.already_running"), "yellow")
+		return
+	}
+	sql := at.editor.GetText()
+	if sql == "" {
+		at.updateStatus(i18n.T("athena.enter_query"), "yellow")
+		return
+	}
+	if at.workgroup == "" {
+		at.updateStatus(i18n.T("athena.select_workgroup"), "yellow")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), athenaQueryTimeout)
+	at.queryCancel = cancel
+	at.queryGen++
+	gen := at.queryGen
+	at.running = true
+	at.queryID = ""
+	at.clearResults()
+	at.updateStatus(i18n.T("athena.starting"), "yellow")
+
+	svc := client.GetClients().Athena
+	workgroup, database := at.workgroup, at.database
+	at.queryARN, at.queryDetail = workgroupARN(client, workgroup), queryDetail(database, sql)
+	resource, detail := at.queryARN, at.queryDetail
+	go func() {
+		defer cancel()
+
+		id, err := svc.StartQuery(ctx, sql, workgroup, database)
+		at.audit.recordDetail(client, "athena:StartQueryExecution", resource, detail, err)
+		if err != nil {
+			at.queueUpdate(gen, func() {
+				at.running = false
+				at.updateStatus(i18n.T("athena.start_failed", clients.ErrorReason(err)), "red")
+				at.showMessage(err.Error(), tcell.ColorRed)
+			})
+			return
+		}
+		logger.Info("Started Athena query", zap.String("id", id), zap.String("workgroup", workgroup), zap.String("database", database))
+		at.queueUpdate(gen, func() { at.queryID = id })
+
+		status, err := client.WaitForQuery(ctx, id, athenaPollInterval, func(status clients.QueryStatus) {
+			at.queueUpdate(gen, func() {
+				at.updateStatus(fmt.Sprintf("%s %s", status.State, status.Runtime.Round(100*time.Millisecond)), "yellow")
+			})
+		})
+		if err != nil {
+			at.queueUpdate(gen, func() {
+				at.running = false
+				at.updateStatus(i18n.T("athena.lost_track", clients.ErrorReason(err)), "red")
+			})
+			return
+		}
+
+		switch status.State {
+		case clients.QuerySucceeded:
+			page, err := svc.GetQueryResults(ctx, id, "", athenaPageSize)
+			at.queueUpdate(gen, func() {
+				at.running = false
+				if err != nil {
+					at.updateStatus(i18n.T("athena.read_failed", clients.ErrorReason(err)), "red")
+					return
+				}
+				at.columns = page.Columns
+				at.pages = [][][]string{page.Rows}
+				at.nextToken = page.NextToken
+				at.showPage(0)
+				at.updateStatus(i18n.T("athena.succeeded", status.Runtime.Round(time.Millisecond), formatBytes(status.DataScanned)), "green")
+				at.focus(at.resultTable)
+			})
+		default:
+			at.queueUpdate(gen, func() {
+				at.running = false
+				color, name := tcell.ColorRed, "red"
+				if status.State == clients.QueryCancelled {
+					color, name = tcell.ColorYellow, "yellow"
+				}
+				at.updateStatus(i18n.T("athena.query_state", status.State), name)
+				at.showMessage(status.Reason, color)
+			})
+		}
+	}()
+}
+
+// queueUpdate runs f on the UI goroutine unless a newer query started since
+func (at *AthenaTab) queueUpdate(gen uint64, f func()) {
+	if at.app == nil {
+		return
+	}
+	at.app.QueueUpdateDraw(func() {
+		if gen == at.queryGen {
+			f()
+		}
+	})
+}
+
+// stopQuery cancels the running query
+func (at *AthenaTab) stopQuery() {
+	client := at.client()
+	if !at.running || at.queryID == "" || client == nil {
+		at.updateStatus(i18n.T("athena.not_running"), "yellow")
+		return
+	}
+
+	svc := client.GetClients().Athena
+	id, resource, detail := at.queryID, at.queryARN, at.queryDetail
+	at.updateStatus(i18n.T("athena.stopping"), "yellow")
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		// The poller picks up the cancelled state
+		err := svc.StopQuery(ctx, id)
+		at.audit.recordDetail(client, "athena:StopQueryExecution", resource, detail, err)
+		if err != nil {
+			logger.Error("Failed to stop Athena query", zap.String("id", id), zap.Error(err))
+			if at.app != nil {
+				at.app.QueueUpdateDraw(func() {
+					at.updateStatus(i18n.T("athena.stop_failed", clients.ErrorReason(err)), "red")
+				})
+			}
+		}
+	}()
+}
+
+// workgroupARN returns the ARN of workgroup in the region and account of
+// client, which audit entries of its queries name
+func workgroupARN(client *aws.Client, workgroup string) string {
+	region := client.GetRegion()
+	return fmt.Sprintf("arn:%s:athena:%s:%s:workgroup/%s", awsPartition(region), region, client.GetAccountID(), workgroup)
+}
+
+// queryDetail describes a query run in database for the audit log
+func queryDetail(database, sql string) string {
+	if database == "" {
+		return sql
+	}
+	return fmt.Sprintf("database %s: %s", database, sql)
+}
+
+// clearResults forgets the results of the last query
+func (at *AthenaTab) clearResults() {
+	at.columns = nil
+	at.pages = nil
+	at.page = 0
+	at.nextToken = ""
+	at.resultTable.Clear()
+	at.resultTable.SetTitle(panelTitle("athena.results"))
+}
+
+// showMessage replaces the results with a message, e.g. why a query failed
+func (at *AthenaTab) showMessage(message string, color tcell.Color) {
+	at.resultTable.Clear()
+	at.resultTable.SetCell(0, 0, tview.NewTableCell(message).
+		SetTextColor(color).
+		SetSelectable(false).
+		SetExpansion(1))
+}
+
+// showPage shows result page index, fetching it first if it is the page
+// after the last fetched one
+func (at *AthenaTab) showPage(index int) {
+	if index < 0 || at.pages == nil {
+		return
+	}
+	if index < len(at.pages) {
+		at.page = index
+		at.renderPage()
+		return
+	}
+	if index > len(at.pages) || at.nextToken == "" || at.fetching {
+		return
+	}
+
+	client := at.client()
+	if client == nil {
+		return
+	}
+	svc := client.GetClients().Athena
+	gen, id, token := at.queryGen, at.queryID, at.nextToken
+	at.fetching = true
+	at.updateStatus(i18n.T("athena.loading_page", index+1), "yellow")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		page, err := svc.GetQueryResults(ctx, id, token, athenaPageSize)
+		at.queueUpdate(gen, func() {
+			at.fetching = false
+			if err != nil {
+				at.updateStatus(i18n.T("athena.page_failed", index+1, clients.ErrorReason(err)), "red")
+				return
+			}
+			at.pages = append(at.pages, page.Rows)
+			at.nextToken = page.NextToken
+			at.page = index
+			at.renderPage()
+			at.updateStatus(i18n.T("athena.page_loaded", index+1), "green")
+		})
+	}()
+}
+
+// renderPage draws the shown result page
+func (at *AthenaTab) renderPage() {
+	at.resultTable.Clear()
+	for col, name := range at.columns {
+		at.resultTable.SetCell(0, col, tview.NewTableCell(name).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	rows := at.pages[at.page]
+	for row, values := range rows {
+		for col, value := range values {
+			at.resultTable.SetCell(row+1, col, tview.NewTableCell(value).SetMaxWidth(40))
+		}
+	}
+	at.resultTable.ScrollToBeginning()
+	at.resultTable.Select(1, 0)
+
+	first := at.page*athenaPageSize + 1
+	more := ""
+	if at.nextToken != "" || at.page < len(at.pages)-1 {
+		more = i18n.T("athena.more_next")
+	}
+	if at.page > 0 {
+		more += i18n.T("athena.more_previous")
+	}
+	at.resultTable.SetTitle(panelTitle("athena.results_page",
+		first, first+len(rows)-1, at.page+1, more))
+	if len(rows) == 0 {
+		at.resultTable.SetTitle(panelTitle("athena.results_none"))
+	}
+}
+
+// exportResults writes all rows of the last query to a CSV file, fetching
+// the pages not loaded yet
+func (at *AthenaTab) exportResults() {
+	client := at.client()
+	if at.pages == nil || client == nil {
+		at.updateStatus(i18n.T("athena.no_results"), "yellow")
+		return
+	}
+
+	svc := client.GetClients().Athena
+	gen, id, token := at.queryGen, at.queryID, at.nextToken
+	columns := at.columns
+	var rows [][]string
+	for _, page := range at.pages {
+		rows = append(rows, page...)
+	}
+	path := athenaExportPath(id)
+	at.updateStatus(i18n.T("athena.exporting"), "yellow")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		for token != "" {
+			page, err := svc.GetQueryResults(ctx, id, token, 1000)
+			if err != nil {
+				at.queueUpdate(gen, func() {
+					at.updateStatus(i18n.T("athena.export_failed", clients.ErrorReason(err)), "red")
+				})
+				return
+			}
+			rows = append(rows, page.Rows...)
+			token = page.NextToken
+		}
+
+		err := writeQueryCSV(path, columns, rows)
+		if err != nil {
+			logger.Error("Failed to export query results", zap.String("path", path), zap.Error(err))
+		} else {
+			logger.Info("Exported query results", zap.String("path", path), zap.Int("rows", len(rows)))
+		}
+		at.queueUpdate(gen, func() {
+			if err != nil {
+				at.updateStatus(i18n.T("athena.export_failed", err.Error()), "red")
+				return
+			}
+			at.updateStatus(i18n.T("athena.exported", len(rows), path), "green")
+		})
+	}()
+}
+
+// athenaExportPath returns the file results of query id are exported to
+func athenaExportPath(id string) string {
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	name := fmt.Sprintf("athena-%s-%s.csv", id, time.Now().Format("20060102-150405"))
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(homeDir, ".swiss-army-tui", "exports", name)
+}
+
+// writeQueryCSV writes columns and rows to path as CSV
+func writeQueryCSV(path string, columns []string, rows [][]string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return exportQueryCSV(file, columns, rows)
+}
+
+// exportQueryCSV writes a header of columns followed by rows
+func exportQueryCSV(w io.Writer, columns []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write CSV rows: %w", err)
+	}
+	return nil
+}
+
+// formatBytes formats a size in bytes, e.g. 1.5 MB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
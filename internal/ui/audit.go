@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"swiss-army-tui/internal/audit"
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// awsPartition returns the ARN partition of a region
+func awsPartition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	default:
+		return "aws"
+	}
+}
+
+// auditTrail records the mutating actions of the tabs to the audit log of
+// the App. Nothing is recorded while it has no log, as in demo and offline
+// mode, whose actions are made up.
+type auditTrail struct {
+	log atomic.Pointer[audit.Log]
+}
+
+// current returns the log actions are recorded to, nil if none, as for
+// tabs made without an App
+func (t *auditTrail) current() *audit.Log {
+	if t == nil {
+		return nil
+	}
+	return t.log.Load()
+}
+
+// record appends a mutating action and its outcome to the audit log
+func (t *auditTrail) record(client *aws.Client, action, resource string, err error) {
+	t.recordDetail(client, action, resource, "", err)
+}
+
+// recordDetail records an action like record, with the detail of what it did
+func (t *auditTrail) recordDetail(client *aws.Client, action, resource, detail string, err error) {
+	log := t.current()
+	if log == nil {
+		return
+	}
+
+	entry := audit.Entry{
+		Action:   action,
+		Resource: resource,
+		Result:   audit.ResultSuccess,
+		Detail:   detail,
+	}
+	if client != nil {
+		entry.Profile = client.GetProfile()
+		entry.Account = client.GetAccountID()
+		entry.Region = client.GetRegion()
+	}
+	if err != nil {
+		entry.Result = audit.ResultFailure
+		entry.Error = err.Error()
+	}
+
+	if err := log.Record(entry); err != nil {
+		logger.Error("Failed to write audit log", zap.String("action", action), zap.Error(err))
+	}
+}
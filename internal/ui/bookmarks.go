@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+
+	"swiss-army-tui/internal/bookmarks"
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// bookmarkList is the open bookmarks overlay
+type bookmarkList struct {
+	layout *tview.Flex
+	table  *tview.Table
+	status *tview.TextView
+	// Name of the bookmark being added, nil unless one is
+	name  *tview.InputField
+	items []bookmarks.Bookmark
+}
+
+// showBookmarks opens the list of saved bookmarks
+func (app *App) showBookmarks() {
+	if app.closeOverlay != nil {
+		return
+	}
+
+	l := &bookmarkList{
+		table:  tview.NewTable().SetSelectable(true, false).SetFixed(1, 0),
+		status: tview.NewTextView().SetDynamicColors(true),
+	}
+	// The current view is read before the overlay takes the focus
+	target, name, canAdd := app.currentTarget()
+	focus := app.app.GetFocus()
+
+	closeList := func() {
+		app.closeOverlay = nil
+		app.pages.RemovePage("bookmarks")
+		app.app.SetFocus(focus)
+	}
+	app.closeOverlay = func() {
+		if l.name != nil {
+			l.stopNaming(app)
+			return
+		}
+		closeList()
+	}
+
+	l.table.SetSelectedFunc(func(row, column int) {
+		if row > 0 && row-1 < len(l.items) {
+			closeList()
+			app.openBookmark(l.items[row-1])
+		}
+	})
+	l.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'a':
+			if !canAdd {
+				l.setStatus("[yellow]" + i18n.T("bookmarks.nothing") + "[-]")
+				return nil
+			}
+			l.startNaming(app, target, name)
+			return nil
+		case 'd':
+			row, _ := l.table.GetSelection()
+			if row > 0 && row-1 < len(l.items) {
+				if err := app.bookmarks.Remove(l.items[row-1].Name); err != nil {
+					l.setStatus(fmt.Sprintf("[red]%s[-]", err))
+					return nil
+				}
+				l.fill(app.bookmarks.List())
+			}
+			return nil
+		}
+		return event
+	})
+
+	l.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(l.table, 0, 1, true).
+		AddItem(l.status, 1, 0, false)
+	l.layout.SetBorder(true).SetTitle(panelTitle("bookmarks.title")).SetTitleAlign(tview.AlignLeft)
+
+	l.fill(app.bookmarks.List())
+	app.pages.AddPage("bookmarks", centered(l.layout, 100, 20), true, true)
+	app.app.SetFocus(l.table)
+}
+
+// fill lists items in the table
+func (l *bookmarkList) fill(items []bookmarks.Bookmark) {
+	l.items = items
+	l.table.Clear()
+	for col, header := range []string{"Name", "Target", "Profile", "Region"} {
+		l.table.SetCell(0, col, tview.NewTableCell(columnTitle(header)).
+			SetTextColor(tcell.ColorYellow).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+	}
+	for i, b := range items {
+		row := i + 1
+		l.table.SetCell(row, 0, tview.NewTableCell(b.Name).SetMaxWidth(30))
+		l.table.SetCell(row, 1, tview.NewTableCell(b.Target).SetMaxWidth(50))
+		l.table.SetCell(row, 2, tview.NewTableCell(b.Profile))
+		l.table.SetCell(row, 3, tview.NewTableCell(b.Region))
+	}
+	if len(items) > 0 {
+		l.table.Select(1, 0)
+	}
+
+	if len(items) == 0 {
+		l.setStatus(i18n.T("bookmarks.empty") + " [gray]| " + i18n.T("bookmarks.empty_hint") + "[-]")
+	} else {
+		l.setStatus("[gray]" + i18n.T("bookmarks.hint") + "[-]")
+	}
+}
+
+// setStatus shows text below the table
+func (l *bookmarkList) setStatus(text string) {
+	l.status.SetText(text)
+}
+
+// startNaming asks for the name of a new bookmark of target
+func (l *bookmarkList) startNaming(app *App, target bookmarks.Target, name string) {
+	l.name = tview.NewInputField().SetLabel(i18n.T("column.name") + ": ").SetText(name).SetFieldWidth(0)
+	l.name.SetDoneFunc(func(key tcell.Key) {
+		if key != tcell.KeyEnter {
+			return
+		}
+		bookmark := bookmarks.Bookmark{Name: l.name.GetText(), Target: target.String()}
+		if app.awsClient != nil {
+			bookmark.Profile = app.awsClient.GetProfile()
+			bookmark.Region = app.awsClient.GetRegion()
+		}
+		if err := app.bookmarks.Add(bookmark); err != nil {
+			l.setStatus(fmt.Sprintf("[red]%s[-]", err))
+			return
+		}
+		l.stopNaming(app)
+		l.fill(app.bookmarks.List())
+	})
+
+	l.layout.AddItem(l.name, 1, 0, true)
+	l.setStatus("[gray]" + i18n.T("bookmarks.naming", target) + "[-]")
+	app.app.SetFocus(l.name)
+}
+
+// stopNaming drops the name input
+func (l *bookmarkList) stopNaming(app *App) {
+	l.layout.RemoveItem(l.name)
+	l.name = nil
+	l.fill(l.items)
+	app.app.SetFocus(l.table)
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// consoleHost returns the AWS console host for the partition of a region
+func consoleHost(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "console.amazonaws.cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "console.amazonaws-us-gov.com"
+	default:
+		return region + ".console.aws.amazon.com"
+	}
+}
+
+// consoleURL builds the AWS console deep link for a resource of the service
+// of view
+func consoleURL(view ServiceView, res Resource) (string, error) {
+	region := res.Region
+	if region == "" {
+		return "", fmt.Errorf("resource %s has no region", res.Name)
+	}
+
+	base := fmt.Sprintf("https://%s", consoleHost(region))
+	query := "region=" + url.QueryEscape(region)
+	link := view.ConsoleURL(base, query, res)
+	if link == "" {
+		return "", fmt.Errorf("no console link for service %q", view.Info().Name)
+	}
+	return link, nil
+}
+
+// s3BucketConsoleURL links the bucket of res, which the S3 services name
+// their resources after
+func s3BucketConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/s3/buckets/%s?%s", base, url.PathEscape(res.Name), query)
+}
+
+// openBrowser opens url in the default browser of the platform
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	// Reap the launcher in the background so it doesn't linger as a zombie
+	go cmd.Wait()
+	return nil
+}
+
+// copyToClipboard writes text to the system clipboard using the platform's clipboard tool
+func copyToClipboard(text string) error {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		candidates = [][]string{{"wl-copy"}, {"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}}
+	}
+
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(candidate[0], candidate[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to copy to clipboard: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("no clipboard tool found")
+}
@@ -0,0 +1,273 @@
+package ui
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// EventType names a topic on the event bus
+type EventType string
+
+const (
+	EventProfileChanged EventType = "profile_changed"
+	EventRegionChanged  EventType = "region_changed"
+	EventRefresh        EventType = "refresh"
+	EventError          EventType = "error"
+	EventShowLambdaLogs EventType = "show_lambda_logs"
+	EventShowPodLogs    EventType = "show_pod_logs"
+	EventShowLogStream  EventType = "show_log_stream"
+	EventConfigChanged  EventType = "config_changed"
+	EventToast          EventType = "toast"
+)
+
+// Event is the payload of an application event. Every payload type belongs
+// to one EventType, so subscribers get the fields of an event without type
+// asserting them. Payloads are passed by value.
+type Event interface {
+	Type() EventType
+}
+
+// ProfileChangedEvent asks to switch to a profile in a region
+type ProfileChangedEvent struct {
+	Profile string
+	Region  string
+}
+
+// RegionChangedEvent asks to switch the current profile to a region
+type RegionChangedEvent struct {
+	Region string
+}
+
+// RefreshEvent asks the current tab to reload
+type RefreshEvent struct{}
+
+// ErrorEvent reports an error to show in a dialog
+type ErrorEvent struct {
+	Err error
+}
+
+// ConfigChangedEvent carries the configuration read after the config file
+// changed
+type ConfigChangedEvent struct {
+	Config *config.Config
+}
+
+// ShowLambdaLogsEvent asks the Logs tab to show the log group of a Lambda
+// function
+type ShowLambdaLogsEvent struct {
+	Function string
+	LogGroup string
+}
+
+// ShowPodLogsEvent asks the Logs tab to stream the logs of a pod
+type ShowPodLogsEvent struct {
+	Cluster string
+	Pod     clients.Pod
+}
+
+// ShowLogStreamEvent asks the Logs tab to show the events of one stream of
+// a CloudWatch log group
+type ShowLogStreamEvent struct {
+	LogGroup string
+	Stream   string
+}
+
+// Toast is a short message shown in the footer
+type Toast struct {
+	Message string
+	Color   string
+}
+
+func (ProfileChangedEvent) Type() EventType { return EventProfileChanged }
+func (RegionChangedEvent) Type() EventType  { return EventRegionChanged }
+func (RefreshEvent) Type() EventType        { return EventRefresh }
+func (ErrorEvent) Type() EventType          { return EventError }
+func (ConfigChangedEvent) Type() EventType  { return EventConfigChanged }
+func (ShowLambdaLogsEvent) Type() EventType { return EventShowLambdaLogs }
+func (ShowPodLogsEvent) Type() EventType    { return EventShowPodLogs }
+func (ShowLogStreamEvent) Type() EventType  { return EventShowLogStream }
+func (Toast) Type() EventType               { return EventToast }
+
+// EventRouter calls the handler of the payload type of an event, so one
+// subscriber handles several types of events in publish order
+type EventRouter struct {
+	handlers map[EventType]func(Event)
+}
+
+// Route makes r call handler for the events with payloads of type E,
+// replacing the handler routed to before
+func Route[E Event](r *EventRouter, handler func(E)) {
+	if r.handlers == nil {
+		r.handlers = make(map[EventType]func(Event))
+	}
+	var zero E
+	r.handlers[zero.Type()] = func(event Event) {
+		if payload, ok := event.(E); ok {
+			handler(payload)
+		}
+	}
+}
+
+// Handle calls the handler routed to for the type of event, if any
+func (r *EventRouter) Handle(event Event) {
+	if handler, ok := r.handlers[event.Type()]; ok {
+		handler(event)
+	}
+}
+
+// Types returns the event types r has handlers for
+func (r *EventRouter) Types() []EventType {
+	types := make([]EventType, 0, len(r.handlers))
+	for eventType := range r.handlers {
+		types = append(types, eventType)
+	}
+	return types
+}
+
+// On calls handler for every event with a payload of type E on b until the
+// returned function is called or the bus is closed
+func On[E Event](b *EventBus, handler func(E)) (unsubscribe func()) {
+	var r EventRouter
+	Route(&r, handler)
+	return b.Subscribe(r.Handle, r.Types()...)
+}
+
+// eventQueueSize is how many events a subscriber can fall behind before
+// Publish waits for it
+const eventQueueSize = 100
+
+// EventBus delivers events to the subscribers of their type. Every subscriber
+// has its own buffered queue and goroutine, so a slow or panicking handler
+// does not hold up the others. Events reach a subscriber in publish order.
+type EventBus struct {
+	mu     sync.RWMutex
+	subs   map[EventType][]*subscription
+	done   chan struct{}
+	closed bool
+}
+
+type subscription struct {
+	queue   chan Event
+	handler func(Event)
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewEventBus creates an event bus without subscribers
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subs: make(map[EventType][]*subscription),
+		done: make(chan struct{}),
+	}
+}
+
+// Subscribe calls handler for every event of the given types until the
+// returned function is called or the bus is closed
+func (b *EventBus) Subscribe(handler func(Event), types ...EventType) (unsubscribe func()) {
+	sub := &subscription{
+		queue:   make(chan Event, eventQueueSize),
+		handler: handler,
+		done:    make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return func() {}
+	}
+	for _, eventType := range types {
+		b.subs[eventType] = append(b.subs[eventType], sub)
+	}
+	b.mu.Unlock()
+
+	go sub.run(b.done)
+
+	return func() {
+		b.mu.Lock()
+		for _, eventType := range types {
+			subs := b.subs[eventType]
+			for i, s := range subs {
+				if s == sub {
+					b.subs[eventType] = append(subs[:i:i], subs[i+1:]...)
+					break
+				}
+			}
+		}
+		b.mu.Unlock()
+		sub.stop()
+	}
+}
+
+// Publish queues event for every subscriber of its type. It waits while a
+// subscriber's queue is full and returns without delivering once the bus is
+// closed.
+func (b *EventBus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	subs := b.subs[event.Type()]
+	b.mu.RUnlock()
+
+	for _, sub := range subs {
+		select {
+		case sub.queue <- event:
+		case <-sub.done:
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// Close stops delivery to all subscribers. Queued events are dropped.
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	close(b.done)
+	b.subs = make(map[EventType][]*subscription)
+}
+
+func (s *subscription) stop() {
+	s.once.Do(func() { close(s.done) })
+}
+
+func (s *subscription) run(busDone <-chan struct{}) {
+	for {
+		select {
+		case event := <-s.queue:
+			s.deliver(event)
+		case <-s.done:
+			return
+		case <-busDone:
+			return
+		}
+	}
+}
+
+// deliver calls the handler, turning a panic into a log entry so the
+// subscriber keeps receiving events
+func (s *subscription) deliver(event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("Event handler panicked",
+				zap.String("event", string(event.Type())),
+				zap.String("panic", fmt.Sprint(r)),
+				zap.ByteString("stack", debug.Stack()))
+		}
+	}()
+
+	s.handler(event)
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventBusDelivery(t *testing.T) {
+	bus := NewEventBus()
+	defer bus.Close()
+
+	var mu sync.Mutex
+	var got []Event
+	received := make(chan struct{}, 10)
+	bus.Subscribe(func(event Event) {
+		mu.Lock()
+		got = append(got, event)
+		mu.Unlock()
+		received <- struct{}{}
+	}, EventToast, EventRegionChanged)
+
+	bus.Publish(Toast{Message: "1"})
+	bus.Publish(ShowLambdaLogsEvent{})
+	bus.Publish(RegionChangedEvent{Region: "2"})
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-received:
+		case <-time.After(time.Second):
+			t.Fatalf("Expected 2 events, got %d", i)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 2 || got[0] != (Toast{Message: "1"}) || got[1] != (RegionChangedEvent{Region: "2"}) {
+		t.Errorf("Expected the subscribed events in order, got %+v", got)
+	}
+}
+
+func TestEventBusPanicIsolation(t *testing.T) {
+	bus := NewEventBus()
+	defer bus.Close()
+
+	received := make(chan string, 10)
+	On(bus, func(toast Toast) {
+		if toast.Message == "boom" {
+			panic("boom")
+		}
+		received <- toast.Message
+	})
+	On(bus, func(toast Toast) {
+		received <- toast.Message
+	})
+
+	bus.Publish(Toast{Message: "boom"})
+	bus.Publish(Toast{Message: "ok"})
+
+	// The second subscriber sees both, the panicking one keeps receiving
+	counts := make(map[string]int)
+	for i := 0; i < 3; i++ {
+		select {
+		case data := <-received:
+			counts[data]++
+		case <-time.After(time.Second):
+			t.Fatalf("Expected 3 deliveries, got %v", counts)
+		}
+	}
+	if counts["boom"] != 1 || counts["ok"] != 2 {
+		t.Errorf("Unexpected deliveries %v", counts)
+	}
+}
+
+func TestEventBusUnsubscribeAndClose(t *testing.T) {
+	bus := NewEventBus()
+
+	received := make(chan struct{}, 10)
+	unsubscribe := bus.Subscribe(func(event Event) {
+		received <- struct{}{}
+	}, EventRefresh)
+	unsubscribe()
+	unsubscribe()
+
+	bus.Publish(RefreshEvent{})
+	select {
+	case <-received:
+		t.Error("Expected no delivery after unsubscribe")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// A full queue must not block publishers once the bus is closed
+	block := make(chan struct{})
+	bus.Subscribe(func(event Event) { <-block }, EventRefresh)
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < eventQueueSize+10; i++ {
+			bus.Publish(RefreshEvent{})
+		}
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	bus.Close()
+	bus.Close()
+	close(block)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked after Close")
+	}
+
+	var nilBus *EventBus
+	nilBus.Publish(RefreshEvent{})
+}
+
+func TestEventRouter(t *testing.T) {
+	var r EventRouter
+	var profiles []ProfileChangedEvent
+	var toasts []string
+	Route(&r, func(event ProfileChangedEvent) { profiles = append(profiles, event) })
+	Route(&r, func(toast Toast) { toasts = append(toasts, toast.Message) })
+
+	if types := r.Types(); len(types) != 2 {
+		t.Errorf("Expected the routed types, got %v", types)
+	}
+	r.Handle(ProfileChangedEvent{Profile: "staging", Region: "eu-west-1"})
+	r.Handle(Toast{Message: "done"})
+	r.Handle(RefreshEvent{})
+
+	if len(profiles) != 1 || profiles[0].Profile != "staging" || profiles[0].Region != "eu-west-1" {
+		t.Errorf("Expected the profile change routed with its fields, got %+v", profiles)
+	}
+	if len(toasts) != 1 || toasts[0] != "done" {
+		t.Errorf("Expected the toast routed, got %v", toasts)
+	}
+}
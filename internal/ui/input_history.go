@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"swiss-army-tui/internal/history"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// inputHistory recalls earlier entries of an input field with the up and
+// down arrows, the way a shell does
+type inputHistory struct {
+	store *history.Store
+	kind  string
+	// Position of the recalled entry, -1 while editing a new one
+	pos int
+	// What was typed before the first entry was recalled
+	draft string
+}
+
+// newInputHistory creates the history of kind, without entries until a
+// store is set
+func newInputHistory(kind string) *inputHistory {
+	return &inputHistory{kind: kind, pos: -1}
+}
+
+// SetStore sets the store the entries are kept in
+func (h *inputHistory) SetStore(store *history.Store) {
+	h.store = store
+	h.pos = -1
+}
+
+// HandleKey recalls an older entry into input on up and a newer one on down,
+// returning false for other keys
+func (h *inputHistory) HandleKey(input *tview.InputField, event *tcell.EventKey) bool {
+	if event.Key() != tcell.KeyUp && event.Key() != tcell.KeyDown {
+		return false
+	}
+	if h.store == nil {
+		return true
+	}
+	entries := h.store.Entries(h.kind)
+
+	if event.Key() == tcell.KeyUp {
+		if len(entries) == 0 || h.pos == 0 {
+			return true
+		}
+		if h.pos < 0 || h.pos > len(entries) {
+			h.draft = input.GetText()
+			h.pos = len(entries)
+		}
+		h.pos--
+		input.SetText(entries[h.pos])
+		return true
+	}
+
+	if h.pos < 0 {
+		return true
+	}
+	h.pos++
+	if h.pos >= len(entries) {
+		h.pos = -1
+		input.SetText(h.draft)
+		return true
+	}
+	input.SetText(entries[h.pos])
+	return true
+}
+
+// Commit records text as the newest entry and starts a new one
+func (h *inputHistory) Commit(text string) {
+	h.pos = -1
+	h.draft = ""
+	if h.store == nil {
+		return
+	}
+	if err := h.store.Add(h.kind, text); err != nil {
+		logger.Warn("Failed to save input history", zap.String("kind", h.kind), zap.Error(err))
+	}
+}
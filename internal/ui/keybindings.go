@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Global key binding actions
+const (
+	ActionNextTab   = "next_tab"
+	ActionPrevTab   = "prev_tab"
+	ActionRefresh   = "refresh"
+	ActionQuit      = "quit"
+	ActionHelp      = "help"
+	ActionSearch    = "search"
+	ActionBookmarks = "bookmarks"
+	ActionSnapshot  = "snapshot"
+	ActionRecord    = "record"
+)
+
+var defaultKeyBindings = map[string]string{
+	ActionNextTab:   "Tab",
+	ActionPrevTab:   "Backtab",
+	ActionRefresh:   "Ctrl+R",
+	ActionQuit:      "Ctrl+C, Esc",
+	ActionHelp:      "F1",
+	ActionSearch:    "Ctrl+F",
+	ActionBookmarks: "Ctrl+B",
+	ActionSnapshot:  "Ctrl+T",
+	ActionRecord:    "Ctrl+O",
+}
+
+// keyBinding is a single key, either a special key or a rune
+type keyBinding struct {
+	key tcell.Key
+	ch  rune
+}
+
+// runeKey and specialKey return the keyBinding of a rune or another key
+func runeKey(ch rune) keyBinding          { return keyBinding{key: tcell.KeyRune, ch: ch} }
+func specialKey(key tcell.Key) keyBinding { return keyBinding{key: key} }
+
+// matches reports whether event is the key
+func (b keyBinding) matches(event *tcell.EventKey) bool {
+	if b.key == tcell.KeyRune {
+		return event.Key() == tcell.KeyRune && event.Rune() == b.ch
+	}
+	return event.Key() == b.key
+}
+
+// label names the key as the help shows it
+func (b keyBinding) label() string {
+	if b.key == tcell.KeyRune {
+		return string(b.ch)
+	}
+	return tcell.KeyNames[b.key]
+}
+
+// KeyBindings maps actions to the keys that trigger them
+type KeyBindings struct {
+	bindings map[string][]keyBinding
+	labels   map[string]string
+}
+
+// NewKeyBindings builds key bindings from the defaults overridden by the
+// configured values. Invalid overrides are reported and fall back to the default.
+func NewKeyBindings(overrides map[string]string) (*KeyBindings, []error) {
+	kb := &KeyBindings{
+		bindings: make(map[string][]keyBinding),
+		labels:   make(map[string]string),
+	}
+
+	var errs []error
+	for action, def := range defaultKeyBindings {
+		spec := def
+		if override, ok := overrides[action]; ok && strings.TrimSpace(override) != "" {
+			spec = override
+		}
+
+		keys, err := parseKeySpec(spec)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid key binding for %s: %w", action, err))
+			spec = def
+			keys, _ = parseKeySpec(def)
+		}
+
+		kb.bindings[action] = keys
+		kb.labels[action] = spec
+	}
+
+	for action := range overrides {
+		if _, ok := defaultKeyBindings[action]; !ok {
+			errs = append(errs, fmt.Errorf("unknown key binding action %q", action))
+		}
+	}
+
+	return kb, errs
+}
+
+// Matches reports whether the event triggers the given action
+func (kb *KeyBindings) Matches(action string, event *tcell.EventKey) bool {
+	for _, binding := range kb.bindings[action] {
+		if binding.matches(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// Label returns the human readable keys bound to an action
+func (kb *KeyBindings) Label(action string) string {
+	return kb.labels[action]
+}
+
+// parseKeySpec parses a comma separated list of keys such as "Ctrl+R, F5, q"
+func parseKeySpec(spec string) ([]keyBinding, error) {
+	var keys []keyBinding
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key, err := parseKey(part)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys given")
+	}
+	return keys, nil
+}
+
+func parseKey(name string) (keyBinding, error) {
+	if utf8.RuneCountInString(name) == 1 {
+		r, _ := utf8.DecodeRuneInString(name)
+		return keyBinding{key: tcell.KeyRune, ch: r}, nil
+	}
+
+	// tcell names control keys "Ctrl-R"; accept "Ctrl+R" as well
+	normalized := strings.ReplaceAll(name, "+", "-")
+	for key, keyName := range tcell.KeyNames {
+		if strings.EqualFold(keyName, normalized) {
+			return keyBinding{key: key}, nil
+		}
+	}
+
+	return keyBinding{}, fmt.Errorf("unknown key %q", name)
+}
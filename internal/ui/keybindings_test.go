@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestKeyBindingsDefaults(t *testing.T) {
+	kb, errs := NewKeyBindings(nil)
+	if len(errs) != 0 {
+		t.Fatalf("Expected no errors for default bindings, got %v", errs)
+	}
+
+	if !kb.Matches(ActionRefresh, tcell.NewEventKey(tcell.KeyCtrlR, 0, tcell.ModCtrl)) {
+		t.Error("Expected Ctrl+R to trigger refresh")
+	}
+	if !kb.Matches(ActionQuit, tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone)) {
+		t.Error("Expected Esc to trigger quit")
+	}
+	if kb.Matches(ActionHelp, tcell.NewEventKey(tcell.KeyRune, '?', tcell.ModNone)) {
+		t.Error("Expected '?' not to trigger help by default")
+	}
+}
+
+func TestKeyBindingsOverrides(t *testing.T) {
+	kb, errs := NewKeyBindings(map[string]string{
+		ActionRefresh: "F5, r",
+		ActionHelp:    "Ctrl+Nope",
+		"unknown":     "x",
+	})
+	if len(errs) != 2 {
+		t.Errorf("Expected 2 errors, got %v", errs)
+	}
+
+	if !kb.Matches(ActionRefresh, tcell.NewEventKey(tcell.KeyF5, 0, tcell.ModNone)) {
+		t.Error("Expected F5 to trigger refresh")
+	}
+	if !kb.Matches(ActionRefresh, tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone)) {
+		t.Error("Expected 'r' to trigger refresh")
+	}
+	if kb.Matches(ActionRefresh, tcell.NewEventKey(tcell.KeyCtrlR, 0, tcell.ModCtrl)) {
+		t.Error("Expected Ctrl+R to be replaced by the override")
+	}
+
+	// Invalid overrides fall back to the default binding
+	if kb.Label(ActionHelp) != "F1" {
+		t.Errorf("Expected help label F1, got %q", kb.Label(ActionHelp))
+	}
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/jobs"
+
+	"github.com/rivo/tview"
+)
+
+// exportPollInterval is how often a running export task is checked on.
+// Tests shorten it.
+var exportPollInterval = 5 * time.Second
+
+// askLogGroupExport asks for the bucket and time range to export group to
+// and starts the export as a background job, shown in the jobs view of the
+// Resources tab
+func (lt *LogsTab) askLogGroupExport(b *logGroupBrowser, group clients.LogGroupInfo) {
+	if account := b.client.GetAccountID(); account != "" && group.Account != "" && group.Account != account {
+		// Export tasks take the name of a group of the own account
+		lt.updateStatus(i18n.T("logs.export_linked"), "yellow")
+		return
+	}
+	if lt.jobs == nil {
+		lt.updateStatus(i18n.T("logs.no_jobs"), "yellow")
+		return
+	}
+
+	bucket, prefix, from, to := "", "exportedlogs/"+strings.Trim(group.Name, "/"), "-24h", "now"
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("logs.export_bucket"), bucket, 48, nil, func(text string) { bucket = strings.TrimSpace(text) })
+	form.AddInputField(i18n.T("logs.export_prefix"), prefix, 48, nil, func(text string) { prefix = strings.Trim(strings.TrimSpace(text), "/") })
+	form.AddInputField(i18n.T("logs.export_from"), from, 20, nil, func(text string) { from = text })
+	form.AddInputField(i18n.T("logs.export_to"), to, 20, nil, func(text string) { to = text })
+	form.AddButton(i18n.T("logs.export_button"), func() {
+		now := time.Now()
+		start, err := parsePastTime(from, now)
+		if err != nil {
+			lt.updateStatus(err.Error(), "red")
+			return
+		}
+		end, err := parsePastTime(to, now)
+		if err != nil {
+			lt.updateStatus(err.Error(), "red")
+			return
+		}
+		switch {
+		case bucket == "":
+			lt.updateStatus(i18n.T("logs.export_no_bucket"), "red")
+			return
+		case !end.After(start):
+			lt.updateStatus(i18n.T("logs.export_range"), "red")
+			return
+		}
+		lt.closeLogGroupDialog(b)
+		lt.startLogGroupExport(b.client, group.Name, bucket, prefix, start, end)
+	})
+	form.AddButton(i18n.T("common.cancel"), func() { lt.closeLogGroupDialog(b) })
+	form.SetBorder(true).
+		SetTitle(panelTitle("logs.export_title", tview.Escape(group.Name))).
+		SetTitleAlign(tview.AlignLeft)
+
+	lt.view.AddPage("loggroups-dialog", centered(form, 72, 13), true, true)
+	if lt.app != nil {
+		lt.app.SetFocus(form)
+	}
+}
+
+// closeLogGroupDialog removes the export dialog and returns focus to the
+// log group browser b
+func (lt *LogsTab) closeLogGroupDialog(b *logGroupBrowser) {
+	lt.view.RemovePage("loggroups-dialog")
+	if lt.app != nil {
+		lt.app.SetFocus(b.table)
+	}
+}
+
+// startLogGroupExport exports the events of group between from and to to
+// s3://bucket/prefix as a background job. Cancelling the job cancels the
+// export task.
+func (lt *LogsTab) startLogGroupExport(client *aws.Client, group, bucket, prefix string, from, to time.Time) {
+	dest := "s3://" + bucket + "/" + prefix
+	title := fmt.Sprintf("Export %s (%s - %s) to %s", group,
+		zonedTime(from, "2006-01-02 15:04"), zonedTime(to, "2006-01-02 15:04"), dest)
+	lt.jobs.Start(title, "log group", func(ctx context.Context, progress jobs.Progress) error {
+		svc := client.GetClients()
+		if svc == nil || svc.CloudWatchLogs == nil {
+			return fmt.Errorf("CloudWatch Logs service not initialized")
+		}
+		err := exportLogGroup(ctx, svc.CloudWatchLogs, group, bucket, prefix, from, to, progress)
+		lt.audit.record(client, "logs:CreateExportTask", group, err)
+		return err
+	})
+	lt.updateStatus(i18n.T("logs.export_started", title), "yellow")
+}
+
+// exportLogGroup creates an export task for group and waits for it to
+// finish. Tasks are cancelled when ctx is, since an account runs only one
+// at a time.
+func exportLogGroup(ctx context.Context, svc aws.CloudWatchLogsService, group, bucket, prefix string, from, to time.Time, progress jobs.Progress) error {
+	id, err := svc.CreateExportTask(ctx, group, bucket, prefix, from, to)
+	if err != nil {
+		return err
+	}
+	progress(0, 1)
+
+	ticker := time.NewTicker(exportPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			cancelCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := svc.CancelExportTask(cancelCtx, id); err != nil {
+				return fmt.Errorf("%w (the export task %s could not be cancelled: %s)", ctx.Err(), id, clients.ErrorReason(err))
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		task, err := svc.DescribeExportTask(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			return err
+		}
+		if !task.Finished() {
+			continue
+		}
+		if task.Status != "COMPLETED" {
+			return fmt.Errorf("export task %s %s: %s", id, strings.ToLower(task.Status), task.Message)
+		}
+		progress(1, 1)
+		return nil
+	}
+}
+
+// parsePastTime parses a point in time up to now: now, a time ago (-90m,
+// -24h, -7d), a date and time (2026-10-16 19:00) or a date (2026-10-16, its
+// start)
+func parsePastTime(spec string, now time.Time) (time.Time, error) {
+	spec = strings.ToLower(strings.Join(strings.Fields(spec), " "))
+	switch {
+	case spec == "":
+		return time.Time{}, fmt.Errorf("no time given")
+	case spec == "now":
+		return now, nil
+	}
+
+	if ago, ok := strings.CutPrefix(spec, "-"); ok {
+		ago = strings.ReplaceAll(ago, " ", "")
+		var d time.Duration
+		var err error
+		if days, isDays := strings.CutSuffix(ago, "d"); isDays {
+			var n int
+			n, err = strconv.Atoi(days)
+			d = time.Duration(n) * 24 * time.Hour
+		} else {
+			d, err = time.ParseDuration(ago)
+		}
+		if err != nil || d <= 0 {
+			return time.Time{}, fmt.Errorf("invalid time ago %q, expected e.g. -90m, -24h or -7d", spec)
+		}
+		return now.Add(-d).Truncate(time.Second), nil
+	}
+
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02"} {
+		if at, err := time.ParseInLocation(layout, spec, now.Location()); err == nil {
+			if at.After(now) {
+				return time.Time{}, fmt.Errorf("%s is in the future", spec)
+			}
+			return at, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected now, -24h, -7d, 2026-10-16 or 2026-10-16 19:00", spec)
+}
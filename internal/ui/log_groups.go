@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// logGroupBrowser lists the CloudWatch log groups of the account a page at a
+// time, so accounts with thousands of groups open quickly. The next page
+// loads when the selection reaches the last group.
+type logGroupBrowser struct {
+	client *aws.Client
+	prefix *tview.InputField
+	table  *tview.Table
+
+	groups    []clients.LogGroupInfo
+	nextToken string
+	loading   bool
+	// gen is bumped for every new prefix; pages of an older one are dropped
+	gen int
+}
+
+// showLogGroups opens the log group browser. Enter on a group shows its
+// events in the CloudWatch source; groups of linked source accounts are
+// opened by ARN. x exports a group to S3.
+func (lt *LogsTab) showLogGroups() {
+	if lt.offline {
+		lt.updateStatus(offlineStatus(), "yellow")
+		return
+	}
+	lt.mu.RLock()
+	client := lt.awsClient
+	lt.mu.RUnlock()
+	if client == nil {
+		lt.updateStatus(i18n.T("logs.no_client"), "yellow")
+		return
+	}
+
+	b := &logGroupBrowser{client: client}
+	b.table = tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	b.table.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("logs.groups"))
+	b.prefix = tview.NewInputField().
+		SetLabel(i18n.T("logs.prefix_label")).
+		SetFieldWidth(0)
+	b.prefix.SetBorder(true)
+
+	b.table.SetSelectionChangedFunc(func(row, column int) {
+		if row >= len(b.groups) && b.nextToken != "" && !b.loading {
+			lt.loadLogGroupPage(b)
+		}
+	})
+	b.table.SetSelectedFunc(func(row, column int) {
+		if row < 1 || row > len(b.groups) {
+			return
+		}
+		lt.openLogGroup(b, b.groups[row-1])
+	})
+	b.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			lt.closeLogGroups()
+			return nil
+		case 'x':
+			if row, _ := b.table.GetSelection(); row >= 1 && row <= len(b.groups) {
+				lt.askLogGroupExport(b, b.groups[row-1])
+			}
+			return nil
+		case '/':
+			if lt.app != nil {
+				lt.app.SetFocus(b.prefix)
+			}
+			return nil
+		}
+		return event
+	})
+	b.prefix.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			b.groups, b.nextToken = nil, ""
+			b.gen++
+			lt.loadLogGroupPage(b)
+		}
+		if lt.app != nil {
+			lt.app.SetFocus(b.table)
+		}
+	})
+
+	// The status stays in sight below the groups
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(b.prefix, 3, 0, false).
+		AddItem(b.table, 0, 1, true).
+		AddItem(lt.statusText, 9, 0, false)
+	lt.view.AddPage("loggroups", layout, true, true)
+	if lt.app != nil {
+		lt.app.SetFocus(b.table)
+	}
+	lt.loadLogGroupPage(b)
+}
+
+// loadLogGroupPage loads the next page of log groups of b in the background
+// and appends it to the table
+func (lt *LogsTab) loadLogGroupPage(b *logGroupBrowser) {
+	b.loading = true
+	gen, prefix, token := b.gen, b.prefix.GetText(), b.nextToken
+	if len(b.groups) == 0 {
+		setTableMessage(b.table, i18n.T("logs.groups_loading"), tcell.ColorGray)
+		b.table.Select(0, 0)
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var page clients.LogGroupPage
+		err := fmt.Errorf("CloudWatch Logs service not initialized")
+		if svc := b.client.GetClients(); svc != nil && svc.CloudWatchLogs != nil {
+			page, err = svc.CloudWatchLogs.ListLogGroups(ctx, prefix, token)
+		}
+		if err != nil {
+			logger.Error("Failed to list log groups", zap.String("prefix", prefix), zap.Error(err))
+		}
+		if lt.app == nil {
+			return
+		}
+		lt.app.QueueUpdateDraw(func() {
+			if gen != b.gen {
+				return
+			}
+			b.loading = false
+			if err != nil {
+				if len(b.groups) == 0 {
+					setTableMessage(b.table, i18n.T("logs.groups_failed", clients.ErrorReason(err)), tcell.ColorRed)
+				} else {
+					lt.updateStatus(i18n.T("logs.groups_more_failed", clients.ErrorReason(err)), "red")
+				}
+				return
+			}
+			b.groups = append(b.groups, page.Groups...)
+			b.nextToken = page.NextToken
+			fillLogGroups(b.table, b.groups, b.nextToken != "", b.client.GetAccountID())
+		})
+	}()
+}
+
+// logGroupColumns are the columns of the log group browser
+var logGroupColumns = []string{"Log Group", "Retention", "Stored", "Created", "Account"}
+
+// fillLogGroups lists groups with their retention and stored size, marking
+// the groups of accounts other than account. A last row tells when more
+// groups follow.
+func fillLogGroups(table *tview.Table, groups []clients.LogGroupInfo, more bool, account string) {
+	row, _ := table.GetSelection()
+	if len(groups) == 0 {
+		setTableMessage(table, i18n.T("logs.groups_none"), tcell.ColorGray)
+		return
+	}
+
+	table.Clear()
+	for col, name := range logGroupColumns {
+		table.SetCell(0, col, tview.NewTableCell(columnTitle(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, group := range groups {
+		retention, retentionColor := i18n.T("logs.retention_days", group.RetentionDays), tcell.ColorWhite
+		if group.RetentionDays == 0 {
+			// Events kept forever keep costing
+			retention, retentionColor = i18n.T("logs.retention_never"), tcell.ColorYellow
+		}
+		owner, ownerColor := i18n.T("logs.account_own"), tcell.ColorGray
+		if account != "" && group.Account != "" && group.Account != account {
+			owner, ownerColor = i18n.T("logs.account_linked", group.Account), tcell.ColorAqua
+		}
+		created := "-"
+		if !group.CreatedAt.IsZero() {
+			created = zonedTime(group.CreatedAt, "2006-01-02")
+		}
+
+		table.SetCell(i+1, 0, tview.NewTableCell(tview.Escape(group.Name)).SetExpansion(1))
+		table.SetCell(i+1, 1, tview.NewTableCell(retention).SetTextColor(retentionColor))
+		table.SetCell(i+1, 2, tview.NewTableCell(formatBytes(group.StoredBytes)).SetAlign(tview.AlignRight))
+		table.SetCell(i+1, 3, tview.NewTableCell(created))
+		table.SetCell(i+1, 4, tview.NewTableCell(owner).SetTextColor(ownerColor))
+	}
+	if more {
+		table.SetCell(len(groups)+1, 0, tview.NewTableCell(i18n.T("logs.groups_more")).
+			SetTextColor(tcell.ColorGray))
+	}
+	count := fmt.Sprint(len(groups))
+	if more {
+		count += "+"
+	}
+	table.SetTitle(panelTitle("logs.groups_count", count))
+	if row < 1 {
+		row = 1
+	}
+	table.Select(row, 0)
+}
+
+// openLogGroup closes the browser b and shows the events of group. The
+// groups of linked accounts are read by ARN.
+func (lt *LogsTab) openLogGroup(b *logGroupBrowser, group clients.LogGroupInfo) {
+	lt.closeLogGroups()
+	ref := group.Name
+	if account := b.client.GetAccountID(); account != "" && group.Account != "" && group.Account != account {
+		ref = group.ARN
+	}
+	lt.ShowLogGroup(ref)
+}
+
+// closeLogGroups removes the log group browser and returns focus to the
+// source list
+func (lt *LogsTab) closeLogGroups() {
+	lt.view.RemovePage("loggroups")
+	if lt.app != nil {
+		lt.app.SetFocus(lt.logSourceList)
+	}
+}
@@ -0,0 +1,283 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// logPane is a set of the panes of the Logs tab a key works in
+type logPane int
+
+const (
+	logSourcesPane logPane = 1 << iota
+	logTablePane
+	logPinnedPane
+	logFilterPane
+)
+
+// logPaneNames are the catalog keys naming the panes in the help, by bit
+var logPaneNames = []string{"logs.pane_sources", "logs.pane_logs", "logs.pane_pinned", "logs.pane_filter"}
+
+// logKey is a key of the Logs tab. Keys without run are handled by the
+// panes themselves, e.g. Enter, or by handleLogKey, like ?, and are only
+// listed in the help.
+type logKey struct {
+	keyBinding
+	panes logPane
+	// help is the catalog key of the help text
+	help string
+	// run acts on the log table the key was pressed in, or the main one
+	run func(lt *LogsTab, table *tview.Table, rows *logTableContent)
+}
+
+// logKeys are the keys of the Logs tab in the order the help lists them
+var logKeys []logKey
+
+// logKeys is set in init as the keys refer to the panes handling them
+func init() {
+	logKeys = []logKey{
+		{runeKey('?'), logSourcesPane | logTablePane | logPinnedPane, "logs.key_help", nil},
+		{specialKey(tcell.KeyEnter), logTablePane, "logs.key_show", nil},
+		{runeKey('r'), logSourcesPane | logTablePane, "logs.key_refresh", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.Refresh()
+		}},
+		{runeKey('c'), logSourcesPane | logTablePane, "logs.key_clear", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.clearLogs()
+		}},
+		{runeKey('s'), logSourcesPane | logTablePane, "logs.key_autoscroll", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.toggleAutoScroll()
+		}},
+		{runeKey('f'), logSourcesPane | logTablePane, "logs.key_filter", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.focusFilter()
+		}},
+		{specialKey(tcell.KeyUp), logFilterPane, "logs.key_filter_recall", nil},
+		{specialKey(tcell.KeyEnter), logFilterPane, "logs.key_filter_keep", nil},
+		{runeKey('g'), logTablePane | logPinnedPane, "logs.key_jump", nil},
+		{runeKey('y'), logTablePane, "logs.key_copy", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.copyLogEntry()
+		}},
+		{runeKey('x'), logTablePane, "logs.key_context", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.showLogContext()
+		}},
+		{runeKey('o'), logSourcesPane | logTablePane, "logs.key_groups", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.showLogGroups()
+		}},
+		{runeKey('t'), logSourcesPane | logTablePane, "logs.key_stream", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.focusStreamSelect()
+		}},
+		{runeKey('b'), logSourcesPane | logTablePane | logPinnedPane, "logs.key_group", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.toggleStreamGroups()
+		}},
+		{runeKey('v'), logSourcesPane | logTablePane | logPinnedPane, "logs.key_pin", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.toggleSplit()
+		}},
+		{runeKey('w'), logSourcesPane | logTablePane | logPinnedPane, "logs.key_pane", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.switchLogPane()
+		}},
+		{runeKey('W'), logTablePane | logPinnedPane, "logs.key_wrap", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.toggleWrap()
+		}},
+		{specialKey(tcell.KeyLeft), logTablePane | logPinnedPane, "logs.key_scroll_left", func(lt *LogsTab, _ *tview.Table, rows *logTableContent) {
+			lt.scrollMessages(rows, -logScrollStep)
+		}},
+		{specialKey(tcell.KeyRight), logTablePane | logPinnedPane, "logs.key_scroll_right", func(lt *LogsTab, _ *tview.Table, rows *logTableContent) {
+			lt.scrollMessages(rows, logScrollStep)
+		}},
+		{runeKey('p'), logTablePane | logPinnedPane, "logs.key_pretty", func(lt *LogsTab, _ *tview.Table, _ *logTableContent) {
+			lt.togglePretty()
+		}},
+		{runeKey('z'), logTablePane | logPinnedPane, "logs.key_fold", func(lt *LogsTab, table *tview.Table, rows *logTableContent) {
+			lt.toggleFold(table, rows)
+		}},
+	}
+}
+
+// handleLogKey runs the key of event in pane on table and reports whether
+// it was one of the Logs tab keys
+func (lt *LogsTab) handleLogKey(pane logPane, table *tview.Table, rows *logTableContent, event *tcell.EventKey) bool {
+	if runeKey('?').matches(event) {
+		lt.showLogHelp()
+		return true
+	}
+	for _, key := range logKeys {
+		if key.run != nil && key.panes&pane != 0 && key.matches(event) {
+			key.run(lt, table, rows)
+			return true
+		}
+	}
+	return false
+}
+
+// analyzerHelp are the catalog keys telling how the fields of an analyzer
+// are matched
+var analyzerHelp = map[string]string{
+	"standard": "logs.analyzer_standard",
+	"simple":   "logs.analyzer_simple",
+	"keyword":  "logs.analyzer_keyword",
+	"en":       "logs.analyzer_en",
+}
+
+// logHelpText renders the help of the Logs tab: the query syntax with the
+// fields of the search index mapping m, the levels with the level rules
+// configured and the keys
+func logHelpText(m *mapping.IndexMappingImpl, rules levelRules) string {
+	var text strings.Builder
+
+	text.WriteString("[yellow::b]" + i18n.T("logs.help_filter") + "[-::-]\n")
+	chars := make([]string, 0, len(searchQueryChars))
+	for _, ch := range searchQueryChars {
+		chars = append(chars, fmt.Sprintf("%q", ch))
+	}
+	text.WriteString(i18n.T("logs.help_filter_text", strings.Join(chars, ", ")) + "\n\n")
+	for _, line := range [][2]string{
+		{"timeout", "logs.query_word"},
+		{"+timeout +db", "logs.query_words"},
+		{`"connection reset"`, "logs.query_phrase"},
+		{"time*", "logs.query_wildcard"},
+		{"/time.*ut/", "logs.query_regexp"},
+		{"Field:value", "logs.query_field"},
+		{"timeout^2", "logs.query_boost"},
+	} {
+		fmt.Fprintf(&text, "  [aqua]%-22s[-] %s\n", tview.Escape(line[0]), i18n.T(line[1]))
+	}
+
+	text.WriteString("\n[yellow::b]" + i18n.T("logs.help_fields") + "[-::-]\n")
+	doc := m.TypeMapping[m.DefaultType]
+	if doc == nil {
+		doc = m.DefaultMapping
+	}
+	names := make([]string, 0, len(doc.Properties))
+	for name := range doc.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, field := range doc.Properties[name].Fields {
+			if field.Name != "" {
+				name = field.Name
+			}
+			fmt.Fprintf(&text, "  [aqua]%-10s[-] %-8s %s\n", name, field.Type, fieldMatchHelp(m, field))
+		}
+	}
+	if doc.Dynamic {
+		fmt.Fprintf(&text, "  %s\n", i18n.T("logs.help_other_fields", analyzerText(m.DefaultAnalyzer)))
+	}
+
+	text.WriteString("\n[yellow::b]" + i18n.T("logs.help_levels") + "[-::-]\n")
+	levels := make([]string, 0, len(logLevels))
+	for _, level := range logLevels {
+		levels = append(levels, fmt.Sprintf("[%s]%s[-]", levelColor(level), level))
+	}
+	fmt.Fprintf(&text, "  %s\n", strings.Join(levels, " "))
+	if field, ok := doc.Properties["Level"]; ok && len(field.Fields) > 0 && field.Fields[0].Analyzer == "keyword" {
+		text.WriteString(i18n.T("logs.help_level_example") + "\n")
+	}
+	if len(rules) > 0 {
+		fmt.Fprintf(&text, "  %s\n", i18n.T("logs.help_rules"))
+		for _, rule := range rules {
+			group := rule.logGroup
+			if group == "" {
+				group = i18n.T("logs.rule_any_group")
+			}
+			from := i18n.T("logs.rule_field", strings.Join(rule.field, "."))
+			if rule.pattern != nil {
+				from = i18n.T("logs.rule_pattern", rule.pattern.String())
+			}
+			level := rule.level
+			if level == "" {
+				level = i18n.T("logs.rule_level_read")
+			}
+			fmt.Fprintf(&text, "  %s: %s -> %s\n", tview.Escape(group), tview.Escape(from), level)
+		}
+	}
+
+	text.WriteString("\n[yellow::b]" + i18n.T("logs.help_keys") + "[-::-]\n")
+	for _, key := range logKeys {
+		var panes []string
+		for i, name := range logPaneNames {
+			if key.panes&(1<<i) != 0 {
+				panes = append(panes, i18n.T(name))
+			}
+		}
+		fmt.Fprintf(&text, "  [aqua]%-6s[-] %-62s [gray]%s[-]\n", tview.Escape(key.label()), i18n.T(key.help), strings.Join(panes, ", "))
+	}
+	return text.String()
+}
+
+// fieldMatchHelp tells how the values of field are matched
+func fieldMatchHelp(m *mapping.IndexMappingImpl, field *mapping.FieldMapping) string {
+	if field.Type != "text" {
+		return i18n.T("logs.match_value")
+	}
+	analyzer := field.Analyzer
+	if analyzer == "" {
+		analyzer = m.DefaultAnalyzer
+	}
+	return analyzerText(analyzer)
+}
+
+// analyzerText names an analyzer with how it matches
+func analyzerText(analyzer string) string {
+	if help, ok := analyzerHelp[analyzer]; ok {
+		return i18n.T("logs.analyzer_named", i18n.T(help), analyzer)
+	}
+	return i18n.T("logs.analyzer_other", analyzer)
+}
+
+// showLogHelp shows the query syntax, fields, levels and keys of the Logs tab
+func (lt *LogsTab) showLogHelp() {
+	lt.searchIndexMu.RLock()
+	index := lt.searchIndex
+	lt.searchIndexMu.RUnlock()
+
+	m := newLogIndexMapping()
+	if index != nil {
+		if im, ok := index.Mapping().(*mapping.IndexMappingImpl); ok {
+			m = im
+		}
+	}
+
+	var back tview.Primitive
+	if lt.app != nil {
+		back = lt.app.GetFocus()
+	}
+
+	help := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false).
+		SetText(logHelpText(m, lt.levelRules))
+	help.SetBorder(true).
+		SetTitle(panelTitle("logs.help_title")).
+		SetTitleAlign(tview.AlignLeft)
+
+	closeHelp := func() {
+		lt.view.RemovePage("help")
+		if back != nil {
+			lt.app.SetFocus(back)
+		}
+	}
+	help.SetDoneFunc(func(key tcell.Key) {
+		closeHelp()
+	})
+	help.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q', '?':
+			closeHelp()
+			return nil
+		}
+		return event
+	})
+
+	lt.view.AddPage("help", centered(help, 100, 40), true, true)
+	if lt.app != nil {
+		lt.app.SetFocus(help)
+	}
+}
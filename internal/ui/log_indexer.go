@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/blevesearch/bleve/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	// logIndexQueueSize bounds the entries waiting to be indexed; when it is
+	// full new entries are not indexed so tailing never blocks on the index
+	logIndexQueueSize = 10000
+	// logIndexBatchSize is the most entries committed in one index batch
+	logIndexBatchSize = 500
+	// logIndexFlushInterval is how long a partial batch waits before it is committed
+	logIndexFlushInterval = 250 * time.Millisecond
+)
+
+// logIndexer indexes log entries into Bleve in batches from a buffered queue
+type logIndexer struct {
+	index      bleve.Index
+	queue      chan LogEntry
+	onProgress func()
+
+	pending  atomic.Int64
+	dropped  atomic.Int64
+	oldestMu sync.Mutex
+	oldest   time.Time
+
+	closeMu sync.RWMutex
+	closed  bool
+	done    chan struct{}
+}
+
+// newLogIndexer starts indexing into index; onProgress is called after each
+// committed batch and may be nil
+func newLogIndexer(index bleve.Index, onProgress func()) *logIndexer {
+	li := &logIndexer{
+		index:      index,
+		queue:      make(chan LogEntry, logIndexQueueSize),
+		onProgress: onProgress,
+		done:       make(chan struct{}),
+	}
+	go li.run()
+	return li
+}
+
+// logEntryID returns the search index document ID of an entry
+func logEntryID(entry LogEntry) string {
+	return fmt.Sprintf("%s_%d_%s", entry.Source, entry.Timestamp.UnixNano(), entry.Message[:min(50, len(entry.Message))])
+}
+
+// Enqueue queues entries for indexing without blocking. It returns false if
+// the queue was full and some entries were dropped.
+func (li *logIndexer) Enqueue(entries ...LogEntry) bool {
+	if li == nil {
+		return false
+	}
+
+	li.closeMu.RLock()
+	defer li.closeMu.RUnlock()
+	if li.closed {
+		return false
+	}
+
+	for i, entry := range entries {
+		select {
+		case li.queue <- entry:
+			if li.pending.Add(1) == 1 {
+				li.oldestMu.Lock()
+				li.oldest = time.Now()
+				li.oldestMu.Unlock()
+			}
+		default:
+			li.dropped.Add(int64(len(entries) - i))
+			return false
+		}
+	}
+	return true
+}
+
+// Lag returns the number of entries waiting to be indexed, roughly how long
+// they have waited and how many entries were dropped so far
+func (li *logIndexer) Lag() (pending int, delay time.Duration, dropped int) {
+	if li == nil {
+		return 0, 0, 0
+	}
+
+	pending = int(li.pending.Load())
+	if pending > 0 {
+		li.oldestMu.Lock()
+		delay = time.Since(li.oldest)
+		li.oldestMu.Unlock()
+	}
+	return pending, delay, int(li.dropped.Load())
+}
+
+// Close stops accepting entries and waits until the queued ones are indexed
+func (li *logIndexer) Close() {
+	if li == nil {
+		return
+	}
+
+	li.closeMu.Lock()
+	if !li.closed {
+		li.closed = true
+		close(li.queue)
+	}
+	li.closeMu.Unlock()
+	<-li.done
+}
+
+func (li *logIndexer) run() {
+	defer close(li.done)
+
+	ticker := time.NewTicker(logIndexFlushInterval)
+	defer ticker.Stop()
+
+	batch := li.index.NewBatch()
+	count := 0
+
+	flush := func() {
+		if count == 0 {
+			return
+		}
+		if err := li.index.Batch(batch); err != nil {
+			logger.Debug("Failed to index log batch", zap.Int("entries", count), zap.Error(err))
+		}
+		batch.Reset()
+
+		li.oldestMu.Lock()
+		if li.pending.Add(-int64(count)) > 0 {
+			li.oldest = time.Now()
+		}
+		li.oldestMu.Unlock()
+		count = 0
+
+		if li.onProgress != nil {
+			li.onProgress()
+		}
+	}
+
+	for {
+		select {
+		case entry, ok := <-li.queue:
+			if !ok {
+				flush()
+				return
+			}
+			if err := batch.Index(logEntryID(entry), entry); err != nil {
+				logger.Debug("Failed to add log entry to batch", zap.Error(err))
+				li.pending.Add(-1)
+				continue
+			}
+			count++
+			if count >= logIndexBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+)
+
+func TestLogIndexerBatches(t *testing.T) {
+	index, err := bleve.NewMemOnly(bleve.NewIndexMapping())
+	if err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer index.Close()
+
+	progress := 0
+	indexer := newLogIndexer(index, func() { progress++ })
+
+	now := time.Now()
+	var entries []LogEntry
+	for i := 0; i < logIndexBatchSize+10; i++ {
+		entries = append(entries, LogEntry{Timestamp: now.Add(time.Duration(i)), Level: "INFO", Message: "entry", Source: "test"})
+	}
+	if !indexer.Enqueue(entries...) {
+		t.Fatal("Expected entries to be queued")
+	}
+	indexer.Close()
+
+	count, err := index.DocCount()
+	if err != nil {
+		t.Fatalf("Failed to count documents: %v", err)
+	}
+	if count != uint64(len(entries)) {
+		t.Errorf("Expected %d indexed documents, got %d", len(entries), count)
+	}
+	if progress < 2 {
+		t.Errorf("Expected at least 2 committed batches, got %d", progress)
+	}
+
+	if pending, _, dropped := indexer.Lag(); pending != 0 || dropped != 0 {
+		t.Errorf("Expected no lag after close, got %d pending and %d dropped", pending, dropped)
+	}
+	if indexer.Enqueue(entries[0]) {
+		t.Error("Expected enqueue after close to fail")
+	}
+}
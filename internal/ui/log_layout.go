@@ -0,0 +1,464 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/tview"
+)
+
+// logScrollStep is how many characters Left and Right scroll cut off messages
+const logScrollStep = 10
+
+// logLayout is how the log table lays out messages. Long messages are cut
+// off at the edge of the table and scrolled horizontally unless wrap is set;
+// pretty shows JSON messages indented over several rows. group groups the
+// entries of log streams by stream.
+type logLayout struct {
+	wrap   bool
+	pretty bool
+	group  bool
+}
+
+// logLine is a row of the log table while entries may take more than one
+// row: a line of the message of an entry, whose time and level are shown on
+// its first line, or the header of the group of a log stream, whose entry
+// is -1
+type logLine struct {
+	entry int
+	text  string
+	first bool
+	group string
+}
+
+// setLayout lays out the messages anew. Scrolling starts over.
+func (c *logTableContent) setLayout(layout logLayout) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.layout = layout
+	c.offset = 0
+	c.relayout()
+}
+
+// resize sets the inner width of the table, which wrapped messages are
+// fitted to, and reports whether the rows changed
+func (c *logTableContent) resize(width int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Time and level come first, with a space after each
+	width -= runewidth.StringWidth("↺ "+logTime(time.Now())) + len("ERROR") + 2
+	if c.streams {
+		width -= c.streamWidth + 1
+	}
+	if width == c.width {
+		return false
+	}
+	c.width = width
+	if !c.layout.wrap {
+		return false
+	}
+	c.relayout()
+	return true
+}
+
+// scroll moves cut off messages by delta characters and reports whether
+// they moved
+func (c *logTableContent) scroll(delta int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	offset := max(c.offset+delta, 0)
+	if c.layout.wrap || offset == c.offset {
+		return false
+	}
+	c.offset = offset
+	return true
+}
+
+// toggleFold folds the pretty printed JSON message of the entry at index
+// into one row, or unfolds it, and reports whether it is such a message
+func (c *logTableContent) toggleFold(index int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.layout.pretty || index < 0 || index >= len(c.entries) {
+		return false
+	}
+	entry := c.entries[index]
+	if _, ok := prettyJSON(entry.Message); !ok {
+		return false
+	}
+	if c.folded == nil {
+		c.folded = make(map[string]bool)
+	}
+	key := foldKey(entry)
+	c.folded[key] = !c.folded[key]
+	c.relayout()
+	return true
+}
+
+// relayout maps the rows to the lines of the entries; every entry takes one
+// row unless messages are wrapped or pretty printed (locked)
+func (c *logTableContent) relayout() {
+	c.lines = nil
+	switch {
+	case c.layout.group && c.streams:
+		c.lines = make([]logLine, 0, len(c.entries))
+		c.layoutGroups()
+	case c.layout.wrap || c.layout.pretty:
+		c.lines = make([]logLine, 0, len(c.entries))
+		c.layoutEntries(0)
+	}
+}
+
+// layoutEntries adds the lines of the entries from index from on (locked)
+func (c *logTableContent) layoutEntries(from int) {
+	for i := from; i < len(c.entries); i++ {
+		c.layoutEntry(i)
+	}
+}
+
+// layoutEntry adds the lines of the entry at index i (locked)
+func (c *logTableContent) layoutEntry(i int) {
+	entry := c.entries[i]
+	parts := []string{plainMessage(entry)}
+	if lines, ok := prettyJSON(entry.Message); ok && c.layout.pretty {
+		if c.folded[foldKey(entry)] {
+			parts = []string{"▸ " + parts[0]}
+		} else {
+			parts = append([]string{"▾ " + lines[0]}, lines[1:]...)
+			if fields := fieldSummary(entry); fields != "" {
+				parts = append(parts, fields)
+			}
+		}
+	}
+
+	first := true
+	for _, part := range parts {
+		wrapped := []string{part}
+		if c.layout.wrap {
+			wrapped = wrapText(part, c.width)
+		}
+		for _, text := range wrapped {
+			c.lines = append(c.lines, logLine{entry: i, text: text, first: first})
+			first = false
+		}
+	}
+}
+
+// lineText renders a line of the message of entry, moved by the scroll
+// offset, in its ANSI colors and with the filter and search terms
+// highlighted (locked)
+func (c *logTableContent) lineText(entry LogEntry, text string) string {
+	if !c.layout.wrap && c.offset > 0 {
+		rest, ok := skipCharacters(text, c.offset)
+		if !ok {
+			return ""
+		}
+		return "[gray]…[-]" + ansiToTview(rest, c.terms(entry))
+	}
+	return ansiToTview(text, c.terms(entry))
+}
+
+// skipCharacters drops the first n characters of text, keeping the escape
+// sequences among them, and reports whether any characters are left
+func skipCharacters(text string, n int) (string, bool) {
+	var escapes strings.Builder
+	for i := 0; i < len(text); {
+		if text[i] == '\x1b' {
+			size, _, _ := nextEscape(text[i:])
+			escapes.WriteString(text[i : i+size])
+			i += size
+			continue
+		}
+		if n == 0 {
+			return escapes.String() + text[i:], true
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		n--
+	}
+	return "", false
+}
+
+// terms returns the filter text and the terms a search matched in entry (locked)
+func (c *logTableContent) terms(entry LogEntry) []string {
+	var terms []string
+	if c.filterText != "" {
+		terms = append(terms, c.filterText)
+	}
+	for _, fragments := range entry.Highlights {
+		for _, fragment := range fragments {
+			for {
+				start := strings.Index(fragment, "<mark>")
+				if start < 0 {
+					break
+				}
+				fragment = fragment[start+len("<mark>"):]
+				end := strings.Index(fragment, "</mark>")
+				if end < 0 {
+					break
+				}
+				terms = append(terms, fragment[:end])
+				fragment = fragment[end+len("</mark>"):]
+			}
+		}
+	}
+	return terms
+}
+
+// rowOf returns the row of the first line of the entry at index
+func (c *logTableContent) rowOf(index int) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.lines == nil {
+		return index + 1
+	}
+	for row, line := range c.lines {
+		if line.entry == index {
+			return row + 1
+		}
+	}
+	// The entries of collapsed groups are found at their header
+	if index >= 0 && index < len(c.entries) {
+		for row, line := range c.lines {
+			if line.entry < 0 && line.group == c.entries[index].Stream {
+				return row + 1
+			}
+		}
+	}
+	return len(c.lines)
+}
+
+// indexAt returns the index of the entry shown in row, or -1
+func (c *logTableContent) indexAt(row int) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.lines == nil {
+		if row < 1 || row > len(c.entries) {
+			return -1
+		}
+		return row - 1
+	}
+	if row < 1 || row > len(c.lines) {
+		return -1
+	}
+	return c.lines[row-1].entry
+}
+
+// plainMessage renders the message and the fields of entry on one line
+// without colors
+func plainMessage(entry LogEntry) string {
+	message := lineBreaks.Replace(entry.Message)
+	if fields := fieldSummary(entry); fields != "" {
+		return message + " " + fields
+	}
+	return message
+}
+
+// fieldSummary renders the fields of entry as key=value pairs
+func fieldSummary(entry LogEntry) string {
+	pairs := make([]string, 0, len(entry.Fields))
+	for _, key := range sortedFieldKeys(entry.Fields) {
+		pairs = append(pairs, key+"="+lineBreaks.Replace(fmt.Sprintf("%v", entry.Fields[key])))
+	}
+	return strings.Join(pairs, " ")
+}
+
+// foldKey identifies an entry across relayouts and trims
+func foldKey(entry LogEntry) string {
+	return fmt.Sprintf("%d|%s", entry.Timestamp.UnixNano(), entry.Message)
+}
+
+// prettyJSON indents the JSON object of message over several lines, keeping
+// what comes before it, such as the time and request ID of Lambda runtimes,
+// on the first line
+func prettyJSON(message string) ([]string, bool) {
+	start := strings.IndexByte(message, '{')
+	if start < 0 {
+		return nil, false
+	}
+	body := strings.TrimSpace(message[start:])
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(body), "", "  "); err != nil {
+		return nil, false
+	}
+	lines := strings.Split(indented.String(), "\n")
+	lines[0] = lineBreaks.Replace(message[:start]) + lines[0]
+	return lines, true
+}
+
+// wrapText breaks text into lines of at most width columns, at spaces where
+// it can. Lines go on in the ANSI colors the line before ended with.
+func wrapText(text string, width int) []string {
+	if width < 1 || runewidth.StringWidth(stripANSI(text)) <= width {
+		return []string{text}
+	}
+
+	var lines []string
+	for text != "" {
+		end, columns, lastSpace := 0, 0, -1
+		for end < len(text) {
+			if text[end] == '\x1b' {
+				size, _, _ := nextEscape(text[end:])
+				end += size
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(text[end:])
+			w := runewidth.RuneWidth(r)
+			if columns+w > width {
+				if columns == 0 {
+					// A character wider than the table
+					end += size
+				}
+				break
+			}
+			if r == ' ' {
+				lastSpace = end
+			}
+			columns += w
+			end += size
+		}
+		if end == len(text) {
+			lines = append(lines, text)
+			break
+		}
+
+		var line string
+		if text[end] == ' ' {
+			lastSpace = end
+		}
+		if lastSpace > 0 {
+			line, text = text[:lastSpace], text[lastSpace+1:]
+		} else {
+			line, text = text[:end], text[end:]
+		}
+		lines = append(lines, line)
+		if text == "" {
+			break
+		}
+		text = ansiState(line) + text
+	}
+	return lines
+}
+
+// markTerms highlights the terms in text, ignoring case, and goes on in style
+// after each
+func markTerms(text string, terms []string, style ansiStyle) string {
+	if len(terms) == 0 {
+		return text
+	}
+	restore := "[-:-:-]"
+	if style != (ansiStyle{}) {
+		restore = style.tag()
+	}
+	lower := strings.ToLower(text)
+	var result strings.Builder
+	for i := 0; i < len(text); {
+		matched := 0
+		for _, term := range terms {
+			if len(term) > matched && strings.HasPrefix(lower[i:], strings.ToLower(term)) {
+				matched = len(term)
+			}
+		}
+		if matched == 0 {
+			result.WriteByte(text[i])
+			i++
+			continue
+		}
+		result.WriteString("[#ffff00::b]" + text[i:i+matched] + restore)
+		i += matched
+	}
+	return result.String()
+}
+
+// fitLogTable returns the draw func of a log table, which fits wrapped
+// messages to its width before its rows are drawn and keeps the selected
+// entry selected
+func fitLogTable(table *tview.Table, rows *logTableContent) func(tcell.Screen, int, int, int, int) (int, int, int, int) {
+	return func(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+		x, y, width, height = x+1, y+1, width-2, height-2
+		row, _ := table.GetSelection()
+		index := rows.indexAt(row)
+		if rows.resize(width) && index >= 0 {
+			table.Select(rows.rowOf(index), 0)
+		}
+		return x, y, width, height
+	}
+}
+
+// toggleWrap wraps long messages over several rows, or cuts them off
+func (lt *LogsTab) toggleWrap() {
+	lt.lineLayout.wrap = !lt.lineLayout.wrap
+	lt.applyLineLayout()
+	if lt.lineLayout.wrap {
+		lt.updateStatus(i18n.T("logs.wrap_on"), "green")
+	} else {
+		lt.updateStatus(i18n.T("logs.wrap_off"), "green")
+	}
+}
+
+// togglePretty pretty prints JSON messages, or shows them on one line
+func (lt *LogsTab) togglePretty() {
+	lt.lineLayout.pretty = !lt.lineLayout.pretty
+	lt.applyLineLayout()
+	if lt.lineLayout.pretty {
+		lt.updateStatus(i18n.T("logs.pretty_on"), "green")
+	} else {
+		lt.updateStatus(i18n.T("logs.pretty_off"), "green")
+	}
+}
+
+// toggleFold collapses the stream group or folds the pretty printed JSON
+// message selected in table, or unfolds it
+func (lt *LogsTab) toggleFold(table *tview.Table, rows *logTableContent) {
+	if toggleSelectedGroup(table, rows) {
+		return
+	}
+	row, _ := table.GetSelection()
+	index := rows.indexAt(row)
+	if !rows.toggleFold(index) {
+		lt.updateStatus(i18n.T("logs.fold_json_only"), "yellow")
+		return
+	}
+	table.Select(rows.rowOf(index), 0)
+}
+
+// scrollMessages scrolls the cut off messages of rows horizontally
+func (lt *LogsTab) scrollMessages(rows *logTableContent, delta int) {
+	if lt.lineLayout.wrap {
+		lt.updateStatus(i18n.T("logs.wrapped_no_scroll"), "yellow")
+		return
+	}
+	rows.scroll(delta)
+}
+
+// applyLineLayout lays out the messages of the log table and the pinned
+// pane anew, keeping the selected entries selected
+func (lt *LogsTab) applyLineLayout() {
+	relayoutTable(lt.logView, lt.logRows, lt.lineLayout)
+	if lt.split != nil {
+		relayoutTable(lt.split.table, lt.split.rows, lt.lineLayout)
+	}
+	lt.updateLogTitle()
+}
+
+func relayoutTable(table *tview.Table, rows *logTableContent, layout logLayout) {
+	row, _ := table.GetSelection()
+	index := rows.indexAt(row)
+	rows.setLayout(layout)
+	if index >= 0 {
+		table.Select(rows.rowOf(index), 0)
+	}
+}
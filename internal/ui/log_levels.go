@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// levelRule is a compiled config.LevelRule
+type levelRule struct {
+	logGroup string
+	field    []string
+	pattern  *regexp.Regexp
+	// group is the index of the pattern group holding the level, 0 if it
+	// has none and a match means level
+	group int
+	level string
+}
+
+// levelRules infer the levels of CloudWatch events
+type levelRules []levelRule
+
+// compileLevelRules compiles the configured rules, skipping invalid ones
+func compileLevelRules(rules []config.LevelRule) levelRules {
+	compiled := make(levelRules, 0, len(rules))
+	for i, rule := range rules {
+		r := levelRule{logGroup: rule.LogGroup, level: normalizeLevel(rule.Level)}
+		if rule.Field != "" {
+			r.field = strings.Split(rule.Field, ".")
+		} else {
+			re, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				logger.Warn("Ignoring level rule", zap.Int("rule", i+1), zap.Error(err))
+				continue
+			}
+			r.pattern = re
+			if r.group = re.SubexpIndex("level"); r.group < 0 {
+				r.group = min(re.NumSubexp(), 1)
+			}
+		}
+		compiled = append(compiled, r)
+	}
+	return compiled
+}
+
+// infer returns the level of message, an event of logGroup, from the first
+// matching rule, or guesses it from common formats
+func (rules levelRules) infer(logGroup, message string) string {
+	message = stripANSI(message)
+	for _, rule := range rules {
+		if !strings.HasPrefix(logGroup, rule.logGroup) {
+			continue
+		}
+		if level := rule.match(message); level != "" {
+			return level
+		}
+	}
+	return guessLogLevel(message)
+}
+
+// match returns the level rule reads from message, "" if it does not match
+func (rule levelRule) match(message string) string {
+	if rule.pattern != nil {
+		groups := rule.pattern.FindStringSubmatch(message)
+		switch {
+		case groups == nil:
+			return ""
+		case rule.group == 0:
+			return rule.level
+		default:
+			return normalizeLevel(groups[rule.group])
+		}
+	}
+
+	// Lambda runtimes put the time and request ID before JSON messages
+	start := strings.IndexByte(message, '{')
+	if start < 0 {
+		return ""
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(message[start:]), &value); err != nil {
+		return ""
+	}
+	for _, key := range rule.field {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = object[key]
+	}
+	switch v := value.(type) {
+	case string:
+		return normalizeLevel(v)
+	case float64:
+		return normalizeLevel(strconv.Itoa(int(v)))
+	default:
+		return ""
+	}
+}
+
+// logLevels are the levels normalizeLevel maps to, least severe first
+var logLevels = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+// normalizeLevel maps the level names and numbers of common loggers to the
+// levels the Logs tab colors and filters by
+func normalizeLevel(level string) string {
+	level = strings.ToUpper(strings.TrimSpace(level))
+	switch level {
+	case "WARNING", "40":
+		return "WARN"
+	case "ERR", "CRITICAL", "CRIT", "SEVERE", "ALERT", "EMERGENCY", "EMERG", "50":
+		return "ERROR"
+	case "PANIC", "60":
+		return "FATAL"
+	case "INFORMATION", "NOTICE", "30":
+		return "INFO"
+	case "20":
+		return "DEBUG"
+	case "10":
+		return "TRACE"
+	}
+	return level
+}
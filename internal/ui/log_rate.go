@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// logRateWindow is how many seconds of live entries the rate
+	// sparklines of the status panel show
+	logRateWindow = 60
+	// logRateBars is how many bars a rate sparkline has, each the average
+	// rate over logRateWindow/logRateBars seconds
+	logRateBars = 10
+)
+
+// rateBlocks are the bar heights of a rate sparkline, lowest first
+var rateBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// logRate counts the live entries of the shown source and the errors among
+// them per second over the last logRateWindow seconds, so spikes stay
+// visible while auto-scroll races past them
+type logRate struct {
+	mu sync.Mutex
+	// second is the Unix second of the newest bucket; the buckets of a
+	// second s are at s % logRateWindow
+	second int64
+	events [logRateWindow]int
+	errors [logRateWindow]int
+	// ticking is set while the status panel is redrawn every second
+	ticking bool
+}
+
+// add counts an entry that arrived at
+func (r *logRate) add(at time.Time, isError bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := at.Unix()
+	if s < r.second-logRateWindow+1 {
+		return
+	}
+	r.advance(s)
+	r.events[s%logRateWindow]++
+	if isError {
+		r.errors[s%logRateWindow]++
+	}
+}
+
+// advance empties the buckets of the seconds up to now (locked)
+func (r *logRate) advance(now int64) {
+	if now <= r.second {
+		return
+	}
+	if now-r.second >= logRateWindow {
+		r.events, r.errors = [logRateWindow]int{}, [logRateWindow]int{}
+	} else {
+		for s := r.second + 1; s <= now; s++ {
+			r.events[s%logRateWindow], r.errors[s%logRateWindow] = 0, 0
+		}
+	}
+	r.second = now
+}
+
+// series returns the entries and errors per second in logRateBars bars up
+// to now, oldest first
+func (r *logRate) series(now time.Time) (events, errors []float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.advance(now.Unix())
+	per := logRateWindow / logRateBars
+	first := r.second - logRateWindow + 1
+	events, errors = make([]float64, logRateBars), make([]float64, logRateBars)
+	for bar := 0; bar < logRateBars; bar++ {
+		for s := first + int64(bar*per); s < first+int64((bar+1)*per); s++ {
+			i := (s%logRateWindow + logRateWindow) % logRateWindow
+			events[bar] += float64(r.events[i])
+			errors[bar] += float64(r.errors[i])
+		}
+		events[bar] /= float64(per)
+		errors[bar] /= float64(per)
+	}
+	return events, errors
+}
+
+// reset forgets the entries counted, e.g. of another source
+func (r *logRate) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events, r.errors = [logRateWindow]int{}, [logRateWindow]int{}
+}
+
+// rateSparkline draws rates as bars from zero up to the highest
+func rateSparkline(values []float64) string {
+	high := 0.0
+	for _, v := range values {
+		high = max(high, v)
+	}
+	var line strings.Builder
+	for _, v := range values {
+		index := 0
+		if high > 0 && v > 0 {
+			// Any entry at all shows above the baseline
+			index = max(int(v/high*float64(len(rateBlocks)-1)+0.5), 1)
+		}
+		line.WriteRune(rateBlocks[index])
+	}
+	return line.String()
+}
+
+// formatRate shows a rate per second with one decimal below 10
+func formatRate(rate float64) string {
+	if rate >= 10 {
+		return fmt.Sprintf("%.0f/s", rate)
+	}
+	return fmt.Sprintf("%.1f/s", rate)
+}
+
+// rateStatus renders the sparklines of the entries and errors per second
+// for the status panel, or "" if no entries arrived in the window
+func (lt *LogsTab) rateStatus(now time.Time) string {
+	events, errors := lt.rate.series(now)
+	idle := true
+	for _, v := range events {
+		if v > 0 {
+			idle = false
+		}
+	}
+	if idle {
+		return ""
+	}
+	return fmt.Sprintf("[green]Events %s %s[-]\n[red]Errors %s %s[-]",
+		rateSparkline(events), formatRate(events[len(events)-1]),
+		rateSparkline(errors), formatRate(errors[len(errors)-1]))
+}
+
+// countLiveEntry counts an entry arriving in the shown source and keeps the
+// status panel moving while entries arrive
+func (lt *LogsTab) countLiveEntry(entry LogEntry) {
+	level := strings.ToUpper(entry.Level)
+	lt.rate.add(time.Now(), level == "ERROR" || level == "FATAL")
+
+	lt.rate.mu.Lock()
+	defer lt.rate.mu.Unlock()
+	if lt.app == nil || lt.rate.ticking {
+		return
+	}
+	lt.rate.ticking = true
+	time.AfterFunc(time.Second, lt.tickRate)
+}
+
+// tickRate redraws the status panel every second until no entries arrived
+// for logRateWindow seconds
+func (lt *LogsTab) tickRate() {
+	lt.app.QueueUpdateDraw(lt.renderStatus)
+
+	if lt.rateStatus(time.Now()) == "" {
+		lt.rate.mu.Lock()
+		lt.rate.ticking = false
+		lt.rate.mu.Unlock()
+		return
+	}
+	time.AfterFunc(time.Second, lt.tickRate)
+}
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"sort"
+	"time"
+
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// logSplit is the second pane of the split log layout: the entries shown
+// when it was opened, pinned next to the log table for comparison, e.g. the
+// logs of a Lambda function next to those of the consumer of its queue. The
+// selections of both panes follow each other by time.
+type logSplit struct {
+	label string
+	rows  *logTableContent
+	table *tview.Table
+	// following is set while one pane follows the selection of the other
+	following bool
+}
+
+// toggleSplit pins the shown entries in a second pane, or closes it. The log
+// table then goes on with the next source or log group chosen.
+func (lt *LogsTab) toggleSplit() {
+	if lt.split != nil {
+		lt.closeSplit()
+		return
+	}
+	if lt.logRows.count() == 0 {
+		lt.updateStatus(i18n.T("logs.pin_empty"), "yellow")
+		return
+	}
+
+	split := &logSplit{label: lt.sourceLabel(), rows: newLogTableContent(lt)}
+	split.rows.set(lt.logRows.all(), "")
+	split.rows.setLayout(lt.lineLayout)
+	split.table = tview.NewTable().
+		SetContent(split.rows).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorDarkBlue))
+	split.table.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("logs.pinned", tview.Escape(split.label), split.rows.count()))
+
+	split.table.SetSelectionChangedFunc(func(row, column int) {
+		lt.followSelection(split.table, split.rows, lt.logView, lt.logRows)
+	})
+	split.table.SetDrawFunc(fitLogTable(split.table, split.rows))
+	split.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if lt.handleLogKey(logPinnedPane, split.table, split.rows, event) {
+			return nil
+		}
+		return event
+	})
+
+	lt.split = split
+	lt.logPanes.AddItem(split.table, 0, 1, false)
+	lt.followSelection(lt.logView, lt.logRows, split.table, split.rows)
+	lt.updateStatus(i18n.T("logs.pinned_status", split.label), "green")
+}
+
+// closeSplit removes the pinned pane
+func (lt *LogsTab) closeSplit() {
+	if lt.split == nil {
+		return
+	}
+	focused := lt.app != nil && lt.app.GetFocus() == lt.split.table
+	lt.logPanes.RemoveItem(lt.split.table)
+	lt.split = nil
+	if focused {
+		lt.app.SetFocus(lt.logView)
+	}
+}
+
+// switchLogPane moves the focus between the log table and the pinned pane
+func (lt *LogsTab) switchLogPane() {
+	if lt.app == nil {
+		return
+	}
+	if lt.split != nil && lt.app.GetFocus() == lt.logView {
+		lt.app.SetFocus(lt.split.table)
+		return
+	}
+	lt.app.SetFocus(lt.logView)
+}
+
+// followSelection selects the entry of the other pane closest in time to the
+// one selected in the leading pane
+func (lt *LogsTab) followSelection(leader *tview.Table, leaderRows *logTableContent, follower *tview.Table, followerRows *logTableContent) {
+	split := lt.split
+	if split == nil || split.following {
+		return
+	}
+	row, _ := leader.GetSelection()
+	entry, ok := leaderRows.entry(leaderRows.indexAt(row))
+	if !ok {
+		return
+	}
+	index := closestEntry(followerRows.all(), entry.Timestamp)
+	if index < 0 {
+		return
+	}
+
+	split.following = true
+	follower.Select(followerRows.rowOf(index), 0)
+	split.following = false
+}
+
+// closestEntry returns the index of the entry of entries, sorted by time,
+// closest to at, or -1 if there are none
+func closestEntry(entries []LogEntry, at time.Time) int {
+	if len(entries) == 0 {
+		return -1
+	}
+	i := sort.Search(len(entries), func(i int) bool {
+		return !entries[i].Timestamp.Before(at)
+	})
+	switch {
+	case i == len(entries):
+		return i - 1
+	case i > 0 && at.Sub(entries[i-1].Timestamp) <= entries[i].Timestamp.Sub(at):
+		return i - 1
+	default:
+		return i
+	}
+}
+
+// sourceLabel names what the log table shows: the log group, the pod or
+// the source
+func (lt *LogsTab) sourceLabel() string {
+	lt.mu.RLock()
+	defer lt.mu.RUnlock()
+
+	switch {
+	case lt.selectedSource == "cloudwatch" && lt.activeLogGroup != "":
+		return lt.activeLogGroup
+	case lt.selectedSource == "kubernetes" && lt.activePod != nil:
+		return lt.activePod.Namespace + "/" + lt.activePod.Name
+	}
+	for _, source := range logSources {
+		if source.Name == lt.selectedSource {
+			return source.Title()
+		}
+	}
+	return lt.selectedSource
+}
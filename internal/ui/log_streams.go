@@ -0,0 +1,238 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/tview"
+)
+
+// streamLabelWidth is the widest a log stream is shown in the log table
+const streamLabelWidth = 16
+
+// allStreams is the stream filter option showing the entries of all streams
+func allStreams() string {
+	return i18n.T("logs.all_streams")
+}
+
+// streamLabel shortens a log stream name to its last part, e.g. the task ID
+// of an ECS stream (prefix/container/task ID), cut to streamLabelWidth
+func streamLabel(name string) string {
+	for i := len(name) - 1; i >= 0; i-- {
+		if name[i] == '/' {
+			name = name[i+1:]
+			break
+		}
+	}
+	if runes := []rune(name); len(runes) > streamLabelWidth {
+		return string(runes[:streamLabelWidth-1]) + "…"
+	}
+	return name
+}
+
+// noteStreams notes the log streams of entries being shown (locked)
+func (c *logTableContent) noteStreams(entries []LogEntry) {
+	for _, entry := range entries {
+		if entry.Stream == "" {
+			continue
+		}
+		c.streams = true
+		c.streamWidth = max(c.streamWidth, runewidth.StringWidth(streamLabel(entry.Stream)))
+	}
+}
+
+// layoutGroups lays out the entries by log stream, each group under a
+// header row. Groups are ordered by their newest entry, so the stream
+// written to last comes last, like the newest entry. (locked)
+func (c *logTableContent) layoutGroups() {
+	var streams []string
+	members := make(map[string][]int)
+	for i, entry := range c.entries {
+		if _, ok := members[entry.Stream]; !ok {
+			streams = append(streams, entry.Stream)
+		}
+		members[entry.Stream] = append(members[entry.Stream], i)
+	}
+	newest := func(stream string) int {
+		m := members[stream]
+		return m[len(m)-1]
+	}
+	sort.SliceStable(streams, func(i, j int) bool {
+		return c.entries[newest(streams[i])].Timestamp.Before(c.entries[newest(streams[j])].Timestamp)
+	})
+
+	for _, stream := range streams {
+		c.lines = append(c.lines, logLine{entry: -1, group: stream, text: fmt.Sprint(len(members[stream]))})
+		if c.collapsed[stream] {
+			continue
+		}
+		for _, i := range members[stream] {
+			c.layoutEntry(i)
+		}
+	}
+}
+
+// groupText renders the header row of a log stream group (locked)
+func (c *logTableContent) groupText(line *logLine) string {
+	marker := "▾"
+	if c.collapsed[line.group] {
+		marker = "▸"
+	}
+	name := line.group
+	if name == "" {
+		name = "(" + i18n.T("logs.no_stream") + ")"
+	}
+	return fmt.Sprintf("[teal::b]%s %s[-::B] [gray](%s)[-]", marker, tview.Escape(name), i18n.T("logs.group_entries", line.text))
+}
+
+// groupAt returns the log stream whose group header is shown in row
+func (c *logTableContent) groupAt(row int) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.lines == nil || row < 1 || row > len(c.lines) || c.lines[row-1].entry >= 0 {
+		return "", false
+	}
+	return c.lines[row-1].group, true
+}
+
+// toggleGroup collapses the group of stream to its header, or expands it
+func (c *logTableContent) toggleGroup(stream string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.collapsed == nil {
+		c.collapsed = make(map[string]bool)
+	}
+	c.collapsed[stream] = !c.collapsed[stream]
+	c.relayout()
+}
+
+// initStreamSelect creates the stream filter, which shows the entries of
+// one log stream of the log group
+func (lt *LogsTab) initStreamSelect() {
+	lt.streamSelect = tview.NewDropDown().
+		SetLabel(i18n.T("logs.stream_label")).
+		SetFieldWidth(0).
+		SetOptions([]string{allStreams()}, nil).
+		SetCurrentOption(0)
+	lt.streamSelect.SetBorder(true).SetTitle(panelTitle("logs.stream")).SetTitleAlign(tview.AlignLeft)
+	lt.streamSelect.SetSelectedFunc(lt.onStreamSelected)
+	lt.streamSelect.SetDoneFunc(func(key tcell.Key) {
+		if lt.app != nil {
+			lt.app.SetFocus(lt.logSourceList)
+		}
+	})
+}
+
+// setLogStreams offers the streams of the log group in the stream filter,
+// keeping the stream chosen if it is still among them
+func (lt *LogsTab) setLogStreams(streams []string) {
+	lt.streamNames = append([]string(nil), streams...)
+	sort.Strings(lt.streamNames)
+
+	options := []string{allStreams()}
+	current := 0
+	for i, stream := range lt.streamNames {
+		options = append(options, streamLabel(stream))
+		if stream == lt.streamFilter {
+			current = i + 1
+		}
+	}
+	if current == 0 {
+		lt.streamFilter = ""
+	}
+	lt.streamSelect.SetOptions(options, nil).SetCurrentOption(current)
+	lt.streamSelect.SetSelectedFunc(lt.onStreamSelected)
+}
+
+// loadedStreams returns the log streams of the CloudWatch entries loaded
+func (lt *LogsTab) loadedStreams() []string {
+	lt.mu.RLock()
+	defer lt.mu.RUnlock()
+
+	var streams []string
+	seen := make(map[string]bool)
+	for _, entry := range lt.logs["cloudwatch"] {
+		if entry.Stream != "" && !seen[entry.Stream] {
+			seen[entry.Stream] = true
+			streams = append(streams, entry.Stream)
+		}
+	}
+	return streams
+}
+
+// onStreamSelected shows the entries of the stream chosen in the filter
+func (lt *LogsTab) onStreamSelected(text string, index int) {
+	stream := ""
+	if index > 0 && index <= len(lt.streamNames) {
+		stream = lt.streamNames[index-1]
+	}
+	if lt.app != nil && lt.streamSelect.HasFocus() {
+		lt.app.SetFocus(lt.logSourceList)
+	}
+	if stream == lt.streamFilter {
+		return
+	}
+
+	lt.streamFilter = stream
+	lt.applyFilter()
+	if stream == "" {
+		lt.updateStatus(i18n.T("logs.all_stream_entries"), "green")
+	} else {
+		lt.updateStatus(i18n.T("logs.stream_entries", stream), "green")
+	}
+}
+
+// resetStreamFilter shows the entries of all streams again
+func (lt *LogsTab) resetStreamFilter() {
+	lt.streamFilter = ""
+	lt.streamSelect.SetOptions([]string{allStreams()}, nil).SetCurrentOption(0)
+	lt.streamSelect.SetSelectedFunc(lt.onStreamSelected)
+	lt.streamNames = nil
+}
+
+// matchesStream reports whether entry passes the stream filter
+func (lt *LogsTab) matchesStream(entry LogEntry) bool {
+	return lt.streamFilter == "" || entry.Stream == lt.streamFilter
+}
+
+// focusStreamSelect moves the focus to the stream filter
+func (lt *LogsTab) focusStreamSelect() {
+	if len(lt.streamNames) == 0 {
+		lt.updateStatus(i18n.T("logs.no_streams_hint"), "yellow")
+		return
+	}
+	if lt.app != nil {
+		lt.app.SetFocus(lt.streamSelect)
+	}
+}
+
+// toggleStreamGroups groups the entries of the log table by log stream, or
+// shows them in time order again
+func (lt *LogsTab) toggleStreamGroups() {
+	lt.lineLayout.group = !lt.lineLayout.group
+	lt.applyLineLayout()
+	if lt.lineLayout.group {
+		lt.updateStatus(i18n.T("logs.group_on"), "green")
+	} else {
+		lt.updateStatus(i18n.T("logs.group_off"), "green")
+	}
+}
+
+// toggleSelectedGroup collapses or expands the log stream group whose
+// header is selected in table and reports whether one was
+func toggleSelectedGroup(table *tview.Table, rows *logTableContent) bool {
+	row, _ := table.GetSelection()
+	stream, ok := rows.groupAt(row)
+	if !ok {
+		return false
+	}
+	rows.toggleGroup(stream)
+	table.Select(min(row, rows.GetRowCount()-1), 0)
+	return true
+}
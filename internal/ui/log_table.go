@@ -0,0 +1,295 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// logColumns are the columns of the log table; Stream is shown between
+// Level and Message while entries of CloudWatch log streams are shown
+var logColumns = []string{"Time", "Level", "Message"}
+
+// backfilledField marks the entries a live tail missed while it was
+// interrupted and read once it could poll again; their time is marked ↺
+const backfilledField = "backfilled"
+
+// lineBreaks flattens multi-line messages into a single table row
+var lineBreaks = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// logTableContent serves log entries to a virtual tview.Table, so only the
+// rows on screen are formatted no matter how many entries are loaded
+type logTableContent struct {
+	tview.TableContentReadOnly
+
+	tab        *LogsTab
+	mu         sync.RWMutex
+	entries    []LogEntry
+	filterText string
+
+	layout logLayout
+	// lines maps the rows to the entries while an entry may take more than
+	// one row, nil while every entry takes one
+	lines []logLine
+	// width is the width of the message column, offset how far cut off
+	// messages are scrolled
+	width  int
+	offset int
+	// folded are the pretty printed JSON messages shown on one row
+	folded map[string]bool
+	// streams is set while entries of log streams are shown, streamWidth is
+	// the width of their labels
+	streams     bool
+	streamWidth int
+	// collapsed are the log stream groups whose entries are hidden
+	collapsed map[string]bool
+}
+
+func newLogTableContent(tab *LogsTab) *logTableContent {
+	return &logTableContent{tab: tab}
+}
+
+// columns returns the columns shown (locked)
+func (c *logTableContent) columns() []string {
+	if !c.streams {
+		return logColumns
+	}
+	return []string{"Time", "Level", "Stream", "Message"}
+}
+
+// GetCell formats the cell of an entry on demand; row 0 is the header
+func (c *logTableContent) GetCell(row, column int) *tview.TableCell {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	columns := c.columns()
+	if column < 0 || column >= len(columns) {
+		return nil
+	}
+	name := columns[column]
+	if row == 0 {
+		return tview.NewTableCell(columnTitle(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false)
+	}
+
+	var line *logLine
+	index := row - 1
+	if c.lines != nil {
+		if row-1 >= len(c.lines) {
+			return nil
+		}
+		line = &c.lines[row-1]
+		index = line.entry
+	}
+	if index < 0 {
+		if name != "Message" {
+			return tview.NewTableCell("")
+		}
+		return tview.NewTableCell(c.groupText(line)).SetExpansion(1)
+	}
+	if index >= len(c.entries) {
+		return nil
+	}
+	entry := c.entries[index]
+	if line != nil && !line.first && name != "Message" {
+		return tview.NewTableCell("")
+	}
+
+	switch name {
+	case "Time":
+		if _, backfilled := entry.Fields[backfilledField]; backfilled {
+			return tview.NewTableCell("↺ " + logTime(entry.Timestamp)).
+				SetTextColor(tcell.ColorAqua)
+		}
+		return tview.NewTableCell(logTime(entry.Timestamp)).
+			SetTextColor(tcell.ColorGray)
+	case "Level":
+		level := strings.ToUpper(entry.Level)
+		if len(entry.Highlights["Level"]) > 0 {
+			level = c.tab.renderHighlightedText(level, "", entry.Highlights["Level"])
+		} else if c.filterText != "" && strings.Contains(strings.ToLower(level), c.filterText) {
+			level = c.tab.renderHighlightedText(level, c.filterText, nil)
+		}
+		return tview.NewTableCell(fmt.Sprintf("[%s]%s[-]", levelColor(entry.Level), level))
+	case "Stream":
+		return tview.NewTableCell(tview.Escape(streamLabel(entry.Stream))).
+			SetTextColor(tcell.ColorTeal)
+	default:
+		if line != nil && (c.layout.wrap || c.layout.pretty) {
+			return tview.NewTableCell(c.lineText(entry, line.text)).SetExpansion(1)
+		}
+		if c.offset > 0 {
+			return tview.NewTableCell(c.lineText(entry, plainMessage(entry))).SetExpansion(1)
+		}
+		return tview.NewTableCell(c.messageText(entry)).SetExpansion(1)
+	}
+}
+
+// messageText renders the message and a compact field summary on one line.
+// Messages with ANSI escape sequences, e.g. from containers, show their colors.
+func (c *logTableContent) messageText(entry LogEntry) string {
+	message := lineBreaks.Replace(entry.Message)
+	if strings.Contains(message, "\x1b") {
+		message = ansiToTview(message, c.terms(entry))
+	} else if len(entry.Highlights["Message"]) > 0 {
+		message = c.tab.renderHighlightedText(message, "", entry.Highlights["Message"])
+	} else if c.filterText != "" {
+		message = c.tab.renderHighlightedText(message, c.filterText, nil)
+	}
+
+	if len(entry.Fields) == 0 {
+		return message
+	}
+
+	var text strings.Builder
+	text.WriteString(message)
+	for _, key := range sortedFieldKeys(entry.Fields) {
+		value := lineBreaks.Replace(fmt.Sprintf("%v", entry.Fields[key]))
+		if strings.Contains(value, "\x1b") {
+			value = ansiToTview(value, c.terms(entry))
+		} else if len(entry.Highlights[key]) > 0 {
+			value = c.tab.renderHighlightedText(value, "", entry.Highlights[key])
+		} else if c.filterText != "" && strings.Contains(strings.ToLower(value), c.filterText) {
+			value = c.tab.renderHighlightedText(value, c.filterText, nil)
+		}
+		text.WriteString(fmt.Sprintf(" [blue]%s=[-]%s", key, value))
+	}
+	return text.String()
+}
+
+func (c *logTableContent) GetRowCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.lines != nil {
+		return len(c.lines) + 1
+	}
+	return len(c.entries) + 1
+}
+
+func (c *logTableContent) GetColumnCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.columns())
+}
+
+// set replaces the shown entries and the filter text they are highlighted with
+func (c *logTableContent) set(entries []LogEntry, filterText string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = entries
+	c.filterText = filterText
+	c.streams, c.streamWidth = false, 0
+	c.noteStreams(entries)
+	c.relayout()
+}
+
+// appendEntries adds entries after the last row
+func (c *logTableContent) appendEntries(entries []LogEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	from := len(c.entries)
+	c.entries = append(c.entries, entries...)
+	c.noteStreams(entries)
+	switch {
+	case c.layout.group:
+		// New entries go into their group
+		c.relayout()
+	case c.lines != nil:
+		c.layoutEntries(from)
+	}
+}
+
+// trim drops the oldest entries beyond max and returns how many were dropped
+func (c *logTableContent) trim(max int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dropped := len(c.entries) - max
+	if dropped <= 0 {
+		return 0
+	}
+	c.entries = append([]LogEntry(nil), c.entries[dropped:]...)
+	c.relayout()
+	return dropped
+}
+
+// all returns a copy of the shown entries
+func (c *logTableContent) all() []LogEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]LogEntry(nil), c.entries...)
+}
+
+func (c *logTableContent) count() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// entry returns the entry shown at index i
+func (c *logTableContent) entry(i int) (LogEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if i < 0 || i >= len(c.entries) {
+		return LogEntry{}, false
+	}
+	return c.entries[i], true
+}
+
+// indexOf returns the index of the entry with the same time, level and message, or -1
+func (c *logTableContent) indexOf(target LogEntry) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for i, entry := range c.entries {
+		if entry.Timestamp.Equal(target.Timestamp) && entry.Level == target.Level && entry.Message == target.Message {
+			return i
+		}
+	}
+	return -1
+}
+
+// levelColor returns the tview color used for a log level
+func levelColor(level string) string {
+	switch strings.ToUpper(level) {
+	case "ERROR", "FATAL":
+		return "red"
+	case "WARN", "WARNING":
+		return "yellow"
+	case "INFO":
+		return "green"
+	case "DEBUG":
+		return "blue"
+	default:
+		return "white"
+	}
+}
+
+// sortedFieldKeys returns the field names of an entry in a stable order
+func sortedFieldKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// logEntryText renders an entry as plain text, e.g. for the clipboard
+func logEntryText(entry LogEntry) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("%s [%s] %s",
+		zonedTime(entry.Timestamp, "2006-01-02 15:04:05.000"),
+		strings.ToUpper(entry.Level),
+		stripANSI(entry.Message)))
+	for _, key := range sortedFieldKeys(entry.Fields) {
+		text.WriteString(fmt.Sprintf(" %s=%s", key, stripANSI(fmt.Sprintf("%v", entry.Fields[key]))))
+	}
+	return text.String()
+}
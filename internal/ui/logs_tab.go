@@ -5,31 +5,46 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"swiss-army-tui/internal/audit"
 	"swiss-army-tui/internal/aws"
 	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/internal/history"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/jobs"
 	"swiss-army-tui/pkg/logger"
 
 	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/mapping"
 	blevequery "github.com/blevesearch/bleve/v2/search/query"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"go.uber.org/zap"
 )
 
+// logRenderInterval caps log view redraws for new entries at 10 per second
+const logRenderInterval = 100 * time.Millisecond
+
 type LogsTab struct {
-	view *tview.Flex
-	app  *tview.Application
+	view  *tview.Pages
+	app   *tview.Application
+	audit *auditTrail
 
 	logSourceList *tview.List
-	logView       *tview.TextView
-	filterInput   *tview.InputField
-	statusText    *tview.TextView
+	logView       *tview.Table
+	logRows       *logTableContent
+	// logPanes holds the log table and, while split, the pinned pane
+	logPanes    *tview.Flex
+	split       *logSplit
+	filterInput *tview.InputField
+	statusText  *tview.TextView
 
 	selectedSource string
 	logs           map[string][]LogEntry
@@ -38,42 +53,102 @@ type LogsTab struct {
 	autoScroll     bool
 	maxLines       int
 	activeLogGroup string
-	awsClient      *aws.Client
+	// linkedStream is the stream of activeLogGroup a link asked for, loaded
+	// even if it is not among the latest streams
+	linkedStream string
+	awsClient    *aws.Client
 
 	// CloudWatch Logs specific fields
 	cloudWatchCtx    context.Context
 	cloudWatchCancel context.CancelFunc
 	tailingActive    bool
 
-	// Bleve search index
+	// The logs of activePod stream into the kubernetes source until
+	// podCancel is called
+	activeCluster string
+	activePod     *clients.Pod
+	podCancel     context.CancelFunc
+
+	// The CloudWatch load is cancelled on navigation and profile changes;
+	// results of an older loadGen are discarded
+	loadMu     sync.Mutex
+	loadCancel context.CancelFunc
+	loadGen    uint64
+
+	// Incremental rendering: entries added since the last redraw
+	pending        []LogEntry
+	flushScheduled bool
+	searchActive   bool
+
+	// Bleve search index, fed in batches by indexer
 	searchIndex   bleve.Index
 	searchIndexMu sync.RWMutex
+	indexer       *logIndexer
+
+	// Filters typed earlier, recalled with up and down
+	filterHistory *inputHistory
+
+	// Infer the levels of CloudWatch events
+	levelRules levelRules
+
+	// How messages are laid out in the log table and the pinned pane
+	lineLayout logLayout
+
+	// The log streams of the log group and the one whose entries are shown,
+	// "" for all
+	streamSelect *tview.DropDown
+	streamNames  []string
+	streamFilter string
+
+	// Entries and errors per second arriving in the shown source
+	rate logRate
+
+	// Runs log group exports, shared with the jobs view of the Resources tab
+	jobs *jobs.Tracker
+
+	// Stops following the application log
+	stopAppLogs func()
+	// Set while browsing a snapshot, whose entries are shown instead of
+	// loading logs from AWS
+	offline bool
+
+	statusMessage string
+	statusColor   string
+	statusTime    time.Time
 }
 
 type LogEntry struct {
-	Timestamp  time.Time
-	Level      string
-	Message    string
-	Source     string
+	Timestamp time.Time
+	Level     string
+	Message   string
+	Source    string
+	// Stream is the CloudWatch log stream the entry was read from
+	Stream     string
 	Fields     map[string]interface{}
 	Highlights map[string][]string // Store highlighting information
 }
 
 type LogSource struct {
-	Name        string
-	DisplayName string
-	Type        string
-	Path        string
-	Enabled     bool
+	Name    string
+	Type    string
+	Path    string
+	Enabled bool
+}
+
+// Title is the name of the source in the current locale
+func (s LogSource) Title() string {
+	return i18n.T("logs.source." + s.Name)
 }
 
 var logSources = []LogSource{
-	{Name: "app", DisplayName: "Application Logs", Type: "memory", Path: "", Enabled: true},
-	{Name: "aws-sdk", DisplayName: "AWS SDK Logs", Type: "memory", Path: "", Enabled: false},
-	{Name: "system", DisplayName: "System Logs", Type: "file", Path: "/var/log/system.log", Enabled: false},
-	{Name: "cloudwatch", DisplayName: "CloudWatch Logs", Type: "aws", Path: "", Enabled: true},
-	{Name: "docker", DisplayName: "Docker Logs", Type: "command", Path: "docker logs", Enabled: false},
-	{Name: "kubectl", DisplayName: "Kubernetes Logs", Type: "command", Path: "kubectl logs", Enabled: false},
+	{Name: "app", Type: "memory", Path: "", Enabled: true},
+	{Name: "alerts", Type: "memory", Path: "", Enabled: true},
+	{Name: "audit", Type: "file", Path: audit.DefaultPath(), Enabled: true},
+	{Name: "aws-sdk", Type: "memory", Path: "", Enabled: false},
+	{Name: "system", Type: "file", Path: "/var/log/system.log", Enabled: false},
+	{Name: "cloudwatch", Type: "aws", Path: "", Enabled: true},
+	{Name: "docker", Type: "command", Path: "docker logs", Enabled: false},
+	{Name: "kubernetes", Type: "eks", Path: "", Enabled: true},
 }
 
 func NewLogsTab(app *tview.Application) (*LogsTab, error) {
@@ -82,6 +157,8 @@ func NewLogsTab(app *tview.Application) (*LogsTab, error) {
 		logs:       make(map[string][]LogEntry),
 		autoScroll: true,
 		maxLines:   1000,
+
+		filterHistory: newInputHistory("logs"),
 	}
 
 	// Initialize Bleve search index
@@ -93,7 +170,7 @@ func NewLogsTab(app *tview.Application) (*LogsTab, error) {
 		return nil, fmt.Errorf("failed to initialize logs tab UI: %w", err)
 	}
 
-	tab.initializeAppLogs()
+	tab.followAppLogs()
 
 	return tab, nil
 }
@@ -106,31 +183,20 @@ func (lt *LogsTab) initializeUI() error {
 		SetSelectedBackgroundColor(tcell.ColorWhite).
 		ShowSecondaryText(true)
 
-	lt.logSourceList.SetBorder(true).SetTitle(" Log Sources ").SetTitleAlign(tview.AlignLeft)
+	lt.logSourceList.SetBorder(true).SetTitle(panelTitle("logs.sources")).SetTitleAlign(tview.AlignLeft)
 
 	lt.logSourceList.SetSelectedFunc(lt.onSourceSelected)
 	lt.logSourceList.SetChangedFunc(lt.onSourceHighlighted)
 
 	lt.logSourceList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Rune() {
-		case 'r':
-			lt.Refresh()
-			return nil
-		case 'c':
-			lt.clearLogs()
-			return nil
-		case 's':
-			lt.toggleAutoScroll()
-			return nil
-		case 'f':
-			lt.focusFilter()
+		if lt.handleLogKey(logSourcesPane, lt.logView, lt.logRows, event) {
 			return nil
 		}
 		return event
 	})
 
 	lt.filterInput = tview.NewInputField().
-		SetLabel("Filter: ").
+		SetLabel(i18n.T("logs.filter_label")).
 		SetFieldWidth(0).
 		SetChangedFunc(lt.onFilterChanged)
 
@@ -140,45 +206,48 @@ func (lt *LogsTab) initializeUI() error {
 			if lt.app != nil {
 				lt.app.SetFocus(lt.logSourceList)
 			}
-			lt.filterInput.SetBorder(true).SetTitle(" Filter Logs ").SetTitleAlign(tview.AlignLeft)
+			lt.filterInput.SetBorder(true).SetTitle(panelTitle("logs.filter")).SetTitleAlign(tview.AlignLeft)
 			return nil
 		case tcell.KeyEnter:
+			lt.filterHistory.Commit(lt.filterInput.GetText())
 			if lt.app != nil {
 				lt.app.SetFocus(lt.logSourceList)
 			}
 			return nil
 		}
+		if lt.filterHistory.HandleKey(lt.filterInput, event) {
+			return nil
+		}
 		return event
 	})
 
-	lt.filterInput.SetBorder(true).SetTitle(" Filter Logs ").SetTitleAlign(tview.AlignLeft)
+	lt.filterInput.SetBorder(true).SetTitle(panelTitle("logs.filter")).SetTitleAlign(tview.AlignLeft)
+	lt.initStreamSelect()
 
-	lt.logView = tview.NewTextView().
-		SetDynamicColors(true).
-		SetScrollable(true).
-		SetWrap(true)
+	// The table is virtual: rows are formatted only when they are drawn
+	lt.logRows = newLogTableContent(lt)
+	lt.logView = tview.NewTable().
+		SetContent(lt.logRows).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorDarkBlue))
 
-	lt.logView.SetBorder(true).SetTitle(" Logs ").SetTitleAlign(tview.AlignLeft)
+	lt.logView.SetBorder(true).SetTitle(panelTitle("logs.logs")).SetTitleAlign(tview.AlignLeft)
+	lt.logView.SetSelectedFunc(func(row, column int) {
+		if toggleSelectedGroup(lt.logView, lt.logRows) {
+			return
+		}
+		lt.showLogDetail(lt.logRows.indexAt(row))
+	})
+	lt.logView.SetDrawFunc(fitLogTable(lt.logView, lt.logRows))
+	lt.logView.SetSelectionChangedFunc(func(row, column int) {
+		if lt.split != nil {
+			lt.followSelection(lt.logView, lt.logRows, lt.split.table, lt.split.rows)
+		}
+	})
 
 	lt.logView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Rune() {
-		case 'r':
-			lt.Refresh()
-			return nil
-		case 'c':
-			lt.clearLogs()
-			return nil
-		case 's':
-			lt.toggleAutoScroll()
-			return nil
-		case 'f':
-			lt.focusFilter()
-			return nil
-		case 'g':
-			lt.logView.ScrollToBeginning()
-			return nil
-		case 'G':
-			lt.logView.ScrollToEnd()
+		if lt.handleLogKey(logTablePane, lt.logView, lt.logRows, event) {
 			return nil
 		}
 		return event
@@ -188,22 +257,25 @@ func (lt *LogsTab) initializeUI() error {
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter)
 
-	lt.statusText.SetBorder(true).SetTitle(" Status ").SetTitleAlign(tview.AlignLeft)
-	lt.updateStatus("Ready", "green")
+	lt.statusText.SetBorder(true).SetTitle(panelTitle("common.status")).SetTitleAlign(tview.AlignLeft)
+	lt.updateStatus(i18n.T("common.ready"), "green")
 
 	lt.loadLogSources()
 
 	leftPanel := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(lt.logSourceList, 0, 2, true).
 		AddItem(lt.filterInput, 3, 0, false).
-		AddItem(lt.statusText, 5, 0, false)
+		AddItem(lt.streamSelect, 3, 0, false).
+		AddItem(lt.statusText, 9, 0, false)
 
-	rightPanel := tview.NewFlex().SetDirection(tview.FlexRow).
+	lt.logPanes = tview.NewFlex().SetDirection(tview.FlexColumn).
 		AddItem(lt.logView, 0, 1, false)
 
-	lt.view = tview.NewFlex().SetDirection(tview.FlexColumn).
+	mainLayout := tview.NewFlex().SetDirection(tview.FlexColumn).
 		AddItem(leftPanel, 25, 0, true).
-		AddItem(rightPanel, 0, 1, false)
+		AddItem(lt.logPanes, 0, 1, false)
+
+	lt.view = tview.NewPages().AddPage("main", mainLayout, true, true)
 
 	return nil
 }
@@ -212,14 +284,7 @@ func (lt *LogsTab) loadLogSources() {
 	lt.logSourceList.Clear()
 
 	for i, source := range logSources {
-		mainText := source.DisplayName
-		secondaryText := source.Type
-
-		if !source.Enabled {
-			mainText = fmt.Sprintf("[gray]%s (Disabled)[-]", mainText)
-			secondaryText = "Not available"
-		}
-
+		mainText, secondaryText := sourceItem(source)
 		lt.logSourceList.AddItem(mainText, secondaryText, rune('0'+i%10), func() {
 			if source.Enabled {
 				lt.selectSource(source.Name)
@@ -235,6 +300,29 @@ func (lt *LogsTab) loadLogSources() {
 	}
 }
 
+// sourceItem returns the texts of source in the source list
+func sourceItem(source LogSource) (string, string) {
+	if !source.Enabled {
+		return "[gray]" + i18n.T("logs.source_disabled", source.Title()) + "[-]", i18n.T("logs.not_available")
+	}
+	return source.Title(), source.Type
+}
+
+// applyLocale titles the panels and names the sources in the current locale
+func (lt *LogsTab) applyLocale() {
+	lt.logSourceList.SetTitle(panelTitle("logs.sources"))
+	for i, source := range logSources {
+		mainText, secondaryText := sourceItem(source)
+		lt.logSourceList.SetItemText(i, mainText, secondaryText)
+	}
+	lt.filterInput.SetLabel(i18n.T("logs.filter_label")).SetTitle(panelTitle("logs.filter"))
+	lt.streamSelect.SetLabel(i18n.T("logs.stream_label")).SetTitle(panelTitle("logs.stream"))
+	lt.setLogStreams(lt.streamNames)
+	lt.statusText.SetTitle(panelTitle("common.status"))
+	lt.updateLogTitle()
+	lt.renderStatus()
+}
+
 func (lt *LogsTab) onSourceSelected(index int, mainText, secondaryText string, shortcut rune) {
 	if index >= 0 && index < len(logSources) {
 		source := logSources[index]
@@ -248,7 +336,7 @@ func (lt *LogsTab) onSourceHighlighted(index int, mainText, secondaryText string
 
 	if index >= 0 && index < len(logSources) {
 		source := logSources[index]
-		lt.updateStatus(fmt.Sprintf("Source: %s (%s)", source.DisplayName, source.Type), "blue")
+		lt.updateStatus(i18n.T("logs.source_status", source.Title(), source.Type), "blue")
 	}
 }
 
@@ -256,6 +344,14 @@ func (lt *LogsTab) selectSource(sourceName string) {
 	lt.mu.Lock()
 	lt.selectedSource = sourceName
 	lt.mu.Unlock()
+	lt.rate.reset()
+
+	if sourceName != "cloudwatch" {
+		lt.cancelCloudWatchLoad()
+		lt.resetStreamFilter()
+	} else if len(lt.streamNames) == 0 {
+		lt.setLogStreams(lt.loadedStreams())
+	}
 
 	logger.Debug("Selecting log source", zap.String("source", sourceName))
 
@@ -263,6 +359,11 @@ func (lt *LogsTab) selectSource(sourceName string) {
 }
 
 func (lt *LogsTab) loadLogsForSource(sourceName string) {
+	// The audit log is written by other tabs, so always reread it
+	if sourceName == "audit" {
+		lt.loadAuditLog()
+	}
+
 	lt.mu.RLock()
 	logs, exists := lt.logs[sourceName]
 	lt.mu.RUnlock()
@@ -270,13 +371,19 @@ func (lt *LogsTab) loadLogsForSource(sourceName string) {
 	if !exists {
 		lt.mu.Lock()
 		switch sourceName {
+		case "kubernetes":
+			lt.logs[sourceName] = []LogEntry{}
+			lt.updateStatus(i18n.T("logs.no_pod"), "yellow")
 		case "cloudwatch":
 			logger.Info("CloudWatch logs activated...")
 			lt.logs[sourceName] = []LogEntry{}
-			if lt.activeLogGroup != "" && lt.awsClient != nil {
-				go lt.loadCloudWatchLogs(lt.activeLogGroup)
-			} else {
-				lt.updateStatus("No active log group or AWS client available", "yellow")
+			switch {
+			case lt.offline:
+				lt.updateStatus(i18n.T("logs.group_not_in_snapshot", lt.activeLogGroup), "yellow")
+			case lt.activeLogGroup != "" && lt.awsClient != nil:
+				lt.startCloudWatchLoad(lt.activeLogGroup)
+			default:
+				lt.updateStatus(i18n.T("logs.no_group_hint"), "yellow")
 			}
 		default:
 			lt.logs[sourceName] = []LogEntry{}
@@ -286,7 +393,7 @@ func (lt *LogsTab) loadLogsForSource(sourceName string) {
 	}
 
 	lt.updateLogDisplay(logs)
-	lt.updateStatus(fmt.Sprintf("Showing %d log entries from %s", len(logs), sourceName), "green")
+	lt.updateStatus(i18n.T("logs.showing", len(logs), sourceName), "green")
 }
 
 func (lt *LogsTab) updateLogDisplay(logs []LogEntry) {
@@ -294,112 +401,127 @@ func (lt *LogsTab) updateLogDisplay(logs []LogEntry) {
 	lt.applyFilter()
 }
 
+// applyFilter replaces the shown entries with filteredLogs matching the current filter
 func (lt *LogsTab) applyFilter() {
-	if lt.filterInput == nil || lt.logView == nil {
+	if lt.filterInput == nil || lt.logRows == nil {
 		return
 	}
-	lt.logView.Clear()
+	lt.searchActive = false
+	lt.pending = nil
 
 	filterText := strings.ToLower(strings.TrimSpace(lt.filterInput.GetText()))
 
 	var filtered []LogEntry
-	if filterText == "" {
-		filtered = lt.filteredLogs
+	if filterText == "" && lt.streamFilter == "" {
+		filtered = append(filtered, lt.filteredLogs...)
 	} else {
 		for _, log := range lt.filteredLogs {
-			if strings.Contains(strings.ToLower(log.Message), filterText) ||
-				strings.Contains(strings.ToLower(log.Level), filterText) ||
-				strings.Contains(strings.ToLower(log.Source), filterText) {
+			if lt.matchesStream(log) && (filterText == "" || matchesLogFilter(log, filterText)) {
 				filtered = append(filtered, log)
 			}
 		}
 	}
 
-	sort.Slice(filtered, func(i, j int) bool {
+	sort.SliceStable(filtered, func(i, j int) bool {
 		return filtered[i].Timestamp.Before(filtered[j].Timestamp)
 	})
 
-	var logText strings.Builder
-
-	for _, log := range filtered {
-
-		levelColor := "white"
-		switch strings.ToUpper(log.Level) {
-		case "ERROR", "FATAL":
-			levelColor = "red"
-		case "WARN", "WARNING":
-			levelColor = "yellow"
-		case "INFO":
-			levelColor = "green"
-		case "DEBUG":
-			levelColor = "blue"
-		}
+	lt.renderEntries(filtered, filterText)
+}
 
-		timestamp := log.Timestamp.Format("15:04:05.000")
+// renderEntries replaces the entries shown in the log table
+func (lt *LogsTab) renderEntries(entries []LogEntry, filterText string) {
+	lt.logRows.set(entries, filterText)
 
-		// Apply highlighting to the message
-		highlightedMessage := log.Message
-		if log.Highlights != nil && len(log.Highlights["Message"]) > 0 {
-			highlightedMessage = lt.renderHighlightedText(log.Message, "", log.Highlights["Message"])
-		} else if filterText != "" {
-			highlightedMessage = lt.renderHighlightedText(log.Message, filterText, nil)
-		}
+	if lt.autoScroll {
+		lt.scrollToLatest()
+	} else if row, _ := lt.logView.GetSelection(); row >= lt.logRows.GetRowCount() {
+		lt.logView.Select(lt.logRows.GetRowCount()-1, 0)
+	}
+	lt.updateLogTitle()
+}
 
-		// Apply highlighting to the level if needed
-		highlightedLevel := strings.ToUpper(log.Level)
-		if log.Highlights != nil && len(log.Highlights["Level"]) > 0 {
-			highlightedLevel = lt.renderHighlightedText(highlightedLevel, "", log.Highlights["Level"])
-		} else if filterText != "" && strings.Contains(strings.ToLower(log.Level), filterText) {
-			highlightedLevel = lt.renderHighlightedText(highlightedLevel, filterText, nil)
-		}
+// scrollToLatest selects the newest entry
+func (lt *LogsTab) scrollToLatest() {
+	if count := lt.logRows.count(); count > 0 {
+		lt.logView.Select(lt.logRows.rowOf(count-1), 0)
+	}
+	lt.logView.ScrollToEnd()
+}
 
-		logText.WriteString(fmt.Sprintf("[gray]%s[-] [%s]%-5s[-] %s\n",
-			timestamp, levelColor, highlightedLevel, highlightedMessage))
+// matchesLogFilter reports whether an entry matches the lower-cased filter text
+func matchesLogFilter(log LogEntry, filterText string) bool {
+	return strings.Contains(strings.ToLower(stripANSI(log.Message)), filterText) ||
+		strings.Contains(strings.ToLower(log.Level), filterText) ||
+		strings.Contains(strings.ToLower(log.Source), filterText)
+}
 
-		if len(log.Fields) > 0 {
-			var fieldKeys []string
-			for key := range log.Fields {
-				fieldKeys = append(fieldKeys, key)
-			}
-			sort.Strings(fieldKeys)
+// formatLogEntry renders the full entry with all its fields for the detail view
+func formatLogEntry(log LogEntry) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("%s %s\n", infoLabel("logs.detail_time"), detailTime(log.Timestamp, "2006-01-02 15:04:05.000 MST")))
+	text.WriteString(fmt.Sprintf("%s [%s]%s[-]\n", infoLabel("logs.detail_level"), levelColor(log.Level), strings.ToUpper(log.Level)))
+	text.WriteString(fmt.Sprintf("%s %s\n", infoLabel("logs.detail_source"), log.Source))
+	if log.Stream != "" {
+		text.WriteString(fmt.Sprintf("%s %s\n", infoLabel("logs.detail_stream"), tview.Escape(log.Stream)))
+	}
 
-			for _, key := range fieldKeys {
-				fieldValue := fmt.Sprintf("%v", log.Fields[key])
-				if filterText != "" && strings.Contains(strings.ToLower(fieldValue), filterText) {
-					fieldValue = lt.renderHighlightedText(fieldValue, filterText, nil)
-				}
-				logText.WriteString(fmt.Sprintf("  [blue]%s:[-] %s\n", key, fieldValue))
-			}
+	if len(log.Fields) > 0 {
+		text.WriteString("\n" + infoLabel("logs.detail_fields") + "\n")
+		for _, key := range sortedFieldKeys(log.Fields) {
+			text.WriteString(fmt.Sprintf("  [blue]%s:[-] %s\n", key, ansiToTview(fmt.Sprintf("%v", log.Fields[key]), nil)))
 		}
 	}
 
-	lt.logView.SetText(logText.String())
+	text.WriteString("\n" + infoLabel("logs.detail_message") + "\n")
+	text.WriteString(ansiToTview(log.Message, nil))
+	return text.String()
+}
 
-	if lt.autoScroll {
-		lt.logView.ScrollToEnd()
+// updateLogTitle shows the number of shown entries in the log table title
+func (lt *LogsTab) updateLogTitle() {
+	shown := lt.logRows.count()
+	title := panelTitle("logs.logs_count", shown)
+	if total := len(lt.logs[lt.selectedSource]); shown != total {
+		title = panelTitle("logs.logs_count_of", shown, total)
 	}
-
-	title := fmt.Sprintf(" Logs (%d", len(filtered))
-	if len(filtered) != len(lt.filteredLogs) {
-		title += fmt.Sprintf(" of %d", len(lt.filteredLogs))
+	if lt.lineLayout.wrap {
+		title += "(" + i18n.T("logs.title_wrapped") + ") "
+	}
+	if lt.lineLayout.pretty {
+		title += "(" + i18n.T("logs.title_pretty") + ") "
+	}
+	if lt.lineLayout.group {
+		title += "(" + i18n.T("logs.title_by_stream") + ") "
 	}
-	title += ") "
 	lt.logView.SetTitle(title)
 }
 
+// searchQueryChars are the characters making a filter a search query
+// instead of a plain text match
+const searchQueryChars = " \"*"
+
+// isSearchQuery reports whether a filter looks like a search query, i.e.
+// contains advanced operators
+func isSearchQuery(text string) bool {
+	return strings.ContainsAny(text, searchQueryChars)
+}
+
 func (lt *LogsTab) onFilterChanged(text string) {
-	// Check if this looks like a search query (contains advanced operators)
-	if strings.Contains(text, " ") || strings.Contains(text, "\"") || strings.Contains(text, "*") {
+	if isSearchQuery(text) {
 		lt.performSearch(text)
 	} else {
+		if lt.searchActive {
+			// Leaving search mode: filter the full source again
+			lt.filteredLogs = lt.logs[lt.selectedSource]
+		}
 		lt.applyFilter()
 	}
 }
 
-// initializeSearchIndex creates a Bleve index for fast log searching
-func (lt *LogsTab) initializeSearchIndex() error {
-	// Create a memory-based index for now (could be persisted later)
-	mapping := bleve.NewIndexMapping()
+// newLogIndexMapping maps the fields of log entries in the search index
+func newLogIndexMapping() *mapping.IndexMappingImpl {
+	indexMapping := bleve.NewIndexMapping()
 
 	logEntryMapping := bleve.NewDocumentMapping()
 
@@ -429,10 +551,14 @@ func (lt *LogsTab) initializeSearchIndex() error {
 	timestampFieldMapping.Index = true
 	logEntryMapping.AddFieldMappingsAt("Timestamp", timestampFieldMapping)
 
-	mapping.AddDocumentMapping("_default", logEntryMapping)
+	indexMapping.AddDocumentMapping("_default", logEntryMapping)
+	return indexMapping
+}
 
-	// Create index in memory
-	index, err := bleve.NewMemOnly(mapping)
+// initializeSearchIndex creates a Bleve index for fast log searching
+func (lt *LogsTab) initializeSearchIndex() error {
+	// Create a memory-based index for now (could be persisted later)
+	index, err := bleve.NewMemOnly(newLogIndexMapping())
 	if err != nil {
 		return fmt.Errorf("failed to create search index: %w", err)
 	}
@@ -441,27 +567,16 @@ func (lt *LogsTab) initializeSearchIndex() error {
 	lt.searchIndex = index
 	lt.searchIndexMu.Unlock()
 
+	lt.indexer = newLogIndexer(index, lt.onIndexProgress)
+
 	logger.Info("Search index initialized successfully")
 	return nil
 }
 
-// indexLogEntry adds a log entry to the search index
-func (lt *LogsTab) indexLogEntry(entry LogEntry) {
-	lt.searchIndexMu.RLock()
-	index := lt.searchIndex
-	lt.searchIndexMu.RUnlock()
-
-	if index == nil {
-		return
-	}
-
-	// Create a unique ID for the entry
-	id := fmt.Sprintf("%s_%d_%s", entry.Source, entry.Timestamp.UnixNano(), entry.Message[:min(50, len(entry.Message))])
-
-	// Index the document
-	err := index.Index(id, entry)
-	if err != nil {
-		logger.Debug("Failed to index log entry", zap.Error(err))
+// onIndexProgress refreshes the indexing lag shown in the status panel
+func (lt *LogsTab) onIndexProgress() {
+	if lt.app != nil {
+		go lt.app.QueueUpdateDraw(lt.renderStatus)
 	}
 }
 
@@ -472,7 +587,7 @@ func (lt *LogsTab) performSearch(queryStr string) {
 	lt.searchIndexMu.RUnlock()
 
 	if index == nil {
-		lt.updateStatus("Search index not available", "red")
+		lt.updateStatus(i18n.T("logs.no_index"), "red")
 		return
 	}
 
@@ -494,13 +609,10 @@ func (lt *LogsTab) performSearch(queryStr string) {
 	searchResults, err := index.Search(searchRequest)
 	if err != nil {
 		logger.Error("Search failed", zap.Error(err))
-		lt.updateStatus(fmt.Sprintf("Search error: %s", err.Error()), "red")
+		lt.updateStatus(i18n.T("logs.search_error", err.Error()), "red")
 		return
 	}
 
-	lt.mu.Lock()
-	defer lt.mu.Unlock()
-
 	var searchResultsEntries []LogEntry
 	for _, hit := range searchResults.Hits {
 		// Try to find the original log entry
@@ -519,11 +631,13 @@ func (lt *LogsTab) performSearch(queryStr string) {
 	}
 
 	// Update display with search results
+	lt.mu.Lock()
 	lt.filteredLogs = searchResultsEntries
 	lt.updateLogDisplayFromFiltered()
+	lt.mu.Unlock()
 
 	// Update status
-	status := fmt.Sprintf("Found %d results for '%s'", len(searchResultsEntries), queryStr)
+	status := i18n.T("logs.search_results", len(searchResultsEntries), queryStr)
 	lt.updateStatus(status, "green")
 }
 
@@ -546,8 +660,8 @@ func (lt *LogsTab) renderHighlightedText(text, searchTerm string, highlights []s
 func (lt *LogsTab) renderBleveHighlights(text string, highlights []string) string {
 	for _, fragment := range highlights {
 		if strings.Contains(fragment, "\x1b[") {
-			// Convert ANSI escape sequences to tview format
-			return lt.convertAnsiToTview(fragment)
+			// Bleve's ANSI highlighter marks the matches with escape sequences
+			return ansiToTview(fragment, nil)
 		}
 	}
 
@@ -587,27 +701,6 @@ func (lt *LogsTab) renderSimpleHighlight(text, searchTerm string) string {
 	return result.String()
 }
 
-func (lt *LogsTab) convertAnsiToTview(text string) string {
-	ansiToTview := map[string]string{
-		"\x1b[31m":   "[red]",        // Red
-		"\x1b[33m":   "[yellow]",     // Yellow
-		"\x1b[33;1m": "[#ffff00::b]", // Bold Yellow (highlight)
-		"\x1b[43m":   "[#ffff00]",    // Yellow background
-		"\x1b[1m":    "[::b]",        // Bold
-		"\x1b[0m":    "[-]",          // Reset
-	}
-
-	result := text
-	for ansi, tview := range ansiToTview {
-		result = strings.ReplaceAll(result, ansi, tview)
-	}
-
-	result = strings.ReplaceAll(result, "\x1b[", "[")
-	result = strings.ReplaceAll(result, "m", "]")
-
-	return result
-}
-
 func (lt *LogsTab) enhanceSearchRequest(searchRequest *bleve.SearchRequest) {
 	if searchRequest.Highlight == nil {
 		searchRequest.Highlight = bleve.NewHighlight()
@@ -623,7 +716,7 @@ func (lt *LogsTab) buildSearchQuery(queryStr string) blevequery.Query {
 		return nil
 	}
 
-	if strings.Contains(queryStr, " ") || strings.Contains(queryStr, "\"") || strings.Contains(queryStr, "*") {
+	if isSearchQuery(queryStr) {
 		query := blevequery.NewQueryStringQuery(queryStr)
 		return query
 	}
@@ -683,154 +776,266 @@ func (lt *LogsTab) findLogEntryByID(id string) *LogEntry {
 	return nil
 }
 
-// updateLogDisplayFromFiltered updates the display using filteredLogs
+// updateLogDisplayFromFiltered shows the search results in filteredLogs
 func (lt *LogsTab) updateLogDisplayFromFiltered() {
-	if lt.logView == nil {
+	if lt.logRows == nil {
 		return
 	}
-	lt.logView.Clear()
+	lt.pending = nil
 
-	var logText strings.Builder
 	filterText := strings.ToLower(strings.TrimSpace(lt.filterInput.GetText()))
+	lt.renderEntries(append([]LogEntry(nil), lt.filteredLogs...), filterText)
+	lt.searchActive = true
+}
 
-	for _, log := range lt.filteredLogs {
-		levelColor := "white"
-		switch strings.ToUpper(log.Level) {
-		case "ERROR", "FATAL":
-			levelColor = "red"
-		case "WARN", "WARNING":
-			levelColor = "yellow"
-		case "INFO":
-			levelColor = "green"
-		case "DEBUG":
-			levelColor = "blue"
-		}
+func (lt *LogsTab) addLogEntry(sourceName string, entry LogEntry) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
 
-		timestamp := log.Timestamp.Format("15:04:05.000")
+	// Add to logs
+	lt.logs[sourceName] = append(lt.logs[sourceName], entry)
+	if len(lt.logs[sourceName]) > lt.maxLines {
+		lt.logs[sourceName] = lt.logs[sourceName][len(lt.logs[sourceName])-lt.maxLines:]
+	}
 
-		highlightedMessage := log.Message
-		if log.Highlights != nil && len(log.Highlights["Message"]) > 0 {
-			highlightedMessage = lt.renderHighlightedText(log.Message, "", log.Highlights["Message"])
-		} else if filterText != "" {
-			highlightedMessage = lt.renderHighlightedText(log.Message, filterText, nil)
-		}
+	// Queue the entry for batched indexing
+	lt.indexer.Enqueue(entry)
 
-		highlightedLevel := strings.ToUpper(log.Level)
-		if log.Highlights != nil && len(log.Highlights["Level"]) > 0 {
-			highlightedLevel = lt.renderHighlightedText(highlightedLevel, "", log.Highlights["Level"])
-		} else if filterText != "" && strings.Contains(strings.ToLower(log.Level), filterText) {
-			highlightedLevel = lt.renderHighlightedText(highlightedLevel, filterText, nil)
-		}
+	if sourceName == lt.selectedSource {
+		lt.countLiveEntry(entry)
+	}
 
-		logText.WriteString(fmt.Sprintf("[gray]%s[-] [%s]%-5s[-] %s\n",
-			timestamp, levelColor, highlightedLevel, highlightedMessage))
+	// Search results are a snapshot; new entries show up once the search is cleared
+	if sourceName != lt.selectedSource || lt.searchActive {
+		return
+	}
+	lt.filteredLogs = lt.logs[sourceName]
 
-		if len(log.Fields) > 0 {
-			var fieldKeys []string
-			for key := range log.Fields {
-				fieldKeys = append(fieldKeys, key)
-			}
-			sort.Strings(fieldKeys)
-
-			for _, key := range fieldKeys {
-				fieldValue := fmt.Sprintf("%v", log.Fields[key])
-				if log.Highlights != nil && len(log.Highlights[key]) > 0 {
-					fieldValue = lt.renderHighlightedText(fieldValue, "", log.Highlights[key])
-				} else if filterText != "" && strings.Contains(strings.ToLower(fieldValue), filterText) {
-					fieldValue = lt.renderHighlightedText(fieldValue, filterText, nil)
-				}
-				logText.WriteString(fmt.Sprintf("  [blue]%s:[-] %s\n", key, fieldValue))
-			}
-		}
+	if lt.filterInput == nil || lt.logRows == nil {
+		return
+	}
+
+	filterText := strings.ToLower(strings.TrimSpace(lt.filterInput.GetText()))
+	if filterText != "" && !matchesLogFilter(entry, filterText) || !lt.matchesStream(entry) {
+		return
+	}
+
+	lt.pending = append(lt.pending, entry)
+	lt.scheduleFlush()
+}
+
+// scheduleFlush adds pending entries to the log table after logRenderInterval,
+// so bursts of entries cause a single redraw
+func (lt *LogsTab) scheduleFlush() {
+	if lt.app == nil {
+		lt.flushPending()
+		return
+	}
+	if lt.flushScheduled {
+		return
+	}
+
+	lt.flushScheduled = true
+	time.AfterFunc(logRenderInterval, func() {
+		lt.app.QueueUpdateDraw(func() {
+			lt.mu.Lock()
+			defer lt.mu.Unlock()
+			lt.flushPending()
+		})
+	})
+}
+
+// flushPending appends the pending entries to the log table, dropping the
+// oldest rows once it holds well over maxLines entries
+func (lt *LogsTab) flushPending() {
+	lt.flushScheduled = false
+	if lt.logRows == nil || len(lt.pending) == 0 {
+		lt.pending = nil
+		return
 	}
 
-	lt.logView.SetText(logText.String())
+	lt.logRows.appendEntries(lt.pending)
+	lt.pending = nil
+
+	if lt.logRows.count() > lt.maxLines+lt.maxLines/10 {
+		row, _ := lt.logView.GetSelection()
+		index := lt.logRows.indexAt(row)
+		dropped := lt.logRows.trim(lt.maxLines)
+		// Keep the selection on the same entry while reading older logs
+		if !lt.autoScroll && index >= 0 {
+			lt.logView.Select(lt.logRows.rowOf(max(index-dropped, 0)), 0)
+		}
+	}
 
 	if lt.autoScroll {
-		lt.logView.ScrollToEnd()
+		lt.scrollToLatest()
 	}
+	lt.updateLogTitle()
+}
 
-	title := fmt.Sprintf(" Logs (%d", len(lt.filteredLogs))
-	if len(lt.filteredLogs) != len(lt.logs[lt.selectedSource]) {
-		title += fmt.Sprintf(" of %d", len(lt.logs[lt.selectedSource]))
+// selectedLogEntry returns the entry of the selected table row
+func (lt *LogsTab) selectedLogEntry() (LogEntry, bool) {
+	row, _ := lt.logView.GetSelection()
+	return lt.logRows.entry(lt.logRows.indexAt(row))
+}
+
+// showLogDetail shows the entry at index with its full message and all fields
+func (lt *LogsTab) showLogDetail(index int) {
+	entry, ok := lt.logRows.entry(index)
+	if !ok {
+		return
+	}
+
+	detail := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(formatLogEntry(entry))
+	detail.SetBorder(true).
+		SetTitle(panelTitle("logs.entry")).
+		SetTitleAlign(tview.AlignLeft)
+
+	detail.SetDoneFunc(func(key tcell.Key) {
+		lt.closeLogDetail()
+	})
+	detail.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			lt.closeLogDetail()
+			return nil
+		case 'y':
+			lt.copyToClipboard(entry)
+			return nil
+		}
+		return event
+	})
+
+	lt.view.AddPage("detail", centered(detail, 100, 25), true, true)
+	if lt.app != nil {
+		lt.app.SetFocus(detail)
 	}
-	title += ") "
-	lt.logView.SetTitle(title)
 }
 
-func (lt *LogsTab) addLogEntry(sourceName string, entry LogEntry) {
-	lt.mu.Lock()
-	defer lt.mu.Unlock()
+// closeLogDetail removes the detail view and returns focus to the log table
+func (lt *LogsTab) closeLogDetail() {
+	lt.view.RemovePage("detail")
+	if lt.app != nil {
+		lt.app.SetFocus(lt.logView)
+	}
+}
 
-	if lt.logs[sourceName] == nil {
-		lt.logs[sourceName] = []LogEntry{}
+// copyLogEntry copies the selected entry to the clipboard
+func (lt *LogsTab) copyLogEntry() {
+	entry, ok := lt.selectedLogEntry()
+	if !ok {
+		lt.updateStatus(i18n.T("logs.no_entry"), "yellow")
+		return
 	}
+	lt.copyToClipboard(entry)
+}
 
-	// Add to logs
-	lt.logs[sourceName] = append(lt.logs[sourceName], entry)
+func (lt *LogsTab) copyToClipboard(entry LogEntry) {
+	if err := copyToClipboard(logEntryText(entry)); err != nil {
+		logger.Warn("Failed to copy log entry", zap.Error(err))
+		lt.updateStatus(i18n.T("logs.copy_failed", err), "red")
+		return
+	}
+	lt.updateStatus(i18n.T("logs.copied"), "green")
+}
 
-	// Index the entry for fast search
-	go lt.indexLogEntry(entry)
+// showLogContext clears the filter and search and selects the chosen entry
+// among all entries of the source, pausing auto-scroll to keep it in view
+func (lt *LogsTab) showLogContext() {
+	entry, ok := lt.selectedLogEntry()
+	if !ok {
+		lt.updateStatus(i18n.T("logs.no_entry"), "yellow")
+		return
+	}
 
-	// Update display if this is the current source
-	if sourceName == lt.selectedSource {
+	lt.autoScroll = false
+	lt.mu.RLock()
+	lt.filteredLogs = lt.logs[lt.selectedSource]
+	lt.mu.RUnlock()
+	if lt.filterInput.GetText() != "" {
+		// Clearing the text reapplies the (now empty) filter
+		lt.filterInput.SetText("")
+	} else {
 		lt.applyFilter()
 	}
 
-	if len(lt.logs[sourceName]) > lt.maxLines {
-		lt.logs[sourceName] = lt.logs[sourceName][len(lt.logs[sourceName])-lt.maxLines:]
+	if index := lt.logRows.indexOf(entry); index >= 0 {
+		lt.logView.Select(lt.logRows.rowOf(index), 0)
+	}
+	if lt.app != nil {
+		lt.app.SetFocus(lt.logView)
 	}
+	lt.updateStatus(i18n.T("logs.in_context"), "blue")
 }
 
-func (lt *LogsTab) initializeAppLogs() {
-	sampleLogs := []LogEntry{
-		{
-			Timestamp: time.Now().Add(-5 * time.Minute),
-			Level:     "INFO",
-			Message:   "Application started successfully",
-			Source:    "app",
-			Fields:    map[string]interface{}{"version": "1.0.0", "pid": 12345},
-		},
-		{
-			Timestamp: time.Now().Add(-4 * time.Minute),
-			Level:     "DEBUG",
-			Message:   "Loading configuration from config.yaml",
-			Source:    "app",
-			Fields:    map[string]interface{}{"config_file": "config.yaml"},
-		},
-		{
-			Timestamp: time.Now().Add(-3 * time.Minute),
-			Level:     "WARN",
-			Message:   "Deprecated API endpoint used",
-			Source:    "app",
-			Fields:    map[string]interface{}{"endpoint": "/old-api", "replacement": "/new-api"},
-		},
-		{
-			Timestamp: time.Now().Add(-2 * time.Minute),
-			Level:     "ERROR",
-			Message:   "Database connection failed",
-			Source:    "app",
-			Fields:    map[string]interface{}{"error": "connection timeout", "retry_count": 3},
-		},
-		{
-			Timestamp: time.Now().Add(-1 * time.Minute),
-			Level:     "INFO",
-			Message:   "Database connection re-established",
-			Source:    "app",
-			Fields:    map[string]interface{}{"connection_time": "150ms"},
-		},
-		{
-			Timestamp: time.Now(),
-			Level:     "INFO",
-			Message:   "Logs tab initialized",
-			Source:    "app",
-			Fields:    map[string]interface{}{"sources_available": len(logSources)},
-		},
+// appLogBuffer is how many application log entries wait to be added to the
+// app source before further ones are dropped
+const appLogBuffer = 1000
+
+// followAppLogs shows the entries of the application logger in the app
+// source as they are written, starting with the ones from before the tab
+// existed
+func (lt *LogsTab) followAppLogs() {
+	entries := make(chan logger.Entry, appLogBuffer)
+	done := make(chan struct{})
+	before, unsubscribe := logger.Subscribe(func(entry logger.Entry) {
+		// Entries are logged on any goroutine, some holding lt.mu, so they
+		// are handed over instead of added here
+		select {
+		case entries <- entry:
+		default:
+		}
+	})
+	lt.stopAppLogs = func() {
+		unsubscribe()
+		close(done)
 	}
 
+	initial := make([]LogEntry, 0, len(before))
+	for _, entry := range before {
+		initial = append(initial, appLogEntry(entry))
+	}
+	if len(initial) > lt.maxLines {
+		initial = initial[len(initial)-lt.maxLines:]
+	}
 	lt.mu.Lock()
-	lt.logs["app"] = sampleLogs
+	lt.logs["app"] = initial
 	lt.mu.Unlock()
+	lt.indexer.Enqueue(initial...)
+
+	go func() {
+		for {
+			select {
+			case entry := <-entries:
+				lt.addLogEntry("app", appLogEntry(entry))
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+// appLogEntry converts an entry of the application logger
+func appLogEntry(entry logger.Entry) LogEntry {
+	fields := entry.Fields
+	if entry.Caller != "" {
+		fields = make(map[string]interface{}, len(entry.Fields)+1)
+		for key, value := range entry.Fields {
+			fields[key] = value
+		}
+		fields["caller"] = entry.Caller
+	}
+	return LogEntry{
+		Timestamp: entry.Time,
+		Level:     entry.Level,
+		Message:   entry.Message,
+		Source:    "app",
+		Fields:    fields,
+	}
 }
 
 func (lt *LogsTab) clearLogs() {
@@ -840,42 +1045,59 @@ func (lt *LogsTab) clearLogs() {
 	if lt.selectedSource != "" {
 		lt.logs[lt.selectedSource] = []LogEntry{}
 		lt.updateLogDisplay([]LogEntry{})
-		lt.updateStatus("Logs cleared", "yellow")
+		lt.updateStatus(i18n.T("logs.cleared"), "yellow")
 	}
 }
 
 func (lt *LogsTab) toggleAutoScroll() {
 	lt.autoScroll = !lt.autoScroll
-	status := "disabled"
+	status := i18n.T("logs.autoscroll_disabled")
 	if lt.autoScroll {
-		status = "enabled"
-		lt.logView.ScrollToEnd()
+		status = i18n.T("logs.autoscroll_enabled")
+		lt.scrollToLatest()
 	}
-	lt.updateStatus(fmt.Sprintf("Auto-scroll %s", status), "blue")
+	lt.updateStatus(status, "blue")
 }
 
 func (lt *LogsTab) focusFilter() {
 	if lt.filterInput != nil && lt.app != nil {
 		lt.app.SetFocus(lt.filterInput)
-		lt.filterInput.SetBorder(true).SetTitle(" Filter Logs (Active) ").SetTitleAlign(tview.AlignLeft)
+		lt.filterInput.SetBorder(true).SetTitle(panelTitle("logs.filter_active")).SetTitleAlign(tview.AlignLeft)
 	}
 }
 
 func (lt *LogsTab) updateStatus(message, color string) {
+	lt.statusMessage = message
+	lt.statusColor = color
+	lt.statusTime = time.Now()
+	lt.renderStatus()
+}
+
+// renderStatus shows the last status message, auto-scroll and the indexing lag
+func (lt *LogsTab) renderStatus() {
 	if lt.statusText == nil {
 		return
 	}
-	lt.statusText.Clear()
-
-	timestamp := time.Now().Format("15:04:05")
 
-	autoScrollStatus := "ON"
+	autoScrollStatus := i18n.T("logs.autoscroll_on")
 	if !lt.autoScroll {
-		autoScrollStatus = "OFF"
+		autoScrollStatus = i18n.T("logs.autoscroll_off")
 	}
 
-	statusText := fmt.Sprintf("[%s]%s[-]\n[gray]%s[-]\n[blue]Auto-scroll: %s[-]",
-		color, message, timestamp, autoScrollStatus)
+	indexStatus := "[gray]" + i18n.T("logs.index_current") + "[-]"
+	pending, delay, dropped := lt.indexer.Lag()
+	if pending > 0 {
+		indexStatus = "[yellow]" + i18n.T("logs.index_behind", pending, delay.Seconds()) + "[-]"
+	}
+	if dropped > 0 {
+		indexStatus += " [red](" + i18n.T("logs.index_skipped", dropped) + ")[-]"
+	}
+
+	statusText := fmt.Sprintf("[%s]%s%s[-]\n[gray]%s[-]\n[blue]%s[-]\n%s",
+		lt.statusColor, stateWord(lt.statusColor), lt.statusMessage, lt.statusTime.Format("15:04:05"), i18n.T("logs.autoscroll", autoScrollStatus), indexStatus)
+	if rates := lt.rateStatus(time.Now()); rates != "" {
+		statusText += "\n" + rates
+	}
 	lt.statusText.SetText(statusText)
 }
 
@@ -885,33 +1107,90 @@ func (lt *LogsTab) Refresh() {
 	lt.mu.RUnlock()
 
 	if source == "" {
-		lt.updateStatus("No source selected", "yellow")
+		lt.updateStatus(i18n.T("logs.no_source"), "yellow")
 		return
 	}
 
 	logger.Debug("Refreshing logs", zap.String("source", source))
 
+	// The logs of a snapshot cannot be reloaded
+	if lt.offline && (source == "cloudwatch" || source == "kubernetes") {
+		lt.loadLogsForSource(source)
+		return
+	}
+
 	switch source {
 	case "app":
-		lt.addLogEntry("app", LogEntry{
-			Timestamp: time.Now(),
-			Level:     "INFO",
-			Message:   "Logs refreshed manually",
-			Source:    "app",
-			Fields:    map[string]interface{}{"action": "refresh"},
-		})
+		// The application log is followed live, so only the view is rebuilt
+		lt.mu.RLock()
+		logs := lt.logs["app"]
+		lt.mu.RUnlock()
+		lt.updateLogDisplay(logs)
+	case "kubernetes":
+		lt.mu.Lock()
+		lt.logs["kubernetes"] = []LogEntry{}
+		lt.mu.Unlock()
+		lt.updateLogDisplay([]LogEntry{})
+		lt.startPodStream()
 	case "cloudwatch":
 		lt.stopTailing()
 		if lt.activeLogGroup != "" && lt.awsClient != nil {
-			go lt.loadCloudWatchLogs(lt.activeLogGroup)
+			lt.startCloudWatchLoad(lt.activeLogGroup)
 		} else {
-			lt.updateStatus("No active log group or AWS client available", "yellow")
+			lt.updateStatus(i18n.T("logs.no_group"), "yellow")
 		}
 	default:
 		lt.loadLogsForSource(source)
 	}
 
-	lt.updateStatus("Logs refreshed", "green")
+	lt.updateStatus(i18n.T("logs.refreshed"), "green")
+}
+
+// loadAuditLog reads the most recent audit log entries into the audit source
+func (lt *LogsTab) loadAuditLog() {
+	log := lt.audit.current()
+	if log == nil {
+		lt.updateStatus(i18n.T("logs.audit_not_recorded"), "yellow")
+		return
+	}
+	records, err := log.Read()
+	if err != nil {
+		logger.Warn("Failed to read audit log", zap.Error(err))
+		lt.updateStatus(i18n.T("logs.audit_failed", err), "red")
+		return
+	}
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	if len(records) > lt.maxLines {
+		records = records[len(records)-lt.maxLines:]
+	}
+
+	entries := make([]LogEntry, 0, len(records))
+	for _, record := range records {
+		entry := LogEntry{
+			Timestamp: record.Timestamp,
+			Level:     "INFO",
+			Message:   fmt.Sprintf("%s %s (%s)", record.Action, record.Resource, record.Result),
+			Source:    "audit",
+			Fields: map[string]interface{}{
+				"profile": record.Profile,
+				"account": record.Account,
+				"region":  record.Region,
+			},
+		}
+		if record.Detail != "" {
+			entry.Fields["detail"] = record.Detail
+		}
+		if record.Result != audit.ResultSuccess {
+			entry.Level = "ERROR"
+			entry.Fields["error"] = record.Error
+		}
+		entries = append(entries, entry)
+	}
+	lt.logs["audit"] = entries
+	lt.indexer.Enqueue(entries...)
 }
 
 func (lt *LogsTab) AddApplicationLog(level, message string, fields map[string]interface{}) {
@@ -934,9 +1213,45 @@ func (lt *LogsTab) ShowLambdaLogGroup(functionName, logGroup string) {
 		return
 	}
 
+	lt.ShowLogGroup(logGroup)
+
+	message := i18n.T("logs.lambda_group", functionName, logGroup)
+	lt.updateStatus(message, "blue")
+}
+
+// ShowLogGroup shows the CloudWatch source with the events of logGroup
+func (lt *LogsTab) ShowLogGroup(logGroup string) {
+	lt.showLogGroup(logGroup, "")
+}
+
+// ShowLogStream shows the CloudWatch source with the events of stream of
+// logGroup, choosing it in the stream filter
+func (lt *LogsTab) ShowLogStream(logGroup, stream string) {
+	lt.showLogGroup(logGroup, stream)
+	if lt != nil {
+		lt.updateStatus(i18n.T("logs.stream_entries", stream), "blue")
+	}
+}
+
+// showLogGroup shows the CloudWatch source with the events of logGroup,
+// only those of stream unless it is empty
+func (lt *LogsTab) showLogGroup(logGroup, stream string) {
+	if lt == nil {
+		return
+	}
+
 	lt.mu.Lock()
+	// The events of another group are reloaded rather than shown from before
+	changed := lt.activeLogGroup != logGroup || lt.linkedStream != stream
+	if changed {
+		delete(lt.logs, "cloudwatch")
+	}
 	lt.activeLogGroup = logGroup
+	lt.linkedStream = stream
 	lt.mu.Unlock()
+	if changed {
+		lt.resetStreamFilter()
+	}
 
 	index := -1
 	for i, source := range logSources {
@@ -950,13 +1265,225 @@ func (lt *LogsTab) ShowLambdaLogGroup(functionName, logGroup string) {
 		lt.logSourceList.SetCurrentItem(index)
 		lt.selectSource("cloudwatch")
 	}
+	// Chosen once the source is selected, which drops filters on streams
+	// that are not loaded; the load offers the stream again
+	if changed {
+		lt.streamFilter = stream
+	}
+}
 
-	message := fmt.Sprintf("Lambda %s - CloudWatch log group: %s", functionName, logGroup)
-	lt.updateStatus(message, "blue")
+// ActiveLogGroup returns the CloudWatch log group shown, if the CloudWatch
+// source is selected
+func (lt *LogsTab) ActiveLogGroup() string {
+	lt.mu.RLock()
+	defer lt.mu.RUnlock()
+	if lt.selectedSource != "cloudwatch" {
+		return ""
+	}
+	return lt.activeLogGroup
+}
+
+// ShowPodLogs shows the kubernetes source and streams the logs of pod of
+// the EKS cluster into it, replacing the logs of the previous pod
+func (lt *LogsTab) ShowPodLogs(cluster string, pod clients.Pod) {
+	if lt == nil {
+		return
+	}
+
+	lt.mu.Lock()
+	lt.activeCluster, lt.activePod = cluster, &pod
+	lt.logs["kubernetes"] = []LogEntry{}
+	lt.mu.Unlock()
+
+	for i, source := range logSources {
+		if source.Name == "kubernetes" && source.Enabled {
+			lt.logSourceList.SetCurrentItem(i)
+			lt.selectSource("kubernetes")
+			break
+		}
+	}
+	lt.startPodStream()
+}
+
+// startPodStream restarts streaming the logs of the active pod
+func (lt *LogsTab) startPodStream() {
+	lt.stopPodStream()
+
+	lt.mu.Lock()
+	client, cluster, pod := lt.awsClient, lt.activeCluster, lt.activePod
+	tailLines := lt.maxLines
+	if client == nil || pod == nil {
+		lt.mu.Unlock()
+		lt.updateStatus(i18n.T("logs.no_pod"), "yellow")
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	lt.podCancel = cancel
+	lt.mu.Unlock()
+
+	lt.updateStatus(i18n.T("logs.pod_streaming", pod.Namespace, pod.Name, cluster), "blue")
+	go lt.streamPodLogs(ctx, client, cluster, *pod, tailLines)
+}
+
+// stopPodStream stops streaming pod logs, if a stream is running
+func (lt *LogsTab) stopPodStream() {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	if lt.podCancel != nil {
+		lt.podCancel()
+		lt.podCancel = nil
+	}
+}
+
+// streamPodLogs adds the logs of pod to the kubernetes source until ctx is
+// done or the stream ends
+func (lt *LogsTab) streamPodLogs(ctx context.Context, client *aws.Client, cluster string, pod clients.Pod, tailLines int) {
+	status := func(message, color string) {
+		if lt.app == nil {
+			return
+		}
+		lt.app.QueueUpdateDraw(func() {
+			if ctx.Err() == nil {
+				lt.updateStatus(message, color)
+			}
+		})
+	}
+
+	svc := client.GetClients()
+	if svc == nil || svc.EKS == nil {
+		status(i18n.T("logs.no_eks"), "red")
+		return
+	}
+
+	lines := make(chan clients.PodLogLine, 100)
+	done := make(chan error, 1)
+	go func() {
+		done <- svc.EKS.StreamPodLogs(ctx, cluster, pod, tailLines, lines)
+	}()
+
+	for {
+		select {
+		case line := <-lines:
+			lt.addPodLogLine(line)
+		case err := <-done:
+			// Lines sent before the stream ended may still be buffered
+			for len(lines) > 0 {
+				lt.addPodLogLine(<-lines)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				logger.Error("Failed to stream pod logs", zap.String("cluster", cluster), zap.String("pod", pod.Name), zap.Error(err))
+				status(i18n.T("logs.pod_failed", pod.Name, err.Error()), "red")
+				return
+			}
+			status(i18n.T("logs.pod_ended", pod.Name), "yellow")
+			return
+		}
+	}
+}
+
+// addPodLogLine adds a line of a pod to the kubernetes source
+func (lt *LogsTab) addPodLogLine(line clients.PodLogLine) {
+	entry := LogEntry{
+		Timestamp: line.Timestamp,
+		Level:     guessLogLevel(line.Message),
+		Message:   line.Message,
+		Source:    "kubernetes",
+		Fields: map[string]interface{}{
+			"namespace": line.Namespace,
+			"pod":       line.Pod,
+			"container": line.Container,
+		},
+	}
+
+	if lt.app != nil {
+		// addLogEntry schedules its own debounced redraw
+		lt.app.QueueUpdate(func() {
+			lt.addLogEntry("kubernetes", entry)
+		})
+	} else {
+		lt.addLogEntry("kubernetes", entry)
+	}
+}
+
+// guessLogLevel guesses the level of a log line from common formats, for
+// container logs, which Kubernetes records without levels, and CloudWatch
+// events no level rule matches
+func guessLogLevel(message string) string {
+	message = stripANSI(message)
+	lower := strings.ToLower(message)
+	switch {
+	case strings.HasPrefix(lower, "panic:"), strings.Contains(lower, "level=error"), strings.Contains(lower, `"level":"error"`),
+		strings.Contains(lower, "level=fatal"), strings.Contains(message, "ERROR"):
+		return "ERROR"
+	case strings.Contains(lower, "level=warn"), strings.Contains(lower, `"level":"warn`), strings.Contains(message, "WARN"):
+		return "WARN"
+	default:
+		return "INFO"
+	}
+}
+
+// ApplyConfig applies the configured level rules to events loaded from now
+// on and the log buffer size, trimming existing buffers
+func (lt *LogsTab) ApplyConfig(cfg *config.Config) {
+	rules := compileLevelRules(cfg.Logs.LevelRules)
+	lt.mu.Lock()
+	lt.levelRules = rules
+	lt.mu.Unlock()
+
+	if cfg.UI.LogBufferSize <= 0 {
+		return
+	}
+
+	lt.mu.Lock()
+	lt.maxLines = cfg.UI.LogBufferSize
+	for source, entries := range lt.logs {
+		if len(entries) > lt.maxLines {
+			lt.logs[source] = entries[len(entries)-lt.maxLines:]
+		}
+	}
+	logs := lt.logs[lt.selectedSource]
+	lt.mu.Unlock()
+
+	lt.updateLogDisplay(logs)
+}
+
+// SetHistory keeps the filter history in store
+func (lt *LogsTab) SetHistory(store *history.Store) {
+	lt.filterHistory.SetStore(store)
+}
+
+// SetJobs sets the tracker log group exports run in
+func (lt *LogsTab) SetJobs(tracker *jobs.Tracker) {
+	lt.jobs = tracker
+}
+
+// ActiveWork describes the live tail and the pod log stream running, if any,
+// which a profile or region switch stops
+func (lt *LogsTab) ActiveWork() []string {
+	lt.mu.RLock()
+	defer lt.mu.RUnlock()
+
+	var work []string
+	if lt.tailingActive && lt.activeLogGroup != "" {
+		work = append(work, i18n.T("logs.work_tail", lt.activeLogGroup))
+	}
+	if lt.podCancel != nil && lt.activePod != nil {
+		work = append(work, i18n.T("logs.work_pod", lt.activePod.Namespace, lt.activePod.Name))
+	}
+	return work
 }
 
 // SetAWSClient sets the AWS client for the LogsTab
 func (lt *LogsTab) SetAWSClient(client *aws.Client) {
+	// Loads and tails of the previous profile must not show up in the new one
+	lt.cancelCloudWatchLoad()
+	lt.stopTailing()
+	lt.stopPodStream()
+
 	lt.mu.Lock()
 	defer lt.mu.Unlock()
 
@@ -968,47 +1495,91 @@ func (lt *LogsTab) SetAWSClient(client *aws.Client) {
 	}
 }
 
-// loadCloudWatchLogs loads logs from CloudWatch Logs
-func (lt *LogsTab) loadCloudWatchLogs(logGroupName string) {
-	if lt.awsClient == nil {
-		lt.updateStatus("No AWS client available", "red")
+// startCloudWatchLoad cancels any running CloudWatch load and loads logGroupName
+func (lt *LogsTab) startCloudWatchLoad(logGroupName string) {
+	lt.loadMu.Lock()
+	if lt.loadCancel != nil {
+		lt.loadCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	lt.loadCancel = cancel
+	lt.loadGen++
+	gen := lt.loadGen
+	lt.loadMu.Unlock()
+
+	lt.updateStatus(i18n.T("logs.loading", logGroupName), "yellow")
+	go lt.loadCloudWatchLogs(ctx, gen, lt.awsClient, logGroupName)
+}
+
+// cancelCloudWatchLoad stops the running CloudWatch load, if any
+func (lt *LogsTab) cancelCloudWatchLoad() {
+	lt.loadMu.Lock()
+	defer lt.loadMu.Unlock()
+
+	if lt.loadCancel != nil {
+		lt.loadCancel()
+		lt.loadCancel = nil
+	}
+	lt.loadGen++
+}
+
+// isCurrentLoad reports whether gen is still the running CloudWatch load
+func (lt *LogsTab) isCurrentLoad(gen uint64) bool {
+	lt.loadMu.Lock()
+	defer lt.loadMu.Unlock()
+	return lt.loadGen == gen
+}
+
+// loadCloudWatchLogs loads logs from CloudWatch Logs. Results are discarded
+// once gen is no longer the current load.
+func (lt *LogsTab) loadCloudWatchLogs(ctx context.Context, gen uint64, client *aws.Client, logGroupName string) {
+	if client == nil {
+		lt.queueStatus(gen, i18n.T("logs.no_client"), "red")
 		return
 	}
 
-	cloudWatchService := lt.awsClient.GetCloudWatchLogsService()
+	cloudWatchService := client.GetCloudWatchLogsService()
 	if cloudWatchService == nil {
-		lt.updateStatus("CloudWatch Logs service not available", "red")
+		lt.queueStatus(gen, i18n.T("logs.no_cloudwatch"), "red")
 		return
 	}
 
-	lt.updateStatus(fmt.Sprintf("Loading CloudWatch logs from %s...", logGroupName), "yellow")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	streams, err := cloudWatchService.DescribeLogStreams(ctx, logGroupName, 10)
 	if err != nil {
-		logger.Error("Failed to describe log streams", zap.String("logGroup", logGroupName), zap.Error(err))
-		if lt.app != nil {
-			lt.app.QueueUpdateDraw(func() {
-				lt.updateStatus(fmt.Sprintf("Failed to get log streams: %s", err.Error()), "red")
-			})
+		if !lt.isCurrentLoad(gen) {
+			lt.discardCloudWatchLoad(logGroupName)
+			return
 		}
+		logger.Error("Failed to describe log streams", zap.String("logGroup", logGroupName), zap.Error(err))
+		lt.queueStatus(gen, i18n.T("logs.streams_failed", err.Error()), "red")
 		return
 	}
 
+	// A linked stream is loaded even if other streams were written to since
+	lt.mu.RLock()
+	linked := lt.linkedStream
+	if logGroupName != lt.activeLogGroup {
+		linked = ""
+	}
+	lt.mu.RUnlock()
+	if linked != "" && !slices.ContainsFunc(streams, func(stream clients.LogStreamInfo) bool { return stream.LogStreamName == linked }) {
+		streams = append(streams, clients.LogStreamInfo{LogStreamName: linked})
+	}
+
 	if len(streams) == 0 {
-		if lt.app != nil {
-			lt.app.QueueUpdateDraw(func() {
-				lt.updateStatus(fmt.Sprintf("No log streams found in %s", logGroupName), "yellow")
-			})
-		}
+		lt.queueStatus(gen, i18n.T("logs.no_streams", logGroupName), "yellow")
 		return
 	}
 
 	// Load events from the most recent streams
 	var allEvents []clients.LogEvent
 	for _, stream := range streams {
+		if ctx.Err() != nil {
+			break
+		}
 		events, _, err := cloudWatchService.GetLogEvents(ctx, logGroupName, stream.LogStreamName, 50, false)
 		if err != nil {
 			logger.Error("Failed to get log events", zap.String("logGroup", logGroupName), zap.String("stream", stream.LogStreamName), zap.Error(err))
@@ -1017,15 +1588,21 @@ func (lt *LogsTab) loadCloudWatchLogs(logGroupName string) {
 		allEvents = append(allEvents, events...)
 	}
 
+	if !lt.isCurrentLoad(gen) {
+		lt.discardCloudWatchLoad(logGroupName)
+		return
+	}
+
 	// Convert to LogEntry format and add to logs
 	lt.mu.Lock()
 	var logEntries []LogEntry
 	for _, event := range allEvents {
 		entry := LogEntry{
 			Timestamp: time.Now(), // We'll use the event timestamp below
-			Level:     "INFO",
+			Level:     lt.levelRules.infer(logGroupName, event.Message),
 			Message:   event.Message,
 			Source:    "cloudwatch",
+			Stream:    event.LogStreamName,
 			Fields:    make(map[string]interface{}),
 		}
 
@@ -1033,7 +1610,7 @@ func (lt *LogsTab) loadCloudWatchLogs(logGroupName string) {
 			entry.Timestamp = time.UnixMilli(event.Timestamp)
 		}
 		if event.IngestionTime != 0 {
-			entry.Fields["ingestionTime"] = time.UnixMilli(event.IngestionTime).Format("2006-01-02 15:04:05")
+			entry.Fields["ingestionTime"] = zonedTime(time.UnixMilli(event.IngestionTime), "2006-01-02 15:04:05")
 		}
 
 		logEntries = append(logEntries, entry)
@@ -1046,25 +1623,57 @@ func (lt *LogsTab) loadCloudWatchLogs(logGroupName string) {
 	lt.logs["cloudwatch"] = logEntries
 	lt.mu.Unlock()
 
+	lt.indexer.Enqueue(logEntries...)
+
 	lt.mu.RLock()
 	selectedSource := lt.selectedSource
 	lt.mu.RUnlock()
 
-	if selectedSource == "cloudwatch" {
-		if lt.app != nil {
-			lt.app.QueueUpdateDraw(func() {
-				lt.updateLogDisplay(logEntries)
-			})
-		}
+	var streamNames []string
+	for _, stream := range streams {
+		streamNames = append(streamNames, stream.LogStreamName)
 	}
-
 	if lt.app != nil {
 		lt.app.QueueUpdateDraw(func() {
-			lt.updateStatus(fmt.Sprintf("Loaded %d CloudWatch log entries from %d streams", len(logEntries), len(streams)), "green")
+			if !lt.isCurrentLoad(gen) {
+				return
+			}
+			lt.setLogStreams(streamNames)
+			if selectedSource == "cloudwatch" {
+				lt.updateLogDisplay(logEntries)
+			}
 		})
 	}
 
-	lt.startTailing(logGroupName, streams)
+	lt.queueStatus(gen, i18n.T("logs.loaded", len(logEntries), len(streams)), "green")
+
+	if lt.isCurrentLoad(gen) {
+		lt.startTailing(logGroupName, streams)
+	}
+}
+
+// queueStatus shows a status message of the CloudWatch load gen unless it is stale
+func (lt *LogsTab) queueStatus(gen uint64, message, color string) {
+	if lt.app == nil {
+		return
+	}
+	lt.app.QueueUpdateDraw(func() {
+		if lt.isCurrentLoad(gen) {
+			lt.updateStatus(message, color)
+		}
+	})
+}
+
+// discardCloudWatchLoad drops the placeholder of a cancelled load so the
+// source is loaded again when it is selected next
+func (lt *LogsTab) discardCloudWatchLoad(logGroupName string) {
+	logger.Debug("CloudWatch load cancelled", zap.String("logGroup", logGroupName))
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	if len(lt.logs["cloudwatch"]) == 0 {
+		delete(lt.logs, "cloudwatch")
+	}
 }
 
 // startTailing starts real-time tailing of log streams
@@ -1106,17 +1715,32 @@ func (lt *LogsTab) startTailing(logGroupName string, streams []clients.LogStream
 
 		go cloudWatchService.TailLogStreams(lt.cloudWatchCtx, logGroupName, streamNames, eventsChan, errorChan)
 
+		// The tail backfills the events it missed while interrupted
+		backfilling := false
 		for {
 			select {
 			case <-lt.cloudWatchCtx.Done():
 				return
-			case event := <-eventsChan:
-				lt.addCloudWatchEvent(event)
-			case err := <-errorChan:
+			case event, ok := <-eventsChan:
+				if !ok {
+					return
+				}
+				if event.Backfilled && !backfilling && lt.app != nil {
+					lt.app.QueueUpdateDraw(func() {
+						lt.updateStatus(i18n.T("logs.tail_resumed"), "yellow")
+					})
+				}
+				backfilling = event.Backfilled
+				lt.addCloudWatchEvent(logGroupName, event)
+			case err, ok := <-errorChan:
+				if !ok {
+					errorChan = nil
+					continue
+				}
 				logger.Error("CloudWatch tailing error", zap.Error(err))
 				if lt.app != nil {
 					lt.app.QueueUpdateDraw(func() {
-						lt.updateStatus(fmt.Sprintf("Tailing error: %s", err.Error()), "red")
+						lt.updateStatus(i18n.T("logs.tail_error", err.Error()), "red")
 					})
 				}
 			}
@@ -1124,12 +1748,17 @@ func (lt *LogsTab) startTailing(logGroupName string, streams []clients.LogStream
 	}()
 }
 
-// addCloudWatchEvent adds a CloudWatch event to the logs
-func (lt *LogsTab) addCloudWatchEvent(event clients.LogEvent) {
+// addCloudWatchEvent adds a CloudWatch event of logGroup to the logs
+func (lt *LogsTab) addCloudWatchEvent(logGroup string, event clients.LogEvent) {
+	lt.mu.RLock()
+	level := lt.levelRules.infer(logGroup, event.Message)
+	lt.mu.RUnlock()
+
 	entry := LogEntry{
-		Level:   "INFO",
+		Level:   level,
 		Message: event.Message,
 		Source:  "cloudwatch",
+		Stream:  event.LogStreamName,
 		Fields:  make(map[string]interface{}),
 	}
 
@@ -1140,11 +1769,15 @@ func (lt *LogsTab) addCloudWatchEvent(event clients.LogEvent) {
 	}
 
 	if event.IngestionTime != 0 {
-		entry.Fields["ingestionTime"] = time.UnixMilli(event.IngestionTime).Format("2006-01-02 15:04:05")
+		entry.Fields["ingestionTime"] = zonedTime(time.UnixMilli(event.IngestionTime), "2006-01-02 15:04:05")
+	}
+	if event.Backfilled {
+		entry.Fields[backfilledField] = "missed while tailing was interrupted"
 	}
 
 	if lt.app != nil {
-		lt.app.QueueUpdateDraw(func() {
+		// addLogEntry schedules its own debounced redraw
+		lt.app.QueueUpdate(func() {
 			lt.addLogEntry("cloudwatch", entry)
 		})
 	} else {
@@ -1190,7 +1823,7 @@ func (lt *LogsTab) ExportLogs(filename string) error {
 	if logs, exists := lt.logs[lt.selectedSource]; exists {
 		for _, log := range logs {
 			line := fmt.Sprintf("%s [%s] %s\n",
-				log.Timestamp.Format("2006-01-02 15:04:05.000"),
+				zonedTime(log.Timestamp, "2006-01-02 15:04:05.000"),
 				strings.ToUpper(log.Level),
 				log.Message)
 			if _, err := writer.WriteString(line); err != nil {
@@ -1202,9 +1835,16 @@ func (lt *LogsTab) ExportLogs(filename string) error {
 	return nil
 }
 
-// Cleanup stops any active tailing processes and closes the search index
+// Cleanup stops following the application log and any active tailing
+// processes and closes the search index
 func (lt *LogsTab) Cleanup() {
+	if lt.stopAppLogs != nil {
+		lt.stopAppLogs()
+		lt.stopAppLogs = nil
+	}
 	lt.stopTailing()
+	lt.stopPodStream()
+	lt.indexer.Close()
 
 	lt.searchIndexMu.Lock()
 	defer lt.searchIndexMu.Unlock()
@@ -1,8 +1,19 @@
 package ui
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/aws/fake"
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
 )
 
 func TestLogsTabHighlighting(t *testing.T) {
@@ -90,6 +101,80 @@ func TestLogsTabAddEntry(t *testing.T) {
 	}
 }
 
+func TestLogsTabAddEntryIncremental(t *testing.T) {
+	lt := &LogsTab{
+		logs:           make(map[string][]LogEntry),
+		autoScroll:     true,
+		maxLines:       1000,
+		selectedSource: "test",
+		filterInput:    tview.NewInputField(),
+		logView:        tview.NewTable(),
+	}
+	lt.logRows = newLogTableContent(lt)
+	lt.logView.SetContent(lt.logRows)
+
+	lt.addLogEntry("test", LogEntry{Timestamp: time.Now(), Level: "INFO", Message: "first"})
+	lt.filterInput.SetText("second")
+	lt.addLogEntry("test", LogEntry{Timestamp: time.Now(), Level: "INFO", Message: "second"})
+	lt.addLogEntry("test", LogEntry{Timestamp: time.Now(), Level: "INFO", Message: "third"})
+
+	if lt.logRows.count() != 2 {
+		t.Errorf("Expected 2 rendered entries, got %d", lt.logRows.count())
+	}
+	if len(lt.filteredLogs) != 3 {
+		t.Errorf("Expected 3 filtered logs, got %d", len(lt.filteredLogs))
+	}
+
+	if text := lt.logView.GetCell(1, 2).Text; text != "first" {
+		t.Errorf("Expected first row message %q, got %q", "first", text)
+	}
+	if text := lt.logView.GetCell(2, 2).Text; !strings.Contains(text, "second") {
+		t.Errorf("Expected second row to contain %q, got %q", "second", text)
+	}
+	if row, _ := lt.logView.GetSelection(); row != 2 {
+		t.Errorf("Expected auto-scroll to select row 2, got %d", row)
+	}
+}
+
+func TestLogTableContent(t *testing.T) {
+	lt := &LogsTab{}
+	content := newLogTableContent(lt)
+
+	now := time.Now()
+	content.set([]LogEntry{
+		{Timestamp: now, Level: "info", Message: "line one\nline two", Fields: map[string]interface{}{"b": 2, "a": 1}},
+	}, "")
+	content.appendEntries([]LogEntry{{Timestamp: now.Add(time.Second), Level: "error", Message: "boom"}})
+
+	if content.GetRowCount() != 3 {
+		t.Fatalf("Expected 3 rows including the header, got %d", content.GetRowCount())
+	}
+	if cell := content.GetCell(0, 2); cell.Text != "Message" {
+		t.Errorf("Expected header %q, got %q", "Message", cell.Text)
+	}
+	for _, column := range append(append([]string{"Stream"}, logColumns...), logGroupColumns...) {
+		if columnTitle(column) == columnKey(column) {
+			t.Errorf("Expected the %s column in the catalogs", column)
+		}
+	}
+	if cell := content.GetCell(1, 2); cell.Text != "line one line two [blue]a=[-]1 [blue]b=[-]2" {
+		t.Errorf("Unexpected message cell %q", cell.Text)
+	}
+	if cell := content.GetCell(2, 1); cell.Text != "[red]ERROR[-]" {
+		t.Errorf("Unexpected level cell %q", cell.Text)
+	}
+	if content.GetCell(3, 0) != nil {
+		t.Error("Expected nil cell past the last entry")
+	}
+
+	if index := content.indexOf(LogEntry{Timestamp: now.Add(time.Second), Level: "error", Message: "boom"}); index != 1 {
+		t.Errorf("Expected index 1, got %d", index)
+	}
+	if dropped := content.trim(1); dropped != 1 || content.count() != 1 {
+		t.Errorf("Expected 1 dropped and 1 left, got %d and %d", dropped, content.count())
+	}
+}
+
 func TestLogsTabSetAWSClient(t *testing.T) {
 	lt := &LogsTab{}
 
@@ -103,3 +188,442 @@ func TestLogsTabSetAWSClient(t *testing.T) {
 	// but we can verify method doesn't panic
 	lt.SetAWSClient(nil)
 }
+
+func TestLogsTabStreamPodLogs(t *testing.T) {
+	lt := &LogsTab{
+		logs:     make(map[string][]LogEntry),
+		maxLines: 1000,
+	}
+	pod := clients.Pod{Name: "cart-7d9f8b6c5-vb4rm", Namespace: "shop", Status: "CrashLoopBackOff", Containers: []string{"app"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lt.streamPodLogs(ctx, fake.NewClient(), "shop-prod", pod, 5)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for lt.GetLogCount("kubernetes") < 5 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	lt.mu.RLock()
+	entries := lt.logs["kubernetes"]
+	lt.mu.RUnlock()
+	if len(entries) != 5 {
+		t.Fatalf("Expected the last 5 lines of the pod, got %d", len(entries))
+	}
+	levels := map[string]int{}
+	for _, entry := range entries {
+		levels[entry.Level]++
+		if entry.Fields["pod"] != pod.Name || entry.Fields["container"] != "app" {
+			t.Errorf("Expected the pod and container in the fields, got %v", entry.Fields)
+		}
+	}
+	if levels["ERROR"] != 2 {
+		t.Errorf("Expected the error and the panic of the crashing pod as errors, got %v", levels)
+	}
+}
+
+func TestGuessLogLevel(t *testing.T) {
+	for message, want := range map[string]string{
+		`panic: runtime error: index out of range`:          "ERROR",
+		`level=error msg="redis: connection refused"`:       "ERROR",
+		`{"level":"warn","msg":"slow query"}`:               "WARN",
+		`2026/10/15 08:00:01 WARN retrying`:                 "WARN",
+		`level=info msg="request handled" path=/api/errors`: "INFO",
+	} {
+		if got := guessLogLevel(message); got != want {
+			t.Errorf("guessLogLevel(%q): expected %s, got %s", message, want, got)
+		}
+	}
+}
+
+func TestLevelRulesInfer(t *testing.T) {
+	rules := compileLevelRules([]config.LevelRule{
+		{LogGroup: "/aws/lambda/", Field: "log.level"},
+		{LogGroup: "/ecs/orders", Pattern: `^\S+ \[(?P<level>\w+)\]`},
+		{Pattern: `Task timed out`, Level: "error"},
+	})
+	for _, tt := range []struct {
+		group, message, want string
+	}{
+		{"/aws/lambda/orders-api", `2026-10-16T08:00:00Z 7f3c {"log":{"level":"warning"},"msg":"slow"}`, "WARN"},
+		{"/aws/lambda/orders-api", `{"log":{"level":50},"msg":"pino error"}`, "ERROR"},
+		{"/aws/lambda/orders-api", `Task timed out after 3.00 seconds`, "ERROR"},
+		{"/ecs/orders-service", `08:00:01 [debug] cache miss`, "DEBUG"},
+		{"/ecs/payments", `08:00:01 [debug] cache miss`, "INFO"},
+		{"/ecs/payments", `level=error msg="redis: connection refused"`, "ERROR"},
+	} {
+		if got := rules.infer(tt.group, tt.message); got != tt.want {
+			t.Errorf("infer(%q, %q): expected %s, got %s", tt.group, tt.message, tt.want, got)
+		}
+	}
+}
+
+func TestParsePastTime(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"now":              now,
+		"-90m":             now.Add(-90 * time.Minute),
+		"-24h":             now.Add(-24 * time.Hour),
+		"-7d":              now.AddDate(0, 0, -7),
+		"2026-10-01":       time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		"2026-10-16 08:15": time.Date(2026, 10, 16, 8, 15, 0, 0, time.UTC),
+	}
+	for spec, want := range tests {
+		got, err := parsePastTime(spec, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parsePastTime(%q) = %v, %v, expected %v", spec, got, err, want)
+		}
+	}
+
+	for _, spec := range []string{"", "yesterday", "-0h", "-xd", "2026-10-17"} {
+		if _, err := parsePastTime(spec, now); err == nil {
+			t.Errorf("Expected parsePastTime(%q) to fail", spec)
+		}
+	}
+}
+
+func TestTimeDisplay(t *testing.T) {
+	defer setTimeDisplay(config.UIConfig{})
+
+	at := time.Date(2026, 10, 16, 6, 30, 15, 250e6, time.UTC)
+	setTimeDisplay(config.UIConfig{Timezone: "Asia/Tokyo", Timestamps: config.TimestampsDateTime})
+	if got := logTime(at); got != "2026-10-16 15:30:15.250" {
+		t.Errorf("Expected the date and time in Tokyo, got %s", got)
+	}
+	if got := createdTime("2026-10-16 06:30:15"); got != "2026-10-16 15:30:15" {
+		t.Errorf("Expected the Created date in Tokyo, got %s", got)
+	}
+	if got := createdTime("2026-10-16T06:30:15.000+0000"); got != "2026-10-16 15:30:15" {
+		t.Errorf("Expected the Lambda date in Tokyo, got %s", got)
+	}
+	if got := createdTime("unknown"); got != "unknown" {
+		t.Errorf("Expected other dates unchanged, got %s", got)
+	}
+
+	setTimeDisplay(config.UIConfig{Timezone: "UTC", Timestamps: config.TimestampsRelative})
+	if got := logTime(time.Now().Add(-2 * time.Minute)); got != "2m ago" {
+		t.Errorf("Expected the age, got %s", got)
+	}
+	if got := detailTime(at, "2006-01-02 15:04 MST"); !strings.HasPrefix(got, "2026-10-16 06:30 UTC (") {
+		t.Errorf("Expected the full time with its age, got %s", got)
+	}
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		at   time.Time
+		want string
+	}{
+		{now, "just now"},
+		{now.Add(-45 * time.Second), "45s ago"},
+		{now.Add(-30 * time.Hour), "30h ago"},
+		{now.Add(-72 * time.Hour), "3d ago"},
+		{now.Add(10 * time.Minute), "10m from now"},
+	} {
+		if got := relativeTime(tt.at, now); got != tt.want {
+			t.Errorf("relativeTime(%v): expected %s, got %s", tt.at, tt.want, got)
+		}
+	}
+}
+
+func TestLogsTabSplit(t *testing.T) {
+	lt, err := NewLogsTab(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	entriesAt := func(seconds ...int) []LogEntry {
+		var entries []LogEntry
+		for _, s := range seconds {
+			entries = append(entries, LogEntry{Timestamp: base.Add(time.Duration(s) * time.Second), Level: "INFO", Message: fmt.Sprint(s)})
+		}
+		return entries
+	}
+
+	lt.logRows.set(entriesAt(0, 5, 10), "")
+	lt.toggleSplit()
+	if lt.split == nil || lt.split.rows.count() != 3 || lt.split.label != "Application Logs" {
+		t.Fatalf("Expected the shown entries pinned, got %+v", lt.split)
+	}
+
+	// Another source in the log table; the pinned pane follows its selection
+	lt.logRows.set(entriesAt(4, 9), "")
+	lt.logView.Select(2, 0)
+	if row, _ := lt.split.table.GetSelection(); row != 3 {
+		t.Errorf("Expected the pinned entry closest in time selected, got row %d", row)
+	}
+	lt.split.table.Select(1, 0)
+	if row, _ := lt.logView.GetSelection(); row != 1 {
+		t.Errorf("Expected the log table to follow the pinned pane, got row %d", row)
+	}
+
+	lt.toggleSplit()
+	if lt.split != nil || lt.logPanes.GetItemCount() != 1 {
+		t.Error("Expected the pinned pane closed")
+	}
+}
+
+func TestLogTableLayout(t *testing.T) {
+	lt, err := NewLogsTab(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	lt.logRows.set([]LogEntry{
+		{Timestamp: base, Level: "INFO", Message: "short"},
+		{Timestamp: base.Add(time.Second), Level: "ERROR", Message: `START {"user":"ann","error":{"code":42}}`},
+		{Timestamp: base.Add(2 * time.Second), Level: "INFO", Message: "one two three four five six"},
+	}, "")
+	lt.logRows.resize(100)
+	rows := lt.logRows
+	width := rows.width
+	if rows.GetRowCount() != 4 {
+		t.Fatalf("Expected one row per entry, got %d rows", rows.GetRowCount())
+	}
+
+	// Cut off messages scroll horizontally
+	rows.scroll(2)
+	if text := rows.GetCell(1, 2).Text; text != "[gray]…[-]ort" {
+		t.Errorf("Expected the message scrolled, got %q", text)
+	}
+	rows.scroll(-logScrollStep)
+	if text := rows.GetCell(1, 2).Text; text != "short" {
+		t.Errorf("Expected the scroll to stop at the start, got %q", text)
+	}
+
+	// Wrapped messages take more rows, the time and level only on the first
+	lt.logRows.resize(100 - width + 10)
+	lt.logView.Select(3, 0)
+	lt.handleLogKey(logTablePane, lt.logView, rows, tcell.NewEventKey(tcell.KeyRune, 'W', tcell.ModNone))
+	row := rows.rowOf(2)
+	if rows.GetRowCount() != row+3 || rows.indexAt(row+2) != 2 {
+		t.Fatalf("Expected the last message wrapped over 3 rows from row %d, got %d rows", row, rows.GetRowCount())
+	}
+	if selected, _ := lt.logView.GetSelection(); selected != row {
+		t.Errorf("Expected the selected entry kept selected, got row %d", selected)
+	}
+	if text := rows.GetCell(row+1, 2).Text; text != "three four" {
+		t.Errorf("Expected the message wrapped at spaces, got %q", text)
+	}
+	if rows.GetCell(row+1, 0).Text != "" {
+		t.Error("Expected no time on continuation rows")
+	}
+	if rows.scroll(logScrollStep) {
+		t.Error("Expected wrapped messages not to scroll")
+	}
+
+	// Pretty printed JSON folds into one row
+	lt.handleLogKey(logTablePane, lt.logView, rows, tcell.NewEventKey(tcell.KeyRune, 'W', tcell.ModNone))
+	lt.handleLogKey(logTablePane, lt.logView, rows, tcell.NewEventKey(tcell.KeyRune, 'p', tcell.ModNone))
+	if rows.GetRowCount() != 9 {
+		t.Fatalf("Expected the JSON message over 6 rows, got %d rows", rows.GetRowCount())
+	}
+	if text := rows.GetCell(2, 2).Text; text != "▾ START {" {
+		t.Errorf("Expected the prefix before the JSON kept, got %q", text)
+	}
+	if text := rows.GetCell(4, 2).Text; text != `  "error": {` {
+		t.Errorf("Expected the JSON indented, got %q", text)
+	}
+	if rows.toggleFold(0) {
+		t.Error("Expected plain messages not to fold")
+	}
+	if !rows.toggleFold(1) || rows.GetRowCount() != 4 || rows.indexAt(3) != 2 {
+		t.Errorf("Expected the JSON message folded, got %d rows", rows.GetRowCount())
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"short", 10, []string{"short"}},
+		{"one two three", 7, []string{"one two", "three"}},
+		{"abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"日本語", 4, []string{"日本", "語"}},
+		{"anything", 0, []string{"anything"}},
+	}
+	for _, tt := range tests {
+		if got := wrapText(tt.text, tt.width); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("wrapText(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestANSIToTview(t *testing.T) {
+	tests := []struct {
+		name, text string
+		terms      []string
+		want       string
+	}{
+		{"plain", "no [colors] here", nil, "no [colors[] here"},
+		{"basic", "\x1b[31mfail\x1b[0m ok", nil, "[maroon:-:BDIULRS]fail[-:-:BDIULRS] ok"},
+		{"bold bright", "\x1b[1;92mup\x1b[22m", nil, "[lime:-:bDIULRS]up[lime:-:BDIULRS][-:-:-]"},
+		{"256", "\x1b[38;5;208;48;5;238mx", nil, "[#ff8700:#444444:BDIULRS]x[-:-:-]"},
+		{"truecolor underline", "\x1b[4;38;2;1;2;3mx\x1b[m", nil, "[#010203:-:BDIuLRS]x[-:-:BDIULRS]"},
+		{"cursor moves dropped", "\x1b[2Kline\x1b]0;title\a", nil, "line"},
+		{"terms keep the color", "\x1b[33mwarn: disk full", []string{"disk"}, "[olive:-:BDIULRS]warn: [#ffff00::b]disk[olive:-:BDIULRS] full[-:-:-]"},
+	}
+	for _, tt := range tests {
+		if got := ansiToTview(tt.text, tt.terms); got != tt.want {
+			t.Errorf("%s: ansiToTview(%q) = %q, want %q", tt.name, tt.text, got, tt.want)
+		}
+	}
+
+	if got := stripANSI("\x1b[1;31mERROR\x1b[0m failed"); got != "ERROR failed" {
+		t.Errorf("Expected the escape sequences stripped, got %q", got)
+	}
+	if got := guessLogLevel("\x1b[31mERROR\x1b[0m failed"); got != "ERROR" {
+		t.Errorf("Expected the level of a colored message, got %q", got)
+	}
+	if got := wrapText("\x1b[31mred text here\x1b[0m", 8); fmt.Sprint(got) != fmt.Sprint([]string{"\x1b[31mred text", "\x1b[31mhere\x1b[0m"}) {
+		t.Errorf("Expected the escape sequences left out of the width and the color carried on, got %q", got)
+	}
+	if got, _ := skipCharacters("\x1b[31mabcdef", 2); got != "\x1b[31mcdef" {
+		t.Errorf("Expected the color kept when scrolling, got %q", got)
+	}
+}
+
+func TestLogsTabStreams(t *testing.T) {
+	lt, err := NewLogsTab(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	web := "ecs/web/0123456789abcdef0123456789abcdef"
+	worker := "ecs/worker/fedcba9876543210"
+	entries := []LogEntry{
+		{Timestamp: base, Level: "INFO", Message: "web 1", Source: "cloudwatch", Stream: web},
+		{Timestamp: base.Add(time.Second), Level: "INFO", Message: "worker 1", Source: "cloudwatch", Stream: worker},
+		{Timestamp: base.Add(2 * time.Second), Level: "INFO", Message: "web 2", Source: "cloudwatch", Stream: web},
+	}
+	lt.mu.Lock()
+	lt.logs["cloudwatch"] = entries
+	lt.selectedSource = "cloudwatch"
+	lt.mu.Unlock()
+	lt.updateLogDisplay(entries)
+
+	rows := lt.logRows
+	if rows.GetColumnCount() != 4 || rows.GetCell(0, 2).Text != "Stream" {
+		t.Fatalf("Expected a stream column, got %d columns", rows.GetColumnCount())
+	}
+	if text := rows.GetCell(1, 2).Text; text != "0123456789abcde…" {
+		t.Errorf("Expected the task ID of the stream, cut, got %q", text)
+	}
+
+	// Only the entries of the chosen stream
+	lt.setLogStreams(lt.loadedStreams())
+	lt.streamSelect.SetCurrentOption(2)
+	if lt.streamFilter != worker || rows.count() != 1 {
+		t.Fatalf("Expected the worker stream shown, got %q with %d entries", lt.streamFilter, rows.count())
+	}
+	lt.addLogEntry("cloudwatch", LogEntry{Timestamp: base.Add(3 * time.Second), Message: "web 3", Source: "cloudwatch", Stream: web})
+	if rows.count() != 1 {
+		t.Errorf("Expected new entries of other streams left out, got %d entries", rows.count())
+	}
+	lt.streamSelect.SetCurrentOption(0)
+	if rows.count() != 4 {
+		t.Fatalf("Expected all streams shown again, got %d entries", rows.count())
+	}
+
+	// Groups by stream, the stream written to last at the end
+	lt.toggleStreamGroups()
+	if stream, ok := rows.groupAt(1); !ok || stream != worker {
+		t.Fatalf("Expected the worker group first, got %q", stream)
+	}
+	if stream, ok := rows.groupAt(3); !ok || stream != web || rows.GetRowCount() != 7 {
+		t.Fatalf("Expected the web group after it, got %q with %d rows", stream, rows.GetRowCount())
+	}
+	if rows.indexAt(1) != -1 || rows.indexAt(2) != 1 {
+		t.Error("Expected the entries of a group under its header")
+	}
+	lt.logView.Select(3, 0)
+	if !toggleSelectedGroup(lt.logView, rows) || rows.GetRowCount() != 4 {
+		t.Errorf("Expected the web group collapsed, got %d rows", rows.GetRowCount())
+	}
+	if rows.rowOf(3) != 3 {
+		t.Errorf("Expected collapsed entries found at their header, got row %d", rows.rowOf(3))
+	}
+
+	lt.selectSource("app")
+	if lt.streamFilter != "" || len(lt.streamNames) != 0 {
+		t.Error("Expected the stream filter reset for other sources")
+	}
+}
+
+func TestLogRate(t *testing.T) {
+	var rate logRate
+	now := time.Unix(1_800_000_000, 0)
+	for i := 0; i < 12; i++ {
+		rate.add(now.Add(-70*time.Second), false) // Before the window
+		rate.add(now, i%4 == 0)
+	}
+	rate.add(now.Add(-30*time.Second), false)
+
+	events, errors := rate.series(now)
+	if len(events) != logRateBars || events[logRateBars-1] != 2 || errors[logRateBars-1] != 0.5 {
+		t.Errorf("Expected 12 entries and 3 errors in the last 6 seconds, got %v and %v", events, errors)
+	}
+	if got := rateSparkline(events); got != "▁▁▁▁▂▁▁▁▁█" {
+		t.Errorf("Expected the spike in the last bar, got %q", got)
+	}
+	if got := rateSparkline([]float64{0, 0}); got != "▁▁" {
+		t.Errorf("Expected no errors on the baseline, got %q", got)
+	}
+
+	// The window moves on
+	if events, _ := rate.series(now.Add(2 * time.Minute)); rateSparkline(events) != "▁▁▁▁▁▁▁▁▁▁" {
+		t.Errorf("Expected the old entries dropped, got %v", events)
+	}
+
+	lt, err := NewLogsTab(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lt.selectSource("app")
+	if lt.rateStatus(time.Now()) != "" {
+		t.Error("Expected no rates without live entries")
+	}
+	lt.addLogEntry("app", LogEntry{Timestamp: time.Now(), Level: "ERROR", Message: "failed"})
+	if status := lt.rateStatus(time.Now()); !strings.Contains(status, "Events") || !strings.Contains(status, "Errors") {
+		t.Errorf("Expected the rates of the live entries, got %q", status)
+	}
+}
+
+func TestLogHelp(t *testing.T) {
+	rules := compileLevelRules([]config.LevelRule{{LogGroup: "/aws/lambda/", Field: "log.level"}})
+	text := logHelpText(newLogIndexMapping(), rules)
+	for _, want := range []string{
+		"Message   [-] text     words, in any case (standard analyzer)",
+		"Level     [-] text     the whole value, in the exact case (keyword analyzer)",
+		"Timestamp [-] number",
+		"+Level:ERROR",
+		"/aws/lambda/: field log.level -> the level read",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected the help to contain %q, got:\n%s", want, text)
+		}
+	}
+	for _, key := range logKeys {
+		if help := i18n.T(key.help); help == key.help || !strings.Contains(text, help) {
+			t.Errorf("Expected the help to list %s", key.label())
+		}
+	}
+
+	lt, err := NewLogsTab(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lt.handleLogKey(logSourcesPane, lt.logView, lt.logRows, tcell.NewEventKey(tcell.KeyRune, '?', tcell.ModNone)) || !lt.view.HasPage("help") {
+		t.Error("Expected ? to show the help")
+	}
+	if lt.handleLogKey(logSourcesPane, lt.logView, lt.logRows, tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone)) {
+		t.Error("Expected y to work in the log table only")
+	}
+}
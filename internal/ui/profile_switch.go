@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/rivo/tview"
+)
+
+// activeWork describes the work a switch to another profile or region
+// interrupts
+func (app *App) activeWork() []string {
+	work := app.logsTab.ActiveWork()
+	switch running := app.resourcesTab.RunningJobs(); {
+	case running == 1:
+		work = append(work, i18n.T("switch.job"))
+	case running > 1:
+		work = append(work, i18n.T("switch.jobs", running))
+	}
+	if app.athenaTab.IsRunning() {
+		work = append(work, i18n.T("switch.athena"))
+	}
+	if app.settingsTab.IsModified() {
+		work = append(work, i18n.T("switch.settings"))
+	}
+	return work
+}
+
+// confirmSwitch calls proceed right away when switching to target would not
+// interrupt anything, and otherwise asks first, calling cancel if the switch
+// is called off. It must be called from the UI goroutine.
+func (app *App) confirmSwitch(target string, proceed, cancel func()) {
+	work := app.activeWork()
+	if len(work) == 0 {
+		proceed()
+		return
+	}
+
+	focus := app.app.GetFocus()
+	done := func(switching bool) {
+		app.closeOverlay = nil
+		app.pages.RemovePage("switch")
+		app.app.SetFocus(focus)
+		if switching {
+			proceed()
+		} else if cancel != nil {
+			cancel()
+		}
+	}
+
+	modal := tview.NewModal().
+		SetText(i18n.T("switch.confirm", target, "• "+strings.Join(work, "\n• "))).
+		AddButtons([]string{i18n.T("switch.button"), i18n.T("common.cancel")}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			done(buttonIndex == 0)
+		})
+	app.closeOverlay = func() { done(false) }
+	app.pages.AddPage("switch", modal, false, true)
+	app.app.SetFocus(modal)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/internal/i18n"
 	"swiss-army-tui/pkg/logger"
 
 	"github.com/gdamore/tcell/v2"
@@ -19,8 +22,10 @@ type ProfileTab struct {
 	// Core components
 	view           *tview.Flex
 	app            *tview.Application
-	profileManager *aws.ProfileManager
-	eventChan      chan<- Event
+	profileManager ProfileStore
+	clients        ClientFactory
+	clock          Clock
+	events         *EventBus
 
 	// UI components
 	profileList  *tview.List
@@ -32,14 +37,27 @@ type ProfileTab struct {
 	selectedProfile *aws.Profile
 	selectedRegion  string
 	profiles        map[string]*aws.Profile
+	// regions are the options of the region dropdown in their order, sorted
+	// by latency once probed
+	regions   []string
+	latencies map[string]aws.RegionLatency
+	probing   bool
+	// relabeling is set while the region options are rebuilt, which selects
+	// the current region again without switching to it
+	relabeling bool
+	// Set while browsing a snapshot, when no AWS calls are made
+	offline bool
 }
 
-// NewProfileTab creates a new profile tab
-func NewProfileTab(app *tview.Application, profileManager *aws.ProfileManager, eventChan chan<- Event) (*ProfileTab, error) {
+// NewProfileTab creates a new profile tab listing the profiles of
+// profileManager, whose connections are tested with clients
+func NewProfileTab(app *tview.Application, profileManager ProfileStore, clients ClientFactory, clock Clock, events *EventBus) (*ProfileTab, error) {
 	tab := &ProfileTab{
 		app:            app,
 		profileManager: profileManager,
-		eventChan:      eventChan,
+		clients:        clients,
+		clock:          clock,
+		events:         events,
 		profiles:       make(map[string]*aws.Profile),
 		selectedRegion: "us-east-1",
 	}
@@ -63,7 +81,7 @@ func (pt *ProfileTab) initializeUI() error {
 		SetSelectedBackgroundColor(tcell.ColorWhite).
 		ShowSecondaryText(true)
 
-	pt.profileList.SetBorder(true).SetTitle(" AWS Profiles ").SetTitleAlign(tview.AlignLeft)
+	pt.profileList.SetBorder(true).SetTitleAlign(tview.AlignLeft)
 
 	// Set up profile list selection handler
 	pt.profileList.SetSelectedFunc(pt.onProfileSelected)
@@ -78,6 +96,9 @@ func (pt *ProfileTab) initializeUI() error {
 		case ' ':
 			pt.testConnection()
 			return nil
+		case 'l':
+			pt.probeLatency()
+			return nil
 		}
 		return event
 	})
@@ -87,25 +108,26 @@ func (pt *ProfileTab) initializeUI() error {
 		SetDynamicColors(true).
 		SetWrap(true)
 
-	pt.profileInfo.SetBorder(true).SetTitle(" Profile Details ").SetTitleAlign(tview.AlignLeft)
+	pt.profileInfo.SetBorder(true).SetTitleAlign(tview.AlignLeft)
 
 	// Create region selector
+	pt.regions = getAWSRegions()
 	pt.regionSelect = tview.NewDropDown().
-		SetLabel("Region: ").
-		SetOptions(getAWSRegions(), pt.onRegionSelected)
+		SetOptions(pt.regionOptions(), pt.onRegionSelected)
 
-	pt.regionSelect.SetBorder(true).SetTitle(" AWS Region ").SetTitleAlign(tview.AlignLeft)
+	pt.regionSelect.SetBorder(true).SetTitleAlign(tview.AlignLeft)
 
 	// Set default region
-	pt.regionSelect.SetCurrentOption(findRegionIndex("eu-central-1"))
+	pt.regionSelect.SetCurrentOption(pt.regionIndex("eu-central-1"))
 
 	// Create status text
 	pt.statusText = tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter)
 
-	pt.statusText.SetBorder(true).SetTitle(" Status ").SetTitleAlign(tview.AlignLeft)
-	pt.updateStatus("Ready", "white")
+	pt.statusText.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	pt.applyLocale()
+	pt.updateStatus(i18n.T("common.ready"), "white")
 
 	// Create layout
 	leftPanel := tview.NewFlex().SetDirection(tview.FlexRow).
@@ -120,6 +142,17 @@ func (pt *ProfileTab) initializeUI() error {
 	return nil
 }
 
+// applyLocale titles the panels in the current locale
+func (pt *ProfileTab) applyLocale() {
+	pt.profileList.SetTitle(panelTitle("profiles.list"))
+	pt.profileInfo.SetTitle(panelTitle("profiles.details"))
+	pt.regionSelect.SetLabel(i18n.T("profiles.region_label")).SetTitle(panelTitle("profiles.region"))
+	pt.statusText.SetTitle(panelTitle("common.status"))
+	if pt.selectedProfile != nil {
+		pt.updateProfileInfo(pt.selectedProfile)
+	}
+}
+
 // loadProfiles loads AWS profiles into the list
 func (pt *ProfileTab) loadProfiles() {
 	logger.Debug("Loading AWS profiles in UI")
@@ -127,7 +160,7 @@ func (pt *ProfileTab) loadProfiles() {
 	// Reload profiles from profile manager
 	if err := pt.profileManager.LoadProfiles(); err != nil {
 		logger.Error("Failed to load profiles", zap.Error(err))
-		pt.updateStatus("Error loading profiles", "red")
+		pt.updateStatus(i18n.T("profiles.load_failed"), "red")
 		return
 	}
 
@@ -139,8 +172,8 @@ func (pt *ProfileTab) loadProfiles() {
 	pt.statusText.SetText("")  // Clear status text
 
 	if len(pt.profiles) == 0 {
-		pt.profileList.AddItem("No profiles found", "Check ~/.aws/config and ~/.aws/credentials", 0, nil)
-		pt.updateStatus("No profiles found", "yellow")
+		pt.profileList.AddItem(i18n.T("profiles.none"), i18n.T("profiles.none_hint"), 0, nil)
+		pt.updateStatus(i18n.T("profiles.none"), "yellow")
 		return
 	}
 
@@ -156,18 +189,20 @@ func (pt *ProfileTab) loadProfiles() {
 		profile := pt.profiles[name]
 		mainText := name
 		if name == "default" {
-			mainText = fmt.Sprintf("[yellow]%s[-] (default)", name)
+			mainText = fmt.Sprintf("[yellow]%s[-] %s", name, i18n.T("profiles.default"))
 		}
 
-		secondaryText := fmt.Sprintf("Region: %s | Source: %s",
-			getProfileRegion(profile), profile.Source)
+		secondaryText := i18n.T("profiles.item", getProfileRegion(profile), profile.Source)
+		if profile.SSOSessionName != "" {
+			secondaryText += " | " + i18n.T("profiles.item_sso", profile.SSOSessionName)
+		}
 
 		pt.profileList.AddItem(mainText, secondaryText, rune('0'+i%10), func() {
 			pt.selectProfile(name)
 		})
 	}
 
-	pt.updateStatus(fmt.Sprintf("Found %d profiles", len(pt.profiles)), "green")
+	pt.updateStatus(i18n.T("profiles.found", len(pt.profiles)), "green")
 	logger.Info("Loaded AWS profiles", zap.Int("count", len(pt.profiles)))
 }
 
@@ -197,7 +232,7 @@ func (pt *ProfileTab) selectProfile(profileName string) {
 
 	profile, exists := pt.profiles[profileName]
 	if !exists {
-		pt.updateStatus("Profile not found", "red")
+		pt.updateStatus(i18n.T("profiles.not_found"), "red")
 		return
 	}
 
@@ -209,26 +244,25 @@ func (pt *ProfileTab) selectProfile(profileName string) {
 	if profile.Region != "" {
 		currentRegion = profile.Region
 		// Update region dropdown
-		if regionIndex := findRegionIndex(profile.Region); regionIndex >= 0 {
+		if regionIndex := pt.regionIndex(profile.Region); regionIndex >= 0 {
 			pt.regionSelect.SetCurrentOption(regionIndex)
 		}
 	}
 
 	// Notify about profile change
-	pt.eventChan <- Event{
-		Type: EventProfileChanged,
-		Data: map[string]string{
-			"profile": profileName,
-			"region":  currentRegion,
-		},
-	}
+	pt.events.Publish(ProfileChangedEvent{Profile: profileName, Region: currentRegion})
 
 	pt.profileList.Clear() // Clear existing list to prevent duplication
-	pt.updateStatus(fmt.Sprintf("Selected profile: %s", profileName), "green")
+	pt.updateStatus(i18n.T("profiles.selected", profileName), "green")
 }
 
-// onRegionSelected handles region selection
+// onRegionSelected handles region selection. The option may carry the
+// latency of the region, so the region is looked up by index.
 func (pt *ProfileTab) onRegionSelected(option string, index int) {
+	if index < 0 || index >= len(pt.regions) || pt.relabeling {
+		return
+	}
+	option = pt.regions[index]
 	pt.selectedRegion = option
 
 	if pt.selectedProfile != nil {
@@ -236,12 +270,9 @@ func (pt *ProfileTab) onRegionSelected(option string, index int) {
 			zap.String("profile", pt.selectedProfile.Name),
 			zap.String("region", option))
 
-		pt.eventChan <- Event{
-			Type: EventRegionChanged,
-			Data: option,
-		}
+		pt.events.Publish(RegionChangedEvent{Region: option})
 
-		pt.updateStatus(fmt.Sprintf("Changed region to: %s", option), "green")
+		pt.updateStatus(i18n.T("profiles.region_changed", option), "green")
 	}
 }
 
@@ -253,39 +284,29 @@ func (pt *ProfileTab) updateProfileInfo(profile *aws.Profile) {
 	}
 
 	if profile == nil {
-		pt.profileInfo.SetText("No profile selected")
+		pt.profileInfo.SetText(i18n.T("profiles.no_selection"))
 		return
 	}
 
-	info := fmt.Sprintf(`[yellow]Profile Name:[-] %s
-
-[yellow]Region:[-] %s
-[yellow]Output Format:[-] %s
-[yellow]Source:[-] %s
-
-`, profile.Name,
-		getProfileRegion(profile),
-		getProfileOutput(profile),
-		profile.Source)
+	info := fmt.Sprintf("%s %s\n\n%s %s\n%s %s\n%s %s\n\n",
+		infoLabel("profiles.info_name"), profile.Name,
+		infoLabel("profiles.info_region"), getProfileRegion(profile),
+		infoLabel("profiles.info_output"), getProfileOutput(profile),
+		infoLabel("profiles.info_source"), profile.Source)
 
 	if profile.RoleARN != "" {
-		info += fmt.Sprintf("[yellow]Role ARN:[-] %s\n", profile.RoleARN)
+		info += fmt.Sprintf("%s %s\n", infoLabel("profiles.info_role"), profile.RoleARN)
 	}
 
 	if profile.SourceProfile != "" {
-		info += fmt.Sprintf("[yellow]Source Profile:[-] %s\n", profile.SourceProfile)
+		info += fmt.Sprintf("%s %s\n", infoLabel("profiles.info_source_profile"), profile.SourceProfile)
 	}
 
-	info += `
-[blue]Actions:[-]
-• [white]Enter[-]: Select profile
-• [white]Space[-]: Test connection
-• [white]r[-]: Refresh profiles
+	if share := pt.profileManager.SSOShare(profile.Name); share != nil {
+		info += describeSSOShare(profile.Name, share, pt.clock.Now())
+	}
 
-[blue]Tips:[-]
-• Profiles are loaded from ~/.aws/config and ~/.aws/credentials
-• Use the region dropdown to override the profile region
-• Selected profile will be used for all AWS operations`
+	info += "\n" + i18n.T("profiles.info_help")
 
 	pt.profileInfo.SetText(info)
 }
@@ -293,21 +314,25 @@ func (pt *ProfileTab) updateProfileInfo(profile *aws.Profile) {
 // testConnection tests the connection with the selected profile
 func (pt *ProfileTab) testConnection() {
 	if pt.selectedProfile == nil {
-		pt.updateStatus("No profile selected", "yellow")
+		pt.updateStatus(i18n.T("profiles.no_selection"), "yellow")
+		return
+	}
+	if pt.offline {
+		pt.updateStatus(offlineStatus(), "yellow")
 		return
 	}
 
-	pt.updateStatus("Testing connection...", "yellow")
+	pt.updateStatus(i18n.T("profiles.testing"), "yellow")
 
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		client, err := aws.NewClient(pt.selectedProfile.Name, pt.selectedRegion)
+		client, err := pt.clients.NewClient(pt.selectedProfile.Name, pt.selectedRegion)
 		if err != nil {
 			if pt.app != nil {
 				pt.app.QueueUpdateDraw(func() {
-					pt.updateStatus("Connection failed", "red")
+					pt.updateStatus(i18n.T("profiles.connection_failed"), "red")
 				})
 			}
 			logger.Error("Failed to create AWS client for connection test", zap.Error(err))
@@ -316,9 +341,13 @@ func (pt *ProfileTab) testConnection() {
 		defer client.Close()
 
 		if err := client.TestConnection(ctx); err != nil {
+			status := i18n.T("profiles.connection_failed")
+			if hint := aws.RemediationHint(aws.ClassifyError(err), client.GetProfile()); hint != "" {
+				status += ". " + hint
+			}
 			if pt.app != nil {
 				pt.app.QueueUpdateDraw(func() {
-					pt.updateStatus("Connection failed", "red")
+					pt.updateStatus(status, "red")
 				})
 			}
 			logger.Error("Connection test failed", zap.Error(err))
@@ -326,7 +355,7 @@ func (pt *ProfileTab) testConnection() {
 			accountID := client.GetAccountID()
 			if pt.app != nil {
 				pt.app.QueueUpdateDraw(func() {
-					pt.updateStatus(fmt.Sprintf("Connected to account: %s", accountID), "green")
+					pt.updateStatus(i18n.T("profiles.connected", accountID), "green")
 				})
 			}
 			logger.Info("Connection test successful", zap.String("account_id", accountID))
@@ -341,8 +370,8 @@ func (pt *ProfileTab) updateStatus(message, color string) {
 		return
 	}
 
-	timestamp := time.Now().Format("15:04:05")
-	statusText := fmt.Sprintf("[%s]%s[-]\n[gray]%s[-]", color, message, timestamp)
+	timestamp := pt.clock.Now().Format("15:04:05")
+	statusText := fmt.Sprintf("[%s]%s%s[-]\n[gray]%s[-]", color, stateWord(color), message, timestamp)
 	pt.statusText.SetText(statusText)
 }
 
@@ -350,7 +379,7 @@ func (pt *ProfileTab) updateStatus(message, color string) {
 func (pt *ProfileTab) Refresh() {
 	logger.Debug("Refreshing profile tab")
 	pt.profileList.Clear() // Clear existing list to prevent duplication
-	pt.updateStatus("Refreshing...", "yellow")
+	pt.updateStatus(i18n.T("profiles.refreshing"), "yellow")
 	pt.loadProfiles()
 }
 
@@ -364,6 +393,16 @@ func (pt *ProfileTab) getSortedProfileNames() []string {
 	return names
 }
 
+// ApplyConfig applies the configured default region while no profile is active
+func (pt *ProfileTab) ApplyConfig(cfg *config.Config) {
+	if pt.selectedProfile != nil || cfg.AWS.DefaultRegion == "" {
+		return
+	}
+
+	pt.selectedRegion = cfg.AWS.DefaultRegion
+	pt.regionSelect.SetCurrentOption(pt.regionIndex(cfg.AWS.DefaultRegion))
+}
+
 // GetView returns the main view component
 func (pt *ProfileTab) GetView() tview.Primitive {
 	return pt.view
@@ -380,15 +419,91 @@ func getAWSRegions() []string {
 	}
 }
 
-// findRegionIndex finds the index of a region in the regions list
-func findRegionIndex(region string) int {
-	regions := getAWSRegions()
-	for i, r := range regions {
+// regionIndex finds the index of a region in the region dropdown
+func (pt *ProfileTab) regionIndex(region string) int {
+	for i, r := range pt.regions {
 		if r == region {
 			return i
 		}
 	}
-	return 0 // Default to us-east-1
+	return 0 // Default to the first region
+}
+
+// regionOptions labels the regions of the dropdown with their latency once
+// it was measured
+func (pt *ProfileTab) regionOptions() []string {
+	options := make([]string, len(pt.regions))
+	for i, region := range pt.regions {
+		options[i] = region
+		if latency, ok := pt.latencies[region]; ok {
+			options[i] = fmt.Sprintf("%s (%s)", region, describeLatency(latency))
+		}
+	}
+	return options
+}
+
+// describeLatency shows a latency in milliseconds, or why it is unknown
+func describeLatency(latency aws.RegionLatency) string {
+	if latency.Err != nil {
+		return i18n.T("profiles.unreachable")
+	}
+	return fmt.Sprintf("%dms", latency.Latency.Milliseconds())
+}
+
+// probeLatency measures the latency to every region in the background, then
+// sorts the region dropdown by it and suggests the nearest region
+func (pt *ProfileTab) probeLatency() {
+	if pt.offline {
+		pt.updateStatus(offlineStatus(), "yellow")
+		return
+	}
+	if pt.probing {
+		return
+	}
+	pt.probing = true
+	pt.updateStatus(i18n.T("profiles.measuring", len(pt.regions)), "yellow")
+
+	regions := getAWSRegions()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		results := aws.ProbeRegionLatency(ctx, regions)
+		logger.Info("Measured region latency", zap.Int("regions", len(results)))
+		if pt.app != nil {
+			pt.app.QueueUpdateDraw(func() {
+				pt.probing = false
+				pt.applyLatency(results)
+			})
+		}
+	}()
+}
+
+// applyLatency orders the region dropdown by results, fastest first, keeping
+// the current region selected, and names the nearest region
+func (pt *ProfileTab) applyLatency(results []aws.RegionLatency) {
+	pt.latencies = make(map[string]aws.RegionLatency, len(results))
+	pt.regions = make([]string, 0, len(results))
+	for _, result := range results {
+		pt.latencies[result.Region] = result
+		pt.regions = append(pt.regions, result.Region)
+	}
+
+	pt.relabeling = true
+	pt.regionSelect.SetOptions(pt.regionOptions(), pt.onRegionSelected)
+	pt.regionSelect.SetCurrentOption(pt.regionIndex(pt.selectedRegion))
+	pt.relabeling = false
+
+	if len(results) == 0 || results[0].Err != nil {
+		pt.updateStatus(i18n.T("profiles.no_region"), "red")
+		return
+	}
+	nearest := results[0]
+	message := i18n.T("profiles.nearest", nearest.Region, describeLatency(nearest))
+	if current, ok := pt.latencies[pt.selectedRegion]; ok && nearest.Region != pt.selectedRegion {
+		message += fmt.Sprintf(", %s: %s", pt.selectedRegion, describeLatency(current))
+	}
+	pt.updateStatus(message, "green")
 }
 
 // getProfileRegion returns the profile's region or default
@@ -396,7 +511,7 @@ func getProfileRegion(profile *aws.Profile) string {
 	if profile.Region != "" {
 		return profile.Region
 	}
-	return "us-east-1 (default)"
+	return "us-east-1 " + i18n.T("profiles.default")
 }
 
 // getProfileOutput returns the profile's output format or default
@@ -404,5 +519,29 @@ func getProfileOutput(profile *aws.Profile) string {
 	if profile.Output != "" {
 		return profile.Output
 	}
-	return "json (default)"
+	return "json " + i18n.T("profiles.default")
+}
+
+// describeSSOShare shows the SSO session of profile, the profiles signing in
+// with the same token and whether the cached token is still valid as of now
+func describeSSOShare(profile string, share *aws.SSOShare, now time.Time) string {
+	session := share.Session
+	if session == "" {
+		session = i18n.T("profiles.sso_legacy", share.StartURL)
+	}
+	info := fmt.Sprintf("%s %s\n", infoLabel("profiles.info_sso_session"), tview.Escape(session))
+	if others := share.Others(profile); len(others) > 0 {
+		info += fmt.Sprintf("%s %s\n", infoLabel("profiles.info_sso_shared"), strings.Join(others, ", "))
+	}
+
+	token := infoLabel("profiles.info_sso_token")
+	switch {
+	case share.ExpiresAt.IsZero():
+		info += fmt.Sprintf("%s %s\n", token, i18n.T("profiles.sso_signed_out", share.LoginCommand(profile)))
+	case share.Expired(now):
+		info += fmt.Sprintf("%s %s\n", token, i18n.T("profiles.sso_expired", workloadAge(share.ExpiresAt), share.LoginCommand(profile)))
+	default:
+		info += fmt.Sprintf("%s %s\n", token, i18n.T("profiles.sso_valid", zonedTime(share.ExpiresAt, "2006-01-02 15:04")))
+	}
+	return info
 }
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/recording"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"go.uber.org/zap"
+)
+
+// toggleRecording starts recording the session to a new cast file, or
+// stops the recording running
+func (app *App) toggleRecording() {
+	if app.recorder != nil {
+		app.stopRecording(nil)
+		return
+	}
+	if app.screenWidth == 0 {
+		app.showNotice(i18n.T("app.record_nothing"), "yellow")
+		return
+	}
+
+	now := app.clock.Now()
+	title := "swiss-army-tui"
+	if app.awsClient != nil {
+		title = fmt.Sprintf("swiss-army-tui %s %s", app.awsClient.GetProfile(), app.awsClient.GetRegion())
+	}
+	recorder, err := recording.Start(recording.DefaultPath(now), app.screenWidth, app.screenHeight, now, title)
+	if err != nil {
+		app.showError(err)
+		return
+	}
+	app.recorder = recorder
+	app.recordedWidth, app.recordedHeight = app.screenWidth, app.screenHeight
+
+	logger.Info("Recording session", zap.String("path", recorder.Path()))
+	app.showNotice(i18n.T("app.recording", recorder.Path(), app.keys.Label(ActionRecord)), "green")
+}
+
+// stopRecording closes the recording running, reporting err as the reason
+// it stopped if not nil
+func (app *App) stopRecording(err error) {
+	recorder := app.recorder
+	app.recorder = nil
+	if closeErr := recorder.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		app.showError(err)
+		return
+	}
+	logger.Info("Recording saved", zap.String("path", recorder.Path()))
+	app.showNotice(i18n.T("app.record_saved", recorder.Path()), "green")
+}
+
+// recordFrame is called after every draw of the application: it keeps the
+// size of the screen and adds the frame drawn to the recording running
+func (app *App) recordFrame(screen tcell.Screen) {
+	app.screenWidth, app.screenHeight = screen.Size()
+	if app.recorder == nil {
+		return
+	}
+
+	now := app.clock.Now()
+	err := func() error {
+		if app.screenWidth != app.recordedWidth || app.screenHeight != app.recordedHeight {
+			app.recordedWidth, app.recordedHeight = app.screenWidth, app.screenHeight
+			if err := app.recorder.Resize(now, app.screenWidth, app.screenHeight); err != nil {
+				return err
+			}
+		}
+		return app.recorder.Frame(now, renderFrame(screen))
+	}()
+	if err != nil {
+		// The footer cannot change while drawing
+		go app.app.QueueUpdateDraw(func() {
+			if app.recorder != nil {
+				app.stopRecording(err)
+			}
+		})
+	}
+}
+
+// recordKey adds the key of event to the recording running: the character
+// typed or the name of the key, such as "Enter" or "Ctrl+R"
+func (app *App) recordKey(event *tcell.EventKey) {
+	if app.recorder == nil {
+		return
+	}
+	key := event.Name()
+	if event.Key() == tcell.KeyRune {
+		key = string(event.Rune())
+	}
+	// Failures show with the next frame
+	_ = app.recorder.Key(app.clock.Now(), key)
+}
+
+// renderFrame renders the contents of screen as ANSI escape sequences that
+// draw it from the top left corner of a terminal of its size
+func renderFrame(screen tcell.Screen) string {
+	width, height := screen.Size()
+	var frame strings.Builder
+	frame.WriteString("\x1b[0m\x1b[2J")
+
+	last := tcell.StyleDefault
+	for y := 0; y < height; y++ {
+		fmt.Fprintf(&frame, "\x1b[%d;1H", y+1)
+		for x := 0; x < width; {
+			primary, combining, style, cellWidth := screen.GetContent(x, y)
+			if style != last {
+				frame.WriteString(sgr(style))
+				last = style
+			}
+			if primary == 0 {
+				primary = ' '
+			}
+			frame.WriteRune(primary)
+			for _, r := range combining {
+				frame.WriteRune(r)
+			}
+			x += max(cellWidth, 1)
+		}
+	}
+	frame.WriteString("\x1b[0m")
+	return frame.String()
+}
+
+// sgr returns the escape sequence selecting style, from the default one
+func sgr(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+	params := []string{"0"}
+	for _, attr := range []struct {
+		mask  tcell.AttrMask
+		param string
+	}{
+		{tcell.AttrBold, "1"},
+		{tcell.AttrDim, "2"},
+		{tcell.AttrItalic, "3"},
+		{tcell.AttrUnderline, "4"},
+		{tcell.AttrBlink, "5"},
+		{tcell.AttrReverse, "7"},
+		{tcell.AttrStrikeThrough, "9"},
+	} {
+		if attrs&attr.mask != 0 {
+			params = append(params, attr.param)
+		}
+	}
+	if r, g, b := fg.RGB(); r >= 0 {
+		params = append(params, fmt.Sprintf("38;2;%d;%d;%d", r, g, b))
+	}
+	if r, g, b := bg.RGB(); r >= 0 {
+		params = append(params, fmt.Sprintf("48;2;%d;%d;%d", r, g, b))
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
@@ -0,0 +1,398 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/alarmrule"
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// alarmsView lists the CloudWatch metric and composite alarms; Enter shows
+// the rule of a composite alarm and the state history of an alarm
+type alarmsView struct{ baseView }
+
+var alarmsService = alarmsView{baseView{
+	info: ServiceInfo{Name: "alarms", DisplayName: "CloudWatch Alarms", Icon: "🚨", Label: "ALM", Enabled: true, Permission: "cloudwatch:DescribeAlarms"},
+	noun: "alarm",
+}}
+
+// ConsoleURL links the alarm
+func (alarmsView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/cloudwatch/home?%s#alarmsV2:alarm/%s", base, query, url.PathEscape(res.Name))
+}
+
+// StateColor colors the alarm states in words like the state history
+func (alarmsView) StateColor(state string) tcell.Color {
+	return alarmStateTcellColor(strings.ToUpper(strings.ReplaceAll(state, " ", "_")))
+}
+
+// alarmTimelineSlots is how many slots the state timeline of an alarm is
+// wide
+const alarmTimelineSlots = 96
+
+// alarmWindows are the periods the history view shows, toggled with w
+var alarmWindows = []time.Duration{24 * time.Hour, 7 * 24 * time.Hour}
+
+// Load lists the alarms
+func (alarmsView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return loadAlarms(ctx, client)
+}
+
+// Summary counts the alarms by state
+func (alarmsView) Summary(resources []Resource, failed int) (string, string) {
+	return alarmsSummary(resources, failed)
+}
+
+// Open shows the state history of the alarm
+func (alarmsView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showAlarmHistory(resource)
+}
+
+// loadAlarms lists the metric and composite alarms of the region
+func loadAlarms(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.CloudWatch == nil {
+		return nil, fmt.Errorf("CloudWatch service not initialized")
+	}
+
+	alarms, err := svc.CloudWatch.DescribeAlarms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]Resource, 0, len(alarms))
+	for _, alarm := range alarms {
+		resources = append(resources, alarmResource(alarm, client.GetRegion()))
+	}
+	return resources, nil
+}
+
+// alarmResource describes alarm with what makes it fire. Alarms in ALARM
+// are flagged.
+func alarmResource(alarm clients.AlarmDetail, region string) Resource {
+	res := Resource{
+		ID:     alarm.Name,
+		Name:   alarm.Name,
+		Type:   "Metric Alarm",
+		State:  alarmStateWord(alarm.State),
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":     alarm.ARN,
+			"Reason":  alarm.StateReason,
+			"Actions": "enabled",
+			"View":    "press Enter for the state history",
+		},
+	}
+	if !alarm.UpdatedAt.IsZero() {
+		res.Details["Since"] = fmt.Sprintf("%s (%s ago)", alarm.UpdatedAt.Local().Format("2006-01-02 15:04:05"), workloadAge(alarm.UpdatedAt))
+	}
+	if alarm.Type == "CompositeAlarm" {
+		res.Type = "Composite Alarm"
+		res.Details["Rule"] = alarm.Rule
+		res.Details["View"] = "press Enter for the rule and the state history"
+	} else {
+		res.Details["Condition"] = alarm.Condition
+	}
+	if alarm.Description != "" {
+		res.Details["Description"] = alarm.Description
+	}
+	if !alarm.ActionsEnabled {
+		res.Details["Actions"] = "disabled, state changes notify no one"
+	}
+	if alarm.State == alarmrule.StateAlarm {
+		res.Alert = true
+		res.Details["Flag"] = "in alarm"
+	}
+	return res
+}
+
+// alarmStateWord returns an alarm state as the state column shows it, e.g.
+// "insufficient data"
+func alarmStateWord(state string) string {
+	return strings.ToLower(strings.ReplaceAll(state, "_", " "))
+}
+
+// alarmsSummary counts the alarms in alarm, without data and composite
+func alarmsSummary(resources []Resource, failed int) (string, string) {
+	firing, unknown, composite := 0, 0, 0
+	for _, res := range resources {
+		switch res.State {
+		case "alarm":
+			firing++
+		case "insufficient data":
+			unknown++
+		}
+		if res.Type == "Composite Alarm" {
+			composite++
+		}
+	}
+
+	message := fmt.Sprintf("%s, %d in alarm, %d without data, %d composite",
+		pluralize(len(resources), "alarm"), firing, unknown, composite)
+	switch {
+	case firing > 0:
+		return message, "red"
+	case unknown > 0 || failed > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// alarmHistory is the state of the history view of an alarm
+type alarmHistory struct {
+	name      string
+	composite bool
+	// window is the index in alarmWindows of the shown period
+	window      int
+	overview    *tview.TextView
+	transitions *tview.Table
+	loads       int
+}
+
+// showAlarmHistory shows the alarm res over the tab: the rule of a
+// composite alarm evaluated against the current states of its alarms, a
+// timeline of its states and its transitions, newest first. w toggles the
+// period, r reloads and q closes the view.
+func (rt *ResourcesTab) showAlarmHistory(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	h := &alarmHistory{
+		name:        res.Name,
+		composite:   res.Type == "Composite Alarm",
+		overview:    tview.NewTextView().SetDynamicColors(true).SetScrollable(true).SetWrap(false),
+		transitions: workloadTable(" Transitions "),
+	}
+	h.overview.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	load := func() {
+		h.loads++
+		gen := h.loads
+		window := alarmWindows[h.window]
+		h.overview.SetTitle(panelTitle("alarms.window_title", formatWindow(window)))
+		h.overview.SetText("[gray]" + i18n.T("common.loading") + "[-]")
+		setTableMessage(h.transitions, i18n.T("common.loading"), tcell.ColorGray)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			end := time.Now()
+			var alarms []clients.AlarmDetail
+			var transitions []clients.AlarmTransition
+			err := fmt.Errorf("CloudWatch service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.CloudWatch != nil {
+				// The alarms are read again for the current states the rule
+				// is evaluated against
+				alarms, err = svc.CloudWatch.DescribeAlarms(ctx)
+				if err == nil {
+					transitions, err = svc.CloudWatch.AlarmHistory(ctx, h.name, end.Add(-window), end)
+				}
+			}
+			if err != nil {
+				logger.Error("Failed to read alarm history", zap.String("alarm", h.name), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != h.loads {
+					return
+				}
+				if err != nil {
+					h.overview.SetText("[red]" + i18n.T("alarms.history_failed", tview.Escape(clients.ErrorReason(err))) + "[-]")
+					setTableMessage(h.transitions, "-", tcell.ColorGray)
+					return
+				}
+				h.overview.SetText(renderAlarmOverview(h.name, alarms, transitions, end.Add(-window), end))
+				h.overview.ScrollToBeginning()
+				fillAlarmTransitions(h.transitions, transitions)
+			})
+		}()
+	}
+
+	h.transitions.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.view.RemovePage("alarm-history")
+			if rt.app != nil {
+				rt.app.SetFocus(rt.resourceTable)
+			}
+			return nil
+		case 'r':
+			load()
+			return nil
+		case 'w':
+			h.window = (h.window + 1) % len(alarmWindows)
+			load()
+			return nil
+		}
+		return event
+	})
+
+	// The rule of a composite alarm needs room for its tree
+	overviewHeight := 9
+	if h.composite {
+		overviewHeight = 18
+	}
+	view := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(h.overview, overviewHeight, 0, false).
+		AddItem(h.transitions, 0, 1, true)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("alarms.history_title", h.name))
+
+	rt.view.AddPage("alarm-history", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(h.transitions)
+	}
+	load()
+}
+
+// formatWindow returns a period of the history view, e.g. "24h" or "7d"
+func formatWindow(window time.Duration) string {
+	if window%(24*time.Hour) == 0 && window > 24*time.Hour {
+		return fmt.Sprintf("%dd", int(window/(24*time.Hour)))
+	}
+	return fmt.Sprintf("%dh", int(window.Hours()))
+}
+
+// renderAlarmOverview draws what makes the alarm name fire, the rule of a
+// composite alarm as a tree or the condition of a metric alarm, and its
+// states between start and end
+func renderAlarmOverview(name string, alarms []clients.AlarmDetail, transitions []clients.AlarmTransition, start, end time.Time) string {
+	states := make(map[string]string, len(alarms))
+	var alarm clients.AlarmDetail
+	for _, a := range alarms {
+		states[a.Name] = a.State
+		if a.Name == name {
+			alarm = a
+		}
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "State: %s\n", alarmStateText(alarm.State))
+	switch {
+	case alarm.Rule != "":
+		rule, err := alarmrule.Parse(alarm.Rule)
+		if err != nil {
+			fmt.Fprintf(&text, "Rule: %s\n[red]Could not parse the rule: %s[-]\n", tview.Escape(alarm.Rule), tview.Escape(err.Error()))
+			break
+		}
+		text.WriteString("Rule, evaluated against the current states:\n")
+		text.WriteString(alarmrule.Render(rule, states))
+	case alarm.Condition != "":
+		fmt.Fprintf(&text, "Condition: %s\n", tview.Escape(alarm.Condition))
+	}
+
+	flaps := 0
+	for _, transition := range transitions {
+		if transition.To == alarmrule.StateAlarm {
+			flaps++
+		}
+	}
+	fmt.Fprintf(&text, "\n%s, went into alarm %s\n", pluralize(len(transitions), "transition"), pluralize(flaps, "time"))
+	text.WriteString(alarmTimeline(transitions, alarm.State, start, end, alarmTimelineSlots))
+	return text.String()
+}
+
+// alarmTimeline draws the states of an alarm between start and end as a
+// strip of slots with a time axis below. A slot shows ALARM if the alarm was
+// in alarm at any time in it, so short flaps stay visible, and otherwise the
+// state at its end. The state before the first transition is the one it
+// left, current if there were none.
+func alarmTimeline(transitions []clients.AlarmTransition, current string, start, end time.Time, slots int) string {
+	state := current
+	if len(transitions) > 0 {
+		state = transitions[0].From
+	}
+
+	var strip strings.Builder
+	slot := end.Sub(start) / time.Duration(slots)
+	next := 0
+	for i := 0; i < slots; i++ {
+		slotEnd := start.Add(slot * time.Duration(i+1))
+		shown := state
+		for next < len(transitions) && transitions[next].At.Before(slotEnd) {
+			state = transitions[next].To
+			if state == alarmrule.StateAlarm {
+				shown = state
+			} else if shown != alarmrule.StateAlarm {
+				shown = state
+			}
+			next++
+		}
+		fmt.Fprintf(&strip, "[%s]█", alarmStateColor(shown))
+	}
+	strip.WriteString("[-]\n")
+
+	// Times at every quarter, "now" at the end
+	axis := []rune(strings.Repeat(" ", slots+4))
+	for quarter := 0; quarter < 4; quarter++ {
+		at := start.Add(end.Sub(start) * time.Duration(quarter) / 4).Local()
+		label := at.Format("15:04")
+		if end.Sub(start) > 24*time.Hour {
+			label = at.Format("Mon 15h")
+		}
+		copy(axis[slots*quarter/4:], []rune("|"+label))
+	}
+	copy(axis[slots-1:], []rune("|now"))
+	strip.WriteString("[gray]" + strings.TrimRight(string(axis), " ") + "[-]\n")
+	return strip.String()
+}
+
+// fillAlarmTransitions lists the transitions, newest first
+func fillAlarmTransitions(table *tview.Table, transitions []clients.AlarmTransition) {
+	if len(transitions) == 0 {
+		setTableMessage(table, i18n.T("alarms.no_changes"), tcell.ColorGray)
+		return
+	}
+	table.Clear()
+	setWorkloadHeader(table, false, "Time", "From", "To", "Reason")
+	for i := range transitions {
+		transition := transitions[len(transitions)-1-i]
+		row := i + 1
+		table.SetCell(row, 0, tview.NewTableCell(transition.At.Local().Format("2006-01-02 15:04:05")))
+		table.SetCell(row, 1, tview.NewTableCell(orDash(transition.From)).SetTextColor(alarmStateTcellColor(transition.From)))
+		table.SetCell(row, 2, tview.NewTableCell(orDash(transition.To)).SetTextColor(alarmStateTcellColor(transition.To)))
+		table.SetCell(row, 3, tview.NewTableCell(tview.Escape(transition.Reason)).SetExpansion(1))
+	}
+	table.Select(1, 0)
+}
+
+// alarmStateColor returns the tview color of an alarm state
+func alarmStateColor(state string) string {
+	switch state {
+	case alarmrule.StateOK:
+		return "green"
+	case alarmrule.StateAlarm:
+		return "red"
+	case alarmrule.StateInsufficientData:
+		return "yellow"
+	default:
+		return "gray"
+	}
+}
+
+// alarmStateTcellColor returns the color of an alarm state in tables
+func alarmStateTcellColor(state string) tcell.Color {
+	return tcell.GetColor(alarmStateColor(state))
+}
+
+// alarmStateText returns state in its color
+func alarmStateText(state string) string {
+	return fmt.Sprintf("[%s]%s[-]", alarmStateColor(state), orDash(state))
+}
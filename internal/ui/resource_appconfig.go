@@ -0,0 +1,677 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// appConfigView lists the AppConfig configuration profiles with the
+// versions deployed to the environments of their application; Enter shows
+// the differences between the latest two versions
+type appConfigView struct{ baseView }
+
+var appConfigService = appConfigView{baseView{
+	info: ServiceInfo{Name: "appconfig", DisplayName: "AppConfig", Icon: "🚩", Label: "APC", Enabled: true, Permission: "appconfig:ListApplications"},
+	noun: "configuration profile",
+}}
+
+// ConsoleURL links the configuration profile in its application
+func (appConfigView) ConsoleURL(base, query string, res Resource) string {
+	application := url.PathEscape(fmt.Sprint(res.Details["Application ID"]))
+	return fmt.Sprintf("%s/systems-manager/appconfig/applications/%s/configurationprofiles/%s?%s", base, application, url.PathEscape(res.ID), query)
+}
+
+// StateColor colors the deployment of the profiles
+func (appConfigView) StateColor(state string) tcell.Color {
+	return stateColor(appConfigStateColors, state)
+}
+
+// appConfigStateColors color whether a profile is deployed to its
+// environments
+var appConfigStateColors = map[string]tcell.Color{
+	"deployed":     tcell.ColorGreen,
+	"rolled back":  tcell.ColorRed,
+	"deploying":    tcell.ColorYellow,
+	"not deployed": tcell.ColorYellow,
+}
+
+// Load lists the configuration profiles of all applications
+func (appConfigView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return loadAppConfig(ctx, client)
+}
+
+// Summary counts the profiles whose deployments run or rolled back
+func (appConfigView) Summary(resources []Resource, failed int) (string, string) {
+	applications := make(map[string]bool)
+	deploying, rolledBack := 0, 0
+	for _, res := range resources {
+		applications[detailString(res, "Application")] = true
+		switch res.State {
+		case "deploying":
+			deploying++
+		case "rolled back":
+			rolledBack++
+		}
+	}
+
+	message := fmt.Sprintf("%s in %s, %d deploying", pluralize(len(resources), "profile"), pluralize(len(applications), "application"), deploying)
+	switch {
+	case rolledBack > 0:
+		return message + fmt.Sprintf(", %d rolled back", rolledBack), "red"
+	case failed > 0 || deploying > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// Open shows the differences between the latest two versions
+func (appConfigView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showAppConfigVersions(resource)
+}
+
+// Actions deploys the latest version of a profile
+func (appConfigView) Actions() []resourceAction {
+	return []resourceAction{
+		{name: "appconfig deploy", key: 'd', description: "action.appconfig_deploy",
+			onResource: true, online: true,
+			run: (*ResourcesTab).onAppConfigDeploy},
+	}
+}
+
+// appConfigProfileTypes name the types of configuration profiles
+var appConfigProfileTypes = map[string]string{
+	"AWS.AppConfig.FeatureFlags": "Feature Flags",
+	"AWS.Freeform":               "Freeform Configuration",
+}
+
+// appConfigRunning are the states of a deployment that has not finished
+var appConfigRunning = map[string]bool{"BAKING": true, "VALIDATING": true, "DEPLOYING": true, "ROLLING_BACK": true}
+
+// loadAppConfig lists the configuration profiles of the applications of the
+// region with the version last deployed to each environment. Profiles whose
+// last deployment rolled back are flagged.
+func loadAppConfig(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.AppConfig == nil {
+		return nil, fmt.Errorf("AppConfig service not initialized")
+	}
+
+	applications, err := svc.AppConfig.ListApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	region := client.GetRegion()
+	var resources []Resource
+	var failures []clients.ItemError
+	for _, application := range applications {
+		environments, err := svc.AppConfig.ListEnvironments(ctx, application.ID)
+		if err != nil {
+			failures = append(failures, clients.ItemError{Item: application.Name, Region: region, Err: err})
+			continue
+		}
+		profiles, err := svc.AppConfig.ListConfigurationProfiles(ctx, application.ID)
+		if err != nil {
+			failures = append(failures, clients.ItemError{Item: application.Name, Region: region, Err: err})
+			continue
+		}
+
+		deployments := make(map[string][]clients.AppConfigDeployment, len(environments))
+		for _, environment := range environments {
+			found, err := svc.AppConfig.ListDeployments(ctx, application.ID, environment.ID)
+			if err != nil {
+				failures = append(failures, clients.ItemError{Item: application.Name + "/" + environment.Name, Region: region, Err: err})
+				continue
+			}
+			deployments[environment.ID] = found
+		}
+
+		for _, profile := range profiles {
+			latest := 0
+			if profile.Hosted() {
+				versions, err := svc.AppConfig.ListHostedVersions(ctx, application.ID, profile.ID)
+				if err != nil {
+					failures = append(failures, clients.ItemError{Item: application.Name + "/" + profile.Name + " versions", Region: region, Err: err})
+				} else if len(versions) > 0 {
+					latest = versions[0].Number
+				}
+			}
+			resources = append(resources, appConfigResource(application, profile, environments, deployments, latest, region))
+		}
+	}
+
+	if len(failures) > 0 {
+		return resources, &clients.PartialError{Op: "appconfig", Failures: failures}
+	}
+	return resources, nil
+}
+
+// appConfigResource describes profile with the version last deployed to
+// each of environments, given their deployments newest first, and its
+// latest hosted version, 0 if it has none
+func appConfigResource(application clients.AppConfigApplication, profile clients.AppConfigProfile, environments []clients.AppConfigEnvironment,
+	deployments map[string][]clients.AppConfigDeployment, latest int, region string) Resource {
+	typ, ok := appConfigProfileTypes[profile.Type]
+	if !ok {
+		typ = orDash(profile.Type)
+	}
+	res := Resource{
+		ID:     profile.ID,
+		Name:   application.Name + "/" + profile.Name,
+		Type:   typ,
+		State:  "not deployed",
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"Application":    application.Name,
+			"Application ID": application.ID,
+			"Location":       profile.LocationURI,
+		},
+	}
+	if profile.Hosted() {
+		res.Details["Latest Version"] = orDash(versionNumber(latest))
+		res.Details["View"] = "press Enter for the differences between the latest two versions"
+	}
+
+	var behind, rolledBack []string
+	for _, environment := range environments {
+		key := "Env " + environment.Name
+		res.Details[key] = "not deployed"
+		for _, deployment := range deployments[environment.ID] {
+			if deployment.ConfigurationName != profile.Name {
+				continue
+			}
+			res.Details[key] = appConfigDeploymentWords(deployment)
+			switch {
+			case deployment.State == "ROLLED_BACK" || deployment.State == "REVERTED":
+				res.State = "rolled back"
+				rolledBack = append(rolledBack, fmt.Sprintf("version %s to %s", deployment.ConfigurationVersion, environment.Name))
+			case appConfigRunning[deployment.State]:
+				if res.State != "rolled back" {
+					res.State = "deploying"
+				}
+			default:
+				if res.State == "not deployed" {
+					res.State = "deployed"
+				}
+				if version, err := strconv.Atoi(deployment.ConfigurationVersion); err == nil && latest > 0 && version < latest {
+					behind = append(behind, fmt.Sprintf("%s runs version %d", environment.Name, version))
+				}
+			}
+			break
+		}
+	}
+
+	switch {
+	case len(rolledBack) > 0:
+		res.Alert = true
+		res.Details["Flag"] = "the last deployment of " + strings.Join(rolledBack, " and ") + " rolled back"
+	case len(behind) > 0:
+		res.Details["Flag"] = fmt.Sprintf("%s, behind the latest version %d", strings.Join(behind, ", "), latest)
+	}
+	return res
+}
+
+// versionNumber renders a hosted version number, "" for none
+func versionNumber(version int) string {
+	if version == 0 {
+		return ""
+	}
+	return strconv.Itoa(version)
+}
+
+// appConfigDeploymentWords describes a deployment, e.g. "version 3,
+// complete 6d ago" or "version 4 deploying, 40%"
+func appConfigDeploymentWords(deployment clients.AppConfigDeployment) string {
+	if appConfigRunning[deployment.State] {
+		return fmt.Sprintf("version %s %s, %.0f%%", deployment.ConfigurationVersion, statusWords(deployment.State), deployment.Percentage)
+	}
+	at := deployment.CompletedAt
+	if at.IsZero() {
+		at = deployment.StartedAt
+	}
+	return fmt.Sprintf("version %s, %s %s ago", deployment.ConfigurationVersion, statusWords(deployment.State), workloadAge(at))
+}
+
+// showAppConfigVersions lists the hosted versions of the profile res over
+// the tab with the differences between the latest two. d deploys the latest
+// version, r reloads and q closes the panel.
+func (rt *ResourcesTab) showAppConfigVersions(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+	if _, hosted := res.Details["Latest Version"]; !hosted {
+		rt.updateStatus(i18n.T("appconfig.kept_elsewhere", res.Name, detailString(res, "Location")), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	application := detailString(res, "Application ID")
+	loads := 0
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("appconfig.versions_title", res.Name))
+
+	load := func() {
+		view.SetText("[gray]" + i18n.T("common.loading") + "[-]")
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var versions []clients.AppConfigVersion
+			var contents [][]byte
+			err := fmt.Errorf("AppConfig service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.AppConfig != nil {
+				versions, err = svc.AppConfig.ListHostedVersions(ctx, application, res.ID)
+				for i := 0; err == nil && i < min(len(versions), 2); i++ {
+					var content []byte
+					if content, err = svc.AppConfig.GetHostedVersion(ctx, application, res.ID, versions[i].Number); err == nil {
+						contents = append(contents, content)
+					}
+				}
+			}
+			if err != nil {
+				logger.Error("Failed to load AppConfig versions", zap.String("profile", res.Name), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					view.SetText("[red]" + i18n.T("appconfig.versions_failed", stateWord("red"), tview.Escape(res.Name), tview.Escape(clients.ErrorReason(err))) + "[-]")
+					return
+				}
+				view.SetText(renderAppConfigVersions(versions, contents)).ScrollToBeginning()
+			})
+		}()
+	}
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeAppConfigVersions()
+			return nil
+		case 'r':
+			load()
+			return nil
+		case 'd':
+			rt.deployAppConfig(client, res, view, load)
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("appconfig-versions", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(view)
+	}
+	load()
+}
+
+// closeAppConfigVersions removes the versions panel and returns focus to
+// the table
+func (rt *ResourcesTab) closeAppConfigVersions() {
+	rt.view.RemovePage("appconfig-versions")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// renderAppConfigVersions lists versions, newest first, followed by the
+// differences between the contents of the latest two
+func renderAppConfigVersions(versions []clients.AppConfigVersion, contents [][]byte) string {
+	if len(versions) == 0 {
+		return "[gray]The profile has no hosted versions yet[-]"
+	}
+
+	var text strings.Builder
+	text.WriteString("[yellow]Versions:[-]\n")
+	for _, version := range versions {
+		fmt.Fprintf(&text, "  %3d  %-12s %-24s %s\n", version.Number, tview.Escape(orDash(version.Label)),
+			version.ContentType, tview.Escape(version.Description))
+	}
+	text.WriteString("\n")
+
+	switch len(contents) {
+	case 0:
+	case 1:
+		fmt.Fprintf(&text, "[yellow]Version %d, the only one:[-]\n", versions[0].Number)
+		for _, line := range configLines(contents[0]) {
+			fmt.Fprintf(&text, "  %s\n", tview.Escape(line))
+		}
+	default:
+		fmt.Fprintf(&text, "[yellow]Changes from version %d to %d:[-]\n", versions[1].Number, versions[0].Number)
+		text.WriteString(renderLineDiff(diffLines(configLines(contents[1]), configLines(contents[0])), 3))
+	}
+	return text.String()
+}
+
+// configLines splits a configuration into lines, indenting JSON first so
+// that a change to one flag is a change to one line
+func configLines(content []byte) []string {
+	var indented bytes.Buffer
+	if json.Indent(&indented, content, "", "  ") == nil {
+		content = indented.Bytes()
+	}
+	return strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+}
+
+// diffLine is a line of a diff: kept (' '), removed ('-') or added ('+')
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// maxDiffCells bounds the table of the longest common subsequence of two
+// configurations; larger changes are shown as replacing every line
+const maxDiffCells = 4 << 20
+
+// diffLines returns the lines that turn old into new, along the longest
+// common subsequence of the lines both share
+func diffLines(old, new []string) []diffLine {
+	var diff []diffLine
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		diff = append(diff, diffLine{' ', old[prefix]})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix && old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+	a, b := old[prefix:len(old)-suffix], new[prefix:len(new)-suffix]
+
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		for _, line := range a {
+			diff = append(diff, diffLine{'-', line})
+		}
+		for _, line := range b {
+			diff = append(diff, diffLine{'+', line})
+		}
+	} else {
+		// common[i][j] is the length of the longest common subsequence of
+		// a[i:] and b[j:]
+		common := make([][]int, len(a)+1)
+		for i := range common {
+			common[i] = make([]int, len(b)+1)
+		}
+		for i := len(a) - 1; i >= 0; i-- {
+			for j := len(b) - 1; j >= 0; j-- {
+				if a[i] == b[j] {
+					common[i][j] = common[i+1][j+1] + 1
+				} else {
+					common[i][j] = max(common[i+1][j], common[i][j+1])
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < len(a) || j < len(b) {
+			switch {
+			case i < len(a) && j < len(b) && a[i] == b[j]:
+				diff = append(diff, diffLine{' ', a[i]})
+				i++
+				j++
+			case j < len(b) && (i == len(a) || common[i][j+1] >= common[i+1][j]):
+				diff = append(diff, diffLine{'+', b[j]})
+				j++
+			default:
+				diff = append(diff, diffLine{'-', a[i]})
+				i++
+			}
+		}
+	}
+
+	for _, line := range old[len(old)-suffix:] {
+		diff = append(diff, diffLine{' ', line})
+	}
+	return diff
+}
+
+// renderLineDiff colors removed lines red and added lines green, keeping
+// context unchanged lines around each change and eliding the others
+func renderLineDiff(diff []diffLine, context int) string {
+	changed := make([]bool, len(diff))
+	any := false
+	for i, line := range diff {
+		if line.op == ' ' {
+			continue
+		}
+		any = true
+		for j := max(i-context, 0); j <= min(i+context, len(diff)-1); j++ {
+			changed[j] = true
+		}
+	}
+	if !any {
+		return "[gray]The versions are identical[-]\n"
+	}
+
+	var text strings.Builder
+	elided := false
+	for i, line := range diff {
+		if !changed[i] {
+			if !elided {
+				text.WriteString("[gray]  ...[-]\n")
+				elided = true
+			}
+			continue
+		}
+		elided = false
+		switch line.op {
+		case '-':
+			fmt.Fprintf(&text, "[red]- %s[-]\n", tview.Escape(line.text))
+		case '+':
+			fmt.Fprintf(&text, "[green]+ %s[-]\n", tview.Escape(line.text))
+		default:
+			fmt.Fprintf(&text, "  %s\n", tview.Escape(line.text))
+		}
+	}
+	return text.String()
+}
+
+// onAppConfigDeploy deploys the latest version of the selected profile
+func (rt *ResourcesTab) onAppConfigDeploy() {
+	if rt.selectedService != "appconfig" || rt.selectedRes == nil || rt.awsClient == nil {
+		return
+	}
+	rt.deployAppConfig(rt.awsClient, *rt.selectedRes, rt.resourceTable, nil)
+}
+
+// deployAppConfig asks for an environment and a deployment strategy and
+// deploys the latest hosted version of the profile res there, returning
+// focus to back and calling done, if any, once it started
+func (rt *ResourcesTab) deployAppConfig(client *aws.Client, res Resource, back tview.Primitive, done func()) {
+	if _, hosted := res.Details["Latest Version"]; !hosted {
+		rt.updateStatus(i18n.T("appconfig.deploy_elsewhere", res.Name, detailString(res, "Location")), "yellow")
+		return
+	}
+	application := detailString(res, "Application ID")
+	rt.updateStatus(i18n.T("appconfig.loading_environments", detailString(res, "Application")), "yellow")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var environments []clients.AppConfigEnvironment
+		var strategies []clients.AppConfigStrategy
+		var versions []clients.AppConfigVersion
+		err := fmt.Errorf("AppConfig service not initialized")
+		if svc := client.GetClients(); svc != nil && svc.AppConfig != nil {
+			if environments, err = svc.AppConfig.ListEnvironments(ctx, application); err == nil {
+				if strategies, err = svc.AppConfig.ListDeploymentStrategies(ctx); err == nil {
+					versions, err = svc.AppConfig.ListHostedVersions(ctx, application, res.ID)
+				}
+			}
+		}
+		if err != nil {
+			logger.Error("Failed to prepare AppConfig deployment", zap.String("profile", res.Name), zap.Error(err))
+		}
+		if rt.app == nil {
+			return
+		}
+		rt.app.QueueUpdateDraw(func() {
+			switch {
+			case err != nil:
+				rt.updateStatus(i18n.T("appconfig.cannot_deploy", res.Name, clients.ErrorReason(err)), "red")
+			case len(versions) == 0:
+				rt.updateStatus(i18n.T("appconfig.no_versions", res.Name), "yellow")
+			case len(environments) == 0:
+				rt.updateStatus(i18n.T("appconfig.no_environments", detailString(res, "Application")), "yellow")
+			case len(strategies) == 0:
+				rt.updateStatus(i18n.T("appconfig.no_strategies"), "yellow")
+			default:
+				rt.updateStatus(i18n.T("appconfig.choose_target", versions[0].Number, res.Name), "green")
+				rt.chooseAppConfigTarget(client, res, versions[0].Number, environments, strategies, back, done)
+			}
+		})
+	}()
+}
+
+// chooseAppConfigTarget lists environments, then strategies, and confirms
+// deploying version of res with the chosen ones
+func (rt *ResourcesTab) chooseAppConfigTarget(client *aws.Client, res Resource, version int, environments []clients.AppConfigEnvironment,
+	strategies []clients.AppConfigStrategy, back tview.Primitive, done func()) {
+	closeChoice := func() {
+		rt.view.RemovePage("appconfig-deploy")
+		if rt.app != nil {
+			rt.app.SetFocus(back)
+		}
+	}
+	newList := func(title string) *tview.List {
+		list := tview.NewList().
+			SetMainTextColor(tcell.ColorWhite).
+			SetSelectedTextColor(tcell.ColorBlack).
+			SetSelectedBackgroundColor(tcell.ColorWhite).
+			ShowSecondaryText(false)
+		list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			if event.Rune() == 'q' {
+				closeChoice()
+				return nil
+			}
+			return event
+		})
+		list.SetBorder(true).SetTitle(title).SetTitleAlign(tview.AlignLeft)
+		return list
+	}
+	show := func(list *tview.List, items int) {
+		rt.view.RemovePage("appconfig-deploy")
+		rt.view.AddPage("appconfig-deploy", centered(list, 76, min(items, 10)+2), true, true)
+		if rt.app != nil {
+			rt.app.SetFocus(list)
+		}
+	}
+
+	confirm := func(environment clients.AppConfigEnvironment, strategy clients.AppConfigStrategy) {
+		rt.view.RemovePage("appconfig-deploy")
+		deployed := detailString(res, "Env "+environment.Name)
+		modal := tview.NewModal().
+			SetText(i18n.T("dialog.appconfig_deploy",
+				version, res.Name, environment.Name, strategy.Name, environment.Name, orDash(deployed))).
+			AddButtons([]string{i18n.T("dialog.deploy"), i18n.T("common.cancel")}).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				closeChoice()
+				if buttonIndex == 0 {
+					rt.startAppConfigDeployment(client, res, environment, strategy, version, done)
+				}
+			})
+		rt.view.AddPage("appconfig-deploy", modal, false, true)
+	}
+
+	chooseStrategy := func(environment clients.AppConfigEnvironment) {
+		list := newList(panelTitle("appconfig.choose_strategy", environment.Name))
+		for _, strategy := range strategies {
+			list.AddItem(fmt.Sprintf("%s (%s)", strategy.Name, appConfigStrategyWords(strategy)), "", 0, nil)
+		}
+		list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+			confirm(environment, strategies[index])
+		})
+		show(list, len(strategies))
+	}
+
+	list := newList(panelTitle("appconfig.choose_environment", version, res.Name))
+	for _, environment := range environments {
+		list.AddItem(fmt.Sprintf("%s (%s)", environment.Name, detailString(res, "Env "+environment.Name)), "", 0, nil)
+	}
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		chooseStrategy(environments[index])
+	})
+	show(list, len(environments))
+}
+
+// appConfigStrategyWords describes how a strategy rolls out, e.g. "all at
+// once, bakes 10m" or "10% steps over 20m, bakes 10m"
+func appConfigStrategyWords(strategy clients.AppConfigStrategy) string {
+	rollout := "all at once"
+	if strategy.DurationMinutes > 0 && strategy.GrowthFactor < 100 {
+		rollout = fmt.Sprintf("%g%% steps over %dm", strategy.GrowthFactor, strategy.DurationMinutes)
+	}
+	return fmt.Sprintf("%s, bakes %dm", rollout, strategy.BakeMinutes)
+}
+
+// startAppConfigDeployment deploys version of res to environment, records
+// it and reloads the listing when it is still shown
+func (rt *ResourcesTab) startAppConfigDeployment(client *aws.Client, res Resource, environment clients.AppConfigEnvironment,
+	strategy clients.AppConfigStrategy, version int, done func()) {
+	rt.updateStatus(i18n.T("appconfig.deploying", version, res.Name, environment.Name), "yellow")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var deployment clients.AppConfigDeployment
+		err := fmt.Errorf("AppConfig service not initialized")
+		if svc := client.GetClients(); svc != nil && svc.AppConfig != nil {
+			deployment, err = svc.AppConfig.StartDeployment(ctx, environment.ApplicationID, environment.ID, res.ID, strconv.Itoa(version), strategy.ID)
+		}
+		resource := fmt.Sprintf("%s:%d to %s", res.Name, version, environment.Name)
+		rt.audit.record(client, "appconfig:StartDeployment", resource, err)
+		if err != nil {
+			logger.Error("Failed to start AppConfig deployment", zap.String("profile", res.Name), zap.String("environment", environment.Name), zap.Error(err))
+		} else {
+			logger.Info("Started AppConfig deployment", zap.String("profile", res.Name), zap.String("environment", environment.Name),
+				zap.Int("deployment", deployment.Number))
+		}
+
+		if rt.app == nil {
+			return
+		}
+		rt.app.QueueUpdateDraw(func() {
+			if err != nil {
+				rt.updateStatus(i18n.T("appconfig.deploy_failed", res.Name, clients.ErrorReason(err)), "red")
+				return
+			}
+			if rt.awsClient == client && rt.selectedService == "appconfig" {
+				rt.loadService("appconfig", true)
+			}
+			if done != nil {
+				done()
+			}
+			rt.updateStatus(i18n.T("appconfig.deployment", deployment.Number, res.Name, environment.Name, statusWords(deployment.State)), "green")
+		})
+	}()
+}
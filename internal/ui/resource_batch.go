@@ -0,0 +1,475 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// batchView lists the AWS Batch job queues with their compute environments
+// and how many jobs wait, run and failed; Enter shows the jobs
+type batchView struct{ baseView }
+
+var batchService = batchView{baseView{
+	info: ServiceInfo{Name: "batch", DisplayName: "Batch Job Queues", Icon: "🧮", Label: "BAT", Enabled: true, Permission: "batch:DescribeJobQueues"},
+	noun: "job queue",
+}}
+
+// ConsoleURL links the job queue
+func (batchView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/batch/home?%s#queues/detail/%s", base, query, url.QueryEscape(fmt.Sprint(res.Details["ARN"])))
+}
+
+// StateColor colors the states of the job queues
+func (batchView) StateColor(state string) tcell.Color {
+	return stateColor(batchQueueStateColors, state)
+}
+
+// batchQueueStateColors color the states of job queues
+var batchQueueStateColors = map[string]tcell.Color{
+	"enabled":  tcell.ColorGreen,
+	"invalid":  tcell.ColorRed,
+	"disabled": tcell.ColorYellow,
+}
+
+// Load lists the job queues
+func (batchView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadBatchQueues(ctx, client)
+}
+
+// Summary counts the jobs of all queues and the queues that cannot run them
+func (batchView) Summary(resources []Resource, failed int) (string, string) {
+	running, runnable, failedJobs, stuck := 0, 0, 0, 0
+	for _, res := range resources {
+		counts, _ := res.Details["Jobs"].(batchJobCounts)
+		running += counts.Running
+		runnable += counts.Runnable
+		failedJobs += counts.Failed
+		if res.Alert {
+			stuck++
+		}
+	}
+
+	message := fmt.Sprintf("%d queues, %d jobs running, %d runnable, %d failed", len(resources), running, runnable, failedJobs)
+	switch {
+	case stuck > 0:
+		return message + fmt.Sprintf(", %d cannot run jobs", stuck), "red"
+	case failed > 0 || failedJobs > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// Open shows the jobs of the queue
+func (batchView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showBatchJobs(resource)
+}
+
+// batchJobStatuses are the statuses of the jobs shown, in the order shown
+var batchJobStatuses = []string{"RUNNABLE", "RUNNING", "FAILED"}
+
+// batchJobCounts counts the jobs of a queue by status
+type batchJobCounts struct {
+	Runnable, Running, Failed int
+}
+
+func (c batchJobCounts) String() string {
+	return fmt.Sprintf("%d runnable, %d running, %d failed", c.Runnable, c.Running, c.Failed)
+}
+
+// loadBatchQueues lists the job queues of the region with their compute
+// environments and job counts. Queues that cannot place jobs, or whose jobs
+// wait with none running, are flagged.
+func (rt *ResourcesTab) loadBatchQueues(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.Batch == nil {
+		return nil, fmt.Errorf("Batch service not initialized")
+	}
+
+	queues, err := svc.Batch.ListJobQueues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	environments, err := svc.Batch.ListComputeEnvironments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byARN := make(map[string]clients.BatchComputeEnvironment, len(environments))
+	for _, environment := range environments {
+		byARN[environment.ARN] = environment
+	}
+
+	resources := make([]Resource, 0, len(queues))
+	for _, queue := range queues {
+		var counts batchJobCounts
+		var countErr error
+		for _, status := range batchJobStatuses {
+			jobs, err := svc.Batch.ListJobs(ctx, queue.ARN, status)
+			if err != nil {
+				countErr = err
+				break
+			}
+			switch status {
+			case "RUNNABLE":
+				counts.Runnable = len(jobs)
+			case "RUNNING":
+				counts.Running = len(jobs)
+			case "FAILED":
+				counts.Failed = len(jobs)
+			}
+		}
+		if countErr != nil {
+			logger.Warn("Failed to count Batch jobs", zap.String("queue", queue.Name), zap.Error(countErr))
+		}
+		resources = append(resources, batchQueueResource(queue, byARN, counts, countErr, client.GetRegion()))
+	}
+	return resources, nil
+}
+
+// batchQueueResource describes queue with the compute environments it
+// places jobs in and its jobs
+func batchQueueResource(queue clients.BatchJobQueue, environments map[string]clients.BatchComputeEnvironment, counts batchJobCounts, countErr error, region string) Resource {
+	res := Resource{
+		ID:     queue.Name,
+		Name:   queue.Name,
+		Type:   "Batch Job Queue",
+		State:  statusWords(queue.State),
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":      queue.ARN,
+			"Priority": queue.Priority,
+			"Jobs":     counts,
+			"View":     "press Enter for the runnable, running and failed jobs of the queue",
+		},
+	}
+	if countErr != nil {
+		res.Details["Jobs"] = "could not be counted: " + clients.ErrorReason(countErr)
+	}
+
+	var names, broken []string
+	for _, arn := range queue.ComputeEnvironments {
+		environment, ok := environments[arn]
+		if !ok {
+			names = append(names, arn[strings.LastIndex(arn, "/")+1:])
+			continue
+		}
+		names = append(names, fmt.Sprintf("%s (%s, %d of %d vCPUs)", environment.Name,
+			statusWords(environment.ResourceType), environment.DesiredVCPUs, environment.MaxVCPUs))
+		switch {
+		case environment.Status == "INVALID":
+			broken = append(broken, fmt.Sprintf("%s is invalid: %s", environment.Name, environment.StatusReason))
+		case environment.State == "DISABLED":
+			broken = append(broken, environment.Name+" is disabled")
+		}
+	}
+	res.Details["Compute Environments"] = orDash(strings.Join(names, ", "))
+
+	switch {
+	case queue.Status == "INVALID":
+		res.State = "invalid"
+		res.Alert = true
+		res.Details["Flag"] = "the queue is invalid: " + queue.StatusReason
+	case len(broken) > 0 && len(broken) == len(queue.ComputeEnvironments):
+		res.Alert = true
+		res.Details["Flag"] = "no compute environment can run jobs: " + strings.Join(broken, "; ")
+	case counts.Runnable > 0 && counts.Running == 0 && queue.State == "ENABLED":
+		res.Alert = true
+		res.Details["Flag"] = "jobs are runnable but none run, check the compute environments"
+	case len(broken) > 0:
+		res.Details["Flag"] = strings.Join(broken, "; ")
+	}
+	return res
+}
+
+// batchJobColors color the statuses of Batch jobs
+var batchJobColors = map[string]tcell.Color{
+	"RUNNING":   tcell.ColorGreen,
+	"SUCCEEDED": tcell.ColorGreen,
+	"FAILED":    tcell.ColorRed,
+}
+
+// showBatchJobs shows the runnable, running and failed jobs of the job
+// queue res over the tab, newest first within each status, with why the
+// selected job stopped and where it logs below the table. x terminates the
+// selected job, l shows its log stream in the Logs tab, r reloads and q
+// closes the panel.
+func (rt *ResourcesTab) showBatchJobs(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	queue, _ := res.Details["ARN"].(string)
+	// The jobs, nil until loaded
+	var jobs []clients.BatchJob
+	loads := 0
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	notice := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	panel := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(table, 0, 1, true).
+		AddItem(notice, 2, 0, false)
+	keys := i18n.T("batch.keys")
+	panel.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("batch.title", res.Name, keys))
+
+	selected := func() *clients.BatchJob {
+		row, _ := table.GetSelection()
+		if row < 1 || row > len(jobs) {
+			return nil
+		}
+		return &jobs[row-1]
+	}
+	setNotice := func(message, color string) {
+		notice.SetText(fmt.Sprintf("[%s]%s%s[-]", color, stateWord(color), tview.Escape(message)))
+	}
+
+	load := func() {
+		setTableMessage(table, i18n.T("common.loading"), tcell.ColorGray)
+		notice.SetText("")
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var loaded []clients.BatchJob
+			err := fmt.Errorf("Batch service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.Batch != nil {
+				err = nil
+				for _, status := range batchJobStatuses {
+					var found []clients.BatchJob
+					if found, err = svc.Batch.ListJobs(ctx, queue, status); err != nil {
+						break
+					}
+					loaded = append(loaded, found...)
+				}
+			}
+			if err != nil {
+				logger.Error("Failed to list Batch jobs", zap.String("queue", queue), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					setTableMessage(table, i18n.T("batch.list_failed", res.Name, clients.ErrorReason(err)), tcell.ColorRed)
+					return
+				}
+				jobs = loaded
+				panel.SetTitle(panelTitle("batch.title_count", res.Name, countBatchJobs(jobs), keys))
+				fillBatchJobs(table, jobs)
+				showBatchJob(notice, jobs, 1)
+			})
+		}()
+	}
+
+	terminate := func(job clients.BatchJob) {
+		setNotice(i18n.T("batch.terminating", job.Name), "yellow")
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			err := fmt.Errorf("Batch service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.Batch != nil {
+				err = svc.Batch.TerminateJob(ctx, job.ID, "Terminated from the Resources tab")
+			}
+			rt.audit.record(client, "batch:TerminateJob", job.ARN, err)
+			if err != nil {
+				logger.Error("Failed to terminate Batch job", zap.String("job", job.ID), zap.Error(err))
+			} else {
+				logger.Info("Terminated Batch job", zap.String("job", job.ID))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if err != nil {
+					setNotice(i18n.T("batch.terminate_failed", job.Name, clients.ErrorReason(err)), "red")
+					return
+				}
+				load()
+				setNotice(i18n.T("batch.terminated", job.Name), "green")
+			})
+		}()
+	}
+
+	confirmTerminate := func(job clients.BatchJob) {
+		if job.Status == "FAILED" || job.Status == "SUCCEEDED" {
+			setNotice(i18n.T("batch.finished", job.Name), "yellow")
+			return
+		}
+		text := i18n.T("dialog.batch_terminate", job.Name)
+		if job.Status == "RUNNABLE" {
+			text = i18n.T("dialog.batch_cancel", job.Name)
+		}
+		modal := tview.NewModal().
+			SetText(text).
+			AddButtons([]string{i18n.T("dialog.terminate"), i18n.T("common.cancel")}).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				rt.view.RemovePage("batch-terminate")
+				if rt.app != nil {
+					rt.app.SetFocus(table)
+				}
+				if buttonIndex == 0 {
+					terminate(job)
+				}
+			})
+		rt.view.AddPage("batch-terminate", modal, false, true)
+	}
+
+	showLogs := func(job clients.BatchJob) {
+		switch {
+		case job.LogGroup == "":
+			setNotice(i18n.T("batch.no_logs", job.Name), "yellow")
+		case job.LogStream == "":
+			setNotice(i18n.T("batch.not_logging", job.Name), "yellow")
+		default:
+			logger.Info("Emitting EventShowLogStream", zap.String("logGroup", job.LogGroup), zap.String("stream", job.LogStream))
+			rt.events.Publish(ShowLogStreamEvent{LogGroup: job.LogGroup, Stream: job.LogStream})
+		}
+	}
+
+	table.SetSelectionChangedFunc(func(row, _ int) {
+		showBatchJob(notice, jobs, row)
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeBatchJobs()
+			return nil
+		case 'r':
+			load()
+			return nil
+		case 'x':
+			if job := selected(); job != nil {
+				confirmTerminate(*job)
+			}
+			return nil
+		case 'l':
+			if job := selected(); job != nil {
+				showLogs(*job)
+			}
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("batch-jobs", panel, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(table)
+	}
+	load()
+}
+
+// closeBatchJobs removes the jobs panel and returns focus to the table
+func (rt *ResourcesTab) closeBatchJobs() {
+	rt.view.RemovePage("batch-jobs")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// countBatchJobs counts jobs by status, e.g. "1 runnable, 2 running, 1
+// failed"
+func countBatchJobs(jobs []clients.BatchJob) string {
+	var counts batchJobCounts
+	for _, job := range jobs {
+		switch job.Status {
+		case "RUNNABLE":
+			counts.Runnable++
+		case "RUNNING":
+			counts.Running++
+		case "FAILED":
+			counts.Failed++
+		}
+	}
+	return counts.String()
+}
+
+// showBatchJob shows why the job in row of the jobs table stopped, or where
+// it logs, in notice
+func showBatchJob(notice *tview.TextView, jobs []clients.BatchJob, row int) {
+	notice.SetText("")
+	if row < 1 || row > len(jobs) {
+		return
+	}
+	job := jobs[row-1]
+	if job.Status == "FAILED" {
+		reason := job.StatusReason
+		if job.Reason != "" {
+			reason += ": " + job.Reason
+		}
+		notice.SetText(fmt.Sprintf("[red]%s%s[-]", stateWord("red"), tview.Escape(reason)))
+		return
+	}
+	if job.LogStream != "" {
+		notice.SetText("[gray]" + i18n.T("batch.logs", tview.Escape(job.LogGroup), tview.Escape(job.LogStream)) + "[-]")
+	}
+}
+
+// fillBatchJobs lists the jobs of a queue
+func fillBatchJobs(table *tview.Table, jobs []clients.BatchJob) {
+	if len(jobs) == 0 {
+		setTableMessage(table, i18n.T("batch.no_jobs"), tcell.ColorGray)
+		return
+	}
+
+	table.Clear()
+	for col, header := range []string{"Status", "Job", "ID", "Age", "Ran", "Attempts", "Exit", "Definition"} {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, job := range jobs {
+		row := i + 1
+		statusColor, ok := batchJobColors[job.Status]
+		if !ok {
+			statusColor = tcell.ColorYellow
+		}
+		ran := "-"
+		switch {
+		case !job.StartedAt.IsZero() && !job.StoppedAt.IsZero():
+			ran = job.StoppedAt.Sub(job.StartedAt).Round(time.Second).String()
+		case !job.StartedAt.IsZero():
+			ran = time.Since(job.StartedAt).Round(time.Second).String()
+		}
+		exit := "-"
+		if job.ExitCode != nil {
+			exit = fmt.Sprintf("%d", *job.ExitCode)
+		}
+		definition := job.Definition[strings.LastIndex(job.Definition, "/")+1:]
+
+		table.SetCell(row, 0, tview.NewTableCell(statusWords(job.Status)).SetTextColor(statusColor))
+		table.SetCell(row, 1, tview.NewTableCell(tview.Escape(job.Name)))
+		table.SetCell(row, 2, tview.NewTableCell(job.ID[:min(8, len(job.ID))]))
+		table.SetCell(row, 3, tview.NewTableCell(workloadAge(job.CreatedAt)).SetAlign(tview.AlignRight))
+		table.SetCell(row, 4, tview.NewTableCell(ran).SetAlign(tview.AlignRight))
+		table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("%d", job.Attempts)).SetAlign(tview.AlignRight))
+		table.SetCell(row, 6, tview.NewTableCell(exit).SetAlign(tview.AlignRight))
+		table.SetCell(row, 7, tview.NewTableCell(tview.Escape(orDash(definition))).SetExpansion(1))
+	}
+	table.Select(1, 0).ScrollToBeginning()
+}
@@ -0,0 +1,277 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// bedrockView lists the foundation models of Amazon Bedrock; Enter sends
+// the selected model a prompt
+type bedrockView struct{ baseView }
+
+var bedrockService = bedrockView{baseView{
+	info: ServiceInfo{Name: "bedrock", DisplayName: "Bedrock Models", Icon: "💬", Label: "BDR", Enabled: true, Permission: "bedrock:ListFoundationModels"},
+	noun: "model",
+}}
+
+// ConsoleURL links the model among those of its provider
+func (bedrockView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/bedrock/home?%s#/providers?model=%s", base, query, url.QueryEscape(res.ID))
+}
+
+// StateColor colors the lifecycle of the models
+func (bedrockView) StateColor(state string) tcell.Color {
+	return stateColor(bedrockStateColors, state)
+}
+
+// bedrockStateColors color the lifecycle of models
+var bedrockStateColors = map[string]tcell.Color{
+	"legacy": tcell.ColorYellow,
+}
+
+// Load lists the foundation models
+func (bedrockView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return loadBedrockModels(ctx, client)
+}
+
+// Summary counts the providers and the models that take prompts
+func (bedrockView) Summary(resources []Resource, failed int) (string, string) {
+	providers := make(map[string]bool)
+	prompted, legacy := 0, 0
+	for _, res := range resources {
+		providers[fmt.Sprint(res.Details["Provider"])] = true
+		if _, ok := res.Details["Prompt"]; !ok {
+			prompted++
+		}
+		if res.State == "legacy" {
+			legacy++
+		}
+	}
+
+	message := fmt.Sprintf("%s from %s, %d take prompts", pluralize(len(resources), "model"), pluralize(len(providers), "provider"), prompted)
+	if legacy > 0 {
+		return message + fmt.Sprintf(", %d legacy", legacy), "yellow"
+	}
+	return message, "green"
+}
+
+// Open asks for a prompt for the model
+func (bedrockView) Open(rt *ResourcesTab, resource Resource) {
+	if reason, ok := resource.Details["Prompt"].(string); ok {
+		rt.updateStatus(i18n.T("bedrock.cannot_prompt", resource.Name, reason), "yellow")
+		return
+	}
+	rt.showBedrockPrompt(resource, "", bedrockMaxTokens)
+}
+
+// bedrockMaxTokens is how long answers may be unless asked otherwise
+const bedrockMaxTokens = 512
+
+// bedrockModalities names modalities in words, e.g. "text, image"
+func bedrockModalities(modalities []string) string {
+	return orDash(strings.ToLower(strings.Join(modalities, ", ")))
+}
+
+// loadBedrockModels lists the foundation models of the region. Models that
+// do not answer text, or are not served on demand, keep why they cannot be
+// prompted in their Prompt detail.
+func loadBedrockModels(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.Bedrock == nil {
+		return nil, fmt.Errorf("Bedrock service not initialized")
+	}
+
+	models, err := svc.Bedrock.ListFoundationModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	region := client.GetRegion()
+	resources := make([]Resource, 0, len(models))
+	for _, model := range models {
+		inference := make([]string, 0, len(model.InferenceTypes))
+		for _, typ := range model.InferenceTypes {
+			inference = append(inference, statusWords(typ))
+		}
+		res := Resource{
+			ID:     model.ID,
+			Name:   model.Name,
+			Type:   fmt.Sprintf("%s → %s", bedrockModalities(model.InputModalities), bedrockModalities(model.OutputModalities)),
+			State:  statusWords(model.Lifecycle),
+			Region: region,
+			Tags:   make(map[string]string),
+			Details: map[string]interface{}{
+				"Provider":  model.Provider,
+				"Inference": orDash(strings.Join(inference, ", ")),
+				"Streaming": model.Streaming,
+			},
+		}
+		switch {
+		case !model.Chat():
+			res.Details["Prompt"] = "it does not answer text with text"
+		case !model.OnDemand():
+			res.Details["Prompt"] = "it is only served through provisioned throughput or inference profiles"
+		default:
+			res.Details["View"] = "press Enter to send the model a prompt"
+		}
+		if model.Lifecycle == "LEGACY" {
+			res.Details["Flag"] = "legacy: the model is to be retired, move to a newer one"
+		}
+		resources = append(resources, res)
+	}
+	return resources, nil
+}
+
+// showBedrockPrompt asks for a prompt for the model res, starting from
+// prompt, and the most tokens to answer with
+func (rt *ResourcesTab) showBedrockPrompt(res Resource, prompt string, maxTokens int) {
+	limit := strconv.Itoa(maxTokens)
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("bedrock.prompt"), prompt, 0, nil, func(text string) { prompt = text })
+	form.AddInputField(i18n.T("bedrock.max_tokens"), limit, 8, tview.InputFieldInteger, func(text string) { limit = text })
+	form.AddButton(i18n.T("bedrock.send"), func() {
+		tokens, err := strconv.Atoi(limit)
+		if strings.TrimSpace(prompt) == "" || err != nil || tokens < 1 {
+			rt.updateStatus(i18n.T("bedrock.bad_prompt"), "yellow")
+			return
+		}
+		rt.closeBedrockPrompt()
+		rt.showBedrockReply(res, prompt, tokens)
+	})
+	form.AddButton(i18n.T("common.cancel"), rt.closeBedrockPrompt)
+	form.SetCancelFunc(rt.closeBedrockPrompt)
+	form.SetBorder(true).
+		SetTitle(panelTitle("bedrock.prompt_title", res.Name)).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("bedrock-prompt", centered(form, 96, 9), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(form)
+	}
+}
+
+// closeBedrockPrompt removes the prompt form and returns focus to the table
+func (rt *ResourcesTab) closeBedrockPrompt() {
+	rt.view.RemovePage("bedrock-prompt")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// showBedrockReply sends prompt to the model res and shows its answer with
+// the tokens it took. p asks for another prompt, r sends the prompt again
+// and q closes the panel.
+func (rt *ResourcesTab) showBedrockReply(res Resource, prompt string, maxTokens int) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	sends := 0
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetWordWrap(true)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("bedrock.reply_title", res.Name))
+
+	question := infoLabel("bedrock.prompt") + " " + tview.Escape(prompt) + "\n\n"
+	send := func() {
+		view.SetText(question + "[gray]" + i18n.T("bedrock.waiting", tview.Escape(res.Name)) + "[-]")
+		sends++
+		gen := sends
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			var reply clients.BedrockReply
+			err := fmt.Errorf("Bedrock service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.Bedrock != nil {
+				reply, err = svc.Bedrock.Converse(ctx, res.ID, prompt, maxTokens)
+			}
+			if err != nil {
+				logger.Error("Failed to invoke Bedrock model", zap.String("model", res.ID), zap.Error(err))
+			} else {
+				logger.Info("Invoked Bedrock model", zap.String("model", res.ID),
+					zap.Int("inputTokens", reply.InputTokens), zap.Int("outputTokens", reply.OutputTokens))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != sends {
+					return
+				}
+				if err != nil {
+					view.SetText(question + "[red]" + i18n.T("bedrock.no_answer", stateWord("red"), tview.Escape(res.Name), tview.Escape(clients.ErrorReason(err))) + "[-]")
+					return
+				}
+				view.SetText(question + renderBedrockReply(reply, maxTokens)).ScrollToBeginning()
+			})
+		}()
+	}
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeBedrockReply()
+			return nil
+		case 'r':
+			send()
+			return nil
+		case 'p':
+			rt.closeBedrockReply()
+			rt.showBedrockPrompt(res, prompt, maxTokens)
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("bedrock-reply", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(view)
+	}
+	send()
+}
+
+// closeBedrockReply removes the answer panel and returns focus to the table
+func (rt *ResourcesTab) closeBedrockReply() {
+	rt.view.RemovePage("bedrock-reply")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// renderBedrockReply shows the answer of a model with the tokens it took,
+// warning when it was cut at maxTokens or stopped by a guardrail
+func renderBedrockReply(reply clients.BedrockReply, maxTokens int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "[yellow]Answer:[-]\n%s\n\n", tview.Escape(orDash(reply.Text)))
+	fmt.Fprintf(&text, "[yellow]Tokens:[-] %d in, %d out, %d total  [yellow]Latency:[-] %s  [yellow]Stop reason:[-] %s\n",
+		reply.InputTokens, reply.OutputTokens, reply.InputTokens+reply.OutputTokens,
+		reply.Latency.Round(time.Millisecond), statusWords(reply.StopReason))
+	switch reply.StopReason {
+	case "max_tokens":
+		fmt.Fprintf(&text, "[yellow]%sThe answer was cut at %d tokens; press p to allow more[-]\n", stateWord("yellow"), maxTokens)
+	case "guardrail_intervened", "content_filtered":
+		fmt.Fprintf(&text, "[red]%sThe answer was filtered[-]\n", stateWord("red"))
+	}
+	return text.String()
+}
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+)
+
+// certificatesView lists the ACM certificates by expiry
+type certificatesView struct{ baseView }
+
+var certificatesService = certificatesView{baseView{
+	info: ServiceInfo{Name: "acm", DisplayName: "ACM Certificates", Icon: "🔏", Label: "ACM", Enabled: true, Permission: "acm:ListCertificates"},
+	noun: "certificate",
+}}
+
+// Load lists the certificates
+func (certificatesView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadCertificates(ctx, client)
+}
+
+// Summary sums up the certificates
+func (certificatesView) Summary(resources []Resource, failed int) (string, string) {
+	return certificatesSummary(resources, failed)
+}
+
+// certificateExpiryWarning is how close to its expiry a certificate is shown
+// in red
+const certificateExpiryWarning = 30 * 24 * time.Hour
+
+// daysUntil returns the whole days from now until t, negative once t passed
+func daysUntil(t, now time.Time) int {
+	return int(t.Sub(now).Hours() / 24)
+}
+
+// loadCertificates lists the ACM certificates of the region, soonest expiry
+// first. Certificates that expire within certificateExpiryWarning or expired
+// already are marked as alerts.
+func (rt *ResourcesTab) loadCertificates(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.ACM == nil {
+		return nil, fmt.Errorf("ACM service not initialized")
+	}
+
+	certificates, err := svc.ACM.ListCertificates(ctx)
+	if certificates == nil && err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resources := make([]Resource, 0, len(certificates))
+	for _, certificate := range certificates {
+		resources = append(resources, certificateResource(certificate, client.GetRegion(), now))
+	}
+
+	// Unissued certificates have no expiry and go last
+	sort.SliceStable(resources, func(i, j int) bool {
+		a, okA := resources[i].Details["Days Left"].(int)
+		b, okB := resources[j].Details["Days Left"].(int)
+		if okA != okB {
+			return okA
+		}
+		return a < b
+	})
+	return resources, err
+}
+
+// certificateResource describes certificate, with the days until it
+// expires in its state
+func certificateResource(certificate clients.CertificateDetail, region string, now time.Time) Resource {
+	typ := "ACM Certificate"
+	if certificate.Type == "IMPORTED" {
+		typ = "Imported Certificate"
+	}
+
+	inUse := "not in use"
+	if len(certificate.InUseBy) > 0 {
+		inUse = strings.Join(certificate.InUseBy, ", ")
+	}
+
+	res := Resource{
+		ID:     certificate.ARN[strings.LastIndex(certificate.ARN, "/")+1:],
+		Name:   certificate.Domain,
+		Type:   typ,
+		State:  strings.ToLower(certificate.Status),
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":           certificate.ARN,
+			"Domains":       strings.Join(certificate.SANs, ", "),
+			"Key Algorithm": certificate.KeyType,
+			"Issuer":        certificate.Type,
+			"Validation":    certificate.ValidationStatus,
+			"Renewal":       certificate.RenewalEligibility,
+			"In Use By":     inUse,
+		},
+	}
+	if !certificate.IssuedAt.IsZero() {
+		res.CreatedDate = certificate.IssuedAt.Format("2006-01-02 15:04:05")
+	}
+
+	if !certificate.NotAfter.IsZero() {
+		days := daysUntil(certificate.NotAfter, now)
+		res.Details["Expires"] = zonedTime(certificate.NotAfter, "2006-01-02")
+		res.Details["Days Left"] = days
+		res.Alert = certificate.NotAfter.Sub(now) < certificateExpiryWarning
+		if days >= 0 && certificate.Status == "ISSUED" {
+			res.State = fmt.Sprintf("issued, %dd left", days)
+		}
+	}
+	return res
+}
+
+// certificatesSummary counts the certificates that expire soon and those
+// that expired while still in use
+func certificatesSummary(resources []Resource, failed int) (string, string) {
+	var expiring, expiredInUse int
+	for _, res := range resources {
+		if !res.Alert {
+			continue
+		}
+		days, _ := res.Details["Days Left"].(int)
+		if days < 0 {
+			if res.Details["In Use By"] != "not in use" {
+				expiredInUse++
+			}
+			continue
+		}
+		expiring++
+	}
+
+	message := fmt.Sprintf("%d certificates, %d expire within %d days", len(resources), expiring, int(certificateExpiryWarning.Hours()/24))
+	if expiredInUse > 0 {
+		message += fmt.Sprintf(", %d expired but in use", expiredInUse)
+	}
+	switch {
+	case expiring > 0 || expiredInUse > 0:
+		return message, "red"
+	case failed > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
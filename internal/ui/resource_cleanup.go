@@ -0,0 +1,589 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/pricing"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// cleanupView lists the AMIs and EBS snapshots of the account that no
+// instance, volume, launch template or Auto Scaling group references, and
+// cleans them up after a dry run
+type cleanupView struct{ baseView }
+
+var cleanupService = cleanupView{baseView{
+	info: ServiceInfo{Name: "ec2cleanup", DisplayName: "Snapshot & AMI Cleanup", Icon: "🧹", Label: "CLN", Enabled: true, Permission: "ec2:DescribeSnapshots"},
+	noun: "candidate",
+}}
+
+// ConsoleURL links the AMI or the snapshot
+func (cleanupView) ConsoleURL(base, query string, res Resource) string {
+	if res.Type == "AMI" {
+		return fmt.Sprintf("%s/ec2/home?%s#ImageDetails:imageId=%s", base, query, res.ID)
+	}
+	return fmt.Sprintf("%s/ec2/home?%s#SnapshotDetails:snapshotId=%s", base, query, res.ID)
+}
+
+// StateColor colors the candidates
+func (cleanupView) StateColor(state string) tcell.Color {
+	return stateColor(cleanupStateColors, state)
+}
+
+// cleanupStateColors color the candidates for cleanup
+var cleanupStateColors = map[string]tcell.Color{
+	"unused": tcell.ColorRed,
+}
+
+// cleanupAfterDays is the age the cleanup proposes to keep newer images and
+// snapshots for
+const cleanupAfterDays = 90
+
+// cleanupAgeGroups are the groups candidates are reported in, oldest first,
+// each holding those at least days old
+var cleanupAgeGroups = []struct {
+	name string
+	days int
+}{
+	{"over 1 year", 365},
+	{"6 to 12 months", 180},
+	{"1 to 6 months", 30},
+	{"under 1 month", 0},
+}
+
+// cleanupKinds are the catalog keys of what the cleanup can be limited to
+var cleanupKinds = []string{"cleanup.both", "cleanup.amis", "cleanup.snapshots"}
+
+// Load lists the candidates
+func (cleanupView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return loadCleanupCandidates(ctx, client)
+}
+
+// Summary counts the candidates and what they cost
+func (cleanupView) Summary(resources []Resource, failed int) (string, string) {
+	return cleanupSummary(resources, failed)
+}
+
+// Actions clean up the candidates
+func (cleanupView) Actions() []resourceAction {
+	return []resourceAction{
+		{name: "ec2cleanup clean up", key: 'c', description: "action.ec2cleanup_clean_up",
+			online: true, inServiceList: true,
+			run: (*ResourcesTab).onCleanup},
+	}
+}
+
+// findCleanupCandidates looks up what Auto Scaling launches instances from,
+// then the AMIs and snapshots nothing in the region references
+func findCleanupCandidates(ctx context.Context, svc *aws.ServiceClients) (clients.CleanupCandidates, error) {
+	if svc == nil || svc.EC2 == nil {
+		return clients.CleanupCandidates{}, fmt.Errorf("EC2 service not initialized")
+	}
+	if svc.AutoScaling == nil {
+		return clients.CleanupCandidates{}, fmt.Errorf("Auto Scaling service not initialized")
+	}
+
+	launches, err := svc.AutoScaling.ListLaunchReferences(ctx)
+	if err != nil {
+		return clients.CleanupCandidates{}, err
+	}
+	return svc.EC2.FindCleanupCandidates(ctx, launches)
+}
+
+// loadCleanupCandidates lists the unused AMIs and snapshots of the region
+func loadCleanupCandidates(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	found, err := findCleanupCandidates(ctx, client.GetClients())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resources := make([]Resource, 0, len(found.Images)+len(found.Snapshots))
+	for _, image := range found.Images {
+		resources = append(resources, unusedImageResource(image, client.GetRegion(), now))
+	}
+	for _, snapshot := range found.Snapshots {
+		resources = append(resources, unusedSnapshotResource(snapshot, client.GetRegion(), now))
+	}
+	return resources, nil
+}
+
+// unusedImageResource describes an unused AMI with the snapshots that are
+// deleted with it and what they cost at most
+func unusedImageResource(image clients.UnusedImage, region string, now time.Time) Resource {
+	res := Resource{
+		ID:     image.ID,
+		Name:   orDash(image.Name),
+		Type:   "AMI",
+		State:  "unused",
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"Age Group": cleanupAgeGroup(image.CreatedAt, now),
+			"Size (GB)": image.SizeGB,
+			"Snapshots": orDash(strings.Join(image.Snapshots, ", ")),
+			"Cleanup":   "press c to deregister it with its snapshots after a dry run",
+		},
+	}
+	if len(image.SharedWith) > 0 {
+		// Other accounts may still launch it
+		res.Details["Shared With"] = strings.Join(image.SharedWith, ", ")
+	}
+	if !image.CreatedAt.IsZero() {
+		res.CreatedDate = image.CreatedAt.Format("2006-01-02 15:04:05")
+	}
+	if cost, ok := pricing.EBSSnapshotMonthly("standard", image.SizeGB, region); ok {
+		res.MonthlyCost = cost
+	}
+	return res
+}
+
+// unusedSnapshotResource describes an unused snapshot and what it costs at
+// most
+func unusedSnapshotResource(snapshot clients.UnusedSnapshot, region string, now time.Time) Resource {
+	name := snapshot.Description
+	if name == "" {
+		name = snapshot.VolumeID
+	}
+	res := Resource{
+		ID:          snapshot.ID,
+		Name:        name,
+		Type:        "EBS Snapshot",
+		State:       "unused",
+		Region:      region,
+		Tags:        make(map[string]string),
+		CreatedDate: snapshot.StartedAt.Format("2006-01-02 15:04:05"),
+		Details: map[string]interface{}{
+			"Age Group":    cleanupAgeGroup(snapshot.StartedAt, now),
+			"Size (GB)":    snapshot.SizeGB,
+			"Volume":       snapshot.VolumeID + " (deleted)",
+			"Storage Tier": orDash(snapshot.StorageTier),
+			"Cleanup":      "press c to delete it after a dry run",
+		},
+	}
+	if cost, ok := pricing.EBSSnapshotMonthly(snapshot.StorageTier, snapshot.SizeGB, region); ok {
+		res.MonthlyCost = cost
+	}
+	return res
+}
+
+// cleanupAgeGroup returns the age group of something created at created
+func cleanupAgeGroup(created, now time.Time) string {
+	age := now.Sub(created)
+	for _, group := range cleanupAgeGroups {
+		if age >= time.Duration(group.days)*24*time.Hour {
+			return group.name
+		}
+	}
+	return cleanupAgeGroups[len(cleanupAgeGroups)-1].name
+}
+
+// cleanupSummary counts the unused AMIs and snapshots, their size and what
+// they cost at most
+func cleanupSummary(resources []Resource, failed int) (string, string) {
+	images, snapshots, size := 0, 0, 0
+	var cost float64
+	for _, res := range resources {
+		if res.Type == "AMI" {
+			images++
+		} else {
+			snapshots++
+		}
+		if gb, ok := res.Details["Size (GB)"].(int); ok {
+			size += gb
+		}
+		cost += res.MonthlyCost
+	}
+
+	message := fmt.Sprintf("%s and %s unused, %d GB ($%.2f/month)",
+		pluralize(images, "AMI"), pluralize(snapshots, "snapshot"), size, cost)
+	switch {
+	case failed > 0 || len(resources) > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// onCleanup asks what to clean up and runs a dry run of it
+func (rt *ResourcesTab) onCleanup() {
+	if rt.selectedService != "ec2cleanup" || rt.awsClient == nil {
+		return
+	}
+	client := rt.awsClient
+	back := rt.resourceTable
+	value, kind := strconv.Itoa(cleanupAfterDays), 0
+
+	kinds := make([]string, len(cleanupKinds))
+	for i, key := range cleanupKinds {
+		kinds[i] = i18n.T(key)
+	}
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("common.older_than"), value, 6, tview.InputFieldInteger, func(text string) { value = text })
+	form.AddDropDown(i18n.T("cleanup.kind"), kinds, kind, func(_ string, index int) { kind = index })
+	form.AddButton(i18n.T("cleanup.dry_run"), func() {
+		rt.closeCleanupEdit(back)
+		days, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || days < 0 {
+			rt.updateStatus(i18n.T("common.bad_age"), "red")
+			return
+		}
+		rt.dryRunCleanup(client, days, kind)
+	})
+	form.AddButton(i18n.T("common.cancel"), func() { rt.closeCleanupEdit(back) })
+	form.SetBorder(true).
+		SetTitle(panelTitle("cleanup.title")).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("ec2-cleanup-edit", centered(form, 64, 9), true, true)
+	// As an overlay the form gets Tab to move past the drop-down
+	rt.setOverlay(func() { rt.closeCleanupEdit(back) })
+	if rt.app != nil {
+		rt.app.SetFocus(form)
+	}
+}
+
+// closeCleanupEdit removes the form or confirmation of the cleanup and
+// returns focus to back
+func (rt *ResourcesTab) closeCleanupEdit(back tview.Primitive) {
+	rt.view.RemovePage("ec2-cleanup-edit")
+	rt.setOverlay(nil)
+	if rt.app != nil {
+		rt.app.SetFocus(back)
+	}
+}
+
+// cleanupPlan are the candidates a cleanup covers and why EC2 refused those
+// the dry run failed for
+type cleanupPlan struct {
+	days      int
+	images    []clients.UnusedImage
+	snapshots []clients.UnusedSnapshot
+	refused   map[string]string
+}
+
+// passed returns the candidates the dry run allowed; an image is left out
+// when it or one of its snapshots was refused
+func (p cleanupPlan) passed() ([]clients.UnusedImage, []clients.UnusedSnapshot) {
+	var images []clients.UnusedImage
+	var snapshots []clients.UnusedSnapshot
+	for _, image := range p.images {
+		if p.imageRefusal(image) == "" {
+			images = append(images, image)
+		}
+	}
+	for _, snapshot := range p.snapshots {
+		if p.refused[snapshot.ID] == "" {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return images, snapshots
+}
+
+// imageRefusal returns why the dry run refused image or one of its
+// snapshots, empty if it did not
+func (p cleanupPlan) imageRefusal(image clients.UnusedImage) string {
+	if reason := p.refused[image.ID]; reason != "" {
+		return reason
+	}
+	for _, snapshot := range image.Snapshots {
+		if reason := p.refused[snapshot]; reason != "" {
+			return fmt.Sprintf("%s: %s", snapshot, reason)
+		}
+	}
+	return ""
+}
+
+// dryRunCleanup looks up the candidates older than days of the kind chosen
+// from cleanupKinds again and shows the report of a dry run of their
+// cleanup
+func (rt *ResourcesTab) dryRunCleanup(client *aws.Client, days, kind int) {
+	rt.updateStatus(i18n.T("cleanup.dry_running"), "yellow")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+		defer cancel()
+
+		plan := cleanupPlan{days: days, refused: make(map[string]string)}
+		svc := client.GetClients()
+		found, err := findCleanupCandidates(ctx, svc)
+		if err != nil {
+			logger.Error("Failed to find cleanup candidates", zap.Error(err))
+			rt.afterCleanupChange(client, "", i18n.T("cleanup.find_failed"), err)
+			return
+		}
+
+		cutoff := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+		if kind != 2 {
+			for _, image := range found.Images {
+				if image.CreatedAt.Before(cutoff) {
+					plan.images = append(plan.images, image)
+				}
+			}
+		}
+		if kind != 1 {
+			for _, snapshot := range found.Snapshots {
+				if snapshot.StartedAt.Before(cutoff) {
+					plan.snapshots = append(plan.snapshots, snapshot)
+				}
+			}
+		}
+
+		if len(plan.images)+len(plan.snapshots) > 0 {
+			err = svc.EC2.CleanUp(ctx, plan.images, plan.snapshots, true)
+			var partial *clients.PartialError
+			switch {
+			case errors.As(err, &partial):
+				for _, failure := range partial.Failures {
+					plan.refused[failure.Item] = failure.Reason()
+				}
+			case err != nil:
+				logger.Error("Failed to run the cleanup dry run", zap.Error(err))
+				rt.afterCleanupChange(client, "", i18n.T("cleanup.dry_run_failed"), err)
+				return
+			}
+		}
+		if rt.app == nil {
+			return
+		}
+		rt.app.QueueUpdateDraw(func() {
+			if len(plan.images)+len(plan.snapshots) == 0 {
+				rt.updateStatus(i18n.T("cleanup.nothing_older", i18n.N("count.day", days)), "green")
+				return
+			}
+			rt.showCleanupReport(client, plan)
+		})
+	}()
+}
+
+// showCleanupReport shows the dry run report of plan over the tab. d cleans
+// up what passed the dry run and q closes the report.
+func (rt *ResourcesTab) showCleanupReport(client *aws.Client, plan cleanupPlan) {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("cleanup.report_title"))
+	view.SetText(renderCleanupReport(plan, client.GetRegion(), time.Now()))
+
+	closeReport := func() {
+		rt.view.RemovePage("ec2-cleanup")
+		if rt.app != nil {
+			rt.app.SetFocus(rt.resourceTable)
+		}
+	}
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			closeReport()
+			return nil
+		case 'd':
+			rt.confirmCleanup(client, plan, view, closeReport)
+			return nil
+		}
+		return event
+	})
+
+	rt.updateStatus(i18n.T("cleanup.dry_run_done",
+		i18n.N("count.ami", len(plan.images)), i18n.N("count.snapshot", len(plan.snapshots))), "green")
+	rt.view.AddPage("ec2-cleanup", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(view)
+	}
+}
+
+// renderCleanupReport lists the candidates of plan by age group, with their
+// size, what they cost and whether the dry run allowed their cleanup
+func renderCleanupReport(plan cleanupPlan, region string, now time.Time) string {
+	type line struct {
+		text   string
+		sizeGB int
+		cost   float64
+		image  bool
+	}
+	groups := make(map[string][]line)
+	for _, image := range plan.images {
+		cost, _ := pricing.EBSSnapshotMonthly("standard", image.SizeGB, region)
+		outcome := fmt.Sprintf("[green]deregister with %s[-]", pluralize(len(image.Snapshots), "snapshot"))
+		if reason := plan.imageRefusal(image); reason != "" {
+			outcome = "[red]refused, " + tview.Escape(reason) + "[-]"
+		} else if len(image.SharedWith) > 0 {
+			outcome += fmt.Sprintf(", [yellow]shared with %s, which may still launch it[-]", tview.Escape(strings.Join(image.SharedWith, ", ")))
+		}
+		group := cleanupAgeGroup(image.CreatedAt, now)
+		groups[group] = append(groups[group], line{
+			text:   fmt.Sprintf("  %-22s %-40s %6d GB  %s", image.ID, tview.Escape(clip(orDash(image.Name), 40)), image.SizeGB, outcome),
+			sizeGB: image.SizeGB, cost: cost, image: true,
+		})
+	}
+	for _, snapshot := range plan.snapshots {
+		cost, _ := pricing.EBSSnapshotMonthly(snapshot.StorageTier, snapshot.SizeGB, region)
+		outcome := "[green]delete[-]"
+		if reason := plan.refused[snapshot.ID]; reason != "" {
+			outcome = "[red]refused, " + tview.Escape(reason) + "[-]"
+		}
+		group := cleanupAgeGroup(snapshot.StartedAt, now)
+		groups[group] = append(groups[group], line{
+			text:   fmt.Sprintf("  %-22s %-40s %6d GB  %s", snapshot.ID, tview.Escape(clip(orDash(snapshot.Description), 40)), snapshot.SizeGB, outcome),
+			sizeGB: snapshot.SizeGB, cost: cost,
+		})
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "[yellow]Dry run of the cleanup of the unused AMIs and snapshots older than %s; nothing was changed[-]\n\n", pluralize(plan.days, "day"))
+	for _, group := range cleanupAgeGroups {
+		lines := groups[group.name]
+		if len(lines) == 0 {
+			continue
+		}
+		images, size := 0, 0
+		var cost float64
+		for _, l := range lines {
+			if l.image {
+				images++
+			}
+			size += l.sizeGB
+			cost += l.cost
+		}
+		fmt.Fprintf(&text, "[yellow]%s[-]: %s, %s, %d GB, $%.2f/month\n", group.name,
+			pluralize(images, "AMI"), pluralize(len(lines)-images, "snapshot"), size, cost)
+		for _, l := range lines {
+			text.WriteString(l.text + "\n")
+		}
+		text.WriteString("\n")
+	}
+
+	images, snapshots := plan.passed()
+	if len(images)+len(snapshots) == 0 {
+		text.WriteString("[red]EC2 refused the cleanup of every candidate[-]")
+		return text.String()
+	}
+	size := 0
+	for _, image := range images {
+		size += image.SizeGB
+	}
+	for _, snapshot := range snapshots {
+		size += snapshot.SizeGB
+	}
+	fmt.Fprintf(&text, "%s and %s (%d GB) would be cleaned up; press d to clean them up",
+		pluralize(len(images), "AMI"), pluralize(len(snapshots), "snapshot"), size)
+	if refused := len(plan.images) + len(plan.snapshots) - len(images) - len(snapshots); refused > 0 {
+		fmt.Fprintf(&text, ", the %d refused are kept", refused)
+	}
+	return text.String()
+}
+
+// clip shortens text to width runes, marking the cut with an ellipsis
+func clip(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// confirmCleanup asks before cleaning up what passed the dry run of plan,
+// closing the report once it is done
+func (rt *ResourcesTab) confirmCleanup(client *aws.Client, plan cleanupPlan, back tview.Primitive, done func()) {
+	images, snapshots := plan.passed()
+	if len(images)+len(snapshots) == 0 {
+		rt.updateStatus(i18n.T("cleanup.nothing_passed"), "yellow")
+		return
+	}
+	imageSnapshots := 0
+	for _, image := range images {
+		imageSnapshots += len(image.Snapshots)
+	}
+
+	modal := tview.NewModal().
+		SetText(i18n.T("dialog.cleanup",
+			i18n.N("count.ami", len(images)), i18n.N("count.snapshot", len(snapshots)+imageSnapshots))).
+		AddButtons([]string{i18n.T("dialog.clean_up"), i18n.T("common.cancel")}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			rt.closeCleanupEdit(back)
+			if buttonIndex == 0 {
+				done()
+				rt.runCleanup(client, images, snapshots)
+			}
+		})
+	rt.view.AddPage("ec2-cleanup-edit", modal, false, true)
+}
+
+// runCleanup deregisters images and deletes snapshots, records each in the
+// audit log and reloads the candidates when they are still shown
+func (rt *ResourcesTab) runCleanup(client *aws.Client, images []clients.UnusedImage, snapshots []clients.UnusedSnapshot) {
+	rt.updateStatus(i18n.T("cleanup.cleaning", i18n.N("count.ami", len(images)), i18n.N("count.snapshot", len(snapshots))), "yellow")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
+		defer cancel()
+
+		err := fmt.Errorf("EC2 service not initialized")
+		if svc := client.GetClients(); svc != nil && svc.EC2 != nil {
+			err = svc.EC2.CleanUp(ctx, images, snapshots, false)
+		}
+
+		failed := make(map[string]error)
+		var partial *clients.PartialError
+		if errors.As(err, &partial) {
+			for _, failure := range partial.Failures {
+				failed[failure.Item] = failure.Err
+			}
+		}
+		itemErr := func(id string) error {
+			if partial == nil {
+				return err
+			}
+			return failed[id]
+		}
+		for _, image := range images {
+			rt.audit.record(client, "ec2:DeregisterImage", image.ID, itemErr(image.ID))
+			for _, snapshot := range image.Snapshots {
+				rt.audit.record(client, "ec2:DeleteSnapshot", snapshot, itemErr(snapshot))
+			}
+		}
+		for _, snapshot := range snapshots {
+			rt.audit.record(client, "ec2:DeleteSnapshot", snapshot.ID, itemErr(snapshot.ID))
+		}
+
+		if err != nil {
+			logger.Error("Failed to clean up images and snapshots", zap.Error(err))
+		} else {
+			logger.Info("Cleaned up images and snapshots", zap.Int("images", len(images)), zap.Int("snapshots", len(snapshots)))
+		}
+		rt.afterCleanupChange(client, i18n.T("cleanup.done", i18n.N("count.ami", len(images)), i18n.N("count.snapshot", len(snapshots))),
+			i18n.T("cleanup.failed"), err)
+	}()
+}
+
+// afterCleanupChange reports the outcome of a cleanup step and reloads the
+// candidates when they are still shown and something changed
+func (rt *ResourcesTab) afterCleanupChange(client *aws.Client, success, failure string, err error) {
+	if rt.app == nil {
+		return
+	}
+	rt.app.QueueUpdateDraw(func() {
+		if success != "" && rt.awsClient == client && rt.selectedService == "ec2cleanup" {
+			rt.loadService("ec2cleanup", true)
+		}
+		if err != nil {
+			rt.updateStatus(fmt.Sprintf("%s: %s", failure, clients.ErrorReason(err)), "red")
+			return
+		}
+		rt.updateStatus(success, "green")
+	})
+}
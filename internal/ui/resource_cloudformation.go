@@ -0,0 +1,439 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// cloudFormationView lists the CloudFormation stacks by drift; Enter browses
+// the resources of a stack down its nested stacks and d detects drift anew
+type cloudFormationView struct{ baseView }
+
+var cloudFormationService = cloudFormationView{baseView{
+	info: ServiceInfo{Name: "cloudformation", DisplayName: "CloudFormation", Icon: "📚", Label: "CFN", Enabled: true, Permission: "cloudformation:DescribeStacks"},
+	noun: "stack",
+}}
+
+// ConsoleURL links the drifts of the stack
+func (cloudFormationView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/cloudformation/home?%s#/stacks/drifts?stackId=%s", base, query, url.QueryEscape(fmt.Sprint(res.Details["Stack ID"])))
+}
+
+// StateColor colors the drift of the stacks
+func (cloudFormationView) StateColor(state string) tcell.Color {
+	return stateColor(stackDriftStateColors, state)
+}
+
+// stackDriftStateColors color the drift of stacks and StackSets in words
+var stackDriftStateColors = map[string]tcell.Color{
+	"in sync": tcell.ColorGreen,
+	"drifted": tcell.ColorRed,
+}
+
+// Load lists the stacks
+func (cloudFormationView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadStacks(ctx, client)
+}
+
+// Summary counts the drifted stacks
+func (cloudFormationView) Summary(resources []Resource, failed int) (string, string) {
+	return stacksSummary(resources, failed)
+}
+
+// Open browses the resources of the stack, with the stacks it is nested in
+// on the trail
+func (cloudFormationView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showStackResources(stackTrail(rt.filteredRes, resource), rt.resourceTable)
+}
+
+// Actions detects drift
+func (cloudFormationView) Actions() []resourceAction {
+	return []resourceAction{
+		{name: "cloudformation drift", key: 'd', description: "action.cloudformation_drift",
+			onResource: true, online: true, run: (*ResourcesTab).onStackDrift},
+	}
+}
+
+// stackDriftTimeout is how long a drift detection may take; CloudFormation
+// checks every resource of the stack, which takes minutes for large stacks
+const stackDriftTimeout = 10 * time.Minute
+
+// loadStacks lists the CloudFormation stacks of the region with their drift
+// as of the last detection. Drifted stacks and stacks a change failed on are
+// flagged.
+func (rt *ResourcesTab) loadStacks(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.CloudFormation == nil {
+		return nil, fmt.Errorf("CloudFormation service not initialized")
+	}
+
+	stacks, err := svc.CloudFormation.ListStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(stacks))
+	for _, stack := range stacks {
+		resources = append(resources, stackResource(stack, client.GetRegion()))
+	}
+	return resources, nil
+}
+
+// stackResource describes stack of region
+func stackResource(stack clients.Stack, region string) Resource {
+	res := Resource{
+		ID:          stack.Name,
+		Name:        stack.Name,
+		Type:        "CloudFormation Stack",
+		State:       driftWords(stack.DriftStatus),
+		Region:      region,
+		CreatedDate: stack.CreatedAt.Format("2006-01-02 15:04:05"),
+		Tags:        make(map[string]string),
+		Details: map[string]interface{}{
+			"Stack ID":     stack.ID,
+			"Stack Status": stack.Status,
+			"View":         "press Enter for the resources of the stack, d to detect drift",
+		},
+	}
+	if stack.ParentID != "" {
+		res.Type = "Nested Stack"
+		res.Details["Parent Stack"] = stack.ParentID
+		if stack.RootID != stack.ParentID {
+			res.Details["Root Stack"] = stack.RootID
+		}
+	}
+	if stack.Reason != "" {
+		res.Details["Status Reason"] = stack.Reason
+	}
+	if stack.Description != "" {
+		res.Details["Description"] = stack.Description
+	}
+	if !stack.UpdatedAt.IsZero() {
+		res.Details["Last Updated"] = zonedTime(stack.UpdatedAt, "2006-01-02 15:04")
+	}
+	if stack.DriftCheckedAt.IsZero() {
+		res.Details["Drift Checked"] = "never"
+	} else {
+		res.Details["Drift Checked"] = fmt.Sprintf("%s (%s ago)", zonedTime(stack.DriftCheckedAt, "2006-01-02 15:04"), workloadAge(stack.DriftCheckedAt))
+	}
+
+	switch {
+	case stack.DriftStatus == "DRIFTED":
+		res.Alert = true
+		res.Details["Flag"] = "resources were changed outside CloudFormation"
+	case strings.HasSuffix(stack.Status, "_FAILED") || strings.Contains(stack.Status, "ROLLBACK"):
+		res.Alert = true
+		res.Details["Flag"] = "the last change of the stack failed"
+	}
+	return res
+}
+
+// driftWords renders a drift status of a stack or resource in words, e.g.
+// "not checked" for NOT_CHECKED
+func driftWords(status string) string {
+	if status == "" {
+		return "not checked"
+	}
+	return statusWords(status)
+}
+
+// statusWords renders a CloudFormation status in words, e.g. "update
+// complete" for UPDATE_COMPLETE, "-" for none
+func statusWords(status string) string {
+	if status == "" {
+		return "-"
+	}
+	return strings.ToLower(strings.ReplaceAll(status, "_", " "))
+}
+
+// stacksSummary counts the drifted stacks and the stacks whose drift was
+// never checked
+func stacksSummary(resources []Resource, failed int) (string, string) {
+	drifted, unchecked := 0, 0
+	for _, res := range resources {
+		switch res.State {
+		case "drifted":
+			drifted++
+		case "not checked":
+			unchecked++
+		}
+	}
+
+	message := fmt.Sprintf("%d stacks, %d drifted, %d never checked for drift", len(resources), drifted, unchecked)
+	switch {
+	case drifted > 0:
+		return message, "red"
+	case failed > 0 || unchecked > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// onStackDrift detects the drift of the selected stack
+func (rt *ResourcesTab) onStackDrift() {
+	if rt.selectedService != "cloudformation" || rt.selectedRes == nil {
+		return
+	}
+	rt.showStackDrift(rt.selectedRes.Name, true)
+}
+
+// showStackDrift shows the resources of stack as its last drift detection
+// found them over the tab, drifted ones first, with the property
+// differences of the selected resource below: ADD for properties set outside
+// the template, REMOVE for ones removed and NOT_EQUAL for ones changed. With
+// detect it first detects drift and polls until CloudFormation finished. d
+// detects drift, r reloads and q closes the panel.
+func (rt *ResourcesTab) showStackDrift(stack string, detect bool) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	// The resources of the last detection, nil until loaded
+	var drifts []clients.ResourceDrift
+	loads := 0
+	detecting := false
+
+	notice := tview.NewTextView().SetDynamicColors(true)
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	differences := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	differences.SetBorder(true).SetTitleAlign(tview.AlignLeft).SetTitle(panelTitle("cloudformation.differences_title"))
+	panel := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(notice, 2, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(differences, 0, 1, false)
+	panel.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("cloudformation.drift_title", stack))
+
+	setNotice := func(message, color string) {
+		notice.SetText(fmt.Sprintf("[%s]%s%s[-]", color, stateWord(color), tview.Escape(message)))
+	}
+
+	table.SetSelectionChangedFunc(func(row, _ int) {
+		if row < 1 || row > len(drifts) {
+			differences.SetText("")
+			return
+		}
+		differences.SetText(renderPropertyDifferences(drifts[row-1])).ScrollToBeginning()
+	})
+
+	load := func() {
+		table.Clear()
+		differences.SetText("")
+		table.SetCell(0, 0, tview.NewTableCell(i18n.T("common.loading")).SetTextColor(tcell.ColorGray).SetSelectable(false))
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var loaded []clients.ResourceDrift
+			err := fmt.Errorf("CloudFormation service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.CloudFormation != nil {
+				loaded, err = svc.CloudFormation.ListResourceDrifts(ctx, stack)
+			}
+			if err != nil {
+				logger.Error("Failed to read resource drifts", zap.String("stack", stack), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					setTableMessage(table, i18n.T("cloudformation.drift_failed", stack, clients.ErrorReason(err)), tcell.ColorRed)
+					return
+				}
+				drifts = loaded
+				renderResourceDrifts(table, drifts)
+				if len(drifts) > 0 {
+					differences.SetText(renderPropertyDifferences(drifts[0]))
+				}
+			})
+		}()
+	}
+
+	// run detects the drift of the stack, polls until CloudFormation is
+	// done, records it and reloads the panel
+	run := func() {
+		if detecting {
+			return
+		}
+		detecting = true
+		setNotice(fmt.Sprintf("Detecting the drift of %s, this takes a minute for large stacks...", stack), "gray")
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), stackDriftTimeout)
+			defer cancel()
+
+			var detection clients.DriftDetection
+			err := fmt.Errorf("CloudFormation service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.CloudFormation != nil {
+				var id string
+				id, err = svc.CloudFormation.DetectDrift(ctx, stack)
+				rt.audit.record(client, "cloudformation:DetectStackDrift", stack, err)
+				if err == nil {
+					detection, err = svc.CloudFormation.WaitForDriftDetection(ctx, id)
+				}
+			}
+			if err != nil {
+				logger.Error("Failed to detect stack drift", zap.String("stack", stack), zap.Error(err))
+			} else {
+				logger.Info("Detected stack drift", zap.String("stack", stack), zap.String("status", detection.Status),
+					zap.String("drift", detection.StackDriftStatus), zap.Int("drifted_resources", detection.DriftedResources))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				detecting = false
+				switch {
+				case err != nil:
+					setNotice(fmt.Sprintf("Could not detect the drift of %s: %s", stack, clients.ErrorReason(err)), "red")
+					return
+				case detection.Status == "DETECTION_FAILED":
+					setNotice(fmt.Sprintf("Drift detection of %s failed, the results are partial: %s", stack, detection.Reason), "yellow")
+				case detection.StackDriftStatus == "DRIFTED":
+					setNotice(fmt.Sprintf("%s drifted: %d resources differ from the template", stack, detection.DriftedResources), "red")
+				default:
+					setNotice(fmt.Sprintf("%s is in sync with its template", stack), "green")
+				}
+				load()
+				// The listing shows the drift found
+				if rt.awsClient == client && rt.selectedService == "cloudformation" {
+					rt.loadService("cloudformation", true)
+				}
+			})
+		}()
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeStackDrift()
+			return nil
+		case 'r':
+			load()
+			return nil
+		case 'd':
+			run()
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("cloudformation-drift", panel, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(table)
+	}
+	if detect {
+		run()
+		return
+	}
+	setNotice("Showing the last drift detection; press d to detect drift anew", "gray")
+	load()
+}
+
+// closeStackDrift removes the drift panel and returns focus to the stack
+// browser it opened from, or to the table
+func (rt *ResourcesTab) closeStackDrift() {
+	rt.view.RemovePage("cloudformation-drift")
+	if rt.app == nil {
+		return
+	}
+	if rt.stackBrowser != nil {
+		rt.app.SetFocus(rt.stackBrowser.table)
+		return
+	}
+	rt.app.SetFocus(rt.resourceTable)
+}
+
+// resourceDriftColors color the drift statuses of resources
+var resourceDriftColors = map[string]tcell.Color{
+	"DELETED":     tcell.ColorRed,
+	"MODIFIED":    tcell.ColorYellow,
+	"IN_SYNC":     tcell.ColorGreen,
+	"NOT_CHECKED": tcell.ColorGray,
+}
+
+// renderResourceDrifts lists the resources of a stack with their drift
+func renderResourceDrifts(table *tview.Table, drifts []clients.ResourceDrift) {
+	if len(drifts) == 0 {
+		setTableMessage(table, i18n.T("cloudformation.no_drift"), tcell.ColorGray)
+		return
+	}
+
+	table.Clear()
+	for col, header := range []string{"Logical ID", "Type", "Physical ID", "Drift", "Differences"} {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, drift := range drifts {
+		row := i + 1
+		color, ok := resourceDriftColors[drift.Status]
+		if !ok {
+			color = tcell.ColorWhite
+		}
+		diffs := "-"
+		if len(drift.Differences) > 0 {
+			diffs = fmt.Sprint(len(drift.Differences))
+		}
+		table.SetCell(row, 0, tview.NewTableCell(tview.Escape(drift.LogicalID)).SetTextColor(color))
+		table.SetCell(row, 1, tview.NewTableCell(drift.Type))
+		table.SetCell(row, 2, tview.NewTableCell(tview.Escape(drift.PhysicalID)).SetMaxWidth(48).SetExpansion(1))
+		table.SetCell(row, 3, tview.NewTableCell(driftWords(drift.Status)).SetTextColor(color))
+		table.SetCell(row, 4, tview.NewTableCell(diffs).SetAlign(tview.AlignRight))
+	}
+	table.Select(1, 0).ScrollToBeginning()
+}
+
+// renderPropertyDifferences describes how drift differs from the template,
+// a line per value: green for what was added outside the template, red for
+// what was removed, the expected value of a changed property in red and the
+// actual one in green
+func renderPropertyDifferences(drift clients.ResourceDrift) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[::b]%s[::-] (%s)\n", tview.Escape(drift.LogicalID), drift.Type)
+	switch {
+	case drift.Status == "DELETED":
+		b.WriteString("[red]The resource was deleted outside CloudFormation[-]\n")
+	case drift.Status == "NOT_CHECKED":
+		b.WriteString("[gray]CloudFormation cannot detect the drift of this resource type[-]\n")
+	case len(drift.Differences) == 0:
+		b.WriteString("[green]The resource matches its template[-]\n")
+	}
+
+	for _, diff := range drift.Differences {
+		path := tview.Escape(diff.Path)
+		switch diff.Type {
+		case "ADD":
+			fmt.Fprintf(&b, "\n[green]ADD[-]       %s\n  [green]+ actual:   %s[-]\n", path, tview.Escape(diff.Actual))
+		case "REMOVE":
+			fmt.Fprintf(&b, "\n[red]REMOVE[-]    %s\n  [red]- expected: %s[-]\n", path, tview.Escape(diff.Expected))
+		default:
+			fmt.Fprintf(&b, "\n[yellow]%s[-] %s\n  [red]- expected: %s[-]\n  [green]+ actual:   %s[-]\n",
+				tview.Escape(fmt.Sprintf("%-9s", diff.Type)), path, tview.Escape(diff.Expected), tview.Escape(diff.Actual))
+		}
+	}
+	return b.String()
+}
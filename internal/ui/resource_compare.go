@@ -0,0 +1,262 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// markedPrefix marks the name of the resource marked for comparison
+const markedPrefix = "◆ "
+
+// attributeDiff is an attribute of two compared resources with its values,
+// "" where a resource does not have it
+type attributeDiff struct {
+	name  string
+	left  string
+	right string
+}
+
+// differs reports whether the two values of the attribute differ
+func (d attributeDiff) differs() bool {
+	return d.left != d.right
+}
+
+func init() {
+	registerResourceActions(resourceAction{name: "compare", key: 'm',
+		description: "action.compare",
+		onResource:  true, run: (*ResourcesTab).onMarkResource})
+}
+
+// onMarkResource marks the selected resource for comparison, or compares it
+// with the resource marked before. Marking the marked resource again
+// unmarks it.
+func (rt *ResourcesTab) onMarkResource() {
+	if rt.selectedRes == nil {
+		return
+	}
+	selected := *rt.selectedRes
+
+	switch {
+	case rt.marked == nil || rt.markedService != rt.shownService:
+		rt.marked = &selected
+		rt.markedService = rt.shownService
+		rt.updateStatus(i18n.T("compare.marked", selected.Name), "green")
+	case rt.marked.ID == selected.ID:
+		rt.marked = nil
+		rt.updateStatus(i18n.T("compare.unmarked", selected.Name), "green")
+	default:
+		// The listing may have been reloaded since the first one was marked
+		left := *rt.marked
+		for _, res := range rt.filteredRes {
+			if res.ID == left.ID {
+				left = res
+				break
+			}
+		}
+		rt.marked = nil
+		rt.showComparison(left, selected)
+	}
+	rt.applyFilter()
+}
+
+// showComparison shows the attributes of left and right side by side over
+// the tab, only the differing ones until a toggles all. q closes it.
+func (rt *ResourcesTab) showComparison(left, right Resource) {
+	diffs := compareResources(left, right)
+	differing := 0
+	for _, d := range diffs {
+		if d.differs() {
+			differing++
+		}
+	}
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 1).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	all := false
+	fill := func() {
+		table.Clear()
+		for col, name := range []string{columnTitle("Attribute"), left.Name, right.Name} {
+			table.SetCell(0, col, tview.NewTableCell(name).
+				SetTextColor(tcell.ColorYellow).
+				SetSelectable(false).
+				SetAttributes(tcell.AttrBold))
+		}
+
+		row := 1
+		for _, d := range diffs {
+			if !all && !d.differs() {
+				continue
+			}
+			color := tcell.ColorGray
+			if d.differs() {
+				color = tcell.ColorWhite
+			}
+			table.SetCell(row, 0, tview.NewTableCell(d.name).SetTextColor(tcell.ColorAqua))
+			table.SetCell(row, 1, tview.NewTableCell(orDash(d.left)).SetTextColor(color).SetMaxWidth(60).SetExpansion(1))
+			table.SetCell(row, 2, tview.NewTableCell(orDash(d.right)).SetTextColor(color).SetMaxWidth(60).SetExpansion(1))
+			if d.differs() {
+				table.GetCell(row, 0).SetAttributes(tcell.AttrBold)
+			}
+			row++
+		}
+		if row == 1 {
+			table.SetCell(1, 0, tview.NewTableCell(i18n.T("compare.none")).SetTextColor(tcell.ColorGreen).SetSelectable(false))
+		}
+
+		shown := i18n.T("compare.differences_only")
+		if all {
+			shown = i18n.T("compare.all_attributes")
+		}
+		table.SetTitle(panelTitle("compare.title",
+			left.Name, right.Name, differing, len(diffs), shown))
+		table.ScrollToBeginning()
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeComparison()
+			return nil
+		case 'a':
+			all = !all
+			fill()
+			return nil
+		}
+		return event
+	})
+
+	fill()
+	rt.view.AddPage("compare", table, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(table)
+	}
+}
+
+// closeComparison removes the comparison view
+func (rt *ResourcesTab) closeComparison() {
+	rt.view.RemovePage("compare")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// compareResources pairs the attributes of left and right by name, sorted
+func compareResources(left, right Resource) []attributeDiff {
+	leftAttrs := flattenResource(left)
+	rightAttrs := flattenResource(right)
+
+	names := make([]string, 0, len(leftAttrs)+len(rightAttrs))
+	for name := range leftAttrs {
+		names = append(names, name)
+	}
+	for name := range rightAttrs {
+		if _, ok := leftAttrs[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	diffs := make([]attributeDiff, len(names))
+	for i, name := range names {
+		diffs[i] = attributeDiff{name: name, left: leftAttrs[name], right: rightAttrs[name]}
+	}
+	return diffs
+}
+
+// flattenResource returns the attributes of res by path, e.g.
+// "Tags.env" or "SecurityGroups[0].GroupName". Name and ID are left out,
+// they always differ.
+func flattenResource(res Resource) map[string]string {
+	attrs := map[string]string{
+		"Type":    res.Type,
+		"State":   res.State,
+		"Region":  res.Region,
+		"Created": createdTime(res.CreatedDate),
+	}
+	if res.MonthlyCost > 0 {
+		attrs["Cost/mo"] = formatMonthlyCost(res.MonthlyCost)
+	}
+	for key, value := range res.Tags {
+		if key == "Name" {
+			continue
+		}
+		attrs["Tags."+key] = value
+	}
+	for key, value := range res.Details {
+		flattenValue(attrs, key, value)
+	}
+	for name, value := range attrs {
+		if value == "" {
+			delete(attrs, name)
+		}
+	}
+	return attrs
+}
+
+// flattenValue adds value under path to attrs, descending into maps, slices
+// and structs through their JSON form
+func flattenValue(attrs map[string]string, path string, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		return
+	case string:
+		attrs[path] = v
+		return
+	case bool, int, int32, int64, float32, float64:
+		attrs[path] = fmt.Sprint(v)
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		attrs[path] = fmt.Sprint(value)
+		return
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		attrs[path] = string(data)
+		return
+	}
+	flattenJSON(attrs, path, generic)
+}
+
+// flattenJSON adds the decoded JSON value under path to attrs
+func flattenJSON(attrs map[string]string, path string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			flattenJSON(attrs, path+"."+key, item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			flattenJSON(attrs, fmt.Sprintf("%s[%d]", path, i), item)
+		}
+	case nil:
+	case string:
+		attrs[path] = v
+	case float64:
+		attrs[path] = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		attrs[path] = fmt.Sprint(v)
+	}
+}
+
+// orDash shows a missing value as a dash
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,393 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/dashboard"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// concurrencyWindow is how far back the concurrency metrics of a function go
+const concurrencyWindow = 3 * time.Hour
+
+// concurrencyPeriod is the period of the concurrency metrics, in seconds
+const concurrencyPeriod = 300
+
+// onLambdaConcurrency opens the concurrency panel of the selected function
+func (rt *ResourcesTab) onLambdaConcurrency() {
+	if rt.selectedService != "lambda" || rt.selectedRes == nil {
+		return
+	}
+	rt.showConcurrency(rt.selectedRes.Name)
+}
+
+// showConcurrency shows the reserved and provisioned concurrency of
+// functionName with its recent concurrency and throttles over the tab. c sets
+// the reserved concurrency, a the provisioned concurrency of an alias, r
+// reloads and q closes the panel.
+func (rt *ResourcesTab) showConcurrency(functionName string) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	// The latest settings, nil until loaded
+	var current *clients.LambdaConcurrency
+	// notice is the outcome of the last change, shown above the settings
+	notice := ""
+	loads := 0
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("concurrency.title", functionName))
+
+	var load func()
+	load = func() {
+		view.SetText(notice + "[gray]" + i18n.T("common.loading") + "[-]")
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			concurrency, series, err := fetchConcurrency(ctx, client, functionName)
+			if err != nil {
+				logger.Error("Failed to load Lambda concurrency", zap.String("function", functionName), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					view.SetText(notice + "[red]" + i18n.T("concurrency.load_failed", functionName, tview.Escape(err.Error())) + "[-]")
+					return
+				}
+				current = &concurrency
+				view.SetText(notice + renderConcurrency(concurrency, series, dashboardSparkWidth)).ScrollToBeginning()
+			})
+		}()
+	}
+
+	// apply runs change in the background, records it and reloads the panel
+	apply := func(action, resource, done string, change func(ctx context.Context, svc aws.LambdaService) error) {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			err := fmt.Errorf("lambda service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.Lambda != nil {
+				err = change(ctx, svc.Lambda)
+			}
+			rt.audit.record(client, action, resource, err)
+			if err != nil {
+				logger.Error("Failed to change Lambda concurrency", zap.String("action", action), zap.String("function", resource), zap.Error(err))
+			} else {
+				logger.Info("Changed Lambda concurrency", zap.String("action", action), zap.String("function", resource))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if err != nil {
+					notice = fmt.Sprintf("[red]%s[-]\n\n", tview.Escape(err.Error()))
+				} else {
+					notice = fmt.Sprintf("[green]%s[-]\n\n", done)
+				}
+				load()
+			})
+		}()
+	}
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeConcurrency()
+			return nil
+		case 'r':
+			notice = ""
+			load()
+			return nil
+		case 'c':
+			if current != nil {
+				rt.editReservedConcurrency(functionName, current, view, apply)
+			}
+			return nil
+		case 'a':
+			if current == nil {
+				return nil
+			}
+			if len(current.Aliases) == 0 {
+				notice = "[yellow]" + i18n.T("concurrency.no_aliases", functionName) + "[-]\n\n"
+				load()
+				return nil
+			}
+			rt.editProvisionedConcurrency(functionName, current, view, apply)
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("lambda-concurrency", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(view)
+	}
+	load()
+}
+
+// concurrencyChange applies a concurrency change of a function with the
+// audit action and resource, and the message shown once it succeeded
+type concurrencyChange func(action, resource, done string, change func(ctx context.Context, svc aws.LambdaService) error)
+
+// editReservedConcurrency asks for the reserved concurrency of functionName.
+// An empty value removes the reservation.
+func (rt *ResourcesTab) editReservedConcurrency(functionName string, current *clients.LambdaConcurrency, panel tview.Primitive, apply concurrencyChange) {
+	value := ""
+	if current.Reserved != nil {
+		value = strconv.Itoa(int(*current.Reserved))
+	}
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("concurrency.reserved"), value, 10, tview.InputFieldInteger, func(text string) { value = text })
+	form.AddButton(i18n.T("common.save"), func() {
+		rt.closeConcurrencyEdit(panel)
+		if strings.TrimSpace(value) == "" {
+			apply("lambda:DeleteFunctionConcurrency", functionName, i18n.T("concurrency.unreserved"),
+				func(ctx context.Context, svc aws.LambdaService) error {
+					return svc.DeleteReservedConcurrency(ctx, functionName)
+				})
+			return
+		}
+		executions, err := strconv.Atoi(value)
+		if err != nil || executions < 0 {
+			rt.updateStatus(i18n.T("concurrency.bad_reserved"), "red")
+			return
+		}
+		apply("lambda:PutFunctionConcurrency", functionName, i18n.T("concurrency.reserved_set", executions),
+			func(ctx context.Context, svc aws.LambdaService) error {
+				return svc.PutReservedConcurrency(ctx, functionName, int32(executions))
+			})
+	})
+	form.AddButton(i18n.T("common.cancel"), func() { rt.closeConcurrencyEdit(panel) })
+	form.SetBorder(true).
+		SetTitle(panelTitle("concurrency.reserved_title")).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("lambda-concurrency-edit", centered(form, 64, 7), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(form)
+	}
+}
+
+// editProvisionedConcurrency lists the aliases of functionName with their
+// provisioned concurrency and asks for the executions of the chosen one
+func (rt *ResourcesTab) editProvisionedConcurrency(functionName string, current *clients.LambdaConcurrency, panel tview.Primitive, apply concurrencyChange) {
+	provisioned := make(map[string]int32, len(current.Provisioned))
+	for _, p := range current.Provisioned {
+		provisioned[p.Qualifier] = p.Requested
+	}
+
+	list := tview.NewList().
+		SetMainTextColor(tcell.ColorWhite).
+		SetSelectedTextColor(tcell.ColorBlack).
+		SetSelectedBackgroundColor(tcell.ColorWhite).
+		ShowSecondaryText(false)
+	for _, alias := range current.Aliases {
+		label := alias
+		if executions, ok := provisioned[alias]; ok {
+			label = i18n.T("concurrency.alias_provisioned", alias, executions)
+		}
+		list.AddItem(label, "", 0, nil)
+	}
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		alias := current.Aliases[index]
+		_, exists := provisioned[alias]
+		rt.editAliasConcurrency(functionName, alias, provisioned[alias], exists, panel, apply)
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' {
+			rt.closeConcurrencyEdit(panel)
+			return nil
+		}
+		return event
+	})
+	list.SetBorder(true).
+		SetTitle(panelTitle("concurrency.alias_title")).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("lambda-concurrency-edit", centered(list, 64, min(len(current.Aliases), 10)+2), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(list)
+	}
+}
+
+// editAliasConcurrency asks for the provisioned concurrency of alias. Zero
+// removes the provisioned concurrency when the alias has one.
+func (rt *ResourcesTab) editAliasConcurrency(functionName, alias string, executions int32, exists bool, panel tview.Primitive, apply concurrencyChange) {
+	value := strconv.Itoa(int(executions))
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("concurrency.executions"), value, 10, tview.InputFieldInteger, func(text string) { value = text })
+	form.AddButton(i18n.T("common.save"), func() {
+		rt.closeConcurrencyEdit(panel)
+		executions, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || executions < 0 {
+			rt.updateStatus(i18n.T("concurrency.bad_provisioned"), "red")
+			return
+		}
+		resource := functionName + ":" + alias
+		if executions == 0 {
+			if !exists {
+				return
+			}
+			apply("lambda:DeleteProvisionedConcurrencyConfig", resource, i18n.T("concurrency.unprovisioned", alias),
+				func(ctx context.Context, svc aws.LambdaService) error {
+					return svc.DeleteProvisionedConcurrency(ctx, functionName, alias)
+				})
+			return
+		}
+		apply("lambda:PutProvisionedConcurrencyConfig", resource, i18n.T("concurrency.provisioned_set", alias, executions),
+			func(ctx context.Context, svc aws.LambdaService) error {
+				return svc.PutProvisionedConcurrency(ctx, functionName, alias, int32(executions))
+			})
+	})
+	form.AddButton(i18n.T("common.cancel"), func() { rt.closeConcurrencyEdit(panel) })
+	form.SetBorder(true).
+		SetTitle(panelTitle("concurrency.provisioned_title", alias)).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("lambda-concurrency-edit", centered(form, 64, 7), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(form)
+	}
+}
+
+// closeConcurrencyEdit removes the concurrency form and returns focus to panel
+func (rt *ResourcesTab) closeConcurrencyEdit(panel tview.Primitive) {
+	rt.view.RemovePage("lambda-concurrency-edit")
+	if rt.app != nil {
+		rt.app.SetFocus(panel)
+	}
+}
+
+// closeConcurrency removes the concurrency panel and returns focus to the table
+func (rt *ResourcesTab) closeConcurrency() {
+	rt.view.RemovePage("lambda-concurrency")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// fetchConcurrency returns the concurrency settings of functionName and its
+// ConcurrentExecutions and Throttles over the last concurrencyWindow
+func fetchConcurrency(ctx context.Context, client *aws.Client, functionName string) (clients.LambdaConcurrency, []clients.MetricSeries, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.Lambda == nil || svc.CloudWatch == nil {
+		return clients.LambdaConcurrency{}, nil, fmt.Errorf("lambda service not initialized")
+	}
+
+	concurrency, err := svc.Lambda.GetConcurrency(ctx, functionName)
+	if err != nil {
+		return clients.LambdaConcurrency{}, nil, err
+	}
+
+	dimensions := []clients.MetricDimension{{Name: "FunctionName", Value: functionName}}
+	end := time.Now()
+	series, err := svc.CloudWatch.GetMetricSeries(ctx, []clients.MetricQuery{
+		{Namespace: "AWS/Lambda", Metric: "ConcurrentExecutions", Dimensions: dimensions, Stat: "Maximum", Period: concurrencyPeriod},
+		{Namespace: "AWS/Lambda", Metric: "Throttles", Dimensions: dimensions, Stat: "Sum", Period: concurrencyPeriod},
+	}, end.Add(-concurrencyWindow), end)
+	if err != nil {
+		return clients.LambdaConcurrency{}, nil, err
+	}
+	return concurrency, series, nil
+}
+
+// renderConcurrency describes the concurrency settings and draws the
+// concurrent executions and throttles in series as sparklines of width
+func renderConcurrency(concurrency clients.LambdaConcurrency, series []clients.MetricSeries, width int) string {
+	var text strings.Builder
+
+	pool := fmt.Sprintf("%d of %d unreserved in the account", concurrency.AccountUnreserved, concurrency.AccountLimit)
+	if concurrency.Reserved == nil {
+		fmt.Fprintf(&text, "[yellow]Reserved concurrency:[-] none, shares the %s\n", pool)
+	} else {
+		fmt.Fprintf(&text, "[yellow]Reserved concurrency:[-] %d (%s)\n", *concurrency.Reserved, pool)
+		if *concurrency.Reserved == 0 {
+			text.WriteString("  [red]Every invocation is throttled[-]\n")
+		}
+	}
+
+	text.WriteString("\n[yellow]Provisioned concurrency:[-]\n")
+	if len(concurrency.Provisioned) == 0 {
+		text.WriteString("  none\n")
+	}
+	for _, p := range concurrency.Provisioned {
+		color := "green"
+		switch p.Status {
+		case "IN_PROGRESS":
+			color = "yellow"
+		case "FAILED":
+			color = "red"
+		}
+		fmt.Fprintf(&text, "  %-12s %d requested, %d allocated  [%s]%s[-]", p.Qualifier, p.Requested, p.Allocated, color, p.Status)
+		if p.StatusReason != "" {
+			fmt.Fprintf(&text, " %s", tview.Escape(p.StatusReason))
+		}
+		text.WriteString("\n")
+	}
+
+	aliases := "none"
+	if len(concurrency.Aliases) > 0 {
+		aliases = strings.Join(concurrency.Aliases, ", ")
+	}
+	fmt.Fprintf(&text, "\n[yellow]Aliases:[-] %s\n", aliases)
+
+	fmt.Fprintf(&text, "\n[yellow]Last %s[-]\n", formatRange(concurrencyWindow))
+	labels := []string{"Concurrent executions (max)", "Throttles (sum)"}
+	for i, label := range labels {
+		if i >= len(series) || len(series[i].Values) == 0 {
+			fmt.Fprintf(&text, "%-28s [gray]no data[-]\n", label)
+			continue
+		}
+
+		values := series[i].Values
+		var peak, total float64
+		for _, v := range values {
+			peak = max(peak, v)
+			total += v
+		}
+		latest := values[len(values)-1]
+
+		color := "green"
+		summary := fmt.Sprintf("latest %g, peak %g", latest, peak)
+		if i == 1 {
+			summary = fmt.Sprintf("latest %g, total %g", latest, total)
+			if total > 0 {
+				color = "red"
+			}
+		} else if concurrency.Reserved != nil && *concurrency.Reserved > 0 && peak >= float64(*concurrency.Reserved) {
+			color = "red"
+			summary += ", reached the reserved concurrency"
+		}
+		fmt.Fprintf(&text, "%-28s [%s]%s[-]  %s\n", label, color, dashboard.Sparkline(values, width), summary)
+	}
+	return text.String()
+}
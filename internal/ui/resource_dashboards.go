@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/dashboard"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// dashboardsView lists the CloudWatch dashboards; Enter shows one
+type dashboardsView struct{ baseView }
+
+var dashboardsService = dashboardsView{baseView{
+	info: ServiceInfo{Name: "dashboards", DisplayName: "CW Dashboards", Icon: "📊", Label: "CWD", Enabled: true, Permission: "cloudwatch:ListDashboards"},
+}}
+
+// Load lists the dashboards
+func (dashboardsView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadDashboards(ctx, client)
+}
+
+// Open renders the dashboard
+func (dashboardsView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showDashboard(resource.ID)
+}
+
+// dashboardRanges are the time ranges a dashboard can show, cycled with t
+var dashboardRanges = []time.Duration{3 * time.Hour, 12 * time.Hour, 24 * time.Hour, 7 * 24 * time.Hour, time.Hour}
+
+// dashboardSparkWidth is the width of the sparklines of a dashboard
+const dashboardSparkWidth = 48
+
+// loadDashboards lists the CloudWatch dashboards of the account
+func (rt *ResourcesTab) loadDashboards(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.CloudWatch == nil {
+		return nil, fmt.Errorf("CloudWatch service not initialized")
+	}
+
+	dashboards, err := svc.CloudWatch.ListDashboards(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(dashboards))
+	for _, d := range dashboards {
+		resources = append(resources, Resource{
+			ID:          d.Name,
+			Name:        d.Name,
+			Type:        "CloudWatch Dashboard",
+			State:       "available",
+			Region:      "global",
+			CreatedDate: d.LastModified.Format("2006-01-02 15:04:05"),
+			Tags:        make(map[string]string),
+			Details: map[string]interface{}{
+				"ARN":  d.ARN,
+				"Size": fmt.Sprintf("%d bytes", d.Size),
+				"View": "press Enter to render",
+			},
+		})
+	}
+	return resources, nil
+}
+
+// showDashboard renders the dashboard name over the tab and loads its data in
+// the background. The view closes with q.
+func (rt *ResourcesTab) showDashboard(name string) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	rangeIndex := 0
+	// Only the latest load is shown when the range changes while loading
+	loads := 0
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	load := func() {
+		period := dashboardRanges[rangeIndex]
+		view.SetTitle(panelTitle("dashboards.title", name, formatRange(period)))
+		view.SetText("[gray]" + i18n.T("common.loading") + "[-]")
+
+		loads++
+		gen := loads
+		go func() {
+			text := renderDashboard(client, name, period)
+			if rt.app != nil {
+				rt.app.QueueUpdateDraw(func() {
+					if gen == loads {
+						view.SetText(text).ScrollToBeginning()
+					}
+				})
+			}
+		}()
+	}
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeDashboard()
+			return nil
+		case 'r':
+			load()
+			return nil
+		case 't':
+			rangeIndex = (rangeIndex + 1) % len(dashboardRanges)
+			load()
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("dashboard", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(view)
+	}
+	load()
+}
+
+// renderDashboard fetches the body and data of the dashboard name and returns
+// it rendered, or why it could not be loaded
+func renderDashboard(client *aws.Client, name string, period time.Duration) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	text, err := fetchDashboardText(ctx, client, name, period)
+	if err != nil {
+		logger.Error("Failed to render dashboard", zap.String("dashboard", name), zap.Error(err))
+		return fmt.Sprintf("[red]Could not load dashboard %s: %s[-]", name, tview.Escape(err.Error()))
+	}
+	return text
+}
+
+// fetchDashboardText returns the rendered dashboard name over the last period
+func fetchDashboardText(ctx context.Context, client *aws.Client, name string, period time.Duration) (string, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.CloudWatch == nil {
+		return "", fmt.Errorf("CloudWatch service not initialized")
+	}
+
+	body, err := svc.CloudWatch.GetDashboardBody(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	d, err := dashboard.Parse(name, body)
+	if err != nil {
+		return "", err
+	}
+
+	end := time.Now()
+	data := dashboard.Fetch(ctx, svc.CloudWatch, d, client.GetRegion(), end.Add(-period), end)
+	return dashboard.Render(d, data, client.GetRegion(), dashboardSparkWidth), nil
+}
+
+// closeDashboard removes the dashboard view and returns focus to the table
+func (rt *ResourcesTab) closeDashboard() {
+	rt.view.RemovePage("dashboard")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// formatRange names a dashboard time range, e.g. 3h or 7d
+func formatRange(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
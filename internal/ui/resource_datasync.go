@@ -0,0 +1,336 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// dataSyncView lists the DataSync tasks with the progress of running ones;
+// Enter shows their execution history with throughput
+type dataSyncView struct{ baseView }
+
+var dataSyncService = dataSyncView{baseView{
+	info: ServiceInfo{Name: "datasync", DisplayName: "DataSync Tasks", Icon: "🔁", Label: "DSY", Enabled: true, Permission: "datasync:ListTasks"},
+	noun: "task",
+}}
+
+// ConsoleURL links the task
+func (dataSyncView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/datasync/home?%s#/tasks/%s", base, query, url.PathEscape(res.ID))
+}
+
+// StateColor colors the states of the tasks
+func (dataSyncView) StateColor(state string) tcell.Color {
+	return stateColor(dataSyncStateColors, state)
+}
+
+// dataSyncStateColors color the states of tasks
+var dataSyncStateColors = map[string]tcell.Color{
+	"unavailable": tcell.ColorRed,
+	"queued":      tcell.ColorYellow,
+}
+
+// Load lists the tasks
+func (dataSyncView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadDataSyncTasks(ctx, client)
+}
+
+// Summary counts the running tasks and the ones that cannot run
+func (dataSyncView) Summary(resources []Resource, failed int) (string, string) {
+	running, broken := 0, 0
+	for _, res := range resources {
+		if res.State == "running" {
+			running++
+		}
+		if res.Alert {
+			broken++
+		}
+	}
+
+	message := fmt.Sprintf("%d tasks, %d running, %d failing", len(resources), running, broken)
+	switch {
+	case broken > 0:
+		return message, "red"
+	case failed > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// Open shows the executions of the task
+func (dataSyncView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showTaskExecutions(resource)
+}
+
+// dataSyncHistory is how many executions the history of a task shows
+const dataSyncHistory = 20
+
+// loadDataSyncTasks lists the DataSync tasks of the region; tasks that
+// cannot run are flagged
+func (rt *ResourcesTab) loadDataSyncTasks(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.DataSync == nil {
+		return nil, fmt.Errorf("DataSync service not initialized")
+	}
+
+	tasks, err := svc.DataSync.ListTasks(ctx)
+	if tasks == nil && err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(tasks))
+	for _, task := range tasks {
+		resources = append(resources, dataSyncTaskResource(task, client.GetRegion()))
+	}
+	return resources, err
+}
+
+// dataSyncTaskResource describes task with where it copies and how far its
+// running execution got
+func dataSyncTaskResource(task clients.DataSyncTask, region string) Resource {
+	res := Resource{
+		ID:     task.ARN[strings.LastIndex(task.ARN, "/")+1:],
+		Name:   task.Name,
+		Type:   "DataSync Task",
+		State:  statusWords(task.Status),
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":         task.ARN,
+			"Source":      task.Source,
+			"Destination": task.Destination,
+			"Schedule":    task.Schedule,
+			"View":        "press Enter for the executions of the task with their throughput",
+		},
+	}
+	if res.Name == "" {
+		res.Name = res.ID
+	}
+	if task.Schedule == "" {
+		res.Details["Schedule"] = "none, started by hand"
+	}
+	if !task.CreatedAt.IsZero() {
+		res.CreatedDate = task.CreatedAt.Format("2006-01-02 15:04:05")
+	}
+	if task.Current != nil {
+		res.Details["Running"] = executionProgress(*task.Current)
+	}
+	if task.ErrorCode != "" || task.Status == "UNAVAILABLE" {
+		res.Alert = true
+		res.Details["Flag"] = "the task cannot run"
+		if task.ErrorCode != "" {
+			res.Details["Error"] = fmt.Sprintf("%s: %s", task.ErrorCode, task.ErrorDetail)
+		}
+	}
+	return res
+}
+
+// executionProgress describes how far a running execution got, e.g.
+// "transferring since 14:05, 84.0 GB of 200.0 GB (42%) at 71.7 MB/s"
+func executionProgress(e clients.DataSyncExecution) string {
+	progress := fmt.Sprintf("%s since %s, %s", statusWords(e.Status), zonedTime(e.StartedAt, "15:04"), formatBytes(e.BytesTransferred))
+	if e.EstimatedBytes > 0 {
+		progress += fmt.Sprintf(" of %s (%d%%)", formatBytes(e.EstimatedBytes), e.BytesTransferred*100/e.EstimatedBytes)
+	}
+	if throughput := e.Throughput(); throughput > 0 {
+		progress += " at " + formatThroughput(throughput)
+	}
+	return progress
+}
+
+// formatThroughput formats bytes per second, e.g. 71.7 MB/s
+func formatThroughput(bytesPerSecond float64) string {
+	return formatBytes(int64(bytesPerSecond)) + "/s"
+}
+
+// executionColors color the statuses of DataSync executions
+var executionColors = map[string]tcell.Color{
+	"SUCCESS": tcell.ColorGreen,
+	"ERROR":   tcell.ColorRed,
+}
+
+// showTaskExecutions shows the last executions of the DataSync task res
+// over the tab, newest first, with how much they copied and how fast, and
+// the throughput of the successful ones over time in the title. The full
+// error of the selected execution is shown below the table; r reloads and
+// q closes the panel.
+func (rt *ResourcesTab) showTaskExecutions(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	arn, _ := res.Details["ARN"].(string)
+	// The executions, nil until loaded
+	var executions []clients.DataSyncExecution
+	loads := 0
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	notice := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	panel := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(table, 0, 1, true).
+		AddItem(notice, 2, 0, false)
+	panel.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("datasync.title", res.Name))
+
+	load := func() {
+		setTableMessage(table, i18n.T("common.loading"), tcell.ColorGray)
+		notice.SetText("")
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var loaded []clients.DataSyncExecution
+			err := fmt.Errorf("DataSync service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.DataSync != nil {
+				loaded, err = svc.DataSync.ListTaskExecutions(ctx, arn, dataSyncHistory)
+			}
+			if err != nil {
+				logger.Error("Failed to list DataSync executions", zap.String("task", arn), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					setTableMessage(table, i18n.T("datasync.list_failed", res.Name, clients.ErrorReason(err)), tcell.ColorRed)
+					return
+				}
+				executions = loaded
+				panel.SetTitle(panelTitle("datasync.title_trend", res.Name, executionsTrend(executions)))
+				fillTaskExecutions(table, executions)
+				showExecutionError(notice, executions, 1)
+			})
+		}()
+	}
+
+	table.SetSelectionChangedFunc(func(row, _ int) {
+		showExecutionError(notice, executions, row)
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeTaskExecutions()
+			return nil
+		case 'r':
+			load()
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("datasync-executions", panel, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(table)
+	}
+	load()
+}
+
+// closeTaskExecutions removes the executions panel and returns focus to the
+// table
+func (rt *ResourcesTab) closeTaskExecutions() {
+	rt.view.RemovePage("datasync-executions")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// showExecutionError shows the error of the execution in row of the
+// executions table in notice
+func showExecutionError(notice *tview.TextView, executions []clients.DataSyncExecution, row int) {
+	notice.SetText("")
+	if row < 1 || row > len(executions) || executions[row-1].ErrorCode == "" {
+		return
+	}
+	e := executions[row-1]
+	notice.SetText(fmt.Sprintf("[red]%s%s[-]", stateWord("red"), tview.Escape(e.ErrorCode+": "+e.ErrorDetail)))
+}
+
+// executionsTrend sums up executions, newest first, with the throughput of
+// the successful ones from oldest to newest, e.g. "7 executions, 1 failed,
+// throughput ▃▄▆▅▇▆█ last 82.1 MB/s"
+func executionsTrend(executions []clients.DataSyncExecution) string {
+	failed := 0
+	var throughputs []float64
+	for i := len(executions) - 1; i >= 0; i-- {
+		switch executions[i].Status {
+		case "ERROR":
+			failed++
+		case "SUCCESS":
+			throughputs = append(throughputs, executions[i].Throughput())
+		}
+	}
+
+	trend := fmt.Sprintf("%s, %d failed", pluralize(len(executions), "execution"), failed)
+	if len(throughputs) > 0 {
+		trend += fmt.Sprintf(", throughput %s last %s", rateSparkline(throughputs), formatThroughput(throughputs[len(throughputs)-1]))
+	}
+	return trend
+}
+
+// fillTaskExecutions lists the executions of a task, newest first
+func fillTaskExecutions(table *tview.Table, executions []clients.DataSyncExecution) {
+	if len(executions) == 0 {
+		setTableMessage(table, i18n.T("datasync.not_run"), tcell.ColorGray)
+		return
+	}
+
+	table.Clear()
+	for col, header := range []string{"Started", "Execution", "Status", "Took", "Transferred", "Files", "Throughput"} {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, e := range executions {
+		row := i + 1
+		statusColor, ok := executionColors[e.Status]
+		if !ok {
+			statusColor = tcell.ColorYellow
+		}
+		took := "-"
+		if e.Duration > 0 {
+			took = e.Duration.Round(time.Second).String()
+		}
+		transferred := formatBytes(e.BytesTransferred)
+		if e.EstimatedBytes > 0 && e.BytesTransferred < e.EstimatedBytes {
+			transferred += fmt.Sprintf(" of %s", formatBytes(e.EstimatedBytes))
+		}
+		throughput := "-"
+		if t := e.Throughput(); t > 0 {
+			throughput = formatThroughput(t)
+		}
+
+		table.SetCell(row, 0, tview.NewTableCell(zonedTime(e.StartedAt, "2006-01-02 15:04")))
+		table.SetCell(row, 1, tview.NewTableCell(e.ID()))
+		table.SetCell(row, 2, tview.NewTableCell(statusWords(e.Status)).SetTextColor(statusColor))
+		table.SetCell(row, 3, tview.NewTableCell(took).SetAlign(tview.AlignRight))
+		table.SetCell(row, 4, tview.NewTableCell(transferred).SetAlign(tview.AlignRight))
+		table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("%d", e.FilesTransferred)).SetAlign(tview.AlignRight))
+		table.SetCell(row, 6, tview.NewTableCell(throughput).SetAlign(tview.AlignRight).SetExpansion(1))
+	}
+	table.Select(1, 0).ScrollToBeginning()
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// detailedView is a ServiceView whose listing only holds a summary of each
+// resource: what the table, the filter, grouping and the actions need. The
+// rest of its details load when a resource is highlighted, so listings of
+// accounts with many resources stay small.
+type detailedView interface {
+	ServiceView
+	// LoadDetails returns resource with the details its listing left out
+	LoadDetails(ctx context.Context, client *aws.Client, resource Resource) (Resource, error)
+}
+
+// detailsPending reports whether the details of resource of the shown
+// service are still to load
+func (rt *ResourcesTab) detailsPending(resource Resource) bool {
+	if _, ok := serviceViewOf(rt.shownService).(detailedView); !ok || rt.isOffline() {
+		return false
+	}
+	return !rt.detailsLoaded[resource.ID]
+}
+
+// loadDetails loads the details of the highlighted resource of view in the
+// background, cancelling the details loading for the one highlighted before
+func (rt *ResourcesTab) loadDetails(view detailedView, resource Resource) {
+	if rt.detailsLoaded[resource.ID] || rt.awsClient == nil {
+		return
+	}
+
+	if rt.detailCancel != nil {
+		rt.detailCancel()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	rt.detailCancel = cancel
+
+	client := rt.awsClient
+	service := view.Info().Name
+	go func() {
+		defer cancel()
+
+		detailed, err := view.LoadDetails(ctx, client, resource)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Warn("Failed to load resource details", zap.String("service", service), zap.String("id", resource.ID), zap.Error(err))
+				if rt.app != nil {
+					rt.app.QueueUpdateDraw(func() {
+						rt.addWarnings(clients.ItemError{Item: resource.ID, Err: err})
+					})
+				}
+			}
+			return
+		}
+
+		if rt.app != nil {
+			rt.app.QueueUpdateDraw(func() {
+				rt.applyDetails(client, service, detailed)
+			})
+		}
+	}()
+}
+
+// keepDetails carries the details loaded while the first pages of a
+// listing were shown over to resources, the whole listing
+func (rt *ResourcesTab) keepDetails(resources []Resource) {
+	if len(rt.detailsLoaded) == 0 {
+		return
+	}
+	detailed := make(map[string]Resource, len(rt.detailsLoaded))
+	for _, res := range rt.filteredRes {
+		if rt.detailsLoaded[res.ID] {
+			detailed[res.ID] = res
+		}
+	}
+	for i, res := range resources {
+		if d, ok := detailed[res.ID]; ok {
+			resources[i] = d
+		}
+	}
+}
+
+// applyDetails replaces the listed resource with its details if the
+// listing of service of client is still shown
+func (rt *ResourcesTab) applyDetails(client *aws.Client, service string, detailed Resource) {
+	if client != rt.awsClient || rt.shownService != service {
+		return
+	}
+	rt.detailsLoaded[detailed.ID] = true
+
+	for i := range rt.filteredRes {
+		if rt.filteredRes[i].ID != detailed.ID {
+			continue
+		}
+		rt.filteredRes[i] = detailed
+
+		if rt.selectedRes != nil && rt.selectedRes.ID == detailed.ID {
+			updated := detailed
+			rt.selectedRes = &updated
+			rt.updateResourceDetails(&updated)
+		}
+	}
+
+	rt.applyFilter()
+}
@@ -0,0 +1,442 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/dashboard"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// dynamoDBView lists the DynamoDB tables; Enter shows the capacity of one
+type dynamoDBView struct{ baseView }
+
+var dynamoDBService = dynamoDBView{baseView{
+	info: ServiceInfo{Name: "dynamodb", DisplayName: "DynamoDB Tables", Icon: "🗄", Label: "DDB", Enabled: true, Permission: "dynamodb:ListTables"},
+	noun: "table",
+}}
+
+// ConsoleURL links the table
+func (dynamoDBView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/dynamodbv2/home?%s#table?name=%s", base, query, url.QueryEscape(res.Name))
+}
+
+// ARN returns the ARN of the table
+func (dynamoDBView) ARN(account string, res Resource) string {
+	return fmt.Sprintf("arn:%s:dynamodb:%s:%s:table/%s", awsPartition(res.Region), res.Region, account, res.Name)
+}
+
+// Load lists the tables
+func (dynamoDBView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadDynamoDBTables(ctx, client)
+}
+
+// Columns shows the items of the tables instead of their cost
+func (dynamoDBView) Columns() []string {
+	columns := append([]string(nil), resourceHeaders...)
+	columns[costColumn] = itemsColumn
+	return columns
+}
+
+// Cell renders the item count and size of the table
+func (dynamoDBView) Cell(resource Resource, column int) *tview.TableCell {
+	if column != costColumn {
+		return nil
+	}
+	items := "-"
+	switch count := resource.Details["Items"].(type) {
+	case int64:
+		items = fmt.Sprintf("%d", count)
+	case float64:
+		// Snapshots restore numbers as float64
+		items = fmt.Sprintf("%.0f", count)
+	}
+	if size := detailString(resource, "Size"); size != "" {
+		items += " (" + size + ")"
+	}
+	return tview.NewTableCell(items).SetAlign(tview.AlignRight)
+}
+
+// itemsColumn is the column of DynamoDB tables with their item count and
+// size, as last updated by DynamoDB about every six hours
+const itemsColumn = "Items (Size)"
+
+// Open shows the capacity of the table
+func (dynamoDBView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showTableCapacity(resource)
+}
+
+// capacityWarning is the share of the provisioned capacity at which a
+// table is shown as close to throttling
+const capacityWarning = 0.8
+
+// tableCapacity is the capacity of a table over a time range: consumed and
+// provisioned units per second and throttle events, for reads and writes
+type tableCapacity struct {
+	consumed    [2][]float64
+	provisioned [2][]float64
+	throttles   [2][]float64
+	scaling     []clients.ScalableTarget
+}
+
+// capacityKinds are the two kinds of capacity of a table, in the order of
+// the arrays of tableCapacity
+var capacityKinds = [2]string{"Read", "Write"}
+
+// loadDynamoDBTables lists the DynamoDB tables of the region by name
+func (rt *ResourcesTab) loadDynamoDBTables(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.DynamoDB == nil {
+		return nil, fmt.Errorf("DynamoDB service not initialized")
+	}
+
+	tables, err := svc.DynamoDB.ListTables(ctx)
+	if tables == nil && err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(tables))
+	for _, table := range tables {
+		resources = append(resources, tableResource(table, client.GetRegion()))
+	}
+	sort.SliceStable(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+	return resources, err
+}
+
+// tableResource describes table with its keys, capacity settings and
+// indexes
+func tableResource(table clients.TableDetail, region string) Resource {
+	res := Resource{
+		ID:     table.Name,
+		Name:   table.Name,
+		Type:   "On-demand Table",
+		State:  strings.ToLower(table.Status),
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":                 table.ARN,
+			"Billing Mode":        table.BillingMode,
+			"Items":               table.ItemCount,
+			"Size":                formatBytes(table.SizeBytes),
+			"Deletion Protection": table.DeletionLocked,
+			"Partition Key":       tableKey(table.PartitionKey),
+			"Table Class":         statusWords(table.TableClass),
+			"Stream":              "off",
+			"View":                "press Enter to show capacity, throttling and auto scaling",
+		},
+	}
+	if table.BillingMode == "PROVISIONED" {
+		res.Type = "Provisioned Table"
+		res.Details["Read Capacity"] = fmt.Sprintf("%d RCU", table.ReadCapacity)
+		res.Details["Write Capacity"] = fmt.Sprintf("%d WCU", table.WriteCapacity)
+	} else {
+		res.Details["Read Capacity"] = onDemandLimit(table.MaxReadRequests, "read")
+		res.Details["Write Capacity"] = onDemandLimit(table.MaxWriteRequests, "write")
+	}
+	if table.SortKey.Name != "" {
+		res.Details["Sort Key"] = tableKey(table.SortKey)
+	}
+	if table.StreamView != "" {
+		res.Details["Stream"] = statusWords(table.StreamView)
+	}
+
+	var indexes []string
+	if n := len(table.GlobalIndexes); n > 0 {
+		indexes = append(indexes, pluralize(n, "GSI"))
+		names := make([]string, n)
+		for i, index := range table.GlobalIndexes {
+			names[i] = index.Name
+			res.Details["GSI "+index.Name] = tableIndex(index, true)
+		}
+		res.Details["Indexes"] = strings.Join(names, ", ")
+	}
+	if n := len(table.LocalIndexes); n > 0 {
+		indexes = append(indexes, pluralize(n, "LSI"))
+		for _, index := range table.LocalIndexes {
+			res.Details["LSI "+index.Name] = tableIndex(index, false)
+		}
+	}
+	if len(indexes) > 0 {
+		res.Type += " (" + strings.Join(indexes, ", ") + ")"
+	}
+	if !table.CreatedAt.IsZero() {
+		res.CreatedDate = table.CreatedAt.Format("2006-01-02 15:04:05")
+	}
+	return res
+}
+
+// tableKeyTypes name the types of key attributes
+var tableKeyTypes = map[string]string{"S": "string", "N": "number", "B": "binary"}
+
+// tableKey describes a key attribute, e.g. "orderId (string)"
+func tableKey(key clients.TableKey) string {
+	if key.Name == "" {
+		return "-"
+	}
+	if typ, ok := tableKeyTypes[key.Type]; ok {
+		return fmt.Sprintf("%s (%s)", key.Name, typ)
+	}
+	return key.Name
+}
+
+// onDemandLimit describes the most request units per second an on-demand
+// table may serve of kind
+func onDemandLimit(units int64, kind string) string {
+	if units == 0 {
+		return "on demand, unlimited"
+	}
+	return fmt.Sprintf("on demand, at most %d %s request units per second", units, kind)
+}
+
+// tableIndex sums up a secondary index: its keys, what it projects, its
+// provisioned capacity if global and provisioned, and its items
+func tableIndex(index clients.TableIndex, global bool) string {
+	keys := tableKey(index.PartitionKey)
+	if index.SortKey.Name != "" {
+		keys += " + " + tableKey(index.SortKey)
+	}
+	parts := []string{keys, "projects " + statusWords(index.Projection)}
+	if global && index.ReadCapacity+index.WriteCapacity > 0 {
+		parts = append(parts, fmt.Sprintf("%d RCU, %d WCU", index.ReadCapacity, index.WriteCapacity))
+	}
+	parts = append(parts, fmt.Sprintf("%d items, %s", index.ItemCount, formatBytes(index.SizeBytes)))
+	if global && index.Status != "" && index.Status != "ACTIVE" {
+		parts = append(parts, statusWords(index.Status))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// showTableCapacity shows the consumed against the provisioned capacity of
+// the table res, its throttle events and the auto scaling of the table and
+// its indexes over the tab. t cycles the time range, r reloads and q closes
+// the view.
+func (rt *ResourcesTab) showTableCapacity(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	var indexes []string
+	if joined, _ := res.Details["Indexes"].(string); joined != "" {
+		indexes = strings.Split(joined, ", ")
+	}
+	rangeIndex := 0
+	loads := 0
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	load := func() {
+		window := dashboardRanges[rangeIndex]
+		view.SetTitle(panelTitle("dynamodb.capacity_title", res.Name, formatRange(window)))
+		view.SetText("[gray]" + i18n.T("common.loading") + "[-]")
+
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			capacity, err := fetchTableCapacity(ctx, client, res.Name, indexes, window)
+			if err != nil {
+				logger.Error("Failed to load table capacity", zap.String("table", res.Name), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					view.SetText("[red]" + i18n.T("dynamodb.capacity_failed", res.Name, tview.Escape(err.Error())) + "[-]")
+					return
+				}
+				view.SetText(renderTableCapacity(res, capacity, dashboardSparkWidth)).ScrollToBeginning()
+			})
+		}()
+	}
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeTableCapacity()
+			return nil
+		case 'r':
+			load()
+			return nil
+		case 't':
+			rangeIndex = (rangeIndex + 1) % len(dashboardRanges)
+			load()
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("dynamodb-capacity", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(view)
+	}
+	load()
+}
+
+// closeTableCapacity removes the capacity view and returns focus to the table
+func (rt *ResourcesTab) closeTableCapacity() {
+	rt.view.RemovePage("dynamodb-capacity")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// capacityPeriod returns the metric period in seconds for window, keeping
+// about as many datapoints as a sparkline has cells
+func capacityPeriod(window time.Duration) int32 {
+	period := int32(window.Seconds()) / (dashboardSparkWidth * 60) * 60
+	return max(period, 60)
+}
+
+// fetchTableCapacity returns the capacity metrics of tableName over the last
+// window and the scalable targets of it and its indexes
+func fetchTableCapacity(ctx context.Context, client *aws.Client, tableName string, indexes []string, window time.Duration) (tableCapacity, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.CloudWatch == nil || svc.AppAutoScaling == nil {
+		return tableCapacity{}, fmt.Errorf("DynamoDB service not initialized")
+	}
+
+	period := capacityPeriod(window)
+	dimensions := []clients.MetricDimension{{Name: "TableName", Value: tableName}}
+	var queries []clients.MetricQuery
+	for _, kind := range capacityKinds {
+		queries = append(queries,
+			clients.MetricQuery{Namespace: "AWS/DynamoDB", Metric: "Consumed" + kind + "CapacityUnits", Dimensions: dimensions, Stat: "Sum", Period: period},
+			clients.MetricQuery{Namespace: "AWS/DynamoDB", Metric: "Provisioned" + kind + "CapacityUnits", Dimensions: dimensions, Stat: "Average", Period: period},
+			clients.MetricQuery{Namespace: "AWS/DynamoDB", Metric: kind + "ThrottleEvents", Dimensions: dimensions, Stat: "Sum", Period: period},
+		)
+	}
+
+	end := time.Now()
+	series, err := svc.CloudWatch.GetMetricSeries(ctx, queries, end.Add(-window), end)
+	if err != nil {
+		return tableCapacity{}, err
+	}
+
+	var capacity tableCapacity
+	for i := range capacityKinds {
+		if len(series) < 3*(i+1) {
+			break
+		}
+		// Consumed capacity is summed over the period, provisioned capacity
+		// is per second
+		consumed := make([]float64, len(series[3*i].Values))
+		for j, v := range series[3*i].Values {
+			consumed[j] = v / float64(period)
+		}
+		capacity.consumed[i] = consumed
+		capacity.provisioned[i] = series[3*i+1].Values
+		capacity.throttles[i] = series[3*i+2].Values
+	}
+
+	capacity.scaling, err = svc.AppAutoScaling.GetTableScaling(ctx, tableName, indexes)
+	if err != nil {
+		return tableCapacity{}, err
+	}
+	return capacity, nil
+}
+
+// renderTableCapacity draws the consumed capacity and throttle events of the
+// table res as sparklines of width, compared with its provisioned capacity,
+// followed by its auto scaling
+func renderTableCapacity(res Resource, capacity tableCapacity, width int) string {
+	var text strings.Builder
+
+	for i, kind := range capacityKinds {
+		consumed := capacity.consumed[i]
+		label := kind + " capacity (units/s)"
+		if len(consumed) == 0 {
+			fmt.Fprintf(&text, "%-28s [gray]no data[-]\n", label)
+		} else {
+			var peak float64
+			for _, v := range consumed {
+				peak = max(peak, v)
+			}
+			summary := fmt.Sprintf("latest %.1f, peak %.1f", consumed[len(consumed)-1], peak)
+			color := "green"
+			if provisioned := capacity.provisioned[i]; len(provisioned) > 0 {
+				limit := provisioned[len(provisioned)-1]
+				summary += fmt.Sprintf(" of %g provisioned", limit)
+				if limit > 0 {
+					summary += fmt.Sprintf(" (%.0f%%)", peak/limit*100)
+					if peak >= limit*capacityWarning {
+						color = "yellow"
+					}
+				}
+			} else if res.Details["Billing Mode"] == "PAY_PER_REQUEST" {
+				summary += ", on-demand"
+			}
+			fmt.Fprintf(&text, "%-28s [%s]%s[-]  %s\n", label, color, dashboard.Sparkline(consumed, width), summary)
+		}
+
+		throttles := capacity.throttles[i]
+		label = kind + " throttle events"
+		var total float64
+		for _, v := range throttles {
+			total += v
+		}
+		if total == 0 {
+			fmt.Fprintf(&text, "%-28s [green]none[-]\n", label)
+		} else {
+			fmt.Fprintf(&text, "%-28s [red]%s[-]  total %g\n", label, dashboard.Sparkline(throttles, width), total)
+		}
+		text.WriteString("\n")
+	}
+
+	text.WriteString("[yellow]Auto scaling:[-]\n")
+	if len(capacity.scaling) == 0 {
+		if res.Details["Billing Mode"] == "PROVISIONED" {
+			text.WriteString("  [yellow]none, the provisioned capacity is fixed[-]\n")
+		} else {
+			text.WriteString("  none\n")
+		}
+		return text.String()
+	}
+	for _, target := range capacity.scaling {
+		resource := strings.TrimPrefix(target.ResourceID, "table/"+res.Name)
+		if resource == "" {
+			resource = "table"
+		} else {
+			resource = "index " + strings.TrimPrefix(resource, "/index/")
+		}
+		dimension := target.Dimension[strings.LastIndex(target.Dimension, ":")+1:]
+		fmt.Fprintf(&text, "  %-24s %-20s %d - %d\n", resource, dimension, target.Min, target.Max)
+		if len(target.Policies) == 0 {
+			text.WriteString("    [yellow]no scaling policy[-]\n")
+		}
+		for _, policy := range target.Policies {
+			if policy.Type != "TargetTrackingScaling" {
+				fmt.Fprintf(&text, "    %s (%s)\n", policy.Name, policy.Type)
+				continue
+			}
+			fmt.Fprintf(&text, "    target %g%% %s, cooldown in %ds / out %ds", policy.TargetValue, policy.Metric, policy.ScaleInCooldown, policy.ScaleOutCooldown)
+			if policy.ScaleInDisabled {
+				text.WriteString(", scale in disabled")
+			}
+			text.WriteString("\n")
+		}
+	}
+	return text.String()
+}
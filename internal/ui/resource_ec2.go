@@ -0,0 +1,349 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/pricing"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.uber.org/zap"
+)
+
+// ec2View lists the EC2 instances with their estimated cost. Highlighting
+// an instance loads its details and checks whether it may be started and
+// stopped.
+type ec2View struct{ baseView }
+
+var ec2Service = ec2View{baseView{
+	info: ServiceInfo{Name: "ec2", DisplayName: "EC2 Instances", Icon: "🤖", Label: "EC2", Enabled: true, Permission: "ec2:DescribeInstances"},
+	noun: "instance",
+}}
+
+// ConsoleURL links the details of the instance
+func (ec2View) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/ec2/home?%s#InstanceDetails:instanceId=%s", base, query, res.ID)
+}
+
+// ARN returns the ARN of the instance
+func (ec2View) ARN(account string, res Resource) string {
+	return fmt.Sprintf("arn:%s:ec2:%s:%s:instance/%s", awsPartition(res.Region), res.Region, account, res.ID)
+}
+
+// Load lists the instances
+func (v ec2View) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return v.LoadPages(ctx, rt, client, nil)
+}
+
+// LoadPages lists the instances a page at a time
+func (ec2View) LoadPages(ctx context.Context, rt *ResourcesTab, client *aws.Client, onPage func([]Resource)) ([]Resource, error) {
+	return rt.loadEC2Instances(ctx, client, onPage)
+}
+
+// Detail lists the instance actions with the outcome of their preflights
+func (ec2View) Detail(rt *ResourcesTab, resource Resource) string {
+	return rt.instanceActionsText(resource.ID) + "\n"
+}
+
+// Highlight checks the instance actions
+func (ec2View) Highlight(rt *ResourcesTab, resource Resource) {
+	rt.checkInstanceActions(resource.ID)
+}
+
+// LoadDetails describes the instance again with its network, key and
+// security groups
+func (ec2View) LoadDetails(ctx context.Context, client *aws.Client, resource Resource) (Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.EC2 == nil {
+		return Resource{}, fmt.Errorf("EC2 service not initialized")
+	}
+	instance, err := svc.EC2.DescribeInstance(ctx, resource.ID)
+	if err != nil {
+		return Resource{}, err
+	}
+	return ec2InstanceDetails(instance, client.GetRegion()), nil
+}
+
+// Actions starts, stops, schedules and groups instances
+func (ec2View) Actions() []resourceAction {
+	return []resourceAction{
+		{name: "ec2 start", key: 's', description: "action.ec2_start",
+			permissions: []string{"ec2:StartInstances"}, onResource: true, online: true,
+			run: (*ResourcesTab).onEC2StartInstance},
+		{name: "ec2 stop", key: 'p', description: "action.ec2_stop",
+			permissions: []string{"ec2:StopInstances"}, onResource: true, online: true,
+			run: (*ResourcesTab).onEC2StopInstance},
+		{name: "ec2 schedule", key: 'S', description: "action.ec2_schedule",
+			onResource: true, online: true, run: (*ResourcesTab).onScheduleAction},
+		{name: "ec2 group", key: 'g', description: "action.ec2_group",
+			run: (*ResourcesTab).cycleGrouping},
+	}
+}
+
+// loadEC2Instances loads EC2 instances, calling onPage, if set, with the
+// instances loaded so far after every page
+func (rt *ResourcesTab) loadEC2Instances(ctx context.Context, client *aws.Client, onPage func([]Resource)) ([]Resource, error) {
+	var resources []Resource
+	_, err := client.GetEC2FunctionDetails(ctx, func(page []types.Instance) {
+		for _, instance := range page {
+			resources = append(resources, ec2InstanceToResource(instance, client.GetRegion()))
+		}
+		if onPage != nil {
+			onPage(resources)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe instances: %w", err)
+	}
+
+	return resources, nil
+}
+
+// ec2InstanceToResource summarizes instance of region for the listing: its
+// type, image and zone, which grouping needs, and cost. ec2InstanceDetails
+// adds the rest when it is highlighted.
+func ec2InstanceToResource(instance types.Instance, region string) Resource {
+	res := Resource{
+		Type:   "EC2 Instance",
+		State:  string(instance.State.Name),
+		Region: region,
+		Tags:   make(map[string]string),
+	}
+
+	if instance.InstanceId != nil {
+		res.ID = *instance.InstanceId
+	}
+
+	if instance.LaunchTime != nil {
+		res.CreatedDate = instance.LaunchTime.Format("2006-01-02 15:04:05")
+	}
+
+	for _, tag := range instance.Tags {
+		if tag.Key != nil && tag.Value != nil {
+			res.Tags[*tag.Key] = *tag.Value
+			if *tag.Key == "Name" {
+				res.Name = *tag.Value
+			}
+		}
+	}
+
+	if res.Name == "" {
+		res.Name = res.ID
+	}
+
+	res.Details = map[string]interface{}{
+		"InstanceType":     string(instance.InstanceType),
+		"ImageId":          getStringValue(instance.ImageId),
+		"AvailabilityZone": "",
+	}
+
+	if instance.Placement != nil {
+		res.Details["AvailabilityZone"] = getStringValue(instance.Placement.AvailabilityZone)
+	}
+
+	// Stopped instances do not pay for compute
+	switch instance.State.Name {
+	case types.InstanceStateNameRunning, types.InstanceStateNamePending:
+		res.MonthlyCost, _ = pricing.EC2Monthly(string(instance.InstanceType), region)
+	}
+
+	return res
+}
+
+// ec2InstanceDetails describes instance of region with all its details
+func ec2InstanceDetails(instance types.Instance, region string) Resource {
+	res := ec2InstanceToResource(instance, region)
+	res.Details["VpcId"] = getStringValue(instance.VpcId)
+	res.Details["SubnetId"] = getStringValue(instance.SubnetId)
+	res.Details["PublicIpAddress"] = getStringValue(instance.PublicIpAddress)
+	res.Details["PrivateIpAddress"] = getStringValue(instance.PrivateIpAddress)
+	res.Details["KeyName"] = getStringValue(instance.KeyName)
+	res.Details["SecurityGroups"] = instance.SecurityGroups
+	return res
+}
+
+func (rt *ResourcesTab) onEC2StartInstance() {
+	logger.Info("onEC2StartInstance called", zap.String("selectedService", rt.selectedService))
+
+	if rt.selectedService != "ec2" {
+		logger.Info("Not EC2 service, ignoring")
+		return
+	}
+
+	if rt.selectedRes == nil {
+		logger.Info("No resource selected")
+		return
+	}
+
+	instanceID := rt.selectedRes.ID
+	if instanceID == "" {
+		rt.updateStatus(i18n.T("ec2.no_instance_id"), "red")
+		logger.Error("No InstanceId found in selected resource")
+		return
+	}
+	if rt.actionDenied(instanceID, "ec2:StartInstances") {
+		rt.updateStatus(i18n.T("ec2.cannot_start", instanceID), "red")
+		return
+	}
+	arn := ec2Service.ARN(rt.awsClient.GetAccountID(), *rt.selectedRes)
+
+	rt.updateStatus(i18n.T("ec2.starting", instanceID), "yellow")
+
+	// Since this is a UI-triggered asynchronous operation meant not to block the UI,
+	// we do NOT generally use a WaitGroup for the user-facing routine.
+	// State is protected with locks where appropriate, and UI updates are handled on the main UI goroutine.
+	// If you ever need to clean up or synchronize these routines (such as cancelling/retrying),
+	// consider keeping a list/context for outstanding operations, not just WaitGroups.
+
+	client := rt.awsClient
+	go func(id string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := client.GetClients().EC2.StartInstance(ctx, id)
+		rt.audit.record(client, "ec2:StartInstances", arn, err)
+		if err != nil {
+			logger.Error("Failed to start EC2 instance", zap.String("instanceID", id), zap.Error(err))
+			if rt.app != nil {
+				rt.app.QueueUpdateDraw(func() {
+					rt.updateStatus(i18n.T("ec2.start_failed", err.Error()), "red")
+				})
+			}
+			return
+		}
+
+		logger.Info("EC2 instance starting", zap.String("instanceID", id))
+		if rt.app != nil {
+			rt.app.QueueUpdateDraw(func() {
+				rt.updateStatus(i18n.T("ec2.started", id), "green")
+			})
+		}
+		rt.followInstance(client, id, types.InstanceStateNameRunning)
+	}(instanceID)
+}
+
+func (rt *ResourcesTab) onEC2StopInstance() {
+	logger.Info("onEC2StopInstance called", zap.String("selectedService", rt.selectedService))
+
+	if rt.selectedService != "ec2" {
+		logger.Info("Not EC2 service, ignoring")
+		return
+	}
+
+	if rt.selectedRes == nil {
+		logger.Info("No resource selected")
+		return
+	}
+
+	instanceID := rt.selectedRes.ID
+	if instanceID == "" {
+		rt.updateStatus(i18n.T("ec2.no_instance_id"), "red")
+		logger.Error("No InstanceId found in selected resource")
+		return
+	}
+	if rt.actionDenied(instanceID, "ec2:StopInstances") {
+		rt.updateStatus(i18n.T("ec2.cannot_stop", instanceID), "red")
+		return
+	}
+	arn := ec2Service.ARN(rt.awsClient.GetAccountID(), *rt.selectedRes)
+
+	rt.updateStatus(i18n.T("ec2.stopping", instanceID), "yellow")
+
+	client := rt.awsClient
+	go func(id string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := client.GetClients().EC2.StopInstance(ctx, id)
+		rt.audit.record(client, "ec2:StopInstances", arn, err)
+		if err != nil {
+			logger.Error("Failed to stop EC2 instance", zap.String("instanceID", id), zap.Error(err))
+			if rt.app != nil {
+				rt.app.QueueUpdateDraw(func() {
+					rt.updateStatus(i18n.T("ec2.stop_failed", err.Error()), "red")
+				})
+			}
+			return
+		}
+
+		logger.Info("EC2 instance stopping", zap.String("instanceID", id))
+		if rt.app != nil {
+			rt.app.QueueUpdateDraw(func() {
+				rt.updateStatus(i18n.T("ec2.stopped", id), "green")
+			})
+		}
+		rt.followInstance(client, id, types.InstanceStateNameStopped)
+	}(instanceID)
+}
+
+// instanceStatePollInterval is how often a started or stopped instance is
+// described until it reaches its target state
+var instanceStatePollInterval = 5 * time.Second
+
+// instanceStateTimeout is how long an instance is followed at most
+const instanceStateTimeout = 10 * time.Minute
+
+// followInstance updates the row of an instance until it reaches target and
+// announces the outcome in a toast. It blocks, so run it off the UI goroutine.
+func (rt *ResourcesTab) followInstance(client *aws.Client, instanceID string, target types.InstanceStateName) {
+	rt.mu.Lock()
+	if rt.waitCtx == nil {
+		rt.waitCtx, rt.waitCancel = context.WithCancel(context.Background())
+	}
+	parent := rt.waitCtx
+	rt.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(parent, instanceStateTimeout)
+	defer cancel()
+
+	err := client.WaitForInstanceState(ctx, instanceID, target, instanceStatePollInterval, func(instance types.Instance) {
+		if rt.app != nil {
+			rt.app.QueueUpdateDraw(func() {
+				rt.setInstance(client, instance)
+			})
+		}
+	})
+	if parent.Err() != nil {
+		// The profile or region changed, nobody is looking at this instance anymore
+		return
+	}
+
+	toast := Toast{Message: fmt.Sprintf("Instance %s is now %s", instanceID, target), Color: "green"}
+	if err != nil {
+		logger.Warn("Instance did not reach its target state",
+			zap.String("instanceID", instanceID),
+			zap.String("target", string(target)),
+			zap.Error(err))
+		toast = Toast{Message: fmt.Sprintf("Instance %s: %v", instanceID, err), Color: "red"}
+	}
+	rt.events.Publish(toast)
+}
+
+// setInstance replaces the row of an instance with a fresh description if
+// the EC2 listing of client is shown
+func (rt *ResourcesTab) setInstance(client *aws.Client, instance types.Instance) {
+	if client != rt.awsClient || rt.shownService != "ec2" {
+		return
+	}
+
+	updated := ec2InstanceDetails(instance, client.GetRegion())
+	for i, res := range rt.filteredRes {
+		if res.ID != updated.ID {
+			continue
+		}
+
+		// The listing may be shared with the cache, which is stale now
+		resources := append([]Resource(nil), rt.filteredRes...)
+		resources[i] = updated
+		rt.markStateChanges(rt.filteredRes, resources)
+		rt.filteredRes = resources
+		rt.detailsLoaded[updated.ID] = true
+		rt.cache.Invalidate(rt.cacheKey("ec2"))
+		rt.applyFilter()
+		return
+	}
+}
@@ -0,0 +1,548 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// ecsView lists the ECS services with the scan findings of their images in
+// place of a cost estimate; Enter shows the tasks of a service
+type ecsView struct{ baseView }
+
+var ecsService = ecsView{baseView{
+	info: ServiceInfo{Name: "ecs", DisplayName: "ECS Services", Icon: "🐳", Label: "ECS", Enabled: true, Permission: "ecs:ListServices"},
+	noun: "service",
+}}
+
+// ConsoleURL links the clusters, of which the console has no page per
+// service without its cluster
+func (ecsView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/ecs/v2/clusters?%s", base, query)
+}
+
+// StateColor colors the services short of tasks
+func (ecsView) StateColor(state string) tcell.Color {
+	return stateColor(ecsStateColors, state)
+}
+
+// ecsStateColors color services short of their desired tasks
+var ecsStateColors = map[string]tcell.Color{
+	"degraded": tcell.ColorYellow,
+}
+
+// Load lists the services
+func (ecsView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadECSServices(ctx, client)
+}
+
+// Summary sums up the services
+func (ecsView) Summary(resources []Resource, failed int) (string, string) {
+	return ecsSummary(resources, failed)
+}
+
+// Open shows the tasks of the service
+func (ecsView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showECSTasks(resource)
+}
+
+// Columns shows the scan findings in the cost column
+func (ecsView) Columns() []string {
+	columns := append([]string(nil), resourceHeaders...)
+	columns[costColumn] = findingsColumn
+	return columns
+}
+
+// Cell renders the scan findings of the images of the service
+func (ecsView) Cell(resource Resource, column int) *tview.TableCell {
+	if column != costColumn {
+		return nil
+	}
+	findings := detailString(resource, findingsColumn)
+	return tview.NewTableCell(orDash(findings)).SetAlign(tview.AlignRight).SetTextColor(findingsColor(findings))
+}
+
+// findingsColumn is the column of ECS services and EKS deployments with the
+// critical and high findings of the latest scans of their images
+const findingsColumn = "Critical/High"
+
+// imageScan is the scan of an image, or why it could not be read
+type imageScan struct {
+	findings clients.ImageScanFindings
+	err      error
+}
+
+// loadECSServices lists the ECS services of the region with the task
+// counts of their clusters and the scan findings of their images; services
+// running fewer tasks than desired or images with critical findings are
+// flagged
+func (rt *ResourcesTab) loadECSServices(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.ECS == nil {
+		return nil, fmt.Errorf("ECS service not initialized")
+	}
+
+	var failures []clients.ItemError
+	services, err := svc.ECS.ListServices(ctx)
+	if err := collectFailures(err, &failures); err != nil {
+		return nil, err
+	}
+
+	clusters := make(map[string]clients.ECSCluster)
+	found, err := svc.ECS.ListClusters(ctx)
+	if err != nil {
+		logger.Warn("Failed to describe ECS clusters", zap.Error(err))
+		failures = append(failures, clients.ItemError{Item: "clusters", Region: client.GetRegion(), Err: err})
+	}
+	for _, cluster := range found {
+		clusters[cluster.Name] = cluster
+	}
+
+	var images []string
+	for _, service := range services {
+		for _, image := range service.Images {
+			images = append(images, image)
+		}
+	}
+	scans := scanImages(ctx, svc.ECR, images)
+
+	resources := make([]Resource, 0, len(services))
+	for _, service := range services {
+		resources = append(resources, ecsServiceResource(service, clusters, scans, client.GetRegion()))
+	}
+	if len(failures) > 0 {
+		return resources, &clients.PartialError{Op: "ecs:ListServices", Failures: failures}
+	}
+	return resources, nil
+}
+
+// ecsServiceResource describes service with its tasks, the task counts of
+// its cluster, if described, and the findings of its images
+func ecsServiceResource(service clients.ECSServiceDetail, clusters map[string]clients.ECSCluster, scans map[string]imageScan, region string) Resource {
+	res := Resource{
+		ID:     service.Cluster + "/" + service.Name,
+		Name:   service.Name,
+		Type:   "ECS Service",
+		State:  strings.ToLower(service.Status),
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":             service.ARN,
+			"Cluster":         service.Cluster,
+			"Launch Type":     service.LaunchType,
+			"Task Definition": service.TaskDefinition[strings.LastIndex(service.TaskDefinition, "/")+1:],
+			"Tasks":           fmt.Sprintf("%d running, %d pending of %d desired", service.Running, service.Pending, service.Desired),
+			"Images":          formatContainers(service.Images),
+			"View":            "press Enter for the tasks and their containers",
+		},
+	}
+	if cluster, ok := clusters[service.Cluster]; ok {
+		res.Details["Cluster Tasks"] = fmt.Sprintf("%d running, %d pending in %s",
+			cluster.RunningTasks, cluster.PendingTasks, pluralize(cluster.ActiveServices, "service"))
+	}
+	if !service.CreatedAt.IsZero() {
+		res.CreatedDate = service.CreatedAt.Format("2006-01-02 15:04:05")
+	}
+
+	var flags []string
+	summary := summarizeScans(service.Images, scans)
+	res.Details[findingsColumn] = summary.column
+	if len(summary.perContainer) > 0 {
+		res.Details["Image Findings"] = formatContainers(summary.perContainer)
+	}
+	if summary.critical > 0 {
+		flags = append(flags, fmt.Sprintf("runs images with %d known critical vulnerabilities: %s",
+			summary.critical, strings.Join(summary.criticalImages, ", ")))
+	}
+	if service.Status == "ACTIVE" && service.Running < service.Desired {
+		res.State = "degraded"
+		flags = append(flags, fmt.Sprintf("runs %d of %d desired tasks", service.Running, service.Desired))
+	}
+	if len(flags) > 0 {
+		res.Alert = true
+		res.Details["Flag"] = strings.Join(flags, "; ")
+	}
+	return res
+}
+
+// scanImages reads the findings of the latest scans of images, each once.
+// Failures are logged and kept with the image.
+func scanImages(ctx context.Context, ecr aws.ECRService, images []string) map[string]imageScan {
+	scans := make(map[string]imageScan)
+	for _, image := range images {
+		if _, ok := scans[image]; ok {
+			continue
+		}
+		if ecr == nil {
+			scans[image] = imageScan{err: fmt.Errorf("ECR service not initialized")}
+			continue
+		}
+		findings, err := ecr.GetImageScanFindings(ctx, image)
+		if err != nil {
+			logger.Warn("Failed to read image scan findings", zap.String("image", image), zap.Error(err))
+		}
+		scans[image] = imageScan{findings: findings, err: err}
+	}
+	return scans
+}
+
+// scanSummary sums up the findings of the images of a workload
+type scanSummary struct {
+	critical int
+	high     int
+	// column is the findings column: critical/high counts, or why there
+	// are none
+	column string
+	// criticalImages are the short names of the images with critical
+	// findings
+	criticalImages []string
+	// perContainer describes the scan of the image of each container
+	perContainer map[string]string
+}
+
+// summarizeScans sums up the findings of the images of containers, by
+// container name. Images shared by containers count once.
+func summarizeScans(containers map[string]string, scans map[string]imageScan) scanSummary {
+	summary := scanSummary{perContainer: make(map[string]string, len(containers))}
+	counted := make(map[string]bool)
+	var scanned, scanning, failed, unscanned bool
+
+	names := make([]string, 0, len(containers))
+	for name := range containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		image := containers[name]
+		scan, ok := scans[image]
+		if !ok {
+			continue
+		}
+		f := scan.findings
+		switch {
+		case scan.err != nil:
+			failed = true
+			summary.perContainer[name] = "unavailable: " + clients.ErrorReason(scan.err)
+		case f.Status == clients.ScanStatusNotInECR:
+			summary.perContainer[name] = "not in ECR: " + image
+		case f.Status == clients.ScanStatusNotScanned:
+			unscanned = true
+			summary.perContainer[name] = "never scanned"
+		case f.Status == "IN_PROGRESS" || f.Status == "PENDING":
+			scanning = true
+			summary.perContainer[name] = "scan in progress"
+		default:
+			scanned = true
+			text := fmt.Sprintf("%d critical, %d high, %d medium, %d low", f.Critical, f.High, f.Medium, f.Low)
+			if !f.ScannedAt.IsZero() {
+				text += ", scanned " + zonedTime(f.ScannedAt, "2006-01-02 15:04")
+			}
+			if f.Status != "COMPLETE" && f.Status != "ACTIVE" {
+				text += " (" + strings.ToLower(f.Status) + ")"
+			}
+			summary.perContainer[name] = text
+
+			if counted[image] {
+				continue
+			}
+			counted[image] = true
+			summary.critical += f.Critical
+			summary.high += f.High
+			if f.Critical > 0 {
+				summary.criticalImages = append(summary.criticalImages, image[strings.LastIndex(image, "/")+1:])
+			}
+		}
+	}
+
+	switch {
+	case scanned:
+		summary.column = fmt.Sprintf("%d/%d", summary.critical, summary.high)
+	case scanning:
+		summary.column = "scanning"
+	case unscanned:
+		summary.column = "not scanned"
+	case failed:
+		summary.column = "?"
+	default:
+		summary.column = "-"
+	}
+	return summary
+}
+
+// formatContainers shows a value per container, e.g. "app: nginx:1.25;
+// envoy: envoy:v1.31", sorted by container name
+func formatContainers(values map[string]string) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + ": " + values[name]
+	}
+	return strings.Join(parts, "; ")
+}
+
+// findingsColor colors a findings column red for critical findings, yellow
+// for high ones, green for none and gray when nothing was scanned
+func findingsColor(summary string) tcell.Color {
+	var critical, high int
+	if _, err := fmt.Sscanf(summary, "%d/%d", &critical, &high); err != nil {
+		return tcell.ColorGray
+	}
+	switch {
+	case critical > 0:
+		return tcell.ColorRed
+	case high > 0:
+		return tcell.ColorYellow
+	default:
+		return tcell.ColorGreen
+	}
+}
+
+// ecsSummary counts the clusters, the degraded services and the services
+// running images with critical findings
+func ecsSummary(resources []Resource, failed int) (string, string) {
+	clusters := make(map[string]bool)
+	degraded, vulnerable := 0, 0
+	for _, res := range resources {
+		clusters[detailString(res, "Cluster")] = true
+		if res.State == "degraded" {
+			degraded++
+		}
+		if strings.Contains(detailString(res, "Flag"), "critical vulnerabilities") {
+			vulnerable++
+		}
+	}
+
+	message := fmt.Sprintf("%d services in %s, %d degraded, %d run images with critical vulnerabilities",
+		len(resources), pluralize(len(clusters), "cluster"), degraded, vulnerable)
+	switch {
+	case vulnerable > 0:
+		return message, "red"
+	case degraded > 0 || failed > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// ecsTasks is the state of the tasks view of a service
+type ecsTasks struct {
+	cluster    string
+	service    string
+	table      *tview.Table
+	containers *tview.TextView
+	loads      int
+}
+
+// showECSTasks lists the running and recently stopped tasks of the ECS
+// service res over the tab, with the containers of the selected task. r
+// reloads and q closes the view.
+func (rt *ResourcesTab) showECSTasks(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	t := &ecsTasks{
+		cluster:    detailString(res, "Cluster"),
+		service:    res.Name,
+		table:      workloadTable(" Tasks "),
+		containers: tview.NewTextView().SetDynamicColors(true).SetScrollable(true).SetWrap(true),
+	}
+	t.containers.SetBorder(true).SetTitleAlign(tview.AlignLeft).SetTitle(panelTitle("ecs.containers_title"))
+
+	load := func() {
+		t.loads++
+		gen := t.loads
+		setTableMessage(t.table, i18n.T("common.loading"), tcell.ColorGray)
+		t.containers.Clear()
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var tasks []clients.ECSTask
+			err := fmt.Errorf("ECS service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.ECS != nil {
+				tasks, err = svc.ECS.ListTasks(ctx, t.cluster, t.service)
+			}
+			if err != nil {
+				logger.Error("Failed to list ECS tasks", zap.String("cluster", t.cluster), zap.String("service", t.service), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != t.loads {
+					return
+				}
+				fillECSTasks(t.table, tasks, err)
+				showTaskContainers(t)
+			})
+		}()
+	}
+
+	t.table.SetSelectionChangedFunc(func(row, _ int) {
+		showTaskContainers(t)
+	})
+	t.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.view.RemovePage("ecs-tasks")
+			if rt.app != nil {
+				rt.app.SetFocus(rt.resourceTable)
+			}
+			return nil
+		case 'r':
+			load()
+			return nil
+		}
+		return event
+	})
+
+	view := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(t.table, 0, 1, true).
+		AddItem(t.containers, 0, 1, false)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("ecs.tasks_title", t.cluster, t.service))
+
+	rt.view.AddPage("ecs-tasks", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(t.table)
+	}
+	load()
+}
+
+// fillECSTasks lists tasks with their status and health; the task is the
+// reference of the first cell
+func fillECSTasks(table *tview.Table, tasks []clients.ECSTask, err error) {
+	if err != nil {
+		setTableMessage(table, i18n.T("ecs.tasks_failed", clients.ErrorReason(err)), tcell.ColorRed)
+		return
+	}
+	table.Clear()
+	setWorkloadHeader(table, false, "Task", "Status", "Health", "Age", "Definition", "Zone")
+	if len(tasks) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No tasks").SetTextColor(tcell.ColorGray).SetSelectable(false))
+		return
+	}
+
+	for i, task := range tasks {
+		status := task.LastStatus
+		if task.Stopped() && task.LastStatus != "STOPPED" {
+			status += " → STOPPED"
+		}
+		cells := []*tview.TableCell{
+			tview.NewTableCell(task.ID).SetExpansion(1),
+			tview.NewTableCell(status).SetTextColor(taskStatusColor(task)),
+			tview.NewTableCell(statusWords(task.HealthStatus)).SetTextColor(healthColor(task.HealthStatus)),
+			tview.NewTableCell(workloadAge(task.CreatedAt)).SetAlign(tview.AlignRight),
+			tview.NewTableCell(task.TaskDefinition),
+			tview.NewTableCell(task.Zone).SetTextColor(tcell.ColorGray),
+		}
+		cells[0].SetReference(task)
+		for col, cell := range cells {
+			table.SetCell(i+1, col, cell)
+		}
+	}
+	table.Select(1, 0)
+}
+
+// taskStatusColor colors running tasks green, starting ones yellow, tasks
+// that stopped as asked gray and tasks whose containers failed red
+func taskStatusColor(task clients.ECSTask) tcell.Color {
+	switch {
+	case task.Stopped():
+		for _, container := range task.Containers {
+			if container.ExitCode != nil && *container.ExitCode != 0 {
+				return tcell.ColorRed
+			}
+		}
+		return tcell.ColorGray
+	case task.LastStatus == "RUNNING":
+		return tcell.ColorGreen
+	default:
+		return tcell.ColorYellow
+	}
+}
+
+// healthColor colors a health check status
+func healthColor(status string) tcell.Color {
+	switch status {
+	case "HEALTHY":
+		return tcell.ColorGreen
+	case "UNHEALTHY":
+		return tcell.ColorRed
+	default:
+		return tcell.ColorGray
+	}
+}
+
+// showTaskContainers describes the selected task and its containers
+func showTaskContainers(t *ecsTasks) {
+	row, _ := t.table.GetSelection()
+	task, ok := t.table.GetCell(row, 0).GetReference().(clients.ECSTask)
+	if !ok {
+		t.containers.Clear()
+		return
+	}
+	t.containers.SetText(renderTaskContainers(task)).ScrollToBeginning()
+}
+
+// renderTaskContainers describes task with the status, health, exit code
+// and failure of each of its containers
+func renderTaskContainers(task clients.ECSTask) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "[yellow]Task %s[-] %s, %s CPU units, %s MiB", task.ID, orDash(task.LaunchType), orDash(task.CPU), orDash(task.Memory))
+	if !task.StartedAt.IsZero() {
+		fmt.Fprintf(&text, ", started %s ago", workloadAge(task.StartedAt))
+	}
+	if !task.StoppedAt.IsZero() {
+		fmt.Fprintf(&text, ", stopped %s ago", workloadAge(task.StoppedAt))
+	}
+	text.WriteString("\n")
+	if task.StoppedReason != "" {
+		fmt.Fprintf(&text, "Stopped: %s\n", tview.Escape(task.StoppedReason))
+	}
+	text.WriteString("\n")
+
+	for _, container := range task.Containers {
+		color := "green"
+		switch {
+		case container.ExitCode != nil && *container.ExitCode != 0, container.HealthStatus == "UNHEALTHY":
+			color = "red"
+		case container.LastStatus == "STOPPED":
+			color = "gray"
+		case container.LastStatus != "RUNNING":
+			color = "yellow"
+		}
+		fmt.Fprintf(&text, "[%s]%s[-] %s", color, tview.Escape(container.Name), statusWords(container.LastStatus))
+		if container.HealthStatus != "" && container.HealthStatus != "UNKNOWN" {
+			fmt.Fprintf(&text, ", %s", statusWords(container.HealthStatus))
+		}
+		if container.ExitCode != nil {
+			fmt.Fprintf(&text, ", exit code %d", *container.ExitCode)
+		}
+		fmt.Fprintf(&text, "\n  [gray]%s[-]\n", tview.Escape(container.Image))
+		if container.Reason != "" {
+			fmt.Fprintf(&text, "  %s\n", tview.Escape(container.Reason))
+		}
+	}
+	return text.String()
+}
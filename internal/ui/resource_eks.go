@@ -0,0 +1,411 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// eksView lists the EKS clusters; Enter shows the workloads of one
+type eksView struct{ baseView }
+
+var eksService = eksView{baseView{
+	info: ServiceInfo{Name: "eks", DisplayName: "EKS Clusters", Icon: "☸", Label: "EKS", Enabled: true, Permission: "eks:ListClusters"},
+	noun: "cluster",
+}}
+
+// ConsoleURL links the cluster
+func (eksView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/eks/home?%s#/clusters/%s", base, query, url.PathEscape(res.Name))
+}
+
+// Load lists the clusters
+func (eksView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadEKSClusters(ctx, client)
+}
+
+// Open shows the workloads of the cluster
+func (eksView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showWorkloads(resource)
+}
+
+// allNamespaces is the namespace list entry showing every namespace
+const allNamespaces = "All namespaces"
+
+// loadEKSClusters lists the EKS clusters of the region by name
+func (rt *ResourcesTab) loadEKSClusters(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.EKS == nil {
+		return nil, fmt.Errorf("EKS service not initialized")
+	}
+
+	clusters, err := svc.EKS.ListClusters(ctx)
+	if clusters == nil && err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(clusters))
+	for _, cluster := range clusters {
+		resources = append(resources, clusterResource(cluster, client.GetRegion()))
+	}
+	sort.SliceStable(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+	return resources, err
+}
+
+// clusterResource describes cluster with its version and endpoint access
+func clusterResource(cluster clients.EKSCluster, region string) Resource {
+	res := Resource{
+		ID:     cluster.Name,
+		Name:   cluster.Name,
+		Type:   "EKS Cluster",
+		State:  strings.ToLower(cluster.Status),
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":  cluster.ARN,
+			"View": "press Enter to browse its namespaces, deployments and pods",
+		},
+	}
+	if !cluster.CreatedAt.IsZero() {
+		res.CreatedDate = cluster.CreatedAt.Format("2006-01-02 15:04:05")
+	}
+	if cluster.Version != "" {
+		res.Details["Version"] = fmt.Sprintf("%s (%s)", cluster.Version, cluster.PlatformVersion)
+	}
+	switch {
+	case cluster.Endpoint == "":
+		res.Details["Endpoint"] = "not available yet"
+	case cluster.PublicEndpoint:
+		res.Details["Endpoint"] = "public"
+	default:
+		res.Details["Endpoint"] = "private only, reachable from inside the VPC"
+	}
+	return res
+}
+
+// workloads is the state of the workloads view of a cluster
+type workloads struct {
+	cluster     string
+	namespace   string
+	namespaces  *tview.List
+	deployments *tview.Table
+	pods        *tview.Table
+	loads       int
+}
+
+// showWorkloads browses the namespaces, deployments and pods of the EKS
+// cluster res over the tab. Enter on a namespace shows its workloads, l or
+// Enter on a pod streams its logs into the Logs tab; n, d and p move
+// between the lists, r reloads and q closes the view.
+func (rt *ResourcesTab) showWorkloads(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	w := &workloads{
+		cluster:     res.Name,
+		namespaces:  tview.NewList().ShowSecondaryText(false).SetHighlightFullLine(true),
+		deployments: workloadTable(" Deployments "),
+		pods:        workloadTable(" Pods (l: logs) "),
+	}
+	w.namespaces.SetBorder(true).SetTitleAlign(tview.AlignLeft).SetTitle(panelTitle("eks.namespaces_title"))
+
+	load := func() {
+		w.loads++
+		gen := w.loads
+		namespace := w.namespace
+		setTableMessage(w.deployments, i18n.T("common.loading"), tcell.ColorGray)
+		setTableMessage(w.pods, i18n.T("common.loading"), tcell.ColorGray)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var (
+				namespaces  []string
+				deployments []clients.Deployment
+				pods        []clients.Pod
+				scans       map[string]imageScan
+			)
+			nsErr := fmt.Errorf("EKS service not initialized")
+			depErr, podErr := nsErr, nsErr
+			if svc := client.GetClients(); svc != nil && svc.EKS != nil {
+				namespaces, nsErr = svc.EKS.ListNamespaces(ctx, w.cluster)
+				deployments, depErr = svc.EKS.ListDeployments(ctx, w.cluster, namespace)
+				pods, podErr = svc.EKS.ListPods(ctx, w.cluster, namespace)
+
+				var images []string
+				for _, d := range deployments {
+					for _, image := range d.Images {
+						images = append(images, image)
+					}
+				}
+				scans = scanImages(ctx, svc.ECR, images)
+			}
+			for _, err := range []error{nsErr, depErr, podErr} {
+				if err != nil {
+					logger.Error("Failed to read cluster workloads", zap.String("cluster", w.cluster), zap.String("namespace", namespace), zap.Error(err))
+					break
+				}
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != w.loads {
+					return
+				}
+				if nsErr == nil {
+					fillNamespaces(w, namespaces)
+				}
+				fillDeployments(w.deployments, deployments, scans, namespace == "", depErr)
+				fillPods(w.pods, pods, namespace == "", podErr)
+			})
+		}()
+	}
+
+	w.namespaces.SetSelectedFunc(func(index int, name, _ string, _ rune) {
+		if name == allNamespaces {
+			name = ""
+		}
+		w.namespace = name
+		load()
+		if rt.app != nil {
+			rt.app.SetFocus(w.pods)
+		}
+	})
+	w.pods.SetSelectedFunc(func(row, _ int) {
+		rt.streamPodLogs(w)
+	})
+
+	focus := func(p tview.Primitive) {
+		if rt.app != nil {
+			rt.app.SetFocus(p)
+		}
+	}
+	keys := func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeWorkloads()
+			return nil
+		case 'r':
+			load()
+			return nil
+		case 'n':
+			focus(w.namespaces)
+			return nil
+		case 'd':
+			focus(w.deployments)
+			return nil
+		case 'p':
+			focus(w.pods)
+			return nil
+		case 'l':
+			rt.streamPodLogs(w)
+			return nil
+		}
+		return event
+	}
+	w.namespaces.SetInputCapture(keys)
+	w.deployments.SetInputCapture(keys)
+	w.pods.SetInputCapture(keys)
+
+	view := tview.NewFlex().
+		AddItem(w.namespaces, 28, 0, true).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(w.deployments, 0, 1, false).
+			AddItem(w.pods, 0, 2, false), 0, 1, false)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("eks.workloads_title", w.cluster))
+
+	fillNamespaces(w, nil)
+	rt.view.AddPage("eks-workloads", view, true, true)
+	focus(w.namespaces)
+	load()
+}
+
+// closeWorkloads removes the workloads view and returns focus to the table
+func (rt *ResourcesTab) closeWorkloads() {
+	rt.view.RemovePage("eks-workloads")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// streamPodLogs asks the Logs tab to stream the logs of the selected pod
+func (rt *ResourcesTab) streamPodLogs(w *workloads) {
+	row, _ := w.pods.GetSelection()
+	pod, ok := w.pods.GetCell(row, 0).GetReference().(clients.Pod)
+	if !ok {
+		return
+	}
+	logger.Info("Emitting EventShowPodLogs", zap.String("cluster", w.cluster), zap.String("pod", pod.Name))
+	rt.events.Publish(ShowPodLogsEvent{Cluster: w.cluster, Pod: pod})
+}
+
+// workloadTable creates a selectable table titled title
+func workloadTable(title string) *tview.Table {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft).SetTitle(title)
+	return table
+}
+
+// fillNamespaces lists namespaces after the entry for all of them, keeping
+// the selected namespace
+func fillNamespaces(w *workloads, namespaces []string) {
+	w.namespaces.Clear()
+	w.namespaces.AddItem(allNamespaces, "", 0, nil)
+	for i, name := range namespaces {
+		w.namespaces.AddItem(name, "", 0, nil)
+		if name == w.namespace {
+			w.namespaces.SetCurrentItem(i + 1)
+		}
+	}
+}
+
+// setWorkloadHeader writes the header row of a workload table, starting
+// with the namespace column when all namespaces are shown
+func setWorkloadHeader(table *tview.Table, withNamespace bool, columns ...string) {
+	if withNamespace {
+		columns = append([]string{"Namespace"}, columns...)
+	}
+	for col, name := range columns {
+		table.SetCell(0, col, tview.NewTableCell(name).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+}
+
+// fillDeployments lists deployments with their replica counts and the
+// critical and high findings of their images in scans; those with fewer
+// ready replicas than wanted are yellow, those running images with critical
+// findings red
+func fillDeployments(table *tview.Table, deployments []clients.Deployment, scans map[string]imageScan, withNamespace bool, err error) {
+	if err != nil {
+		setTableMessage(table, i18n.T("eks.deployments_failed", err), tcell.ColorRed)
+		return
+	}
+	table.Clear()
+	setWorkloadHeader(table, withNamespace, "Name", "Ready", "Up-to-date", "Available", "Age", findingsColumn)
+	if len(deployments) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No deployments").SetTextColor(tcell.ColorGray).SetSelectable(false))
+		return
+	}
+
+	for i, d := range deployments {
+		color := tcell.ColorGreen
+		if d.Ready < d.Replicas {
+			color = tcell.ColorYellow
+		}
+		summary := summarizeScans(d.Images, scans)
+		name := tview.NewTableCell(d.Name).SetExpansion(1)
+		if summary.critical > 0 {
+			name.SetTextColor(tcell.ColorRed)
+		}
+		cells := []*tview.TableCell{
+			name,
+			tview.NewTableCell(fmt.Sprintf("%d/%d", d.Ready, d.Replicas)).SetTextColor(color).SetAlign(tview.AlignRight),
+			tview.NewTableCell(fmt.Sprint(d.UpToDate)).SetAlign(tview.AlignRight),
+			tview.NewTableCell(fmt.Sprint(d.Available)).SetAlign(tview.AlignRight),
+			tview.NewTableCell(workloadAge(d.CreatedAt)).SetAlign(tview.AlignRight),
+			tview.NewTableCell(summary.column).SetTextColor(findingsColor(summary.column)).SetAlign(tview.AlignRight),
+		}
+		if withNamespace {
+			cells = append([]*tview.TableCell{tview.NewTableCell(d.Namespace)}, cells...)
+		}
+		for col, cell := range cells {
+			table.SetCell(i+1, col, cell)
+		}
+	}
+	table.Select(1, 0)
+}
+
+// fillPods lists pods with their status and restarts. Pods that are not
+// running are colored by how they fail; the pod is the reference of the
+// first cell.
+func fillPods(table *tview.Table, pods []clients.Pod, withNamespace bool, err error) {
+	if err != nil {
+		setTableMessage(table, i18n.T("eks.pods_failed", err), tcell.ColorRed)
+		return
+	}
+	table.Clear()
+	setWorkloadHeader(table, withNamespace, "Name", "Ready", "Status", "Restarts", "Age", "Node")
+	if len(pods) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No pods").SetTextColor(tcell.ColorGray).SetSelectable(false))
+		return
+	}
+
+	for i, pod := range pods {
+		restarts := tview.NewTableCell(fmt.Sprint(pod.Restarts)).SetAlign(tview.AlignRight)
+		if pod.Restarts > 0 {
+			restarts.SetTextColor(tcell.ColorYellow)
+		}
+		cells := []*tview.TableCell{
+			tview.NewTableCell(pod.Name).SetExpansion(1),
+			tview.NewTableCell(fmt.Sprintf("%d/%d", pod.Ready, len(pod.Containers))).SetAlign(tview.AlignRight),
+			tview.NewTableCell(pod.Status).SetTextColor(podStatusColor(pod.Status)),
+			restarts,
+			tview.NewTableCell(workloadAge(pod.CreatedAt)).SetAlign(tview.AlignRight),
+			tview.NewTableCell(pod.Node).SetTextColor(tcell.ColorGray),
+		}
+		if withNamespace {
+			cells = append([]*tview.TableCell{tview.NewTableCell(pod.Namespace)}, cells...)
+		}
+		cells[0].SetReference(pod)
+		for col, cell := range cells {
+			table.SetCell(i+1, col, cell)
+		}
+	}
+	table.Select(1, 0)
+}
+
+// podStatusColor colors running pods green, starting ones yellow, finished
+// ones gray and everything else, such as CrashLoopBackOff, red
+func podStatusColor(status string) tcell.Color {
+	switch status {
+	case "Running":
+		return tcell.ColorGreen
+	case "Pending", "ContainerCreating", "PodInitializing", "Terminating":
+		return tcell.ColorYellow
+	case "Succeeded", "Completed":
+		return tcell.ColorGray
+	default:
+		return tcell.ColorRed
+	}
+}
+
+// workloadAge shows how long ago created was, like kubectl: 45s, 12m, 6h, 21d
+func workloadAge(created time.Time) string {
+	if created.IsZero() {
+		return "-"
+	}
+	d := time.Since(created)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// resourceRecord is the JSON representation of an exported resource
+type resourceRecord struct {
+	Name    string                 `json:"name"`
+	ID      string                 `json:"id"`
+	Type    string                 `json:"type"`
+	State   string                 `json:"state"`
+	Region  string                 `json:"region"`
+	Created string                 `json:"created"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	Tags    map[string]string      `json:"tags,omitempty"`
+}
+
+// detailKeys returns the sorted union of detail field names of the resources
+func detailKeys(resources []Resource) []string {
+	keys := make(map[string]struct{})
+	for _, res := range resources {
+		for key := range res.Details {
+			keys[key] = struct{}{}
+		}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// exportResources writes resources as "csv" or "json" including the given
+// detail fields and, optionally, their tags
+func exportResources(w io.Writer, format string, resources []Resource, fields []string, includeTags bool) error {
+	switch format {
+	case "csv":
+		return exportResourcesCSV(w, resources, fields, includeTags)
+	case "json":
+		return exportResourcesJSON(w, resources, fields, includeTags)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func exportResourcesCSV(w io.Writer, resources []Resource, fields []string, includeTags bool) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"Name", "ID", "Type", "State", "Region", "Created"}
+	header = append(header, fields...)
+	if includeTags {
+		header = append(header, "Tags")
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, res := range resources {
+		row := []string{res.Name, res.ID, res.Type, res.State, res.Region, res.CreatedDate}
+		for _, field := range fields {
+			value := ""
+			if v, ok := res.Details[field]; ok && v != nil {
+				value = fmt.Sprintf("%v", v)
+			}
+			row = append(row, value)
+		}
+		if includeTags {
+			row = append(row, formatTags(res.Tags))
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func exportResourcesJSON(w io.Writer, resources []Resource, fields []string, includeTags bool) error {
+	records := make([]resourceRecord, 0, len(resources))
+	for _, res := range resources {
+		record := resourceRecord{
+			Name:    res.Name,
+			ID:      res.ID,
+			Type:    res.Type,
+			State:   res.State,
+			Region:  res.Region,
+			Created: res.CreatedDate,
+		}
+
+		for _, field := range fields {
+			if v, ok := res.Details[field]; ok {
+				if record.Details == nil {
+					record.Details = make(map[string]interface{})
+				}
+				record.Details[field] = v
+			}
+		}
+		if includeTags && len(res.Tags) > 0 {
+			record.Tags = res.Tags
+		}
+
+		records = append(records, record)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(records); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
+
+// formatTags renders tags as sorted "key=value" pairs separated by semicolons
+func formatTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, tags[key]))
+	}
+	return strings.Join(pairs, ";")
+}
@@ -0,0 +1,375 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// instanceGrouping is a way to group the EC2 listing, keyed by what the
+// instances of a group have in common
+type instanceGrouping struct {
+	name string
+	// label is the catalog key of what the instances have in common, or of
+	// the tag they share the value of
+	label string
+	tag   string
+	key   func(res Resource) string
+}
+
+// title names what the instances of a group have in common
+func (g instanceGrouping) title() string {
+	if g.tag != "" {
+		return i18n.T(g.label, g.tag)
+	}
+	return i18n.T(g.label)
+}
+
+// instanceGroupings are cycled through with g, after which the listing is
+// grouped by the value of a tag
+var instanceGroupings = []instanceGrouping{
+	{name: "type", label: "groups.instance_type", key: func(res Resource) string { return detailString(res, "InstanceType") }},
+	{name: "az", label: "groups.availability_zone", key: func(res Resource) string { return detailString(res, "AvailabilityZone") }},
+	{name: "ami", label: "groups.ami", key: func(res Resource) string { return detailString(res, "ImageId") }},
+}
+
+// tagGrouping groups instances by the value of their tag key
+func tagGrouping(key string) instanceGrouping {
+	return instanceGrouping{
+		name:  "tag:" + key,
+		label: "groups.tag",
+		tag:   key,
+		key:   func(res Resource) string { return res.Tags[key] },
+	}
+}
+
+// detailString returns the detail key of res as a string, "" if not set
+func detailString(res Resource, key string) string {
+	value, _ := res.Details[key].(string)
+	return value
+}
+
+// resourceGroup is a group of the shown instances and how many of them run
+type resourceGroup struct {
+	key     string
+	members []int
+	running int
+	cost    float64
+}
+
+// resourceRow is a row of the grouped table: a group header, or the
+// instance res of visibleRes below it
+type resourceRow struct {
+	group *resourceGroup
+	res   int
+}
+
+// grouping returns how the shown listing is grouped, nil if it is not
+func (rt *ResourcesTab) grouping() *instanceGrouping {
+	if rt.shownService != "ec2" || rt.groupBy == "" {
+		return nil
+	}
+	if key, ok := strings.CutPrefix(rt.groupBy, "tag:"); ok {
+		g := tagGrouping(key)
+		return &g
+	}
+	for _, g := range instanceGroupings {
+		if g.name == rt.groupBy {
+			return &g
+		}
+	}
+	return nil
+}
+
+// cycleGrouping groups the EC2 listing the next way: by instance type,
+// availability zone, AMI, tag value and not at all
+func (rt *ResourcesTab) cycleGrouping() {
+	if rt.shownService != "ec2" {
+		rt.updateStatus(i18n.T("groups.ec2_only"), "yellow")
+		return
+	}
+
+	next := ""
+	switch {
+	case rt.groupBy == "":
+		next = instanceGroupings[0].name
+	case strings.HasPrefix(rt.groupBy, "tag:"):
+		next = ""
+	default:
+		for i, g := range instanceGroupings {
+			if g.name != rt.groupBy {
+				continue
+			}
+			if i+1 < len(instanceGroupings) {
+				next = instanceGroupings[i+1].name
+			} else {
+				rt.askGroupingTag()
+				return
+			}
+		}
+	}
+	rt.setGrouping(next)
+}
+
+// setGrouping groups the EC2 listing by groupBy, with all groups collapsed
+func (rt *ResourcesTab) setGrouping(groupBy string) {
+	rt.groupBy = groupBy
+	rt.expanded = make(map[string]bool)
+	rt.applyFilter()
+	if g := rt.grouping(); g != nil {
+		rt.updateStatus(i18n.T("groups.grouped", g.title()), "green")
+	} else {
+		rt.updateStatus(i18n.T("groups.off"), "green")
+	}
+}
+
+// askGroupingTag asks for the tag key to group by, suggesting the one used
+// last
+func (rt *ResourcesTab) askGroupingTag() {
+	input := tview.NewInputField().
+		SetLabel(i18n.T("groups.tag_key") + ": ").
+		SetText(rt.groupTag).
+		SetFieldWidth(0)
+	input.SetDoneFunc(func(key tcell.Key) {
+		rt.view.RemovePage("group-tag")
+		if rt.app != nil {
+			rt.app.SetFocus(rt.resourceTable)
+		}
+		tag := strings.TrimSpace(input.GetText())
+		if key != tcell.KeyEnter || tag == "" {
+			rt.setGrouping("")
+			return
+		}
+		rt.groupTag = tag
+		rt.setGrouping("tag:" + tag)
+	})
+	input.SetBorder(true).
+		SetTitle(panelTitle("groups.by_tag")).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("group-tag", centered(input, 50, 3), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(input)
+	}
+}
+
+// groupResources groups resources by g, the largest groups first
+func groupResources(resources []Resource, g instanceGrouping) []*resourceGroup {
+	byKey := make(map[string]*resourceGroup)
+	var groups []*resourceGroup
+	for i, res := range resources {
+		key := g.key(res)
+		group, ok := byKey[key]
+		if !ok {
+			group = &resourceGroup{key: key}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.members = append(group.members, i)
+		if isRunning(res) {
+			group.running++
+		}
+		group.cost += res.MonthlyCost
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if len(groups[i].members) != len(groups[j].members) {
+			return len(groups[i].members) > len(groups[j].members)
+		}
+		return groups[i].key < groups[j].key
+	})
+	return groups
+}
+
+// isRunning reports whether an instance counts as on
+func isRunning(res Resource) bool {
+	switch strings.ToLower(res.State) {
+	case "running", "pending":
+		return true
+	}
+	return false
+}
+
+// renderGroups fills the table with a header row per group of resources,
+// followed by the instances of the expanded groups
+func (rt *ResourcesTab) renderGroups(resources []Resource, g instanceGrouping, matches map[string][]string) {
+	groups := groupResources(resources, g)
+	rt.groupCount = len(groups)
+	rt.tableRows = rt.tableRows[:0]
+
+	row := 1
+	for _, group := range groups {
+		rt.tableRows = append(rt.tableRows, resourceRow{group: group, res: -1})
+		rt.renderGroupRow(row, group, g)
+		row++
+
+		if !rt.expanded[group.key] {
+			continue
+		}
+		for _, i := range group.members {
+			rt.tableRows = append(rt.tableRows, resourceRow{group: group, res: i})
+			rt.renderResourceRow(row, resources[i], "  "+resources[i].Name, matches[resources[i].ID])
+			row++
+		}
+	}
+}
+
+// renderGroupRow shows the size, on/off ratio and cost of group in row
+func (rt *ResourcesTab) renderGroupRow(row int, group *resourceGroup, g instanceGrouping) {
+	marker := "▸"
+	if rt.expanded[group.key] {
+		marker = "▾"
+	}
+	total := len(group.members)
+	stopped := total - group.running
+
+	ratioColor := tcell.ColorYellow
+	switch {
+	case stopped == 0:
+		ratioColor = tcell.ColorGreen
+	case group.running == 0:
+		ratioColor = tcell.ColorRed
+	}
+
+	rt.resourceTable.SetCell(row, 0, tview.NewTableCell(marker+" "+groupName(group.key, g)).
+		SetTextColor(tcell.ColorAqua).SetAttributes(tcell.AttrBold))
+	rt.resourceTable.SetCell(row, 1, tview.NewTableCell(i18n.N("count.instance", total)).SetTextColor(tcell.ColorAqua))
+	rt.resourceTable.SetCell(row, 2, tview.NewTableCell(g.title()).SetTextColor(tcell.ColorGray))
+	rt.resourceTable.SetCell(row, 3, tview.NewTableCell(i18n.T("groups.on_off", group.running, stopped)).SetTextColor(ratioColor))
+	rt.resourceTable.SetCell(row, 4, tview.NewTableCell(formatMonthlyCost(group.cost)).SetAlign(tview.AlignRight).SetTextColor(tcell.ColorAqua))
+	rt.resourceTable.SetCell(row, 5, tview.NewTableCell(""))
+	rt.resourceTable.SetCell(row, 6, tview.NewTableCell(""))
+}
+
+// groupName shows the key of a group, naming instances without one
+func groupName(key string, g instanceGrouping) string {
+	if key != "" {
+		return key
+	}
+	return "(" + i18n.T("groups.none", g.title()) + ")"
+}
+
+// pluralize returns "1 instance" or "n instances"
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// showGroupDetails summarizes group in the details panel
+func (rt *ResourcesTab) showGroupDetails(group *resourceGroup) {
+	g := rt.grouping()
+	if g == nil {
+		return
+	}
+	total := len(group.members)
+	stopped := total - group.running
+
+	title := []rune(g.title())
+	info := fmt.Sprintf("[yellow]%s:[-] %s\n%s %d\n%s %d (%d%%)\n%s %d\n",
+		strings.ToUpper(string(title[:1]))+string(title[1:]), groupName(group.key, *g),
+		infoLabel("groups.instances"), total,
+		infoLabel("groups.on"), group.running, group.running*100/total,
+		infoLabel("groups.off_count"), stopped)
+	if group.cost > 0 {
+		info += fmt.Sprintf("%s %s\n", infoLabel("resources.est_cost"), i18n.T("resources.per_month", formatMonthlyCost(group.cost)))
+	}
+
+	info += "\n" + infoLabel("groups.instances") + "\n"
+	for _, i := range group.members {
+		res := rt.visibleRes[i]
+		info += fmt.Sprintf("  %s (%s) %s\n", res.Name, res.ID, res.State)
+	}
+	info += "\n" + i18n.T("groups.hint")
+	rt.updateResourceInfo(info)
+}
+
+// resourceAt returns the resource shown in row, false for headers and group
+// rows
+func (rt *ResourcesTab) resourceAt(row int) (Resource, bool) {
+	if row <= 0 {
+		return Resource{}, false
+	}
+	if rt.tableRows == nil {
+		if row-1 >= len(rt.visibleRes) {
+			return Resource{}, false
+		}
+		return rt.visibleRes[row-1], true
+	}
+	if row-1 >= len(rt.tableRows) || rt.tableRows[row-1].res < 0 {
+		return Resource{}, false
+	}
+	return rt.visibleRes[rt.tableRows[row-1].res], true
+}
+
+// groupAt returns the group whose header is row, nil for other rows
+func (rt *ResourcesTab) groupAt(row int) *resourceGroup {
+	if rt.tableRows == nil || row <= 0 || row-1 >= len(rt.tableRows) || rt.tableRows[row-1].res >= 0 {
+		return nil
+	}
+	return rt.tableRows[row-1].group
+}
+
+// rowOf returns the row showing the resource id, 0 if it is not shown
+func (rt *ResourcesTab) rowOf(id string) int {
+	if rt.tableRows == nil {
+		for i, res := range rt.visibleRes {
+			if res.ID == id {
+				return i + 1
+			}
+		}
+		return 0
+	}
+	for i, r := range rt.tableRows {
+		if r.res >= 0 && rt.visibleRes[r.res].ID == id {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// groupRowOf returns the header row of the group key, or of the group of the
+// resource id if key is "", 0 if there is none
+func (rt *ResourcesTab) groupRowOf(key, id string) int {
+	for i, r := range rt.tableRows {
+		if r.res >= 0 {
+			continue
+		}
+		if id == "" && r.group.key == key {
+			return i + 1
+		}
+		for _, member := range r.group.members {
+			if id != "" && rt.visibleRes[member].ID == id {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
+
+// expandGroupOf expands the collapsed group of the shown resource id and
+// reports whether it did
+func (rt *ResourcesTab) expandGroupOf(id string) bool {
+	row := rt.groupRowOf("", id)
+	if row == 0 {
+		return false
+	}
+	group := rt.tableRows[row-1].group
+	if rt.expanded[group.key] {
+		return false
+	}
+	rt.expanded[group.key] = true
+	return true
+}
+
+// toggleGroup expands or collapses group, keeping its header selected
+func (rt *ResourcesTab) toggleGroup(group *resourceGroup) {
+	rt.expanded[group.key] = !rt.expanded[group.key]
+	rt.applyFilter()
+}
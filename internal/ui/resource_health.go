@@ -0,0 +1,304 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// healthView lists the AWS Health events; Enter shows one
+type healthView struct{ baseView }
+
+var healthService = healthView{baseView{
+	info: ServiceInfo{Name: "health", DisplayName: "Health Events", Icon: "🚑", Label: "HLT", Enabled: true, Permission: "health:DescribeEvents"},
+	noun: "event",
+}}
+
+// StateColor colors the status of the events
+func (healthView) StateColor(state string) tcell.Color {
+	return stateColor(healthStateColors, state)
+}
+
+// healthStateColors color the status of events
+var healthStateColors = map[string]tcell.Color{
+	"upcoming": tcell.ColorYellow,
+}
+
+// Load lists the events
+func (healthView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadHealthEvents(ctx, client)
+}
+
+// Summary sums up the events
+func (healthView) Summary(resources []Resource, failed int) (string, string) {
+	return healthSummary(resources)
+}
+
+// Open shows the event and the resources it affects
+func (healthView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showHealthEvent(resource)
+}
+
+// healthCategories names the categories of Health events
+var healthCategories = map[string]string{
+	"issue":               "Service Issue",
+	"scheduledChange":     "Scheduled Change",
+	"accountNotification": "Notification",
+	"investigation":       "Investigation",
+}
+
+// healthEntityColors colors the status of an affected entity
+var healthEntityColors = map[string]tcell.Color{
+	"IMPAIRED":   tcell.ColorRed,
+	"PENDING":    tcell.ColorYellow,
+	"UNIMPAIRED": tcell.ColorGreen,
+	"RESOLVED":   tcell.ColorGreen,
+}
+
+// isCriticalHealthEvent reports whether event is an ongoing service issue,
+// the events surfaced as a banner when they appear
+func isCriticalHealthEvent(event clients.HealthEvent) bool {
+	return event.Category == "issue" && event.Status == "open"
+}
+
+// newCriticalHealthEvents returns the critical events that are not in seen
+// and adds them to it
+func newCriticalHealthEvents(events []clients.HealthEvent, seen map[string]bool) []clients.HealthEvent {
+	var fresh []clients.HealthEvent
+	for _, event := range events {
+		if isCriticalHealthEvent(event) && !seen[event.ARN] {
+			seen[event.ARN] = true
+			fresh = append(fresh, event)
+		}
+	}
+	return fresh
+}
+
+// healthBanner announces the new critical events, e.g.
+// "AWS Health: EC2 issue in us-east-1 (and 1 more)"
+func healthBanner(events []clients.HealthEvent) string {
+	banner := fmt.Sprintf("AWS Health: %s issue in %s", events[0].Service, events[0].Region)
+	if len(events) > 1 {
+		banner += fmt.Sprintf(" (and %d more)", len(events)-1)
+	}
+	return banner
+}
+
+// loadHealthEvents lists the open and upcoming Health events, ongoing
+// issues first, then by start time
+func (rt *ResourcesTab) loadHealthEvents(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.Health == nil {
+		return nil, fmt.Errorf("Health service not initialized")
+	}
+
+	events, err := svc.Health.ListOpenEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := isCriticalHealthEvent(events[i]), isCriticalHealthEvent(events[j])
+		if a != b {
+			return a
+		}
+		return events[i].Start.Before(events[j].Start)
+	})
+
+	resources := make([]Resource, 0, len(events))
+	for _, event := range events {
+		resources = append(resources, healthEventResource(event))
+	}
+	return resources, nil
+}
+
+// healthEventResource describes event. Ongoing issues are alerts.
+func healthEventResource(event clients.HealthEvent) Resource {
+	category := healthCategories[event.Category]
+	if category == "" {
+		category = event.Category
+	}
+	scope := "public"
+	if event.AccountSpecific {
+		scope = "account specific"
+	}
+
+	// Event IDs repeat the event type, which is the name already
+	id := strings.TrimPrefix(event.ARN[strings.LastIndex(event.ARN, "/")+1:], event.Type+"_")
+
+	res := Resource{
+		ID:          id,
+		Name:        event.Type,
+		Type:        category,
+		State:       event.Status,
+		Region:      event.Region,
+		CreatedDate: event.Start.Format("2006-01-02 15:04:05"),
+		Tags:        make(map[string]string),
+		Alert:       isCriticalHealthEvent(event),
+		Details: map[string]interface{}{
+			"ARN":     event.ARN,
+			"Service": event.Service,
+			"Scope":   scope,
+			"View":    "press Enter for the description and affected resources",
+		},
+	}
+	if event.AvailabilityZone != "" {
+		res.Details["Availability Zone"] = event.AvailabilityZone
+	}
+	if !event.End.IsZero() {
+		res.Details["Ends"] = zonedTime(event.End, "2006-01-02 15:04:05")
+	}
+	if !event.LastUpdated.IsZero() {
+		res.Details["Last Updated"] = zonedTime(event.LastUpdated, "2006-01-02 15:04:05")
+	}
+	return res
+}
+
+// healthSummary counts the ongoing issues and the upcoming scheduled changes
+func healthSummary(resources []Resource) (string, string) {
+	var issues, scheduled int
+	for _, res := range resources {
+		switch {
+		case res.Alert:
+			issues++
+		case res.State == "upcoming":
+			scheduled++
+		}
+	}
+
+	message := fmt.Sprintf("%d events, %d open issues, %d upcoming changes", len(resources), issues, scheduled)
+	switch {
+	case issues > 0:
+		return message, "red"
+	case scheduled > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// showHealthEvent shows the description of the Health event res and the
+// resources it affects over the tab. The view closes with q.
+func (rt *ResourcesTab) showHealthEvent(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	arn := fmt.Sprint(res.Details["ARN"])
+
+	description := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetWordWrap(true).
+		SetText("[gray]" + i18n.T("common.loading") + "[-]")
+	description.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("health.event_title", res.Name, res.Region))
+
+	entities := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	entities.SetBorder(true).SetTitleAlign(tview.AlignLeft).SetTitle(panelTitle("health.affected_title"))
+	entities.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' {
+			rt.closeHealthEvent()
+			return nil
+		}
+		return event
+	})
+	setTableMessage(entities, i18n.T("common.loading"), tcell.ColorGray)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(description, 0, 1, false).
+		AddItem(entities, 0, 2, true)
+
+	rt.view.AddPage("health", layout, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(entities)
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var text string
+		var affected []clients.AffectedEntity
+		err := fmt.Errorf("Health service not initialized")
+		if svc := client.GetClients(); svc != nil && svc.Health != nil {
+			text, err = svc.Health.GetEventDescription(ctx, arn)
+			if err == nil {
+				affected, err = svc.Health.ListAffectedEntities(ctx, arn)
+			}
+		}
+		if err != nil {
+			logger.Error("Failed to describe health event", zap.String("event", arn), zap.Error(err))
+		}
+		if rt.app != nil {
+			rt.app.QueueUpdateDraw(func() {
+				if err != nil {
+					description.SetText("[red]" + i18n.T("health.event_failed", tview.Escape(err.Error())) + "[-]")
+					setTableMessage(entities, "", tcell.ColorGray)
+					return
+				}
+				description.SetText(tview.Escape(text)).ScrollToBeginning()
+				entities.SetTitle(panelTitle("health.affected_count_title", len(affected)))
+				fillAffectedEntities(entities, affected)
+			})
+		}
+	}()
+}
+
+// fillAffectedEntities lists the resources affected by an event with their
+// status colored
+func fillAffectedEntities(table *tview.Table, affected []clients.AffectedEntity) {
+	if len(affected) == 0 {
+		setTableMessage(table, i18n.T("health.no_resources"), tcell.ColorGray)
+		return
+	}
+
+	table.Clear()
+	for col, name := range []string{"Resource", "Status", "Last Updated", "ARN"} {
+		table.SetCell(0, col, tview.NewTableCell(name).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, entity := range affected {
+		color, ok := healthEntityColors[entity.Status]
+		if !ok {
+			color = tcell.ColorWhite
+		}
+		updated := ""
+		if !entity.LastUpdated.IsZero() {
+			updated = zonedTime(entity.LastUpdated, "2006-01-02 15:04")
+		}
+		table.SetCell(i+1, 0, tview.NewTableCell(entity.Value).SetMaxWidth(48))
+		table.SetCell(i+1, 1, tview.NewTableCell(entity.Status).SetTextColor(color))
+		table.SetCell(i+1, 2, tview.NewTableCell(updated))
+		table.SetCell(i+1, 3, tview.NewTableCell(entity.ARN).SetExpansion(1))
+	}
+	table.Select(1, 0).ScrollToBeginning()
+}
+
+// closeHealthEvent removes the Health event view and returns focus to the
+// table
+func (rt *ResourcesTab) closeHealthEvent() {
+	rt.view.RemovePage("health")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
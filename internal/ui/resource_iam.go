@@ -0,0 +1,356 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// iamView lists the IAM roles by use; Enter shows who may assume one and
+// what it accessed
+type iamView struct{ baseView }
+
+var iamService = iamView{baseView{
+	info: ServiceInfo{Name: "iam", DisplayName: "IAM Roles", Icon: "🔐", Label: "IAM", Enabled: true, Permission: "iam:ListRoles"},
+	noun: "role",
+}}
+
+// StateColor colors how recently the roles and keys were used
+func (iamView) StateColor(state string) tcell.Color {
+	return stateColor(iamStateColors, state)
+}
+
+// iamStateColors color how recently a role or key was used
+var iamStateColors = map[string]tcell.Color{
+	"unused": tcell.ColorRed,
+}
+
+// Load lists the roles
+func (iamView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadIAMRoles(ctx, client)
+}
+
+// Summary sums up the roles
+func (iamView) Summary(resources []Resource, failed int) (string, string) {
+	return iamSummary(resources, failed)
+}
+
+// Open shows the trust policy and access of the role
+func (iamView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showRoleAccess(resource)
+}
+
+// roleUnusedAfter is how long a role may go unused before it is flagged as a
+// candidate for removal
+const roleUnusedAfter = 90 * 24 * time.Hour
+
+// trustPolicy is the decoded trust policy of a role. It shows as one line
+// per statement in the details and is exported as that text.
+type trustPolicy []clients.TrustStatement
+
+func (p trustPolicy) String() string {
+	lines := make([]string, len(p))
+	for i, stmt := range p {
+		line := fmt.Sprintf("%s %s by %s", stmt.Effect, strings.Join(stmt.Actions, ", "), strings.Join(stmt.Principals, ", "))
+		if len(stmt.Conditions) > 0 {
+			line += " if " + strings.Join(stmt.Conditions, " and ")
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "; ")
+}
+
+func (p trustPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// loadIAMRoles lists the IAM roles of the account with who may assume them
+// and when they were last used. Roles unused for more than roleUnusedAfter
+// are flagged as candidates for removal.
+func (rt *ResourcesTab) loadIAMRoles(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.IAM == nil {
+		return nil, fmt.Errorf("IAM service not initialized")
+	}
+
+	roles, err := svc.IAM.ListRoles(ctx)
+	if roles == nil && err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resources := make([]Resource, 0, len(roles))
+	for _, role := range roles {
+		resources = append(resources, iamRoleResource(role, now))
+	}
+	return resources, err
+}
+
+// iamRoleResource describes role as of now
+func iamRoleResource(role clients.IAMRole, now time.Time) Resource {
+	res := Resource{
+		ID:          role.Name,
+		Name:        role.Name,
+		Type:        "IAM Role",
+		Region:      "global",
+		CreatedDate: role.CreatedAt.Format("2006-01-02 15:04:05"),
+		Tags:        make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":         role.ARN,
+			"Path":        role.Path,
+			"Max Session": (time.Duration(role.MaxSessionDuration) * time.Second).String(),
+			"View":        "press Enter for the trust policy and the services last accessed",
+		},
+	}
+	if role.Description != "" {
+		res.Details["Description"] = role.Description
+	}
+	if role.ServiceLinked() {
+		res.Type = "Service-linked Role"
+	}
+
+	if statements, err := clients.ParseTrustPolicy(role.TrustPolicy); err != nil {
+		res.Details["Trust Policy"] = "unreadable: " + err.Error()
+	} else {
+		res.Details["Trust Policy"] = trustPolicy(statements)
+	}
+
+	// IAM only tracks the last 400 days; a role unused since is as unused as
+	// one never used
+	lastUsed := role.LastUsed
+	if lastUsed.IsZero() {
+		lastUsed = role.CreatedAt
+		res.Details["Last Used"] = "never"
+	} else {
+		res.Details["Last Used"] = fmt.Sprintf("%s (%s ago) in %s",
+			zonedTime(role.LastUsed, "2006-01-02 15:04"), workloadAge(role.LastUsed), role.LastUsedRegion)
+	}
+
+	idle := now.Sub(lastUsed)
+	switch {
+	case idle <= roleUnusedAfter && role.LastUsed.IsZero():
+		res.State = "new"
+	case idle <= roleUnusedAfter:
+		res.State = "in use"
+	case role.ServiceLinked():
+		// The service owning the role decides when it goes
+		res.State = "idle"
+	default:
+		res.State = "unused"
+		res.Alert = true
+		days := int(idle.Hours() / 24)
+		if role.LastUsed.IsZero() {
+			res.Details["Flag"] = fmt.Sprintf("never used in the %d days since it was created, a candidate for removal", days)
+		} else {
+			res.Details["Flag"] = fmt.Sprintf("unused for %d days, a candidate for removal", days)
+		}
+	}
+	return res
+}
+
+// iamSummary counts the roles that are candidates for removal
+func iamSummary(resources []Resource, failed int) (string, string) {
+	unused := 0
+	for _, res := range resources {
+		if res.Alert {
+			unused++
+		}
+	}
+
+	message := fmt.Sprintf("%d roles, %d unused for more than %d days", len(resources), unused, int(roleUnusedAfter.Hours()/24))
+	switch {
+	case unused > 0:
+		return message, "red"
+	case failed > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// showRoleAccess shows the trust policy of the IAM role res as a table and
+// the services its policies allow with when it last used them. IAM
+// generates the services report on request, so it loads in the background.
+// The view closes with q.
+func (rt *ResourcesTab) showRoleAccess(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	arn := fmt.Sprint(res.Details["ARN"])
+
+	trust := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	trust.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("iam.trust_title", res.Name))
+	if statements, ok := res.Details["Trust Policy"].(trustPolicy); ok {
+		fillTrustPolicy(trust, statements)
+	} else {
+		setTableMessage(trust, i18n.T("iam.trust_failed", res.Details["Trust Policy"]), tcell.ColorRed)
+	}
+
+	services := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	services.SetBorder(true).SetTitleAlign(tview.AlignLeft).SetTitle(panelTitle("iam.services_title"))
+	setTableMessage(services, i18n.T("iam.generating"), tcell.ColorGray)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(trust, 0, 1, true).
+		AddItem(services, 0, 2, false)
+
+	capture := func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyTab:
+			if rt.app != nil {
+				if trust.HasFocus() {
+					rt.app.SetFocus(services)
+				} else {
+					rt.app.SetFocus(trust)
+				}
+			}
+			return nil
+		case event.Rune() == 'q':
+			rt.closeRoleAccess()
+			return nil
+		}
+		return event
+	}
+	trust.SetInputCapture(capture)
+	services.SetInputCapture(capture)
+
+	rt.view.AddPage("iam", layout, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(trust)
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var accessed []clients.ServiceLastAccessed
+		err := fmt.Errorf("IAM service not initialized")
+		if svc := client.GetClients(); svc != nil && svc.IAM != nil {
+			accessed, err = svc.IAM.GetServiceLastAccessed(ctx, arn)
+		}
+		if err != nil {
+			logger.Error("Failed to read services last accessed", zap.String("role", arn), zap.Error(err))
+		}
+		if rt.app != nil {
+			rt.app.QueueUpdateDraw(func() {
+				if err != nil {
+					setTableMessage(services, i18n.T("iam.services_failed", clients.ErrorReason(err)), tcell.ColorRed)
+					return
+				}
+				used := 0
+				for _, service := range accessed {
+					if !service.LastAuthenticated.IsZero() {
+						used++
+					}
+				}
+				services.SetTitle(panelTitle("iam.services_count_title", used, len(accessed)))
+				fillServicesLastAccessed(services, accessed, time.Now())
+			})
+		}
+	}()
+}
+
+// fillTrustPolicy lists the statements of a trust policy, a row per
+// principal
+func fillTrustPolicy(table *tview.Table, statements trustPolicy) {
+	if len(statements) == 0 {
+		setTableMessage(table, i18n.T("iam.no_statements"), tcell.ColorGray)
+		return
+	}
+
+	table.Clear()
+	for col, name := range []string{"Effect", "Principal", "Action", "Condition"} {
+		table.SetCell(0, col, tview.NewTableCell(name).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	row := 1
+	for _, stmt := range statements {
+		color := tcell.ColorGreen
+		if stmt.Effect != "Allow" {
+			color = tcell.ColorRed
+		}
+		conditions := strings.Join(stmt.Conditions, "; ")
+		if conditions == "" {
+			conditions = "-"
+		}
+		principals := stmt.Principals
+		if len(principals) == 0 {
+			principals = []string{"-"}
+		}
+		for _, principal := range principals {
+			table.SetCell(row, 0, tview.NewTableCell(stmt.Effect).SetTextColor(color))
+			table.SetCell(row, 1, tview.NewTableCell(tview.Escape(principal)).SetMaxWidth(64))
+			table.SetCell(row, 2, tview.NewTableCell(strings.Join(stmt.Actions, ", ")))
+			table.SetCell(row, 3, tview.NewTableCell(tview.Escape(conditions)).SetExpansion(1))
+			row++
+		}
+	}
+	table.Select(1, 0).ScrollToBeginning()
+}
+
+// fillServicesLastAccessed lists the services a role may use with when it
+// last did as of now; services unused for more than roleUnusedAfter are
+// yellow and services never used gray
+func fillServicesLastAccessed(table *tview.Table, services []clients.ServiceLastAccessed, now time.Time) {
+	if len(services) == 0 {
+		setTableMessage(table, i18n.T("iam.no_services"), tcell.ColorGray)
+		return
+	}
+
+	table.Clear()
+	for col, name := range []string{"Service", "Namespace", "Last Accessed", "Region"} {
+		table.SetCell(0, col, tview.NewTableCell(name).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, service := range services {
+		last, region, color := "never", "-", tcell.ColorGray
+		if !service.LastAuthenticated.IsZero() {
+			last = fmt.Sprintf("%s (%s ago)", zonedTime(service.LastAuthenticated, "2006-01-02 15:04"), workloadAge(service.LastAuthenticated))
+			region = service.LastAuthenticatedRegion
+			color = tcell.ColorWhite
+			if now.Sub(service.LastAuthenticated) > roleUnusedAfter {
+				color = tcell.ColorYellow
+			}
+		}
+		table.SetCell(i+1, 0, tview.NewTableCell(service.Service).SetTextColor(color))
+		table.SetCell(i+1, 1, tview.NewTableCell(service.Namespace))
+		table.SetCell(i+1, 2, tview.NewTableCell(last).SetTextColor(color))
+		table.SetCell(i+1, 3, tview.NewTableCell(region).SetExpansion(1))
+	}
+	table.Select(1, 0).ScrollToBeginning()
+}
+
+// closeRoleAccess removes the IAM role view and returns focus to the table
+func (rt *ResourcesTab) closeRoleAccess() {
+	rt.view.RemovePage("iam")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
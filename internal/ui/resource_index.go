@@ -0,0 +1,319 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/datetime/flexible"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
+	blevequery "github.com/blevesearch/bleve/v2/search/query"
+	"go.uber.org/zap"
+)
+
+const (
+	// resourceAnalyzer indexes a field as a single lowercased token, so that
+	// wildcard queries match anywhere in names and IDs regardless of case
+	resourceAnalyzer = "lowercase_keyword"
+	// resourceDateParser parses nothing, so tag values that look like dates
+	// are still indexed as text
+	resourceDateParser = "no_dates"
+)
+
+// resourceDocument is what the search index holds of a resource. Tags are
+// indexed both as "key=value" pairs and by key with lowercased keys, so
+// that tag.env:prod matches the tag Env=prod.
+type resourceDocument struct {
+	Name    string            `json:"name"`
+	ID      string            `json:"id"`
+	ARN     string            `json:"arn"`
+	Type    string            `json:"type"`
+	State   string            `json:"state"`
+	Service string            `json:"service"`
+	Region  string            `json:"region"`
+	Profile string            `json:"profile"`
+	Tags    []string          `json:"tags"`
+	Tag     map[string]string `json:"tag"`
+	// Scope is the profile and region of the listing, to search one of them
+	Scope string `json:"scope"`
+}
+
+// indexedResource is a resource of a cached listing with the profile, region
+// and service it was listed for
+type indexedResource struct {
+	Profile  string
+	Region   string
+	Service  string
+	Resource Resource
+}
+
+// resourceHit is a search result with the fields that matched
+type resourceHit struct {
+	indexedResource
+	Fields []string
+}
+
+// resourceIndex is a Bleve index over the resource listings of all profiles,
+// regions and services that were loaded, kept in step with the resource cache
+type resourceIndex struct {
+	mu    sync.RWMutex
+	index bleve.Index
+	// Document IDs by listing, to replace a listing when it is reloaded
+	keys map[string][]string
+	docs map[string]indexedResource
+}
+
+// newResourceIndex creates an empty in-memory resource index
+func newResourceIndex() (*resourceIndex, error) {
+	mapping := bleve.NewIndexMapping()
+	if err := mapping.AddCustomAnalyzer(resourceAnalyzer, map[string]interface{}{
+		"type":          custom.Name,
+		"tokenizer":     single.Name,
+		"token_filters": []string{lowercase.Name},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to register resource analyzer: %w", err)
+	}
+	mapping.DefaultAnalyzer = resourceAnalyzer
+	if err := mapping.AddCustomDateTimeParser(resourceDateParser, map[string]interface{}{
+		"type":    flexible.Name,
+		"layouts": []interface{}{},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to register resource date parser: %w", err)
+	}
+	mapping.DefaultDateTimeParser = resourceDateParser
+
+	// Fields not mapped here, the tags by key, are indexed dynamically with
+	// the default analyzer
+	docMapping := bleve.NewDocumentMapping()
+	for _, field := range []string{"name", "id", "arn", "type", "state", "service", "region", "profile", "tags", "scope"} {
+		fieldMapping := bleve.NewTextFieldMapping()
+		fieldMapping.Analyzer = resourceAnalyzer
+		fieldMapping.IncludeTermVectors = true
+		docMapping.AddFieldMappingsAt(field, fieldMapping)
+	}
+	mapping.AddDocumentMapping("_default", docMapping)
+
+	index, err := bleve.NewMemOnly(mapping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource index: %w", err)
+	}
+	return &resourceIndex{
+		index: index,
+		keys:  make(map[string][]string),
+		docs:  make(map[string]indexedResource),
+	}, nil
+}
+
+// Update replaces the listing of service in profile and region with resources
+func (ri *resourceIndex) Update(profile, region, service string, resources []Resource) error {
+	key := fmt.Sprintf("%s|%s|%s", profile, region, service)
+
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+
+	batch := ri.index.NewBatch()
+	for _, id := range ri.keys[key] {
+		batch.Delete(id)
+		delete(ri.docs, id)
+	}
+
+	ids := make([]string, 0, len(resources))
+	for _, res := range resources {
+		id := key + "|" + res.ID
+		if err := batch.Index(id, newResourceDocument(profile, region, service, res)); err != nil {
+			return fmt.Errorf("failed to index %s: %w", res.ID, err)
+		}
+		ids = append(ids, id)
+		ri.docs[id] = indexedResource{Profile: profile, Region: region, Service: service, Resource: res}
+	}
+	ri.keys[key] = ids
+
+	return ri.index.Batch(batch)
+}
+
+// Search returns up to limit resources matching text, the best matches
+// first. Unless profile and region are empty, only resources listed for them
+// are searched. See parseResourceQuery for the syntax of text.
+func (ri *resourceIndex) Search(text, profile, region string, limit int) ([]resourceHit, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	query, err := parseResourceQuery(text)
+	if err != nil {
+		return nil, err
+	}
+	if profile != "" && region != "" {
+		query = blevequery.NewConjunctionQuery([]blevequery.Query{query, resourceTermQuery("scope", profile+"|"+region)})
+	}
+	return ri.Query(query, limit)
+}
+
+// Query returns up to limit resources matching query, the best matches first
+func (ri *resourceIndex) Query(query blevequery.Query, limit int) ([]resourceHit, error) {
+	req := bleve.NewSearchRequestOptions(query, limit, 0, false)
+	req.IncludeLocations = true
+
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+
+	result, err := ri.index.Search(req)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]resourceHit, 0, len(result.Hits))
+	for _, match := range result.Hits {
+		doc, ok := ri.docs[match.ID]
+		if !ok {
+			continue
+		}
+		hit := resourceHit{indexedResource: doc}
+		for field := range match.Locations {
+			if field != "scope" {
+				hit.Fields = append(hit.Fields, field)
+			}
+		}
+		sort.Strings(hit.Fields)
+		hits = append(hits, hit)
+	}
+	return hits, nil
+}
+
+// Close releases the index
+func (ri *resourceIndex) Close() error {
+	return ri.index.Close()
+}
+
+// Len returns the number of indexed resources
+func (ri *resourceIndex) Len() int {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	return len(ri.docs)
+}
+
+// newResourceDocument describes res of the listing of service in profile
+// and region for the index
+func newResourceDocument(profile, region, service string, res Resource) resourceDocument {
+	doc := resourceDocument{
+		Name:    res.Name,
+		ID:      res.ID,
+		Type:    res.Type,
+		State:   res.State,
+		Service: service,
+		Region:  res.Region,
+		Profile: profile,
+		Tag:     make(map[string]string, len(res.Tags)),
+		Scope:   profile + "|" + region,
+	}
+	// Buckets and other global resources name their own region
+	if doc.Region == "" {
+		doc.Region = region
+	}
+	if arn, ok := res.Details["ARN"].(string); ok {
+		doc.ARN = arn
+	} else if strings.HasPrefix(res.ID, "arn:") {
+		doc.ARN = res.ID
+	}
+	for k, v := range res.Tags {
+		doc.Tags = append(doc.Tags, k+"="+v)
+		doc.Tag[strings.ToLower(k)] = v
+	}
+	return doc
+}
+
+// resourceJump is a resource picked in the search, selected as soon as the
+// listing of its service is shown
+type resourceJump struct {
+	service string
+	id      string
+}
+
+// indexListing adds the listing of service loaded with client to the search index
+func (rt *ResourcesTab) indexListing(client *aws.Client, service string, resources []Resource) {
+	if rt.index == nil {
+		return
+	}
+	if err := rt.index.Update(client.GetProfile(), client.GetRegion(), service, resources); err != nil {
+		logger.Warn("Failed to index resources", zap.String("service", service), zap.Error(err))
+	}
+}
+
+// SearchResources searches the cached listings of the current profile and
+// region, or of all profiles and regions if all is set
+func (rt *ResourcesTab) SearchResources(text string, all bool, limit int) ([]resourceHit, error) {
+	if rt.index == nil {
+		return nil, fmt.Errorf("resource search index not available")
+	}
+	var profile, region string
+	if !all && rt.awsClient != nil {
+		profile, region = rt.awsClient.GetProfile(), rt.awsClient.GetRegion()
+	}
+	return rt.index.Search(text, profile, region, limit)
+}
+
+// ShowResource shows the listing of service and selects the resource id in it
+func (rt *ResourcesTab) ShowResource(service, id string) {
+	for i, info := range rt.services {
+		if info.Name == service {
+			rt.serviceList.SetCurrentItem(i)
+			break
+		}
+	}
+	// A filter could hide the resource
+	rt.filterInput.SetText("")
+
+	rt.jump = resourceJump{service: service, id: id}
+	rt.loadService(service, false)
+}
+
+// Selection returns the service whose listing is shown and the resource
+// selected in it, nil if none is
+func (rt *ResourcesTab) Selection() (string, *Resource) {
+	if rt.shownService == "" || rt.selectedService != rt.shownService {
+		return rt.shownService, nil
+	}
+	return rt.shownService, rt.selectedRes
+}
+
+// selectJump selects the resource picked in the search once the listing of
+// its service is shown, and forgets it if the listing no longer has it once
+// all its pages loaded
+func (rt *ResourcesTab) selectJump() {
+	if rt.jump.id == "" || rt.jump.service != rt.shownService {
+		return
+	}
+	if rt.expandGroupOf(rt.jump.id) {
+		// Shows the resource and comes back here
+		rt.applyFilter()
+		return
+	}
+	jump := rt.jump
+	rt.jump = resourceJump{}
+
+	if row := rt.rowOf(jump.id); row > 0 {
+		// Selecting the selected row again shows no details
+		if selected, _ := rt.resourceTable.GetSelection(); selected == row {
+			rt.onResourceHighlighted(row, 0)
+		} else {
+			rt.resourceTable.Select(row, 0)
+		}
+		if rt.app != nil {
+			rt.app.SetFocus(rt.resourceTable)
+		}
+		return
+	}
+	if rt.morePages {
+		// It may be on a page still loading
+		rt.jump = jump
+		return
+	}
+	rt.updateStatus(i18n.T("search.gone", jump.id), "yellow")
+}
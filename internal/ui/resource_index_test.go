@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"testing"
+)
+
+func TestResourceIndexSearch(t *testing.T) {
+	index, err := newResourceIndex()
+	if err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+
+	mustUpdate := func(profile, region, service string, resources []Resource) {
+		t.Helper()
+		if err := index.Update(profile, region, service, resources); err != nil {
+			t.Fatalf("Failed to index %s: %v", service, err)
+		}
+	}
+	mustUpdate("dev", "eu-west-1", "ec2", []Resource{
+		{ID: "i-0abc", Name: "orders-api", Type: "t3.micro", State: "running", Tags: map[string]string{"env": "Prod"}},
+		{ID: "i-0def", Name: "batch-worker", Type: "t3.large", State: "stopped", Tags: map[string]string{"env": "staging"}},
+	})
+	mustUpdate("dev", "eu-west-1", "rds", []Resource{{ID: "orders-db", Name: "orders-db", State: "available"}})
+	mustUpdate("prod", "us-east-1", "ec2", []Resource{{ID: "i-0fff", Name: "orders-api", State: "running"}})
+
+	ids := func(hits []resourceHit) map[string]bool {
+		found := make(map[string]bool)
+		for _, hit := range hits {
+			found[hit.Resource.ID] = true
+		}
+		return found
+	}
+
+	hits, err := index.Search("ORDERS", "dev", "eu-west-1", 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if found := ids(hits); len(found) != 2 || !found["i-0abc"] || !found["orders-db"] {
+		t.Errorf("Expected orders-api and orders-db of dev, got %v", found)
+	}
+
+	// Every word has to match, in any field
+	hits, _ = index.Search("orders prod", "dev", "eu-west-1", 10)
+	if len(hits) != 1 || hits[0].Resource.ID != "i-0abc" {
+		t.Fatalf("Expected only i-0abc by name and tag, got %+v", hits)
+	}
+	if got := hits[0].Fields; len(got) != 2 || got[0] != "name" || got[1] != "tags" {
+		t.Errorf("Expected matches in name and tags, got %v", got)
+	}
+
+	hits, _ = index.Search("orders-api", "", "", 10)
+	if found := ids(hits); len(found) != 2 || !found["i-0fff"] {
+		t.Errorf("Expected orders-api of both profiles, got %v", found)
+	}
+
+	// A reload replaces the listing
+	mustUpdate("dev", "eu-west-1", "ec2", []Resource{{ID: "i-0def", Name: "batch-worker"}})
+	if hits, _ = index.Search("i-0abc", "", "", 10); len(hits) != 0 {
+		t.Errorf("Expected the removed instance to be gone, got %+v", hits)
+	}
+	if index.Len() != 3 {
+		t.Errorf("Expected 3 indexed resources, got %d", index.Len())
+	}
+
+	if hits, _ = index.Search("  ", "", "", 10); hits != nil {
+		t.Errorf("Expected no results for an empty search, got %+v", hits)
+	}
+}
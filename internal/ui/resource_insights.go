@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/insights"
+)
+
+// insightsView lists the cost and hygiene findings of the account
+type insightsView struct{ baseView }
+
+var insightsService = insightsView{baseView{
+	info: ServiceInfo{Name: "insights", DisplayName: "Insights", Icon: "💡", Label: "INS", Enabled: true, Permission: "ec2:DescribeVolumes"},
+	noun: "check",
+}}
+
+// Load lists the findings
+func (insightsView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadInsights(ctx, client)
+}
+
+// Summary sums up the findings
+func (insightsView) Summary(resources []Resource, failed int) (string, string) {
+	return insightsSummary(resources, failed)
+}
+
+// insightStates is the state shown for each kind of finding
+var insightStates = map[string]string{
+	insights.KindStoppedInstance:   "stopped",
+	insights.KindUnattachedVolume:  "unattached",
+	insights.KindUnattachedEIP:     "unattached",
+	insights.KindIdleFunction:      "idle",
+	insights.KindEmptyLoadBalancer: "empty",
+}
+
+// loadInsights lists likely wasted resources, the most expensive first. The
+// Cost/mo column shows what removing them would save.
+func (rt *ResourcesTab) loadInsights(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	findings, err := insights.Scan(ctx, client, time.Now())
+	if findings == nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(findings))
+	for _, finding := range findings {
+		name := finding.Name
+		if name == "" {
+			name = finding.ResourceID
+		}
+
+		savings := formatMonthlyCost(finding.MonthlySavings)
+		if savings == "" {
+			savings = "none"
+		}
+
+		res := Resource{
+			ID:          finding.ResourceID,
+			Name:        name,
+			Type:        finding.Kind,
+			State:       insightStates[finding.Kind],
+			Region:      finding.Region,
+			Tags:        make(map[string]string),
+			MonthlyCost: finding.MonthlySavings,
+			Details: map[string]interface{}{
+				"Finding":           finding.Reason,
+				"Estimated Savings": savings,
+			},
+		}
+		// ARNs are too long for the table, e.g. those of load balancers
+		if strings.HasPrefix(res.ID, "arn:") {
+			res.Details["ARN"] = res.ID
+			res.ID = name
+		}
+		resources = append(resources, res)
+	}
+
+	// A partial error keeps the findings of the checks that worked
+	return resources, err
+}
+
+// insightsSummary returns the status message and color for loaded findings
+func insightsSummary(resources []Resource, failedChecks int) (string, string) {
+	var total float64
+	for _, res := range resources {
+		total += res.MonthlyCost
+	}
+
+	message := fmt.Sprintf("%d findings, est. savings $%.2f/month", len(resources), total)
+	if failedChecks > 0 {
+		return fmt.Sprintf("%s, %d checks failed", message, failedChecks), "yellow"
+	}
+	return message, "green"
+}
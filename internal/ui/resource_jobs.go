@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/jobs"
+	"swiss-army-tui/internal/schedule"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// jobStateColors colors the state of a background job
+var jobStateColors = map[jobs.State]tcell.Color{
+	jobs.StateRunning:   tcell.ColorYellow,
+	jobs.StateSucceeded: tcell.ColorGreen,
+	jobs.StateFailed:    tcell.ColorRed,
+	jobs.StateCancelled: tcell.ColorGray,
+}
+
+// onJobChanged keeps the jobs view and the object browser current and
+// announces finished jobs in the footer. It is called from the job.
+func (rt *ResourcesTab) onJobChanged(job jobs.Job) {
+	if job.IsFinished() {
+		rt.events.Publish(jobToast(job))
+	}
+	if rt.app == nil {
+		return
+	}
+	rt.app.QueueUpdateDraw(func() {
+		if rt.jobsTable != nil {
+			fillJobs(rt.jobsTable, rt.jobs.Jobs(), rt.pendingSchedules())
+		}
+		if job.IsFinished() {
+			// The job may have changed the listed objects
+			rt.loadObjects()
+		} else if rt.objects != nil {
+			rt.objects.table.SetTitle(rt.objectsTitle(rt.objects))
+		}
+	})
+}
+
+// jobToast announces the outcome of the finished job
+func jobToast(job jobs.Job) Toast {
+	switch job.State {
+	case jobs.StateSucceeded:
+		return Toast{Message: fmt.Sprintf("Done: %s (%d %ss)", job.Title, job.Total, job.Noun), Color: "green"}
+	case jobs.StateCancelled:
+		return Toast{Message: fmt.Sprintf("Cancelled: %s after %d of %d", job.Title, job.Done, job.Total), Color: "yellow"}
+	default:
+		return Toast{Message: fmt.Sprintf("Failed: %s: %s", job.Title, jobError(job)), Color: "red"}
+	}
+}
+
+// jobError describes why job failed, summarizing failed items
+func jobError(job jobs.Job) string {
+	var partial *clients.PartialError
+	if errors.As(job.Err, &partial) {
+		return fmt.Sprintf("%d failed, %s", len(partial.Failures), clients.SummarizeFailures(partial.Failures, job.Noun))
+	}
+	return job.Err.Error()
+}
+
+func init() {
+	registerResourceActions(resourceAction{name: "jobs", key: 'J',
+		description: "action.jobs",
+		run:         (*ResourcesTab).showJobs})
+}
+
+// showJobs lists the background jobs over the tab, newest first, followed by
+// the pending schedules. x cancels the selected job or schedule, C clears the
+// finished jobs and q closes the view.
+func (rt *ResourcesTab) showJobs() {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("jobs.title"))
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeJobs()
+			return nil
+		case 'x':
+			row, _ := table.GetSelection()
+			switch ref := table.GetCell(row, 0).GetReference().(type) {
+			case int:
+				rt.jobs.Cancel(ref)
+			case schedule.Schedule:
+				rt.cancelSchedule(ref.ID)
+			}
+			return nil
+		case 'C':
+			rt.jobs.ClearFinished()
+			fillJobs(table, rt.jobs.Jobs(), rt.pendingSchedules())
+			return nil
+		}
+		return event
+	})
+
+	rt.jobsTable = table
+	fillJobs(table, rt.jobs.Jobs(), rt.pendingSchedules())
+	rt.view.AddPage("jobs", table, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(table)
+	}
+}
+
+// closeJobs removes the jobs view and returns focus to the view below it
+func (rt *ResourcesTab) closeJobs() {
+	rt.jobsTable = nil
+	rt.view.RemovePage("jobs")
+	if rt.app == nil {
+		return
+	}
+	if rt.objects != nil {
+		rt.app.SetFocus(rt.objects.table)
+	} else {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// fillJobs lists jobs with their progress and outcome, then the pending
+// schedules with when they are due, keeping the selection
+func fillJobs(table *tview.Table, list []jobs.Job, pending []schedule.Schedule) {
+	row, _ := table.GetSelection()
+	table.Clear()
+	for col, name := range []string{"State", "Job", "Progress", "Took", "Result"} {
+		table.SetCell(0, col, tview.NewTableCell(columnTitle(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	if len(list) == 0 && len(pending) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell(i18n.T("jobs.none")).SetTextColor(tcell.ColorGray).SetSelectable(false))
+		return
+	}
+
+	for i, job := range list {
+		progress := "-"
+		if job.Total > 0 {
+			progress = fmt.Sprintf("%d/%d (%d%%)", job.Done, job.Total, job.Done*100/job.Total)
+		}
+		end := job.Finished
+		if end.IsZero() {
+			end = time.Now()
+		}
+		result := ""
+		if job.Err != nil {
+			result = jobError(job)
+		}
+
+		table.SetCell(i+1, 0, tview.NewTableCell(string(job.State)).SetTextColor(jobStateColors[job.State]).SetReference(job.ID))
+		table.SetCell(i+1, 1, tview.NewTableCell(job.Title).SetMaxWidth(80))
+		table.SetCell(i+1, 2, tview.NewTableCell(progress).SetAlign(tview.AlignRight))
+		table.SetCell(i+1, 3, tview.NewTableCell(end.Sub(job.Started).Round(time.Second).String()).SetAlign(tview.AlignRight))
+		table.SetCell(i+1, 4, tview.NewTableCell(result).SetTextColor(tcell.ColorRed).SetExpansion(1))
+	}
+
+	now := time.Now()
+	for i, s := range pending {
+		r := len(list) + i + 1
+		table.SetCell(r, 0, tview.NewTableCell("scheduled").SetTextColor(tcell.ColorAqua).SetReference(s))
+		table.SetCell(r, 1, tview.NewTableCell(s.Title()).SetMaxWidth(80))
+		table.SetCell(r, 2, tview.NewTableCell("-").SetAlign(tview.AlignRight))
+		table.SetCell(r, 3, tview.NewTableCell("-").SetAlign(tview.AlignRight))
+		table.SetCell(r, 4, tview.NewTableCell("due "+formatScheduleTime(s.At, now)).SetTextColor(tcell.ColorAqua).SetExpansion(1))
+	}
+	table.Select(min(max(row, 1), len(list)+len(pending)), 0)
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// lambdaView lists the Lambda functions with their runtime and size.
+// Highlighting a function loads its extended configuration.
+type lambdaView struct{ baseView }
+
+var lambdaService = lambdaView{baseView{
+	info: ServiceInfo{Name: "lambda", DisplayName: "Lambda Functions", Icon: "⚡", Label: "LMB", Enabled: true, Permission: "lambda:ListFunctions"},
+	noun: "function",
+}}
+
+// ConsoleURL links the function
+func (lambdaView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/lambda/home?%s#/functions/%s", base, query, url.PathEscape(res.Name))
+}
+
+// ARN returns the ARN of the function
+func (lambdaView) ARN(account string, res Resource) string {
+	return fmt.Sprintf("arn:%s:lambda:%s:%s:function:%s", awsPartition(res.Region), res.Region, account, res.Name)
+}
+
+// Load lists the functions
+func (lambdaView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadLambdaFunctions(ctx, client)
+}
+
+// Actions shows the logs, the concurrency and the alias traffic of functions
+func (lambdaView) Actions() []resourceAction {
+	return []resourceAction{
+		{name: "lambda logs", key: 'l', description: "action.lambda_logs",
+			onResource: true, online: true, run: (*ResourcesTab).onLambdaLogsKey},
+		{name: "lambda concurrency", key: 'c', description: "action.lambda_concurrency",
+			onResource: true, online: true, run: (*ResourcesTab).onLambdaConcurrency},
+		{name: "lambda traffic", key: 't', description: "action.lambda_traffic",
+			onResource: true, online: true, run: (*ResourcesTab).onLambdaTraffic},
+	}
+}
+
+// loadLambdaFunctions loads Lambda functions using the Lambda service wrapper.
+func (rt *ResourcesTab) loadLambdaFunctions(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	// Listing is fast; the extended configuration is fetched when a function is focused
+	details, err := client.ListLambdaFunctions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []Resource
+	for _, d := range details {
+		res := Resource{
+			ID:          d.FunctionName,
+			Name:        d.FunctionName,
+			Type:        "Lambda Function",
+			State:       d.State,
+			Region:      client.GetRegion(),
+			CreatedDate: d.LastModified,
+			Tags:        make(map[string]string),
+			Details: map[string]interface{}{
+				"Runtime":      d.Runtime,
+				"MemorySize":   d.MemorySize,
+				"Timeout":      d.Timeout,
+				"LogGroupName": d.LogGroupName,
+			},
+		}
+		resources = append(resources, res)
+	}
+
+	return resources, nil
+}
+
+// LoadDetails fetches the extended configuration of the function
+func (lambdaView) LoadDetails(ctx context.Context, client *aws.Client, resource Resource) (Resource, error) {
+	detail, err := client.GetLambdaFunction(ctx, resource.ID)
+	if err != nil {
+		return Resource{}, err
+	}
+
+	details := make(map[string]interface{}, len(resource.Details)+8)
+	for key, value := range resource.Details {
+		details[key] = value
+	}
+	details["Handler"] = detail.Handler
+	details["Description"] = detail.Description
+	details["CodeSize"] = detail.CodeSize
+	details["StateReason"] = detail.StateReason
+	details["LastUpdateStatus"] = detail.LastUpdateStatus
+	details["SnapStartEnabled"] = detail.SnapStartEnabled
+	details["SnapStartStatus"] = detail.SnapStartStatus
+
+	resource.State = detail.State
+	resource.Details = details
+	return resource, nil
+}
+
+func (rt *ResourcesTab) onLambdaLogsKey() {
+	logger.Info("onLambdaLogsKey called", zap.String("selectedService", rt.selectedService))
+	if rt.selectedService != "lambda" {
+		logger.Info("Not lambda service, ignoring")
+		return
+	}
+
+	if rt.selectedRes == nil {
+		logger.Info("No resource selected")
+		return
+	}
+
+	logGroup := ""
+	if v, ok := rt.selectedRes.Details["LogGroupName"]; ok {
+		if s, ok := v.(string); ok {
+			logGroup = s
+		}
+	}
+
+	if logGroup == "" {
+		logGroup = fmt.Sprintf("/aws/lambda/%s", rt.selectedRes.Name)
+	}
+
+	logger.Info("Emitting EventShowLambdaLogs", zap.String("function", rt.selectedRes.Name), zap.String("logGroup", logGroup))
+	rt.events.Publish(ShowLambdaLogsEvent{Function: rt.selectedRes.Name, LogGroup: logGroup})
+}
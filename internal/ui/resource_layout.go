@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Widths of the Resources tab below which its layout changes
+const (
+	// narrowWidth collapses the details panel, which i then opens
+	narrowWidth = 120
+	// stackedWidth stacks the service list above the resources
+	stackedWidth = 80
+)
+
+// tabLayout is how the panels of the Resources tab are laid out
+type tabLayout int
+
+const (
+	// layoutWide shows the service list, resources and details side by side
+	layoutWide tabLayout = iota
+	// layoutNarrow shows the service list and resources, the details on demand
+	layoutNarrow
+	// layoutStacked shows the service list above the resources
+	layoutStacked
+)
+
+// layoutFor returns the layout of a tab width columns wide
+func layoutFor(width int) tabLayout {
+	switch {
+	case width < stackedWidth:
+		return layoutStacked
+	case width < narrowWidth:
+		return layoutNarrow
+	default:
+		return layoutWide
+	}
+}
+
+// fitLayout is the draw func of the main layout, which lays out the panels
+// anew when the tab is resized past a breakpoint
+func (rt *ResourcesTab) fitLayout(_ tcell.Screen, x, y, width, height int) (int, int, int, int) {
+	rt.width, rt.height = width, height
+	if layout := layoutFor(width); layout != rt.layout {
+		rt.applyLayout(layout)
+		if layout == layoutWide && rt.view.HasPage("details") && rt.app != nil {
+			// The details are back in their panel. Focus cannot change
+			// while drawing.
+			go rt.app.QueueUpdateDraw(rt.closeDetails)
+		}
+	}
+	return x, y, width, height
+}
+
+// applyLayout lays out the panels of the main layout
+func (rt *ResourcesTab) applyLayout(layout tabLayout) {
+	rt.layout = layout
+	rt.mainLayout.Clear()
+	switch layout {
+	case layoutStacked:
+		rt.mainLayout.SetDirection(tview.FlexRow).
+			AddItem(rt.leftPanel, 0, 1, true).
+			AddItem(rt.centerPanel, 0, 2, false)
+	case layoutNarrow:
+		rt.mainLayout.SetDirection(tview.FlexColumn).
+			AddItem(rt.leftPanel, 30, 0, true).
+			AddItem(rt.centerPanel, 0, 1, false)
+	default:
+		rt.mainLayout.SetDirection(tview.FlexColumn).
+			AddItem(rt.leftPanel, 30, 0, true).
+			AddItem(rt.centerPanel, 0, 2, false).
+			AddItem(rt.resourceInfo, 40, 0, false)
+	}
+}
+
+// showDetails opens the details of the selected resource over the tab while
+// the layout has no details panel
+func (rt *ResourcesTab) showDetails() {
+	if rt.layout == layoutWide {
+		rt.updateStatus(i18n.T("resources.details_shown"), "yellow")
+		return
+	}
+
+	rt.resourceInfo.SetTitle(panelTitle("resources.details_modal"))
+	rt.resourceInfo.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' {
+			rt.closeDetails()
+			return nil
+		}
+		return event
+	})
+	rt.resourceInfo.ScrollToBeginning()
+
+	rt.setOverlay(rt.closeDetails)
+	rt.view.AddPage("details", centered(rt.resourceInfo, min(80, rt.width-2), max(rt.height-4, 10)), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceInfo)
+	}
+}
+
+// closeDetails closes the details opened by showDetails
+func (rt *ResourcesTab) closeDetails() {
+	if !rt.view.HasPage("details") {
+		return
+	}
+	rt.setOverlay(nil)
+	rt.view.RemovePage("details")
+	rt.resourceInfo.SetTitle(panelTitle("resources.details"))
+	rt.resourceInfo.SetInputCapture(nil)
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
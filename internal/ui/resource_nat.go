@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/pricing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// natGatewaysView lists the NAT gateways with their traffic
+type natGatewaysView struct{ baseView }
+
+var natGatewaysService = natGatewaysView{baseView{
+	info: ServiceInfo{Name: "natgateways", DisplayName: "NAT Gateways", Icon: "🔀", Label: "NAT", Enabled: true, Permission: "ec2:DescribeNatGateways"},
+	noun: "NAT gateway",
+}}
+
+// ConsoleURL links the NAT gateway
+func (natGatewaysView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/vpcconsole/home?%s#NatGatewayDetails:natGatewayId=%s", base, query, res.ID)
+}
+
+// Load lists the NAT gateways
+func (natGatewaysView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadNATGateways(ctx, client)
+}
+
+// natTrafficWindow is how far back the traffic of a NAT gateway is summed to
+// estimate a month of it
+const natTrafficWindow = 7 * 24 * time.Hour
+
+// A NAT gateway has unusually high traffic when it processes natHighTrafficFactor
+// times the median of the gateways of the region and at least natHighTrafficMinGB
+// a month, or natHighTrafficGB a month on its own
+const (
+	natHighTrafficFactor = 3
+	natHighTrafficMinGB  = 100
+	natHighTrafficGB     = 5000
+)
+
+// natTraffic is what a NAT gateway moved over natTrafficWindow in bytes
+type natTraffic struct {
+	toDestination   float64
+	fromSource      float64
+	fromDestination float64
+}
+
+// processed returns the bytes the gateway processed and is charged for: what
+// came in from the private subnets and what came back from the destinations
+func (t natTraffic) processed() float64 {
+	return t.fromSource + t.fromDestination
+}
+
+// monthlyGB extrapolates the processed bytes of the window to a month in GB
+func (t natTraffic) monthlyGB() float64 {
+	return t.processed() / 1e9 * pricing.HoursPerMonth / natTrafficWindow.Hours()
+}
+
+// loadNATGateways lists the NAT gateways of the region with their traffic
+// over the last week and an estimate of their monthly cost, the busiest
+// first. Gateways whose traffic could not be read are listed without it and
+// named in a PartialError.
+func (rt *ResourcesTab) loadNATGateways(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.EC2 == nil || svc.CloudWatch == nil {
+		return nil, fmt.Errorf("EC2 service not initialized")
+	}
+
+	gateways, err := svc.EC2.DescribeNatGateways(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	region := client.GetRegion()
+	end := time.Now()
+	start := end.Add(-natTrafficWindow)
+
+	var (
+		resources []Resource
+		traffic   = make(map[string]natTraffic)
+		failures  []clients.ItemError
+	)
+	for _, gateway := range gateways {
+		// Deleted gateways stay listed for a while but no longer cost anything
+		if gateway.State == types.NatGatewayStateDeleted {
+			continue
+		}
+		id := awssdk.ToString(gateway.NatGatewayId)
+		if gateway.State == types.NatGatewayStateAvailable {
+			t, err := natGatewayTraffic(ctx, svc.CloudWatch, id, start, end)
+			if err != nil {
+				failures = append(failures, clients.ItemError{Item: id, Region: region, Err: err})
+			} else {
+				traffic[id] = t
+			}
+		}
+		resources = append(resources, natGatewayResource(gateway, region))
+	}
+
+	median := medianMonthlyGB(traffic)
+	for i := range resources {
+		if t, ok := traffic[resources[i].ID]; ok {
+			addNATTraffic(&resources[i], t, median, region)
+		}
+	}
+	sort.SliceStable(resources, func(i, j int) bool { return resources[i].MonthlyCost > resources[j].MonthlyCost })
+
+	if len(failures) > 0 {
+		return resources, &clients.PartialError{Op: "nat gateways", Failures: failures}
+	}
+	return resources, nil
+}
+
+// natGatewayTraffic sums the traffic of the gateway id between start and end
+func natGatewayTraffic(ctx context.Context, cw aws.CloudWatchService, id string, start, end time.Time) (natTraffic, error) {
+	var t natTraffic
+	for metric, sum := range map[string]*float64{
+		"BytesOutToDestination":  &t.toDestination,
+		"BytesInFromSource":      &t.fromSource,
+		"BytesInFromDestination": &t.fromDestination,
+	} {
+		value, err := cw.MetricSum(ctx, "AWS/NATGateway", metric, "NatGatewayId", id, start, end)
+		if err != nil {
+			return natTraffic{}, err
+		}
+		*sum = value
+	}
+	return t, nil
+}
+
+// natGatewayResource describes gateway without its traffic
+func natGatewayResource(gateway types.NatGateway, region string) Resource {
+	id := awssdk.ToString(gateway.NatGatewayId)
+	res := Resource{
+		ID:     id,
+		Name:   id,
+		Type:   "NAT Gateway",
+		State:  string(gateway.State),
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"VPC":          awssdk.ToString(gateway.VpcId),
+			"Subnet":       awssdk.ToString(gateway.SubnetId),
+			"Connectivity": string(gateway.ConnectivityType),
+		},
+	}
+	for _, tag := range gateway.Tags {
+		res.Tags[awssdk.ToString(tag.Key)] = awssdk.ToString(tag.Value)
+		if awssdk.ToString(tag.Key) == "Name" {
+			res.Name = awssdk.ToString(tag.Value)
+		}
+	}
+	if gateway.CreateTime != nil {
+		res.CreatedDate = gateway.CreateTime.Format("2006-01-02 15:04:05")
+	}
+	return res
+}
+
+// addNATTraffic adds the traffic t of a gateway and its estimated monthly
+// cost to res, flagging it when it processes far more than the median
+// gateway of the region
+func addNATTraffic(res *Resource, t natTraffic, medianGB float64, region string) {
+	gb := t.monthlyGB()
+	res.Details["Bytes Out To Destination (7d)"] = formatBytes(int64(t.toDestination))
+	res.Details["Bytes In From Source (7d)"] = formatBytes(int64(t.fromSource))
+	res.Details["Bytes In From Destination (7d)"] = formatBytes(int64(t.fromDestination))
+	res.Details["Processed per Month (est.)"] = fmt.Sprintf("%.0f GB", gb)
+
+	if hourly, processing, ok := pricing.NATGatewayMonthly(gb, region); ok {
+		res.MonthlyCost = hourly + processing
+		res.Details["Hourly Charge/mo"] = fmt.Sprintf("$%.2f", hourly)
+		res.Details["Data Processing/mo"] = fmt.Sprintf("$%.2f", processing)
+	}
+
+	if !isHighNATTraffic(gb, medianGB) {
+		return
+	}
+	res.Alert = true
+	flag := fmt.Sprintf("processes %.0f GB a month, %.0fx the median gateway of the region", gb, gb/max(medianGB, 1))
+	// Downloads dominate, e.g. from S3 or ECR, which a VPC endpoint serves
+	// without the processing charge
+	if t.fromDestination > 2*t.fromSource {
+		flag += "; mostly responses, check whether S3, DynamoDB or ECR traffic could use a VPC endpoint"
+	}
+	res.Details["Flag"] = flag
+}
+
+// isHighNATTraffic reports whether a gateway processing gb a month has
+// unusually high traffic compared to the median gateway
+func isHighNATTraffic(gb, medianGB float64) bool {
+	return gb >= natHighTrafficGB || (gb >= natHighTrafficMinGB && gb >= natHighTrafficFactor*medianGB)
+}
+
+// medianMonthlyGB returns the median monthly processed GB of the gateways
+func medianMonthlyGB(traffic map[string]natTraffic) float64 {
+	if len(traffic) == 0 {
+		return 0
+	}
+	gbs := make([]float64, 0, len(traffic))
+	for _, t := range traffic {
+		gbs = append(gbs, t.monthlyGB())
+	}
+	sort.Float64s(gbs)
+	mid := len(gbs) / 2
+	if len(gbs)%2 == 0 {
+		return (gbs[mid-1] + gbs[mid]) / 2
+	}
+	return gbs[mid]
+}
@@ -0,0 +1,525 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/jobs"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// objectStorageClasses are the storage classes objects can be changed to
+var objectStorageClasses = []string{
+	"STANDARD", "INTELLIGENT_TIERING", "STANDARD_IA", "ONEZONE_IA", "GLACIER_IR", "GLACIER", "DEEP_ARCHIVE",
+}
+
+// objectDeleteBatch is how many keys a delete job removes between progress
+// updates
+const objectDeleteBatch = 1000
+
+// objectBrowser is the open S3 object browser: the objects and prefixes of
+// bucket directly below prefix
+type objectBrowser struct {
+	client  *aws.Client
+	bucket  string
+	prefix  string
+	objects []clients.S3Object
+	table   *tview.Table
+	loads   int
+}
+
+// location returns the S3 URI of key in the browsed bucket
+func (b *objectBrowser) location(key string) string {
+	return fmt.Sprintf("s3://%s/%s", b.bucket, key)
+}
+
+// selected returns the object or prefix of the selected row, nil for the
+// parent row and while nothing is listed
+func (b *objectBrowser) selected() *clients.S3Object {
+	row, _ := b.table.GetSelection()
+	if row <= 0 {
+		return nil
+	}
+	object, _ := b.table.GetCell(row, 0).GetReference().(*clients.S3Object)
+	return object
+}
+
+// showObjects opens the object browser of bucket over the tab. Enter opens
+// a prefix or previews an object, Backspace goes up, d deletes, c copies, m moves and s changes
+// the storage class of the selected object or prefix as background jobs. J
+// lists the jobs, r reloads and q closes the browser.
+func (rt *ResourcesTab) showObjects(bucket string) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	browser := &objectBrowser{client: rt.awsClient, bucket: bucket, table: table}
+	rt.objects = browser
+
+	table.SetSelectedFunc(func(row, column int) {
+		if row <= 0 {
+			return
+		}
+		object := browser.selected()
+		switch {
+		case object == nil:
+			rt.openPrefix(parentPrefix(browser.prefix))
+		case object.IsPrefix:
+			rt.openPrefix(object.Key)
+		default:
+			rt.showObjectPreview(*object)
+		}
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if browser.prefix != "" {
+				rt.openPrefix(parentPrefix(browser.prefix))
+			}
+			return nil
+		}
+		switch event.Rune() {
+		case 'q':
+			rt.closeObjects()
+			return nil
+		case 'r':
+			rt.loadObjects()
+			return nil
+		case 'J':
+			rt.showJobs()
+			return nil
+		case 'd':
+			if object := browser.selected(); object != nil {
+				rt.confirmDeleteObjects(*object)
+			}
+			return nil
+		case 'c', 'm':
+			if object := browser.selected(); object != nil {
+				rt.askObjectDestination(*object, event.Rune() == 'm')
+			}
+			return nil
+		case 's':
+			if object := browser.selected(); object != nil {
+				rt.chooseStorageClass(*object)
+			}
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("s3-objects", table, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(table)
+	}
+	rt.loadObjects()
+}
+
+// closeObjects removes the object browser and returns focus to the table
+func (rt *ResourcesTab) closeObjects() {
+	rt.objects = nil
+	rt.view.RemovePage("s3-objects")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// openPrefix lists the objects below prefix in the object browser
+func (rt *ResourcesTab) openPrefix(prefix string) {
+	if rt.objects == nil {
+		return
+	}
+	rt.objects.prefix = prefix
+	rt.objects.objects = nil
+	rt.loadObjects()
+}
+
+// parentPrefix returns the prefix one level above prefix, "" at the top
+func parentPrefix(prefix string) string {
+	parent := path.Dir(strings.TrimSuffix(prefix, "/"))
+	if parent == "." {
+		return ""
+	}
+	return parent + "/"
+}
+
+// loadObjects lists the objects of the browser in the background
+func (rt *ResourcesTab) loadObjects() {
+	browser := rt.objects
+	if browser == nil {
+		return
+	}
+
+	browser.table.SetTitle(rt.objectsTitle(browser))
+	setTableMessage(browser.table, i18n.T("common.loading"), tcell.ColorGray)
+	browser.loads++
+	gen := browser.loads
+	bucket, prefix := browser.bucket, browser.prefix
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		svc := browser.client.GetClients()
+		var objects []clients.S3Object
+		err := fmt.Errorf("S3 service not initialized")
+		if svc != nil && svc.S3 != nil {
+			objects, err = svc.S3.ListObjects(ctx, bucket, prefix, false)
+		}
+		if err != nil {
+			logger.Error("Failed to list objects", zap.String("bucket", bucket), zap.String("prefix", prefix), zap.Error(err))
+		}
+		if rt.app == nil {
+			return
+		}
+		rt.app.QueueUpdateDraw(func() {
+			if gen != browser.loads {
+				return
+			}
+			if err != nil {
+				setTableMessage(browser.table, i18n.T("objects.list_failed", clients.ErrorReason(err)), tcell.ColorRed)
+				return
+			}
+			browser.objects = objects
+			fillObjects(browser.table, prefix, objects)
+			browser.table.SetTitle(rt.objectsTitle(browser))
+		})
+	}()
+}
+
+// objectsTitle names the listed location of browser and the running jobs
+func (rt *ResourcesTab) objectsTitle(browser *objectBrowser) string {
+	title := fmt.Sprintf(" %s (%d)", browser.location(browser.prefix), len(browser.objects))
+	if running := rt.jobs.Running(); running > 0 {
+		title += fmt.Sprintf(" - %d jobs running", running)
+	}
+	return title + " (d: delete, c: copy, m: move, s: storage class, J: jobs, q: close) "
+}
+
+// fillObjects lists objects below prefix, prefixes first, with a row to go
+// up unless prefix is the top of the bucket
+func fillObjects(table *tview.Table, prefix string, objects []clients.S3Object) {
+	table.Clear()
+	for col, name := range []string{"Key", "Size", "Storage Class", "Last Modified"} {
+		table.SetCell(0, col, tview.NewTableCell(name).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	row := 1
+	if prefix != "" {
+		table.SetCell(row, 0, tview.NewTableCell("../").SetTextColor(tcell.ColorBlue).SetExpansion(1))
+		row++
+	}
+	for i := range objects {
+		object := &objects[i]
+		name := strings.TrimPrefix(object.Key, prefix)
+		if object.IsPrefix {
+			table.SetCell(row, 0, tview.NewTableCell(name).SetTextColor(tcell.ColorBlue).SetExpansion(1).SetReference(object))
+			row++
+			continue
+		}
+		table.SetCell(row, 0, tview.NewTableCell(name).SetExpansion(1).SetReference(object))
+		table.SetCell(row, 1, tview.NewTableCell(formatBytes(object.Size)).SetAlign(tview.AlignRight))
+		table.SetCell(row, 2, tview.NewTableCell(object.StorageClass))
+		table.SetCell(row, 3, tview.NewTableCell(zonedTime(object.LastModified, "2006-01-02 15:04")))
+		row++
+	}
+	if row == 1 {
+		table.SetCell(row, 0, tview.NewTableCell("No objects").SetTextColor(tcell.ColorGray).SetSelectable(false))
+	}
+	table.Select(1, 0)
+}
+
+// confirmDeleteObjects asks before deleting object. Deleting a prefix
+// deletes every object below it and has to be confirmed by typing the prefix.
+func (rt *ResourcesTab) confirmDeleteObjects(object clients.S3Object) {
+	browser := rt.objects
+	location := browser.location(object.Key)
+	start := func() {
+		rt.startObjectJob(fmt.Sprintf("Delete %s", location), "s3:DeleteObjects", location,
+			func(ctx context.Context, svc aws.S3Service, progress jobs.Progress) error {
+				return deleteObjects(ctx, svc, browser.bucket, object.Key, progress)
+			})
+	}
+
+	if !object.IsPrefix {
+		modal := tview.NewModal().
+			SetText(i18n.T("dialog.delete_object", location)).
+			AddButtons([]string{i18n.T("dialog.delete"), i18n.T("common.cancel")}).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				rt.closeObjectDialog()
+				if buttonIndex == 0 {
+					start()
+				}
+			})
+		rt.view.AddPage("s3-objects-dialog", modal, false, true)
+		return
+	}
+
+	typed := ""
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("dialog.type_prefix", object.Key), "", 40, nil, func(text string) { typed = text })
+	form.AddButton(i18n.T("dialog.delete"), func() {
+		if typed != object.Key {
+			rt.updateStatus(i18n.T("dialog.type_prefix_hint", object.Key), "red")
+			return
+		}
+		rt.closeObjectDialog()
+		start()
+	})
+	form.AddButton(i18n.T("common.cancel"), rt.closeObjectDialog)
+	form.SetBorder(true).
+		SetTitle(panelTitle("dialog.delete_prefix", location)).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("s3-objects-dialog", centered(form, 72, 7), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(form)
+	}
+}
+
+// askObjectDestination asks where to copy or move object. A prefix is
+// copied or moved with every object below it.
+func (rt *ResourcesTab) askObjectDestination(object clients.S3Object, move bool) {
+	browser := rt.objects
+	verb, job, action := i18n.T("objects.copy"), "objects.copy_job", "s3:CopyObject"
+	if move {
+		verb, job, action = i18n.T("objects.move"), "objects.move_job", "s3:CopyObject+DeleteObjects"
+	}
+	destBucket, destKey := browser.bucket, object.Key
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("objects.bucket"), destBucket, 48, nil, func(text string) { destBucket = strings.TrimSpace(text) })
+	form.AddInputField(i18n.T("objects.key"), destKey, 48, nil, func(text string) { destKey = strings.TrimSpace(text) })
+	form.AddButton(verb, func() {
+		if object.IsPrefix && destKey != "" && !strings.HasSuffix(destKey, "/") {
+			destKey += "/"
+		}
+		switch {
+		case destBucket == "" || (destKey == "" && !object.IsPrefix):
+			rt.updateStatus(i18n.T("objects.no_destination"), "red")
+			return
+		case destBucket == browser.bucket && destKey == object.Key:
+			rt.updateStatus(i18n.T("objects.same_destination"), "red")
+			return
+		case object.IsPrefix && destBucket == browser.bucket && strings.HasPrefix(destKey, object.Key):
+			rt.updateStatus(i18n.T("objects.inner_destination"), "red")
+			return
+		}
+		rt.closeObjectDialog()
+
+		source := browser.location(object.Key)
+		dest := fmt.Sprintf("s3://%s/%s", destBucket, destKey)
+		rt.startObjectJob(i18n.T(job, source, dest), action, source,
+			func(ctx context.Context, svc aws.S3Service, progress jobs.Progress) error {
+				return copyObjects(ctx, svc, browser.bucket, object.Key, destBucket, destKey, "", move, progress)
+			})
+	})
+	form.AddButton(i18n.T("common.cancel"), rt.closeObjectDialog)
+	form.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s %s ", verb, browser.location(object.Key))).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("s3-objects-dialog", centered(form, 72, 9), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(form)
+	}
+}
+
+// chooseStorageClass lists the storage classes to change object, or every
+// object below a prefix, to
+func (rt *ResourcesTab) chooseStorageClass(object clients.S3Object) {
+	browser := rt.objects
+	location := browser.location(object.Key)
+
+	list := tview.NewList().
+		SetMainTextColor(tcell.ColorWhite).
+		SetSelectedTextColor(tcell.ColorBlack).
+		SetSelectedBackgroundColor(tcell.ColorWhite).
+		ShowSecondaryText(false)
+	for _, class := range objectStorageClasses {
+		label := class
+		if class == object.StorageClass {
+			label = i18n.T("objects.current_class", class)
+		}
+		list.AddItem(label, "", 0, nil)
+	}
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		rt.closeObjectDialog()
+		class := objectStorageClasses[index]
+		if class == object.StorageClass {
+			return
+		}
+		rt.startObjectJob(i18n.T("objects.change_class_job", location, class), "s3:CopyObject", location,
+			func(ctx context.Context, svc aws.S3Service, progress jobs.Progress) error {
+				return copyObjects(ctx, svc, browser.bucket, object.Key, browser.bucket, object.Key, class, false, progress)
+			})
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' {
+			rt.closeObjectDialog()
+			return nil
+		}
+		return event
+	})
+	list.SetBorder(true).
+		SetTitle(panelTitle("objects.class_title", location)).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("s3-objects-dialog", centered(list, 72, len(objectStorageClasses)+2), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(list)
+	}
+}
+
+// closeObjectDialog removes an object action dialog and returns focus to the
+// object browser
+func (rt *ResourcesTab) closeObjectDialog() {
+	rt.view.RemovePage("s3-objects-dialog")
+	if rt.app != nil && rt.objects != nil {
+		rt.app.SetFocus(rt.objects.table)
+	}
+}
+
+// startObjectJob runs work as the background job title and records its
+// outcome as action on resource in the audit log
+func (rt *ResourcesTab) startObjectJob(title, action, resource string, work func(ctx context.Context, svc aws.S3Service, progress jobs.Progress) error) {
+	client := rt.objects.client
+	rt.jobs.Start(title, "object", func(ctx context.Context, progress jobs.Progress) error {
+		svc := client.GetClients()
+		if svc == nil || svc.S3 == nil {
+			return fmt.Errorf("S3 service not initialized")
+		}
+		err := work(ctx, svc.S3, progress)
+		rt.audit.record(client, action, resource, err)
+		return err
+	})
+	rt.updateStatus(i18n.T("objects.job_started", title), "yellow")
+}
+
+// objectsBelow returns the object key of bucket, or every object below it
+// when key is a prefix or empty
+func objectsBelow(ctx context.Context, svc aws.S3Service, bucket, key string) ([]clients.S3Object, error) {
+	objects, err := svc.ListObjects(ctx, bucket, key, true)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" || strings.HasSuffix(key, "/") {
+		return objects, nil
+	}
+	for _, object := range objects {
+		if object.Key == key {
+			return []clients.S3Object{object}, nil
+		}
+	}
+	return nil, fmt.Errorf("object %s not found in %s", key, bucket)
+}
+
+// deleteObjects deletes key of bucket, or every object below it when key is
+// a prefix, reporting progress after every batch
+func deleteObjects(ctx context.Context, svc aws.S3Service, bucket, key string, progress jobs.Progress) error {
+	objects, err := objectsBelow(ctx, svc, bucket, key)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, len(objects))
+	for i, object := range objects {
+		keys[i] = object.Key
+	}
+
+	var failures []clients.ItemError
+	progress(0, len(keys))
+	for start := 0; start < len(keys); start += objectDeleteBatch {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		end := min(start+objectDeleteBatch, len(keys))
+		if err := collectFailures(svc.DeleteObjects(ctx, bucket, keys[start:end]), &failures); err != nil {
+			return err
+		}
+		progress(end, len(keys))
+	}
+
+	if len(failures) > 0 {
+		return &clients.PartialError{Op: "delete objects", Failures: failures}
+	}
+	return nil
+}
+
+// copyObjects copies key of bucket to destKey of destBucket, or every object
+// below the prefix key to below the prefix destKey, setting storageClass
+// when not empty. With move the copied objects are deleted afterwards.
+// Objects that fail are skipped and returned as a *PartialError.
+func copyObjects(ctx context.Context, svc aws.S3Service, bucket, key, destBucket, destKey, storageClass string, move bool, progress jobs.Progress) error {
+	objects, err := objectsBelow(ctx, svc, bucket, key)
+	if err != nil {
+		return err
+	}
+
+	var failures []clients.ItemError
+	var copied []string
+	progress(0, len(objects))
+	for i, object := range objects {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if storageClass != "" && object.StorageClass == storageClass && bucket == destBucket && key == destKey {
+			progress(i+1, len(objects))
+			continue
+		}
+
+		dest := destKey + strings.TrimPrefix(object.Key, key)
+		if err := svc.CopyObject(ctx, bucket, object.Key, object.Size, destBucket, dest, storageClass); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failures = append(failures, clients.ItemError{Item: object.Key, Err: err})
+		} else {
+			copied = append(copied, object.Key)
+		}
+		progress(i+1, len(objects))
+	}
+
+	if move && len(copied) > 0 {
+		if err := collectFailures(svc.DeleteObjects(ctx, bucket, copied), &failures); err != nil {
+			return err
+		}
+	}
+
+	if len(failures) > 0 {
+		return &clients.PartialError{Op: "copy objects", Failures: failures}
+	}
+	return nil
+}
+
+// collectFailures adds the failed items of a *PartialError to failures and
+// returns any other error
+func collectFailures(err error, failures *[]clients.ItemError) error {
+	var partial *clients.PartialError
+	if errors.As(err, &partial) {
+		*failures = append(*failures, partial.Failures...)
+		return nil
+	}
+	return err
+}
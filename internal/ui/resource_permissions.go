@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// permission is the outcome of a permission preflight
+type permission int
+
+const (
+	permissionChecking permission = iota + 1
+	permissionAllowed
+	permissionDenied
+)
+
+// instanceAction is an action on the selected EC2 instance, together with the
+// IAM permission it needs and a dry run that checks it
+type instanceAction struct {
+	key        rune
+	name       string
+	permission string
+	check      func(svc aws.EC2Service, ctx context.Context, instanceID string) (bool, error)
+}
+
+var instanceActions = []instanceAction{
+	{key: 's', name: "start", permission: "ec2:StartInstances", check: aws.EC2Service.CanStartInstance},
+	{key: 'p', name: "stop", permission: "ec2:StopInstances", check: aws.EC2Service.CanStopInstance},
+}
+
+// permissionKey identifies the preflight of an action on a resource.
+// Permissions can be scoped to resources, so every resource is checked.
+func permissionKey(resourceID, action string) string {
+	return resourceID + "|" + action
+}
+
+// checkInstanceActions runs the preflights of all instance actions that were
+// not checked yet in the background and shows the outcome in the details
+func (rt *ResourcesTab) checkInstanceActions(instanceID string) {
+	if rt.awsClient == nil {
+		return
+	}
+
+	var pending []instanceAction
+	for _, action := range instanceActions {
+		key := permissionKey(instanceID, action.permission)
+		if _, ok := rt.permissions[key]; !ok {
+			rt.permissions[key] = permissionChecking
+			pending = append(pending, action)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	client := rt.awsClient
+	svc := client.GetClients().EC2
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		results := make(map[string]permission, len(pending))
+		for _, action := range pending {
+			allowed, err := action.check(svc, ctx, instanceID)
+			switch {
+			case err != nil:
+				// Unknown: the action stays available and fails at runtime if it has to
+				logger.Warn("Permission preflight failed",
+					zap.String("instanceID", instanceID),
+					zap.String("permission", action.permission),
+					zap.Error(err))
+				results[action.permission] = permissionAllowed
+			case allowed:
+				results[action.permission] = permissionAllowed
+			default:
+				results[action.permission] = permissionDenied
+			}
+		}
+
+		if rt.app != nil {
+			rt.app.QueueUpdateDraw(func() {
+				rt.applyPermissions(client, instanceID, results)
+			})
+		}
+	}()
+}
+
+// applyPermissions stores preflight results and refreshes the details of the
+// instance if it is still selected
+func (rt *ResourcesTab) applyPermissions(client *aws.Client, instanceID string, results map[string]permission) {
+	// Results for another profile do not apply
+	if client != rt.awsClient {
+		return
+	}
+
+	for action, result := range results {
+		rt.permissions[permissionKey(instanceID, action)] = result
+	}
+	if rt.selectedRes != nil && rt.selectedRes.ID == instanceID {
+		rt.updateResourceDetails(rt.selectedRes)
+	}
+}
+
+// actionDenied reports whether the preflight found that the caller lacks
+// permission for action on the resource. Unchecked actions are not denied.
+func (rt *ResourcesTab) actionDenied(resourceID, action string) bool {
+	return rt.permissions[permissionKey(resourceID, action)] == permissionDenied
+}
+
+// instanceActionsText lists the instance actions for the details panel,
+// graying out the ones the caller may not perform
+func (rt *ResourcesTab) instanceActionsText(instanceID string) string {
+	var text strings.Builder
+	text.WriteString("[yellow]Actions:[-]\n")
+	for _, action := range instanceActions {
+		switch rt.permissions[permissionKey(instanceID, action.permission)] {
+		case permissionDenied:
+			text.WriteString(fmt.Sprintf("  [gray]%c  %s (missing %s)[-]\n", action.key, action.name, action.permission))
+		case permissionChecking:
+			text.WriteString(fmt.Sprintf("  [gray]%c  %s (checking...)[-]\n", action.key, action.name))
+		default:
+			text.WriteString(fmt.Sprintf("  %c  %s\n", action.key, action.name))
+		}
+	}
+	text.WriteString("  S  schedule a start or stop\n")
+	return text.String()
+}
@@ -0,0 +1,273 @@
+package ui
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultPreviewKB is how many KB of an object are previewed when the
+// config does not say
+const defaultPreviewKB = 64
+
+// previewInflateFactor bounds how much larger than the fetched bytes a
+// compressed preview may get when decompressed
+const previewInflateFactor = 16
+
+// previewHexBytes is how many bytes of binary content are shown as hex
+const previewHexBytes = 256
+
+// previewBytes returns how many bytes of an object are fetched to preview it
+func (rt *ResourcesTab) previewBytes() int64 {
+	kb := defaultPreviewKB
+	if rt.config != nil && rt.config.UI.PreviewKB > 0 {
+		kb = rt.config.UI.PreviewKB
+	}
+	return int64(kb) * 1024
+}
+
+// showObjectPreview previews the start of object over the object browser:
+// its headers and its content, decompressed and pretty-printed where
+// possible. w toggles wrapping and q closes the preview.
+func (rt *ResourcesTab) showObjectPreview(object clients.S3Object) {
+	browser := rt.objects
+	if browser == nil {
+		return
+	}
+	location := browser.location(object.Key)
+	maxBytes := rt.previewBytes()
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("preview.title", location))
+	view.SetText("[gray]" + i18n.T("common.loading") + "[-]")
+
+	wrap := false
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeObjectPreview()
+			return nil
+		case 'w':
+			wrap = !wrap
+			view.SetWrap(wrap)
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("s3-preview", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(view)
+	}
+
+	client, bucket := browser.client, browser.bucket
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		svc := client.GetClients()
+		var preview clients.ObjectPreview
+		err := fmt.Errorf("S3 service not initialized")
+		if svc != nil && svc.S3 != nil {
+			preview, err = svc.S3.GetObjectPreview(ctx, bucket, object.Key, maxBytes)
+		}
+		if err != nil {
+			logger.Error("Failed to preview object", zap.String("bucket", bucket), zap.String("key", object.Key), zap.Error(err))
+		}
+		if rt.app == nil {
+			return
+		}
+		rt.app.QueueUpdateDraw(func() {
+			if err != nil {
+				view.SetText("[red]" + i18n.T("preview.failed", location, tview.Escape(err.Error())) + "[-]")
+				return
+			}
+			view.SetText(renderObjectPreview(location, preview)).ScrollToBeginning()
+		})
+	}()
+}
+
+// closeObjectPreview removes the preview and returns focus to the browser
+func (rt *ResourcesTab) closeObjectPreview() {
+	rt.view.RemovePage("s3-preview")
+	if rt.app == nil {
+		return
+	}
+	if rt.objects != nil {
+		rt.app.SetFocus(rt.objects.table)
+	} else {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// renderObjectPreview shows the headers of the object at location followed
+// by its decoded content, or the first bytes as hex for binary content
+func renderObjectPreview(location string, preview clients.ObjectPreview) string {
+	var text strings.Builder
+
+	size := formatBytes(preview.Size)
+	if preview.Truncated() {
+		size += fmt.Sprintf(" (previewing the first %s)", formatBytes(int64(len(preview.Body))))
+	}
+	fmt.Fprintf(&text, "[yellow]%s[-]\n", tview.Escape(location))
+	header := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&text, "%-18s %s\n", name, tview.Escape(value))
+		}
+	}
+	header("Size", size)
+	header("Content-Type", preview.ContentType)
+	header("Content-Encoding", preview.ContentEncoding)
+	header("Storage Class", preview.StorageClass)
+	if !preview.LastModified.IsZero() {
+		header("Last Modified", zonedTime(preview.LastModified, "2006-01-02 15:04:05"))
+	}
+	header("ETag", preview.ETag)
+	keys := make([]string, 0, len(preview.Metadata))
+	for key := range preview.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		header("x-amz-meta-"+key, preview.Metadata[key])
+	}
+	text.WriteString("\n")
+
+	if len(preview.Body) == 0 {
+		text.WriteString("[gray]Empty object[-]\n")
+		return text.String()
+	}
+
+	content, kind, ok := decodePreview(preview)
+	if !ok {
+		fmt.Fprintf(&text, "[gray]── %s, first %d bytes ──[-]\n", kind, min(len(content), previewHexBytes))
+		text.WriteString(tview.Escape(hex.Dump([]byte(content[:min(len(content), previewHexBytes)]))))
+		return text.String()
+	}
+	fmt.Fprintf(&text, "[gray]── %s ──[-]\n", kind)
+	text.WriteString(tview.Escape(content))
+	if preview.Truncated() {
+		text.WriteString("\n[gray]── cut off, the rest of the object was not downloaded ──[-]\n")
+	}
+	return text.String()
+}
+
+// decodePreview decompresses gzip content and pretty-prints JSON and YAML.
+// It returns the content, what kind of content it is and whether it is
+// text; binary content is returned as raw bytes.
+func decodePreview(preview clients.ObjectPreview) (string, string, bool) {
+	body := preview.Body
+	name := preview.Key
+	var notes []string
+
+	if len(body) >= 2 && body[0] == 0x1f && body[1] == 0x8b {
+		if reader, err := gzip.NewReader(bytes.NewReader(body)); err == nil {
+			// A cut off stream decompresses up to where it was cut
+			inflated, _ := io.ReadAll(io.LimitReader(reader, int64(len(body))*previewInflateFactor))
+			if len(inflated) > 0 {
+				body = inflated
+				name = strings.TrimSuffix(name, ".gz")
+				notes = append(notes, "gzip-decompressed")
+			}
+		}
+	}
+
+	if !isText(body) {
+		return string(body), joinKind("Binary", notes), false
+	}
+	// Drop a character cut in half at the end of the preview
+	for i := 1; i < utf8.UTFMax && preview.Truncated(); i++ {
+		if r, size := utf8.DecodeLastRune(body); r != utf8.RuneError || size != 1 {
+			break
+		}
+		body = body[:len(body)-1]
+	}
+	body = bytes.ToValidUTF8(body, []byte("\uFFFD"))
+
+	ext := path.Ext(name)
+	contentType := preview.ContentType
+	trimmed := bytes.TrimSpace(body)
+	switch {
+	case ext == ".json" || ext == ".tfstate" || strings.Contains(contentType, "json") ||
+		(len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed)):
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, trimmed, "", "  "); err == nil {
+			return pretty.String(), joinKind("JSON", notes), true
+		}
+		return string(body), joinKind("JSON, cut off or invalid so shown as is", notes), true
+	case ext == ".yaml" || ext == ".yml" || strings.Contains(contentType, "yaml"):
+		if pretty, err := prettyYAML(body, preview.Truncated()); err == nil {
+			return pretty, joinKind("YAML", notes), true
+		}
+		return string(body), joinKind("YAML, cut off or invalid so shown as is", notes), true
+	}
+	return string(body), joinKind("Text", notes), true
+}
+
+// prettyYAML re-indents YAML. The last line of a cut off document is
+// dropped, since it may end in the middle of a value.
+func prettyYAML(body []byte, truncated bool) (string, error) {
+	if truncated {
+		if i := bytes.LastIndexByte(body, '\n'); i >= 0 {
+			body = body[:i+1]
+		}
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(body, &node); err != nil {
+		return "", err
+	}
+	var pretty bytes.Buffer
+	encoder := yaml.NewEncoder(&pretty)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return "", err
+	}
+	encoder.Close()
+	return pretty.String(), nil
+}
+
+// isText reports whether body looks like text: no NUL bytes and few other
+// control characters
+func isText(body []byte) bool {
+	control := 0
+	for _, b := range body {
+		switch {
+		case b == 0:
+			return false
+		case b < 0x20 && b != '\n' && b != '\r' && b != '\t' && b != 0x1b:
+			control++
+		}
+	}
+	return control*100 <= len(body)
+}
+
+// joinKind names the kind of content with how it was decoded
+func joinKind(kind string, notes []string) string {
+	if len(notes) == 0 {
+		return kind
+	}
+	return kind + ", " + strings.Join(notes, ", ")
+}
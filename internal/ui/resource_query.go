@@ -0,0 +1,397 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	blevequery "github.com/blevesearch/bleve/v2/search/query"
+	"github.com/gdamore/tcell/v2"
+)
+
+// resourceQueryFields are the fields a resource query can name, besides
+// tag.<key> for the value of a tag
+var resourceQueryFields = map[string]bool{
+	"name":    true,
+	"id":      true,
+	"arn":     true,
+	"type":    true,
+	"state":   true,
+	"service": true,
+	"region":  true,
+	"tags":    true,
+}
+
+// resourceWordFields are the fields a word without a field is looked for in
+var resourceWordFields = []string{"name", "id", "arn", "tags", "type", "state"}
+
+// resourceToken is a lexical token of a resource query
+type resourceToken struct {
+	kind  resourceTokenKind
+	field string
+	value string
+}
+
+type resourceTokenKind int
+
+const (
+	tokenTerm resourceTokenKind = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+// isResourceQuery reports whether filter text uses the query syntax rather
+// than being plain words to look for
+func isResourceQuery(text string) bool {
+	if strings.ContainsAny(text, ":()\"") {
+		return true
+	}
+	for _, word := range strings.Fields(text) {
+		switch word {
+		case "AND", "OR", "NOT":
+			return true
+		}
+		if strings.HasPrefix(word, "-") && len(word) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// parseResourceQuery parses a resource query such as
+//
+//	state:running AND tag.env:prod
+//	(type:t3.* OR type:m5.*) NOT name:bastion
+//
+// into a Bleve query. field:value matches the whole value of a field,
+// ignoring case, unless the value has * or ? wildcards. A word without a
+// field matches anywhere in the name, ID, ARN, tags, type or state. Terms
+// next to each other must all match; AND, OR, NOT, -term and parentheses
+// combine them, and values with spaces are quoted.
+func parseResourceQuery(text string) (blevequery.Query, error) {
+	tokens, err := lexResourceQuery(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	p := &resourceQueryParser{tokens: tokens}
+	query, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %s", p.tokens[p.pos].describe())
+	}
+	return query, nil
+}
+
+// lexResourceQuery splits text into tokens
+func lexResourceQuery(text string) ([]resourceToken, error) {
+	var tokens []resourceToken
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, resourceToken{kind: tokenOpen})
+			i++
+			continue
+		case r == ')':
+			tokens = append(tokens, resourceToken{kind: tokenClose})
+			i++
+			continue
+		case r == '-' || r == '!':
+			tokens = append(tokens, resourceToken{kind: tokenNot})
+			i++
+			continue
+		}
+
+		// A term runs to the next space or parenthesis outside of quotes
+		var word strings.Builder
+		quoted, wasQuoted := false, false
+		for ; i < len(runes); i++ {
+			r := runes[i]
+			if r == '"' {
+				quoted = !quoted
+				wasQuoted = true
+				continue
+			}
+			if !quoted && (unicode.IsSpace(r) || r == '(' || r == ')') {
+				break
+			}
+			word.WriteRune(r)
+		}
+		if quoted {
+			return nil, fmt.Errorf("missing closing quote")
+		}
+
+		term := word.String()
+		if !wasQuoted {
+			switch term {
+			case "AND":
+				tokens = append(tokens, resourceToken{kind: tokenAnd})
+				continue
+			case "OR":
+				tokens = append(tokens, resourceToken{kind: tokenOr})
+				continue
+			case "NOT":
+				tokens = append(tokens, resourceToken{kind: tokenNot})
+				continue
+			}
+		}
+
+		token := resourceToken{kind: tokenTerm, value: term}
+		if field, value, ok := strings.Cut(term, ":"); ok {
+			token.field, token.value = strings.ToLower(field), value
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// describe names the token in error messages
+func (t resourceToken) describe() string {
+	switch t.kind {
+	case tokenAnd:
+		return "AND"
+	case tokenOr:
+		return "OR"
+	case tokenNot:
+		return "NOT"
+	case tokenOpen:
+		return "("
+	case tokenClose:
+		return ")"
+	}
+	if t.field != "" {
+		return fmt.Sprintf("%q", t.field+":"+t.value)
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+// resourceQueryParser is a recursive descent parser over the tokens of a
+// resource query, where NOT binds tighter than AND and AND tighter than OR
+type resourceQueryParser struct {
+	tokens []resourceToken
+	pos    int
+}
+
+func (p *resourceQueryParser) peek() (resourceToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return resourceToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *resourceQueryParser) parseOr() (blevequery.Query, error) {
+	query, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	queries := []blevequery.Query{query}
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind != tokenOr {
+			break
+		}
+		p.pos++
+		query, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+	if len(queries) == 1 {
+		return queries[0], nil
+	}
+	return blevequery.NewDisjunctionQuery(queries), nil
+}
+
+func (p *resourceQueryParser) parseAnd() (blevequery.Query, error) {
+	var must, mustNot []blevequery.Query
+	afterAnd := false
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind == tokenOr || token.kind == tokenClose {
+			if afterAnd {
+				return nil, fmt.Errorf("expected a term after AND")
+			}
+			break
+		}
+		if token.kind == tokenAnd {
+			if len(must)+len(mustNot) == 0 || afterAnd {
+				return nil, fmt.Errorf("AND without a term before it")
+			}
+			afterAnd = true
+			p.pos++
+			continue
+		}
+		afterAnd = false
+
+		negated := false
+		for ok && token.kind == tokenNot {
+			negated = !negated
+			p.pos++
+			token, ok = p.peek()
+		}
+		query, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if negated {
+			mustNot = append(mustNot, query)
+		} else {
+			must = append(must, query)
+		}
+	}
+
+	switch {
+	case len(must)+len(mustNot) == 0:
+		if token, ok := p.peek(); ok {
+			return nil, fmt.Errorf("expected a term before %s", token.describe())
+		}
+		return nil, fmt.Errorf("expected a term at the end")
+	case len(must) == 1 && len(mustNot) == 0:
+		return must[0], nil
+	case len(mustNot) == 0:
+		return blevequery.NewConjunctionQuery(must), nil
+	}
+	// Only excluding terms exclude from everything
+	if len(must) == 0 {
+		must = []blevequery.Query{blevequery.NewMatchAllQuery()}
+	}
+	return blevequery.NewBooleanQuery(must, nil, mustNot), nil
+}
+
+func (p *resourceQueryParser) parseOperand() (blevequery.Query, error) {
+	token, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("expected a term at the end")
+	}
+	p.pos++
+
+	switch token.kind {
+	case tokenOpen:
+		query, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.peek(); !ok || closing.kind != tokenClose {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return query, nil
+	case tokenTerm:
+		return resourceTermTokenQuery(token)
+	}
+	return nil, fmt.Errorf("unexpected %s", token.describe())
+}
+
+// resourceTermTokenQuery matches a single term of a resource query
+func resourceTermTokenQuery(token resourceToken) (blevequery.Query, error) {
+	if token.value == "" {
+		return nil, fmt.Errorf("missing value for %s", token.field)
+	}
+	switch {
+	case token.field == "":
+		return resourceWordQuery(strings.ToLower(token.value)), nil
+	case strings.HasPrefix(token.field, "tag.") && len(token.field) > len("tag."):
+		return resourceFieldQuery(token.field, token.value), nil
+	case resourceQueryFields[token.field]:
+		return resourceFieldQuery(token.field, token.value), nil
+	}
+	return nil, fmt.Errorf("unknown field %q, use name, id, arn, type, state, service, region, tags or tag.<key>", token.field)
+}
+
+// resourceWordQuery matches word anywhere in the searchable fields of a
+// resource, names weighing most
+func resourceWordQuery(word string) blevequery.Query {
+	var fields []blevequery.Query
+	for _, field := range resourceWordFields {
+		q := blevequery.NewWildcardQuery("*" + word + "*")
+		q.SetField(field)
+		if field == "name" {
+			q.SetBoost(2)
+		}
+		fields = append(fields, q)
+	}
+	return blevequery.NewDisjunctionQuery(fields)
+}
+
+// resourceFieldQuery matches the whole value of field, or the wildcard
+// pattern value, ignoring case
+func resourceFieldQuery(field, value string) blevequery.Query {
+	value = strings.ToLower(value)
+	if strings.ContainsAny(value, "*?") {
+		q := blevequery.NewWildcardQuery(value)
+		q.SetField(field)
+		return q
+	}
+	return resourceTermQuery(field, value)
+}
+
+// resourceTermQuery matches field exactly, ignoring case
+func resourceTermQuery(field, value string) blevequery.Query {
+	q := blevequery.NewTermQuery(strings.ToLower(value))
+	q.SetField(field)
+	return q
+}
+
+// filterMatchColor highlights the fields a filter matched, like search
+// matches in the Logs tab
+var filterMatchColor = tcell.NewHexColor(0xffff00)
+
+// resourceMatchColumns are the columns of the resource table showing the
+// fields a filter can match
+var resourceMatchColumns = map[string]int{"name": 0, "id": 1, "type": 2, "state": 3, "region": 5}
+
+// queryFilter returns the resources of the shown listing that match the
+// resource query text, in listing order, with the fields each one matched
+func (rt *ResourcesTab) queryFilter(text string) ([]Resource, map[string][]string, error) {
+	query, err := parseResourceQuery(text)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if rt.filterIndex == nil {
+		index, err := newResourceIndex()
+		if err != nil {
+			return nil, nil, err
+		}
+		var profile, region string
+		if rt.awsClient != nil {
+			profile, region = rt.awsClient.GetProfile(), rt.awsClient.GetRegion()
+		}
+		if err := index.Update(profile, region, rt.shownService, rt.filteredRes); err != nil {
+			index.Close()
+			return nil, nil, err
+		}
+		rt.filterIndex = index
+	}
+
+	hits, err := rt.filterIndex.Query(query, len(rt.filteredRes))
+	if err != nil {
+		return nil, nil, err
+	}
+	matches := make(map[string][]string, len(hits))
+	for _, hit := range hits {
+		matches[hit.Resource.ID] = hit.Fields
+	}
+
+	var filtered []Resource
+	for _, res := range rt.filteredRes {
+		if _, ok := matches[res.ID]; ok {
+			filtered = append(filtered, res)
+		}
+	}
+	return filtered, matches, nil
+}
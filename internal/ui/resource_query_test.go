@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseResourceQuery(t *testing.T) {
+	index, err := newResourceIndex()
+	if err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer index.Close()
+
+	err = index.Update("dev", "eu-west-1", "ec2", []Resource{
+		{ID: "i-1", Name: "web-1", Type: "t3.medium", State: "running", Tags: map[string]string{"Env": "prod", "team": "web"}},
+		{ID: "i-2", Name: "worker-1", Type: "c6i.large", State: "running", Tags: map[string]string{"env": "prod", "team": "orders"}},
+		{ID: "i-3", Name: "staging app", Type: "t3.small", State: "stopped", Tags: map[string]string{"env": "staging", "created": "2024-05-01"}},
+		{ID: "i-4", Name: "bastion", Type: "t3.micro", State: "stopped", Region: "us-east-1", Details: map[string]interface{}{"ARN": "arn:aws:ec2:us-east-1:123456789012:instance/i-4"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"state:running AND tag.env:prod", "i-1 i-2"},
+		{"state:RUNNING tag.ENV:Prod", "i-1 i-2"},
+		{"tag.team:web OR name:bastion", "i-1 i-4"},
+		{"type:t3.* NOT state:stopped", "i-1"},
+		{"type:t3.* -state:stopped", "i-1"},
+		{"NOT state:running", "i-3 i-4"},
+		{"(tag.team:web OR tag.team:orders) AND -name:worker*", "i-1"},
+		{`name:"staging app"`, "i-3"},
+		{"tag.created:2024-05-01", "i-3"},
+		{"region:us-east-1", "i-4"},
+		{"region:eu-west-1", "i-1 i-2 i-3"},
+		{"arn:*instance/i-4", "i-4"},
+		{"worker running", "i-2"},
+		{"service:ec2 tags:team=orders", "i-2"},
+	}
+	for _, tt := range tests {
+		query, err := parseResourceQuery(tt.query)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.query, err)
+			continue
+		}
+		hits, err := index.Query(query, 10)
+		if err != nil {
+			t.Errorf("%s: search failed: %v", tt.query, err)
+			continue
+		}
+		var ids []string
+		for _, hit := range hits {
+			ids = append(ids, hit.Resource.ID)
+		}
+		sort.Strings(ids)
+		if got := strings.Join(ids, " "); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.query, tt.want, got)
+		}
+	}
+
+	for query, want := range map[string]string{
+		"owner:me":            "unknown field",
+		"state:running AND":   "expected a term",
+		"AND state:running":   "AND without a term",
+		"(state:running":      "missing )",
+		"state:running)":      "unexpected )",
+		`name:"staging app`:   "missing closing quote",
+		"state: AND name:web": "missing value",
+	} {
+		if _, err := parseResourceQuery(query); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected error containing %q, got %v", query, want, err)
+		}
+	}
+}
+
+func TestIsResourceQuery(t *testing.T) {
+	for text, want := range map[string]bool{
+		"web":           false,
+		"web prod":      false,
+		"i-0abc":        false,
+		"state:running": true,
+		"web OR worker": true,
+		"-bastion":      true,
+		`"staging app"`: true,
+		"(web)":         true,
+	} {
+		if got := isResourceQuery(text); got != want {
+			t.Errorf("isResourceQuery(%q) = %v, expected %v", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,614 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/pricing"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// piTopLimit is how many SQL statements and wait events are listed
+const piTopLimit = 10
+
+// rdsView lists the RDS instances; Enter shows their Performance Insights
+type rdsView struct{ baseView }
+
+var rdsService = rdsView{baseView{
+	info: ServiceInfo{Name: "rds", DisplayName: "RDS Databases", Icon: "📚", Label: "RDS", Enabled: true, Permission: "rds:DescribeDBInstances"},
+	noun: "instance",
+}}
+
+// ConsoleURL links the database
+func (rdsView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/rds/home?%s#database:id=%s", base, query, res.ID)
+}
+
+// ARN returns the ARN of the database
+func (rdsView) ARN(account string, res Resource) string {
+	return fmt.Sprintf("arn:%s:rds:%s:%s:db:%s", awsPartition(res.Region), res.Region, account, res.ID)
+}
+
+// Load lists the instances
+func (v rdsView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return v.LoadPages(ctx, rt, client, nil)
+}
+
+// LoadPages lists the instances a page at a time
+func (rdsView) LoadPages(ctx context.Context, rt *ResourcesTab, client *aws.Client, onPage func([]Resource)) ([]Resource, error) {
+	return rt.loadRDSInstances(ctx, client, onPage)
+}
+
+// Open shows the database load of the instance
+func (rdsView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showPerformanceInsights(resource)
+}
+
+// loadRDSInstances loads RDS instances using the RDS service wrapper,
+// calling onPage, if set, with the instances loaded so far after every page
+func (rt *ResourcesTab) loadRDSInstances(ctx context.Context, client *aws.Client, onPage func([]Resource)) ([]Resource, error) {
+	var resources []Resource
+	_, err := client.GetRDSFunctionDetails(ctx, func(page []clients.RDSDetails) {
+		for _, d := range page {
+			resources = append(resources, rdsInstanceToResource(d, client.GetRegion()))
+		}
+		if onPage != nil {
+			onPage(resources)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe RDS instances: %w", err)
+	}
+
+	return resources, nil
+}
+
+// rdsInstanceToResource describes the RDS instance d of region
+func rdsInstanceToResource(d clients.RDSDetails, region string) Resource {
+	createdDate := ""
+	if d.InstanceCreateTime != nil {
+		createdDate = d.InstanceCreateTime.Format("2006-01-02 15:04:05")
+	}
+
+	resource := Resource{
+		ID:          d.DBInstanceIdentifier,
+		Name:        d.DBInstanceIdentifier,
+		Type:        "RDS Instance",
+		State:       d.DBInstanceStatus,
+		Region:      region,
+		CreatedDate: createdDate,
+		Tags:        make(map[string]string),
+		Details:     make(map[string]interface{}),
+	}
+
+	// A stopped database only pays for storage
+	if d.DBInstanceStatus != "stopped" {
+		resource.MonthlyCost, _ = pricing.RDSMonthly(d.DBInstanceClass, region, d.MultiAZ)
+	}
+
+	// Add additional details
+	resource.Details["Instance Class"] = d.DBInstanceClass
+	resource.Details["Multi-AZ"] = d.MultiAZ
+	resource.Details["Engine"] = d.Engine
+	resource.Details["Engine Version"] = d.EngineVersion
+	resource.Details["Status"] = d.DBInstanceStatus
+	resource.Details["Endpoint"] = d.Endpoint
+	resource.Details["Allocated Storage (GB)"] = d.AllocatedStorage
+	resource.Details["Resource ID"] = d.DbiResourceID
+	if d.PerformanceInsightsEnabled {
+		resource.Details["Performance Insights"] = "enabled, press Enter for top SQL and waits"
+	} else {
+		resource.Details["Performance Insights"] = "disabled"
+	}
+
+	return resource
+}
+
+// showPerformanceInsights shows the database load of the RDS instance res
+// over the tab with the SQL statements and wait events causing most of it.
+// t cycles the time range, s and w focus the tables, Enter on a statement
+// shows all of it, r reloads and q closes the view.
+func (rt *ResourcesTab) showPerformanceInsights(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+	resourceID, _ := res.Details["Resource ID"].(string)
+	if enabled, _ := res.Details["Performance Insights"].(string); resourceID == "" || enabled == "disabled" {
+		rt.updateStatus(i18n.T("rds.insights_disabled", res.Name), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	rangeIndex := 0
+	loads := 0
+
+	summary := tview.NewTextView().SetDynamicColors(true)
+	topSQL := workloadTable(" Top SQL (Enter: full statement) ")
+	topWaits := workloadTable(" Top Waits ")
+
+	view := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(summary, 2, 0, false).
+		AddItem(topSQL, 0, 3, true).
+		AddItem(topWaits, 0, 2, false)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	load := func() {
+		window := dashboardRanges[rangeIndex]
+		view.SetTitle(panelTitle("rds.insights_title", res.Name, formatRange(window)))
+		summary.SetText("[gray]" + i18n.T("common.loading") + "[-]")
+		setTableMessage(topSQL, i18n.T("common.loading"), tcell.ColorGray)
+		setTableMessage(topWaits, i18n.T("common.loading"), tcell.ColorGray)
+
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var dbLoad clients.DBLoad
+			err := fmt.Errorf("Performance Insights service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.PI != nil {
+				end := time.Now()
+				dbLoad, err = svc.PI.GetDBLoad(ctx, resourceID, end.Add(-window), end, piTopLimit)
+			}
+			if err != nil {
+				logger.Error("Failed to load database load", zap.String("instance", res.Name), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					summary.SetText("[red]" + i18n.T("rds.load_failed", res.Name, tview.Escape(err.Error())) + "[-]")
+					topSQL.Clear()
+					topWaits.Clear()
+					return
+				}
+				summary.SetText(renderDBLoadSummary(dbLoad))
+				fillTopSQL(topSQL, dbLoad)
+				fillTopWaits(topWaits, dbLoad)
+			})
+		}()
+	}
+
+	topSQL.SetSelectedFunc(func(row, _ int) {
+		if item, ok := topSQL.GetCell(row, 0).GetReference().(clients.DBLoadItem); ok {
+			rt.showSQLStatement(item, topSQL)
+		}
+	})
+
+	focus := func(p tview.Primitive) {
+		if rt.app != nil {
+			rt.app.SetFocus(p)
+		}
+	}
+	keys := func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closePerformanceInsights()
+			return nil
+		case 'r':
+			load()
+			return nil
+		case 't':
+			rangeIndex = (rangeIndex + 1) % len(dashboardRanges)
+			load()
+			return nil
+		case 's':
+			focus(topSQL)
+			return nil
+		case 'w':
+			focus(topWaits)
+			return nil
+		}
+		return event
+	}
+	topSQL.SetInputCapture(keys)
+	topWaits.SetInputCapture(keys)
+
+	rt.view.AddPage("rds-insights", view, true, true)
+	focus(topSQL)
+	load()
+}
+
+// closePerformanceInsights removes the Performance Insights view and returns
+// focus to the table
+func (rt *ResourcesTab) closePerformanceInsights() {
+	rt.view.RemovePage("rds-insights")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// showSQLStatement shows the whole statement of item over the Performance
+// Insights view, q closes it and focuses back
+func (rt *ResourcesTab) showSQLStatement(item clients.DBLoadItem, back tview.Primitive) {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(fmt.Sprintf("%s %s\n%s %s\n\n%s", infoLabel("rds.digest"), item.ID, infoLabel("rds.load"),
+			i18n.T("rds.aas", item.Load), tview.Escape(item.Name)))
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft).SetTitle(panelTitle("rds.statement_title"))
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' {
+			rt.view.RemovePage("rds-sql")
+			if rt.app != nil {
+				rt.app.SetFocus(back)
+			}
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("rds-sql", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(view)
+	}
+}
+
+// renderDBLoadSummary describes the average and peak load of a window
+func renderDBLoadSummary(load clients.DBLoad) string {
+	return fmt.Sprintf("[yellow]Database load:[-] %.2f average active sessions, peak %.2f\n[gray]Share is the part of the average load caused by a statement or wait[-]",
+		load.Average, load.Peak)
+}
+
+// fillTopSQL lists the statements of load by load; the statement is the
+// reference of the first cell
+func fillTopSQL(table *tview.Table, load clients.DBLoad) {
+	items := append([]clients.DBLoadItem(nil), load.TopSQL...)
+	clients.SortDBLoadItems(items)
+
+	table.Clear()
+	setWorkloadHeader(table, false, "Load (AAS)", "Share", "", "SQL")
+	if len(items) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No SQL ran in this window").SetTextColor(tcell.ColorGray).SetSelectable(false))
+		return
+	}
+	for i, item := range items {
+		share := loadShare(item.Load, load)
+		cells := []*tview.TableCell{
+			tview.NewTableCell(fmt.Sprintf("%.2f", item.Load)).SetAlign(tview.AlignRight),
+			tview.NewTableCell(fmt.Sprintf("%.1f%%", share)).SetAlign(tview.AlignRight),
+			tview.NewTableCell(loadBar(share)).SetTextColor(tcell.ColorTeal),
+			tview.NewTableCell(strings.Join(strings.Fields(item.Name), " ")).SetExpansion(1).SetMaxWidth(120),
+		}
+		cells[0].SetReference(item)
+		for col, cell := range cells {
+			table.SetCell(i+1, col, cell)
+		}
+	}
+	table.Select(1, 0)
+}
+
+// fillTopWaits lists the wait events of load by load, colored by type
+func fillTopWaits(table *tview.Table, load clients.DBLoad) {
+	items := append([]clients.DBLoadItem(nil), load.TopWaits...)
+	clients.SortDBLoadItems(items)
+
+	table.Clear()
+	setWorkloadHeader(table, false, "Load (AAS)", "Share", "", "Type", "Wait Event")
+	if len(items) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No waits in this window").SetTextColor(tcell.ColorGray).SetSelectable(false))
+		return
+	}
+	for i, item := range items {
+		share := loadShare(item.Load, load)
+		color := waitTypeColor(item.Type)
+		cells := []*tview.TableCell{
+			tview.NewTableCell(fmt.Sprintf("%.2f", item.Load)).SetAlign(tview.AlignRight),
+			tview.NewTableCell(fmt.Sprintf("%.1f%%", share)).SetAlign(tview.AlignRight),
+			tview.NewTableCell(loadBar(share)).SetTextColor(color),
+			tview.NewTableCell(item.Type).SetTextColor(color),
+			tview.NewTableCell(item.Name).SetExpansion(1),
+		}
+		for col, cell := range cells {
+			table.SetCell(i+1, col, cell)
+		}
+	}
+	table.Select(1, 0)
+}
+
+// loadShare returns the percentage of the average load of the window that
+// is caused by an item with load
+func loadShare(itemLoad float64, load clients.DBLoad) float64 {
+	if load.Average <= 0 {
+		return 0
+	}
+	return min(itemLoad/load.Average*100, 100)
+}
+
+// loadBar draws share as a bar of up to 20 cells
+func loadBar(share float64) string {
+	return strings.Repeat("█", int(share/5+0.5))
+}
+
+// waitTypeColor colors CPU green, IO blue, locks red and other waits yellow
+func waitTypeColor(waitType string) tcell.Color {
+	switch {
+	case waitType == "CPU":
+		return tcell.ColorGreen
+	case strings.HasPrefix(waitType, "IO"):
+		return tcell.ColorDodgerBlue
+	case waitType == "Lock" || waitType == "LWLock":
+		return tcell.ColorRed
+	default:
+		return tcell.ColorYellow
+	}
+}
+
+// rdsParamsView lists the DB parameter groups; Enter shows the parameters
+// of one
+type rdsParamsView struct{ baseView }
+
+var rdsParamsService = rdsParamsView{baseView{
+	info: ServiceInfo{Name: "rdsparams", DisplayName: "RDS Parameter Groups", Icon: "🎛", Label: "PRM", Enabled: true, Permission: "rds:DescribeDBParameterGroups"},
+	noun: "parameter group",
+}}
+
+// ConsoleURL links the parameter group
+func (rdsParamsView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/rds/home?%s#parameter-groups-detail:ids=%s;type=DbParameterGroup", base, query, url.QueryEscape(res.Name))
+}
+
+// StateColor colors whether the groups are applied
+func (rdsParamsView) StateColor(state string) tcell.Color {
+	return stateColor(parameterGroupStateColors, state)
+}
+
+// parameterGroupStateColors color whether the instances of a group apply it
+var parameterGroupStateColors = map[string]tcell.Color{
+	"in sync":        tcell.ColorGreen,
+	"unused":         tcell.ColorRed,
+	"pending reboot": tcell.ColorYellow,
+}
+
+// Load lists the parameter groups
+func (rdsParamsView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadParameterGroups(ctx, client)
+}
+
+// Open shows the parameters of the group
+func (rdsParamsView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showParameterGroup(resource)
+}
+
+// loadParameterGroups lists the DB parameter groups of the region with the
+// instances using them
+func (rt *ResourcesTab) loadParameterGroups(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.RDS == nil {
+		return nil, fmt.Errorf("RDS service not initialized")
+	}
+
+	groups, err := svc.RDS.ListParameterGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(groups))
+	for _, group := range groups {
+		resources = append(resources, parameterGroupResource(group, client.GetRegion()))
+	}
+	return resources, nil
+}
+
+// parameterGroupResource describes group with its family and members. Its
+// state is whether every instance using it applied its changes.
+func parameterGroupResource(group clients.ParameterGroup, region string) Resource {
+	res := Resource{
+		ID:     group.Name,
+		Name:   group.Name,
+		Type:   "DB Parameter Group",
+		State:  "in sync",
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":         group.ARN,
+			"Family":      group.Family,
+			"Description": group.Description,
+			"View":        "press Enter for its non-default parameters",
+		},
+	}
+
+	var members []string
+	for _, member := range group.Instances {
+		members = append(members, fmt.Sprintf("%s (%s)", member.Instance, member.ApplyStatus))
+	}
+	switch {
+	case len(members) == 0:
+		res.State = "unused"
+		res.Details["Instances"] = "none"
+	case len(group.PendingReboot()) > 0:
+		res.State = "pending reboot"
+		res.Details["Reboot Pending"] = strings.Join(group.PendingReboot(), ", ")
+		fallthrough
+	default:
+		res.Details["Instances"] = strings.Join(members, ", ")
+	}
+	return res
+}
+
+// showParameterGroup shows the parameters set in the parameter group res
+// next to their engine defaults over the tab, and the instances using it.
+// r reloads and q closes the view.
+func (rt *ResourcesTab) showParameterGroup(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	family, _ := res.Details["Family"].(string)
+	loads := 0
+
+	members := tview.NewTextView().SetDynamicColors(true)
+	parameters := workloadTable(" Parameters set in the group ")
+	description := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+
+	view := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(members, 2, 0, false).
+		AddItem(parameters, 0, 1, true).
+		AddItem(description, 2, 0, false)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("rds.parameters_title", res.Name, family))
+
+	load := func() {
+		members.SetText("[gray]" + i18n.T("common.loading") + "[-]")
+		setTableMessage(parameters, i18n.T("common.loading"), tcell.ColorGray)
+		description.SetText("")
+
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var (
+				group clients.ParameterGroup
+				diffs []clients.ParameterDiff
+			)
+			err := fmt.Errorf("RDS service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.RDS != nil {
+				var groups []clients.ParameterGroup
+				if groups, err = svc.RDS.ListParameterGroups(ctx); err == nil {
+					group = clients.ParameterGroup{Name: res.Name, Family: family}
+					for _, g := range groups {
+						if g.Name == res.Name {
+							group = g
+						}
+					}
+					diffs, err = svc.RDS.DiffParameterGroup(ctx, group.Name, group.Family)
+				}
+			}
+			if err != nil {
+				logger.Error("Failed to load parameter group", zap.String("group", res.Name), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					members.SetText("[red]" + i18n.T("rds.parameters_failed", res.Name, tview.Escape(err.Error())) + "[-]")
+					parameters.Clear()
+					return
+				}
+				members.SetText(renderParameterGroupMembers(group))
+				fillParameterDiffs(parameters, diffs, len(group.PendingReboot()) > 0)
+			})
+		}()
+	}
+
+	parameters.SetSelectionChangedFunc(func(row, _ int) {
+		if diff, ok := parameters.GetCell(row, 0).GetReference().(clients.ParameterDiff); ok {
+			description.SetText(fmt.Sprintf("[gray]%s[-]", tview.Escape(diff.Description)))
+		}
+	})
+	parameters.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeParameterGroup()
+			return nil
+		case 'r':
+			load()
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("rds-parameters", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(parameters)
+	}
+	load()
+}
+
+// closeParameterGroup removes the parameter group view and returns focus to
+// the table
+func (rt *ResourcesTab) closeParameterGroup() {
+	rt.view.RemovePage("rds-parameters")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// renderParameterGroupMembers lists the instances using group, those still
+// waiting for a reboot to apply its changes in yellow
+func renderParameterGroupMembers(group clients.ParameterGroup) string {
+	if len(group.Instances) == 0 {
+		return "[yellow]Used by:[-] [gray]no instances[-]"
+	}
+
+	var members []string
+	for _, member := range group.Instances {
+		color := "green"
+		switch member.ApplyStatus {
+		case "pending-reboot", "applying":
+			color = "yellow"
+		case "failed-to-apply":
+			color = "red"
+		}
+		members = append(members, fmt.Sprintf("%s [%s](%s)[-]", member.Instance, color, member.ApplyStatus))
+	}
+	text := "[yellow]Used by:[-] " + strings.Join(members, ", ")
+	if pending := group.PendingReboot(); len(pending) > 0 {
+		text += fmt.Sprintf("\n[yellow]Reboot %s to apply the static parameters[-]", strings.Join(pending, ", "))
+	}
+	return text
+}
+
+// fillParameterDiffs lists the parameters set in a group with their engine
+// defaults. Values equal to the default are gray; static parameters are
+// yellow while a reboot is pending. The parameter is the reference of the
+// first cell.
+func fillParameterDiffs(table *tview.Table, diffs []clients.ParameterDiff, rebootPending bool) {
+	table.Clear()
+	setWorkloadHeader(table, false, "Parameter", "Value", "Engine Default", "Apply", "")
+	if len(diffs) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No parameters differ from the engine defaults").SetTextColor(tcell.ColorGray).SetSelectable(false))
+		return
+	}
+
+	for i, diff := range diffs {
+		engineDefault := diff.Default
+		if engineDefault == "" {
+			engineDefault = "(engine default)"
+		}
+		color, note := tcell.ColorWhite, ""
+		switch {
+		case diff.Value == diff.Default:
+			color, note = tcell.ColorGray, "same as default"
+		case diff.ApplyType == "static" && rebootPending:
+			color, note = tcell.ColorYellow, "reboot pending"
+		}
+		cells := []*tview.TableCell{
+			tview.NewTableCell(diff.Name).SetTextColor(color),
+			tview.NewTableCell(diff.Value).SetTextColor(color).SetMaxWidth(48),
+			tview.NewTableCell(engineDefault).SetTextColor(tcell.ColorGray).SetMaxWidth(48),
+			tview.NewTableCell(diff.ApplyType),
+			tview.NewTableCell(note).SetTextColor(color).SetExpansion(1),
+		}
+		cells[0].SetReference(diff)
+		for col, cell := range cells {
+			table.SetCell(i+1, col, cell)
+		}
+	}
+	table.Select(1, 0)
+}
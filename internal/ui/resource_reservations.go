@@ -0,0 +1,365 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/pricing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+// spotView lists the Spot Instance requests
+type spotView struct{ baseView }
+
+var spotService = spotView{baseView{
+	info: ServiceInfo{Name: "spot", DisplayName: "Spot Requests", Icon: "💸", Label: "SPOT", Enabled: true, Permission: "ec2:DescribeSpotInstanceRequests"},
+}}
+
+// StateColor colors the status codes of the requests
+func (spotView) StateColor(state string) tcell.Color {
+	return stateColor(spotStateColors, state)
+}
+
+// spotStateColors color the status codes of fulfilled requests
+var spotStateColors = map[string]tcell.Color{
+	"fulfilled": tcell.ColorGreen,
+}
+
+// Load lists the requests
+func (spotView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadSpotRequests(ctx, client)
+}
+
+// Summary sums up the requests
+func (spotView) Summary(resources []Resource, failed int) (string, string) {
+	return spotSummary(resources)
+}
+
+// reservationsView lists the Reserved Instances and Savings Plans with their
+// usage
+type reservationsView struct{ baseView }
+
+var reservationsService = reservationsView{baseView{
+	info: ServiceInfo{Name: "reservations", DisplayName: "Reservations", Icon: "📅", Label: "RI", Enabled: true, Permission: "ec2:DescribeReservedInstances"},
+	noun: "listing",
+}}
+
+// StateColor colors the usage of the reservations
+func (reservationsView) StateColor(state string) tcell.Color {
+	return stateColor(reservationStateColors, state)
+}
+
+// reservationStateColors color how much of a reservation is used
+var reservationStateColors = map[string]tcell.Color{
+	"used":        tcell.ColorGreen,
+	"unused":      tcell.ColorRed,
+	"partly used": tcell.ColorYellow,
+}
+
+// Load lists the reservations
+func (reservationsView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadReservations(ctx, client)
+}
+
+// Summary sums up the reservations
+func (reservationsView) Summary(resources []Resource, failed int) (string, string) {
+	return reservationsSummary(resources, failed)
+}
+
+// Resource types of the commitment views
+const (
+	typeReservedInstance = "Reserved Instance"
+	typeSavingsPlan      = "Savings Plan"
+)
+
+// spotInterrupted reports whether a Spot request status code announces that
+// its instance is being or was interrupted
+func spotInterrupted(code string) bool {
+	return strings.HasPrefix(code, "marked-for-") ||
+		strings.HasPrefix(code, "instance-terminated-by-") ||
+		strings.HasPrefix(code, "instance-stopped-by-")
+}
+
+// loadSpotRequests lists Spot Instance requests with their state, maximum
+// price and latest status, which carries interruption notices
+func (rt *ResourcesTab) loadSpotRequests(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.EC2 == nil {
+		return nil, fmt.Errorf("EC2 service not initialized")
+	}
+
+	requests, err := svc.EC2.DescribeSpotInstanceRequests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(requests))
+	for _, request := range requests {
+		id := awssdk.ToString(request.SpotInstanceRequestId)
+		instanceType := ""
+		if request.LaunchSpecification != nil {
+			instanceType = string(request.LaunchSpecification.InstanceType)
+		}
+
+		name := awssdk.ToString(request.InstanceId)
+		if name == "" {
+			name = "(no instance)"
+		}
+
+		res := Resource{
+			ID:     id,
+			Name:   name,
+			Type:   "Spot " + instanceType,
+			State:  string(request.State),
+			Region: client.GetRegion(),
+			Tags:   make(map[string]string),
+			Details: map[string]interface{}{
+				"Instance Type": instanceType,
+				"Request Type":  string(request.Type),
+			},
+		}
+		if request.CreateTime != nil {
+			res.CreatedDate = request.CreateTime.Format("2006-01-02 15:04:05")
+		}
+		if price := awssdk.ToString(request.SpotPrice); price != "" {
+			res.Details["Max Price"] = fmt.Sprintf("$%s/h", price)
+		}
+		if zone := awssdk.ToString(request.LaunchedAvailabilityZone); zone != "" {
+			res.Details["Availability Zone"] = zone
+		}
+		if status := request.Status; status != nil {
+			code := awssdk.ToString(status.Code)
+			res.Details["Status"] = code
+			res.Details["Status Message"] = awssdk.ToString(status.Message)
+			if status.UpdateTime != nil {
+				res.Details["Status Updated"] = zonedTime(*status.UpdateTime, "2006-01-02 15:04:05")
+			}
+			// The status code is more telling than the request state, which
+			// stays active until the instance is gone
+			if code != "" {
+				res.State = code
+			}
+			if spotInterrupted(code) {
+				res.Details["Interruption Notice"] = "yes"
+			}
+		}
+		for _, tag := range request.Tags {
+			res.Tags[awssdk.ToString(tag.Key)] = awssdk.ToString(tag.Value)
+		}
+		resources = append(resources, res)
+	}
+	return resources, nil
+}
+
+// spotSummary returns the status message and color for loaded Spot requests
+func spotSummary(resources []Resource) (string, string) {
+	interrupted := 0
+	for _, res := range resources {
+		if _, ok := res.Details["Interruption Notice"]; ok {
+			interrupted++
+		}
+	}
+
+	message := fmt.Sprintf("%d Spot requests", len(resources))
+	if interrupted > 0 {
+		return fmt.Sprintf("%s, %d with interruption notice", message, interrupted), "yellow"
+	}
+	return message, "green"
+}
+
+// reservationUsage returns how many instances of each Reserved Instance are
+// matched by running on-demand instances. Reservations are matched by
+// instance type, and by zone for zonal ones; size flexibility and platforms
+// are not taken into account.
+func reservationUsage(reserved []types.ReservedInstances, instances []types.Instance) []int {
+	available := make(map[string]int)
+	for _, instance := range instances {
+		if instance.State == nil || instance.State.Name != types.InstanceStateNameRunning {
+			continue
+		}
+		if instance.InstanceLifecycle != "" {
+			continue
+		}
+		available[string(instance.InstanceType)+"|"]++
+		if instance.Placement != nil {
+			zone := awssdk.ToString(instance.Placement.AvailabilityZone)
+			available[string(instance.InstanceType)+"|"+zone]++
+		}
+	}
+
+	used := make([]int, len(reserved))
+	// Zonal reservations apply first, as regional ones can take any zone
+	for _, zonal := range []bool{true, false} {
+		for i, ri := range reserved {
+			if (ri.Scope == types.ScopeAvailabilityZone) != zonal {
+				continue
+			}
+			zone := ""
+			if zonal {
+				zone = awssdk.ToString(ri.AvailabilityZone)
+			}
+
+			instanceType := string(ri.InstanceType)
+			n := min(int(awssdk.ToInt32(ri.InstanceCount)), available[instanceType+"|"+zone])
+			used[i] = n
+			available[instanceType+"|"] -= n
+			if zonal {
+				available[instanceType+"|"+zone] -= n
+			}
+		}
+	}
+	return used
+}
+
+// reservedMonthly returns the monthly cost of a Reserved Instance: its hourly
+// charges plus the upfront payment spread over its term
+func reservedMonthly(ri types.ReservedInstances) float64 {
+	hourly := float64(awssdk.ToFloat32(ri.UsagePrice))
+	for _, charge := range ri.RecurringCharges {
+		if charge.Frequency == types.RecurringChargeFrequencyHourly {
+			hourly += awssdk.ToFloat64(charge.Amount)
+		}
+	}
+
+	monthly := hourly * pricing.HoursPerMonth
+	if months := float64(awssdk.ToInt64(ri.Duration)) / 3600 / pricing.HoursPerMonth; months > 0 {
+		monthly += float64(awssdk.ToFloat32(ri.FixedPrice)) / months
+	}
+	return monthly * float64(awssdk.ToInt32(ri.InstanceCount))
+}
+
+// loadReservations lists Reserved Instances with how many of them running
+// instances use, followed by Savings Plans with their commitment. Savings Plans
+// that cannot be listed are reported as a partial failure.
+func (rt *ResourcesTab) loadReservations(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.EC2 == nil {
+		return nil, fmt.Errorf("EC2 service not initialized")
+	}
+
+	reserved, err := svc.EC2.DescribeReservedInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := svc.EC2.GetEC2Detail(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	used := reservationUsage(reserved, instances)
+
+	resources := make([]Resource, 0, len(reserved))
+	for i, ri := range reserved {
+		count := int(awssdk.ToInt32(ri.InstanceCount))
+		state := "used"
+		switch {
+		case used[i] == 0:
+			state = "unused"
+		case used[i] < count:
+			state = "partly used"
+		}
+
+		res := Resource{
+			ID:          awssdk.ToString(ri.ReservedInstancesId),
+			Name:        fmt.Sprintf("%d x %s", count, ri.InstanceType),
+			Type:        typeReservedInstance,
+			State:       state,
+			Region:      client.GetRegion(),
+			Tags:        make(map[string]string),
+			MonthlyCost: reservedMonthly(ri),
+			Details: map[string]interface{}{
+				"Utilization":    fmt.Sprintf("%d of %d in use", used[i], count),
+				"Scope":          string(ri.Scope),
+				"Offering Type":  string(ri.OfferingType),
+				"Platform":       string(ri.ProductDescription),
+				"Instance Count": count,
+				"In Use":         used[i],
+			},
+		}
+		if zone := awssdk.ToString(ri.AvailabilityZone); zone != "" {
+			res.Details["Availability Zone"] = zone
+		}
+		if ri.Start != nil {
+			res.CreatedDate = ri.Start.Format("2006-01-02 15:04:05")
+		}
+		if ri.End != nil {
+			res.Details["Expires"] = zonedTime(*ri.End, "2006-01-02")
+		}
+		for _, tag := range ri.Tags {
+			res.Tags[awssdk.ToString(tag.Key)] = awssdk.ToString(tag.Value)
+		}
+		resources = append(resources, res)
+	}
+
+	if svc.SavingsPlans == nil {
+		return resources, nil
+	}
+	plans, err := svc.SavingsPlans.DescribeSavingsPlans(ctx)
+	if err != nil {
+		return resources, &clients.PartialError{
+			Op:       "reservations",
+			Failures: []clients.ItemError{{Item: "Savings Plans", Err: err}},
+		}
+	}
+
+	for _, plan := range plans {
+		res := Resource{
+			ID:          plan.ID,
+			Name:        fmt.Sprintf("%s $%.2f/h", plan.Type, plan.Commitment),
+			Type:        typeSavingsPlan,
+			State:       plan.State,
+			Region:      plan.Region,
+			CreatedDate: plan.Start.Format("2006-01-02 15:04:05"),
+			Tags:        make(map[string]string),
+			MonthlyCost: plan.Commitment * pricing.HoursPerMonth,
+			Details: map[string]interface{}{
+				"Plan Type":      plan.Type,
+				"Payment Option": plan.PaymentOption,
+				"Commitment":     fmt.Sprintf("$%.3f/h", plan.Commitment),
+				"Expires":        zonedTime(plan.End, "2006-01-02"),
+			},
+		}
+		if res.Region == "" {
+			res.Region = "all"
+		}
+		if plan.InstanceFamily != "" {
+			res.Details["Instance Family"] = plan.InstanceFamily
+		}
+		resources = append(resources, res)
+	}
+	return resources, nil
+}
+
+// reservationsSummary returns the status message and color for loaded
+// reservations: how much of the reserved capacity is in use and the hourly
+// Savings Plans commitment
+func reservationsSummary(resources []Resource, failed int) (string, string) {
+	var reserved, used int
+	var commitment float64
+	for _, res := range resources {
+		switch res.Type {
+		case typeReservedInstance:
+			count, _ := res.Details["Instance Count"].(int)
+			inUse, _ := res.Details["In Use"].(int)
+			reserved += count
+			used += inUse
+		case typeSavingsPlan:
+			commitment += res.MonthlyCost / pricing.HoursPerMonth
+		}
+	}
+
+	message := fmt.Sprintf("%d of %d reserved instances in use, Savings Plans $%.2f/h", used, reserved, commitment)
+	switch {
+	case failed > 0:
+		return message + ", Savings Plans failed to load", "yellow"
+	case used < reserved:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
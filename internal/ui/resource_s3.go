@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+
+	"github.com/rivo/tview"
+)
+
+// s3View lists the S3 buckets. The regions the listing left out are looked
+// up when it is shown, and Enter browses the objects of a bucket.
+type s3View struct{ baseView }
+
+var s3Service = s3View{baseView{
+	info: ServiceInfo{Name: "s3", DisplayName: "S3 Buckets", Icon: "🪣", Label: "S3", Enabled: true, Permission: "s3:ListAllMyBuckets"},
+	noun: "bucket",
+}}
+
+// ConsoleURL links the bucket
+func (s3View) ConsoleURL(base, query string, res Resource) string {
+	return s3BucketConsoleURL(base, query, res)
+}
+
+// ARN returns the ARN of the bucket
+func (s3View) ARN(account string, res Resource) string {
+	return fmt.Sprintf("arn:%s:s3:::%s", awsPartition(res.Region), res.Name)
+}
+
+// Load lists the buckets
+func (s3View) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadS3Buckets(ctx, client)
+}
+
+// Shown looks up the missing bucket regions
+func (s3View) Shown(ctx context.Context, rt *ResourcesTab, resources []Resource) {
+	rt.resolveBucketRegions(ctx, resources)
+}
+
+// Open browses the objects of the bucket
+func (s3View) Open(rt *ResourcesTab, resource Resource) {
+	rt.showObjects(resource.Name)
+}
+
+// loadS3Buckets loads S3 buckets
+func (rt *ResourcesTab) loadS3Buckets(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	// Regions not looked up yet are filled in by resolveBucketRegions after rendering
+	details, err := client.ListS3Buckets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets: %w", err)
+	}
+
+	var resources []Resource
+
+	for i, detail := range details {
+		resource := Resource{
+			ID:     strconv.Itoa(i),
+			Name:   detail.Name,
+			Type:   "S3 Bucket",
+			State:  "Available",
+			Region: detail.Region,
+			Tags:   make(map[string]string),
+		}
+
+		if detail.CreationDate != nil {
+			resource.CreatedDate = detail.CreationDate.Format("2006-01-02 15:04:05")
+		}
+
+		resource.Details = map[string]interface{}{
+			"BucketName": detail.Name,
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
+
+// resolveBucketRegions looks up the missing bucket regions in the background
+// and fills them into the table as they arrive
+func (rt *ResourcesTab) resolveBucketRegions(ctx context.Context, resources []Resource) {
+	var names []string
+	for _, res := range resources {
+		if res.Region == "" {
+			names = append(names, res.Name)
+		}
+	}
+	if len(names) == 0 || rt.awsClient == nil {
+		return
+	}
+
+	client := rt.awsClient
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+		defer cancel()
+
+		err := client.LookupS3BucketRegions(ctx, names, func(name, region string) {
+			if rt.app != nil {
+				rt.app.QueueUpdateDraw(func() {
+					rt.setBucketRegion(name, region)
+				})
+			}
+		})
+
+		var partial *clients.PartialError
+		if errors.As(err, &partial) && rt.app != nil {
+			rt.app.QueueUpdateDraw(func() {
+				// Navigation cancels ctx; the warnings belong to the listing it was for
+				if ctx.Err() == nil {
+					rt.addWarnings(partial.Failures...)
+				}
+			})
+		}
+	}()
+}
+
+// setBucketRegion updates the region of a listed bucket in place
+func (rt *ResourcesTab) setBucketRegion(name, region string) {
+	if rt.selectedService != "s3" {
+		return
+	}
+
+	// filteredRes shares its backing array with the cached listing, so this
+	// also updates the cache
+	for i := range rt.filteredRes {
+		if rt.filteredRes[i].Type == "S3 Bucket" && rt.filteredRes[i].Name == name {
+			rt.filteredRes[i].Region = region
+		}
+	}
+
+	for row := range rt.visibleRes {
+		res := &rt.visibleRes[row]
+		if res.Type != "S3 Bucket" || res.Name != name {
+			continue
+		}
+		res.Region = region
+		rt.resourceTable.SetCell(row+1, 4, tview.NewTableCell(region))
+
+		if rt.selectedRes != nil && rt.selectedRes.Type == res.Type && rt.selectedRes.Name == name {
+			rt.selectedRes.Region = region
+			rt.updateResourceDetails(rt.selectedRes)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// s3ExposureView audits the buckets for public and cross-account access
+type s3ExposureView struct{ baseView }
+
+var s3ExposureService = s3ExposureView{baseView{
+	info: ServiceInfo{Name: "s3exposure", DisplayName: "S3 Exposure", Icon: "🔓", Label: "S3X", Enabled: true, Permission: "s3:ListAllMyBuckets"},
+	noun: "bucket",
+}}
+
+// ConsoleURL links the bucket
+func (s3ExposureView) ConsoleURL(base, query string, res Resource) string {
+	return s3BucketConsoleURL(base, query, res)
+}
+
+// Load lists the buckets
+func (s3ExposureView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadBucketExposure(ctx, client)
+}
+
+// Summary sums up the buckets
+func (s3ExposureView) Summary(resources []Resource, failed int) (string, string) {
+	return bucketExposureSummary(resources, failed)
+}
+
+// loadBucketExposure audits the buckets of the account for public access,
+// disabled public access blocks and policies granting other accounts
+// access, adding the findings of IAM Access Analyzer in the regions that
+// have an analyzer. Buckets that could not be audited are named in a
+// PartialError.
+func (rt *ResourcesTab) loadBucketExposure(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.S3 == nil {
+		return nil, fmt.Errorf("S3 service not initialized")
+	}
+
+	identity, err := client.WaitForIdentity(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the account: %w", err)
+	}
+	account := ""
+	if identity != nil && identity.Account != nil {
+		account = *identity.Account
+	}
+
+	var failures []clients.ItemError
+	buckets, err := svc.S3.GetS3Detail(ctx)
+	if err := collectFailures(err, &failures); err != nil {
+		return nil, err
+	}
+	// Buckets are read in their region; those whose region could not be
+	// looked up already failed
+	var located []clients.S3Details
+	for _, bucket := range buckets {
+		if bucket.Region != "" {
+			located = append(located, bucket)
+		}
+	}
+	exposures, err := svc.S3.AuditBuckets(ctx, located, account)
+	if err := collectFailures(err, &failures); err != nil {
+		return nil, err
+	}
+
+	// Analyzers report the buckets of their own region
+	var regions []string
+	seen := make(map[string]bool)
+	for _, exposure := range exposures {
+		if exposure.Region != "" && !seen[exposure.Region] {
+			seen[exposure.Region] = true
+			regions = append(regions, exposure.Region)
+		}
+	}
+	sort.Strings(regions)
+
+	findings := make(map[string][]clients.AccessFinding)
+	analyzerNotes := make(map[string]string)
+	for _, region := range regions {
+		if svc.AccessAnalyzer == nil {
+			analyzerNotes[region] = "Access Analyzer service not initialized"
+			continue
+		}
+		list, err := svc.AccessAnalyzer.ListBucketFindings(ctx, region)
+		switch {
+		case errors.Is(err, clients.ErrNoAnalyzer):
+			analyzerNotes[region] = "no analyzer in " + region
+		case err != nil:
+			logger.Warn("Failed to read Access Analyzer findings", zap.String("region", region), zap.Error(err))
+			analyzerNotes[region] = "unavailable: " + clients.ErrorReason(err)
+		default:
+			for _, finding := range list {
+				findings[finding.Resource] = append(findings[finding.Resource], finding)
+			}
+		}
+	}
+
+	resources := make([]Resource, 0, len(exposures))
+	for _, exposure := range exposures {
+		resources = append(resources, bucketExposureResource(exposure, findings[exposure.Bucket], analyzerNotes[exposure.Region]))
+	}
+	if len(failures) > 0 {
+		return resources, &clients.PartialError{Op: "s3 exposure", Failures: failures}
+	}
+	return resources, nil
+}
+
+// bucketExposureResource describes how the bucket of exposure is exposed,
+// with the active Access Analyzer findings about it or analyzerNote when
+// they could not be read. Public buckets, buckets shared with other
+// accounts and buckets whose public access block is off are flagged.
+func bucketExposureResource(exposure clients.BucketExposure, findings []clients.AccessFinding, analyzerNote string) Resource {
+	res := Resource{
+		ID:     exposure.Bucket,
+		Name:   exposure.Bucket,
+		Type:   "S3 Bucket",
+		Region: exposure.Region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"Public Access Block": describeAccessBlock(exposure.PublicAccessBlock),
+			"ACL":                 "private",
+		},
+	}
+
+	switch {
+	case !exposure.HasPolicy:
+		res.Details["Policy"] = "none"
+	case exposure.PolicyPublic:
+		res.Details["Policy"] = "grants public access"
+	default:
+		res.Details["Policy"] = "not public"
+	}
+	if len(exposure.PublicGrants) > 0 {
+		res.Details["ACL"] = "grants " + strings.Join(exposure.PublicGrants, ", ")
+	}
+	if len(exposure.CrossAccount) > 0 {
+		res.Details["Cross-account"] = strings.Join(exposure.CrossAccount, ", ")
+	}
+
+	analyzerPublic := false
+	var shared []string
+	switch {
+	case analyzerNote != "":
+		res.Details["Access Analyzer"] = analyzerNote
+	case len(findings) == 0:
+		res.Details["Access Analyzer"] = "no active findings"
+	default:
+		parts := make([]string, len(findings))
+		for i, finding := range findings {
+			parts[i] = fmt.Sprintf("%s may %s", strings.Join(finding.Principals, ", "), strings.Join(finding.Actions, ", "))
+			if len(finding.Conditions) > 0 {
+				parts[i] += " if " + strings.Join(finding.Conditions, " and ")
+			}
+			if finding.IsPublic {
+				analyzerPublic = true
+			} else {
+				shared = append(shared, finding.Principals...)
+			}
+		}
+		res.Details["Access Analyzer"] = strings.Join(parts, "; ")
+	}
+
+	var flags []string
+	if exposure.Public() || analyzerPublic {
+		flags = append(flags, "public")
+	}
+	if len(exposure.CrossAccount) > 0 || len(shared) > 0 {
+		flags = append(flags, "shared with other accounts")
+	}
+	if len(exposure.PublicAccessBlock.Disabled()) > 0 {
+		flags = append(flags, "public access block off")
+	}
+
+	switch {
+	case len(flags) == 0:
+		res.State = "private"
+	case flags[0] == "public":
+		res.State = "public"
+	case flags[0] == "shared with other accounts":
+		res.State = "cross-account"
+	default:
+		res.State = "unblocked"
+	}
+	if len(flags) > 0 {
+		res.Alert = true
+		res.Details["Flag"] = strings.Join(flags, ", ")
+	}
+	return res
+}
+
+// describeAccessBlock sums up a public access block, e.g. "off:
+// BlockPublicAcls, IgnorePublicAcls"
+func describeAccessBlock(block clients.PublicAccessBlock) string {
+	disabled := block.Disabled()
+	switch {
+	case !block.Configured:
+		return "not configured"
+	case len(disabled) == 0:
+		return "blocks all public access"
+	default:
+		return "off: " + strings.Join(disabled, ", ")
+	}
+}
+
+// bucketExposureSummary counts the public buckets, those shared with other
+// accounts and those whose public access block is off
+func bucketExposureSummary(resources []Resource, failed int) (string, string) {
+	var public, shared, unblocked int
+	for _, res := range resources {
+		flag, _ := res.Details["Flag"].(string)
+		if res.State == "public" {
+			public++
+		}
+		if strings.Contains(flag, "shared with other accounts") {
+			shared++
+		}
+		if strings.Contains(flag, "public access block off") {
+			unblocked++
+		}
+	}
+
+	message := fmt.Sprintf("%d buckets, %d public, %d shared with other accounts, %d without a full public access block",
+		len(resources), public, shared, unblocked)
+	switch {
+	case public > 0:
+		return message, "red"
+	case shared > 0 || unblocked > 0 || failed > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
@@ -0,0 +1,519 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/pricing"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// s3UploadsView lists the incomplete multipart uploads of every bucket,
+// whose parts are billed until they are aborted, and whether a lifecycle
+// rule aborts them
+type s3UploadsView struct{ baseView }
+
+var s3UploadsService = s3UploadsView{baseView{
+	info: ServiceInfo{Name: "s3uploads", DisplayName: "S3 Multipart Uploads", Icon: "🧩", Label: "S3U", Enabled: true, Permission: "s3:ListBucketMultipartUploads"},
+	noun: "bucket",
+}}
+
+// ConsoleURL links the bucket of the upload
+func (s3UploadsView) ConsoleURL(base, query string, res Resource) string {
+	return s3BucketConsoleURL(base, query, res)
+}
+
+// StateColor colors the buckets by their uploads
+func (s3UploadsView) StateColor(state string) tcell.Color {
+	return stateColor(s3UploadStateColors, state)
+}
+
+// s3UploadStateColors color the buckets by their incomplete uploads
+var s3UploadStateColors = map[string]tcell.Color{
+	"clean":         tcell.ColorGreen,
+	"stale uploads": tcell.ColorRed,
+	"uploading":     tcell.ColorYellow,
+}
+
+// staleUploadDays is the age after which an upload is unlikely to still be
+// running and is flagged
+const staleUploadDays = 7
+
+// Load lists the uploads of all buckets
+func (s3UploadsView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return loadIncompleteUploads(ctx, client)
+}
+
+// Summary counts the buckets holding uploads and what they cost
+func (s3UploadsView) Summary(resources []Resource, failed int) (string, string) {
+	return incompleteUploadsSummary(resources, failed)
+}
+
+// Open lists the uploads of the bucket
+func (s3UploadsView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showIncompleteUploads(resource)
+}
+
+// Actions abort the uploads of a bucket and add a lifecycle rule aborting
+// them
+func (s3UploadsView) Actions() []resourceAction {
+	return []resourceAction{
+		{name: "s3uploads abort", key: 'a', description: "action.s3uploads_abort",
+			onResource: true, online: true,
+			run: (*ResourcesTab).onAbortUploads},
+		{name: "s3uploads lifecycle", key: 'l', description: "action.s3uploads_lifecycle",
+			onResource: true, online: true,
+			run: (*ResourcesTab).onAbortRule},
+	}
+}
+
+// loadIncompleteUploads lists the incomplete multipart uploads of the
+// buckets of the account. Buckets that could not be read are named in a
+// PartialError.
+func loadIncompleteUploads(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.S3 == nil {
+		return nil, fmt.Errorf("S3 service not initialized")
+	}
+
+	var failures []clients.ItemError
+	buckets, err := svc.S3.GetS3Detail(ctx)
+	if err := collectFailures(err, &failures); err != nil {
+		return nil, err
+	}
+	// Buckets are read in their region; those whose region could not be
+	// looked up already failed
+	var located []clients.S3Details
+	for _, bucket := range buckets {
+		if bucket.Region != "" {
+			located = append(located, bucket)
+		}
+	}
+	found, err := svc.S3.ListIncompleteUploads(ctx, located)
+	if err := collectFailures(err, &failures); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resources := make([]Resource, 0, len(found))
+	for _, uploads := range found {
+		resources = append(resources, incompleteUploadsResource(uploads, now))
+	}
+	if len(failures) > 0 {
+		return resources, &clients.PartialError{Op: "s3 uploads", Failures: failures}
+	}
+	return resources, nil
+}
+
+// incompleteUploadsResource describes the incomplete uploads of a bucket
+// and what their parts cost a month. Buckets holding uploads older than
+// staleUploadDays that no lifecycle rule aborts are flagged.
+func incompleteUploadsResource(uploads clients.BucketUploads, now time.Time) Resource {
+	res := Resource{
+		ID:     uploads.Bucket,
+		Name:   uploads.Bucket,
+		Type:   "S3 Bucket",
+		State:  "clean",
+		Region: uploads.Region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"Incomplete Uploads": "none",
+			"Abort Rule":         "none, press l to add one",
+		},
+	}
+	if uploads.AbortAfterDays > 0 {
+		res.Details["Abort Rule"] = fmt.Sprintf("aborts uploads %s after they started", pluralize(uploads.AbortAfterDays, "day"))
+	}
+	if len(uploads.Uploads) == 0 {
+		return res
+	}
+
+	parts, stale := 0, 0
+	var staleSize int64
+	for _, upload := range uploads.Uploads {
+		parts += upload.Parts
+		if cost, ok := pricing.S3Monthly(upload.StorageClass, upload.Size, uploads.Region); ok {
+			res.MonthlyCost += cost
+		}
+		if now.Sub(upload.Initiated) > staleUploadDays*24*time.Hour {
+			stale++
+			staleSize += upload.Size
+		}
+	}
+	oldest := uploads.Uploads[0]
+	res.Details["Incomplete Uploads"] = fmt.Sprintf("%d (%s in %s)", len(uploads.Uploads), formatBytes(uploads.Size()), pluralize(parts, "part"))
+	res.Details["Oldest Upload"] = fmt.Sprintf("%s, started %s ago", oldest.Key, workloadAge(oldest.Initiated))
+	res.Details["View"] = "press Enter to list the uploads"
+
+	if stale == 0 {
+		res.State = "uploading"
+		return res
+	}
+	res.State = "stale uploads"
+	flag := fmt.Sprintf("%s older than %d days hold %s", pluralize(stale, "upload"), staleUploadDays, formatBytes(staleSize))
+	if uploads.AbortAfterDays == 0 {
+		res.Alert = true
+		flag += " and no lifecycle rule aborts them"
+	}
+	res.Details["Flag"] = flag
+	return res
+}
+
+// incompleteUploadsSummary counts the buckets holding uploads, what their
+// parts cost and the buckets that are not cleaned up
+func incompleteUploadsSummary(resources []Resource, failed int) (string, string) {
+	holding, unruled, alerts := 0, 0, 0
+	var cost float64
+	for _, res := range resources {
+		if res.State != "clean" {
+			holding++
+		}
+		if res.Alert {
+			alerts++
+		}
+		if strings.HasPrefix(detailString(res, "Abort Rule"), "none") {
+			unruled++
+		}
+		cost += res.MonthlyCost
+	}
+
+	message := fmt.Sprintf("%s, %d holding incomplete uploads ($%.2f/month), %d without an abort rule",
+		pluralize(len(resources), "bucket"), holding, cost, unruled)
+	switch {
+	case alerts > 0:
+		return message, "red"
+	case holding > 0 || failed > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// showIncompleteUploads lists the uploads of the bucket res over the tab.
+// a aborts old uploads, l adds a lifecycle rule, r reloads and q closes
+// the panel.
+func (rt *ResourcesTab) showIncompleteUploads(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	bucket := clients.S3Details{Name: res.Name, Region: res.Region}
+	loads := 0
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("uploads.title", res.Name))
+
+	load := func() {
+		view.SetText("[gray]" + i18n.T("common.loading") + "[-]")
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			var found []clients.BucketUploads
+			err := fmt.Errorf("S3 service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.S3 != nil {
+				found, err = svc.S3.ListIncompleteUploads(ctx, []clients.S3Details{bucket})
+			}
+			if err == nil && len(found) != 1 {
+				err = fmt.Errorf("bucket %s not found", bucket.Name)
+			}
+			if err != nil {
+				logger.Error("Failed to list multipart uploads", zap.String("bucket", bucket.Name), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					view.SetText("[red]" + i18n.T("uploads.list_failed", stateWord("red"), tview.Escape(bucket.Name), tview.Escape(clients.ErrorReason(err))) + "[-]")
+					return
+				}
+				view.SetText(renderIncompleteUploads(found[0], time.Now())).ScrollToBeginning()
+			})
+		}()
+	}
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.view.RemovePage("s3-uploads")
+			if rt.app != nil {
+				rt.app.SetFocus(rt.resourceTable)
+			}
+			return nil
+		case 'r':
+			load()
+			return nil
+		case 'a':
+			rt.abortOldUploads(client, bucket, view, load)
+			return nil
+		case 'l':
+			rt.editAbortRule(client, bucket, detailString(res, "Abort Rule"), view, load)
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("s3-uploads", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(view)
+	}
+	load()
+}
+
+// renderIncompleteUploads lists the uploads of a bucket, oldest first,
+// marking those older than staleUploadDays
+func renderIncompleteUploads(uploads clients.BucketUploads, now time.Time) string {
+	var text strings.Builder
+	if uploads.AbortAfterDays > 0 {
+		fmt.Fprintf(&text, "[green]A lifecycle rule aborts uploads %s after they started[-]\n\n", pluralize(uploads.AbortAfterDays, "day"))
+	} else {
+		text.WriteString("[yellow]No lifecycle rule aborts incomplete uploads; press l to add one[-]\n\n")
+	}
+	if len(uploads.Uploads) == 0 {
+		text.WriteString("[gray]The bucket holds no incomplete uploads[-]")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "[yellow]%-8s %10s %7s  %-13s %-16s %s[-]\n", "Started", "Size", "Parts", "Class", "Initiator", "Key")
+	for _, upload := range uploads.Uploads {
+		age := workloadAge(upload.Initiated) + " ago"
+		line := fmt.Sprintf("%-8s %10s %7d  %-13s %-16s %s", age, formatBytes(upload.Size), upload.Parts,
+			orDash(upload.StorageClass), tview.Escape(orDash(upload.Initiator)), tview.Escape(upload.Key))
+		if now.Sub(upload.Initiated) > staleUploadDays*24*time.Hour {
+			line = "[red]" + line + "[-]"
+		}
+		text.WriteString(line + "\n")
+	}
+	fmt.Fprintf(&text, "\n%s in %s", formatBytes(uploads.Size()), pluralize(len(uploads.Uploads), "upload"))
+	return text.String()
+}
+
+// onAbortUploads aborts old uploads of the selected bucket
+func (rt *ResourcesTab) onAbortUploads() {
+	if rt.selectedService != "s3uploads" || rt.selectedRes == nil || rt.awsClient == nil {
+		return
+	}
+	res := *rt.selectedRes
+	rt.abortOldUploads(rt.awsClient, clients.S3Details{Name: res.Name, Region: res.Region}, rt.resourceTable, nil)
+}
+
+// onAbortRule adds a lifecycle rule aborting the uploads of the selected
+// bucket
+func (rt *ResourcesTab) onAbortRule() {
+	if rt.selectedService != "s3uploads" || rt.selectedRes == nil || rt.awsClient == nil {
+		return
+	}
+	res := *rt.selectedRes
+	rt.editAbortRule(rt.awsClient, clients.S3Details{Name: res.Name, Region: res.Region}, detailString(res, "Abort Rule"), rt.resourceTable, nil)
+}
+
+// closeUploadsEdit removes the form or confirmation over the uploads and
+// returns focus to back
+func (rt *ResourcesTab) closeUploadsEdit(back tview.Primitive) {
+	rt.view.RemovePage("s3-uploads-edit")
+	if rt.app != nil {
+		rt.app.SetFocus(back)
+	}
+}
+
+// abortOldUploads asks for an age and, once confirmed, aborts the uploads
+// of bucket older than that, returning focus to back and calling done, if
+// any, once they were aborted
+func (rt *ResourcesTab) abortOldUploads(client *aws.Client, bucket clients.S3Details, back tview.Primitive, done func()) {
+	value := strconv.Itoa(staleUploadDays)
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("common.older_than"), value, 6, tview.InputFieldInteger, func(text string) { value = text })
+	form.AddButton(i18n.T("uploads.find"), func() {
+		rt.closeUploadsEdit(back)
+		days, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || days < 0 {
+			rt.updateStatus(i18n.T("common.bad_age"), "red")
+			return
+		}
+		rt.confirmAbortUploads(client, bucket, days, back, done)
+	})
+	form.AddButton(i18n.T("common.cancel"), func() { rt.closeUploadsEdit(back) })
+	form.SetCancelFunc(func() { rt.closeUploadsEdit(back) })
+	form.SetBorder(true).
+		SetTitle(panelTitle("uploads.abort_title", bucket.Name)).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("s3-uploads-edit", centered(form, 64, 7), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(form)
+	}
+}
+
+// confirmAbortUploads lists the uploads of bucket older than days and asks
+// before aborting them
+func (rt *ResourcesTab) confirmAbortUploads(client *aws.Client, bucket clients.S3Details, days int, back tview.Primitive, done func()) {
+	rt.updateStatus(i18n.T("uploads.listing", bucket.Name), "yellow")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		var found []clients.BucketUploads
+		err := fmt.Errorf("S3 service not initialized")
+		if svc := client.GetClients(); svc != nil && svc.S3 != nil {
+			found, err = svc.S3.ListIncompleteUploads(ctx, []clients.S3Details{bucket})
+		}
+		var old []clients.MultipartUpload
+		var size int64
+		if err == nil && len(found) == 1 {
+			cutoff := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+			for _, upload := range found[0].Uploads {
+				if upload.Initiated.Before(cutoff) {
+					old = append(old, upload)
+					size += upload.Size
+				}
+			}
+		}
+		if rt.app == nil {
+			return
+		}
+		rt.app.QueueUpdateDraw(func() {
+			switch {
+			case err != nil:
+				rt.updateStatus(i18n.T("uploads.cannot_list", bucket.Name, clients.ErrorReason(err)), "red")
+				return
+			case len(old) == 0:
+				rt.updateStatus(i18n.T("uploads.none_older", bucket.Name, i18n.N("count.day", days)), "green")
+				return
+			}
+
+			rt.updateStatus(i18n.T("uploads.confirm", i18n.N("count.upload", len(old)), bucket.Name), "yellow")
+			modal := tview.NewModal().
+				SetText(i18n.T("dialog.abort_uploads",
+					i18n.N("count.upload", len(old)), bucket.Name, i18n.N("count.day", days), formatBytes(size))).
+				AddButtons([]string{i18n.T("dialog.abort"), i18n.T("common.cancel")}).
+				SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+					rt.closeUploadsEdit(back)
+					if buttonIndex == 0 {
+						rt.runAbortUploads(client, bucket, old, done)
+					} else {
+						rt.updateStatus(i18n.T("uploads.nothing_aborted"), "green")
+					}
+				})
+			rt.view.AddPage("s3-uploads-edit", modal, false, true)
+		})
+	}()
+}
+
+// runAbortUploads aborts uploads of bucket, records it and reloads the
+// listing when it is still shown
+func (rt *ResourcesTab) runAbortUploads(client *aws.Client, bucket clients.S3Details, uploads []clients.MultipartUpload, done func()) {
+	rt.updateStatus(i18n.T("uploads.aborting", i18n.N("count.upload", len(uploads)), bucket.Name), "yellow")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+		defer cancel()
+
+		err := fmt.Errorf("S3 service not initialized")
+		if svc := client.GetClients(); svc != nil && svc.S3 != nil {
+			err = svc.S3.AbortUploads(ctx, bucket.Name, uploads)
+		}
+		rt.audit.record(client, "s3:AbortMultipartUpload", fmt.Sprintf("%s (%s)", bucket.Name, pluralize(len(uploads), "upload")), err)
+		if err != nil {
+			logger.Error("Failed to abort multipart uploads", zap.String("bucket", bucket.Name), zap.Error(err))
+		} else {
+			logger.Info("Aborted multipart uploads", zap.String("bucket", bucket.Name), zap.Int("uploads", len(uploads)))
+		}
+		rt.afterUploadsChange(client, i18n.T("uploads.aborted", i18n.N("count.upload", len(uploads)), bucket.Name),
+			i18n.T("uploads.abort_failed", bucket.Name), err, done)
+	}()
+}
+
+// editAbortRule asks after how many days a lifecycle rule of bucket should
+// abort incomplete uploads, describing the current rule as current
+func (rt *ResourcesTab) editAbortRule(client *aws.Client, bucket clients.S3Details, current string, back tview.Primitive, done func()) {
+	value := strconv.Itoa(staleUploadDays)
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("uploads.abort_after"), value, 6, tview.InputFieldInteger, func(text string) { value = text })
+	form.AddButton(i18n.T("common.save"), func() {
+		rt.closeUploadsEdit(back)
+		days, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || days < 1 {
+			rt.updateStatus(i18n.T("uploads.min_age"), "red")
+			return
+		}
+		rt.runAbortRule(client, bucket, days, done)
+	})
+	form.AddButton(i18n.T("common.cancel"), func() { rt.closeUploadsEdit(back) })
+	form.SetCancelFunc(func() { rt.closeUploadsEdit(back) })
+	form.SetBorder(true).
+		SetTitle(panelTitle("uploads.rule_title", bucket.Name, strings.TrimSuffix(current, ", press l to add one"))).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("s3-uploads-edit", centered(form, 72, 7), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(form)
+	}
+}
+
+// runAbortRule sets the lifecycle rule of bucket aborting uploads after
+// days, records it and reloads the listing when it is still shown
+func (rt *ResourcesTab) runAbortRule(client *aws.Client, bucket clients.S3Details, days int, done func()) {
+	rt.updateStatus(i18n.T("uploads.adding_rule", bucket.Name), "yellow")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := fmt.Errorf("S3 service not initialized")
+		if svc := client.GetClients(); svc != nil && svc.S3 != nil {
+			err = svc.S3.SetAbortIncompleteUploads(ctx, bucket.Name, days)
+		}
+		rt.audit.record(client, "s3:PutLifecycleConfiguration", fmt.Sprintf("%s (abort uploads after %s)", bucket.Name, pluralize(days, "day")), err)
+		if err != nil {
+			logger.Error("Failed to set the abort rule", zap.String("bucket", bucket.Name), zap.Error(err))
+		}
+		rt.afterUploadsChange(client, i18n.T("uploads.rule_added", bucket.Name, i18n.N("count.day", days)),
+			i18n.T("uploads.rule_failed", bucket.Name), err, done)
+	}()
+}
+
+// afterUploadsChange reports a change to the uploads of a bucket and
+// reloads the listing when it is still shown
+func (rt *ResourcesTab) afterUploadsChange(client *aws.Client, success, failure string, err error, done func()) {
+	if rt.app == nil {
+		return
+	}
+	rt.app.QueueUpdateDraw(func() {
+		if err != nil {
+			rt.updateStatus(fmt.Sprintf("%s: %s", failure, clients.ErrorReason(err)), "red")
+			return
+		}
+		if rt.awsClient == client && rt.selectedService == "s3uploads" {
+			rt.loadService("s3uploads", true)
+		}
+		if done != nil {
+			done()
+		}
+		rt.updateStatus(success, "green")
+	})
+}
@@ -0,0 +1,440 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/pricing"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// sageMakerView lists the SageMaker notebook instances, endpoints with
+// their invocations and the latest training jobs
+type sageMakerView struct{ baseView }
+
+var sageMakerService = sageMakerView{baseView{
+	info: ServiceInfo{Name: "sagemaker", DisplayName: "SageMaker", Icon: "🧠", Label: "SGM", Enabled: true, Permission: "sagemaker:ListEndpoints"},
+	noun: "listing",
+}}
+
+// ConsoleURL links the notebook instance, training job or endpoint
+func (sageMakerView) ConsoleURL(base, query string, res Resource) string {
+	page := "endpoints"
+	switch {
+	case strings.HasPrefix(res.Type, typeNotebookInstance):
+		page = "notebook-instances"
+	case res.Type == typeTrainingJob:
+		page = "jobs"
+	}
+	return fmt.Sprintf("%s/sagemaker/home?%s#/%s/%s", base, query, page, url.PathEscape(res.Name))
+}
+
+// StateColor colors the statuses of the notebooks, endpoints and jobs
+func (sageMakerView) StateColor(state string) tcell.Color {
+	return stateColor(sageMakerStateColors, state)
+}
+
+// sageMakerStateColors color the statuses of notebooks, endpoints and
+// training jobs in words
+var sageMakerStateColors = map[string]tcell.Color{
+	"in service":     tcell.ColorGreen,
+	"completed":      tcell.ColorGreen,
+	"out of service": tcell.ColorRed,
+	"in progress":    tcell.ColorYellow,
+}
+
+// Load lists the notebooks, endpoints and training jobs
+func (sageMakerView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadSageMaker(ctx, client)
+}
+
+// Summary counts the endpoints that cost without being called and what is
+// running
+func (sageMakerView) Summary(resources []Resource, failed int) (string, string) {
+	return sageMakerSummary(resources, failed)
+}
+
+// Actions stops notebooks and idle endpoints
+func (sageMakerView) Actions() []resourceAction {
+	return []resourceAction{
+		{name: "sagemaker stop", key: 's', description: "action.sagemaker_stop",
+			onResource: true, online: true,
+			run: (*ResourcesTab).onSageMakerStop},
+	}
+}
+
+// Resource types of the SageMaker view
+const (
+	typeNotebookInstance  = "Notebook Instance"
+	typeSageMakerEndpoint = "Endpoint"
+	typeTrainingJob       = "Training Job"
+)
+
+const (
+	// sageMakerTrainingJobs is how many of the latest training jobs are listed
+	sageMakerTrainingJobs = 20
+	// sageMakerIdleWindow is how long an endpoint without invocations has to
+	// be idle
+	sageMakerIdleWindow = 24 * time.Hour
+)
+
+// sageMakerState turns a SageMaker status into words, e.g. InService into
+// "in service"
+func sageMakerState(status string) string {
+	if status == "" {
+		return "-"
+	}
+	var words strings.Builder
+	for i, r := range status {
+		if i > 0 && unicode.IsUpper(r) {
+			words.WriteByte(' ')
+		}
+		words.WriteRune(unicode.ToLower(r))
+	}
+	return words.String()
+}
+
+// loadSageMaker lists the notebook instances, the endpoints with their
+// invocations over the last day and the latest training jobs of the
+// region. Endpoints that fail, or cost without being invoked, are flagged.
+func (rt *ResourcesTab) loadSageMaker(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.SageMaker == nil {
+		return nil, fmt.Errorf("SageMaker service not initialized")
+	}
+
+	endpoints, err := svc.SageMaker.ListEndpoints(ctx)
+	var partial *clients.PartialError
+	if err != nil && !errors.As(err, &partial) {
+		return nil, err
+	}
+
+	region := client.GetRegion()
+	var resources []Resource
+	var failures []clients.ItemError
+	if partial != nil {
+		failures = append(failures, partial.Failures...)
+	}
+
+	for _, endpoint := range endpoints {
+		res := sageMakerEndpointResource(endpoint, region)
+		if endpoint.Status == "InService" {
+			invocations, err := endpointInvocations(ctx, client, endpoint)
+			if err != nil {
+				failures = append(failures, clients.ItemError{Item: endpoint.Name + " invocations", Region: region, Err: err})
+			} else {
+				flagIdleEndpoint(&res, invocations)
+			}
+		}
+		resources = append(resources, res)
+	}
+
+	notebooks, err := svc.SageMaker.ListNotebookInstances(ctx)
+	if err != nil {
+		failures = append(failures, clients.ItemError{Item: "notebook instances", Region: region, Err: err})
+	}
+	for _, notebook := range notebooks {
+		resources = append(resources, sageMakerNotebookResource(notebook, region))
+	}
+
+	jobs, err := svc.SageMaker.ListTrainingJobs(ctx, sageMakerTrainingJobs)
+	if err != nil {
+		failures = append(failures, clients.ItemError{Item: "training jobs", Region: region, Err: err})
+	}
+	now := time.Now()
+	for _, job := range jobs {
+		resources = append(resources, Resource{
+			ID:          job.Name,
+			Name:        job.Name,
+			Type:        typeTrainingJob,
+			State:       sageMakerState(job.Status),
+			Region:      region,
+			CreatedDate: job.CreatedAt.Format("2006-01-02 15:04:05"),
+			Tags:        make(map[string]string),
+			Details: map[string]interface{}{
+				"ARN":      job.ARN,
+				"Duration": job.Duration(now).Round(time.Minute).String(),
+			},
+		})
+	}
+
+	if len(failures) > 0 {
+		return resources, &clients.PartialError{Op: "sagemaker", Failures: failures}
+	}
+	return resources, nil
+}
+
+// sageMakerEndpointResource describes endpoint with its variants and what
+// its instances cost
+func sageMakerEndpointResource(endpoint clients.SageMakerEndpoint, region string) Resource {
+	res := Resource{
+		ID:          endpoint.Name,
+		Name:        endpoint.Name,
+		Type:        typeSageMakerEndpoint,
+		State:       sageMakerState(endpoint.Status),
+		Region:      region,
+		CreatedDate: endpoint.CreatedAt.Format("2006-01-02 15:04:05"),
+		Tags:        make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":    endpoint.ARN,
+			"Config": endpoint.Config,
+		},
+	}
+
+	var variants, types []string
+	for _, variant := range endpoint.Variants {
+		if variant.Serverless {
+			variants = append(variants, variant.Name+": serverless")
+			types = append(types, "serverless")
+			continue
+		}
+		variants = append(variants, fmt.Sprintf("%s: %d × %s", variant.Name, variant.Instances, variant.InstanceType))
+		types = append(types, fmt.Sprintf("%d × %s", variant.Instances, variant.InstanceType))
+		if cost, ok := pricing.SageMakerMonthly(variant.InstanceType, variant.Instances, region); ok {
+			res.MonthlyCost += cost
+		}
+	}
+	res.Details["Variants"] = orDash(strings.Join(variants, ", "))
+	if len(types) > 0 {
+		res.Type = fmt.Sprintf("%s (%s)", typeSageMakerEndpoint, strings.Join(types, ", "))
+	}
+	if endpoint.FailureReason != "" {
+		res.Alert = true
+		res.Details["Flag"] = endpoint.FailureReason
+	}
+	return res
+}
+
+// endpointInvocations returns the invocations of all variants of endpoint
+// per hour over sageMakerIdleWindow, oldest first
+func endpointInvocations(ctx context.Context, client *aws.Client, endpoint clients.SageMakerEndpoint) ([]float64, error) {
+	svc := client.GetClients()
+	if svc.CloudWatch == nil {
+		return nil, fmt.Errorf("CloudWatch service not initialized")
+	}
+
+	queries := make([]clients.MetricQuery, 0, len(endpoint.Variants))
+	for _, variant := range endpoint.Variants {
+		queries = append(queries, clients.MetricQuery{
+			Namespace: "AWS/SageMaker",
+			Metric:    "Invocations",
+			Dimensions: []clients.MetricDimension{
+				{Name: "EndpointName", Value: endpoint.Name},
+				{Name: "VariantName", Value: variant.Name},
+			},
+			Stat:   "Sum",
+			Period: 3600,
+		})
+	}
+	end := time.Now()
+	series, err := svc.CloudWatch.GetMetricSeries(ctx, queries, end.Add(-sageMakerIdleWindow), end)
+	if err != nil {
+		return nil, err
+	}
+
+	var hourly []float64
+	for _, s := range series {
+		for i, v := range s.Values {
+			if i < len(hourly) {
+				hourly[i] += v
+			} else {
+				hourly = append(hourly, v)
+			}
+		}
+	}
+	return hourly, nil
+}
+
+// flagIdleEndpoint notes the invocations of the endpoint res and flags it
+// if it costs without having been invoked
+func flagIdleEndpoint(res *Resource, hourly []float64) {
+	var total float64
+	for _, v := range hourly {
+		total += v
+	}
+	res.Details["Invocations (24h)"] = int(total)
+	if len(hourly) > 0 {
+		res.Details["Invocations per Hour"] = rateSparkline(hourly)
+	}
+	if total == 0 && res.MonthlyCost > 0 {
+		res.Alert = true
+		res.Details["Flag"] = fmt.Sprintf("idle: no invocations in 24 hours, yet its instances cost $%.2f a month; press s to delete it, keeping its configuration", res.MonthlyCost)
+	}
+}
+
+// sageMakerNotebookResource describes notebook with what it costs while it
+// runs
+func sageMakerNotebookResource(notebook clients.SageMakerNotebook, region string) Resource {
+	res := Resource{
+		ID:          notebook.Name,
+		Name:        notebook.Name,
+		Type:        fmt.Sprintf("%s (%s)", typeNotebookInstance, notebook.InstanceType),
+		State:       sageMakerState(notebook.Status),
+		Region:      region,
+		CreatedDate: notebook.CreatedAt.Format("2006-01-02 15:04:05"),
+		Tags:        make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":           notebook.ARN,
+			"Instance Type": notebook.InstanceType,
+			"Last Modified": zonedTime(notebook.ModifiedAt, "2006-01-02 15:04"),
+		},
+	}
+	if notebook.Status == "InService" {
+		if cost, ok := pricing.SageMakerMonthly(notebook.InstanceType, 1, region); ok {
+			res.MonthlyCost = cost
+		}
+		res.Details["Stop"] = "press s to stop the notebook, keeping its storage"
+	}
+	return res
+}
+
+// sageMakerSummary counts the idle and failed endpoints and what runs
+func sageMakerSummary(resources []Resource, failed int) (string, string) {
+	endpoints, idle, broken, notebooks, training := 0, 0, 0, 0, 0
+	var idleCost float64
+	for _, res := range resources {
+		switch {
+		case strings.HasPrefix(res.Type, typeSageMakerEndpoint):
+			endpoints++
+			if res.State == "failed" {
+				broken++
+			} else if res.Alert {
+				idle++
+				idleCost += res.MonthlyCost
+			}
+		case strings.HasPrefix(res.Type, typeNotebookInstance):
+			if res.State == "in service" {
+				notebooks++
+			}
+		case res.Type == typeTrainingJob:
+			if res.State == "in progress" {
+				training++
+			}
+		}
+	}
+
+	message := fmt.Sprintf("%s, %d idle", pluralize(endpoints, "endpoint"), idle)
+	if idle > 0 {
+		message += fmt.Sprintf(" ($%.0f/mo)", idleCost)
+	}
+	if broken > 0 {
+		message += fmt.Sprintf(", %d failed", broken)
+	}
+	message += fmt.Sprintf(", %d notebooks running, %d training", notebooks, training)
+	switch {
+	case idle > 0 || broken > 0:
+		return message, "red"
+	case failed > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// onSageMakerStop asks to stop the selected notebook or delete the
+// selected endpoint, and does so when confirmed
+func (rt *ResourcesTab) onSageMakerStop() {
+	if rt.selectedService != "sagemaker" || rt.selectedRes == nil || rt.awsClient == nil {
+		return
+	}
+
+	res := *rt.selectedRes
+	var text, button string
+	switch {
+	case strings.HasPrefix(res.Type, typeNotebookInstance):
+		if res.State != "in service" {
+			rt.updateStatus(i18n.T("sagemaker.not_running", res.Name), "yellow")
+			return
+		}
+		text = i18n.T("dialog.sagemaker_stop", res.Name)
+		button = i18n.T("dialog.stop")
+	case strings.HasPrefix(res.Type, typeSageMakerEndpoint):
+		config, _ := res.Details["Config"].(string)
+		text = i18n.T("dialog.sagemaker_delete", res.Name, config)
+		if invocations, ok := res.Details["Invocations (24h)"].(int); ok && invocations > 0 {
+			text += "\n\n" + i18n.T("dialog.sagemaker_invocations", invocations)
+		}
+		button = i18n.T("dialog.delete")
+	default:
+		rt.updateStatus(i18n.T("sagemaker.select"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{button, i18n.T("common.cancel")}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			rt.view.RemovePage("sagemaker-stop")
+			if rt.app != nil {
+				rt.app.SetFocus(rt.resourceTable)
+			}
+			if buttonIndex == 0 {
+				rt.stopSageMaker(client, res)
+			}
+		})
+
+	rt.view.AddPage("sagemaker-stop", modal, false, true)
+}
+
+// stopSageMaker stops the notebook or deletes the endpoint res and reloads
+// the listing when it is still shown
+func (rt *ResourcesTab) stopSageMaker(client *aws.Client, res Resource) {
+	notebook := strings.HasPrefix(res.Type, typeNotebookInstance)
+	progress, action := "sagemaker.deleting", "sagemaker:DeleteEndpoint"
+	if notebook {
+		progress, action = "sagemaker.stopping", "sagemaker:StopNotebookInstance"
+	}
+	rt.updateStatus(i18n.T(progress, res.Name), "yellow")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := fmt.Errorf("SageMaker service not initialized")
+		if svc := client.GetClients(); svc != nil && svc.SageMaker != nil {
+			if notebook {
+				err = svc.SageMaker.StopNotebookInstance(ctx, res.Name)
+			} else {
+				err = svc.SageMaker.DeleteEndpoint(ctx, res.Name)
+			}
+		}
+		arn, _ := res.Details["ARN"].(string)
+		rt.audit.record(client, action, arn, err)
+		if err != nil {
+			logger.Error("Failed to stop SageMaker resource", zap.String("resource", res.Name), zap.Error(err))
+		} else {
+			logger.Info("Stopped SageMaker resource", zap.String("resource", res.Name), zap.String("action", action))
+		}
+
+		if rt.app == nil {
+			return
+		}
+		rt.app.QueueUpdateDraw(func() {
+			if err != nil {
+				rt.updateStatus(i18n.T("sagemaker.stop_failed", res.Name, clients.ErrorReason(err)), "red")
+				return
+			}
+			if rt.awsClient == client && rt.selectedService == "sagemaker" {
+				rt.loadService("sagemaker", true)
+			}
+			if notebook {
+				rt.updateStatus(i18n.T("sagemaker.stopped", res.Name), "green")
+			} else {
+				rt.updateStatus(i18n.T("sagemaker.deleted", res.Name), "green")
+			}
+		})
+	}()
+}
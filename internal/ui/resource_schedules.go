@@ -0,0 +1,526 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/jobs"
+	"swiss-army-tui/internal/schedule"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// scheduleCheckInterval is how often due schedules are looked for
+var scheduleCheckInterval = 15 * time.Second
+
+// scheduleGrace is how late a schedule may still run, e.g. when the TUI was
+// started after it was due. Later ones fail as missed rather than stopping
+// instances at a surprising time.
+const scheduleGrace = time.Hour
+
+// scheduleClientFunc returns a client for the profile and region of a
+// schedule, with a func to call once it is no longer needed
+type scheduleClientFunc func(profile, region string) (*aws.Client, func(), error)
+
+// scheduleClient returns the current client if it is for profile and region,
+// and otherwise a new one that is closed once the schedule ran
+func (app *App) scheduleClient(profile, region string) (*aws.Client, func(), error) {
+	app.mu.RLock()
+	current := app.awsClient
+	app.mu.RUnlock()
+
+	if current != nil && current.GetProfile() == profile && current.GetRegion() == region {
+		return current, func() {}, nil
+	}
+	if mode := app.fixedClientMode(); mode != "" {
+		return nil, nil, fmt.Errorf("profile %s in %s cannot be used in %s", profile, region, mode)
+	}
+
+	client, err := app.clients.NewClient(profile, region)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create client for profile %s: %w", profile, err)
+	}
+	return client, func() { client.Close() }, nil
+}
+
+// SetSchedules keeps the scheduled actions in store and runs them with the
+// clients of clientFor
+func (rt *ResourcesTab) SetSchedules(store *schedule.Store, clientFor scheduleClientFunc) {
+	rt.schedules = store
+	rt.scheduleClient = clientFor
+}
+
+// RunSchedules runs the schedules as they become due by clock until ctx is
+// done
+func (rt *ResourcesTab) RunSchedules(ctx context.Context, clock Clock) {
+	ticker := time.NewTicker(scheduleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		rt.runDueSchedules(clock.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDueSchedules starts a job for every schedule due at now
+func (rt *ResourcesTab) runDueSchedules(now time.Time) {
+	if rt.schedules == nil || rt.isOffline() {
+		return
+	}
+
+	due, err := rt.schedules.TakeDue(now)
+	if err != nil {
+		logger.Error("Failed to take due schedules", zap.Error(err))
+		return
+	}
+	for _, s := range due {
+		rt.runSchedule(s, now)
+	}
+	if len(due) > 0 {
+		rt.refreshJobs()
+	}
+}
+
+// runSchedule runs the action of s on its targets as a background job
+func (rt *ResourcesTab) runSchedule(s schedule.Schedule, now time.Time) {
+	logger.Info("Running scheduled action",
+		zap.Int("id", s.ID),
+		zap.String("action", string(s.Action)),
+		zap.String("profile", s.Profile),
+		zap.String("region", s.Region),
+		zap.Strings("targets", s.Targets),
+		zap.Time("at", s.At))
+
+	noun := "instance"
+	if s.Action.Noun() != "instances" {
+		noun = "group"
+	}
+	rt.jobs.Start(s.Title()+" (scheduled)", noun, func(ctx context.Context, progress jobs.Progress) error {
+		// The restore of a scale to 0 waits for it to finish, run or not
+		defer rt.schedules.Release(s)
+
+		if late := now.Sub(s.At); late > scheduleGrace {
+			return fmt.Errorf("missed, it was due %s ago while the TUI was not running", late.Round(time.Minute))
+		}
+
+		client, release, err := rt.scheduleClient(s.Profile, s.Region)
+		if err != nil {
+			return err
+		}
+		defer release()
+		run, err := rt.scheduledAction(ctx, client, s)
+		if err != nil {
+			return err
+		}
+
+		var failures []clients.ItemError
+		progress(0, len(s.Targets))
+		for i, id := range s.Targets {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			callCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+			err := run(callCtx, id)
+			cancel()
+
+			rt.audit.record(client, s.Action.Permission(), scheduleTargetARN(s, client.GetAccountID(), id), err)
+			if err != nil {
+				logger.Error("Scheduled action failed", zap.String("action", string(s.Action)), zap.String("target", id), zap.Error(err))
+				failures = append(failures, clients.ItemError{Item: id, Region: s.Region, Err: err})
+			}
+			progress(i+1, len(s.Targets))
+		}
+		if len(failures) > 0 {
+			return &clients.PartialError{Op: string(s.Action), Failures: failures}
+		}
+		return nil
+	})
+}
+
+// scheduleTargetARN returns the ARN of target of s in account, an instance
+// or an Auto Scaling group by the action of s
+func scheduleTargetARN(s schedule.Schedule, account, target string) string {
+	if s.Action.Noun() == "instances" {
+		return ec2Service.ARN(account, Resource{ID: target, Name: target, Region: s.Region})
+	}
+	// The ARN of a group has an ID that is not needed to name it
+	return fmt.Sprintf("arn:%s:autoscaling:%s:%s:autoScalingGroup:*:autoScalingGroupName/%s", awsPartition(s.Region), s.Region, account, target)
+}
+
+// scheduledAction returns what the action of s does to each of its targets
+// with client. A scale to 0 first saves the capacity of its groups in its
+// restore schedule, and scales nothing if that fails.
+func (rt *ResourcesTab) scheduledAction(ctx context.Context, client *aws.Client, s schedule.Schedule) (func(ctx context.Context, target string) error, error) {
+	svc := client.GetClients()
+	switch s.Action {
+	case schedule.ActionStopInstances, schedule.ActionStartInstances:
+		if svc == nil || svc.EC2 == nil {
+			return nil, fmt.Errorf("EC2 service not initialized")
+		}
+		if s.Action == schedule.ActionStopInstances {
+			return svc.EC2.StopInstance, nil
+		}
+		return svc.EC2.StartInstance, nil
+	case schedule.ActionScaleToZero, schedule.ActionRestoreCapacity:
+		if svc == nil || svc.AutoScaling == nil {
+			return nil, fmt.Errorf("Auto Scaling service not initialized")
+		}
+	default:
+		return nil, fmt.Errorf("unknown action %q", s.Action)
+	}
+
+	if s.Action == schedule.ActionRestoreCapacity {
+		return func(ctx context.Context, group string) error {
+			capacity, ok := s.Capacities[group]
+			if !ok {
+				return fmt.Errorf("no capacity saved for %s, it was not scaled to 0 by its schedule", group)
+			}
+			return svc.AutoScaling.SetCapacity(ctx, group, capacity.Min, capacity.Max, capacity.Desired)
+		}, nil
+	}
+
+	groups, err := svc.AutoScaling.ListGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.Restore != 0 {
+		// Groups already at 0 are saved too, so their restore leaves them there
+		saved := make(map[string]schedule.Capacity)
+		for _, group := range groups {
+			if slices.Contains(s.Targets, group.Name) {
+				saved[group.Name] = schedule.Capacity{Min: group.Min, Max: group.Max, Desired: group.Desired}
+			}
+		}
+		if err := rt.schedules.SaveCapacities(s.Restore, saved); err != nil {
+			return nil, fmt.Errorf("failed to save the capacity to restore, nothing was scaled: %w", err)
+		}
+	}
+	return func(ctx context.Context, group string) error {
+		return svc.AutoScaling.SetCapacity(ctx, group, 0, 0, 0)
+	}, nil
+}
+
+// scheduleChoice is an action on instances offered for scheduling
+type scheduleChoice struct {
+	label   string
+	action  schedule.Action
+	targets []string
+}
+
+// scaleGroupsLabel is the choice that picks Auto Scaling groups to scale to
+// 0 and restore
+const scaleGroupsLabel = "Scale Auto Scaling groups to 0 and restore them later"
+
+// onScheduleAction offers to start or stop the selected instance, or all
+// instances shown, or to scale Auto Scaling groups to 0, and asks when
+func (rt *ResourcesTab) onScheduleAction() {
+	if rt.selectedService != "ec2" || rt.selectedRes == nil {
+		return
+	}
+	if rt.schedules == nil || rt.awsClient == nil {
+		rt.updateStatus(i18n.T("schedules.unavailable"), "yellow")
+		return
+	}
+
+	var choices []scheduleChoice
+	for _, action := range schedule.InstanceActions {
+		choices = append(choices, scheduleChoice{
+			label:   fmt.Sprintf("%s %s (%s)", action.Verb(), rt.selectedRes.Name, rt.selectedRes.ID),
+			action:  action,
+			targets: []string{rt.selectedRes.ID},
+		})
+	}
+	if len(rt.visibleRes) > 1 {
+		shown := make([]string, len(rt.visibleRes))
+		for i, res := range rt.visibleRes {
+			shown[i] = res.ID
+		}
+		for _, action := range schedule.InstanceActions {
+			choices = append(choices, scheduleChoice{
+				label:   fmt.Sprintf("%s all %d shown instances", action.Verb(), len(shown)),
+				action:  action,
+				targets: shown,
+			})
+		}
+	}
+	choices = append(choices, scheduleChoice{label: scaleGroupsLabel, action: schedule.ActionScaleToZero})
+
+	list := tview.NewList().
+		SetMainTextColor(tcell.ColorWhite).
+		SetSelectedTextColor(tcell.ColorBlack).
+		SetSelectedBackgroundColor(tcell.ColorWhite).
+		ShowSecondaryText(false)
+	for _, choice := range choices {
+		list.AddItem(choice.label, "", 0, nil)
+	}
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) {
+		if choices[index].action == schedule.ActionScaleToZero {
+			rt.pickScheduleGroups(rt.awsClient)
+			return
+		}
+		rt.askScheduleTime(rt.awsClient, choices[index])
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' {
+			rt.closeScheduleDialog()
+			return nil
+		}
+		return event
+	})
+	list.SetBorder(true).
+		SetTitle(panelTitle("schedules.choose_title")).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("schedule", centered(list, 64, len(choices)+2), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(list)
+	}
+}
+
+// askScheduleTime asks when to run choice and queues it for the profile and
+// region of client
+func (rt *ResourcesTab) askScheduleTime(client *aws.Client, choice scheduleChoice) {
+	when := ""
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("schedules.when"), "", 30, nil, func(text string) { when = text })
+	form.AddButton(i18n.T("schedules.schedule"), func() {
+		at, err := schedule.ParseTime(when, time.Now())
+		if err != nil {
+			rt.closeScheduleDialog()
+			rt.updateStatus(err.Error(), "red")
+			return
+		}
+
+		added, err := rt.schedules.Add(schedule.Schedule{
+			Action:  choice.action,
+			Profile: client.GetProfile(),
+			Region:  client.GetRegion(),
+			Targets: choice.targets,
+			At:      at,
+		})
+		rt.closeScheduleDialog()
+		if err != nil {
+			rt.updateStatus(i18n.T("schedules.save_failed", err), "red")
+			return
+		}
+		logger.Info("Scheduled action", zap.Int("id", added.ID), zap.String("title", added.Title()), zap.Time("at", added.At))
+		rt.updateStatus(i18n.T("schedules.scheduled", added.Title(), formatScheduleTime(added.At, time.Now())), "green")
+		rt.refreshJobs()
+	})
+	form.AddButton(i18n.T("common.cancel"), rt.closeScheduleDialog)
+	form.SetBorder(true).
+		SetTitle(panelTitle("schedules.when_title")).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("schedule-time", centered(form, 64, 7), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(form)
+	}
+}
+
+// pickScheduleGroups lists the Auto Scaling groups of the region of client
+// with their capacity to pick those to scale to 0. Space picks a group,
+// Enter goes on with the picked groups, or the highlighted one if none were
+// picked.
+func (rt *ResourcesTab) pickScheduleGroups(client *aws.Client) {
+	list := tview.NewList().
+		SetMainTextColor(tcell.ColorWhite).
+		SetSelectedTextColor(tcell.ColorBlack).
+		SetSelectedBackgroundColor(tcell.ColorWhite).
+		ShowSecondaryText(false)
+	list.AddItem(i18n.T("common.loading"), "", 0, nil)
+	list.SetBorder(true).
+		SetTitle(panelTitle("schedules.groups_title")).
+		SetTitleAlign(tview.AlignLeft)
+	rt.view.AddPage("schedule-groups", centered(list, 72, 12), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(list)
+	}
+
+	var groups []clients.AutoScalingGroup
+	picked := make(map[string]bool)
+	label := func(group clients.AutoScalingGroup) string {
+		box := "[ ]"
+		if picked[group.Name] {
+			box = "[x]"
+		}
+		return fmt.Sprintf("%s %s  (min %d, max %d, desired %d)", tview.Escape(box), group.Name, group.Min, group.Max, group.Desired)
+	}
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Rune() == 'q':
+			rt.closeScheduleDialog()
+			return nil
+		case len(groups) == 0:
+			return event
+		case event.Rune() == ' ':
+			index := list.GetCurrentItem()
+			picked[groups[index].Name] = !picked[groups[index].Name]
+			list.SetItemText(index, label(groups[index]), "")
+			return nil
+		case event.Key() == tcell.KeyEnter:
+			var targets []string
+			for _, group := range groups {
+				if picked[group.Name] {
+					targets = append(targets, group.Name)
+				}
+			}
+			if len(targets) == 0 {
+				targets = []string{groups[list.GetCurrentItem()].Name}
+			}
+			rt.askScaleTimes(client, targets)
+			return nil
+		}
+		return event
+	})
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var found []clients.AutoScalingGroup
+		err := fmt.Errorf("Auto Scaling service not initialized")
+		if svc := client.GetClients(); svc != nil && svc.AutoScaling != nil {
+			found, err = svc.AutoScaling.ListGroups(ctx)
+		}
+		if err != nil {
+			logger.Error("Failed to list Auto Scaling groups", zap.Error(err))
+		}
+		if rt.app == nil {
+			return
+		}
+		rt.app.QueueUpdateDraw(func() {
+			list.Clear()
+			switch {
+			case err != nil:
+				list.AddItem(i18n.T("schedules.groups_failed", clients.ErrorReason(err)), "", 0, nil)
+			case len(found) == 0:
+				list.AddItem(i18n.T("schedules.no_groups"), "", 0, nil)
+			}
+			groups = found
+			for _, group := range groups {
+				list.AddItem(label(group), "", 0, nil)
+			}
+		})
+	}()
+}
+
+// askScaleTimes asks when to scale the groups to 0 and when to restore their
+// capacity, and queues both for the profile and region of client
+func (rt *ResourcesTab) askScaleTimes(client *aws.Client, groups []string) {
+	scaleAt, restoreAt := "", ""
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("schedules.scale_at"), "", 30, nil, func(text string) { scaleAt = text })
+	form.AddInputField(i18n.T("schedules.restore_at"), "", 30, nil, func(text string) { restoreAt = text })
+	form.AddButton(i18n.T("schedules.schedule"), func() {
+		scale, err := schedule.ParseTime(scaleAt, time.Now())
+		var restore time.Time
+		if err == nil {
+			// The restore is the next one after the scale
+			restore, err = schedule.ParseTime(restoreAt, scale)
+		}
+		rt.closeScheduleDialog()
+		if err != nil {
+			rt.updateStatus(err.Error(), "red")
+			return
+		}
+		rt.addScaleToZero(client, groups, scale, restore)
+	})
+	form.AddButton(i18n.T("common.cancel"), rt.closeScheduleDialog)
+	form.SetBorder(true).
+		SetTitle(panelTitle("schedules.scale_title", i18n.N("count.group", len(groups)))).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("schedule-time", centered(form, 64, 9), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(form)
+	}
+}
+
+// addScaleToZero queues the scale of groups to 0 at scale and the restore of
+// their capacity at restore
+func (rt *ResourcesTab) addScaleToZero(client *aws.Client, groups []string, scale, restore time.Time) {
+	scaleDown, restoreUp, err := rt.schedules.AddScaleToZero(
+		schedule.Schedule{Profile: client.GetProfile(), Region: client.GetRegion(), Targets: groups, At: scale},
+		schedule.Schedule{Profile: client.GetProfile(), Region: client.GetRegion(), At: restore},
+	)
+	if err != nil {
+		rt.updateStatus(i18n.T("schedules.save_failed", err), "red")
+		return
+	}
+	logger.Info("Scheduled action", zap.Int("id", scaleDown.ID), zap.String("title", scaleDown.Title()), zap.Time("at", scaleDown.At))
+	logger.Info("Scheduled action", zap.Int("id", restoreUp.ID), zap.String("title", restoreUp.Title()), zap.Time("at", restoreUp.At))
+	now := time.Now()
+	rt.updateStatus(i18n.T("schedules.scheduled_restore", scaleDown.Title(),
+		formatScheduleTime(scaleDown.At, now), formatScheduleTime(restoreUp.At, now)), "green")
+	rt.refreshJobs()
+}
+
+// closeScheduleDialog removes the scheduling dialog
+func (rt *ResourcesTab) closeScheduleDialog() {
+	rt.view.RemovePage("schedule-time")
+	rt.view.RemovePage("schedule-groups")
+	rt.view.RemovePage("schedule")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// cancelSchedule removes the pending schedule id
+func (rt *ResourcesTab) cancelSchedule(id int) {
+	if err := rt.schedules.Remove(id); err != nil {
+		logger.Error("Failed to remove schedule", zap.Int("id", id), zap.Error(err))
+		return
+	}
+	logger.Info("Removed schedule", zap.Int("id", id))
+	rt.refreshJobs()
+}
+
+// pendingSchedules returns the schedules not run yet, the soonest first
+func (rt *ResourcesTab) pendingSchedules() []schedule.Schedule {
+	if rt.schedules == nil {
+		return nil
+	}
+	return rt.schedules.List()
+}
+
+// refreshJobs redraws the jobs view if it is open. It may be called from any
+// goroutine.
+func (rt *ResourcesTab) refreshJobs() {
+	if rt.app == nil {
+		return
+	}
+	go rt.app.QueueUpdateDraw(func() {
+		if rt.jobsTable != nil {
+			fillJobs(rt.jobsTable, rt.jobs.Jobs(), rt.pendingSchedules())
+		}
+	})
+}
+
+// formatScheduleTime shows at briefly, with its date unless it is today
+func formatScheduleTime(at, now time.Time) string {
+	at = at.Local()
+	if y, m, d := at.Date(); y == now.Year() && m == now.Month() && d == now.Day() {
+		return at.Format("15:04")
+	}
+	if at.Sub(now) < 7*24*time.Hour {
+		return at.Format("Mon 15:04")
+	}
+	return at.Format("2006-01-02 15:04")
+}
@@ -0,0 +1,350 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// sesView lists the SES identities, sending quota and suppressed addresses
+type sesView struct{ baseView }
+
+var sesService = sesView{baseView{
+	info: ServiceInfo{Name: "ses", DisplayName: "SES Sending", Icon: "📧", Label: "SES", Enabled: true, Permission: "ses:ListEmailIdentities"},
+	noun: "listing",
+}}
+
+// StateColor colors the verification and enforcement states
+func (sesView) StateColor(state string) tcell.Color {
+	return stateColor(sesStateColors, state)
+}
+
+// sesStateColors color the verification of identities and the enforcement
+// status of the account
+var sesStateColors = map[string]tcell.Color{
+	"verified":  tcell.ColorGreen,
+	"healthy":   tcell.ColorGreen,
+	"paused":    tcell.ColorRed,
+	"shutdown":  tcell.ColorRed,
+	"probation": tcell.ColorYellow,
+}
+
+// Load lists the SES listings
+func (sesView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadSES(ctx, client)
+}
+
+// Summary sums up the SES listings
+func (sesView) Summary(resources []Resource, failed int) (string, string) {
+	return sesSummary(resources, failed)
+}
+
+// Actions removes suppressed addresses
+func (sesView) Actions() []resourceAction {
+	return []resourceAction{
+		{name: "ses unsuppress", key: 'd', description: "action.ses_unsuppress",
+			onResource: true, online: true,
+			run: (*ResourcesTab).onSESRemoveSuppressed},
+	}
+}
+
+// Resource types of the SES view
+const (
+	typeSESAccount        = "SES Account"
+	typeEmailIdentity     = "Email Identity"
+	typeDomainIdentity    = "Domain Identity"
+	typeSuppressedAddress = "Suppressed Address"
+)
+
+// SES reviews accounts whose bounce rate exceeds 5% or complaint rate 0.1%
+// and may pause sending at 10% and 0.5%
+const (
+	sesBounceReview     = 0.05
+	sesBouncePause      = 0.10
+	sesComplaintReview  = 0.001
+	sesComplaintPause   = 0.005
+	sesReputationWindow = 24 * time.Hour
+)
+
+// sesIdentityStates names the verification states of identities
+var sesIdentityStates = map[string]string{
+	"SUCCESS":           "verified",
+	"PENDING":           "pending",
+	"FAILED":            "failed",
+	"TEMPORARY_FAILURE": "failed",
+	"NOT_STARTED":       "pending",
+}
+
+// loadSES lists the sending account with its quota and reputation, the
+// identities and the suppressed addresses. The account, suppression list and
+// reputation metrics are optional; their failures are returned as a
+// PartialError.
+func (rt *ResourcesTab) loadSES(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.SES == nil {
+		return nil, fmt.Errorf("SES service not initialized")
+	}
+
+	identities, err := svc.SES.ListIdentities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	region := client.GetRegion()
+	var resources []Resource
+	var failures []clients.ItemError
+
+	account, err := svc.SES.GetSendingAccount(ctx)
+	if err != nil {
+		failures = append(failures, clients.ItemError{Item: "sending account", Err: err})
+	} else {
+		res := sesAccountResource(account, region)
+		bounce, complaint, err := sesReputation(ctx, client)
+		if err != nil {
+			failures = append(failures, clients.ItemError{Item: "reputation metrics", Err: err})
+		} else {
+			res.Details["Bounce Rate"] = percent(bounce)
+			res.Details["Complaint Rate"] = percent(complaint)
+		}
+		resources = append(resources, res)
+	}
+
+	for _, identity := range identities {
+		typ := typeDomainIdentity
+		if identity.Type == "EMAIL_ADDRESS" {
+			typ = typeEmailIdentity
+		}
+		state := sesIdentityStates[identity.VerificationStatus]
+		if state == "" {
+			state = strings.ToLower(identity.VerificationStatus)
+		}
+		resources = append(resources, Resource{
+			ID:     identity.Name,
+			Name:   identity.Name,
+			Type:   typ,
+			State:  state,
+			Region: region,
+			Tags:   make(map[string]string),
+			Details: map[string]interface{}{
+				"Identity Type":   identity.Type,
+				"Verification":    identity.VerificationStatus,
+				"Sending Enabled": identity.SendingEnabled,
+			},
+		})
+	}
+
+	suppressed, err := svc.SES.ListSuppressedDestinations(ctx)
+	if err != nil {
+		failures = append(failures, clients.ItemError{Item: "suppression list", Err: err})
+	}
+	for _, destination := range suppressed {
+		resources = append(resources, Resource{
+			ID:          destination.Email,
+			Name:        destination.Email,
+			Type:        typeSuppressedAddress,
+			State:       strings.ToLower(destination.Reason),
+			Region:      region,
+			CreatedDate: destination.LastUpdate.Format("2006-01-02 15:04:05"),
+			Tags:        make(map[string]string),
+			Details: map[string]interface{}{
+				"Reason": destination.Reason,
+				"Remove": "press d to remove from the suppression list",
+			},
+		})
+	}
+
+	if len(failures) > 0 {
+		return resources, &clients.PartialError{Op: "ses", Failures: failures}
+	}
+	return resources, nil
+}
+
+// sesAccountResource describes the sending quota and status of account
+func sesAccountResource(account clients.SendingAccount, region string) Resource {
+	state := strings.ToLower(account.EnforcementStatus)
+	if !account.SendingEnabled {
+		state = "paused"
+	}
+	production := "yes"
+	if !account.ProductionAccess {
+		production = "no (sandbox)"
+	}
+
+	var used float64
+	if account.Max24HourSend > 0 {
+		used = account.SentLast24Hours / account.Max24HourSend
+	}
+
+	return Resource{
+		ID:     "account",
+		Name:   "Sending quota",
+		Type:   typeSESAccount,
+		State:  state,
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"Sent (24h)":        int(account.SentLast24Hours),
+			"Quota (24h)":       int(account.Max24HourSend),
+			"Quota Used":        percent(used),
+			"Max Send Rate":     fmt.Sprintf("%g/s", account.MaxSendRate),
+			"Production Access": production,
+		},
+	}
+}
+
+// sesReputation returns the latest bounce and complaint rates of the account
+// from the AWS/SES reputation metrics, as fractions
+func sesReputation(ctx context.Context, client *aws.Client) (float64, float64, error) {
+	svc := client.GetClients()
+	if svc.CloudWatch == nil {
+		return 0, 0, fmt.Errorf("CloudWatch service not initialized")
+	}
+
+	queries := []clients.MetricQuery{
+		{Namespace: "AWS/SES", Metric: "Reputation.BounceRate", Stat: "Average", Period: 3600},
+		{Namespace: "AWS/SES", Metric: "Reputation.ComplaintRate", Stat: "Average", Period: 3600},
+	}
+	end := time.Now()
+	series, err := svc.CloudWatch.GetMetricSeries(ctx, queries, end.Add(-sesReputationWindow), end)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	latest := func(i int) float64 {
+		if i >= len(series) || len(series[i].Values) == 0 {
+			return 0
+		}
+		return series[i].Values[len(series[i].Values)-1]
+	}
+	return latest(0), latest(1), nil
+}
+
+// percent is a fraction shown as a percentage, e.g. 0.021 as 2.10%
+type percent float64
+
+func (p percent) String() string {
+	return fmt.Sprintf("%.2f%%", float64(p)*100)
+}
+
+// sesSummary reports the quota usage and reputation of the account,
+// yellow when SES would review the account and red when it may pause it
+func sesSummary(resources []Resource, failed int) (string, string) {
+	var account *Resource
+	var identities, suppressed int
+	for i, res := range resources {
+		switch res.Type {
+		case typeSESAccount:
+			account = &resources[i]
+		case typeEmailIdentity, typeDomainIdentity:
+			identities++
+		case typeSuppressedAddress:
+			suppressed++
+		}
+	}
+
+	message := fmt.Sprintf("%d identities, %d suppressed", identities, suppressed)
+	color := "green"
+	if account != nil {
+		sent, _ := account.Details["Sent (24h)"].(int)
+		quota, _ := account.Details["Quota (24h)"].(int)
+		message = fmt.Sprintf("Sent %d of %d today, %s", sent, quota, message)
+
+		bounce, okBounce := account.Details["Bounce Rate"].(percent)
+		complaint, okComplaint := account.Details["Complaint Rate"].(percent)
+		if okBounce && okComplaint {
+			message += fmt.Sprintf(", bounce %s, complaint %s", bounce, complaint)
+			switch {
+			case bounce >= sesBouncePause || complaint >= sesComplaintPause:
+				color = "red"
+			case bounce >= sesBounceReview || complaint >= sesComplaintReview:
+				color = "yellow"
+			}
+		}
+		switch account.State {
+		case "healthy", "":
+		case "probation":
+			if color == "green" {
+				color = "yellow"
+			}
+		default:
+			color = "red"
+		}
+	}
+
+	if failed > 0 && color == "green" {
+		color = "yellow"
+	}
+	return message, color
+}
+
+// onSESRemoveSuppressed asks to remove the selected address from the
+// suppression list and removes it when confirmed
+func (rt *ResourcesTab) onSESRemoveSuppressed() {
+	if rt.selectedService != "ses" || rt.selectedRes == nil || rt.awsClient == nil {
+		return
+	}
+	if rt.selectedRes.Type != typeSuppressedAddress {
+		rt.updateStatus(i18n.T("ses.select"), "yellow")
+		return
+	}
+
+	email := rt.selectedRes.ID
+	client := rt.awsClient
+	modal := tview.NewModal().
+		SetText(i18n.T("dialog.ses_remove", email)).
+		AddButtons([]string{i18n.T("dialog.remove"), i18n.T("common.cancel")}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			rt.view.RemovePage("ses-remove")
+			if rt.app != nil {
+				rt.app.SetFocus(rt.resourceTable)
+			}
+			if buttonIndex == 0 {
+				rt.removeSuppressed(client, email)
+			}
+		})
+
+	rt.view.AddPage("ses-remove", modal, false, true)
+}
+
+// removeSuppressed removes email from the suppression list and reloads the
+// listing when it is still shown
+func (rt *ResourcesTab) removeSuppressed(client *aws.Client, email string) {
+	rt.updateStatus(i18n.T("ses.removing", email), "yellow")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := client.GetClients().SES.RemoveSuppressedDestination(ctx, email)
+		rt.audit.record(client, "ses:DeleteSuppressedDestination", email, err)
+		if err != nil {
+			logger.Error("Failed to remove suppressed address", zap.String("email", email), zap.Error(err))
+		} else {
+			logger.Info("Removed suppressed address", zap.String("email", email))
+		}
+
+		if rt.app == nil {
+			return
+		}
+		rt.app.QueueUpdateDraw(func() {
+			if err != nil {
+				rt.updateStatus(i18n.T("ses.remove_failed", email, clients.ErrorReason(err)), "red")
+				return
+			}
+			if rt.awsClient == client && rt.selectedService == "ses" {
+				rt.loadService("ses", true)
+			}
+			rt.updateStatus(i18n.T("ses.removed", email), "green")
+		})
+	}()
+}
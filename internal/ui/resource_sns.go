@@ -0,0 +1,508 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// snsView lists the SNS topics; Enter shows where the messages of one go
+type snsView struct{ baseView }
+
+var snsService = snsView{baseView{
+	info: ServiceInfo{Name: "sns", DisplayName: "SNS Topics", Icon: "📣", Label: "SNS", Enabled: true, Permission: "sns:ListTopics"},
+	noun: "topic",
+}}
+
+// ConsoleURL links the topic
+func (snsView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/sns/v3/home?%s#/topic/%s", base, query, res.Details["ARN"])
+}
+
+// Load lists the topics
+func (snsView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadSNSTopics(ctx, client)
+}
+
+// LoadDetails reads the display name, encryption and subscription counts of
+// the topic
+func (snsView) LoadDetails(ctx context.Context, client *aws.Client, resource Resource) (Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.SNS == nil {
+		return Resource{}, fmt.Errorf("SNS service not initialized")
+	}
+	topic, err := svc.SNS.GetTopic(ctx, detailString(resource, "ARN"))
+	if err != nil {
+		return Resource{}, err
+	}
+	return topicResource(topic, resource.Region), nil
+}
+
+// Open shows the message flow of the topic
+func (snsView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showMessageFlow(resource)
+}
+
+// flowWindow is how far back the metrics of a message flow go
+const flowWindow = time.Hour
+
+// A queue with at least flowBacklogMessages visible messages, or whose oldest
+// message waits flowBacklogAge or longer, is shown as piling up
+const (
+	flowBacklogMessages = 1000
+	flowBacklogAge      = 5 * time.Minute
+)
+
+// messageFlow is where the messages published to a topic go: its
+// subscriptions with the queues and functions behind them
+type messageFlow struct {
+	published float64
+	failed    float64
+	branches  []flowBranch
+}
+
+// flowBranch is a subscription of a topic. Queue subscriptions carry the
+// queue, its dead-letter queue and the functions reading from it.
+type flowBranch struct {
+	subscription clients.Subscription
+	queue        *flowQueue
+	deadLetter   *flowQueue
+	consumers    []flowFunction
+	function     *flowFunction
+	// err is why the queue or its consumers could not be read
+	err error
+}
+
+// flowQueue is a queue of a message flow with the age of its oldest message
+type flowQueue struct {
+	detail clients.QueueDetail
+	// oldest is the age of the oldest message, -1 if unknown
+	oldest time.Duration
+}
+
+// flowFunction is a function of a message flow with its invocations over
+// flowWindow
+type flowFunction struct {
+	name        string
+	state       string
+	batchSize   int32
+	invocations float64
+	errors      float64
+	throttles   float64
+}
+
+// loadSNSTopics lists the SNS topics of the region by name
+func (rt *ResourcesTab) loadSNSTopics(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.SNS == nil {
+		return nil, fmt.Errorf("SNS service not initialized")
+	}
+
+	topics, err := svc.SNS.ListTopics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(topics))
+	for _, topic := range topics {
+		resources = append(resources, topicSummary(topic, client.GetRegion()))
+	}
+	sort.SliceStable(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+	return resources, nil
+}
+
+// topicSummary describes a topic listed, whose details load with LoadDetails
+func topicSummary(topic clients.TopicDetail, region string) Resource {
+	typ := "SNS Topic"
+	if topic.FIFO {
+		typ = "SNS FIFO Topic"
+	}
+
+	return Resource{
+		ID:     topic.Name,
+		Name:   topic.Name,
+		Type:   typ,
+		State:  "active",
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":  topic.ARN,
+			"View": "press Enter to trace where its messages go",
+		},
+	}
+}
+
+// topicResource describes topic with its subscription counts
+func topicResource(topic clients.TopicDetail, region string) Resource {
+	res := topicSummary(topic, region)
+
+	subscriptions := fmt.Sprintf("%d confirmed", topic.SubscriptionsActive)
+	if topic.SubscriptionsPending > 0 {
+		subscriptions += fmt.Sprintf(", %d pending", topic.SubscriptionsPending)
+	}
+
+	res.Details["Subscriptions"] = subscriptions
+	res.Details["Encrypted"] = topic.Encrypted
+	if topic.DisplayName != "" {
+		res.Details["Display Name"] = topic.DisplayName
+	}
+	return res
+}
+
+// showMessageFlow draws where the messages published to the topic res go
+// over the tab: its subscribed queues and functions with their depth and
+// errors. r reloads and q closes the view.
+func (rt *ResourcesTab) showMessageFlow(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	topicARN, _ := res.Details["ARN"].(string)
+	loads := 0
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false)
+	view.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("sns.flow_title", res.Name, formatRange(flowWindow)))
+
+	load := func() {
+		view.SetText("[gray]" + i18n.T("common.loading") + "[-]")
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			flow, err := fetchMessageFlow(ctx, client, topicARN)
+			if err != nil {
+				logger.Error("Failed to trace message flow", zap.String("topic", topicARN), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					view.SetText("[red]" + i18n.T("sns.flow_failed", res.Name, tview.Escape(err.Error())) + "[-]")
+					return
+				}
+				view.SetText(renderMessageFlow(res.Name, flow)).ScrollToBeginning()
+			})
+		}()
+	}
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeMessageFlow()
+			return nil
+		case 'r':
+			load()
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("sns-flow", view, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(view)
+	}
+	load()
+}
+
+// closeMessageFlow removes the message flow view and returns focus to the table
+func (rt *ResourcesTab) closeMessageFlow() {
+	rt.view.RemovePage("sns-flow")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// fetchMessageFlow follows the subscriptions of topicARN to the queues and
+// functions behind them and fetches their metrics over the last flowWindow.
+// Queues that cannot be read are kept with the error.
+func fetchMessageFlow(ctx context.Context, client *aws.Client, topicARN string) (messageFlow, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.SNS == nil || svc.SQS == nil || svc.Lambda == nil || svc.CloudWatch == nil {
+		return messageFlow{}, fmt.Errorf("SNS service not initialized")
+	}
+
+	subscriptions, err := svc.SNS.ListSubscriptions(ctx, topicARN)
+	if err != nil {
+		return messageFlow{}, err
+	}
+
+	var flow messageFlow
+	for _, subscription := range subscriptions {
+		branch := flowBranch{subscription: subscription}
+		switch subscription.Protocol {
+		case "lambda":
+			branch.function = &flowFunction{name: functionName(subscription.Endpoint)}
+		case "sqs":
+			branch.err = followQueue(ctx, svc, &branch)
+		}
+		flow.branches = append(flow.branches, branch)
+	}
+
+	// Fetch the metrics of the topic, queues and functions in one go
+	end := time.Now()
+	period := int32(flowWindow.Seconds())
+	metric := func(namespace, name, dimension, value, stat string) clients.MetricQuery {
+		return clients.MetricQuery{
+			Namespace:  namespace,
+			Metric:     name,
+			Dimensions: []clients.MetricDimension{{Name: dimension, Value: value}},
+			Stat:       stat,
+			Period:     period,
+		}
+	}
+
+	topic := topicARN[strings.LastIndex(topicARN, ":")+1:]
+	queries := []clients.MetricQuery{
+		metric("AWS/SNS", "NumberOfMessagesPublished", "TopicName", topic, "Sum"),
+		metric("AWS/SNS", "NumberOfNotificationsFailed", "TopicName", topic, "Sum"),
+	}
+	// Each receiver reads the results of the queries it added
+	var receivers []func(series []clients.MetricSeries)
+	addFunction := func(fn *flowFunction) {
+		first := len(queries)
+		queries = append(queries,
+			metric("AWS/Lambda", "Invocations", "FunctionName", fn.name, "Sum"),
+			metric("AWS/Lambda", "Errors", "FunctionName", fn.name, "Sum"),
+			metric("AWS/Lambda", "Throttles", "FunctionName", fn.name, "Sum"),
+		)
+		receivers = append(receivers, func(series []clients.MetricSeries) {
+			fn.invocations = seriesTotal(series[first])
+			fn.errors = seriesTotal(series[first+1])
+			fn.throttles = seriesTotal(series[first+2])
+		})
+	}
+	addQueue := func(queue *flowQueue) {
+		first := len(queries)
+		// Age is only reported at a one minute resolution
+		age := metric("AWS/SQS", "ApproximateAgeOfOldestMessage", "QueueName", queue.detail.Name, "Maximum")
+		age.Period = 60
+		queries = append(queries, age)
+		receivers = append(receivers, func(series []clients.MetricSeries) {
+			if values := series[first].Values; len(values) > 0 {
+				queue.oldest = time.Duration(values[len(values)-1]) * time.Second
+			}
+		})
+	}
+	for i := range flow.branches {
+		branch := &flow.branches[i]
+		if branch.function != nil {
+			addFunction(branch.function)
+		}
+		if branch.queue != nil {
+			addQueue(branch.queue)
+		}
+		if branch.deadLetter != nil {
+			addQueue(branch.deadLetter)
+		}
+		for j := range branch.consumers {
+			addFunction(&branch.consumers[j])
+		}
+	}
+
+	series, err := svc.CloudWatch.GetMetricSeries(ctx, queries, end.Add(-flowWindow), end)
+	if err != nil {
+		return messageFlow{}, err
+	}
+	if len(series) != len(queries) {
+		return messageFlow{}, fmt.Errorf("expected %d metric series, got %d", len(queries), len(series))
+	}
+	flow.published = seriesTotal(series[0])
+	flow.failed = seriesTotal(series[1])
+	for _, receive := range receivers {
+		receive(series)
+	}
+	return flow, nil
+}
+
+// followQueue reads the queue of the sqs subscription of branch, its
+// dead-letter queue and the functions reading from it
+func followQueue(ctx context.Context, svc *aws.ServiceClients, branch *flowBranch) error {
+	queue, err := svc.SQS.GetQueue(ctx, branch.subscription.Endpoint)
+	if err != nil {
+		return err
+	}
+	branch.queue = &flowQueue{detail: queue, oldest: -1}
+
+	if queue.DeadLetterARN != "" {
+		deadLetter, err := svc.SQS.GetQueue(ctx, queue.DeadLetterARN)
+		if err != nil {
+			return err
+		}
+		branch.deadLetter = &flowQueue{detail: deadLetter, oldest: -1}
+	}
+
+	mappings, err := svc.Lambda.ListEventSourceMappings(ctx, queue.ARN)
+	if err != nil {
+		return err
+	}
+	for _, mapping := range mappings {
+		branch.consumers = append(branch.consumers, flowFunction{
+			name:      functionName(mapping.FunctionARN),
+			state:     mapping.State,
+			batchSize: mapping.BatchSize,
+		})
+	}
+	return nil
+}
+
+// functionName returns the name of the function with the ARN arn, which may
+// end in a version or alias
+func functionName(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 7 {
+		return arn
+	}
+	return parts[6]
+}
+
+// seriesTotal returns the sum of the values of series
+func seriesTotal(series clients.MetricSeries) float64 {
+	var total float64
+	for _, v := range series.Values {
+		total += v
+	}
+	return total
+}
+
+// pilingUp reports whether messages pile up in queue
+func (q *flowQueue) pilingUp() bool {
+	return q.detail.Visible >= flowBacklogMessages || q.oldest >= flowBacklogAge
+}
+
+// renderMessageFlow draws flow as a tree from the topic to its subscribers,
+// naming the queues where messages pile up first
+func renderMessageFlow(topic string, flow messageFlow) string {
+	var text strings.Builder
+
+	var piling []string
+	for _, branch := range flow.branches {
+		if branch.queue != nil && branch.queue.pilingUp() {
+			piling = append(piling, branch.queue.detail.Name)
+		}
+		if branch.deadLetter != nil && branch.deadLetter.detail.Visible > 0 {
+			piling = append(piling, branch.deadLetter.detail.Name+" (dead letters)")
+		}
+	}
+	if len(piling) > 0 {
+		fmt.Fprintf(&text, "[red]Messages pile up in %s[-]\n\n", strings.Join(piling, ", "))
+	} else {
+		text.WriteString("[green]No queue is backing up[-]\n\n")
+	}
+
+	failed := "[green]0 failed deliveries[-]"
+	if flow.failed > 0 {
+		failed = fmt.Sprintf("[red]%g failed deliveries[-]", flow.failed)
+	}
+	fmt.Fprintf(&text, "[yellow]SNS %s[-]  %g published, %s\n", topic, flow.published, failed)
+	if len(flow.branches) == 0 {
+		text.WriteString("└── [gray]no subscriptions[-]\n")
+	}
+
+	for i, branch := range flow.branches {
+		last := i == len(flow.branches)-1
+		branchPrefix, childPrefix := "├─▶ ", "│   "
+		if last {
+			branchPrefix, childPrefix = "└─▶ ", "    "
+		}
+
+		subscription := branch.subscription
+		switch {
+		case branch.function != nil:
+			fmt.Fprintf(&text, "%sLambda %s  %s\n", branchPrefix, branch.function.name, describeFunction(*branch.function))
+			continue
+		case subscription.Protocol != "sqs":
+			pending := ""
+			if subscription.Pending {
+				pending = "  [yellow]pending confirmation[-]"
+			}
+			fmt.Fprintf(&text, "%s%s %s%s\n", branchPrefix, subscription.Protocol, tview.Escape(subscription.Endpoint), pending)
+			continue
+		case branch.queue == nil:
+			fmt.Fprintf(&text, "%sSQS %s  [red]%s[-]\n", branchPrefix, subscription.Endpoint[strings.LastIndex(subscription.Endpoint, ":")+1:], clients.ErrorReason(branch.err))
+			continue
+		}
+
+		fmt.Fprintf(&text, "%sSQS %s  %s\n", branchPrefix, branch.queue.detail.Name, describeQueue(*branch.queue))
+
+		var children []string
+		for _, consumer := range branch.consumers {
+			children = append(children, fmt.Sprintf("Lambda %s  %s (batch %d, %s)", consumer.name, describeFunction(consumer), consumer.batchSize, strings.ToLower(consumer.state)))
+		}
+		if len(branch.consumers) == 0 {
+			children = append(children, "[yellow]no Lambda consumer[-]")
+		}
+		if dlq := branch.deadLetter; dlq != nil {
+			color := "green"
+			if dlq.detail.Visible > 0 {
+				color = "red"
+			}
+			children = append(children, fmt.Sprintf("DLQ %s  [%s]%d messages[-] after %d failed receives", dlq.detail.Name, color, dlq.detail.Visible, branch.queue.detail.MaxReceives))
+		}
+		if branch.err != nil {
+			children = append(children, fmt.Sprintf("[red]%s[-]", tview.Escape(branch.err.Error())))
+		}
+		for j, child := range children {
+			connector := "├─▶ "
+			if j == len(children)-1 {
+				connector = "└─▶ "
+			}
+			fmt.Fprintf(&text, "%s%s%s\n", childPrefix, connector, child)
+		}
+	}
+	return text.String()
+}
+
+// describeQueue summarizes the depth of queue, in red when it piles up
+func describeQueue(queue flowQueue) string {
+	color := "green"
+	if queue.pilingUp() {
+		color = "red"
+	}
+	summary := fmt.Sprintf("[%s]%d visible[-], %d in flight", color, queue.detail.Visible, queue.detail.InFlight)
+	if queue.detail.Delayed > 0 {
+		summary += fmt.Sprintf(", %d delayed", queue.detail.Delayed)
+	}
+	if queue.oldest >= 0 {
+		summary += ", oldest sent " + formatAge(queue.oldest)
+	}
+	if queue.pilingUp() {
+		summary += "  [red]◀ piling up[-]"
+	}
+	return summary
+}
+
+// describeFunction summarizes the invocations of fn, in red when it failed
+func describeFunction(fn flowFunction) string {
+	errors := fmt.Sprintf("%g errors", fn.errors)
+	if fn.errors > 0 {
+		errors = "[red]" + errors + "[-]"
+	}
+	throttles := fmt.Sprintf("%g throttles", fn.throttles)
+	if fn.throttles > 0 {
+		throttles = "[red]" + throttles + "[-]"
+	}
+	return fmt.Sprintf("%g invocations, %s, %s", fn.invocations, errors, throttles)
+}
@@ -0,0 +1,273 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// stackCrumb is a step of the trail to the stack shown in the stack browser
+type stackCrumb struct {
+	// name of the stack, or of the StackSet the stack is an instance of
+	name string
+	// id of the stack, empty for a StackSet
+	id string
+}
+
+// stackBrowser is the open stack browser: the resources of the last stack
+// of trail
+type stackBrowser struct {
+	client *aws.Client
+	// trail leads from the root stack, or the StackSet, down to the stack
+	// shown
+	trail     []stackCrumb
+	resources []clients.StackResource
+	table     *tview.Table
+	loads     int
+	// back is focused when the browser closes
+	back tview.Primitive
+}
+
+// stack returns the crumb of the stack shown
+func (b *stackBrowser) stack() stackCrumb {
+	return b.trail[len(b.trail)-1]
+}
+
+// breadcrumb renders trail, e.g. "shop > shop-Web-1AB > shop-Web-1AB-Cache-2CD"
+func breadcrumb(trail []stackCrumb) string {
+	names := make([]string, len(trail))
+	for i, crumb := range trail {
+		names[i] = crumb.name
+		if crumb.id == "" {
+			names[i] = "StackSet " + crumb.name
+		}
+	}
+	return strings.Join(names, " > ")
+}
+
+// stackTrail returns the trail from the root stack down to the stack res of
+// listing, following the parents of nested stacks through listing. A parent
+// missing from listing ends the trail.
+func stackTrail(listing []Resource, res Resource) []stackCrumb {
+	id, _ := res.Details["Stack ID"].(string)
+	trail := []stackCrumb{{name: res.Name, id: id}}
+	parent, _ := res.Details["Parent Stack"].(string)
+	for parent != "" && len(trail) < maxStackDepth {
+		found := false
+		for _, stack := range listing {
+			if stack.Details["Stack ID"] == parent {
+				trail = append([]stackCrumb{{name: stack.Name, id: parent}}, trail...)
+				parent, _ = stack.Details["Parent Stack"].(string)
+				found = true
+				break
+			}
+		}
+		if !found {
+			trail = append([]stackCrumb{{name: clients.StackNameOf(parent), id: parent}}, trail...)
+			break
+		}
+	}
+	return trail
+}
+
+// maxStackDepth bounds the trail of nested stacks; CloudFormation nests
+// stacks at most five levels deep
+const maxStackDepth = 8
+
+// showStackResources opens the stack browser on the last stack of trail
+// over the tab. Enter on a nested stack opens it and Backspace goes up to
+// the parent stack, closing the browser at the top of the trail or below a
+// StackSet. d shows the drift of the stack shown, r reloads and q closes the
+// browser, focusing back.
+func (rt *ResourcesTab) showStackResources(trail []stackCrumb, back tview.Primitive) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	browser := &stackBrowser{client: rt.awsClient, trail: trail, table: table, back: back}
+	rt.stackBrowser = browser
+
+	table.SetSelectedFunc(func(row, _ int) {
+		if row < 1 || row > len(browser.resources) {
+			return
+		}
+		resource := browser.resources[row-1]
+		if !resource.Nested() || resource.PhysicalID == "" {
+			return
+		}
+		browser.trail = append(browser.trail, stackCrumb{name: clients.StackNameOf(resource.PhysicalID), id: resource.PhysicalID})
+		rt.loadStackResources("")
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if len(browser.trail) < 2 || browser.trail[len(browser.trail)-2].id == "" {
+				rt.closeStackResources()
+				return nil
+			}
+			child := browser.stack()
+			browser.trail = browser.trail[:len(browser.trail)-1]
+			rt.loadStackResources(child.id)
+			return nil
+		}
+		switch event.Rune() {
+		case 'q':
+			rt.closeStackResources()
+			return nil
+		case 'r':
+			rt.loadStackResources("")
+			return nil
+		case 'd':
+			rt.showStackDrift(browser.stack().name, false)
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("cloudformation-stack", table, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(table)
+	}
+	rt.loadStackResources("")
+}
+
+// closeStackResources removes the stack browser and focuses what it opened
+// from
+func (rt *ResourcesTab) closeStackResources() {
+	back := tview.Primitive(rt.resourceTable)
+	if rt.stackBrowser != nil && rt.stackBrowser.back != nil {
+		back = rt.stackBrowser.back
+	}
+	rt.stackBrowser = nil
+	rt.view.RemovePage("cloudformation-stack")
+	if rt.app != nil {
+		rt.app.SetFocus(back)
+	}
+}
+
+// loadStackResources lists the resources of the stack of the browser in
+// the background, selecting the nested stack with the ID selectID if it is
+// one of them
+func (rt *ResourcesTab) loadStackResources(selectID string) {
+	browser := rt.stackBrowser
+	if browser == nil {
+		return
+	}
+
+	browser.resources = nil
+	browser.table.SetTitle(stackBrowserTitle(browser))
+	setTableMessage(browser.table, i18n.T("common.loading"), tcell.ColorGray)
+	browser.loads++
+	gen := browser.loads
+	stack := browser.stack()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var resources []clients.StackResource
+		err := fmt.Errorf("CloudFormation service not initialized")
+		if svc := browser.client.GetClients(); svc != nil && svc.CloudFormation != nil {
+			resources, err = svc.CloudFormation.ListStackResources(ctx, stack.id)
+		}
+		if err != nil {
+			logger.Error("Failed to list stack resources", zap.String("stack", stack.id), zap.Error(err))
+		}
+		if rt.app == nil {
+			return
+		}
+		rt.app.QueueUpdateDraw(func() {
+			if gen != browser.loads {
+				return
+			}
+			if err != nil {
+				setTableMessage(browser.table, i18n.T("stacks.resources_failed", stack.name, clients.ErrorReason(err)), tcell.ColorRed)
+				return
+			}
+			browser.resources = resources
+			browser.table.SetTitle(stackBrowserTitle(browser))
+			fillStackResources(browser.table, resources)
+			for i, resource := range resources {
+				if selectID != "" && resource.PhysicalID == selectID {
+					browser.table.Select(i+1, 0)
+				}
+			}
+		})
+	}()
+}
+
+// stackBrowserTitle shows the trail to the stack of browser
+func stackBrowserTitle(browser *stackBrowser) string {
+	title := fmt.Sprintf(" %s", tview.Escape(breadcrumb(browser.trail)))
+	if browser.resources != nil {
+		title += fmt.Sprintf(" (%d)", len(browser.resources))
+	}
+	return title + " (Enter: open nested stack, Backspace: up, d: drift, r: reload, q: close) "
+}
+
+// fillStackResources lists the resources of a stack, nested stacks in aqua
+func fillStackResources(table *tview.Table, resources []clients.StackResource) {
+	if len(resources) == 0 {
+		setTableMessage(table, i18n.T("stacks.no_resources"), tcell.ColorGray)
+		return
+	}
+
+	table.Clear()
+	for col, header := range []string{"Logical ID", "Type", "Physical ID", "Status", "Drift"} {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, resource := range resources {
+		row := i + 1
+		name, physical := resource.LogicalID, resource.PhysicalID
+		nameColor := tcell.ColorWhite
+		if resource.Nested() {
+			// The stack is named by the ID
+			name += " >"
+			physical = clients.StackNameOf(physical)
+			nameColor = tcell.ColorAqua
+		}
+		statusColor := tcell.ColorGreen
+		switch {
+		case strings.HasSuffix(resource.Status, "_FAILED"):
+			statusColor = tcell.ColorRed
+		case strings.HasSuffix(resource.Status, "_IN_PROGRESS"):
+			statusColor = tcell.ColorYellow
+		}
+		status := statusWords(resource.Status)
+		if resource.Reason != "" {
+			status += ": " + resource.Reason
+		}
+		driftColor, ok := resourceDriftColors[resource.DriftStatus]
+		if !ok {
+			driftColor = tcell.ColorGray
+		}
+
+		table.SetCell(row, 0, tview.NewTableCell(tview.Escape(name)).SetTextColor(nameColor))
+		table.SetCell(row, 1, tview.NewTableCell(resource.Type))
+		table.SetCell(row, 2, tview.NewTableCell(tview.Escape(physical)).SetMaxWidth(48))
+		table.SetCell(row, 3, tview.NewTableCell(tview.Escape(status)).SetTextColor(statusColor).SetExpansion(1))
+		table.SetCell(row, 4, tview.NewTableCell(driftWords(resource.DriftStatus)).SetTextColor(driftColor))
+	}
+	table.Select(1, 0).ScrollToBeginning()
+}
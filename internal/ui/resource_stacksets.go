@@ -0,0 +1,320 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// stackSetsView lists the CloudFormation StackSets administered from the
+// account; Enter shows their stack instances by account and region
+type stackSetsView struct{ baseView }
+
+var stackSetsService = stackSetsView{baseView{
+	info: ServiceInfo{Name: "stacksets", DisplayName: "CFN StackSets", Icon: "🗂", Label: "CFS", Enabled: true, Permission: "cloudformation:ListStackSets"},
+	noun: "StackSet",
+}}
+
+// ConsoleURL links the stack set
+func (stackSetsView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/cloudformation/home?%s#/stacksets/%s/info", base, query, url.PathEscape(res.Name))
+}
+
+// StateColor colors the drift of the StackSets like that of stacks
+func (stackSetsView) StateColor(state string) tcell.Color {
+	return stateColor(stackDriftStateColors, state)
+}
+
+// Load lists the StackSets
+func (stackSetsView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadStackSets(ctx, client)
+}
+
+// Summary counts the drifted StackSets
+func (stackSetsView) Summary(resources []Resource, failed int) (string, string) {
+	drifted := 0
+	for _, res := range resources {
+		if res.Alert {
+			drifted++
+		}
+	}
+
+	message := fmt.Sprintf("%d StackSets, %d drifted", len(resources), drifted)
+	switch {
+	case drifted > 0:
+		return message, "red"
+	case failed > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// Open shows the stack instances of the StackSet
+func (stackSetsView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showStackInstances(resource.Name)
+}
+
+// loadStackSets lists the active StackSets of the account in the region
+// with their drift as of the last detection
+func (rt *ResourcesTab) loadStackSets(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.CloudFormation == nil {
+		return nil, fmt.Errorf("CloudFormation service not initialized")
+	}
+
+	sets, err := svc.CloudFormation.ListStackSets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(sets))
+	for _, set := range sets {
+		res := Resource{
+			ID:     set.Name,
+			Name:   set.Name,
+			Type:   "StackSet (self-managed)",
+			State:  driftWords(set.DriftStatus),
+			Region: client.GetRegion(),
+			Tags:   make(map[string]string),
+			Details: map[string]interface{}{
+				"StackSet ID": set.ID,
+				"Status":      set.Status,
+				"View":        "press Enter for the stack instances by account and region",
+			},
+		}
+		if set.PermissionModel == "SERVICE_MANAGED" {
+			res.Type = "StackSet (service-managed)"
+		}
+		if set.Description != "" {
+			res.Details["Description"] = set.Description
+		}
+		if set.DriftCheckedAt.IsZero() {
+			res.Details["Drift Checked"] = "never"
+		} else {
+			res.Details["Drift Checked"] = fmt.Sprintf("%s (%s ago)", zonedTime(set.DriftCheckedAt, "2006-01-02 15:04"), workloadAge(set.DriftCheckedAt))
+		}
+		if set.DriftStatus == "DRIFTED" {
+			res.Alert = true
+			res.Details["Flag"] = "stack instances were changed outside CloudFormation"
+		}
+		resources = append(resources, res)
+	}
+	return resources, nil
+}
+
+// stackInstanceColors color the statuses of stack instances
+var stackInstanceColors = map[string]tcell.Color{
+	"CURRENT":    tcell.ColorGreen,
+	"OUTDATED":   tcell.ColorYellow,
+	"INOPERABLE": tcell.ColorRed,
+}
+
+// showStackInstances shows the stack instances of stackSet over the tab, by
+// account and region, with how the last operation on each went. Enter on an
+// instance of the account and region of the tab browses its stack, r
+// reloads and q closes the panel.
+func (rt *ResourcesTab) showStackInstances(stackSet string) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	// The instances, nil until loaded
+	var instances []clients.StackInstance
+	loads := 0
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	// notice shows the full reason of the selected instance, or why its
+	// stack cannot be opened
+	notice := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	panel := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(table, 0, 1, true).
+		AddItem(notice, 2, 0, false)
+	panel.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("stacksets.title", stackSet))
+
+	setNotice := func(message, color string) {
+		notice.SetText(fmt.Sprintf("[%s]%s%s[-]", color, stateWord(color), tview.Escape(message)))
+	}
+
+	load := func() {
+		setTableMessage(table, i18n.T("common.loading"), tcell.ColorGray)
+		notice.SetText("")
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var loaded []clients.StackInstance
+			err := fmt.Errorf("CloudFormation service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.CloudFormation != nil {
+				loaded, err = svc.CloudFormation.ListStackInstances(ctx, stackSet)
+			}
+			if err != nil {
+				logger.Error("Failed to list stack instances", zap.String("stack_set", stackSet), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					setTableMessage(table, i18n.T("stacksets.list_failed", stackSet, clients.ErrorReason(err)), tcell.ColorRed)
+					return
+				}
+				instances = sortStackInstances(loaded)
+				panel.SetTitle(panelTitle("stacksets.title_status",
+					stackSet, stackInstancesSpread(instances)))
+				fillStackInstances(table, instances)
+			})
+		}()
+	}
+
+	table.SetSelectionChangedFunc(func(row, _ int) {
+		notice.SetText("")
+		if row >= 1 && row <= len(instances) && instances[row-1].Reason != "" {
+			setNotice(instances[row-1].Reason, "red")
+		}
+	})
+	table.SetSelectedFunc(func(row, _ int) {
+		if row < 1 || row > len(instances) {
+			return
+		}
+		instance := instances[row-1]
+		switch {
+		case instance.StackID == "":
+			setNotice(fmt.Sprintf("The instance in %s %s has no stack yet", instance.Account, instance.Region), "yellow")
+		case instance.Account != client.GetAccountID() || instance.Region != client.GetRegion():
+			setNotice(fmt.Sprintf("The stack in %s %s is in another account or region; switch to it to browse the stack", instance.Account, instance.Region), "yellow")
+		default:
+			rt.showStackResources([]stackCrumb{
+				{name: stackSet},
+				{name: clients.StackNameOf(instance.StackID), id: instance.StackID},
+			}, table)
+		}
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeStackInstances()
+			return nil
+		case 'r':
+			load()
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("cloudformation-stacksets", panel, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(table)
+	}
+	load()
+}
+
+// closeStackInstances removes the stack instances panel and returns focus
+// to the table
+func (rt *ResourcesTab) closeStackInstances() {
+	rt.view.RemovePage("cloudformation-stacksets")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// sortStackInstances sorts instances by account, then region
+func sortStackInstances(instances []clients.StackInstance) []clients.StackInstance {
+	sort.SliceStable(instances, func(i, j int) bool {
+		if instances[i].Account != instances[j].Account {
+			return instances[i].Account < instances[j].Account
+		}
+		return instances[i].Region < instances[j].Region
+	})
+	return instances
+}
+
+// stackInstancesSpread counts instances with the accounts and regions they
+// are in, e.g. "6 instances in 3 accounts and 2 regions"
+func stackInstancesSpread(instances []clients.StackInstance) string {
+	accounts, regions := make(map[string]bool), make(map[string]bool)
+	for _, instance := range instances {
+		accounts[instance.Account] = true
+		regions[instance.Region] = true
+	}
+	return fmt.Sprintf("%s in %s and %s", pluralize(len(instances), "instance"), pluralize(len(accounts), "account"), pluralize(len(regions), "region"))
+}
+
+// fillStackInstances lists the stack instances of a StackSet, failed
+// operations in red
+func fillStackInstances(table *tview.Table, instances []clients.StackInstance) {
+	if len(instances) == 0 {
+		setTableMessage(table, i18n.T("stacksets.no_instances"), tcell.ColorGray)
+		return
+	}
+
+	table.Clear()
+	for col, header := range []string{"Account", "Region", "OU", "Status", "Operation", "Drift", "Reason"} {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, instance := range instances {
+		row := i + 1
+		statusColor, ok := stackInstanceColors[instance.Status]
+		if !ok {
+			statusColor = tcell.ColorWhite
+		}
+		operationColor := tcell.ColorWhite
+		switch instance.DetailedStatus {
+		case "FAILED", "CANCELLED":
+			operationColor = tcell.ColorRed
+		case "SUCCEEDED":
+			operationColor = tcell.ColorGreen
+		case "PENDING", "RUNNING":
+			operationColor = tcell.ColorYellow
+		}
+		driftColor, ok := resourceDriftColors[instance.DriftStatus]
+		if !ok {
+			driftColor = tcell.ColorGray
+		}
+		if instance.DriftStatus == "DRIFTED" {
+			driftColor = tcell.ColorRed
+		}
+		ou, reason := instance.OrganizationalUnit, instance.Reason
+		if ou == "" {
+			ou = "-"
+		}
+		if reason == "" {
+			reason = "-"
+		}
+
+		table.SetCell(row, 0, tview.NewTableCell(instance.Account))
+		table.SetCell(row, 1, tview.NewTableCell(instance.Region))
+		table.SetCell(row, 2, tview.NewTableCell(ou))
+		table.SetCell(row, 3, tview.NewTableCell(statusWords(instance.Status)).SetTextColor(statusColor))
+		table.SetCell(row, 4, tview.NewTableCell(statusWords(instance.DetailedStatus)).SetTextColor(operationColor))
+		table.SetCell(row, 5, tview.NewTableCell(driftWords(instance.DriftStatus)).SetTextColor(driftColor))
+		table.SetCell(row, 6, tview.NewTableCell(tview.Escape(reason)).SetExpansion(1))
+	}
+	table.Select(1, 0).ScrollToBeginning()
+}
@@ -0,0 +1,332 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// onLambdaTraffic opens the traffic panel of the selected function
+func (rt *ResourcesTab) onLambdaTraffic() {
+	if rt.selectedService != "lambda" || rt.selectedRes == nil {
+		return
+	}
+	rt.showTraffic(rt.selectedRes.Name)
+}
+
+// showTraffic shows the aliases of functionName with the versions they
+// route invocations to over the tab. w sets the weights of the selected
+// alias, p promotes its canary to take all invocations, b rolls the canary
+// back, r reloads and q closes the panel.
+func (rt *ResourcesTab) showTraffic(functionName string) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	// The aliases and published versions, nil until loaded
+	var aliases []clients.LambdaAlias
+	var versions []string
+	loads := 0
+
+	notice := tview.NewTextView().SetDynamicColors(true)
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	panel := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(notice, 2, 0, false).
+		AddItem(table, 0, 1, true)
+	panel.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("traffic.title", functionName))
+
+	setNotice := func(message, color string) {
+		if message == "" {
+			notice.SetText("")
+			return
+		}
+		notice.SetText(fmt.Sprintf("[%s]%s%s[-]", color, stateWord(color), tview.Escape(message)))
+	}
+
+	var load func()
+	load = func() {
+		// Reloads keep the selected alias
+		row, _ := table.GetSelection()
+		table.Clear()
+		table.SetCell(0, 0, tview.NewTableCell(i18n.T("common.loading")).SetTextColor(tcell.ColorGray).SetSelectable(false))
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			loaded, published, err := fetchTraffic(ctx, client, functionName)
+			if err != nil {
+				logger.Error("Failed to load Lambda aliases", zap.String("function", functionName), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					setTableMessage(table, i18n.T("traffic.aliases_failed", functionName, err), tcell.ColorRed)
+					return
+				}
+				aliases, versions = loaded, published
+				renderTraffic(table, aliases)
+				if row > 1 && row <= len(aliases) {
+					table.Select(row, 0)
+				}
+			})
+		}()
+	}
+
+	// apply routes the invocations of alias to version and weight of them to
+	// additionalVersion in the background, records it and reloads the panel
+	apply := func(alias, version, additionalVersion string, weight float64, done string) {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			err := fmt.Errorf("lambda service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.Lambda != nil {
+				err = svc.Lambda.UpdateAliasRouting(ctx, functionName, alias, version, additionalVersion, weight)
+			}
+			resource := functionName + ":" + alias
+			rt.audit.record(client, "lambda:UpdateAlias", resource, err)
+			if err != nil {
+				logger.Error("Failed to update Lambda alias", zap.String("alias", resource), zap.Error(err))
+			} else {
+				logger.Info("Updated Lambda alias", zap.String("alias", resource),
+					zap.String("version", version), zap.String("additional_version", additionalVersion), zap.Float64("weight", weight))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if err != nil {
+					setNotice(err.Error(), "red")
+				} else {
+					setNotice(done, "green")
+				}
+				load()
+			})
+		}()
+	}
+
+	// selected returns the alias of the selected row
+	selected := func() (clients.LambdaAlias, bool) {
+		row, _ := table.GetSelection()
+		if row < 1 || row > len(aliases) {
+			return clients.LambdaAlias{}, false
+		}
+		return aliases[row-1], true
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeTraffic()
+			return nil
+		case 'r':
+			setNotice("", "")
+			load()
+			return nil
+		case 'w':
+			if alias, ok := selected(); ok {
+				rt.editTraffic(functionName, alias, versions, panel, apply)
+			}
+			return nil
+		case 'p':
+			alias, ok := selected()
+			if !ok {
+				return nil
+			}
+			if alias.AdditionalVersion == "" {
+				setNotice(i18n.T("traffic.no_canary_promote", alias.Name), "yellow")
+				return nil
+			}
+			apply(alias.Name, alias.AdditionalVersion, "", 0,
+				i18n.T("traffic.promoted", alias.AdditionalVersion, alias.Name))
+			return nil
+		case 'b':
+			alias, ok := selected()
+			if !ok {
+				return nil
+			}
+			if alias.AdditionalVersion == "" {
+				setNotice(i18n.T("traffic.no_canary_rollback", alias.Name), "yellow")
+				return nil
+			}
+			apply(alias.Name, alias.Version, "", 0,
+				i18n.T("traffic.rolled_back", alias.AdditionalVersion, alias.Name, alias.Version))
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("lambda-traffic", panel, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(table)
+	}
+	load()
+}
+
+// trafficChange routes the invocations of an alias to version and weight
+// of them to additionalVersion, showing done once it succeeded
+type trafficChange func(alias, version, additionalVersion string, weight float64, done string)
+
+// editTraffic asks for the versions alias routes to and the share of the
+// canary, in percent
+func (rt *ResourcesTab) editTraffic(functionName string, alias clients.LambdaAlias, versions []string, panel tview.Primitive, apply trafficChange) {
+	if len(versions) == 0 {
+		rt.updateStatus(i18n.T("traffic.no_versions", functionName), "yellow")
+		return
+	}
+
+	version, canary := alias.Version, alias.AdditionalVersion
+	percent := "10"
+	if canary != "" {
+		percent = strings.TrimSuffix(formatWeight(alias.Weight), "%")
+	}
+	canaries := append([]string{"none"}, versions...)
+
+	form := tview.NewForm()
+	form.AddDropDown(i18n.T("traffic.version"), versions, slices.Index(versions, version), func(option string, _ int) { version = option })
+	form.AddDropDown(i18n.T("traffic.canary"), canaries, max(slices.Index(canaries, canary), 0), func(option string, index int) {
+		canary = ""
+		if index > 0 {
+			canary = option
+		}
+	})
+	form.AddInputField(i18n.T("traffic.canary_percent"), percent, 8, nil, func(text string) { percent = text })
+	form.AddButton(i18n.T("common.save"), func() {
+		weight, err := canaryWeight(percent)
+		if canary != "" && err != nil {
+			rt.updateStatus(err.Error(), "red")
+			return
+		}
+		if canary == version {
+			rt.updateStatus(i18n.T("traffic.same_canary"), "red")
+			return
+		}
+		rt.closeTrafficEdit(panel)
+		if canary == "" {
+			apply(alias.Name, version, "", 0, i18n.T("traffic.all_to", alias.Name, version))
+			return
+		}
+		apply(alias.Name, version, canary, weight, i18n.T("traffic.split",
+			alias.Name, formatWeight(1-weight), version, formatWeight(weight), canary))
+	})
+	form.AddButton(i18n.T("common.cancel"), func() { rt.closeTrafficEdit(panel) })
+	form.SetBorder(true).
+		SetTitle(panelTitle("traffic.edit_title", alias.Name)).
+		SetTitleAlign(tview.AlignLeft)
+
+	rt.view.AddPage("lambda-traffic-edit", centered(form, 64, 11), true, true)
+	// As an overlay the form gets Tab to move between its fields
+	rt.setOverlay(func() { rt.closeTrafficEdit(panel) })
+	if rt.app != nil {
+		rt.app.SetFocus(form)
+	}
+}
+
+// closeTrafficEdit removes the traffic form and returns focus to panel
+func (rt *ResourcesTab) closeTrafficEdit(panel tview.Primitive) {
+	rt.view.RemovePage("lambda-traffic-edit")
+	rt.setOverlay(nil)
+	if rt.app != nil {
+		rt.app.SetFocus(panel)
+	}
+}
+
+// closeTraffic removes the traffic panel and returns focus to the table
+func (rt *ResourcesTab) closeTraffic() {
+	rt.view.RemovePage("lambda-traffic")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// fetchTraffic returns the aliases and published versions of functionName
+func fetchTraffic(ctx context.Context, client *aws.Client, functionName string) ([]clients.LambdaAlias, []string, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.Lambda == nil {
+		return nil, nil, fmt.Errorf("lambda service not initialized")
+	}
+
+	aliases, err := svc.Lambda.ListAliases(ctx, functionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	versions, err := svc.Lambda.ListVersions(ctx, functionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return aliases, versions, nil
+}
+
+// renderTraffic lists aliases in table with the share of the invocations
+// each of their versions receives
+func renderTraffic(table *tview.Table, aliases []clients.LambdaAlias) {
+	table.Clear()
+	for col, header := range []string{"Alias", "Version", "Weight", "Canary", "Weight"} {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	if len(aliases) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No aliases; traffic shifting needs an alias").SetTextColor(tcell.ColorGray).SetSelectable(false))
+		return
+	}
+
+	for i, alias := range aliases {
+		row := i + 1
+		table.SetCell(row, 0, tview.NewTableCell(alias.Name).SetExpansion(1))
+		table.SetCell(row, 1, tview.NewTableCell(alias.Version))
+		if alias.AdditionalVersion == "" {
+			table.SetCell(row, 2, tview.NewTableCell("100%").SetAlign(tview.AlignRight))
+			table.SetCell(row, 3, tview.NewTableCell("-").SetTextColor(tcell.ColorGray))
+			table.SetCell(row, 4, tview.NewTableCell("-").SetTextColor(tcell.ColorGray).SetAlign(tview.AlignRight))
+			continue
+		}
+		table.SetCell(row, 2, tview.NewTableCell(formatWeight(1-alias.Weight)).SetAlign(tview.AlignRight))
+		table.SetCell(row, 3, tview.NewTableCell(alias.AdditionalVersion).SetTextColor(tcell.ColorYellow))
+		table.SetCell(row, 4, tview.NewTableCell(formatWeight(alias.Weight)).SetTextColor(tcell.ColorYellow).SetAlign(tview.AlignRight))
+	}
+	table.Select(1, 0)
+}
+
+// canaryWeight parses the share of a canary in percent, e.g. "10" or "2.5",
+// into a weight between 0 and 1
+func canaryWeight(percent string) (float64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(percent), "%"), 64)
+	if err != nil || value <= 0 || value >= 100 {
+		return 0, fmt.Errorf("canary share must be a percentage between 0 and 100, got %q", percent)
+	}
+	return value / 100, nil
+}
+
+// formatWeight renders a weight between 0 and 1 as a percentage with up to
+// two decimals
+func formatWeight(weight float64) string {
+	return strconv.FormatFloat(math.Round(weight*10000)/100, 'f', -1, 64) + "%"
+}
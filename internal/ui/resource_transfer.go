@@ -0,0 +1,286 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/pricing"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// transferView lists the Transfer Family servers with their protocols and
+// endpoints; Enter shows their users
+type transferView struct{ baseView }
+
+var transferService = transferView{baseView{
+	info: ServiceInfo{Name: "transfer", DisplayName: "Transfer Family", Icon: "📤", Label: "XFR", Enabled: true, Permission: "transfer:ListServers"},
+	noun: "server",
+}}
+
+// ConsoleURL links the server
+func (transferView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/transfer/home?%s#/servers/%s", base, query, url.PathEscape(res.ID))
+}
+
+// StateColor colors the states of the servers
+func (transferView) StateColor(state string) tcell.Color {
+	return stateColor(transferStateColors, state)
+}
+
+// transferStateColors color the states of servers
+var transferStateColors = map[string]tcell.Color{
+	"online":       tcell.ColorGreen,
+	"offline":      tcell.ColorRed,
+	"start failed": tcell.ColorRed,
+	"stop failed":  tcell.ColorRed,
+}
+
+// Load lists the servers
+func (transferView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadTransferServers(ctx, client)
+}
+
+// Summary counts the online servers, their users and the servers that
+// accept unencrypted FTP
+func (transferView) Summary(resources []Resource, failed int) (string, string) {
+	online, users, plainFTP := 0, 0, 0
+	for _, res := range resources {
+		if res.State == "online" {
+			online++
+		}
+		if n, ok := res.Details["Users"].(int); ok {
+			users += n
+		}
+		if res.Alert {
+			plainFTP++
+		}
+	}
+
+	message := fmt.Sprintf("%d servers, %d online, %s", len(resources), online, pluralize(users, "user"))
+	if plainFTP > 0 {
+		message += fmt.Sprintf(", %d with plain FTP", plainFTP)
+	}
+	switch {
+	case plainFTP > 0:
+		return message, "red"
+	case failed > 0 || online < len(resources):
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// Open shows the users of the server
+func (transferView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showTransferUsers(resource)
+}
+
+// loadTransferServers lists the Transfer Family servers of the region with
+// what they cost; servers accepting plain FTP are flagged
+func (rt *ResourcesTab) loadTransferServers(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.Transfer == nil {
+		return nil, fmt.Errorf("Transfer Family service not initialized")
+	}
+
+	servers, err := svc.Transfer.ListServers(ctx)
+	if servers == nil && err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(servers))
+	for _, server := range servers {
+		resources = append(resources, transferServerResource(server, client.GetRegion()))
+	}
+	return resources, err
+}
+
+// transferServerResource describes server with its endpoint and users
+func transferServerResource(server clients.TransferServer, region string) Resource {
+	protocols := strings.Join(server.Protocols, ", ")
+	res := Resource{
+		ID:     server.ID,
+		Name:   server.ID,
+		Type:   "Transfer Server",
+		State:  statusWords(server.State),
+		Region: region,
+		Tags:   server.Tags,
+		Details: map[string]interface{}{
+			"ARN":               server.ARN,
+			"Protocols":         orDash(protocols),
+			"Endpoint":          server.Endpoint,
+			"Endpoint Type":     server.EndpointType,
+			"Storage":           server.Domain,
+			"Identity Provider": server.IdentityProvider,
+			"Users":             server.Users,
+			"View":              "press Enter for the users of the server",
+		},
+	}
+	if res.Tags == nil {
+		res.Tags = make(map[string]string)
+	}
+	if name := res.Tags["Name"]; name != "" {
+		res.Name = name
+	}
+	if protocols != "" {
+		res.Type = fmt.Sprintf("Transfer Server (%s)", protocols)
+	}
+	if server.VPCID != "" {
+		res.Details["VPC"] = server.VPCID
+	}
+	if len(server.AddressAllocationIDs) > 0 {
+		res.Details["Elastic IPs"] = strings.Join(server.AddressAllocationIDs, ", ")
+	}
+	if server.LoggingRole == "" {
+		res.Details["Logging Role"] = "none, transfers are not logged"
+	} else {
+		res.Details["Logging Role"] = server.LoggingRole
+	}
+	if cost, ok := pricing.TransferServerMonthly(len(server.Protocols), region); ok {
+		res.MonthlyCost = cost
+	}
+
+	switch {
+	case slices.Contains(server.Protocols, "FTP"):
+		res.Alert = true
+		res.Details["Flag"] = "FTP sends credentials and files unencrypted"
+	case server.State == "OFFLINE":
+		res.Details["Flag"] = "stopped, but still billed for its protocols"
+	}
+	return res
+}
+
+// showTransferUsers shows the users of the Transfer Family server res over
+// the tab with their home directories and SSH keys; r reloads and q closes
+// the panel. Servers with an identity provider of their own have no users
+// in Transfer Family.
+func (rt *ResourcesTab) showTransferUsers(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	identity, _ := res.Details["Identity Provider"].(string)
+	protocols, _ := res.Details["Protocols"].(string)
+	loads := 0
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("transfer.title", res.Name))
+
+	load := func() {
+		if identity != "" && identity != "SERVICE_MANAGED" {
+			setTableMessage(table, i18n.T("transfer.managed", res.Name, statusWords(identity)), tcell.ColorGray)
+			return
+		}
+		setTableMessage(table, i18n.T("common.loading"), tcell.ColorGray)
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var users []clients.TransferUser
+			err := fmt.Errorf("Transfer Family service not initialized")
+			if svc := client.GetClients(); svc != nil && svc.Transfer != nil {
+				users, err = svc.Transfer.ListUsers(ctx, res.ID)
+			}
+			if err != nil {
+				logger.Error("Failed to list Transfer Family users", zap.String("server", res.ID), zap.Error(err))
+			}
+			if rt.app == nil {
+				return
+			}
+			rt.app.QueueUpdateDraw(func() {
+				if gen != loads {
+					return
+				}
+				if err != nil {
+					setTableMessage(table, i18n.T("transfer.list_failed", res.Name, clients.ErrorReason(err)), tcell.ColorRed)
+					return
+				}
+				table.SetTitle(panelTitle("transfer.title_count", res.Name, pluralize(len(users), "user")))
+				fillTransferUsers(table, users, strings.Contains(protocols, "SFTP"))
+			})
+		}()
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			rt.closeTransferUsers()
+			return nil
+		case 'r':
+			load()
+			return nil
+		}
+		return event
+	})
+
+	rt.view.AddPage("transfer-users", table, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(table)
+	}
+	load()
+}
+
+// closeTransferUsers removes the users panel and returns focus to the table
+func (rt *ResourcesTab) closeTransferUsers() {
+	rt.view.RemovePage("transfer-users")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// fillTransferUsers lists the users of a server. On SFTP servers, users
+// without an SSH key cannot log in and are shown in yellow.
+func fillTransferUsers(table *tview.Table, users []clients.TransferUser, sftp bool) {
+	if len(users) == 0 {
+		setTableMessage(table, i18n.T("transfer.no_users"), tcell.ColorGray)
+		return
+	}
+
+	table.Clear()
+	for col, header := range []string{"User", "Home Directory", "Mapping", "SSH Keys", "Role"} {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, user := range users {
+		row := i + 1
+		keys := fmt.Sprintf("%d", user.SSHKeys)
+		keysColor := tcell.ColorWhite
+		if sftp && user.SSHKeys == 0 {
+			keys = "none"
+			keysColor = tcell.ColorYellow
+		}
+		role := user.Role
+		if slash := strings.LastIndex(role, "/"); slash >= 0 {
+			role = role[slash+1:]
+		}
+
+		table.SetCell(row, 0, tview.NewTableCell(tview.Escape(user.Name)))
+		table.SetCell(row, 1, tview.NewTableCell(tview.Escape(orDash(user.HomeDirectory))))
+		table.SetCell(row, 2, tview.NewTableCell(strings.ToLower(orDash(user.HomeDirectoryType))))
+		table.SetCell(row, 3, tview.NewTableCell(keys).SetTextColor(keysColor).SetAlign(tview.AlignRight))
+		table.SetCell(row, 4, tview.NewTableCell(tview.Escape(orDash(role))).SetExpansion(1))
+	}
+	table.Select(1, 0).ScrollToBeginning()
+}
@@ -0,0 +1,297 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// trustedAdvisorView lists the Trusted Advisor checks; Enter shows the
+// resources a check flagged
+type trustedAdvisorView struct{ baseView }
+
+var trustedAdvisorService = trustedAdvisorView{baseView{
+	info: ServiceInfo{Name: "trustedadvisor", DisplayName: "Trusted Advisor", Icon: "🩺", Label: "TA", Enabled: true, Permission: "support:DescribeTrustedAdvisorChecks"},
+	noun: "check",
+}}
+
+// StateColor colors the status of the checks
+func (trustedAdvisorView) StateColor(state string) tcell.Color {
+	return stateColor(checkStateColors, state)
+}
+
+// checkStateColors color the status of checks
+var checkStateColors = map[string]tcell.Color{
+	"ok":      tcell.ColorGreen,
+	"error":   tcell.ColorRed,
+	"warning": tcell.ColorYellow,
+}
+
+// Load lists the checks
+func (trustedAdvisorView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadTrustedAdvisor(ctx, client)
+}
+
+// Summary sums up the checks
+func (trustedAdvisorView) Summary(resources []Resource, failed int) (string, string) {
+	return trustedAdvisorSummary(resources)
+}
+
+// Open shows the resources the check flagged
+func (trustedAdvisorView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showFlaggedResources(resource)
+}
+
+// trustedAdvisorCategories are the check categories shown, in order, with
+// their display names
+var trustedAdvisorCategories = []struct {
+	id   string
+	name string
+}{
+	{"cost_optimizing", "Cost Optimization"},
+	{"fault_tolerance", "Fault Tolerance"},
+	{"security", "Security"},
+}
+
+// trustedAdvisorSeverity orders check statuses, worst first
+var trustedAdvisorSeverity = map[string]int{
+	"error":         0,
+	"warning":       1,
+	"ok":            2,
+	"not available": 3,
+}
+
+// columnSeparator joins the metadata columns of a check in its details
+const columnSeparator = " | "
+
+// loadTrustedAdvisor lists the cost optimization, fault tolerance and security
+// checks of Trusted Advisor, by category with the worst status first
+func (rt *ResourcesTab) loadTrustedAdvisor(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.Support == nil {
+		return nil, fmt.Errorf("Support service not initialized")
+	}
+
+	checks, err := svc.Support.ListTrustedAdvisorChecks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[string]int, len(trustedAdvisorCategories))
+	for i, category := range trustedAdvisorCategories {
+		categories[category.id] = i
+	}
+
+	var resources []Resource
+	for _, check := range checks {
+		if _, ok := categories[check.Category]; ok {
+			resources = append(resources, trustedAdvisorResource(check))
+		}
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		ca, cb := categories[a.Details["Category"].(string)], categories[b.Details["Category"].(string)]
+		if ca != cb {
+			return ca < cb
+		}
+		if sa, sb := trustedAdvisorSeverity[a.State], trustedAdvisorSeverity[b.State]; sa != sb {
+			return sa < sb
+		}
+		return a.Name < b.Name
+	})
+	return resources, nil
+}
+
+// trustedAdvisorResource describes check with what its latest run flagged
+func trustedAdvisorResource(check clients.TrustedAdvisorCheck) Resource {
+	typ := check.Category
+	for _, category := range trustedAdvisorCategories {
+		if category.id == check.Category {
+			typ = category.name
+		}
+	}
+
+	res := Resource{
+		ID:     check.ID,
+		Name:   check.Name,
+		Type:   typ,
+		State:  strings.ReplaceAll(check.Status, "_", " "),
+		Region: "global",
+		Tags:   make(map[string]string),
+		Alert:  check.Status == "error",
+		Details: map[string]interface{}{
+			"Category":    check.Category,
+			"Description": check.Description,
+			"Flagged":     fmt.Sprintf("%d of %d resources", check.Flagged, check.Processed),
+			"Columns":     strings.Join(check.Columns, columnSeparator),
+			"View":        "press Enter to list the flagged resources",
+		},
+	}
+	if check.Suppressed > 0 {
+		res.Details["Suppressed"] = check.Suppressed
+	}
+	if check.EstimatedMonthlySavings > 0 {
+		res.Details["Estimated Savings"] = formatMonthlyCost(check.EstimatedMonthlySavings) + " per month"
+		res.Details["Monthly Savings"] = check.EstimatedMonthlySavings
+	}
+	if !check.RefreshedAt.IsZero() {
+		res.CreatedDate = check.RefreshedAt.Format("2006-01-02 15:04:05")
+	}
+	return res
+}
+
+// trustedAdvisorSummary counts the checks that need action and the savings
+// the cost optimization checks estimate
+func trustedAdvisorSummary(resources []Resource) (string, string) {
+	var errored, warned int
+	var savings float64
+	for _, res := range resources {
+		switch res.State {
+		case "error":
+			errored++
+		case "warning":
+			warned++
+		}
+		if s, ok := res.Details["Monthly Savings"].(float64); ok {
+			savings += s
+		}
+	}
+
+	message := fmt.Sprintf("%d checks, %d action recommended, %d investigate", len(resources), errored, warned)
+	if savings > 0 {
+		message += fmt.Sprintf(", save %s/mo", formatMonthlyCost(savings))
+	}
+	switch {
+	case errored > 0:
+		return message, "red"
+	case warned > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// showFlaggedResources lists the resources the Trusted Advisor check res
+// flagged over the tab. The view closes with q.
+func (rt *ResourcesTab) showFlaggedResources(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	var columns []string
+	if joined, _ := res.Details["Columns"].(string); joined != "" {
+		columns = strings.Split(joined, columnSeparator)
+	}
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	table.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("trustedadvisor.title", res.Name))
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' {
+			rt.closeFlaggedResources()
+			return nil
+		}
+		return event
+	})
+	setTableMessage(table, i18n.T("common.loading"), tcell.ColorGray)
+
+	rt.view.AddPage("trustedadvisor", table, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(table)
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var flagged []clients.FlaggedResource
+		err := fmt.Errorf("Support service not initialized")
+		if svc := client.GetClients(); svc != nil && svc.Support != nil {
+			flagged, err = svc.Support.GetFlaggedResources(ctx, res.ID)
+		}
+		if err != nil {
+			logger.Error("Failed to get flagged resources", zap.String("check", res.ID), zap.Error(err))
+		}
+		if rt.app != nil {
+			rt.app.QueueUpdateDraw(func() {
+				if err != nil {
+					setTableMessage(table, i18n.T("trustedadvisor.flagged_failed", err.Error()), tcell.ColorRed)
+					return
+				}
+				fillFlaggedResources(table, columns, flagged)
+			})
+		}
+	}()
+}
+
+// fillFlaggedResources lists flagged with their status and the metadata
+// columns of the check. Suppressed resources are shown in gray.
+func fillFlaggedResources(table *tview.Table, columns []string, flagged []clients.FlaggedResource) {
+	if len(flagged) == 0 {
+		setTableMessage(table, i18n.T("trustedadvisor.none_flagged"), tcell.ColorGreen)
+		return
+	}
+
+	table.Clear()
+	for col, name := range append([]string{"Status"}, columns...) {
+		table.SetCell(0, col, tview.NewTableCell(name).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, resource := range flagged {
+		color := tcell.ColorWhite
+		switch strings.ToLower(resource.Status) {
+		case "error":
+			color = tcell.ColorRed
+		case "warning":
+			color = tcell.ColorYellow
+		case "ok":
+			color = tcell.ColorGreen
+		}
+		status := resource.Status
+		if resource.Suppressed {
+			status += " (suppressed)"
+			color = tcell.ColorGray
+		}
+		table.SetCell(i+1, 0, tview.NewTableCell(status).SetTextColor(color))
+		for col, value := range resource.Metadata {
+			table.SetCell(i+1, col+1, tview.NewTableCell(value).SetMaxWidth(40))
+		}
+	}
+	table.Select(1, 0).ScrollToBeginning()
+}
+
+// closeFlaggedResources removes the flagged resources view and returns focus
+// to the table
+func (rt *ResourcesTab) closeFlaggedResources() {
+	rt.view.RemovePage("trustedadvisor")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
+
+// isSupportPlanError reports whether err means the account's support plan
+// does not include Trusted Advisor
+func isSupportPlanError(err error) bool {
+	return errors.Is(err, clients.ErrSupportPlanRequired)
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+)
+
+// vpcView lists the VPCs
+type vpcView struct{ baseView }
+
+var vpcService = vpcView{baseView{
+	info: ServiceInfo{Name: "vpc", DisplayName: "VPC Networks", Icon: "🌐", Label: "VPC", Enabled: true, Permission: "ec2:DescribeVpcs"},
+}}
+
+// ConsoleURL links the VPC
+func (vpcView) ConsoleURL(base, query string, res Resource) string {
+	return fmt.Sprintf("%s/vpcconsole/home?%s#VpcDetails:VpcId=%s", base, query, res.ID)
+}
+
+// ARN returns the ARN of the VPC
+func (vpcView) ARN(account string, res Resource) string {
+	return fmt.Sprintf("arn:%s:ec2:%s:%s:vpc/%s", awsPartition(res.Region), res.Region, account, res.ID)
+}
+
+// Load lists the VPCs
+func (vpcView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadVPCs(ctx, client)
+}
+
+// loadVPCs loads VPCs (placeholder)
+func (rt *ResourcesTab) loadVPCs(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	// Placeholder implementation
+	return []Resource{
+		{
+			ID:          "vpc-example-1",
+			Name:        "Example VPC",
+			Type:        "VPC",
+			State:       "Available",
+			Region:      client.GetRegion(),
+			CreatedDate: time.Now().UTC().Format("2006-01-02 15:04:05"),
+			Tags:        make(map[string]string),
+			Details:     map[string]interface{}{"Note": "VPC implementation coming soon"},
+		},
+	}, nil
+}
@@ -0,0 +1,414 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"go.uber.org/zap"
+)
+
+// wafView lists the WAF web ACLs; Enter shows the rules of one
+type wafView struct{ baseView }
+
+var wafService = wafView{baseView{
+	info: ServiceInfo{Name: "waf", DisplayName: "WAF Web ACLs", Icon: "🧱", Label: "WAF", Enabled: true, Permission: "wafv2:ListWebACLs"},
+	noun: "web ACL",
+}}
+
+// StateColor colors the web ACLs protecting nothing
+func (wafView) StateColor(state string) tcell.Color {
+	return stateColor(wafStateColors, state)
+}
+
+// wafStateColors color web ACLs protecting nothing
+var wafStateColors = map[string]tcell.Color{
+	"unused": tcell.ColorRed,
+}
+
+// Load lists the web ACLs
+func (wafView) Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error) {
+	return rt.loadWebACLs(ctx, client)
+}
+
+// Summary sums up the web ACLs
+func (wafView) Summary(resources []Resource, failed int) (string, string) {
+	return wafSummary(resources, failed)
+}
+
+// Open shows the rules of the web ACL
+func (wafView) Open(rt *ResourcesTab, resource Resource) {
+	rt.showWebACL(resource)
+}
+
+// wafSampleWindow is how far back sampled requests are fetched. WAF keeps
+// samples for three hours.
+const wafSampleWindow = 3 * time.Hour
+
+// wafActionColors colors the action of a rule or sampled request
+var wafActionColors = map[string]tcell.Color{
+	"BLOCK":     tcell.ColorRed,
+	"CAPTCHA":   tcell.ColorYellow,
+	"CHALLENGE": tcell.ColorYellow,
+	"COUNT":     tcell.ColorYellow,
+	"ALLOW":     tcell.ColorGreen,
+}
+
+// loadWebACLs lists the regional Web ACLs, and in us-east-1 also those of
+// CloudFront, with their rules and the resources they protect. Web ACLs whose
+// rules or resources could not be read are returned as a PartialError.
+func (rt *ResourcesTab) loadWebACLs(ctx context.Context, client *aws.Client) ([]Resource, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.WAF == nil {
+		return nil, fmt.Errorf("WAF service not initialized")
+	}
+
+	region := client.GetRegion()
+	scopes := []string{clients.WAFScopeRegional}
+	if region == "us-east-1" {
+		scopes = append(scopes, clients.WAFScopeCloudFront)
+	}
+
+	var resources []Resource
+	var failures []clients.ItemError
+	for i, scope := range scopes {
+		acls, err := svc.WAF.ListWebACLs(ctx, scope)
+		if err != nil {
+			// The regional listing decides whether WAF can be read at all
+			if i == 0 {
+				return nil, err
+			}
+			failures = append(failures, clients.ItemError{Item: strings.ToLower(scope) + " web ACLs", Err: err})
+			continue
+		}
+
+		for _, acl := range acls {
+			detail, err := svc.WAF.GetWebACL(ctx, acl)
+			if err != nil {
+				failures = append(failures, clients.ItemError{Item: acl.Name, Region: region, Err: err})
+				detail = clients.WebACLDetail{WebACLSummary: acl}
+			}
+
+			var protected []string
+			if scope == clients.WAFScopeRegional {
+				protected, err = svc.WAF.ListResourcesForWebACL(ctx, acl.ARN)
+				if err != nil {
+					failures = append(failures, clients.ItemError{Item: acl.Name, Region: region, Err: err})
+				}
+			}
+			resources = append(resources, webACLResource(detail, protected, region))
+		}
+	}
+
+	if len(failures) > 0 {
+		return resources, &clients.PartialError{Op: "waf", Failures: failures}
+	}
+	return resources, nil
+}
+
+// webACLResource describes acl with its rules and the resources it protects.
+// A regional Web ACL that protects nothing is shown as unused.
+func webACLResource(acl clients.WebACLDetail, protected []string, region string) Resource {
+	rules := make([]string, 0, len(acl.Rules))
+	for _, rule := range acl.Rules {
+		rules = append(rules, fmt.Sprintf("%d %s (%s)", rule.Priority, rule.Name, strings.ToLower(rule.Action)))
+	}
+
+	state := "active"
+	associated := strings.Join(protected, ", ")
+	switch {
+	case acl.Scope == clients.WAFScopeCloudFront:
+		associated = "CloudFront distributions (not listed)"
+		region = "global"
+	case len(protected) == 0:
+		state = "unused"
+		associated = "none"
+	}
+
+	return Resource{
+		ID:     acl.Name,
+		Name:   acl.Name,
+		Type:   "WAF Web ACL",
+		State:  state,
+		Region: region,
+		Tags:   make(map[string]string),
+		Details: map[string]interface{}{
+			"ARN":            acl.ARN,
+			"Scope":          acl.Scope,
+			"Description":    acl.Description,
+			"Default Action": acl.DefaultAction,
+			"Capacity":       fmt.Sprintf("%d WCU", acl.Capacity),
+			"Rules":          strings.Join(rules, "; "),
+			"Protects":       associated,
+			"View":           "press Enter to view rules and sampled requests",
+		},
+	}
+}
+
+// wafSummary counts the Web ACLs, their rules and the unused ones
+func wafSummary(resources []Resource, failed int) (string, string) {
+	var unused int
+	for _, res := range resources {
+		if res.State == "unused" {
+			unused++
+		}
+	}
+
+	message := fmt.Sprintf("%d web ACLs", len(resources))
+	if unused > 0 {
+		message += fmt.Sprintf(", %d protect nothing", unused)
+	}
+	switch {
+	case failed > 0:
+		return message, "yellow"
+	default:
+		return message, "green"
+	}
+}
+
+// showWebACL shows the rules of the Web ACL res over the tab. Selecting a
+// rule, or the default action, loads the requests it sampled in the last
+// wafSampleWindow. The view closes with q.
+func (rt *ResourcesTab) showWebACL(res Resource) {
+	if rt.awsClient == nil {
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+
+	client := rt.awsClient
+	acl := clients.WebACLSummary{
+		Name:  res.Name,
+		ARN:   fmt.Sprint(res.Details["ARN"]),
+		Scope: fmt.Sprint(res.Details["Scope"]),
+	}
+	// Only the latest load is shown when another rule is selected while loading
+	loads := 0
+
+	rules := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	rules.SetBorder(true).SetTitleAlign(tview.AlignLeft).
+		SetTitle(panelTitle("waf.title", res.Name))
+
+	samples := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack))
+	samples.SetBorder(true).SetTitleAlign(tview.AlignLeft).SetTitle(panelTitle("waf.samples_title"))
+	setTableMessage(samples, i18n.T("waf.select_rule"), tcell.ColorGray)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(rules, 0, 1, true).
+		AddItem(samples, 0, 2, false)
+
+	// metricNames holds the metric name of each rule row
+	var metricNames []string
+	loadSamples := func(row int) {
+		if row <= 0 || row > len(metricNames) {
+			return
+		}
+		name := rules.GetCell(row, 1).Text
+		metricName := metricNames[row-1]
+		samples.SetTitle(panelTitle("waf.samples_loading_title", name, formatRange(wafSampleWindow)))
+		setTableMessage(samples, i18n.T("common.loading"), tcell.ColorGray)
+
+		loads++
+		gen := loads
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			end := time.Now()
+			requests, population, err := fetchSampledRequests(ctx, client, acl, metricName, end.Add(-wafSampleWindow), end)
+			if err != nil {
+				logger.Error("Failed to get sampled requests", zap.String("webACL", acl.Name), zap.String("rule", name), zap.Error(err))
+			}
+			if rt.app != nil {
+				rt.app.QueueUpdateDraw(func() {
+					if gen != loads {
+						return
+					}
+					if err != nil {
+						samples.SetTitle(panelTitle("waf.samples_rule_title", name))
+						setTableMessage(samples, i18n.T("waf.samples_failed", err.Error()), tcell.ColorRed)
+						return
+					}
+					samples.SetTitle(panelTitle("waf.samples_count_title", name, formatRange(wafSampleWindow), len(requests), population))
+					fillSampledRequests(samples, requests)
+				})
+			}
+		}()
+	}
+
+	rules.SetSelectedFunc(func(row, column int) {
+		loadSamples(row)
+	})
+
+	switchFocus := func() {
+		if rt.app == nil {
+			return
+		}
+		if rules.HasFocus() {
+			rt.app.SetFocus(samples)
+		} else {
+			rt.app.SetFocus(rules)
+		}
+	}
+	capture := func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyTab:
+			switchFocus()
+			return nil
+		case event.Rune() == 'q':
+			rt.closeWebACL()
+			return nil
+		}
+		return event
+	}
+	rules.SetInputCapture(capture)
+	samples.SetInputCapture(capture)
+
+	rt.view.AddPage("waf", layout, true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(rules)
+	}
+
+	setTableMessage(rules, i18n.T("common.loading"), tcell.ColorGray)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		svc := client.GetClients()
+		var detail clients.WebACLDetail
+		err := fmt.Errorf("WAF service not initialized")
+		if svc != nil && svc.WAF != nil {
+			acl.ID = webACLID(acl.ARN)
+			detail, err = svc.WAF.GetWebACL(ctx, acl)
+		}
+		if err != nil {
+			logger.Error("Failed to get web ACL", zap.String("webACL", acl.Name), zap.Error(err))
+		}
+		if rt.app != nil {
+			rt.app.QueueUpdateDraw(func() {
+				if err != nil {
+					setTableMessage(rules, i18n.T("waf.acl_failed", err.Error()), tcell.ColorRed)
+					return
+				}
+				metricNames = fillWebACLRules(rules, detail)
+			})
+		}
+	}()
+}
+
+// webACLID returns the ID at the end of the ARN of a Web ACL
+func webACLID(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+// fetchSampledRequests returns the requests the rule with metricName of acl
+// sampled between start and end, and how many requests were inspected
+func fetchSampledRequests(ctx context.Context, client *aws.Client, acl clients.WebACLSummary, metricName string, start, end time.Time) ([]clients.SampledRequest, int64, error) {
+	svc := client.GetClients()
+	if svc == nil || svc.WAF == nil {
+		return nil, 0, fmt.Errorf("WAF service not initialized")
+	}
+	return svc.WAF.GetSampledRequests(ctx, acl, metricName, start, end)
+}
+
+// fillWebACLRules lists the rules of acl in priority order followed by its
+// default action, and returns the metric name of each row
+func fillWebACLRules(table *tview.Table, acl clients.WebACLDetail) []string {
+	table.Clear()
+	for col, name := range []string{"Priority", "Rule", "Action", "Inspects"} {
+		table.SetCell(0, col, tview.NewTableCell(name).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	rules := append([]clients.WAFRule(nil), acl.Rules...)
+	rules = append(rules, clients.WAFRule{
+		Name:       "Default action",
+		Priority:   -1,
+		Action:     acl.DefaultAction,
+		Statement:  "requests no rule terminated",
+		MetricName: acl.MetricName,
+	})
+
+	metricNames := make([]string, 0, len(rules))
+	for i, rule := range rules {
+		priority := fmt.Sprint(rule.Priority)
+		if rule.Priority < 0 {
+			priority = "-"
+		}
+		color, ok := wafActionColors[rule.Action]
+		if !ok {
+			color = tcell.ColorWhite
+		}
+		table.SetCell(i+1, 0, tview.NewTableCell(priority))
+		table.SetCell(i+1, 1, tview.NewTableCell(rule.Name).SetMaxWidth(48))
+		table.SetCell(i+1, 2, tview.NewTableCell(rule.Action).SetTextColor(color))
+		table.SetCell(i+1, 3, tview.NewTableCell(rule.Statement).SetExpansion(1))
+		metricNames = append(metricNames, rule.MetricName)
+	}
+	table.Select(1, 0).ScrollToBeginning()
+	return metricNames
+}
+
+// fillSampledRequests lists requests with their action colored
+func fillSampledRequests(table *tview.Table, requests []clients.SampledRequest) {
+	if len(requests) == 0 {
+		setTableMessage(table, i18n.T("waf.no_requests"), tcell.ColorGray)
+		return
+	}
+
+	table.Clear()
+	for col, name := range []string{"Time", "Action", "Client IP", "Country", "Method", "URI", "Matched Rule"} {
+		table.SetCell(0, col, tview.NewTableCell(name).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, request := range requests {
+		color, ok := wafActionColors[request.Action]
+		if !ok {
+			color = tcell.ColorWhite
+		}
+		table.SetCell(i+1, 0, tview.NewTableCell(zonedTime(request.Time, "15:04:05")))
+		table.SetCell(i+1, 1, tview.NewTableCell(request.Action).SetTextColor(color))
+		table.SetCell(i+1, 2, tview.NewTableCell(request.ClientIP))
+		table.SetCell(i+1, 3, tview.NewTableCell(request.Country))
+		table.SetCell(i+1, 4, tview.NewTableCell(request.Method))
+		table.SetCell(i+1, 5, tview.NewTableCell(request.URI).SetMaxWidth(60))
+		table.SetCell(i+1, 6, tview.NewTableCell(request.Rule).SetExpansion(1))
+	}
+	table.Select(1, 0).ScrollToBeginning()
+}
+
+// setTableMessage replaces the contents of table with message
+func setTableMessage(table *tview.Table, message string, color tcell.Color) {
+	table.Clear()
+	table.SetCell(0, 0, tview.NewTableCell(message).
+		SetTextColor(color).
+		SetSelectable(false).
+		SetExpansion(1))
+}
+
+// closeWebACL removes the Web ACL view and returns focus to the table
+func (rt *ResourcesTab) closeWebACL() {
+	rt.view.RemovePage("waf")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
+}
@@ -2,17 +2,26 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/cache"
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/internal/history"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/jobs"
+	"swiss-army-tui/internal/schedule"
+	"swiss-army-tui/internal/snapshot"
 	"swiss-army-tui/pkg/logger"
 
-	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"go.uber.org/zap"
@@ -21,10 +30,12 @@ import (
 // ResourcesTab represents the AWS resources tab
 type ResourcesTab struct {
 	// Core components
-	view      *tview.Flex
+	view      *tview.Pages
 	app       *tview.Application
 	awsClient *aws.Client
-	eventChan chan<- Event
+	events    *EventBus
+	config    *config.Config
+	audit     *auditTrail
 
 	// UI components
 	serviceList   *tview.List
@@ -32,14 +43,96 @@ type ResourcesTab struct {
 	resourceInfo  *tview.TextView
 	statusText    *tview.TextView
 	filterInput   *tview.InputField
+	warningsText  *tview.TextView
+	centerPanel   *tview.Flex
+	leftPanel     *tview.Flex
+	mainLayout    *tview.Flex
+	// layout is the layout of the panels for the size of the tab
+	layout tabLayout
+	width  int
+	height int
 
 	// State
+	services        []ServiceInfo
 	selectedService string
-	resources       map[string][]Resource
+	cache           *cache.Cache[cachedListing]
+	fetchedAt       time.Time
 	filteredRes     []Resource
+	visibleRes      []Resource
 	selectedRes     *Resource
-	mu              sync.RWMutex
-	loading         bool
+	shownService    string
+	// morePages is set while the listing shown is the first pages of one
+	// still loading
+	morePages bool
+	changedAt map[string]time.Time
+	// detailsLoaded holds the IDs of the resources of the shown listing
+	// whose details loaded, for services listing summaries only
+	detailsLoaded map[string]bool
+	permissions   map[string]permission
+	detailCancel  context.CancelFunc
+	mu            sync.RWMutex
+	loading       bool
+
+	// Per-item failures of the shown listing, e.g. buckets whose region lookup failed
+	warnings    []clients.ItemError
+	warningNoun string
+
+	// The load of the shown service is cancelled on navigation and profile
+	// changes; results of an older loadGen are discarded
+	loadCancel context.CancelFunc
+	loadGen    uint64
+
+	// Prefetching of recently used services
+	recentServices []string
+	prefetchCount  int
+	prefetching    map[string]bool
+	prefetchCtx    context.Context
+	prefetchCancel context.CancelFunc
+
+	// Instances followed after a start or stop until they reach their target state
+	waitCtx    context.Context
+	waitCancel context.CancelFunc
+
+	// Background jobs such as S3 object copies, the open object browser and
+	// jobs view, nil while closed
+	jobs      *jobs.Tracker
+	objects   *objectBrowser
+	jobsTable *tview.Table
+	// The open CloudFormation stack browser, nil while closed
+	stackBrowser *stackBrowser
+	// Actions queued for later and the clients they run with, nil if
+	// scheduling is not available
+	schedules      *schedule.Store
+	scheduleClient scheduleClientFunc
+	// overlay tells the app an overlay of the tab opened, with the func
+	// closing it, or closed, with nil
+	overlay func(closeOverlay func())
+
+	// Search index over all cached listings, nil if it could not be created,
+	// and the resource to select once its service is shown
+	index *resourceIndex
+	jump  resourceJump
+	// Index of the shown listing for filters in the query syntax, built on
+	// the first such filter
+	filterIndex *resourceIndex
+	// Filters typed earlier, recalled with up and down
+	filterHistory *inputHistory
+	// How the EC2 listing is grouped ("type", "az", "ami", "tag:<key>" or
+	// "" for not at all), the tag key used last and the expanded groups.
+	// tableRows maps the rows of a grouped table to groups and resources,
+	// nil while the table is not grouped.
+	groupBy    string
+	groupTag   string
+	expanded   map[string]bool
+	tableRows  []resourceRow
+	groupCount int
+	// Resource marked for comparison with the next one marked, and the
+	// service it was listed in, nil if none is marked
+	marked        *Resource
+	markedService string
+	// Listings of the snapshot browsed in offline mode by cache key, nil
+	// unless offline; nothing is loaded from AWS then
+	offline map[string]snapshot.Listing
 }
 
 // Resource represents an AWS resource
@@ -52,6 +145,12 @@ type Resource struct {
 	CreatedDate string
 	Tags        map[string]string
 	Details     map[string]interface{}
+
+	// Estimated monthly on-demand cost in USD, 0 if unknown or not running
+	MonthlyCost float64
+
+	// Alert shows the whole row in red, e.g. for a certificate about to expire
+	Alert bool
 }
 
 // ServiceInfo represents information about an AWS service
@@ -59,27 +158,108 @@ type ServiceInfo struct {
 	Name        string
 	DisplayName string
 	Icon        string
-	Enabled     bool
+	// Label stands in for Icon in the accessibility mode
+	Label   string
+	Enabled bool
+
+	// IAM action needed to list the resources, shown when a load is denied
+	Permission string
+}
+
+// serviceViews are the services of the Resources tab in their default order.
+// A new service is a module with its view, added here.
+var serviceViews = []ServiceView{
+	ec2Service,
+	s3Service,
+	rdsService,
+	lambdaService,
+	ecsService,
+	vpcService,
+	insightsService,
+	spotService,
+	reservationsService,
+	dashboardsService,
+	sesService,
+	certificatesService,
+	wafService,
+	trustedAdvisorService,
+	healthService,
+	dynamoDBService,
+	snsService,
+	eksService,
+	rdsParamsService,
+	natGatewaysService,
+	iamService,
+	s3ExposureService,
+	cloudFormationService,
+	stackSetsService,
+	transferService,
+	dataSyncService,
+	batchService,
+	sageMakerService,
+	bedrockService,
+	appConfigService,
+	s3UploadsService,
+	cleanupService,
+	alarmsService,
+}
+
+// supportedServices are the services of serviceViews
+var supportedServices = serviceInfos(serviceViews)
+
+// serviceInfo returns the supported service named name
+func serviceInfo(name string) (ServiceInfo, bool) {
+	for _, service := range supportedServices {
+		if service.Name == name {
+			return service, true
+		}
+	}
+	return ServiceInfo{}, false
 }
 
-var supportedServices = []ServiceInfo{
-	{Name: "ec2", DisplayName: "EC2 Instances", Icon: "🤖", Enabled: true},
-	{Name: "s3", DisplayName: "S3 Buckets", Icon: "🪣", Enabled: true},
-	{Name: "rds", DisplayName: "RDS Databases", Icon: "📚", Enabled: true},
-	{Name: "lambda", DisplayName: "Lambda Functions", Icon: "⚡", Enabled: true},
-	{Name: "ecs", DisplayName: "ECS Services", Icon: "🐳", Enabled: true},
-	{Name: "vpc", DisplayName: "VPC Networks", Icon: "🌐", Enabled: true},
-	{Name: "iam", DisplayName: "IAM Resources", Icon: "🔐", Enabled: false},
-	{Name: "cloudformation", DisplayName: "CloudFormation", Icon: "📚", Enabled: false},
+// orderServices returns the services named in names in that order. An empty
+// list keeps all supported services in their default order.
+func orderServices(names []string) []ServiceInfo {
+	if len(names) == 0 {
+		return supportedServices
+	}
+
+	var services []ServiceInfo
+	seen := make(map[string]bool)
+	for _, name := range names {
+		for _, service := range supportedServices {
+			if service.Name == name && !seen[name] {
+				services = append(services, service)
+				seen[name] = true
+			}
+		}
+	}
+	return services
 }
 
 // NewResourcesTab creates a new resources tab
-func NewResourcesTab(app *tview.Application, eventChan chan<- Event) (*ResourcesTab, error) {
+func NewResourcesTab(app *tview.Application, events *EventBus) (*ResourcesTab, error) {
 	tab := &ResourcesTab{
-		app:       app,
-		eventChan: eventChan,
-		resources: make(map[string][]Resource),
+		app:      app,
+		events:   events,
+		services: supportedServices,
+		cache:    cache.New[cachedListing](60 * time.Second),
+
+		detailsLoaded: make(map[string]bool),
+		permissions:   make(map[string]permission),
+		changedAt:     make(map[string]time.Time),
+		prefetchCount: 3,
+		prefetching:   make(map[string]bool),
+		filterHistory: newInputHistory("resources"),
+		expanded:      make(map[string]bool),
 	}
+	tab.jobs = jobs.NewTracker(tab.onJobChanged)
+
+	index, err := newResourceIndex()
+	if err != nil {
+		logger.Warn("Failed to initialize resource search index", zap.Error(err))
+	}
+	tab.index = index
 
 	if err := tab.initializeUI(); err != nil {
 		return nil, fmt.Errorf("failed to initialize resources tab UI: %w", err)
@@ -98,7 +278,7 @@ func (rt *ResourcesTab) initializeUI() error {
 		SetSelectedBackgroundColor(tcell.ColorWhite).
 		ShowSecondaryText(true)
 
-	rt.serviceList.SetBorder(true).SetTitle(" AWS Services ").SetTitleAlign(tview.AlignLeft)
+	rt.serviceList.SetBorder(true).SetTitle(panelTitle("resources.services")).SetTitleAlign(tview.AlignLeft)
 
 	// Set up service list handlers
 	rt.serviceList.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
@@ -112,12 +292,11 @@ func (rt *ResourcesTab) initializeUI() error {
 
 	// Add key bindings for service list
 	rt.serviceList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Rune() {
-		case 'r':
-			rt.Refresh()
+		if event.Rune() == ':' {
+			rt.showActionPalette()
 			return nil
-		case 'f':
-			rt.focusFilter()
+		}
+		if rt.handleActionKey(event, true) {
 			return nil
 		}
 		return event
@@ -129,7 +308,23 @@ func (rt *ResourcesTab) initializeUI() error {
 		SetFieldWidth(0).
 		SetChangedFunc(rt.onFilterChanged)
 
-	rt.filterInput.SetBorder(true).SetTitle(" Filter Resources ").SetTitleAlign(tview.AlignLeft)
+	rt.filterInput.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			rt.blurFilter(rt.serviceList)
+			return nil
+		case tcell.KeyEnter:
+			rt.filterHistory.Commit(rt.filterInput.GetText())
+			rt.blurFilter(rt.resourceTable)
+			return nil
+		}
+		if rt.filterHistory.HandleKey(rt.filterInput, event) {
+			return nil
+		}
+		return event
+	})
+
+	rt.filterInput.SetBorder(true).SetTitle(panelTitle("resources.filter")).SetTitleAlign(tview.AlignLeft)
 
 	// Create resource table
 	if rt.resourceTable != nil {
@@ -140,7 +335,7 @@ func (rt *ResourcesTab) initializeUI() error {
 		SetSelectable(true, false).
 		SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack).Attributes(tcell.AttrBold))
 
-	rt.resourceTable.SetBorder(true).SetTitle(" Resources ").SetTitleAlign(tview.AlignLeft)
+	rt.resourceTable.SetBorder(true).SetTitle(panelTitle("resources.table")).SetTitleAlign(tview.AlignLeft)
 
 	// Set up resource table handlers
 	rt.resourceTable.SetSelectedFunc(rt.onResourceSelected)
@@ -149,20 +344,14 @@ func (rt *ResourcesTab) initializeUI() error {
 	// Add key bindings for resource table
 	rt.resourceTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Rune() {
-		case 'r':
-			rt.Refresh()
-			return nil
-		case 'f':
-			rt.focusFilter()
-			return nil
-		case 'l':
-			rt.onLambdaLogsKey()
+		case ':':
+			rt.showActionPalette()
 			return nil
-		case 's':
-			rt.onEC2StartInstance()
+		case 'a':
+			rt.showActionMenu()
 			return nil
-		case 'p':
-			rt.onEC2StopInstance()
+		}
+		if rt.handleActionKey(event, false) {
 			return nil
 		}
 		logger.Info("Service list key pressed", zap.String("key", event.Name()))
@@ -176,32 +365,43 @@ func (rt *ResourcesTab) initializeUI() error {
 		SetWrap(true).
 		SetScrollable(true)
 
-	rt.resourceInfo.SetBorder(true).SetTitle(" Resource Details ").SetTitleAlign(tview.AlignLeft)
+	rt.resourceInfo.SetBorder(true).SetTitle(panelTitle("resources.details")).SetTitleAlign(tview.AlignLeft)
 
 	// Create status text
 	rt.statusText = tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter)
 
-	rt.statusText.SetBorder(true).SetTitle(" Status ").SetTitleAlign(tview.AlignLeft)
-	rt.updateStatus("No AWS client configured", "yellow")
+	rt.statusText.SetBorder(true).SetTitle(panelTitle("resources.status")).SetTitleAlign(tview.AlignLeft)
+	rt.updateStatus(i18n.T("resources.no_client"), "yellow")
 
 	// Load services into list
 	rt.loadServices()
 
 	// Create layout
-	leftPanel := tview.NewFlex().SetDirection(tview.FlexRow).
+	rt.leftPanel = tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(rt.serviceList, 0, 2, true).
 		AddItem(rt.filterInput, 3, 0, false).
 		AddItem(rt.statusText, 5, 0, false)
 
-	centerPanel := tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(rt.resourceTable, 0, 1, false)
+	// Warnings panel, collapsed until a listing has partial failures
+	rt.warningsText = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false).
+		SetScrollable(true)
+
+	rt.warningsText.SetBorder(true).SetTitle(panelTitle("resources.warnings")).SetTitleAlign(tview.AlignLeft)
+
+	rt.centerPanel = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(rt.resourceTable, 0, 1, false).
+		AddItem(rt.warningsText, 0, 0, false)
 
-	rt.view = tview.NewFlex().SetDirection(tview.FlexColumn).
-		AddItem(leftPanel, 30, 0, true).
-		AddItem(centerPanel, 0, 2, false).
-		AddItem(rt.resourceInfo, 40, 0, false)
+	// The panels are laid out for the width of the tab when drawn
+	rt.mainLayout = tview.NewFlex()
+	rt.mainLayout.SetDrawFunc(rt.fitLayout)
+	rt.applyLayout(layoutWide)
+
+	rt.view = tview.NewPages().AddPage("main", rt.mainLayout, true, true)
 
 	return nil
 }
@@ -210,14 +410,14 @@ func (rt *ResourcesTab) initializeUI() error {
 func (rt *ResourcesTab) loadServices() {
 	rt.serviceList.Clear()
 
-	for i, service := range supportedServices {
-		mainText := fmt.Sprintf("%s %s", service.Icon, service.DisplayName)
+	for i, service := range rt.services {
+		mainText := fmt.Sprintf("%s %s", serviceIcon(service), service.DisplayName)
 		// secondaryText := service.Name
 		secondaryText := ""
 
 		if !service.Enabled {
-			mainText = fmt.Sprintf("[gray]%s (Coming Soon)[-]", mainText)
-			secondaryText = "Not implemented yet"
+			mainText = fmt.Sprintf("[gray]%s (%s)[-]", mainText, i18n.T("resources.coming_soon"))
+			secondaryText = i18n.T("resources.not_implemented")
 		}
 
 		rt.serviceList.AddItem(mainText, secondaryText, rune('0'+i%10), func() {
@@ -230,9 +430,10 @@ func (rt *ResourcesTab) loadServices() {
 
 // onServiceSelected handles service selection
 func (rt *ResourcesTab) onServiceSelected(index int, mainText, secondaryText string, shortcut rune) {
-	if index >= 0 && index < len(supportedServices) {
-		service := supportedServices[index]
+	if index >= 0 && index < len(rt.services) {
+		service := rt.services[index]
 		if service.Enabled {
+			rt.jump = resourceJump{}
 			rt.selectService(service.Name)
 		}
 	}
@@ -240,371 +441,627 @@ func (rt *ResourcesTab) onServiceSelected(index int, mainText, secondaryText str
 
 // onServiceHighlighted handles service highlighting
 func (rt *ResourcesTab) onServiceHighlighted(index int, mainText, secondaryText string, shortcut rune) {
-	if index >= 0 && index < len(supportedServices) {
-		service := supportedServices[index]
-		rt.updateResourceInfo(fmt.Sprintf("Service: %s\n\nSelect this service to view resources.", service.DisplayName))
+	if index >= 0 && index < len(rt.services) {
+		service := rt.services[index]
+		rt.updateResourceInfo(i18n.T("resources.service_info", service.DisplayName))
 	}
 }
 
-// selectService selects a service and loads its resources
+// selectService selects a service and shows its resources, from the cache if possible
 func (rt *ResourcesTab) selectService(serviceName string) {
+	rt.loadService(serviceName, false)
+}
+
+// loadService shows the resources of a service. Fresh cached listings are shown
+// as is, stale ones are shown while they are reloaded in the background. force
+// bypasses the cache.
+func (rt *ResourcesTab) loadService(serviceName string, force bool) {
 	if rt.awsClient == nil {
-		rt.updateStatus("No AWS client configured", "yellow")
+		rt.updateStatus(i18n.T("resources.no_client"), "yellow")
+		return
+	}
+	if rt.isOffline() {
+		rt.showOfflineListing(serviceName)
 		return
 	}
 
+	key := rt.cacheKey(serviceName)
+	client := rt.awsClient
+
 	rt.mu.Lock()
 	rt.selectedService = serviceName
+	rt.noteRecentService(serviceName)
+	ctx, gen := rt.beginLoad()
+	rt.mu.Unlock()
+
+	view := serviceViewOf(serviceName)
+	rt.setWarnings(view.Noun(), nil)
+	rt.morePages = false
+
+	logger.Info("Selecting service", zap.String("service", serviceName), zap.Bool("force", force))
+
+	if !force {
+		if listing, fetchedAt, fresh, ok := rt.cache.Get(key); ok {
+			resources := listing.resources
+			rt.fetchedAt = fetchedAt
+			rt.updateResourceTable(resources)
+			rt.setWarnings(view.Noun(), listing.failures)
+			view.Shown(ctx, rt, resources)
+			if fresh {
+				rt.showListingStatus(view, serviceName, resources, len(listing.failures), true)
+				return
+			}
+			rt.updateStatus(i18n.T("resources.cached_refreshing"), "yellow")
+		} else {
+			rt.updateStatus(i18n.T("resources.loading"), "yellow")
+		}
+	} else {
+		rt.updateStatus(i18n.T("resources.loading"), "yellow")
+	}
+
+	rt.mu.Lock()
 	rt.loading = true
 	rt.mu.Unlock()
 
-	logger.Info("Selecting service", zap.String("service", serviceName))
-	rt.updateStatus("Loading resources...", "yellow")
+	// Pages are only shown as they load while no listing of the service is
+	// shown, so a refresh does not shrink the listing for a moment
+	stream := rt.shownService != serviceName
+	go rt.loadResourcesAsync(ctx, gen, client, serviceName, key, stream)
+}
+
+// cachedListing is a listing of a service kept in the cache, with the items
+// that failed to load in a partial listing
+type cachedListing struct {
+	resources []Resource
+	failures  []clients.ItemError
+}
 
-	go rt.loadResourcesAsync(serviceName)
+// beginLoad cancels the running load and returns the context and generation
+// of a new one. The caller must hold rt.mu.
+func (rt *ResourcesTab) beginLoad() (context.Context, uint64) {
+	if rt.loadCancel != nil {
+		rt.loadCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	rt.loadCancel = cancel
+	rt.loadGen++
+	rt.loading = false
+	return ctx, rt.loadGen
 }
 
-// loadResourcesAsync loads resources for a service asynchronously
-func (rt *ResourcesTab) loadResourcesAsync(serviceName string) {
+// cancelLoads stops all in-flight loads, e.g. when the profile changes. The
+// caller must hold rt.mu.
+func (rt *ResourcesTab) cancelLoads() {
+	if rt.loadCancel != nil {
+		rt.loadCancel()
+		rt.loadCancel = nil
+	}
+	if rt.prefetchCancel != nil {
+		rt.prefetchCancel()
+		rt.prefetchCtx, rt.prefetchCancel = nil, nil
+	}
+	if rt.detailCancel != nil {
+		rt.detailCancel()
+		rt.detailCancel = nil
+	}
+	if rt.waitCancel != nil {
+		rt.waitCancel()
+		rt.waitCtx, rt.waitCancel = nil, nil
+	}
+	rt.loadGen++
+	rt.loading = false
+}
+
+// cacheKey identifies the listing of a service for the current profile and region
+func (rt *ResourcesTab) cacheKey(serviceName string) string {
+	return fmt.Sprintf("%s|%s|%s", rt.awsClient.GetProfile(), rt.awsClient.GetRegion(), serviceName)
+}
+
+// loadResourcesAsync loads resources for a service asynchronously and caches
+// them under key. Results are only shown while gen is the current load;
+// stream shows the pages of services listed a page at a time as they load.
+func (rt *ResourcesTab) loadResourcesAsync(ctx context.Context, gen uint64, client *aws.Client, serviceName, key string, stream bool) {
 	defer func() {
 		rt.mu.Lock()
-		rt.loading = false
+		if rt.loadGen == gen {
+			rt.loading = false
+		}
 		rt.mu.Unlock()
 	}()
 
-	var resources []Resource
-	var err error
-
-	switch serviceName {
-	case "ec2":
-		resources, err = rt.loadEC2Instances()
-	case "s3":
-		resources, err = rt.loadS3Buckets()
-	case "rds":
-		resources, err = rt.loadRDSInstances()
-	case "lambda":
-		resources, err = rt.loadLambdaFunctions()
-	case "ecs":
-		resources, err = rt.loadECSServices()
-	case "vpc":
-		resources, err = rt.loadVPCs()
-	default:
-		err = fmt.Errorf("service %s not implemented", serviceName)
+	var onPage func([]Resource)
+	if stream && rt.app != nil {
+		onPage = func(loaded []Resource) {
+			// The loader keeps appending to loaded
+			shown := append([]Resource(nil), loaded...)
+			rt.app.QueueUpdateDraw(func() {
+				if !rt.isCurrentLoad(gen) {
+					return
+				}
+				rt.morePages = true
+				rt.updateResourceTable(shown)
+				rt.updateStatus(i18n.T("resources.loaded_more", len(shown), serviceName), "yellow")
+			})
+		}
+	}
+
+	resources, err := rt.fetchResources(ctx, client, serviceName, onPage)
+
+	// Partial failures still show what succeeded, with the failures as warnings
+	var failures []clients.ItemError
+	var partial *clients.PartialError
+	if errors.As(err, &partial) && resources != nil {
+		failures = partial.Failures
+		err = nil
 	}
 
 	if err != nil {
+		if ctx.Err() != nil {
+			logger.Debug("Resource load cancelled", zap.String("service", serviceName))
+			return
+		}
 		logger.Error("Failed to load resources", zap.String("service", serviceName), zap.Error(err))
 		if rt.app != nil {
 			rt.app.QueueUpdateDraw(func() {
-				rt.updateStatus(fmt.Sprintf("Error loading %s: %s", serviceName, err.Error()), "red")
+				if rt.isCurrentLoad(gen) {
+					rt.morePages = false
+					rt.showLoadError(serviceName, err)
+				}
 			})
 		}
 		return
 	}
 
-	rt.mu.Lock()
-	rt.resources[serviceName] = resources
-	rt.mu.Unlock()
+	// The key names the client's profile and region, so the listing stays valid
+	rt.cache.Set(key, cachedListing{resources: resources, failures: failures})
+	rt.indexListing(client, serviceName, resources)
 
 	if rt.app != nil {
 		rt.app.QueueUpdateDraw(func() {
+			// The user may have moved on to another service or profile while this one loaded
+			if !rt.isCurrentLoad(gen) {
+				return
+			}
+
+			rt.fetchedAt = time.Now()
+			view := serviceViewOf(serviceName)
+			if rt.morePages {
+				rt.keepDetails(resources)
+			} else {
+				// Details are loaded again for the new listing
+				rt.detailsLoaded = make(map[string]bool)
+			}
+			rt.morePages = false
 			rt.updateResourceTable(resources)
-			rt.updateStatus(fmt.Sprintf("Loaded %d %s resources", len(resources), serviceName), "green")
+			rt.setWarnings(view.Noun(), failures)
+			view.Shown(ctx, rt, resources)
+			rt.showListingStatus(view, serviceName, resources, len(failures), false)
+			rt.Prefetch()
 		})
 	}
 
 	logger.Info("Loaded resources", zap.String("service", serviceName), zap.Int("count", len(resources)))
 }
 
-// loadEC2Instances loads EC2 instances
-func (rt *ResourcesTab) loadEC2Instances() ([]Resource, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+// showListingStatus shows the summary of a listing by its view in the status,
+// or the count of its resources for views without one. cached listings come
+// from the cache rather than AWS.
+func (rt *ResourcesTab) showListingStatus(view ServiceView, serviceName string, resources []Resource, failed int, cached bool) {
+	message, color := view.Summary(resources, failed)
+	switch {
+	case message != "":
+		rt.updateStatus(message, color)
+	case failed > 0:
+		rt.updateStatus(i18n.T("resources.loaded_failed", len(resources), serviceName, failed), "yellow")
+	case cached:
+		rt.updateStatus(i18n.T("resources.loaded_cached", len(resources), serviceName), "green")
+	default:
+		rt.updateStatus(i18n.T("resources.loaded", len(resources), serviceName), "green")
+	}
+}
 
-	instances, err := rt.awsClient.GetEC2FunctionDetails(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to describe instances: %w", err)
+// showLoadError explains a failed load in the table area: the cause, the IAM
+// permission the listing needs and how to retry. A listing of the service that
+// is already shown stays, with the error in the status only.
+func (rt *ResourcesTab) showLoadError(serviceName string, err error) {
+	rt.updateStatus(i18n.T("resources.load_error", serviceName, err.Error()), "red")
+	if rt.shownService == serviceName && len(rt.filteredRes) > 0 {
+		return
+	}
+
+	rt.shownService = serviceName
+	rt.filteredRes = nil
+	rt.visibleRes = nil
+	rt.tableRows = nil
+	rt.selectedRes = nil
+	rt.changedAt = make(map[string]time.Time)
+
+	service, ok := serviceInfo(serviceName)
+	if !ok {
+		service = ServiceInfo{Name: serviceName, DisplayName: serviceName}
 	}
 
-	var resources []Resource
+	rt.resourceTable.Clear()
+	row := 0
+	addRow := func(text string, color tcell.Color) {
+		rt.resourceTable.SetCell(row, 0,
+			tview.NewTableCell(text).
+				SetTextColor(color).
+				SetSelectable(false).
+				SetExpansion(1))
+		row++
+	}
 
-	for _, instance := range instances {
-		res := ec2InstanceToResource(instance, rt.awsClient.GetRegion())
-		resources = append(resources, res)
+	addRow(i18n.T("resources.could_not_load", service.DisplayName, clients.ErrorReason(err)), tcell.ColorRed)
+	addRow(err.Error(), tcell.ColorGray)
+	addRow("", tcell.ColorWhite)
+	if isSupportPlanError(err) {
+		addRow(clients.ErrSupportPlanRequired.Error(), tcell.ColorYellow)
+	} else if service.Permission != "" {
+		addRow(i18n.T("resources.required_permission", service.Permission), tcell.ColorYellow)
 	}
+	addRow(i18n.T("resources.press_retry"), tcell.ColorWhite)
 
-	return resources, nil
+	rt.resourceTable.SetTitle(panelTitle("resources.failed"))
+	rt.updateResourceInfo(i18n.T("resources.select_service"))
 }
 
-// loadS3Buckets loads S3 buckets
-func (rt *ResourcesTab) loadS3Buckets() ([]Resource, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+// isCurrentLoad reports whether gen is still the load of the shown service
+func (rt *ResourcesTab) isCurrentLoad(gen uint64) bool {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	return rt.loadGen == gen
+}
+
+// fetchResources lists the resources of a service from AWS using client.
+// Services listed a page at a time call onPage, if set, with the resources
+// loaded so far after every page.
+func (rt *ResourcesTab) fetchResources(ctx context.Context, client *aws.Client, serviceName string, onPage func([]Resource)) ([]Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	details, err := rt.awsClient.GetS3FunctionDetails(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list buckets: %w", err)
+	view := serviceViewOf(serviceName)
+	if paged, ok := view.(pagedView); ok && onPage != nil {
+		return paged.LoadPages(ctx, rt, client, onPage)
 	}
+	return view.Load(ctx, rt, client)
+}
 
-	var resources []Resource
-
-	for i, detail := range details {
-		region := detail.Region
-		if region == "" {
-			region = rt.awsClient.GetRegion()
+// noteRecentService moves serviceName to the front of the recently used services
+func (rt *ResourcesTab) noteRecentService(serviceName string) {
+	recent := []string{serviceName}
+	for _, name := range rt.recentServices {
+		if name != serviceName {
+			recent = append(recent, name)
 		}
+	}
+	rt.recentServices = recent
+}
 
-		resource := Resource{
-			ID:     strconv.Itoa(i),
-			Name:   detail.Name,
-			Type:   "S3 Bucket",
-			State:  "Available",
-			Region: region,
-			Tags:   make(map[string]string),
-		}
+// prefetchCandidates returns up to prefetchCount services to load ahead of
+// time: the most recently used ones first, then the rest in display order
+func (rt *ResourcesTab) prefetchCandidates() []string {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
 
-		if detail.CreationDate != nil {
-			resource.CreatedDate = detail.CreationDate.Format("2006-01-02 15:04:05")
+	enabled := make(map[string]bool)
+	var ordered []string
+	for _, service := range rt.services {
+		if service.Enabled {
+			enabled[service.Name] = true
+			ordered = append(ordered, service.Name)
 		}
+	}
 
-		resource.Details = map[string]interface{}{
-			"BucketName": detail.Name,
+	var candidates []string
+	seen := map[string]bool{rt.selectedService: true}
+	for _, name := range append(append([]string{}, rt.recentServices...), ordered...) {
+		if len(candidates) >= rt.prefetchCount {
+			break
+		}
+		if enabled[name] && !seen[name] {
+			candidates = append(candidates, name)
+			seen[name] = true
 		}
-
-		resources = append(resources, resource)
 	}
-
-	return resources, nil
+	return candidates
 }
 
-func ec2InstanceToResource(instance types.Instance, region string) Resource {
-	res := Resource{
-		Type:   "EC2 Instance",
-		State:  string(instance.State.Name),
-		Region: region,
-		Tags:   make(map[string]string),
+// Prefetch loads the listings of recently used services that are not freshly
+// cached in the background. Services are fetched one after another so
+// prefetching stays well within the shared API rate limit.
+func (rt *ResourcesTab) Prefetch() {
+	client := rt.awsClient
+	if client == nil || rt.isOffline() {
+		return
 	}
 
-	if instance.InstanceId != nil {
-		res.ID = *instance.InstanceId
+	var services, keys []string
+	candidates := rt.prefetchCandidates()
+	rt.mu.Lock()
+	if rt.prefetchCtx == nil {
+		rt.prefetchCtx, rt.prefetchCancel = context.WithCancel(context.Background())
+	}
+	ctx := rt.prefetchCtx
+	for _, name := range candidates {
+		key := rt.cacheKey(name)
+		if _, _, fresh, ok := rt.cache.Get(key); (ok && fresh) || rt.prefetching[key] {
+			continue
+		}
+		rt.prefetching[key] = true
+		services = append(services, name)
+		keys = append(keys, key)
 	}
+	rt.mu.Unlock()
 
-	if instance.LaunchTime != nil {
-		res.CreatedDate = instance.LaunchTime.Format("2006-01-02 15:04:05")
+	if len(services) == 0 {
+		return
 	}
 
-	for _, tag := range instance.Tags {
-		if tag.Key != nil && tag.Value != nil {
-			res.Tags[*tag.Key] = *tag.Value
-			if *tag.Key == "Name" {
-				res.Name = *tag.Value
+	go func() {
+		for i, name := range services {
+			// The context is cancelled when the profile or region changes
+			if ctx.Err() == nil {
+				resources, err := rt.fetchResources(ctx, client, name, nil)
+				var failures []clients.ItemError
+				var partial *clients.PartialError
+				if errors.As(err, &partial) && resources != nil {
+					failures, err = partial.Failures, nil
+				}
+				if err != nil {
+					logger.Debug("Failed to prefetch resources", zap.String("service", name), zap.Error(err))
+				} else {
+					rt.cache.Set(keys[i], cachedListing{resources: resources, failures: failures})
+					rt.indexListing(client, name, resources)
+					logger.Debug("Prefetched resources", zap.String("service", name), zap.Int("count", len(resources)))
+				}
 			}
+
+			rt.mu.Lock()
+			delete(rt.prefetching, keys[i])
+			rt.mu.Unlock()
 		}
-	}
+	}()
+}
 
-	if res.Name == "" {
-		res.Name = res.ID
-	}
+// stateChangeHighlight is how long rows stay highlighted after their state changed
+const stateChangeHighlight = 5 * time.Second
 
-	res.Details = map[string]interface{}{
-		"InstanceType":     string(instance.InstanceType),
-		"ImageId":          getStringValue(instance.ImageId),
-		"VpcId":            getStringValue(instance.VpcId),
-		"SubnetId":         getStringValue(instance.SubnetId),
-		"PublicIpAddress":  getStringValue(instance.PublicIpAddress),
-		"PrivateIpAddress": getStringValue(instance.PrivateIpAddress),
-		"KeyName":          getStringValue(instance.KeyName),
-		"SecurityGroups":   instance.SecurityGroups,
+// updateResourceTable updates the resource table with the given resources. On
+// a reload of the shown service, rows whose state changed are highlighted.
+func (rt *ResourcesTab) updateResourceTable(resources []Resource) {
+	rt.mu.RLock()
+	service := rt.selectedService
+	rt.mu.RUnlock()
+
+	if service == rt.shownService {
+		rt.markStateChanges(rt.filteredRes, resources)
+	} else {
+		rt.changedAt = make(map[string]time.Time)
+		rt.detailsLoaded = make(map[string]bool)
 	}
+	rt.shownService = service
 
-	return res
+	rt.filteredRes = resources
+	if rt.filterIndex != nil {
+		rt.filterIndex.Close()
+		rt.filterIndex = nil
+	}
+	rt.applyFilter()
 }
 
-// loadRDSInstances loads RDS instances using the RDS service wrapper
-func (rt *ResourcesTab) loadRDSInstances() ([]Resource, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	details, err := rt.awsClient.GetRDSFunctionDetails(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to describe RDS instances: %w", err)
+// markStateChanges remembers which resources changed their state between the
+// previous and the new listing
+func (rt *ResourcesTab) markStateChanges(previous, resources []Resource) {
+	states := make(map[string]string, len(previous))
+	for _, res := range previous {
+		states[res.ID] = res.State
 	}
 
-	var resources []Resource
-	for _, d := range details {
-		createdDate := ""
-		if d.InstanceCreateTime != nil {
-			createdDate = d.InstanceCreateTime.Format("2006-01-02 15:04:05")
+	changed := false
+	now := time.Now()
+	for _, res := range resources {
+		if state, ok := states[res.ID]; ok && state != res.State {
+			rt.changedAt[res.ID] = now
+			changed = true
 		}
-
-		resource := Resource{
-			ID:          d.DBInstanceIdentifier,
-			Name:        d.DBInstanceIdentifier,
-			Type:        "RDS Instance",
-			State:       d.DBInstanceStatus,
-			Region:      rt.awsClient.GetRegion(),
-			CreatedDate: createdDate,
-			Tags:        make(map[string]string),
-			Details:     make(map[string]interface{}),
-		}
-
-		// Add additional details
-		resource.Details["Engine"] = d.Engine
-		resource.Details["Engine Version"] = d.EngineVersion
-		resource.Details["Status"] = d.DBInstanceStatus
-		resource.Details["Endpoint"] = d.Endpoint
-		resource.Details["Allocated Storage (GB)"] = d.AllocatedStorage
-
-		resources = append(resources, resource)
 	}
 
-	return resources, nil
-}
-
-// loadLambdaFunctions loads Lambda functions using the Lambda service wrapper.
-func (rt *ResourcesTab) loadLambdaFunctions() ([]Resource, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	// Use the higher-level lambda service wrapper on the aws client to get detailed metadata
-	details, err := rt.awsClient.GetLambdaFunctionDetails(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	var resources []Resource
-	for _, d := range details {
-		res := Resource{
-			ID:          d.FunctionName,
-			Name:        d.FunctionName,
-			Type:        "Lambda Function",
-			State:       d.State,
-			Region:      rt.awsClient.GetRegion(),
-			CreatedDate: d.LastModified,
-			Tags:        make(map[string]string),
-			Details: map[string]interface{}{
-				"Runtime":          d.Runtime,
-				"Handler":          d.Handler,
-				"MemorySize":       d.MemorySize,
-				"Timeout":          d.Timeout,
-				"Description":      d.Description,
-				"CodeSize":         d.CodeSize,
-				"SnapStartEnabled": d.SnapStartEnabled,
-				"SnapStartStatus":  d.SnapStartStatus,
-				"LogGroupName":     d.LogGroupName,
-			},
-		}
-		resources = append(resources, res)
-	}
-
-	return resources, nil
-}
-
-// loadECSServices loads ECS services (placeholder)
-func (rt *ResourcesTab) loadECSServices() ([]Resource, error) {
-	// Placeholder implementation
-	return []Resource{
-		{
-			ID:          "ecs-example-1",
-			Name:        "Example ECS Service",
-			Type:        "ECS Service",
-			State:       "Running",
-			Region:      rt.awsClient.GetRegion(),
-			CreatedDate: time.Now().Format("2006-01-02 15:04:05"),
-			Tags:        make(map[string]string),
-			Details:     map[string]interface{}{"Note": "ECS implementation coming soon"},
-		},
-	}, nil
-}
-
-// loadVPCs loads VPCs (placeholder)
-func (rt *ResourcesTab) loadVPCs() ([]Resource, error) {
-	// Placeholder implementation
-	return []Resource{
-		{
-			ID:          "vpc-example-1",
-			Name:        "Example VPC",
-			Type:        "VPC",
-			State:       "Available",
-			Region:      rt.awsClient.GetRegion(),
-			CreatedDate: time.Now().Format("2006-01-02 15:04:05"),
-			Tags:        make(map[string]string),
-			Details:     map[string]interface{}{"Note": "VPC implementation coming soon"},
-		},
-	}, nil
-}
-
-// updateResourceTable updates the resource table with the given resources
-func (rt *ResourcesTab) updateResourceTable(resources []Resource) {
-	rt.filteredRes = resources
-	rt.applyFilter()
+	// Redraw without the highlight once it expired
+	if changed && rt.app != nil {
+		time.AfterFunc(stateChangeHighlight, func() {
+			rt.app.QueueUpdateDraw(rt.applyFilter)
+		})
+	}
 }
 
 // applyFilter applies the current filter to resources
 func (rt *ResourcesTab) applyFilter() {
-	filterText := strings.ToLower(strings.TrimSpace(rt.filterInput.GetText()))
+	filterText := strings.TrimSpace(rt.filterInput.GetText())
 
+	// Fields that matched the filter by resource ID, highlighted in the table
 	var filtered []Resource
-	if filterText == "" {
+	matches := make(map[string][]string)
+	switch {
+	case filterText == "":
 		filtered = rt.filteredRes
-	} else {
+	case isResourceQuery(filterText):
+		var err error
+		filtered, matches, err = rt.queryFilter(filterText)
+		if err != nil {
+			rt.updateStatus(i18n.T("resources.invalid_filter", err), "red")
+		}
+	default:
+		needle := strings.ToLower(filterText)
 		for _, res := range rt.filteredRes {
-			if strings.Contains(strings.ToLower(res.Name), filterText) ||
-				strings.Contains(strings.ToLower(res.ID), filterText) ||
-				strings.Contains(strings.ToLower(res.State), filterText) ||
-				strings.Contains(strings.ToLower(res.Type), filterText) {
+			var fields []string
+			for _, field := range [][2]string{{"name", res.Name}, {"id", res.ID}, {"state", res.State}, {"type", res.Type}} {
+				if strings.Contains(strings.ToLower(field[1]), needle) {
+					fields = append(fields, field[0])
+				}
+			}
+			if len(fields) > 0 {
 				filtered = append(filtered, res)
+				matches[res.ID] = fields
 			}
 		}
 	}
 
-	// Update table
-	if rt.resourceTable != nil {
-		logger.Info("Clearing resource table")
-		rt.resourceTable.Clear()
+	// Keep the selected resource or group selected, wherever it ends up
+	selectedID, selectedGroup := "", ""
+	row, _ := rt.resourceTable.GetSelection()
+	if group := rt.groupAt(row); group != nil {
+		selectedGroup = group.key
+	} else if res, ok := rt.resourceAt(row); ok {
+		selectedID = res.ID
 	}
+	rt.visibleRes = filtered
+
+	// Update table
+	logger.Debug("Clearing resource table")
+	rt.resourceTable.Clear()
 
 	// Add headers
-	headers := []string{"Name", "ID", "Type", "State", "Region", "Created"}
-	for col, header := range headers {
+	for col, header := range rt.resourceColumns() {
 		rt.resourceTable.SetCell(0, col,
-			tview.NewTableCell(header).
+			tview.NewTableCell(columnTitle(header)).
 				SetTextColor(tcell.ColorYellow).
 				SetAttributes(tcell.AttrBold))
 	}
 
-	// Add resources
-	for row, resource := range filtered {
-		rt.resourceTable.SetCell(row+1, 0, tview.NewTableCell(resource.Name))
-		rt.resourceTable.SetCell(row+1, 1, tview.NewTableCell(resource.ID))
-		rt.resourceTable.SetCell(row+1, 2, tview.NewTableCell(resource.Type))
+	// Add resources, in groups if the listing is grouped
+	if g := rt.grouping(); g != nil {
+		rt.tableRows = make([]resourceRow, 0, len(filtered))
+		rt.renderGroups(filtered, *g, matches)
+	} else {
+		rt.tableRows = nil
+		for row, resource := range filtered {
+			rt.renderResourceRow(row+1, resource, resource.Name, matches[resource.ID])
+		}
+	}
 
-		// Color-code state
-		stateColor := tcell.ColorWhite
-		switch strings.ToLower(resource.State) {
-		case "running", "available", "active":
-			stateColor = tcell.ColorGreen
-		case "stopped", "terminated":
-			stateColor = tcell.ColorRed
-		case "pending", "stopping":
-			stateColor = tcell.ColorYellow
+	switch {
+	case selectedGroup != "":
+		if row := rt.groupRowOf(selectedGroup, ""); row > 0 {
+			rt.resourceTable.Select(row, 0)
+		}
+	case selectedID != "":
+		row := rt.rowOf(selectedID)
+		if row == 0 && rt.tableRows != nil {
+			// Its group was collapsed
+			row = rt.groupRowOf("", selectedID)
 		}
-		rt.resourceTable.SetCell(row+1, 3,
-			tview.NewTableCell(resource.State).SetTextColor(stateColor))
+		if row > 0 {
+			rt.resourceTable.Select(row, 0)
+		}
+	}
+	rt.selectJump()
+
+	rt.updateTableTitle()
+}
+
+// resourceHeaders are the columns of the resource table
+var resourceHeaders = []string{"Name", "ID", "Type", "State", "Cost/mo", "Region", "Created"}
+
+// costColumn is the column of resourceHeaders with the monthly cost
+const costColumn = 4
+
+// resourceColumns returns the columns of the shown listing
+func (rt *ResourcesTab) resourceColumns() []string {
+	return serviceViewOf(rt.shownService).Columns()
+}
+
+// renderResourceRow shows resource in row under name, highlighting the
+// fields the filter matched and a recent state change
+func (rt *ResourcesTab) renderResourceRow(row int, resource Resource, name string, matched []string) {
+	if rt.marked != nil && rt.marked.ID == resource.ID && rt.markedService == rt.shownService {
+		name = markedPrefix + name
+	}
+	changedAt, changed := rt.changedAt[resource.ID]
+	changed = changed && time.Since(changedAt) < stateChangeHighlight
+	state := resource.State
+	if accessible() {
+		// Alerts and state changes are marked in words, not by color alone
+		if resource.Alert {
+			name = alertPrefix + name
+		}
+		if changed {
+			state += " (" + i18n.T("resources.changed") + ")"
+		}
+	}
+	rt.resourceTable.SetCell(row, 0, tview.NewTableCell(name))
+	rt.resourceTable.SetCell(row, 1, tview.NewTableCell(resource.ID))
+	rt.resourceTable.SetCell(row, 2, tview.NewTableCell(resource.Type))
 
-		rt.resourceTable.SetCell(row+1, 4, tview.NewTableCell(resource.Region))
-		rt.resourceTable.SetCell(row+1, 5, tview.NewTableCell(resource.CreatedDate))
+	rt.resourceTable.SetCell(row, 3,
+		tview.NewTableCell(state).SetTextColor(serviceViewOf(rt.shownService).StateColor(resource.State)))
+
+	if cell := serviceViewOf(rt.shownService).Cell(resource, costColumn); cell != nil {
+		rt.resourceTable.SetCell(row, costColumn, cell)
+	} else {
+		rt.resourceTable.SetCell(row, costColumn,
+			tview.NewTableCell(formatMonthlyCost(resource.MonthlyCost)).SetAlign(tview.AlignRight))
 	}
+	rt.resourceTable.SetCell(row, 5, tview.NewTableCell(resource.Region))
+	rt.resourceTable.SetCell(row, 6, tview.NewTableCell(createdTime(resource.CreatedDate)))
 
-	// Update title with count
-	title := fmt.Sprintf(" Resources (%d", len(filtered))
-	if len(filtered) != len(rt.filteredRes) {
-		title += fmt.Sprintf(" of %d", len(rt.filteredRes))
+	if resource.Alert {
+		for col := range resourceHeaders {
+			rt.resourceTable.GetCell(row, col).SetTextColor(tcell.ColorRed)
+		}
+	}
+	for _, field := range matched {
+		if col, ok := resourceMatchColumns[field]; ok {
+			rt.resourceTable.GetCell(row, col).SetTextColor(filterMatchColor).SetAttributes(tcell.AttrBold)
+		}
 	}
-	title += ") "
+	if changed {
+		for col := range resourceHeaders {
+			rt.resourceTable.GetCell(row, col).SetBackgroundColor(tview.Styles.ContrastBackgroundColor)
+		}
+	} else {
+		delete(rt.changedAt, resource.ID)
+	}
+}
+
+// updateTableTitle shows the resource count and the age of the data in the table title
+func (rt *ResourcesTab) updateTableTitle() {
+	title := " " + i18n.T("resources.count", len(rt.visibleRes))
+	if len(rt.visibleRes) != len(rt.filteredRes) {
+		title = " " + i18n.T("resources.count_of", len(rt.visibleRes), len(rt.filteredRes))
+	}
+	if g := rt.grouping(); g != nil {
+		title += " " + i18n.T("groups.title", g.title(), i18n.N("count.group", rt.groupCount))
+	}
+	if !rt.fetchedAt.IsZero() {
+		title += " - " + i18n.T("resources.updated", formatAge(time.Since(rt.fetchedAt)))
+	}
+	title += " "
 	rt.resourceTable.SetTitle(title)
 }
 
+// formatMonthlyCost renders an estimated monthly cost, or nothing if unknown
+func formatMonthlyCost(cost float64) string {
+	if cost <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("$%.2f", cost)
+}
+
+// formatAge renders a duration as a short "how long ago" string
+func formatAge(d time.Duration) string {
+	switch {
+	case d < 5*time.Second:
+		return i18n.T("common.just_now")
+	case d < time.Minute:
+		return i18n.T("common.seconds_ago", int(d.Seconds()))
+	case d < time.Hour:
+		return i18n.T("common.minutes_ago", int(d.Minutes()))
+	default:
+		return i18n.T("common.hours_ago", int(d.Hours()))
+	}
+}
+
 // onFilterChanged handles filter text changes
 func (rt *ResourcesTab) onFilterChanged(text string) {
 	rt.applyFilter()
@@ -612,41 +1069,126 @@ func (rt *ResourcesTab) onFilterChanged(text string) {
 
 // onResourceSelected handles resource selection
 func (rt *ResourcesTab) onResourceSelected(row, column int) {
-	if row <= 0 || row-1 >= len(rt.filteredRes) {
+	if group := rt.groupAt(row); group != nil {
+		rt.toggleGroup(group)
+		return
+	}
+	resource, ok := rt.resourceAt(row)
+	if !ok {
 		return
 	}
 
-	resource := rt.filteredRes[row-1]
 	rt.selectedRes = &resource
 	rt.updateResourceDetails(&resource)
+
+	// The drill-down views load what they show from AWS
+	if rt.isOffline() {
+		return
+	}
+
+	serviceViewOf(rt.selectedService).Open(rt, resource)
 }
 
 // onResourceHighlighted handles resource highlighting
 func (rt *ResourcesTab) onResourceHighlighted(row, column int) {
-	if row <= 0 || row-1 >= len(rt.filteredRes) {
-		rt.updateResourceInfo("Select a resource to view details")
+	if group := rt.groupAt(row); group != nil {
+		rt.selectedRes = nil
+		rt.showGroupDetails(group)
+		return
+	}
+	resource, ok := rt.resourceAt(row)
+	if !ok {
+		rt.updateResourceInfo(i18n.T("resources.select_resource"))
 		return
 	}
 
-	resource := rt.filteredRes[row-1]
 	rt.selectedRes = &resource
 	rt.updateResourceDetails(&resource)
+
+	if rt.isOffline() {
+		return
+	}
+	view := serviceViewOf(rt.selectedService)
+	view.Highlight(rt, resource)
+	if detailed, ok := view.(detailedView); ok {
+		rt.loadDetails(detailed, resource)
+	}
+}
+
+// setWarnings replaces the warnings of the shown listing
+func (rt *ResourcesTab) setWarnings(noun string, failures []clients.ItemError) {
+	rt.warningNoun = noun
+	rt.warnings = append([]clients.ItemError(nil), failures...)
+	rt.renderWarnings()
+}
+
+// addWarnings adds failures to the shown listing, replacing older ones of the same item
+func (rt *ResourcesTab) addWarnings(failures ...clients.ItemError) {
+	for _, failure := range failures {
+		replaced := false
+		for i, existing := range rt.warnings {
+			if existing.Item == failure.Item && existing.Region == failure.Region {
+				rt.warnings[i] = failure
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			rt.warnings = append(rt.warnings, failure)
+		}
+	}
+	rt.renderWarnings()
+}
+
+// renderWarnings lists the warnings below the table, hiding the panel when there are none
+func (rt *ResourcesTab) renderWarnings() {
+	if rt.warningsText == nil || rt.centerPanel == nil {
+		return
+	}
+
+	if len(rt.warnings) == 0 {
+		rt.warningsText.Clear()
+		rt.centerPanel.ResizeItem(rt.warningsText, 0, 0)
+		return
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("[yellow]%s[-]\n", clients.SummarizeFailures(rt.warnings, rt.warningNoun)))
+	for _, warning := range rt.warnings {
+		item := warning.Item
+		if warning.Region != "" {
+			item += " (" + warning.Region + ")"
+		}
+		text.WriteString(fmt.Sprintf("[red]•[-] %s: %s\n", tview.Escape(item), warning.Reason()))
+	}
+
+	rt.warningsText.SetText(text.String())
+	rt.warningsText.SetTitle(panelTitle("resources.warnings_count", len(rt.warnings)))
+	rt.centerPanel.ResizeItem(rt.warningsText, min(len(rt.warnings)+3, 8), 0)
 }
 
 // updateResourceDetails updates the resource details panel
 func (rt *ResourcesTab) updateResourceDetails(resource *Resource) {
-	info := fmt.Sprintf(`[yellow]Name:[-] %s
-[yellow]ID:[-] %s
-[yellow]Type:[-] %s
-[yellow]State:[-] %s
-[yellow]Region:[-] %s
-[yellow]Created:[-] %s
+	var info string
+	for _, field := range []struct{ key, value string }{
+		{"column.name", resource.Name},
+		{"column.id", resource.ID},
+		{"column.type", resource.Type},
+		{"column.state", resource.State},
+		{"column.region", resource.Region},
+		{"column.created", createdTime(resource.CreatedDate)},
+	} {
+		info += fmt.Sprintf("%s %s\n", infoLabel(field.key), field.value)
+	}
+	info += "\n"
 
-`, resource.Name, resource.ID, resource.Type, resource.State, resource.Region, resource.CreatedDate)
+	if resource.MonthlyCost > 0 {
+		info += fmt.Sprintf("%s %s\n\n", infoLabel("resources.est_cost"), i18n.T("resources.per_month", formatMonthlyCost(resource.MonthlyCost)))
+	}
 
 	// Add tags if any
 	if len(resource.Tags) > 0 {
-		info += "[yellow]Tags:[-]\n"
+		info += infoLabel("resources.tags") + "\n"
 		var tagKeys []string
 		for key := range resource.Tags {
 			tagKeys = append(tagKeys, key)
@@ -659,9 +1201,11 @@ func (rt *ResourcesTab) updateResourceDetails(resource *Resource) {
 		info += "\n"
 	}
 
+	info += serviceViewOf(rt.selectedService).Detail(rt, *resource)
+
 	// Add details if any
 	if len(resource.Details) > 0 {
-		info += "[yellow]Details:[-]\n"
+		info += infoLabel("resources.detail_fields") + "\n"
 		var detailKeys []string
 		for key := range resource.Details {
 			detailKeys = append(detailKeys, key)
@@ -672,6 +1216,9 @@ func (rt *ResourcesTab) updateResourceDetails(resource *Resource) {
 			info += fmt.Sprintf("  %s: %v\n", key, resource.Details[key])
 		}
 	}
+	if rt.detailsPending(*resource) {
+		info += "[gray]" + i18n.T("resources.loading_details") + "[-]\n"
+	}
 
 	rt.updateResourceInfo(info)
 }
@@ -688,7 +1235,27 @@ func (rt *ResourcesTab) updateResourceInfo(text string) {
 
 // focusFilter focuses on the filter input field
 func (rt *ResourcesTab) focusFilter() {
-	// This would be called from the application level
+	if rt.filterInput != nil && rt.app != nil {
+		rt.app.SetFocus(rt.filterInput)
+		rt.filterInput.SetTitle(panelTitle("resources.filter_active"))
+	}
+}
+
+// blurFilter moves the focus from the filter input to p
+func (rt *ResourcesTab) blurFilter(p tview.Primitive) {
+	if rt.app != nil {
+		rt.app.SetFocus(p)
+	}
+	rt.filterInput.SetTitle(panelTitle("resources.filter"))
+}
+
+// applyLocale titles the fixed panels in the current locale; the table
+// takes its title from the next load
+func (rt *ResourcesTab) applyLocale() {
+	rt.serviceList.SetTitle(panelTitle("resources.services"))
+	rt.filterInput.SetTitle(panelTitle("resources.filter"))
+	rt.resourceInfo.SetTitle(panelTitle("resources.details"))
+	rt.statusText.SetTitle(panelTitle("resources.status"))
 }
 
 // updateStatus updates the status display
@@ -699,7 +1266,7 @@ func (rt *ResourcesTab) updateStatus(message, color string) {
 	}
 	rt.statusText.Clear() // Clear existing status to prevent duplication
 	timestamp := time.Now().Format("15:04:05")
-	statusText := fmt.Sprintf("[%s]%s[-]\n[gray]%s[-]", color, message, timestamp)
+	statusText := fmt.Sprintf("[%s]%s%s[-]\n[gray]%s[-]", color, stateWord(color), message, timestamp)
 	rt.statusText.SetText(statusText)
 }
 
@@ -708,20 +1275,44 @@ func (rt *ResourcesTab) SetAWSClient(client *aws.Client) {
 	rt.mu.Lock()
 	defer rt.mu.Unlock()
 
+	// Loads of the previous profile must not show up in the new one, and its
+	// background jobs must not go on against the new one
+	rt.cancelLoads()
+	if rt.jobs != nil {
+		if cancelled := rt.jobs.CancelAll(); cancelled > 0 {
+			logger.Info("Cancelled background jobs of the previous client", zap.Int("count", cancelled))
+		}
+	}
+
 	rt.awsClient = client
 	if client != nil {
-		rt.updateStatus("AWS client configured", "green")
+		rt.updateStatus(i18n.T("resources.client_configured"), "green")
 	} else {
-		rt.updateStatus("AWS client removed", "yellow")
+		rt.updateStatus(i18n.T("resources.client_removed"), "yellow")
 	}
 
-	// Clear current resources
-	rt.resources = make(map[string][]Resource)
+	// Clear current resources; cached listings are keyed by profile and region and stay valid
+	rt.fetchedAt = time.Time{}
+	rt.detailsLoaded = make(map[string]bool)
+	rt.permissions = make(map[string]permission)
+	rt.shownService = ""
+	rt.changedAt = make(map[string]time.Time)
+	rt.setWarnings("", nil)
 	if rt.resourceTable != nil {
 		logger.Info("Clearing resource table in SetAWSClient")
 		rt.resourceTable.Clear()
 	}
-	rt.updateResourceInfo("Select a service to view resources")
+	rt.updateResourceInfo(i18n.T("resources.select_service"))
+}
+
+// RunningJobs returns how many background jobs are running
+func (rt *ResourcesTab) RunningJobs() int {
+	return rt.jobs.Running()
+}
+
+// SetHistory keeps the filter history in store
+func (rt *ResourcesTab) SetHistory(store *history.Store) {
+	rt.filterHistory.SetStore(store)
 }
 
 // Refresh refreshes the current service resources
@@ -732,19 +1323,55 @@ func (rt *ResourcesTab) Refresh() {
 	rt.mu.RUnlock()
 
 	if loading {
-		rt.updateStatus("Already loading...", "yellow")
+		rt.updateStatus(i18n.T("resources.already_loading"), "yellow")
 		return
 	}
 
 	if service == "" {
-		rt.updateStatus("No service selected", "yellow")
+		rt.updateStatus(i18n.T("resources.no_service"), "yellow")
+		return
+	}
+	if rt.isOffline() {
+		rt.updateStatus(offlineStatus(), "yellow")
 		return
 	}
 
+	// The rows stay until the new listing replaces them, see updateResourceTable
+	rt.loadService(service, true)
+}
+
+// ApplyConfig applies configuration changes to the resources tab
+func (rt *ResourcesTab) ApplyConfig(cfg *config.Config) {
+	rt.mu.Lock()
+	rt.config = cfg
+	rt.services = orderServices(cfg.UI.Services)
+	rt.prefetchCount = cfg.UI.PrefetchServices
+	if cfg.UI.CacheTTL == 0 {
+		// Prefetched listings would never be served without a cache
+		rt.prefetchCount = 0
+	}
+	rt.mu.Unlock()
+
+	rt.cache.SetTTL(time.Duration(cfg.UI.CacheTTL) * time.Second)
+
+	rt.loadServices()
+}
+
+// AutoRefresh reloads the selected service unless nothing is selected or a load is running
+func (rt *ResourcesTab) AutoRefresh() {
+	rt.mu.RLock()
+	service := rt.selectedService
+	loading := rt.loading
+	rt.mu.RUnlock()
+
 	if rt.resourceTable != nil {
-		logger.Info("Clearing resource table in Refresh")
-		rt.resourceTable.Clear() // Clear existing resources to prevent duplication
+		rt.updateTableTitle()
+	}
+
+	if service == "" || loading || rt.awsClient == nil || rt.isOffline() {
+		return
 	}
+
 	rt.selectService(service)
 }
 
@@ -753,137 +1380,164 @@ func (rt *ResourcesTab) GetView() tview.Primitive {
 
 	return rt.view
 }
-func (rt *ResourcesTab) onEC2StartInstance() {
-	logger.Info("onEC2StartInstance called", zap.String("selectedService", rt.selectedService))
 
-	if rt.selectedService != "ec2" {
-		logger.Info("Not EC2 service, ignoring")
-		return
-	}
+func init() {
+	registerResourceActions(
+		resourceAction{name: "refresh", key: 'r', description: "action.refresh",
+			inServiceList: true, run: (*ResourcesTab).Refresh},
+		resourceAction{name: "filter", key: 'f', description: "action.filter",
+			inServiceList: true, run: (*ResourcesTab).focusFilter},
+		resourceAction{name: "export", key: 'e', description: "action.export",
+			run: (*ResourcesTab).showExportDialog},
+		resourceAction{name: "console", key: 'O', description: "action.console",
+			onResource: true, run: (*ResourcesTab).openInConsole},
+		resourceAction{name: "console-url", key: 'y', description: "action.console_url",
+			onResource: true, run: (*ResourcesTab).copyConsoleURL},
+		resourceAction{name: "details", key: 'i', description: "action.details",
+			onResource: true, run: (*ResourcesTab).showDetails},
+	)
+}
 
+// openInConsole opens the selected resource in the AWS console. If no browser
+// can be started the link is shown in the details panel to copy it from there.
+func (rt *ResourcesTab) openInConsole() {
 	if rt.selectedRes == nil {
-		logger.Info("No resource selected")
+		rt.updateStatus(i18n.T("resources.no_resource"), "yellow")
 		return
 	}
 
-	instanceID := rt.selectedRes.ID
-	if instanceID == "" {
-		rt.updateStatus("No InstanceId found for selected resource", "red")
-		logger.Error("No InstanceId found in selected resource")
+	link, err := consoleURL(serviceViewOf(rt.selectedService), *rt.selectedRes)
+	if err != nil {
+		rt.updateStatus(err.Error(), "red")
 		return
 	}
 
-	rt.updateStatus(fmt.Sprintf("Starting EC2 instance %s...", instanceID), "yellow")
-
-	// Since this is a UI-triggered asynchronous operation meant not to block the UI,
-	// we do NOT generally use a WaitGroup for the user-facing routine.
-	// State is protected with locks where appropriate, and UI updates are handled on the main UI goroutine.
-	// If you ever need to clean up or synchronize these routines (such as cancelling/retrying),
-	// consider keeping a list/context for outstanding operations, not just WaitGroups.
-
-	go func(id string) {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-
-		err := rt.awsClient.GetClients().EC2.StartInstance(ctx, id)
-		if err != nil {
-			logger.Error("Failed to start EC2 instance", zap.String("instanceID", id), zap.Error(err))
-			if rt.app != nil {
-				rt.app.QueueUpdateDraw(func() {
-					rt.updateStatus(fmt.Sprintf("Failed to start instance: %s", err.Error()), "red")
-				})
-			}
-			return
-		}
+	if err := openBrowser(link); err != nil {
+		logger.Warn("Failed to open console link", zap.String("url", link), zap.Error(err))
+		rt.updateResourceInfo(infoLabel("resources.console_url") + "\n" + link)
+		rt.updateStatus(i18n.T("resources.no_browser"), "yellow")
+		return
+	}
 
-		logger.Info("EC2 instance started", zap.String("instanceID", id))
-		if rt.app != nil {
-			rt.app.QueueUpdateDraw(func() {
-				rt.updateStatus(fmt.Sprintf("Instance %s started", id), "green")
-				rt.Refresh()
-			})
-		}
-	}(instanceID)
+	logger.Info("Opened console link", zap.String("url", link))
+	rt.updateStatus(i18n.T("resources.console_opened", rt.selectedRes.Name), "green")
 }
 
-func (rt *ResourcesTab) onEC2StopInstance() {
-	logger.Info("onEC2StopInstance called", zap.String("selectedService", rt.selectedService))
-
-	if rt.selectedService != "ec2" {
-		logger.Info("Not EC2 service, ignoring")
+// copyConsoleURL copies the AWS console link of the selected resource to the
+// clipboard
+func (rt *ResourcesTab) copyConsoleURL() {
+	if rt.selectedRes == nil {
+		rt.updateStatus(i18n.T("resources.no_resource"), "yellow")
 		return
 	}
 
-	if rt.selectedRes == nil {
-		logger.Info("No resource selected")
+	link, err := consoleURL(serviceViewOf(rt.selectedService), *rt.selectedRes)
+	if err != nil {
+		rt.updateStatus(err.Error(), "red")
 		return
 	}
 
-	instanceID := rt.selectedRes.ID
-	if instanceID == "" {
-		rt.updateStatus("No InstanceId found for selected resource", "red")
-		logger.Error("No InstanceId found in selected resource")
+	if err := copyToClipboard(link); err != nil {
+		logger.Warn("Failed to copy console link", zap.String("url", link), zap.Error(err))
+		rt.updateResourceInfo(infoLabel("resources.console_url") + "\n" + link)
+		rt.updateStatus(i18n.T("resources.url_copy_failed", err), "red")
 		return
 	}
+	rt.updateStatus(i18n.T("resources.url_copied", rt.selectedRes.Name), "green")
+}
 
-	rt.updateStatus(fmt.Sprintf("Stopping EC2 instance %s...", instanceID), "yellow")
+// showExportDialog asks for the export path, format and fields of the visible resources
+func (rt *ResourcesTab) showExportDialog() {
+	if len(rt.visibleRes) == 0 {
+		rt.updateStatus(i18n.T("resources.export_nothing"), "yellow")
+		return
+	}
 
-	go func(id string) {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+	resources := rt.visibleRes
+	path := defaultExportPath(rt.selectedService)
+	fields := strings.Join(detailKeys(resources), ", ")
+	includeTags := true
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("resources.export_path_field"), path, 50, nil, func(text string) { path = text })
+	form.AddInputField(i18n.T("resources.export_fields"), fields, 50, nil, func(text string) { fields = text })
+	form.AddCheckbox(i18n.T("resources.export_tags"), includeTags, func(checked bool) { includeTags = checked })
+	form.AddButton(i18n.T("resources.export"), func() {
+		rt.closeExportDialog()
+		rt.exportToFile(path, resources, splitFields(fields), includeTags)
+	})
+	form.AddButton(i18n.T("common.cancel"), func() {
+		rt.closeExportDialog()
+		rt.updateStatus(i18n.T("resources.export_cancelled"), "blue")
+	})
+	form.SetBorder(true).
+		SetTitle(panelTitle("resources.export_title", len(resources))).
+		SetTitleAlign(tview.AlignLeft)
 
-		err := rt.awsClient.GetClients().EC2.StopInstance(ctx, id)
-		if err != nil {
-			logger.Error("Failed to stop EC2 instance", zap.String("instanceID", id), zap.Error(err))
-			if rt.app != nil {
-				rt.app.QueueUpdateDraw(func() {
-					rt.updateStatus(fmt.Sprintf("Failed to stop instance: %s", err.Error()), "red")
-				})
-			}
-			return
-		}
+	rt.view.AddPage("export", centered(form, 70, 11), true, true)
+	if rt.app != nil {
+		rt.app.SetFocus(form)
+	}
+}
 
-		logger.Info("EC2 instance stopped", zap.String("instanceID", id))
-		if rt.app != nil {
-			rt.app.QueueUpdateDraw(func() {
-				rt.updateStatus(fmt.Sprintf("Instance %s stopped", id), "green")
-				rt.Refresh()
-			})
-		}
-	}(instanceID)
+// closeExportDialog removes the export dialog and returns focus to the table
+func (rt *ResourcesTab) closeExportDialog() {
+	rt.view.RemovePage("export")
+	if rt.app != nil {
+		rt.app.SetFocus(rt.resourceTable)
+	}
 }
 
-func (rt *ResourcesTab) onLambdaLogsKey() {
-	logger.Info("onLambdaLogsKey called", zap.String("selectedService", rt.selectedService))
-	if rt.selectedService != "lambda" {
-		logger.Info("Not lambda service, ignoring")
+// exportToFile writes resources to path, picking the format from its extension
+func (rt *ResourcesTab) exportToFile(path string, resources []Resource, fields []string, includeTags bool) {
+	path = strings.TrimSpace(path)
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	if format != "csv" && format != "json" {
+		rt.updateStatus(i18n.T("resources.export_path"), "red")
 		return
 	}
 
-	if rt.selectedRes == nil {
-		logger.Info("No resource selected")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		rt.updateStatus(i18n.T("resources.export_failed", err), "red")
 		return
 	}
 
-	logGroup := ""
-	if v, ok := rt.selectedRes.Details["LogGroupName"]; ok {
-		if s, ok := v.(string); ok {
-			logGroup = s
-		}
+	file, err := os.Create(path)
+	if err != nil {
+		rt.updateStatus(i18n.T("resources.export_failed", err), "red")
+		return
 	}
+	defer file.Close()
 
-	if logGroup == "" {
-		logGroup = fmt.Sprintf("/aws/lambda/%s", rt.selectedRes.Name)
+	if err := exportResources(file, format, resources, fields, includeTags); err != nil {
+		logger.Error("Failed to export resources", zap.String("path", path), zap.Error(err))
+		rt.updateStatus(i18n.T("resources.export_failed", err), "red")
+		return
 	}
 
-	logger.Info("Emitting EventShowLambdaLogs", zap.String("function", rt.selectedRes.Name), zap.String("logGroup", logGroup))
-	if rt.eventChan != nil {
-		data := map[string]string{
-			"function": rt.selectedRes.Name,
-			"logGroup": logGroup,
+	logger.Info("Exported resources", zap.String("path", path), zap.Int("count", len(resources)))
+	rt.updateStatus(i18n.T("resources.exported", len(resources), path), "green")
+}
+
+// defaultExportPath returns the default export file for a service
+func defaultExportPath(service string) string {
+	name := fmt.Sprintf("%s-resources-%s.csv", service, time.Now().Format("20060102-150405"))
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(homeDir, ".swiss-army-tui", "exports", name)
+}
+
+// splitFields splits a comma separated list of field names
+func splitFields(text string) []string {
+	var fields []string
+	for _, field := range strings.Split(text, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
 		}
-		rt.eventChan <- Event{Type: EventShowLambdaLogs, Data: data}
 	}
+	return fields
 }
 
 // getStringValue safely gets a string value from a pointer
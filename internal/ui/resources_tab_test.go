@@ -0,0 +1,1505 @@
+package ui
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"swiss-army-tui/internal/aws/clients"
+	"swiss-army-tui/internal/aws/fake"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/jobs"
+	"swiss-army-tui/internal/snapshot"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestOrderServices(t *testing.T) {
+	if got := orderServices(nil); len(got) != len(supportedServices) {
+		t.Errorf("Expected all %d services for empty config, got %d", len(supportedServices), len(got))
+	}
+
+	got := orderServices([]string{"lambda", "ec2", "unknown", "lambda"})
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 services, got %d", len(got))
+	}
+	if got[0].Name != "lambda" || got[1].Name != "ec2" {
+		t.Errorf("Expected lambda before ec2, got %s, %s", got[0].Name, got[1].Name)
+	}
+}
+
+func TestExportResources(t *testing.T) {
+	resources := []Resource{
+		{
+			ID:      "i-123",
+			Name:    "web",
+			Type:    "t3.micro",
+			State:   "running",
+			Details: map[string]interface{}{"PrivateIP": "10.0.0.1", "VpcId": "vpc-1"},
+			Tags:    map[string]string{"env": "prod", "app": "web"},
+		},
+	}
+
+	var csvOut bytes.Buffer
+	if err := exportResources(&csvOut, "csv", resources, []string{"PrivateIP"}, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if lines[0] != "Name,ID,Type,State,Region,Created,PrivateIP,Tags" {
+		t.Errorf("Unexpected CSV header: %s", lines[0])
+	}
+	if lines[1] != "web,i-123,t3.micro,running,,,10.0.0.1,app=web;env=prod" {
+		t.Errorf("Unexpected CSV row: %s", lines[1])
+	}
+
+	var jsonOut bytes.Buffer
+	if err := exportResources(&jsonOut, "json", resources, []string{"VpcId"}, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var records []resourceRecord
+	if err := json.Unmarshal(jsonOut.Bytes(), &records); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if len(records) != 1 || records[0].Details["VpcId"] != "vpc-1" || records[0].Tags != nil {
+		t.Errorf("Unexpected JSON records: %+v", records)
+	}
+	if _, ok := records[0].Details["PrivateIP"]; ok {
+		t.Error("Expected unselected detail field to be omitted")
+	}
+
+	if err := exportResources(&jsonOut, "xml", resources, nil, false); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestConsoleURL(t *testing.T) {
+	got, err := consoleURL(ec2Service, Resource{ID: "i-123", Region: "eu-west-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := "https://eu-west-1.console.aws.amazon.com/ec2/home?region=eu-west-1#InstanceDetails:instanceId=i-123"
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	got, _ = consoleURL(lambdaService, Resource{Name: "fn", Region: "cn-north-1"})
+	if !strings.HasPrefix(got, "https://console.amazonaws.cn/lambda/") {
+		t.Errorf("Expected China partition console link, got %s", got)
+	}
+
+	got, _ = consoleURL(alarmsService, Resource{Name: "api 5xx", Region: "us-east-1"})
+	if got != "https://us-east-1.console.aws.amazon.com/cloudwatch/home?region=us-east-1#alarmsV2:alarm/api%205xx" {
+		t.Errorf("Unexpected alarm console link %s", got)
+	}
+
+	if _, err := consoleURL(iamService, Resource{Region: "us-east-1"}); err == nil {
+		t.Error("Expected error for unsupported service")
+	}
+}
+
+func TestResourceARN(t *testing.T) {
+	instance := Resource{ID: "i-123", Region: "cn-north-1"}
+	if got := ec2Service.ARN("123456789012", instance); got != "arn:aws-cn:ec2:cn-north-1:123456789012:instance/i-123" {
+		t.Errorf("Unexpected instance ARN %s", got)
+	}
+
+	topic := Resource{Name: "alerts", Region: "us-east-1", Details: map[string]interface{}{"ARN": "arn:aws:sns:us-east-1:123456789012:alerts"}}
+	if got := snsService.ARN("123456789012", topic); got != "arn:aws:sns:us-east-1:123456789012:alerts" {
+		t.Errorf("Expected the ARN of the listing, got %s", got)
+	}
+	if got := iamService.ARN("123456789012", Resource{ID: "role", Region: "us-east-1"}); got != "" {
+		t.Errorf("Expected no ARN made up for a service without one, got %s", got)
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	cases := map[time.Duration]string{
+		2 * time.Second:  "just now",
+		42 * time.Second: "42s ago",
+		5 * time.Minute:  "5m ago",
+		3 * time.Hour:    "3h ago",
+	}
+	for d, want := range cases {
+		if got := formatAge(d); got != want {
+			t.Errorf("formatAge(%v): expected %q, got %q", d, want, got)
+		}
+	}
+}
+
+func TestPrefetchCandidates(t *testing.T) {
+	rt := &ResourcesTab{
+		services:        supportedServices,
+		selectedService: "ec2",
+		prefetchCount:   3,
+	}
+	rt.noteRecentService("rds")
+	rt.noteRecentService("lambda")
+	rt.noteRecentService("ec2")
+
+	got := rt.prefetchCandidates()
+	want := []string{"lambda", "rds", "s3"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected candidates %v, got %v", want, got)
+	}
+
+	rt.prefetchCount = 0
+	if got := rt.prefetchCandidates(); len(got) != 0 {
+		t.Errorf("Expected no candidates with prefetching disabled, got %v", got)
+	}
+}
+
+func TestResourcesTabLoadGenerations(t *testing.T) {
+	rt := &ResourcesTab{}
+
+	first, firstGen := rt.beginLoad()
+	second, secondGen := rt.beginLoad()
+
+	if first.Err() == nil {
+		t.Error("Expected the first load to be cancelled by the second")
+	}
+	if rt.isCurrentLoad(firstGen) || !rt.isCurrentLoad(secondGen) {
+		t.Error("Expected only the second load to be current")
+	}
+
+	rt.loading = true
+	rt.cancelLoads()
+	if second.Err() == nil {
+		t.Error("Expected cancelLoads to cancel the running load")
+	}
+	if rt.isCurrentLoad(secondGen) {
+		t.Error("Expected no load to be current after cancelLoads")
+	}
+	if rt.loading {
+		t.Error("Expected loading to be reset")
+	}
+}
+
+func TestFetchResourcesPages(t *testing.T) {
+	rt, err := NewResourcesTab(nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create resources tab: %v", err)
+	}
+	client := fake.NewClient()
+
+	for _, tt := range []struct {
+		service string
+		pages   []int
+	}{
+		{"ec2", []int{2, 4, 5}},
+		{"rds", []int{1, 2}},
+		// Services listed at once never call onPage
+		{"s3", nil},
+	} {
+		var pages []int
+		resources, err := rt.fetchResources(context.Background(), client, tt.service, func(loaded []Resource) {
+			pages = append(pages, len(loaded))
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.service, err)
+		}
+		if fmt.Sprint(pages) != fmt.Sprint(tt.pages) {
+			t.Errorf("%s: expected pages of %v resources so far, got %v", tt.service, tt.pages, pages)
+		}
+		if len(tt.pages) > 0 && len(resources) != tt.pages[len(tt.pages)-1] {
+			t.Errorf("%s: expected all %d resources, got %d", tt.service, tt.pages[len(tt.pages)-1], len(resources))
+		}
+	}
+}
+
+func TestLoadDetails(t *testing.T) {
+	rt, err := NewResourcesTab(nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create resources tab: %v", err)
+	}
+	client := fake.NewClient()
+
+	for _, tt := range []struct {
+		view   detailedView
+		detail string
+	}{
+		{ec2Service, "PrivateIpAddress"},
+		{lambdaService, "Handler"},
+		{snsService, "Subscriptions"},
+	} {
+		service := tt.view.Info().Name
+		resources, err := rt.fetchResources(context.Background(), client, service, nil)
+		if err != nil || len(resources) == 0 {
+			t.Fatalf("%s: expected resources, got %d: %v", service, len(resources), err)
+		}
+		// Listings hold a summary only
+		if _, ok := resources[0].Details[tt.detail]; ok {
+			t.Errorf("%s: expected no %s in the listing", service, tt.detail)
+		}
+
+		detailed, err := tt.view.LoadDetails(context.Background(), client, resources[0])
+		if err != nil {
+			t.Fatalf("%s: %v", service, err)
+		}
+		if detailed.ID != resources[0].ID || detailed.Details[tt.detail] == nil {
+			t.Errorf("%s: expected %s of %s, got %v", service, tt.detail, resources[0].ID, detailed.Details)
+		}
+	}
+
+	// Details loaded survive the rest of a streamed listing
+	rt.shownService = "ec2"
+	rt.filteredRes = []Resource{{ID: "i-1", Details: map[string]interface{}{"KeyName": "ops"}}}
+	rt.detailsLoaded = map[string]bool{"i-1": true}
+	listing := []Resource{{ID: "i-1"}, {ID: "i-2"}}
+	rt.keepDetails(listing)
+	if listing[0].Details["KeyName"] != "ops" || listing[1].Details != nil {
+		t.Errorf("Expected the details of i-1 only to be kept, got %+v", listing)
+	}
+}
+
+func TestLayoutFor(t *testing.T) {
+	for width, want := range map[int]tabLayout{
+		200:              layoutWide,
+		narrowWidth:      layoutWide,
+		narrowWidth - 1:  layoutNarrow,
+		stackedWidth:     layoutNarrow,
+		stackedWidth - 1: layoutStacked,
+		0:                layoutStacked,
+	} {
+		if got := layoutFor(width); got != want {
+			t.Errorf("Expected layout %d at width %d, got %d", want, width, got)
+		}
+	}
+}
+
+func TestResourcesTabRefreshDiff(t *testing.T) {
+	rt, err := NewResourcesTab(nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create resources tab: %v", err)
+	}
+	rt.selectedService = "ec2"
+
+	rt.updateResourceTable([]Resource{
+		{ID: "i-1", Name: "a", State: "running"},
+		{ID: "i-2", Name: "b", State: "pending"},
+		{ID: "i-3", Name: "c", State: "stopped"},
+	})
+	rt.resourceTable.Select(2, 0)
+
+	// i-2 moved to the top and started
+	rt.updateResourceTable([]Resource{
+		{ID: "i-2", Name: "b", State: "running"},
+		{ID: "i-1", Name: "a", State: "running"},
+		{ID: "i-3", Name: "c", State: "stopped"},
+	})
+
+	if row, _ := rt.resourceTable.GetSelection(); row != 1 {
+		t.Errorf("Expected i-2 to stay selected in row 1, got row %d", row)
+	}
+	if rt.selectedRes == nil || rt.selectedRes.State != "running" {
+		t.Errorf("Expected the details to show the new state, got %+v", rt.selectedRes)
+	}
+	if _, ok := rt.changedAt["i-2"]; !ok || len(rt.changedAt) != 1 {
+		t.Errorf("Expected only i-2 to be marked as changed, got %v", rt.changedAt)
+	}
+	if _, bg, _ := rt.resourceTable.GetCell(1, 3).Style.Decompose(); bg != tview.Styles.ContrastBackgroundColor {
+		t.Errorf("Expected the changed row to be highlighted, got background %v", bg)
+	}
+
+	// Another service starts without highlights
+	rt.selectedService = "rds"
+	rt.updateResourceTable([]Resource{{ID: "db-1", State: "available"}})
+	if len(rt.changedAt) != 0 {
+		t.Errorf("Expected no changes for a new service, got %v", rt.changedAt)
+	}
+}
+
+func TestResourceStateColors(t *testing.T) {
+	rt, err := NewResourcesTab(nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create resources tab: %v", err)
+	}
+
+	// The view of the listing colors its own states on top of the shared ones
+	rt.selectedService = "reservations"
+	rt.updateResourceTable([]Resource{
+		{ID: "ri-1", State: "partly used"},
+		{ID: "ri-2", State: "unused"},
+		{ID: "sp-1", State: "Active"},
+		{ID: "sp-2", State: "retired"},
+	})
+	for row, want := range []tcell.Color{tcell.ColorYellow, tcell.ColorRed, tcell.ColorGreen, tcell.ColorWhite} {
+		cell := rt.resourceTable.GetCell(row+1, 3)
+		if fg, _, _ := cell.Style.Decompose(); fg != want {
+			t.Errorf("Expected %q in %v, got %v", cell.Text, want, fg)
+		}
+	}
+
+	if got := serviceViewOf("ses").StateColor("probation"); got != tcell.ColorYellow {
+		t.Errorf("Expected an SES account on probation in yellow, got %v", got)
+	}
+	if got := serviceViewOf("ec2").StateColor("probation"); got != tcell.ColorWhite {
+		t.Errorf("Expected other services to leave the SES states white, got %v", got)
+	}
+	if got := serviceViewOf("transfer").StateColor("start failed"); got != tcell.ColorRed {
+		t.Errorf("Expected a Transfer server that failed to start in red, got %v", got)
+	}
+	if got := serviceViewOf("datasync").StateColor("queued"); got != tcell.ColorYellow {
+		t.Errorf("Expected a queued DataSync task in yellow, got %v", got)
+	}
+	if got := serviceViewOf("batch").StateColor("disabled"); got != tcell.ColorYellow {
+		t.Errorf("Expected a disabled Batch job queue in yellow, got %v", got)
+	}
+	if got := serviceViewOf("sagemaker").StateColor("out of service"); got != tcell.ColorRed {
+		t.Errorf("Expected a SageMaker endpoint out of service in red, got %v", got)
+	}
+	if got := serviceViewOf("bedrock").StateColor("legacy"); got != tcell.ColorYellow {
+		t.Errorf("Expected a legacy Bedrock model in yellow, got %v", got)
+	}
+	if got := serviceViewOf("appconfig").StateColor("rolled back"); got != tcell.ColorRed {
+		t.Errorf("Expected a rolled back AppConfig profile in red, got %v", got)
+	}
+	if got := serviceViewOf("s3uploads").StateColor("stale uploads"); got != tcell.ColorRed {
+		t.Errorf("Expected a bucket with stale uploads in red, got %v", got)
+	}
+	if got := serviceViewOf("ecs").StateColor("degraded"); got != tcell.ColorYellow {
+		t.Errorf("Expected a degraded ECS service in yellow, got %v", got)
+	}
+	if got := serviceViewOf("alarms").StateColor("insufficient data"); got != tcell.ColorYellow {
+		t.Errorf("Expected an alarm without data in yellow, got %v", got)
+	}
+	for _, service := range []string{"cloudformation", "stacksets"} {
+		if got := serviceViewOf(service).StateColor("drifted"); got != tcell.ColorRed {
+			t.Errorf("Expected drifted %s in red, got %v", service, got)
+		}
+	}
+}
+
+func TestResourcesTabLoadError(t *testing.T) {
+	rt, err := NewResourcesTab(nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create resources tab: %v", err)
+	}
+
+	denied := fmt.Errorf("failed to describe instances: %w",
+		&smithy.GenericAPIError{Code: "UnauthorizedOperation", Message: "You are not authorized to perform this operation."})
+	rt.selectedService = "ec2"
+	rt.showLoadError("ec2", denied)
+
+	var rows []string
+	for row := 0; row < rt.resourceTable.GetRowCount(); row++ {
+		rows = append(rows, rt.resourceTable.GetCell(row, 0).Text)
+	}
+	text := strings.Join(rows, "\n")
+	for _, want := range []string{"Could not load EC2 Instances: access denied", "ec2:DescribeInstances", "Press r to retry"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in the error rows, got:\n%s", want, text)
+		}
+	}
+
+	// A failed reload keeps the listing that is already shown
+	rt.updateResourceTable([]Resource{{ID: "i-1", Name: "a", State: "running"}})
+	rt.showLoadError("ec2", denied)
+	if got := rt.resourceTable.GetCell(1, 1).Text; got != "i-1" {
+		t.Errorf("Expected the listing to stay, got %q in row 1", got)
+	}
+}
+
+func TestReservationUsage(t *testing.T) {
+	instance := func(instanceType, zone string, state types.InstanceStateName, lifecycle types.InstanceLifecycleType) types.Instance {
+		return types.Instance{
+			InstanceType:      types.InstanceType(instanceType),
+			State:             &types.InstanceState{Name: state},
+			Placement:         &types.Placement{AvailabilityZone: awssdk.String(zone)},
+			InstanceLifecycle: lifecycle,
+		}
+	}
+	instances := []types.Instance{
+		instance("t3.medium", "us-east-1a", types.InstanceStateNameRunning, ""),
+		instance("t3.medium", "us-east-1b", types.InstanceStateNameRunning, ""),
+		instance("t3.medium", "us-east-1b", types.InstanceStateNameStopped, ""),
+		instance("t3.medium", "us-east-1a", types.InstanceStateNameRunning, types.InstanceLifecycleTypeSpot),
+		instance("c6i.large", "us-east-1a", types.InstanceStateNameRunning, ""),
+	}
+	reserved := []types.ReservedInstances{
+		{InstanceType: "t3.medium", InstanceCount: awssdk.Int32(3), Scope: types.ScopeRegional},
+		{InstanceType: "t3.medium", InstanceCount: awssdk.Int32(1), Scope: types.ScopeAvailabilityZone, AvailabilityZone: awssdk.String("us-east-1b")},
+		{InstanceType: "m5.large", InstanceCount: awssdk.Int32(2), Scope: types.ScopeRegional},
+	}
+
+	got := reservationUsage(reserved, instances)
+	want := []int{1, 1, 0}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Reservation %d: expected %d in use, got %d", i, want[i], got[i])
+		}
+	}
+
+	message, color := reservationsSummary([]Resource{
+		{Type: typeReservedInstance, Details: map[string]interface{}{"Instance Count": 3, "In Use": 1}},
+		{Type: typeSavingsPlan, MonthlyCost: 0.25 * 730},
+	}, 0)
+	if message != "1 of 3 reserved instances in use, Savings Plans $0.25/h" || color != "yellow" {
+		t.Errorf("Unexpected summary %q (%s)", message, color)
+	}
+}
+
+func TestSESSummary(t *testing.T) {
+	account := func(state string, bounce, complaint float64) Resource {
+		res := sesAccountResource(clients.SendingAccount{
+			Max24HourSend:     200,
+			SentLast24Hours:   50,
+			SendingEnabled:    state != "paused",
+			EnforcementStatus: state,
+		}, "us-east-1")
+		res.Details["Bounce Rate"] = percent(bounce)
+		res.Details["Complaint Rate"] = percent(complaint)
+		return res
+	}
+	identity := Resource{Type: typeDomainIdentity}
+	suppressed := Resource{Type: typeSuppressedAddress}
+
+	tests := []struct {
+		name      string
+		resources []Resource
+		failed    int
+		message   string
+		color     string
+	}{
+		{"healthy", []Resource{account("HEALTHY", 0.01, 0.0005), identity, suppressed}, 0,
+			"Sent 50 of 200 today, 1 identities, 1 suppressed, bounce 1.00%, complaint 0.05%", "green"},
+		{"bounces under review", []Resource{account("HEALTHY", 0.06, 0), identity}, 0,
+			"Sent 50 of 200 today, 1 identities, 0 suppressed, bounce 6.00%, complaint 0.00%", "yellow"},
+		{"complaints risk a pause", []Resource{account("HEALTHY", 0, 0.006)}, 0,
+			"Sent 50 of 200 today, 0 identities, 0 suppressed, bounce 0.00%, complaint 0.60%", "red"},
+		{"sending paused", []Resource{account("paused", 0, 0)}, 0,
+			"Sent 50 of 200 today, 0 identities, 0 suppressed, bounce 0.00%, complaint 0.00%", "red"},
+		{"account failed to load", []Resource{identity}, 1, "1 identities, 0 suppressed", "yellow"},
+	}
+	for _, tt := range tests {
+		message, color := sesSummary(tt.resources, tt.failed)
+		if message != tt.message || color != tt.color {
+			t.Errorf("%s: got %q (%s), want %q (%s)", tt.name, message, color, tt.message, tt.color)
+		}
+	}
+}
+
+func TestCertificateExpiry(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	certificate := func(status string, expiresIn time.Duration, inUseBy ...string) Resource {
+		detail := clients.CertificateDetail{
+			ARN:     "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+			Domain:  "example.com",
+			Status:  status,
+			InUseBy: inUseBy,
+		}
+		if expiresIn != 0 {
+			detail.NotAfter = now.Add(expiresIn)
+		}
+		return certificateResource(detail, "us-east-1", now)
+	}
+
+	valid := certificate("ISSUED", 90*24*time.Hour)
+	if valid.Alert || valid.State != "issued, 90d left" || valid.ID != "abc" {
+		t.Errorf("Certificate valid for 90 days: alert %v, state %q, ID %q", valid.Alert, valid.State, valid.ID)
+	}
+	expiring := certificate("ISSUED", 29*24*time.Hour, "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/web/1")
+	if !expiring.Alert || expiring.State != "issued, 29d left" {
+		t.Errorf("Certificate expiring in 29 days: alert %v, state %q", expiring.Alert, expiring.State)
+	}
+	expired := certificate("EXPIRED", -3*24*time.Hour, "arn:aws:cloudfront::123456789012:distribution/E1")
+	if !expired.Alert || expired.State != "expired" {
+		t.Errorf("Expired certificate: alert %v, state %q", expired.Alert, expired.State)
+	}
+	pending := certificate("PENDING_VALIDATION", 0)
+	if pending.Alert || pending.State != "pending_validation" {
+		t.Errorf("Pending certificate: alert %v, state %q", pending.Alert, pending.State)
+	}
+
+	message, color := certificatesSummary([]Resource{valid, expiring, expired, pending}, 0)
+	if message != "4 certificates, 1 expire within 30 days, 1 expired but in use" || color != "red" {
+		t.Errorf("Unexpected summary %q (%s)", message, color)
+	}
+	message, color = certificatesSummary([]Resource{valid, pending}, 0)
+	if message != "2 certificates, 0 expire within 30 days" || color != "green" {
+		t.Errorf("Unexpected summary %q (%s)", message, color)
+	}
+}
+
+func TestWebACLResource(t *testing.T) {
+	acl := clients.WebACLDetail{
+		WebACLSummary: clients.WebACLSummary{
+			Name:  "web",
+			ARN:   "arn:aws:wafv2:us-east-1:123456789012:regional/webacl/web/1",
+			Scope: clients.WAFScopeRegional,
+		},
+		DefaultAction: "ALLOW",
+		Rules: []clients.WAFRule{
+			{Name: "common", Priority: 0, Action: "NONE"},
+			{Name: "rate", Priority: 1, Action: "BLOCK"},
+		},
+	}
+
+	used := webACLResource(acl, []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/web/1"}, "us-east-1")
+	if used.State != "active" || used.Details["Rules"] != "0 common (none); 1 rate (block)" {
+		t.Errorf("Used web ACL: state %q, rules %q", used.State, used.Details["Rules"])
+	}
+
+	unused := webACLResource(acl, nil, "us-east-1")
+	if unused.State != "unused" || unused.Details["Protects"] != "none" {
+		t.Errorf("Unused web ACL: state %q, protects %q", unused.State, unused.Details["Protects"])
+	}
+
+	acl.Scope = clients.WAFScopeCloudFront
+	cloudFront := webACLResource(acl, nil, "us-east-1")
+	if cloudFront.State != "active" || cloudFront.Region != "global" {
+		t.Errorf("CloudFront web ACL: state %q, region %q", cloudFront.State, cloudFront.Region)
+	}
+
+	message, color := wafSummary([]Resource{used, unused, cloudFront}, 0)
+	if message != "3 web ACLs, 1 protect nothing" || color != "green" {
+		t.Errorf("Unexpected summary %q (%s)", message, color)
+	}
+}
+
+func TestTrustedAdvisorSummary(t *testing.T) {
+	check := func(status string, savings float64) Resource {
+		return trustedAdvisorResource(clients.TrustedAdvisorCheck{
+			ID:                      "check",
+			Name:                    "Check",
+			Category:                "cost_optimizing",
+			Status:                  status,
+			EstimatedMonthlySavings: savings,
+		})
+	}
+
+	unavailable := check("not_available", 0)
+	if unavailable.State != "not available" || unavailable.Type != "Cost Optimization" {
+		t.Errorf("Unexpected check: state %q, type %q", unavailable.State, unavailable.Type)
+	}
+	if failing := check("error", 0); !failing.Alert {
+		t.Error("Expected a check in error to be an alert")
+	}
+
+	tests := []struct {
+		name      string
+		resources []Resource
+		message   string
+		color     string
+	}{
+		{"all ok", []Resource{check("ok", 0), unavailable}, "2 checks, 0 action recommended, 0 investigate", "green"},
+		{"savings", []Resource{check("warning", 97.09), check("warning", 40)}, "2 checks, 0 action recommended, 2 investigate, save $137.09/mo", "yellow"},
+		{"error", []Resource{check("error", 0), check("warning", 0)}, "2 checks, 1 action recommended, 1 investigate", "red"},
+	}
+	for _, tt := range tests {
+		message, color := trustedAdvisorSummary(tt.resources)
+		if message != tt.message || color != tt.color {
+			t.Errorf("%s: got %q (%s), want %q (%s)", tt.name, message, color, tt.message, tt.color)
+		}
+	}
+}
+
+func TestNewCriticalHealthEvents(t *testing.T) {
+	issue := clients.HealthEvent{ARN: "arn:aws:health:us-east-1::event/EC2/AWS_EC2_OPERATIONAL_ISSUE/1", Service: "EC2", Category: "issue", Status: "open", Region: "us-east-1"}
+	closed := clients.HealthEvent{ARN: "arn:aws:health:us-east-1::event/EC2/AWS_EC2_OPERATIONAL_ISSUE/2", Service: "EC2", Category: "issue", Status: "closed", Region: "us-east-1"}
+	scheduled := clients.HealthEvent{ARN: "arn:aws:health:us-east-1::event/RDS/AWS_RDS_SYSTEM_UPGRADE_SCHEDULED/3", Service: "RDS", Category: "scheduledChange", Status: "upcoming", Region: "us-east-1"}
+	other := clients.HealthEvent{ARN: "arn:aws:health:eu-west-1::event/S3/AWS_S3_OPERATIONAL_ISSUE/4", Service: "S3", Category: "issue", Status: "open", Region: "eu-west-1"}
+
+	seen := make(map[string]bool)
+	fresh := newCriticalHealthEvents([]clients.HealthEvent{issue, closed, scheduled}, seen)
+	if len(fresh) != 1 || fresh[0].ARN != issue.ARN {
+		t.Fatalf("Expected only the open issue, got %+v", fresh)
+	}
+	if banner := healthBanner(fresh); banner != "AWS Health: EC2 issue in us-east-1" {
+		t.Errorf("Unexpected banner %q", banner)
+	}
+
+	// Issues already announced are not announced again
+	fresh = newCriticalHealthEvents([]clients.HealthEvent{issue, other}, seen)
+	if len(fresh) != 1 || fresh[0].ARN != other.ARN {
+		t.Errorf("Expected only the new issue, got %+v", fresh)
+	}
+
+	resources := []Resource{healthEventResource(issue), healthEventResource(scheduled)}
+	if !resources[0].Alert || resources[0].ID != "1" || resources[1].Type != "Scheduled Change" {
+		t.Errorf("Unexpected resources %+v", resources)
+	}
+	message, color := healthSummary(resources)
+	if message != "2 events, 1 open issues, 1 upcoming changes" || color != "red" {
+		t.Errorf("Unexpected summary %q (%s)", message, color)
+	}
+}
+
+func TestRenderConcurrency(t *testing.T) {
+	reserved := int32(10)
+	concurrency := clients.LambdaConcurrency{
+		Reserved: &reserved,
+		Provisioned: []clients.ProvisionedConcurrency{
+			{Qualifier: "live", Requested: 5, Allocated: 2, Status: "IN_PROGRESS"},
+		},
+		Aliases:           []string{"live", "staging"},
+		AccountLimit:      1000,
+		AccountUnreserved: 890,
+	}
+	series := []clients.MetricSeries{
+		{Values: []float64{4, 10, 7}},
+		{Values: []float64{0, 3, 1}},
+	}
+
+	text := renderConcurrency(concurrency, series, 10)
+	for _, want := range []string{
+		"10 (890 of 1000 unreserved in the account)",
+		"5 requested, 2 allocated  [yellow]IN_PROGRESS",
+		"live, staging",
+		"latest 7, peak 10, reached the reserved concurrency",
+		"[red]",
+		"latest 1, total 4",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in:\n%s", want, text)
+		}
+	}
+
+	text = renderConcurrency(clients.LambdaConcurrency{AccountLimit: 1000, AccountUnreserved: 1000}, nil, 10)
+	for _, want := range []string{"none, shares the 1000 of 1000", "Aliases:[-] none", "no data"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in:\n%s", want, text)
+		}
+	}
+}
+
+func TestRenderTableCapacity(t *testing.T) {
+	res := tableResource(clients.TableDetail{
+		Name: "orders", Status: "ACTIVE", BillingMode: "PROVISIONED", ReadCapacity: 50, WriteCapacity: 10,
+		PartitionKey: clients.TableKey{Name: "orderId", Type: "S"},
+		GlobalIndexes: []clients.TableIndex{
+			{Name: "by-customer", PartitionKey: clients.TableKey{Name: "customerId", Type: "S"}, SortKey: clients.TableKey{Name: "placedAt", Type: "N"},
+				Projection: "KEYS_ONLY", ReadCapacity: 5, WriteCapacity: 5, ItemCount: 42, SizeBytes: 2048},
+			{Name: "by-date"},
+		},
+	}, "us-east-1")
+	if res.Type != "Provisioned Table (2 GSIs)" || res.Details["Read Capacity"] != "50 RCU" || res.Details["Indexes"] != "by-customer, by-date" {
+		t.Fatalf("Unexpected resource %+v", res)
+	}
+	if res.Details["Partition Key"] != "orderId (string)" || res.Details["Sort Key"] != nil || res.Details["Stream"] != "off" {
+		t.Errorf("Unexpected keys or stream %+v", res.Details)
+	}
+	if gsi := res.Details["GSI by-customer"]; gsi != "customerId (string) + placedAt (number); projects keys only; 5 RCU, 5 WCU; 42 items, 2.0 KB" {
+		t.Errorf("Unexpected index summary %q", gsi)
+	}
+
+	capacity := tableCapacity{
+		consumed:    [2][]float64{{20, 30, 25}, {9, 10, 10}},
+		provisioned: [2][]float64{{50, 50, 50}, {10, 10, 10}},
+		throttles:   [2][]float64{{0, 0, 0}, {0, 4, 2}},
+		scaling: []clients.ScalableTarget{{
+			ResourceID: "table/orders/index/by-customer",
+			Dimension:  "dynamodb:index:ReadCapacityUnits",
+			Min:        5,
+			Max:        100,
+			Policies:   []clients.ScalingPolicy{{Type: "TargetTrackingScaling", TargetValue: 70, Metric: "DynamoDBReadCapacityUtilization", ScaleInCooldown: 60, ScaleOutCooldown: 30}},
+		}},
+	}
+	text := renderTableCapacity(res, capacity, 10)
+	for _, want := range []string{
+		"latest 25.0, peak 30.0 of 50 provisioned (60%)",
+		"[yellow]",
+		"latest 10.0, peak 10.0 of 10 provisioned (100%)",
+		"Read throttle events         [green]none",
+		"total 6",
+		"index by-customer",
+		"ReadCapacityUnits",
+		"5 - 100",
+		"target 70% DynamoDBReadCapacityUtilization, cooldown in 60s / out 30s",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in:\n%s", want, text)
+		}
+	}
+
+	text = renderTableCapacity(res, tableCapacity{}, 10)
+	if !strings.Contains(text, "none, the provisioned capacity is fixed") {
+		t.Errorf("Expected a warning about fixed capacity in:\n%s", text)
+	}
+
+	if got := capacityPeriod(3 * time.Hour); got != 180 {
+		t.Errorf("Expected a 180s period for 3h, got %d", got)
+	}
+	if got := capacityPeriod(time.Hour); got != 60 {
+		t.Errorf("Expected a 60s period for 1h, got %d", got)
+	}
+}
+
+func TestRenderMessageFlow(t *testing.T) {
+	res := topicResource(clients.TopicDetail{Name: "orders-events", SubscriptionsActive: 3, SubscriptionsPending: 1}, "us-east-1")
+	if res.Type != "SNS Topic" || res.Details["Subscriptions"] != "3 confirmed, 1 pending" {
+		t.Fatalf("Unexpected resource %+v", res)
+	}
+
+	flow := messageFlow{
+		published: 1200,
+		failed:    3,
+		branches: []flowBranch{
+			{
+				subscription: clients.Subscription{Protocol: "sqs"},
+				queue:        &flowQueue{detail: clients.QueueDetail{Name: "orders-billing", Visible: 3, MaxReceives: 5}, oldest: 40 * time.Second},
+				deadLetter:   &flowQueue{detail: clients.QueueDetail{Name: "orders-billing-dlq", Visible: 12}, oldest: -1},
+				consumers:    []flowFunction{{name: "orders-worker", state: "Enabled", batchSize: 10, invocations: 40}},
+			},
+			{
+				subscription: clients.Subscription{Protocol: "sqs"},
+				queue:        &flowQueue{detail: clients.QueueDetail{Name: "orders-shipping", Visible: 20}, oldest: 52 * time.Minute},
+			},
+			{
+				subscription: clients.Subscription{Protocol: "sqs", Endpoint: "arn:aws:sqs:us-east-1:123456789012:orders-audit"},
+				err:          fmt.Errorf("AccessDenied"),
+			},
+			{
+				subscription: clients.Subscription{Protocol: "lambda"},
+				function:     &flowFunction{name: "orders-api", invocations: 900, errors: 7},
+			},
+			{subscription: clients.Subscription{Protocol: "email", Endpoint: "team@example.com", Pending: true}},
+		},
+	}
+	text := renderMessageFlow("orders-events", flow)
+	for _, want := range []string{
+		"Messages pile up in orders-billing-dlq (dead letters), orders-shipping",
+		"1200 published, [red]3 failed deliveries",
+		"├─▶ SQS orders-billing  [green]3 visible[-], 0 in flight, oldest sent 40s ago",
+		"│   ├─▶ Lambda orders-worker  40 invocations, 0 errors, 0 throttles (batch 10, enabled)",
+		"│   └─▶ DLQ orders-billing-dlq  [red]12 messages[-] after 5 failed receives",
+		"[red]20 visible[-], 0 in flight, oldest sent 52m ago  [red]◀ piling up",
+		"no Lambda consumer",
+		"├─▶ SQS orders-audit  [red]failed",
+		"├─▶ Lambda orders-api  900 invocations, [red]7 errors[-], 0 throttles",
+		"└─▶ email team@example.com  [yellow]pending confirmation",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in:\n%s", want, text)
+		}
+	}
+
+	text = renderMessageFlow("quiet", messageFlow{})
+	if !strings.Contains(text, "No queue is backing up") || !strings.Contains(text, "no subscriptions") {
+		t.Errorf("Expected an empty flow, got:\n%s", text)
+	}
+
+	if got := functionName("arn:aws:lambda:us-east-1:123456789012:function:orders-worker:live"); got != "orders-worker" {
+		t.Errorf("Expected orders-worker, got %q", got)
+	}
+}
+
+func TestObjectJobs(t *testing.T) {
+	ctx := context.Background()
+	svc := fake.NewS3Service()
+	var done, total int
+	progress := func(d, n int) { done, total = d, n }
+	keys := func(bucket, prefix string) []string {
+		objects, err := svc.ListObjects(ctx, bucket, prefix, true)
+		if err != nil {
+			t.Fatalf("Failed to list %s: %v", prefix, err)
+		}
+		var keys []string
+		for _, object := range objects {
+			keys = append(keys, object.Key+" "+object.StorageClass)
+		}
+		return keys
+	}
+
+	// Moving a prefix copies every object below it and deletes the sources
+	if err := copyObjects(ctx, svc, "acme-web-assets", "images/", "acme-order-exports", "web/images/", "", true, progress); err != nil {
+		t.Fatalf("Failed to move images: %v", err)
+	}
+	if done != 2 || total != 2 {
+		t.Errorf("Expected 2 of 2 objects moved, got %d of %d", done, total)
+	}
+	if got := keys("acme-order-exports", "web/"); strings.Join(got, ",") != "web/images/hero.jpg STANDARD,web/images/logo.png STANDARD" {
+		t.Errorf("Unexpected moved objects %v", got)
+	}
+	if got := keys("acme-web-assets", "images/"); len(got) != 0 {
+		t.Errorf("Expected the moved objects to be deleted, got %v", got)
+	}
+
+	// Changing the storage class copies objects onto themselves
+	if err := copyObjects(ctx, svc, "acme-web-assets", "index.html", "acme-web-assets", "index.html", "STANDARD_IA", false, progress); err != nil {
+		t.Fatalf("Failed to change the storage class: %v", err)
+	}
+	if got := keys("acme-web-assets", "index.html"); len(got) != 1 || got[0] != "index.html STANDARD_IA" {
+		t.Errorf("Expected index.html in STANDARD_IA, got %v", got)
+	}
+
+	// Archived objects cannot be copied; the others still are
+	err := copyObjects(ctx, svc, "acme-order-exports", "", "acme-backups-replica", "exports/", "", false, progress)
+	var partial *clients.PartialError
+	if !errors.As(err, &partial) || len(partial.Failures) != 1 || partial.Failures[0].Item != "archive/2025/orders.csv.gz" {
+		t.Errorf("Expected the archived object to fail, got %v", err)
+	}
+	if got := jobError(jobs.Job{Noun: "object", Err: err}); !strings.HasPrefix(got, "1 failed, InvalidObjectState") {
+		t.Errorf("Unexpected job error %q", got)
+	}
+
+	// Objects under legal hold are not deleted with their prefix
+	err = deleteObjects(ctx, svc, "acme-order-exports", "legal-hold/", progress)
+	if !errors.As(err, &partial) || len(partial.Failures) != 1 {
+		t.Errorf("Expected the held object to fail, got %v", err)
+	}
+	if err := deleteObjects(ctx, svc, "acme-web-assets", "robots.txt", progress); err != nil || done != 1 {
+		t.Errorf("Expected robots.txt deleted, got %v after %d", err, done)
+	}
+	if err := deleteObjects(ctx, svc, "acme-web-assets", "robots.txt", progress); err == nil {
+		t.Error("Expected an error for a missing object")
+	}
+
+	for prefix, want := range map[string]string{"": "", "css/": "", "exports/2026/": "exports/"} {
+		if got := parentPrefix(prefix); got != want {
+			t.Errorf("parentPrefix(%q): expected %q, got %q", prefix, want, got)
+		}
+	}
+}
+
+func TestObjectMovePartialFailure(t *testing.T) {
+	ctx := context.Background()
+	svc := fake.NewS3Service()
+	progress := func(int, int) {}
+
+	// The archived object fails to copy, the held one to be deleted
+	err := copyObjects(ctx, svc, "acme-order-exports", "", "acme-backups-replica", "moved/", "", true, progress)
+	var partial *clients.PartialError
+	if !errors.As(err, &partial) || len(partial.Failures) != 2 {
+		t.Fatalf("Expected the archived and the held object to fail, got %v", err)
+	}
+
+	left, err := svc.ListObjects(ctx, "acme-order-exports", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, object := range left {
+		keys = append(keys, object.Key)
+	}
+	// A source whose copy failed is never deleted
+	if strings.Join(keys, ",") != "archive/2025/orders.csv.gz,legal-hold/case-1182/orders.csv" {
+		t.Errorf("Expected only the failed objects left, got %v", keys)
+	}
+	moved, err := svc.ListObjects(ctx, "acme-backups-replica", "moved/", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range moved {
+		if object.Key == "moved/archive/2025/orders.csv.gz" {
+			t.Error("Expected the archived object not to be copied")
+		}
+	}
+}
+
+func TestRenderObjectPreview(t *testing.T) {
+	modified := time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		preview clients.ObjectPreview
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "json is pretty-printed",
+			preview: clients.ObjectPreview{
+				Key: "config/site.json", Size: 29, ContentType: "application/json", LastModified: modified,
+				Metadata: map[string]string{"uploaded-by": "ci-pipeline"},
+				Body:     []byte(`{"name":"acme","cdn":{"ttl":60}}`),
+			},
+			want:    []string{"Content-Type       application/json", "x-amz-meta-uploaded-by ci-pipeline", "── JSON ──", "  \"cdn\": {\n    \"ttl\": 60"},
+			notWant: []string{"previewing the first", "cut off"},
+		},
+		{
+			name: "cut off yaml drops the partial line",
+			preview: clients.ObjectPreview{
+				Key: "flags.yaml", Size: 4096,
+				Body: []byte("flags:\n    dark-mode: true\nowner: web-te"),
+			},
+			want:    []string{"previewing the first 40 B", "── YAML ──", "flags:\n  dark-mode: true", "cut off, the rest"},
+			notWant: []string{"web-te"},
+		},
+		{
+			name: "gzip is decompressed",
+			preview: clients.ObjectPreview{
+				Key: "logs/access.log.gz", Size: 40, ContentEncoding: "gzip",
+				Body: gzipped(t, "GET /index.html 200\n"),
+			},
+			want: []string{"Content-Encoding   gzip", "── Text, gzip-decompressed ──", "GET /index.html 200"},
+		},
+		{
+			name: "binary is shown as hex",
+			preview: clients.ObjectPreview{
+				Key: "images/logo.png", Size: 8, ContentType: "image/png",
+				Body: []byte("\x89PNG\r\n\x1a\n\x00\x00"),
+			},
+			want: []string{"── Binary, first 10 bytes ──", "89 50 4e 47"},
+		},
+		{
+			name:    "empty object",
+			preview: clients.ObjectPreview{Key: "marker"},
+			want:    []string{"Empty object"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := renderObjectPreview("s3://acme/"+tt.preview.Key, tt.preview)
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("Expected %q in the preview:\n%s", want, text)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(text, notWant) {
+					t.Errorf("Did not expect %q in the preview:\n%s", notWant, text)
+				}
+			}
+		})
+	}
+}
+
+// gzipped compresses text
+func gzipped(t *testing.T, text string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(text)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestFillPods(t *testing.T) {
+	pods := []clients.Pod{
+		{Name: "cart-1", Namespace: "shop", Status: "CrashLoopBackOff", Ready: 0, Containers: []string{"app"}, Restarts: 14, CreatedAt: time.Now().Add(-6 * time.Hour)},
+		{Name: "frontend-1", Namespace: "shop", Status: "Running", Ready: 2, Containers: []string{"app", "envoy"}, CreatedAt: time.Now().Add(-50 * time.Hour)},
+	}
+
+	table := tview.NewTable()
+	fillPods(table, pods, true, nil)
+	if got := table.GetCell(0, 0).Text; got != "Namespace" {
+		t.Errorf("Expected a namespace column across namespaces, got %q", got)
+	}
+	if pod, ok := table.GetCell(1, 0).GetReference().(clients.Pod); !ok || pod.Name != "cart-1" {
+		t.Errorf("Expected the pod as reference of its row, got %v", table.GetCell(1, 0).GetReference())
+	}
+	if fg, _, _ := table.GetCell(1, 3).Style.Decompose(); table.GetCell(1, 3).Text != "CrashLoopBackOff" || fg != tcell.ColorRed {
+		t.Errorf("Expected a red CrashLoopBackOff, got %q", table.GetCell(1, 3).Text)
+	}
+	for col, want := range []string{"shop", "frontend-1", "2/2", "Running", "0", "2d"} {
+		if got := table.GetCell(2, col).Text; got != want {
+			t.Errorf("Column %d: expected %q, got %q", col, want, got)
+		}
+	}
+
+	fillPods(table, pods, false, nil)
+	if got := table.GetCell(0, 0).Text; got != "Name" {
+		t.Errorf("Expected no namespace column in a namespace, got %q", got)
+	}
+	if pod, ok := table.GetCell(1, 0).GetReference().(clients.Pod); !ok || pod.Name != "cart-1" {
+		t.Errorf("Expected the pod as reference of the name, got %v", table.GetCell(1, 0).GetReference())
+	}
+
+	fillPods(table, nil, false, errors.New("pods is forbidden"))
+	if got := table.GetCell(0, 0).Text; !strings.Contains(got, "pods is forbidden") {
+		t.Errorf("Expected the error, got %q", got)
+	}
+}
+
+func TestFillTopSQLAndWaits(t *testing.T) {
+	load := clients.DBLoad{
+		Average: 2.0,
+		TopSQL: []clients.DBLoadItem{
+			{ID: "B", Name: "COMMIT", Load: 0.2},
+			{ID: "A", Name: "SELECT *\n  FROM orders\n  WHERE id = $1", Load: 1.5},
+		},
+		TopWaits: []clients.DBLoadItem{
+			{Name: "IO:DataFileRead", Type: "IO", Load: 0.5},
+			{Name: "Lock:transactionid", Type: "Lock", Load: 1.0},
+		},
+	}
+
+	table := tview.NewTable()
+	fillTopSQL(table, load)
+	item, ok := table.GetCell(1, 0).GetReference().(clients.DBLoadItem)
+	if !ok || item.ID != "A" {
+		t.Fatalf("Expected the heaviest statement first, got %v", table.GetCell(1, 0).GetReference())
+	}
+	for col, want := range []string{"1.50", "75.0%", "███████████████", "SELECT * FROM orders WHERE id = $1"} {
+		if got := table.GetCell(1, col).Text; got != want {
+			t.Errorf("Column %d: expected %q, got %q", col, want, got)
+		}
+	}
+
+	fillTopWaits(table, load)
+	if got := table.GetCell(1, 4).Text; got != "Lock:transactionid" {
+		t.Errorf("Expected the lock wait first, got %q", got)
+	}
+	if fg, _, _ := table.GetCell(1, 3).Style.Decompose(); fg != tcell.ColorRed {
+		t.Errorf("Expected lock waits in red, got %v", fg)
+	}
+
+	fillTopSQL(table, clients.DBLoad{})
+	if got := table.GetCell(1, 0).Text; got != "No SQL ran in this window" {
+		t.Errorf("Expected an empty window message, got %q", got)
+	}
+}
+
+func TestParameterGroupResource(t *testing.T) {
+	group := clients.ParameterGroup{
+		Name:   "orders-postgres16",
+		Family: "postgres16",
+		Instances: []clients.ParameterGroupMember{
+			{Instance: "orders-prod", ApplyStatus: "pending-reboot"},
+			{Instance: "orders-replica", ApplyStatus: "in-sync"},
+		},
+	}
+	res := parameterGroupResource(group, "us-east-1")
+	if res.State != "pending reboot" || res.Details["Reboot Pending"] != "orders-prod" {
+		t.Errorf("Expected a reboot pending on orders-prod, got %q, %v", res.State, res.Details["Reboot Pending"])
+	}
+	if got := res.Details["Instances"]; got != "orders-prod (pending-reboot), orders-replica (in-sync)" {
+		t.Errorf("Unexpected instances %q", got)
+	}
+
+	group.Instances = group.Instances[1:]
+	if res := parameterGroupResource(group, "us-east-1"); res.State != "in sync" {
+		t.Errorf("Expected in sync, got %q", res.State)
+	}
+	group.Instances = nil
+	if res := parameterGroupResource(group, "us-east-1"); res.State != "unused" {
+		t.Errorf("Expected unused, got %q", res.State)
+	}
+}
+
+func TestFillParameterDiffs(t *testing.T) {
+	diffs := []clients.ParameterDiff{
+		{Name: "max_connections", Value: "400", Default: "LEAST({DBInstanceClassMemory/9531392},5000)", ApplyType: "static"},
+		{Name: "rds.force_ssl", Value: "1", Default: "1", ApplyType: "dynamic"},
+		{Name: "work_mem", Value: "16384", ApplyType: "dynamic"},
+	}
+
+	table := tview.NewTable()
+	fillParameterDiffs(table, diffs, true)
+	if got := table.GetCell(1, 4).Text; got != "reboot pending" {
+		t.Errorf("Expected a static parameter to wait for the reboot, got %q", got)
+	}
+	if fg, _, _ := table.GetCell(2, 0).Style.Decompose(); table.GetCell(2, 4).Text != "same as default" || fg != tcell.ColorGray {
+		t.Errorf("Expected a parameter set to its default in gray, got %q", table.GetCell(2, 4).Text)
+	}
+	if got := table.GetCell(3, 2).Text; got != "(engine default)" {
+		t.Errorf("Expected a parameter without default to say so, got %q", got)
+	}
+	if diff, ok := table.GetCell(3, 0).GetReference().(clients.ParameterDiff); !ok || diff.Name != "work_mem" {
+		t.Errorf("Expected the parameter as reference of its row, got %v", table.GetCell(3, 0).GetReference())
+	}
+
+	fillParameterDiffs(table, diffs, false)
+	if got := table.GetCell(1, 4).Text; got != "" {
+		t.Errorf("Expected no note without a pending reboot, got %q", got)
+	}
+}
+
+func TestAddNATTraffic(t *testing.T) {
+	week := natTrafficWindow.Hours()
+	quiet := natTraffic{toDestination: 1e9 * week, fromSource: 1e9 * week, fromDestination: 0.5e9 * week}
+	busy := natTraffic{toDestination: 0.4e9 * week, fromSource: 0.4e9 * week, fromDestination: 20e9 * week}
+
+	median := medianMonthlyGB(map[string]natTraffic{"a": quiet, "b": quiet, "c": busy})
+	if math.Abs(median-quiet.monthlyGB()) > 0.001 {
+		t.Errorf("Expected the median to be the quiet gateway, got %.1f GB", median)
+	}
+
+	res := Resource{Details: map[string]interface{}{}}
+	addNATTraffic(&res, quiet, median, "us-east-1")
+	if res.Alert || res.Details["Flag"] != nil {
+		t.Errorf("Expected no flag for a median gateway, got %v", res.Details["Flag"])
+	}
+	// 1.5 GB an hour is 1095 GB a month: $32.85 hourly and $49.28 processing
+	if math.Abs(res.MonthlyCost-82.125) > 0.01 || res.Details["Processed per Month (est.)"] != "1095 GB" {
+		t.Errorf("Unexpected estimate $%.3f for %v", res.MonthlyCost, res.Details["Processed per Month (est.)"])
+	}
+
+	res = Resource{Details: map[string]interface{}{}}
+	addNATTraffic(&res, busy, median, "us-east-1")
+	flag, _ := res.Details["Flag"].(string)
+	if !res.Alert || !strings.Contains(flag, "VPC endpoint") {
+		t.Errorf("Expected a flagged download-heavy gateway, got %q", flag)
+	}
+
+	if isHighNATTraffic(50, 1) {
+		t.Error("Expected small gateways not to be flagged")
+	}
+	if !isHighNATTraffic(natHighTrafficGB, natHighTrafficGB) {
+		t.Error("Expected a gateway above the absolute threshold to be flagged")
+	}
+}
+
+func TestCompareResources(t *testing.T) {
+	left := Resource{
+		ID: "sg-1", Name: "web", Type: "Security Group", Region: "us-east-1",
+		Tags: map[string]string{"Name": "web", "env": "prod"},
+		Details: map[string]interface{}{
+			"Rules": []types.IpPermission{{IpProtocol: awssdk.String("tcp"), FromPort: awssdk.Int32(443)}},
+			"Port":  443,
+		},
+	}
+	right := Resource{
+		ID: "sg-2", Name: "web-staging", Type: "Security Group", Region: "us-east-1",
+		Tags: map[string]string{"Name": "web-staging", "env": "staging", "team": "web"},
+		Details: map[string]interface{}{
+			"Rules": []types.IpPermission{{IpProtocol: awssdk.String("tcp"), FromPort: awssdk.Int32(80)}},
+			"Port":  443,
+		},
+	}
+
+	got := make(map[string]attributeDiff)
+	for _, d := range compareResources(left, right) {
+		got[d.name] = d
+	}
+	if _, ok := got["Tags.Name"]; ok {
+		t.Error("Expected the Name tag to be left out")
+	}
+	for name, want := range map[string]attributeDiff{
+		"Tags.env":            {left: "prod", right: "staging"},
+		"Tags.team":           {left: "", right: "web"},
+		"Rules[0].FromPort":   {left: "443", right: "80"},
+		"Rules[0].IpProtocol": {left: "tcp", right: "tcp"},
+		"Port":                {left: "443", right: "443"},
+		"Type":                {left: "Security Group", right: "Security Group"},
+	} {
+		d, ok := got[name]
+		if !ok || d.left != want.left || d.right != want.right {
+			t.Errorf("%s: expected %q vs %q, got %+v (%v)", name, want.left, want.right, d, ok)
+		}
+	}
+	if got["Port"].differs() || !got["Rules[0].FromPort"].differs() {
+		t.Error("Expected only differing values to differ")
+	}
+}
+
+func TestResourceActions(t *testing.T) {
+	rt, err := NewResourcesTab(nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create resources tab: %v", err)
+	}
+	rt.selectedService = "ec2"
+
+	names := func(actions []resourceAction) []string {
+		var names []string
+		for _, action := range actions {
+			names = append(names, action.name)
+		}
+		return names
+	}
+	got := strings.Join(names(actionsFor("ec2", "", false)), ", ")
+	for _, want := range []string{"refresh", "ec2 start", "ec2 stop", "ec2 schedule", "compare"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q among the EC2 actions, got %s", want, got)
+		}
+	}
+	if strings.Contains(got, "lambda") {
+		t.Errorf("Expected no Lambda actions for EC2, got %s", got)
+	}
+	if got := names(actionsFor("ec2", "STOP THE", true)); len(got) != 1 || got[0] != "ec2 stop" {
+		t.Errorf("Expected the palette filter to match the stop action, got %v", got)
+	}
+	for _, action := range actionsFor("ec2", "", true) {
+		if !action.onResource {
+			t.Errorf("Expected only actions on the resource in its menu, got %s", action.name)
+		}
+	}
+
+	if action, ok := actionForKey('s', "ec2"); !ok || action.name != "ec2 start" {
+		t.Errorf("Expected s to start an instance, got %+v", action)
+	}
+	// Keys of other services still run and tell where they apply
+	if action, ok := actionForKey('l', "ec2"); !ok || action.name != "lambda logs" {
+		t.Errorf("Expected l to run the Lambda logs action, got %+v", action)
+	}
+
+	start, _ := actionForKey('s', "ec2")
+	if reason := rt.actionUnavailable(start); reason != "Select a resource first" {
+		t.Errorf("Expected start to need a resource, got %q", reason)
+	}
+	rt.selectedRes = &Resource{ID: "i-1", Name: "web"}
+	rt.permissions[permissionKey("i-1", "ec2:StartInstances")] = permissionDenied
+	if perm := rt.missingPermission(start); perm != "ec2:StartInstances" {
+		t.Errorf("Expected start to miss its permission, got %q", perm)
+	}
+	if item := rt.actionItem(start); !strings.HasPrefix(item, "[gray]") {
+		t.Errorf("Expected the denied action to be grayed out, got %q", item)
+	}
+	stop, _ := actionForKey('p', "ec2")
+	if reason, perm := rt.actionUnavailable(stop), rt.missingPermission(stop); reason != "" || perm != "" {
+		t.Errorf("Expected stop to be available, got %q %q", reason, perm)
+	}
+	rt.offline = map[string]snapshot.Listing{}
+	if reason := rt.actionUnavailable(stop); reason != offlineStatus() {
+		t.Errorf("Expected stop to be unavailable offline, got %q", reason)
+	}
+	if reason := rt.actionUnavailable(start); reason != offlineStatus() {
+		t.Errorf("Expected offline to be told first, got %q", reason)
+	}
+
+	help := resourceActionsHelp()
+	for _, action := range allResourceActions() {
+		if description := i18n.T(action.description); description == action.description || !strings.Contains(help, description) {
+			t.Errorf("Expected the help to describe %s", action.name)
+		}
+	}
+}
+
+func TestActionPermissionsChecked(t *testing.T) {
+	checked := map[string]bool{}
+	for _, action := range instanceActions {
+		checked[action.permission] = true
+	}
+	// A permission no preflight checks would never disable its action
+	for _, action := range allResourceActions() {
+		for _, perm := range action.permissions {
+			if !checked[perm] {
+				t.Errorf("Expected %s to be checked by a preflight for %s", perm, action.name)
+			}
+		}
+	}
+}
+
+func TestServiceViews(t *testing.T) {
+	general := make(map[rune]string)
+	for _, action := range resourceActions {
+		general[action.key] = action.name
+	}
+
+	names := make(map[string]bool)
+	for _, view := range serviceViews {
+		info := view.Info()
+		if names[info.Name] {
+			t.Errorf("Expected one view of %s", info.Name)
+		}
+		names[info.Name] = true
+		if info.Enabled && info.Permission == "" {
+			t.Errorf("Expected %s to name the permission its listing needs", info.Name)
+		}
+		if len(view.Columns()) != len(resourceHeaders) {
+			t.Errorf("Expected %s to have the %d columns of the table, got %v", info.Name, len(resourceHeaders), view.Columns())
+		}
+		for _, column := range view.Columns() {
+			if columnTitle(column) == columnKey(column) {
+				t.Errorf("Expected the %s column of %s in the catalogs", column, info.Name)
+			}
+		}
+
+		keys := make(map[rune]string)
+		for _, action := range view.Actions() {
+			if name, ok := general[action.key]; ok {
+				t.Errorf("%s: key %c of %s is taken by %s", info.Name, action.key, action.name, name)
+			}
+			if name, ok := keys[action.key]; ok {
+				t.Errorf("%s: key %c of %s is taken by %s", info.Name, action.key, action.name, name)
+			}
+			keys[action.key] = action.name
+		}
+	}
+
+	ecs := serviceViewOf("ecs")
+	if got := ecs.Columns()[costColumn]; got != findingsColumn {
+		t.Errorf("Expected ECS services to show %s, got %s", findingsColumn, got)
+	}
+	cell := ecs.Cell(Resource{Details: map[string]interface{}{findingsColumn: "2 critical"}}, costColumn)
+	if cell == nil || cell.Text != "2 critical" {
+		t.Errorf("Expected the findings in the cost column, got %+v", cell)
+	}
+	if serviceViewOf("ec2").Cell(Resource{MonthlyCost: 10}, costColumn) != nil {
+		t.Error("Expected EC2 instances to show their cost")
+	}
+	if noun := serviceViewOf("lambda").Noun(); noun != "function" {
+		t.Errorf("Expected Lambda warnings to name functions, got %q", noun)
+	}
+
+	if _, err := serviceViewOf("unknown").Load(context.Background(), nil, nil); err == nil || !strings.Contains(err.Error(), "not implemented") {
+		t.Errorf("Expected an unknown service not to load, got %v", err)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	old := configLines([]byte(`{"values":{"a":{"enabled":false},"b":{"enabled":true}}}`))
+	new := configLines([]byte(`{"values":{"a":{"enabled":true},"b":{"enabled":true},"c":{"enabled":false}}}`))
+
+	var removed, added []string
+	for _, line := range diffLines(old, new) {
+		switch line.op {
+		case '-':
+			removed = append(removed, strings.TrimSpace(line.text))
+		case '+':
+			added = append(added, strings.TrimSpace(line.text))
+		}
+	}
+	if strings.Join(removed, " ") != `"enabled": false` {
+		t.Errorf("Expected only the flag of a removed, got %q", removed)
+	}
+	if strings.Join(added, " ") != `"enabled": true }, "c": { "enabled": false` {
+		t.Errorf("Expected the flag of a and c added, got %q", added)
+	}
+
+	text := renderLineDiff(diffLines(old, new), 1)
+	if !strings.Contains(text, `[red]-       "enabled": false[-]`) || !strings.Contains(text, "[gray]  ...[-]") {
+		t.Errorf("Expected a colored diff eliding unchanged lines, got:\n%s", text)
+	}
+	if text := renderLineDiff(diffLines(old, old), 3); !strings.Contains(text, "identical") {
+		t.Errorf("Expected identical versions to say so, got %q", text)
+	}
+}
+
+func TestIncompleteUploadsResource(t *testing.T) {
+	now := time.Now()
+	uploads := clients.BucketUploads{
+		Bucket: "backups",
+		Region: "us-east-1",
+		Uploads: []clients.MultipartUpload{
+			{Key: "db/old.dump", Initiated: now.Add(-30 * 24 * time.Hour), StorageClass: "STANDARD", Parts: 10, Size: 10 << 30},
+			{Key: "db/new.dump", Initiated: now.Add(-time.Hour), StorageClass: "STANDARD", Parts: 1, Size: 5 << 20},
+		},
+	}
+
+	res := incompleteUploadsResource(uploads, now)
+	if res.State != "stale uploads" || !res.Alert {
+		t.Errorf("Expected stale uploads without an abort rule flagged, got %q %v", res.State, res.Alert)
+	}
+	if flag := detailString(res, "Flag"); !strings.Contains(flag, "1 upload older than 7 days") || !strings.Contains(flag, "no lifecycle rule") {
+		t.Errorf("Unexpected flag %q", flag)
+	}
+	if got := detailString(res, "Incomplete Uploads"); !strings.HasPrefix(got, "2 (") || !strings.HasSuffix(got, "in 11 parts)") {
+		t.Errorf("Unexpected uploads %q", got)
+	}
+	if res.MonthlyCost < 0.23 || res.MonthlyCost > 0.24 {
+		t.Errorf("Expected about $0.23 a month for 10 GB in Standard, got %v", res.MonthlyCost)
+	}
+
+	// A rule aborts the uploads eventually; they are no alert
+	uploads.AbortAfterDays = 45
+	if res := incompleteUploadsResource(uploads, now); res.Alert || detailString(res, "Abort Rule") != "aborts uploads 45 days after they started" {
+		t.Errorf("Expected no alert with an abort rule, got %+v", res)
+	}
+	uploads.Uploads = uploads.Uploads[1:]
+	running := incompleteUploadsResource(uploads, now)
+	if running.State != "uploading" || detailString(running, "Flag") != "" {
+		t.Errorf("Expected a recent upload to be running, got %+v", running)
+	}
+	clean := incompleteUploadsResource(clients.BucketUploads{Bucket: "assets"}, now)
+	if clean.State != "clean" || clean.MonthlyCost != 0 {
+		t.Errorf("Expected a bucket without uploads clean, got %+v", clean)
+	}
+
+	message, color := incompleteUploadsSummary([]Resource{res, running, clean}, 0)
+	if message != "3 buckets, 2 holding incomplete uploads ($0.23/month), 2 without an abort rule" || color != "red" {
+		t.Errorf("Unexpected summary %q %s", message, color)
+	}
+}
+
+func TestCleanupCandidates(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+	image := clients.UnusedImage{ID: "ami-old", Name: "web-2024", CreatedAt: now.Add(-400 * day), Snapshots: []string{"snap-root", "snap-data"}, SizeGB: 100}
+	orphan := clients.UnusedSnapshot{ID: "snap-orphan", VolumeID: "vol-gone", StorageTier: "archive", StartedAt: now.Add(-200 * day), SizeGB: 400}
+	recent := clients.UnusedSnapshot{ID: "snap-recent", VolumeID: "vol-tmp", StartedAt: now.Add(-3 * day), SizeGB: 8}
+
+	res := unusedImageResource(image, "us-east-1", now)
+	if detailString(res, "Age Group") != "over 1 year" || detailString(res, "Snapshots") != "snap-root, snap-data" || res.MonthlyCost != 5 {
+		t.Errorf("Unexpected image %+v", res)
+	}
+	archived := unusedSnapshotResource(orphan, "us-east-1", now)
+	if archived.Name != "vol-gone" || detailString(archived, "Age Group") != "6 to 12 months" || archived.MonthlyCost != 5 {
+		t.Errorf("Expected the archived snapshot named after its volume, got %+v", archived)
+	}
+	if got := cleanupAgeGroup(recent.StartedAt, now); got != "under 1 month" {
+		t.Errorf("Expected a recent snapshot under 1 month, got %q", got)
+	}
+
+	message, color := cleanupSummary([]Resource{res, archived}, 0)
+	if message != "1 AMI and 1 snapshot unused, 500 GB ($10.00/month)" || color != "yellow" {
+		t.Errorf("Unexpected summary %q %s", message, color)
+	}
+	if _, color := cleanupSummary(nil, 0); color != "green" {
+		t.Errorf("Expected nothing to clean up green, got %s", color)
+	}
+
+	// A refused snapshot of the image keeps the whole image
+	plan := cleanupPlan{days: 0, images: []clients.UnusedImage{image}, snapshots: []clients.UnusedSnapshot{orphan, recent},
+		refused: map[string]string{"snap-data": "access denied"}}
+	images, snapshots := plan.passed()
+	if len(images) != 0 || len(snapshots) != 2 {
+		t.Errorf("Expected only the snapshots to pass, got %v %v", images, snapshots)
+	}
+	report := renderCleanupReport(plan, "us-east-1", now)
+	for _, want := range []string{
+		"over 1 year[-]: 1 AMI, 0 snapshots, 100 GB, $5.00/month",
+		"refused, snap-data: access denied",
+		"under 1 month[-]: 0 AMIs, 1 snapshot, 8 GB",
+		"0 AMIs and 2 snapshots (408 GB) would be cleaned up; press d to clean them up, the 1 refused are kept",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected %q in the report:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "6 to 12 months[-]: 1 AMI") {
+		t.Errorf("Expected the image in its own group only:\n%s", report)
+	}
+
+	// Images other accounts may launch are flagged, not left out
+	image.SharedWith = []string{"222233334444", "public"}
+	if res := unusedImageResource(image, "us-east-1", now); detailString(res, "Shared With") != "222233334444, public" {
+		t.Errorf("Expected who the image is shared with, got %+v", res.Details)
+	}
+	report = renderCleanupReport(cleanupPlan{images: []clients.UnusedImage{image}, refused: map[string]string{}}, "us-east-1", now)
+	if !strings.Contains(report, "shared with 222233334444, public, which may still launch it") ||
+		!strings.Contains(report, "1 AMI and 0 snapshots (100 GB) would be cleaned up") {
+		t.Errorf("Expected the shared image flagged:\n%s", report)
+	}
+}
+
+func TestAlarmTimeline(t *testing.T) {
+	end := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	start := end.Add(-8 * time.Hour)
+	transitions := []clients.AlarmTransition{
+		// A flap within the third slot shows as ALARM
+		{At: start.Add(2*time.Hour + 10*time.Minute), From: "OK", To: "ALARM"},
+		{At: start.Add(2*time.Hour + 20*time.Minute), From: "ALARM", To: "OK"},
+		{At: start.Add(5*time.Hour + 30*time.Minute), From: "OK", To: "INSUFFICIENT_DATA"},
+		{At: start.Add(7 * time.Hour), From: "INSUFFICIENT_DATA", To: "ALARM"},
+	}
+	strip, _, _ := strings.Cut(alarmTimeline(transitions, "ALARM", start, end, 8), "\n")
+	want := "[green]█[green]█[red]█[green]█[green]█[yellow]█[yellow]█[red]█[-]"
+	if strip != want {
+		t.Errorf("alarmTimeline() = %q, want %q", strip, want)
+	}
+
+	// Without transitions the alarm stayed in its current state
+	strip, _, _ = strings.Cut(alarmTimeline(nil, "OK", start, end, 2), "\n")
+	if strip != "[green]█[green]█[-]" {
+		t.Errorf("Expected a steady OK, got %q", strip)
+	}
+
+	res := alarmResource(clients.AlarmDetail{Name: "health", Type: "CompositeAlarm", State: "INSUFFICIENT_DATA", Rule: "ALARM(a)"}, "us-east-1")
+	if res.State != "insufficient data" || detailString(res, "Rule") != "ALARM(a)" || res.Alert {
+		t.Errorf("Unexpected composite alarm %+v", res)
+	}
+	firing := alarmResource(clients.AlarmDetail{Name: "a", Type: "MetricAlarm", State: "ALARM", ActionsEnabled: true}, "us-east-1")
+	if !firing.Alert || detailString(firing, "Actions") != "enabled" {
+		t.Errorf("Expected the alarm flagged, got %+v", firing)
+	}
+	message, color := alarmsSummary([]Resource{res, firing}, 0)
+	if message != "2 alarms, 1 in alarm, 1 without data, 1 composite" || color != "red" {
+		t.Errorf("Unexpected summary %q %s", message, color)
+	}
+}
@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"swiss-army-tui/internal/i18n"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// searchResultLimit is the number of matches the search overlay lists
+const searchResultLimit = 50
+
+// resourceSearch is the open search overlay over all loaded resources
+type resourceSearch struct {
+	input  *tview.InputField
+	table  *tview.Table
+	status *tview.TextView
+	// Search the listings of all profiles and regions, not only the current one
+	all  bool
+	hits []resourceHit
+}
+
+// showSearch opens the search overlay over the resource listings loaded so far
+func (app *App) showSearch() {
+	if app.search != nil {
+		app.app.SetFocus(app.search.input)
+		return
+	}
+
+	s := &resourceSearch{
+		input:  tview.NewInputField().SetLabel(i18n.T("search.label") + ": ").SetFieldWidth(0),
+		table:  tview.NewTable().SetSelectable(true, false).SetFixed(1, 0),
+		status: tview.NewTextView().SetDynamicColors(true),
+	}
+	app.search = s
+	app.closeOverlay = app.closeSearch
+
+	s.input.SetChangedFunc(func(string) { app.runSearch() })
+	s.input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyDown:
+			if len(s.hits) > 0 {
+				app.app.SetFocus(s.table)
+			}
+			return nil
+		case tcell.KeyEnter:
+			if len(s.hits) > 0 {
+				app.jumpToResource(s.hits[0])
+			}
+			return nil
+		}
+		return event
+	})
+	s.table.SetSelectedFunc(func(row, column int) {
+		if row > 0 && row-1 < len(s.hits) {
+			app.jumpToResource(s.hits[row-1])
+		}
+	})
+	s.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if row, _ := s.table.GetSelection(); event.Key() == tcell.KeyUp && row <= 1 {
+			app.app.SetFocus(s.input)
+			return nil
+		}
+		return event
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(s.input, 1, 0, true).
+		AddItem(s.table, 0, 1, false).
+		AddItem(s.status, 1, 0, false)
+	layout.SetBorder(true).SetTitle(panelTitle("search.title")).SetTitleAlign(tview.AlignLeft)
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyF2 {
+			s.all = !s.all
+			app.runSearch()
+			return nil
+		}
+		return event
+	})
+
+	app.runSearch()
+	app.pages.AddPage("search", centered(layout, 110, 22), true, true)
+	app.app.SetFocus(s.input)
+}
+
+// closeSearch closes the search overlay
+func (app *App) closeSearch() {
+	if app.search == nil {
+		return
+	}
+	app.search = nil
+	app.closeOverlay = nil
+	app.pages.RemovePage("search")
+}
+
+// runSearch lists the resources matching the search text
+func (app *App) runSearch() {
+	s := app.search
+	if s == nil {
+		return
+	}
+
+	text := s.input.GetText()
+	hits, err := app.resourcesTab.SearchResources(text, s.all, searchResultLimit)
+	s.hits = hits
+	fillSearchResults(s.table, hits)
+
+	scope := i18n.T("search.all_scopes")
+	if !s.all && app.awsClient != nil {
+		scope = fmt.Sprintf("%s (%s)", app.awsClient.GetProfile(), app.awsClient.GetRegion())
+	}
+	var status string
+	switch {
+	case err != nil:
+		status = fmt.Sprintf("[red]%s[-]", err)
+	case strings.TrimSpace(text) == "":
+		status = i18n.T("search.prompt", scope)
+	default:
+		status = i18n.T("search.matches", len(hits), scope)
+	}
+	other := i18n.T("search.all_scopes")
+	if s.all {
+		other = i18n.T("search.current_scope")
+	}
+	s.status.SetText(status + " [gray]| " + i18n.T("search.hint", other) + "[-]")
+}
+
+// fillSearchResults lists hits in table
+func fillSearchResults(table *tview.Table, hits []resourceHit) {
+	table.Clear()
+	for col, header := range []string{"Name", "ID", "Service", "Profile", "Region", "Matched"} {
+		table.SetCell(0, col, tview.NewTableCell(columnTitle(header)).
+			SetTextColor(tcell.ColorYellow).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+	}
+	for i, hit := range hits {
+		row := i + 1
+		table.SetCell(row, 0, tview.NewTableCell(hit.Resource.Name).SetMaxWidth(32))
+		table.SetCell(row, 1, tview.NewTableCell(hit.Resource.ID).SetMaxWidth(32))
+		table.SetCell(row, 2, tview.NewTableCell(hit.Service))
+		table.SetCell(row, 3, tview.NewTableCell(hit.Profile))
+		table.SetCell(row, 4, tview.NewTableCell(hit.Region))
+		table.SetCell(row, 5, tview.NewTableCell(strings.Join(hit.Fields, ", ")).SetTextColor(tcell.ColorGray))
+	}
+	if len(hits) > 0 {
+		table.Select(1, 0)
+	}
+}
+
+// jumpToResource closes the search and shows the resource of hit in the
+// Resources tab, switching to its profile and region first if needed
+func (app *App) jumpToResource(hit resourceHit) {
+	app.closeSearch()
+
+	app.inProfile(hit.Profile, hit.Region, hit.Resource.ID, func() {
+		app.switchTab(1)
+		app.resourcesTab.ShowResource(hit.Service, hit.Resource.ID)
+	})
+}
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"swiss-army-tui/internal/aws"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ServiceView is a service of the Resources tab: how its resources are
+// listed, shown and acted on. Every service has a module of its own whose
+// view embeds baseView for what it does like most services, and is added
+// to serviceViews.
+type ServiceView interface {
+	// Info names the service in the service list
+	Info() ServiceInfo
+	// Noun names a single resource of the service in warnings
+	Noun() string
+	// Load lists the resources of the service with client
+	Load(ctx context.Context, rt *ResourcesTab, client *aws.Client) ([]Resource, error)
+	// Summary sums up a listing loaded, of which failed items failed, for
+	// the status; "" for the count of resources
+	Summary(resources []Resource, failed int) (message, color string)
+	// Shown follows up on a listing shown, cached or loaded, e.g. by
+	// loading what the listing left out
+	Shown(ctx context.Context, rt *ResourcesTab, resources []Resource)
+	// Columns returns the headers of the listing
+	Columns() []string
+	// Cell renders column of resource, nil for the cell of resourceHeaders
+	Cell(resource Resource, column int) *tview.TableCell
+	// StateColor returns the color of state in the State column
+	StateColor(state string) tcell.Color
+	// Detail returns what the details panel shows of resource beyond its
+	// fields, tags and details
+	Detail(rt *ResourcesTab, resource Resource) string
+	// Open drills down into resource on Enter
+	Open(rt *ResourcesTab, resource Resource)
+	// Highlight loads more of resource when it is highlighted
+	Highlight(rt *ResourcesTab, resource Resource)
+	// Actions returns the actions on the resources of the service
+	Actions() []resourceAction
+	// ConsoleURL returns the console page of resource under base, the
+	// console host of its region, which query selects; "" if it has none
+	ConsoleURL(base, query string, resource Resource) string
+	// ARN returns the ARN of resource in account, "" if it cannot be told
+	ARN(account string, resource Resource) string
+}
+
+// pagedView is a ServiceView whose listing arrives a page at a time, so the
+// pages loaded are shown while the next ones load
+type pagedView interface {
+	ServiceView
+	// LoadPages loads like Load, calling onPage with the resources loaded
+	// so far after every page
+	LoadPages(ctx context.Context, rt *ResourcesTab, client *aws.Client, onPage func([]Resource)) ([]Resource, error)
+}
+
+// baseView is what a ServiceView does unless it does otherwise: the
+// default columns, no drill-down and no actions of its own
+type baseView struct {
+	info ServiceInfo
+	noun string
+}
+
+// Info returns the service of the view
+func (v baseView) Info() ServiceInfo { return v.info }
+
+// Noun returns the noun of the view, "resource" if it has none
+func (v baseView) Noun() string {
+	if v.noun == "" {
+		return "resource"
+	}
+	return v.noun
+}
+
+// Summary leaves the status to the count of resources
+func (baseView) Summary([]Resource, int) (string, string) { return "", "" }
+
+// Shown does nothing
+func (baseView) Shown(context.Context, *ResourcesTab, []Resource) {}
+
+// Columns returns resourceHeaders
+func (baseView) Columns() []string { return resourceHeaders }
+
+// Cell leaves all cells to resourceHeaders
+func (baseView) Cell(Resource, int) *tview.TableCell { return nil }
+
+// StateColor colors the lifecycle states most services share
+func (baseView) StateColor(state string) tcell.Color { return stateColor(nil, state) }
+
+// Detail adds nothing
+func (baseView) Detail(*ResourcesTab, Resource) string { return "" }
+
+// Open does nothing
+func (baseView) Open(*ResourcesTab, Resource) {}
+
+// Highlight does nothing
+func (baseView) Highlight(*ResourcesTab, Resource) {}
+
+// Actions returns none
+func (baseView) Actions() []resourceAction { return nil }
+
+// ConsoleURL links no console page
+func (baseView) ConsoleURL(string, string, Resource) string { return "" }
+
+// ARN returns the ARN the listing gave resource, "" if none
+func (baseView) ARN(_ string, res Resource) string { return detailString(res, "ARN") }
+
+// lifecycleStateColors are the colors of the lifecycle states most services
+// share
+var lifecycleStateColors = map[string]tcell.Color{
+	"running":    tcell.ColorGreen,
+	"available":  tcell.ColorGreen,
+	"active":     tcell.ColorGreen,
+	"stopped":    tcell.ColorRed,
+	"terminated": tcell.ColorRed,
+	"failed":     tcell.ColorRed,
+	"pending":    tcell.ColorYellow,
+	"stopping":   tcell.ColorYellow,
+	"starting":   tcell.ColorYellow,
+	"creating":   tcell.ColorYellow,
+	"updating":   tcell.ColorYellow,
+}
+
+// stateColor returns the color of state in colors, else its lifecycle color,
+// white if it has neither
+func stateColor(colors map[string]tcell.Color, state string) tcell.Color {
+	state = strings.ToLower(state)
+	if color, ok := colors[state]; ok {
+		return color
+	}
+	if color, ok := lifecycleStateColors[state]; ok {
+		return color
+	}
+	return tcell.ColorWhite
+}
+
+// unimplementedView is a service that cannot be listed yet
+type unimplementedView struct{ baseView }
+
+// Load fails
+func (v unimplementedView) Load(context.Context, *ResourcesTab, *aws.Client) ([]Resource, error) {
+	return nil, fmt.Errorf("service %s not implemented", v.info.Name)
+}
+
+// serviceViewOf returns the view of the service named name, an
+// unimplemented one if there is none
+func serviceViewOf(name string) ServiceView {
+	for _, view := range serviceViews {
+		if view.Info().Name == name {
+			return view
+		}
+	}
+	return unimplementedView{baseView{info: ServiceInfo{Name: name, DisplayName: name}}}
+}
+
+// serviceInfos returns the services of views
+func serviceInfos(views []ServiceView) []ServiceInfo {
+	services := make([]ServiceInfo, len(views))
+	for i, view := range views {
+		services[i] = view.Info()
+	}
+	return services
+}
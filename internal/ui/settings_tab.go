@@ -1,11 +1,17 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
 	"swiss-army-tui/internal/config"
+	"swiss-army-tui/internal/i18n"
 	"swiss-army-tui/pkg/logger"
 
 	"github.com/gdamore/tcell/v2"
@@ -13,25 +19,40 @@ import (
 	"go.uber.org/zap"
 )
 
+// serviceListRows is the most services the service list shows at once,
+// leaving room for the form above it; the list scrolls to the others
+const serviceListRows = 16
+
 // SettingsTab represents the application settings tab
 type SettingsTab struct {
 	// Core components
-	view   *tview.Flex
+	view   *tview.Pages
+	app    *tview.Application
 	config *config.Config
 
 	// UI components
-	form       *tview.Form
-	infoPanel  *tview.TextView
-	statusText *tview.TextView
+	form        *tview.Form
+	serviceList *tview.List
+	infoPanel   *tview.TextView
+	statusText  *tview.TextView
 
 	// State
-	modified bool
+	modified     bool
+	transferPath string
+	serviceOrder []string
+	serviceShown map[string]bool
+	// Errors of the fields with invalid text by label, and the labels of the
+	// checked fields in form order
+	fieldErrors map[string]string
+	fieldOrder  []string
 }
 
 // NewSettingsTab creates a new settings tab
-func NewSettingsTab(cfg *config.Config) (*SettingsTab, error) {
+func NewSettingsTab(app *tview.Application, cfg *config.Config) (*SettingsTab, error) {
 	tab := &SettingsTab{
-		config: cfg,
+		app:          app,
+		config:       cfg,
+		transferPath: defaultTransferPath(),
 	}
 
 	if err := tab.initializeUI(); err != nil {
@@ -48,23 +69,18 @@ func (st *SettingsTab) initializeUI() error {
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter)
 
-	st.statusText.SetBorder(true).SetTitle(" Status ").SetTitleAlign(tview.AlignLeft)
+	st.statusText.SetBorder(true).SetTitle(panelTitle("common.status")).SetTitleAlign(tview.AlignLeft)
 
 	// Create status text first (needed by form field callbacks)
 	// Set initial status
-	st.updateStatus("Settings loaded", "green")
+	st.updateStatus(i18n.T("settings.loaded"), "green")
 
 	// Create settings form
 	st.form = tview.NewForm()
-	st.form.SetBorder(true).SetTitle(" Application Settings ").SetTitleAlign(tview.AlignLeft)
-
-	// Add form fields based on configuration
-	st.addFormFields()
+	st.form.SetBorder(true).SetTitle(panelTitle("settings.form")).SetTitleAlign(tview.AlignLeft)
 
-	// Set form button handlers
-	st.form.AddButton("Save", st.saveSettings)
-	st.form.AddButton("Reset", st.resetSettings)
-	st.form.AddButton("Export Config", st.exportConfig)
+	// Add form fields and buttons based on configuration
+	st.buildForm()
 
 	// Add key bindings
 	st.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
@@ -75,58 +91,108 @@ func (st *SettingsTab) initializeUI() error {
 		case tcell.KeyCtrlR:
 			st.resetSettings()
 			return nil
+		case tcell.KeyCtrlL:
+			st.focusServiceList()
+			return nil
 		}
 		return event
 	})
 
+	// Create services list for choosing Resources tab services and their order
+	st.serviceList = tview.NewList().
+		ShowSecondaryText(false).
+		SetSelectedTextColor(tcell.ColorBlack).
+		SetSelectedBackgroundColor(tcell.ColorWhite)
+
+	st.serviceList.SetBorder(true).SetTitle(panelTitle("settings.services")).SetTitleAlign(tview.AlignLeft)
+	st.serviceList.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		st.toggleService(index)
+	})
+	st.serviceList.SetInputCapture(st.onServiceListKey)
+	st.loadServiceOrder()
+
 	// Create info panel
 	st.infoPanel = tview.NewTextView().
 		SetDynamicColors(true).
 		SetWrap(true).
 		SetScrollable(true)
 
-	st.infoPanel.SetBorder(true).SetTitle(" Configuration Info ").SetTitleAlign(tview.AlignLeft)
+	st.infoPanel.SetBorder(true).SetTitle(panelTitle("settings.info_title")).SetTitleAlign(tview.AlignLeft)
 	st.updateInfoPanel()
 
 	// Set initial status
-	st.updateStatus("Settings loaded", "green")
+	if summary := st.fieldErrorSummary(); summary != "" {
+		st.updateStatus(summary, "red")
+	} else {
+		st.updateStatus(i18n.T("settings.loaded"), "green")
+	}
 
 	// Create layout
 	leftPanel := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(st.form, 0, 1, true).
+		AddItem(st.serviceList, min(len(supportedServices), serviceListRows)+2, 0, false).
 		AddItem(st.statusText, 5, 0, false)
 
-	st.view = tview.NewFlex().SetDirection(tview.FlexColumn).
+	mainView := tview.NewFlex().SetDirection(tview.FlexColumn).
 		AddItem(leftPanel, 0, 1, true).
 		AddItem(st.infoPanel, 50, 0, false)
 
+	// Pages allow showing the import preview as a modal on top of the form
+	st.view = tview.NewPages().
+		AddPage("main", mainView, true, true)
+
 	return nil
 }
 
+// buildForm adds all form fields and buttons
+func (st *SettingsTab) buildForm() {
+	st.fieldErrors = make(map[string]string)
+	st.fieldOrder = nil
+	st.addFormFields()
+
+	st.form.AddButton(i18n.T("settings.save"), st.saveSettings)
+	st.form.AddButton(i18n.T("settings.reset"), st.resetSettings)
+	st.form.AddButton(i18n.T("settings.export"), st.exportConfig)
+	st.form.AddButton(i18n.T("settings.import"), st.importConfig)
+	st.updateSaveButton()
+}
+
+// rebuildForm recreates the form from the current configuration values
+func (st *SettingsTab) rebuildForm() {
+	hadFocus := st.form.HasFocus()
+
+	st.form.Clear(true)
+	st.buildForm()
+
+	// The previously focused form item no longer exists
+	if hadFocus && st.app != nil {
+		st.app.SetFocus(st.form)
+	}
+}
+
 // addFormFields adds configuration fields to the form
 func (st *SettingsTab) addFormFields() {
 	// Application settings
-	st.form.AddTextView("Application", "", 0, 1, false, false)
+	st.form.AddTextView(i18n.T("settings.section.application"), "", 0, 1, false, false)
 
-	st.form.AddInputField("App Name", st.config.App.Name, 30, nil,
+	st.addCheckedField(i18n.T("settings.field.app_name"), st.config.App.Name, 30, nil, checkRequired,
 		func(text string) {
 			st.config.App.Name = text
-			st.markModified()
 		})
 
-	st.form.AddInputField("Version", st.config.App.Version, 15, nil,
+	st.form.AddInputField(i18n.T("settings.field.version"), st.config.App.Version, 15, nil,
 		func(text string) {
 			st.config.App.Version = text
 			st.markModified()
 		})
 
-	st.form.AddInputField("Description", st.config.App.Description, 50, nil,
+	st.form.AddInputField(i18n.T("settings.field.description"), st.config.App.Description, 50, nil,
 		func(text string) {
 			st.config.App.Description = text
 			st.markModified()
 		})
 
-	st.form.AddCheckbox("Debug Mode", st.config.App.Debug,
+	st.form.AddCheckbox(i18n.T("settings.field.debug_mode"), st.config.App.Debug,
 		func(checked bool) {
 			st.config.App.Debug = checked
 			st.markModified()
@@ -134,54 +200,113 @@ func (st *SettingsTab) addFormFields() {
 
 	// AWS settings
 	st.form.AddTextView("", "", 0, 1, false, false) // Spacer
-	st.form.AddTextView("AWS Configuration", "", 0, 1, false, false)
+	st.form.AddTextView(i18n.T("settings.section.aws_configuration"), "", 0, 1, false, false)
 
-	st.form.AddInputField("Default Profile", st.config.AWS.DefaultProfile, 20, nil,
+	st.form.AddInputField(i18n.T("settings.field.default_profile"), st.config.AWS.DefaultProfile, 20, nil,
 		func(text string) {
 			st.config.AWS.DefaultProfile = text
 			st.markModified()
 		})
 
-	// Region dropdown
-	regions := []string{
-		"us-east-1", "us-east-2", "us-west-1", "us-west-2",
-		"eu-west-1", "eu-west-2", "eu-west-3", "eu-central-1", "eu-north-1",
-		"ap-southeast-1", "ap-southeast-2", "ap-northeast-1", "ap-northeast-2", "ap-south-1",
-		"ca-central-1", "sa-east-1", "af-south-1", "me-south-1",
-		"ap-east-1", "ap-northeast-3", "eu-south-1",
-	}
-
-	currentRegionIndex := 0
+	// Region dropdown; a configured region missing from the list is kept as
+	// the first option, and marked if it is not a region at all
+	regions := getAWSRegions()
+	currentRegionIndex := -1
 	for i, region := range regions {
 		if region == st.config.AWS.DefaultRegion {
 			currentRegionIndex = i
 			break
 		}
 	}
+	if currentRegionIndex < 0 {
+		currentRegionIndex = 0
+		if st.config.AWS.DefaultRegion != "" {
+			regions = append([]string{st.config.AWS.DefaultRegion}, regions...)
+		}
+	}
 
-	st.form.AddDropDown("Default Region", regions, currentRegionIndex,
-		func(option string, optionIndex int) {
+	regionSelect := tview.NewDropDown().SetLabel(i18n.T("settings.field.default_region")).SetOptions(regions, nil)
+	regionSelect.SetCurrentOption(currentRegionIndex)
+	setRegionLabel := func(label string) { regionSelect.SetLabel(label) }
+	st.checkField(i18n.T("settings.field.default_region"), regions[currentRegionIndex], checkRegion, setRegionLabel)
+	regionSelect.SetSelectedFunc(func(option string, optionIndex int) {
+		if st.checkField(i18n.T("settings.field.default_region"), option, checkRegion, setRegionLabel) != nil {
+			st.updateStatus(st.fieldErrorSummary(), "red")
+			return
+		}
+		if option != st.config.AWS.DefaultRegion {
 			st.config.AWS.DefaultRegion = option
 			st.markModified()
-		})
+		}
+	})
+	st.form.AddFormItem(regionSelect)
 
-	st.form.AddInputField("Config Path", st.config.AWS.ConfigPath, 50, nil,
+	st.addCheckedField(i18n.T("settings.field.config_path"), st.config.AWS.ConfigPath, 50, nil, checkFilePath,
 		func(text string) {
 			st.config.AWS.ConfigPath = text
-			st.markModified()
 		})
 
-	st.form.AddInputField("Credentials Path", st.config.AWS.CredentialsPath, 50, nil,
+	st.addCheckedField(i18n.T("settings.field.credentials_path"), st.config.AWS.CredentialsPath, 50, nil, checkFilePath,
 		func(text string) {
 			st.config.AWS.CredentialsPath = text
-			st.markModified()
+		})
+
+	// AWS request settings
+	st.form.AddTextView("", "", 0, 1, false, false) // Spacer
+	st.form.AddTextView(i18n.T("settings.section.aws_requests"), "", 0, 1, false, false)
+
+	retryModes := []string{config.RetryModeAdaptive, config.RetryModeStandard}
+	currentRetryIndex := 0
+	for i, mode := range retryModes {
+		if mode == st.config.AWS.RetryMode {
+			currentRetryIndex = i
+			break
+		}
+	}
+
+	st.form.AddDropDown(i18n.T("settings.field.retry_mode"), retryModes, currentRetryIndex,
+		func(option string, optionIndex int) {
+			if option != st.config.AWS.RetryMode {
+				st.config.AWS.RetryMode = option
+				st.markModified()
+			}
+		})
+
+	st.addNumberField(i18n.T("settings.field.max_attempts"), st.config.AWS.MaxAttempts, checkNotNegative,
+		func(attempts int) {
+			st.config.AWS.MaxAttempts = attempts
+		})
+
+	st.addNumberField(i18n.T("settings.field.max_backoff_seconds"), st.config.AWS.MaxBackoff, checkNotNegative,
+		func(seconds int) {
+			st.config.AWS.MaxBackoff = seconds
+		})
+
+	st.addNumberField(i18n.T("settings.field.request_timeout_seconds"), st.config.AWS.RequestTimeout, checkNotNegative,
+		func(seconds int) {
+			st.config.AWS.RequestTimeout = seconds
+		})
+
+	st.addCheckedField(i18n.T("settings.field.operation_timeouts"), formatPairs(st.config.AWS.OperationTimeouts), 50, nil, checkOperationTimeouts,
+		func(text string) {
+			st.config.AWS.OperationTimeouts, _ = parseOperationTimeouts(text)
+		})
+
+	st.addNumberField(i18n.T("settings.field.concurrency"), st.config.AWS.Concurrency, checkNotNegative,
+		func(limit int) {
+			st.config.AWS.Concurrency = limit
+		})
+
+	st.addCheckedField(i18n.T("settings.field.service_concurrency"), formatPairs(st.config.AWS.ServiceConcurrency), 50, nil, checkServiceConcurrency,
+		func(text string) {
+			st.config.AWS.ServiceConcurrency, _ = parseServiceConcurrency(text)
 		})
 
 	// UI settings
 	st.form.AddTextView("", "", 0, 1, false, false) // Spacer
-	st.form.AddTextView("User Interface", "", 0, 1, false, false)
+	st.form.AddTextView(i18n.T("settings.section.user_interface"), "", 0, 1, false, false)
 
-	themes := []string{"dark", "light", "auto"}
+	themes := []string{"dark", "light", "auto", "high-contrast"}
 	currentThemeIndex := 0
 	for i, theme := range themes {
 		if theme == st.config.UI.Theme {
@@ -190,30 +315,85 @@ func (st *SettingsTab) addFormFields() {
 		}
 	}
 
-	st.form.AddDropDown("Theme", themes, currentThemeIndex,
+	st.form.AddDropDown(i18n.T("settings.field.theme"), themes, currentThemeIndex,
 		func(option string, optionIndex int) {
 			st.config.UI.Theme = option
 			st.markModified()
 		})
 
-	st.form.AddInputField("Refresh Interval (seconds)", strconv.Itoa(st.config.UI.RefreshInterval), 10,
-		func(textToCheck string, lastChar rune) bool {
-			_, err := strconv.Atoi(textToCheck)
-			return err == nil || textToCheck == ""
-		},
+	st.addNumberField(i18n.T("settings.field.refresh_interval_seconds"), st.config.UI.RefreshInterval, checkRefreshInterval,
+		func(interval int) {
+			st.config.UI.RefreshInterval = interval
+		})
+
+	st.addNumberField(i18n.T("settings.field.log_buffer_size"), st.config.UI.LogBufferSize, checkPositive,
+		func(size int) {
+			st.config.UI.LogBufferSize = size
+		})
+
+	st.addNumberField(i18n.T("settings.field.cache_ttl_seconds"), st.config.UI.CacheTTL, checkNotNegative,
+		func(ttl int) {
+			st.config.UI.CacheTTL = ttl
+		})
+
+	st.addNumberField(i18n.T("settings.field.prefetch_services"), st.config.UI.PrefetchServices, checkPrefetchServices,
+		func(count int) {
+			st.config.UI.PrefetchServices = count
+		})
+
+	st.addNumberField(i18n.T("settings.field.preview_size_kb"), st.config.UI.PreviewKB, checkPositive,
+		func(size int) {
+			st.config.UI.PreviewKB = size
+		})
+
+	st.addCheckedField(i18n.T("settings.field.timezone"), st.config.UI.Timezone, 30, nil, checkTimezone,
 		func(text string) {
-			if interval, err := strconv.Atoi(text); err == nil && interval > 0 {
-				st.config.UI.RefreshInterval = interval
-				st.markModified()
-			}
+			st.config.UI.Timezone = text
+		})
+
+	timestampFormats := []string{config.TimestampsTime, config.TimestampsDateTime, config.TimestampsRelative}
+	currentTimestampIndex := 0
+	for i, format := range timestampFormats {
+		if format == st.config.UI.Timestamps {
+			currentTimestampIndex = i
+			break
+		}
+	}
+
+	st.form.AddDropDown(i18n.T("settings.field.timestamps"), timestampFormats, currentTimestampIndex,
+		func(option string, optionIndex int) {
+			st.config.UI.Timestamps = option
+			st.markModified()
 		})
 
-	st.form.AddCheckbox("Mouse Enabled", st.config.UI.MouseEnabled,
+	locales := i18n.Locales()
+	languages := make([]string, len(locales))
+	currentLocaleIndex := 0
+	for i, locale := range locales {
+		languages[i] = i18n.Name(locale)
+		if locale == st.config.UI.Locale {
+			currentLocaleIndex = i
+		}
+	}
+
+	st.form.AddDropDown(i18n.T("settings.field.language"), languages, currentLocaleIndex,
+		func(option string, optionIndex int) {
+			st.config.UI.Locale = locales[optionIndex]
+			st.markModified()
+		})
+
+	st.form.AddCheckbox(i18n.T("settings.field.mouse_enabled"), st.config.UI.MouseEnabled,
 		func(checked bool) {
 			st.config.UI.MouseEnabled = checked
 			st.markModified()
 		})
 
+	st.form.AddCheckbox(i18n.T("settings.field.accessibility_mode"), st.config.UI.Accessible,
+		func(checked bool) {
+			st.config.UI.Accessible = checked
+			st.markModified()
+		})
+
 	borderStyles := []string{"rounded", "double", "single", "none"}
 	currentBorderIndex := 0
 	for i, style := range borderStyles {
@@ -223,7 +403,7 @@ func (st *SettingsTab) addFormFields() {
 		}
 	}
 
-	st.form.AddDropDown("Border Style", borderStyles, currentBorderIndex,
+	st.form.AddDropDown(i18n.T("settings.field.border_style"), borderStyles, currentBorderIndex,
 		func(option string, optionIndex int) {
 			st.config.UI.BorderStyle = option
 			st.markModified()
@@ -231,7 +411,7 @@ func (st *SettingsTab) addFormFields() {
 
 	// Logger settings
 	st.form.AddTextView("", "", 0, 1, false, false) // Spacer
-	st.form.AddTextView("Logging", "", 0, 1, false, false)
+	st.form.AddTextView(i18n.T("settings.section.logging"), "", 0, 1, false, false)
 
 	logLevels := []string{"debug", "info", "warn", "error"}
 	currentLevelIndex := 0
@@ -242,13 +422,13 @@ func (st *SettingsTab) addFormFields() {
 		}
 	}
 
-	st.form.AddDropDown("Log Level", logLevels, currentLevelIndex,
+	st.form.AddDropDown(i18n.T("settings.field.log_level"), logLevels, currentLevelIndex,
 		func(option string, optionIndex int) {
 			st.config.Logger.Level = option
 			st.markModified()
 		})
 
-	st.form.AddCheckbox("Development Mode", st.config.Logger.Development,
+	st.form.AddCheckbox(i18n.T("settings.field.development_mode"), st.config.Logger.Development,
 		func(checked bool) {
 			st.config.Logger.Development = checked
 			st.markModified()
@@ -263,48 +443,457 @@ func (st *SettingsTab) addFormFields() {
 		}
 	}
 
-	st.form.AddDropDown("Log Encoding", encodings, currentEncodingIndex,
+	st.form.AddDropDown(i18n.T("settings.field.log_encoding"), encodings, currentEncodingIndex,
 		func(option string, optionIndex int) {
 			st.config.Logger.Encoding = option
 			st.markModified()
 		})
+
+	st.addCheckedField(i18n.T("settings.field.log_output_paths"), strings.Join(st.config.Logger.OutputPaths, ", "), 50, nil, checkLogPaths,
+		func(text string) {
+			st.config.Logger.OutputPaths = splitLogPaths(text)
+		})
+
+	st.addNumberField(i18n.T("settings.field.log_max_size_mb"), st.config.Logger.MaxSizeMB, checkNotNegative,
+		func(size int) {
+			st.config.Logger.MaxSizeMB = size
+		})
+
+	st.addNumberField(i18n.T("settings.field.log_backups"), st.config.Logger.MaxBackups, checkNotNegative,
+		func(count int) {
+			st.config.Logger.MaxBackups = count
+		})
+
+	st.form.AddCheckbox(i18n.T("settings.field.compress_rotated_logs"), st.config.Logger.Compress,
+		func(checked bool) {
+			st.config.Logger.Compress = checked
+			st.markModified()
+		})
+
+	// Export / import
+	st.form.AddTextView("", "", 0, 1, false, false) // Spacer
+	st.form.AddTextView(i18n.T("settings.section.export_import"), "", 0, 1, false, false)
+
+	st.form.AddInputField(i18n.T("settings.field.config_file_path"), st.transferPath, 50, nil,
+		func(text string) {
+			st.transferPath = strings.TrimSpace(text)
+		})
+}
+
+// loadServiceOrder initializes the service order from the configuration:
+// shown services in configured order, followed by the hidden ones
+func (st *SettingsTab) loadServiceOrder() {
+	st.serviceOrder = nil
+	st.serviceShown = make(map[string]bool)
+
+	for _, service := range orderServices(st.config.UI.Services) {
+		st.serviceOrder = append(st.serviceOrder, service.Name)
+		st.serviceShown[service.Name] = true
+	}
+	for _, service := range supportedServices {
+		if !st.serviceShown[service.Name] {
+			st.serviceOrder = append(st.serviceOrder, service.Name)
+		}
+	}
+
+	st.renderServiceList()
+}
+
+// renderServiceList redraws the service list keeping the current selection
+func (st *SettingsTab) renderServiceList() {
+	current := st.serviceList.GetCurrentItem()
+	st.serviceList.Clear()
+
+	for _, name := range st.serviceOrder {
+		for _, service := range supportedServices {
+			if service.Name != name {
+				continue
+			}
+
+			marker := "[gray]✘[-]"
+			if st.serviceShown[name] {
+				marker = "[green]✔[-]"
+			}
+			st.serviceList.AddItem(fmt.Sprintf("%s %s %s", marker, serviceIcon(service), service.DisplayName), "", 0, nil)
+		}
+	}
+
+	st.serviceList.SetCurrentItem(current)
+}
+
+// onServiceListKey handles toggling and reordering in the service list
+func (st *SettingsTab) onServiceListKey(event *tcell.EventKey) *tcell.EventKey {
+	index := st.serviceList.GetCurrentItem()
+
+	switch {
+	case event.Key() == tcell.KeyCtrlL:
+		if st.app != nil {
+			st.app.SetFocus(st.form)
+		}
+		return nil
+	case event.Rune() == ' ':
+		st.toggleService(index)
+		return nil
+	case event.Rune() == 'K' || (event.Key() == tcell.KeyUp && event.Modifiers()&tcell.ModShift != 0):
+		st.moveService(index, -1)
+		return nil
+	case event.Rune() == 'J' || (event.Key() == tcell.KeyDown && event.Modifiers()&tcell.ModShift != 0):
+		st.moveService(index, 1)
+		return nil
+	}
+	return event
+}
+
+// toggleService shows or hides the service at index
+func (st *SettingsTab) toggleService(index int) {
+	if index < 0 || index >= len(st.serviceOrder) {
+		return
+	}
+
+	name := st.serviceOrder[index]
+	// An empty service list means "show all", so keep at least one service
+	if st.serviceShown[name] && len(st.config.UI.Services) == 1 {
+		st.updateStatus(i18n.T("settings.one_service"), "yellow")
+		return
+	}
+
+	st.serviceShown[name] = !st.serviceShown[name]
+	st.updateServicesConfig()
+}
+
+// moveService moves the service at index by delta positions
+func (st *SettingsTab) moveService(index, delta int) {
+	target := index + delta
+	if index < 0 || target < 0 || target >= len(st.serviceOrder) {
+		return
+	}
+
+	st.serviceOrder[index], st.serviceOrder[target] = st.serviceOrder[target], st.serviceOrder[index]
+	st.serviceList.SetCurrentItem(target)
+	st.updateServicesConfig()
+}
+
+// updateServicesConfig stores the shown services in order in the configuration
+func (st *SettingsTab) updateServicesConfig() {
+	services := []string{}
+	for _, name := range st.serviceOrder {
+		if st.serviceShown[name] {
+			services = append(services, name)
+		}
+	}
+
+	st.config.UI.Services = services
+	st.renderServiceList()
+	st.markModified()
+}
+
+// focusServiceList moves focus from the form to the service list
+func (st *SettingsTab) focusServiceList() {
+	if st.app != nil {
+		st.app.SetFocus(st.serviceList)
+	}
 }
 
 // markModified marks the configuration as modified
 func (st *SettingsTab) markModified() {
 	if st.statusText != nil {
 		st.modified = true
-		st.updateStatus("Configuration modified (unsaved)", "yellow")
+		if summary := st.fieldErrorSummary(); summary != "" {
+			st.updateStatus(summary, "red")
+		} else {
+			st.updateStatus(i18n.T("settings.modified"), "yellow")
+		}
 		st.updateInfoPanel()
 	}
 }
 
+// addCheckedField adds an input field whose text is checked on every change.
+// Valid text is passed to apply; invalid text is marked next to the label
+// and keeps Save disabled until it is fixed.
+func (st *SettingsTab) addCheckedField(label, value string, width int, accept func(string, rune) bool, check func(string) error, apply func(string)) {
+	input := tview.NewInputField().
+		SetLabel(label).
+		SetText(value).
+		SetFieldWidth(width).
+		SetAcceptanceFunc(accept)
+	setLabel := func(text string) { input.SetLabel(text) }
+
+	st.checkField(label, value, check, setLabel)
+	input.SetChangedFunc(func(text string) {
+		if st.checkField(label, text, check, setLabel) != nil {
+			st.updateStatus(st.fieldErrorSummary(), "red")
+			return
+		}
+		apply(text)
+		st.markModified()
+	})
+	st.form.AddFormItem(input)
+}
+
+// addNumberField adds a field for a whole number that check accepts
+func (st *SettingsTab) addNumberField(label string, value int, check func(int) error, apply func(int)) {
+	st.addCheckedField(label, strconv.Itoa(value), 10,
+		func(textToCheck string, lastChar rune) bool {
+			_, err := strconv.Atoi(textToCheck)
+			return err == nil || textToCheck == "" || textToCheck == "-"
+		},
+		func(text string) error {
+			number, err := strconv.Atoi(text)
+			if err != nil {
+				return errors.New(i18n.T("settings.check_number"))
+			}
+			return check(number)
+		},
+		func(text string) {
+			number, _ := strconv.Atoi(text)
+			apply(number)
+		})
+}
+
+// checkField records whether check accepts value for the field label and
+// marks the label through setLabel if it does not
+func (st *SettingsTab) checkField(label, value string, check func(string) error, setLabel func(string)) error {
+	if !slices.Contains(st.fieldOrder, label) {
+		st.fieldOrder = append(st.fieldOrder, label)
+	}
+
+	err := check(value)
+	if err != nil {
+		st.fieldErrors[label] = err.Error()
+		setLabel(fmt.Sprintf("[red]✘ %s[-]", label))
+	} else {
+		delete(st.fieldErrors, label)
+		setLabel(label)
+	}
+	st.updateSaveButton()
+	return err
+}
+
+// fieldErrorSummary describes the first invalid field in form order and how
+// many more there are, "" if all fields are valid
+func (st *SettingsTab) fieldErrorSummary() string {
+	for _, label := range st.fieldOrder {
+		message, ok := st.fieldErrors[label]
+		if !ok {
+			continue
+		}
+		summary := fmt.Sprintf("%s: %s", label, message)
+		if more := len(st.fieldErrors) - 1; more > 0 {
+			summary += " (" + i18n.T("settings.more_invalid", more) + ")"
+		}
+		return summary
+	}
+	return ""
+}
+
+// updateSaveButton disables Save while a field is invalid
+func (st *SettingsTab) updateSaveButton() {
+	if index := st.form.GetButtonIndex(i18n.T("settings.save")); index >= 0 {
+		st.form.GetButton(index).SetDisabled(len(st.fieldErrors) > 0)
+	}
+}
+
+// checkRequired requires text to be set
+func checkRequired(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return errors.New(i18n.T("settings.check_required"))
+	}
+	return nil
+}
+
+// checkFilePath requires path to name an existing file. Empty uses the
+// default path.
+func checkFilePath(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return nil
+	}
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+		return errors.New(i18n.T("settings.check_not_found"))
+	case err != nil:
+		return err
+	case info.IsDir():
+		return errors.New(i18n.T("settings.check_directory"))
+	}
+	return nil
+}
+
+// splitLogPaths splits a comma separated list of log output paths
+func splitLogPaths(text string) []string {
+	var paths []string
+	for _, path := range strings.Split(text, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// checkLogPaths requires at least one log output path, stdout, stderr or a
+// file, and no directories
+func checkLogPaths(text string) error {
+	paths := splitLogPaths(text)
+	if len(paths) == 0 {
+		return errors.New(i18n.T("settings.check_log_paths", logger.DefaultPath()))
+	}
+	for _, path := range paths {
+		if path == "stdout" || path == "stderr" {
+			continue
+		}
+		if info, err := os.Stat(logger.ExpandHome(path)); err == nil && info.IsDir() {
+			return errors.New(i18n.T("settings.check_path_directory", path))
+		}
+	}
+	return nil
+}
+
+// parseOperationTimeouts parses a comma separated list of operation=seconds
+// pairs such as "GetObject=120, athena:GetQueryResults=60"
+func parseOperationTimeouts(text string) (map[string]int, error) {
+	timeouts := make(map[string]int)
+	for _, pair := range strings.Split(text, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		operation, value, ok := strings.Cut(pair, "=")
+		operation = strings.TrimSpace(operation)
+		if !ok || operation == "" {
+			return nil, errors.New(i18n.T("settings.check_operation_pair", pair))
+		}
+		seconds, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || seconds < 0 {
+			return nil, errors.New(i18n.T("settings.check_operation_seconds", operation))
+		}
+		timeouts[operation] = seconds
+	}
+	return timeouts, nil
+}
+
+// formatPairs lists values as name=value pairs in the form
+// parseOperationTimeouts and parseServiceConcurrency read, sorted by name
+func formatPairs(values map[string]int) string {
+	pairs := make([]string, 0, len(values))
+	for name, value := range values {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", name, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// checkOperationTimeouts requires text to be a list of operation=seconds
+// pairs
+func checkOperationTimeouts(text string) error {
+	_, err := parseOperationTimeouts(text)
+	return err
+}
+
+// parseServiceConcurrency parses a comma separated list of service=limit
+// pairs such as "lambda=4, s3=16"
+func parseServiceConcurrency(text string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, pair := range strings.Split(text, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		service, value, ok := strings.Cut(pair, "=")
+		service = strings.TrimSpace(service)
+		if !ok || service == "" {
+			return nil, errors.New(i18n.T("settings.check_service_pair", pair))
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || limit < 0 {
+			return nil, errors.New(i18n.T("settings.check_service_limit", service))
+		}
+		limits[service] = limit
+	}
+	return limits, nil
+}
+
+// checkServiceConcurrency requires text to be a list of service=limit pairs
+func checkServiceConcurrency(text string) error {
+	_, err := parseServiceConcurrency(text)
+	return err
+}
+
+// checkRegion requires region to be an AWS region name
+func checkRegion(region string) error {
+	if !config.IsValidRegion(region) {
+		return errors.New(i18n.T("settings.check_region", region))
+	}
+	return nil
+}
+
+// checkTimezone requires name to be local, UTC or a known IANA timezone
+func checkTimezone(name string) error {
+	_, err := config.LoadTimezone(name)
+	return err
+}
+
+// checkRefreshInterval requires seconds to be within the supported range
+func checkRefreshInterval(seconds int) error {
+	if seconds < config.MinRefreshInterval || seconds > config.MaxRefreshInterval {
+		return errors.New(i18n.T("settings.check_between", config.MinRefreshInterval, config.MaxRefreshInterval))
+	}
+	return nil
+}
+
+// checkPositive requires number to be above zero
+func checkPositive(number int) error {
+	if number <= 0 {
+		return errors.New(i18n.T("settings.check_positive"))
+	}
+	return nil
+}
+
+// checkNotNegative requires number to be zero or more
+func checkNotNegative(number int) error {
+	if number < 0 {
+		return errors.New(i18n.T("settings.check_not_negative"))
+	}
+	return nil
+}
+
+// checkPrefetchServices requires count to be between zero and the number of
+// services
+func checkPrefetchServices(count int) error {
+	if count < 0 || count > len(supportedServices) {
+		return errors.New(i18n.T("settings.check_between", 0, len(supportedServices)))
+	}
+	return nil
+}
+
 // saveSettings saves the current settings
 func (st *SettingsTab) saveSettings() {
 	logger.Info("Saving configuration settings")
 
+	if summary := st.fieldErrorSummary(); summary != "" {
+		st.updateStatus(i18n.T("settings.cannot_save", summary), "red")
+		return
+	}
+
 	// Validate configuration
 	if err := st.config.Validate(); err != nil {
-		st.updateStatus(fmt.Sprintf("Validation error: %s", err.Error()), "red")
+		st.updateStatus(i18n.T("settings.invalid", err.Error()), "red")
 		logger.Error("Configuration validation failed", zap.Error(err))
 		return
 	}
 
 	// Save configuration
 	if err := config.SaveConfig(); err != nil {
-		st.updateStatus(fmt.Sprintf("Save failed: %s", err.Error()), "red")
+		st.updateStatus(i18n.T("settings.save_failed", err.Error()), "red")
 		logger.Error("Failed to save configuration", zap.Error(err))
 		return
 	}
 
 	// Reinitialize logger with new settings
 	if err := logger.Initialize(&st.config.Logger); err != nil {
-		st.updateStatus(fmt.Sprintf("Logger reinit failed: %s", err.Error()), "yellow")
+		st.updateStatus(i18n.T("settings.logger_failed", err.Error()), "yellow")
 		logger.Warn("Failed to reinitialize logger", zap.Error(err))
 	}
 
 	st.modified = false
-	st.updateStatus("Configuration saved successfully", "green")
+	st.updateStatus(i18n.T("settings.saved"), "green")
 	st.updateInfoPanel()
 
 	logger.Info("Configuration saved successfully")
@@ -317,7 +906,7 @@ func (st *SettingsTab) resetSettings() {
 	// Reload configuration from file/defaults
 	newConfig, err := config.Load()
 	if err != nil {
-		st.updateStatus(fmt.Sprintf("Reset failed: %s", err.Error()), "red")
+		st.updateStatus(i18n.T("settings.reset_failed", err.Error()), "red")
 		logger.Error("Failed to reload configuration", zap.Error(err))
 		return
 	}
@@ -326,23 +915,91 @@ func (st *SettingsTab) resetSettings() {
 	st.modified = false
 
 	// Recreate the form with reset values
-	st.form.Clear(true)
-	st.addFormFields()
-	st.form.AddButton("Save", st.saveSettings)
-	st.form.AddButton("Reset", st.resetSettings)
-	st.form.AddButton("Export Config", st.exportConfig)
+	st.rebuildForm()
+	st.loadServiceOrder()
 
-	st.updateStatus("Configuration reset to defaults", "blue")
+	st.updateStatus(i18n.T("settings.reset_done"), "blue")
 	st.updateInfoPanel()
 
 	logger.Info("Configuration reset successfully")
 }
 
-// exportConfig exports the current configuration
+// exportConfig exports the current configuration to the configured file path
 func (st *SettingsTab) exportConfig() {
-	logger.Info("Exporting configuration")
-	// This would typically open a file dialog or provide export options
-	st.updateStatus("Export functionality coming soon", "blue")
+	logger.Info("Exporting configuration", zap.String("path", st.transferPath))
+
+	if err := config.ExportToFile(st.config, st.transferPath); err != nil {
+		st.updateStatus(i18n.T("settings.export_failed", err.Error()), "red")
+		logger.Error("Failed to export configuration", zap.Error(err))
+		return
+	}
+
+	st.updateStatus(i18n.T("settings.exported", st.transferPath), "green")
+}
+
+// importConfig validates the configuration file at the configured path and
+// previews its differences before applying it
+func (st *SettingsTab) importConfig() {
+	logger.Info("Importing configuration", zap.String("path", st.transferPath))
+
+	imported, err := config.ImportFromFile(st.transferPath)
+	if err != nil {
+		st.updateStatus(i18n.T("settings.import_failed", err.Error()), "red")
+		logger.Error("Failed to import configuration", zap.Error(err))
+		return
+	}
+
+	changes, err := config.Diff(st.config, imported)
+	if err != nil {
+		st.updateStatus(i18n.T("settings.import_failed", err.Error()), "red")
+		logger.Error("Failed to compare configurations", zap.Error(err))
+		return
+	}
+
+	if len(changes) == 0 {
+		st.updateStatus(i18n.T("settings.import_same"), "blue")
+		return
+	}
+
+	st.showImportPreview(imported, changes)
+}
+
+// showImportPreview shows the pending changes and applies them on confirmation
+func (st *SettingsTab) showImportPreview(imported *config.Config, changes []config.Change) {
+	var preview strings.Builder
+	preview.WriteString(i18n.T("settings.import_confirm", len(changes), st.transferPath) + "\n\n")
+	for _, change := range changes {
+		preview.WriteString(fmt.Sprintf("%s: %v -> %v\n", change.Key, change.Old, change.New))
+	}
+
+	modal := tview.NewModal().
+		SetText(preview.String()).
+		AddButtons([]string{i18n.T("settings.apply"), i18n.T("common.cancel")}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			st.view.RemovePage("import-preview")
+			if buttonIndex == 0 {
+				st.applyImportedConfig(imported, len(changes))
+			} else {
+				st.updateStatus(i18n.T("settings.import_cancelled"), "blue")
+			}
+		})
+
+	st.view.AddPage("import-preview", modal, false, true)
+}
+
+// applyImportedConfig replaces the current settings with the imported ones and saves them
+func (st *SettingsTab) applyImportedConfig(imported *config.Config, changeCount int) {
+	// Copy into the existing struct so other holders of the config see the change
+	*st.config = *imported
+
+	st.rebuildForm()
+	st.loadServiceOrder()
+	st.markModified()
+	st.saveSettings()
+
+	if !st.modified {
+		st.updateStatus(i18n.T("settings.imported", changeCount, st.transferPath), "green")
+	}
 }
 
 // updateInfoPanel updates the configuration information panel
@@ -352,44 +1009,7 @@ func (st *SettingsTab) updateInfoPanel() {
 		return
 	}
 
-	info := fmt.Sprintf(`[yellow]Configuration Overview[-]
-
-[blue]Application:[-]
-• Name: %s
-• Version: %s
-• Debug: %t
-
-[blue]AWS:[-]
-• Default Profile: %s
-• Default Region: %s
-• Config Path: %s
-• Credentials Path: %s
-
-[blue]User Interface:[-]
-• Theme: %s
-• Refresh Interval: %ds
-• Mouse Enabled: %t
-• Border Style: %s
-
-[blue]Logging:[-]
-• Level: %s
-• Development: %t
-• Encoding: %s
-• Output Paths: %s
-
-[blue]Status:[-]
-• Modified: %t
-
-[blue]Shortcuts:[-]
-• [white]Ctrl+S[-]: Save settings
-• [white]Ctrl+R[-]: Reset settings
-• [white]Tab[-]: Navigate form fields
-
-[blue]Tips:[-]
-• Changes are not applied until saved
-• Some settings may require application restart
-• Configuration is saved to ~/.swiss-army-tui/config.yaml
-• Use Reset to discard unsaved changes`,
+	info := i18n.T("settings.info",
 		st.config.App.Name,
 		st.config.App.Version,
 		st.config.App.Debug,
@@ -397,14 +1017,25 @@ func (st *SettingsTab) updateInfoPanel() {
 		st.config.AWS.DefaultRegion,
 		st.config.AWS.ConfigPath,
 		st.config.AWS.CredentialsPath,
+		requestSummary(st.config.AWS),
 		st.config.UI.Theme,
 		st.config.UI.RefreshInterval,
 		st.config.UI.MouseEnabled,
 		st.config.UI.BorderStyle,
+		st.config.UI.LogBufferSize,
+		st.config.UI.CacheTTL,
+		st.config.UI.PrefetchServices,
+		st.config.UI.PreviewKB,
+		st.config.UI.Timestamps,
+		st.config.UI.Timezone,
+		i18n.Name(st.config.UI.Locale),
+		st.config.UI.Accessible,
+		servicesSummary(st.config.UI.Services),
 		st.config.Logger.Level,
 		st.config.Logger.Development,
 		st.config.Logger.Encoding,
 		strings.Join(st.config.Logger.OutputPaths, ", "),
+		rotationSummary(st.config.Logger),
 		st.modified)
 
 	st.infoPanel.SetText(info)
@@ -420,10 +1051,10 @@ func (st *SettingsTab) updateStatus(message, color string) {
 	if st.statusText != nil {
 		modifiedText := ""
 		if st.modified {
-			modifiedText = "\n[yellow]* Unsaved changes[-]"
+			modifiedText = "\n[yellow]* " + i18n.T("settings.unsaved") + "[-]"
 		}
 
-		statusText := fmt.Sprintf("[%s]%s[-]%s", color, message, modifiedText)
+		statusText := fmt.Sprintf("[%s]%s%s[-]%s", color, stateWord(color), message, modifiedText)
 		st.statusText.SetText(statusText)
 	}
 }
@@ -432,7 +1063,25 @@ func (st *SettingsTab) updateStatus(message, color string) {
 func (st *SettingsTab) Refresh() {
 	logger.Debug("Refreshing settings tab")
 	st.updateInfoPanel()
-	st.updateStatus("Settings refreshed", "green")
+	st.updateStatus(i18n.T("settings.refreshed"), "green")
+}
+
+// applyLocale titles the panels in the current locale; ApplyConfig labels
+// the form anew
+func (st *SettingsTab) applyLocale() {
+	st.statusText.SetTitle(panelTitle("common.status"))
+	st.form.SetTitle(panelTitle("settings.form"))
+	st.serviceList.SetTitle(panelTitle("settings.services"))
+	st.infoPanel.SetTitle(panelTitle("settings.info_title"))
+}
+
+// ApplyConfig refreshes the form after the configuration was replaced
+func (st *SettingsTab) ApplyConfig(cfg *config.Config) {
+	st.config = cfg
+	st.modified = false
+	st.rebuildForm()
+	st.loadServiceOrder()
+	st.updateInfoPanel()
 }
 
 // GetView returns the main view component
@@ -440,6 +1089,57 @@ func (st *SettingsTab) GetView() tview.Primitive {
 	return st.view
 }
 
+// rotationSummary describes when log files are rotated and what is kept
+func rotationSummary(cfg logger.Config) string {
+	if cfg.MaxSizeMB <= 0 {
+		return i18n.T("settings.rotation_off")
+	}
+	kept := i18n.T("settings.rotation_all")
+	if cfg.MaxBackups > 0 {
+		kept = i18n.T("settings.rotation_backups", cfg.MaxBackups)
+	}
+	if cfg.Compress {
+		kept += ", " + i18n.T("settings.rotation_compressed")
+	}
+	return i18n.T("settings.rotation", cfg.MaxSizeMB, kept)
+}
+
+// requestSummary describes the retries and timeouts of AWS API calls
+func requestSummary(cfg config.AWSConfig) string {
+	mode := cfg.RetryMode
+	if mode == "" {
+		mode = config.RetryModeAdaptive
+	}
+	summary := i18n.T("settings.requests_retries", mode)
+	if cfg.MaxAttempts > 0 {
+		summary += ", " + i18n.T("settings.requests_attempts", cfg.MaxAttempts)
+	}
+	if cfg.RequestTimeout > 0 {
+		summary += ", " + i18n.T("settings.requests_timeout", cfg.RequestTimeout)
+	}
+	if len(cfg.OperationTimeouts) > 0 {
+		summary += ", " + i18n.T("settings.requests_operations", len(cfg.OperationTimeouts))
+	}
+	return summary
+}
+
+// servicesSummary describes the configured Resources tab services
+func servicesSummary(services []string) string {
+	if len(services) == 0 {
+		return i18n.T("settings.services_all")
+	}
+	return strings.Join(services, ", ")
+}
+
+// defaultTransferPath returns the default file used for config export and import
+func defaultTransferPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "swiss-army-tui-config.yaml"
+	}
+	return filepath.Join(homeDir, ".swiss-army-tui", "config-export.yaml")
+}
+
 // IsModified returns whether the configuration has been modified
 func (st *SettingsTab) IsModified() bool {
 	return st.modified
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/pkg/logger"
+
+	"github.com/rivo/tview"
+)
+
+// settingsField returns the input field of the settings form labelled label,
+// with or without an error marker
+func settingsField(t *testing.T, st *SettingsTab, label string) *tview.InputField {
+	t.Helper()
+	for i := 0; i < st.form.GetFormItemCount(); i++ {
+		if input, ok := st.form.GetFormItem(i).(*tview.InputField); ok && strings.Contains(input.GetLabel(), label) {
+			return input
+		}
+	}
+	t.Fatalf("No field %q in the settings form", label)
+	return nil
+}
+
+func TestSettingsTabFieldValidation(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config")
+	credentialsPath := filepath.Join(dir, "credentials")
+	for _, path := range []string{configPath, credentialsPath} {
+		if err := os.WriteFile(path, []byte("[default]\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &config.Config{
+		App:    config.AppConfig{Name: "Swiss Army TUI"},
+		AWS:    config.AWSConfig{DefaultRegion: "eu-west-1", ConfigPath: configPath, CredentialsPath: credentialsPath},
+		UI:     config.UIConfig{RefreshInterval: 30, LogBufferSize: 1000, PreviewKB: 64},
+		Logger: logger.Config{OutputPaths: []string{filepath.Join(dir, "tui.log")}},
+	}
+
+	st, err := NewSettingsTab(nil, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create settings tab: %v", err)
+	}
+	save := st.form.GetButton(st.form.GetButtonIndex("Save"))
+	if len(st.fieldErrors) != 0 || save.IsDisabled() {
+		t.Fatalf("Expected a valid form, got errors %v", st.fieldErrors)
+	}
+
+	// An interval out of range is marked and not applied
+	refresh := settingsField(t, st, "Refresh Interval (seconds)")
+	refresh.SetText("7200")
+	if !save.IsDisabled() || !strings.Contains(refresh.GetLabel(), "✘") {
+		t.Errorf("Expected the interval to be marked and Save disabled, label %q", refresh.GetLabel())
+	}
+	if cfg.UI.RefreshInterval != 30 {
+		t.Errorf("Expected the invalid interval not to be applied, got %d", cfg.UI.RefreshInterval)
+	}
+
+	// A missing file is reported first, in form order
+	settingsField(t, st, "Credentials Path").SetText(filepath.Join(dir, "missing"))
+	if summary := st.fieldErrorSummary(); !strings.HasPrefix(summary, "Credentials Path: file not found") ||
+		!strings.Contains(summary, "1 more") {
+		t.Errorf("Expected the missing file and one more error, got %q", summary)
+	}
+
+	// Fixing both enables Save again
+	refresh.SetText("60")
+	settingsField(t, st, "Credentials Path").SetText(credentialsPath)
+	if len(st.fieldErrors) != 0 || save.IsDisabled() || refresh.GetLabel() != "Refresh Interval (seconds)" {
+		t.Errorf("Expected a valid form, got errors %v", st.fieldErrors)
+	}
+	if cfg.UI.RefreshInterval != 60 || !st.IsModified() {
+		t.Errorf("Expected the new interval to be applied, got %d", cfg.UI.RefreshInterval)
+	}
+
+	// Log paths are a comma separated list that cannot be emptied
+	paths := settingsField(t, st, "Log Output Paths")
+	paths.SetText(" ")
+	if _, ok := st.fieldErrors["Log Output Paths"]; !ok {
+		t.Errorf("Expected empty log paths to be marked, got errors %v", st.fieldErrors)
+	}
+	paths.SetText("stderr, " + filepath.Join(dir, "other.log"))
+	if want := []string{"stderr", filepath.Join(dir, "other.log")}; !slices.Equal(cfg.Logger.OutputPaths, want) {
+		t.Errorf("Expected log paths %v, got %v", want, cfg.Logger.OutputPaths)
+	}
+
+	// Operation timeouts are operation=seconds pairs
+	timeouts := settingsField(t, st, "Operation Timeouts")
+	timeouts.SetText("GetObject=120, athena")
+	if _, ok := st.fieldErrors["Operation Timeouts"]; !ok {
+		t.Errorf("Expected a pair without seconds to be marked, got errors %v", st.fieldErrors)
+	}
+	timeouts.SetText("GetObject=120, athena:GetQueryResults=60")
+	if cfg.AWS.OperationTimeouts["GetObject"] != 120 || cfg.AWS.OperationTimeouts["athena:GetQueryResults"] != 60 {
+		t.Errorf("Expected the operation timeouts to be applied, got %v", cfg.AWS.OperationTimeouts)
+	}
+
+	// Service concurrency is service=limit pairs
+	limits := settingsField(t, st, "Service Concurrency")
+	limits.SetText("lambda=-1")
+	if _, ok := st.fieldErrors["Service Concurrency"]; !ok {
+		t.Errorf("Expected a negative limit to be marked, got errors %v", st.fieldErrors)
+	}
+	limits.SetText("lambda=4, s3=16")
+	if cfg.AWS.ServiceConcurrency["lambda"] != 4 || cfg.AWS.ServiceConcurrency["s3"] != 16 {
+		t.Errorf("Expected the service limits to be applied, got %v", cfg.AWS.ServiceConcurrency)
+	}
+}
+
+func TestSettingsTabUnknownRegion(t *testing.T) {
+	cfg := &config.Config{
+		App: config.AppConfig{Name: "Swiss Army TUI"},
+		AWS: config.AWSConfig{DefaultRegion: "mars-1"},
+		UI:  config.UIConfig{RefreshInterval: 30, LogBufferSize: 1000, PreviewKB: 64},
+	}
+
+	st, err := NewSettingsTab(nil, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create settings tab: %v", err)
+	}
+	if _, ok := st.fieldErrors["Default Region"]; !ok {
+		t.Errorf("Expected the unknown region to be marked, got errors %v", st.fieldErrors)
+	}
+	if !st.form.GetButton(st.form.GetButtonIndex("Save")).IsDisabled() {
+		t.Error("Expected Save to be disabled")
+	}
+}
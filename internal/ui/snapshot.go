@@ -0,0 +1,248 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"swiss-army-tui/internal/aws"
+	"swiss-army-tui/internal/i18n"
+	"swiss-army-tui/internal/snapshot"
+	"swiss-army-tui/pkg/logger"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"go.uber.org/zap"
+)
+
+// Log sources kept in snapshots. The application and audit logs are local
+// files that can be read at any time.
+var snapshotSources = []string{"cloudwatch", "kubernetes", "alerts"}
+
+// offlineStatus is shown instead of actions that need AWS
+func offlineStatus() string { return i18n.T("common.offline") }
+
+// takeSnapshot saves the loaded resource listings and log entries to a new
+// snapshot file
+func (app *App) takeSnapshot() {
+	if app.offline != nil {
+		app.showNotice(i18n.T("app.snapshot_browsing"), "yellow")
+		return
+	}
+	if app.awsClient == nil {
+		app.showNotice(i18n.T("app.snapshot_no_client"), "yellow")
+		return
+	}
+
+	now := app.clock.Now()
+	snap := &snapshot.Snapshot{
+		Version:  snapshot.Version,
+		TakenAt:  now,
+		Profile:  app.awsClient.GetProfile(),
+		Region:   app.awsClient.GetRegion(),
+		Account:  app.awsClient.GetAccountID(),
+		Listings: app.resourcesTab.SnapshotListings(),
+		Logs:     app.logsTab.SnapshotLogs(),
+	}
+
+	path := snapshot.DefaultPath(now)
+	if err := snapshot.Save(path, snap); err != nil {
+		app.showError(err)
+		return
+	}
+	logger.Info("Snapshot saved",
+		zap.String("path", path),
+		zap.Int("listings", len(snap.Listings)),
+		zap.Int("log_sources", len(snap.Logs)))
+	app.showNotice(i18n.T("app.snapshot_saved", len(snap.Listings), path), "green")
+}
+
+// EnableOfflineMode browses snap instead of AWS. No AWS calls are made and
+// profile and region changes are ignored.
+func (app *App) EnableOfflineMode(snap *snapshot.Snapshot, path string) {
+	app.offline = snap
+	app.offlinePath = path
+	app.audit.log.Store(nil)
+	app.useClient(aws.NewClientWithServices(snap.Profile, snap.Region, &aws.ServiceClients{
+		STS: snapshotSTS{account: snap.Account},
+	}))
+	app.profileTab.offline = true
+	app.resourcesTab.SetOffline(snap.Listings)
+	app.logsTab.SetOffline(snap.Logs)
+	app.updateFooter()
+
+	logger.Info("Browsing snapshot",
+		zap.String("path", path),
+		zap.Time("taken_at", snap.TakenAt),
+		zap.Int("listings", len(snap.Listings)))
+}
+
+// fixedClientMode names the mode that keeps the client from changing, or
+// returns "" if profiles and regions can be switched
+func (app *App) fixedClientMode() string {
+	switch {
+	case app.offline != nil:
+		return i18n.T("app.offline_mode")
+	case app.demo:
+		return i18n.T("app.demo_mode")
+	}
+	return ""
+}
+
+// offlineFooter describes the snapshot browsed for the footer
+func (app *App) offlineFooter() string {
+	return "[yellow]" + i18n.T("app.offline_footer",
+		filepath.Base(app.offlinePath), zonedTime(app.offline.TakenAt, "2006-01-02 15:04")) + "[-]"
+}
+
+// snapshotSTS returns the identity saved in a snapshot
+type snapshotSTS struct {
+	account string
+}
+
+// GetCallerIdentity returns the account of the snapshot
+func (s snapshotSTS) GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	if s.account == "" {
+		return nil, fmt.Errorf("the snapshot does not name its account")
+	}
+	return &sts.GetCallerIdentityOutput{Account: awssdk.String(s.account)}, nil
+}
+
+// SnapshotListings returns the listings loaded so far in all profiles and
+// regions
+func (rt *ResourcesTab) SnapshotListings() []snapshot.Listing {
+	var listings []snapshot.Listing
+	rt.cache.Each(func(key string, cached cachedListing, fetchedAt time.Time) {
+		resources := cached.resources
+		parts := strings.SplitN(key, "|", 3)
+		if len(parts) != 3 {
+			return
+		}
+		listing := snapshot.Listing{
+			Profile:   parts[0],
+			Region:    parts[1],
+			Service:   parts[2],
+			FetchedAt: fetchedAt,
+			Resources: make([]snapshot.Resource, len(resources)),
+		}
+		for i, res := range resources {
+			listing.Resources[i] = snapshot.Resource(res)
+		}
+		listings = append(listings, listing)
+	})
+	return listings
+}
+
+// SetOffline shows listings instead of loading resources from AWS
+func (rt *ResourcesTab) SetOffline(listings []snapshot.Listing) {
+	offline := make(map[string]snapshot.Listing, len(listings))
+	for _, listing := range listings {
+		key := fmt.Sprintf("%s|%s|%s", listing.Profile, listing.Region, listing.Service)
+		offline[key] = listing
+		if rt.index != nil {
+			if err := rt.index.Update(listing.Profile, listing.Region, listing.Service, offlineResources(listing)); err != nil {
+				logger.Warn("Failed to index snapshot listing", zap.String("service", listing.Service), zap.Error(err))
+			}
+		}
+	}
+
+	rt.mu.Lock()
+	rt.offline = offline
+	rt.mu.Unlock()
+	rt.updateStatus(i18n.T("snapshot.browsing", len(listings)), "yellow")
+}
+
+// showOfflineListing shows the saved listing of serviceName
+func (rt *ResourcesTab) showOfflineListing(serviceName string) {
+	rt.mu.Lock()
+	rt.selectedService = serviceName
+	listing, ok := rt.offline[rt.cacheKey(serviceName)]
+	rt.mu.Unlock()
+
+	rt.setWarnings(serviceViewOf(serviceName).Noun(), nil)
+	if !ok {
+		rt.fetchedAt = time.Time{}
+		rt.updateResourceTable(nil)
+		rt.updateStatus(i18n.T("snapshot.no_listing", serviceName), "yellow")
+		return
+	}
+
+	rt.fetchedAt = listing.FetchedAt
+	rt.updateResourceTable(offlineResources(listing))
+	rt.updateStatus(i18n.T("snapshot.loaded", len(listing.Resources), serviceName), "green")
+}
+
+// isOffline reports whether the tab shows a snapshot
+func (rt *ResourcesTab) isOffline() bool {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	return rt.offline != nil
+}
+
+// offlineResources returns the resources of listing
+func offlineResources(listing snapshot.Listing) []Resource {
+	resources := make([]Resource, len(listing.Resources))
+	for i, res := range listing.Resources {
+		resources[i] = Resource(res)
+	}
+	return resources
+}
+
+// SnapshotLogs returns the entries of the log sources kept in snapshots
+func (lt *LogsTab) SnapshotLogs() []snapshot.Logs {
+	lt.mu.RLock()
+	defer lt.mu.RUnlock()
+
+	var logs []snapshot.Logs
+	for _, source := range snapshotSources {
+		entries := lt.logs[source]
+		if len(entries) == 0 {
+			continue
+		}
+		saved := snapshot.Logs{Source: source, Entries: make([]snapshot.LogEntry, len(entries))}
+		if source == "cloudwatch" {
+			saved.LogGroup = lt.activeLogGroup
+		}
+		for i, entry := range entries {
+			saved.Entries[i] = snapshot.LogEntry{
+				Timestamp: entry.Timestamp,
+				Level:     entry.Level,
+				Message:   entry.Message,
+				Stream:    entry.Stream,
+				Fields:    entry.Fields,
+			}
+		}
+		logs = append(logs, saved)
+	}
+	return logs
+}
+
+// SetOffline shows the saved logs instead of loading logs from AWS
+func (lt *LogsTab) SetOffline(logs []snapshot.Logs) {
+	lt.mu.Lock()
+	lt.offline = true
+	var indexed []LogEntry
+	for _, saved := range logs {
+		entries := make([]LogEntry, len(saved.Entries))
+		for i, entry := range saved.Entries {
+			entries[i] = LogEntry{
+				Timestamp: entry.Timestamp,
+				Level:     entry.Level,
+				Message:   entry.Message,
+				Source:    saved.Source,
+				Stream:    entry.Stream,
+				Fields:    entry.Fields,
+			}
+		}
+		lt.logs[saved.Source] = entries
+		if saved.Source == "cloudwatch" {
+			lt.activeLogGroup = saved.LogGroup
+		}
+		indexed = append(indexed, entries...)
+	}
+	lt.mu.Unlock()
+
+	lt.indexer.Enqueue(indexed...)
+}
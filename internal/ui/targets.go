@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"swiss-army-tui/internal/bookmarks"
+)
+
+// Open shows target, such as ec2:i-0abc or logs:/aws/lambda/foo, once the
+// application runs. The target is checked right away, so a mistyped --open
+// fails before the screen is taken over.
+func (app *App) Open(spec string) error {
+	target, err := bookmarks.ParseTarget(spec)
+	if err != nil {
+		return err
+	}
+	if err := app.checkTarget(target); err != nil {
+		return err
+	}
+
+	// Updates queued before Run are applied as soon as the event loop starts
+	app.app.QueueUpdateDraw(func() {
+		app.openTarget(target)
+	})
+	return nil
+}
+
+// checkTarget reports whether target names a log group, an enabled service
+// or a saved bookmark
+func (app *App) checkTarget(target bookmarks.Target) error {
+	switch target.Kind {
+	case "logs":
+		return nil
+	case "bookmark":
+		if _, ok := app.bookmarks.Find(target.Ref); !ok {
+			return fmt.Errorf("no bookmark named %q", target.Ref)
+		}
+		return nil
+	}
+
+	var kinds []string
+	for _, service := range app.resourcesTab.services {
+		if service.Name == target.Kind && service.Enabled {
+			return nil
+		}
+		if service.Enabled {
+			kinds = append(kinds, service.Name)
+		}
+	}
+	sort.Strings(kinds)
+	return fmt.Errorf("unknown target %q, expected logs, bookmark or one of %s", target.Kind, strings.Join(kinds, ", "))
+}
+
+// openTarget switches to the tab of target and shows it. Without an AWS
+// client the default profile is used.
+func (app *App) openTarget(target bookmarks.Target) {
+	if err := app.checkTarget(target); err != nil {
+		app.showError(err)
+		return
+	}
+	if target.Kind == "bookmark" {
+		bookmark, _ := app.bookmarks.Find(target.Ref)
+		app.openBookmark(bookmark)
+		return
+	}
+
+	if app.awsClient == nil {
+		cfg := app.currentConfig()
+		profile, region := cfg.AWS.DefaultProfile, cfg.AWS.DefaultRegion
+		if profile == "" {
+			app.showError(fmt.Errorf("no AWS profile to open %s with, pass --aws-profile or select a profile first", target))
+			return
+		}
+		app.inProfile(profile, region, target.String(), func() {
+			app.openTarget(target)
+		})
+		return
+	}
+
+	switch target.Kind {
+	case "logs":
+		app.switchTab(2)
+		app.logsTab.ShowLogGroup(target.Ref)
+	default:
+		app.switchTab(1)
+		app.resourcesTab.ShowResource(target.Kind, target.Ref)
+	}
+}
+
+// openBookmark opens the target of bookmark in its profile and region
+func (app *App) openBookmark(bookmark bookmarks.Bookmark) {
+	target, err := bookmarks.ParseTarget(bookmark.Target)
+	if err != nil {
+		app.showError(fmt.Errorf("bookmark %s: %w", bookmark.Name, err))
+		return
+	}
+	if target.Kind == "bookmark" {
+		app.showError(fmt.Errorf("bookmark %s points to another bookmark", bookmark.Name))
+		return
+	}
+	app.inProfile(bookmark.Profile, bookmark.Region, bookmark.Name, func() {
+		app.openTarget(target)
+	})
+}
+
+// currentTarget returns the view shown in the current tab as a target with
+// a name for it: the selected resource or service listing in the Resources
+// tab, or the CloudWatch log group in the Logs tab
+func (app *App) currentTarget() (bookmarks.Target, string, bool) {
+	app.mu.RLock()
+	tab := app.currentTab
+	app.mu.RUnlock()
+
+	switch tab {
+	case 1:
+		service, res := app.resourcesTab.Selection()
+		if service == "" {
+			return bookmarks.Target{}, "", false
+		}
+		if res == nil {
+			return bookmarks.Target{Kind: service}, service, true
+		}
+		name := res.Name
+		if name == "" {
+			name = res.ID
+		}
+		return bookmarks.Target{Kind: service, Ref: res.ID}, name, true
+	case 2:
+		if group := app.logsTab.ActiveLogGroup(); group != "" {
+			return bookmarks.Target{Kind: "logs", Ref: group}, group, true
+		}
+	}
+	return bookmarks.Target{}, "", false
+}
+
+// inProfile runs fn with the client of profile and region, switching to
+// them first if the current client uses others. An empty profile runs fn
+// with the current client; what names the target in messages.
+func (app *App) inProfile(profile, region, what string, fn func()) {
+	if profile == "" || app.usesProfile(profile, region) {
+		fn()
+		return
+	}
+	if mode := app.fixedClientMode(); mode != "" {
+		app.showMessage(fmt.Sprintf("%s is in profile %s (%s); profile switching is disabled in %s",
+			what, profile, region, mode))
+		return
+	}
+
+	// Creating the client of another profile may read credentials, so it
+	// happens off the UI goroutine like other profile changes
+	app.confirmSwitch(fmt.Sprintf("profile %s (%s)", profile, region), func() {
+		go func() {
+			app.handleProfileChange(ProfileChangedEvent{Profile: profile, Region: region})
+			app.app.QueueUpdateDraw(func() {
+				if app.usesProfile(profile, region) {
+					fn()
+				}
+			})
+		}()
+	}, nil)
+}
+
+// usesProfile reports whether the current client uses profile and region.
+// An empty region matches any.
+func (app *App) usesProfile(profile, region string) bool {
+	return app.awsClient != nil && app.awsClient.GetProfile() == profile &&
+		(region == "" || app.awsClient.GetRegion() == region)
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var themes = map[string]tview.Theme{
+	"dark": {
+		PrimitiveBackgroundColor:    tcell.ColorBlack,
+		ContrastBackgroundColor:     tcell.ColorBlue,
+		MoreContrastBackgroundColor: tcell.ColorGreen,
+		BorderColor:                 tcell.ColorWhite,
+		TitleColor:                  tcell.ColorWhite,
+		GraphicsColor:               tcell.ColorWhite,
+		PrimaryTextColor:            tcell.ColorWhite,
+		SecondaryTextColor:          tcell.ColorYellow,
+		TertiaryTextColor:           tcell.ColorGreen,
+		InverseTextColor:            tcell.ColorBlue,
+		ContrastSecondaryTextColor:  tcell.ColorNavy,
+	},
+	"light": {
+		PrimitiveBackgroundColor:    tcell.ColorWhite,
+		ContrastBackgroundColor:     tcell.ColorLightBlue,
+		MoreContrastBackgroundColor: tcell.ColorLightGreen,
+		BorderColor:                 tcell.ColorBlack,
+		TitleColor:                  tcell.ColorBlack,
+		GraphicsColor:               tcell.ColorBlack,
+		PrimaryTextColor:            tcell.ColorBlack,
+		SecondaryTextColor:          tcell.ColorNavy,
+		TertiaryTextColor:           tcell.ColorDarkGreen,
+		InverseTextColor:            tcell.ColorWhite,
+		ContrastSecondaryTextColor:  tcell.ColorBlack,
+	},
+	// "auto" keeps the terminal's own background and foreground colors
+	"auto": {
+		PrimitiveBackgroundColor:    tcell.ColorDefault,
+		ContrastBackgroundColor:     tcell.ColorBlue,
+		MoreContrastBackgroundColor: tcell.ColorGreen,
+		BorderColor:                 tcell.ColorDefault,
+		TitleColor:                  tcell.ColorDefault,
+		GraphicsColor:               tcell.ColorDefault,
+		PrimaryTextColor:            tcell.ColorDefault,
+		SecondaryTextColor:          tcell.ColorYellow,
+		TertiaryTextColor:           tcell.ColorGreen,
+		InverseTextColor:            tcell.ColorBlue,
+		ContrastSecondaryTextColor:  tcell.ColorNavy,
+	},
+	// "high-contrast" is white and yellow on black, for low vision
+	"high-contrast": {
+		PrimitiveBackgroundColor:    tcell.ColorBlack,
+		ContrastBackgroundColor:     tcell.ColorNavy,
+		MoreContrastBackgroundColor: tcell.ColorPurple,
+		BorderColor:                 tcell.ColorWhite,
+		TitleColor:                  tcell.ColorYellow,
+		GraphicsColor:               tcell.ColorWhite,
+		PrimaryTextColor:            tcell.ColorWhite,
+		SecondaryTextColor:          tcell.ColorYellow,
+		TertiaryTextColor:           tcell.ColorAqua,
+		InverseTextColor:            tcell.ColorBlack,
+		ContrastSecondaryTextColor:  tcell.ColorYellow,
+	},
+}
+
+// themedBox is implemented by every tview primitive that embeds a Box
+type themedBox interface {
+	SetBackgroundColor(color tcell.Color) *tview.Box
+	SetBorderColor(color tcell.Color) *tview.Box
+	SetTitleColor(color tcell.Color) *tview.Box
+}
+
+// applyTheme sets the global tview styles for newly created primitives and
+// recolors the existing primitive tree below root. With colors dropped the
+// theme is noColorTheme whatever name is.
+func applyTheme(name string, root tview.Primitive) {
+	theme, ok := themes[name]
+	if !ok {
+		theme = themes["dark"]
+	}
+	if colorless() {
+		theme = noColorTheme
+	}
+
+	tview.Styles = theme
+	recolor(root, theme)
+}
+
+func recolor(p tview.Primitive, theme tview.Theme) {
+	if p == nil {
+		return
+	}
+
+	if box, ok := p.(themedBox); ok {
+		box.SetBackgroundColor(theme.PrimitiveBackgroundColor)
+		box.SetBorderColor(theme.BorderColor)
+		box.SetTitleColor(theme.TitleColor)
+	}
+
+	switch v := p.(type) {
+	case *tview.Flex:
+		for i := 0; i < v.GetItemCount(); i++ {
+			recolor(v.GetItem(i), theme)
+		}
+	case *tview.Pages:
+		for _, name := range v.GetPageNames(false) {
+			recolor(v.GetPage(name), theme)
+		}
+	case *tview.Form:
+		for i := 0; i < v.GetFormItemCount(); i++ {
+			recolor(v.GetFormItem(i), theme)
+		}
+	case *tview.TextView:
+		v.SetTextColor(theme.PrimaryTextColor)
+	}
+}
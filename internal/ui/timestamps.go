@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// timeDisplay is the configured timezone and timestamp format, shared by
+// the log rows, the Created columns and the detail panels of all tabs
+var timeDisplay = struct {
+	sync.RWMutex
+	loc    *time.Location
+	format string
+}{loc: time.Local, format: config.TimestampsTime}
+
+// createdLayouts are the layouts the Created dates of resources are
+// stored in, in UTC unless they carry an offset
+var createdLayouts = []string{"2006-01-02 15:04:05", "2006-01-02T15:04:05.000-0700", time.RFC3339}
+
+// setTimeDisplay applies the timezone and timestamp format of cfg
+func setTimeDisplay(cfg config.UIConfig) {
+	loc, err := config.LoadTimezone(cfg.Timezone)
+	if err != nil {
+		logger.Warn("Showing timestamps in the local timezone", zap.Error(err))
+		loc = time.Local
+	}
+	format := cfg.Timestamps
+	if format == "" {
+		format = config.TimestampsTime
+	}
+
+	timeDisplay.Lock()
+	defer timeDisplay.Unlock()
+	timeDisplay.loc, timeDisplay.format = loc, format
+}
+
+// displayedTime returns t in the configured timezone and the timestamp format
+func displayedTime(t time.Time) (time.Time, string) {
+	timeDisplay.RLock()
+	defer timeDisplay.RUnlock()
+	return t.In(timeDisplay.loc), timeDisplay.format
+}
+
+// logTime renders the timestamp of a log row: the time of day, the date and
+// time or the age of the entry
+func logTime(t time.Time) string {
+	local, format := displayedTime(t)
+	switch format {
+	case config.TimestampsRelative:
+		return relativeTime(t, time.Now())
+	case config.TimestampsDateTime:
+		return local.Format("2006-01-02 15:04:05.000")
+	default:
+		return local.Format("15:04:05.000")
+	}
+}
+
+// detailTime renders t in full with its zone for detail panels, followed by
+// its age when timestamps are relative
+func detailTime(t time.Time, layout string) string {
+	local, format := displayedTime(t)
+	text := local.Format(layout)
+	if format == config.TimestampsRelative {
+		text += " (" + relativeTime(t, time.Now()) + ")"
+	}
+	return text
+}
+
+// zonedTime renders t with layout in the configured timezone, for copies,
+// exports and details formatted once
+func zonedTime(t time.Time, layout string) string {
+	local, _ := displayedTime(t)
+	return local.Format(layout)
+}
+
+// createdTime renders the Created date of a resource in the configured
+// timezone, or its age when timestamps are relative. Dates in other layouts
+// are shown as they are.
+func createdTime(created string) string {
+	for _, layout := range createdLayouts {
+		t, err := time.Parse(layout, created)
+		if err != nil {
+			continue
+		}
+		local, format := displayedTime(t)
+		if format == config.TimestampsRelative {
+			return relativeTime(t, time.Now())
+		}
+		return local.Format("2006-01-02 15:04:05")
+	}
+	return created
+}
+
+// relativeTime renders how long before now t was, e.g. "2m ago", or how long
+// after for future times
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	suffix := " ago"
+	if d < 0 {
+		d, suffix = -d, " from now"
+	}
+	switch {
+	case d < time.Second:
+		return "just now"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds%s", int(d.Seconds()), suffix)
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%s", int(d.Minutes()), suffix)
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh%s", int(d.Hours()), suffix)
+	default:
+		return fmt.Sprintf("%dd%s", int(d.Hours()/24), suffix)
+	}
+}
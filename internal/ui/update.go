@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	"swiss-army-tui/internal/config"
+	"swiss-army-tui/internal/update"
+	"swiss-army-tui/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// updateCheckTimeout bounds the lookup of the latest release
+const updateCheckTimeout = 10 * time.Second
+
+// checkForUpdate looks up the latest release as cfg says and shows it in the
+// footer if it is newer than the version running. Failures, such as having
+// no internet access, are only logged.
+func (app *App) checkForUpdate(cfg config.UpdateConfig) {
+	if app.version == "" || !cfg.Check {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(app.ctx, updateCheckTimeout)
+	defer cancel()
+
+	client := update.NewClient(cfg.APIURL, cfg.Repository)
+	release, err := client.Latest(ctx)
+	if err != nil {
+		logger.Debug("Update check failed", zap.Error(err))
+		return
+	}
+	if !update.Newer(app.version, release.Version) {
+		return
+	}
+
+	logger.Info("New version available", zap.String("version", release.Version), zap.String("url", release.URL))
+	app.app.QueueUpdateDraw(func() {
+		app.newVersion = release.Version
+		app.updateFooter()
+	})
+}
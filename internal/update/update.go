@@ -0,0 +1,301 @@
+// Package update checks the GitHub releases of the tool for a newer version
+// and replaces the running binary with the one released, verified against
+// the checksums published with the release.
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the GitHub API releases are looked up with
+const DefaultAPIURL = "https://api.github.com"
+
+// DefaultRepository is the repository the tool is released from
+const DefaultRepository = "lukasVirtual/swiss-army-aws-tui"
+
+// ChecksumsAsset is the asset of a release listing the SHA-256 checksums of
+// the others, one "<checksum>  <asset>" per line
+const ChecksumsAsset = "checksums.txt"
+
+// maxBinarySize is the largest binary downloaded
+const maxBinarySize = 200 << 20
+
+// Release is a release of the tool
+type Release struct {
+	Version string  `json:"tag_name"`
+	URL     string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset is a file of a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// asset returns the asset of release named name
+func (r *Release) asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Client looks up the releases of a repository
+type Client struct {
+	apiURL     string
+	repository string
+	http       *http.Client
+}
+
+// NewClient creates a client of the releases of repository ("owner/name")
+// on the GitHub API at apiURL, DefaultAPIURL if empty
+func NewClient(apiURL, repository string) *Client {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	return &Client{
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		repository: repository,
+		http:       &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// Latest returns the latest release
+func (c *Client) Latest(ctx context.Context) (*Release, error) {
+	body, err := c.get(ctx, fmt.Sprintf("%s/repos/%s/releases/latest", c.apiURL, c.repository), 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the latest release: %w", err)
+	}
+	var release Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("failed to decode the latest release: %w", err)
+	}
+	if release.Version == "" {
+		return nil, fmt.Errorf("latest release of %s has no version", c.repository)
+	}
+	return &release, nil
+}
+
+// Download returns the binary of release for goos and goarch after checking
+// it against the checksums of the release
+func (c *Client) Download(ctx context.Context, release *Release, goos, goarch string) ([]byte, error) {
+	name := AssetName(goos, goarch)
+	asset, ok := release.asset(name)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no binary for %s/%s", release.Version, goos, goarch)
+	}
+	checksums, ok := release.asset(ChecksumsAsset)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s to verify %s with", release.Version, ChecksumsAsset, name)
+	}
+
+	sums, err := c.get(ctx, checksums.URL, 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", ChecksumsAsset, err)
+	}
+	want, err := checksumOf(sums, name)
+	if err != nil {
+		return nil, err
+	}
+
+	binary, err := c.get(ctx, asset.URL, maxBinarySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	sum := sha256.Sum256(binary)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return nil, fmt.Errorf("checksum of %s is %s, expected %s", name, got, want)
+	}
+	return binary, nil
+}
+
+// get returns the body of url, failing if it is larger than limit
+func (c *Client) get(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%s is larger than %d bytes", url, limit)
+	}
+	return body, nil
+}
+
+// checksumOf returns the checksum of the asset named name in the checksums
+// file sums
+func checksumOf(sums []byte, name string) (string, error) {
+	lines := bufio.NewScanner(bytes.NewReader(sums))
+	for lines.Scan() {
+		fields := strings.Fields(lines.Text())
+		// Binary mode checksums mark the file with *
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s has no checksum of %s", ChecksumsAsset, name)
+}
+
+// AssetName returns the name of the binary released for goos and goarch,
+// e.g. swiss-army-tui_linux_amd64
+func AssetName(goos, goarch string) string {
+	name := fmt.Sprintf("swiss-army-tui_%s_%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Newer reports whether latest is a newer version than current. Versions are
+// compared as vMAJOR.MINOR.PATCH; a pre-release (v1.2.0-rc.1) is older than
+// its release and pre-releases compare the semver way, so rc.10 is newer than
+// rc.9. Versions that are no such version, e.g. of development builds,
+// are never newer nor older.
+func Newer(current, latest string) bool {
+	c, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		if l.parts[i] != c.parts[i] {
+			return l.parts[i] > c.parts[i]
+		}
+	}
+	// Of the same version the release is newer than a pre-release
+	return c.pre != "" && (l.pre == "" || comparePre(l.pre, c.pre) > 0)
+}
+
+// comparePre compares the pre-releases a and b by their dot-separated
+// identifiers: numeric ones as numbers and lower than alphanumeric ones,
+// alphanumeric ones as strings, and a prefix lower than the longer one
+func comparePre(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.ParseUint(as[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bs[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return cmp.Compare(an, bn)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmp.Compare(len(as), len(bs))
+}
+
+// IsVersion reports whether v is a version Newer compares, unlike the
+// version of a development build
+func IsVersion(v string) bool {
+	_, ok := parseVersion(v)
+	return ok
+}
+
+// version is a parsed vMAJOR.MINOR.PATCH[-PRE]
+type version struct {
+	parts [3]int
+	pre   string
+}
+
+// parseVersion parses v, with or without the leading v
+func parseVersion(v string) (version, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	core, pre, _ := strings.Cut(v, "-")
+	core, _, _ = strings.Cut(core, "+")
+
+	fields := strings.Split(core, ".")
+	if len(fields) != 3 {
+		return version{}, false
+	}
+	var parsed version
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		parsed.parts[i] = n
+	}
+	parsed.pre = pre
+	return parsed, true
+}
+
+// Replace replaces the binary at exe with binary, keeping its permissions.
+// The new binary is written next to it and renamed over it, so a failed
+// update leaves the old one in place. Windows cannot replace a running
+// binary, so there it is moved aside to exe.old first.
+func Replace(exe string, binary []byte, goos string) error {
+	info, err := os.Stat(exe)
+	if err != nil {
+		return fmt.Errorf("failed to read current binary: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(exe), filepath.Base(exe)+".update-*")
+	if err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+
+	if goos == "windows" {
+		old := exe + ".old"
+		os.Remove(old)
+		if err := os.Rename(exe, old); err != nil {
+			return fmt.Errorf("failed to move current binary aside: %w", err)
+		}
+	}
+	if err := os.Rename(tmp.Name(), exe); err != nil {
+		return fmt.Errorf("failed to replace binary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package update
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewer(t *testing.T) {
+	for _, tt := range []struct {
+		current, latest string
+		want            bool
+	}{
+		{"v1.2.3", "v1.2.4", true},
+		{"1.2.3", "v1.10.0", true},
+		{"v1.2.3", "v1.2.3", false},
+		{"v2.0.0", "v1.9.9", false},
+		{"v1.2.0-rc.1", "v1.2.0", true},
+		{"v1.2.0", "v1.3.0-rc.1", true},
+		{"v1.2.0", "v1.2.0-rc.1", false},
+		{"v1.2.0-rc.9", "v1.2.0-rc.10", true},
+		{"v1.2.0-rc.10", "v1.2.0-rc.9", false},
+		{"v1.2.0-beta.2", "v1.2.0-rc.1", true},
+		{"v1.2.0-rc", "v1.2.0-rc.1", true},
+		{"v1.2.0-rc.1", "v1.2.0-rc.1", false},
+		// Development builds are not compared
+		{"dev", "v9.9.9", false},
+		{"v1.2.3", "nightly", false},
+	} {
+		if got := Newer(tt.current, tt.latest); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, expected %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+	if IsVersion("dev") || !IsVersion("v1.2.3-rc.1") {
+		t.Error("Expected dev to be no version and v1.2.3-rc.1 one")
+	}
+}
+
+// releaseServer serves a release v1.3.0 with binary for linux/amd64 and the
+// checksum sum of it
+func releaseServer(t *testing.T, binary []byte, sum string) *httptest.Server {
+	t.Helper()
+	name := AssetName("linux", "amd64")
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/tool/releases/latest":
+			fmt.Fprintf(w, `{"tag_name": "v1.3.0", "html_url": "https://example.com/v1.3.0", "assets": [
+				{"name": %q, "browser_download_url": "%s/download/bin"},
+				{"name": "checksums.txt", "browser_download_url": "%s/download/sums"}]}`, name, server.URL, server.URL)
+		case "/download/bin":
+			w.Write(binary)
+		case "/download/sums":
+			fmt.Fprintf(w, "%s  swiss-army-tui_darwin_arm64\n%s  %s\n", strings.Repeat("0", 64), sum, name)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDownload(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho v1.3.0\n")
+	sum := sha256.Sum256(binary)
+	server := releaseServer(t, binary, hex.EncodeToString(sum[:]))
+	client := NewClient(server.URL, "owner/tool")
+
+	release, err := client.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Latest failed: %v", err)
+	}
+	if release.Version != "v1.3.0" || len(release.Assets) != 2 {
+		t.Fatalf("Unexpected release %+v", release)
+	}
+
+	got, err := client.Download(context.Background(), release, "linux", "amd64")
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if string(got) != string(binary) {
+		t.Errorf("Expected the binary, got %q", got)
+	}
+	if _, err := client.Download(context.Background(), release, "plan9", "386"); err == nil {
+		t.Error("Expected an error for a platform without a binary")
+	}
+
+	if _, err := NewClient(server.URL, "owner/missing").Latest(context.Background()); err == nil {
+		t.Error("Expected an error for a repository without releases")
+	}
+}
+
+func TestDownloadRejectsChecksumMismatch(t *testing.T) {
+	server := releaseServer(t, []byte("tampered"), strings.Repeat("a", 64))
+	client := NewClient(server.URL, "owner/tool")
+	release, err := client.Latest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Download(context.Background(), release, "linux", "amd64"); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("Expected a checksum error, got %v", err)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "swiss-army-tui")
+	if err := os.WriteFile(exe, []byte("old"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := Replace(exe, []byte("new"), "linux"); err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+
+	data, err := os.ReadFile(exe)
+	if err != nil || string(data) != "new" {
+		t.Errorf("Expected the new binary, got %q (%v)", data, err)
+	}
+	if info, err := os.Stat(exe); err != nil || info.Mode().Perm() != 0750 {
+		t.Errorf("Expected the permissions to be kept, got %v (%v)", info, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(exe)); len(entries) != 1 {
+		t.Errorf("Expected no files left behind, got %d files", len(entries))
+	}
+}
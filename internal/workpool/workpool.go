@@ -0,0 +1,183 @@
+// Package workpool bounds the AWS calls that fan out over many items, such
+// as one call per Lambda function or bucket, per service. All clients share
+// one pool so the fan-outs of several tabs do not add up.
+package workpool
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultLimit is the default number of calls run concurrently per service
+const DefaultLimit = 8
+
+// Stats is how many calls of a pool run and how many wait for a free slot
+type Stats struct {
+	Running int
+	Queued  int
+}
+
+// Busy reports whether any call runs or waits
+func (s Stats) Busy() bool {
+	return s.Running > 0 || s.Queued > 0
+}
+
+// Pool runs the calls of fan-outs over many items, such as the details of
+// every Lambda function or the region of every bucket, with at most a limit
+// of them running per service across all callers
+type Pool struct {
+	mu       sync.Mutex
+	limit    int
+	limits   map[string]int
+	slots    map[string]chan struct{}
+	stats    Stats
+	onChange func(Stats)
+}
+
+// New creates a pool running limit calls per service, or the limit of
+// limits for the services it names. Limits of zero or less use DefaultLimit
+// and limit respectively.
+func New(limit int, limits map[string]int) *Pool {
+	p := &Pool{}
+	p.SetLimits(limit, limits)
+	return p
+}
+
+// SetLimits changes the limits of the pool. Calls running keep their slot
+// until they return.
+func (p *Pool) SetLimits(limit int, limits map[string]int) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	copied := make(map[string]int, len(limits))
+	for service, n := range limits {
+		if n > 0 {
+			copied[service] = n
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.limit = limit
+	p.limits = copied
+	p.slots = make(map[string]chan struct{})
+}
+
+// Limit returns how many calls of service run concurrently
+func (p *Pool) Limit(service string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.limitOf(service)
+}
+
+func (p *Pool) limitOf(service string) int {
+	if n, ok := p.limits[service]; ok {
+		return n
+	}
+	return p.limit
+}
+
+// slotsOf returns the slots the calls of service take
+func (p *Pool) slotsOf(service string) chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	slots, ok := p.slots[service]
+	if !ok {
+		slots = make(chan struct{}, p.limitOf(service))
+		p.slots[service] = slots
+	}
+	return slots
+}
+
+// Stats returns how many calls run and wait
+func (p *Pool) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+// OnChange registers fn to be called with the stats whenever a call is
+// queued, starts or returns. fn is called from the goroutines of the calls
+// and must not block.
+func (p *Pool) OnChange(fn func(Stats)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onChange = fn
+}
+
+// change adds running and queued to the stats and tells the OnChange func
+func (p *Pool) change(running, queued int) {
+	p.mu.Lock()
+	p.stats.Running += running
+	p.stats.Queued += queued
+	stats, fn := p.stats, p.onChange
+	p.mu.Unlock()
+
+	if fn != nil {
+		fn(stats)
+	}
+}
+
+// Each calls fn for each of n items of service concurrently, each once a
+// slot of service is free, and returns once all calls returned. Once ctx is
+// done no more calls start and the error of ctx is returned.
+func (p *Pool) Each(ctx context.Context, service string, n int, fn func(i int)) error {
+	if n <= 0 {
+		return ctx.Err()
+	}
+	slots := p.slotsOf(service)
+	p.change(0, n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			p.change(0, -(n - i))
+			wg.Wait()
+			return ctx.Err()
+		}
+		if ctx.Err() != nil {
+			<-slots
+			p.change(0, -(n - i))
+			wg.Wait()
+			return ctx.Err()
+		}
+
+		p.change(1, -1)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				<-slots
+				p.change(-1, 0)
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// shared is the pool of all AWS clients
+var shared = New(DefaultLimit, nil)
+
+// SetLimits changes the limits of the shared pool
+func SetLimits(limit int, limits map[string]int) {
+	shared.SetLimits(limit, limits)
+}
+
+// Each calls fn for each of n items of service in the shared pool
+func Each(ctx context.Context, service string, n int, fn func(i int)) error {
+	return shared.Each(ctx, service, n, fn)
+}
+
+// Current returns the stats of the shared pool
+func Current() Stats {
+	return shared.Stats()
+}
+
+// OnChange registers fn to be told the stats of the shared pool
+func OnChange(fn func(Stats)) {
+	shared.OnChange(fn)
+}
@@ -0,0 +1,114 @@
+package workpool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPoolLimitsServices(t *testing.T) {
+	p := New(0, map[string]int{"lambda": 2, "s3": -1})
+	if p.Limit("lambda") != 2 || p.Limit("s3") != DefaultLimit || p.Limit("iam") != DefaultLimit {
+		t.Fatalf("Expected lambda 2 and the default elsewhere, got %d %d %d", p.Limit("lambda"), p.Limit("s3"), p.Limit("iam"))
+	}
+
+	// Two fan-outs of the same service share its slots
+	var running, most int32
+	var wg sync.WaitGroup
+	for caller := 0; caller < 2; caller++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := p.Each(context.Background(), "lambda", 5, func(int) {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					m := atomic.LoadInt32(&most)
+					if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if most != 2 {
+		t.Errorf("Expected at most 2 lambda calls at once, got %d", most)
+	}
+	if stats := p.Stats(); stats.Busy() {
+		t.Errorf("Expected an idle pool, got %+v", stats)
+	}
+}
+
+func TestPoolEachCallsAll(t *testing.T) {
+	p := New(3, nil)
+	done := make([]bool, 10)
+	if err := p.Each(context.Background(), "iam", len(done), func(i int) { done[i] = true }); err != nil {
+		t.Fatal(err)
+	}
+	for i, ok := range done {
+		if !ok {
+			t.Errorf("Expected item %d to be called", i)
+		}
+	}
+}
+
+func TestPoolStats(t *testing.T) {
+	p := New(1, nil)
+	var mu sync.Mutex
+	var seen []Stats
+	p.OnChange(func(s Stats) {
+		mu.Lock()
+		seen = append(seen, s)
+		mu.Unlock()
+	})
+
+	release := make(chan struct{})
+	go p.Each(context.Background(), "s3", 3, func(int) { <-release })
+
+	deadline := time.Now().Add(time.Second)
+	for p.Stats() != (Stats{Running: 1, Queued: 2}) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 1 running and 2 queued, got %+v", p.Stats())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	for p.Stats().Busy() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the pool to drain, got %+v", p.Stats())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) == 0 || seen[len(seen)-1] != (Stats{}) {
+		t.Errorf("Expected OnChange to end with an idle pool, got %+v", seen)
+	}
+}
+
+func TestPoolEachCancelled(t *testing.T) {
+	p := New(1, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+	err := p.Each(ctx, "lambda", 5, func(int) {
+		atomic.AddInt32(&calls, 1)
+		cancel()
+	})
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected no calls after cancelling, got %d", calls)
+	}
+	if stats := p.Stats(); stats.Busy() {
+		t.Errorf("Expected the queued calls to be dropped, got %+v", stats)
+	}
+}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// recentLimit is how many entries are kept for subscribers that start later
+const recentLimit = 500
+
+// Entry is an entry of the global logger as passed to subscribers
+type Entry struct {
+	Time    time.Time
+	Level   string
+	Message string
+	Caller  string
+	Fields  map[string]interface{}
+}
+
+var (
+	hooksMu  sync.Mutex
+	hooks    = make(map[int]func(Entry))
+	nextHook int
+	recent   []Entry
+)
+
+// Subscribe calls hook with every entry the global logger writes from now on
+// and returns the most recent entries written before, the oldest first. hook
+// is called on the goroutine that logs, which may hold any lock, so it must
+// hand the entry over rather than act on it.
+func Subscribe(hook func(Entry)) (before []Entry, unsubscribe func()) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	nextHook++
+	id := nextHook
+	hooks[id] = hook
+	before = append([]Entry(nil), recent...)
+
+	return before, func() {
+		hooksMu.Lock()
+		defer hooksMu.Unlock()
+		delete(hooks, id)
+	}
+}
+
+// publish keeps entry for later subscribers and passes it to the current ones
+func publish(entry Entry) {
+	hooksMu.Lock()
+	recent = append(recent, entry)
+	if len(recent) > recentLimit {
+		recent = append([]Entry(nil), recent[len(recent)-recentLimit:]...)
+	}
+	current := make([]func(Entry), 0, len(hooks))
+	for _, hook := range hooks {
+		current = append(current, hook)
+	}
+	hooksMu.Unlock()
+
+	for _, hook := range current {
+		hook(entry)
+	}
+}
+
+// hookCore is a zap core publishing the entries it is enabled for
+type hookCore struct {
+	zapcore.LevelEnabler
+	fields []zapcore.Field
+}
+
+func (c *hookCore) With(fields []zapcore.Field) zapcore.Core {
+	return &hookCore{
+		LevelEnabler: c.LevelEnabler,
+		fields:       append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *hookCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *hookCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	published := Entry{
+		Time:    entry.Time,
+		Level:   entry.Level.CapitalString(),
+		Message: entry.Message,
+		Fields:  enc.Fields,
+	}
+	if entry.Caller.Defined {
+		published.Caller = entry.Caller.TrimmedPath()
+	}
+	publish(published)
+	return nil
+}
+
+func (c *hookCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestSubscribeReceivesEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tui.log")
+	if err := Initialize(&Config{Level: "info", Encoding: "json", OutputPaths: []string{path}}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	t.Cleanup(func() {
+		Logger, Sugar = nil, nil
+		closeFiles(files)
+		files = nil
+	})
+
+	Info("before subscribing")
+
+	var got []Entry
+	before, unsubscribe := Subscribe(func(entry Entry) { got = append(got, entry) })
+	if len(before) == 0 || before[len(before)-1].Message != "before subscribing" {
+		t.Errorf("Expected the earlier entry to be returned, got %+v", before)
+	}
+
+	Logger.With(zap.String("profile", "dev")).Warn("region switched", zap.String("region", "eu-west-1"))
+	Debug("below the level")
+	unsubscribe()
+	Info("after unsubscribing")
+
+	if len(got) != 1 {
+		t.Fatalf("Expected one entry, got %+v", got)
+	}
+	entry := got[0]
+	if entry.Message != "region switched" || entry.Level != "WARN" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if entry.Fields["profile"] != "dev" || entry.Fields["region"] != "eu-west-1" {
+		t.Errorf("Expected the logger and entry fields, got %v", entry.Fields)
+	}
+	if entry.Caller == "" {
+		t.Error("Expected the caller to be set")
+	}
+}
@@ -2,8 +2,13 @@ package logger
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 var (
@@ -11,6 +16,10 @@ var (
 	Logger *zap.Logger
 	// Sugar is the sugared logger instance for easier use
 	Sugar *zap.SugaredLogger
+
+	// Log files of the global logger, closed when it is replaced
+	filesMu sync.Mutex
+	files   []*rotatingFile
 )
 
 // Config holds logger configuration
@@ -19,6 +28,27 @@ type Config struct {
 	Development bool     `mapstructure:"development" yaml:"development"`
 	Encoding    string   `mapstructure:"encoding" yaml:"encoding"`
 	OutputPaths []string `mapstructure:"output_paths" yaml:"output_paths"`
+	// Log files are rotated once they reach MaxSizeMB, 0 never rotates them.
+	// MaxBackups rotated files are kept, 0 keeps all, gzip compressed when
+	// Compress is set.
+	MaxSizeMB  int  `mapstructure:"max_size_mb" yaml:"max_size_mb"`
+	MaxBackups int  `mapstructure:"max_backups" yaml:"max_backups"`
+	Compress   bool `mapstructure:"compress" yaml:"compress"`
+}
+
+// Rotation defaults
+const (
+	DefaultMaxSizeMB  = 10
+	DefaultMaxBackups = 3
+)
+
+// DefaultPath returns ~/.swiss-army-tui/swiss-army-tui.log
+func DefaultPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "swiss-army-tui.log"
+	}
+	return filepath.Join(homeDir, ".swiss-army-tui", "swiss-army-tui.log")
 }
 
 // DefaultConfig returns default logger configuration
@@ -28,7 +58,10 @@ func DefaultConfig() *Config {
 		Development: false,
 		Encoding:    "json",
 		// Default to a log file instead of stdout to avoid interfering with the TUI screen
-		OutputPaths: []string{"swiss-army-tui.log"},
+		OutputPaths: []string{DefaultPath()},
+		MaxSizeMB:   DefaultMaxSizeMB,
+		MaxBackups:  DefaultMaxBackups,
+		Compress:    true,
 	}
 }
 
@@ -51,23 +84,95 @@ func Initialize(cfg *Config) error {
 	}
 	zapCfg.Level = level
 
-	// Set output paths
-	if len(cfg.OutputPaths) > 0 {
-		zapCfg.OutputPaths = cfg.OutputPaths
+	var encoder zapcore.Encoder
+	switch zapCfg.Encoding {
+	case "console":
+		encoder = zapcore.NewConsoleEncoder(zapCfg.EncoderConfig)
+	default:
+		encoder = zapcore.NewJSONEncoder(zapCfg.EncoderConfig)
 	}
 
+	// Files are written by rotatingFile rather than opened by zap, so the
+	// output paths are left out of the zap configuration
+	outputs, opened := openOutputs(cfg)
+	zapCfg.OutputPaths = nil
+	sampling := zapCfg.Sampling
+
 	// Build logger
-	logger, err := zapCfg.Build()
+	logger, err := zapCfg.Build(zap.WrapCore(func(zapcore.Core) zapcore.Core {
+		// Subscribers such as the Logs tab see the entries written to the outputs
+		core := zapcore.NewTee(
+			zapcore.NewCore(encoder, outputs, zapCfg.Level),
+			&hookCore{LevelEnabler: zapCfg.Level},
+		)
+		if sampling != nil {
+			core = zapcore.NewSamplerWithOptions(core, time.Second, sampling.Initial, sampling.Thereafter)
+		}
+		return core
+	}))
 	if err != nil {
+		closeFiles(opened)
 		return err
 	}
 
 	Logger = logger
 	Sugar = logger.Sugar()
 
+	filesMu.Lock()
+	previous := files
+	files = opened
+	filesMu.Unlock()
+	closeFiles(previous)
+
 	return nil
 }
 
+// openOutputs returns a writer to the output paths of cfg, stderr by
+// default like zap, and the log files among them
+func openOutputs(cfg *Config) (zapcore.WriteSyncer, []*rotatingFile) {
+	paths := cfg.OutputPaths
+	if len(paths) == 0 {
+		paths = []string{"stderr"}
+	}
+
+	var writers []zapcore.WriteSyncer
+	var opened []*rotatingFile
+	for _, path := range paths {
+		switch path = strings.TrimSpace(path); path {
+		case "":
+			continue
+		case "stdout":
+			writers = append(writers, zapcore.Lock(os.Stdout))
+		case "stderr":
+			writers = append(writers, zapcore.Lock(os.Stderr))
+		default:
+			file := newRotatingFile(ExpandHome(path), cfg.MaxSizeMB, cfg.MaxBackups, cfg.Compress)
+			opened = append(opened, file)
+			writers = append(writers, file)
+		}
+	}
+	return zapcore.NewMultiWriteSyncer(writers...), opened
+}
+
+// closeFiles closes the log files
+func closeFiles(list []*rotatingFile) {
+	for _, file := range list {
+		file.Close()
+	}
+}
+
+// ExpandHome replaces a leading ~ of path with the home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+}
+
 // InitializeDefault initializes the logger with default configuration
 func InitializeDefault() error {
 	cfg := DefaultConfig()
@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInitializeWritesLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "tui.log")
+	if err := Initialize(&Config{Level: "info", Encoding: "json", OutputPaths: []string{path}, MaxSizeMB: 1}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	t.Cleanup(func() {
+		Logger, Sugar = nil, nil
+		closeFiles(files)
+		files = nil
+	})
+
+	Info("profile switched")
+	Debug("below the level")
+	Sync()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the log file to be created, got %v", err)
+	}
+	if !strings.Contains(string(content), `"msg":"profile switched"`) || strings.Contains(string(content), "below the level") {
+		t.Errorf("Expected only the info entry as JSON, got %q", content)
+	}
+}
@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs", "tui.log")
+
+	file := newRotatingFile(path, 0, 2, true)
+	// Rotate after 100 bytes instead of megabytes
+	file.maxSize = 100
+	clock := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	file.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	defer file.Close()
+
+	line := strings.Repeat("x", 59) + "\n"
+	for i := 0; i < 5; i++ {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	// Every write after the first rotates; only the newest 2 backups are kept
+	backups, err := file.backups()
+	if err != nil {
+		t.Fatalf("Failed to list backups: %v", err)
+	}
+	want := []string{
+		filepath.Join(dir, "logs", "tui-2024-05-01T10-00-03.000.log.gz"),
+		filepath.Join(dir, "logs", "tui-2024-05-01T10-00-04.000.log.gz"),
+	}
+	if len(backups) != len(want) || backups[0] != want[0] || backups[1] != want[1] {
+		t.Fatalf("Expected backups %v, got %v", want, backups)
+	}
+
+	current, err := os.ReadFile(path)
+	if err != nil || string(current) != line {
+		t.Errorf("Expected the last line in the current file, got %q (%v)", current, err)
+	}
+
+	compressed, err := os.Open(backups[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer compressed.Close()
+	zr, err := gzip.NewReader(compressed)
+	if err != nil {
+		t.Fatalf("Expected a gzip backup, got %v", err)
+	}
+	if content, err := io.ReadAll(zr); err != nil || string(content) != line {
+		t.Errorf("Expected the rotated line in the backup, got %q (%v)", content, err)
+	}
+}
+
+func TestRotatingFileAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tui.log")
+	if err := os.WriteFile(path, []byte("earlier\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a size limit the file is only appended to
+	file := newRotatingFile(path, 0, 0, false)
+	if _, err := file.Write([]byte("later\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	file.Close()
+
+	content, err := os.ReadFile(path)
+	if err != nil || string(content) != "earlier\nlater\n" {
+		t.Errorf("Expected both lines, got %q (%v)", content, err)
+	}
+	if backups, _ := file.backups(); len(backups) != 0 {
+		t.Errorf("Expected no backups, got %v", backups)
+	}
+}